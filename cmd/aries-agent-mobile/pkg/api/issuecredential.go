@@ -17,18 +17,33 @@ type IssueCredentialController interface {
 	// SendOffer is used by the Issuer to send an offer.
 	SendOffer(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendOfferV3 is used by the Issuer to send an offer over DIDComm V2.
+	SendOfferV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// SendProposal is used by the Holder to send a proposal.
 	SendProposal(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendProposalV3 is used by the Holder to send a proposal over DIDComm V2.
+	SendProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// SendRequest is used by the Holder to send a request.
 	SendRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendRequestV3 is used by the Holder to send a request over DIDComm V2.
+	SendRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// AcceptProposal is used when the Issuer is willing to accept the proposal.
 	AcceptProposal(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// AcceptProposalV3 is used when the Issuer is willing to accept the proposal over DIDComm V2.
+	AcceptProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// NegotiateProposal is used when the Holder wants to negotiate about an offer he received.
 	NegotiateProposal(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// NegotiateProposalV3 is used when the Holder wants to negotiate about an offer he received over DIDComm V2.
+	NegotiateProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// DeclineProposal is used when the Issuer does not want to accept the proposal.
 	DeclineProposal(request *models.RequestEnvelope) *models.ResponseEnvelope
 
@@ -44,6 +59,9 @@ type IssueCredentialController interface {
 	// AcceptRequest is used when the Issuer is willing to accept the request.
 	AcceptRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// AcceptRequestV3 is used when the Issuer is willing to accept the request over DIDComm V2.
+	AcceptRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// DeclineRequest is used when the Issuer does not want to accept the request.
 	DeclineRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
 
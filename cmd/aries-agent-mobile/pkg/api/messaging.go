@@ -26,6 +26,9 @@ type MessagingController interface {
 	// Reply sends reply to existing message.
 	Reply(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendPlain sends new DIDComm V2 message to destination provided.
+	SendPlain(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// RegisterHTTPService registers new http over didcomm service to message handler registrar.
 	RegisterHTTPService(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
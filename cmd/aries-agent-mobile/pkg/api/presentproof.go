@@ -17,21 +17,37 @@ type PresentProofController interface {
 	// SendRequestPresentation is used by the Verifier to send a request presentation.
 	SendRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendRequestPresentationV3 is used by the Verifier to send a request presentation over DIDComm V2.
+	SendRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// SendProposePresentation is used by the Prover to send a propose presentation.
 	SendProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// SendProposePresentationV3 is used by the Prover to send a propose presentation over DIDComm V2.
+	SendProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// AcceptRequestPresentation is used by the Prover is to accept a presentation request.
 	AcceptRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// AcceptRequestPresentationV3 is used by the Prover is to accept a presentation request over DIDComm V2.
+	AcceptRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// NegotiateRequestPresentation is used by the Prover to counter a presentation request they received with a proposal.
 	NegotiateRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// NegotiateRequestPresentationV3 is used by the Prover to counter a presentation request they received with
+	// a proposal over DIDComm V2.
+	NegotiateRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// DeclineRequestPresentation is used when the Prover does not want to accept the request presentation.
 	DeclineRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
 	// AcceptProposePresentation is used when the Verifier is willing to accept the propose presentation.
 	AcceptProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// AcceptProposePresentationV3 is used when the Verifier is willing to accept the propose presentation over DIDComm V2.
+	AcceptProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// DeclineProposePresentation is used when the Verifier does not want to accept the propose presentation.
 	DeclineProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope
 
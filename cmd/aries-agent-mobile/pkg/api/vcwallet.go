@@ -68,4 +68,10 @@ type VCWalletController interface {
 
 	// sends message present proof message from wallet to relying party.
 	PresentProof(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// exports all wallet contents and keys into an archive encrypted by given password.
+	Export(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// imports wallet contents and keys from an archive produced by export operation.
+	Import(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
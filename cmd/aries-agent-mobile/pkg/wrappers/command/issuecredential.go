@@ -45,6 +45,22 @@ func (ic *IssueCredential) SendOffer(request *models.RequestEnvelope) *models.Re
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendOfferV3 is used by the Issuer to send an offer over DIDComm V2.
+func (ic *IssueCredential) SendOfferV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.SendOfferV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.SendOfferV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // SendProposal is used by the Holder to send a proposal.
 func (ic *IssueCredential) SendProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.SendProposalArgs{}
@@ -61,6 +77,22 @@ func (ic *IssueCredential) SendProposal(request *models.RequestEnvelope) *models
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendProposalV3 is used by the Holder to send a proposal over DIDComm V2.
+func (ic *IssueCredential) SendProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.SendProposalV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.SendProposalV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // SendRequest is used by the Holder to send a request.
 func (ic *IssueCredential) SendRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.SendRequestArgs{}
@@ -77,6 +109,22 @@ func (ic *IssueCredential) SendRequest(request *models.RequestEnvelope) *models.
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendRequestV3 is used by the Holder to send a request over DIDComm V2.
+func (ic *IssueCredential) SendRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.SendRequestV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.SendRequestV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
 func (ic *IssueCredential) AcceptProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.AcceptProposalArgs{}
@@ -93,6 +141,22 @@ func (ic *IssueCredential) AcceptProposal(request *models.RequestEnvelope) *mode
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// AcceptProposalV3 is used when the Issuer is willing to accept the proposal over DIDComm V2.
+func (ic *IssueCredential) AcceptProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.AcceptProposalV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.AcceptProposalV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // NegotiateProposal is used when the Holder wants to negotiate about an offer he received.
 func (ic *IssueCredential) NegotiateProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.NegotiateProposalArgs{}
@@ -109,6 +173,22 @@ func (ic *IssueCredential) NegotiateProposal(request *models.RequestEnvelope) *m
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// NegotiateProposalV3 is used when the Holder wants to negotiate about an offer he received over DIDComm V2.
+func (ic *IssueCredential) NegotiateProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.NegotiateProposalV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.NegotiateProposalV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 func (ic *IssueCredential) DeclineProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.DeclineProposalArgs{}
@@ -189,6 +269,22 @@ func (ic *IssueCredential) AcceptRequest(request *models.RequestEnvelope) *model
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// AcceptRequestV3 is used when the Issuer is willing to accept the request over DIDComm V2.
+func (ic *IssueCredential) AcceptRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdisscred.AcceptRequestV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(ic.handlers[cmdisscred.AcceptRequestV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // DeclineRequest is used when the Issuer does not want to accept the request.
 func (ic *IssueCredential) DeclineRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdisscred.DeclineRequestArgs{}
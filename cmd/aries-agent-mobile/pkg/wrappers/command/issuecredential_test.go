@@ -313,3 +313,123 @@ func TestIssueCredential_DeclineCredential(t *testing.T) {
 			string(resp.Payload))
 	})
 }
+
+func TestIssueCredential_SendOfferV3(t *testing.T) {
+	t.Run("test it sends an offer over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.SendOfferV3] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","offer_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.SendOfferV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_SendProposalV3(t *testing.T) {
+	t.Run("test it sends a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.SendProposalV3] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","propose_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.SendProposalV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_SendRequestV3(t *testing.T) {
+	t.Run("test it sends a request over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.SendRequestV3] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","request_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.SendRequestV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_AcceptProposalV3(t *testing.T) {
+	t.Run("test it accepts a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.AcceptProposalV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","offer_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.AcceptProposalV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_NegotiateProposalV3(t *testing.T) {
+	t.Run("test it negotiates a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.NegotiateProposalV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","propose_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.NegotiateProposalV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_AcceptRequestV3(t *testing.T) {
+	t.Run("test it accepts a request over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		ic.handlers[cmdisscred.AcceptRequestV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","issue_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := ic.AcceptRequestV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
@@ -93,6 +93,22 @@ func (m *Messaging) Reply(request *models.RequestEnvelope) *models.ResponseEnvel
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendPlain sends new DIDComm V2 message to destination provided.
+func (m *Messaging) SendPlain(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := messaging.SendPlainMessageArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(m.handlers[messaging.SendPlainMessageCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // RegisterHTTPService registers new http over didcomm service to message handler registrar.
 func (m *Messaging) RegisterHTTPService(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := messaging.RegisterHTTPMsgSvcArgs{}
@@ -110,6 +110,27 @@ func TestMessaging_Send(t *testing.T) {
 	})
 }
 
+func TestMessaging_SendPlain(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getMessagingController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[messaging.SendPlainMessageCommandMethod] = fakeHandler.exec
+
+		payload := `{"type": "https://example.com/custom/1.0/ping", "body": {"text":"sample"},
+"connection_ID": "sample-conn-ID-001"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.SendPlain(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestMessaging_Services(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		controller := getMessagingController(t)
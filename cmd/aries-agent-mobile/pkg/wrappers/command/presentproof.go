@@ -45,6 +45,22 @@ func (p *PresentProof) SendRequestPresentation(request *models.RequestEnvelope)
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendRequestPresentationV3 is used by the Verifier to send a request presentation over DIDComm V2.
+func (p *PresentProof) SendRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.SendRequestPresentationV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.SendRequestPresentationV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // SendProposePresentation is used by the Prover to send a propose presentation.
 func (p *PresentProof) SendProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdpresproof.SendProposePresentationArgs{}
@@ -61,6 +77,22 @@ func (p *PresentProof) SendProposePresentation(request *models.RequestEnvelope)
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// SendProposePresentationV3 is used by the Prover to send a propose presentation over DIDComm V2.
+func (p *PresentProof) SendProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.SendProposePresentationV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.SendProposePresentationV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // AcceptRequestPresentation is used by the Prover is to accept a presentation request.
 func (p *PresentProof) AcceptRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdpresproof.AcceptRequestPresentationArgs{}
@@ -77,6 +109,22 @@ func (p *PresentProof) AcceptRequestPresentation(request *models.RequestEnvelope
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// AcceptRequestPresentationV3 is used by the Prover is to accept a presentation request over DIDComm V2.
+func (p *PresentProof) AcceptRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.AcceptRequestPresentationV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.AcceptRequestPresentationV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // NegotiateRequestPresentation is used by the Prover to counter a presentation request they received with a proposal.
 func (p *PresentProof) NegotiateRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdpresproof.NegotiateRequestPresentationArgs{}
@@ -93,6 +141,23 @@ func (p *PresentProof) NegotiateRequestPresentation(request *models.RequestEnvel
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// NegotiateRequestPresentationV3 is used by the Prover to counter a presentation request they received with
+// a proposal over DIDComm V2.
+func (p *PresentProof) NegotiateRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.NegotiateRequestPresentationV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.NegotiateRequestPresentationV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // DeclineRequestPresentation is used when the Prover does not want to accept the request presentation.
 func (p *PresentProof) DeclineRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdpresproof.DeclineRequestPresentationArgs{}
@@ -125,6 +190,22 @@ func (p *PresentProof) AcceptProposePresentation(request *models.RequestEnvelope
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// AcceptProposePresentationV3 is used when the Verifier is willing to accept the propose presentation over DIDComm V2.
+func (p *PresentProof) AcceptProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdpresproof.AcceptProposePresentationV3Args{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(p.handlers[cmdpresproof.AcceptProposePresentationV3], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // DeclineProposePresentation is used when the Verifier does not want to accept the propose presentation.
 func (p *PresentProof) DeclineProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdpresproof.DeclineProposePresentationArgs{}
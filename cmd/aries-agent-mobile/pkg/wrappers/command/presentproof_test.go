@@ -70,6 +70,26 @@ func TestPresentProof_SendRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_SendRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.SendRequestPresentationV3] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","request_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.SendRequestPresentationV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestPresentProof_SendProposePresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -90,6 +110,26 @@ func TestPresentProof_SendProposePresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_SendProposePresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.SendProposePresentationV3] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","propose_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.SendProposePresentationV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestPresentProof_AcceptRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -110,6 +150,26 @@ func TestPresentProof_AcceptRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_AcceptRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.AcceptRequestPresentationV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.AcceptRequestPresentationV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestPresentProof_NegotiateRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -130,6 +190,26 @@ func TestPresentProof_NegotiateRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_NegotiateRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.NegotiateRequestPresentationV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","propose_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.NegotiateRequestPresentationV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestPresentProof_DeclineRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -170,6 +250,26 @@ func TestPresentProof_AcceptProposePresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_AcceptProposePresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		p.handlers[cmdpresproof.AcceptProposePresentationV3] = fakeHandler.exec
+
+		payload := `{"piid":"id","request_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := p.AcceptProposePresentationV3(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestPresentProof_DeclineProposePresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
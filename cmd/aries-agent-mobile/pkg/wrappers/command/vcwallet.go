@@ -307,3 +307,35 @@ func (v *VCWallet) PresentProof(request *models.RequestEnvelope) *models.Respons
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// Export exports all wallet contents and keys into an archive encrypted by given password.
+func (v *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ExportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ExportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// Import imports wallet contents and keys from an archive produced by export operation.
+func (v *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdvcwallet.ImportRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdvcwallet.ImportMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
@@ -429,3 +429,42 @@ func TestVCWallet_Add_Get_GetAll(t *testing.T) {
 		require.NotNil(t, resp.Error)
 	})
 }
+
+func TestVCWallet_Export_Import(t *testing.T) {
+	vcwalletController := getVCWalletController(t)
+	require.NotNil(t, vcwalletController)
+
+	t.Run("export", func(t *testing.T) {
+		mockResponse := `{"contents":{"type":"EncryptedWalletArchive"}}`
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		vcwalletController.handlers[cmdvcwallet.ExportMethod] = fakeHandler.exec
+
+		payload := `{"userID":"user1","auth":"token","password":"secret"}`
+
+		resp := vcwalletController.Export(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+
+	t.Run("import", func(t *testing.T) {
+		fakeHandler := mockCommandRunner{data: []byte(``)}
+		vcwalletController.handlers[cmdvcwallet.ImportMethod] = fakeHandler.exec
+
+		payload := `{"userID":"user1","auth":"token","password":"secret","contents":{"type":"EncryptedWalletArchive"}}`
+
+		resp := vcwalletController.Import(&models.RequestEnvelope{Payload: []byte(payload)})
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		resp := vcwalletController.Export(&models.RequestEnvelope{Payload: []byte(`{`)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+
+		resp = vcwalletController.Import(&models.RequestEnvelope{Payload: []byte(`{`)})
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Error)
+	})
+}
@@ -217,22 +217,42 @@ func getIssueCredentialEndpoints() map[string]*endpoint {
 			Path:   opisscred.SendOffer,
 			Method: http.MethodPost,
 		},
+		cmdisscred.SendOfferV3: {
+			Path:   opisscred.SendOfferV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.SendProposal: {
 			Path:   opisscred.SendProposal,
 			Method: http.MethodPost,
 		},
+		cmdisscred.SendProposalV3: {
+			Path:   opisscred.SendProposalV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.SendRequest: {
 			Path:   opisscred.SendRequest,
 			Method: http.MethodPost,
 		},
+		cmdisscred.SendRequestV3: {
+			Path:   opisscred.SendRequestV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.AcceptProposal: {
 			Path:   opisscred.AcceptProposal,
 			Method: http.MethodPost,
 		},
+		cmdisscred.AcceptProposalV3: {
+			Path:   opisscred.AcceptProposalV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.NegotiateProposal: {
 			Path:   opisscred.NegotiateProposal,
 			Method: http.MethodPost,
 		},
+		cmdisscred.NegotiateProposalV3: {
+			Path:   opisscred.NegotiateProposalV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.DeclineProposal: {
 			Path:   opisscred.DeclineProposal,
 			Method: http.MethodPost,
@@ -253,6 +273,10 @@ func getIssueCredentialEndpoints() map[string]*endpoint {
 			Path:   opisscred.AcceptRequest,
 			Method: http.MethodPost,
 		},
+		cmdisscred.AcceptRequestV3: {
+			Path:   opisscred.AcceptRequestV3,
+			Method: http.MethodPost,
+		},
 		cmdisscred.DeclineRequest: {
 			Path:   opisscred.DeclineRequest,
 			Method: http.MethodPost,
@@ -278,18 +302,34 @@ func getPresentProofEndpoints() map[string]*endpoint {
 			Path:   oppresproof.SendRequestPresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.SendRequestPresentationV3: {
+			Path:   oppresproof.SendRequestPresentationV3,
+			Method: http.MethodPost,
+		},
 		cmdpresproof.SendProposePresentation: {
 			Path:   oppresproof.SendProposePresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.SendProposePresentationV3: {
+			Path:   oppresproof.SendProposePresentationV3,
+			Method: http.MethodPost,
+		},
 		cmdpresproof.AcceptRequestPresentation: {
 			Path:   oppresproof.AcceptRequestPresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.AcceptRequestPresentationV3: {
+			Path:   oppresproof.AcceptRequestPresentationV3,
+			Method: http.MethodPost,
+		},
 		cmdpresproof.NegotiateRequestPresentation: {
 			Path:   oppresproof.NegotiateRequestPresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.NegotiateRequestPresentationV3: {
+			Path:   oppresproof.NegotiateRequestPresentationV3,
+			Method: http.MethodPost,
+		},
 		cmdpresproof.DeclineRequestPresentation: {
 			Path:   oppresproof.DeclineRequestPresentation,
 			Method: http.MethodPost,
@@ -298,6 +338,10 @@ func getPresentProofEndpoints() map[string]*endpoint {
 			Path:   oppresproof.AcceptProposePresentation,
 			Method: http.MethodPost,
 		},
+		cmdpresproof.AcceptProposePresentationV3: {
+			Path:   oppresproof.AcceptProposePresentationV3,
+			Method: http.MethodPost,
+		},
 		cmdpresproof.DeclineProposePresentation: {
 			Path:   oppresproof.DeclineProposePresentation,
 			Method: http.MethodPost,
@@ -397,6 +441,10 @@ func getMessagingEndpoints() map[string]*endpoint {
 			Path:   opmessaging.SendReplyMsg,
 			Method: http.MethodPost,
 		},
+		cmdmessaging.SendPlainMessageCommandMethod: {
+			Path:   opmessaging.SendPlainMsg,
+			Method: http.MethodPost,
+		},
 		cmdmessaging.RegisterHTTPMessageServiceCommandMethod: {
 			Path:   opmessaging.RegisterHTTPOverDIDCommService,
 			Method: http.MethodPost,
@@ -527,5 +575,11 @@ func getVCWalletEndpoints() map[string]*endpoint {
 		cmdvcwallet.PresentProofMethod: {
 			Path: opvcwallet.PresentProofPath, Method: http.MethodPost,
 		},
+		cmdvcwallet.ExportMethod: {
+			Path: opvcwallet.ExportPath, Method: http.MethodPost,
+		},
+		cmdvcwallet.ImportMethod: {
+			Path: opvcwallet.ImportPath, Method: http.MethodPost,
+		},
 	}
 }
@@ -30,26 +30,51 @@ func (ic *IssueCredential) SendOffer(request *models.RequestEnvelope) *models.Re
 	return ic.createRespEnvelope(request, cmdisscred.SendOffer)
 }
 
+// SendOfferV3 is used by the Issuer to send an offer over DIDComm V2.
+func (ic *IssueCredential) SendOfferV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.SendOfferV3)
+}
+
 // SendProposal is used by the Holder to send a proposal.
 func (ic *IssueCredential) SendProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.SendProposal)
 }
 
+// SendProposalV3 is used by the Holder to send a proposal over DIDComm V2.
+func (ic *IssueCredential) SendProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.SendProposalV3)
+}
+
 // SendRequest is used by the Holder to send a request.
 func (ic *IssueCredential) SendRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.SendRequest)
 }
 
+// SendRequestV3 is used by the Holder to send a request over DIDComm V2.
+func (ic *IssueCredential) SendRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.SendRequestV3)
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
 func (ic *IssueCredential) AcceptProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.AcceptProposal)
 }
 
+// AcceptProposalV3 is used when the Issuer is willing to accept the proposal over DIDComm V2.
+func (ic *IssueCredential) AcceptProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.AcceptProposalV3)
+}
+
 // NegotiateProposal is used when the Holder wants to negotiate about an offer he received.
 func (ic *IssueCredential) NegotiateProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.NegotiateProposal)
 }
 
+// NegotiateProposalV3 is used when the Holder wants to negotiate about an offer he received over DIDComm V2.
+func (ic *IssueCredential) NegotiateProposalV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.NegotiateProposalV3)
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 func (ic *IssueCredential) DeclineProposal(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.DeclineProposal)
@@ -75,6 +100,11 @@ func (ic *IssueCredential) AcceptRequest(request *models.RequestEnvelope) *model
 	return ic.createRespEnvelope(request, cmdisscred.AcceptRequest)
 }
 
+// AcceptRequestV3 is used when the Issuer is willing to accept the request over DIDComm V2.
+func (ic *IssueCredential) AcceptRequestV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return ic.createRespEnvelope(request, cmdisscred.AcceptRequestV3)
+}
+
 // DeclineRequest is used when the Issuer does not want to accept the request.
 func (ic *IssueCredential) DeclineRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return ic.createRespEnvelope(request, cmdisscred.DeclineRequest)
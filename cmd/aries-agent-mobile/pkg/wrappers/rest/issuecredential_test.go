@@ -345,3 +345,135 @@ func TestIssueCredential_DeclineCredential(t *testing.T) {
 		require.Equal(t, mockResponse, string(resp.Payload))
 	})
 }
+
+func TestIssueCredential_SendOfferV3(t *testing.T) {
+	t.Run("test it sends an offer over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opisscred.SendOfferV3,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","offer_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.SendOfferV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_SendProposalV3(t *testing.T) {
+	t.Run("test it sends a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opisscred.SendProposalV3,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","propose_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.SendProposalV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_SendRequestV3(t *testing.T) {
+	t.Run("test it sends a request over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		mockResponse := fmt.Sprintf(`{"piid": "%s"}`, mockPIID)
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opisscred.SendRequestV3,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","request_credential":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.SendRequestV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_AcceptProposalV3(t *testing.T) {
+	t.Run("test it accepts a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		reqData := `{"piid":"id","offer_credential":{}}`
+		mockURL, err := parseURL(mockAgentURL, opisscred.AcceptProposalV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.AcceptProposalV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_NegotiateProposalV3(t *testing.T) {
+	t.Run("test it negotiates a proposal over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		reqData := `{"piid":"id","propose_credential":{}}`
+		mockURL, err := parseURL(mockAgentURL, opisscred.NegotiateProposalV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.NegotiateProposalV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestIssueCredential_AcceptRequestV3(t *testing.T) {
+	t.Run("test it accepts a request over DIDComm V2", func(t *testing.T) {
+		ic := getIssueCredentialController(t)
+
+		reqData := `{"piid":"id","issue_credential":{}}`
+		mockURL, err := parseURL(mockAgentURL, opisscred.AcceptRequestV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		ic.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := ic.AcceptRequestV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -45,6 +45,11 @@ func (m *Messaging) Reply(request *models.RequestEnvelope) *models.ResponseEnvel
 	return m.createRespEnvelope(request, messaging.SendReplyMessageCommandMethod)
 }
 
+// SendPlain sends new DIDComm V2 message to destination provided.
+func (m *Messaging) SendPlain(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return m.createRespEnvelope(request, messaging.SendPlainMessageCommandMethod)
+}
+
 // RegisterHTTPService registers new http over didcomm service to message handler registrar.
 func (m *Messaging) RegisterHTTPService(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return m.createRespEnvelope(request, messaging.RegisterHTTPMessageServiceCommandMethod)
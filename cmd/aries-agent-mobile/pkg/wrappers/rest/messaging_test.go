@@ -116,6 +116,28 @@ func TestMessaging_Send(t *testing.T) {
 	})
 }
 
+func TestMessaging_SendPlain(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getMessagingController(t)
+
+		reqData := `{"type": "https://example.com/custom/1.0/ping", "body": {"text":"sample"},
+"connection_ID": "sample-conn-ID-001"}`
+		mockResponse := emptyJSON
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + messaging.SendPlainMsg,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.SendPlain(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestMessaging_Services(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		controller := getMessagingController(t)
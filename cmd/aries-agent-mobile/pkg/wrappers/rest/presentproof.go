@@ -30,21 +30,42 @@ func (p *PresentProof) SendRequestPresentation(request *models.RequestEnvelope)
 	return p.createRespEnvelope(request, cmdpresproof.SendRequestPresentation)
 }
 
+// SendRequestPresentationV3 is used by the Verifier to send a request presentation over DIDComm V2.
+func (p *PresentProof) SendRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.SendRequestPresentationV3)
+}
+
 // SendProposePresentation is used by the Prover to send a propose presentation.
 func (p *PresentProof) SendProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return p.createRespEnvelope(request, cmdpresproof.SendProposePresentation)
 }
 
+// SendProposePresentationV3 is used by the Prover to send a propose presentation over DIDComm V2.
+func (p *PresentProof) SendProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.SendProposePresentationV3)
+}
+
 // AcceptRequestPresentation is used by the Prover is to accept a presentation request.
 func (p *PresentProof) AcceptRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return p.createRespEnvelope(request, cmdpresproof.AcceptRequestPresentation)
 }
 
+// AcceptRequestPresentationV3 is used by the Prover is to accept a presentation request over DIDComm V2.
+func (p *PresentProof) AcceptRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.AcceptRequestPresentationV3)
+}
+
 // NegotiateRequestPresentation is used by the Prover to counter a presentation request they received with a proposal.
 func (p *PresentProof) NegotiateRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return p.createRespEnvelope(request, cmdpresproof.NegotiateRequestPresentation)
 }
 
+// NegotiateRequestPresentationV3 is used by the Prover to counter a presentation request they received with
+// a proposal over DIDComm V2.
+func (p *PresentProof) NegotiateRequestPresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.NegotiateRequestPresentationV3)
+}
+
 // DeclineRequestPresentation is used when the Prover does not want to accept the request presentation.
 func (p *PresentProof) DeclineRequestPresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return p.createRespEnvelope(request, cmdpresproof.DeclineRequestPresentation)
@@ -55,6 +76,11 @@ func (p *PresentProof) AcceptProposePresentation(request *models.RequestEnvelope
 	return p.createRespEnvelope(request, cmdpresproof.AcceptProposePresentation)
 }
 
+// AcceptProposePresentationV3 is used when the Verifier is willing to accept the propose presentation over DIDComm V2.
+func (p *PresentProof) AcceptProposePresentationV3(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return p.createRespEnvelope(request, cmdpresproof.AcceptProposePresentationV3)
+}
+
 // DeclineProposePresentation is used when the Verifier does not want to accept the propose presentation.
 func (p *PresentProof) DeclineProposePresentation(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return p.createRespEnvelope(request, cmdpresproof.DeclineProposePresentation)
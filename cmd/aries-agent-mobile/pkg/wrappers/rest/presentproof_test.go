@@ -74,6 +74,27 @@ func TestPresentProof_SendRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_SendRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := mockPIID
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + oppresproof.SendRequestPresentationV3,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","request_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.SendRequestPresentationV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestPresentProof_SendProposePresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -95,6 +116,27 @@ func TestPresentProof_SendProposePresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_SendProposePresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		mockResponse := mockPIID
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + oppresproof.SendProposePresentationV3,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","propose_presentation":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.SendProposePresentationV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestPresentProof_AcceptRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -118,6 +160,29 @@ func TestPresentProof_AcceptRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_AcceptRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := `{"piid":"id","presentation":{}}`
+		mockURL, err := parseURL(mockAgentURL, oppresproof.AcceptRequestPresentationV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.AcceptRequestPresentationV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestPresentProof_NegotiateRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -141,6 +206,29 @@ func TestPresentProof_NegotiateRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_NegotiateRequestPresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := `{"piid":"id","propose_presentation":{}}`
+		mockURL, err := parseURL(mockAgentURL, oppresproof.NegotiateRequestPresentationV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.NegotiateRequestPresentationV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestPresentProof_DeclineRequestPresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -187,6 +275,29 @@ func TestPresentProof_AcceptProposePresentation(t *testing.T) {
 	})
 }
 
+func TestPresentProof_AcceptProposePresentationV3(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := getPresentProofController(t)
+
+		reqData := `{"piid":"id","request_presentation":{}}`
+		mockURL, err := parseURL(mockAgentURL, oppresproof.AcceptProposePresentationV3, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		p.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := p.AcceptProposePresentationV3(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestPresentProof_DeclineProposePresentation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p := getPresentProofController(t)
@@ -111,6 +111,16 @@ func (wallet *VCWallet) PresentProof(request *models.RequestEnvelope) *models.Re
 	return wallet.createRespEnvelope(request, cmdvcwallet.PresentProofMethod)
 }
 
+// Export exports all wallet contents and keys into an archive encrypted by given password.
+func (wallet *VCWallet) Export(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ExportMethod)
+}
+
+// Import imports wallet contents and keys from an archive produced by export operation.
+func (wallet *VCWallet) Import(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return wallet.createRespEnvelope(request, cmdvcwallet.ImportMethod)
+}
+
 func (wallet *VCWallet) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        wallet.URL,
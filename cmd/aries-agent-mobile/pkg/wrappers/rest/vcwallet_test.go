@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	opvcwallet "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
+)
+
+func getVCWalletController(t *testing.T) *VCWallet {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	controller, err := a.GetVCWalletController()
+	require.NoError(t, err)
+	require.NotNil(t, controller)
+
+	v, ok := controller.(*VCWallet)
+	require.Equal(t, ok, true)
+
+	return v
+}
+
+func TestVCWallet_Export(t *testing.T) {
+	t.Run("test it exports the wallet", func(t *testing.T) {
+		controller := getVCWalletController(t)
+
+		mockResponse := `{"contents":{"type":"EncryptedWalletArchive"}}`
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opvcwallet.ExportPath,
+		}
+
+		reqData := `{"userID":"user1","auth":"token","password":"secret"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.Export(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestVCWallet_Import(t *testing.T) {
+	t.Run("test it imports the wallet", func(t *testing.T) {
+		controller := getVCWalletController(t)
+
+		mockResponse := emptyJSON
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opvcwallet.ImportPath,
+		}
+
+		reqData := `{"userID":"user1","auth":"token","password":"secret","contents":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.Import(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
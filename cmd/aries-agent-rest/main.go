@@ -47,6 +47,7 @@ func main() {
 	}
 
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(startcmd.MigrateCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Fatalf("Failed to run aries-agent-rest: %s", err)
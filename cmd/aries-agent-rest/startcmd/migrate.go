@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sourceDatabaseTypeFlagName  = "source-database-type"
+	sourceDatabaseTypeEnvKey    = "ARIESD_SOURCE_DATABASE_TYPE"
+	sourceDatabaseTypeFlagUsage = "The type of the database migrated. Supported options: leveldb." +
+		" Alternatively, this can be set with the following environment variable: " + sourceDatabaseTypeEnvKey
+
+	sourceDatabasePrefixFlagName  = "source-database-prefix"
+	sourceDatabasePrefixEnvKey    = "ARIESD_SOURCE_DATABASE_PREFIX"
+	sourceDatabasePrefixFlagUsage = "The prefix of the database migrated, as set in the " + databasePrefixFlagName +
+		" flag of the agent." +
+		" Alternatively, this can be set with the following environment variable: " + sourceDatabasePrefixEnvKey
+
+	targetDatabaseTypeFlagName  = "target-database-type"
+	targetDatabaseTypeEnvKey    = "ARIESD_TARGET_DATABASE_TYPE"
+	targetDatabaseTypeFlagUsage = "The type of the database the data is migrated to. Supported options: leveldb." +
+		" Alternatively, this can be set with the following environment variable: " + targetDatabaseTypeEnvKey
+
+	targetDatabasePrefixFlagName  = "target-database-prefix"
+	targetDatabasePrefixEnvKey    = "ARIESD_TARGET_DATABASE_PREFIX"
+	targetDatabasePrefixFlagUsage = "The prefix of the database the data is migrated to." +
+		" Alternatively, this can be set with the following environment variable: " + targetDatabasePrefixEnvKey
+
+	migrateStoresFlagName  = "stores"
+	migrateStoresEnvKey    = "ARIESD_MIGRATE_STORES"
+	migrateStoresFlagUsage = "The names of the stores migrated, all the stores of the database if not set." +
+		" This flag can be repeated." +
+		" Alternatively, this can be set with the following environment variable (in CSV format): " +
+		migrateStoresEnvKey
+
+	migrateBatchSizeFlagName  = "batch-size"
+	migrateBatchSizeEnvKey    = "ARIESD_MIGRATE_BATCH_SIZE"
+	migrateBatchSizeFlagUsage = "The number of entries written to the target database at once. Defaults to 100." +
+		" Alternatively, this can be set with the following environment variable: " + migrateBatchSizeEnvKey
+)
+
+// nolint:gochecknoglobals
+var migrationStorageProviders = map[string]func(prefix string) (storage.Provider, error){
+	databaseTypeLevelDBOption: supportedStorageProviders[databaseTypeLevelDBOption],
+}
+
+// MigrateCmd returns the Cobra command migrating the data of an agent from a database to another. The agent must be
+// stopped during the migration. A migration that was interrupted resumes where it stopped when run again.
+func MigrateCmd() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the database of an agent",
+		Long:  `Copy all the stores of an agent database to another database, the agent being stopped`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceParam, targetParam, err := getMigrationDBParams(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts, err := getMigrateOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			source, err := migrationStorageProviders[sourceParam.dbType](sourceParam.prefix)
+			if err != nil {
+				return fmt.Errorf("failed to open source database: %w", err)
+			}
+
+			defer closeProvider(source)
+
+			target, err := migrationStorageProviders[targetParam.dbType](targetParam.prefix)
+			if err != nil {
+				return fmt.Errorf("failed to open target database: %w", err)
+			}
+
+			defer closeProvider(target)
+
+			err = migrate.Migrate(source, target, opts...)
+			if err != nil {
+				return fmt.Errorf("failed to migrate database: %w", err)
+			}
+
+			logger.Infof("database migrated")
+
+			return nil
+		},
+	}
+
+	migrateCmd.Flags().StringP(sourceDatabaseTypeFlagName, "", "", sourceDatabaseTypeFlagUsage)
+	migrateCmd.Flags().StringP(sourceDatabasePrefixFlagName, "", "", sourceDatabasePrefixFlagUsage)
+	migrateCmd.Flags().StringP(targetDatabaseTypeFlagName, "", "", targetDatabaseTypeFlagUsage)
+	migrateCmd.Flags().StringP(targetDatabasePrefixFlagName, "", "", targetDatabasePrefixFlagUsage)
+	migrateCmd.Flags().StringSliceP(migrateStoresFlagName, "", []string{}, migrateStoresFlagUsage)
+	migrateCmd.Flags().StringP(migrateBatchSizeFlagName, "", "", migrateBatchSizeFlagUsage)
+
+	return migrateCmd
+}
+
+func getMigrationDBParams(cmd *cobra.Command) (*dbParam, *dbParam, error) {
+	source, err := getMigrationDBParam(cmd, sourceDatabaseTypeFlagName, sourceDatabaseTypeEnvKey,
+		sourceDatabasePrefixFlagName, sourceDatabasePrefixEnvKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := getMigrationDBParam(cmd, targetDatabaseTypeFlagName, targetDatabaseTypeEnvKey,
+		targetDatabasePrefixFlagName, targetDatabasePrefixEnvKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if *source == *target {
+		return nil, nil, errors.New("the source and target databases must differ")
+	}
+
+	return source, target, nil
+}
+
+func getMigrationDBParam(cmd *cobra.Command, typeFlagName, typeEnvKey, prefixFlagName,
+	prefixEnvKey string) (*dbParam, error) {
+	dbType, err := getUserSetVar(cmd, typeFlagName, typeEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, supported := migrationStorageProviders[dbType]; !supported {
+		return nil, fmt.Errorf("%s not set to a valid type. run migrate --help to see the available options",
+			typeFlagName)
+	}
+
+	prefix, err := getUserSetVar(cmd, prefixFlagName, prefixEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbParam{dbType: dbType, prefix: prefix}, nil
+}
+
+func getMigrateOpts(cmd *cobra.Command) ([]migrate.Opt, error) {
+	stores, err := getUserSetVars(cmd, migrateStoresFlagName, migrateStoresEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize, err := getUserSetVar(cmd, migrateBatchSizeFlagName, migrateBatchSizeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []migrate.Opt{migrate.WithProgress(func(p migrate.Progress) {
+		if p.Done {
+			logger.Infof("store %s migrated (%d/%d): %d entries", p.Store, p.StoreIndex, p.StoreCount, p.Entries)
+
+			return
+		}
+
+		logger.Debugf("store %s (%d/%d): %d entries copied", p.Store, p.StoreIndex, p.StoreCount, p.Entries)
+	})}
+
+	if len(stores) > 0 {
+		opts = append(opts, migrate.WithStoreNames(stores...))
+	}
+
+	if batchSize != "" {
+		size, err := strconv.Atoi(batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse batch size %s: %w", batchSize, err)
+		}
+
+		opts = append(opts, migrate.WithBatchSize(size))
+	}
+
+	return opts, nil
+}
+
+func closeProvider(provider storage.Provider) {
+	if err := provider.Close(); err != nil {
+		logger.Warnf("failed to close storage provider: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestMigrateCmd(t *testing.T) {
+	dir := t.TempDir()
+	sourcePrefix, targetPrefix := filepath.Join(dir, "source"), filepath.Join(dir, "target")
+
+	source := leveldb.NewProvider(sourcePrefix)
+
+	connections, err := source.OpenStore("connections")
+	require.NoError(t, err)
+
+	require.NoError(t, connections.Put("conn1", []byte("record"), storage.Tag{Name: "state", Value: "completed"}))
+
+	kms, err := source.OpenStore("kms")
+	require.NoError(t, err)
+
+	require.NoError(t, kms.Put("kid", []byte("keyset")))
+	require.NoError(t, source.Close())
+
+	migrationArgs := func(args ...string) []string {
+		return append([]string{
+			"--" + sourceDatabaseTypeFlagName, databaseTypeLevelDBOption,
+			"--" + sourceDatabasePrefixFlagName, sourcePrefix,
+			"--" + targetDatabaseTypeFlagName, databaseTypeLevelDBOption,
+		}, args...)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		migrateCmd := MigrateCmd()
+		migrateCmd.SetArgs(migrationArgs("--"+targetDatabasePrefixFlagName, targetPrefix,
+			"--"+migrateStoresFlagName, "connections", "--"+migrateBatchSizeFlagName, "10"))
+
+		require.NoError(t, migrateCmd.Execute())
+
+		target := leveldb.NewProvider(targetPrefix)
+
+		defer func() {
+			require.NoError(t, target.Close())
+		}()
+
+		names, err := target.StoreNames()
+		require.NoError(t, err)
+		require.NotContains(t, names, "kms")
+
+		store, err := target.OpenStore("connections")
+		require.NoError(t, err)
+
+		tags, err := store.GetTags("conn1")
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "state", Value: "completed"}}, tags)
+	})
+
+	t.Run("error - missing target database", func(t *testing.T) {
+		migrateCmd := MigrateCmd()
+		migrateCmd.SetArgs(migrationArgs())
+
+		err := migrateCmd.Execute()
+		require.EqualError(t, err, "Neither target-database-prefix (command line flag) nor "+
+			"ARIESD_TARGET_DATABASE_PREFIX (environment variable) have been set.")
+	})
+
+	t.Run("error - unsupported database type", func(t *testing.T) {
+		migrateCmd := MigrateCmd()
+		migrateCmd.SetArgs([]string{"--" + sourceDatabaseTypeFlagName, databaseTypeMemOption})
+
+		err := migrateCmd.Execute()
+		require.EqualError(t, err, "source-database-type not set to a valid type. "+
+			"run migrate --help to see the available options")
+	})
+
+	t.Run("error - same source and target", func(t *testing.T) {
+		migrateCmd := MigrateCmd()
+		migrateCmd.SetArgs(migrationArgs("--"+targetDatabasePrefixFlagName, sourcePrefix))
+
+		err := migrateCmd.Execute()
+		require.EqualError(t, err, "the source and target databases must differ")
+	})
+
+	t.Run("error - invalid batch size", func(t *testing.T) {
+		migrateCmd := MigrateCmd()
+		migrateCmd.SetArgs(migrationArgs("--"+targetDatabasePrefixFlagName, targetPrefix,
+			"--"+migrateBatchSizeFlagName, "invalid"))
+
+		err := migrateCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse batch size invalid")
+
+		migrateCmd = MigrateCmd()
+		migrateCmd.SetArgs(migrationArgs("--"+targetDatabasePrefixFlagName, targetPrefix,
+			"--"+migrateBatchSizeFlagName, "0"))
+
+		err = migrateCmd.Execute()
+		require.EqualError(t, err, "failed to migrate database: invalid batch size: 0")
+	})
+}
@@ -6,11 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	gocontext "context"
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -19,10 +22,16 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics/prometheus"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
@@ -33,6 +42,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -133,6 +143,14 @@ const (
 		" This flag can be repeated, allowing to configure multiple inbound transports." +
 		" Alternatively, this can be set with the following environment variable: " + agentInboundHostEnvKey
 
+	// controller API type flag.
+	agentAPITypeFlagName  = "api-type"
+	agentAPITypeEnvKey    = "ARIESD_API_TYPE"
+	agentAPITypeFlagUsage = "Type of the controller API served on the API host." +
+		" Possible values [rest] [grpc]. Defaults to rest if not set." +
+		" Webhook URLs are ignored by the gRPC API, events are streamed to its subscribers instead." +
+		" Alternatively, this can be set with the following environment variable: " + agentAPITypeEnvKey
+
 	// inbound host external url flag.
 	agentInboundHostExternalFlagName      = "inbound-host-external"
 	agentInboundHostExternalEnvKey        = "ARIESD_INBOUND_HOST_EXTERNAL"
@@ -163,6 +181,31 @@ const (
 		"RFC0593-compliant attachment formats. Default is false." +
 		" Alternatively, this can be set with the following environment variable: " + agentAutoExecuteRFC0593EnvKey
 
+	// metrics flag.
+	agentMetricsFlagName  = "metrics"
+	agentMetricsEnvKey    = "ARIESD_METRICS"
+	agentMetricsFlagUsage = "Enables the collection of the agent metrics, exposed in the Prometheus format" +
+		" on the /metrics endpoint of the REST API. Default is false." +
+		" Alternatively, this can be set with the following environment variable: " + agentMetricsEnvKey
+
+	metricsPath = "/metrics"
+
+	// multi-tenant flag.
+	agentMultiTenantFlagName  = "multi-tenant"
+	agentMultiTenantEnvKey    = "ARIESD_MULTI_TENANT"
+	agentMultiTenantFlagUsage = "Enables the hosting of many isolated agents (tenants) by the REST API." +
+		" Tenants are managed with the " + adminTenantsPath + " admin API and their API is served under " +
+		tenantsPath + "/{id}. Tenants have no inbound transport, they receive messages over their outbound" +
+		" transports (eg. through a mediator with return route). Default is false." +
+		" Alternatively, this can be set with the following environment variable: " + agentMultiTenantEnvKey
+
+	// sender policy flag.
+	agentSenderPolicyFlagName  = "sender-policy"
+	agentSenderPolicyEnvKey    = "ARIESD_SENDER_POLICY"
+	agentSenderPolicyFlagUsage = "Enables the filtering of the inbound messages by sender DID. The allow and deny rules" +
+		" are managed with the /sender-policy/rules API. Default is false." +
+		" Alternatively, this can be set with the following environment variable: " + agentSenderPolicyEnvKey
+
 	// remote JSON-LD context provider url flag.
 	agentContextProviderFlagName  = "context-provider-url"
 	agentContextProviderEnvKey    = "ARIESD_CONTEXT_PROVIDER_URL"
@@ -171,6 +214,14 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentContextProviderEnvKey
 
+	// remote JSON-LD context refresh interval flag.
+	agentContextRefreshIntervalFlagName  = "context-refresh-interval"
+	agentContextRefreshIntervalEnvKey    = "ARIESD_CONTEXT_REFRESH_INTERVAL"
+	agentContextRefreshIntervalFlagUsage = "Interval of the background refresh of the JSON-LD contexts of the remote" +
+		" context providers (eg. 1h). The contexts aren't refreshed in the background if not set." +
+		" Alternatively, this can be set with the following environment variable: " +
+		agentContextRefreshIntervalEnvKey
+
 	// default verification key type flag.
 	agentKeyTypeFlagName = "key-type"
 	agentKeyTypeEnvKey   = "ARIESD_KEY_TYPE"
@@ -195,6 +246,16 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentMediaTypeProfilesEnvKey
 
+	// framework configuration file flag.
+	agentConfigFileFlagName  = "config-file"
+	agentConfigFileEnvKey    = "ARIESD_CONFIG_FILE"
+	agentConfigFileFlagUsage = "Path of the YAML or JSON framework configuration file (optional)." +
+		" The flags override the settings of the configuration file, the database type is optional when it is set." +
+		" Alternatively, this can be set with the following environment variable: " + agentConfigFileEnvKey
+
+	apiTypeREST = "rest"
+	apiTypeGRPC = "grpc"
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
@@ -228,9 +289,12 @@ var (
 
 type agentParameters struct {
 	server                                         server
-	host, defaultLabel, transportReturnRoute       string
+	host, apiType, defaultLabel                    string
+	transportReturnRoute                           string
 	tlsCertFile, tlsKeyFile                        string
 	token, keyType, keyAgreementType               string
+	configFile                                     string
+	tenantStorePrefix                              string
 	webhookURLs, httpResolvers, outboundTransports []string
 	inboundHostInternals, inboundHostExternals     []string
 	contextProviderURLs, mediaTypeProfiles         []string
@@ -238,6 +302,11 @@ type agentParameters struct {
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
 	autoExecuteRFC0593                             bool
+	metrics                                        bool
+	metricsProvider                                *prometheus.Provider
+	multiTenant                                    bool
+	senderPolicy                                   bool
+	contextRefresh                                 time.Duration
 }
 
 type dbParam struct {
@@ -303,6 +372,11 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
+			apiType, err := getUserSetVar(cmd, agentAPITypeFlagName, agentAPITypeEnvKey, true)
+			if err != nil {
+				return err
+			}
+
 			token, err := getUserSetVar(cmd, agentTokenFlagName, agentTokenEnvKey, true)
 			if err != nil {
 				return err
@@ -319,7 +393,12 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
-			dbParam, err := getDBParam(cmd)
+			configFile, err := getUserSetVar(cmd, agentConfigFileFlagName, agentConfigFileEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			dbParam, err := getDBParam(cmd, configFile != "")
 			if err != nil {
 				return err
 			}
@@ -361,11 +440,31 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
+			contextRefreshInterval, err := getContextRefreshInterval(cmd)
+			if err != nil {
+				return err
+			}
+
 			autoExecuteRFC0593, err := getAutoExecuteRFC0593(cmd)
 			if err != nil {
 				return err
 			}
 
+			metrics, err := getMetrics(cmd)
+			if err != nil {
+				return err
+			}
+
+			multiTenant, err := getMultiTenant(cmd)
+			if err != nil {
+				return err
+			}
+
+			senderPolicy, err := getSenderPolicy(cmd)
+			if err != nil {
+				return err
+			}
+
 			tlsCertFile, err := getUserSetVar(cmd, agentTLSCertFileFlagName, agentTLSCertFileEnvKey, true)
 			if err != nil {
 				return err
@@ -394,6 +493,7 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 			parameters := &agentParameters{
 				server:               server,
 				host:                 host,
+				apiType:              apiType,
 				token:                token,
 				inboundHostInternals: inboundHosts,
 				inboundHostExternals: inboundHostExternals,
@@ -405,12 +505,17 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				autoAccept:           autoAccept,
 				transportReturnRoute: transportReturnRoute,
 				contextProviderURLs:  contextProviderURLs,
+				contextRefresh:       contextRefreshInterval,
 				tlsCertFile:          tlsCertFile,
 				tlsKeyFile:           tlsKeyFile,
 				autoExecuteRFC0593:   autoExecuteRFC0593,
 				keyType:              keyType,
 				keyAgreementType:     keyAgreementType,
 				mediaTypeProfiles:    mediaTypeProfiles,
+				metrics:              metrics,
+				multiTenant:          multiTenant,
+				senderPolicy:         senderPolicy,
+				configFile:           configFile,
 			}
 
 			return startAgent(parameters)
@@ -418,12 +523,12 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 	}
 }
 
-func getDBParam(cmd *cobra.Command) (*dbParam, error) {
+func getDBParam(cmd *cobra.Command, isOptional bool) (*dbParam, error) {
 	dbParam := &dbParam{}
 
 	var err error
 
-	dbParam.dbType, err = getUserSetVar(cmd, databaseTypeFlagName, databaseTypeEnvKey, false)
+	dbParam.dbType, err = getUserSetVar(cmd, databaseTypeFlagName, databaseTypeEnvKey, isOptional)
 	if err != nil {
 		return nil, err
 	}
@@ -479,6 +584,63 @@ func getAutoExecuteRFC0593(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(autoExecuteRFC0593Str)
 }
 
+func getMetrics(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentMetricsFlagName, agentMetricsEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+func getSenderPolicy(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentSenderPolicyFlagName, agentSenderPolicyEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+func getContextRefreshInterval(cmd *cobra.Command) (time.Duration, error) {
+	v, err := getUserSetVar(cmd, agentContextRefreshIntervalFlagName, agentContextRefreshIntervalEnvKey, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if v == "" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("parse context refresh interval: %w", err)
+	}
+
+	return interval, nil
+}
+
+func getMultiTenant(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentMultiTenantFlagName, agentMultiTenantEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
 //nolint:funlen
 func createFlags(startCmd *cobra.Command) {
 	// agent host flag
@@ -487,6 +649,9 @@ func createFlags(startCmd *cobra.Command) {
 	// agent token flag
 	startCmd.Flags().StringP(agentTokenFlagName, agentTokenFlagShorthand, "", agentTokenFlagUsage)
 
+	// controller API type flag
+	startCmd.Flags().StringP(agentAPITypeFlagName, "", "", agentAPITypeFlagUsage)
+
 	// inbound host flag
 	startCmd.Flags().StringSliceP(agentInboundHostFlagName, agentInboundHostFlagShorthand, []string{},
 		agentInboundHostFlagUsage)
@@ -495,6 +660,9 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringSliceP(agentInboundHostExternalFlagName, agentInboundHostExternalFlagShorthand,
 		[]string{}, agentInboundHostExternalFlagUsage)
 
+	// framework configuration file flag
+	startCmd.Flags().StringP(agentConfigFileFlagName, "", "", agentConfigFileFlagUsage)
+
 	// db type
 	startCmd.Flags().StringP(databaseTypeFlagName, databaseTypeFlagShorthand, "", databaseTypeFlagUsage)
 
@@ -528,6 +696,9 @@ func createFlags(startCmd *cobra.Command) {
 	// remote JSON-LD context provider url flag
 	startCmd.Flags().StringSliceP(agentContextProviderFlagName, "", []string{}, agentContextProviderFlagUsage)
 
+	// remote JSON-LD context refresh interval flag
+	startCmd.Flags().StringP(agentContextRefreshIntervalFlagName, "", "", agentContextRefreshIntervalFlagUsage)
+
 	startCmd.Flags().StringP(agentAutoExecuteRFC0593FlagName, "", "", agentAutoExecuteRFC0593FlagUsage)
 
 	// tls cert file
@@ -546,6 +717,12 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentKeyAgreementTypeFlagName, "", "", agentKeyAgreementTypeUsage)
 
 	startCmd.Flags().StringSliceP(agentMediaTypeProfilesFlagName, "", []string{}, agentMediaTypeProfilesUsage)
+
+	startCmd.Flags().StringP(agentMetricsFlagName, "", "", agentMetricsFlagUsage)
+
+	startCmd.Flags().StringP(agentMultiTenantFlagName, "", "", agentMultiTenantFlagUsage)
+
+	startCmd.Flags().StringP(agentSenderPolicyFlagName, "", "", agentSenderPolicyFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -733,7 +910,7 @@ func authorizationMiddleware(token string) mux.MiddlewareFunc {
 	return middleware
 }
 
-func startAgent(parameters *agentParameters) error {
+func startAgent(parameters *agentParameters) error { //nolint:funlen
 	if parameters.host == "" {
 		return errMissingHost
 	}
@@ -741,16 +918,41 @@ func startAgent(parameters *agentParameters) error {
 	// set message handler
 	parameters.msgHandler = msghandler.NewRegistrar()
 
+	if parameters.metrics {
+		parameters.metricsProvider = prometheus.New()
+	}
+
 	ctx, err := createAriesAgent(parameters)
 	if err != nil {
 		return err
 	}
 
-	// get all HTTP REST API handlers available for controller API
-	handlers, err := controller.GetRESTHandlers(ctx, controller.WithWebhookURLs(parameters.webhookURLs...),
+	controllerOpts := []controller.Opt{
+		controller.WithWebhookURLs(parameters.webhookURLs...),
 		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
 		controller.WithMessageHandler(parameters.msgHandler),
-		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593))
+		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593),
+	}
+
+	switch parameters.apiType {
+	case "", apiTypeREST:
+	case apiTypeGRPC:
+		if parameters.multiTenant {
+			return errors.New("multi-tenant mode is only supported by the REST API")
+		}
+
+		err = serveGRPC(ctx, parameters, controllerOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to start aries agent grpc on port [%s], cause:  %w", parameters.host, err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid api type [%s]", parameters.apiType)
+	}
+
+	// get all HTTP REST API handlers available for controller API
+	handlers, err := controller.GetRESTHandlers(ctx, controllerOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
 			parameters.host, err)
@@ -766,6 +968,22 @@ func startAgent(parameters *agentParameters) error {
 		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
 	}
 
+	if parameters.metricsProvider != nil {
+		router.Handle(metricsPath, parameters.metricsProvider).Methods(http.MethodGet)
+	}
+
+	if parameters.multiTenant {
+		t, e := newTenants(parameters, ctx.StorageProvider())
+		if e != nil {
+			return fmt.Errorf("failed to start aries agent rest on port [%s], failed to start tenants : %w",
+				parameters.host, e)
+		}
+
+		defer t.close()
+
+		t.registerRoutes(router)
+	}
+
 	logger.Infof("Starting aries agent rest on host [%s]", parameters.host)
 	// start server on given port and serve using given handlers
 	handler := cors.New(
@@ -783,16 +1001,109 @@ func startAgent(parameters *agentParameters) error {
 	return nil
 }
 
-//nolint:funlen,gocyclo
-func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
-	var opts []aries.Option
+func serveGRPC(ctx *context.Provider, parameters *agentParameters, opts ...controller.Opt) error {
+	grpcAPI, err := controller.GetGRPCServer(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to get grpc service api: %w", err)
+	}
+
+	var serverOpts []grpc.ServerOption
 
-	storePro, err := createStoreProviders(parameters)
+	if parameters.tlsCertFile != "" && parameters.tlsKeyFile != "" {
+		creds, e := credentials.NewServerTLSFromFile(parameters.tlsCertFile, parameters.tlsKeyFile)
+		if e != nil {
+			return e
+		}
+
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+
+	if parameters.token != "" {
+		serverOpts = append(serverOpts,
+			grpc.UnaryInterceptor(func(ctx gocontext.Context, req interface{}, _ *grpc.UnaryServerInfo,
+				handler grpc.UnaryHandler) (interface{}, error) {
+				if !validateGRPCAuthorizationToken(ctx, parameters.token) {
+					return nil, status.Error(codes.Unauthenticated, "unauthorised")
+				}
+
+				return handler(ctx, req)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+				handler grpc.StreamHandler) error {
+				if !validateGRPCAuthorizationToken(ss.Context(), parameters.token) {
+					return status.Error(codes.Unauthenticated, "unauthorised")
+				}
+
+				return handler(srv, ss)
+			}))
+	}
+
+	listener, err := net.Listen("tcp", parameters.host)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(serverOpts...)
+	grpcAPI.Register(server)
+
+	logger.Infof("Starting aries agent grpc on host [%s]", parameters.host)
+
+	return server.Serve(listener)
+}
+
+func validateGRPCAuthorizationToken(ctx gocontext.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, actHdr := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(actHdr), []byte("Bearer "+token)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
+	framework, err := createAriesFramework(parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	opts = append(opts, aries.WithStoreProvider(storePro))
+	ctx, err := framework.Context()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get aries context : %w",
+			parameters.host, err)
+	}
+
+	return ctx, nil
+}
+
+//nolint:funlen,gocyclo
+func createAriesFramework(parameters *agentParameters) (*aries.Aries, error) {
+	var opts []aries.Option
+
+	if parameters.configFile != "" {
+		configOpts, err := getConfigFileOpts(parameters.configFile, parameters.tenantStorePrefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to config file opts : %w",
+				parameters.host, err)
+		}
+
+		opts = append(opts, configOpts...)
+	}
+
+	// the storage of the configuration file, if any, is overridden by the database type flag only
+	if parameters.configFile == "" || parameters.dbParam.dbType != "" {
+		storePro, err := createStoreProviders(parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, aries.WithStoreProvider(storePro))
+	}
 
 	if parameters.transportReturnRoute != "" {
 		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
@@ -828,6 +1139,14 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 		opts = append(opts, aries.WithJSONLDContextProviderURL(parameters.contextProviderURLs...))
 	}
 
+	if parameters.contextRefresh > 0 {
+		opts = append(opts, aries.WithJSONLDContextRefresh(
+			ld.WithRefreshInterval(parameters.contextRefresh),
+			ld.WithContextsChangedHandler(func(event ld.ContextsChangedEvent) {
+				logger.Infof("JSON-LD contexts of the remote provider %s changed: %v", event.Endpoint, event.URLs)
+			})))
+	}
+
 	if kt, ok := keyTypes[parameters.keyType]; ok {
 		opts = append(opts, aries.WithKeyType(kt))
 	}
@@ -840,19 +1159,62 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 		opts = append(opts, aries.WithMediaTypeProfiles(parameters.mediaTypeProfiles))
 	}
 
+	if parameters.metricsProvider != nil {
+		opts = append(opts, aries.WithMetricsProvider(parameters.metricsProvider))
+	}
+
+	if parameters.senderPolicy {
+		opts = append(opts, aries.WithSenderPolicy())
+	}
+
 	framework, err := aries.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
 			parameters.host, err)
 	}
 
-	ctx, err := framework.Context()
+	return framework, nil
+}
+
+// getConfigFileOpts returns the framework options of the configuration file. The storage prefix of a tenant is appended
+// to the prefix of the storage of the configuration, so that the tenants don't share the stores of the agent.
+func getConfigFileOpts(configFile, tenantStorePrefix string) ([]aries.Option, error) {
+	f, err := os.Open(filepath.Clean(configFile))
 	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get aries context : %w",
-			parameters.host, err)
+		return nil, fmt.Errorf("failed to open config file: %w", err)
 	}
 
-	return ctx, nil
+	defer func() {
+		if errClose := f.Close(); errClose != nil {
+			logger.Warnf("failed to close config file: %s", errClose)
+		}
+	}()
+
+	cfg, err := aries.ParseConfig(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantStorePrefix != "" {
+		// the keys of the tenants would be created in the same key store of the web KMS
+		if cfg.KMS.Type == aries.KMSWeb {
+			return nil, errors.New("the web KMS of the configuration file is not supported in multi-tenant mode")
+		}
+
+		cfg.Storage.Prefix += tenantStorePrefix
+	}
+
+	storageCreators := make(map[string]aries.StorageCreator, len(supportedStorageProviders))
+
+	for dbType, provider := range supportedStorageProviders {
+		provider := provider
+
+		storageCreators[dbType] = func(cfg *aries.StorageConfig) (storage.Provider, error) {
+			return provider(cfg.Prefix)
+		}
+	}
+
+	return cfg.Options(storageCreators)
 }
 
 func createStoreProviders(parameters *agentParameters) (storage.Provider, error) {
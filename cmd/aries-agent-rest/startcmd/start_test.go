@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,14 +15,20 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	grpcapi "github.com/hyperledger/aries-framework-go/pkg/controller/grpc"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
@@ -479,6 +486,49 @@ func TestStartCmdValidArgs(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestStartCmdWithConfigFile(t *testing.T) {
+	t.Run("config file without database type", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "aries.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(
+			"transports:\n  inbound:\n    - type: http\n      internal_addr: %s\n"+
+				"storage:\n  type: leveldb\n  prefix: %s\nprotocols:\n  replay_protection: true\n",
+			randomURL(), t.TempDir())), 0o600))
+
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentConfigFileFlagName,
+			configFile,
+			"--" + agentWebhookFlagName,
+			"",
+		})
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("invalid config file", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "aries.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("storage:\n  type: couchdb\n"), 0o600))
+
+		for _, file := range []string{configFile, filepath.Join(t.TempDir(), "missing.yaml")} {
+			parameters := &agentParameters{
+				server:       &mockServer{},
+				host:         randomURL(),
+				dbParam:      &dbParam{},
+				configFile:   file,
+				defaultLabel: "x",
+			}
+
+			err := startAgent(parameters)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "failed to config file opts")
+		}
+	})
+}
+
 func TestStartCmdValidArgsEnvVar(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -861,6 +911,101 @@ func TestStartAriesWithAuthorization(t *testing.T) {
 	})
 }
 
+func TestStartAriesWithGRPC(t *testing.T) {
+	const token = "ABCD"
+
+	testHostURL := randomURL()
+	testInboundHostURL := randomURL()
+
+	go func() {
+		parameters := &agentParameters{
+			server:               &mockServer{},
+			host:                 testHostURL,
+			apiType:              apiTypeGRPC,
+			token:                token,
+			inboundHostInternals: []string{httpProtocol + "@" + testInboundHostURL},
+			dbParam:              &dbParam{dbType: databaseTypeMemOption},
+			defaultLabel:         "x",
+		}
+
+		err := startAgent(parameters)
+		require.NoError(t, err)
+		require.FailNow(t, agentUnexpectedExitErrMsg+": "+err.Error())
+	}()
+
+	waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+	conn, err := grpc.Dial(testHostURL, grpc.WithInsecure())
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	client := grpcapi.NewClient(conn)
+	request := &grpcapi.Request{Command: "didexchange", Method: "QueryConnections", Payload: []byte(`{}`)}
+
+	t.Run("use good authorization token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+		res, err := client.Execute(ctx, request)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Payload)
+	})
+
+	t.Run("use bad authorization token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer BCDE")
+
+		_, err := client.Execute(ctx, request)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		stream, err := client.Subscribe(ctx, &grpcapi.SubscribeRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("use no authorization header", func(t *testing.T) {
+		_, err := client.Execute(context.Background(), request)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestStartAriesWithAPITypeFailure(t *testing.T) {
+	t.Run("invalid api type", func(t *testing.T) {
+		err := startAgent(&agentParameters{
+			server:  &mockServer{},
+			host:    ":0",
+			apiType: "soap",
+			dbParam: &dbParam{dbType: databaseTypeMemOption},
+		})
+		require.EqualError(t, err, "invalid api type [soap]")
+	})
+
+	t.Run("invalid grpc host", func(t *testing.T) {
+		err := startAgent(&agentParameters{
+			server:  &mockServer{},
+			host:    "invalid-host:port",
+			apiType: apiTypeGRPC,
+			dbParam: &dbParam{dbType: databaseTypeMemOption},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to start aries agent grpc on port [invalid-host:port]")
+	})
+
+	t.Run("invalid grpc tls files", func(t *testing.T) {
+		err := startAgent(&agentParameters{
+			server:      &mockServer{},
+			host:        ":0",
+			apiType:     apiTypeGRPC,
+			dbParam:     &dbParam{dbType: databaseTypeMemOption},
+			tlsCertFile: "invalid",
+			tlsKeyFile:  "invalid",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open invalid: no such file or directory")
+	})
+}
+
 func TestStoreProvider(t *testing.T) {
 	t.Run("test invalid database type", func(t *testing.T) {
 		_, err := createAriesAgent(&agentParameters{dbParam: &dbParam{dbType: "data1"}})
@@ -896,6 +1041,101 @@ func TestStartCmdInvalidAutoExecuteRFC0593Value(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid syntax")
 }
 
+func TestStartCmdInvalidMetricsValue(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentMetricsFlagName,
+		"INVALID",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid syntax")
+}
+
+func TestStartAgentWithMetrics(t *testing.T) {
+	err := startAgent(&agentParameters{
+		server:  &mockServer{},
+		host:    ":0",
+		dbParam: &dbParam{dbType: databaseTypeMemOption},
+		metrics: true,
+	})
+	require.NoError(t, err)
+}
+
+func TestStartCmdInvalidSenderPolicyValue(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentSenderPolicyFlagName,
+		"INVALID",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid syntax")
+}
+
+func TestStartAgentWithSenderPolicy(t *testing.T) {
+	err := startAgent(&agentParameters{
+		server:       &mockServer{},
+		host:         ":0",
+		dbParam:      &dbParam{dbType: databaseTypeMemOption},
+		senderPolicy: true,
+	})
+	require.NoError(t, err)
+}
+
+func TestStartCmdInvalidContextRefreshInterval(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentWebhookFlagName,
+		"",
+		"--" + agentContextRefreshIntervalFlagName,
+		"INVALID",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse context refresh interval")
+}
+
+func TestStartAgentWithContextRefresh(t *testing.T) {
+	err := startAgent(&agentParameters{
+		server:         &mockServer{},
+		host:           ":0",
+		dbParam:        &dbParam{dbType: databaseTypeMemOption},
+		contextRefresh: time.Hour,
+	})
+	require.NoError(t, err)
+}
+
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
 	if err := listenFor(host); err != nil {
 		t.Fatal(err)
@@ -0,0 +1,361 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	tenantsStoreName       = "tenants"
+	tenantRecordTag        = "tenant"
+	deletedTenantRecordTag = "deleted_tenant"
+
+	tenantIDPathVar = "tenantID"
+
+	// the REST API of a tenant is served under /tenants/{tenantID}.
+	tenantsPath = "/tenants"
+	// admin API of the tenants.
+	adminTenantsPath = "/admin/tenants"
+	adminTenantPath  = adminTenantsPath + "/{" + tenantIDPathVar + "}"
+)
+
+// error codes of the tenancy API.
+const (
+	invalidTenantRequestErrorCode = command.Code(iota + command.Tenancy)
+	createTenantErrorCode
+	deleteTenantErrorCode
+	tenantNotFoundErrorCode
+)
+
+var (
+	errTenantNotFound = errors.New("tenant not found")
+	errTenantExists   = errors.New("tenant already exists")
+	errTenantDeleted  = errors.New("tenant id used by a deleted tenant")
+
+	// tenant IDs are used in the storage prefixes and in the REST paths.
+	tenantIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`) //nolint:gochecknoglobals
+)
+
+// tenantRecord is the stored configuration of a tenant, also used as the create tenant request and response.
+type tenantRecord struct {
+	ID          string   `json:"id"`
+	Label       string   `json:"label,omitempty"`
+	WebhookURLs []string `json:"webhook_urls,omitempty"`
+}
+
+type tenantsResponse struct {
+	Tenants []*tenantRecord `json:"tenants"`
+}
+
+// tenant is an agent hosted by the controller.
+type tenant struct {
+	record    *tenantRecord
+	framework *aries.Aries
+	router    *mux.Router
+}
+
+// tenants hosts isolated agents in the controller process. Each tenant has its own framework instance with its own
+// storage, under a storage prefix derived from the tenant ID, and so its own KMS keystore. Tenants have no inbound
+// transport, they receive messages over the outbound transports (eg. through a mediator with return route).
+type tenants struct {
+	parameters *agentParameters
+	store      storage.Store
+	tenants    map[string]*tenant
+	mu         sync.RWMutex
+}
+
+// newTenants starts the agents of the tenants stored in the given storage provider.
+func newTenants(parameters *agentParameters, storageProvider storage.Provider) (*tenants, error) {
+	store, err := storageProvider.OpenStore(tenantsStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open tenants store: %w", err)
+	}
+
+	err = storageProvider.SetStoreConfig(tenantsStoreName,
+		storage.StoreConfiguration{TagNames: []string{tenantRecordTag, deletedTenantRecordTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set tenants store config: %w", err)
+	}
+
+	t := &tenants{
+		parameters: parameters,
+		store:      store,
+		tenants:    make(map[string]*tenant),
+	}
+
+	iter, err := store.Query(tenantRecordTag)
+	if err != nil {
+		return nil, fmt.Errorf("query tenants: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("query tenants: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get tenant: %w", err)
+		}
+
+		var record tenantRecord
+
+		if err = json.Unmarshal(value, &record); err != nil {
+			return nil, fmt.Errorf("unmarshal tenant: %w", err)
+		}
+
+		tn, err := t.start(&record)
+		if err != nil {
+			return nil, fmt.Errorf("start tenant [%s]: %w", record.ID, err)
+		}
+
+		t.tenants[record.ID] = tn
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("query tenants: %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+// registerRoutes registers the admin API and the tenant scoped routes.
+func (t *tenants) registerRoutes(router *mux.Router) {
+	router.HandleFunc(adminTenantsPath, t.createTenant).Methods(http.MethodPost)
+	router.HandleFunc(adminTenantsPath, t.getTenants).Methods(http.MethodGet)
+	router.HandleFunc(adminTenantPath, t.deleteTenant).Methods(http.MethodDelete)
+	router.PathPrefix(tenantsPath + "/{" + tenantIDPathVar + "}/").HandlerFunc(t.serveTenant)
+}
+
+// start creates the agent of the tenant and its REST API.
+func (t *tenants) start(record *tenantRecord) (*tenant, error) {
+	parameters := *t.parameters
+	parameters.tenantStorePrefix = "tenant_" + record.ID + "_"
+	parameters.dbParam = &dbParam{
+		dbType:  t.parameters.dbParam.dbType,
+		prefix:  t.parameters.dbParam.prefix + parameters.tenantStorePrefix,
+		timeout: t.parameters.dbParam.timeout,
+	}
+	parameters.inboundHostInternals = nil
+	parameters.inboundHostExternals = nil
+	parameters.metricsProvider = nil
+	parameters.msgHandler = msghandler.NewRegistrar()
+
+	framework, err := createAriesFramework(&parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := framework.Context()
+	if err != nil {
+		return nil, closeOnError(framework, fmt.Errorf("get aries context: %w", err))
+	}
+
+	handlers, err := controller.GetRESTHandlers(ctx,
+		controller.WithWebhookURLs(record.WebhookURLs...),
+		controller.WithDefaultLabel(record.Label), controller.WithAutoAccept(parameters.autoAccept),
+		controller.WithMessageHandler(parameters.msgHandler),
+		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593),
+	)
+	if err != nil {
+		return nil, closeOnError(framework, fmt.Errorf("get rest handlers: %w", err))
+	}
+
+	router := mux.NewRouter().PathPrefix(tenantsPath + "/" + record.ID).Subrouter()
+
+	for _, handler := range handlers {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	return &tenant{record: record, framework: framework, router: router}, nil
+}
+
+func (t *tenants) createTenant(rw http.ResponseWriter, req *http.Request) {
+	var record tenantRecord
+
+	if err := json.NewDecoder(req.Body).Decode(&record); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, invalidTenantRequestErrorCode, err)
+
+		return
+	}
+
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+
+	if !tenantIDPattern.MatchString(record.ID) {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, invalidTenantRequestErrorCode,
+			fmt.Errorf("invalid tenant id [%s]", record.ID))
+
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.tenants[record.ID]; ok {
+		rest.SendHTTPStatusError(rw, http.StatusConflict, createTenantErrorCode, errTenantExists)
+
+		return
+	}
+
+	// the storage of a deleted tenant isn't purged, its ID can't be reused
+	_, err := t.store.Get(record.ID)
+	if err == nil {
+		rest.SendHTTPStatusError(rw, http.StatusConflict, createTenantErrorCode, errTenantDeleted)
+
+		return
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, createTenantErrorCode,
+			fmt.Errorf("get tenant: %w", err))
+
+		return
+	}
+
+	tn, err := t.start(&record)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, createTenantErrorCode,
+			fmt.Errorf("start tenant: %w", err))
+
+		return
+	}
+
+	value, err := json.Marshal(&record)
+	if err == nil {
+		err = t.store.Put(record.ID, value, storage.Tag{Name: tenantRecordTag})
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, createTenantErrorCode,
+			closeOnError(tn.framework, fmt.Errorf("save tenant: %w", err)))
+
+		return
+	}
+
+	t.tenants[record.ID] = tn
+
+	logger.Infof("created tenant [%s]", record.ID)
+
+	writeJSON(rw, &record)
+}
+
+func (t *tenants) getTenants(rw http.ResponseWriter, _ *http.Request) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	response := tenantsResponse{Tenants: make([]*tenantRecord, 0, len(t.tenants))}
+
+	for _, tn := range t.tenants {
+		response.Tenants = append(response.Tenants, tn.record)
+	}
+
+	writeJSON(rw, &response)
+}
+
+// deleteTenant stops the agent of the tenant and marks its record deleted. The storage of the tenant isn't purged, the
+// record is kept so that the ID isn't reused by a tenant which would inherit the keys and records of the deleted one.
+func (t *tenants) deleteTenant(rw http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)[tenantIDPathVar]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tn, ok := t.tenants[id]
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, tenantNotFoundErrorCode, errTenantNotFound)
+
+		return
+	}
+
+	value, err := json.Marshal(tn.record)
+	if err == nil {
+		err = t.store.Put(id, value, storage.Tag{Name: deletedTenantRecordTag})
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, deleteTenantErrorCode,
+			fmt.Errorf("delete tenant: %w", err))
+
+		return
+	}
+
+	delete(t.tenants, id)
+
+	if err = tn.framework.Close(); err != nil {
+		logger.Warnf("close agent of tenant [%s]: %s", id, err)
+	}
+
+	logger.Infof("deleted tenant [%s]", id)
+
+	writeJSON(rw, &struct{}{})
+}
+
+// serveTenant serves the requests to the REST API of a tenant.
+func (t *tenants) serveTenant(rw http.ResponseWriter, req *http.Request) {
+	t.mu.RLock()
+	tn, ok := t.tenants[mux.Vars(req)[tenantIDPathVar]]
+	t.mu.RUnlock()
+
+	if !ok {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, tenantNotFoundErrorCode, errTenantNotFound)
+
+		return
+	}
+
+	tn.router.ServeHTTP(rw, req)
+}
+
+// close stops the agents of the tenants.
+func (t *tenants) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, tn := range t.tenants {
+		if err := tn.framework.Close(); err != nil {
+			logger.Warnf("close agent of tenant [%s]: %s", id, err)
+		}
+	}
+
+	t.tenants = make(map[string]*tenant)
+}
+
+func closeOnError(framework *aries.Aries, err error) error {
+	if e := framework.Close(); e != nil {
+		logger.Warnf("close aries framework: %s", e)
+	}
+
+	return err
+}
+
+func writeJSON(rw http.ResponseWriter, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		logger.Errorf("Unable to send response, %s", err)
+	}
+}
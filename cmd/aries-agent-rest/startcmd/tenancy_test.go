@@ -0,0 +1,246 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+)
+
+func TestTenants(t *testing.T) {
+	parameters := &agentParameters{
+		dbParam: &dbParam{dbType: databaseTypeMemOption},
+	}
+
+	storageProvider := mem.NewProvider()
+
+	tenants, err := newTenants(parameters, storageProvider)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	tenants.registerRoutes(router)
+
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var reqBody []byte
+
+		if body != nil {
+			reqBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(method, path, bytes.NewReader(reqBody)))
+
+		return rw
+	}
+
+	t.Run("create tenants", func(t *testing.T) {
+		rw := serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: "alice", Label: "Alice"})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var record tenantRecord
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &record))
+		require.Equal(t, tenantRecord{ID: "alice", Label: "Alice"}, record)
+
+		// the tenant ID is generated when not given
+		rw = serve(http.MethodPost, adminTenantsPath, &tenantRecord{Label: "Bob"})
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &record))
+		require.NotEmpty(t, record.ID)
+
+		rw = serve(http.MethodGet, adminTenantsPath, nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response tenantsResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Tenants, 2)
+	})
+
+	t.Run("tenant scoped routes", func(t *testing.T) {
+		rw := serve(http.MethodGet, tenantsPath+"/alice/connections", nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		rw = serve(http.MethodGet, tenantsPath+"/unknown/connections", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		require.Contains(t, rw.Body.String(), errTenantNotFound.Error())
+	})
+
+	t.Run("tenants are isolated", func(t *testing.T) {
+		rw := serve(http.MethodPost, tenantsPath+"/alice/kms/keyset", map[string]string{"keyType": "ED25519"})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var keySet struct {
+			KeyID string `json:"keyID"`
+		}
+
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &keySet))
+		require.NotEmpty(t, keySet.KeyID)
+
+		alice, ok := tenants.tenants["alice"]
+		require.True(t, ok)
+
+		aliceCtx, err := alice.framework.Context()
+		require.NoError(t, err)
+
+		_, err = aliceCtx.KMS().Get(keySet.KeyID)
+		require.NoError(t, err)
+
+		rw = serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: "carol"})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		carolCtx, err := tenants.tenants["carol"].framework.Context()
+		require.NoError(t, err)
+
+		_, err = carolCtx.KMS().Get(keySet.KeyID)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid create tenant requests", func(t *testing.T) {
+		rw := serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: "alice"})
+		require.Equal(t, http.StatusConflict, rw.Code)
+		require.Contains(t, rw.Body.String(), errTenantExists.Error())
+
+		rw = serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: "../alice"})
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "invalid tenant id [../alice]")
+
+		rw = serve(http.MethodPost, adminTenantsPath, "not a tenant")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("tenants are restarted", func(t *testing.T) {
+		restarted, err := newTenants(parameters, storageProvider)
+		require.NoError(t, err)
+
+		defer restarted.close()
+
+		require.Len(t, restarted.tenants, 3)
+		require.Equal(t, "Alice", restarted.tenants["alice"].record.Label)
+	})
+
+	t.Run("delete tenant", func(t *testing.T) {
+		rw := serve(http.MethodDelete, adminTenantsPath+"/alice", nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		rw = serve(http.MethodGet, tenantsPath+"/alice/connections", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = serve(http.MethodDelete, adminTenantsPath+"/alice", nil)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		// the ID of a deleted tenant isn't reused
+		rw = serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: "alice"})
+		require.Equal(t, http.StatusConflict, rw.Code)
+		require.Contains(t, rw.Body.String(), errTenantDeleted.Error())
+
+		restarted, err := newTenants(parameters, storageProvider)
+		require.NoError(t, err)
+
+		defer restarted.close()
+
+		require.Len(t, restarted.tenants, 2)
+	})
+
+	tenants.close()
+	require.Empty(t, tenants.tenants)
+}
+
+func TestTenantsWithConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "aries.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(
+		"storage:\n  type: leveldb\n  prefix: %s\n", t.TempDir())), 0o600))
+
+	parameters := &agentParameters{configFile: configFile, dbParam: &dbParam{}}
+
+	tenants, err := newTenants(parameters, mem.NewProvider())
+	require.NoError(t, err)
+
+	defer tenants.close()
+
+	router := mux.NewRouter()
+	tenants.registerRoutes(router)
+
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		reqBody, e := json.Marshal(body)
+		require.NoError(t, e)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(method, path, bytes.NewReader(reqBody)))
+
+		return rw
+	}
+
+	connections := func(tenantID string) int {
+		rw := serve(http.MethodGet, tenantsPath+"/"+tenantID+"/connections", nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response struct {
+			Results []json.RawMessage `json:"results"`
+		}
+
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+
+		return len(response.Results)
+	}
+
+	for _, id := range []string{"alice", "bob"} {
+		rw := serve(http.MethodPost, adminTenantsPath, &tenantRecord{ID: id})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	}
+
+	rw := serve(http.MethodPost, tenantsPath+"/alice/connections/receive-invitation", map[string]interface{}{
+		"@id":             "4e8650d9-6cc9-491e-b00e-7bf6cb5858fc",
+		"@type":           "https://didcomm.org/didexchange/1.0/invitation",
+		"label":           "Carol",
+		"recipientKeys":   []string{"did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"},
+		"serviceEndpoint": "http://carol.example.com",
+	})
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	require.Equal(t, 1, connections("alice"))
+	require.Equal(t, 0, connections("bob"))
+
+	t.Run("web KMS", func(t *testing.T) {
+		webKMSConfigFile := filepath.Join(t.TempDir(), "aries.yaml")
+		require.NoError(t, os.WriteFile(webKMSConfigFile,
+			[]byte("kms:\n  type: web\n  url: https://kms.example.com/kms/keystores/ks1\n"), 0o600))
+
+		_, err := getConfigFileOpts(webKMSConfigFile, "tenant_alice_")
+		require.EqualError(t, err, "the web KMS of the configuration file is not supported in multi-tenant mode")
+	})
+}
+
+func TestStartMultiTenantAgentWithGRPC(t *testing.T) {
+	err := startAgent(&agentParameters{
+		server:      &mockServer{},
+		host:        ":0",
+		apiType:     apiTypeGRPC,
+		dbParam:     &dbParam{dbType: databaseTypeMemOption},
+		multiTenant: true,
+	})
+	require.EqualError(t, err, "multi-tenant mode is only supported by the REST API")
+}
+
+func TestStartMultiTenantAgent(t *testing.T) {
+	err := startAgent(&agentParameters{
+		server:      &mockServer{},
+		host:        ":0",
+		dbParam:     &dbParam{dbType: databaseTypeMemOption},
+		multiTenant: true,
+	})
+	require.NoError(t, err)
+}
@@ -271,19 +271,36 @@ const pkgs = {
             path: "/issuecredential/send-offer",
             method: "POST",
         },
+        SendOfferV3: {
+            path: "/issuecredential/v3/send-offer",
+            method: "POST",
+        },
         SendProposal: {
             path: "/issuecredential/send-proposal",
             method: "POST",
         },
+        SendProposalV3: {
+            path: "/issuecredential/v3/send-proposal",
+            method: "POST",
+        },
         SendRequest: {
             path: "/issuecredential/send-request",
             method: "POST",
         },
+        SendRequestV3: {
+            path: "/issuecredential/v3/send-request",
+            method: "POST",
+        },
         AcceptProposal: {
             path: "/issuecredential/{piid}/accept-proposal",
             method: "POST",
             pathParam: "piid"
         },
+        AcceptProposalV3: {
+            path: "/issuecredential/v3/{piid}/accept-proposal",
+            method: "POST",
+            pathParam: "piid"
+        },
         DeclineProposal: {
             path: "/issuecredential/{piid}/decline-proposal",
             method: "POST",
@@ -309,11 +326,21 @@ const pkgs = {
             method: "POST",
             pathParam: "piid"
         },
+        NegotiateProposalV3: {
+            path: "/issuecredential/v3/{piid}/negotiate-proposal",
+            method: "POST",
+            pathParam: "piid"
+        },
         AcceptRequest: {
             path: "/issuecredential/{piid}/accept-request",
             method: "POST",
             pathParam: "piid"
         },
+        AcceptRequestV3: {
+            path: "/issuecredential/v3/{piid}/accept-request",
+            method: "POST",
+            pathParam: "piid"
+        },
         DeclineRequest: {
             path: "/issuecredential/{piid}/decline-request",
             method: "POST",
@@ -491,6 +518,14 @@ const pkgs = {
             path: "/vcwallet/present-proof",
             method: "POST",
         },
+        Export: {
+            path: "/vcwallet/export",
+            method: "POST",
+        },
+        Import: {
+            path: "/vcwallet/import",
+            method: "POST",
+        },
     },
     ld: {
         AddContexts: {
@@ -402,6 +402,15 @@ const Aries = function (opts) {
             sendOffer: async function (req) {
                 return invoke(aw, pending, this.pkgname, "SendOffer", req, "timeout while sending an offer")
             },
+            /**
+             * Sends an offer over DIDComm V2.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            sendOfferV3: async function (req) {
+                return invoke(aw, pending, this.pkgname, "SendOfferV3", req, "timeout while sending an offer")
+            },
             /**
              * Sends a proposal.
              *
@@ -411,6 +420,15 @@ const Aries = function (opts) {
             sendProposal: function (req) {
                 return invoke(aw, pending, this.pkgname, "SendProposal", req, "timeout while sending a proposal")
             },
+            /**
+             * Sends a proposal over DIDComm V2.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            sendProposalV3: function (req) {
+                return invoke(aw, pending, this.pkgname, "SendProposalV3", req, "timeout while sending a proposal")
+            },
             /**
              * Sends a request.
              *
@@ -420,6 +438,15 @@ const Aries = function (opts) {
             sendRequest: async function (req) {
                 return invoke(aw, pending, this.pkgname, "SendRequest", req, "timeout while sending a request")
             },
+            /**
+             * Sends a request over DIDComm V2.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            sendRequestV3: async function (req) {
+                return invoke(aw, pending, this.pkgname, "SendRequestV3", req, "timeout while sending a request")
+            },
             /**
              * Accepts a proposal.
              *
@@ -429,6 +456,15 @@ const Aries = function (opts) {
             acceptProposal: function (req) {
                 return invoke(aw, pending, this.pkgname, "AcceptProposal", req, "timeout while accepting a proposal")
             },
+            /**
+             * Accepts a DIDComm V2 proposal.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptProposalV3: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptProposalV3", req, "timeout while accepting a proposal")
+            },
             /**
              * Declines a proposal.
              *
@@ -474,6 +510,15 @@ const Aries = function (opts) {
             negotiateProposal: function (req) {
                 return invoke(aw, pending, this.pkgname, "NegotiateProposal", req, "timeout while negotiating proposal")
             },
+            /**
+             * Is used when the Holder wants to negotiate about a DIDComm V2 offer he received.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            negotiateProposalV3: function (req) {
+                return invoke(aw, pending, this.pkgname, "NegotiateProposalV3", req, "timeout while negotiating proposal")
+            },
             /**
              * Accepts a request.
              *
@@ -483,6 +528,15 @@ const Aries = function (opts) {
             acceptRequest: function (req) {
                 return invoke(aw, pending, this.pkgname, "AcceptRequest", req, "timeout while accepting a request")
             },
+            /**
+             * Accepts a DIDComm V2 request.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptRequestV3: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptRequestV3", req, "timeout while accepting a request")
+            },
             /**
              * Declines a request.
              *
@@ -0,0 +1,16 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/lock/redis
+
+go 1.16
+
+require (
+	github.com/alicebob/miniredis/v2 v2.16.0
+	github.com/go-redis/redis/v8 v8.11.4
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210820175050-dcc7a225178d
+	github.com/stretchr/testify v1.7.0
+)
+
+replace github.com/hyperledger/aries-framework-go/spi => ../../../spi
@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package redis provides a lock service storing the locks in Redis, so that the locks are shared by the instances of
+// an agent running against the same storage.
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+const (
+	// DefaultTTL is the default time after which a lock which wasn't released expires.
+	DefaultTTL = time.Minute
+	// DefaultRetryInterval is the default interval between the attempts to acquire a lock held by another instance.
+	DefaultRetryInterval = 50 * time.Millisecond
+	// DefaultKeyPrefix is the default prefix of the Redis keys of the locks.
+	DefaultKeyPrefix = "aries_lock_"
+
+	tokenLength = 16
+)
+
+// the lock key is deleted only if it still holds the token of the lock, as the lock may have expired and been acquired
+// by another instance.
+var unlockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+// Service is a lock service storing the locks in Redis. A lock is a Redis key set if it doesn't exist, with a random
+// token identifying the holder of the lock, and expiring after a TTL so that the locks held by a crashed instance are
+// eventually released. The TTL must be longer than the critical sections guarded by the locks.
+type Service struct {
+	client        redis.UniversalClient
+	ttl           time.Duration
+	retryInterval time.Duration
+	keyPrefix     string
+}
+
+// Option configures the lock service.
+type Option func(s *Service)
+
+// WithTTL sets the time after which a lock which wasn't released expires, DefaultTTL by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.ttl = ttl
+	}
+}
+
+// WithRetryInterval sets the interval between the attempts to acquire a lock held by another instance,
+// DefaultRetryInterval by default.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(s *Service) {
+		s.retryInterval = interval
+	}
+}
+
+// WithKeyPrefix sets the prefix of the Redis keys of the locks, DefaultKeyPrefix by default. The instances sharing the
+// locks must use the same prefix.
+func WithKeyPrefix(prefix string) Option {
+	return func(s *Service) {
+		s.keyPrefix = prefix
+	}
+}
+
+// New returns a new lock service storing the locks with the given Redis client.
+func New(client redis.UniversalClient, opts ...Option) *Service {
+	s := &Service{
+		client:        client,
+		ttl:           DefaultTTL,
+		retryInterval: DefaultRetryInterval,
+		keyPrefix:     DefaultKeyPrefix,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Lock acquires the lock with the given name, blocking until the lock is acquired or the context is done.
+func (s *Service) Lock(ctx context.Context, name string) (lock.Lock, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.keyPrefix + name
+
+	for {
+		ok, err := s.client.SetNX(ctx, key, token, s.ttl).Result()
+		if err != nil {
+			// the client fails with a network timeout when the deadline of the context expires during the command
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			return nil, fmt.Errorf("set lock key %s: %w", key, err)
+		}
+
+		if ok {
+			return &redisLock{client: s.client, key: key, token: token}, nil
+		}
+
+		timer := time.NewTimer(s.retryInterval)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		}
+	}
+}
+
+type redisLock struct {
+	client redis.UniversalClient
+	key    string
+	token  string
+}
+
+// Unlock releases the lock, returning lock.ErrNotHeld if the lock expired.
+func (l *redisLock) Unlock() error {
+	deleted, err := unlockScript.Run(context.Background(), l.client, []string{l.key}, l.token).Int()
+	if err != nil {
+		return fmt.Errorf("delete lock key %s: %w", l.key, err)
+	}
+
+	if deleted == 0 {
+		return lock.ErrNotHeld
+	}
+
+	return nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, tokenLength)
+
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generate lock token: %w", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/lock/redis"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+func TestService_Lock(t *testing.T) {
+	t.Run("test lock - success", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s := redis.New(client)
+
+		l, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+		require.True(t, server.Exists(redis.DefaultKeyPrefix+"name"))
+		require.Equal(t, redis.DefaultTTL, server.TTL(redis.DefaultKeyPrefix+"name"))
+
+		require.NoError(t, l.Unlock())
+		require.False(t, server.Exists(redis.DefaultKeyPrefix+"name"))
+
+		require.True(t, errors.Is(l.Unlock(), lock.ErrNotHeld))
+	})
+
+	t.Run("test lock - options", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s := redis.New(client, redis.WithKeyPrefix("agent_"), redis.WithTTL(time.Second),
+			redis.WithRetryInterval(time.Millisecond))
+
+		l, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+		require.True(t, server.Exists("agent_name"))
+		require.Equal(t, time.Second, server.TTL("agent_name"))
+
+		require.NoError(t, l.Unlock())
+	})
+
+	t.Run("test lock - shared by the services", func(t *testing.T) {
+		_, client := newRedis(t)
+
+		first := redis.New(client, redis.WithRetryInterval(time.Millisecond))
+		second := redis.New(client, redis.WithRetryInterval(time.Millisecond))
+
+		var (
+			mu      sync.Mutex
+			holders int
+			wg      sync.WaitGroup
+		)
+
+		for i := 0; i < 10; i++ {
+			s := first
+			if i%2 == 0 {
+				s = second
+			}
+
+			wg.Add(1)
+
+			go func(s *redis.Service) {
+				defer wg.Done()
+
+				l, err := s.Lock(context.Background(), "name")
+				require.NoError(t, err)
+
+				mu.Lock()
+				holders++
+				require.Equal(t, 1, holders)
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+
+				require.NoError(t, l.Unlock())
+			}(s)
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("test lock - context done", func(t *testing.T) {
+		_, client := newRedis(t)
+
+		s := redis.New(client, redis.WithRetryInterval(time.Millisecond))
+
+		l, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err = s.Lock(ctx, "name")
+		require.True(t, errors.Is(err, context.Canceled))
+
+		require.NoError(t, l.Unlock())
+	})
+
+	t.Run("test lock - expired", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s := redis.New(client, redis.WithTTL(time.Second))
+
+		expired, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+
+		server.FastForward(time.Second)
+
+		l, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+
+		// the expired lock must not release the lock acquired since
+		require.True(t, errors.Is(expired.Unlock(), lock.ErrNotHeld))
+		require.True(t, server.Exists(redis.DefaultKeyPrefix+"name"))
+
+		require.NoError(t, l.Unlock())
+	})
+
+	t.Run("test lock - redis error", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s := redis.New(client)
+
+		l, err := s.Lock(context.Background(), "name")
+		require.NoError(t, err)
+
+		server.Close()
+
+		_, err = s.Lock(context.Background(), "other")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "set lock key aries_lock_other")
+
+		err = l.Unlock()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "delete lock key aries_lock_name")
+	})
+}
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	t.Helper()
+
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	t.Cleanup(server.Close)
+
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr(), MaxRetries: -1})
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return server, client
+}
@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zap
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+const (
+	// ModuleKey is the key of the field holding the module of the logger.
+	ModuleKey = "module"
+
+	// callerSkip skips the framework logging wrappers when zap adds the caller to the log entries.
+	callerSkip = 3
+)
+
+// Provider is a logger provider routing the framework logs to a zap logger.
+type Provider struct {
+	logger *zap.Logger
+}
+
+// New returns a new logger provider writing to the given zap logger.
+func New(logger *zap.Logger) *Provider {
+	return &Provider{logger: logger.WithOptions(zap.AddCallerSkip(callerSkip))}
+}
+
+// GetLogger returns a logger of the given module.
+func (p *Provider) GetLogger(module string) log.Logger {
+	logger := p.logger.With(zap.String(ModuleKey, module))
+
+	return &Logger{logger: logger, sugar: logger.Sugar()}
+}
+
+// Logger is a structured logger writing to a zap logger.
+type Logger struct {
+	logger *zap.Logger
+	sugar  *zap.SugaredLogger
+}
+
+// Fatalf writes the message at fatal level followed by a call to os.Exit(1).
+func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.sugar.Fatalf(msg, args...)
+}
+
+// Panicf writes the message at panic level followed by a call to panic().
+func (l *Logger) Panicf(msg string, args ...interface{}) {
+	l.sugar.Panicf(msg, args...)
+}
+
+// Debugf writes the message at debug level.
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	l.sugar.Debugf(msg, args...)
+}
+
+// Infof writes the message at info level.
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.sugar.Infof(msg, args...)
+}
+
+// Warnf writes the message at warn level.
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.sugar.Warnf(msg, args...)
+}
+
+// Errorf writes the message at error level.
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	l.sugar.Errorf(msg, args...)
+}
+
+// Log writes the message and its fields at the zap level of the given level, CRITICAL is written at error level.
+func (l *Logger) Log(level log.Level, msg string, fields ...log.Field) {
+	entry := l.logger.Check(zapLevel(level), msg)
+	if entry == nil {
+		return
+	}
+
+	zapFields := make([]zap.Field, len(fields))
+
+	for i, field := range fields {
+		zapFields[i] = zap.Any(field.Key, field.Value)
+	}
+
+	entry.Write(zapFields...)
+}
+
+func zapLevel(level log.Level) zapcore.Level {
+	switch level {
+	case log.CRITICAL, log.ERROR:
+		return zapcore.ErrorLevel
+	case log.WARNING:
+		return zapcore.WarnLevel
+	case log.DEBUG:
+		return zapcore.DebugLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	zaplog "github.com/hyperledger/aries-framework-go/component/log/zap"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+const module = "sample-module"
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	logger := zaplog.New(zap.New(core)).GetLogger(module)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Warnf("warn %d", 3)
+	logger.Errorf("error %d", 4)
+	require.Panics(t, func() {
+		logger.Panicf("panic %d", 5)
+	})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 5)
+
+	expected := []struct {
+		level zapcore.Level
+		msg   string
+	}{
+		{zapcore.DebugLevel, "debug 1"},
+		{zapcore.InfoLevel, "info 2"},
+		{zapcore.WarnLevel, "warn 3"},
+		{zapcore.ErrorLevel, "error 4"},
+		{zapcore.PanicLevel, "panic 5"},
+	}
+
+	for i, entry := range entries {
+		require.Equal(t, expected[i].level, entry.Level)
+		require.Equal(t, expected[i].msg, entry.Message)
+		require.Equal(t, map[string]interface{}{zaplog.ModuleKey: module}, entry.ContextMap())
+	}
+}
+
+func TestLogger_Log(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	logger, ok := zaplog.New(zap.New(core)).GetLogger(module).(log.StructuredLogger)
+	require.True(t, ok)
+
+	logger.Log(log.INFO, "message sent", log.Field{Key: "connectionID", Value: "conn-1"},
+		log.Field{Key: "attempt", Value: 2})
+	logger.Log(log.WARNING, "retrying")
+	logger.Log(log.ERROR, "failed")
+	logger.Log(log.CRITICAL, "critical")
+	logger.Log(log.DEBUG, "filtered")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+
+	require.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	require.Equal(t, "message sent", entries[0].Message)
+	require.Equal(t, map[string]interface{}{
+		zaplog.ModuleKey: module,
+		"connectionID":   "conn-1",
+		"attempt":        int64(2),
+	}, entries[0].ContextMap())
+
+	require.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	require.Equal(t, zapcore.ErrorLevel, entries[2].Level)
+	require.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	require.Equal(t, "critical", entries[3].Message)
+}
@@ -0,0 +1,15 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/log/zerolog
+
+go 1.16
+
+require (
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210820175050-dcc7a225178d
+	github.com/rs/zerolog v1.26.1
+	github.com/stretchr/testify v1.7.0
+)
+
+replace github.com/hyperledger/aries-framework-go/spi => ../../../spi
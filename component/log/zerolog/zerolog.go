@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zerolog
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+// ModuleKey is the key of the field holding the module of the logger.
+const ModuleKey = "module"
+
+// Provider is a logger provider routing the framework logs to a zerolog logger.
+type Provider struct {
+	logger zerolog.Logger
+}
+
+// New returns a new logger provider writing to the given zerolog logger.
+func New(logger zerolog.Logger) *Provider {
+	return &Provider{logger: logger}
+}
+
+// GetLogger returns a logger of the given module.
+func (p *Provider) GetLogger(module string) log.Logger {
+	return &Logger{logger: p.logger.With().Str(ModuleKey, module).Logger()}
+}
+
+// Logger is a structured logger writing to a zerolog logger.
+type Logger struct {
+	logger zerolog.Logger
+}
+
+// Fatalf writes the message at fatal level followed by a call to os.Exit(1).
+func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.logger.Fatal().Msgf(msg, args...)
+}
+
+// Panicf writes the message at panic level followed by a call to panic().
+func (l *Logger) Panicf(msg string, args ...interface{}) {
+	l.logger.Panic().Msgf(msg, args...)
+}
+
+// Debugf writes the message at debug level.
+func (l *Logger) Debugf(msg string, args ...interface{}) {
+	l.logger.Debug().Msgf(msg, args...)
+}
+
+// Infof writes the message at info level.
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.logger.Info().Msgf(msg, args...)
+}
+
+// Warnf writes the message at warn level.
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.logger.Warn().Msgf(msg, args...)
+}
+
+// Errorf writes the message at error level.
+func (l *Logger) Errorf(msg string, args ...interface{}) {
+	l.logger.Error().Msgf(msg, args...)
+}
+
+// Log writes the message and its fields at the zerolog level of the given level, CRITICAL is written at error
+// level.
+func (l *Logger) Log(level log.Level, msg string, fields ...log.Field) {
+	event := l.logger.WithLevel(zerologLevel(level))
+	if event == nil {
+		return
+	}
+
+	for _, field := range fields {
+		event = event.Interface(field.Key, field.Value)
+	}
+
+	event.Msg(msg)
+}
+
+func zerologLevel(level log.Level) zerolog.Level {
+	switch level {
+	case log.CRITICAL, log.ERROR:
+		return zerolog.ErrorLevel
+	case log.WARNING:
+		return zerolog.WarnLevel
+	case log.DEBUG:
+		return zerolog.DebugLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
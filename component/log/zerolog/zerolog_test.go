@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zerolog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	zerologlog "github.com/hyperledger/aries-framework-go/component/log/zerolog"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+const module = "sample-module"
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := zerologlog.New(zerolog.New(&buf).Level(zerolog.DebugLevel)).GetLogger(module)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Warnf("warn %d", 3)
+	logger.Errorf("error %d", 4)
+	require.Panics(t, func() {
+		logger.Panicf("panic %d", 5)
+	})
+
+	entries := readEntries(t, &buf)
+	require.Len(t, entries, 5)
+
+	expected := [][2]string{
+		{"debug", "debug 1"},
+		{"info", "info 2"},
+		{"warn", "warn 3"},
+		{"error", "error 4"},
+		{"panic", "panic 5"},
+	}
+
+	for i, entry := range entries {
+		require.Equal(t, expected[i][0], entry[zerolog.LevelFieldName])
+		require.Equal(t, expected[i][1], entry[zerolog.MessageFieldName])
+		require.Equal(t, module, entry[zerologlog.ModuleKey])
+	}
+}
+
+func TestLogger_Log(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, ok := zerologlog.New(zerolog.New(&buf).Level(zerolog.InfoLevel)).GetLogger(module).(log.StructuredLogger)
+	require.True(t, ok)
+
+	logger.Log(log.INFO, "message sent", log.Field{Key: "connectionID", Value: "conn-1"},
+		log.Field{Key: "attempt", Value: 2})
+	logger.Log(log.WARNING, "retrying")
+	logger.Log(log.ERROR, "failed")
+	logger.Log(log.CRITICAL, "critical")
+	logger.Log(log.DEBUG, "filtered")
+
+	entries := readEntries(t, &buf)
+	require.Len(t, entries, 4)
+
+	require.Equal(t, map[string]interface{}{
+		zerolog.LevelFieldName:   "info",
+		zerolog.MessageFieldName: "message sent",
+		zerologlog.ModuleKey:     module,
+		"connectionID":           "conn-1",
+		"attempt":                float64(2),
+	}, entries[0])
+
+	require.Equal(t, "warn", entries[1][zerolog.LevelFieldName])
+	require.Equal(t, "error", entries[2][zerolog.LevelFieldName])
+	require.Equal(t, "error", entries[3][zerolog.LevelFieldName])
+	require.Equal(t, "critical", entries[3][zerolog.MessageFieldName])
+}
+
+func readEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -10,8 +10,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
 
@@ -141,6 +145,45 @@ func (p *Provider) GetOpenStores() []storage.Store {
 	return openStores
 }
 
+// StoreNames returns the names of all the stores created under the database path of this provider, open or not.
+func (p *Provider) StoreNames() ([]string, error) {
+	dir, prefix := filepath.Split(fmt.Sprintf(pathPattern, p.dbPath, ""))
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database directory: %w", err)
+	}
+
+	names := make(map[string]struct{})
+
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) && len(entry.Name()) > len(prefix) {
+			names[strings.TrimPrefix(entry.Name(), prefix)] = struct{}{}
+		}
+	}
+
+	p.lock.RLock()
+
+	for name := range p.dbs {
+		names[name] = struct{}{}
+	}
+
+	p.lock.RUnlock()
+
+	storeNames := make([]string, 0, len(names))
+
+	for name := range names {
+		storeNames = append(storeNames, name)
+	}
+
+	sort.Strings(storeNames)
+
+	return storeNames, nil
+}
+
 // Close closes all stores created under this store provider.
 func (p *Provider) Close() error {
 	p.lock.RLock()
@@ -165,6 +208,29 @@ func (p *Provider) Close() error {
 	return nil
 }
 
+// DeleteExpired deletes the expired data of all the stores currently open in the Provider.
+// The expired data is never returned by the stores, this frees the disk space it uses.
+func (p *Provider) DeleteExpired() error {
+	p.lock.RLock()
+
+	openStores := make([]*store, 0, len(p.dbs))
+
+	for _, openStore := range p.dbs {
+		openStores = append(openStores, openStore)
+	}
+
+	p.lock.RUnlock()
+
+	for _, openStore := range openStores {
+		err := openStore.DeleteExpired()
+		if err != nil {
+			return fmt.Errorf(`failed to delete expired data of store "%s": %w`, openStore.name, err)
+		}
+	}
+
+	return nil
+}
+
 // getLeveldbStore finds level db store with given name
 // returns nil if not found.
 func (p *Provider) getLeveldbStore(name string) *store {
@@ -253,6 +319,15 @@ func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
 	return s.db.Put([]byte(key), entryBytes, nil)
 }
 
+// PutWithTTL stores the key and the record, the record expiring once the given TTL has elapsed.
+func (s *store) PutWithTTL(key string, value []byte, ttl time.Duration, tags ...storage.Tag) error {
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	return s.Put(key, value, append(tags, storage.ExpiryTag(time.Now().Add(ttl)))...)
+}
+
 // Get fetches the record based on key.
 func (s *store) Get(k string) ([]byte, error) {
 	retrievedDBEntry, err := s.getDBEntry(k)
@@ -383,6 +458,78 @@ func (s *store) Batch(operations []storage.Operation) error {
 	return nil
 }
 
+// DeleteExpired deletes the expired records. The records with an expiry are found with the tag map.
+func (s *store) DeleteExpired() error {
+	tagMap, err := s.getTagMap(false)
+	if err != nil {
+		// If there's no tag map, then no record has ever had an expiry.
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get tag map: %w", err)
+	}
+
+	now := time.Now()
+
+	for key := range tagMap[storage.ExpiryTagName] {
+		entry, errGet := s.getStoredDBEntry(key)
+		if errors.Is(errGet, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if errGet != nil {
+			return fmt.Errorf("failed to get DB entry: %w", errGet)
+		}
+
+		if !storage.IsExpired(entry.Tags, now) {
+			continue
+		}
+
+		err = s.Delete(key)
+		if err != nil {
+			return fmt.Errorf("failed to delete expired record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Iterate returns an iterator over all the unexpired records of the store, sorted by key.
+func (s *store) Iterate() (storage.Iterator, error) {
+	dbIterator := s.db.NewIterator(nil, nil)
+	defer dbIterator.Release()
+
+	var keys []string
+
+	now := time.Now()
+
+	for dbIterator.Next() {
+		key := string(dbIterator.Key())
+		if key == tagMapKey || key == storeConfigKey {
+			continue
+		}
+
+		var entry dbEntry
+
+		err := json.Unmarshal(dbIterator.Value(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal DB entry: %w", err)
+		}
+
+		if !storage.IsExpired(entry.Tags, now) {
+			keys = append(keys, key)
+		}
+	}
+
+	err := dbIterator.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over DB entries: %w", err)
+	}
+
+	return &iterator{keys: keys, store: s}, nil
+}
+
 // This store doesn't queue values, so there's never anything to flush.
 func (s *store) Flush() error {
 	return nil
@@ -431,7 +578,21 @@ func (s *store) updateTagMap(key string, tags []storage.Tag) error {
 	return nil
 }
 
+// getDBEntry gets the entry stored under the key, expired entries not being found.
 func (s *store) getDBEntry(key string) (dbEntry, error) {
+	entry, err := s.getStoredDBEntry(key)
+	if err != nil {
+		return dbEntry{}, err
+	}
+
+	if storage.IsExpired(entry.Tags, time.Now()) {
+		return dbEntry{}, storage.ErrDataNotFound
+	}
+
+	return entry, nil
+}
+
+func (s *store) getStoredDBEntry(key string) (dbEntry, error) {
 	if key == "" {
 		return dbEntry{}, errors.New("key cannot be blank")
 	}
@@ -498,16 +659,46 @@ func (s *store) getDatabaseKeysMatchingQuery(expressionTagName, expressionTagVal
 		return nil, fmt.Errorf("failed to get tag map: %w", err)
 	}
 
+	var matchingDatabaseKeys []string
+
 	if expressionTagValue == "" {
-		return getDatabaseKeysMatchingTagName(tagMap, expressionTagName), nil
+		matchingDatabaseKeys = getDatabaseKeysMatchingTagName(tagMap, expressionTagName)
+	} else {
+		matchingDatabaseKeys, err = s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName,
+			expressionTagValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+		}
 	}
 
-	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+	return s.removeExpiredKeys(tagMap, matchingDatabaseKeys)
+}
+
+// removeExpiredKeys removes the keys of the expired records, only the records with an expiry tag being checked.
+func (s *store) removeExpiredKeys(tagMap tagMapping, keys []string) ([]string, error) {
+	expiringKeys := tagMap[storage.ExpiryTagName]
+	if len(expiringKeys) == 0 {
+		return keys, nil
 	}
 
-	return matchingDatabaseKeys, nil
+	var unexpiredKeys []string
+
+	for _, key := range keys {
+		if _, ok := expiringKeys[key]; ok {
+			_, err := s.getDBEntry(key)
+			if errors.Is(err, storage.ErrDataNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to get DB entry: %w", err)
+			}
+		}
+
+		unexpiredKeys = append(unexpiredKeys, key)
+	}
+
+	return unexpiredKeys, nil
 }
 
 func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
@@ -518,6 +709,11 @@ func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
 		if tagName == expressionTagName {
 			for databaseKey := range databaseKeysSet {
 				tags, err := s.GetTags(databaseKey)
+				if errors.Is(err, storage.ErrDataNotFound) {
+					// The record has expired.
+					continue
+				}
+
 				if err != nil {
 					return nil, fmt.Errorf("failed to get tags: %w", err)
 				}
@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -134,6 +136,142 @@ func TestStore_Query(t *testing.T) {
 	})
 }
 
+func TestStore_Expiry(t *testing.T) {
+	path := setupLevelDB(t)
+
+	provider := leveldb.NewProvider(path)
+
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	require.Implements(t, (*storage.ExpiringStore)(nil), store)
+
+	err = storage.PutWithTTL(store, "live", []byte("value"), time.Hour, storage.Tag{Name: "TagName", Value: "TagValue"})
+	require.NoError(t, err)
+
+	err = store.Put("expired", []byte("value"), storage.Tag{Name: "TagName", Value: "TagValue"},
+		storage.ExpiryTag(time.Now().Add(-time.Second)))
+	require.NoError(t, err)
+
+	err = storage.PutWithTTL(store, "invalid", []byte("value"), 0)
+	require.EqualError(t, err, "ttl must be positive")
+
+	checkExpiry := func() {
+		value, err := store.Get("live")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		_, err = store.Get("expired")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.GetTags("expired")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		values, err := store.GetBulk("live", "expired")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("value"), nil}, values)
+
+		for _, expression := range []string{"TagName", "TagName:TagValue"} {
+			iterator, err := store.Query(expression)
+			require.NoError(t, err)
+
+			count, err := iterator.TotalItems()
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+
+			more, err := iterator.Next()
+			require.NoError(t, err)
+			require.True(t, more)
+
+			key, err := iterator.Key()
+			require.NoError(t, err)
+			require.Equal(t, "live", key)
+		}
+	}
+
+	checkExpiry()
+
+	require.NoError(t, provider.DeleteExpired())
+
+	checkExpiry()
+}
+
+func TestProvider_StoreNames(t *testing.T) {
+	path := filepath.Join(setupLevelDB(t), "db")
+
+	provider := leveldb.NewProvider(path)
+
+	require.Implements(t, (*storage.StoreLister)(nil), provider)
+
+	names, err := provider.StoreNames()
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	store, err := provider.OpenStore("StoreA")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key", []byte("value")))
+	require.NoError(t, provider.Close())
+
+	_, err = provider.OpenStore("storeb")
+	require.NoError(t, err)
+
+	// stores closed in a previous run are found on disk
+	names, err = leveldb.NewProvider(path).StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"storea", "storeb"}, names)
+
+	_, err = leveldb.NewProvider(filepath.Join(path, "missing", "db")).StoreNames()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read database directory")
+}
+
+func TestStore_Iterate(t *testing.T) {
+	path := setupLevelDB(t)
+
+	provider := leveldb.NewProvider(path)
+
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	require.Implements(t, (*storage.IterableStore)(nil), store)
+
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, store.Put("key1", []byte("value1"), storage.Tag{Name: "TagName", Value: "TagValue"}))
+	require.NoError(t, store.Put("expired", []byte("value"), storage.ExpiryTag(time.Now().Add(-time.Second))))
+
+	iterator, err := store.(storage.IterableStore).Iterate()
+	require.NoError(t, err)
+
+	total, err := iterator.TotalItems()
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+
+	var keys []string
+
+	for {
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		if !more {
+			break
+		}
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+
+	// the tag map isn't iterated
+	require.Equal(t, []string{"key1", "key2"}, keys)
+
+	require.NoError(t, store.Close())
+
+	_, err = store.(storage.IterableStore).Iterate()
+	require.Error(t, err)
+}
+
 func TestStore_Flush(t *testing.T) {
 	path := setupLevelDB(t)
 
@@ -0,0 +1,361 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package redis provides a storage provider caching the records in Redis with a time to live, to be used as the cache
+// provider of the cachedstore.CachedProvider. Unlike the in-memory cache, the cache is shared by the instances of an
+// agent.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// DefaultKeyPrefix is the default prefix of the Redis keys of the cached records.
+const DefaultKeyPrefix = "aries_cache_"
+
+var (
+	errEmptyKey = errors.New("key cannot be empty")
+
+	// ErrQueryNotSupported is returned by the stores, which only hold the records cached recently.
+	ErrQueryNotSupported = errors.New("querying is not supported by the redis cache")
+)
+
+// Option configures the Provider.
+type Option func(p *Provider)
+
+// WithTTL sets the time to live of the cached records, no expiry by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(p *Provider) {
+		p.ttl = ttl
+	}
+}
+
+// WithStoreTTL sets the time to live of the records cached in the store with the given name, overriding WithTTL (eg.
+// a short time to live for the resolved DID documents and a long one for the JSON-LD contexts).
+func WithStoreTTL(name string, ttl time.Duration) Option {
+	return func(p *Provider) {
+		p.storeTTLs[strings.ToLower(name)] = ttl
+	}
+}
+
+// WithKeyPrefix sets the prefix of the Redis keys of the cached records, DefaultKeyPrefix by default. The instances
+// sharing the cache must use the same prefix.
+func WithKeyPrefix(prefix string) Option {
+	return func(p *Provider) {
+		p.keyPrefix = prefix
+	}
+}
+
+// Provider is a spi.Provider caching the records in Redis. The records expire after their time to live. The tags are
+// cached along with the values but the stores can't be queried, as the cachedstore.CachedProvider queries the main
+// provider.
+type Provider struct {
+	client     redis.UniversalClient
+	ttl        time.Duration
+	storeTTLs  map[string]time.Duration
+	keyPrefix  string
+	configs    map[string]spi.StoreConfiguration
+	openStores map[string]*store
+	lock       sync.RWMutex
+}
+
+// NewProvider returns a new Provider caching the records with the given Redis client.
+func NewProvider(client redis.UniversalClient, opts ...Option) *Provider {
+	p := &Provider{
+		client:     client,
+		storeTTLs:  make(map[string]time.Duration),
+		keyPrefix:  DefaultKeyPrefix,
+		configs:    make(map[string]spi.StoreConfiguration),
+		openStores: make(map[string]*store),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens a store with the given name and returns a handle.
+// Store names are not case-sensitive.
+func (p *Provider) OpenStore(name string) (spi.Store, error) {
+	if name == "" {
+		return nil, fmt.Errorf("store name cannot be empty")
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if s, ok := p.openStores[name]; ok {
+		return s, nil
+	}
+
+	ttl, ok := p.storeTTLs[name]
+	if !ok {
+		ttl = p.ttl
+	}
+
+	s := &store{
+		name:      name,
+		keyPrefix: p.keyPrefix + name + ":",
+		ttl:       ttl,
+		client:    p.client,
+		close:     p.removeStore,
+	}
+
+	p.openStores[name] = s
+
+	return s, nil
+}
+
+// SetStoreConfig sets the configuration on a store. The configurations are kept in memory, the main provider holding
+// the configurations of the cached stores.
+func (p *Provider) SetStoreConfig(name string, config spi.StoreConfiguration) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.configs[strings.ToLower(name)] = config
+
+	return nil
+}
+
+// GetStoreConfig gets the current store configuration.
+func (p *Provider) GetStoreConfig(name string) (spi.StoreConfiguration, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	config, ok := p.configs[strings.ToLower(name)]
+	if !ok {
+		return spi.StoreConfiguration{}, spi.ErrStoreNotFound
+	}
+
+	return config, nil
+}
+
+// GetOpenStores returns all currently open stores.
+func (p *Provider) GetOpenStores() []spi.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]spi.Store, 0, len(p.openStores))
+
+	for _, s := range p.openStores {
+		openStores = append(openStores, s)
+	}
+
+	return openStores
+}
+
+// Close closes all stores created under this store provider. The Redis client isn't closed, and the cached records are
+// kept until they expire.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.openStores = make(map[string]*store)
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.openStores, name)
+}
+
+// entry is the value of the Redis key of a record.
+type entry struct {
+	Value []byte    `json:"value"`
+	Tags  []spi.Tag `json:"tags,omitempty"`
+}
+
+type store struct {
+	name      string
+	keyPrefix string
+	ttl       time.Duration
+	client    redis.UniversalClient
+	close     func(name string)
+}
+
+func (s *store) Put(key string, value []byte, tags ...spi.Tag) error {
+	if key == "" {
+		return errEmptyKey
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	data, err := json.Marshal(&entry{Value: value, Tags: tags})
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+
+	if err = s.client.Set(context.Background(), s.keyPrefix+key, data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("set key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *store) Get(key string) ([]byte, error) {
+	e, err := s.getEntry(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Value, nil
+}
+
+func (s *store) GetTags(key string) ([]spi.Tag, error) {
+	e, err := s.getEntry(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Tags, nil
+}
+
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	redisKeys := make([]string, len(keys))
+
+	for i, key := range keys {
+		if key == "" {
+			return nil, errEmptyKey
+		}
+
+		redisKeys[i] = s.keyPrefix + key
+	}
+
+	results, err := s.client.MGet(context.Background(), redisKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get keys: %w", err)
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, result := range results {
+		data, ok := result.(string)
+		if !ok { // missing key
+			continue
+		}
+
+		e, err := parseEntry([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = e.Value
+	}
+
+	return values, nil
+}
+
+func (s *store) Query(string, ...spi.QueryOption) (spi.Iterator, error) {
+	return nil, ErrQueryNotSupported
+}
+
+func (s *store) Delete(key string) error {
+	if key == "" {
+		return errEmptyKey
+	}
+
+	if err := s.client.Del(context.Background(), s.keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("delete key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Batch runs the operations in a Redis transaction.
+func (s *store) Batch(operations []spi.Operation) error {
+	if len(operations) == 0 {
+		return errors.New("batch requires at least one operation")
+	}
+
+	ctx := context.Background()
+	pipe := s.client.TxPipeline()
+
+	for _, operation := range operations {
+		if operation.Key == "" {
+			return errEmptyKey
+		}
+
+		if operation.Value == nil {
+			pipe.Del(ctx, s.keyPrefix+operation.Key)
+
+			continue
+		}
+
+		data, err := json.Marshal(&entry{Value: operation.Value, Tags: operation.Tags})
+		if err != nil {
+			return fmt.Errorf("marshal entry: %w", err)
+		}
+
+		pipe.Set(ctx, s.keyPrefix+operation.Key, data, s.ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("run batch operations: %w", err)
+	}
+
+	return nil
+}
+
+// Flush doesn't do anything since the operations are sent to Redis immediately.
+func (s *store) Flush() error {
+	return nil
+}
+
+func (s *store) Close() error {
+	s.close(s.name)
+
+	return nil
+}
+
+func (s *store) getEntry(key string) (*entry, error) {
+	if key == "" {
+		return nil, errEmptyKey
+	}
+
+	data, err := s.client.Get(context.Background(), s.keyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, spi.ErrDataNotFound
+		}
+
+		return nil, fmt.Errorf("get key %s: %w", key, err)
+	}
+
+	return parseEntry(data)
+}
+
+func parseEntry(data []byte) (*entry, error) {
+	e := &entry{}
+
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, fmt.Errorf("unmarshal entry: %w", err)
+	}
+
+	return e, nil
+}
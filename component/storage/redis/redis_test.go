@@ -0,0 +1,225 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/redis"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestProvider_OpenStore(t *testing.T) {
+	_, client := newRedis(t)
+
+	p := redis.NewProvider(client)
+
+	_, err := p.OpenStore("")
+	require.EqualError(t, err, "store name cannot be empty")
+
+	s, err := p.OpenStore("LDContexts")
+	require.NoError(t, err)
+
+	same, err := p.OpenStore("ldcontexts")
+	require.NoError(t, err)
+	require.Equal(t, s, same)
+	require.Len(t, p.GetOpenStores(), 1)
+
+	require.NoError(t, s.Close())
+	require.Empty(t, p.GetOpenStores())
+
+	_, err = p.OpenStore("ldcontexts")
+	require.NoError(t, err)
+
+	require.NoError(t, p.Close())
+	require.Empty(t, p.GetOpenStores())
+}
+
+func TestProvider_StoreConfig(t *testing.T) {
+	_, client := newRedis(t)
+
+	p := redis.NewProvider(client)
+
+	_, err := p.GetStoreConfig("ldcontexts")
+	require.True(t, errors.Is(err, spi.ErrStoreNotFound))
+
+	config := spi.StoreConfiguration{TagNames: []string{"record"}}
+
+	require.NoError(t, p.SetStoreConfig("LDContexts", config))
+
+	result, err := p.GetStoreConfig("ldcontexts")
+	require.NoError(t, err)
+	require.Equal(t, config, result)
+}
+
+func TestStore(t *testing.T) {
+	t.Run("test put and get", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s, err := redis.NewProvider(client, redis.WithTTL(time.Hour)).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Put("https://w3id.org/security/v2", []byte("context"), spi.Tag{Name: "record"}))
+		require.Equal(t, time.Hour, server.TTL(redis.DefaultKeyPrefix+"ldcontexts:https://w3id.org/security/v2"))
+
+		value, err := s.Get("https://w3id.org/security/v2")
+		require.NoError(t, err)
+		require.Equal(t, []byte("context"), value)
+
+		tags, err := s.GetTags("https://w3id.org/security/v2")
+		require.NoError(t, err)
+		require.Equal(t, []spi.Tag{{Name: "record"}}, tags)
+
+		_, err = s.Get("unknown")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		_, err = s.GetTags("unknown")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		// the record expires
+		server.FastForward(time.Hour)
+
+		_, err = s.Get("https://w3id.org/security/v2")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+	})
+
+	t.Run("test store TTL and key prefix", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		p := redis.NewProvider(client, redis.WithKeyPrefix("agent_"), redis.WithTTL(time.Hour),
+			redis.WithStoreTTL("DIDResolutions", time.Minute))
+
+		s, err := p.OpenStore("didresolutions")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Put("did:example:123", []byte("doc")))
+		require.Equal(t, time.Minute, server.TTL("agent_didresolutions:did:example:123"))
+	})
+
+	t.Run("test no expiry", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s, err := redis.NewProvider(client).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Put("key", []byte("value")))
+		require.True(t, server.Exists(redis.DefaultKeyPrefix+"ldcontexts:key"))
+		require.Zero(t, server.TTL(redis.DefaultKeyPrefix+"ldcontexts:key"))
+	})
+
+	t.Run("test get bulk", func(t *testing.T) {
+		_, client := newRedis(t)
+
+		s, err := redis.NewProvider(client).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Put("key1", []byte("value1")))
+
+		values, err := s.GetBulk("key1", "unknown")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("value1"), nil}, values)
+
+		_, err = s.GetBulk()
+		require.Error(t, err)
+
+		_, err = s.GetBulk("")
+		require.EqualError(t, err, "key cannot be empty")
+	})
+
+	t.Run("test batch and delete", func(t *testing.T) {
+		_, client := newRedis(t)
+
+		s, err := redis.NewProvider(client).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Put("key1", []byte("value1")))
+
+		require.NoError(t, s.Batch([]spi.Operation{
+			{Key: "key2", Value: []byte("value2"), Tags: []spi.Tag{{Name: "record"}}},
+			{Key: "key1"},
+		}))
+
+		_, err = s.Get("key1")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		tags, err := s.GetTags("key2")
+		require.NoError(t, err)
+		require.Equal(t, []spi.Tag{{Name: "record"}}, tags)
+
+		require.NoError(t, s.Delete("key2"))
+
+		_, err = s.Get("key2")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		require.Error(t, s.Batch(nil))
+		require.EqualError(t, s.Batch([]spi.Operation{{Key: ""}}), "key cannot be empty")
+		require.NoError(t, s.Flush())
+	})
+
+	t.Run("test invalid arguments", func(t *testing.T) {
+		_, client := newRedis(t)
+
+		s, err := redis.NewProvider(client).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		require.EqualError(t, s.Put("", []byte("value")), "key cannot be empty")
+		require.EqualError(t, s.Put("key", nil), "value cannot be nil")
+		require.EqualError(t, s.Delete(""), "key cannot be empty")
+
+		_, err = s.Get("")
+		require.EqualError(t, err, "key cannot be empty")
+
+		_, err = s.Query("record")
+		require.True(t, errors.Is(err, redis.ErrQueryNotSupported))
+	})
+
+	t.Run("test redis errors", func(t *testing.T) {
+		server, client := newRedis(t)
+
+		s, err := redis.NewProvider(client).OpenStore("ldcontexts")
+		require.NoError(t, err)
+
+		server.SetError("out of memory")
+
+		require.EqualError(t, s.Put("key", []byte("value")), "set key key: out of memory")
+
+		_, err = s.Get("key")
+		require.EqualError(t, err, "get key key: out of memory")
+
+		_, err = s.GetBulk("key")
+		require.EqualError(t, err, "get keys: out of memory")
+
+		require.EqualError(t, s.Delete("key"), "delete key key: out of memory")
+
+		err = s.Batch([]spi.Operation{{Key: "key", Value: []byte("value")}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "run batch operations")
+	})
+}
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	t.Helper()
+
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	t.Cleanup(server.Close)
+
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr(), MaxRetries: -1})
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return server, client
+}
@@ -15,3 +15,5 @@ require (
 	github.com/stretchr/testify v1.7.0
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
+
+replace github.com/hyperledger/aries-framework-go/spi => ../../spi
@@ -9,8 +9,10 @@ package mem
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -34,6 +36,8 @@ var (
 type Provider struct {
 	dbs  map[string]*memStore
 	lock sync.RWMutex
+	// persistence is set by NewPersistentProvider.
+	persistence *persistence
 }
 
 type closer func(storeName string)
@@ -57,15 +61,28 @@ func (p *Provider) OpenStore(name string) (spi.Store, error) {
 
 	store := p.dbs[storeName]
 	if store == nil {
-		newStore := &memStore{name: storeName, db: make(map[string]dbEntry), close: p.removeStore}
-		p.dbs[storeName] = newStore
+		if err := p.persistence.append(&walRecord{Op: walOpen, Store: storeName}); err != nil {
+			return nil, err
+		}
 
-		return newStore, nil
+		return p.newStore(storeName), nil
 	}
 
 	return store, nil
 }
 
+func (p *Provider) newStore(storeName string) *memStore {
+	newStore := &memStore{
+		name:        storeName,
+		db:          make(map[string]dbEntry),
+		close:       p.removeStore,
+		persistence: p.persistence,
+	}
+	p.dbs[storeName] = newStore
+
+	return newStore
+}
+
 // SetStoreConfig sets the configuration on a store.
 // The store must be created prior to calling this method.
 // If the store cannot be found, then an error wrapping spi.ErrStoreNotFound will be returned.
@@ -86,6 +103,10 @@ func (p *Provider) SetStoreConfig(name string, config spi.StoreConfiguration) er
 		return spi.ErrStoreNotFound
 	}
 
+	if err := p.persistence.append(&walRecord{Op: walConfig, Store: storeName, Config: &config}); err != nil {
+		return err
+	}
+
 	store.config = config
 
 	return nil
@@ -122,13 +143,59 @@ func (p *Provider) GetOpenStores() []spi.Store {
 	return openStores
 }
 
+// StoreNames returns the names of all the stores of this provider.
+func (p *Provider) StoreNames() ([]string, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	names := make([]string, 0, len(p.dbs))
+
+	for name := range p.dbs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
 // Close closes all stores created under this store provider.
+// The data of a persistent provider is snapshotted and kept on disk, to be loaded by the next persistent provider.
 func (p *Provider) Close() error {
+	var err error
+
+	if p.persistence != nil {
+		err = p.persistence.close(p)
+	}
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
 	p.dbs = make(map[string]*memStore)
 
+	return err
+}
+
+// DeleteExpired deletes the expired data of all the stores currently open in the Provider.
+// The expired data is never returned by the stores, this frees the memory it uses. A persistent provider deletes the
+// expired data before each periodic snapshot.
+func (p *Provider) DeleteExpired() error {
+	p.lock.RLock()
+
+	openStores := make([]*memStore, 0, len(p.dbs))
+
+	for _, store := range p.dbs {
+		openStores = append(openStores, store)
+	}
+
+	p.lock.RUnlock()
+
+	for _, store := range openStores {
+		if err := store.DeleteExpired(); err != nil {
+			return fmt.Errorf(`failed to delete expired data of store "%s": %w`, store.name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -138,6 +205,9 @@ func (p *Provider) removeStore(name string) {
 
 	_, ok := p.dbs[name]
 	if ok {
+		// the store is dropped anyway, the next persistent provider reloads it if the drop couldn't be recorded.
+		_ = p.persistence.append(&walRecord{Op: walDrop, Store: name}) // nolint: errcheck
+
 		delete(p.dbs, name)
 	}
 }
@@ -148,10 +218,11 @@ type dbEntry struct {
 }
 
 type memStore struct {
-	name   string
-	db     map[string]dbEntry
-	config spi.StoreConfiguration
-	close  closer
+	name        string
+	db          map[string]dbEntry
+	config      spi.StoreConfiguration
+	close       closer
+	persistence *persistence
 	sync.RWMutex
 }
 
@@ -177,6 +248,12 @@ func (m *memStore) Put(key string, value []byte, tags ...spi.Tag) error {
 
 	m.Lock()
 	defer m.Unlock()
+
+	err := m.persistence.append(&walRecord{Op: walPut, Store: m.name, Key: key, Value: value, Tags: tags})
+	if err != nil {
+		return err
+	}
+
 	m.db[key] = dbEntry{
 		value: value,
 		tags:  tags,
@@ -185,6 +262,16 @@ func (m *memStore) Put(key string, value []byte, tags ...spi.Tag) error {
 	return nil
 }
 
+// PutWithTTL stores the key + value pair along with the (optional) tags, the pair expiring once the given TTL has
+// elapsed.
+func (m *memStore) PutWithTTL(key string, value []byte, ttl time.Duration, tags ...spi.Tag) error {
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	return m.Put(key, value, append(tags, spi.ExpiryTag(time.Now().Add(ttl)))...)
+}
+
 // Get fetches the value associated with the given key.
 // If key cannot be found, then an error wrapping spi.ErrDataNotFound will be returned.
 // If key is empty, then an error will be returned.
@@ -197,7 +284,7 @@ func (m *memStore) Get(key string) ([]byte, error) {
 	defer m.RUnlock()
 	entry, ok := m.db[key]
 
-	if !ok {
+	if !ok || spi.IsExpired(entry.tags, time.Now()) {
 		return nil, spi.ErrDataNotFound
 	}
 
@@ -216,7 +303,7 @@ func (m *memStore) GetTags(key string) ([]spi.Tag, error) {
 	defer m.RUnlock()
 	entry, ok := m.db[key]
 
-	if !ok {
+	if !ok || spi.IsExpired(entry.tags, time.Now()) {
 		return nil, spi.ErrDataNotFound
 	}
 
@@ -242,8 +329,12 @@ func (m *memStore) GetBulk(keys ...string) ([][]byte, error) {
 	m.RLock()
 	defer m.RUnlock()
 
+	now := time.Now()
+
 	for i, key := range keys {
-		values[i] = m.db[key].value
+		if entry := m.db[key]; !spi.IsExpired(entry.tags, now) {
+			values[i] = entry.value
+		}
 	}
 
 	return values, nil
@@ -301,6 +392,11 @@ func (m *memStore) Delete(k string) error {
 
 	m.Lock()
 	defer m.Unlock()
+
+	if err := m.persistence.append(&walRecord{Op: walDelete, Store: m.name, Key: k}); err != nil {
+		return err
+	}
+
 	delete(m.db, k)
 
 	return nil
@@ -322,6 +418,16 @@ func (m *memStore) Batch(operations []spi.Operation) error {
 		}
 	}
 
+	if err := m.persistence.append(&walRecord{Op: walBatch, Store: m.name, Operations: toWALOperations(operations)}); err != nil {
+		return err
+	}
+
+	m.applyBatch(operations)
+
+	return nil
+}
+
+func (m *memStore) applyBatch(operations []spi.Operation) {
 	for _, operation := range operations {
 		if operation.Value == nil {
 			delete(m.db, operation.Key)
@@ -333,6 +439,32 @@ func (m *memStore) Batch(operations []spi.Operation) error {
 			tags:  operation.Tags,
 		}
 	}
+}
+
+// DeleteExpired deletes the expired key + value pairs.
+func (m *memStore) DeleteExpired() error {
+	m.Lock()
+	defer m.Unlock()
+
+	now := time.Now()
+
+	var operations []spi.Operation
+
+	for key, entry := range m.db {
+		if spi.IsExpired(entry.tags, now) {
+			operations = append(operations, spi.Operation{Key: key})
+		}
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if err := m.persistence.append(&walRecord{Op: walBatch, Store: m.name, Operations: toWALOperations(operations)}); err != nil {
+		return err
+	}
+
+	m.applyBatch(operations)
 
 	return nil
 }
@@ -344,6 +476,32 @@ func (m *memStore) Close() error {
 	return nil
 }
 
+// Iterate returns an iterator over all the unexpired key + value pairs of the store, sorted by key.
+func (m *memStore) Iterate() (spi.Iterator, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	keys := make([]string, 0, len(m.db))
+
+	now := time.Now()
+
+	for key, entry := range m.db {
+		if !spi.IsExpired(entry.tags, now) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	dbEntries := make([]dbEntry, len(keys))
+
+	for i, key := range keys {
+		dbEntries[i] = m.db[key]
+	}
+
+	return &memIterator{keys: keys, dbEntries: dbEntries}, nil
+}
+
 // memStore doesn't queue values, so there's never anything to flush.
 func (m *memStore) Flush() error {
 	return nil
@@ -359,7 +517,13 @@ func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]stri
 
 	var dbEntries []dbEntry
 
+	now := time.Now()
+
 	for key, dbEntry := range m.db {
+		if spi.IsExpired(dbEntry.tags, now) {
+			continue
+		}
+
 		for _, tag := range dbEntry.tags {
 			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
 				keys = append(keys, key)
@@ -8,6 +8,7 @@ package mem_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -39,6 +40,119 @@ func TestQueryNotSupportedOptions(t *testing.T) {
 	require.Nil(t, iterator)
 }
 
+func TestExpiry(t *testing.T) {
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	require.Implements(t, (*spi.ExpiringStore)(nil), store)
+
+	err = spi.PutWithTTL(store, "live", []byte("value"), time.Hour, spi.Tag{Name: "TagName"})
+	require.NoError(t, err)
+
+	err = store.Put("expired", []byte("value"), spi.Tag{Name: "TagName"},
+		spi.ExpiryTag(time.Now().Add(-time.Second)))
+	require.NoError(t, err)
+
+	err = spi.PutWithTTL(store, "invalid", []byte("value"), 0)
+	require.EqualError(t, err, "ttl must be positive")
+
+	checkExpiry := func() {
+		value, err := store.Get("live")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		_, err = store.Get("expired")
+		require.ErrorIs(t, err, spi.ErrDataNotFound)
+
+		_, err = store.GetTags("expired")
+		require.ErrorIs(t, err, spi.ErrDataNotFound)
+
+		values, err := store.GetBulk("live", "expired")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("value"), nil}, values)
+
+		iterator, err := store.Query("TagName")
+		require.NoError(t, err)
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+		require.Equal(t, "live", key)
+
+		more, err = iterator.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+	}
+
+	checkExpiry()
+
+	require.NoError(t, provider.DeleteExpired())
+
+	checkExpiry()
+}
+
+func TestIterate(t *testing.T) {
+	provider := mem.NewProvider()
+
+	require.Implements(t, (*spi.StoreLister)(nil), provider)
+
+	store, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	_, err = provider.OpenStore("OtherStore")
+	require.NoError(t, err)
+
+	names, err := provider.StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"otherstore", "teststore"}, names)
+
+	require.Implements(t, (*spi.IterableStore)(nil), store)
+
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, store.Put("key1", []byte("value1"), spi.Tag{Name: "TagName", Value: "TagValue"}))
+	require.NoError(t, store.Put("expired", []byte("value"), spi.ExpiryTag(time.Now().Add(-time.Second))))
+
+	iterator, err := store.(spi.IterableStore).Iterate()
+	require.NoError(t, err)
+
+	total, err := iterator.TotalItems()
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+
+	var keys []string
+
+	for {
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		if !more {
+			break
+		}
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+
+		if key == "key1" {
+			value, err := iterator.Value()
+			require.NoError(t, err)
+			require.Equal(t, []byte("value1"), value)
+
+			tags, err := iterator.Tags()
+			require.NoError(t, err)
+			require.Equal(t, []spi.Tag{{Name: "TagName", Value: "TagValue"}}, tags)
+		}
+	}
+
+	require.Equal(t, []string{"key1", "key2"}, keys)
+}
+
 func TestMemIterator(t *testing.T) {
 	provider := mem.NewProvider()
 
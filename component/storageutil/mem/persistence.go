@@ -0,0 +1,392 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	snapshotFileName = "snapshot.json"
+	walFileName      = "wal.log"
+
+	defaultSnapshotInterval = time.Minute
+
+	walOpen   = "open"
+	walConfig = "config"
+	walPut    = "put"
+	walDelete = "delete"
+	walBatch  = "batch"
+	walDrop   = "drop"
+)
+
+var errProviderClosed = errors.New("persistent provider is closed")
+
+// Option configures the persistence of the in-memory provider.
+type Option func(p *persistence)
+
+// WithSnapshotInterval sets the interval between the snapshots of the data, 1 minute by default. The write-ahead log
+// is truncated after each snapshot. A zero interval disables the periodic snapshots: the data is then only snapshotted
+// on start and when the provider is closed.
+func WithSnapshotInterval(interval time.Duration) Option {
+	return func(p *persistence) {
+		p.snapshotInterval = interval
+	}
+}
+
+// walRecord is a write recorded in the write-ahead log.
+type walRecord struct {
+	Op         string                  `json:"op"`
+	Store      string                  `json:"store"`
+	Key        string                  `json:"key,omitempty"`
+	Value      []byte                  `json:"value"`
+	Tags       []spi.Tag               `json:"tags,omitempty"`
+	Operations []walOperation          `json:"operations,omitempty"`
+	Config     *spi.StoreConfiguration `json:"config,omitempty"`
+}
+
+// walOperation is a batch operation. Unlike spi.Operation, an empty value isn't confused with a delete.
+type walOperation struct {
+	Key   string    `json:"key"`
+	Value []byte    `json:"value"`
+	Tags  []spi.Tag `json:"tags,omitempty"`
+}
+
+func toWALOperations(operations []spi.Operation) []walOperation {
+	walOperations := make([]walOperation, len(operations))
+
+	for i, operation := range operations {
+		walOperations[i] = walOperation{Key: operation.Key, Value: operation.Value, Tags: operation.Tags}
+	}
+
+	return walOperations
+}
+
+type snapshot struct {
+	Stores map[string]*storeSnapshot `json:"stores"`
+}
+
+type storeSnapshot struct {
+	Config  spi.StoreConfiguration    `json:"config"`
+	Entries map[string]*entrySnapshot `json:"entries"`
+}
+
+type entrySnapshot struct {
+	Value []byte    `json:"value"`
+	Tags  []spi.Tag `json:"tags,omitempty"`
+}
+
+// persistence persists the data of the provider in a directory: a snapshot of the data and a write-ahead log of the
+// writes since the snapshot.
+type persistence struct {
+	dir              string
+	snapshotInterval time.Duration
+	wal              *os.File
+	closed           bool
+	stop             chan struct{}
+	done             chan struct{}
+	mu               sync.Mutex
+}
+
+// NewPersistentProvider instantiates a new in-memory storage Provider persisting its data in the given directory,
+// so that the data survives restarts. The data is loaded from the directory on start, then every write is appended
+// to a write-ahead log before being applied in memory, and the data is periodically snapshotted.
+// As with the in-memory provider, closing a store deletes its data, while closing the provider keeps the data on
+// disk.
+func NewPersistentProvider(dir string, opts ...Option) (*Provider, error) {
+	pers := &persistence{
+		dir:              dir,
+		snapshotInterval: defaultSnapshotInterval,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(pers)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create persistence directory: %w", err)
+	}
+
+	p := &Provider{dbs: make(map[string]*memStore), persistence: pers}
+
+	err := pers.load(p)
+	if err == nil {
+		// the loaded write-ahead log is compacted into a new snapshot.
+		err = pers.snapshot(p)
+	}
+
+	if err != nil {
+		if pers.wal != nil {
+			_ = pers.wal.Close() // nolint: errcheck
+		}
+
+		return nil, err
+	}
+
+	go pers.run(p)
+
+	return p, nil
+}
+
+// load loads the snapshot then replays the write-ahead log.
+func (pers *persistence) load(p *Provider) error {
+	data, err := ioutil.ReadFile(filepath.Join(pers.dir, snapshotFileName))
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	default:
+		snap := &snapshot{}
+
+		if err = json.Unmarshal(data, snap); err != nil {
+			return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+		}
+
+		for name, s := range snap.Stores {
+			store := p.newStore(name)
+			store.config = s.Config
+
+			for key, entry := range s.Entries {
+				store.db[key] = dbEntry{value: entry.Value, tags: entry.Tags}
+			}
+		}
+	}
+
+	pers.wal, err = os.OpenFile(filepath.Join(pers.dir, walFileName), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	return pers.replay(p)
+}
+
+// replay applies the records of the write-ahead log. A truncated last record, left by a crash while it was written,
+// is discarded.
+func (pers *persistence) replay(p *Provider) error {
+	reader := bufio.NewReader(pers.wal)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+
+		record := &walRecord{}
+
+		if err = json.Unmarshal(line, record); err != nil {
+			return fmt.Errorf("failed to unmarshal write-ahead log record: %w", err)
+		}
+
+		p.apply(record)
+	}
+}
+
+func (p *Provider) apply(record *walRecord) {
+	store := p.dbs[record.Store]
+
+	if store == nil {
+		if record.Op == walDrop {
+			return
+		}
+
+		store = p.newStore(record.Store)
+	}
+
+	switch record.Op {
+	case walConfig:
+		store.config = *record.Config
+	case walPut:
+		store.db[record.Key] = dbEntry{value: record.Value, tags: record.Tags}
+	case walDelete:
+		delete(store.db, record.Key)
+	case walBatch:
+		for _, operation := range record.Operations {
+			if operation.Value == nil {
+				delete(store.db, operation.Key)
+
+				continue
+			}
+
+			store.db[operation.Key] = dbEntry{value: operation.Value, tags: operation.Tags}
+		}
+	case walDrop:
+		delete(p.dbs, record.Store)
+	}
+}
+
+// append appends the record to the write-ahead log. It is a no-op for a non-persistent provider.
+func (pers *persistence) append(record *walRecord) error {
+	if pers == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal write-ahead log record: %w", err)
+	}
+
+	pers.mu.Lock()
+	defer pers.mu.Unlock()
+
+	if pers.closed {
+		return errProviderClosed
+	}
+
+	if _, err = pers.wal.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to write-ahead log: %w", err)
+	}
+
+	return nil
+}
+
+func (pers *persistence) run(p *Provider) {
+	defer close(pers.done)
+
+	if pers.snapshotInterval <= 0 {
+		<-pers.stop
+
+		return
+	}
+
+	ticker := time.NewTicker(pers.snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pers.stop:
+			return
+		case <-ticker.C:
+			// the expired data is deleted first so that it is not snapshotted, a failed deletion is retried at the
+			// next tick.
+			_ = p.DeleteExpired() // nolint: errcheck
+
+			// a failed snapshot is retried at the next tick, the writes are still in the write-ahead log.
+			_ = pers.snapshot(p) // nolint: errcheck
+		}
+	}
+}
+
+// snapshot writes a snapshot of the data then truncates the write-ahead log. The writes are blocked while the
+// snapshot is taken, so that the snapshot and the log are consistent.
+func (pers *persistence) snapshot(p *Provider) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	snap := &snapshot{Stores: make(map[string]*storeSnapshot, len(p.dbs))}
+
+	for name, store := range p.dbs {
+		store.RLock()
+		defer store.RUnlock()
+
+		s := &storeSnapshot{Config: store.config, Entries: make(map[string]*entrySnapshot, len(store.db))}
+
+		for key, entry := range store.db {
+			s.Entries[key] = &entrySnapshot{Value: entry.value, Tags: entry.tags}
+		}
+
+		snap.Stores[name] = s
+	}
+
+	pers.mu.Lock()
+	defer pers.mu.Unlock()
+
+	if pers.closed {
+		return errProviderClosed
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	// the snapshot replaces the previous one atomically.
+	tmpFile := filepath.Join(pers.dir, snapshotFileName+".tmp")
+
+	if err = writeFileSync(tmpFile, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err = os.Rename(tmpFile, filepath.Join(pers.dir, snapshotFileName)); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+
+	if err = pers.wal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+
+	if _, err = pers.wal.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind write-ahead log: %w", err)
+	}
+
+	return nil
+}
+
+// close stops the periodic snapshots, takes a last snapshot and closes the write-ahead log.
+func (pers *persistence) close(p *Provider) error {
+	pers.mu.Lock()
+	closed := pers.closed
+	pers.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	close(pers.stop)
+	<-pers.done
+
+	snapshotErr := pers.snapshot(p)
+
+	pers.mu.Lock()
+	defer pers.mu.Unlock()
+
+	pers.closed = true
+
+	if err := pers.wal.Close(); err != nil {
+		return fmt.Errorf("failed to close write-ahead log: %w", err)
+	}
+
+	return snapshotErr
+}
+
+func writeFileSync(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close() // nolint: errcheck
+
+		return err
+	}
+
+	if err = f.Sync(); err != nil {
+		_ = f.Close() // nolint: errcheck
+
+		return err
+	}
+
+	return f.Close()
+}
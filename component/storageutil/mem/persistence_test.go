@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mem_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func TestPersistentProvider_Common(t *testing.T) {
+	provider, err := mem.NewPersistentProvider(t.TempDir())
+	require.NoError(t, err)
+
+	storagetest.TestAll(t, provider, storagetest.SkipSortTests(false))
+
+	require.NoError(t, provider.Close())
+}
+
+func TestPersistentProvider_Restart(t *testing.T) {
+	t.Run("data is reloaded after the provider is closed", func(t *testing.T) {
+		dir := t.TempDir()
+
+		provider, err := mem.NewPersistentProvider(dir)
+		require.NoError(t, err)
+
+		writeTestData(t, provider)
+		require.NoError(t, provider.Close())
+
+		provider, err = mem.NewPersistentProvider(dir)
+		require.NoError(t, err)
+
+		checkTestData(t, provider)
+		require.NoError(t, provider.Close())
+	})
+
+	t.Run("data is reloaded from the write-ahead log after a crash", func(t *testing.T) {
+		dir := t.TempDir()
+
+		provider, err := mem.NewPersistentProvider(dir, mem.WithSnapshotInterval(0))
+		require.NoError(t, err)
+
+		writeTestData(t, provider)
+
+		// simulates a crash while the last record was written.
+		f, err := os.OpenFile(filepath.Join(dir, "wal.log"), os.O_WRONLY|os.O_APPEND, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"op":"put","store":"sto`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		restarted, err := mem.NewPersistentProvider(dir)
+		require.NoError(t, err)
+
+		checkTestData(t, restarted)
+		require.NoError(t, restarted.Close())
+	})
+
+	t.Run("data is snapshotted periodically", func(t *testing.T) {
+		dir := t.TempDir()
+
+		provider, err := mem.NewPersistentProvider(dir, mem.WithSnapshotInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		writeTestData(t, provider)
+
+		require.Eventually(t, func() bool {
+			info, err := os.Stat(filepath.Join(dir, "wal.log"))
+
+			return err == nil && info.Size() == 0
+		}, time.Second, 10*time.Millisecond)
+
+		snapshot, err := ioutil.ReadFile(filepath.Join(dir, "snapshot.json"))
+		require.NoError(t, err)
+		require.Contains(t, string(snapshot), "key3")
+
+		require.NoError(t, provider.Close())
+	})
+}
+
+func TestPersistentProvider_Errors(t *testing.T) {
+	t.Run("error if the snapshot is corrupted", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "snapshot.json"), []byte("{"), 0o600))
+
+		_, err := mem.NewPersistentProvider(dir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal snapshot")
+	})
+
+	t.Run("error if the write-ahead log is corrupted", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "wal.log"), []byte("{\n"), 0o600))
+
+		_, err := mem.NewPersistentProvider(dir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal write-ahead log record")
+	})
+
+	t.Run("error if the directory can't be created", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, ioutil.WriteFile(file, nil, 0o600))
+
+		_, err := mem.NewPersistentProvider(filepath.Join(file, "dir"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create persistence directory")
+	})
+
+	t.Run("error if writing after the provider is closed", func(t *testing.T) {
+		provider, err := mem.NewPersistentProvider(t.TempDir())
+		require.NoError(t, err)
+
+		store, err := provider.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, provider.Close())
+		require.NoError(t, provider.Close())
+
+		require.EqualError(t, store.Put("key", []byte("value")), "persistent provider is closed")
+	})
+}
+
+func writeTestData(t *testing.T, provider *mem.Provider) {
+	t.Helper()
+
+	store, err := provider.OpenStore("Store")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("store", spi.StoreConfiguration{TagNames: []string{"tag"}}))
+	require.NoError(t, store.Put("key1", []byte("value1"), spi.Tag{Name: "tag", Value: "value"}))
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, store.Put("empty", []byte{}))
+	require.NoError(t, store.Delete("key2"))
+	require.NoError(t, store.Batch([]spi.Operation{
+		{Key: "key3", Value: []byte("value3")},
+		{Key: "key1", Value: []byte("value1"), Tags: []spi.Tag{{Name: "tag", Value: "value"}}},
+	}))
+
+	dropped, err := provider.OpenStore("dropped")
+	require.NoError(t, err)
+	require.NoError(t, dropped.Put("key", []byte("value")))
+	require.NoError(t, dropped.Close())
+}
+
+func checkTestData(t *testing.T, provider *mem.Provider) {
+	t.Helper()
+
+	require.Len(t, provider.GetOpenStores(), 1)
+
+	config, err := provider.GetStoreConfig("store")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tag"}, config.TagNames)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	values, err := store.GetBulk("key1", "key2", "key3")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("value1"), nil, []byte("value3")}, values)
+
+	value, err := store.Get("empty")
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	tags, err := store.GetTags("key1")
+	require.NoError(t, err)
+	require.Equal(t, []spi.Tag{{Name: "tag", Value: "value"}}, tags)
+
+	_, err = store.Get("missing")
+	require.True(t, errors.Is(err, spi.ErrDataNotFound))
+}
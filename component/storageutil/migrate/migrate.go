@@ -0,0 +1,389 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migrate copies all the stores of a storage provider to another one, with their configuration, tags and
+// expiry, e.g. to move an agent from LevelDB to another database.
+//
+// The source provider must implement spi.StoreLister (unless the stores are given WithStoreNames) and its stores
+// spi.IterableStore. The progress of the migration is checkpointed in a store of the target provider after each
+// batch, so that a migration which was interrupted resumes where it stopped when run again.
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DefaultCheckpointStoreName is the name of the store of the target provider holding the progress of the
+	// migration.
+	DefaultCheckpointStoreName = "storage_migration"
+
+	defaultBatchSize = 100
+)
+
+// Progress of the migration of a store, reported after each batch of entries copied.
+type Progress struct {
+	// Store being migrated.
+	Store string
+	// StoreIndex is the position of the store in the migration, starting at 1.
+	StoreIndex int
+	// StoreCount is the number of stores migrated.
+	StoreCount int
+	// Entries copied from the store so far, including the ones copied by the previous runs of the migration.
+	Entries int
+	// Done is true once all the entries of the store have been copied.
+	Done bool
+}
+
+// checkpoint of the migration of a store.
+type checkpoint struct {
+	LastKey string `json:"lastKey,omitempty"`
+	Entries int    `json:"entries"`
+	Done    bool   `json:"done,omitempty"`
+}
+
+type options struct {
+	storeNames          []string
+	batchSize           int
+	checkpointStoreName string
+	progress            func(Progress)
+}
+
+// Opt configures a migration.
+type Opt func(opts *options)
+
+// WithStoreNames sets the stores migrated, all the stores listed by the source provider by default.
+func WithStoreNames(names ...string) Opt {
+	return func(opts *options) {
+		opts.storeNames = names
+	}
+}
+
+// WithBatchSize sets the number of entries written to the target provider in a batch, between checkpoints.
+// Defaults to 100.
+func WithBatchSize(size int) Opt {
+	return func(opts *options) {
+		opts.batchSize = size
+	}
+}
+
+// WithCheckpointStoreName sets the name of the store of the target provider holding the progress of the migration,
+// DefaultCheckpointStoreName by default. This store is never migrated.
+func WithCheckpointStoreName(name string) Opt {
+	return func(opts *options) {
+		opts.checkpointStoreName = name
+	}
+}
+
+// WithProgress sets the function the progress of the migration is reported to.
+func WithProgress(progress func(Progress)) Opt {
+	return func(opts *options) {
+		opts.progress = progress
+	}
+}
+
+// Migrate copies the stores of the source provider to the target provider. The source should not be written to
+// during the migration. The stores already migrated by a previous run are skipped, and the store the previous run
+// stopped in is resumed after the last entry checkpointed.
+func Migrate(source, target spi.Provider, opts ...Opt) error {
+	o := &options{batchSize: defaultBatchSize, checkpointStoreName: DefaultCheckpointStoreName}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.batchSize <= 0 {
+		return fmt.Errorf("invalid batch size: %d", o.batchSize)
+	}
+
+	names, err := storeNames(source, o)
+	if err != nil {
+		return err
+	}
+
+	checkpoints, err := target.OpenStore(o.checkpointStoreName)
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint store: %w", err)
+	}
+
+	m := &migration{
+		source:      source,
+		target:      target,
+		checkpoints: checkpoints,
+		opts:        o,
+	}
+
+	for i, name := range names {
+		m.progress = Progress{Store: name, StoreIndex: i + 1, StoreCount: len(names)}
+
+		err = m.migrateStore(name)
+		if err != nil {
+			return fmt.Errorf(`failed to migrate store "%s": %w`, name, err)
+		}
+	}
+
+	return nil
+}
+
+// storeNames returns the lowercase names of the stores to migrate, without the checkpoint store.
+func storeNames(source spi.Provider, o *options) ([]string, error) {
+	names := o.storeNames
+
+	if names == nil {
+		lister, ok := source.(spi.StoreLister)
+		if !ok {
+			return nil, errors.New("the source provider can't list its stores: the store names must be given")
+		}
+
+		var err error
+
+		names, err = lister.StoreNames()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the stores of the source provider: %w", err)
+		}
+	}
+
+	var storeNames []string
+
+	for _, name := range names {
+		name = strings.ToLower(name)
+
+		if name != strings.ToLower(o.checkpointStoreName) {
+			storeNames = append(storeNames, name)
+		}
+	}
+
+	return storeNames, nil
+}
+
+type migration struct {
+	source      spi.Provider
+	target      spi.Provider
+	checkpoints spi.Store
+	opts        *options
+	progress    Progress
+}
+
+func (m *migration) migrateStore(name string) error {
+	cp, err := m.getCheckpoint(name)
+	if err != nil {
+		return err
+	}
+
+	m.progress.Entries = cp.Entries
+
+	if cp.Done {
+		m.report(true)
+
+		return nil
+	}
+
+	sourceStore, err := m.source.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open source store: %w", err)
+	}
+
+	iterable, ok := sourceStore.(spi.IterableStore)
+	if !ok {
+		return errors.New("the source store can't be iterated")
+	}
+
+	targetStore, err := m.target.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open target store: %w", err)
+	}
+
+	err = m.copyConfig(name)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := iterable.Iterate()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over source store: %w", err)
+	}
+
+	defer spi.Close(iterator, nil)
+
+	err = m.copyEntries(name, iterator, targetStore, cp)
+	if err != nil {
+		return err
+	}
+
+	cp.Done = true
+
+	err = m.putCheckpoint(name, cp)
+	if err != nil {
+		return err
+	}
+
+	m.report(true)
+
+	return nil
+}
+
+func (m *migration) copyConfig(name string) error {
+	config, err := m.source.GetStoreConfig(name)
+	if errors.Is(err, spi.ErrStoreNotFound) || errors.Is(err, spi.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get source store configuration: %w", err)
+	}
+
+	err = m.target.SetStoreConfig(name, config)
+	if err != nil {
+		return fmt.Errorf("failed to set target store configuration: %w", err)
+	}
+
+	return nil
+}
+
+// copyEntries copies the entries after the last one checkpointed, by batch.
+func (m *migration) copyEntries(name string, iterator spi.Iterator, targetStore spi.Store, cp *checkpoint) error {
+	var batch []spi.Operation
+
+	for {
+		more, err := iterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to get next source entry: %w", err)
+		}
+
+		if !more {
+			break
+		}
+
+		op, found, err := entry(iterator)
+		if err != nil {
+			return err
+		}
+
+		// the entries are iterated by key, the ones up to the last key checkpointed have been copied
+		if !found || (cp.LastKey != "" && op.Key <= cp.LastKey) {
+			continue
+		}
+
+		batch = append(batch, op)
+
+		if len(batch) == m.opts.batchSize {
+			err = m.writeBatch(name, targetStore, batch, cp)
+			if err != nil {
+				return err
+			}
+
+			batch = nil
+		}
+	}
+
+	if len(batch) > 0 {
+		return m.writeBatch(name, targetStore, batch, cp)
+	}
+
+	return nil
+}
+
+// entry returns the current entry of the iterator as a put operation, not found if it was deleted or expired since
+// the iterator was created.
+func entry(iterator spi.Iterator) (spi.Operation, bool, error) {
+	key, err := iterator.Key()
+	if err != nil {
+		return spi.Operation{}, false, fmt.Errorf("failed to get source entry key: %w", err)
+	}
+
+	value, err := iterator.Value()
+	if errors.Is(err, spi.ErrDataNotFound) {
+		return spi.Operation{}, false, nil
+	}
+
+	if err != nil {
+		return spi.Operation{}, false, fmt.Errorf(`failed to get value of source entry "%s": %w`, key, err)
+	}
+
+	tags, err := iterator.Tags()
+	if errors.Is(err, spi.ErrDataNotFound) {
+		return spi.Operation{}, false, nil
+	}
+
+	if err != nil {
+		return spi.Operation{}, false, fmt.Errorf(`failed to get tags of source entry "%s": %w`, key, err)
+	}
+
+	return spi.Operation{Key: key, Value: value, Tags: tags}, true, nil
+}
+
+func (m *migration) writeBatch(name string, targetStore spi.Store, batch []spi.Operation, cp *checkpoint) error {
+	err := targetStore.Batch(batch)
+	if err != nil {
+		return fmt.Errorf("failed to write entries to target store: %w", err)
+	}
+
+	err = targetStore.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush target store: %w", err)
+	}
+
+	cp.LastKey = batch[len(batch)-1].Key
+	cp.Entries += len(batch)
+
+	err = m.putCheckpoint(name, cp)
+	if err != nil {
+		return err
+	}
+
+	m.progress.Entries = cp.Entries
+	m.report(false)
+
+	return nil
+}
+
+func (m *migration) getCheckpoint(name string) (*checkpoint, error) {
+	cp := &checkpoint{}
+
+	cpBytes, err := m.checkpoints.Get(name)
+	if errors.Is(err, spi.ErrDataNotFound) {
+		return cp, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+
+	err = json.Unmarshal(cpBytes, cp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	return cp, nil
+}
+
+func (m *migration) putCheckpoint(name string, cp *checkpoint) error {
+	cpBytes, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	err = m.checkpoints.Put(name, cpBytes)
+	if err != nil {
+		return fmt.Errorf("failed to put checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+func (m *migration) report(done bool) {
+	if m.opts.progress == nil {
+		return
+	}
+
+	m.progress.Done = done
+	m.opts.progress(m.progress)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migrate_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const entryCount = 250
+
+func newSource(t *testing.T) *mem.Provider {
+	t.Helper()
+
+	source := mem.NewProvider()
+
+	connections, err := source.OpenStore("Connections")
+	require.NoError(t, err)
+
+	require.NoError(t, source.SetStoreConfig("connections", spi.StoreConfiguration{TagNames: []string{"state"}}))
+
+	for i := 0; i < entryCount; i++ {
+		err = connections.Put(fmt.Sprintf("key%03d", i), []byte(fmt.Sprintf("value%d", i)),
+			spi.Tag{Name: "state", Value: "completed"})
+		require.NoError(t, err)
+	}
+
+	err = spi.PutWithTTL(connections, "expiring", []byte("value"), time.Hour)
+	require.NoError(t, err)
+
+	kms, err := source.OpenStore("kms")
+	require.NoError(t, err)
+
+	require.NoError(t, kms.Put("kid", []byte("keyset")))
+
+	return source
+}
+
+func requireMigrated(t *testing.T, target spi.Provider) {
+	t.Helper()
+
+	connections, err := target.OpenStore("connections")
+	require.NoError(t, err)
+
+	config, err := target.GetStoreConfig("connections")
+	require.NoError(t, err)
+	require.Equal(t, []string{"state"}, config.TagNames)
+
+	for i := 0; i < entryCount; i++ {
+		value, err := connections.Get(fmt.Sprintf("key%03d", i))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+	}
+
+	iterator, err := connections.Query("state:completed")
+	require.NoError(t, err)
+
+	total, err := iterator.TotalItems()
+	require.NoError(t, err)
+	require.Equal(t, entryCount, total)
+
+	tags, err := connections.GetTags("expiring")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, spi.ExpiryTagName, tags[0].Name)
+
+	kms, err := target.OpenStore("kms")
+	require.NoError(t, err)
+
+	value, err := kms.Get("kid")
+	require.NoError(t, err)
+	require.Equal(t, []byte("keyset"), value)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		source, target := newSource(t), mem.NewProvider()
+
+		var progress []migrate.Progress
+
+		err := migrate.Migrate(source, target, migrate.WithProgress(func(p migrate.Progress) {
+			progress = append(progress, p)
+		}))
+		require.NoError(t, err)
+
+		requireMigrated(t, target)
+
+		require.Equal(t, []migrate.Progress{
+			{Store: "connections", StoreIndex: 1, StoreCount: 2, Entries: 100},
+			{Store: "connections", StoreIndex: 1, StoreCount: 2, Entries: 200},
+			{Store: "connections", StoreIndex: 1, StoreCount: 2, Entries: 251},
+			{Store: "connections", StoreIndex: 1, StoreCount: 2, Entries: 251, Done: true},
+			{Store: "kms", StoreIndex: 2, StoreCount: 2, Entries: 1},
+			{Store: "kms", StoreIndex: 2, StoreCount: 2, Entries: 1, Done: true},
+		}, progress)
+
+		// a migration run again skips the stores migrated
+		progress = nil
+
+		err = migrate.Migrate(source, target, migrate.WithProgress(func(p migrate.Progress) {
+			progress = append(progress, p)
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, []migrate.Progress{
+			{Store: "connections", StoreIndex: 1, StoreCount: 2, Entries: 251, Done: true},
+			{Store: "kms", StoreIndex: 2, StoreCount: 2, Entries: 1, Done: true},
+		}, progress)
+	})
+
+	t.Run("success - selected stores", func(t *testing.T) {
+		target := mem.NewProvider()
+
+		err := migrate.Migrate(newSource(t), target, migrate.WithStoreNames("KMS"), migrate.WithBatchSize(10))
+		require.NoError(t, err)
+
+		names, err := target.StoreNames()
+		require.NoError(t, err)
+		require.Equal(t, []string{"kms", migrate.DefaultCheckpointStoreName}, names)
+	})
+
+	t.Run("success - resumes an interrupted migration", func(t *testing.T) {
+		source := newSource(t)
+		target := &failingProvider{Provider: mem.NewProvider(), failAfter: 2}
+
+		err := migrate.Migrate(source, target, migrate.WithBatchSize(50))
+		require.EqualError(t, err, `failed to migrate store "connections": `+
+			"failed to write entries to target store: batch error")
+
+		var progress []migrate.Progress
+
+		target.failAfter = -1
+
+		err = migrate.Migrate(source, target, migrate.WithBatchSize(50), migrate.WithProgress(
+			func(p migrate.Progress) {
+				progress = append(progress, p)
+			}))
+		require.NoError(t, err)
+
+		requireMigrated(t, target)
+
+		// the migration resumed after the 2 batches written, each batch of the 2 stores was written once
+		require.Equal(t, 100+50, progress[0].Entries)
+		require.Equal(t, 6+1, target.batches)
+	})
+
+	t.Run("the checkpoint store of a previous migration isn't migrated", func(t *testing.T) {
+		source, target := newSource(t), mem.NewProvider()
+
+		require.NoError(t, migrate.Migrate(source, mem.NewProvider(), migrate.WithCheckpointStoreName("kms")))
+		require.NoError(t, migrate.Migrate(source, target, migrate.WithCheckpointStoreName("KMS")))
+
+		names, err := target.StoreNames()
+		require.NoError(t, err)
+		require.Equal(t, []string{"connections", "kms"}, names)
+
+		kms, err := target.OpenStore("kms")
+		require.NoError(t, err)
+
+		_, err = kms.Get("kid")
+		require.ErrorIs(t, err, spi.ErrDataNotFound)
+	})
+
+	t.Run("error - invalid batch size", func(t *testing.T) {
+		err := migrate.Migrate(mem.NewProvider(), mem.NewProvider(), migrate.WithBatchSize(0))
+		require.EqualError(t, err, "invalid batch size: 0")
+	})
+
+	t.Run("error - source provider can't list its stores", func(t *testing.T) {
+		err := migrate.Migrate(&mock.Provider{}, mem.NewProvider())
+		require.EqualError(t, err, "the source provider can't list its stores: the store names must be given")
+	})
+
+	t.Run("error - source store can't be iterated", func(t *testing.T) {
+		err := migrate.Migrate(&mock.Provider{OpenStoreReturn: &mock.Store{}}, mem.NewProvider(),
+			migrate.WithStoreNames("store"))
+		require.EqualError(t, err, `failed to migrate store "store": the source store can't be iterated`)
+	})
+
+	t.Run("error - open stores", func(t *testing.T) {
+		err := migrate.Migrate(&mock.Provider{ErrOpenStore: errors.New("open error")}, mem.NewProvider(),
+			migrate.WithStoreNames("store"))
+		require.EqualError(t, err, `failed to migrate store "store": failed to open source store: open error`)
+
+		err = migrate.Migrate(newSource(t), &mock.Provider{ErrOpenStore: errors.New("open error")})
+		require.EqualError(t, err, "failed to open checkpoint store: open error")
+	})
+
+	t.Run("error - store configuration", func(t *testing.T) {
+		source := newSource(t)
+
+		err := migrate.Migrate(source, &configProvider{Provider: mem.NewProvider(), errSet: errors.New("set error")})
+		require.EqualError(t, err, `failed to migrate store "connections": `+
+			"failed to set target store configuration: set error")
+
+		err = migrate.Migrate(&configProvider{Provider: source, errGet: errors.New("get error")}, mem.NewProvider())
+		require.EqualError(t, err, `failed to migrate store "connections": `+
+			"failed to get source store configuration: get error")
+	})
+
+	t.Run("error - invalid checkpoint", func(t *testing.T) {
+		target := mem.NewProvider()
+
+		checkpoints, err := target.OpenStore(migrate.DefaultCheckpointStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, checkpoints.Put("kms", []byte("invalid")))
+
+		err = migrate.Migrate(newSource(t), target, migrate.WithStoreNames("kms"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal checkpoint")
+	})
+}
+
+// failingProvider fails the batches written after failAfter batches, never if failAfter is negative.
+type failingProvider struct {
+	*mem.Provider
+	failAfter int
+	batches   int
+}
+
+func (p *failingProvider) OpenStore(name string) (spi.Store, error) {
+	store, err := p.Provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failingStore{Store: store, provider: p}, nil
+}
+
+type failingStore struct {
+	spi.Store
+	provider *failingProvider
+}
+
+func (s *failingStore) Batch(operations []spi.Operation) error {
+	if s.provider.failAfter >= 0 && s.provider.batches >= s.provider.failAfter {
+		return errors.New("batch error")
+	}
+
+	s.provider.batches++
+
+	return s.Store.Batch(operations)
+}
+
+type configProvider struct {
+	*mem.Provider
+	errGet error
+	errSet error
+}
+
+func (p *configProvider) GetStoreConfig(name string) (spi.StoreConfiguration, error) {
+	if p.errGet != nil {
+		return spi.StoreConfiguration{}, p.errGet
+	}
+
+	return p.Provider.GetStoreConfig(name)
+}
+
+func (p *configProvider) SetStoreConfig(name string, config spi.StoreConfiguration) error {
+	if p.errSet != nil {
+		return p.errSet
+	}
+
+	return p.Provider.SetStoreConfig(name, config)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package benchmark measures the DIDComm packers (authcrypt and anoncrypt pack/unpack) and the linked data proof
+// suites (Ed25519Signature2020 sign/verify, BBS+ sign/derive/verify) across payload sizes. The report of a run is
+// JSON serializable, so that the results can be collected to size the hardware of the agents.
+//
+// The cases are also run as go benchmarks:
+//
+//	go test -run=^$ -bench=. ./pkg/benchmark/...
+package benchmark
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+const (
+	defaultDuration = time.Second
+	kiB             = 1 << 10
+)
+
+// DefaultPayloadSizes returns the payload sizes measured by default, in bytes.
+func DefaultPayloadSizes() []int {
+	return []int{kiB, 16 * kiB, 128 * kiB}
+}
+
+// Case is an operation measured by the benchmark.
+type Case struct {
+	// Name of the case, e.g. "authcrypt/pack".
+	Name string
+	// Prepare returns the operation run on a payload of size bytes.
+	Prepare func(size int) (func() error, error)
+}
+
+// Cases returns the cases of the packers and of the linked data proof suites, with their keys created in memory.
+func Cases() ([]Case, error) {
+	packers, err := packerCases()
+	if err != nil {
+		return nil, fmt.Errorf("packer cases: %w", err)
+	}
+
+	suites, err := suiteCases()
+	if err != nil {
+		return nil, fmt.Errorf("proof suite cases: %w", err)
+	}
+
+	return append(packers, suites...), nil
+}
+
+// Result of a case on a payload size.
+type Result struct {
+	Case        string `json:"case"`
+	PayloadSize int    `json:"payloadSize"`
+	Iterations  int    `json:"iterations"`
+	NsPerOp     int64  `json:"nsPerOp"`
+	BytesPerOp  uint64 `json:"bytesPerOp"`
+	AllocsPerOp uint64 `json:"allocsPerOp"`
+}
+
+// Report of a benchmark run, with the platform the results were measured on.
+type Report struct {
+	GoVersion string    `json:"goVersion"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	CPUs      int       `json:"cpus"`
+	Results   []*Result `json:"results"`
+}
+
+type options struct {
+	cases    map[string]struct{}
+	sizes    []int
+	duration time.Duration
+}
+
+// Opt configures a benchmark run.
+type Opt func(opts *options)
+
+// WithCases selects the cases run by name, all the cases by default.
+func WithCases(names ...string) Opt {
+	return func(opts *options) {
+		opts.cases = make(map[string]struct{})
+
+		for _, name := range names {
+			opts.cases[name] = struct{}{}
+		}
+	}
+}
+
+// WithPayloadSizes sets the payload sizes measured, DefaultPayloadSizes by default.
+func WithPayloadSizes(sizes ...int) Opt {
+	return func(opts *options) {
+		opts.sizes = sizes
+	}
+}
+
+// WithDuration sets the duration each case is run for on each payload size, 1 second by default.
+func WithDuration(d time.Duration) Opt {
+	return func(opts *options) {
+		opts.duration = d
+	}
+}
+
+// Run runs the cases on each payload size and returns the report of the results.
+func Run(cases []Case, opts ...Opt) (*Report, error) {
+	o := &options{sizes: DefaultPayloadSizes(), duration: defaultDuration}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for name := range o.cases {
+		if !hasCase(cases, name) {
+			return nil, fmt.Errorf("unknown benchmark case: %s", name)
+		}
+	}
+
+	for _, size := range o.sizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("invalid payload size: %d", size)
+		}
+	}
+
+	report := &Report{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+	}
+
+	for _, c := range cases {
+		if _, ok := o.cases[c.Name]; o.cases != nil && !ok {
+			continue
+		}
+
+		for _, size := range o.sizes {
+			op, err := c.Prepare(size)
+			if err != nil {
+				return nil, fmt.Errorf("prepare %s on %d bytes: %w", c.Name, size, err)
+			}
+
+			result, err := measure(op, o.duration)
+			if err != nil {
+				return nil, fmt.Errorf("run %s on %d bytes: %w", c.Name, size, err)
+			}
+
+			result.Case = c.Name
+			result.PayloadSize = size
+
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	return report, nil
+}
+
+func hasCase(cases []Case, name string) bool {
+	for _, c := range cases {
+		if c.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// measure runs the operation for the duration, at least once after a warm-up run.
+func measure(op func() error, d time.Duration) (*Result, error) {
+	if err := op(); err != nil {
+		return nil, err
+	}
+
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var n int
+
+	start := time.Now()
+
+	for n == 0 || time.Since(start) < d {
+		if err := op(); err != nil {
+			return nil, err
+		}
+
+		n++
+	}
+
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+
+	return &Result{
+		Iterations:  n,
+		NsPerOp:     elapsed.Nanoseconds() / int64(n),
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / uint64(n),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / uint64(n),
+	}, nil
+}
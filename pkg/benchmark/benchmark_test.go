@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	cases, err := Cases()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		report, err := Run(cases, WithPayloadSizes(64, 1024), WithDuration(time.Millisecond))
+		require.NoError(t, err)
+		require.NotEmpty(t, report.GoVersion)
+		require.Positive(t, report.CPUs)
+		require.Len(t, report.Results, 2*len(cases))
+
+		for _, result := range report.Results {
+			require.Positive(t, result.Iterations, result.Case)
+			require.Positive(t, result.NsPerOp, result.Case)
+		}
+
+		reportBytes, err := json.Marshal(report)
+		require.NoError(t, err)
+		require.Contains(t, string(reportBytes), `"case":"BbsBlsSignatureProof2020/verify","payloadSize":1024`)
+	})
+
+	t.Run("success - selected cases", func(t *testing.T) {
+		report, err := Run(cases, WithCases("authcrypt/unpack", "Ed25519Signature2020/verify"),
+			WithPayloadSizes(64), WithDuration(time.Millisecond))
+		require.NoError(t, err)
+		require.Len(t, report.Results, 2)
+		require.Equal(t, "authcrypt/unpack", report.Results[0].Case)
+		require.Equal(t, "Ed25519Signature2020/verify", report.Results[1].Case)
+	})
+
+	t.Run("error - unknown case", func(t *testing.T) {
+		_, err := Run(cases, WithCases("unknown"))
+		require.EqualError(t, err, "unknown benchmark case: unknown")
+	})
+
+	t.Run("error - invalid payload size", func(t *testing.T) {
+		_, err := Run(cases, WithPayloadSizes(0))
+		require.EqualError(t, err, "invalid payload size: 0")
+	})
+
+	t.Run("error - prepare fails", func(t *testing.T) {
+		_, err := Run([]Case{{
+			Name: "failing",
+			Prepare: func(int) (func() error, error) {
+				return nil, errors.New("prepare error")
+			},
+		}}, WithPayloadSizes(64))
+		require.EqualError(t, err, "prepare failing on 64 bytes: prepare error")
+	})
+
+	t.Run("error - operation fails", func(t *testing.T) {
+		_, err := Run([]Case{{
+			Name: "failing",
+			Prepare: func(int) (func() error, error) {
+				return func() error {
+					return errors.New("operation error")
+				}, nil
+			},
+		}}, WithPayloadSizes(64))
+		require.EqualError(t, err, "run failing on 64 bytes: operation error")
+	})
+}
+
+func BenchmarkCases(b *testing.B) {
+	cases, err := Cases()
+	require.NoError(b, err)
+
+	for _, c := range cases {
+		for _, size := range DefaultPayloadSizes() {
+			op, err := c.Prepare(size)
+			require.NoError(b, err)
+
+			b.Run(fmt.Sprintf("%s/%dB", c.Name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(size))
+
+				for i := 0; i < b.N; i++ {
+					if err := op(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
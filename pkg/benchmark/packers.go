@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmsdidkey"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	benchmarkKeyURI = "local-lock://benchmark/key/"
+	packerKeyType   = kms.X25519ECDHKWType
+)
+
+// provider of the packers, backed by an in-memory KMS so that the keys of the benchmark don't pollute the agent's.
+type provider struct {
+	storage storage.Provider
+	kms     kms.KeyManager
+	crypto  cryptoapi.Crypto
+	vdr     vdrapi.Registry
+}
+
+func (p *provider) StorageProvider() storage.Provider { return p.storage }
+
+func (p *provider) SecretLock() secretlock.Service { return &noop.NoLock{} }
+
+func (p *provider) KMS() kms.KeyManager { return p.kms }
+
+func (p *provider) Crypto() cryptoapi.Crypto { return p.crypto }
+
+func (p *provider) VDRegistry() vdrapi.Registry { return p.vdr }
+
+// packerCases returns the pack and unpack cases of the authcrypt and anoncrypt packers, sending from and to did:key
+// X25519 keys.
+func packerCases() ([]Case, error) {
+	p := &provider{storage: mem.NewProvider(), vdr: vdr.New()}
+
+	k, err := localkms.New(benchmarkKeyURI, p)
+	if err != nil {
+		return nil, fmt.Errorf("create kms: %w", err)
+	}
+
+	p.kms = k
+
+	p.crypto, err = tinkcrypto.New()
+	if err != nil {
+		return nil, fmt.Errorf("create crypto: %w", err)
+	}
+
+	senderKID, senderDIDKey, _, err := createPackerKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, recipientKey, err := createPackerKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	authPacker, err := authcrypt.New(p, jose.A256CBCHS512)
+	if err != nil {
+		return nil, fmt.Errorf("create authcrypt packer: %w", err)
+	}
+
+	anonPacker, err := anoncrypt.New(p, jose.A256GCM)
+	if err != nil {
+		return nil, fmt.Errorf("create anoncrypt packer: %w", err)
+	}
+
+	sender := []byte(senderKID + "." + senderDIDKey)
+	recipients := [][]byte{recipientKey}
+
+	return []Case{
+		packCase("authcrypt/pack", authPacker, sender, recipients),
+		unpackCase("authcrypt/unpack", authPacker, sender, recipients),
+		packCase("anoncrypt/pack", anonPacker, nil, recipients),
+		unpackCase("anoncrypt/unpack", anonPacker, nil, recipients),
+	}, nil
+}
+
+// createPackerKey creates a key in the KMS, returning its KMS ID, its did:key and its public key as expected by the
+// packers.
+func createPackerKey(k kms.KeyManager) (string, string, []byte, error) {
+	kid, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(packerKeyType)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("create packer key: %w", err)
+	}
+
+	didKey, err := kmsdidkey.BuildDIDKeyByKeyType(pubKeyBytes, packerKeyType)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("build did:key of packer key: %w", err)
+	}
+
+	pubKey := &cryptoapi.PublicKey{}
+
+	if err = json.Unmarshal(pubKeyBytes, pubKey); err != nil {
+		return "", "", nil, fmt.Errorf("unmarshal packer key: %w", err)
+	}
+
+	pubKey.KID = didKey
+
+	pubKeyBytes, err = json.Marshal(pubKey)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("marshal packer key: %w", err)
+	}
+
+	return kid, didKey, pubKeyBytes, nil
+}
+
+func packCase(name string, p packer.Packer, sender []byte, recipients [][]byte) Case {
+	return Case{
+		Name: name,
+		Prepare: func(size int) (func() error, error) {
+			payload := newPayload(size)
+
+			return func() error {
+				_, err := p.Pack(transport.MediaTypeV1PlaintextPayload, payload, sender, recipients)
+
+				return err
+			}, nil
+		},
+	}
+}
+
+func unpackCase(name string, p packer.Packer, sender []byte, recipients [][]byte) Case {
+	return Case{
+		Name: name,
+		Prepare: func(size int) (func() error, error) {
+			envelope, err := p.Pack(transport.MediaTypeV1PlaintextPayload, newPayload(size), sender, recipients)
+			if err != nil {
+				return nil, err
+			}
+
+			return func() error {
+				_, err := p.Unpack(envelope)
+
+				return err
+			}, nil
+		},
+	}
+}
+
+// newPayload returns a DIDComm message of about size bytes.
+func newPayload(size int) []byte {
+	return []byte(fmt.Sprintf(`{"@id":"benchmark","@type":"https://didcomm.org/basicmessage/1.0/message","content":"%s"}`,
+		filler(size)))
+}
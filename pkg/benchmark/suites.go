@@ -0,0 +1,321 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
+)
+
+const (
+	benchmarkContextURL = "https://example.com/benchmark/v1"
+	verificationMethod  = "did:example:benchmark#key-1"
+)
+
+// benchmarkContext defines the terms of the credential signed by the benchmark.
+const benchmarkContext = `{
+  "@context": {
+    "@version": 1.1,
+    "BenchmarkCredential": "https://example.com/benchmark#BenchmarkCredential",
+    "payload": "https://example.com/benchmark#payload"
+  }
+}`
+
+// credential template, the payload claim setting the size of the credential.
+const credentialTemplate = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1", "%s", "` + benchmarkContextURL + `"],
+  "id": "https://example.com/credentials/benchmark",
+  "type": ["VerifiableCredential", "BenchmarkCredential"],
+  "issuer": "did:example:benchmark",
+  "issuanceDate": "2021-01-01T00:00:00Z",
+  "credentialSubject": {"id": "did:example:holder", "payload": "%s"}
+}`
+
+// revealTemplate is the frame of the BBS+ selective disclosure, revealing the payload claim.
+const revealTemplate = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1", "https://w3id.org/security/bbs/v1", "` +
+	benchmarkContextURL + `"],
+  "type": ["VerifiableCredential", "BenchmarkCredential"],
+  "@explicit": true,
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {"@explicit": true, "payload": {}}
+}`
+
+type ldProvider struct {
+	contextStore        ldstore.ContextStore
+	remoteProviderStore ldstore.RemoteProviderStore
+}
+
+func (p *ldProvider) JSONLDContextStore() ldstore.ContextStore {
+	return p.contextStore
+}
+
+func (p *ldProvider) JSONLDRemoteProviderStore() ldstore.RemoteProviderStore {
+	return p.remoteProviderStore
+}
+
+// suiteEnv holds the keys and the document loader of the linked data proof cases.
+type suiteEnv struct {
+	loader       *ld.DocumentLoader
+	ed25519      signature.Signer
+	bbsPrivKey   []byte
+	bbsPubKey    []byte
+	bbsNonce     []byte
+	revealDoc    map[string]interface{}
+	ed25519Suite *ed25519signature2020.Suite
+	bbsSuite     *bbsblssignature2020.Suite
+}
+
+// suiteCases returns the sign and verify cases of the Ed25519Signature2020 and BbsBlsSignature2020 suites, and the
+// derive and verify cases of the BbsBlsSignatureProof2020 suite, on credentials with a payload claim of the size.
+func suiteCases() ([]Case, error) {
+	env, err := newSuiteEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return []Case{
+		{Name: "Ed25519Signature2020/sign", Prepare: env.prepareEd25519Sign},
+		{Name: "Ed25519Signature2020/verify", Prepare: env.prepareEd25519Verify},
+		{Name: "BbsBlsSignature2020/sign", Prepare: env.prepareBBSSign},
+		{Name: "BbsBlsSignature2020/verify", Prepare: env.prepareBBSVerify},
+		{Name: "BbsBlsSignatureProof2020/derive", Prepare: env.prepareBBSDerive},
+		{Name: "BbsBlsSignatureProof2020/verify", Prepare: env.prepareBBSProofVerify},
+	}, nil
+}
+
+func newSuiteEnv() (*suiteEnv, error) {
+	contextStore, err := ldstore.NewContextStore(mem.NewProvider())
+	if err != nil {
+		return nil, fmt.Errorf("create JSON-LD context store: %w", err)
+	}
+
+	remoteProviderStore, err := ldstore.NewRemoteProviderStore(mem.NewProvider())
+	if err != nil {
+		return nil, fmt.Errorf("create JSON-LD remote provider store: %w", err)
+	}
+
+	loader, err := ld.NewDocumentLoader(&ldProvider{contextStore, remoteProviderStore},
+		ld.WithExtraContexts(ldcontext.Document{URL: benchmarkContextURL, Content: []byte(benchmarkContext)}))
+	if err != nil {
+		return nil, fmt.Errorf("create JSON-LD document loader: %w", err)
+	}
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("create ed25519 key: %w", err)
+	}
+
+	bbsPubKey, bbsPrivKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create BBS+ key: %w", err)
+	}
+
+	env := &suiteEnv{
+		loader:   loader,
+		ed25519:  signature.GetEd25519Signer(privKey, pubKey),
+		bbsNonce: []byte("benchmark"),
+	}
+
+	if err = json.Unmarshal([]byte(revealTemplate), &env.revealDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal reveal document: %w", err)
+	}
+
+	if env.bbsPrivKey, err = bbsPrivKey.Marshal(); err != nil {
+		return nil, fmt.Errorf("marshal BBS+ private key: %w", err)
+	}
+
+	if env.bbsPubKey, err = bbsPubKey.Marshal(); err != nil {
+		return nil, fmt.Errorf("marshal BBS+ public key: %w", err)
+	}
+
+	env.ed25519Suite = ed25519signature2020.New(
+		suite.WithSigner(env.ed25519), suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier()))
+	env.bbsSuite = bbsblssignature2020.New(
+		suite.WithSigner(&bbsSigner{privKey: env.bbsPrivKey}),
+		suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))
+
+	return env, nil
+}
+
+func (e *suiteEnv) prepareEd25519Sign(size int) (func() error, error) {
+	return e.prepareSign(size, "https://w3id.org/security/suites/ed25519-2020/v1", "Ed25519Signature2020",
+		e.ed25519Suite)
+}
+
+func (e *suiteEnv) prepareBBSSign(size int) (func() error, error) {
+	return e.prepareSign(size, "https://w3id.org/security/bbs/v1", "BbsBlsSignature2020", e.bbsSuite)
+}
+
+func (e *suiteEnv) prepareEd25519Verify(size int) (func() error, error) {
+	vc, err := e.signedCredential(size, "https://w3id.org/security/suites/ed25519-2020/v1", "Ed25519Signature2020",
+		e.ed25519Suite)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.prepareVerify(vc, e.ed25519Suite, e.ed25519.PublicKeyBytes(), "Ed25519VerificationKey2020")
+}
+
+func (e *suiteEnv) prepareBBSVerify(size int) (func() error, error) {
+	vc, err := e.signedCredential(size, "https://w3id.org/security/bbs/v1", "BbsBlsSignature2020", e.bbsSuite)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.prepareVerify(vc, e.bbsSuite, e.bbsPubKey, "Bls12381G2Key2020")
+}
+
+func (e *suiteEnv) prepareBBSDerive(size int) (func() error, error) {
+	vc, err := e.signedCredential(size, "https://w3id.org/security/bbs/v1", "BbsBlsSignature2020", e.bbsSuite)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := e.derive(vc)
+
+		return err
+	}, nil
+}
+
+func (e *suiteEnv) prepareBBSProofVerify(size int) (func() error, error) {
+	vc, err := e.signedCredential(size, "https://w3id.org/security/bbs/v1", "BbsBlsSignature2020", e.bbsSuite)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := e.derive(vc)
+	if err != nil {
+		return nil, fmt.Errorf("derive credential: %w", err)
+	}
+
+	proofSuite := bbsblssignatureproof2020.New(suite.WithCompactProof(),
+		suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(e.bbsNonce)))
+
+	return e.prepareVerify(derived, proofSuite, e.bbsPubKey, "Bls12381G2Key2020")
+}
+
+// derive derives the BBS+ selective disclosure of the credential revealing its payload.
+func (e *suiteEnv) derive(vc *verifiable.Credential) (*verifiable.Credential, error) {
+	return vc.GenerateBBSSelectiveDisclosure(e.revealDoc, e.bbsNonce,
+		verifiable.WithJSONLDDocumentLoader(e.loader),
+		verifiable.WithPublicKeyFetcher(verifiable.SingleKey(e.bbsPubKey, "Bls12381G2Key2020")))
+}
+
+func (e *suiteEnv) prepareSign(size int, suiteContext, signatureType string,
+	signatureSuite signer.SignatureSuite) (func() error, error) {
+	vc, err := e.credential(size, suiteContext)
+	if err != nil {
+		return nil, err
+	}
+
+	ldpContext := &verifiable.LinkedDataProofContext{
+		SignatureType:           signatureType,
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Suite:                   signatureSuite,
+		VerificationMethod:      verificationMethod,
+	}
+
+	return func() error {
+		vc.Proofs = nil
+
+		return vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(e.loader))
+	}, nil
+}
+
+func (e *suiteEnv) prepareVerify(vc *verifiable.Credential, signatureSuite verifier.SignatureSuite,
+	pubKey []byte, pubKeyType string) (func() error, error) {
+	vcBytes, err := json.Marshal(vc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	opts := []verifiable.CredentialOpt{
+		verifiable.WithJSONLDDocumentLoader(e.loader),
+		verifiable.WithEmbeddedSignatureSuites(signatureSuite),
+		verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, pubKeyType)),
+	}
+
+	return func() error {
+		_, err := verifiable.ParseCredential(vcBytes, opts...)
+
+		return err
+	}, nil
+}
+
+func (e *suiteEnv) signedCredential(size int, suiteContext, signatureType string,
+	signatureSuite signer.SignatureSuite) (*verifiable.Credential, error) {
+	vc, err := e.credential(size, suiteContext)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           signatureType,
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Suite:                   signatureSuite,
+		VerificationMethod:      verificationMethod,
+	}, jsonld.WithDocumentLoader(e.loader))
+	if err != nil {
+		return nil, fmt.Errorf("sign credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+func (e *suiteEnv) credential(size int, suiteContext string) (*verifiable.Credential, error) {
+	vc, err := verifiable.ParseCredential([]byte(fmt.Sprintf(credentialTemplate, suiteContext, filler(size))),
+		verifiable.WithJSONLDDocumentLoader(e.loader), verifiable.WithDisabledProofCheck())
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+// bbsSigner signs the statements of the documents with a BBS+ private key.
+type bbsSigner struct {
+	privKey []byte
+}
+
+func (s *bbsSigner) Sign(data []byte) ([]byte, error) {
+	var msgs [][]byte
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			msgs = append(msgs, []byte(line))
+		}
+	}
+
+	return bbs12381g2pub.New().Sign(msgs, s.privKey)
+}
+
+// filler returns a string of size characters.
+func filler(size int) string {
+	return strings.Repeat("a", size)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+type (
+	// Menu is the menu of actions the responder offers to the requester.
+	Menu = actionmenu.Menu
+	// MenuOption is an action of the menu.
+	MenuOption = actionmenu.MenuOption
+	// Form is the form of parameters of a menu option.
+	Form = actionmenu.Form
+	// FormParam is a parameter of a form.
+	FormParam = actionmenu.FormParam
+	// Perform is the option of the menu performed by the requester.
+	Perform = actionmenu.Perform
+)
+
+// Provider contains dependencies for the action menu protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the action menu service.
+type ProtocolService interface {
+	service.DIDComm
+	SendMenu(connectionID string, menu *actionmenu.Menu) (string, error)
+	RequestMenu(connectionID string) (string, error)
+	Perform(connectionID string, perform *actionmenu.Perform) (string, error)
+	ActiveMenu(connectionID string) (*actionmenu.Menu, error)
+	CloseMenu(connectionID string) error
+}
+
+// Client enable access to action menu API.
+//
+// The requester receives the menus of the responder through the actionmenu.StateMenuReceived message events, the
+// responder is notified of the menu requests and performed options through the actionmenu.StateMenuRequested and
+// actionmenu.StatePerformRequested message events.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of action menu client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(actionmenu.ActionMenu)
+	if err != nil {
+		return nil, err
+	}
+
+	actionMenuSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Action Menu Service failed")
+	}
+
+	return &Client{
+		Event:   actionMenuSvc,
+		service: actionMenuSvc,
+	}, nil
+}
+
+// SendMenu sends a menu to the connection, returning the ID of the menu message.
+func (c *Client) SendMenu(connectionID string, menu *Menu) (string, error) {
+	id, err := c.service.SendMenu(connectionID, menu)
+	if err != nil {
+		return "", fmt.Errorf("action menu client - send menu: %w", err)
+	}
+
+	return id, nil
+}
+
+// RequestMenu asks the connection for its menu, returning the ID of the menu-request message.
+func (c *Client) RequestMenu(connectionID string) (string, error) {
+	id, err := c.service.RequestMenu(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("action menu client - request menu: %w", err)
+	}
+
+	return id, nil
+}
+
+// Perform performs an option of the menu received from the connection, returning the ID of the perform message.
+func (c *Client) Perform(connectionID string, perform *Perform) (string, error) {
+	id, err := c.service.Perform(connectionID, perform)
+	if err != nil {
+		return "", fmt.Errorf("action menu client - perform: %w", err)
+	}
+
+	return id, nil
+}
+
+// ActiveMenu returns the last menu received from the connection.
+func (c *Client) ActiveMenu(connectionID string) (*Menu, error) {
+	menu, err := c.service.ActiveMenu(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("action menu client - active menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// CloseMenu discards the menu received from the connection.
+func (c *Client) CloseMenu(connectionID string) error {
+	err := c.service.CloseMenu(connectionID)
+	if err != nil {
+		return fmt.Errorf("action menu client - close menu: %w", err)
+	}
+
+	return nil
+}
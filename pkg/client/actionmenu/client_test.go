@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "service error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "invalid"})
+		require.EqualError(t, err, "cast service to Action Menu Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("responder and requester", func(t *testing.T) {
+		var sent []service.DIDCommMsgMap
+
+		prov := newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = append(sent, msg.(service.DIDCommMsgMap))
+
+				return nil
+			},
+		})
+
+		client, err := New(prov)
+		require.NoError(t, err)
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, client.RegisterMsgEvent(states))
+
+		_, err = client.RequestMenu(connectionutil.ConnectionID)
+		require.NoError(t, err)
+
+		menuID, err := client.SendMenu(connectionutil.ConnectionID, &Menu{
+			Title:   "Welcome",
+			Options: []MenuOption{{Name: "option-1", Title: "Option 1"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, sent, 2)
+
+		// the menu sent is received back on the same connection.
+		svc, err := prov.Service(actionmenu.ActionMenu)
+		require.NoError(t, err)
+
+		_, err = svc.(service.DIDComm).HandleInbound(sent[1],
+			service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+		require.NoError(t, err)
+
+		state := <-states
+		require.Equal(t, actionmenu.StateMenuReceived, state.StateID)
+
+		menu, err := client.ActiveMenu(connectionutil.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, menuID, menu.ID)
+
+		_, err = client.Perform(connectionutil.ConnectionID, &Perform{Name: "option-1"})
+		require.NoError(t, err)
+		require.Len(t, sent, 3)
+
+		thID, err := sent[2].ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, menuID, thID)
+
+		require.NoError(t, client.CloseMenu(connectionutil.ConnectionID))
+
+		_, err = client.ActiveMenu(connectionutil.ConnectionID)
+		require.True(t, errors.Is(err, actionmenu.ErrMenuNotFound))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		prov := newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		prov.StorageProviderValue.(*mockstore.MockStoreProvider).Store.ErrDelete = errors.New("delete error")
+
+		client, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = client.SendMenu(connectionutil.ConnectionID, &Menu{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu client - send menu")
+
+		_, err = client.RequestMenu(connectionutil.ConnectionID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu client - request menu")
+
+		_, err = client.Perform(connectionutil.ConnectionID, &Perform{Name: "option-1"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu client - perform")
+
+		_, err = client.ActiveMenu(connectionutil.ConnectionID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu client - active menu")
+
+		err = client.CloseMenu(connectionutil.ConnectionID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu client - close menu")
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return actionmenu.New(prov)
+	})
+}
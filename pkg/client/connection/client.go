@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package connection enables the agent to manage its existing connections, such as rotating its DID of a DIDComm v2
+// connection or upgrading a DIDComm v1 connection to DIDComm v2.
+package connection
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/didrotate"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/upgrade"
+)
+
+// ErrUpgradeNotSupported is returned by Upgrade when one of the parties doesn't support DIDComm v2.
+var ErrUpgradeNotSupported = upgrade.ErrNotSupported
+
+type provider interface {
+	DIDRotator() *didrotate.DIDRotator
+	ConnectionUpgrader() *upgrade.Upgrader
+}
+
+// Client enables access to the connection management features.
+type Client struct {
+	didRotator *didrotate.DIDRotator
+	upgrader   *upgrade.Upgrader
+}
+
+// New returns a new connection client.
+func New(prov provider) (*Client, error) {
+	didRotator := prov.DIDRotator()
+	if didRotator == nil {
+		return nil, errors.New("DID rotator is not initialized")
+	}
+
+	upgrader := prov.ConnectionUpgrader()
+	if upgrader == nil {
+		return nil, errors.New("connection upgrader is not initialized")
+	}
+
+	return &Client{didRotator: didRotator, upgrader: upgrader}, nil
+}
+
+// RotateDIDOption configures the DID rotation.
+type RotateDIDOption func(opts *rotateDIDOpts)
+
+type rotateDIDOpts struct {
+	signingKID string
+}
+
+// WithSigningKeyID sets the ID of the key of the current DID signing the from_prior JWT.
+// By default the first Ed25519 authentication key of the current DID is used.
+func WithSigningKeyID(kid string) RotateDIDOption {
+	return func(opts *rotateDIDOpts) {
+		opts.signingKID = kid
+	}
+}
+
+// RotateDID rotates my DID of the DIDComm v2 connection to newDID. The DIDComm v2 messages sent over the connection
+// carry a from_prior JWT, signed with a key of the current DID, until the other party sends a message to newDID.
+func (c *Client) RotateDID(connectionID, newDID string, opts ...RotateDIDOption) error {
+	options := &rotateDIDOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := c.didRotator.RotateConnectionDID(connectionID, options.signingKID, newDID); err != nil {
+		return fmt.Errorf("rotate DID: %w", err)
+	}
+
+	return nil
+}
+
+// Upgrade upgrades the DIDComm v1 connection to DIDComm v2, keeping its DIDs. The DIDComm v2 profiles accepted by the
+// other party are discovered over the connection, which is switched to a common profile on both sides. Both DIDs of
+// the connection need a DIDComm v2 service, otherwise ErrUpgradeNotSupported is returned. Upgrading a DIDComm v2
+// connection is a no-op.
+func (c *Client) Upgrade(connectionID string) error {
+	if err := c.upgrader.Upgrade(connectionID); err != nil {
+		return fmt.Errorf("upgrade connection: %w", err)
+	}
+
+	return nil
+}
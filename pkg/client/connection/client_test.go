@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/didrotate"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/upgrade"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID    = "conn-1"
+	myDID     = "did:test:alice"
+	newDID    = "did:test:alice2"
+	theirDID  = "did:test:bob"
+	keyID     = "#key-1"
+	keyType   = "Ed25519VerificationKey2018"
+	lockKeyID = "local-lock://test/master/key/"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New(&mockProvider{didRotator: &didrotate.DIDRotator{}, upgrader: &upgrade.Upgrader{}})
+		require.NoError(t, err)
+		require.NotNil(t, c)
+	})
+
+	t.Run("error if DID rotator is not initialized", func(t *testing.T) {
+		_, err := New(&mockProvider{})
+		require.EqualError(t, err, "DID rotator is not initialized")
+	})
+
+	t.Run("error if connection upgrader is not initialized", func(t *testing.T) {
+		_, err := New(&mockProvider{didRotator: &didrotate.DIDRotator{}})
+		require.EqualError(t, err, "connection upgrader is not initialized")
+	})
+}
+
+func TestClient_RotateDID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, connections := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		require.NoError(t, c.RotateDID(connID, newDID, WithSigningKeyID(myDID+keyID)))
+
+		record, err := connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.Equal(t, newDID, record.MyDID)
+		require.NotNil(t, record.MyDIDRotation)
+		require.Equal(t, myDID, record.MyDIDRotation.OldDID)
+		require.NotEmpty(t, record.MyDIDRotation.FromPrior)
+	})
+
+	t.Run("error if rotation fails", func(t *testing.T) {
+		p, _ := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		err = c.RotateDID("unknown", newDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rotate DID")
+	})
+}
+
+func TestClient_Upgrade(t *testing.T) {
+	t.Run("error if one of the DIDs has no DIDComm v2 service", func(t *testing.T) {
+		p, connections := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		err = c.Upgrade(connID)
+		require.ErrorIs(t, err, ErrUpgradeNotSupported)
+		require.Contains(t, err.Error(), "upgrade connection")
+
+		record, err := connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.Empty(t, record.MediaTypeProfiles)
+	})
+
+	t.Run("error if upgrade fails", func(t *testing.T) {
+		p, _ := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		err = c.Upgrade("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "upgrade connection")
+	})
+}
+
+type mockProvider struct {
+	didRotator *didrotate.DIDRotator
+	upgrader   *upgrade.Upgrader
+}
+
+func (p *mockProvider) DIDRotator() *didrotate.DIDRotator {
+	return p.didRotator
+}
+
+func (p *mockProvider) ConnectionUpgrader() *upgrade.Upgrader {
+	return p.upgrader
+}
+
+func newProvider(t *testing.T) (*mockProvider, *connection.Recorder) {
+	t.Helper()
+
+	km, err := localkms.New(lockKeyID, mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	docs := map[string]*did.Doc{myDID: newDoc(t, km, myDID), newDID: newDoc(t, km, newDID)}
+
+	p := &mockprovider.Provider{
+		KMSValue:    km,
+		CryptoValue: c,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc, ok := docs[didID]
+				if !ok {
+					return nil, fmt.Errorf("DID %s not found", didID)
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+
+	connections, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, connections.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	didRotator, err := didrotate.New(p)
+	require.NoError(t, err)
+
+	upgrader, err := upgrade.New(p)
+	require.NoError(t, err)
+
+	return &mockProvider{didRotator: didRotator, upgrader: upgrader}, connections
+}
+
+func newDoc(t *testing.T, km kms.KeyManager, id string) *did.Doc {
+	t.Helper()
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes(keyID, keyType, id, pubKey)
+
+	return &did.Doc{
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+	}
+}
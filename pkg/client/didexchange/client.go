@@ -334,41 +334,40 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 }
 
 // QueryConnections queries connections matching given criteria(parameters).
-func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) { //nolint: gocyclo
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
-	//  also results needs to be paged.
-	records, err := c.connectionStore.QueryConnectionRecords()
+func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
+	page, err := c.QueryConnectionsPage(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed query connections: %w", err)
+		return nil, err
 	}
 
-	var result []*Connection
-
-	for _, record := range records {
-		if request.State != "" && request.State != record.State {
-			continue
-		}
-
-		if request.InvitationID != "" && request.InvitationID != record.InvitationID {
-			continue
-		}
-
-		if request.ParentThreadID != "" && request.ParentThreadID != record.ParentThreadID {
-			continue
-		}
+	return page.Connections, nil
+}
 
-		if request.MyDID != "" && request.MyDID != record.MyDID {
-			continue
-		}
+// QueryConnectionsPage queries connections matching given criteria(parameters), the results being paged
+// with the Limit and PageToken of the criteria.
+func (c *Client) QueryConnectionsPage(request *QueryConnectionsParams) (*ConnectionsPage, error) {
+	result, err := c.connectionStore.QueryConnections(&connection.QueryParams{
+		TheirDID:       request.TheirDID,
+		MyDID:          request.MyDID,
+		State:          request.State,
+		InvitationID:   request.InvitationID,
+		ParentThreadID: request.ParentThreadID,
+		CreatedAfter:   request.CreatedAfter,
+		CreatedBefore:  request.CreatedBefore,
+		Limit:          request.Limit,
+		PageToken:      request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed query connections: %w", err)
+	}
 
-		if request.TheirDID != "" && request.TheirDID != record.TheirDID {
-			continue
-		}
+	page := &ConnectionsPage{NextPageToken: result.NextPageToken}
 
-		result = append(result, &Connection{Record: record})
+	for _, record := range result.Records {
+		page.Connections = append(page.Connections, &Connection{Record: record})
 	}
 
-	return result, nil
+	return page, nil
 }
 
 // GetConnection fetches single connection record for given id.
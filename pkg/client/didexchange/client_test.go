@@ -1133,6 +1133,61 @@ func TestClient_QueryConnectionsByParams(t *testing.T) { // nolint: gocyclo
 		}
 	})
 
+	t.Run("test get connections page", func(t *testing.T) {
+		svc, err := didexchange.New(&mockprotocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		storageProvider := mem.NewProvider()
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			StorageProviderValue:              storageProvider,
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: svc,
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		const count = 5
+		for i := 0; i < count; i++ {
+			require.NoError(t, c.connectionStore.SaveConnectionRecord(&connection.Record{
+				ConnectionID: fmt.Sprint(i),
+				State:        "completed",
+				MyDID:        "my_did",
+			}))
+		}
+
+		params := &QueryConnectionsParams{MyDID: "my_did", Limit: 2}
+
+		var ids []string
+
+		for {
+			page, err := c.QueryConnectionsPage(params)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Connections), params.Limit)
+
+			for _, conn := range page.Connections {
+				ids = append(ids, conn.ConnectionID)
+			}
+
+			if page.NextPageToken == "" {
+				break
+			}
+
+			params.PageToken = page.NextPageToken
+		}
+
+		require.ElementsMatch(t, []string{"0", "1", "2", "3", "4"}, ids)
+
+		_, err = c.QueryConnectionsPage(&QueryConnectionsParams{PageToken: "invalid!"})
+		require.True(t, errors.Is(err, connection.ErrInvalidPageToken))
+	})
+
 	t.Run("test get connections error", func(t *testing.T) {
 		svc, err := didexchange.New(&mockprotocol.MockProvider{
 			ServiceMap: map[string]interface{}{
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
@@ -43,6 +45,29 @@ type QueryConnectionsParams struct {
 
 	// TheirRole is other party's role
 	TheirRole string `json:"their_role,omitempty"`
+
+	// CreatedAfter limits the results to the connections created after this time
+	CreatedAfter time.Time `json:"created_after,omitempty"`
+
+	// CreatedBefore limits the results to the connections created before this time
+	CreatedBefore time.Time `json:"created_before,omitempty"`
+
+	// Limit is the maximum number of connections returned, all the connections are returned if not set
+	Limit int `json:"limit,omitempty"`
+
+	// PageToken is the token of the page of the results to return, as returned with the previous page
+	PageToken string `json:"page_token,omitempty"`
+}
+
+// ConnectionsPage model
+//
+// This is used to represent a page of query connections results.
+//
+type ConnectionsPage struct {
+	Connections []*Connection
+
+	// NextPageToken is the token to query the next page of the results, empty on the last page
+	NextPageToken string
 }
 
 // Connection model
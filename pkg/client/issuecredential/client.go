@@ -36,6 +36,19 @@ type (
 	// IssueCredential contains as attached payload the credentials being issued and is
 	// sent in response to a valid Invitation Credential message.
 	IssueCredential issuecredential.IssueCredential
+	// OfferCredentialV3 is a message sent by the Issuer to the potential Holder,
+	// describing the credential they intend to offer, over DIDComm V2.
+	OfferCredentialV3 issuecredential.OfferCredentialV3
+	// ProposeCredentialV3 is an optional message sent by the potential Holder to the Issuer
+	// to initiate the protocol or in response to a offer-credential message when the Holder
+	// wants some adjustments made to the credential data offered by Issuer, over DIDComm V2.
+	ProposeCredentialV3 issuecredential.ProposeCredentialV3
+	// RequestCredentialV3 is a message sent by the potential Holder to the Issuer,
+	// to request the issuance of a credential, over DIDComm V2.
+	RequestCredentialV3 issuecredential.RequestCredentialV3
+	// IssueCredentialV3 contains as attached payload the credentials being issued and is
+	// sent in response to a valid RequestCredentialV3 message, over DIDComm V2.
+	IssueCredentialV3 issuecredential.IssueCredentialV3
 	// Action contains helpful information about action.
 	Action issuecredential.Action
 )
@@ -103,6 +116,17 @@ func (c *Client) SendOffer(offer *OfferCredential, myDID, theirDID string) (stri
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
 }
 
+// SendOfferV3 is used by the Issuer to send an offer over DIDComm V2.
+func (c *Client) SendOfferV3(offer *OfferCredentialV3, myDID, theirDID string) (string, error) {
+	if offer == nil {
+		return "", errEmptyOffer
+	}
+
+	offer.Type = issuecredential.OfferCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
+}
+
 // SendProposal is used by the Holder to send a proposal.
 func (c *Client) SendProposal(proposal *ProposeCredential, myDID, theirDID string) (string, error) {
 	if proposal == nil {
@@ -114,6 +138,17 @@ func (c *Client) SendProposal(proposal *ProposeCredential, myDID, theirDID strin
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
 }
 
+// SendProposalV3 is used by the Holder to send a proposal over DIDComm V2.
+func (c *Client) SendProposalV3(proposal *ProposeCredentialV3, myDID, theirDID string) (string, error) {
+	if proposal == nil {
+		return "", errEmptyProposal
+	}
+
+	proposal.Type = issuecredential.ProposeCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
+}
+
 // SendRequest is used by the Holder to send a request.
 func (c *Client) SendRequest(request *RequestCredential, myDID, theirDID string) (string, error) {
 	if request == nil {
@@ -125,12 +160,29 @@ func (c *Client) SendRequest(request *RequestCredential, myDID, theirDID string)
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
 }
 
+// SendRequestV3 is used by the Holder to send a request over DIDComm V2.
+func (c *Client) SendRequestV3(request *RequestCredentialV3, myDID, theirDID string) (string, error) {
+	if request == nil {
+		return "", errEmptyRequest
+	}
+
+	request.Type = issuecredential.RequestCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
 // NOTE: For async usage.
 func (c *Client) AcceptProposal(piID string, msg *OfferCredential) error {
 	return c.service.ActionContinue(piID, WithOfferCredential(msg))
 }
 
+// AcceptProposalV3 is used when the Issuer is willing to accept the DIDComm V2 proposal.
+// NOTE: For async usage.
+func (c *Client) AcceptProposalV3(piID string, msg *OfferCredentialV3) error {
+	return c.service.ActionContinue(piID, WithOfferCredentialV3(msg))
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 // NOTE: For async usage.
 func (c *Client) DeclineProposal(piID, reason string) error {
@@ -154,12 +206,24 @@ func (c *Client) NegotiateProposal(piID string, msg *ProposeCredential) error {
 	return c.service.ActionContinue(piID, WithProposeCredential(msg))
 }
 
+// NegotiateProposalV3 is used when the Holder wants to negotiate about a DIDComm V2 offer he received.
+// NOTE: For async usage. This function can be used only after receiving OfferCredentialV3.
+func (c *Client) NegotiateProposalV3(piID string, msg *ProposeCredentialV3) error {
+	return c.service.ActionContinue(piID, WithProposeCredentialV3(msg))
+}
+
 // AcceptRequest is used when the Issuer is willing to accept the request.
 // NOTE: For async usage.
 func (c *Client) AcceptRequest(piID string, msg *IssueCredential) error {
 	return c.service.ActionContinue(piID, WithIssueCredential(msg))
 }
 
+// AcceptRequestV3 is used when the Issuer is willing to accept the DIDComm V2 request.
+// NOTE: For async usage.
+func (c *Client) AcceptRequestV3(piID string, msg *IssueCredentialV3) error {
+	return c.service.ActionContinue(piID, WithIssueCredentialV3(msg))
+}
+
 // DeclineRequest is used when the Issuer does not want to accept the request.
 // NOTE: For async usage.
 func (c *Client) DeclineRequest(piID, reason string) error {
@@ -215,6 +279,38 @@ func WithIssueCredential(msg *IssueCredential) issuecredential.Opt {
 	return issuecredential.WithIssueCredential(&origin)
 }
 
+// WithProposeCredentialV3 allows providing ProposeCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithProposeCredentialV3(msg *ProposeCredentialV3) issuecredential.Opt {
+	origin := issuecredential.ProposeCredentialV3(*msg)
+
+	return issuecredential.WithProposeCredentialV3(&origin)
+}
+
+// WithRequestCredentialV3 allows providing RequestCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithRequestCredentialV3(msg *RequestCredentialV3) issuecredential.Opt {
+	origin := issuecredential.RequestCredentialV3(*msg)
+
+	return issuecredential.WithRequestCredentialV3(&origin)
+}
+
+// WithOfferCredentialV3 allows providing OfferCredentialV3 message
+// USAGE: This message should be provided after receiving a ProposeCredentialV3 message.
+func WithOfferCredentialV3(msg *OfferCredentialV3) issuecredential.Opt {
+	origin := issuecredential.OfferCredentialV3(*msg)
+
+	return issuecredential.WithOfferCredentialV3(&origin)
+}
+
+// WithIssueCredentialV3 allows providing IssueCredentialV3 message
+// USAGE: This message should be provided after receiving a RequestCredentialV3 message.
+func WithIssueCredentialV3(msg *IssueCredentialV3) issuecredential.Opt {
+	origin := issuecredential.IssueCredentialV3(*msg)
+
+	return issuecredential.WithIssueCredentialV3(&origin)
+}
+
 // WithFriendlyNames allows providing names for the credentials.
 // USAGE: This function should be used when the Holder receives IssueCredential message.
 func WithFriendlyNames(names ...string) issuecredential.Opt {
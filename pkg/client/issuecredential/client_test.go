@@ -316,3 +316,162 @@ func TestClient_DeclineCredential(t *testing.T) {
 
 	require.NoError(t, client.DeclineCredential("PIID", "the reason"))
 }
+
+func TestClient_SendOfferV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msg.Type(), issuecredential.OfferCredentialMsgTypeV3)
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendOfferV3(&OfferCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty offer", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendOfferV3(nil, Alice, Bob)
+		require.Empty(t, piid)
+		require.EqualError(t, err, errEmptyOffer.Error())
+	})
+}
+
+func TestClient_SendProposalV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msg.Type(), issuecredential.ProposeCredentialMsgTypeV3)
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendProposalV3(&ProposeCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty proposal", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendProposalV3(nil, Alice, Bob)
+		require.Empty(t, piid)
+		require.EqualError(t, err, errEmptyProposal.Error())
+	})
+}
+
+func TestClient_SendRequestV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msg.Type(), issuecredential.RequestCredentialMsgTypeV3)
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendRequestV3(&RequestCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty request", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		piid, err := client.SendRequestV3(nil, Alice, Bob)
+		require.Empty(t, piid)
+		require.EqualError(t, err, errEmptyRequest.Error())
+	})
+}
+
+func TestClient_AcceptProposalV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptProposalV3("PIID", &OfferCredentialV3{}))
+}
+
+func TestClient_NegotiateProposalV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.NegotiateProposalV3("PIID", &ProposeCredentialV3{}))
+}
+
+func TestClient_AcceptRequestV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptRequestV3("PIID", &IssueCredentialV3{}))
+}
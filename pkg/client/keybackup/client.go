@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+)
+
+type (
+	// Consent is the consent of the user to the custodian holding the keys backed up.
+	Consent = keybackup.Consent
+	// RecoveryRequest is a request to recover the keys of a backup.
+	RecoveryRequest = keybackup.RecoveryRequest
+)
+
+// Provider contains dependencies for the key backup protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the key backup service.
+type ProtocolService interface {
+	service.DIDComm
+	RequestKey(connectionID string) (string, error)
+	Backup(connectionID, backupID string, keyIDs []string, consent *keybackup.Consent) (string, error)
+	RequestRecovery(connectionID, backupID string) (string, error)
+	RecoveryRequest(requestID string) (*keybackup.RecoveryRequest, error)
+	AcceptRecovery(requestID string) error
+	DeclineRecovery(requestID, reason string) error
+}
+
+// Client enable access to key backup API.
+//
+// The key owner gets the backup key of the custodian with RequestKey, backs up keys with Backup once the
+// keybackup.StateKeyReceived message event is received, and recovers them later with RequestRecovery, possibly from
+// another device: the keys are imported in the KMS on the keybackup.StateKeysRecovered message event. The custodian
+// accepts or declines the recovery requests received through the keybackup.StateRecoveryRequested message events.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of key backup client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(keybackup.KeyBackup)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBackupSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Key Backup Service failed")
+	}
+
+	return &Client{
+		Event:   keyBackupSvc,
+		service: keyBackupSvc,
+	}, nil
+}
+
+// RequestKey asks the custodian of the connection for its backup public key, returning the ID of the request.
+func (c *Client) RequestKey(connectionID string) (string, error) {
+	id, err := c.service.RequestKey(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("key backup client - request key: %w", err)
+	}
+
+	return id, nil
+}
+
+// Backup backs up the keys of the KMS with the given IDs to the custodian of the connection, with the consent of
+// the user. A backup ID is generated if empty, the ID of the backup is returned.
+func (c *Client) Backup(connectionID, backupID string, keyIDs []string, consent *Consent) (string, error) {
+	id, err := c.service.Backup(connectionID, backupID, keyIDs, consent)
+	if err != nil {
+		return "", fmt.Errorf("key backup client - backup: %w", err)
+	}
+
+	return id, nil
+}
+
+// RequestRecovery asks the custodian of the connection to recover the keys of the backup, returning the ID of the
+// request.
+func (c *Client) RequestRecovery(connectionID, backupID string) (string, error) {
+	id, err := c.service.RequestRecovery(connectionID, backupID)
+	if err != nil {
+		return "", fmt.Errorf("key backup client - request recovery: %w", err)
+	}
+
+	return id, nil
+}
+
+// RecoveryRequest returns the recovery request received with the given ID, if not handled yet.
+func (c *Client) RecoveryRequest(requestID string) (*RecoveryRequest, error) {
+	request, err := c.service.RecoveryRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("key backup client - recovery request: %w", err)
+	}
+
+	return request, nil
+}
+
+// AcceptRecovery sends the keys of the backup to the requester of the recovery.
+func (c *Client) AcceptRecovery(requestID string) error {
+	if err := c.service.AcceptRecovery(requestID); err != nil {
+		return fmt.Errorf("key backup client - accept recovery: %w", err)
+	}
+
+	return nil
+}
+
+// DeclineRecovery declines the recovery request with the given reason.
+func (c *Client) DeclineRecovery(requestID, reason string) error {
+	if err := c.service.DeclineRecovery(requestID, reason); err != nil {
+		return fmt.Errorf("key backup client - decline recovery: %w", err)
+	}
+
+	return nil
+}
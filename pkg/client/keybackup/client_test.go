@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "service error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "invalid"})
+		require.EqualError(t, err, "cast service to Key Backup Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("owner and custodian", func(t *testing.T) {
+		var sent []service.DIDCommMsgMap
+
+		prov := newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = append(sent, msg.(service.DIDCommMsgMap))
+
+				return nil
+			},
+		})
+
+		client, err := New(prov)
+		require.NoError(t, err)
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, client.RegisterMsgEvent(states))
+
+		// the messages sent are received back on the same connection, the agent is its own custodian.
+		svc, err := prov.Service(keybackup.KeyBackup)
+		require.NoError(t, err)
+
+		deliver := func(expectedState string) {
+			_, err = svc.(service.DIDComm).HandleInbound(sent[len(sent)-1],
+				service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+			require.NoError(t, err)
+			require.Equal(t, expectedState, (<-states).StateID)
+		}
+
+		_, err = client.RequestKey(connectionutil.ConnectionID)
+		require.NoError(t, err)
+
+		deliver(keybackup.StateKeyRequested)
+		deliver(keybackup.StateKeyReceived)
+
+		kid, _, err := prov.KMSValue.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		backupID, err := client.Backup(connectionutil.ConnectionID, "", []string{kid}, &Consent{Statement: "I agree"})
+		require.NoError(t, err)
+		require.NotEmpty(t, backupID)
+
+		deliver(keybackup.StateBackupReceived)
+		deliver(keybackup.StateBackupStored)
+
+		requestID, err := client.RequestRecovery(connectionutil.ConnectionID, backupID)
+		require.NoError(t, err)
+
+		deliver(keybackup.StateRecoveryRequested)
+
+		request, err := client.RecoveryRequest(requestID)
+		require.NoError(t, err)
+		require.Equal(t, backupID, request.BackupID)
+
+		require.NoError(t, client.DeclineRecovery(requestID, "identity not verified"))
+
+		deliver(keybackup.StateRecoveryDeclined)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}))
+		require.NoError(t, err)
+
+		_, err = client.RequestKey(connectionutil.ConnectionID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key backup client - request key")
+
+		_, err = client.Backup(connectionutil.ConnectionID, "", []string{"kid"}, &Consent{Statement: "I agree"})
+		require.True(t, errors.Is(err, keybackup.ErrCustodianKeyNotFound))
+
+		_, err = client.RequestRecovery(connectionutil.ConnectionID, "backup-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key backup client - request recovery")
+
+		_, err = client.RecoveryRequest("unknown")
+		require.True(t, errors.Is(err, keybackup.ErrRecoveryRequestNotFound))
+
+		err = client.AcceptRecovery("unknown")
+		require.True(t, errors.Is(err, keybackup.ErrRecoveryRequestNotFound))
+
+		err = client.DeclineRecovery("unknown", "")
+		require.True(t, errors.Is(err, keybackup.ErrRecoveryRequestNotFound))
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return keybackup.New(prov)
+	})
+}
@@ -43,6 +43,15 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// KeylistQuery queries a page of the keys added to the router.
+	KeylistQuery(connID string, paginate *mediator.Paginate, options ...mediator.ClientOption) (*mediator.Keylist, error)
+
+	// ComposeChain composes a mediation chain of the registered routers.
+	ComposeChain(connIDs []string) (string, error)
+
+	// RemoveChain removes the mediation chain.
+	RemoveChain(chainID string) error
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -109,3 +118,84 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// ComposeChain composes a mediation chain of the registered routers (passed in connIDs), the first router being the
+// one the messages are sent to and the last one the router closest to the agent. The messages sent to the agent are
+// forwarded through every router of the chain.
+//
+// The chain ID returned is usable wherever a router connection ID is accepted, like the router connections of the
+// DID exchange and out-of-band clients: the service endpoint built for the chain has the endpoint of the first router
+// and the routing keys of all the routers, and the agent's keys are added to the last router.
+func (c *Client) ComposeChain(connIDs ...string) (string, error) {
+	chainID, err := c.routeSvc.ComposeChain(connIDs)
+	if err != nil {
+		return "", fmt.Errorf("compose mediation chain: %w", err)
+	}
+
+	return chainID, nil
+}
+
+// RemoveChain removes the mediation chain, its routers stay registered.
+func (c *Client) RemoveChain(chainID string) error {
+	if err := c.routeSvc.RemoveChain(chainID); err != nil {
+		return fmt.Errorf("remove mediation chain: %w", err)
+	}
+
+	return nil
+}
+
+// QueryKeylist returns an iterator over the pages of the recipient keys the agent added to the router, each page
+// holding at most limit keys (the router's default page size when limit isn't positive). The pages are queried from
+// the router as the iterator advances.
+func (c *Client) QueryKeylist(connID string, limit int) *KeylistIterator {
+	return &KeylistIterator{
+		routeSvc: c.routeSvc,
+		options:  c.options,
+		connID:   connID,
+		limit:    limit,
+	}
+}
+
+// KeylistIterator iterates over the pages of the recipient keys added to a router.
+type KeylistIterator struct {
+	routeSvc protocolService
+	options  []mediator.ClientOption
+	connID   string
+	limit    int
+	offset   int
+	keys     []string
+	done     bool
+}
+
+// Next queries the next page of keys from the router, returning false once all the pages have been queried.
+func (i *KeylistIterator) Next() (bool, error) {
+	if i.done {
+		return false, nil
+	}
+
+	keylist, err := i.routeSvc.KeylistQuery(i.connID, &mediator.Paginate{Limit: i.limit, Offset: i.offset},
+		i.options...)
+	if err != nil {
+		return false, fmt.Errorf("query keylist : %w", err)
+	}
+
+	i.keys = make([]string, 0, len(keylist.Keys))
+
+	for _, key := range keylist.Keys {
+		i.keys = append(i.keys, key.RecipientKey)
+	}
+
+	// routers not paginating the keylist return all the keys at once
+	if keylist.Pagination == nil || keylist.Pagination.Remaining <= 0 || keylist.Pagination.Offset <= i.offset {
+		i.done = true
+	} else {
+		i.offset = keylist.Pagination.Offset
+	}
+
+	return len(i.keys) > 0, nil
+}
+
+// Keys returns the keys of the current page.
+func (i *KeylistIterator) Keys() []string {
+	return i.keys
+}
@@ -170,3 +170,155 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_ComposeChain(t *testing.T) {
+	t.Run("composes the chain", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				ComposeChainFunc: func(connIDs []string) (string, error) {
+					require.Equal(t, []string{"outer", "inner"}, connIDs)
+
+					return "chain-id", nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		chainID, err := c.ComposeChain("outer", "inner")
+		require.NoError(t, err)
+		require.Equal(t, "chain-id", chainID)
+	})
+
+	t.Run("wraps compose error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				ComposeChainFunc: func([]string) (string, error) {
+					return "", expected
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.ComposeChain("outer", "inner")
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "compose mediation chain")
+	})
+}
+
+func TestClient_RemoveChain(t *testing.T) {
+	c, err := New(&mockprovider.Provider{ServiceValue: &mockroute.MockMediatorSvc{}})
+	require.NoError(t, err)
+	require.NoError(t, c.RemoveChain("chain-id"))
+
+	expected := errors.New("test")
+	c, err = New(&mockprovider.Provider{ServiceValue: &mockroute.MockMediatorSvc{RemoveChainErr: expected}})
+	require.NoError(t, err)
+
+	err = c.RemoveChain("chain-id")
+	require.True(t, errors.Is(err, expected))
+	require.Contains(t, err.Error(), "remove mediation chain")
+}
+
+func TestClient_QueryKeylist(t *testing.T) {
+	t.Run("iterates the keylist pages", func(t *testing.T) {
+		keys := []string{"key-0", "key-1", "key-2", "key-3", "key-4"}
+
+		var queries []mediator.Paginate
+
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+					require.Equal(t, "conn", connID)
+
+					queries = append(queries, *paginate)
+
+					end := paginate.Offset + paginate.Limit
+					if end > len(keys) {
+						end = len(keys)
+					}
+
+					keylist := &mediator.Keylist{Pagination: &mediator.Pagination{
+						Count: end - paginate.Offset, Offset: end, Remaining: len(keys) - end,
+					}}
+
+					for _, key := range keys[paginate.Offset:end] {
+						keylist.Keys = append(keylist.Keys, mediator.KeylistKey{RecipientKey: key})
+					}
+
+					return keylist, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		it := c.QueryKeylist("conn", 2)
+
+		var pages [][]string
+
+		for {
+			more, err := it.Next()
+			require.NoError(t, err)
+
+			if !more {
+				break
+			}
+
+			pages = append(pages, it.Keys())
+		}
+
+		require.Equal(t, [][]string{{"key-0", "key-1"}, {"key-2", "key-3"}, {"key-4"}}, pages)
+		require.Equal(t, []mediator.Paginate{{Limit: 2}, {Limit: 2, Offset: 2}, {Limit: 2, Offset: 4}}, queries)
+
+		// the iterator is exhausted
+		more, err := it.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+		require.Len(t, queries, 3)
+	})
+
+	t.Run("router not paginating the keylist", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+					return &mediator.Keylist{Keys: []mediator.KeylistKey{{RecipientKey: "key-0"}}}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		it := c.QueryKeylist("conn", 0)
+
+		more, err := it.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+		require.Equal(t, []string{"key-0"}, it.Keys())
+
+		more, err = it.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{ServiceValue: &mockroute.MockMediatorSvc{}})
+		require.NoError(t, err)
+
+		more, err := c.QueryKeylist("conn", 10).Next()
+		require.NoError(t, err)
+		require.False(t, more)
+	})
+
+	t.Run("wraps keylist query error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+					return nil, errors.New("keylist error")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.QueryKeylist("conn", 10).Next()
+		require.EqualError(t, err, "query keylist : keylist error")
+	})
+}
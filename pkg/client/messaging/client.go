@@ -9,13 +9,16 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
@@ -29,6 +32,8 @@ const (
 
 	// errors.
 	errMsgDestinationMissing = "missing message destination"
+	errResponseTypeMissing   = "missing response message type"
+	errMsgTypeMissing        = "missing message type"
 )
 
 var logger = log.New("aries-framework/client/messaging")
@@ -77,6 +82,22 @@ type sendMsgOpts struct {
 
 	// context for await reply operation.
 	waitForResponseCtx context.Context
+
+	// number of times a request is resent when no reply is received before the attempt timeout.
+	retries int
+
+	// time to wait for the reply of a request before resending it.
+	attemptTimeout time.Duration
+}
+
+// PlainMessage is a DIDComm V2 plaintext message, used to send messages of custom protocols without a protocol
+// service.
+type PlainMessage struct {
+	// ID of the message, generated if empty. The ID is the thread ID of the replies.
+	ID          string                   `json:"id,omitempty"`
+	Type        string                   `json:"type"`
+	Body        json.RawMessage          `json:"body"`
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
 }
 
 // SendMessageOpions is the options for choosing message destinations.
@@ -112,6 +133,15 @@ func WaitForResponse(ctx context.Context, responseType string) SendMessageOpions
 	}
 }
 
+// WithRetries option to resend a request up to the given number of times when no reply is received within the
+// attempt timeout. It only applies to Request, a request is sent once by default.
+func WithRetries(retries int, attemptTimeout time.Duration) SendMessageOpions {
+	return func(opts *sendMsgOpts) {
+		opts.retries = retries
+		opts.attemptTimeout = attemptTimeout
+	}
+}
+
 // messageDispatcher is message dispatch action which returns id of the message sent or error if it fails.
 type messageDispatcher func() error
 
@@ -168,24 +198,12 @@ func (c *Client) Send(msg json.RawMessage, opts ...SendMessageOpions) (json.RawM
 		opt(sendOpts)
 	}
 
-	var action messageDispatcher
-
 	didCommMsg, err := prepareMessage(msg)
 	if err != nil {
 		return nil, err
 	}
 
-	switch {
-	case sendOpts.connectionID != "":
-		action, err = c.sendToConnection(didCommMsg, sendOpts.connectionID)
-	case sendOpts.theirDID != "":
-		action, err = c.sendToTheirDID(didCommMsg, sendOpts.theirDID)
-	case sendOpts.destination != nil:
-		action, err = c.sendToDestination(didCommMsg, sendOpts.destination)
-	default:
-		return nil, fmt.Errorf(errMsgDestinationMissing)
-	}
-
+	action, err := c.dispatcher(didCommMsg, sendOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +211,25 @@ func (c *Client) Send(msg json.RawMessage, opts ...SendMessageOpions) (json.RawM
 	return c.sendAndWaitForReply(sendOpts.waitForResponseCtx, action, didCommMsg.ID(), sendOpts.responseMsgType)
 }
 
+// SendPlainMessage sends the DIDComm V2 message based on destination options provided. The reply of the type given
+// with the WaitForResponse option is awaited on the thread of the message.
+func (c *Client) SendPlainMessage(msg *PlainMessage, opts ...SendMessageOpions) (json.RawMessage, error) {
+	if msg.Type == "" {
+		return nil, errors.New(errMsgTypeMissing)
+	}
+
+	if len(msg.Body) == 0 {
+		msg.Body = json.RawMessage("{}")
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return c.Send(raw, opts...)
+}
+
 // Reply sends reply to existing message.
 func (c *Client) Reply(ctx context.Context, msg json.RawMessage, msgID string, startNewThread bool,
 	waitForResponse string) (json.RawMessage, error) {
@@ -218,6 +255,113 @@ func (c *Client) Reply(ctx context.Context, msg json.RawMessage, msgID string, s
 	return c.sendAndWaitForReply(ctx, action, "", waitForResponse)
 }
 
+// Request sends the message to the destination chosen by the send options, waits for the reply of the given type
+// on the thread of the message and decodes the reply message into the response, if not nil.
+// The message is resent when no reply is received before the attempt timeout, up to the number of retries set with
+// the WithRetries option. Returns an error if the context is done before a reply is received, or if all the attempts
+// timed out.
+func (c *Client) Request(ctx context.Context, msg interface{}, responseType string, response interface{},
+	opts ...SendMessageOpions) error {
+	if responseType == "" {
+		return errors.New(errResponseTypeMissing)
+	}
+
+	sendOpts := &sendMsgOpts{}
+
+	for _, opt := range opts {
+		opt(sendOpts)
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	didCommMsg, err := prepareMessage(raw)
+	if err != nil {
+		return err
+	}
+
+	action, err := c.dispatcher(didCommMsg, sendOpts)
+	if err != nil {
+		return err
+	}
+
+	// each attempt may get a reply, the replies not read don't block the inbound message handling.
+	notificationCh, unregister, err := c.registerReplyHandler(didCommMsg.ID(), responseType, sendOpts.retries+1)
+	if err != nil {
+		return err
+	}
+
+	defer unregister()
+
+	reply, err := sendWithRetries(ctx, action, notificationCh, sendOpts)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	var topic struct {
+		Message json.RawMessage `json:"message"`
+	}
+
+	if err = json.Unmarshal(reply, &topic); err != nil {
+		return fmt.Errorf("failed to unmarshal reply: %w", err)
+	}
+
+	if err = json.Unmarshal(topic.Message, response); err != nil {
+		return fmt.Errorf("failed to decode reply: %w", err)
+	}
+
+	return nil
+}
+
+// sendWithRetries sends the message and waits for the reply, resending the message each time the attempt times out.
+func sendWithRetries(ctx context.Context, action messageDispatcher, notificationCh chan NotificationPayload,
+	opts *sendMsgOpts) (json.RawMessage, error) {
+	for attempt := 0; ; attempt++ {
+		if err := action(); err != nil {
+			return nil, err
+		}
+
+		reply, err := waitForAttempt(ctx, notificationCh, opts.attemptTimeout)
+		if err == nil || ctx.Err() != nil || attempt >= opts.retries {
+			return reply, err
+		}
+
+		logger.Debugf("no reply received, resending request (attempt %d of %d)", attempt+2, opts.retries+1)
+	}
+}
+
+func waitForAttempt(ctx context.Context, notificationCh chan NotificationPayload,
+	timeout time.Duration) (json.RawMessage, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return waitForResponse(ctx, notificationCh)
+}
+
+// dispatcher returns the send action for the destination chosen by the options.
+func (c *Client) dispatcher(msg service.DIDCommMsgMap, opts *sendMsgOpts) (messageDispatcher, error) {
+	switch {
+	case opts.connectionID != "":
+		return c.sendToConnection(msg, opts.connectionID)
+	case opts.theirDID != "":
+		return c.sendToTheirDID(msg, opts.theirDID)
+	case opts.destination != nil:
+		return c.sendToDestination(msg, opts.destination)
+	default:
+		return nil, fmt.Errorf(errMsgDestinationMissing)
+	}
+}
+
 func (c *Client) sendToConnection(msg service.DIDCommMsgMap, connectionID string) (messageDispatcher, error) {
 	conn, err := c.connectionLookup.GetConnectionRecord(connectionID)
 	if err != nil {
@@ -225,7 +369,7 @@ func (c *Client) sendToConnection(msg service.DIDCommMsgMap, connectionID string
 	}
 
 	return func() error {
-		return c.ctx.Messenger().Send(msg, conn.MyDID, conn.TheirDID)
+		return c.ctx.Messenger().Send(msg, conn.MyDID, conn.TheirDID, withMsgVersion(msg))
 	}, nil
 }
 
@@ -246,7 +390,7 @@ func (c *Client) sendToTheirDID(msg service.DIDCommMsgMap, theirDID string) (mes
 
 	if conn != nil {
 		return func() error {
-			return c.ctx.Messenger().Send(msg, conn.MyDID, conn.TheirDID)
+			return c.ctx.Messenger().Send(msg, conn.MyDID, conn.TheirDID, withMsgVersion(msg))
 		}, nil
 	}
 
@@ -267,7 +411,7 @@ func (c *Client) sendToDestination(msg service.DIDCommMsgMap, dest *service.Dest
 	didKey, _ := fingerprint.CreateDIDKey(sigPubKey)
 
 	return func() error {
-		return c.ctx.Messenger().SendToDestination(msg, didKey, dest)
+		return c.ctx.Messenger().SendToDestination(msg, didKey, dest, withMsgVersion(msg))
 	}, nil
 }
 
@@ -276,39 +420,17 @@ func (c *Client) sendAndWaitForReply(ctx context.Context, action messageDispatch
 	var notificationCh chan NotificationPayload
 
 	if replyType != "" {
-		topic := uuid.New().String()
-		notificationCh = make(chan NotificationPayload)
-
-		err := c.msgRegistrar.Register(newMessageService(topic, replyType, nil,
-			NewNotifier(notificationCh, func(topic string, msgBytes []byte) bool {
-				var message struct {
-					Message service.DIDCommMsgMap `json:"message"`
-				}
-
-				err := json.Unmarshal(msgBytes, &message)
-				if err != nil {
-					logger.Debugf("failed to unmarshal incoming message reply: %s", err)
-					return false
-				}
-
-				msgThID, err := message.Message.ThreadID()
-				if err != nil {
-					logger.Debugf("failed to read incoming message reply thread ID: %s", err)
-					return false
-				}
-
-				return thID == "" || thID == msgThID
-			})))
+		var (
+			unregister func()
+			err        error
+		)
+
+		notificationCh, unregister, err = c.registerReplyHandler(thID, replyType, 0)
 		if err != nil {
 			return nil, err
 		}
 
-		defer func() {
-			e := c.msgRegistrar.Unregister(topic)
-			if e != nil {
-				logger.Warnf("Failed to unregister wait for reply notifier: %w", e)
-			}
-		}()
+		defer unregister()
 	}
 
 	err := action()
@@ -323,6 +445,45 @@ func (c *Client) sendAndWaitForReply(ctx context.Context, action messageDispatch
 	return json.RawMessage{}, nil
 }
 
+// registerReplyHandler registers a message service notifying the replies of the given type on the given thread,
+// any thread if empty, to a channel with the given buffer size. The returned function unregisters the message service.
+func (c *Client) registerReplyHandler(thID, replyType string,
+	bufferSize int) (chan NotificationPayload, func(), error) {
+	topic := uuid.New().String()
+	notificationCh := make(chan NotificationPayload, bufferSize)
+
+	err := c.msgRegistrar.Register(newMessageService(topic, replyType, nil,
+		NewNotifier(notificationCh, func(topic string, msgBytes []byte) bool {
+			var message struct {
+				Message service.DIDCommMsgMap `json:"message"`
+			}
+
+			err := json.Unmarshal(msgBytes, &message)
+			if err != nil {
+				logger.Debugf("failed to unmarshal incoming message reply: %s", err)
+				return false
+			}
+
+			msgThID, err := message.Message.ThreadID()
+			if err != nil {
+				logger.Debugf("failed to read incoming message reply thread ID: %s", err)
+				return false
+			}
+
+			return thID == "" || thID == msgThID
+		})))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return notificationCh, func() {
+		e := c.msgRegistrar.Unregister(topic)
+		if e != nil {
+			logger.Warnf("Failed to unregister wait for reply notifier: %w", e)
+		}
+	}, nil
+}
+
 func waitForResponse(ctx context.Context, notificationCh chan NotificationPayload) (json.RawMessage, error) {
 	select {
 	case payload := <-notificationCh:
@@ -340,8 +501,17 @@ func prepareMessage(msg json.RawMessage) (service.DIDCommMsgMap, error) {
 	}
 
 	if didCommMsg.ID() == "" {
-		didCommMsg.SetID(uuid.New().String())
+		didCommMsg.SetID(uuid.New().String(), withMsgVersion(didCommMsg))
 	}
 
 	return didCommMsg, nil
 }
+
+// withMsgVersion returns the option of the DIDComm version of the message.
+func withMsgVersion(msg service.DIDCommMsgMap) service.Opt {
+	if msg.IsDIDCommV2() {
+		return service.WithVersion(service.V2)
+	}
+
+	return service.WithVersion(service.V1)
+}
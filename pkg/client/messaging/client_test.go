@@ -10,7 +10,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
@@ -626,6 +629,316 @@ func TestCommand_Reply(t *testing.T) {
 	})
 }
 
+func TestCommand_Request(t *testing.T) {
+	const replyType = "sample-response-type"
+
+	conn := &connection.Record{
+		ConnectionID: "sample-conn-ID-001",
+		State:        "completed", MyDID: "mydid", TheirDID: "theirDID-001",
+	}
+
+	// newClient returns a client replying to the requests sent from the given attempt.
+	newClient := func(t *testing.T, replyFrom int, sendErr error) (*Client, *requestMessenger) {
+		t.Helper()
+
+		memProvider := mem.NewProvider()
+
+		memStore, err := memProvider.OpenStore("didexchange")
+		require.NoError(t, err)
+
+		connBytes, err := json.Marshal(conn)
+		require.NoError(t, err)
+		require.NoError(t, memStore.Put("conn_"+conn.ConnectionID, connBytes, spi.Tag{Name: "conn_"}))
+
+		registrar := msghandler.NewMockMsgServiceProvider()
+		messenger := &requestMessenger{err: sendErr}
+
+		messenger.onSend = func(msg service.DIDCommMsgMap, attempt int) {
+			if attempt < replyFrom {
+				return
+			}
+
+			reply := service.DIDCommMsgMap{
+				"@id":     fmt.Sprintf("reply-%d", attempt),
+				"@type":   replyType,
+				"~thread": map[string]interface{}{"thid": msg.ID()},
+				"text":    "pong",
+			}
+
+			go func() {
+				for _, svc := range registrar.Services() {
+					if svc.Accept(replyType, nil) {
+						_, e := svc.HandleInbound(reply, service.NewDIDCommContext("sampleDID", "sampleTheirDID", nil))
+						require.NoError(t, e)
+					}
+				}
+			}()
+		}
+
+		client, err := New(&requestProvider{
+			MockProvider: &protocol.MockProvider{
+				StoreProvider:              memProvider,
+				ProtocolStateStoreProvider: mem.NewProvider(),
+			},
+			messenger: messenger,
+		}, registrar, &mockNotifier{})
+		require.NoError(t, err)
+
+		return client, messenger
+	}
+
+	t.Run("success", func(t *testing.T) {
+		client, messenger := newClient(t, 1, nil)
+
+		var response struct {
+			ID   string `json:"@id"`
+			Text string `json:"text"`
+		}
+
+		err := client.Request(context.Background(), map[string]interface{}{"@type": "ping", "text": "ping"},
+			replyType, &response, SendByConnectionID(conn.ConnectionID))
+		require.NoError(t, err)
+		require.Equal(t, "reply-1", response.ID)
+		require.Equal(t, "pong", response.Text)
+		require.Equal(t, 1, messenger.attempts())
+	})
+
+	t.Run("success without decoding the reply", func(t *testing.T) {
+		client, _ := newClient(t, 1, nil)
+
+		err := client.Request(context.Background(), json.RawMessage(`{"@type":"ping"}`), replyType, nil,
+			SendByConnectionID(conn.ConnectionID))
+		require.NoError(t, err)
+	})
+
+	t.Run("success after retries", func(t *testing.T) {
+		client, messenger := newClient(t, 3, nil)
+
+		response := service.DIDCommMsgMap{}
+
+		err := client.Request(context.Background(), map[string]interface{}{"@type": "ping"}, replyType, &response,
+			SendByConnectionID(conn.ConnectionID), WithRetries(3, 50*time.Millisecond))
+		require.NoError(t, err)
+		require.Equal(t, "reply-3", response.ID())
+		require.Equal(t, 3, messenger.attempts())
+	})
+
+	t.Run("all attempts time out", func(t *testing.T) {
+		client, messenger := newClient(t, 5, nil)
+
+		err := client.Request(context.Background(), map[string]interface{}{"@type": "ping"}, replyType, nil,
+			SendByConnectionID(conn.ConnectionID), WithRetries(2, 10*time.Millisecond))
+		require.EqualError(t, err, "failed to get reply, context deadline exceeded")
+		require.Equal(t, 3, messenger.attempts())
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		client, messenger := newClient(t, 5, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := client.Request(ctx, map[string]interface{}{"@type": "ping"}, replyType, nil,
+			SendByConnectionID(conn.ConnectionID), WithRetries(10, time.Second))
+		require.EqualError(t, err, "failed to get reply, context deadline exceeded")
+		require.Equal(t, 1, messenger.attempts())
+	})
+
+	t.Run("missing response type", func(t *testing.T) {
+		client, _ := newClient(t, 1, nil)
+
+		err := client.Request(context.Background(), map[string]interface{}{}, "", nil,
+			SendByConnectionID(conn.ConnectionID))
+		require.EqualError(t, err, errResponseTypeMissing)
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		client, _ := newClient(t, 1, nil)
+
+		err := client.Request(context.Background(), map[string]interface{}{}, replyType, nil)
+		require.EqualError(t, err, errMsgDestinationMissing)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		client, _ := newClient(t, 1, nil)
+
+		err := client.Request(context.Background(), make(chan int), replyType, nil,
+			SendByConnectionID(conn.ConnectionID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to marshal request")
+
+		err = client.Request(context.Background(), "text", replyType, nil, SendByConnectionID(conn.ConnectionID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid payload data format")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		client, _ := newClient(t, 1, errors.New("send error"))
+
+		err := client.Request(context.Background(), map[string]interface{}{}, replyType, nil,
+			SendByConnectionID(conn.ConnectionID))
+		require.EqualError(t, err, "send error")
+	})
+
+	t.Run("reply decode error", func(t *testing.T) {
+		client, _ := newClient(t, 1, nil)
+
+		var response struct {
+			Text int `json:"text"`
+		}
+
+		err := client.Request(context.Background(), map[string]interface{}{}, replyType, &response,
+			SendByConnectionID(conn.ConnectionID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decode reply")
+	})
+}
+
+func TestCommand_SendPlainMessage(t *testing.T) {
+	const replyType = "https://example.com/custom/1.0/pong"
+
+	conn := &connection.Record{
+		ConnectionID: "sample-conn-ID-001",
+		State:        "completed", MyDID: "mydid", TheirDID: "theirDID-001",
+	}
+
+	memProvider := mem.NewProvider()
+
+	memStore, err := memProvider.OpenStore("didexchange")
+	require.NoError(t, err)
+
+	connBytes, err := json.Marshal(conn)
+	require.NoError(t, err)
+	require.NoError(t, memStore.Put("conn_"+conn.ConnectionID, connBytes, spi.Tag{Name: "conn_"}))
+
+	registrar := msghandler.NewMockMsgServiceProvider()
+	sent := make(chan service.DIDCommMsgMap, 1)
+
+	messenger := &requestMessenger{
+		onSend: func(msg service.DIDCommMsgMap, _ int) {
+			sent <- msg
+
+			reply := service.DIDCommMsgMap{
+				"id":   "reply-1",
+				"type": replyType,
+				"thid": msg.ID(),
+				"body": map[string]interface{}{"text": "pong"},
+			}
+
+			go func() {
+				for _, svc := range registrar.Services() {
+					if svc.Accept(replyType, nil) {
+						_, e := svc.HandleInbound(reply, service.NewDIDCommContext("sampleDID", "sampleTheirDID", nil))
+						require.NoError(t, e)
+					}
+				}
+			}()
+		},
+	}
+
+	client, err := New(&requestProvider{
+		MockProvider: &protocol.MockProvider{
+			StoreProvider:              memProvider,
+			ProtocolStateStoreProvider: mem.NewProvider(),
+		},
+		messenger: messenger,
+	}, registrar, &mockNotifier{})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		res, err := client.SendPlainMessage(&PlainMessage{
+			Type: "https://example.com/custom/1.0/ping",
+			Body: json.RawMessage(`{"text":"ping"}`),
+			Attachments: []decorator.AttachmentV2{{
+				ID:   "attachment-1",
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{"key": "value"}},
+			}},
+		}, SendByConnectionID(conn.ConnectionID), WaitForResponse(context.Background(), replyType))
+		require.NoError(t, err)
+
+		msg := <-sent
+		require.True(t, msg.IsDIDCommV2())
+		require.NotEmpty(t, msg["id"])
+		require.NotContains(t, msg, "@id")
+		require.Equal(t, map[string]interface{}{"text": "ping"}, msg["body"])
+		require.Len(t, msg["attachments"], 1)
+
+		var reply struct {
+			Message struct {
+				ID   string `json:"id"`
+				Body struct {
+					Text string `json:"text"`
+				} `json:"body"`
+			} `json:"message"`
+		}
+
+		require.NoError(t, json.Unmarshal(res, &reply))
+		require.Equal(t, "reply-1", reply.Message.ID)
+		require.Equal(t, "pong", reply.Message.Body.Text)
+	})
+
+	t.Run("message without body", func(t *testing.T) {
+		_, err := client.SendPlainMessage(&PlainMessage{ID: "message-1", Type: "https://example.com/custom/1.0/ping"},
+			SendByConnectionID(conn.ConnectionID))
+		require.NoError(t, err)
+
+		msg := <-sent
+		require.Equal(t, "message-1", msg.ID())
+		require.Equal(t, map[string]interface{}{}, msg["body"])
+	})
+
+	t.Run("missing message type", func(t *testing.T) {
+		_, err := client.SendPlainMessage(&PlainMessage{}, SendByConnectionID(conn.ConnectionID))
+		require.EqualError(t, err, errMsgTypeMissing)
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		_, err := client.SendPlainMessage(&PlainMessage{Type: "https://example.com/custom/1.0/ping"})
+		require.EqualError(t, err, errMsgDestinationMissing)
+	})
+}
+
+// requestProvider is a provider with a custom messenger.
+type requestProvider struct {
+	*protocol.MockProvider
+	messenger service.Messenger
+}
+
+func (p *requestProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+// requestMessenger is a messenger calling back on each message sent.
+type requestMessenger struct {
+	mocksvc.MockMessenger
+	err    error
+	onSend func(msg service.DIDCommMsgMap, attempt int)
+	sent   int
+	mu     sync.Mutex
+}
+
+func (m *requestMessenger) Send(msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.mu.Lock()
+	m.sent++
+	attempt := m.sent
+	m.mu.Unlock()
+
+	m.onSend(msg, attempt)
+
+	return nil
+}
+
+func (m *requestMessenger) attempts() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sent
+}
+
 // mockNotifier is mock implementation of Notifier.
 type mockNotifier struct {
 	NotifyFunc func(topic string, message []byte) error
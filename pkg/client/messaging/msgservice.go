@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 )
 
 const (
@@ -63,14 +64,8 @@ func (m *msgService) Accept(msgType string, purpose []string) bool {
 		return false
 	}
 
-	for _, purposeCriteria := range m.purpose {
-		for _, msgPurpose := range purpose {
-			if purposeCriteria == msgPurpose {
-				purposeMatched = true
-
-				break
-			}
-		}
+	if msghandler.MatchPurpose(m.purpose, purpose) {
+		purposeMatched = true
 	}
 
 	if m.msgType == msgType {
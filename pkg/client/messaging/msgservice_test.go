@@ -100,6 +100,28 @@ func TestMsgService_AcceptAndName(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "msgService accept success with wildcard purposes",
+			args: &testArgs{Name: "test-01", Type: "msg-type-01", Purpose: []string{"prp-01-*"}},
+			testdata: []struct {
+				msgtype string
+				purpose []string
+				result  bool
+			}{
+				{
+					"msg-type-01", []string{"prp-01-01"}, true,
+				},
+				{
+					"msg-type-01", []string{"prp-02-01", "prp-01-02"}, true,
+				},
+				{
+					"msg-type-01", []string{"prp-02-01"}, false,
+				},
+				{
+					"msg-type-02", []string{"prp-01-01"}, false,
+				},
+			},
+		},
 		{
 			name: "msgService accept success with only message type",
 			args: &testArgs{Name: "test-01", Type: "msg-type-01"},
@@ -0,0 +1,422 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	issuerMetadataPath              = "/.well-known/openid-credential-issuer"
+	authorizationServerMetadataPath = "/.well-known/oauth-authorization-server"
+
+	credentialOfferParam    = "credential_offer"
+	credentialOfferURIParam = "credential_offer_uri"
+
+	proofTypeJWT    = "jwt"
+	proofJWTType    = "openid4vci-proof+jwt"
+	contentTypeKey  = "Content-Type"
+	contentTypeJSON = "application/json"
+	contentTypeForm = "application/x-www-form-urlencoded"
+)
+
+var logger = log.New("aries-framework/client/oidc4vci")
+
+// HTTPClient sends the HTTP requests of the client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is the wallet side of OIDC4VCI.
+type Client struct {
+	httpClient HTTPClient
+}
+
+// Opt is an option of the client.
+type Opt func(*Client)
+
+// WithHTTPClient sets the HTTP client sending the requests, http.DefaultClient by default.
+func WithHTTPClient(httpClient HTTPClient) Opt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New returns a new OIDC4VCI client.
+func New(opts ...Opt) *Client {
+	c := &Client{httpClient: http.DefaultClient}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ClaimOpt is an option of ClaimPreAuthorized.
+type ClaimOpt func(*claimOpts)
+
+type claimOpts struct {
+	userPIN  string
+	clientID string
+}
+
+// WithUserPIN sets the PIN the user received from the issuer through another channel than the offer.
+func WithUserPIN(pin string) ClaimOpt {
+	return func(opts *claimOpts) {
+		opts.userPIN = pin
+	}
+}
+
+// WithClientID sets the client ID of the wallet, sent in the token request and as issuer of the proof.
+func WithClientID(clientID string) ClaimOpt {
+	return func(opts *claimOpts) {
+		opts.clientID = clientID
+	}
+}
+
+// ResolveCredentialOffer returns the credential offer of a credential offer URI
+// (`openid-credential-offer://?credential_offer=...`), fetching it when it is sent by reference
+// (`credential_offer_uri`).
+func (c *Client) ResolveCredentialOffer(offerURI string) (*CredentialOffer, error) {
+	u, err := url.Parse(offerURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential offer URI: %w", err)
+	}
+
+	var offerBytes []byte
+
+	switch query := u.Query(); {
+	case query.Get(credentialOfferParam) != "":
+		offerBytes = []byte(query.Get(credentialOfferParam))
+	case query.Get(credentialOfferURIParam) != "":
+		offerBytes, err = c.get(query.Get(credentialOfferURIParam))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch credential offer: %w", err)
+		}
+	default:
+		return nil, errors.New("invalid credential offer URI: missing credential offer")
+	}
+
+	var offer CredentialOffer
+
+	err = json.Unmarshal(offerBytes, &offer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential offer: %w", err)
+	}
+
+	if offer.CredentialIssuer == "" {
+		return nil, errors.New("invalid credential offer: missing credential issuer")
+	}
+
+	return &offer, nil
+}
+
+// IssuerMetadata fetches the metadata of the credential issuer.
+func (c *Client) IssuerMetadata(credentialIssuer string) (*IssuerMetadata, error) {
+	respBytes, err := c.get(strings.TrimSuffix(credentialIssuer, "/") + issuerMetadataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issuer metadata: %w", err)
+	}
+
+	var metadata IssuerMetadata
+
+	err = json.Unmarshal(respBytes, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer metadata: %w", err)
+	}
+
+	if metadata.CredentialEndpoint == "" {
+		return nil, errors.New("invalid issuer metadata: missing credential endpoint")
+	}
+
+	return &metadata, nil
+}
+
+// ClaimPreAuthorized claims the credentials of the offer with its pre-authorized code, signing the proofs of
+// possession of the holder key with the given signer, whose headers must identify the key (`kid` or `jwk`).
+//
+// Errors returned by the issuer are *Error, unwrapping to the Err* errors of this package.
+func (c *Client) ClaimPreAuthorized(offer *CredentialOffer, signer jose.Signer,
+	opts ...ClaimOpt) ([]*CredentialResponse, error) {
+	options := &claimOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	grant := offer.PreAuthorizedCode()
+	if grant == nil || grant.PreAuthorizedCode == "" {
+		return nil, errors.New("credential offer doesn't have a pre-authorized code grant")
+	}
+
+	if grant.UserPINRequired && options.userPIN == "" {
+		return nil, ErrUserPINRequired
+	}
+
+	metadata, err := c.IssuerMetadata(offer.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenEndpoint, err := c.tokenEndpoint(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.requestToken(tokenEndpoint, grant, options)
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+
+	nonce := token.CNonce
+
+	var credentials []*CredentialResponse
+
+	for _, offered := range offer.Credentials {
+		request, err := credentialRequestFor(offered, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		cred, err := c.requestCredential(metadata, token.AccessToken, request, nonce, signer, options)
+		if err != nil {
+			return nil, fmt.Errorf("credential request: %w", err)
+		}
+
+		if cred.CNonce != "" {
+			nonce = cred.CNonce
+		}
+
+		credentials = append(credentials, cred)
+	}
+
+	return credentials, nil
+}
+
+// tokenEndpoint returns the token endpoint of the issuer metadata, falling back to the one of its authorization
+// server metadata.
+func (c *Client) tokenEndpoint(metadata *IssuerMetadata) (string, error) {
+	if metadata.TokenEndpoint != "" {
+		return metadata.TokenEndpoint, nil
+	}
+
+	authServer := metadata.AuthorizationServer
+	if authServer == "" {
+		authServer = metadata.CredentialIssuer
+	}
+
+	respBytes, err := c.get(strings.TrimSuffix(authServer, "/") + authorizationServerMetadataPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch authorization server metadata: %w", err)
+	}
+
+	var authMetadata authorizationServerMetadata
+
+	err = json.Unmarshal(respBytes, &authMetadata)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization server metadata: %w", err)
+	}
+
+	if authMetadata.TokenEndpoint == "" {
+		return "", errors.New("invalid authorization server metadata: missing token endpoint")
+	}
+
+	return authMetadata.TokenEndpoint, nil
+}
+
+func (c *Client) requestToken(tokenEndpoint string, grant *PreAuthorizedCodeGrant,
+	opts *claimOpts) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", PreAuthorizedCodeGrantType)
+	form.Set("pre-authorized_code", grant.PreAuthorizedCode)
+
+	if opts.userPIN != "" {
+		form.Set("user_pin", opts.userPIN)
+	}
+
+	if opts.clientID != "" {
+		form.Set("client_id", opts.clientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(contentTypeKey, contentTypeForm)
+
+	respBytes, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var token tokenResponse
+
+	err = json.Unmarshal(respBytes, &token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("invalid token response: missing access token")
+	}
+
+	return &token, nil
+}
+
+// requestCredential requests the credential, retrying once with the nonce returned by the issuer if it rejects the
+// proof, as the issuer may require a nonce the wallet didn't get yet.
+func (c *Client) requestCredential(metadata *IssuerMetadata, accessToken string, request *credentialRequest,
+	nonce string, signer jose.Signer, opts *claimOpts) (*CredentialResponse, error) {
+	cred, err := c.sendCredentialRequest(metadata, accessToken, request, nonce, signer, opts)
+
+	var issuerErr *Error
+	if errors.As(err, &issuerErr) && errors.Is(err, ErrInvalidProof) &&
+		issuerErr.CNonce != "" && issuerErr.CNonce != nonce {
+		logger.Debugf("credential request proof rejected, retrying with issuer nonce")
+
+		return c.sendCredentialRequest(metadata, accessToken, request, issuerErr.CNonce, signer, opts)
+	}
+
+	return cred, err
+}
+
+func (c *Client) sendCredentialRequest(metadata *IssuerMetadata, accessToken string, request *credentialRequest,
+	nonce string, signer jose.Signer, opts *claimOpts) (*CredentialResponse, error) {
+	proofJWT, err := signProof(metadata.CredentialIssuer, nonce, signer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Proof = &proof{ProofType: proofTypeJWT, JWT: proofJWT}
+
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, metadata.CredentialEndpoint, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(contentTypeKey, contentTypeJSON)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	respBytes, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cred CredentialResponse
+
+	err = json.Unmarshal(respBytes, &cred)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential response: %w", err)
+	}
+
+	if len(cred.Credential) == 0 {
+		return nil, errors.New("invalid credential response: missing credential")
+	}
+
+	return &cred, nil
+}
+
+// credentialRequestFor returns the credential request of the offered credential, looking it up in the issuer
+// metadata when it is offered by reference.
+func credentialRequestFor(offered *OfferedCredential, metadata *IssuerMetadata) (*credentialRequest, error) {
+	if offered.ID == "" {
+		return &credentialRequest{Format: offered.Format, Types: offered.Types}, nil
+	}
+
+	for _, supported := range metadata.CredentialsSupported {
+		if supported.ID == offered.ID {
+			return &credentialRequest{Format: supported.Format, Types: supported.Types}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("offered credential '%s' not found in issuer metadata: %w", offered.ID,
+		ErrUnsupportedCredential)
+}
+
+func signProof(audience, nonce string, signer jose.Signer, opts *claimOpts) (string, error) {
+	claims := &proofClaims{
+		Issuer:   opts.clientID,
+		Audience: audience,
+		IssuedAt: time.Now().Unix(),
+		Nonce:    nonce,
+	}
+
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderType: proofJWTType}, signer)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign proof: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+func (c *Client) get(endpoint string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", contentTypeJSON)
+
+	return c.do(req)
+}
+
+// do sends the request, returning the body of a successful response or an *Error for an error response.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL, err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", req.URL, err)
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return respBytes, nil
+	}
+
+	issuerErr := &Error{}
+
+	if err = json.Unmarshal(respBytes, issuerErr); err != nil || issuerErr.Code == "" {
+		issuerErr.Code = ""
+		issuerErr.Description = string(respBytes)
+	}
+
+	issuerErr.StatusCode = resp.StatusCode
+
+	return nil, issuerErr
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,413 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vci
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+const (
+	samplePreAuthorizedCode = "SplxlOBeZQQYbYS6WxSbIA"
+	sampleUserPIN           = "493536"
+	sampleAccessToken       = "eyJhbGciOiJSUzI1NiIsInR5cCI6Ikp"
+	sampleNonce             = "tZignsnFbp"
+	sampleKeyID             = "did:example:holder#key-1"
+	sampleCredentialID      = "UniversityDegree_JWT"
+	sampleCredential        = `"eyJhbGciOiJFZERTQSJ9.eyJ2YyI6e319.c2lnbmF0dXJl"`
+)
+
+func TestClient_ResolveCredentialOffer(t *testing.T) {
+	offerJSON := `{
+		"credential_issuer": "https://issuer.example.com",
+		"credentials": [
+			"UniversityDegree_JWT",
+			{"format": "ldp_vc", "types": ["VerifiableCredential", "UniversityDegreeCredential"]}
+		],
+		"grants": {
+			"urn:ietf:params:oauth:grant-type:pre-authorized_code": {
+				"pre-authorized_code": "SplxlOBeZQQYbYS6WxSbIA",
+				"user_pin_required": true
+			}
+		}
+	}`
+
+	t.Run("offer by value", func(t *testing.T) {
+		offer, err := New().ResolveCredentialOffer(
+			"openid-credential-offer://?credential_offer=" + url.QueryEscape(offerJSON))
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.example.com", offer.CredentialIssuer)
+		require.Len(t, offer.Credentials, 2)
+		require.Equal(t, sampleCredentialID, offer.Credentials[0].ID)
+		require.Equal(t, "ldp_vc", offer.Credentials[1].Format)
+		require.NotNil(t, offer.PreAuthorizedCode())
+		require.Equal(t, samplePreAuthorizedCode, offer.PreAuthorizedCode().PreAuthorizedCode)
+		require.True(t, offer.PreAuthorizedCode().UserPINRequired)
+
+		offerBytes, err := json.Marshal(offer)
+		require.NoError(t, err)
+		require.JSONEq(t, offerJSON, string(offerBytes))
+	})
+
+	t.Run("offer by reference", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(offerJSON))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		offer, err := New().ResolveCredentialOffer(
+			"openid-credential-offer://?credential_offer_uri=" + url.QueryEscape(server.URL+"/offer"))
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.example.com", offer.CredentialIssuer)
+	})
+
+	t.Run("invalid offers", func(t *testing.T) {
+		client := New()
+
+		_, err := client.ResolveCredentialOffer("openid-credential-offer://?other=value")
+		require.EqualError(t, err, "invalid credential offer URI: missing credential offer")
+
+		_, err = client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid credential offer")
+
+		_, err = client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=" +
+			url.QueryEscape(`{"credentials":["UniversityDegree_JWT"]}`))
+		require.EqualError(t, err, "invalid credential offer: missing credential issuer")
+
+		_, err = client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=" +
+			url.QueryEscape(`{"credential_issuer":"https://issuer.example.com","credentials":[1]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid offered credential")
+
+		_, err = client.ResolveCredentialOffer("openid-credential-offer://?credential_offer_uri=" +
+			url.QueryEscape("http://127.0.0.1:0/offer"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to fetch credential offer")
+	})
+}
+
+func TestClient_ClaimPreAuthorized(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		credentials, err := New().ClaimPreAuthorized(issuer.offer(true), issuer.signer,
+			WithUserPIN(sampleUserPIN), WithClientID("wallet"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Equal(t, "jwt_vc_json", credentials[0].Format)
+		require.Equal(t, sampleCredential, string(credentials[0].Credential))
+		require.Equal(t, "ldp_vc", credentials[1].Format)
+		require.Equal(t, 1, issuer.tokenRequests)
+		require.Equal(t, 2, issuer.credentialRequests)
+	})
+
+	t.Run("success - token endpoint of authorization server", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		issuer.metadata.TokenEndpoint = ""
+
+		credentials, err := New().ClaimPreAuthorized(issuer.offer(false), issuer.signer)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+	})
+
+	t.Run("success - retry with issuer nonce", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		issuer.tokenNonce = ""
+
+		credentials, err := New().ClaimPreAuthorized(issuer.offer(false), issuer.signer)
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Equal(t, 3, issuer.credentialRequests)
+	})
+
+	t.Run("user PIN required", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		_, err := New().ClaimPreAuthorized(issuer.offer(true), issuer.signer)
+		require.True(t, errors.Is(err, ErrUserPINRequired))
+		require.Zero(t, issuer.tokenRequests)
+	})
+
+	t.Run("wrong user PIN", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		_, err := New().ClaimPreAuthorized(issuer.offer(true), issuer.signer, WithUserPIN("000000"))
+		require.True(t, errors.Is(err, ErrInvalidGrant))
+
+		var issuerErr *Error
+		require.True(t, errors.As(err, &issuerErr))
+		require.Equal(t, "invalid_grant", issuerErr.Code)
+		require.Equal(t, http.StatusBadRequest, issuerErr.StatusCode)
+		require.Contains(t, err.Error(), "token request: issuer error 'invalid_grant' (status 400): invalid PIN")
+	})
+
+	t.Run("unsupported credential", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		offer := issuer.offer(false)
+		offer.Credentials = []*OfferedCredential{{Format: "mso_mdoc"}}
+
+		_, err := New().ClaimPreAuthorized(offer, issuer.signer)
+		require.True(t, errors.Is(err, ErrUnsupportedCredential))
+
+		offer.Credentials = []*OfferedCredential{{ID: "unknown"}}
+
+		_, err = New().ClaimPreAuthorized(offer, issuer.signer)
+		require.True(t, errors.Is(err, ErrUnsupportedCredential))
+		require.Contains(t, err.Error(), "offered credential 'unknown' not found in issuer metadata")
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		_, err := New().ClaimPreAuthorized(issuer.offer(false), &ed25519Signer{privKey: issuer.signer.privKey})
+		require.True(t, errors.Is(err, ErrInvalidProof))
+	})
+
+	t.Run("offer without pre-authorized code", func(t *testing.T) {
+		_, err := New().ClaimPreAuthorized(&CredentialOffer{CredentialIssuer: "https://issuer.example.com"}, nil)
+		require.EqualError(t, err, "credential offer doesn't have a pre-authorized code grant")
+	})
+
+	t.Run("issuer errors", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		defer issuer.Close()
+
+		issuer.metadata.CredentialEndpoint = ""
+
+		_, err := New().ClaimPreAuthorized(issuer.offer(false), issuer.signer)
+		require.EqualError(t, err, "invalid issuer metadata: missing credential endpoint")
+
+		issuer.metadata = nil
+
+		_, err = New().ClaimPreAuthorized(issuer.offer(false), issuer.signer)
+		require.True(t, errors.Is(err, ErrIssuer))
+		require.Contains(t, err.Error(), "failed to fetch issuer metadata: issuer error '' (status 404)")
+	})
+}
+
+func TestError(t *testing.T) {
+	for code, expected := range map[string]error{
+		"invalid_grant":               ErrInvalidGrant,
+		"unauthorized_client":         ErrInvalidRequest,
+		"invalid_token":               ErrInvalidToken,
+		"unsupported_credential_type": ErrUnsupportedCredential,
+		"invalid_or_missing_proof":    ErrInvalidProof,
+		"server_error":                ErrIssuer,
+	} {
+		err := &Error{Code: code, StatusCode: http.StatusBadRequest}
+		require.True(t, errors.Is(err, expected), code)
+		require.Equal(t, fmt.Sprintf("issuer error '%s' (status 400)", code), err.Error())
+	}
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+	kid     string
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA", jose.HeaderKeyID: s.kid}
+}
+
+type mockIssuer struct {
+	*httptest.Server
+	t                  *testing.T
+	signer             *ed25519Signer
+	pubKey             ed25519.PublicKey
+	metadata           *IssuerMetadata
+	tokenNonce         string
+	tokenRequests      int
+	credentialRequests int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	issuer := &mockIssuer{
+		t:          t,
+		signer:     &ed25519Signer{privKey: privKey, kid: sampleKeyID},
+		pubKey:     pubKey,
+		tokenNonce: sampleNonce,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(issuerMetadataPath, issuer.serveMetadata)
+	mux.HandleFunc(authorizationServerMetadataPath, issuer.serveAuthorizationServerMetadata)
+	mux.HandleFunc("/token", issuer.serveToken)
+	mux.HandleFunc("/credential", issuer.serveCredential)
+
+	issuer.Server = httptest.NewServer(mux)
+	issuer.metadata = &IssuerMetadata{
+		CredentialIssuer:   issuer.URL,
+		CredentialEndpoint: issuer.URL + "/credential",
+		TokenEndpoint:      issuer.URL + "/token",
+		CredentialsSupported: []*SupportedCredential{
+			{ID: sampleCredentialID, Format: "jwt_vc_json", Types: []string{"VerifiableCredential"}},
+		},
+	}
+
+	return issuer
+}
+
+func (m *mockIssuer) offer(userPINRequired bool) *CredentialOffer {
+	return &CredentialOffer{
+		CredentialIssuer: m.URL,
+		Credentials: []*OfferedCredential{
+			{ID: sampleCredentialID},
+			{Format: "ldp_vc", Types: []string{"VerifiableCredential"}},
+		},
+		Grants: &Grants{PreAuthorizedCode: &PreAuthorizedCodeGrant{
+			PreAuthorizedCode: samplePreAuthorizedCode,
+			UserPINRequired:   userPINRequired,
+		}},
+	}
+}
+
+func (m *mockIssuer) serveMetadata(w http.ResponseWriter, _ *http.Request) {
+	if m.metadata == nil {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	m.writeJSON(w, http.StatusOK, m.metadata)
+}
+
+func (m *mockIssuer) serveAuthorizationServerMetadata(w http.ResponseWriter, _ *http.Request) {
+	m.writeJSON(w, http.StatusOK, &authorizationServerMetadata{TokenEndpoint: m.URL + "/token"})
+}
+
+func (m *mockIssuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	m.tokenRequests++
+
+	require.NoError(m.t, r.ParseForm())
+	require.Equal(m.t, PreAuthorizedCodeGrantType, r.PostForm.Get("grant_type"))
+	require.Equal(m.t, samplePreAuthorizedCode, r.PostForm.Get("pre-authorized_code"))
+
+	if pin := r.PostForm.Get("user_pin"); pin != "" && pin != sampleUserPIN {
+		m.writeJSON(w, http.StatusBadRequest, &Error{Code: "invalid_grant", Description: "invalid PIN"})
+
+		return
+	}
+
+	m.writeJSON(w, http.StatusOK, &tokenResponse{
+		AccessToken: sampleAccessToken,
+		TokenType:   "bearer",
+		CNonce:      m.tokenNonce,
+	})
+}
+
+func (m *mockIssuer) serveCredential(w http.ResponseWriter, r *http.Request) {
+	m.credentialRequests++
+
+	if r.Header.Get("Authorization") != "Bearer "+sampleAccessToken {
+		m.writeJSON(w, http.StatusUnauthorized, &Error{Code: "invalid_token"})
+
+		return
+	}
+
+	var request credentialRequest
+
+	require.NoError(m.t, json.NewDecoder(r.Body).Decode(&request))
+
+	if request.Format == "mso_mdoc" {
+		m.writeJSON(w, http.StatusBadRequest, &Error{Code: "unsupported_credential_format"})
+
+		return
+	}
+
+	if !m.validProof(request.Proof) {
+		m.writeJSON(w, http.StatusBadRequest, &Error{Code: "invalid_or_missing_proof", CNonce: sampleNonce})
+
+		return
+	}
+
+	credential := json.RawMessage(sampleCredential)
+	if request.Format == "ldp_vc" {
+		credential = json.RawMessage(`{"type":["VerifiableCredential"]}`)
+	}
+
+	m.writeJSON(w, http.StatusOK, &CredentialResponse{
+		Format:     request.Format,
+		Credential: credential,
+		CNonce:     sampleNonce,
+	})
+}
+
+func (m *mockIssuer) validProof(p *proof) bool {
+	if p == nil || p.ProofType != proofTypeJWT {
+		return false
+	}
+
+	jws, err := jose.ParseJWS(p.JWT, &ed25519Verifier{pubKey: m.pubKey})
+	if err != nil {
+		return false
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != proofJWTType { // nolint:errcheck
+		return false
+	}
+
+	if kid, _ := jws.ProtectedHeaders.KeyID(); kid != sampleKeyID { // nolint:errcheck
+		return false
+	}
+
+	var claims proofClaims
+
+	require.NoError(m.t, json.Unmarshal(jws.Payload, &claims))
+
+	return claims.Nonce == sampleNonce && claims.Audience == m.URL && claims.IssuedAt > 0
+}
+
+func (m *mockIssuer) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set(contentTypeKey, contentTypeJSON)
+	w.WriteHeader(status)
+
+	require.NoError(m.t, json.NewEncoder(w).Encode(v))
+}
+
+type ed25519Verifier struct {
+	pubKey ed25519.PublicKey
+}
+
+func (v *ed25519Verifier) Verify(_ jose.Headers, _, signingInput, signature []byte) error {
+	if !ed25519.Verify(v.pubKey, signingInput, signature) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package oidc4vci provides the wallet side of OpenID for Verifiable Credential Issuance
+// (https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html).
+//
+// The pre-authorized code flow is supported: the wallet claims the credentials of a credential offer by exchanging
+// the pre-authorized code (and the user PIN, if the issuer requires it) for an access token, then requesting the
+// credentials with a proof of possession of the holder key. Errors returned by the issuer are mapped to the
+// Err* errors of this package, so wallets can tell their user what went wrong.
+//
+//	client := oidc4vci.New()
+//
+//	offer, err := client.ResolveCredentialOffer(offerURI)
+//	if err != nil {
+//		return err
+//	}
+//
+//	credentials, err := client.ClaimPreAuthorized(offer, holderSigner, oidc4vci.WithUserPIN(pin))
+//	if errors.Is(err, oidc4vci.ErrInvalidGrant) {
+//		// the offer expired or the PIN is wrong.
+//	}
+package oidc4vci
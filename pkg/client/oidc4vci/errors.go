@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vci
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors the client returns, each one being a situation the wallet reports to its user differently. Errors returned
+// by the issuer are wrapped in an *Error, which unwraps to one of these, so callers can use errors.Is.
+var (
+	// ErrUserPINRequired is returned when the offer requires a user PIN and none was given.
+	ErrUserPINRequired = errors.New("user PIN required")
+	// ErrInvalidGrant is returned when the pre-authorized code is invalid or expired, or the user PIN is wrong.
+	ErrInvalidGrant = errors.New("invalid grant")
+	// ErrInvalidRequest is returned when the issuer rejects the request of the wallet as malformed or unauthorized.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrInvalidToken is returned when the issuer rejects the access token of the wallet.
+	ErrInvalidToken = errors.New("invalid access token")
+	// ErrUnsupportedCredential is returned when the issuer can't issue the requested credential type or format.
+	ErrUnsupportedCredential = errors.New("unsupported credential")
+	// ErrInvalidProof is returned when the issuer rejects the proof of possession of the holder key.
+	ErrInvalidProof = errors.New("invalid proof")
+	// ErrIssuer is returned for any other error of the issuer.
+	ErrIssuer = errors.New("issuer error")
+)
+
+// errorCodes maps the error codes of RFC 6749 and OIDC4VCI to the errors of the client.
+// nolint:gochecknoglobals
+var errorCodes = map[string]error{
+	"invalid_grant":                 ErrInvalidGrant,
+	"invalid_request":               ErrInvalidRequest,
+	"invalid_client":                ErrInvalidRequest,
+	"unauthorized_client":           ErrInvalidRequest,
+	"unsupported_grant_type":        ErrInvalidRequest,
+	"invalid_token":                 ErrInvalidToken,
+	"unsupported_credential_type":   ErrUnsupportedCredential,
+	"unsupported_credential_format": ErrUnsupportedCredential,
+	"invalid_or_missing_proof":      ErrInvalidProof,
+}
+
+// Error is an error response of the issuer.
+type Error struct {
+	// Code is the error code returned by the issuer.
+	Code string `json:"error"`
+	// Description is the human readable description of the error, if any.
+	Description string `json:"error_description,omitempty"`
+	// CNonce is the fresh nonce to use in the proof, returned along with an invalid_or_missing_proof error.
+	CNonce string `json:"c_nonce,omitempty"`
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("issuer error '%s' (status %d)", e.Code, e.StatusCode)
+	}
+
+	return fmt.Sprintf("issuer error '%s' (status %d): %s", e.Code, e.StatusCode, e.Description)
+}
+
+// Unwrap returns the client error the error code of the issuer maps to.
+func (e *Error) Unwrap() error {
+	if err, ok := errorCodes[e.Code]; ok {
+		return err
+	}
+
+	return ErrIssuer
+}
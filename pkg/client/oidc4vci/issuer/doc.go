@@ -0,0 +1,27 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package issuer provides the issuer side of OpenID for Verifiable Credential Issuance
+// (https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html), so agents embedding the framework can
+// run an issuer without writing the protocol plumbing themselves.
+//
+// The issuer creates credential offers for the pre-authorized code flow, validates the token requests of the
+// wallets, manages the c_nonce the wallets prove the possession of their key with, and issues the offered
+// credentials by signing them with the verifiable command. As the verifiable command signs JSON-LD credentials, the
+// supported credentials of the issuer metadata are expected to have the ldp_vc format.
+//
+//	iss, err := issuer.New(ctx, verifiableCmd, issuerDID, metadata)
+//	if err != nil {
+//		return err
+//	}
+//
+//	router.HandleFunc("/.well-known/openid-credential-issuer", iss.ServeMetadata)
+//	router.HandleFunc("/token", iss.ServeToken)
+//	router.HandleFunc("/credential", iss.ServeCredential)
+//
+//	offer, err := iss.CreateOffer([]*issuer.Credential{{ID: "UniversityDegree", Credential: unsignedVC}},
+//		issuer.WithUserPIN(pin))
+package issuer
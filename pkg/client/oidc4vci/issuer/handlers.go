@@ -0,0 +1,280 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/oidc4vci"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// error codes of RFC 6749 and OIDC4VCI.
+const (
+	errorInvalidRequest              = "invalid_request"
+	errorInvalidGrant                = "invalid_grant"
+	errorUnsupportedGrantType        = "unsupported_grant_type"
+	errorInvalidToken                = "invalid_token"
+	errorUnsupportedCredentialType   = "unsupported_credential_type"
+	errorUnsupportedCredentialFormat = "unsupported_credential_format"
+	errorInvalidOrMissingProof       = "invalid_or_missing_proof"
+	errorServerError                 = "server_error"
+)
+
+const bearerPrefix = "Bearer "
+
+var errInvalidGrant = errors.New("invalid or expired pre-authorized code or user PIN")
+
+// ServeMetadata serves the issuer metadata, at `{credential_issuer}/.well-known/openid-credential-issuer`.
+func (i *Issuer) ServeMetadata(rw http.ResponseWriter, _ *http.Request) {
+	writeResponse(rw, http.StatusOK, i.metadata)
+}
+
+// ServeToken serves the token endpoint, exchanging the pre-authorized code of an offer for an access token.
+func (i *Issuer) ServeToken(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeError(rw, http.StatusBadRequest, errorInvalidRequest, err.Error(), "")
+
+		return
+	}
+
+	if req.PostForm.Get("grant_type") != oidc4vci.PreAuthorizedCodeGrantType {
+		writeError(rw, http.StatusBadRequest, errorUnsupportedGrantType, "", "")
+
+		return
+	}
+
+	code := req.PostForm.Get("pre-authorized_code")
+	if code == "" {
+		writeError(rw, http.StatusBadRequest, errorInvalidRequest, "missing pre-authorized_code", "")
+
+		return
+	}
+
+	accessToken, token, err := i.redeemCode(code, req.PostForm.Get("user_pin"))
+	if errors.Is(err, errInvalidGrant) {
+		writeError(rw, http.StatusBadRequest, errorInvalidGrant, err.Error(), "")
+
+		return
+	}
+
+	if err != nil {
+		logger.Errorf("oidc4vci issuer - redeem pre-authorized code: %v", err)
+		writeError(rw, http.StatusInternalServerError, errorServerError, "", "")
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, &tokenResponse{
+		AccessToken:     accessToken,
+		TokenType:       "bearer",
+		ExpiresIn:       int(i.tokenExpiry.Seconds()),
+		CNonce:          token.CNonce,
+		CNonceExpiresIn: int(i.cNonceExpiry.Seconds()),
+	})
+}
+
+// ServeCredential serves the credential endpoint, issuing an offered credential to the holder of the access token
+// once the possession of the holder key is proven.
+func (i *Issuer) ServeCredential(rw http.ResponseWriter, req *http.Request) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	accessToken, token, ok := i.authorize(rw, req)
+	if !ok {
+		return
+	}
+
+	request := &credentialRequest{}
+
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		writeError(rw, http.StatusBadRequest, errorInvalidRequest, err.Error(), "")
+
+		return
+	}
+
+	index, errCode := i.offeredCredential(token, request)
+	if errCode != "" {
+		writeError(rw, http.StatusBadRequest, errCode, "", "")
+
+		return
+	}
+
+	holderDID, err := i.verifyProof(request.Proof, token)
+	if err != nil {
+		i.rejectProof(rw, accessToken, token, err)
+
+		return
+	}
+
+	vc, err := i.signCredential(token.Credentials[index], holderDID)
+	if err != nil {
+		logger.Errorf("oidc4vci issuer - sign credential: %v", err)
+		writeError(rw, http.StatusInternalServerError, errorServerError, "", "")
+
+		return
+	}
+
+	token.Credentials = append(token.Credentials[:index], token.Credentials[index+1:]...)
+
+	if err = i.saveToken(accessToken, token); err != nil {
+		logger.Errorf("oidc4vci issuer - save access token: %v", err)
+		writeError(rw, http.StatusInternalServerError, errorServerError, "", "")
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, &credentialResponse{
+		Format:          request.Format,
+		Credential:      vc,
+		CNonce:          token.CNonce,
+		CNonceExpiresIn: int(i.cNonceExpiry.Seconds()),
+	})
+}
+
+// authorize returns the record of the bearer access token of the request, writing the error response if the token
+// is missing, unknown or expired.
+func (i *Issuer) authorize(rw http.ResponseWriter, req *http.Request) (string, *tokenRecord, bool) {
+	authorization := req.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		writeError(rw, http.StatusUnauthorized, errorInvalidToken, "missing access token", "")
+
+		return "", nil, false
+	}
+
+	accessToken := strings.TrimPrefix(authorization, bearerPrefix)
+	token := &tokenRecord{}
+
+	err := i.get(tokenKeyPrefix+accessToken, token)
+	if errors.Is(err, storage.ErrDataNotFound) || (err == nil && i.now().After(token.ExpiresAt)) {
+		writeError(rw, http.StatusUnauthorized, errorInvalidToken, "invalid or expired access token", "")
+
+		return "", nil, false
+	}
+
+	if err != nil {
+		logger.Errorf("oidc4vci issuer - get access token: %v", err)
+		writeError(rw, http.StatusInternalServerError, errorServerError, "", "")
+
+		return "", nil, false
+	}
+
+	return accessToken, token, true
+}
+
+// offeredCredential returns the index of the offered credential matching the request, or the error code if none
+// does.
+func (i *Issuer) offeredCredential(token *tokenRecord, request *credentialRequest) (int, string) {
+	errCode := errorUnsupportedCredentialFormat
+
+	for index, credential := range token.Credentials {
+		supported := i.supportedCredential(credential.ID)
+		if supported == nil || supported.Format != request.Format {
+			continue
+		}
+
+		if equalTypes(supported.Types, request.Types) {
+			return index, ""
+		}
+
+		errCode = errorUnsupportedCredentialType
+	}
+
+	return 0, errCode
+}
+
+// rejectProof rotates the c_nonce of the access token and writes the invalid proof error response with it.
+func (i *Issuer) rejectProof(rw http.ResponseWriter, accessToken string, token *tokenRecord, proofErr error) {
+	if err := i.saveToken(accessToken, token); err != nil {
+		logger.Errorf("oidc4vci issuer - save access token: %v", err)
+		writeError(rw, http.StatusInternalServerError, errorServerError, "", "")
+
+		return
+	}
+
+	writeError(rw, http.StatusBadRequest, errorInvalidOrMissingProof, proofErr.Error(), token.CNonce)
+}
+
+// signCredential signs the credential with the verifiable command, binding it to the holder DID.
+func (i *Issuer) signCredential(credential *Credential, holderDID string) (json.RawMessage, error) {
+	vc := map[string]interface{}{}
+
+	if err := json.Unmarshal(credential.Credential, &vc); err != nil {
+		return nil, err
+	}
+
+	if subject, ok := vc["credentialSubject"].(map[string]interface{}); ok {
+		subject["id"] = holderDID
+	}
+
+	vcBytes, err := json.Marshal(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := json.Marshal(&verifiable.SignCredentialRequest{
+		Credential:   vcBytes,
+		DID:          i.did,
+		ProofOptions: i.proofOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+
+	if cmdErr := i.signer.SignCredential(&b, bytes.NewReader(request)); cmdErr != nil {
+		return nil, cmdErr
+	}
+
+	response := &verifiable.SignCredentialResponse{}
+
+	if err = json.Unmarshal(b.Bytes(), response); err != nil {
+		return nil, err
+	}
+
+	return response.VerifiableCredential, nil
+}
+
+func equalTypes(types, other []string) bool {
+	if len(types) != len(other) {
+		return false
+	}
+
+	set := make(map[string]struct{}, len(types))
+
+	for _, t := range types {
+		set[t] = struct{}{}
+	}
+
+	for _, t := range other {
+		if _, ok := set[t]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeError(rw http.ResponseWriter, status int, code, description, cNonce string) {
+	writeResponse(rw, status, &oidc4vci.Error{Code: code, Description: description, CNonce: cNonce})
+}
+
+func writeResponse(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(status)
+
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		logger.Errorf("Unable to send response, %v", err)
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/oidc4vci"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the store of the pre-authorized codes and access tokens.
+	StoreName = "oidc4vciissuer"
+	// FormatLDPVC is the format of the credentials signed by the verifiable command.
+	FormatLDPVC = "ldp_vc"
+
+	codeKeyPrefix  = "code_"
+	tokenKeyPrefix = "token_"
+
+	defaultCodeExpiry   = 10 * time.Minute
+	defaultTokenExpiry  = 5 * time.Minute
+	defaultCNonceExpiry = 5 * time.Minute
+
+	randomValueLength = 32
+)
+
+var logger = log.New("aries-framework/client/oidc4vci/issuer")
+
+// Provider contains dependencies for the issuer and is typically created by using aries.Context().
+type Provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+}
+
+// CredentialSigner signs the credentials issued, implemented by the verifiable command.
+type CredentialSigner interface {
+	SignCredential(rw io.Writer, req io.Reader) command.Error
+}
+
+// Issuer is the issuer side of OIDC4VCI.
+type Issuer struct {
+	store        storage.Store
+	vdr          vdrapi.Registry
+	signer       CredentialSigner
+	did          string
+	metadata     *oidc4vci.IssuerMetadata
+	proofOptions *verifiable.ProofOptions
+	codeExpiry   time.Duration
+	tokenExpiry  time.Duration
+	cNonceExpiry time.Duration
+	now          func() time.Time
+	mutex        sync.Mutex
+}
+
+// Opt configures the issuer.
+type Opt func(*Issuer)
+
+// WithProofOptions sets the options of the proofs of the issued credentials.
+func WithProofOptions(proofOptions *verifiable.ProofOptions) Opt {
+	return func(i *Issuer) {
+		i.proofOptions = proofOptions
+	}
+}
+
+// WithCodeExpiry sets the time the pre-authorized code of an offer can be exchanged for an access token in.
+func WithCodeExpiry(expiry time.Duration) Opt {
+	return func(i *Issuer) {
+		i.codeExpiry = expiry
+	}
+}
+
+// WithTokenExpiry sets the lifetime of the access tokens.
+func WithTokenExpiry(expiry time.Duration) Opt {
+	return func(i *Issuer) {
+		i.tokenExpiry = expiry
+	}
+}
+
+// WithCNonceExpiry sets the lifetime of the c_nonce values.
+func WithCNonceExpiry(expiry time.Duration) Opt {
+	return func(i *Issuer) {
+		i.cNonceExpiry = expiry
+	}
+}
+
+// New returns a new issuer, signing the credentials issued with the key of the given DID.
+func New(ctx Provider, signer CredentialSigner, did string, metadata *oidc4vci.IssuerMetadata,
+	opts ...Opt) (*Issuer, error) {
+	if metadata == nil || metadata.CredentialIssuer == "" {
+		return nil, errors.New("oidc4vci issuer - credential issuer is required")
+	}
+
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("oidc4vci issuer - open store: %w", err)
+	}
+
+	i := &Issuer{
+		store:        store,
+		vdr:          ctx.VDRegistry(),
+		signer:       signer,
+		did:          did,
+		metadata:     metadata,
+		codeExpiry:   defaultCodeExpiry,
+		tokenExpiry:  defaultTokenExpiry,
+		cNonceExpiry: defaultCNonceExpiry,
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// OfferOpt configures a credential offer.
+type OfferOpt func(*codeRecord)
+
+// WithUserPIN requires the wallet to send the given PIN, sent to the user through another channel, along with the
+// pre-authorized code.
+func WithUserPIN(pin string) OfferOpt {
+	return func(r *codeRecord) {
+		r.UserPIN = pin
+	}
+}
+
+// CreateOffer creates a credential offer of the given credentials for the pre-authorized code flow.
+func (i *Issuer) CreateOffer(credentials []*Credential, opts ...OfferOpt) (*oidc4vci.CredentialOffer, error) {
+	if len(credentials) == 0 {
+		return nil, errors.New("oidc4vci issuer - no credential offered")
+	}
+
+	offered := make([]*oidc4vci.OfferedCredential, len(credentials))
+
+	for j, credential := range credentials {
+		supported := i.supportedCredential(credential.ID)
+		if supported == nil {
+			return nil, fmt.Errorf("oidc4vci issuer - credential %s is not supported", credential.ID)
+		}
+
+		if supported.Format != FormatLDPVC {
+			return nil, fmt.Errorf("oidc4vci issuer - unsupported format %s of credential %s",
+				supported.Format, credential.ID)
+		}
+
+		offered[j] = &oidc4vci.OfferedCredential{ID: credential.ID}
+	}
+
+	record := &codeRecord{Credentials: credentials, ExpiresAt: i.now().Add(i.codeExpiry)}
+
+	for _, opt := range opts {
+		opt(record)
+	}
+
+	code, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.put(codeKeyPrefix+code, record); err != nil {
+		return nil, fmt.Errorf("oidc4vci issuer - save offer: %w", err)
+	}
+
+	return &oidc4vci.CredentialOffer{
+		CredentialIssuer: i.metadata.CredentialIssuer,
+		Credentials:      offered,
+		Grants: &oidc4vci.Grants{
+			PreAuthorizedCode: &oidc4vci.PreAuthorizedCodeGrant{
+				PreAuthorizedCode: code,
+				UserPINRequired:   record.UserPIN != "",
+			},
+		},
+	}, nil
+}
+
+// redeemCode exchanges the pre-authorized code for an access token, the code being usable only once.
+func (i *Issuer) redeemCode(code, userPIN string) (string, *tokenRecord, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	record := &codeRecord{}
+
+	err := i.get(codeKeyPrefix+code, record)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", nil, errInvalidGrant
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if i.now().After(record.ExpiresAt) {
+		return "", nil, errInvalidGrant
+	}
+
+	if subtle.ConstantTimeCompare([]byte(record.UserPIN), []byte(userPIN)) != 1 {
+		return "", nil, errInvalidGrant
+	}
+
+	if err = i.store.Delete(codeKeyPrefix + code); err != nil {
+		return "", nil, err
+	}
+
+	accessToken, err := randomValue()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &tokenRecord{Credentials: record.Credentials, ExpiresAt: i.now().Add(i.tokenExpiry)}
+
+	if err = i.saveToken(accessToken, token); err != nil {
+		return "", nil, err
+	}
+
+	return accessToken, token, nil
+}
+
+// saveToken saves the access token record with a fresh c_nonce.
+func (i *Issuer) saveToken(accessToken string, token *tokenRecord) error {
+	cNonce, err := randomValue()
+	if err != nil {
+		return err
+	}
+
+	token.CNonce = cNonce
+	token.CNonceExpiresAt = i.now().Add(i.cNonceExpiry)
+
+	return i.put(tokenKeyPrefix+accessToken, token)
+}
+
+func (i *Issuer) supportedCredential(id string) *oidc4vci.SupportedCredential {
+	for _, supported := range i.metadata.CredentialsSupported {
+		if supported.ID == id {
+			return supported
+		}
+	}
+
+	return nil
+}
+
+func (i *Issuer) put(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return i.store.Put(key, data)
+}
+
+func (i *Issuer) get(key string, v interface{}) error {
+	data, err := i.store.Get(key)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func randomValue() (string, error) {
+	b := make([]byte, randomValueLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("oidc4vci issuer - generate random value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
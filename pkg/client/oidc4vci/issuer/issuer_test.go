@@ -0,0 +1,386 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/oidc4vci"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const (
+	issuerDID    = "did:example:issuer"
+	holderDID    = "did:example:holder"
+	holderKeyID  = holderDID + "#key-1"
+	credentialID = "UniversityDegree"
+	sampleVC     = `{
+		"@context": ["https://www.w3.org/2018/credentials/v1"],
+		"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+		"issuer": "did:example:issuer",
+		"issuanceDate": "2022-01-01T00:00:00Z",
+		"credentialSubject": {"degree": "Bachelor of Science"}
+	}`
+)
+
+func TestNew(t *testing.T) {
+	t.Run("missing credential issuer", func(t *testing.T) {
+		_, err := New(newProvider(t, nil), &mockSigner{}, issuerDID, &oidc4vci.IssuerMetadata{})
+		require.EqualError(t, err, "oidc4vci issuer - credential issuer is required")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")},
+		}, &mockSigner{}, issuerDID, &oidc4vci.IssuerMetadata{CredentialIssuer: "https://issuer.example.com"})
+		require.EqualError(t, err, "oidc4vci issuer - open store: store error")
+	})
+}
+
+func TestIssuer_CreateOffer(t *testing.T) {
+	s := newTestServer(t, &mockSigner{})
+
+	t.Run("success", func(t *testing.T) {
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}},
+			WithUserPIN("1234"))
+		require.NoError(t, err)
+		require.Equal(t, s.URL, offer.CredentialIssuer)
+		require.Equal(t, credentialID, offer.Credentials[0].ID)
+		require.NotEmpty(t, offer.PreAuthorizedCode().PreAuthorizedCode)
+		require.True(t, offer.PreAuthorizedCode().UserPINRequired)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := s.issuer.CreateOffer(nil)
+		require.EqualError(t, err, "oidc4vci issuer - no credential offered")
+
+		_, err = s.issuer.CreateOffer([]*Credential{{ID: "unknown"}})
+		require.EqualError(t, err, "oidc4vci issuer - credential unknown is not supported")
+
+		_, err = s.issuer.CreateOffer([]*Credential{{ID: "JWTCredential"}})
+		require.EqualError(t, err, "oidc4vci issuer - unsupported format jwt_vc_json of credential JWTCredential")
+	})
+}
+
+func TestIssuer_ClaimPreAuthorized(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		signer := &mockSigner{}
+		s := newTestServer(t, signer)
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}},
+			WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		credentials, err := oidc4vci.New().ClaimPreAuthorized(offer, s.holder, oidc4vci.WithUserPIN("1234"))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Equal(t, FormatLDPVC, credentials[0].Format)
+		require.NotEmpty(t, credentials[0].CNonce)
+
+		vc := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(credentials[0].Credential, &vc))
+		require.Equal(t, holderDID, vc["credentialSubject"].(map[string]interface{})["id"])
+		require.NotNil(t, vc["proof"])
+
+		require.Equal(t, issuerDID, signer.request.DID)
+
+		// the pre-authorized code can be used once only.
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder, oidc4vci.WithUserPIN("1234"))
+		require.True(t, errors.Is(err, oidc4vci.ErrInvalidGrant))
+	})
+
+	t.Run("wrong user PIN", func(t *testing.T) {
+		s := newTestServer(t, &mockSigner{})
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}},
+			WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder, oidc4vci.WithUserPIN("4321"))
+		require.True(t, errors.Is(err, oidc4vci.ErrInvalidGrant))
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		s := newTestServer(t, &mockSigner{}, WithCodeExpiry(-time.Second))
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}})
+		require.NoError(t, err)
+
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder)
+		require.True(t, errors.Is(err, oidc4vci.ErrInvalidGrant))
+	})
+
+	t.Run("expired c_nonce", func(t *testing.T) {
+		s := newTestServer(t, &mockSigner{}, WithCNonceExpiry(-time.Second))
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}})
+		require.NoError(t, err)
+
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder)
+		require.True(t, errors.Is(err, oidc4vci.ErrInvalidProof))
+	})
+
+	t.Run("unknown holder key", func(t *testing.T) {
+		s := newTestServer(t, &mockSigner{})
+		s.holder.kid = "did:example:unknown#key-1"
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}})
+		require.NoError(t, err)
+
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder)
+		require.True(t, errors.Is(err, oidc4vci.ErrInvalidProof))
+	})
+
+	t.Run("sign credential error", func(t *testing.T) {
+		s := newTestServer(t, &mockSigner{err: errors.New("sign error")})
+
+		offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}})
+		require.NoError(t, err)
+
+		_, err = oidc4vci.New().ClaimPreAuthorized(offer, s.holder)
+		require.True(t, errors.Is(err, oidc4vci.ErrIssuer))
+	})
+}
+
+func TestIssuer_ServeToken(t *testing.T) {
+	s := newTestServer(t, &mockSigner{})
+
+	tests := []struct {
+		name string
+		form url.Values
+		code string
+	}{
+		{"unsupported grant type", url.Values{"grant_type": {"authorization_code"}}, errorUnsupportedGrantType},
+		{
+			"missing code", url.Values{"grant_type": {oidc4vci.PreAuthorizedCodeGrantType}},
+			errorInvalidRequest,
+		},
+		{
+			"unknown code", url.Values{
+				"grant_type":          {oidc4vci.PreAuthorizedCodeGrantType},
+				"pre-authorized_code": {"unknown"},
+			},
+			errorInvalidGrant,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(tc.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			s.issuer.ServeToken(rr, req)
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+			require.Equal(t, tc.code, decodeError(t, rr).Code)
+		})
+	}
+}
+
+func TestIssuer_ServeCredential(t *testing.T) {
+	s := newTestServer(t, &mockSigner{})
+
+	offer, err := s.issuer.CreateOffer([]*Credential{{ID: credentialID, Credential: []byte(sampleVC)}})
+	require.NoError(t, err)
+
+	accessToken, _, err := s.issuer.redeemCode(offer.PreAuthorizedCode().PreAuthorizedCode, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		accessToken string
+		body        string
+		status      int
+		code        string
+	}{
+		{"missing access token", "", "{}", http.StatusUnauthorized, errorInvalidToken},
+		{"unknown access token", "unknown", "{}", http.StatusUnauthorized, errorInvalidToken},
+		{"invalid request", accessToken, "--", http.StatusBadRequest, errorInvalidRequest},
+		{
+			"unsupported format", accessToken, `{"format":"jwt_vc_json"}`,
+			http.StatusBadRequest, errorUnsupportedCredentialFormat,
+		},
+		{
+			"unsupported type", accessToken, `{"format":"ldp_vc","types":["VerifiableCredential"]}`,
+			http.StatusBadRequest, errorUnsupportedCredentialType,
+		},
+		{
+			"missing proof", accessToken,
+			`{"format":"ldp_vc","types":["VerifiableCredential","UniversityDegreeCredential"]}`,
+			http.StatusBadRequest, errorInvalidOrMissingProof,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/credential", strings.NewReader(tc.body))
+
+			if tc.accessToken != "" {
+				req.Header.Set("Authorization", bearerPrefix+tc.accessToken)
+			}
+
+			s.issuer.ServeCredential(rr, req)
+			require.Equal(t, tc.status, rr.Code)
+
+			issuerErr := decodeError(t, rr)
+			require.Equal(t, tc.code, issuerErr.Code)
+
+			if tc.code == errorInvalidOrMissingProof {
+				require.NotEmpty(t, issuerErr.CNonce)
+			}
+		})
+	}
+}
+
+type testServer struct {
+	*httptest.Server
+	issuer *Issuer
+	holder *ed25519Signer
+}
+
+func newTestServer(t *testing.T, signer CredentialSigner, opts ...Opt) *testServer {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	s := &testServer{holder: &ed25519Signer{privKey: privKey, kid: holderKeyID}}
+
+	mux := http.NewServeMux()
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	s.issuer, err = New(newProvider(t, pubKey), signer, issuerDID, &oidc4vci.IssuerMetadata{
+		CredentialIssuer:   s.URL,
+		CredentialEndpoint: s.URL + "/credential",
+		TokenEndpoint:      s.URL + "/token",
+		CredentialsSupported: []*oidc4vci.SupportedCredential{
+			{
+				ID:     credentialID,
+				Format: FormatLDPVC,
+				Types:  []string{"VerifiableCredential", "UniversityDegreeCredential"},
+			},
+			{ID: "JWTCredential", Format: "jwt_vc_json", Types: []string{"VerifiableCredential"}},
+		},
+	}, opts...)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/.well-known/openid-credential-issuer", s.issuer.ServeMetadata)
+	mux.HandleFunc("/token", s.issuer.ServeToken)
+	mux.HandleFunc("/credential", s.issuer.ServeCredential)
+
+	return s
+}
+
+func newProvider(t *testing.T, holderKey ed25519.PublicKey) *mockprovider.Provider {
+	t.Helper()
+
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				if didID != holderDID {
+					return nil, vdrapi.ErrNotFound
+				}
+
+				vm := did.NewVerificationMethodFromBytes(holderKeyID, "Ed25519VerificationKey2018", holderDID,
+					holderKey)
+
+				return &did.DocResolution{DIDDocument: &did.Doc{
+					ID:                 holderDID,
+					VerificationMethod: []did.VerificationMethod{*vm},
+					Authentication: []did.Verification{
+						*did.NewReferencedVerification(vm, did.Authentication),
+					},
+				}}, nil
+			},
+		},
+	}
+}
+
+func decodeError(t *testing.T, rr *httptest.ResponseRecorder) *oidc4vci.Error {
+	t.Helper()
+
+	issuerErr := &oidc4vci.Error{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), issuerErr))
+
+	return issuerErr
+}
+
+// mockSigner adds a proof to the credentials as the verifiable command does.
+type mockSigner struct {
+	request *verifiable.SignCredentialRequest
+	err     error
+}
+
+func (s *mockSigner) SignCredential(rw io.Writer, req io.Reader) command.Error {
+	if s.err != nil {
+		return command.NewExecuteError(verifiable.SignCredentialErrorCode, s.err)
+	}
+
+	s.request = &verifiable.SignCredentialRequest{}
+
+	if err := json.NewDecoder(req).Decode(s.request); err != nil {
+		return command.NewValidationError(verifiable.InvalidRequestErrorCode, err)
+	}
+
+	vc := map[string]interface{}{}
+
+	if err := json.Unmarshal(s.request.Credential, &vc); err != nil {
+		return command.NewValidationError(verifiable.InvalidRequestErrorCode, err)
+	}
+
+	vc["proof"] = map[string]interface{}{"type": "Ed25519Signature2018", "verificationMethod": issuerDID + "#key-1"}
+
+	vcBytes, err := json.Marshal(vc)
+	if err != nil {
+		return command.NewExecuteError(verifiable.SignCredentialErrorCode, err)
+	}
+
+	if err = json.NewEncoder(rw).Encode(&verifiable.SignCredentialResponse{VerifiableCredential: vcBytes}); err != nil {
+		return command.NewExecuteError(verifiable.SignCredentialErrorCode, err)
+	}
+
+	return nil
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+	kid     string
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA", jose.HeaderKeyID: s.kid}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Credential is a credential offered to a wallet.
+type Credential struct {
+	// ID of the credential in the supported credentials of the issuer metadata.
+	ID string `json:"id"`
+	// Credential is the unsigned credential issued. Its subject ID is set to the DID of the holder key proven by
+	// the wallet.
+	Credential json.RawMessage `json:"credential"`
+}
+
+// codeRecord is the stored pre-authorized code of a credential offer.
+type codeRecord struct {
+	UserPIN     string        `json:"user_pin,omitempty"`
+	Credentials []*Credential `json:"credentials"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// tokenRecord is the stored access token a pre-authorized code was exchanged for.
+type tokenRecord struct {
+	Credentials     []*Credential `json:"credentials"`
+	CNonce          string        `json:"c_nonce"`
+	CNonceExpiresAt time.Time     `json:"c_nonce_expires_at"`
+	ExpiresAt       time.Time     `json:"expires_at"`
+}
+
+// tokenResponse is the response of the token endpoint.
+type tokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	CNonce          string `json:"c_nonce"`
+	CNonceExpiresIn int    `json:"c_nonce_expires_in"`
+}
+
+// credentialRequest is the request of the credential endpoint.
+type credentialRequest struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types,omitempty"`
+	Proof  *proof   `json:"proof,omitempty"`
+}
+
+type proof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+// proofClaims are the claims of the JWT proving possession of the holder key.
+type proofClaims struct {
+	Audience string `json:"aud"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce"`
+}
+
+// credentialResponse is the response of the credential endpoint.
+type credentialResponse struct {
+	Format          string          `json:"format"`
+	Credential      json.RawMessage `json:"credential"`
+	CNonce          string          `json:"c_nonce"`
+	CNonceExpiresIn int             `json:"c_nonce_expires_in"`
+}
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	proofTypeJWT = "jwt"
+	proofJWTType = "openid4vci-proof+jwt"
+)
+
+// verifyProof verifies the JWT proving possession of the holder key, returning the DID of the holder.
+func (i *Issuer) verifyProof(p *proof, token *tokenRecord) (string, error) {
+	if p == nil || p.ProofType != proofTypeJWT || p.JWT == "" {
+		return "", errors.New("missing jwt proof")
+	}
+
+	jws, err := jose.ParseJWS(p.JWT, i.proofVerifier())
+	if err != nil {
+		return "", fmt.Errorf("invalid proof: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != proofJWTType {
+		return "", fmt.Errorf("invalid proof type %s", typ)
+	}
+
+	claims := &proofClaims{}
+
+	if err = json.Unmarshal(jws.Payload, claims); err != nil {
+		return "", fmt.Errorf("invalid proof claims: %w", err)
+	}
+
+	if claims.Audience != i.metadata.CredentialIssuer {
+		return "", fmt.Errorf("invalid proof audience %s", claims.Audience)
+	}
+
+	if claims.IssuedAt == 0 {
+		return "", errors.New("missing proof issuance time")
+	}
+
+	if claims.Nonce != token.CNonce || i.now().After(token.CNonceExpiresAt) {
+		return "", errors.New("invalid or expired c_nonce")
+	}
+
+	kid, _ := jws.ProtectedHeaders.KeyID()
+
+	return strings.Split(kid, "#")[0], nil
+}
+
+// proofVerifier verifies the signature of the proof with the key the `kid` header references, a DID URL.
+func (i *Issuer) proofVerifier() jose.SignatureVerifier {
+	return jose.NewCompositeAlgSigVerifier(
+		jose.AlgSignatureVerifier{Alg: "EdDSA", Verifier: i.signatureVerifier(jwt.VerifyEdDSA)},
+		jose.AlgSignatureVerifier{Alg: "RS256", Verifier: i.signatureVerifier(jwt.VerifyRS256)},
+	)
+}
+
+func (i *Issuer) signatureVerifier(
+	verify func(pubKey *verifier.PublicKey, message, signature []byte) error) jose.SignatureVerifier {
+	return jose.SignatureVerifierFunc(func(headers jose.Headers, _, signingInput, signature []byte) error {
+		kid, _ := headers.KeyID()
+
+		parts := strings.Split(kid, "#")
+		if len(parts) != 2 { //nolint:gomnd
+			return fmt.Errorf("proof key ID %s is not a DID URL", kid)
+		}
+
+		pubKey, err := docverifiable.NewVDRKeyResolver(i.vdr).PublicKeyFetcher()(parts[0], "#"+parts[1])
+		if err != nil {
+			return err
+		}
+
+		return verify(pubKey, signingInput, signature)
+	})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vci
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PreAuthorizedCodeGrantType is the grant type of the pre-authorized code flow.
+const PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+
+// CredentialOffer is the credential offer of an issuer, sent to the wallet by value or by reference.
+type CredentialOffer struct {
+	// CredentialIssuer is the URL of the credential issuer.
+	CredentialIssuer string `json:"credential_issuer"`
+	// Credentials are the credentials offered.
+	Credentials []*OfferedCredential `json:"credentials"`
+	// Grants are the grant types the issuer is prepared to process for this offer.
+	Grants *Grants `json:"grants,omitempty"`
+}
+
+// PreAuthorizedCode returns the pre-authorized code grant of the offer, nil if the offer doesn't have one.
+func (o *CredentialOffer) PreAuthorizedCode() *PreAuthorizedCodeGrant {
+	if o.Grants == nil {
+		return nil
+	}
+
+	return o.Grants.PreAuthorizedCode
+}
+
+// Grants of a credential offer.
+type Grants struct {
+	// PreAuthorizedCode grant of the pre-authorized code flow.
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// PreAuthorizedCodeGrant is the grant of the pre-authorized code flow.
+type PreAuthorizedCodeGrant struct {
+	// PreAuthorizedCode is the code representing the authorization of the issuer to issue the credentials.
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+	// UserPINRequired tells if the issuer expects a PIN, sent to the user through another channel, along with the code.
+	UserPINRequired bool `json:"user_pin_required,omitempty"`
+}
+
+// OfferedCredential is a credential of a credential offer, either referenced by the ID of the credential in the
+// issuer metadata or described by its format and types.
+type OfferedCredential struct {
+	// ID of the credential in the issuer metadata, when the credential is offered by reference.
+	ID string `json:"-"`
+	// Format of the credential.
+	Format string `json:"format,omitempty"`
+	// Types of the credential.
+	Types []string `json:"types,omitempty"`
+}
+
+// MarshalJSON marshals the offered credential, as a string when it is offered by reference.
+func (c *OfferedCredential) MarshalJSON() ([]byte, error) {
+	if c.ID != "" {
+		return json.Marshal(c.ID)
+	}
+
+	type raw OfferedCredential
+
+	return json.Marshal((*raw)(c))
+}
+
+// UnmarshalJSON unmarshals the offered credential, from a string when it is offered by reference.
+func (c *OfferedCredential) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.ID)
+	}
+
+	type raw OfferedCredential
+
+	if err := json.Unmarshal(data, (*raw)(c)); err != nil {
+		return fmt.Errorf("invalid offered credential: %w", err)
+	}
+
+	return nil
+}
+
+// IssuerMetadata is the metadata of a credential issuer, published at
+// `{credential_issuer}/.well-known/openid-credential-issuer`.
+type IssuerMetadata struct {
+	CredentialIssuer     string                 `json:"credential_issuer"`
+	AuthorizationServer  string                 `json:"authorization_server,omitempty"`
+	CredentialEndpoint   string                 `json:"credential_endpoint"`
+	TokenEndpoint        string                 `json:"token_endpoint,omitempty"`
+	CredentialsSupported []*SupportedCredential `json:"credentials_supported,omitempty"`
+}
+
+// SupportedCredential is a credential the issuer supports.
+type SupportedCredential struct {
+	ID     string   `json:"id,omitempty"`
+	Format string   `json:"format"`
+	Types  []string `json:"types,omitempty"`
+}
+
+// authorizationServerMetadata is the OAuth 2.0 authorization server metadata (RFC 8414).
+type authorizationServerMetadata struct {
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+// tokenResponse is the response of the token endpoint.
+type tokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in,omitempty"`
+	CNonce          string `json:"c_nonce,omitempty"`
+	CNonceExpiresIn int    `json:"c_nonce_expires_in,omitempty"`
+}
+
+// credentialRequest is the request of the credential endpoint.
+type credentialRequest struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types,omitempty"`
+	Proof  *proof   `json:"proof,omitempty"`
+}
+
+type proof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+// proofClaims are the claims of the JWT proving possession of the holder key.
+type proofClaims struct {
+	Issuer   string `json:"iss,omitempty"`
+	Audience string `json:"aud"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+// CredentialResponse is the response of the credential endpoint.
+type CredentialResponse struct {
+	// Format of the issued credential.
+	Format string `json:"format"`
+	// Credential issued, a JSON string for JWT credentials or a JSON object for JSON-LD credentials.
+	Credential json.RawMessage `json:"credential"`
+	// CNonce to be used in the proof of the next credential request.
+	CNonce string `json:"c_nonce,omitempty"`
+}
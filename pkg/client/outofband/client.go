@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package outofband
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -91,9 +93,31 @@ type message struct {
 	Service            []interface{}
 	HandshakeProtocols []string
 	Attachments        []*decorator.Attachment
+	Credentials        []json.RawMessage
 	Accept             []string
 	ReuseAnyConnection bool
 	ReuseConnection    string
+	ExpiresTime        time.Time
+	MaxAccepts         int
+}
+
+// requests returns the attachments of the invitation, followed by the attached credentials.
+func (m *message) requests() []*decorator.Attachment {
+	if len(m.Credentials) == 0 {
+		return m.Attachments
+	}
+
+	requests := append([]*decorator.Attachment{}, m.Attachments...)
+
+	for _, vc := range m.Credentials {
+		requests = append(requests, &decorator.Attachment{
+			ID:       uuid.New().String(),
+			MimeType: outofband.CredentialMimeType,
+			Data:     decorator.AttachmentData{JSON: vc},
+		})
+	}
+
+	return requests
 }
 
 func (m *message) RouterConnection() string {
@@ -108,7 +132,7 @@ func (m *message) RouterConnection() string {
 type OobService interface {
 	service.Event
 	AcceptInvitation(*outofband.Invitation, outofband.Options) (string, error)
-	SaveInvitation(*outofband.Invitation) error
+	SaveInvitation(*outofband.Invitation, ...outofband.SaveOption) error
 	Actions() ([]outofband.Action, error)
 	ActionContinue(string, outofband.Options) error
 	ActionStop(string, error) error
@@ -182,7 +206,11 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 		Services:  services,
 		Accept:    msg.Accept,
 		Protocols: msg.HandshakeProtocols,
-		Requests:  msg.Attachments,
+		Requests:  msg.requests(),
+	}
+
+	if !msg.ExpiresTime.IsZero() {
+		inv.Timing = &decorator.Timing{ExpiresTime: msg.ExpiresTime}
 	}
 
 	if len(inv.Accept) == 0 {
@@ -211,7 +239,7 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 
 	cast := outofband.Invitation(*inv)
 
-	err := c.oobService.SaveInvitation(&cast)
+	err := c.oobService.SaveInvitation(&cast, outofband.WithMaxAccepts(msg.MaxAccepts))
 	if err != nil {
 		return nil, fmt.Errorf("failed to save outofband invitation : %w", err)
 	}
@@ -322,6 +350,53 @@ func WithAttachments(a ...*decorator.Attachment) MessageOption {
 	}
 }
 
+// WithCredentials allows you to attach signed JSON-LD verifiable credentials to the Invitation, which the invitee
+// claims from the Invitation alone, without a connection: see Credentials.
+func WithCredentials(vcs ...json.RawMessage) MessageOption {
+	return func(m *message) {
+		m.Credentials = vcs
+	}
+}
+
+// Credentials returns the verifiable credentials attached to the invitation with WithCredentials. The credentials
+// aren't verified.
+func Credentials(i *Invitation) ([]json.RawMessage, error) {
+	var vcs []json.RawMessage
+
+	for _, a := range i.Requests {
+		if !outofband.IsCredentialAttachment(a) {
+			continue
+		}
+
+		vc, err := a.Data.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch credential attachment %s : %w", a.ID, err)
+		}
+
+		vcs = append(vcs, vc)
+	}
+
+	return vcs, nil
+}
+
+// WithExpiry sets the `~timing.expires_time` of the Invitation. Once it has passed, the invitee rejects the Invitation
+// and the agent rejects the requests referring to it, raising a post-state event with an error wrapping
+// didexchange.ErrInvitationExpired.
+func WithExpiry(t time.Time) MessageOption {
+	return func(m *message) {
+		m.ExpiresTime = t
+	}
+}
+
+// WithMaxAccepts limits the number of parties which can connect with the Invitation, unlimited if zero. The requests
+// of the next parties are rejected, raising a post-state event with an error wrapping
+// didexchange.ErrInvitationExhausted.
+func WithMaxAccepts(n int) MessageOption {
+	return func(m *message) {
+		m.MaxAccepts = n
+	}
+}
+
 // WithAccept will set the given media type profiles in the Invitation's `accept` property.
 // Only valid values from RFC 0044 are supported.
 func WithAccept(a ...string) MessageOption {
@@ -418,6 +493,7 @@ func (c *Client) didServiceBlockFunc(p Provider) func(routerConnID string, accep
 				Type:            didCommServiceType,
 				RecipientKeys:   []string{didKey},
 				ServiceEndpoint: p.ServiceEndpoint(),
+				EndpointObject:  useDIDCommV2,
 			}, nil
 		}
 
@@ -437,6 +513,7 @@ func (c *Client) didServiceBlockFunc(p Provider) func(routerConnID string, accep
 			RecipientKeys:   []string{didKey},
 			RoutingKeys:     routingKeys,
 			ServiceEndpoint: serviceEndpoint,
+			EndpointObject:  useDIDCommV2,
 		}, nil
 	}
 }
@@ -218,6 +218,74 @@ func TestCreateInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Contains(t, inv.Requests, expected)
 	})
+	t.Run("WithCredentials", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		attachment := dummyAttachment(t)
+		vc := json.RawMessage(`{"id":"http://example.edu/credentials/1872","type":["VerifiableCredential"]}`)
+		inv, err := c.CreateInvitation(
+			nil,
+			WithAttachments(attachment),
+			WithCredentials(vc),
+		)
+		require.NoError(t, err)
+		require.Len(t, inv.Requests, 2)
+		require.Equal(t, attachment, inv.Requests[0])
+		require.Equal(t, outofband.CredentialMimeType, inv.Requests[1].MimeType)
+
+		data, err := json.Marshal(inv)
+		require.NoError(t, err)
+
+		received := &Invitation{}
+		require.NoError(t, json.Unmarshal(data, received))
+
+		vcs, err := Credentials(received)
+		require.NoError(t, err)
+		require.Len(t, vcs, 1)
+		require.JSONEq(t, string(vc), string(vcs[0]))
+	})
+	t.Run("WithExpiry and WithMaxAccepts", func(t *testing.T) {
+		saved := &didexchange.OOBInvitation{}
+		provider := withTestProvider()
+		provider.ServiceMap[outofband.Name] = &stubOOBService{
+			saveInvFunc: func(_ *outofband.Invitation, opts ...outofband.SaveOption) error {
+				for _, opt := range opts {
+					opt(saved)
+				}
+
+				return nil
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		expiry := time.Now().Add(time.Hour)
+		inv, err := c.CreateInvitation(
+			nil,
+			WithExpiry(expiry),
+			WithMaxAccepts(3),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, inv.Timing)
+		require.Equal(t, expiry, inv.Timing.ExpiresTime)
+		require.Equal(t, 3, saved.MaxAccepts)
+	})
+}
+
+func TestCredentials(t *testing.T) {
+	t.Run("no credentials", func(t *testing.T) {
+		vcs, err := Credentials(&Invitation{Requests: []*decorator.Attachment{dummyAttachment(t)}})
+		require.NoError(t, err)
+		require.Empty(t, vcs)
+	})
+
+	t.Run("invalid credential attachment", func(t *testing.T) {
+		_, err := Credentials(&Invitation{Requests: []*decorator.Attachment{{
+			ID:       "credential",
+			MimeType: outofband.CredentialMimeType,
+		}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to fetch credential attachment credential")
+	})
 }
 
 func TestClient_ActionContinue(t *testing.T) {
@@ -387,7 +455,7 @@ func withTestProvider() *mockprovider.Provider {
 type stubOOBService struct {
 	service.Event
 	acceptInvFunc      func(*outofband.Invitation, outofband.Options) (string, error)
-	saveInvFunc        func(*outofband.Invitation) error
+	saveInvFunc        func(*outofband.Invitation, ...outofband.SaveOption) error
 	actionsFunc        func() ([]outofband.Action, error)
 	actionContinueFunc func(string, outofband.Options) error
 	actionStopFunc     func(piid string, err error) error
@@ -401,9 +469,9 @@ func (s *stubOOBService) AcceptInvitation(i *outofband.Invitation, o outofband.O
 	return "", nil
 }
 
-func (s *stubOOBService) SaveInvitation(i *outofband.Invitation) error {
+func (s *stubOOBService) SaveInvitation(i *outofband.Invitation, opts ...outofband.SaveOption) error {
 	if s.saveInvFunc != nil {
-		return s.saveInvFunc(i)
+		return s.saveInvFunc(i, opts...)
 	}
 
 	return nil
@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+)
+
+// Profile is the public profile of an agent.
+type Profile = profile.Profile
+
+// Provider contains dependencies for the profile protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the profile service.
+type ProtocolService interface {
+	service.DIDComm
+	SetProfile(profile *profile.Profile, share bool) error
+	Profile() (*profile.Profile, error)
+	SendProfile(connectionID string, sendBackYours bool) (string, error)
+	RequestProfile(connectionID string) (string, error)
+	TheirProfile(connectionID string) (*profile.Profile, error)
+}
+
+// Client enable access to profile API.
+//
+// The profiles received from the connections are saved in the connection records, the profile.StateProfileUpdated
+// message event reports the changes of the profile of a connection.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of profile client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(profile.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	profileSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Profile Service failed")
+	}
+
+	return &Client{
+		Event:   profileSvc,
+		service: profileSvc,
+	}, nil
+}
+
+// SetProfile saves the profile of the agent, sent to all the completed connections with share.
+func (c *Client) SetProfile(p *Profile, share bool) error {
+	if err := c.service.SetProfile(p, share); err != nil {
+		return fmt.Errorf("profile client - set profile: %w", err)
+	}
+
+	return nil
+}
+
+// Profile returns the profile of the agent.
+func (c *Client) Profile() (*Profile, error) {
+	p, err := c.service.Profile()
+	if err != nil {
+		return nil, fmt.Errorf("profile client - profile: %w", err)
+	}
+
+	return p, nil
+}
+
+// SendProfile sends the profile of the agent to the other party of the connection, returning the ID of the message.
+// With sendBackYours, the other party is asked to send its profile in response.
+func (c *Client) SendProfile(connectionID string, sendBackYours bool) (string, error) {
+	id, err := c.service.SendProfile(connectionID, sendBackYours)
+	if err != nil {
+		return "", fmt.Errorf("profile client - send profile: %w", err)
+	}
+
+	return id, nil
+}
+
+// RequestProfile requests the profile of the other party of the connection, returning the ID of the request.
+func (c *Client) RequestProfile(connectionID string) (string, error) {
+	id, err := c.service.RequestProfile(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("profile client - request profile: %w", err)
+	}
+
+	return id, nil
+}
+
+// TheirProfile returns the profile received from the other party of the connection, nil if none was received.
+func (c *Client) TheirProfile(connectionID string) (*Profile, error) {
+	p, err := c.service.TheirProfile(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("profile client - their profile: %w", err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "service error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "invalid"})
+		require.EqualError(t, err, "cast service to Profile Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("profile", func(t *testing.T) {
+		var sent service.DIDCommMsgMap
+
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		require.NoError(t, client.SetProfile(&Profile{DisplayName: "Alice"}, false))
+
+		p, err := client.Profile()
+		require.NoError(t, err)
+		require.Equal(t, "Alice", p.DisplayName)
+
+		id, err := client.SendProfile(connectionutil.ConnectionID, true)
+		require.NoError(t, err)
+		require.Equal(t, id, sent.ID())
+		require.Equal(t, profile.ProfileMsgType, sent.Type())
+
+		id, err = client.RequestProfile(connectionutil.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, id, sent.ID())
+		require.Equal(t, profile.RequestProfileMsgType, sent.Type())
+
+		p, err = client.TheirProfile(connectionutil.ConnectionID)
+		require.NoError(t, err)
+		require.Nil(t, p)
+
+		err = client.SetProfile(&Profile{DisplayPicture: "picture.png"}, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "profile client - set profile: invalid profile")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		_, err = client.SendProfile("unknown", false)
+		require.EqualError(t, err, "profile client - send profile: connection not found")
+
+		_, err = client.RequestProfile("unknown")
+		require.EqualError(t, err, "profile client - request profile: connection not found")
+
+		_, err = client.TheirProfile("unknown")
+		require.EqualError(t, err, "profile client - their profile: connection not found")
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return profile.New(prov)
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+)
+
+type (
+	// Question is the question asked to the responder.
+	Question = questionanswer.Question
+	// ValidResponse is a response the responder can pick.
+	ValidResponse = questionanswer.ValidResponse
+	// Answer is the response picked by the responder.
+	Answer = questionanswer.Answer
+)
+
+// Provider contains dependencies for the question answer protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the question answer service.
+type ProtocolService interface {
+	service.DIDComm
+	SendQuestion(connectionID string, question *questionanswer.Question) (string, error)
+	SendAnswer(connectionID, questionID, response string) (string, error)
+	Question(questionID string) (*questionanswer.Question, error)
+}
+
+// Client enable access to question answer API.
+//
+// The responder receives the questions through the questionanswer.StateQuestionReceived message events, the
+// questioner is notified of the valid answers through the questionanswer.StateAnswerReceived message events.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of question answer client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(questionanswer.QuestionAnswer)
+	if err != nil {
+		return nil, err
+	}
+
+	questionAnswerSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Question Answer Service failed")
+	}
+
+	return &Client{
+		Event:   questionAnswerSvc,
+		service: questionAnswerSvc,
+	}, nil
+}
+
+// SendQuestion asks the connection a question, returning the ID of the question message.
+func (c *Client) SendQuestion(connectionID string, question *Question) (string, error) {
+	id, err := c.service.SendQuestion(connectionID, question)
+	if err != nil {
+		return "", fmt.Errorf("question answer client - send question: %w", err)
+	}
+
+	return id, nil
+}
+
+// SendAnswer answers the question received from the connection with one of its valid responses, returning the ID
+// of the answer message. The answer is signed when the question requires a signature.
+func (c *Client) SendAnswer(connectionID, questionID, response string) (string, error) {
+	id, err := c.service.SendAnswer(connectionID, questionID, response)
+	if err != nil {
+		return "", fmt.Errorf("question answer client - send answer: %w", err)
+	}
+
+	return id, nil
+}
+
+// Question returns the question received with the given ID, if not answered yet.
+func (c *Client) Question(questionID string) (*Question, error) {
+	question, err := c.service.Question(questionID)
+	if err != nil {
+		return nil, fmt.Errorf("question answer client - question: %w", err)
+	}
+
+	return question, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "service error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "invalid"})
+		require.EqualError(t, err, "cast service to Question Answer Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("questioner and responder", func(t *testing.T) {
+		var sent []service.DIDCommMsgMap
+
+		prov := newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = append(sent, msg.(service.DIDCommMsgMap))
+
+				return nil
+			},
+		})
+
+		client, err := New(prov)
+		require.NoError(t, err)
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, client.RegisterMsgEvent(states))
+
+		questionID, err := client.SendQuestion(connectionutil.ConnectionID, &Question{
+			QuestionText:   "Are you logging in?",
+			ValidResponses: []ValidResponse{{Text: "Yes"}, {Text: "No"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+
+		// the question sent is received back on the same connection.
+		svc, err := prov.Service(questionanswer.QuestionAnswer)
+		require.NoError(t, err)
+
+		_, err = svc.(service.DIDComm).HandleInbound(sent[0],
+			service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+		require.NoError(t, err)
+
+		state := <-states
+		require.Equal(t, questionanswer.StateQuestionReceived, state.StateID)
+
+		question, err := client.Question(questionID)
+		require.NoError(t, err)
+		require.Equal(t, "Are you logging in?", question.QuestionText)
+
+		_, err = client.SendAnswer(connectionutil.ConnectionID, questionID, "Yes")
+		require.NoError(t, err)
+		require.Len(t, sent, 2)
+
+		_, err = svc.(service.DIDComm).HandleInbound(sent[1],
+			service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+		require.NoError(t, err)
+
+		state = <-states
+		require.Equal(t, questionanswer.StateAnswerReceived, state.StateID)
+
+		thID, err := sent[1].ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, questionID, thID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}))
+		require.NoError(t, err)
+
+		_, err = client.SendQuestion(connectionutil.ConnectionID, &Question{ValidResponses: []ValidResponse{{Text: "Yes"}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "question answer client - send question")
+
+		_, err = client.SendAnswer(connectionutil.ConnectionID, "unknown", "Yes")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "question answer client - send answer")
+
+		_, err = client.Question("unknown")
+		require.True(t, errors.Is(err, questionanswer.ErrQuestionNotFound))
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return questionanswer.New(prov)
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// HealthStatus is the health check status of a connection.
+type HealthStatus = trustping.HealthStatus
+
+// Provider contains dependencies for the trust ping protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the trust ping service.
+type ProtocolService interface {
+	service.DIDComm
+	Ping(connectionID, comment string) (string, error)
+	SetHealthCheck(connectionID string, enabled bool) error
+	HealthCheck(connectionID string) (*trustping.HealthStatus, error)
+}
+
+// Client enable access to trust ping API.
+//
+// The health check of the connections is configured with the aries.WithTrustPingHealthCheck framework option: the
+// trustping.StateConnectionUnhealthy and trustping.StateConnectionHealthy message events report the changes of the
+// health of the monitored connections.
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of trust ping client.
+func New(ctx Provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, err
+	}
+
+	trustPingSvc, ok := svc.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Trust Ping Service failed")
+	}
+
+	return &Client{
+		Event:   trustPingSvc,
+		service: trustPingSvc,
+	}, nil
+}
+
+// Ping sends a ping requesting a response to the other party of the connection, returning the ID of the ping.
+func (c *Client) Ping(connectionID, comment string) (string, error) {
+	id, err := c.service.Ping(connectionID, comment)
+	if err != nil {
+		return "", fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return id, nil
+}
+
+// SetHealthCheck enables or disables the health check of the connection.
+func (c *Client) SetHealthCheck(connectionID string, enabled bool) error {
+	if err := c.service.SetHealthCheck(connectionID, enabled); err != nil {
+		return fmt.Errorf("trust ping client - set health check: %w", err)
+	}
+
+	return nil
+}
+
+// HealthCheck returns the health check status of the connection.
+func (c *Client) HealthCheck(connectionID string) (*HealthStatus, error) {
+	status, err := c.service.HealthCheck(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("trust ping client - health check: %w", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("get service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "service error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: "invalid"})
+		require.EqualError(t, err, "cast service to Trust Ping Service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("ping", func(t *testing.T) {
+		var sent service.DIDCommMsgMap
+
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}, trustping.WithHealthCheck(trustping.HealthCheckConfig{})))
+		require.NoError(t, err)
+
+		id, err := client.Ping(connectionutil.ConnectionID, "hello")
+		require.NoError(t, err)
+		require.Equal(t, id, sent.ID())
+		require.Equal(t, trustping.PingMsgType, sent.Type())
+
+		_, err = client.Ping("unknown", "")
+		require.EqualError(t, err, "trust ping client - ping: connection not found")
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{},
+			trustping.WithHealthCheck(trustping.HealthCheckConfig{})))
+		require.NoError(t, err)
+
+		status, err := client.HealthCheck(connectionutil.ConnectionID)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.True(t, status.Healthy)
+
+		require.NoError(t, client.SetHealthCheck(connectionutil.ConnectionID, true))
+
+		status, err = client.HealthCheck(connectionutil.ConnectionID)
+		require.NoError(t, err)
+		require.True(t, status.Enabled)
+
+		_, err = client.HealthCheck("unknown")
+		require.EqualError(t, err, "trust ping client - health check: connection not found")
+	})
+
+	t.Run("health check not configured", func(t *testing.T) {
+		client, err := New(newProvider(t, &mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		err = client.SetHealthCheck(connectionutil.ConnectionID, true)
+		require.EqualError(t, err, "trust ping client - set health check: health check is not configured")
+	})
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound,
+	opts ...trustping.Opt) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return trustping.New(prov, opts...)
+	})
+}
@@ -9,7 +9,6 @@ package vcwallet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -144,13 +143,14 @@ func (c *Client) Close() bool {
 	return c.wallet.Close()
 }
 
-// Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// Export produces a serialized exported wallet representation, encrypted by given password.
+// Exported wallet contains all wallet contents, keys of the wallet KMS and mappings of contents to collections,
+// to be imported into a wallet on another device.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- password: password to be used to encrypt exported wallet, required for importing it.
 //
-//	Returns exported locked wallet.
+//	Returns exported encrypted wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -158,18 +158,23 @@ func (c *Client) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Client) Export(password string) (json.RawMessage, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Export(auth, password)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- password: password used while exporting the wallet.
+//		- contents: exported wallet to be imported.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -179,9 +184,13 @@ func (c *Client) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Client) Import(password string, contents json.RawMessage) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.Import(auth, password, contents)
 }
 
 // Add adds given data model to wallet contents store.
@@ -276,6 +285,22 @@ func (c *Client) Query(params ...*wallet.QueryParams) ([]*verifiable.Presentatio
 	return c.wallet.Query(auth, params...)
 }
 
+// Capabilities reports which capability templates are satisfiable from the credentials of the wallet and via which
+// credentials, without creating presentations.
+//
+//	Args:
+//		- capability templates to check, eg. wallet.AgeOverTemplate, wallet.ResidencyTemplate,
+//		wallet.KYCLevelTemplate.
+//
+func (c *Client) Capabilities(templates ...*wallet.CapabilityTemplate) ([]*wallet.Capability, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Capabilities(auth, templates...)
+}
+
 // Issue adds proof to a Verifiable Credential.
 //
 //	Args:
@@ -372,6 +397,26 @@ func (c *Client) Connect(invitation *outofband.Invitation, options ...wallet.Con
 	return c.wallet.Connect(auth, invitation, options...)
 }
 
+// ClaimCredentials claims the verifiable credentials attached to an out-of-band invitation, without a connection:
+// the credentials are verified then added to the wallet.
+//
+// Args:
+// 		- invitation: out-of-band invitation with credentials attached by the issuer.
+// 		- options: options for claiming the credentials.
+//
+// Returns:
+// 		- claimed credentials if operation is successful.
+// 		- error if operation fails.
+//
+func (c *Client) ClaimCredentials(invitation *outofband.Invitation, options ...wallet.ClaimCredentialOptions) ([]*verifiable.Credential, error) { //nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.ClaimCredentials(auth, invitation, options...)
+}
+
 // ProposePresentation accepts out-of-band invitation and sends message proposing presentation
 // from wallet to relying party.
 //
@@ -419,3 +464,39 @@ func (c *Client) PresentProof(thID string, presentProofFrom wallet.PresentProofF
 
 	return c.wallet.PresentProof(auth, thID, presentProofFrom)
 }
+
+// ParseSIOPRequest parses and validates a self-issued OpenID provider (SIOPv2) request of a relying party.
+//
+// Args:
+// 		- request: SIOP request URI ('openid://' URI or URL with the request parameters in its query).
+// 		- options: options for fetching the request object by reference.
+//
+// Returns:
+// 		- the validated SIOP request.
+// 		- error if operation fails.
+//
+func (c *Client) ParseSIOPRequest(request string, options ...wallet.SIOPOptions) (*wallet.SIOPRequest, error) {
+	return c.wallet.ParseSIOPRequest(request, options...)
+}
+
+// RespondSIOPRequest responds to a self-issued OpenID provider (SIOPv2) request with an ID token signed by a wallet
+// DID key, posting the response to the relying party or returning it as a redirect URL for the user agent.
+//
+// Args:
+// 		- request: SIOP request, as parsed by ParseSIOPRequest.
+// 		- proofOptions: proof options for signing the ID token ('controller' is required).
+// 		- options: options for the ID token lifetime and posting the response.
+//
+// Returns:
+// 		- the SIOP response.
+// 		- error if operation fails.
+//
+func (c *Client) RespondSIOPRequest(request *wallet.SIOPRequest, proofOptions *wallet.ProofOptions,
+	options ...wallet.SIOPOptions) (*wallet.SIOPResponse, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.RespondSIOPRequest(auth, request, proofOptions, options...)
+}
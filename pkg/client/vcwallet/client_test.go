@@ -55,7 +55,6 @@ const (
 	sampleRemoteKMSAuth = "sample-auth-token"
 	sampleKeyServerURL  = "sample/keyserver/test"
 	sampleUserID        = "sample-user01"
-	toBeImplementedErr  = "to be implemented"
 	sampleClientErr     = "sample client err"
 	sampleDIDKey        = "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5"
 	sampleDIDKey2       = "did:key:z6MkwFKUCsf8wvn6eSSu1WFAKatN1yexiDM7bf7pZLSFjdz6"
@@ -727,33 +726,90 @@ func TestClient_OpenClose(t *testing.T) {
 	})
 }
 
-func TestClient_Export(t *testing.T) {
+func TestClient_ExportImport(t *testing.T) {
+	const password = "sample-export-password"
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	result, err := vcWalletClient.Export("")
+	defer vcWalletClient.Close()
+
+	err = vcWalletClient.Add(wallet.Metadata, []byte(sampleContentValid))
+	require.NoError(t, err)
+
+	exported, err := vcWalletClient.Export(password)
+	require.NoError(t, err)
+	require.NotEmpty(t, exported)
+
+	// import into wallet of another device.
+	targetctx := newMockProvider(t)
+	err = CreateProfile(sampleUserID+"-target", targetctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	targetClient, err := New(sampleUserID+"-target", targetctx)
+	require.NotEmpty(t, targetClient)
+	require.NoError(t, err)
+
+	// try locked wallet
+	err = targetClient.Import(password, exported)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+
+	require.NoError(t, targetClient.Open(wallet.WithUnlockByPassphrase(samplePassPhrase)))
+
+	defer targetClient.Close()
+
+	err = targetClient.Import(password, exported)
+	require.NoError(t, err)
+
+	content, err := targetClient.Get(wallet.Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+	require.JSONEq(t, sampleContentValid, string(content))
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+	result, err := vcWalletClient.Export(password)
+	require.True(t, errors.Is(err, ErrWalletLocked))
 	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
 }
 
-func TestClient_Import(t *testing.T) {
+func TestClient_Capabilities(t *testing.T) {
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	err = vcWalletClient.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	defer vcWalletClient.Close()
+
+	require.NoError(t, vcWalletClient.Add(wallet.Credential, []byte(sampleUDCVC)))
+
+	degree := &wallet.CapabilityTemplate{
+		ID: "degree",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:          "degree",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{Path: []string{"$.credentialSubject.degree"}}}},
+		}},
+	}
+
+	capabilities, err := vcWalletClient.Capabilities(degree, wallet.KYCLevelTemplate(1))
+	require.NoError(t, err)
+	require.Len(t, capabilities, 2)
+	require.True(t, capabilities[0].Satisfiable)
+	require.Equal(t, []string{"http://example.edu/credentials/1872"}, capabilities[0].Credentials)
+	require.False(t, capabilities[1].Satisfiable)
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+	capabilities, err = vcWalletClient.Capabilities(degree)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, capabilities)
 }
 
 func TestClient_Add(t *testing.T) {
@@ -1539,6 +1595,49 @@ func TestClient_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestClient_SIOP(t *testing.T) {
+	sampleUser := uuid.New().String()
+	mockctx := newMockProvider(t)
+	mockctx.CryptoValue = &cryptomock.Crypto{}
+
+	err := CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	// save a DID & corresponding key
+	require.NoError(t, vcWallet.Add(wallet.Key, []byte(sampleKeyContentBase58)))
+	require.NoError(t, vcWallet.Add(wallet.DIDResolutionResponse, []byte(sampleDIDResolutionResponse)))
+
+	request, err := vcWallet.ParseSIOPRequest("openid://?response_type=id_token&scope=openid&nonce=sample-nonce" +
+		"&client_id=https%3A%2F%2Frp.example.com&redirect_uri=https%3A%2F%2Frp.example.com%2Fcallback")
+	require.NoError(t, err)
+	require.Equal(t, wallet.SIOPResponseModeFragment, request.ResponseMode)
+
+	t.Run("test responding to SIOP request", func(t *testing.T) {
+		response, err := vcWallet.RespondSIOPRequest(request, &wallet.ProofOptions{Controller: sampleDIDKey})
+		require.NoError(t, err)
+		require.NotEmpty(t, response.IDToken)
+		require.True(t, strings.HasPrefix(response.RedirectURL, "https://rp.example.com/callback#id_token="))
+	})
+
+	t.Run("test failure while parsing SIOP request", func(t *testing.T) {
+		result, err := vcWallet.ParseSIOPRequest("openid://?response_type=code")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported response type")
+		require.Empty(t, result)
+	})
+
+	t.Run("test failure while responding to SIOP request (closed wallet)", func(t *testing.T) {
+		require.True(t, vcWallet.Close())
+
+		response, err := vcWallet.RespondSIOPRequest(request, &wallet.ProofOptions{Controller: sampleDIDKey})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, response)
+	})
+}
+
 func TestClient_Connect(t *testing.T) {
 	sampleUser := uuid.New().String()
 	mockctx := newMockProvider(t)
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lock provides a lock service local to the process, the default lock service of the framework, and helpers
+// to run the critical sections of the framework.
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+// DefaultTimeout is the time Do waits for a lock.
+const DefaultTimeout = 30 * time.Second
+
+var logger = log.New("aries-framework/lock")
+
+// Local is a lock service whose locks are local to the process. It is the default lock service of the framework,
+// suitable as long as a single instance of the agent runs against the storage.
+type Local struct {
+	mu    sync.Mutex
+	locks map[string]*localLock
+}
+
+type localLock struct {
+	held chan struct{}
+	refs int
+}
+
+// NewLocal returns a new lock service local to the process.
+func NewLocal() *Local {
+	return &Local{locks: make(map[string]*localLock)}
+}
+
+// Lock acquires the lock with the given name, blocking until the lock is acquired or the context is done.
+func (l *Local) Lock(ctx context.Context, name string) (spilock.Lock, error) {
+	l.mu.Lock()
+
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &localLock{held: make(chan struct{}, 1)}
+		l.locks[name] = lock
+	}
+
+	lock.refs++
+
+	l.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+		return &localUnlocker{release: func() { l.release(name, lock, true) }}, nil
+	case <-ctx.Done():
+		l.release(name, lock, false)
+
+		return nil, ctx.Err()
+	}
+}
+
+func (l *Local) release(name string, lock *localLock, held bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if held {
+		<-lock.held
+	}
+
+	lock.refs--
+
+	if lock.refs == 0 {
+		delete(l.locks, name)
+	}
+}
+
+type localUnlocker struct {
+	once    sync.Once
+	release func()
+}
+
+func (u *localUnlocker) Unlock() error {
+	err := spilock.ErrNotHeld
+
+	u.once.Do(func() {
+		u.release()
+
+		err = nil
+	})
+
+	return err
+}
+
+// Do runs f holding the lock with the given name, waiting at most DefaultTimeout for the lock.
+func Do(service spilock.Service, name string, f func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	lock, err := service.Lock(ctx, name)
+	if err != nil {
+		return fmt.Errorf("acquire lock %s: %w", name, err)
+	}
+
+	defer func() {
+		if e := lock.Unlock(); e != nil {
+			logger.Warnf("release lock %s: %s", name, e)
+		}
+	}()
+
+	return f()
+}
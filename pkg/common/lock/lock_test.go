@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+func TestLocal_Lock(t *testing.T) {
+	t.Run("the lock is exclusive", func(t *testing.T) {
+		service := NewLocal()
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			running int
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				lock, err := service.Lock(context.Background(), "lock")
+				require.NoError(t, err)
+
+				mu.Lock()
+				running++
+				require.Equal(t, 1, running)
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				require.NoError(t, lock.Unlock())
+			}()
+		}
+
+		wg.Wait()
+		require.Empty(t, service.locks)
+	})
+
+	t.Run("the locks are independent", func(t *testing.T) {
+		service := NewLocal()
+
+		lock, err := service.Lock(context.Background(), "lock-1")
+		require.NoError(t, err)
+
+		other, err := service.Lock(context.Background(), "lock-2")
+		require.NoError(t, err)
+
+		require.NoError(t, other.Unlock())
+		require.NoError(t, lock.Unlock())
+	})
+
+	t.Run("context done while waiting for the lock", func(t *testing.T) {
+		service := NewLocal()
+
+		lock, err := service.Lock(context.Background(), "lock")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = service.Lock(ctx, "lock")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		require.NoError(t, lock.Unlock())
+		require.Empty(t, service.locks)
+
+		lock, err = service.Lock(context.Background(), "lock")
+		require.NoError(t, err)
+		require.NoError(t, lock.Unlock())
+	})
+
+	t.Run("unlock twice", func(t *testing.T) {
+		service := NewLocal()
+
+		lock, err := service.Lock(context.Background(), "lock")
+		require.NoError(t, err)
+
+		require.NoError(t, lock.Unlock())
+		require.ErrorIs(t, lock.Unlock(), spilock.ErrNotHeld)
+	})
+}
+
+func TestDo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := NewLocal()
+
+		err := Do(service, "lock", func() error {
+			// the lock is held while running f
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := service.Lock(ctx, "lock")
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+
+			return errors.New("critical section error")
+		})
+		require.EqualError(t, err, "critical section error")
+
+		// the lock is released after running f
+		lock, err := service.Lock(context.Background(), "lock")
+		require.NoError(t, err)
+		require.NoError(t, lock.Unlock())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		err := Do(&mocklock.MockLockService{ErrLock: errors.New("lock error")}, "lock", func() error {
+			require.Fail(t, "f must not be called")
+
+			return nil
+		})
+		require.EqualError(t, err, "acquire lock lock: lock error")
+	})
+
+	t.Run("unlock error", func(t *testing.T) {
+		service := &mocklock.MockLockService{ErrUnlock: errors.New("unlock error")}
+
+		require.NoError(t, Do(service, "lock", func() error {
+			return nil
+		}))
+		require.Equal(t, []string{"lock"}, service.Locked())
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
 	"github.com/hyperledger/aries-framework-go/spi/log"
 )
 
@@ -24,18 +25,25 @@ const (
 // It encapsulates default or custom logger to provide module and level based logging.
 type Log struct {
 	instance log.Logger
+	provider log.LoggerProvider
 	module   string
-	once     sync.Once
+	fields   []log.Field
+	mutex    sync.RWMutex
 }
 
 // New creates and returns a Logger implementation based on given module name.
 // note: the underlying logger instance is lazy initialized on first use.
-// To use your own logger implementation provide logger provider in 'Initialize()' before logging any line.
-// If 'Initialize()' is not called before logging any line then default logging implementation will be used.
+// To use your own logger implementation provide logger provider in 'Initialize()'.
+// If 'Initialize()' is not called then default logging implementation will be used.
 func New(module string) *Log {
 	return &Log{module: module}
 }
 
+// With returns a logger of the same module adding the given fields to every structured log entry.
+func (l *Log) With(fields ...log.Field) *Log {
+	return &Log{module: l.module, fields: l.withFields(fields)}
+}
+
 // Fatalf calls Fatalf function of underlying logger
 // should possibly cause system shutdown based on implementation.
 func (l *Log) Fatalf(msg string, args ...interface{}) {
@@ -68,14 +76,68 @@ func (l *Log) Errorf(msg string, args ...interface{}) {
 	l.logger().Errorf(msg, args...)
 }
 
+// Debug writes a structured log entry at DEBUG level.
+func (l *Log) Debug(msg string, fields ...log.Field) {
+	l.structuredLogger().Log(log.DEBUG, msg, l.withFields(fields)...)
+}
+
+// Info writes a structured log entry at INFO level.
+func (l *Log) Info(msg string, fields ...log.Field) {
+	l.structuredLogger().Log(log.INFO, msg, l.withFields(fields)...)
+}
+
+// Warn writes a structured log entry at WARNING level.
+func (l *Log) Warn(msg string, fields ...log.Field) {
+	l.structuredLogger().Log(log.WARNING, msg, l.withFields(fields)...)
+}
+
+// Error writes a structured log entry at ERROR level.
+func (l *Log) Error(msg string, fields ...log.Field) {
+	l.structuredLogger().Log(log.ERROR, msg, l.withFields(fields)...)
+}
+
+// logger returns the logger of the current logger provider, the instance is replaced when 'Initialize()'
+// switches the provider.
 func (l *Log) logger() log.Logger {
-	l.once.Do(func() {
-		l.instance = loggerProvider().GetLogger(l.module)
-	})
+	provider := loggerProvider()
+
+	l.mutex.RLock()
+	instance, current := l.instance, l.provider
+	l.mutex.RUnlock()
+
+	if current == provider && instance != nil {
+		return instance
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.provider != provider || l.instance == nil {
+		l.instance = provider.GetLogger(l.module)
+		l.provider = provider
+	}
 
 	return l.instance
 }
 
+func (l *Log) structuredLogger() log.StructuredLogger {
+	logger := l.logger()
+
+	if structured, ok := logger.(log.StructuredLogger); ok {
+		return structured
+	}
+
+	return modlog.NewModLog(logger, l.module)
+}
+
+func (l *Log) withFields(fields []log.Field) []log.Field {
+	if len(l.fields) == 0 {
+		return fields
+	}
+
+	return append(append(make([]log.Field, 0, len(l.fields)+len(fields)), l.fields...), fields...)
+}
+
 // SetLevel - setting log level for given module
 //  Parameters:
 //  module is module name
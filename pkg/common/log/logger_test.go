@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
-	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,7 +18,7 @@ import (
 
 // TestDefaultLogger tests default logging feature when no custom logging provider is supplied via 'Initialize()' call.
 func TestDefaultLogger(t *testing.T) {
-	defer func() { loggerProviderOnce = sync.Once{} }()
+	defer func() { loggerProviderInstance = nil }()
 
 	const module = "sample-module"
 
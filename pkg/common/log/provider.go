@@ -17,27 +17,41 @@ import (
 //nolint:gochecknoglobals
 var (
 	loggerProviderInstance log.LoggerProvider
-	loggerProviderOnce     sync.Once
+	loggerProviderMutex    sync.RWMutex
 )
 
 // Initialize sets new custom logging provider which takes over logging operations.
-// It is required to call this function before making any loggings for using custom loggers.
+// Loggers created by New switch to the loggers of the given provider on their next log output, a nil provider
+// restores the default logger.
 func Initialize(l log.LoggerProvider) {
-	loggerProviderOnce.Do(func() {
-		loggerProviderInstance = &modlogProvider{l}
-		logger := loggerProviderInstance.GetLogger(loggerModule)
-		logger.Debugf("Logger provider initialized")
-	})
+	provider := &modlogProvider{l}
+
+	loggerProviderMutex.Lock()
+	loggerProviderInstance = provider
+	loggerProviderMutex.Unlock()
+
+	provider.GetLogger(loggerModule).Debugf("Logger provider initialized")
 }
 
 func loggerProvider() log.LoggerProvider {
-	loggerProviderOnce.Do(func() {
-		// A custom logger must be initialized prior to the first log output
-		// Otherwise the built-in logger is used
+	loggerProviderMutex.RLock()
+	provider := loggerProviderInstance
+	loggerProviderMutex.RUnlock()
+
+	if provider != nil {
+		return provider
+	}
+
+	loggerProviderMutex.Lock()
+	defer loggerProviderMutex.Unlock()
+
+	if loggerProviderInstance == nil {
+		// A custom logger should be initialized prior to the first log output
+		// Otherwise the built-in logger is used until one is
 		loggerProviderInstance = &modlogProvider{}
 		logger := loggerProviderInstance.GetLogger(loggerModule)
 		logger.Debugf(loggerNotInitializedMsg)
-	})
+	}
 
 	return loggerProviderInstance
 }
@@ -7,16 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
-	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log/mocklogger"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
 	"github.com/hyperledger/aries-framework-go/spi/log"
 )
 
 // TestDefaultLogger tests custom logging feature when custom logging provider is supplied through 'Initialize()' call.
 func TestCustomLogger(t *testing.T) {
-	defer func() { loggerProviderOnce = sync.Once{} }()
+	defer func() { loggerProviderInstance = nil }()
 
 	const module = "sample-module"
 
@@ -29,6 +31,83 @@ func TestCustomLogger(t *testing.T) {
 	modlog.VerifyCustomLogger(t, logger, module)
 }
 
+// TestInitializeSwitchesLoggers tests that loggers created before 'Initialize()' use the new logging provider.
+func TestInitializeSwitchesLoggers(t *testing.T) {
+	defer func() { loggerProviderInstance = nil }()
+
+	const module = "sample-module-switch"
+
+	SetLevel(module, log.INFO)
+
+	first := &mocklogger.MockLogger{}
+	Initialize(&mocklogger.Provider{MockLogger: first})
+
+	logger := New(module)
+	logger.Infof("first %s", "message")
+	require.Contains(t, first.InfoLogContents, "first message")
+
+	second := &mocklogger.MockLogger{}
+	Initialize(&mocklogger.Provider{MockLogger: second})
+
+	logger.Infof("second %s", "message")
+	require.Contains(t, second.InfoLogContents, "second message")
+	require.NotContains(t, first.AllLogContents, "second message")
+}
+
+// TestStructuredLogging tests the structured log entries written to a custom printf logger.
+func TestStructuredLogging(t *testing.T) {
+	defer func() { loggerProviderInstance = nil }()
+
+	const module = "sample-module-structured"
+
+	SetLevel(module, log.INFO)
+
+	mockLogger := &mocklogger.MockLogger{}
+	Initialize(&mocklogger.Provider{MockLogger: mockLogger})
+
+	logger := New(module).With(log.Field{Key: "connectionID", Value: "conn-1"})
+
+	logger.Info("message sent", log.Field{Key: "attempt", Value: 1})
+	require.Equal(t, "message sent connectionID=conn-1 attempt=1\n", mockLogger.InfoLogContents)
+
+	logger.Warn("retrying", log.Field{Key: "error", Value: "connection refused"})
+	require.Equal(t, "retrying connectionID=conn-1 error=\"connection refused\"\n", mockLogger.WarnLogContents)
+
+	logger.Error("failed")
+	require.Equal(t, "failed connectionID=conn-1\n", mockLogger.ErrorLogContents)
+
+	logger.Debug("not logged")
+	require.Empty(t, mockLogger.DebugLogContents)
+
+	SetLevel(module, log.DEBUG)
+
+	logger.Debug("logged")
+	require.Equal(t, "logged connectionID=conn-1\n", mockLogger.DebugLogContents)
+}
+
+// TestStructuredLoggingProvider tests that structured log entries are passed to a structured custom logger.
+func TestStructuredLoggingProvider(t *testing.T) {
+	defer func() { loggerProviderInstance = nil }()
+
+	const module = "sample-module-structured-provider"
+
+	SetLevel(module, log.INFO)
+
+	structured := &structuredLogger{}
+	Initialize(&structuredProvider{structured})
+
+	logger := New(module).With(log.Field{Key: "a", Value: 1})
+	logger.With(log.Field{Key: "b", Value: 2}).Info("message", log.Field{Key: "c", Value: 3})
+	logger.Info("other")
+
+	require.Len(t, structured.entries, 2)
+	require.Equal(t, log.INFO, structured.entries[0].level)
+	require.Equal(t, "message", structured.entries[0].msg)
+	require.Equal(t, []log.Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		structured.entries[0].fields)
+	require.Equal(t, []log.Field{{Key: "a", Value: 1}}, structured.entries[1].fields)
+}
+
 // newCustomProvider return new sample logging provider to demonstrate custom logging provider.
 func newCustomProvider(module string) *sampleProvider {
 	return &sampleProvider{modlog.GetSampleCustomLogger(module)}
@@ -43,3 +122,30 @@ type sampleProvider struct {
 func (p *sampleProvider) GetLogger(module string) log.Logger {
 	return p.logger
 }
+
+type structuredEntry struct {
+	level  log.Level
+	msg    string
+	fields []log.Field
+}
+
+// structuredLogger is a custom logger recording the structured log entries.
+type structuredLogger struct {
+	mocklogger.MockLogger
+	entries []structuredEntry
+}
+
+// Log records the structured log entry.
+func (l *structuredLogger) Log(level log.Level, msg string, fields ...log.Field) {
+	l.entries = append(l.entries, structuredEntry{level: level, msg: msg, fields: fields})
+}
+
+// structuredProvider is a custom logging provider of structured loggers.
+type structuredProvider struct {
+	logger *structuredLogger
+}
+
+// GetLogger returns the structured logger.
+func (p *structuredProvider) GetLogger(string) log.Logger {
+	return p.logger
+}
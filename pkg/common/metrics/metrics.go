@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics defines the metrics recorded by the framework internals. Inject a Provider with
+// aries.WithMetricsProvider to collect them, the prometheus sub-package offers a ready-made implementation.
+package metrics
+
+import "time"
+
+// Provider records the metrics of the framework internals. Its methods are called on the hot path of the framework
+// and must not block.
+type Provider interface {
+	// EnvelopePacked counts an outbound DIDComm envelope packed with the given media type, err being the packing
+	// error if any.
+	EnvelopePacked(mediaType string, err error)
+	// EnvelopeUnpacked counts an inbound DIDComm envelope of the given encoding type, err being the unpacking error
+	// if any.
+	EnvelopeUnpacked(encodingType string, err error)
+	// StateTransition counts a protocol instance entering the given state.
+	StateTransition(protocol, state string)
+	// StorageOperation records the duration of an operation on a store, err being the operation error if any.
+	StorageOperation(store, operation string, duration time.Duration, err error)
+	// TransportError counts an outbound message the transport of the given scheme failed to send.
+	TransportError(scheme string)
+}
+
+// Noop is a Provider discarding the metrics, used when no provider is injected.
+type Noop struct{}
+
+// EnvelopePacked discards the metric.
+func (Noop) EnvelopePacked(string, error) {}
+
+// EnvelopeUnpacked discards the metric.
+func (Noop) EnvelopeUnpacked(string, error) {}
+
+// StateTransition discards the metric.
+func (Noop) StateTransition(string, string) {}
+
+// StorageOperation discards the metric.
+func (Noop) StorageOperation(string, string, time.Duration, error) {}
+
+// TransportError discards the metric.
+func (Noop) TransportError(string) {}
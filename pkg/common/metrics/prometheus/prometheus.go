@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package prometheus is a metrics.Provider exposing the metrics of the framework in the Prometheus text exposition
+// format (https://prometheus.io/docs/instrumenting/exposition_formats/), to be served on a `/metrics` endpoint.
+//
+//	metricsProvider := prometheus.New()
+//
+//	framework, err := aries.New(aries.WithMetricsProvider(metricsProvider))
+//
+//	router.Handle("/metrics", metricsProvider)
+package prometheus
+
+import (
+	"bufio"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+const (
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+var logger = log.New("aries-framework/common/metrics/prometheus")
+
+// DefaultStorageBuckets are the default buckets, in seconds, of the storage operation duration histogram.
+// nolint: gochecknoglobals
+var DefaultStorageBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// Provider is a metrics.Provider collecting the metrics of the framework for Prometheus.
+type Provider struct {
+	envelopesPacked   *counterVec
+	envelopesUnpacked *counterVec
+	stateTransitions  *counterVec
+	storageOperations *histogramVec
+	transportErrors   *counterVec
+}
+
+// Opt configures the provider.
+type Opt func(opts *options)
+
+type options struct {
+	namespace      string
+	storageBuckets []float64
+}
+
+// WithNamespace sets the namespace prefixing the metric names, "aries" by default.
+func WithNamespace(namespace string) Opt {
+	return func(opts *options) {
+		opts.namespace = namespace
+	}
+}
+
+// WithStorageBuckets sets the buckets, in seconds, of the storage operation duration histogram.
+func WithStorageBuckets(buckets ...float64) Opt {
+	return func(opts *options) {
+		opts.storageBuckets = buckets
+	}
+}
+
+// New returns a new Prometheus metrics provider.
+func New(opts ...Opt) *Provider {
+	o := &options{namespace: "aries", storageBuckets: DefaultStorageBuckets}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	prefix := o.namespace + "_"
+
+	return &Provider{
+		envelopesPacked: newCounterVec(prefix+"didcomm_envelopes_packed_total",
+			"Number of DIDComm envelopes packed.", "media_type", "status"),
+		envelopesUnpacked: newCounterVec(prefix+"didcomm_envelopes_unpacked_total",
+			"Number of DIDComm envelopes unpacked.", "encoding_type", "status"),
+		stateTransitions: newCounterVec(prefix+"protocol_state_transitions_total",
+			"Number of protocol state transitions.", "protocol", "state"),
+		storageOperations: newHistogramVec(prefix+"storage_operation_duration_seconds",
+			"Duration of the storage operations.", o.storageBuckets, "store", "operation", "status"),
+		transportErrors: newCounterVec(prefix+"transport_errors_total",
+			"Number of outbound messages the transports failed to send.", "transport"),
+	}
+}
+
+// EnvelopePacked counts an outbound DIDComm envelope packed with the given media type.
+func (p *Provider) EnvelopePacked(mediaType string, err error) {
+	p.envelopesPacked.inc(mediaType, status(err))
+}
+
+// EnvelopeUnpacked counts an inbound DIDComm envelope of the given encoding type.
+func (p *Provider) EnvelopeUnpacked(encodingType string, err error) {
+	p.envelopesUnpacked.inc(encodingType, status(err))
+}
+
+// StateTransition counts a protocol instance entering the given state.
+func (p *Provider) StateTransition(protocol, state string) {
+	p.stateTransitions.inc(protocol, state)
+}
+
+// StorageOperation records the duration of an operation on a store.
+func (p *Provider) StorageOperation(store, operation string, duration time.Duration, err error) {
+	p.storageOperations.observe(duration.Seconds(), store, operation, status(err))
+}
+
+// TransportError counts an outbound message the transport of the given scheme failed to send.
+func (p *Provider) TransportError(scheme string) {
+	p.transportErrors.inc(scheme)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (p *Provider) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", contentType)
+
+	w := bufio.NewWriter(rw)
+
+	p.envelopesPacked.write(w)
+	p.envelopesUnpacked.write(w)
+	p.stateTransitions.write(w)
+	p.storageOperations.write(w)
+	p.transportErrors.write(w)
+
+	if err := w.Flush(); err != nil {
+		logger.Errorf("Unable to send metrics, %v", err)
+	}
+}
+
+func status(err error) string {
+	if err != nil {
+		return statusError
+	}
+
+	return statusSuccess
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prometheus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+func TestProvider(t *testing.T) {
+	var p metrics.Provider = New(WithStorageBuckets(0.01, 0.001))
+
+	p.EnvelopePacked("application/didcomm-envelope-enc", nil)
+	p.EnvelopePacked("application/didcomm-envelope-enc", nil)
+	p.EnvelopePacked("application/didcomm-envelope-enc", errors.New("pack error"))
+	p.EnvelopeUnpacked("JWM/1.0", nil)
+	p.StateTransition("didexchange", "completed")
+	p.StateTransition("didexchange", `quo"ted`)
+	p.StorageOperation("didexchange", "put", 5*time.Millisecond, nil)
+	p.StorageOperation("didexchange", "put", 20*time.Millisecond, nil)
+	p.TransportError("http")
+
+	rr := httptest.NewRecorder()
+	p.(http.Handler).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, contentType, rr.Header().Get("Content-Type"))
+	require.Equal(t, `# HELP aries_didcomm_envelopes_packed_total Number of DIDComm envelopes packed.
+# TYPE aries_didcomm_envelopes_packed_total counter
+aries_didcomm_envelopes_packed_total{media_type="application/didcomm-envelope-enc",status="error"} 1
+aries_didcomm_envelopes_packed_total{media_type="application/didcomm-envelope-enc",status="success"} 2
+# HELP aries_didcomm_envelopes_unpacked_total Number of DIDComm envelopes unpacked.
+# TYPE aries_didcomm_envelopes_unpacked_total counter
+aries_didcomm_envelopes_unpacked_total{encoding_type="JWM/1.0",status="success"} 1
+# HELP aries_protocol_state_transitions_total Number of protocol state transitions.
+# TYPE aries_protocol_state_transitions_total counter
+aries_protocol_state_transitions_total{protocol="didexchange",state="completed"} 1
+aries_protocol_state_transitions_total{protocol="didexchange",state="quo\"ted"} 1
+# HELP aries_storage_operation_duration_seconds Duration of the storage operations.
+# TYPE aries_storage_operation_duration_seconds histogram
+aries_storage_operation_duration_seconds_bucket{store="didexchange",operation="put",status="success",le="0.001"} 0
+aries_storage_operation_duration_seconds_bucket{store="didexchange",operation="put",status="success",le="0.01"} 1
+aries_storage_operation_duration_seconds_bucket{store="didexchange",operation="put",status="success",le="+Inf"} 2
+aries_storage_operation_duration_seconds_sum{store="didexchange",operation="put",status="success"} 0.025
+aries_storage_operation_duration_seconds_count{store="didexchange",operation="put",status="success"} 2
+# HELP aries_transport_errors_total Number of outbound messages the transports failed to send.
+# TYPE aries_transport_errors_total counter
+aries_transport_errors_total{transport="http"} 1
+`, rr.Body.String())
+}
+
+func TestWithNamespace(t *testing.T) {
+	p := New(WithNamespace("agent"))
+	p.TransportError("ws")
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Contains(t, rr.Body.String(), `agent_transport_errors_total{transport="ws"} 1`)
+	require.Contains(t, rr.Body.String(), "# TYPE agent_storage_operation_duration_seconds histogram")
+}
@@ -0,0 +1,189 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelSeparator separates the label values of a series in its key, it can't appear in valid UTF-8 strings.
+const labelSeparator = "\xff"
+
+// nolint: gochecknoglobals
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// series is a labeled series of a metric.
+type series struct {
+	labelValues []string
+	value       float64
+	// histogram series only.
+	bucketCounts []uint64
+	count        uint64
+}
+
+// vec is a metric with labeled series.
+type vec struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	series     map[string]*series
+	mutex      sync.Mutex
+}
+
+func newVec(name, help, metricType string, labelNames []string) *vec {
+	return &vec{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		series:     make(map[string]*series),
+	}
+}
+
+// get returns the series with the given label values, creating it if needed. The mutex must be held.
+func (v *vec) get(labelValues []string, buckets int) *series {
+	key := strings.Join(labelValues, labelSeparator)
+
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues, bucketCounts: make([]uint64, buckets)}
+		v.series[key] = s
+	}
+
+	return s
+}
+
+// sortedSeries returns the series ordered by their label values. The mutex must be held.
+func (v *vec) sortedSeries() []*series {
+	keys := make([]string, 0, len(v.series))
+
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	sorted := make([]*series, len(keys))
+
+	for i, key := range keys {
+		sorted[i] = v.series[key]
+	}
+
+	return sorted
+}
+
+func (v *vec) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.metricType)
+}
+
+// labels formats the label pairs of the series, with the extra pair if any.
+func (v *vec) labels(labelValues []string, extra ...string) string {
+	pairs := make([]string, 0, len(labelValues)+1)
+
+	for i, value := range labelValues {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, v.labelNames[i], labelValueEscaper.Replace(value)))
+	}
+
+	if len(extra) == 2 { // nolint: gomnd
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[0], extra[1]))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// counterVec is a counter with labeled series.
+type counterVec struct {
+	*vec
+}
+
+func newCounterVec(name, help string, labelNames ...string) *counterVec {
+	return &counterVec{vec: newVec(name, help, "counter", labelNames)}
+}
+
+func (c *counterVec) inc(labelValues ...string) {
+	c.mutex.Lock()
+	c.get(labelValues, 0).value++
+	c.mutex.Unlock()
+}
+
+func (c *counterVec) write(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.writeHeader(w)
+
+	for _, s := range c.sortedSeries() {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labels(s.labelValues), formatFloat(s.value))
+	}
+}
+
+// histogramVec is a histogram with labeled series.
+type histogramVec struct {
+	*vec
+	buckets []float64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labelNames ...string) *histogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	return &histogramVec{vec: newVec(name, help, "histogram", labelNames), buckets: sorted}
+}
+
+func (h *histogramVec) observe(value float64, labelValues ...string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	s := h.get(labelValues, len(h.buckets))
+
+	// the counts are cumulative, each bucket counting the observations less than or equal to its upper bound.
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			s.bucketCounts[i]++
+		}
+	}
+
+	s.value += value
+	s.count++
+}
+
+func (h *histogramVec) write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.writeHeader(w)
+
+	for _, s := range h.sortedSeries() {
+		for i, upperBound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labels(s.labelValues, "le", formatFloat(upperBound)),
+				s.bucketCounts[i])
+		}
+
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labels(s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labels(s.labelValues), formatFloat(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labels(s.labelValues), s.count)
+	}
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/actionmenu")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid action menu controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.ActionMenu)
+	// SendMenuErrorCode is for failures in send menu command.
+	SendMenuErrorCode
+	// RequestMenuErrorCode is for failures in request menu command.
+	RequestMenuErrorCode
+	// PerformErrorCode is for failures in perform command.
+	PerformErrorCode
+	// ActiveMenuErrorCode is for failures in active menu command.
+	ActiveMenuErrorCode
+	// CloseMenuErrorCode is for failures in close menu command.
+	CloseMenuErrorCode
+)
+
+// constants for command action menu.
+const (
+	CommandName = "actionmenu"
+
+	SendMenu    = "SendMenu"
+	RequestMenu = "RequestMenu"
+	Perform     = "Perform"
+	ActiveMenu  = "ActiveMenu"
+	CloseMenu   = "CloseMenu"
+	// error messages.
+	errEmptyConnectionID = "empty connection_id"
+	errEmptyMenu         = "empty menu"
+	errEmptyName         = "empty name"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for action menu.
+type Command struct {
+	client *actionmenu.Client
+}
+
+// New returns new action menu controller command instance.
+func New(ctx actionmenu.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := actionmenu.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.ActionMenu+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SendMenu, c.SendMenu),
+		cmdutil.NewCommandHandler(CommandName, RequestMenu, c.RequestMenu),
+		cmdutil.NewCommandHandler(CommandName, Perform, c.Perform),
+		cmdutil.NewCommandHandler(CommandName, ActiveMenu, c.ActiveMenu),
+		cmdutil.NewCommandHandler(CommandName, CloseMenu, c.CloseMenu),
+	}
+}
+
+// SendMenu sends a menu to the connection.
+func (c *Command) SendMenu(rw io.Writer, req io.Reader) command.Error {
+	var args SendMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if args.Menu == nil {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyMenu)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMenu))
+	}
+
+	id, err := c.client.SendMenu(args.ConnectionID, args.Menu)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendMenu, err.Error())
+		return command.NewExecuteError(SendMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendMenu, successString)
+
+	return nil
+}
+
+// RequestMenu asks the connection for its menu.
+func (c *Command) RequestMenu(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, RequestMenu, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	id, err := c.client.RequestMenu(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestMenu, err.Error())
+		return command.NewExecuteError(RequestMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestMenu, successString)
+
+	return nil
+}
+
+// Perform performs an option of the menu received from the connection.
+func (c *Command) Perform(rw io.Writer, req io.Reader) command.Error {
+	var args PerformArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Perform, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Perform, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if args.Name == "" {
+		logutil.LogDebug(logger, CommandName, Perform, errEmptyName)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyName))
+	}
+
+	id, err := c.client.Perform(args.ConnectionID, &actionmenu.Perform{Name: args.Name, Params: args.Params})
+	if err != nil {
+		logutil.LogError(logger, CommandName, Perform, err.Error())
+		return command.NewExecuteError(PerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, Perform, successString)
+
+	return nil
+}
+
+// ActiveMenu returns the last menu received from the connection.
+func (c *Command) ActiveMenu(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, ActiveMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, ActiveMenu, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	menu, err := c.client.ActiveMenu(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ActiveMenu, err.Error())
+		return command.NewExecuteError(ActiveMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ActiveMenuResponse{Menu: menu}, logger)
+
+	logutil.LogDebug(logger, CommandName, ActiveMenu, successString)
+
+	return nil
+}
+
+// CloseMenu discards the menu received from the connection.
+func (c *Command) CloseMenu(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, CloseMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, CloseMenu, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if err := c.client.CloseMenu(args.ConnectionID); err != nil {
+		logutil.LogError(logger, CommandName, CloseMenu, err.Error())
+		return command.NewExecuteError(CloseMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, CloseMenu, successString)
+
+	return nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 5)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.EqualError(t, err, "cannot create a client: service error")
+	})
+}
+
+func TestCommand_Requester(t *testing.T) {
+	var sent []service.DIDCommMsgMap
+
+	prov := newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			sent = append(sent, msg.(service.DIDCommMsgMap))
+
+			return nil
+		},
+	})
+
+	cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.RequestMenu(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	res := &MessageIDResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), res))
+	require.Equal(t, sent[0].ID(), res.MessageID)
+
+	b.Reset()
+
+	cmdErr := cmd.ActiveMenu(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID}))
+	require.Error(t, cmdErr)
+	require.Equal(t, ActiveMenuErrorCode, cmdErr.Code())
+	require.Equal(t, command.ExecuteError, cmdErr.Type())
+
+	svc, err := prov.Service(actionmenu.ActionMenu)
+	require.NoError(t, err)
+
+	_, err = svc.(service.DIDComm).HandleInbound(service.NewDIDCommMsgMap(&actionmenu.Menu{
+		Type:    actionmenu.MenuMsgType,
+		ID:      "menu-1",
+		Title:   "Welcome",
+		Options: []actionmenu.MenuOption{{Name: "option-1", Title: "Option 1"}},
+	}), service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+	require.NoError(t, err)
+
+	require.NoError(t, cmd.ActiveMenu(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	menu := &ActiveMenuResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), menu))
+	require.Equal(t, "menu-1", menu.Menu.ID)
+
+	b.Reset()
+
+	require.NoError(t, cmd.Perform(&b, newReader(t, &PerformArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		Name:         "option-1",
+		Params:       map[string]string{"email": "alice@example.com"},
+	})))
+	require.Len(t, sent, 2)
+
+	thID, err := sent[1].ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, "menu-1", thID)
+
+	b.Reset()
+
+	require.NoError(t, cmd.CloseMenu(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID})))
+	require.Error(t, cmd.ActiveMenu(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID})))
+}
+
+func TestCommand_SendMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var sent service.DIDCommMsgMap
+
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SendMenu(&b, newReader(t, &SendMenuArgs{
+			ConnectionID: connectionutil.ConnectionID,
+			Menu:         &actionmenu.Menu{Title: "Welcome"},
+		})))
+
+		res := &MessageIDResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), res))
+		require.Equal(t, actionmenu.MenuMsgType, sent.Type())
+		require.Equal(t, sent.ID(), res.MessageID)
+	})
+
+	t.Run("empty menu", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendMenu(&b, newReader(t, &SendMenuArgs{ConnectionID: connectionutil.ConnectionID}))
+		require.EqualError(t, cmdErr, errEmptyMenu)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+}
+
+func TestCommand_Errors(t *testing.T) {
+	prov := newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+	prov.StorageProviderValue.(*mockstore.MockStoreProvider).Store.ErrDelete = errors.New("delete error")
+
+	cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		fn      command.Exec
+		args    interface{}
+		code    command.Code
+		errType command.Type
+	}{
+		{SendMenu, cmd.SendMenu, &SendMenuArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{RequestMenu, cmd.RequestMenu, &ConnectionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{Perform, cmd.Perform, &PerformArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			Perform, cmd.Perform, &PerformArgs{ConnectionID: connectionutil.ConnectionID},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{ActiveMenu, cmd.ActiveMenu, &ConnectionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{CloseMenu, cmd.CloseMenu, &ConnectionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			SendMenu, cmd.SendMenu, &SendMenuArgs{ConnectionID: connectionutil.ConnectionID, Menu: &actionmenu.Menu{}},
+			SendMenuErrorCode, command.ExecuteError,
+		},
+		{
+			RequestMenu, cmd.RequestMenu, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID},
+			RequestMenuErrorCode, command.ExecuteError,
+		},
+		{
+			Perform, cmd.Perform, &PerformArgs{ConnectionID: connectionutil.ConnectionID, Name: "option-1"},
+			PerformErrorCode, command.ExecuteError,
+		},
+		{
+			CloseMenu, cmd.CloseMenu, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID},
+			CloseMenuErrorCode, command.ExecuteError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%s %d", tc.name, tc.code), func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+			require.Equal(t, tc.errType, cmdErr.Type())
+		})
+
+		t.Run(tc.name+" invalid request", func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		})
+	}
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return actionmenu.New(prov)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+)
+
+// ConnectionArgs model
+//
+// This is used for the action menu commands of a connection.
+//
+type ConnectionArgs struct {
+	// ConnectionID is the ID of the connection.
+	ConnectionID string `json:"connection_id"`
+}
+
+// SendMenuArgs model
+//
+// This is used for sending a menu.
+//
+type SendMenuArgs struct {
+	// ConnectionID is the ID of the connection the menu is sent to.
+	ConnectionID string `json:"connection_id"`
+	// Menu is the menu sent.
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// PerformArgs model
+//
+// This is used for performing an option of a menu.
+//
+type PerformArgs struct {
+	// ConnectionID is the ID of the connection the menu was received from.
+	ConnectionID string `json:"connection_id"`
+	// Name is the name of the menu option performed.
+	Name string `json:"name"`
+	// Params are the parameters of the form of the menu option.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// MessageIDResponse model
+//
+// Represents the response of the commands sending a message.
+//
+type MessageIDResponse struct {
+	// MessageID is the ID of the message sent.
+	MessageID string `json:"message_id"`
+}
+
+// ActiveMenuResponse model
+//
+// Represents the ActiveMenu response message.
+//
+type ActiveMenuResponse struct {
+	// Menu is the last menu received from the connection.
+	Menu *actionmenu.Menu `json:"menu"`
+}
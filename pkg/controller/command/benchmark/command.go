@@ -0,0 +1,157 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/benchmark"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/benchmark")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Benchmark)
+	// RunErrorCode is for failures in run command.
+	RunErrorCode
+)
+
+// constants for the benchmark commands.
+const (
+	// command name.
+	CommandName = "benchmark"
+
+	// command methods.
+	RunCommandMethod = "Run"
+
+	// MaxDuration is the max duration each case is run for on each payload size.
+	MaxDuration = 10 * time.Second
+
+	// error messages.
+	errRunInProgress = "a benchmark run is in progress"
+
+	// log constants.
+	successString = "success"
+)
+
+// Command contains the command running the benchmark of the packers and of the linked data proof suites on the
+// hardware of the agent. The keys of the benchmark are kept in memory, apart from the ones of the agent.
+type Command struct {
+	cases   []benchmark.Case
+	lock    sync.Mutex
+	running bool
+}
+
+// New returns new benchmark command instance.
+func New() (*Command, error) {
+	cases, err := benchmark.Cases()
+	if err != nil {
+		return nil, fmt.Errorf("create benchmark cases: %w", err)
+	}
+
+	return &Command{cases: cases}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, RunCommandMethod, c.Run),
+	}
+}
+
+// Run runs the benchmark and returns the report of the results, one run at a time so that the runs don't skew each
+// other's results.
+func (c *Command) Run(rw io.Writer, req io.Reader) command.Error {
+	var args RunArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RunCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	opts, err := runOpts(&args)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, RunCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if !c.start() {
+		logutil.LogDebug(logger, CommandName, RunCommandMethod, errRunInProgress)
+		return command.NewExecuteError(RunErrorCode, errors.New(errRunInProgress))
+	}
+
+	defer c.stop()
+
+	report, err := benchmark.Run(c.cases, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RunCommandMethod, err.Error())
+		return command.NewExecuteError(RunErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RunResponse{Report: report}, logger)
+
+	logutil.LogDebug(logger, CommandName, RunCommandMethod, successString)
+
+	return nil
+}
+
+func (c *Command) start() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.running {
+		return false
+	}
+
+	c.running = true
+
+	return true
+}
+
+func (c *Command) stop() {
+	c.lock.Lock()
+	c.running = false
+	c.lock.Unlock()
+}
+
+func runOpts(args *RunArgs) ([]benchmark.Opt, error) {
+	var opts []benchmark.Opt
+
+	if len(args.Cases) > 0 {
+		opts = append(opts, benchmark.WithCases(args.Cases...))
+	}
+
+	if len(args.PayloadSizes) > 0 {
+		opts = append(opts, benchmark.WithPayloadSizes(args.PayloadSizes...))
+	}
+
+	if args.Duration != "" {
+		d, err := time.ParseDuration(args.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+
+		if d <= 0 || d > MaxDuration {
+			return nil, fmt.Errorf("duration must be positive and at most %s", MaxDuration)
+		}
+
+		opts = append(opts, benchmark.WithDuration(d))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+)
+
+func TestNew(t *testing.T) {
+	cmd, err := New()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+	require.Len(t, cmd.GetHandlers(), 1)
+}
+
+func TestCommand_Run(t *testing.T) {
+	cmd, err := New()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.Run(&b, bytes.NewBufferString(
+			`{"cases":["anoncrypt/pack","Ed25519Signature2020/sign"],"payload_sizes":[64,128],"duration":"1ms"}`))
+		require.NoError(t, cmdErr)
+
+		var res RunResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.NotNil(t, res.Report)
+		require.Len(t, res.Report.Results, 4)
+		require.Equal(t, "anoncrypt/pack", res.Report.Results[0].Case)
+		require.Equal(t, 128, res.Report.Results[1].PayloadSize)
+		require.Positive(t, res.Report.Results[3].Iterations)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmdErr := cmd.Run(&bytes.Buffer{}, bytes.NewBufferString("{"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		for _, duration := range []string{"soon", "0s", "1h"} {
+			cmdErr := cmd.Run(&bytes.Buffer{}, bytes.NewBufferString(`{"duration":"`+duration+`"}`))
+			require.Error(t, cmdErr, duration)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), duration)
+		}
+	})
+
+	t.Run("unknown case", func(t *testing.T) {
+		cmdErr := cmd.Run(&bytes.Buffer{}, bytes.NewBufferString(`{"cases":["unknown"]}`))
+		require.EqualError(t, cmdErr, "unknown benchmark case: unknown")
+		require.Equal(t, RunErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("run in progress", func(t *testing.T) {
+		require.True(t, cmd.start())
+		defer cmd.stop()
+
+		cmdErr := cmd.Run(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errRunInProgress)
+		require.Equal(t, RunErrorCode, cmdErr.Code())
+	})
+}
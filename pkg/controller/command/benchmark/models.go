@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/benchmark"
+)
+
+// RunArgs model
+//
+// This is used for running the benchmark of the packers and of the linked data proof suites.
+//
+type RunArgs struct {
+	// Cases are the names of the cases run (e.g. "authcrypt/pack"), all the cases by default.
+	Cases []string `json:"cases,omitempty"`
+	// PayloadSizes are the payload sizes measured in bytes, 1 KiB, 16 KiB and 128 KiB by default.
+	PayloadSizes []int `json:"payload_sizes,omitempty"`
+	// Duration each case is run for on each payload size (e.g. "500ms"), 1 second by default.
+	Duration string `json:"duration,omitempty"`
+}
+
+// RunResponse model
+//
+// Represents the response of the benchmark run.
+//
+type RunResponse struct {
+	// Report of the results and of the platform they were measured on.
+	Report *benchmark.Report `json:"report"`
+}
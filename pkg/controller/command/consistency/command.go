@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/consistency"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/consistency")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Consistency)
+	// CheckError is for failures while checking the stores.
+	CheckError
+	// RepairError is for failures while repairing the stores.
+	RepairError
+)
+
+// constants for the consistency commands.
+const (
+	// command name.
+	CommandName = "consistency"
+
+	// command methods.
+	CheckCommandMethod  = "Check"
+	RepairCommandMethod = "Repair"
+)
+
+// provider contains dependencies for the consistency command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+}
+
+type checker interface {
+	Check() (*consistency.Report, error)
+	Repair() (*consistency.Report, error)
+}
+
+// Command contains the store consistency commands.
+type Command struct {
+	checker checker
+}
+
+// New returns new store consistency command instance.
+func New(p provider) (*Command, error) {
+	c, err := consistency.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create consistency checker: %w", err)
+	}
+
+	return &Command{checker: c}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, CheckCommandMethod, c.Check),
+		cmdutil.NewCommandHandler(CommandName, RepairCommandMethod, c.Repair),
+	}
+}
+
+// Check checks the referential integrity of the agent stores and reports the issues found.
+func (c *Command) Check(rw io.Writer, _ io.Reader) command.Error {
+	report, err := c.checker.Check()
+	if err != nil {
+		logutil.LogError(logger, CommandName, CheckCommandMethod, err.Error())
+
+		return command.NewExecuteError(CheckError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ReportResponse{Issues: report.Issues}, logger)
+
+	logutil.LogDebug(logger, CommandName, CheckCommandMethod, "success")
+
+	return nil
+}
+
+// Repair checks the referential integrity of the agent stores and repairs the issues found.
+func (c *Command) Repair(rw io.Writer, _ io.Reader) command.Error {
+	report, err := c.checker.Repair()
+	if err != nil {
+		logutil.LogError(logger, CommandName, RepairCommandMethod, err.Error())
+
+		return command.NewExecuteError(RepairError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ReportResponse{Issues: report.Issues}, logger)
+
+	logutil.LogDebug(logger, CommandName, RepairCommandMethod, "success")
+
+	return nil
+}
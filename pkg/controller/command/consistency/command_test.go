@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/consistency"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command - success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("test new command - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestCommand_Check(t *testing.T) {
+	t.Run("test check - success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.checker = &mockChecker{report: &consistency.Report{Issues: []*consistency.Issue{{
+			Type:         consistency.MissingDID,
+			ConnectionID: "conn-1",
+		}}}}
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Check(&b, nil))
+
+		var res ReportResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Issues, 1)
+		require.Equal(t, consistency.MissingDID, res.Issues[0].Type)
+	})
+
+	t.Run("test check - error", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.checker = &mockChecker{err: errors.New("check error")}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Check(&b, nil)
+		require.NotNil(t, cmdErr)
+		require.Equal(t, CheckError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "check error")
+	})
+}
+
+func TestCommand_Repair(t *testing.T) {
+	t.Run("test repair - success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.checker = &mockChecker{report: &consistency.Report{Issues: []*consistency.Issue{{
+			Type:         consistency.OrphanedRoute,
+			ConnectionID: "conn-1",
+			Repaired:     true,
+		}}}}
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Repair(&b, nil))
+
+		var res ReportResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Issues, 1)
+		require.True(t, res.Issues[0].Repaired)
+	})
+
+	t.Run("test repair - error", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.checker = &mockChecker{err: errors.New("repair error")}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Repair(&b, nil)
+		require.NotNil(t, cmdErr)
+		require.Equal(t, RepairError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "repair error")
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		ServiceErr:                        api.ErrSvcNotFound,
+	}
+}
+
+type mockChecker struct {
+	report *consistency.Report
+	err    error
+}
+
+func (m *mockChecker) Check() (*consistency.Report, error) {
+	return m.report, m.err
+}
+
+func (m *mockChecker) Repair() (*consistency.Report, error) {
+	return m.report, m.err
+}
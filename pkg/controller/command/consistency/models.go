@@ -0,0 +1,19 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/store/consistency"
+)
+
+// ReportResponse model
+//
+// This is used for returning the consistency issues found in the agent stores.
+type ReportResponse struct {
+	// Issues found in the stores, with their repair status when the stores are repaired.
+	Issues []*consistency.Issue `json:"issues"`
+}
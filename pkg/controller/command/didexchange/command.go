@@ -8,6 +8,7 @@ package didexchange
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -372,15 +374,20 @@ func (c *Command) QueryConnections(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
-	results, err := c.client.QueryConnections(&request.QueryConnectionsParams)
+	page, err := c.client.QueryConnectionsPage(&request.QueryConnectionsParams)
 	if err != nil {
 		logutil.LogError(logger, CommandName, QueryConnectionsCommandMethod, err.Error())
 
+		if errors.Is(err, connection.ErrInvalidPageToken) {
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+
 		return command.NewExecuteError(QueryConnectionsErrorCode, err)
 	}
 
 	command.WriteNillableResponse(rw, &QueryConnectionsResponse{
-		Results: results,
+		Results:       page.Connections,
+		NextPageToken: page.NextPageToken,
 	}, logger)
 
 	logutil.LogDebug(logger, CommandName, QueryConnectionsCommandMethod, successString)
@@ -218,6 +218,9 @@ type QueryConnectionResponse struct {
 //
 type QueryConnectionsResponse struct {
 	Results []*didexchange.Connection `json:"results,omitempty"`
+
+	// NextPageToken is the token to query the next page of the results, empty on the last page
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
 // AcceptExchangeRequestArgs model
@@ -73,6 +73,45 @@ const (
 
 	// LD error group for JSON-LD command errors.
 	LD = 14000
+
+	// Consistency error group for store consistency command errors.
+	Consistency = 15000
+
+	// ActionMenu error group for action menu command errors.
+	ActionMenu = 16000
+
+	// Tenancy error group for multi-tenant controller errors.
+	Tenancy = 17000
+
+	// ProblemReport error group for problem report history command errors.
+	ProblemReport = 18000
+
+	// EventJournal error group for event journal command errors.
+	EventJournal = 19000
+
+	// QuestionAnswer error group for question answer command errors.
+	QuestionAnswer = 20000
+
+	// Webhook error group for webhook targets command errors.
+	Webhook = 21000
+
+	// KeyBackup error group for key backup command errors.
+	KeyBackup = 22000
+
+	// TrustPing error group for trust ping command errors.
+	TrustPing = 23000
+
+	// JWKS error group for JWKS command errors.
+	JWKS = 24000
+
+	// Benchmark error group for benchmark command errors.
+	Benchmark = 25000
+
+	// SenderPolicy error group for sender policy command errors.
+	SenderPolicy = 26000
+
+	// Profile error group for profile command errors.
+	Profile = 27000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/store/eventjournal"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/eventjournal")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.EventJournal)
+	// ReplayError is for failures while replaying the events.
+	ReplayError
+)
+
+// constants for the event journal commands.
+const (
+	// command name.
+	CommandName = "eventjournal"
+
+	// command methods.
+	ReplayCommandMethod = "Replay"
+)
+
+// provider contains dependencies for the event journal command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+type journal interface {
+	Replay(cursor uint64, limit int) ([]*eventjournal.Record, error)
+}
+
+// Command contains the event journal commands.
+type Command struct {
+	journal journal
+}
+
+// New returns new event journal command instance.
+func New(p provider) (*Command, error) {
+	j, err := eventjournal.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create event journal: %w", err)
+	}
+
+	return &Command{journal: j}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, ReplayCommandMethod, c.Replay),
+	}
+}
+
+// Replay returns the state events journaled after the given cursor, the events are journaled when the framework is
+// created with the aries.WithEventJournal option.
+func (c *Command) Replay(rw io.Writer, req io.Reader) command.Error {
+	var args ReplayArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil && !errors.Is(err, io.EOF) {
+		logutil.LogInfo(logger, CommandName, ReplayCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if args.Limit < 0 {
+		logutil.LogInfo(logger, CommandName, ReplayCommandMethod, "invalid limit")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("limit must not be negative"))
+	}
+
+	records, err := c.journal.Replay(args.Cursor, args.Limit)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ReplayCommandMethod, err.Error())
+
+		return command.NewExecuteError(ReplayError, err)
+	}
+
+	cursor := args.Cursor
+	if len(records) > 0 {
+		cursor = records[len(records)-1].Sequence
+	}
+
+	command.WriteNillableResponse(rw, &ReplayResponse{Events: records, Cursor: cursor}, logger)
+
+	logutil.LogDebug(logger, CommandName, ReplayCommandMethod, "success")
+
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/eventjournal"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command - success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("test new command - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestCommand_Replay(t *testing.T) {
+	t.Run("test replay - success", func(t *testing.T) {
+		p := newProvider()
+
+		journal, err := eventjournal.New(p)
+		require.NoError(t, err)
+
+		for _, stateID := range []string{"request-sent", "presentation-received", "done"} {
+			_, err = journal.Append(service.StateMsg{
+				ProtocolName: "present-proof",
+				Type:         service.PostState,
+				StateID:      stateID,
+			})
+			require.NoError(t, err)
+		}
+
+		cmd, err := New(p)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Replay(&b, bytes.NewBufferString(`{"limit":2}`)))
+
+		var res ReplayResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Events, 2)
+		require.Equal(t, "request-sent", res.Events[0].StateID)
+		require.Equal(t, uint64(2), res.Cursor)
+
+		b.Reset()
+		require.Nil(t, cmd.Replay(&b, bytes.NewBufferString(`{"cursor":2}`)))
+
+		res = ReplayResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Events, 1)
+		require.Equal(t, "done", res.Events[0].StateID)
+		require.Equal(t, uint64(3), res.Cursor)
+
+		// the cursor is kept when there are no new events
+		b.Reset()
+		require.Nil(t, cmd.Replay(&b, bytes.NewBufferString(`{"cursor":3}`)))
+
+		res = ReplayResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.NotNil(t, res.Events)
+		require.Empty(t, res.Events)
+		require.Equal(t, uint64(3), res.Cursor)
+	})
+
+	t.Run("test replay - arguments", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		j := &mockJournal{}
+		cmd.journal = j
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Replay(&b, bytes.NewBufferString(`{"cursor":10,"limit":5}`)))
+		require.Equal(t, uint64(10), j.cursor)
+		require.Equal(t, 5, j.limit)
+
+		// an empty request replays from the first event
+		require.Nil(t, cmd.Replay(&b, bytes.NewBufferString("")))
+		require.Zero(t, j.cursor)
+		require.Zero(t, j.limit)
+	})
+
+	t.Run("test replay - invalid request", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Replay(&b, bytes.NewBufferString(`{"cursor":"first"}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.Replay(&b, bytes.NewBufferString(`{"limit":-1}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "limit must not be negative")
+	})
+
+	t.Run("test replay - error", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.journal = &mockJournal{err: errors.New("replay error")}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Replay(&b, bytes.NewBufferString(`{}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, ReplayError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "replay error")
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+type mockJournal struct {
+	cursor uint64
+	limit  int
+	err    error
+}
+
+func (m *mockJournal) Replay(cursor uint64, limit int) ([]*eventjournal.Record, error) {
+	m.cursor, m.limit = cursor, limit
+
+	return nil, m.err
+}
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/store/eventjournal"
+)
+
+// ReplayArgs model
+//
+// This is used for replaying the state events journaled after a cursor.
+type ReplayArgs struct {
+	// Cursor is the sequence number of the last event received, the events are replayed from the first one when zero.
+	Cursor uint64 `json:"cursor,omitempty"`
+
+	// Limit is the maximum number of events returned, 100 by default and 1000 at most.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ReplayResponse model
+//
+// This is used for returning the state events journaled after a cursor.
+type ReplayResponse struct {
+	// Events sorted by sequence number.
+	Events []*eventjournal.Record `json:"events"`
+
+	// Cursor to replay the next events from, the sequence number of the last event returned.
+	Cursor uint64 `json:"cursor"`
+}
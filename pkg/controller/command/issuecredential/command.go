@@ -78,6 +78,13 @@ const (
 	AcceptCredential    = "AcceptCredential"
 	DeclineCredential   = "DeclineCredential"
 	AcceptProblemReport = "AcceptProblemReport"
+
+	SendOfferV3         = "SendOfferV3"
+	SendProposalV3      = "SendProposalV3"
+	SendRequestV3       = "SendRequestV3"
+	AcceptProposalV3    = "AcceptProposalV3"
+	NegotiateProposalV3 = "NegotiateProposalV3"
+	AcceptRequestV3     = "AcceptRequestV3"
 )
 
 const (
@@ -186,6 +193,12 @@ func (c *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, DeclineRequest, c.DeclineRequest),
 		cmdutil.NewCommandHandler(CommandName, AcceptCredential, c.AcceptCredential),
 		cmdutil.NewCommandHandler(CommandName, DeclineCredential, c.DeclineCredential),
+		cmdutil.NewCommandHandler(CommandName, SendOfferV3, c.SendOfferV3),
+		cmdutil.NewCommandHandler(CommandName, SendProposalV3, c.SendProposalV3),
+		cmdutil.NewCommandHandler(CommandName, SendRequestV3, c.SendRequestV3),
+		cmdutil.NewCommandHandler(CommandName, AcceptProposalV3, c.AcceptProposalV3),
+		cmdutil.NewCommandHandler(CommandName, NegotiateProposalV3, c.NegotiateProposalV3),
+		cmdutil.NewCommandHandler(CommandName, AcceptRequestV3, c.AcceptRequestV3),
 	}
 }
 
@@ -243,6 +256,43 @@ func (c *Command) SendOffer(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// SendOfferV3 is used by the Issuer to send an offer over DIDComm V2.
+func (c *Command) SendOfferV3(rw io.Writer, req io.Reader) command.Error {
+	var args SendOfferV3Args
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendOfferV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, SendOfferV3, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendOfferV3, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	if args.OfferCredential == nil {
+		logutil.LogDebug(logger, CommandName, SendOfferV3, errEmptyOfferCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyOfferCredential))
+	}
+
+	piid, err := c.client.SendOfferV3(args.OfferCredential, args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendOfferV3, err.Error())
+		return command.NewExecuteError(SendOfferErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendOfferResponse{PIID: piid}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendOfferV3, successString)
+
+	return nil
+}
+
 // SendProposal is used by the Holder to send a proposal.
 func (c *Command) SendProposal(rw io.Writer, req io.Reader) command.Error {
 	var args SendProposalArgs
@@ -280,6 +330,43 @@ func (c *Command) SendProposal(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// SendProposalV3 is used by the Holder to send a proposal over DIDComm V2.
+func (c *Command) SendProposalV3(rw io.Writer, req io.Reader) command.Error {
+	var args SendProposalV3Args
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendProposalV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, SendProposalV3, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendProposalV3, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	if args.ProposeCredential == nil {
+		logutil.LogDebug(logger, CommandName, SendProposalV3, errEmptyProposeCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyProposeCredential))
+	}
+
+	piid, err := c.client.SendProposalV3(args.ProposeCredential, args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendProposalV3, err.Error())
+		return command.NewExecuteError(SendProposalErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendProposalResponse{PIID: piid}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendProposalV3, successString)
+
+	return nil
+}
+
 // SendRequest is used by the Holder to send a request.
 func (c *Command) SendRequest(rw io.Writer, req io.Reader) command.Error {
 	var args SendRequestArgs
@@ -317,6 +404,43 @@ func (c *Command) SendRequest(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// SendRequestV3 is used by the Holder to send a request over DIDComm V2.
+func (c *Command) SendRequestV3(rw io.Writer, req io.Reader) command.Error {
+	var args SendRequestV3Args
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendRequestV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, SendRequestV3, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendRequestV3, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	if args.RequestCredential == nil {
+		logutil.LogDebug(logger, CommandName, SendRequestV3, errEmptyRequestCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyRequestCredential))
+	}
+
+	piid, err := c.client.SendRequestV3(args.RequestCredential, args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendRequestV3, err.Error())
+		return command.NewExecuteError(SendRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendRequestResponse{PIID: piid}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendRequestV3, successString)
+
+	return nil
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
 func (c *Command) AcceptProposal(rw io.Writer, req io.Reader) command.Error {
 	var args AcceptProposalArgs
@@ -348,6 +472,37 @@ func (c *Command) AcceptProposal(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// AcceptProposalV3 is used when the Issuer is willing to accept the proposal over DIDComm V2.
+func (c *Command) AcceptProposalV3(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptProposalV3Args
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptProposalV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptProposalV3, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if args.OfferCredential == nil {
+		logutil.LogDebug(logger, CommandName, AcceptProposalV3, errEmptyOfferCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyOfferCredential))
+	}
+
+	if err := c.client.AcceptProposalV3(args.PIID, args.OfferCredential); err != nil {
+		logutil.LogError(logger, CommandName, AcceptProposalV3, err.Error())
+		return command.NewExecuteError(AcceptProposalErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptProposalResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptProposalV3, successString)
+
+	return nil
+}
+
 // NegotiateProposal is used when the Holder wants to negotiate about an offer he received.
 func (c *Command) NegotiateProposal(rw io.Writer, req io.Reader) command.Error {
 	var args NegotiateProposalArgs
@@ -379,6 +534,37 @@ func (c *Command) NegotiateProposal(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// NegotiateProposalV3 is used when the Holder wants to negotiate about an offer he received over DIDComm V2.
+func (c *Command) NegotiateProposalV3(rw io.Writer, req io.Reader) command.Error {
+	var args NegotiateProposalV3Args
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, NegotiateProposalV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, NegotiateProposalV3, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if args.ProposeCredential == nil {
+		logutil.LogDebug(logger, CommandName, NegotiateProposalV3, errEmptyProposeCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyProposeCredential))
+	}
+
+	if err := c.client.NegotiateProposalV3(args.PIID, args.ProposeCredential); err != nil {
+		logutil.LogError(logger, CommandName, NegotiateProposalV3, err.Error())
+		return command.NewExecuteError(NegotiateProposalErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &NegotiateProposalResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, NegotiateProposalV3, successString)
+
+	return nil
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 func (c *Command) DeclineProposal(rw io.Writer, req io.Reader) command.Error {
 	var args DeclineProposalArgs
@@ -514,6 +700,37 @@ func (c *Command) AcceptRequest(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// AcceptRequestV3 is used when the Issuer is willing to accept the request over DIDComm V2.
+func (c *Command) AcceptRequestV3(rw io.Writer, req io.Reader) command.Error {
+	var request AcceptRequestV3Args
+
+	if err := json.NewDecoder(req).Decode(&request); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptRequestV3, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptRequestV3, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if request.IssueCredential == nil {
+		logutil.LogDebug(logger, CommandName, AcceptRequestV3, errEmptyIssueCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyIssueCredential))
+	}
+
+	if err := c.client.AcceptRequestV3(request.PIID, request.IssueCredential); err != nil {
+		logutil.LogError(logger, CommandName, AcceptRequestV3, err.Error())
+		return command.NewExecuteError(AcceptRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptRequestResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptRequestV3, successString)
+
+	return nil
+}
+
 // DeclineRequest is used when the Issuer does not want to accept the request.
 func (c *Command) DeclineRequest(rw io.Writer, req io.Reader) command.Error {
 	var args DeclineRequestArgs
@@ -256,6 +256,117 @@ func TestCommand_SendOffer(t *testing.T) {
 	})
 }
 
+func TestCommand_SendOfferV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOfferV3(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOfferV3(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOfferV3(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty OfferCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOfferV3(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyOfferCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("SendOfferV3 (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(
+			gomock.Any(), gomock.Any(),
+			gomock.Any(),
+		).Return("", errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","offer_credential":{}}`
+		cmdErr := cmd.SendOfferV3(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, SendOfferErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(gomock.Any(), gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","offer_credential":{}}`
+		require.NoError(t, cmd.SendOfferV3(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
 func TestCommand_SendProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -273,7 +384,340 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString("}"))
+		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty ProposeCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyProposeCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("SendProposal (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(
+			gomock.Any(), gomock.Any(),
+			gomock.Any(),
+		).Return("", errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
+		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, SendProposalErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(gomock.Any(), gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
+		require.NoError(t, cmd.SendProposal(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
+func TestCommand_SendProposalV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposalV3(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposalV3(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposalV3(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty ProposeCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendProposalV3(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyProposeCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("SendProposalV3 (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(
+			gomock.Any(), gomock.Any(),
+			gomock.Any(),
+		).Return("", errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
+		cmdErr := cmd.SendProposalV3(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, SendProposalErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(gomock.Any(), gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
+		require.NoError(t, cmd.SendProposalV3(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
+func TestCommand_SendRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty MyDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty TheirDID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty RequestCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyRequestCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("SendRequest (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(
+			gomock.Any(), gomock.Any(),
+			gomock.Any(),
+		).Return("", errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
+		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, SendRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().HandleOutbound(gomock.Any(), gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
+		require.NoError(t, cmd.SendRequest(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
+func TestCommand_SendRequestV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequestV3(&b, bytes.NewBufferString("}"))
 
 		require.Error(t, cmdErr)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
@@ -286,7 +730,7 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString("{}"))
+		cmdErr := cmd.SendRequestV3(&b, bytes.NewBufferString("{}"))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
@@ -300,7 +744,7 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+		cmdErr := cmd.SendRequestV3(&b, bytes.NewBufferString(`{"my_did":"id"}`))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
@@ -308,21 +752,21 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("Empty ProposeCredential", func(t *testing.T) {
+	t.Run("Empty RequestCredential", func(t *testing.T) {
 		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+		cmdErr := cmd.SendRequestV3(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
 
 		require.Error(t, cmdErr)
-		require.Contains(t, cmdErr.Error(), errEmptyProposeCredential)
+		require.Contains(t, cmdErr.Error(), errEmptyRequestCredential)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("SendProposal (error)", func(t *testing.T) {
+	t.Run("SendRequestV3 (error)", func(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
@@ -339,12 +783,12 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
-		cmdErr := cmd.SendProposal(&b, bytes.NewBufferString(jsonPayload))
+		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
+		cmdErr := cmd.SendRequestV3(&b, bytes.NewBufferString(jsonPayload))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), "some error message")
-		require.Equal(t, SendProposalErrorCode, cmdErr.Code())
+		require.Equal(t, SendRequestErrorCode, cmdErr.Code())
 		require.Equal(t, command.ExecuteError, cmdErr.Type())
 	})
 
@@ -362,12 +806,12 @@ func TestCommand_SendProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		const jsonPayload = `{"my_did":"id","their_did":"id","propose_credential":{}}`
-		require.NoError(t, cmd.SendProposal(&b, bytes.NewBufferString(jsonPayload)))
+		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
+		require.NoError(t, cmd.SendRequestV3(&b, bytes.NewBufferString(jsonPayload)))
 	})
 }
 
-func TestCommand_SendRequest(t *testing.T) {
+func TestCommand_AcceptProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -384,63 +828,46 @@ func TestCommand_SendRequest(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString("}"))
-
-		require.Error(t, cmdErr)
-		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
-		require.Equal(t, command.ValidationError, cmdErr.Type())
-	})
-
-	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
-		require.NoError(t, err)
-		require.NotNil(t, cmd)
-
-		var b bytes.Buffer
-		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString("{}"))
+		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString("}"))
 
 		require.Error(t, cmdErr)
-		require.Contains(t, cmdErr.Error(), errEmptyMyDID)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("Empty TheirDID", func(t *testing.T) {
+	t.Run("Empty PIID", func(t *testing.T) {
 		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(`{"my_did":"id"}`))
+		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString("{}"))
 
 		require.Error(t, cmdErr)
-		require.Contains(t, cmdErr.Error(), errEmptyTheirDID)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("Empty RequestCredential", func(t *testing.T) {
+	t.Run("Empty OfferCredential", func(t *testing.T) {
 		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id"}`))
+		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString(`{"piid":"id"}`))
 
 		require.Error(t, cmdErr)
-		require.Contains(t, cmdErr.Error(), errEmptyRequestCredential)
+		require.Contains(t, cmdErr.Error(), errEmptyOfferCredential)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("SendRequest (error)", func(t *testing.T) {
+	t.Run("AcceptProposal (error)", func(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-		service.EXPECT().HandleOutbound(
-			gomock.Any(), gomock.Any(),
-			gomock.Any(),
-		).Return("", errors.New("some error message"))
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).Return(errors.New("some error message"))
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
@@ -450,12 +877,12 @@ func TestCommand_SendRequest(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
-		cmdErr := cmd.SendRequest(&b, bytes.NewBufferString(jsonPayload))
+		const jsonPayload = `{"piid":"id","offer_credential":{}}`
+		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString(jsonPayload))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), "some error message")
-		require.Equal(t, SendRequestErrorCode, cmdErr.Code())
+		require.Equal(t, AcceptProposalErrorCode, cmdErr.Code())
 		require.Equal(t, command.ExecuteError, cmdErr.Type())
 	})
 
@@ -463,7 +890,7 @@ func TestCommand_SendRequest(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-		service.EXPECT().HandleOutbound(gomock.Any(), gomock.Any(), gomock.Any())
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any())
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
@@ -473,12 +900,12 @@ func TestCommand_SendRequest(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		const jsonPayload = `{"my_did":"id","their_did":"id","request_credential":{}}`
-		require.NoError(t, cmd.SendRequest(&b, bytes.NewBufferString(jsonPayload)))
+		const jsonPayload = `{"piid":"id","offer_credential":{}}`
+		require.NoError(t, cmd.AcceptProposal(&b, bytes.NewBufferString(jsonPayload)))
 	})
 }
 
-func TestCommand_AcceptProposal(t *testing.T) {
+func TestCommand_AcceptProposalV3(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -495,7 +922,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString("}"))
+		cmdErr := cmd.AcceptProposalV3(&b, bytes.NewBufferString("}"))
 
 		require.Error(t, cmdErr)
 		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
@@ -508,7 +935,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString("{}"))
+		cmdErr := cmd.AcceptProposalV3(&b, bytes.NewBufferString("{}"))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), errEmptyPIID)
@@ -522,7 +949,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString(`{"piid":"id"}`))
+		cmdErr := cmd.AcceptProposalV3(&b, bytes.NewBufferString(`{"piid":"id"}`))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), errEmptyOfferCredential)
@@ -530,7 +957,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
-	t.Run("AcceptProposal (error)", func(t *testing.T) {
+	t.Run("AcceptProposalV3 (error)", func(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
@@ -545,7 +972,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 
 		var b bytes.Buffer
 		const jsonPayload = `{"piid":"id","offer_credential":{}}`
-		cmdErr := cmd.AcceptProposal(&b, bytes.NewBufferString(jsonPayload))
+		cmdErr := cmd.AcceptProposalV3(&b, bytes.NewBufferString(jsonPayload))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), "some error message")
@@ -568,7 +995,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 
 		var b bytes.Buffer
 		const jsonPayload = `{"piid":"id","offer_credential":{}}`
-		require.NoError(t, cmd.AcceptProposal(&b, bytes.NewBufferString(jsonPayload)))
+		require.NoError(t, cmd.AcceptProposalV3(&b, bytes.NewBufferString(jsonPayload)))
 	})
 }
 
@@ -666,6 +1093,100 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 	})
 }
 
+func TestCommand_NegotiateProposalV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.NegotiateProposalV3(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.NegotiateProposalV3(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty OfferCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.NegotiateProposalV3(&b, bytes.NewBufferString(`{"piid":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyProposeCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("NegotiateProposalV3 (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).Return(errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"piid":"id","propose_credential":{}}`
+		cmdErr := cmd.NegotiateProposalV3(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, NegotiateProposalErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"piid":"id","propose_credential":{}}`
+		require.NoError(t, cmd.NegotiateProposalV3(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
 func TestCommand_DeclineProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1072,6 +1593,100 @@ func TestCommand_AcceptRequest(t *testing.T) {
 	})
 }
 
+func TestCommand_AcceptRequestV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptRequestV3(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty PIID", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptRequestV3(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyPIID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty IssueCredential", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptRequestV3(&b, bytes.NewBufferString(`{"piid":"id"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyIssueCredential)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("AcceptRequestV3 (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).Return(errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"piid":"id","issue_credential":{}}`
+		cmdErr := cmd.AcceptRequestV3(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, AcceptRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any())
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"piid":"id","issue_credential":{}}`
+		require.NoError(t, cmd.AcceptRequestV3(&b, bytes.NewBufferString(jsonPayload)))
+	})
+}
+
 func TestCommand_DeclineRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
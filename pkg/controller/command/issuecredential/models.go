@@ -20,6 +20,18 @@ type AcceptProposalArgs struct {
 	OfferCredential *issuecredential.OfferCredential `json:"offer_credential"`
 }
 
+// AcceptProposalV3Args model
+//
+// This is used for accepting a DIDComm V2 proposal.
+//
+type AcceptProposalV3Args struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// OfferCredential is a message describing the credential intend to offer and
+	// possibly the price they expect to be paid.
+	OfferCredential *issuecredential.OfferCredentialV3 `json:"offer_credential"`
+}
+
 // AcceptProposalResponse model
 //
 // Represents a AcceptProposal response message.
@@ -52,6 +64,17 @@ type AcceptRequestArgs struct {
 	IssueCredential *issuecredential.IssueCredential `json:"issue_credential"`
 }
 
+// AcceptRequestV3Args model
+//
+// This is used for accepting a DIDComm V2 request.
+//
+type AcceptRequestV3Args struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// IssueCredential contains as attached payload the credentials being issued
+	IssueCredential *issuecredential.IssueCredentialV3 `json:"issue_credential"`
+}
+
 // AcceptRequestResponse model
 //
 // Represents a AcceptRequest response message.
@@ -87,6 +110,18 @@ type NegotiateProposalArgs struct {
 	ProposeCredential *issuecredential.ProposeCredential `json:"propose_credential"`
 }
 
+// NegotiateProposalV3Args model
+//
+// This is used when the Holder wants to negotiate about a DIDComm V2 offer he received.
+//
+type NegotiateProposalV3Args struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// ProposeCredential is a message sent in response to a offer-credential message when the Holder
+	// wants some adjustments made to the credential data offered by Issuer.
+	ProposeCredential *issuecredential.ProposeCredentialV3 `json:"propose_credential"`
+}
+
 // NegotiateProposalResponse model
 //
 // Represents a NegotiateProposal response message.
@@ -174,6 +209,19 @@ type SendProposalArgs struct {
 	ProposeCredential *issuecredential.ProposeCredential `json:"propose_credential"`
 }
 
+// SendProposalV3Args model
+//
+// This is used for sending a DIDComm V2 proposal to initiate the protocol.
+//
+type SendProposalV3Args struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// ProposeCredential is a message sent by the potential Holder to the Issuer to initiate the protocol
+	ProposeCredential *issuecredential.ProposeCredentialV3 `json:"propose_credential"`
+}
+
 // SendProposalResponse model
 //
 // Represents a SendProposal response message.
@@ -197,6 +245,20 @@ type SendOfferArgs struct {
 	OfferCredential *issuecredential.OfferCredential `json:"offer_credential"`
 }
 
+// SendOfferV3Args model
+//
+// This is used for sending a DIDComm V2 offer.
+//
+type SendOfferV3Args struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// OfferCredential is a message describing the credential intend to offer and
+	// possibly the price they expect to be paid.
+	OfferCredential *issuecredential.OfferCredentialV3 `json:"offer_credential"`
+}
+
 // SendOfferResponse model
 //
 // Represents a SendOffer response message.
@@ -220,6 +282,20 @@ type SendRequestArgs struct {
 	RequestCredential *issuecredential.RequestCredential `json:"request_credential"`
 }
 
+// SendRequestV3Args model
+//
+// This is used for sending a DIDComm V2 request.
+//
+type SendRequestV3Args struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// RequestCredential is a message sent by the potential Holder to the Issuer,
+	// to request the issuance of a credential.
+	RequestCredential *issuecredential.RequestCredentialV3 `json:"request_credential"`
+}
+
 // SendRequestResponse model
 //
 // Represents a SendRequest response message.
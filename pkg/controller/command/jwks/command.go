@@ -0,0 +1,445 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/jwks")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.JWKS)
+	// PublishKeyErrorCode is for failures in publish key command.
+	PublishKeyErrorCode
+	// UnpublishKeyErrorCode is for failures in unpublish key command.
+	UnpublishKeyErrorCode
+	// RotateKeyErrorCode is for failures in rotate key command.
+	RotateKeyErrorCode
+	// GetJWKSErrorCode is for failures in get JWKS command.
+	GetJWKSErrorCode
+)
+
+// constants for JWKS commands.
+const (
+	// command name.
+	CommandName = "jwks"
+
+	// command methods.
+	PublishKeyCommandMethod   = "PublishKey"
+	UnpublishKeyCommandMethod = "UnpublishKey"
+	RotateKeyCommandMethod    = "RotateKey"
+	GetJWKSCommandMethod      = "GetJWKS"
+
+	// StoreName is the name of the store of the published keys.
+	StoreName = "jwks"
+
+	// DefaultRetireAfter is the default time the public key rotated stays in the JWKS.
+	DefaultRetireAfter = 24 * time.Hour
+
+	defaultUse = "sig"
+	keyTag     = "jwks_key"
+
+	// error messages.
+	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeyType = "key type is mandatory"
+
+	// log constants.
+	successString = "success"
+)
+
+// ErrKeyNotPublished is returned when rotating or removing a key which isn't published in the JWKS.
+var ErrKeyNotPublished = errors.New("key not published")
+
+// provider contains dependencies for the JWKS command and is typically created by using aries.Context().
+type provider interface {
+	KMS() kms.KeyManager
+	StorageProvider() storage.Provider
+}
+
+// keyRecord is a key published in the JWKS, either a KMS key or the JWK of a key rotated until it retires.
+type keyRecord struct {
+	KeyID     string          `json:"keyID"`
+	KeyType   kms.KeyType     `json:"keyType,omitempty"`
+	Use       string          `json:"use"`
+	Published time.Time       `json:"published"`
+	JWK       json.RawMessage `json:"jwk,omitempty"`
+	Retires   *time.Time      `json:"retires,omitempty"`
+}
+
+// Command contains the commands publishing KMS public keys in a JWKS (RFC 7517), so that the counterparties can
+// verify the JWTs signed by the agent without resolving its DIDs.
+type Command struct {
+	kms   kms.KeyManager
+	store storage.Store
+	lock  sync.Mutex
+	now   func() time.Time // needed for unit test
+}
+
+// New returns new JWKS command instance.
+func New(p provider) (*Command, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open JWKS store: %w", err)
+	}
+
+	err = p.StorageProvider().SetStoreConfig(StoreName, storage.StoreConfiguration{TagNames: []string{keyTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set JWKS store config: %w", err)
+	}
+
+	return &Command{
+		kms:   p.KMS(),
+		store: store,
+		now:   time.Now,
+	}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, PublishKeyCommandMethod, c.PublishKey),
+		cmdutil.NewCommandHandler(CommandName, UnpublishKeyCommandMethod, c.UnpublishKey),
+		cmdutil.NewCommandHandler(CommandName, RotateKeyCommandMethod, c.RotateKey),
+		cmdutil.NewCommandHandler(CommandName, GetJWKSCommandMethod, c.GetJWKS),
+	}
+}
+
+// PublishKey publishes the public key of the KMS key in the JWKS.
+func (c *Command) PublishKey(rw io.Writer, req io.Reader) command.Error {
+	var args PublishKeyArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, PublishKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if args.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, PublishKeyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyID))
+	}
+
+	if args.KeyType == "" {
+		logutil.LogDebug(logger, CommandName, PublishKeyCommandMethod, errEmptyKeyType)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyType))
+	}
+
+	if args.Use == "" {
+		args.Use = defaultUse
+	}
+
+	record := &keyRecord{
+		KeyID:     args.KeyID,
+		KeyType:   kms.KeyType(args.KeyType),
+		Use:       args.Use,
+		Published: c.now().UTC(),
+	}
+
+	// checks the key can be exported before publishing it
+	if _, err := c.exportJWK(record); err != nil {
+		logutil.LogError(logger, CommandName, PublishKeyCommandMethod, err.Error())
+		return command.NewExecuteError(PublishKeyErrorCode, err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.putRecord(record); err != nil {
+		logutil.LogError(logger, CommandName, PublishKeyCommandMethod, err.Error())
+		return command.NewExecuteError(PublishKeyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, PublishKeyCommandMethod, successString)
+
+	return nil
+}
+
+// UnpublishKey removes the key from the JWKS, the KMS key is kept.
+func (c *Command) UnpublishKey(rw io.Writer, req io.Reader) command.Error {
+	var args KeyIDArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, UnpublishKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if args.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, UnpublishKeyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyID))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, err := c.getRecord(args.KeyID); err != nil {
+		logutil.LogError(logger, CommandName, UnpublishKeyCommandMethod, err.Error())
+		return command.NewExecuteError(UnpublishKeyErrorCode, err)
+	}
+
+	if err := c.store.Delete(args.KeyID); err != nil {
+		logutil.LogError(logger, CommandName, UnpublishKeyCommandMethod, err.Error())
+		return command.NewExecuteError(UnpublishKeyErrorCode, fmt.Errorf("delete key record: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, UnpublishKeyCommandMethod, successString)
+
+	return nil
+}
+
+// RotateKey rotates the published KMS key and publishes the new key in its place. The public key rotated stays in
+// the JWKS until it retires, to verify the JWTs signed before the rotation.
+func (c *Command) RotateKey(rw io.Writer, req io.Reader) command.Error {
+	var args RotateKeyArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RotateKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if args.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, RotateKeyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyID))
+	}
+
+	retireAfter := DefaultRetireAfter
+	if args.RetireAfter > 0 {
+		retireAfter = time.Duration(args.RetireAfter) * time.Second
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keyID, err := c.rotate(args.KeyID, retireAfter)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RotateKeyCommandMethod, err.Error())
+		return command.NewExecuteError(RotateKeyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RotateKeyResponse{KeyID: keyID}, logger)
+
+	logutil.LogDebug(logger, CommandName, RotateKeyCommandMethod, successString)
+
+	return nil
+}
+
+// GetJWKS returns the JWKS of the published keys. The keys which can no longer be exported from the KMS are skipped.
+func (c *Command) GetJWKS(rw io.Writer, _ io.Reader) command.Error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	records, err := c.queryRecords()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetJWKSCommandMethod, err.Error())
+		return command.NewExecuteError(GetJWKSErrorCode, err)
+	}
+
+	keys := []*jwk.JWK{}
+
+	for _, record := range records {
+		j, jwkErr := c.recordJWK(record)
+		if jwkErr != nil {
+			logger.Warnf("skipping key %s of the JWKS: %s", record.KeyID, jwkErr)
+
+			continue
+		}
+
+		if j != nil {
+			keys = append(keys, j)
+		}
+	}
+
+	command.WriteNillableResponse(rw, &JWKSResponse{Keys: keys}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetJWKSCommandMethod, successString)
+
+	return nil
+}
+
+func (c *Command) rotate(keyID string, retireAfter time.Duration) (string, error) {
+	record, err := c.getRecord(keyID)
+	if err != nil {
+		return "", err
+	}
+
+	if record.Retires != nil {
+		return "", fmt.Errorf("key %s is already rotated", keyID)
+	}
+
+	// the public key is exported before the rotation, the KMS no longer knows the key ID afterwards
+	j, err := c.exportJWK(record)
+	if err != nil {
+		return "", err
+	}
+
+	jwkBytes, err := j.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("marshal JWK: %w", err)
+	}
+
+	newKeyID, _, err := c.kms.Rotate(record.KeyType, keyID)
+	if err != nil {
+		return "", fmt.Errorf("rotate key: %w", err)
+	}
+
+	now := c.now().UTC()
+	retires := now.Add(retireAfter)
+
+	err = c.putRecord(&keyRecord{
+		KeyID:     newKeyID,
+		KeyType:   record.KeyType,
+		Use:       record.Use,
+		Published: now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	record.JWK = jwkBytes
+	record.Retires = &retires
+
+	if err = c.putRecord(record); err != nil {
+		return "", err
+	}
+
+	return newKeyID, nil
+}
+
+// recordJWK returns the JWK of the record, nil when the key rotated retired.
+func (c *Command) recordJWK(record *keyRecord) (*jwk.JWK, error) {
+	if record.Retires == nil {
+		return c.exportJWK(record)
+	}
+
+	if !c.now().Before(*record.Retires) {
+		if err := c.store.Delete(record.KeyID); err != nil {
+			logger.Warnf("failed to delete retired key %s: %s", record.KeyID, err)
+		}
+
+		return nil, nil
+	}
+
+	j := &jwk.JWK{}
+
+	if err := j.UnmarshalJSON(record.JWK); err != nil {
+		return nil, fmt.Errorf("unmarshal JWK: %w", err)
+	}
+
+	return j, nil
+}
+
+func (c *Command) exportJWK(record *keyRecord) (*jwk.JWK, error) {
+	pubKeyBytes, err := c.kms.ExportPubKeyBytes(record.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("export public key: %w", err)
+	}
+
+	j, err := jwkkid.BuildJWK(pubKeyBytes, record.KeyType)
+	if err != nil {
+		return nil, fmt.Errorf("build JWK: %w", err)
+	}
+
+	j.KeyID = record.KeyID
+	j.Use = record.Use
+
+	return j, nil
+}
+
+func (c *Command) putRecord(record *keyRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal key record: %w", err)
+	}
+
+	if err = c.store.Put(record.KeyID, recordBytes, storage.Tag{Name: keyTag}); err != nil {
+		return fmt.Errorf("save key record: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Command) getRecord(keyID string) (*keyRecord, error) {
+	recordBytes, err := c.store.Get(keyID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("key %s: %w", keyID, ErrKeyNotPublished)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get key record: %w", err)
+	}
+
+	record := &keyRecord{}
+
+	if err = json.Unmarshal(recordBytes, record); err != nil {
+		return nil, fmt.Errorf("unmarshal key record: %w", err)
+	}
+
+	return record, nil
+}
+
+// queryRecords returns the records of the keys published, sorted by publication time.
+func (c *Command) queryRecords() ([]*keyRecord, error) {
+	iter, err := c.store.Query(keyTag)
+	if err != nil {
+		return nil, fmt.Errorf("query key records: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*keyRecord
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("query key records: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get key record: %w", err)
+		}
+
+		record := &keyRecord{}
+
+		if err = json.Unmarshal(value, record); err != nil {
+			return nil, fmt.Errorf("unmarshal key record: %w", err)
+		}
+
+		records = append(records, record)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("query key records: %w", err)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Published.Before(records[j].Published)
+	})
+
+	return records, nil
+}
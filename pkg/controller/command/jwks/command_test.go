@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, _ := newCommand(t)
+		require.Len(t, cmd.GetHandlers(), 4)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+		})
+		require.EqualError(t, err, "open JWKS store: open error")
+	})
+}
+
+func TestCommand_JWKS(t *testing.T) {
+	cmd, km := newCommand(t)
+
+	now := time.Now()
+	cmd.now = func() time.Time { return now }
+
+	edKeyID, _, err := km.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ecKeyID, _, err := km.Create(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	require.NoError(t, cmd.PublishKey(&bytes.Buffer{}, newReader(t, &PublishKeyArgs{
+		KeyID:   edKeyID,
+		KeyType: string(kms.ED25519Type),
+	})))
+
+	now = now.Add(time.Second)
+
+	require.NoError(t, cmd.PublishKey(&bytes.Buffer{}, newReader(t, &PublishKeyArgs{
+		KeyID:   ecKeyID,
+		KeyType: string(kms.ECDSAP256TypeIEEEP1363),
+		Use:     "enc",
+	})))
+
+	keys := getJWKS(t, cmd)
+	require.Len(t, keys, 2)
+	require.Equal(t, edKeyID, keys[0]["kid"])
+	require.Equal(t, "sig", keys[0]["use"])
+	require.Equal(t, "OKP", keys[0]["kty"])
+	require.Equal(t, ecKeyID, keys[1]["kid"])
+	require.Equal(t, "enc", keys[1]["use"])
+	require.Equal(t, "EC", keys[1]["kty"])
+
+	t.Run("rotate key", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.RotateKey(&b, newReader(t, &RotateKeyArgs{KeyID: edKeyID, RetireAfter: 60})))
+
+		var res RotateKeyResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.NotEqual(t, edKeyID, res.KeyID)
+
+		rotated := getJWKS(t, cmd)
+		require.Len(t, rotated, 3)
+		require.Equal(t, edKeyID, rotated[0]["kid"])
+		require.Equal(t, keys[0]["x"], rotated[0]["x"])
+		require.Equal(t, res.KeyID, rotated[2]["kid"])
+		require.NotEqual(t, keys[0]["x"], rotated[2]["x"])
+
+		// the key rotated can't be rotated again
+		cmdErr := cmd.RotateKey(&b, newReader(t, &RotateKeyArgs{KeyID: edKeyID}))
+		require.Error(t, cmdErr)
+		require.Equal(t, RotateKeyErrorCode, cmdErr.Code())
+
+		now = now.Add(time.Minute)
+
+		retired := getJWKS(t, cmd)
+		require.Len(t, retired, 2)
+		require.Equal(t, ecKeyID, retired[0]["kid"])
+		require.Equal(t, res.KeyID, retired[1]["kid"])
+	})
+
+	t.Run("unpublish key", func(t *testing.T) {
+		require.NoError(t, cmd.UnpublishKey(&bytes.Buffer{}, newReader(t, &KeyIDArgs{KeyID: ecKeyID})))
+		require.Len(t, getJWKS(t, cmd), 1)
+
+		cmdErr := cmd.UnpublishKey(&bytes.Buffer{}, newReader(t, &KeyIDArgs{KeyID: ecKeyID}))
+		require.Error(t, cmdErr)
+		require.Equal(t, UnpublishKeyErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), ErrKeyNotPublished.Error())
+	})
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd, _ := newCommand(t)
+
+	tests := []struct {
+		name string
+		exec command.Exec
+		args interface{}
+		code command.Code
+	}{
+		{name: "publish empty key id", exec: cmd.PublishKey, args: &PublishKeyArgs{KeyType: "ED25519"},
+			code: InvalidRequestErrorCode},
+		{name: "publish empty key type", exec: cmd.PublishKey, args: &PublishKeyArgs{KeyID: "kid"},
+			code: InvalidRequestErrorCode},
+		{name: "publish unknown key", exec: cmd.PublishKey, args: &PublishKeyArgs{KeyID: "kid", KeyType: "ED25519"},
+			code: PublishKeyErrorCode},
+		{name: "unpublish empty key id", exec: cmd.UnpublishKey, args: &KeyIDArgs{}, code: InvalidRequestErrorCode},
+		{name: "rotate empty key id", exec: cmd.RotateKey, args: &RotateKeyArgs{}, code: InvalidRequestErrorCode},
+		{name: "rotate key not published", exec: cmd.RotateKey, args: &RotateKeyArgs{KeyID: "kid"},
+			code: RotateKeyErrorCode},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmdErr := tc.exec(&bytes.Buffer{}, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+		})
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, exec := range []command.Exec{cmd.PublishKey, cmd.UnpublishKey, cmd.RotateKey} {
+			cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString("{"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		cmd.store = &mockstore.MockStore{ErrQuery: errors.New("query error")}
+
+		cmdErr := cmd.GetJWKS(&bytes.Buffer{}, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, GetJWKSErrorCode, cmdErr.Code())
+	})
+}
+
+func getJWKS(t *testing.T, cmd *Command) []map[string]interface{} {
+	t.Helper()
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.GetJWKS(&b, nil))
+
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+
+	require.NoError(t, json.Unmarshal(b.Bytes(), &jwks))
+
+	return jwks.Keys
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newCommand(t *testing.T) (*Command, kms.KeyManager) {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue:             km,
+	})
+	require.NoError(t, err)
+
+	return cmd, km
+}
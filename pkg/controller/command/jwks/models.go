@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+)
+
+// PublishKeyArgs model
+//
+// This is used for publishing a KMS key in the JWKS.
+//
+type PublishKeyArgs struct {
+	// KeyID is the ID of the KMS key, used as the JWK kid.
+	KeyID string `json:"key_id"`
+	// KeyType is the KMS key type of the key, eg. ED25519 or ECDSAP256IEEEP1363.
+	KeyType string `json:"key_type"`
+	// Use is the intended use of the key, "sig" by default.
+	Use string `json:"use,omitempty"`
+}
+
+// KeyIDArgs model
+//
+// This is used for removing a key from the JWKS.
+//
+type KeyIDArgs struct {
+	// KeyID is the ID of the KMS key.
+	KeyID string `json:"key_id"`
+}
+
+// RotateKeyArgs model
+//
+// This is used for rotating a key published in the JWKS.
+//
+type RotateKeyArgs struct {
+	// KeyID is the ID of the KMS key rotated.
+	KeyID string `json:"key_id"`
+	// RetireAfter is the number of seconds the public key rotated stays in the JWKS, to verify the JWTs signed
+	// before the rotation. The command RetireAfter default is used if zero.
+	RetireAfter int64 `json:"retire_after,omitempty"`
+}
+
+// RotateKeyResponse model
+//
+// Represents the response of the rotate key command.
+//
+type RotateKeyResponse struct {
+	// KeyID is the ID of the KMS key after the rotation, published in the JWKS in place of the key rotated.
+	KeyID string `json:"key_id"`
+}
+
+// JWKSResponse model
+//
+// Represents the JWKS document of the published keys, see RFC 7517 section 5.
+//
+type JWKSResponse struct {
+	Keys []*jwk.JWK `json:"keys"`
+}
@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/keybackup")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid key backup controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.KeyBackup)
+	// RequestKeyErrorCode is for failures in request key command.
+	RequestKeyErrorCode
+	// BackupErrorCode is for failures in backup command.
+	BackupErrorCode
+	// RequestRecoveryErrorCode is for failures in request recovery command.
+	RequestRecoveryErrorCode
+	// GetRecoveryRequestErrorCode is for failures in get recovery request command.
+	GetRecoveryRequestErrorCode
+	// AcceptRecoveryErrorCode is for failures in accept recovery command.
+	AcceptRecoveryErrorCode
+	// DeclineRecoveryErrorCode is for failures in decline recovery command.
+	DeclineRecoveryErrorCode
+)
+
+// constants for command key backup.
+const (
+	CommandName = "keybackup"
+
+	RequestKey         = "RequestKey"
+	Backup             = "Backup"
+	RequestRecovery    = "RequestRecovery"
+	GetRecoveryRequest = "GetRecoveryRequest"
+	AcceptRecovery     = "AcceptRecovery"
+	DeclineRecovery    = "DeclineRecovery"
+	// error messages.
+	errEmptyConnectionID = "empty connection_id"
+	errEmptyKeyIDs       = "empty key_ids"
+	errEmptyConsent      = "empty consent"
+	errEmptyBackupID     = "empty backup_id"
+	errEmptyRequestID    = "empty request_id"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for key backup.
+type Command struct {
+	client *keybackup.Client
+}
+
+// New returns new key backup controller command instance.
+func New(ctx keybackup.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := keybackup.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.KeyBackup+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, RequestKey, c.RequestKey),
+		cmdutil.NewCommandHandler(CommandName, Backup, c.Backup),
+		cmdutil.NewCommandHandler(CommandName, RequestRecovery, c.RequestRecovery),
+		cmdutil.NewCommandHandler(CommandName, GetRecoveryRequest, c.GetRecoveryRequest),
+		cmdutil.NewCommandHandler(CommandName, AcceptRecovery, c.AcceptRecovery),
+		cmdutil.NewCommandHandler(CommandName, DeclineRecovery, c.DeclineRecovery),
+	}
+}
+
+// RequestKey asks the custodian of the connection for its backup public key.
+func (c *Command) RequestKey(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestKey, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, RequestKey, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	id, err := c.client.RequestKey(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestKey, err.Error())
+		return command.NewExecuteError(RequestKeyErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestKey, successString)
+
+	return nil
+}
+
+// Backup backs up keys of the KMS to the custodian of the connection, with the consent of the user.
+func (c *Command) Backup(rw io.Writer, req io.Reader) command.Error {
+	var args BackupArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Backup, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Backup, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if len(args.KeyIDs) == 0 {
+		logutil.LogDebug(logger, CommandName, Backup, errEmptyKeyIDs)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyIDs))
+	}
+
+	if args.Consent == nil {
+		logutil.LogDebug(logger, CommandName, Backup, errEmptyConsent)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConsent))
+	}
+
+	backupID, err := c.client.Backup(args.ConnectionID, args.BackupID, args.KeyIDs, args.Consent)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Backup, err.Error())
+		return command.NewExecuteError(BackupErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &BackupResponse{BackupID: backupID}, logger)
+
+	logutil.LogDebug(logger, CommandName, Backup, successString)
+
+	return nil
+}
+
+// RequestRecovery asks the custodian of the connection to recover the keys of a backup.
+func (c *Command) RequestRecovery(rw io.Writer, req io.Reader) command.Error {
+	var args RequestRecoveryArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestRecovery, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, RequestRecovery, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if args.BackupID == "" {
+		logutil.LogDebug(logger, CommandName, RequestRecovery, errEmptyBackupID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyBackupID))
+	}
+
+	id, err := c.client.RequestRecovery(args.ConnectionID, args.BackupID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestRecovery, err.Error())
+		return command.NewExecuteError(RequestRecoveryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestRecovery, successString)
+
+	return nil
+}
+
+// GetRecoveryRequest returns the recovery request received with the given ID, if not handled yet.
+func (c *Command) GetRecoveryRequest(rw io.Writer, req io.Reader) command.Error {
+	args, cmdErr := decodeRecoveryRequestArgs(req, GetRecoveryRequest)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	request, err := c.client.RecoveryRequest(args.RequestID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetRecoveryRequest, err.Error())
+		return command.NewExecuteError(GetRecoveryRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RecoveryRequestResponse{RecoveryRequest: request}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetRecoveryRequest, successString)
+
+	return nil
+}
+
+// AcceptRecovery sends the keys of the backup to the requester of the recovery.
+func (c *Command) AcceptRecovery(rw io.Writer, req io.Reader) command.Error {
+	args, cmdErr := decodeRecoveryRequestArgs(req, AcceptRecovery)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	if err := c.client.AcceptRecovery(args.RequestID); err != nil {
+		logutil.LogError(logger, CommandName, AcceptRecovery, err.Error())
+		return command.NewExecuteError(AcceptRecoveryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptRecovery, successString)
+
+	return nil
+}
+
+// DeclineRecovery declines the recovery request with the given reason.
+func (c *Command) DeclineRecovery(rw io.Writer, req io.Reader) command.Error {
+	args, cmdErr := decodeRecoveryRequestArgs(req, DeclineRecovery)
+	if cmdErr != nil {
+		return cmdErr
+	}
+
+	if err := c.client.DeclineRecovery(args.RequestID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclineRecovery, err.Error())
+		return command.NewExecuteError(DeclineRecoveryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclineRecovery, successString)
+
+	return nil
+}
+
+func decodeRecoveryRequestArgs(req io.Reader, commandMethod string) (*RecoveryRequestArgs, command.Error) {
+	var args RecoveryRequestArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, commandMethod, err.Error())
+		return nil, command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.RequestID == "" {
+		logutil.LogDebug(logger, CommandName, commandMethod, errEmptyRequestID)
+		return nil, command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyRequestID))
+	}
+
+	return &args, nil
+}
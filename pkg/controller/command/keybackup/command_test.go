@@ -0,0 +1,217 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 6)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.EqualError(t, err, "cannot create a client: service error")
+	})
+}
+
+func TestCommand_KeyBackup(t *testing.T) {
+	var sent []service.DIDCommMsgMap
+
+	prov := newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			sent = append(sent, msg.(service.DIDCommMsgMap))
+
+			return nil
+		},
+	})
+
+	cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	// the messages sent are received back on the same connection, the agent is its own custodian.
+	svc, err := prov.Service(protocol.KeyBackup)
+	require.NoError(t, err)
+
+	deliver := func() {
+		_, err = svc.(service.DIDComm).HandleInbound(sent[len(sent)-1],
+			service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+		require.NoError(t, err)
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.RequestKey(&b, newReader(t, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	res := &MessageIDResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), res))
+	require.Equal(t, protocol.KeyRequestMsgType, sent[0].Type())
+	require.Equal(t, sent[0].ID(), res.MessageID)
+
+	deliver()
+	deliver()
+
+	kid, _, err := prov.KMSValue.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	b.Reset()
+
+	require.NoError(t, cmd.Backup(&b, newReader(t, &BackupArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		BackupID:     "backup-1",
+		KeyIDs:       []string{kid},
+		Consent:      &keybackup.Consent{Statement: "I agree"},
+	})))
+
+	backup := &BackupResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), backup))
+	require.Equal(t, "backup-1", backup.BackupID)
+
+	deliver()
+
+	b.Reset()
+
+	require.NoError(t, cmd.RequestRecovery(&b, newReader(t, &RequestRecoveryArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		BackupID:     "backup-1",
+	})))
+	require.NoError(t, json.Unmarshal(b.Bytes(), res))
+
+	deliver()
+
+	b.Reset()
+
+	require.NoError(t, cmd.GetRecoveryRequest(&b, newReader(t, &RecoveryRequestArgs{RequestID: res.MessageID})))
+
+	request := &RecoveryRequestResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), request))
+	require.Equal(t, "backup-1", request.RecoveryRequest.BackupID)
+
+	require.NoError(t, cmd.AcceptRecovery(&b, newReader(t, &RecoveryRequestArgs{RequestID: res.MessageID})))
+	require.Equal(t, protocol.RecoveryMsgType, sent[len(sent)-1].Type())
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}),
+		mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	consent := &keybackup.Consent{Statement: "I agree"}
+
+	tests := []struct {
+		name    string
+		fn      command.Exec
+		args    interface{}
+		code    command.Code
+		errType command.Type
+	}{
+		{RequestKey, cmd.RequestKey, &ConnectionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{Backup, cmd.Backup, &BackupArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			Backup, cmd.Backup, &BackupArgs{ConnectionID: connectionutil.ConnectionID},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{
+			Backup, cmd.Backup, &BackupArgs{ConnectionID: connectionutil.ConnectionID, KeyIDs: []string{"kid"}},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{RequestRecovery, cmd.RequestRecovery, &RequestRecoveryArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			RequestRecovery, cmd.RequestRecovery, &RequestRecoveryArgs{ConnectionID: connectionutil.ConnectionID},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{
+			GetRecoveryRequest, cmd.GetRecoveryRequest, &RecoveryRequestArgs{},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{AcceptRecovery, cmd.AcceptRecovery, &RecoveryRequestArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{DeclineRecovery, cmd.DeclineRecovery, &RecoveryRequestArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			RequestKey, cmd.RequestKey, &ConnectionArgs{ConnectionID: connectionutil.ConnectionID},
+			RequestKeyErrorCode, command.ExecuteError,
+		},
+		{
+			Backup, cmd.Backup,
+			&BackupArgs{ConnectionID: connectionutil.ConnectionID, KeyIDs: []string{"kid"}, Consent: consent},
+			BackupErrorCode, command.ExecuteError,
+		},
+		{
+			RequestRecovery, cmd.RequestRecovery,
+			&RequestRecoveryArgs{ConnectionID: connectionutil.ConnectionID, BackupID: "backup-1"},
+			RequestRecoveryErrorCode, command.ExecuteError,
+		},
+		{
+			GetRecoveryRequest, cmd.GetRecoveryRequest, &RecoveryRequestArgs{RequestID: "request-1"},
+			GetRecoveryRequestErrorCode, command.ExecuteError,
+		},
+		{
+			AcceptRecovery, cmd.AcceptRecovery, &RecoveryRequestArgs{RequestID: "request-1"},
+			AcceptRecoveryErrorCode, command.ExecuteError,
+		},
+		{
+			DeclineRecovery, cmd.DeclineRecovery, &RecoveryRequestArgs{RequestID: "request-1"},
+			DeclineRecoveryErrorCode, command.ExecuteError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%s %d", tc.name, tc.code), func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+			require.Equal(t, tc.errType, cmdErr.Type())
+		})
+
+		t.Run(tc.name+" invalid request", func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		})
+	}
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return protocol.New(prov)
+	})
+}
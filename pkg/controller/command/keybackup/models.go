@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/keybackup"
+)
+
+// ConnectionArgs model
+//
+// This is used for requesting the backup key of the custodian of a connection.
+//
+type ConnectionArgs struct {
+	// ConnectionID is the ID of the connection to the custodian.
+	ConnectionID string `json:"connection_id"`
+}
+
+// BackupArgs model
+//
+// This is used for backing up keys to a custodian.
+//
+type BackupArgs struct {
+	// ConnectionID is the ID of the connection to the custodian.
+	ConnectionID string `json:"connection_id"`
+	// BackupID is the ID of the backup, generated if empty. A backup with the same ID is replaced.
+	BackupID string `json:"backup_id,omitempty"`
+	// KeyIDs are the IDs of the KMS keys backed up.
+	KeyIDs []string `json:"key_ids"`
+	// Consent is the consent of the user to the custodian holding the keys.
+	Consent *keybackup.Consent `json:"consent"`
+}
+
+// RequestRecoveryArgs model
+//
+// This is used for requesting the recovery of the keys of a backup.
+//
+type RequestRecoveryArgs struct {
+	// ConnectionID is the ID of the connection to the custodian.
+	ConnectionID string `json:"connection_id"`
+	// BackupID is the ID of the backup recovered.
+	BackupID string `json:"backup_id"`
+}
+
+// RecoveryRequestArgs model
+//
+// This is used for getting, accepting or declining a recovery request received.
+//
+type RecoveryRequestArgs struct {
+	// RequestID is the ID of the recovery request.
+	RequestID string `json:"request_id"`
+	// Reason the recovery is declined, optional.
+	Reason string `json:"reason,omitempty"`
+}
+
+// MessageIDResponse model
+//
+// Represents the response of the commands sending a message.
+//
+type MessageIDResponse struct {
+	// MessageID is the ID of the message sent.
+	MessageID string `json:"message_id"`
+}
+
+// BackupResponse model
+//
+// Represents the response of the backup command.
+//
+type BackupResponse struct {
+	// BackupID is the ID of the backup sent.
+	BackupID string `json:"backup_id"`
+}
+
+// RecoveryRequestResponse model
+//
+// Represents the RecoveryRequest response message.
+//
+type RecoveryRequestResponse struct {
+	// RecoveryRequest is the recovery request received, not handled yet.
+	RecoveryRequest *keybackup.RecoveryRequest `json:"recovery_request"`
+}
@@ -36,6 +36,7 @@ const (
 	errMsgSvcNameRequired            = "service name is required"
 	errMsgInvalidAcceptanceCrit      = "invalid acceptance criteria"
 	errMsgBodyEmpty                  = "empty message body"
+	errMsgTypeEmpty                  = "empty message type"
 	errMsgDestinationMissing         = "missing message destination"
 	errMsgDestSvcEndpointMissing     = "missing service endpoint in message destination"
 	errMsgDestSvcEndpointKeysMissing = "missing service endpoint recipient/routing keys in message destination"
@@ -48,6 +49,7 @@ const (
 	RegisterHTTPMessageServiceCommandMethod = "RegisterHTTPService"
 	SendNewMessageCommandMethod             = "Send"
 	SendReplyMessageCommandMethod           = "Reply"
+	SendPlainMessageCommandMethod           = "SendPlain"
 
 	// log constants.
 	replyTo       = "replyTo"
@@ -109,6 +111,7 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, RegisterHTTPMessageServiceCommandMethod, o.RegisterHTTPService),
 		cmdutil.NewCommandHandler(CommandName, SendNewMessageCommandMethod, o.Send),
 		cmdutil.NewCommandHandler(CommandName, SendReplyMessageCommandMethod, o.Reply),
+		cmdutil.NewCommandHandler(CommandName, SendPlainMessageCommandMethod, o.SendPlain),
 	}
 }
 
@@ -214,6 +217,53 @@ func (o *Command) Send(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// SendPlain sends new DIDComm V2 message of the given type, body and attachments to destination provided.
+func (o *Command) SendPlain(rw io.Writer, req io.Reader) command.Error {
+	var request SendPlainMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SendPlainMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.Type == "" {
+		logutil.LogDebug(logger, CommandName, SendPlainMessageCommandMethod, errMsgTypeEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgTypeEmpty))
+	}
+
+	if request.ConnectionID == "" && request.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendPlainMessageCommandMethod, errMsgDestinationMissing)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgDestinationMissing))
+	}
+
+	ctx, cancel := prepareContext(request.AwaitReply.Timeout)
+	defer cancel()
+
+	res, err := o.msgClient.SendPlainMessage(&messaging.PlainMessage{
+		ID:          request.ID,
+		Type:        request.Type,
+		Body:        request.Body,
+		Attachments: request.Attachments,
+	},
+		messaging.SendByConnectionID(request.ConnectionID),
+		messaging.SendByTheirDID(request.TheirDID),
+		messaging.WaitForResponse(ctx, request.AwaitReply.ReplyMessageType))
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendPlainMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString("type", request.Type))
+
+		return command.NewExecuteError(SendMsgError, err)
+	}
+
+	command.WriteNillableResponse(rw, SendMessageResponse{Response: res}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendPlainMessageCommandMethod, successString,
+		logutil.CreateKeyValueString("type", request.Type))
+
+	return nil
+}
+
 // Reply sends reply to existing message.
 func (o *Command) Reply(rw io.Writer, req io.Reader) command.Error {
 	var request SendReplyMessageArgs
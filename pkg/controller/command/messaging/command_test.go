@@ -616,6 +616,95 @@ func TestCommand_Send(t *testing.T) {
 	})
 }
 
+func TestCommand_SendPlain(t *testing.T) {
+	t.Run("Test input args validation", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			requestJSON string
+			errorMsg    string
+		}{
+			{
+				name:        "missing all params",
+				requestJSON: `{}`,
+				errorMsg:    errMsgTypeEmpty,
+			},
+			{
+				name:        "missing destinations",
+				requestJSON: `{"type": "https://example.com/custom/1.0/ping", "body": {"text":"sample"}}`,
+				errorMsg:    errMsgDestinationMissing,
+			},
+			{
+				name:        "invalid input",
+				requestJSON: `{"type": -----}`,
+				errorMsg:    "invalid character",
+			},
+		}
+
+		t.Parallel()
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				cmd, err := New(&protocol.MockProvider{}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+				require.NoError(t, err)
+				require.NotNil(t, cmd)
+
+				var b bytes.Buffer
+				cmdErr := cmd.SendPlain(&b, bytes.NewBufferString(tc.requestJSON))
+				require.Error(t, cmdErr)
+				require.Empty(t, b.String())
+				require.Equal(t, cmdErr.Type(), command.ValidationError)
+				require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+
+	t.Run("Test send plain message", func(t *testing.T) {
+		memProvider := mem.NewProvider()
+
+		store, err := memProvider.OpenStore("didexchange")
+		require.NoError(t, err)
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "sample-conn-ID-001",
+			State:        "completed", MyDID: "mydid", TheirDID: "theirDID-001",
+		})
+		require.NoError(t, err)
+		require.NoError(t, store.Put("conn_sample-conn-ID-001", connBytes, spi.Tag{Name: "conn_"}))
+
+		cmd, err := New(&protocol.MockProvider{
+			StoreProvider:              memProvider,
+			ProtocolStateStoreProvider: mem.NewProvider(),
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendPlain(&b, bytes.NewBufferString(`{"type": "https://example.com/custom/1.0/ping",
+	"body": {"text":"sample"}, "attachments": [{"id": "1", "data": {"json": {"key": "value"}}}],
+	"connection_ID": "sample-conn-ID-001"}`))
+		require.NoError(t, cmdErr)
+
+		var response SendMessageResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Empty(t, response.Response)
+	})
+
+	t.Run("Test send plain message failure", func(t *testing.T) {
+		cmd, err := New(&protocol.MockProvider{}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendPlain(&b, bytes.NewBufferString(`{"type": "https://example.com/custom/1.0/ping",
+	"connection_ID": "sample-conn-ID-001"}`))
+		require.Error(t, cmdErr)
+		require.Empty(t, b.String())
+		require.Equal(t, cmdErr.Type(), command.ExecuteError)
+		require.Equal(t, cmdErr.Code(), SendMsgError)
+		require.Contains(t, cmdErr.Error(), "data not found")
+	})
+}
+
 func TestCommand_Reply(t *testing.T) {
 	t.Run("Test reply validation and failures", func(t *testing.T) {
 		tests := []struct {
@@ -9,6 +9,8 @@ package messaging
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 )
 
 // RegisterMsgSvcArgs contains parameters for registering a message service to message handler.
@@ -18,7 +20,7 @@ type RegisterMsgSvcArgs struct {
 
 	// Acceptance criteria for message service based on message purpose
 	// in case of multiple purposes, message will be dispatched if any one of the purpose matches
-	// with the purpose of incoming message.
+	// with the purpose of incoming message, a purpose ending with `*` matches any purpose starting with its prefix.
 	// Can be provided in conjunction with other acceptance criteria.
 	Purpose []string `json:"purpose"`
 
@@ -68,6 +70,38 @@ type SendNewMessageArgs struct {
 	AwaitReply AwaitReply `json:"await_reply"`
 }
 
+// SendPlainMessageArgs contains parameters for sending a new DIDComm V2 plaintext message
+// with one of two destination options below,
+//  1. ConnectionID - ID of the connection between sender and receiver of this message.
+//  2. TheirDID - DID of the receiver of this message, connected or not.
+//
+// Note: Precedence logic when both destination options are provided are according to above order.
+type SendPlainMessageArgs struct {
+
+	// Connection ID of the message destination
+	// This parameter takes precedence over `TheirDID` destination parameter.
+	ConnectionID string `json:"connection_ID,omitempty"`
+
+	// DID of the destination.
+	TheirDID string `json:"their_did,omitempty"`
+
+	// ID of the message, generated if empty
+	ID string `json:"id,omitempty"`
+
+	// Type of the message
+	Type string `json:"type"`
+
+	// Body of the message
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// Attachments of the message
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+
+	// Await reply from receiver of this message
+	// If provided, then send message feature will wait response of this type for same thread
+	AwaitReply AwaitReply `json:"await_reply"`
+}
+
 // ServiceEndpointDestinationParams contains service endpoint params.
 type ServiceEndpointDestinationParams struct {
 	// Recipient keys of service endpoint
@@ -123,6 +123,8 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		outofband.WithHandshakeProtocols(args.Protocols...),
 		outofband.WithRouterConnections(args.RouterConnectionID),
 		outofband.WithAccept(args.Accept...),
+		outofband.WithExpiry(args.ExpiresTime),
+		outofband.WithMaxAccepts(args.MaxAccepts),
 	)
 	if err != nil {
 		logutil.LogError(logger, CommandName, CreateInvitation, err.Error())
@@ -114,7 +114,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 		service := mocks.NewMockOobService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-		service.EXPECT().SaveInvitation(gomock.Any()).Return(errors.New("error message"))
+		service.EXPECT().SaveInvitation(gomock.Any(), gomock.Any()).Return(errors.New("error message"))
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
@@ -137,7 +137,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 		service := mocks.NewMockOobService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-		service.EXPECT().SaveInvitation(gomock.Any()).Return(nil)
+		service.EXPECT().SaveInvitation(gomock.Any(), gomock.Any()).Return(nil)
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
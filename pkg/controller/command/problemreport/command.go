@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/problemreport")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.ProblemReport)
+	// QueryError is for failures while querying the problem reports.
+	QueryError
+)
+
+// constants for the problem report commands.
+const (
+	// command name.
+	CommandName = "problemreport"
+
+	// command methods.
+	QueryCommandMethod = "Query"
+)
+
+// provider contains dependencies for the problem report command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type reportStore interface {
+	Query(connectionID string, from, to time.Time) ([]*problemreport.Record, error)
+}
+
+// Command contains the problem report history commands.
+type Command struct {
+	store reportStore
+}
+
+// New returns new problem report command instance.
+func New(p provider) (*Command, error) {
+	store, err := problemreport.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create problem report store: %w", err)
+	}
+
+	return &Command{store: store}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, QueryCommandMethod, c.Query),
+	}
+}
+
+// Query returns the problem reports sent and received by the agent, optionally filtered by connection and time range.
+func (c *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	var args QueryArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil && !errors.Is(err, io.EOF) {
+		logutil.LogInfo(logger, CommandName, QueryCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if !args.From.IsZero() && !args.To.IsZero() && !args.From.Before(args.To) {
+		logutil.LogInfo(logger, CommandName, QueryCommandMethod, "invalid time range")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("from must be before to"))
+	}
+
+	records, err := c.store.Query(args.ConnectionID, args.From, args.To)
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryCommandMethod, err.Error())
+
+		return command.NewExecuteError(QueryError, err)
+	}
+
+	command.WriteNillableResponse(rw, &QueryResponse{ProblemReports: records}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryCommandMethod, "success")
+
+	return nil
+}
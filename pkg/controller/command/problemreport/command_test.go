@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command - success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("test new command - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestCommand_Query(t *testing.T) {
+	t.Run("test query - success", func(t *testing.T) {
+		p := newProvider()
+
+		store, err := problemreport.New(p)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Save(service.DIDCommMsgMap{
+			"@id":   "report-1",
+			"@type": "https://didcomm.org/issue-credential/2.0/problem-report",
+			"description": map[string]interface{}{
+				"code": "issuance-abandoned",
+			},
+		}, problemreport.Inbound, "", ""))
+
+		cmd, err := New(p)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Query(&b, bytes.NewBufferString(`{}`)))
+
+		var res QueryResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.ProblemReports, 1)
+		require.Equal(t, "issuance-abandoned", res.ProblemReports[0].Code)
+
+		b.Reset()
+		require.Nil(t, cmd.Query(&b, bytes.NewBufferString(`{"from":"2999-01-01T00:00:00Z"}`)))
+
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Empty(t, res.ProblemReports)
+	})
+
+	t.Run("test query - arguments", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		store := &mockStore{}
+		cmd.store = store
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Query(&b, bytes.NewBufferString(
+			`{"connectionID":"conn-1","from":"2022-01-01T00:00:00Z","to":"2022-01-02T00:00:00Z"}`)))
+		require.Equal(t, "conn-1", store.connectionID)
+		require.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), store.from)
+		require.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), store.to)
+
+		// an empty request queries all the problem reports
+		require.Nil(t, cmd.Query(&b, bytes.NewBufferString("")))
+		require.Empty(t, store.connectionID)
+	})
+
+	t.Run("test query - invalid request", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{"from":"yesterday"}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.Query(&b, bytes.NewBufferString(`{"from":"2022-01-02T00:00:00Z","to":"2022-01-01T00:00:00Z"}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "from must be before to")
+	})
+
+	t.Run("test query - error", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd.store = &mockStore{err: errors.New("query error")}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{}`))
+		require.NotNil(t, cmdErr)
+		require.Equal(t, QueryError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "query error")
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+type mockStore struct {
+	connectionID string
+	from, to     time.Time
+	err          error
+}
+
+func (m *mockStore) Query(connectionID string, from, to time.Time) ([]*problemreport.Record, error) {
+	m.connectionID, m.from, m.to = connectionID, from, to
+
+	return nil, m.err
+}
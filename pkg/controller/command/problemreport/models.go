@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
+)
+
+// QueryArgs model
+//
+// This is used for querying the problem reports sent and received by the agent.
+type QueryArgs struct {
+	// ConnectionID of the problem reports, all the problem reports are returned when empty.
+	ConnectionID string `json:"connectionID,omitempty"`
+
+	// From is the start of the time range of the problem reports, inclusive.
+	From time.Time `json:"from,omitempty"`
+
+	// To is the end of the time range of the problem reports, exclusive.
+	To time.Time `json:"to,omitempty"`
+}
+
+// QueryResponse model
+//
+// This is used for returning the problem reports sent and received by the agent.
+type QueryResponse struct {
+	// ProblemReports sorted by time.
+	ProblemReports []*problemreport.Record `json:"problemReports"`
+}
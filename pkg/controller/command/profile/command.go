@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/profile")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid profile controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Profile)
+	// SetProfileErrorCode is for failures in set profile command.
+	SetProfileErrorCode
+	// GetProfileErrorCode is for failures in get profile command.
+	GetProfileErrorCode
+	// SendProfileErrorCode is for failures in send profile command.
+	SendProfileErrorCode
+	// RequestProfileErrorCode is for failures in request profile command.
+	RequestProfileErrorCode
+	// TheirProfileErrorCode is for failures in their profile command.
+	TheirProfileErrorCode
+)
+
+// constants for command profile.
+const (
+	CommandName = "profile"
+
+	SetProfile     = "SetProfile"
+	GetProfile     = "GetProfile"
+	SendProfile    = "SendProfile"
+	RequestProfile = "RequestProfile"
+	TheirProfile   = "TheirProfile"
+	// error messages.
+	errEmptyConnectionID = "empty connection_id"
+	errEmptyProfile      = "empty profile"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for profile.
+type Command struct {
+	client *profile.Client
+}
+
+// New returns new profile controller command instance.
+func New(ctx profile.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := profile.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.Name+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SetProfile, c.SetProfile),
+		cmdutil.NewCommandHandler(CommandName, GetProfile, c.GetProfile),
+		cmdutil.NewCommandHandler(CommandName, SendProfile, c.SendProfile),
+		cmdutil.NewCommandHandler(CommandName, RequestProfile, c.RequestProfile),
+		cmdutil.NewCommandHandler(CommandName, TheirProfile, c.TheirProfile),
+	}
+}
+
+// SetProfile saves the profile of the agent, optionally sent to all the completed connections.
+func (c *Command) SetProfile(rw io.Writer, req io.Reader) command.Error {
+	var args SetProfileArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SetProfile, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.Profile == nil {
+		logutil.LogDebug(logger, CommandName, SetProfile, errEmptyProfile)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyProfile))
+	}
+
+	if err := c.client.SetProfile(args.Profile, args.Share); err != nil {
+		logutil.LogError(logger, CommandName, SetProfile, err.Error())
+
+		if errors.Is(err, protocol.ErrInvalidProfile) {
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+
+		return command.NewExecuteError(SetProfileErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SetProfile, successString)
+
+	return nil
+}
+
+// GetProfile returns the profile of the agent.
+func (c *Command) GetProfile(rw io.Writer, _ io.Reader) command.Error {
+	p, err := c.client.Profile()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetProfile, err.Error())
+		return command.NewExecuteError(GetProfileErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ProfileResponse{Profile: p}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetProfile, successString)
+
+	return nil
+}
+
+// SendProfile sends the profile of the agent to the other party of the connection.
+func (c *Command) SendProfile(rw io.Writer, req io.Reader) command.Error {
+	var args SendProfileArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendProfile, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendProfile, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	id, err := c.client.SendProfile(args.ConnectionID, args.SendBackYours)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendProfile, err.Error())
+		return command.NewExecuteError(SendProfileErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendProfile, successString)
+
+	return nil
+}
+
+// RequestProfile requests the profile of the other party of the connection.
+func (c *Command) RequestProfile(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionIDArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestProfile, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, RequestProfile, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	id, err := c.client.RequestProfile(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestProfile, err.Error())
+		return command.NewExecuteError(RequestProfileErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestProfile, successString)
+
+	return nil
+}
+
+// TheirProfile returns the profile received from the other party of the connection.
+func (c *Command) TheirProfile(rw io.Writer, req io.Reader) command.Error {
+	var args ConnectionIDArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, TheirProfile, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, TheirProfile, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	p, err := c.client.TheirProfile(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, TheirProfile, err.Error())
+		return command.NewExecuteError(TheirProfileErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ProfileResponse{Profile: p}, logger)
+
+	logutil.LogDebug(logger, CommandName, TheirProfile, successString)
+
+	return nil
+}
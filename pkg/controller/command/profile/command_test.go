@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 5)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.EqualError(t, err, "cannot create a client: service error")
+	})
+}
+
+func TestCommand_Profile(t *testing.T) {
+	var sent []service.DIDCommMsgMap
+
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			sent = append(sent, msg.(service.DIDCommMsgMap))
+
+			return nil
+		},
+	}), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.SetProfile(&b, newReader(t, &SetProfileArgs{
+		Profile: &protocol.Profile{DisplayName: "Alice", Organization: "Faber College"},
+		Share:   true,
+	})))
+	require.Len(t, sent, 1)
+
+	b.Reset()
+	require.NoError(t, cmd.GetProfile(&b, nil))
+
+	var profileRes ProfileResponse
+	require.NoError(t, json.Unmarshal(b.Bytes(), &profileRes))
+	require.Equal(t, &protocol.Profile{DisplayName: "Alice", Organization: "Faber College"}, profileRes.Profile)
+
+	b.Reset()
+	require.NoError(t, cmd.SendProfile(&b, newReader(t, &SendProfileArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	var msgRes MessageResponse
+	require.NoError(t, json.Unmarshal(b.Bytes(), &msgRes))
+	require.Len(t, sent, 2)
+	require.Equal(t, sent[1].ID(), msgRes.MessageID)
+
+	b.Reset()
+	require.NoError(t, cmd.RequestProfile(&b, newReader(t, &ConnectionIDArgs{ConnectionID: connectionutil.ConnectionID})))
+	require.NoError(t, json.Unmarshal(b.Bytes(), &msgRes))
+	require.Len(t, sent, 3)
+	require.Equal(t, sent[2].ID(), msgRes.MessageID)
+	require.Equal(t, protocol.RequestProfileMsgType, sent[2].Type())
+
+	b.Reset()
+	require.NoError(t, cmd.TheirProfile(&b, newReader(t, &ConnectionIDArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	profileRes = ProfileResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &profileRes))
+	require.Nil(t, profileRes.Profile)
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		exec command.Exec
+		args interface{}
+		code command.Code
+	}{
+		{name: "set profile empty profile", exec: cmd.SetProfile, args: &SetProfileArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "set profile invalid picture", exec: cmd.SetProfile,
+			args: &SetProfileArgs{Profile: &protocol.Profile{DisplayPicture: "picture.png"}},
+			code: InvalidRequestErrorCode},
+		{name: "send profile empty connection", exec: cmd.SendProfile, args: &SendProfileArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "send profile unknown connection", exec: cmd.SendProfile,
+			args: &SendProfileArgs{ConnectionID: "unknown"}, code: SendProfileErrorCode},
+		{name: "request profile empty connection", exec: cmd.RequestProfile, args: &ConnectionIDArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "request profile unknown connection", exec: cmd.RequestProfile,
+			args: &ConnectionIDArgs{ConnectionID: "unknown"}, code: RequestProfileErrorCode},
+		{name: "their profile empty connection", exec: cmd.TheirProfile, args: &ConnectionIDArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "their profile unknown connection", exec: cmd.TheirProfile,
+			args: &ConnectionIDArgs{ConnectionID: "unknown"}, code: TheirProfileErrorCode},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmdErr := tc.exec(&bytes.Buffer{}, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+		})
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, exec := range []command.Exec{cmd.SetProfile, cmd.SendProfile, cmd.RequestProfile, cmd.TheirProfile} {
+			cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString("{"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return protocol.New(prov)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/profile"
+)
+
+// SetProfileArgs model
+//
+// This is used for setting the profile of the agent.
+//
+type SetProfileArgs struct {
+	// Profile of the agent.
+	Profile *profile.Profile `json:"profile"`
+	// Share sends the profile to all the completed connections.
+	Share bool `json:"share,omitempty"`
+}
+
+// ProfileResponse model
+//
+// Represents the response of the profile commands.
+//
+type ProfileResponse struct {
+	// Profile is the profile requested, empty if it is unknown.
+	Profile *profile.Profile `json:"profile,omitempty"`
+}
+
+// SendProfileArgs model
+//
+// This is used for sending the profile of the agent to the other party of a connection.
+//
+type SendProfileArgs struct {
+	// ConnectionID is the ID of the connection.
+	ConnectionID string `json:"connection_id"`
+	// SendBackYours asks the other party to send its profile in response.
+	SendBackYours bool `json:"send_back_yours,omitempty"`
+}
+
+// ConnectionIDArgs model
+//
+// This is used for requesting or getting the profile of the other party of a connection.
+//
+type ConnectionIDArgs struct {
+	// ConnectionID is the ID of the connection.
+	ConnectionID string `json:"connection_id"`
+}
+
+// MessageResponse model
+//
+// Represents the response of the commands sending a message.
+//
+type MessageResponse struct {
+	// MessageID is the ID of the message sent.
+	MessageID string `json:"message_id"`
+}
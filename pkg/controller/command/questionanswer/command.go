@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/questionanswer")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid question answer controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.QuestionAnswer)
+	// SendQuestionErrorCode is for failures in send question command.
+	SendQuestionErrorCode
+	// SendAnswerErrorCode is for failures in send answer command.
+	SendAnswerErrorCode
+	// GetQuestionErrorCode is for failures in get question command.
+	GetQuestionErrorCode
+)
+
+// constants for command question answer.
+const (
+	CommandName = "questionanswer"
+
+	SendQuestion = "SendQuestion"
+	SendAnswer   = "SendAnswer"
+	GetQuestion  = "GetQuestion"
+	// error messages.
+	errEmptyConnectionID = "empty connection_id"
+	errEmptyQuestion     = "empty question"
+	errEmptyQuestionID   = "empty question_id"
+	errEmptyResponse     = "empty response"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for question answer.
+type Command struct {
+	client *questionanswer.Client
+}
+
+// New returns new question answer controller command instance.
+func New(ctx questionanswer.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := questionanswer.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.QuestionAnswer+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SendQuestion, c.SendQuestion),
+		cmdutil.NewCommandHandler(CommandName, SendAnswer, c.SendAnswer),
+		cmdutil.NewCommandHandler(CommandName, GetQuestion, c.GetQuestion),
+	}
+}
+
+// SendQuestion asks the connection a question.
+func (c *Command) SendQuestion(rw io.Writer, req io.Reader) command.Error {
+	var args SendQuestionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendQuestion, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendQuestion, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if args.Question == nil {
+		logutil.LogDebug(logger, CommandName, SendQuestion, errEmptyQuestion)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyQuestion))
+	}
+
+	id, err := c.client.SendQuestion(args.ConnectionID, args.Question)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendQuestion, err.Error())
+		return command.NewExecuteError(SendQuestionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendQuestion, successString)
+
+	return nil
+}
+
+// SendAnswer answers the question received from the connection.
+func (c *Command) SendAnswer(rw io.Writer, req io.Reader) command.Error {
+	var args SendAnswerArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendAnswer, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendAnswer, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if args.QuestionID == "" {
+		logutil.LogDebug(logger, CommandName, SendAnswer, errEmptyQuestionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyQuestionID))
+	}
+
+	if args.Response == "" {
+		logutil.LogDebug(logger, CommandName, SendAnswer, errEmptyResponse)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyResponse))
+	}
+
+	id, err := c.client.SendAnswer(args.ConnectionID, args.QuestionID, args.Response)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendAnswer, err.Error())
+		return command.NewExecuteError(SendAnswerErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MessageIDResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendAnswer, successString)
+
+	return nil
+}
+
+// GetQuestion returns the question received with the given ID, if not answered yet.
+func (c *Command) GetQuestion(rw io.Writer, req io.Reader) command.Error {
+	var args QuestionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, GetQuestion, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.QuestionID == "" {
+		logutil.LogDebug(logger, CommandName, GetQuestion, errEmptyQuestionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyQuestionID))
+	}
+
+	question, err := c.client.Question(args.QuestionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetQuestion, err.Error())
+		return command.NewExecuteError(GetQuestionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &QuestionResponse{Question: question}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetQuestion, successString)
+
+	return nil
+}
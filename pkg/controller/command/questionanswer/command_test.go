@@ -0,0 +1,180 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 3)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.EqualError(t, err, "cannot create a client: service error")
+	})
+}
+
+func TestCommand_QuestionAnswer(t *testing.T) {
+	var sent []service.DIDCommMsgMap
+
+	prov := newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			sent = append(sent, msg.(service.DIDCommMsgMap))
+
+			return nil
+		},
+	})
+
+	cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.SendQuestion(&b, newReader(t, &SendQuestionArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		Question: &questionanswer.Question{
+			QuestionText:   "Are you logging in?",
+			ValidResponses: []questionanswer.ValidResponse{{Text: "Yes"}, {Text: "No"}},
+		},
+	})))
+
+	res := &MessageIDResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), res))
+	require.Equal(t, questionanswer.QuestionMsgType, sent[0].Type())
+	require.Equal(t, sent[0].ID(), res.MessageID)
+
+	// the question sent is received back on the same connection.
+	svc, err := prov.Service(questionanswer.QuestionAnswer)
+	require.NoError(t, err)
+
+	_, err = svc.(service.DIDComm).HandleInbound(sent[0],
+		service.NewDIDCommContext(connectionutil.MyDID, connectionutil.TheirDID, nil))
+	require.NoError(t, err)
+
+	b.Reset()
+
+	require.NoError(t, cmd.GetQuestion(&b, newReader(t, &QuestionArgs{QuestionID: res.MessageID})))
+
+	question := &QuestionResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), question))
+	require.Equal(t, "Are you logging in?", question.Question.QuestionText)
+
+	b.Reset()
+
+	require.NoError(t, cmd.SendAnswer(&b, newReader(t, &SendAnswerArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		QuestionID:   res.MessageID,
+		Response:     "Yes",
+	})))
+	require.Len(t, sent, 2)
+	require.Equal(t, questionanswer.AnswerMsgType, sent[1].Type())
+
+	thID, err := sent[1].ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, res.MessageID, thID)
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}),
+		mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	question := &questionanswer.Question{ValidResponses: []questionanswer.ValidResponse{{Text: "Yes"}}}
+
+	tests := []struct {
+		name    string
+		fn      command.Exec
+		args    interface{}
+		code    command.Code
+		errType command.Type
+	}{
+		{SendQuestion, cmd.SendQuestion, &SendQuestionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			SendQuestion, cmd.SendQuestion, &SendQuestionArgs{ConnectionID: connectionutil.ConnectionID},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{SendAnswer, cmd.SendAnswer, &SendAnswerArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			SendAnswer, cmd.SendAnswer, &SendAnswerArgs{ConnectionID: connectionutil.ConnectionID},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{
+			SendAnswer, cmd.SendAnswer, &SendAnswerArgs{ConnectionID: connectionutil.ConnectionID, QuestionID: "question-1"},
+			InvalidRequestErrorCode, command.ValidationError,
+		},
+		{GetQuestion, cmd.GetQuestion, &QuestionArgs{}, InvalidRequestErrorCode, command.ValidationError},
+		{
+			SendQuestion, cmd.SendQuestion, &SendQuestionArgs{ConnectionID: connectionutil.ConnectionID, Question: question},
+			SendQuestionErrorCode, command.ExecuteError,
+		},
+		{
+			SendAnswer, cmd.SendAnswer,
+			&SendAnswerArgs{ConnectionID: connectionutil.ConnectionID, QuestionID: "question-1", Response: "Yes"},
+			SendAnswerErrorCode, command.ExecuteError,
+		},
+		{
+			GetQuestion, cmd.GetQuestion, &QuestionArgs{QuestionID: "question-1"},
+			GetQuestionErrorCode, command.ExecuteError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%s %d", tc.name, tc.code), func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+			require.Equal(t, tc.errType, cmdErr.Type())
+		})
+
+		t.Run(tc.name+" invalid request", func(t *testing.T) {
+			var b bytes.Buffer
+			cmdErr := tc.fn(&b, bytes.NewBufferString("--"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+		})
+	}
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return questionanswer.New(prov)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+)
+
+// SendQuestionArgs model
+//
+// This is used for asking a question.
+//
+type SendQuestionArgs struct {
+	// ConnectionID is the ID of the connection the question is sent to.
+	ConnectionID string `json:"connection_id"`
+	// Question is the question sent.
+	Question *questionanswer.Question `json:"question"`
+}
+
+// SendAnswerArgs model
+//
+// This is used for answering a question.
+//
+type SendAnswerArgs struct {
+	// ConnectionID is the ID of the connection the question was received from.
+	ConnectionID string `json:"connection_id"`
+	// QuestionID is the ID of the question answered.
+	QuestionID string `json:"question_id"`
+	// Response is one of the valid responses of the question.
+	Response string `json:"response"`
+}
+
+// QuestionArgs model
+//
+// This is used for getting a question received.
+//
+type QuestionArgs struct {
+	// QuestionID is the ID of the question.
+	QuestionID string `json:"question_id"`
+}
+
+// MessageIDResponse model
+//
+// Represents the response of the commands sending a message.
+//
+type MessageIDResponse struct {
+	// MessageID is the ID of the message sent.
+	MessageID string `json:"message_id"`
+}
+
+// QuestionResponse model
+//
+// Represents the Question response message.
+//
+type QuestionResponse struct {
+	// Question is the question received, not answered yet.
+	Question *questionanswer.Question `json:"question"`
+}
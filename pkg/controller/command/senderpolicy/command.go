@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/senderpolicy")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.SenderPolicy)
+	// AddRuleErrorCode is for failures in add rule command.
+	AddRuleErrorCode
+	// RemoveRuleErrorCode is for failures in remove rule command.
+	RemoveRuleErrorCode
+)
+
+// constants for the sender policy commands.
+const (
+	// command name.
+	CommandName = "senderpolicy"
+
+	// command methods.
+	AddRuleCommandMethod    = "AddRule"
+	RemoveRuleCommandMethod = "RemoveRule"
+	RulesCommandMethod      = "Rules"
+
+	// error messages.
+	errEmptyID = "empty id"
+
+	// log constants.
+	successString = "success"
+)
+
+// Policy filters the inbound messages by sender DID, typically implemented by senderpolicy.Policy.
+type Policy interface {
+	AddRule(rule senderpolicy.Rule) error
+	RemoveRule(id string) error
+	Rules() []senderpolicy.Rule
+}
+
+// Command contains the commands managing the rules of the sender policy at runtime.
+type Command struct {
+	policy Policy
+}
+
+// New returns new sender policy command instance.
+func New(policy Policy) *Command {
+	return &Command{policy: policy}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, AddRuleCommandMethod, c.AddRule),
+		cmdutil.NewCommandHandler(CommandName, RemoveRuleCommandMethod, c.RemoveRule),
+		cmdutil.NewCommandHandler(CommandName, RulesCommandMethod, c.Rules),
+	}
+}
+
+// AddRule adds a rule allowing or denying the inbound messages of the senders matching its DID pattern.
+func (c *Command) AddRule(rw io.Writer, req io.Reader) command.Error {
+	var args AddRuleArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AddRuleCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ID == "" {
+		args.ID = uuid.New().String()
+	}
+
+	rule := senderpolicy.Rule{
+		ID:          args.ID,
+		Sender:      args.Sender,
+		MessageType: args.MessageType,
+		Action:      args.Action,
+	}
+
+	if err := c.policy.AddRule(rule); err != nil {
+		if errors.Is(err, senderpolicy.ErrInvalidRule) {
+			logutil.LogDebug(logger, CommandName, AddRuleCommandMethod, err.Error())
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+
+		logutil.LogError(logger, CommandName, AddRuleCommandMethod, err.Error())
+
+		return command.NewExecuteError(AddRuleErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RuleResponse{Rule: rule}, logger)
+
+	logutil.LogDebug(logger, CommandName, AddRuleCommandMethod, successString)
+
+	return nil
+}
+
+// RemoveRule removes a rule of the sender policy.
+func (c *Command) RemoveRule(rw io.Writer, req io.Reader) command.Error {
+	var args RemoveRuleArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RemoveRuleCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ID == "" {
+		logutil.LogDebug(logger, CommandName, RemoveRuleCommandMethod, errEmptyID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyID))
+	}
+
+	if err := c.policy.RemoveRule(args.ID); err != nil {
+		logutil.LogError(logger, CommandName, RemoveRuleCommandMethod, err.Error())
+		return command.NewExecuteError(RemoveRuleErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveRuleCommandMethod, successString)
+
+	return nil
+}
+
+// Rules returns the rules of the sender policy.
+func (c *Command) Rules(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, &RulesResponse{Rules: c.policy.Rules()}, logger)
+
+	logutil.LogDebug(logger, CommandName, RulesCommandMethod, successString)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestCommand(t *testing.T) {
+	policy, err := senderpolicy.New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	cmd := New(policy)
+	require.Len(t, cmd.GetHandlers(), 3)
+
+	t.Run("add, list and remove rules", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.AddRule(&b, bytes.NewBufferString(`{"sender":"did:key:*",`+
+			`"message_type":"https://didcomm.org/issue-credential/*","action":"deny"}`)))
+
+		added := &RuleResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), added))
+		require.NotEmpty(t, added.Rule.ID)
+		require.Equal(t, senderpolicy.Deny, added.Rule.Action)
+
+		b.Reset()
+		require.NoError(t, cmd.AddRule(&b, bytes.NewBufferString(`{"id":"peer","sender":"did:peer:*",`+
+			`"action":"allow"}`)))
+
+		b.Reset()
+		require.NoError(t, cmd.Rules(&b, nil))
+
+		rules := &RulesResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), rules))
+		require.Len(t, rules.Rules, 2)
+
+		b.Reset()
+		require.NoError(t, cmd.RemoveRule(&b, bytes.NewBufferString(`{"id":"peer"}`)))
+
+		b.Reset()
+		require.NoError(t, cmd.Rules(&b, nil))
+		require.NoError(t, json.Unmarshal(b.Bytes(), rules))
+		require.Equal(t, []senderpolicy.Rule{added.Rule}, rules.Rules)
+	})
+
+	t.Run("add rule errors", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.AddRule(&b, bytes.NewBufferString("{"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.AddRule(&b, bytes.NewBufferString(`{"sender":"did:key:*","action":"block"}`))
+		require.EqualError(t, cmdErr, "invalid sender policy rule: unsupported action 'block'")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("remove rule errors", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.RemoveRule(&b, bytes.NewBufferString("{"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.RemoveRule(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errEmptyID)
+
+		cmdErr = cmd.RemoveRule(&b, bytes.NewBufferString(`{"id":"unknown"}`))
+		require.EqualError(t, cmdErr, "sender policy rule unknown: data not found")
+		require.Equal(t, RemoveRuleErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
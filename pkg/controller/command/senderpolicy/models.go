@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
+)
+
+// AddRuleArgs model
+//
+// This is used for adding a sender policy rule.
+//
+type AddRuleArgs struct {
+	// ID of the rule, generated when empty. The rule with the same ID is replaced.
+	ID string `json:"id,omitempty"`
+	// Sender is the pattern of the sender DIDs, where * matches any characters (e.g. did:key:*).
+	Sender string `json:"sender"`
+	// MessageType is the pattern of the message types the rule applies to, all message types when empty.
+	MessageType string `json:"message_type,omitempty"`
+	// Action taken on the matching messages: allow or deny.
+	Action senderpolicy.Action `json:"action"`
+}
+
+// RemoveRuleArgs model
+//
+// This is used for removing a sender policy rule.
+//
+type RemoveRuleArgs struct {
+	// ID of the rule.
+	ID string `json:"id"`
+}
+
+// RuleResponse model
+//
+// Represents the sender policy rule added.
+//
+type RuleResponse struct {
+	Rule senderpolicy.Rule `json:"rule"`
+}
+
+// RulesResponse model
+//
+// Represents the sender policy rules.
+//
+type RulesResponse struct {
+	Rules []senderpolicy.Rule `json:"rules"`
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/controller/trustping")
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid trust ping controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.TrustPing)
+	// PingErrorCode is for failures in ping command.
+	PingErrorCode
+	// SetHealthCheckErrorCode is for failures in set health check command.
+	SetHealthCheckErrorCode
+	// HealthCheckErrorCode is for failures in health check command.
+	HealthCheckErrorCode
+)
+
+// constants for command trust ping.
+const (
+	CommandName = "trustping"
+
+	Ping           = "Ping"
+	SetHealthCheck = "SetHealthCheck"
+	HealthCheck    = "HealthCheck"
+	// error messages.
+	errEmptyConnectionID = "empty connection_id"
+	// log constants.
+	successString = "success"
+
+	_states = "_states"
+)
+
+// Command is controller command for trust ping.
+type Command struct {
+	client *trustping.Client
+}
+
+// New returns new trust ping controller command instance.
+func New(ctx trustping.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterStateMsg(protocol.TrustPing+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Ping, c.Ping),
+		cmdutil.NewCommandHandler(CommandName, SetHealthCheck, c.SetHealthCheck),
+		cmdutil.NewCommandHandler(CommandName, HealthCheck, c.HealthCheck),
+	}
+}
+
+// Ping sends a ping requesting a response to the other party of the connection.
+func (c *Command) Ping(rw io.Writer, req io.Reader) command.Error {
+	var args PingArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Ping, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Ping, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	id, err := c.client.Ping(args.ConnectionID, args.Comment)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Ping, err.Error())
+		return command.NewExecuteError(PingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PingResponse{MessageID: id}, logger)
+
+	logutil.LogDebug(logger, CommandName, Ping, successString)
+
+	return nil
+}
+
+// SetHealthCheck enables or disables the health check of the connection.
+func (c *Command) SetHealthCheck(rw io.Writer, req io.Reader) command.Error {
+	var args SetHealthCheckArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SetHealthCheck, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SetHealthCheck, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	if err := c.client.SetHealthCheck(args.ConnectionID, args.Enabled); err != nil {
+		logutil.LogError(logger, CommandName, SetHealthCheck, err.Error())
+		return command.NewExecuteError(SetHealthCheckErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SetHealthCheck, successString)
+
+	return nil
+}
+
+// HealthCheck returns the health check status of the connection.
+func (c *Command) HealthCheck(rw io.Writer, req io.Reader) command.Error {
+	var args HealthCheckArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, HealthCheck, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, HealthCheck, errEmptyConnectionID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	status, err := c.client.HealthCheck(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, HealthCheck, err.Error())
+		return command.NewExecuteError(HealthCheckErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &HealthCheckResponse{HealthStatus: status}, logger)
+
+	logutil.LogDebug(logger, CommandName, HealthCheck, successString)
+
+	return nil
+}
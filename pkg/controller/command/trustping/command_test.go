@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 3)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.EqualError(t, err, "cannot create a client: service error")
+	})
+}
+
+func TestCommand_TrustPing(t *testing.T) {
+	var sent []service.DIDCommMsgMap
+
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			sent = append(sent, msg.(service.DIDCommMsgMap))
+
+			return nil
+		},
+	}), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.Ping(&b, newReader(t, &PingArgs{ConnectionID: connectionutil.ConnectionID, Comment: "hello"})))
+
+	var pingRes PingResponse
+	require.NoError(t, json.Unmarshal(b.Bytes(), &pingRes))
+	require.Len(t, sent, 1)
+	require.Equal(t, sent[0].ID(), pingRes.MessageID)
+
+	b.Reset()
+	require.NoError(t, cmd.SetHealthCheck(&b, newReader(t, &SetHealthCheckArgs{
+		ConnectionID: connectionutil.ConnectionID,
+		Enabled:      true,
+	})))
+
+	b.Reset()
+	require.NoError(t, cmd.HealthCheck(&b, newReader(t, &HealthCheckArgs{ConnectionID: connectionutil.ConnectionID})))
+
+	var status protocol.HealthStatus
+	require.NoError(t, json.Unmarshal(b.Bytes(), &status))
+	require.Equal(t, connectionutil.ConnectionID, status.ConnectionID)
+	require.True(t, status.Enabled)
+	require.True(t, status.Healthy)
+}
+
+func TestCommand_Errors(t *testing.T) {
+	cmd, err := New(newProvider(t, &mockdispatcher.MockOutbound{}), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		exec command.Exec
+		args interface{}
+		code command.Code
+	}{
+		{name: "ping empty connection", exec: cmd.Ping, args: &PingArgs{}, code: InvalidRequestErrorCode},
+		{name: "ping unknown connection", exec: cmd.Ping, args: &PingArgs{ConnectionID: "unknown"},
+			code: PingErrorCode},
+		{name: "set health check empty connection", exec: cmd.SetHealthCheck, args: &SetHealthCheckArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "set health check unknown connection", exec: cmd.SetHealthCheck,
+			args: &SetHealthCheckArgs{ConnectionID: "unknown"}, code: SetHealthCheckErrorCode},
+		{name: "health check empty connection", exec: cmd.HealthCheck, args: &HealthCheckArgs{},
+			code: InvalidRequestErrorCode},
+		{name: "health check unknown connection", exec: cmd.HealthCheck,
+			args: &HealthCheckArgs{ConnectionID: "unknown"}, code: HealthCheckErrorCode},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmdErr := tc.exec(&bytes.Buffer{}, newReader(t, tc.args))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+		})
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, exec := range []command.Exec{cmd.Ping, cmd.SetHealthCheck, cmd.HealthCheck} {
+			cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString("{"))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+}
+
+func newReader(t *testing.T, v interface{}) *bytes.Buffer {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewBuffer(raw)
+}
+
+func newProvider(t *testing.T, outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, outbound, func(prov *mockprovider.Provider) (interface{}, error) {
+		return protocol.New(prov, protocol.WithHealthCheck(protocol.HealthCheckConfig{}))
+	})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+)
+
+// PingArgs model
+//
+// This is used for sending a ping to the other party of a connection.
+//
+type PingArgs struct {
+	// ConnectionID is the ID of the connection pinged.
+	ConnectionID string `json:"connection_id"`
+	// Comment of the ping, optional.
+	Comment string `json:"comment,omitempty"`
+}
+
+// PingResponse model
+//
+// Represents the response of the ping command.
+//
+type PingResponse struct {
+	// MessageID is the ID of the ping sent.
+	MessageID string `json:"message_id"`
+}
+
+// SetHealthCheckArgs model
+//
+// This is used for enabling or disabling the health check of a connection.
+//
+type SetHealthCheckArgs struct {
+	// ConnectionID is the ID of the connection.
+	ConnectionID string `json:"connection_id"`
+	// Enabled enables or disables the health check of the connection.
+	Enabled bool `json:"enabled"`
+}
+
+// HealthCheckArgs model
+//
+// This is used for getting the health check status of a connection.
+//
+type HealthCheckArgs struct {
+	// ConnectionID is the ID of the connection.
+	ConnectionID string `json:"connection_id"`
+}
+
+// HealthCheckResponse model
+//
+// Represents the response of the health check command.
+//
+type HealthCheckResponse struct {
+	*trustping.HealthStatus
+}
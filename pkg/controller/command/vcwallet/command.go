@@ -89,6 +89,15 @@ const (
 
 	// PresentProofErrorCode for errors while presenting proof from wallet.
 	PresentProofErrorCode
+
+	// ExportWalletErrorCode for errors while exporting wallet.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for errors while importing wallet.
+	ImportWalletErrorCode
+
+	// CapabilitiesErrorCode for errors while getting the capabilities of the wallet.
+	CapabilitiesErrorCode
 )
 
 // All command operations.
@@ -114,6 +123,9 @@ const (
 	ConnectMethod             = "Connect"
 	ProposePresentationMethod = "ProposePresentation"
 	PresentProofMethod        = "PresentProof"
+	ExportMethod              = "Export"
+	ImportMethod              = "Import"
+	CapabilitiesMethod        = "Capabilities"
 )
 
 // miscellaneous constants for the vc wallet command controller.
@@ -227,6 +239,9 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, ConnectMethod, o.Connect),
 		cmdutil.NewCommandHandler(CommandName, ProposePresentationMethod, o.ProposePresentation),
 		cmdutil.NewCommandHandler(CommandName, PresentProofMethod, o.PresentProof),
+		cmdutil.NewCommandHandler(CommandName, ExportMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportMethod, o.Import),
+		cmdutil.NewCommandHandler(CommandName, CapabilitiesMethod, o.Capabilities),
 	}
 }
 
@@ -837,6 +852,104 @@ func (o *Command) PresentProof(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// Export exports all wallet contents and keys into an archive encrypted by given password.
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	contents, err := vcWallet.Export(request.Auth, request.Password)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportResponse{Contents: contents}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import imports wallet contents and keys from an archive produced by export operation.
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	err = vcWallet.Import(request.Auth, request.Password, request.Contents)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ImportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Capabilities reports which capability templates are satisfiable from the credentials of the wallet and via which
+// credentials, without creating presentations.
+func (o *Command) Capabilities(rw io.Writer, req io.Reader) command.Error {
+	request := &CapabilitiesRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CapabilitiesMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CapabilitiesMethod, err.Error())
+
+		return command.NewExecuteError(CapabilitiesErrorCode, err)
+	}
+
+	capabilities, err := vcWallet.Capabilities(request.Auth, request.Templates...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CapabilitiesMethod, err.Error())
+
+		return command.NewExecuteError(CapabilitiesErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &CapabilitiesResponse{Capabilities: capabilities}, logger)
+
+	logutil.LogDebug(logger, CommandName, CapabilitiesMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // prepareProfileOptions prepares options for creating wallet profile.
 func prepareProfileOptions(rqst *CreateOrUpdateProfileRequest) []wallet.ProfileOptions {
 	var options []wallet.ProfileOptions
@@ -29,6 +29,7 @@ import (
 	outofbandSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	presentproofSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
@@ -251,7 +252,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetHandlers(), 18)
+		require.Len(t, cmd.GetHandlers(), 21)
 	})
 }
 
@@ -2182,6 +2183,232 @@ func TestCommand_PresentProof(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImport(t *testing.T) {
+	const (
+		sampleUser1    = "sample-user-export-01"
+		sampleUser2    = "sample-user-import-02"
+		samplePassword = "sample-export-password"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	addContent(t, mockctx, &AddContentRequest{
+		Content:     []byte(sampleUDCVC),
+		ContentType: "credential",
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+	})
+
+	var exported json.RawMessage
+
+	t.Run("successfully export wallet", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		request := &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Password:   samplePassword,
+		}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &request))
+		require.NoError(t, cmdErr)
+
+		var response ExportResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Contents)
+
+		exported = response.Contents
+	})
+
+	t.Run("successfully import wallet", func(t *testing.T) {
+		// wallet imported on another device.
+		targetctx := newMockProvider(t)
+
+		createSampleUserProfile(t, targetctx, &CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		token2, lock2 := unlockWallet(t, targetctx, &UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer lock2()
+
+		cmd := New(targetctx, &Config{})
+
+		request := &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser2, Auth: token2},
+			Password:   samplePassword,
+			Contents:   exported,
+		}
+
+		var b bytes.Buffer
+		cmdErr := cmd.Import(&b, getReader(t, &request))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.Get(&b, getReader(t, &GetContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser2, Auth: token2},
+			ContentType: "credential",
+			ContentID:   "http://example.edu/credentials/1877",
+		}))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("failed to export or import wallet - invalid request", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("failed to export or import wallet - invalid profile", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token1},
+			Password:   samplePassword,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "failed to get VC wallet profile")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token1},
+			Password:   samplePassword,
+			Contents:   exported,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to get VC wallet profile")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("failed to export or import wallet - invalid auth", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Password:   samplePassword,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "invalid auth token")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("failed to import wallet - invalid password", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Password:   samplePassword + "invalid",
+			Contents:   exported,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "invalid password")
+		require.Empty(t, b.Bytes())
+	})
+}
+
+func TestCommand_Capabilities(t *testing.T) {
+	const sampleUser1 = "sample-user-capabilities-01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	addContent(t, mockctx, &AddContentRequest{
+		Content:     []byte(sampleUDCVC),
+		ContentType: "credential",
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+	})
+
+	degree := &wallet.CapabilityTemplate{
+		ID: "degree",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:          "degree",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{Path: []string{"$.credentialSubject.degree"}}}},
+		}},
+	}
+
+	t.Run("successfully get capabilities", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Capabilities(&b, getReader(t, &CapabilitiesRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Templates:  []*wallet.CapabilityTemplate{degree, wallet.AgeOverTemplate(18)},
+		}))
+		require.NoError(t, cmdErr)
+
+		var response CapabilitiesResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Capabilities, 2)
+		require.True(t, response.Capabilities[0].Satisfiable)
+		require.Equal(t, []string{"http://example.edu/credentials/1877"}, response.Capabilities[0].Credentials)
+		require.False(t, response.Capabilities[1].Satisfiable)
+	})
+
+	t.Run("failed to get capabilities - invalid request", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Capabilities(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("failed to get capabilities - invalid profile", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Capabilities(&b, getReader(t, &CapabilitiesRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token1},
+			Templates:  []*wallet.CapabilityTemplate{degree},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, CapabilitiesErrorCode, "failed to get VC wallet profile")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("failed to get capabilities - invalid auth", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Capabilities(&b, getReader(t, &CapabilitiesRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Templates:  []*wallet.CapabilityTemplate{degree},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, CapabilitiesErrorCode, "invalid auth token")
+		require.Empty(t, b.Bytes())
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &Config{})
 	require.NotNil(t, cmd)
@@ -377,3 +377,42 @@ type PresentProofRequest struct {
 	// presentation to be sent as part of present proof message.
 	Presentation json.RawMessage `json:"presentation,omitempty"`
 }
+
+// ExportRequest is request model for exporting wallet.
+type ExportRequest struct {
+	WalletAuth
+
+	// password to be used to encrypt exported wallet, required for importing it.
+	Password string `json:"password"`
+}
+
+// ExportResponse is response model from wallet export operation.
+type ExportResponse struct {
+	// exported wallet, encrypted by password.
+	Contents json.RawMessage `json:"contents"`
+}
+
+// ImportRequest is request model for importing wallet.
+type ImportRequest struct {
+	WalletAuth
+
+	// password used while exporting the wallet.
+	Password string `json:"password"`
+
+	// exported wallet to be imported.
+	Contents json.RawMessage `json:"contents"`
+}
+
+// CapabilitiesRequest is request model for getting the capabilities of the wallet.
+type CapabilitiesRequest struct {
+	WalletAuth
+
+	// capability templates to check against the credentials of the wallet.
+	Templates []*wallet.CapabilityTemplate `json:"templates"`
+}
+
+// CapabilitiesResponse is response model from wallet capabilities operation.
+type CapabilitiesResponse struct {
+	// whether each template is satisfiable, and via which credentials.
+	Capabilities []*wallet.Capability `json:"capabilities"`
+}
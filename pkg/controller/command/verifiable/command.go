@@ -24,7 +24,9 @@ import (
 	verifiablesigner "github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -33,6 +35,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
 	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/vmresolver"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -124,6 +127,10 @@ const (
 
 	// Ed25519Signature2018 ed25519 signature suite.
 	Ed25519Signature2018 = "Ed25519Signature2018"
+	// Ed25519Signature2020 ed25519 signature suite.
+	Ed25519Signature2020 = "Ed25519Signature2020"
+	// DataIntegrityProof data integrity proof with the eddsa-rdfc-2022 cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
 	// JSONWebSignature2020 json web signature suite.
 	JSONWebSignature2020 = "JsonWebSignature2020"
 
@@ -605,17 +612,33 @@ func (o *Command) GetCredentialByName(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
-// GetCredentials retrieves the verifiable credential records containing name and fields of interest.
+// GetCredentials retrieves the verifiable credential records containing name and fields of interest, matching the
+// optional QueryCredentialsArgs criteria.
 func (o *Command) GetCredentials(rw io.Writer, req io.Reader) command.Error {
-	vcRecords, err := o.verifiableStore.GetCredentials()
+	var request QueryCredentialsArgs
+
+	if req != nil {
+		if err := json.NewDecoder(req).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			logutil.LogInfo(logger, CommandName, GetCredentialsCommandMethod, "request decode : "+err.Error())
+
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+		}
+	}
+
+	result, err := o.verifiableStore.QueryCredentials(&request.QueryParams)
 	if err != nil {
 		logutil.LogError(logger, CommandName, GetCredentialsCommandMethod, "get credential records : "+err.Error())
 
+		if errors.Is(err, verifiablestore.ErrInvalidPageToken) {
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+
 		return command.NewValidationError(GetCredentialsErrorCode, fmt.Errorf("get credential records : %w", err))
 	}
 
 	command.WriteNillableResponse(rw, &RecordResult{
-		Result: vcRecords,
+		Result:        result.Records,
+		NextPageToken: result.NextPageToken,
 	}, logger)
 
 	logutil.LogDebug(logger, CommandName, GetCredentialsCommandMethod, "success")
@@ -879,6 +902,10 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 	switch opts.SignatureType {
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
+	case Ed25519Signature2020:
+		signatureSuite = ed25519signature2020.New(suite.WithSigner(s))
+	case DataIntegrityProof:
+		signatureSuite = dataintegrity.New(suite.WithSigner(s))
 	case JSONWebSignature2020:
 		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
 	case BbsBlsSignature2020:
@@ -890,6 +917,11 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 
 	signatureRepresentation := verifiable.SignatureJWS
 
+	// the 2020 suites and data integrity proofs are only defined with a multibase proof value
+	if opts.SignatureType == Ed25519Signature2020 || opts.SignatureType == DataIntegrityProof {
+		signatureRepresentation = verifiable.SignatureProofValue
+	}
+
 	if opts.SignatureRepresentation == nil {
 		opts.SignatureRepresentation = &signatureRepresentation
 	}
@@ -1013,40 +1045,27 @@ func prepareOpts(opts *ProofOptions, didDoc *did.Doc, method did.VerificationRel
 	switch opts.SignatureType {
 	case "Ed25519Signature2018":
 		vmType = "Ed25519VerificationKey2018"
+	case "Ed25519Signature2020", "DataIntegrityProof":
+		vmType = "Ed25519VerificationKey2020"
 	case "BbsBlsSignature2020":
 		vmType = "Bls12381G2Key2020"
 	}
 
-	vMs := didDoc.VerificationMethods(method)[method]
-
-	vmMatched := opts.VerificationMethod == ""
-
-	for _, vm := range vMs {
-		if opts.VerificationMethod != "" {
-			// if verification method is provided as an option, then validate if it belongs to given method
-			if opts.VerificationMethod == vm.VerificationMethod.ID {
-				vmMatched = true
-
-				break
-			}
-
-			continue
-		} else {
-			// by default first authentication public key
-
-			// skip verification methods that don't match the type needed for the signature
-			if vmType != "" && vm.VerificationMethod.Type != vmType {
-				continue
-			}
-
-			opts.VerificationMethod = vm.VerificationMethod.ID
-
-			break
+	if opts.VerificationMethod != "" {
+		// if verification method is provided as an option, then validate if it belongs to given method
+		if _, err = vmresolver.Find(didDoc, opts.VerificationMethod, method); err != nil {
+			return nil, fmt.Errorf("unable to find matching '%s' key IDs for given verification method", opts.proofPurpose)
+		}
+	} else {
+		// by default first verification method of the proof purpose matching the type needed for the signature
+		var keyTypes []string
+		if vmType != "" {
+			keyTypes = append(keyTypes, vmType)
 		}
-	}
 
-	if !vmMatched {
-		return nil, fmt.Errorf("unable to find matching '%s' key IDs for given verification method", opts.proofPurpose)
+		if vm, selectErr := vmresolver.Select(didDoc, method, keyTypes...); selectErr == nil {
+			opts.VerificationMethod = vm.ID
+		}
 	}
 
 	// this is the fallback logic kept for DIDs not having authentication method
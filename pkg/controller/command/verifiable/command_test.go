@@ -920,6 +920,32 @@ func TestGetCredentials(t *testing.T) {
 		require.Equal(t, 1, len(response.Result))
 		require.Len(t, response.Result[0].Context, 2)
 		require.Len(t, response.Result[0].Type, 1)
+
+		getRW.Reset()
+		cmdErr = cmd.GetCredentials(&getRW, bytes.NewBufferString(`{"type":"UniversityDegreeCredential"}`))
+		require.NoError(t, cmdErr)
+
+		response = RecordResult{}
+		require.NoError(t, json.NewDecoder(&getRW).Decode(&response))
+		require.Empty(t, response.Result)
+		require.Empty(t, response.NextPageToken)
+	})
+
+	t.Run("test get credentials with invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mem.NewProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetCredentials(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.GetCredentials(&b, bytes.NewBufferString(`{"pageToken":"invalid!"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "invalid page token")
 	})
 }
 
@@ -2374,6 +2400,40 @@ func TestCommand_SignCredential(t *testing.T) {
 		require.Contains(t, vc.Proofs[0]["type"], "BbsBlsSignature2020")
 	})
 
+	t.Run("test sign credential with proof options - success (Ed25519Signature2020)", func(t *testing.T) {
+		req := SignCredentialRequest{
+			Credential: []byte(strings.Replace(bbsVc, "https://w3id.org/security/bbs/v1",
+				"https://w3id.org/security/suites/ed25519-2020/v1", 1)),
+			DID: "did:peer:123456789abcdefghi#inbox",
+			ProofOptions: &ProofOptions{
+				VerificationMethod: "did:peer:123456789abcdefghi#keys-1",
+				SignatureType:      Ed25519Signature2020,
+			},
+		}
+
+		reqBytes, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.SignCredential(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, err)
+
+		// verify response
+		var response SignCredentialResponse
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+		require.NotEmpty(t, response)
+
+		vc, err := verifiable.ParseCredential(response.VerifiableCredential, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(loader))
+
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "Ed25519Signature2020", vc.Proofs[0]["type"])
+		// signed with a proof value by default
+		require.Empty(t, vc.Proofs[0]["jws"])
+	})
+
 	t.Run("test sign credential with proof options - success (ed25519 jsonwebsignature)", func(t *testing.T) {
 		createdTime := time.Now().AddDate(-1, 0, 0)
 		req := SignCredentialRequest{
@@ -104,10 +104,22 @@ type NameArg struct {
 	Name string `json:"name"`
 }
 
+// QueryCredentialsArgs model
+//
+// This is used for querying the credential records by issuer, type, subject, schema and expiration, a page at a time.
+// All the credential records are returned if no criteria is set.
+//
+type QueryCredentialsArgs struct {
+	verifiable.QueryParams
+}
+
 // RecordResult holds the credential records.
 type RecordResult struct {
 	// Result
 	Result []*verifiable.Record `json:"result,omitempty"`
+
+	// NextPageToken is the token to query the next page of the credential records, empty on the last page
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 // Presentation is model for verifiable presentation.
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/webhook")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Webhook)
+	// AddTargetErrorCode is for failures in add target command.
+	AddTargetErrorCode
+	// RemoveTargetErrorCode is for failures in remove target command.
+	RemoveTargetErrorCode
+)
+
+// constants for the webhook commands.
+const (
+	// command name.
+	CommandName = "webhook"
+
+	// command methods.
+	AddTargetCommandMethod    = "AddTarget"
+	RemoveTargetCommandMethod = "RemoveTarget"
+	TargetsCommandMethod      = "Targets"
+
+	// error messages.
+	errEmptyURL = "empty url"
+	errEmptyID  = "empty id"
+
+	// log constants.
+	successString = "success"
+)
+
+// Router routes the notifications to the webhook targets, typically implemented by webnotifier.WebNotifier.
+type Router interface {
+	AddWebhookTarget(target *webnotifier.WebhookTarget) error
+	RemoveWebhookTarget(id string) error
+	WebhookTargets() []*webnotifier.WebhookTarget
+}
+
+// Command contains the commands managing the webhook targets at runtime.
+type Command struct {
+	router Router
+}
+
+// New returns new webhook command instance.
+func New(router Router) *Command {
+	return &Command{router: router}
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, AddTargetCommandMethod, c.AddTarget),
+		cmdutil.NewCommandHandler(CommandName, RemoveTargetCommandMethod, c.RemoveTarget),
+		cmdutil.NewCommandHandler(CommandName, TargetsCommandMethod, c.Targets),
+	}
+}
+
+// AddTarget adds a webhook target receiving the notifications matching its topics and connection IDs.
+func (c *Command) AddTarget(rw io.Writer, req io.Reader) command.Error {
+	var args AddTargetArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AddTargetCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.URL == "" {
+		logutil.LogDebug(logger, CommandName, AddTargetCommandMethod, errEmptyURL)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyURL))
+	}
+
+	if args.ID == "" {
+		args.ID = uuid.New().String()
+	}
+
+	target := &webnotifier.WebhookTarget{
+		ID:            args.ID,
+		URL:           args.URL,
+		Topics:        args.Topics,
+		ConnectionIDs: args.ConnectionIDs,
+	}
+
+	if err := c.router.AddWebhookTarget(target); err != nil {
+		logutil.LogError(logger, CommandName, AddTargetCommandMethod, err.Error())
+		return command.NewExecuteError(AddTargetErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &TargetResponse{Target: target}, logger)
+
+	logutil.LogDebug(logger, CommandName, AddTargetCommandMethod, successString)
+
+	return nil
+}
+
+// RemoveTarget removes a webhook target.
+func (c *Command) RemoveTarget(rw io.Writer, req io.Reader) command.Error {
+	var args RemoveTargetArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RemoveTargetCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ID == "" {
+		logutil.LogDebug(logger, CommandName, RemoveTargetCommandMethod, errEmptyID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyID))
+	}
+
+	if err := c.router.RemoveWebhookTarget(args.ID); err != nil {
+		logutil.LogError(logger, CommandName, RemoveTargetCommandMethod, err.Error())
+		return command.NewExecuteError(RemoveTargetErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RemoveTargetCommandMethod, successString)
+
+	return nil
+}
+
+// Targets returns the webhook targets.
+func (c *Command) Targets(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, &TargetsResponse{Targets: c.router.WebhookTargets()}, logger)
+
+	logutil.LogDebug(logger, CommandName, TargetsCommandMethod, successString)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := New(webnotifier.NewHTTPNotifier(nil))
+	require.Len(t, cmd.GetHandlers(), 3)
+
+	t.Run("add, list and remove targets", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.AddTarget(&b, bytes.NewBufferString(`{"url":"http://billing.example.com",`+
+			`"topics":["issuecredential_states"]}`)))
+
+		added := &TargetResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), added))
+		require.NotEmpty(t, added.Target.ID)
+		require.Equal(t, "http://billing.example.com", added.Target.URL)
+		require.Equal(t, []string{"issuecredential_states"}, added.Target.Topics)
+
+		b.Reset()
+		require.NoError(t, cmd.AddTarget(&b, bytes.NewBufferString(`{"id":"crm","url":"http://crm.example.com",`+
+			`"connection_ids":["conn-1"]}`)))
+
+		b.Reset()
+		require.NoError(t, cmd.Targets(&b, nil))
+
+		targets := &TargetsResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), targets))
+		require.Len(t, targets.Targets, 2)
+
+		b.Reset()
+		require.NoError(t, cmd.RemoveTarget(&b, bytes.NewBufferString(`{"id":"crm"}`)))
+
+		b.Reset()
+		require.NoError(t, cmd.Targets(&b, nil))
+
+		targets = &TargetsResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), targets))
+		require.Equal(t, []*webnotifier.WebhookTarget{added.Target}, targets.Targets)
+	})
+
+	t.Run("add target errors", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.AddTarget(&b, bytes.NewBufferString("{"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.AddTarget(&b, bytes.NewBufferString(`{"id":"id"}`))
+		require.EqualError(t, cmdErr, errEmptyURL)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.AddTarget(&b, bytes.NewBufferString(`{"url":"localhost"}`))
+		require.EqualError(t, cmdErr, `invalid webhook target URL "localhost"`)
+		require.Equal(t, AddTargetErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("remove target errors", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.RemoveTarget(&b, bytes.NewBufferString("{"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.RemoveTarget(&b, bytes.NewBufferString(`{}`))
+		require.EqualError(t, cmdErr, errEmptyID)
+
+		cmdErr = cmd.RemoveTarget(&b, bytes.NewBufferString(`{"id":"unknown"}`))
+		require.EqualError(t, cmdErr, webnotifier.ErrWebhookTargetNotFound.Error())
+		require.Equal(t, RemoveTargetErrorCode, cmdErr.Code())
+	})
+}
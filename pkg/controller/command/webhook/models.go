@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+)
+
+// AddTargetArgs model
+//
+// This is used for adding a webhook target.
+//
+type AddTargetArgs struct {
+	// ID of the target, generated when empty. The target with the same ID is replaced.
+	ID string `json:"id,omitempty"`
+	// URL the notifications are posted to.
+	URL string `json:"url"`
+	// Topics of the notifications sent to the target (e.g. issuecredential_states), all topics when empty.
+	Topics []string `json:"topics,omitempty"`
+	// ConnectionIDs of the notifications sent to the target, all notifications when empty.
+	ConnectionIDs []string `json:"connection_ids,omitempty"`
+}
+
+// RemoveTargetArgs model
+//
+// This is used for removing a webhook target.
+//
+type RemoveTargetArgs struct {
+	// ID of the target.
+	ID string `json:"id"`
+}
+
+// TargetResponse model
+//
+// Represents the webhook target added.
+//
+type TargetResponse struct {
+	Target *webnotifier.WebhookTarget `json:"target"`
+}
+
+// TargetsResponse model
+//
+// Represents the webhook targets.
+//
+type TargetsResponse struct {
+	Targets []*webnotifier.WebhookTarget `json:"targets"`
+}
@@ -9,35 +9,66 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	actionmenucmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	benchmarkcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/benchmark"
+	consistencycmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/consistency"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	eventjournalcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/eventjournal"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
+	jwkscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/jwks"
+	keybackupcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/keybackup"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	ldcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/ld"
 	routercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/mediator"
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	problemreportcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/problemreport"
+	profilecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/profile"
+	questionanswercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+	senderpolicycmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/senderpolicy"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vcwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	webhookcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/webhook"
+	grpcapi "github.com/hyperledger/aries-framework-go/pkg/controller/grpc"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	actionmenurest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
+	benchmarkrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/benchmark"
+	consistencyrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/consistency"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	eventjournalrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/eventjournal"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
+	jwksrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/jwks"
+	keybackuprest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/keybackup"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
 	ldrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/mediator"
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	problemreportrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/problemreport"
+	profilerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/profile"
+	questionanswerrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/questionanswer"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/rfc0593"
+	senderpolicyrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/senderpolicy"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
+	webhookrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	ldsvc "github.com/hyperledger/aries-framework-go/pkg/ld"
 )
@@ -57,6 +88,8 @@ type allOpts struct {
 	walletConf         *vcwalletcmd.Config
 	httpClient         HTTPClient
 	ldService          ldsvc.Service
+	jwksCacheMaxAge    time.Duration
+	benchmark          bool
 }
 
 const wsPath = "/ws"
@@ -127,6 +160,22 @@ func WithLDService(svc ldsvc.Service) Opt {
 	}
 }
 
+// WithJWKSCacheMaxAge is an option for setting the max age of the JWKS of the published KMS keys in the caches of the
+// clients, 5 minutes by default.
+func WithJWKSCacheMaxAge(maxAge time.Duration) Opt {
+	return func(opts *allOpts) {
+		opts.jwksCacheMaxAge = maxAge
+	}
+}
+
+// WithBenchmark is an option enabling the benchmark of the packers and of the linked data proof suites, disabled by
+// default as a run keeps the CPUs of the agent busy.
+func WithBenchmark(enabled bool) Opt {
+	return func(opts *allOpts) {
+		opts.benchmark = enabled
+	}
+}
+
 // GetRESTHandlers returns all REST handlers provided by controller.
 func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error) { // nolint: funlen,gocyclo
 	restAPIOpts := &allOpts{
@@ -201,6 +250,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create introduce rest command : %w", err)
 	}
 
+	// REST operations of the optional protocols
+	optionalHandlers, err := getOptionalRESTHandlers(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
+
 	// outofband REST operation
 	outofbandOp, err := outofbandrest.New(ctx, notifier)
 	if err != nil {
@@ -210,12 +265,36 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	// kms command operation
 	kmscmd := kmsrest.New(ctx)
 
+	// JWKS REST operation
+	jwksOp, err := jwksrest.New(ctx, restAPIOpts.jwksCacheMaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("create JWKS rest command : %w", err)
+	}
+
 	// vc wallet command controller
 	wallet := vcwalletrest.New(ctx, restAPIOpts.walletConf)
 
 	// JSON-LD REST operation
 	ldOp := ldrest.New(restAPIOpts.ldService, ldrest.WithHTTPClient(restAPIOpts.httpClient))
 
+	// store consistency REST operation
+	consistencyOp, err := consistencyrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// problem report history REST operation
+	problemReportOp, err := problemreportrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// event journal REST operation
+	eventJournalOp, err := eventjournalrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
@@ -227,16 +306,40 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, rfc0593Op.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, presentproofOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, optionalHandlers...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, jwksOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, ldOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, consistencyOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, problemReportOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, eventJournalOp.GetRESTHandlers()...)
+
+	if restAPIOpts.benchmark {
+		benchmarkOp, err := benchmarkrest.New()
+		if err != nil {
+			return nil, fmt.Errorf("create benchmark rest command : %w", err)
+		}
+
+		allHandlers = append(allHandlers, benchmarkOp.GetRESTHandlers()...)
+	}
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
 		allHandlers = append(allHandlers, nhp.GetRESTHandlers()...)
 	}
 
+	// webhook targets REST operation, when the notifier routes the notifications to webhook targets
+	if router, ok := notifier.(webhookcmd.Router); ok {
+		allHandlers = append(allHandlers, webhookrest.New(router).GetRESTHandlers()...)
+	}
+
+	// sender policy REST operation, when the inbound messages are filtered by sender DID
+	if policy := ctx.SenderPolicy(); policy != nil {
+		allHandlers = append(allHandlers, senderpolicyrest.New(policy).GetRESTHandlers()...)
+	}
+
 	return allHandlers, nil
 }
 
@@ -244,6 +347,65 @@ type handlerProvider interface {
 	GetRESTHandlers() []rest.Handler
 }
 
+// getOptionalRESTHandlers returns the REST handlers of the protocols which aren't registered by default by the
+// framework (eg. with aries.WithActionMenu), only for the protocol services registered.
+// nolint: funlen
+func getOptionalRESTHandlers(ctx *context.Provider, notifier command.Notifier) ([]rest.Handler, error) {
+	var handlers []rest.Handler
+
+	// action menu REST operation
+	if protocolRegistered(ctx, actionmenu.ActionMenu) {
+		actionmenuOp, err := actionmenurest.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create action menu rest command : %w", err)
+		}
+
+		handlers = append(handlers, actionmenuOp.GetRESTHandlers()...)
+	}
+
+	// question answer REST operation
+	if protocolRegistered(ctx, questionanswer.QuestionAnswer) {
+		questionanswerOp, err := questionanswerrest.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create question answer rest command : %w", err)
+		}
+
+		handlers = append(handlers, questionanswerOp.GetRESTHandlers()...)
+	}
+
+	// key backup REST operation
+	if protocolRegistered(ctx, keybackup.KeyBackup) {
+		keybackupOp, err := keybackuprest.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create key backup rest command : %w", err)
+		}
+
+		handlers = append(handlers, keybackupOp.GetRESTHandlers()...)
+	}
+
+	// trust ping REST operation
+	if protocolRegistered(ctx, trustping.TrustPing) {
+		trustpingOp, err := trustpingrest.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create trust ping rest command : %w", err)
+		}
+
+		handlers = append(handlers, trustpingOp.GetRESTHandlers()...)
+	}
+
+	// profile REST operation
+	if protocolRegistered(ctx, profile.Name) {
+		profileOp, err := profilerest.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create profile rest command : %w", err)
+		}
+
+		handlers = append(handlers, profileOp.GetRESTHandlers()...)
+	}
+
+	return handlers, nil
+}
+
 // GetCommandHandlers returns all command handlers provided by controller.
 func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler, error) { // nolint: funlen,gocyclo
 	cmdOpts := &allOpts{
@@ -310,6 +472,12 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create introduce command : %w", err)
 	}
 
+	// command operations of the optional protocols
+	optionalHandlers, err := getOptionalCommandHandlers(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
+
 	// outofband command operation
 	outofband, err := outofbandcmd.New(ctx, notifier)
 	if err != nil {
@@ -319,12 +487,36 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	// kms command operation
 	kmscmd := kms.New(ctx)
 
+	// JWKS command operation
+	jwks, err := jwkscmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create JWKS command : %w", err)
+	}
+
 	// vc wallet command controller
 	wallet := vcwalletcmd.New(ctx, cmdOpts.walletConf)
 
 	// JSON-LD command operation
 	ldCmd := ldcmd.New(cmdOpts.ldService, ldcmd.WithHTTPClient(cmdOpts.httpClient))
 
+	// store consistency command operation
+	consistency, err := consistencycmd.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// problem report history command operation
+	problemReport, err := problemreportcmd.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// event journal command operation
+	eventJournal, err := eventjournalcmd.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, vcmd.GetHandlers()...)
@@ -332,12 +524,117 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, routecmd.GetHandlers()...)
 	allHandlers = append(allHandlers, verifiablecmd.GetHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetHandlers()...)
+	allHandlers = append(allHandlers, jwks.GetHandlers()...)
 	allHandlers = append(allHandlers, issuecredential.GetHandlers()...)
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
+	allHandlers = append(allHandlers, optionalHandlers...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetHandlers()...)
 	allHandlers = append(allHandlers, ldCmd.GetHandlers()...)
+	allHandlers = append(allHandlers, consistency.GetHandlers()...)
+	allHandlers = append(allHandlers, problemReport.GetHandlers()...)
+	allHandlers = append(allHandlers, eventJournal.GetHandlers()...)
+
+	if cmdOpts.benchmark {
+		benchmark, err := benchmarkcmd.New()
+		if err != nil {
+			return nil, fmt.Errorf("create benchmark command : %w", err)
+		}
+
+		allHandlers = append(allHandlers, benchmark.GetHandlers()...)
+	}
+
+	// webhook targets command operation, when the notifier routes the notifications to webhook targets
+	if router, ok := notifier.(webhookcmd.Router); ok {
+		allHandlers = append(allHandlers, webhookcmd.New(router).GetHandlers()...)
+	}
+
+	// sender policy command operation, when the inbound messages are filtered by sender DID
+	if policy := ctx.SenderPolicy(); policy != nil {
+		allHandlers = append(allHandlers, senderpolicycmd.New(policy).GetHandlers()...)
+	}
 
 	return allHandlers, nil
 }
+
+// getOptionalCommandHandlers returns the command handlers of the protocols which aren't registered by default by the
+// framework (eg. with aries.WithActionMenu), only for the protocol services registered.
+// nolint: funlen
+func getOptionalCommandHandlers(ctx *context.Provider, notifier command.Notifier) ([]command.Handler, error) {
+	var handlers []command.Handler
+
+	// action menu command operation
+	if protocolRegistered(ctx, actionmenu.ActionMenu) {
+		actionmenuCmd, err := actionmenucmd.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create action menu command : %w", err)
+		}
+
+		handlers = append(handlers, actionmenuCmd.GetHandlers()...)
+	}
+
+	// question answer command operation
+	if protocolRegistered(ctx, questionanswer.QuestionAnswer) {
+		questionanswerCmd, err := questionanswercmd.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create question answer command : %w", err)
+		}
+
+		handlers = append(handlers, questionanswerCmd.GetHandlers()...)
+	}
+
+	// key backup command operation
+	if protocolRegistered(ctx, keybackup.KeyBackup) {
+		keybackupCmd, err := keybackupcmd.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create key backup command : %w", err)
+		}
+
+		handlers = append(handlers, keybackupCmd.GetHandlers()...)
+	}
+
+	// trust ping command operation
+	if protocolRegistered(ctx, trustping.TrustPing) {
+		trustpingCmd, err := trustpingcmd.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create trust ping command : %w", err)
+		}
+
+		handlers = append(handlers, trustpingCmd.GetHandlers()...)
+	}
+
+	// profile command operation
+	if protocolRegistered(ctx, profile.Name) {
+		profileCmd, err := profilecmd.New(ctx, notifier)
+		if err != nil {
+			return nil, fmt.Errorf("create profile command : %w", err)
+		}
+
+		handlers = append(handlers, profileCmd.GetHandlers()...)
+	}
+
+	return handlers, nil
+}
+
+// protocolRegistered reports whether the protocol service with the given name is registered by the framework.
+func protocolRegistered(ctx *context.Provider, name string) bool {
+	_, err := ctx.Service(name)
+
+	return err == nil
+}
+
+// GetGRPCServer returns the controller gRPC service executing all command handlers provided by controller.
+// The controller events are streamed to the gRPC subscribers, WithNotifier and WithWebhookURLs options are ignored.
+// As the commands register the protocol action events, either the REST handlers or the gRPC server can be created for
+// a given framework context.
+func GetGRPCServer(ctx *context.Provider, opts ...Opt) (*grpcapi.Server, error) {
+	notifier := grpcapi.NewNotifier()
+
+	handlers, err := GetCommandHandlers(ctx, append(opts, WithNotifier(notifier))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcapi.NewServer(handlers, notifier), nil
+}
@@ -7,14 +7,26 @@ SPDX-License-Identifier: Apache-2.0
 package controller
 
 import (
+	gocontext "context"
 	"net/http"
+	"path"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	actionmenucmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	keybackupcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/keybackup"
+	profilecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/profile"
+	questionanswercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
+	grpcapi "github.com/hyperledger/aries-framework-go/pkg/controller/grpc"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	actionmenurest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
+	keybackuprest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/keybackup"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
@@ -75,6 +87,100 @@ func TestGetCommandHandlers_Success(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, handlers)
 	})
+
+	t.Run("With benchmark", func(t *testing.T) {
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetCommandHandlers(ctx, WithBenchmark(true))
+		require.NoError(t, err)
+
+		var found bool
+
+		for _, h := range handlers {
+			found = found || h.Name() == "benchmark" && h.Method() == "Run"
+		}
+
+		require.True(t, found)
+	})
+
+	t.Run("With optional protocols", func(t *testing.T) {
+		optional := map[string]bool{
+			actionmenucmd.CommandName: true, questionanswercmd.CommandName: true, keybackupcmd.CommandName: true,
+			trustpingcmd.CommandName: true, profilecmd.CommandName: true,
+		}
+
+		commands := func(t *testing.T, opts ...aries.Option) map[string]bool {
+			t.Helper()
+
+			framework, err := aries.New(append(opts, defaults.WithInboundHTTPAddr(":"+
+				strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))...)
+			require.NoError(t, err)
+
+			defer func() { require.NoError(t, framework.Close()) }()
+
+			ctx, err := framework.Context()
+			require.NoError(t, err)
+
+			handlers, err := GetCommandHandlers(ctx)
+			require.NoError(t, err)
+
+			names := make(map[string]bool)
+
+			for _, h := range handlers {
+				if optional[h.Name()] {
+					names[h.Name()] = true
+				}
+			}
+
+			return names
+		}
+
+		require.Empty(t, commands(t))
+		require.Equal(t, optional, commands(t, aries.WithActionMenu(), aries.WithQuestionAnswer(),
+			aries.WithKeyBackup(), aries.WithTrustPing(), aries.WithProfile()))
+	})
+}
+
+func TestGetGRPCServer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx)
+
+		server, err := GetGRPCServer(ctx, WithAutoAccept(true), WithDefaultLabel("sample-label"))
+		require.NoError(t, err)
+		require.NotNil(t, server)
+
+		res, err := server.Execute(gocontext.Background(), &grpcapi.Request{
+			Command: didexchangecmd.CommandName,
+			Method:  didexchangecmd.QueryConnectionsCommandMethod,
+			Payload: []byte(`{}`),
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Payload)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		server, err := GetGRPCServer(&context.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), api.ErrSvcNotFound.Error())
+		require.Nil(t, server)
+	})
 }
 
 func TestGetRESTHandlers_Success(t *testing.T) {
@@ -108,10 +214,55 @@ func TestGetRESTHandlers_Success(t *testing.T) {
 
 		handlers, err := GetRESTHandlers(ctx, WithMessageHandler(msghandler.NewMockMsgServiceProvider()),
 			WithAutoAccept(true), WithDefaultLabel("sample-label"), WithAutoExecuteRFC0593(true),
-			WithWebhookURLs("sample-wh-url"), WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)))
+			WithWebhookURLs("sample-wh-url"), WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)),
+			WithJWKSCacheMaxAge(time.Minute))
 		require.NoError(t, err)
 		require.NotEmpty(t, handlers)
 	})
+	t.Run("with benchmark", func(t *testing.T) {
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetRESTHandlers(ctx, WithBenchmark(true))
+		require.NoError(t, err)
+
+		var found bool
+
+		for _, h := range handlers {
+			found = found || h.Path() == "/benchmark/run"
+		}
+
+		require.True(t, found)
+	})
+	t.Run("with optional protocols", func(t *testing.T) {
+		framework, err := aries.New(aries.WithActionMenu(), defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		handlers, err := GetRESTHandlers(ctx)
+		require.NoError(t, err)
+
+		var found bool
+
+		for _, h := range handlers {
+			found = found || h.Path() == actionmenurest.SendMenu
+			require.NotEqual(t, keybackuprest.OperationID, path.Dir(h.Path()))
+		}
+
+		require.True(t, found)
+	})
 }
 
 func TestWithWebhookNotifierOption(t *testing.T) {
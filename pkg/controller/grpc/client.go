@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"context"
+
+	googlegrpc "google.golang.org/grpc"
+)
+
+// Client calls the controller gRPC service.
+type Client struct {
+	conn *googlegrpc.ClientConn
+}
+
+// NewClient returns a new controller gRPC client using the given connection.
+func NewClient(conn *googlegrpc.ClientConn) *Client {
+	return &Client{conn: conn}
+}
+
+// Execute executes a controller command.
+func (c *Client) Execute(ctx context.Context, req *Request, opts ...googlegrpc.CallOption) (*Response, error) {
+	res := &Response{}
+
+	err := c.conn.Invoke(ctx, executeMethod, req, res, append(opts, googlegrpc.CallContentSubtype(CodecName))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Subscribe subscribes to the controller events, the subscription ends when ctx is canceled.
+func (c *Client) Subscribe(ctx context.Context, req *SubscribeRequest,
+	opts ...googlegrpc.CallOption) (*EventStream, error) {
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], subscribeMethod,
+		append(opts, googlegrpc.CallContentSubtype(CodecName))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+
+	if err = stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return &EventStream{stream: stream}, nil
+}
+
+// EventStream receives the controller events.
+type EventStream struct {
+	stream googlegrpc.ClientStream
+}
+
+// Recv blocks until the next event is received.
+func (s *EventStream) Recv() (*Event, error) {
+	event := &Event{}
+
+	if err := s.stream.RecvMsg(event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
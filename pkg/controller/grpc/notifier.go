@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"sync"
+)
+
+const subscriberBufferSize = 100
+
+// Notifier is a command.Notifier streaming the controller events to the gRPC subscribers.
+type Notifier struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	topics map[string]struct{}
+	events chan *Event
+}
+
+// NewNotifier returns a new gRPC notifier.
+func NewNotifier() *Notifier {
+	return &Notifier{subscribers: make(map[*subscriber]struct{})}
+}
+
+// Notify sends the message to the subscribers of the topic. Events are dropped for the subscribers which
+// don't keep up with the notifications.
+func (n *Notifier) Notify(topic string, message []byte) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for s := range n.subscribers {
+		if _, ok := s.topics[topic]; len(s.topics) > 0 && !ok {
+			continue
+		}
+
+		select {
+		case s.events <- &Event{Topic: topic, Message: message}:
+		default:
+			logger.Warnf("event dropped, subscriber is too slow to receive [%s] events", topic)
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) subscribe(topics []string) (<-chan *Event, func()) {
+	s := &subscriber{
+		topics: make(map[string]struct{}, len(topics)),
+		events: make(chan *Event, subscriberBufferSize),
+	}
+
+	for _, topic := range topics {
+		s.topics[topic] = struct{}{}
+	}
+
+	n.mu.Lock()
+	n.subscribers[s] = struct{}{}
+	n.mu.Unlock()
+
+	return s.events, func() {
+		n.mu.Lock()
+		delete(n.subscribers, s)
+		n.mu.Unlock()
+	}
+}
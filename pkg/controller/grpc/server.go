@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpc provides the controller API over gRPC, an alternative to the REST controller for server-to-server
+// integrations. The service executes the controller commands (didexchange, issuecredential, presentproof, vcwallet,
+// mediator, ...) and streams the controller events to the subscribers. Messages are JSON encoded, the command payloads
+// being the ones of the REST controller, so clients don't need generated stubs.
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+)
+
+const (
+	// ServiceName is the name of the controller gRPC service.
+	ServiceName = "aries.controller.Controller"
+	// CodecName is the content-subtype of the controller gRPC calls, messages are encoded in JSON.
+	CodecName = "json"
+	// ErrorCodeKey is the trailer metadata key holding the command error code of failed calls.
+	ErrorCodeKey = "command-error-code"
+
+	executeMethod   = "/" + ServiceName + "/Execute"
+	subscribeMethod = "/" + ServiceName + "/Subscribe"
+)
+
+var logger = log.New("aries-framework/controller/grpc")
+
+// nolint: gochecknoinits
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// Request executes a controller command, the command name and method match the command.Handler ones
+// (for instance 'didexchange' and 'CreateInvitation').
+type Request struct {
+	Command string          `json:"command"`
+	Method  string          `json:"method"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Response is the response of the executed controller command.
+type Response struct {
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// SubscribeRequest subscribes to the controller events of the given topics (all topics if empty).
+type SubscribeRequest struct {
+	Topics []string `json:"topics,omitempty"`
+}
+
+// Event is a controller event (the same notifications the REST controller sends to webhooks).
+type Event struct {
+	Topic   string          `json:"topic"`
+	Message json.RawMessage `json:"message"`
+}
+
+// Server is the controller gRPC service executing the controller commands and streaming their events.
+type Server struct {
+	handlers map[string]command.Handler
+	notifier *Notifier
+}
+
+// NewServer returns a new controller gRPC service executing the given command handlers, events are streamed to the
+// subscribers through the notifier the commands were created with.
+func NewServer(handlers []command.Handler, notifier *Notifier) *Server {
+	s := &Server{
+		handlers: make(map[string]command.Handler, len(handlers)),
+		notifier: notifier,
+	}
+
+	for _, h := range handlers {
+		s.handlers[handlerKey(h.Name(), h.Method())] = h
+	}
+
+	return s
+}
+
+// Register registers the controller service on the gRPC server.
+func (s *Server) Register(gs *googlegrpc.Server) {
+	gs.RegisterService(&serviceDesc, s)
+}
+
+// Execute executes the requested command.
+func (s *Server) Execute(ctx context.Context, req *Request) (*Response, error) {
+	h, ok := s.handlers[handlerKey(req.Command, req.Method)]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "command %s/%s not found", req.Command, req.Method)
+	}
+
+	var buf bytes.Buffer
+
+	if cmdErr := h.Handle()(&buf, bytes.NewReader(req.Payload)); cmdErr != nil {
+		if err := googlegrpc.SetTrailer(ctx, metadata.Pairs(ErrorCodeKey, strconv.Itoa(int(cmdErr.Code())))); err != nil {
+			logger.Warnf("failed to set command error code trailer: %s", err)
+		}
+
+		if cmdErr.Type() == command.ValidationError {
+			return nil, status.Error(codes.InvalidArgument, cmdErr.Error())
+		}
+
+		return nil, status.Error(codes.Internal, cmdErr.Error())
+	}
+
+	return &Response{Payload: bytes.TrimSpace(buf.Bytes())}, nil
+}
+
+// Subscribe streams the events of the requested topics until the client cancels the call.
+func (s *Server) Subscribe(req *SubscribeRequest, stream googlegrpc.ServerStream) error {
+	if s.notifier == nil {
+		return status.Error(codes.Unavailable, "events are not available")
+	}
+
+	events, unsubscribe := s.notifier.subscribe(req.Topics)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.SendMsg(event); err != nil {
+				return fmt.Errorf("send event: %w", err)
+			}
+		}
+	}
+}
+
+func handlerKey(name, method string) string {
+	return name + "/" + method
+}
+
+type controllerServer interface {
+	Execute(ctx context.Context, req *Request) (*Response, error)
+	Subscribe(req *SubscribeRequest, stream googlegrpc.ServerStream) error
+}
+
+// nolint: gochecknoglobals
+var serviceDesc = googlegrpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*controllerServer)(nil),
+	Methods: []googlegrpc.MethodDesc{{
+		MethodName: "Execute",
+		Handler:    executeHandler,
+	}},
+	Streams: []googlegrpc.StreamDesc{{
+		StreamName:    "Subscribe",
+		Handler:       subscribeHandler,
+		ServerStreams: true,
+	}},
+}
+
+func executeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor googlegrpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &Request{}
+
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(controllerServer).Execute(ctx, req)
+	}
+
+	info := &googlegrpc.UnaryServerInfo{Server: srv, FullMethod: executeMethod}
+
+	return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(controllerServer).Execute(ctx, req.(*Request))
+	})
+}
+
+func subscribeHandler(srv interface{}, stream googlegrpc.ServerStream) error {
+	req := &SubscribeRequest{}
+
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	return srv.(controllerServer).Subscribe(req, stream)
+}
+
+// jsonCodec encodes the controller messages in JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
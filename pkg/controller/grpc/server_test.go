@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+)
+
+func TestServer_Execute(t *testing.T) {
+	handlers := []command.Handler{
+		cmdutil.NewCommandHandler("sample", "Echo", func(rw io.Writer, req io.Reader) command.Error {
+			var request map[string]interface{}
+
+			if err := json.NewDecoder(req).Decode(&request); err != nil {
+				return command.NewValidationError(1, err)
+			}
+
+			if err := json.NewEncoder(rw).Encode(request); err != nil {
+				return command.NewExecuteError(2, err)
+			}
+
+			return nil
+		}),
+		cmdutil.NewCommandHandler("sample", "Fail", func(rw io.Writer, req io.Reader) command.Error {
+			return command.NewExecuteError(3, errors.New("execute error"))
+		}),
+	}
+
+	client := startServer(t, NewServer(handlers, nil))
+
+	t.Run("success", func(t *testing.T) {
+		res, err := client.Execute(context.Background(), &Request{
+			Command: "sample",
+			Method:  "Echo",
+			Payload: json.RawMessage(`{"label":"sample"}`),
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"label":"sample"}`, string(res.Payload))
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		var trailer metadata.MD
+
+		res, err := client.Execute(context.Background(), &Request{Command: "sample", Method: "Echo"},
+			googlegrpc.Trailer(&trailer))
+		require.Error(t, err)
+		require.Nil(t, res)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, []string{"1"}, trailer.Get(ErrorCodeKey))
+	})
+
+	t.Run("execute error", func(t *testing.T) {
+		var trailer metadata.MD
+
+		res, err := client.Execute(context.Background(), &Request{Command: "sample", Method: "Fail"},
+			googlegrpc.Trailer(&trailer))
+		require.Error(t, err)
+		require.Nil(t, res)
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.Equal(t, "execute error", status.Convert(err).Message())
+		require.Equal(t, []string{"3"}, trailer.Get(ErrorCodeKey))
+	})
+
+	t.Run("command not found", func(t *testing.T) {
+		res, err := client.Execute(context.Background(), &Request{Command: "sample", Method: "Unknown"})
+		require.Error(t, err)
+		require.Nil(t, res)
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		notifier := NewNotifier()
+		client := startServer(t, NewServer(nil, notifier))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.Subscribe(ctx, &SubscribeRequest{Topics: []string{"didexchange_states"}})
+		require.NoError(t, err)
+
+		all, err := client.Subscribe(ctx, &SubscribeRequest{})
+		require.NoError(t, err)
+
+		// wait for the subscriptions to be registered
+		require.Eventually(t, func() bool {
+			notifier.mu.RLock()
+			defer notifier.mu.RUnlock()
+
+			return len(notifier.subscribers) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, notifier.Notify("issue-credential_actions", []byte(`{"id":"1"}`)))
+		require.NoError(t, notifier.Notify("didexchange_states", []byte(`{"id":"2"}`)))
+
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "didexchange_states", event.Topic)
+		require.JSONEq(t, `{"id":"2"}`, string(event.Message))
+
+		event, err = all.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "issue-credential_actions", event.Topic)
+
+		event, err = all.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "didexchange_states", event.Topic)
+
+		cancel()
+
+		require.Eventually(t, func() bool {
+			notifier.mu.RLock()
+			defer notifier.mu.RUnlock()
+
+			return len(notifier.subscribers) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("events not available", func(t *testing.T) {
+		client := startServer(t, NewServer(nil, nil))
+
+		stream, err := client.Subscribe(context.Background(), &SubscribeRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Error(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	notifier := NewNotifier()
+
+	events, unsubscribe := notifier.subscribe(nil)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		require.NoError(t, notifier.Notify("topic", []byte(`{}`)))
+	}
+
+	// events are dropped once the subscriber buffer is full
+	require.Len(t, events, subscriberBufferSize)
+}
+
+func startServer(t *testing.T, s *Server) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	gs := googlegrpc.NewServer()
+	s.Register(gs)
+
+	go func() {
+		require.NoError(t, gs.Serve(listener))
+	}()
+
+	conn, err := googlegrpc.DialContext(context.Background(), "bufnet",
+		googlegrpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}), googlegrpc.WithInsecure())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+		gs.Stop()
+	})
+
+	return NewClient(conn)
+}
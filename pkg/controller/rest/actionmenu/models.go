@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+// actionMenuSendMenuRequest model
+//
+// This is used for operation to send a menu.
+//
+// swagger:parameters actionMenuSendMenu
+type actionMenuSendMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection the menu is sent to.
+		ConnectionID string `json:"connection_id"`
+		// Menu is the menu sent.
+		Menu *protocol.Menu `json:"menu"`
+	}
+}
+
+// actionMenuRequestMenuRequest model
+//
+// This is used for operation to ask the connection for its menu.
+//
+// swagger:parameters actionMenuRequestMenu
+type actionMenuRequestMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection.
+		ConnectionID string `json:"connection_id"`
+	}
+}
+
+// actionMenuPerformRequest model
+//
+// This is used for operation to perform an option of the menu.
+//
+// swagger:parameters actionMenuPerform
+type actionMenuPerformRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection the menu was received from.
+		ConnectionID string `json:"connection_id"`
+		// Name is the name of the menu option performed.
+		Name string `json:"name"`
+		// Params are the parameters of the form of the menu option.
+		Params map[string]string `json:"params"`
+	}
+}
+
+// actionMenuMessageIDResponse model
+//
+// Represents the response of the operations sending a message.
+//
+// swagger:response actionMenuMessageIDResponse
+type actionMenuMessageIDResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MessageID is the ID of the message sent.
+		MessageID string `json:"message_id"`
+	}
+}
+
+// actionMenuConnectionIDRequest model
+//
+// This is used for the operations on the menu of a connection.
+//
+// swagger:parameters actionMenuActiveMenu actionMenuCloseMenu
+type actionMenuConnectionIDRequest struct { // nolint: unused,deadcode
+	// ConnectionID is the ID of the connection.
+	//
+	// in: path
+	// required: true
+	ConnectionID string `json:"connection_id"`
+}
+
+// actionMenuActiveMenuResponse model
+//
+// Represents the ActiveMenu response message.
+//
+// swagger:response actionMenuActiveMenuResponse
+type actionMenuActiveMenuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// Menu is the last menu received from the connection.
+		Menu *protocol.Menu `json:"menu"`
+	}
+}
+
+// actionMenuCloseMenuResponse model
+//
+// Represents the CloseMenu response message.
+//
+// swagger:response actionMenuCloseMenuResponse
+type actionMenuCloseMenuResponse struct{} // nolint: unused,deadcode
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation action menu.
+const (
+	OperationID = "/action-menu"
+	SendMenu    = OperationID + "/send-menu"
+	RequestMenu = OperationID + "/request-menu"
+	Perform     = OperationID + "/perform"
+	ActiveMenu  = OperationID + "/{connection_id}/active-menu"
+	CloseMenu   = OperationID + "/{connection_id}/close-menu"
+)
+
+// Operation is controller REST service controller for the action menu.
+type Operation struct {
+	command  *actionmenu.Command
+	handlers []rest.Handler
+}
+
+// New returns new action menu rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := actionmenu.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("action menu command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(SendMenu, http.MethodPost, c.SendMenu),
+		cmdutil.NewHTTPHandler(RequestMenu, http.MethodPost, c.RequestMenu),
+		cmdutil.NewHTTPHandler(Perform, http.MethodPost, c.Perform),
+		cmdutil.NewHTTPHandler(ActiveMenu, http.MethodGet, c.ActiveMenu),
+		cmdutil.NewHTTPHandler(CloseMenu, http.MethodPost, c.CloseMenu),
+	}
+}
+
+// SendMenu swagger:route POST /action-menu/send-menu action-menu actionMenuSendMenu
+//
+// Sends a menu to the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuMessageIDResponse
+func (c *Operation) SendMenu(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendMenu, rw, req.Body)
+}
+
+// RequestMenu swagger:route POST /action-menu/request-menu action-menu actionMenuRequestMenu
+//
+// Asks the connection for its menu.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuMessageIDResponse
+func (c *Operation) RequestMenu(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.RequestMenu, rw, req.Body)
+}
+
+// Perform swagger:route POST /action-menu/perform action-menu actionMenuPerform
+//
+// Performs an option of the menu received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuMessageIDResponse
+func (c *Operation) Perform(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Perform, rw, req.Body)
+}
+
+// ActiveMenu swagger:route GET /action-menu/{connection_id}/active-menu action-menu actionMenuActiveMenu
+//
+// Returns the last menu received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuActiveMenuResponse
+func (c *Operation) ActiveMenu(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q}`, mux.Vars(req)["connection_id"])
+	rest.Execute(c.command.ActiveMenu, rw, bytes.NewBufferString(payload))
+}
+
+// CloseMenu swagger:route POST /action-menu/{connection_id}/close-menu action-menu actionMenuCloseMenu
+//
+// Discards the menu received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: actionMenuCloseMenuResponse
+func (c *Operation) CloseMenu(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q}`, mux.Vars(req)["connection_id"])
+	rest.Execute(c.command.CloseMenu, rw, bytes.NewBufferString(payload))
+}
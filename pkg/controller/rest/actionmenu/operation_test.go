@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 5)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu command")
+	})
+}
+
+func TestOperation(t *testing.T) {
+	op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	t.Run("send menu", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, SendMenu),
+			bytes.NewBufferString(`{"connection_id":"conn-1","menu":{"title":"Welcome"}}`), SendMenu)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+	})
+
+	t.Run("request menu", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, RequestMenu),
+			bytes.NewBufferString(`{"connection_id":"conn-1"}`), RequestMenu)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+	})
+
+	t.Run("perform", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, Perform),
+			bytes.NewBufferString(`{"connection_id":"conn-1","name":"option-1"}`), Perform)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+	})
+
+	t.Run("active menu not found", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, ActiveMenu), nil,
+			strings.Replace(ActiveMenu, "{connection_id}", connectionutil.ConnectionID, 1))
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("close menu", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, CloseMenu), nil,
+			strings.Replace(CloseMenu, "{connection_id}", connectionutil.ConnectionID, 1))
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, SendMenu),
+			bytes.NewBufferString(`{"connection_id":"conn-1"}`), SendMenu)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, &mockdispatcher.MockOutbound{},
+		func(prov *mockprovider.Provider) (interface{}, error) {
+			return actionmenu.New(prov)
+		})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/benchmark"
+)
+
+// runBenchmarkReq model
+//
+// This is used for operation to run the benchmark.
+//
+// swagger:parameters runBenchmark
+type runBenchmarkReq struct { // nolint: unused,deadcode
+	// in: body
+	Params benchmark.RunArgs
+}
+
+// runBenchmarkRes model
+//
+// Represents the report of the benchmark run.
+//
+// swagger:response runBenchmarkResponse
+type runBenchmarkRes struct { // nolint: unused,deadcode
+	// in: body
+	benchmark.RunResponse
+}
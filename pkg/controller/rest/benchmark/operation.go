@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/benchmark"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for benchmark operations.
+const (
+	OperationID = "/benchmark"
+	RunPath     = OperationID + "/run"
+)
+
+// Operation contains the benchmark operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  *benchmark.Command
+}
+
+// New returns new benchmark operations rest client instance.
+func New() (*Operation, error) {
+	cmd, err := benchmark.New()
+	if err != nil {
+		return nil, fmt.Errorf("benchmark command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(RunPath, http.MethodPost, o.Run),
+	}
+}
+
+// Run swagger:route POST /benchmark/run benchmark runBenchmark
+//
+// Runs the benchmark of the packers and of the linked data proof suites on the hardware of the agent.
+//
+// Responses:
+//    default: genericError
+//        200: runBenchmarkResponse
+func (o *Operation) Run(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Run, rw, req.Body)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package benchmark
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+func TestOperation_Run(t *testing.T) {
+	op, err := New()
+	require.NoError(t, err)
+	require.Len(t, op.GetRESTHandlers(), 1)
+
+	handler := op.GetRESTHandlers()[0]
+	require.Equal(t, RunPath, handler.Path())
+
+	t.Run("success", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handler,
+			bytes.NewBufferString(`{"cases":["authcrypt/pack"],"payload_sizes":[64],"duration":"1ms"}`))
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"case":"authcrypt/pack","payloadSize":64`)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handler, bytes.NewBufferString(`{"duration":"1h"}`))
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("run error", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handler, bytes.NewBufferString(`{"payload_sizes":[-1]}`))
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), handler.Path(), requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
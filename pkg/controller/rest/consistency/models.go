@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/consistency"
+)
+
+// consistencyReportRes model
+//
+// This is used for returning the consistency issues found in the agent stores.
+//
+// swagger:response consistencyReportRes
+type consistencyReportRes struct { // nolint: unused,deadcode
+
+	// in: body
+	consistency.ReportResponse
+}
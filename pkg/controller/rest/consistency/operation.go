@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdconsistency "github.com/hyperledger/aries-framework-go/pkg/controller/command/consistency"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// constants for the consistency operations.
+const (
+	ConsistencyOperationID = "/consistency"
+	CheckPath              = ConsistencyOperationID + "/check"
+	RepairPath             = ConsistencyOperationID + "/repair"
+)
+
+// provider contains dependencies for the consistency command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+}
+
+type consistencyCommand interface {
+	Check(rw io.Writer, req io.Reader) command.Error
+	Repair(rw io.Writer, req io.Reader) command.Error
+}
+
+// Operation contains the store consistency operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  consistencyCommand
+}
+
+// New returns new store consistency operations rest client instance.
+func New(p provider) (*Operation, error) {
+	cmd, err := cmdconsistency.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create consistency command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(CheckPath, http.MethodGet, o.Check),
+		cmdutil.NewHTTPHandler(RepairPath, http.MethodPost, o.Repair),
+	}
+}
+
+// Check swagger:route GET /consistency/check consistency checkConsistency
+//
+// Checks the referential integrity of the agent stores.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: consistencyReportRes
+func (o *Operation) Check(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Check, rw, req.Body)
+}
+
+// Repair swagger:route POST /consistency/repair consistency repairConsistency
+//
+// Checks the referential integrity of the agent stores and repairs the issues found.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: consistencyReportRes
+func (o *Operation) Repair(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Repair, rw, req.Body)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdconsistency "github.com/hyperledger/aries-framework-go/pkg/controller/command/consistency"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, op)
+		require.Len(t, op.GetRESTHandlers(), 2)
+	})
+
+	t.Run("test new operation - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestOperation_Check(t *testing.T) {
+	t.Run("test check - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, CheckPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, CheckPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var res cmdconsistency.ReportResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		require.Empty(t, res.Issues)
+	})
+
+	t.Run("test check - error", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		op.command = &mockCommand{err: command.NewExecuteError(cmdconsistency.CheckError, fmt.Errorf("check error"))}
+
+		handler := lookupHandler(t, op, CheckPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, CheckPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdconsistency.CheckError, "check error", buf.Bytes())
+	})
+}
+
+func TestOperation_Repair(t *testing.T) {
+	t.Run("test repair - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, RepairPath, http.MethodPost)
+		_, code, err := sendRequestToHandler(handler, nil, RepairPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("test repair - error", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		op.command = &mockCommand{err: command.NewExecuteError(cmdconsistency.RepairError, fmt.Errorf("repair error"))}
+
+		handler := lookupHandler(t, op, RepairPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, nil, RepairPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdconsistency.RepairError, "repair error", buf.Bytes())
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		ServiceErr:                        api.ErrSvcNotFound,
+	}
+}
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	t.Helper()
+
+	// Parser generic error response
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal(data, &errResponse)
+	require.NoError(t, err)
+
+	// verify response
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.Contains(t, errResponse.Message, expectedMsg)
+}
+
+type mockCommand struct {
+	err command.Error
+}
+
+func (m *mockCommand) Check(io.Writer, io.Reader) command.Error {
+	return m.err
+}
+
+func (m *mockCommand) Repair(io.Writer, io.Reader) command.Error {
+	return m.err
+}
@@ -177,6 +177,9 @@ type queryConnectionsResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct {
 		Results []*didexchangeSvc.Connection `json:"results,omitempty"`
+
+		// NextPageToken is the token to query the next page of the results, empty on the last page
+		NextPageToken string `json:"next_page_token,omitempty"`
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -35,6 +36,8 @@ const (
 	AcceptExchangeRequest        = OperationID + "/{id}/accept-request"
 	CreateConnection             = OperationID + "/create"
 	RemoveConnection             = OperationID + "/{id}/remove"
+
+	limitParam = "limit"
 )
 
 // provider contains dependencies for the Exchange protocol and is typically created by using aries.Context().
@@ -236,11 +239,11 @@ func (c *Operation) RemoveConnection(rw http.ResponseWriter, req *http.Request)
 	rest.Execute(c.command.RemoveConnection, rw, bytes.NewBufferString(request))
 }
 
-// queryValuesAsJSON converts query strings to `map[string]string`
+// queryValuesAsJSON converts query strings to `map[string]interface{}`, the limit being converted to a number,
 // and marshals them to JSON bytes.
 func queryValuesAsJSON(vals url.Values) ([]byte, error) {
 	// normalize all query string key/values
-	args := make(map[string]string)
+	args := make(map[string]interface{})
 
 	for k, v := range vals {
 		if len(v) > 0 {
@@ -248,6 +251,15 @@ func queryValuesAsJSON(vals url.Values) ([]byte, error) {
 		}
 	}
 
+	if limit := vals.Get(limitParam); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", limitParam, err)
+		}
+
+		args[limitParam] = n
+	}
+
 	return json.Marshal(args)
 }
 
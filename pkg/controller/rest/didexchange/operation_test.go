@@ -231,6 +231,27 @@ func TestOperation_QueryConnectionByParams(t *testing.T) {
 			require.NotNil(t, result.ConnectionID)
 		}
 	})
+
+	t.Run("test query connections with pagination", func(t *testing.T) {
+		handler = getHandler(t, Connections)
+		buf, err := getSuccessResponseFromHandler(handler, nil, OperationID+"?limit=1")
+		require.NoError(t, err)
+
+		response := didexchange.QueryConnectionsResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+
+		buf, code, err := sendRequestToHandler(handler, nil, OperationID+"?limit=one")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, OperationID+"?page_token=invalid!")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+	})
 }
 
 func TestOperation_ReceiveInvitationFailure(t *testing.T) {
@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/eventjournal"
+)
+
+// replayEventsReq model
+//
+// This is used for replaying the state events journaled after a cursor.
+//
+// swagger:parameters replayEvents
+type replayEventsReq struct { // nolint: unused,deadcode
+	// Cursor is the sequence number of the last event received, the events are replayed from the first one when empty.
+	//
+	// in: query
+	Cursor uint64 `json:"cursor"`
+
+	// Limit is the maximum number of events returned, 100 by default and 1000 at most.
+	//
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// replayEventsRes model
+//
+// This is used for returning the state events journaled after a cursor.
+//
+// swagger:response replayEventsRes
+type replayEventsRes struct { // nolint: unused,deadcode
+
+	// in: body
+	eventjournal.ReplayResponse
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdeventjournal "github.com/hyperledger/aries-framework-go/pkg/controller/command/eventjournal"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// constants for the event journal operations.
+const (
+	EventJournalOperationID = "/event-journal"
+	ReplayPath              = EventJournalOperationID
+)
+
+// provider contains dependencies for the event journal command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+type eventJournalCommand interface {
+	Replay(rw io.Writer, req io.Reader) command.Error
+}
+
+// Operation contains the event journal operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  eventJournalCommand
+}
+
+// New returns new event journal operations rest client instance.
+func New(p provider) (*Operation, error) {
+	cmd, err := cmdeventjournal.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create event journal command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(ReplayPath, http.MethodGet, o.Replay),
+	}
+}
+
+// Replay swagger:route GET /event-journal event-journal replayEvents
+//
+// Replays the state events journaled after the given cursor.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: replayEventsRes
+func (o *Operation) Replay(rw http.ResponseWriter, req *http.Request) {
+	var args cmdeventjournal.ReplayArgs
+
+	var err error
+
+	if cursor := req.URL.Query().Get("cursor"); cursor != "" {
+		args.Cursor, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdeventjournal.InvalidRequestErrorCode,
+				fmt.Errorf("invalid cursor : %w", err))
+
+			return
+		}
+	}
+
+	if limit := req.URL.Query().Get("limit"); limit != "" {
+		args.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdeventjournal.InvalidRequestErrorCode,
+				fmt.Errorf("invalid limit : %w", err))
+
+			return
+		}
+	}
+
+	reqBytes, err := json.Marshal(args)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdeventjournal.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.Replay, rw, bytes.NewReader(reqBytes))
+}
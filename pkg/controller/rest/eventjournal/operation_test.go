@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdeventjournal "github.com/hyperledger/aries-framework-go/pkg/controller/command/eventjournal"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, op)
+		require.Len(t, op.GetRESTHandlers(), 1)
+	})
+
+	t.Run("test new operation - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestOperation_Replay(t *testing.T) {
+	t.Run("test replay - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, ReplayPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, ReplayPath+"?cursor=5")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var res cmdeventjournal.ReplayResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		require.Empty(t, res.Events)
+		require.Equal(t, uint64(5), res.Cursor)
+	})
+
+	t.Run("test replay - query parameters", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd := &mockCommand{}
+		op.command = cmd
+
+		handler := lookupHandler(t, op, ReplayPath, http.MethodGet)
+		_, code, err := sendRequestToHandler(handler, nil, ReplayPath+"?cursor=10&limit=20")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"cursor":10,"limit":20}`, string(cmd.request))
+
+		_, code, err = sendRequestToHandler(handler, nil, ReplayPath+"?cursor=&limit=")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{}`, string(cmd.request))
+	})
+
+	t.Run("test replay - invalid query parameters", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, ReplayPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, ReplayPath+"?cursor=first")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, cmdeventjournal.InvalidRequestErrorCode, "invalid cursor", buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, ReplayPath+"?limit=all")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, cmdeventjournal.InvalidRequestErrorCode, "invalid limit", buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, ReplayPath+"?limit=-1")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, cmdeventjournal.InvalidRequestErrorCode, "limit must not be negative", buf.Bytes())
+	})
+
+	t.Run("test replay - error", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		op.command = &mockCommand{err: command.NewExecuteError(cmdeventjournal.ReplayError, fmt.Errorf("replay error"))}
+
+		handler := lookupHandler(t, op, ReplayPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, ReplayPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdeventjournal.ReplayError, "replay error", buf.Bytes())
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	t.Helper()
+
+	// Parser generic error response
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal(data, &errResponse)
+	require.NoError(t, err)
+
+	// verify response
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.Contains(t, errResponse.Message, expectedMsg)
+}
+
+type mockCommand struct {
+	request []byte
+	err     command.Error
+}
+
+func (m *mockCommand) Replay(_ io.Writer, req io.Reader) command.Error {
+	m.request, _ = ioutil.ReadAll(req) //nolint:errcheck
+
+	return m.err
+}
@@ -27,6 +27,25 @@ type issueCredentialAcceptProposalRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialAcceptProposalV3Request model
+//
+// This is used for operation to accept a DIDComm V2 proposal
+//
+// swagger:parameters issueCredentialAcceptProposalV3
+type issueCredentialAcceptProposalV3Request struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// required: true
+		OfferCredential struct{ *protocol.OfferCredentialV3 } `json:"offer_credential"`
+	}
+}
+
 // issueCredentialAcceptProposalResponse model
 //
 // Represents a AcceptProposal response message
@@ -79,6 +98,25 @@ type issueCredentialAcceptRequestRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialAcceptRequestV3Request model
+//
+// This is used for operation to accept a DIDComm V2 request
+//
+// swagger:parameters issueCredentialAcceptRequestV3
+type issueCredentialAcceptRequestV3Request struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// required: true
+		IssueCredential struct{ *protocol.IssueCredentialV3 } `json:"issue_credential"`
+	}
+}
+
 // issueCredentialAcceptRequestResponse model
 //
 // Represents a AcceptRequest response message
@@ -241,6 +279,25 @@ type issueCredentialNegotiateProposalRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialNegotiateProposalV3Request model
+//
+// This is used for operation when the Holder wants to negotiate about a DIDComm V2 offer he received.
+//
+// swagger:parameters issueCredentialNegotiateProposalV3
+type issueCredentialNegotiateProposalV3Request struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// required: true
+		ProposeCredential struct{ *protocol.ProposeCredentialV3 } `json:"propose_credential"`
+	}
+}
+
 // issueCredentialNegotiateProposalResponse model
 //
 // Represents a NegotiateProposal response message
@@ -291,6 +348,26 @@ type issueCredentialSendOfferRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialSendOfferV3Request model
+//
+// This is used for operation to send an offer over DIDComm V2.
+//
+// swagger:parameters issueCredentialSendOfferV3
+type issueCredentialSendOfferV3Request struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// OfferCredential is a message describing the credential intend to offer.
+		// required: true
+		OfferCredential struct{ *protocol.OfferCredentialV3 } `json:"offer_credential"`
+	}
+}
+
 // issueCredentialSendOfferResponse model
 //
 // Represents a SendOffer response message
@@ -324,6 +401,26 @@ type issueCredentialSendProposalRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialSendProposalV3Request model
+//
+// This is used for operation to send a proposal over DIDComm V2.
+//
+// swagger:parameters issueCredentialSendProposalV3
+type issueCredentialSendProposalV3Request struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// ProposeCredential is a message sent by the potential Holder to the Issuer to initiate the protocol
+		// required: true
+		ProposeCredential struct{ *protocol.ProposeCredentialV3 } `json:"propose_credential"`
+	}
+}
+
 // issueCredentialSendProposalResponse model
 //
 // Represents a SendProposal response message
@@ -358,6 +455,26 @@ type issueCredentialSendRequestRequest struct { // nolint: unused,deadcode
 	}
 }
 
+// issueCredentialSendRequestV3Request model
+//
+// This is used for operation to send a request over DIDComm V2.
+//
+// swagger:parameters issueCredentialSendRequestV3
+type issueCredentialSendRequestV3Request struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// RequestCredential is a message sent by the potential Holder to the Issuer to request a credential.
+		// required: true
+		RequestCredential struct{ *protocol.RequestCredentialV3 } `json:"request_credential"`
+	}
+}
+
 // issueCredentialSendRequestResponse model
 //
 // Represents a SendRequest response message
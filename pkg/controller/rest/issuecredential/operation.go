@@ -28,16 +28,23 @@ import (
 // constants for issue credential endpoints.
 const (
 	OperationID         = "/issuecredential"
+	OperationIDV3       = OperationID + "/v3"
 	Actions             = OperationID + "/actions"
 	SendOffer           = OperationID + "/send-offer"
+	SendOfferV3         = OperationIDV3 + "/send-offer"
 	SendProposal        = OperationID + "/send-proposal"
+	SendProposalV3      = OperationIDV3 + "/send-proposal"
 	SendRequest         = OperationID + "/send-request"
+	SendRequestV3       = OperationIDV3 + "/send-request"
 	AcceptProposal      = OperationID + "/{piid}/accept-proposal"
+	AcceptProposalV3    = OperationIDV3 + "/{piid}/accept-proposal"
 	DeclineProposal     = OperationID + "/{piid}/decline-proposal"
 	AcceptOffer         = OperationID + "/{piid}/accept-offer"
 	DeclineOffer        = OperationID + "/{piid}/decline-offer"
 	NegotiateProposal   = OperationID + "/{piid}/negotiate-proposal"
+	NegotiateProposalV3 = OperationIDV3 + "/{piid}/negotiate-proposal"
 	AcceptRequest       = OperationID + "/{piid}/accept-request"
+	AcceptRequestV3     = OperationIDV3 + "/{piid}/accept-request"
 	DeclineRequest      = OperationID + "/{piid}/decline-request"
 	AcceptCredential    = OperationID + "/{piid}/accept-credential"
 	DeclineCredential   = OperationID + "/{piid}/decline-credential"
@@ -80,14 +87,20 @@ func (c *Operation) registerHandler() {
 	c.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(Actions, http.MethodGet, c.Actions),
 		cmdutil.NewHTTPHandler(SendOffer, http.MethodPost, c.SendOffer),
+		cmdutil.NewHTTPHandler(SendOfferV3, http.MethodPost, c.SendOfferV3),
 		cmdutil.NewHTTPHandler(SendProposal, http.MethodPost, c.SendProposal),
+		cmdutil.NewHTTPHandler(SendProposalV3, http.MethodPost, c.SendProposalV3),
 		cmdutil.NewHTTPHandler(SendRequest, http.MethodPost, c.SendRequest),
+		cmdutil.NewHTTPHandler(SendRequestV3, http.MethodPost, c.SendRequestV3),
 		cmdutil.NewHTTPHandler(AcceptProposal, http.MethodPost, c.AcceptProposal),
+		cmdutil.NewHTTPHandler(AcceptProposalV3, http.MethodPost, c.AcceptProposalV3),
 		cmdutil.NewHTTPHandler(DeclineProposal, http.MethodPost, c.DeclineProposal),
 		cmdutil.NewHTTPHandler(AcceptOffer, http.MethodPost, c.AcceptOffer),
 		cmdutil.NewHTTPHandler(DeclineOffer, http.MethodPost, c.DeclineOffer),
 		cmdutil.NewHTTPHandler(NegotiateProposal, http.MethodPost, c.NegotiateProposal),
+		cmdutil.NewHTTPHandler(NegotiateProposalV3, http.MethodPost, c.NegotiateProposalV3),
 		cmdutil.NewHTTPHandler(AcceptRequest, http.MethodPost, c.AcceptRequest),
+		cmdutil.NewHTTPHandler(AcceptRequestV3, http.MethodPost, c.AcceptRequestV3),
 		cmdutil.NewHTTPHandler(DeclineRequest, http.MethodPost, c.DeclineRequest),
 		cmdutil.NewHTTPHandler(AcceptCredential, http.MethodPost, c.AcceptCredential),
 		cmdutil.NewHTTPHandler(DeclineCredential, http.MethodPost, c.DeclineCredential),
@@ -117,6 +130,17 @@ func (c *Operation) SendOffer(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(c.command.SendOffer, rw, req.Body)
 }
 
+// SendOfferV3 swagger:route POST /issuecredential/v3/send-offer issue-credential issueCredentialSendOfferV3
+//
+// Sends an offer over DIDComm V2.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialSendOfferResponse
+func (c *Operation) SendOfferV3(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendOfferV3, rw, req.Body)
+}
+
 // SendProposal swagger:route POST /issuecredential/send-proposal issue-credential issueCredentialSendProposal
 //
 // Sends a proposal.
@@ -128,6 +152,17 @@ func (c *Operation) SendProposal(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(c.command.SendProposal, rw, req.Body)
 }
 
+// SendProposalV3 swagger:route POST /issuecredential/v3/send-proposal issue-credential issueCredentialSendProposalV3
+//
+// Sends a proposal over DIDComm V2.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialSendProposalResponse
+func (c *Operation) SendProposalV3(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendProposalV3, rw, req.Body)
+}
+
 // SendRequest swagger:route POST /issuecredential/send-request issue-credential issueCredentialSendRequest
 //
 // Sends a request.
@@ -139,6 +174,17 @@ func (c *Operation) SendRequest(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(c.command.SendRequest, rw, req.Body)
 }
 
+// SendRequestV3 swagger:route POST /issuecredential/v3/send-request issue-credential issueCredentialSendRequestV3
+//
+// Sends a request over DIDComm V2.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialSendRequestResponse
+func (c *Operation) SendRequestV3(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendRequestV3, rw, req.Body)
+}
+
 // AcceptProposal swagger:route POST /issuecredential/{piid}/accept-proposal issue-credential issueCredentialAcceptProposal
 //
 // Accepts a proposal.
@@ -152,6 +198,19 @@ func (c *Operation) AcceptProposal(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// AcceptProposalV3 swagger:route POST /issuecredential/v3/{piid}/accept-proposal issue-credential issueCredentialAcceptProposalV3
+//
+// Accepts a DIDComm V2 proposal.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialAcceptProposalResponse
+func (c *Operation) AcceptProposalV3(rw http.ResponseWriter, req *http.Request) {
+	if ok, r := toCommandRequest(rw, req); ok {
+		rest.Execute(c.command.AcceptProposalV3, rw, r)
+	}
+}
+
 // DeclineProposal swagger:route POST /issuecredential/{piid}/decline-proposal issue-credential issueCredentialDeclineProposal
 //
 // Declines a proposal.
@@ -219,6 +278,19 @@ func (c *Operation) NegotiateProposal(rw http.ResponseWriter, req *http.Request)
 	}
 }
 
+// NegotiateProposalV3 swagger:route POST /issuecredential/v3/{piid}/negotiate-proposal issue-credential issueCredentialNegotiateProposalV3
+//
+// Is used when the Holder wants to negotiate about a DIDComm V2 offer he received.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialNegotiateProposalResponse
+func (c *Operation) NegotiateProposalV3(rw http.ResponseWriter, req *http.Request) {
+	if ok, r := toCommandRequest(rw, req); ok {
+		rest.Execute(c.command.NegotiateProposalV3, rw, r)
+	}
+}
+
 // AcceptRequest swagger:route POST /issuecredential/{piid}/accept-request issue-credential issueCredentialAcceptRequest
 //
 // Accepts a request.
@@ -232,6 +304,19 @@ func (c *Operation) AcceptRequest(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// AcceptRequestV3 swagger:route POST /issuecredential/v3/{piid}/accept-request issue-credential issueCredentialAcceptRequestV3
+//
+// Accepts a DIDComm V2 request.
+//
+// Responses:
+//    default: genericError
+//        200: issueCredentialAcceptRequestResponse
+func (c *Operation) AcceptRequestV3(rw http.ResponseWriter, req *http.Request) {
+	if ok, r := toCommandRequest(rw, req); ok {
+		rest.Execute(c.command.AcceptRequestV3, rw, r)
+	}
+}
+
 // DeclineRequest swagger:route POST /issuecredential/{piid}/decline-request issue-credential issueCredentialDeclineRequest
 //
 // Declines a request.
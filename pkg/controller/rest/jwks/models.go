@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/jwks"
+)
+
+// publishKeyReq model
+//
+// This is used for operation to publish a KMS key in the JWKS.
+//
+// swagger:parameters publishKey
+type publishKeyReq struct { // nolint: unused,deadcode
+	// in: body
+	Params jwks.PublishKeyArgs
+}
+
+// keyIDReq model
+//
+// This is used for operation to remove a key from the JWKS.
+//
+// swagger:parameters unpublishKey
+type keyIDReq struct { // nolint: unused,deadcode
+	// KeyID is the ID of the KMS key.
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"key_id"`
+}
+
+// rotateKeyReq model
+//
+// This is used for operation to rotate a published KMS key.
+//
+// swagger:parameters rotateKey
+type rotateKeyReq struct { // nolint: unused,deadcode
+	// KeyID is the ID of the KMS key rotated.
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"key_id"`
+
+	// RetireAfter is the number of seconds the public key rotated stays in the JWKS, 24 hours by default.
+	//
+	// in: query
+	RetireAfter int64 `json:"retire_after"`
+}
+
+// rotateKeyRes model
+//
+// Represents the RotateKey response message.
+//
+// swagger:response rotateKeyResponse
+type rotateKeyRes struct { // nolint: unused,deadcode
+	// in: body
+	jwks.RotateKeyResponse
+}
+
+// jwksRes model
+//
+// Represents the JWKS of the published keys.
+//
+// swagger:response jwksResponse
+type jwksRes struct { // nolint: unused,deadcode
+	// in: body
+	jwks.JWKSResponse
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/jwks"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// constants for JWKS operations.
+const (
+	OperationID      = "/kms/jwks"
+	GetJWKSPath      = OperationID
+	PublishKeyPath   = OperationID + "/keys"
+	UnpublishKeyPath = PublishKeyPath + "/{key_id}"
+	RotateKeyPath    = UnpublishKeyPath + "/rotate"
+
+	// DefaultCacheMaxAge is the default max age of the JWKS in the caches of the clients.
+	DefaultCacheMaxAge = 5 * time.Minute
+)
+
+// provider contains dependencies for the JWKS command and is typically created by using aries.Context().
+type provider interface {
+	KMS() kms.KeyManager
+	StorageProvider() storage.Provider
+}
+
+// Operation contains the JWKS operations provided by controller REST API.
+type Operation struct {
+	handlers    []rest.Handler
+	command     *jwks.Command
+	cacheMaxAge time.Duration
+}
+
+// New returns new JWKS operations rest client instance. The JWKS is cached by the clients for cacheMaxAge,
+// DefaultCacheMaxAge if zero.
+func New(p provider, cacheMaxAge time.Duration) (*Operation, error) {
+	cmd, err := jwks.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("JWKS command : %w", err)
+	}
+
+	if cacheMaxAge <= 0 {
+		cacheMaxAge = DefaultCacheMaxAge
+	}
+
+	o := &Operation{command: cmd, cacheMaxAge: cacheMaxAge}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(GetJWKSPath, http.MethodGet, o.GetJWKS),
+		cmdutil.NewHTTPHandler(PublishKeyPath, http.MethodPost, o.PublishKey),
+		cmdutil.NewHTTPHandler(UnpublishKeyPath, http.MethodDelete, o.UnpublishKey),
+		cmdutil.NewHTTPHandler(RotateKeyPath, http.MethodPost, o.RotateKey),
+	}
+}
+
+// GetJWKS swagger:route GET /kms/jwks jwks getJWKS
+//
+// Returns the JWKS of the published keys, to verify the JWTs signed by the agent.
+//
+// Responses:
+//    default: genericError
+//        200: jwksResponse
+func (o *Operation) GetJWKS(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int64(o.cacheMaxAge.Seconds())))
+	rest.Execute(o.command.GetJWKS, rw, req.Body)
+}
+
+// PublishKey swagger:route POST /kms/jwks/keys jwks publishKey
+//
+// Publishes the public key of a KMS key in the JWKS.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) PublishKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.PublishKey, rw, req.Body)
+}
+
+// UnpublishKey swagger:route DELETE /kms/jwks/keys/{key_id} jwks unpublishKey
+//
+// Removes a key from the JWKS, the KMS key is kept.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UnpublishKey(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"key_id":%q}`, mux.Vars(req)["key_id"])
+	rest.Execute(o.command.UnpublishKey, rw, bytes.NewBufferString(payload))
+}
+
+// RotateKey swagger:route POST /kms/jwks/keys/{key_id}/rotate jwks rotateKey
+//
+// Rotates a published KMS key, the public key rotated stays in the JWKS until it retires.
+//
+// Responses:
+//    default: genericError
+//        200: rotateKeyResponse
+func (o *Operation) RotateKey(rw http.ResponseWriter, req *http.Request) {
+	var retireAfter int64
+
+	if value := req.URL.Query().Get("retire_after"); value != "" {
+		var err error
+
+		retireAfter, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, jwks.InvalidRequestErrorCode,
+				fmt.Errorf("invalid retire_after: %w", err))
+
+			return
+		}
+	}
+
+	payload := fmt.Sprintf(`{"key_id":%q,"retire_after":%d}`, mux.Vars(req)["key_id"], retireAfter)
+	rest.Execute(o.command.RotateKey, rw, bytes.NewBufferString(payload))
+}
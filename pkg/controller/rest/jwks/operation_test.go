@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(newProvider(t), 0)
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 4)
+		require.Equal(t, DefaultCacheMaxAge, op.cacheMaxAge)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		}, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWKS command")
+	})
+}
+
+func TestOperation(t *testing.T) {
+	prov := newProvider(t)
+
+	op, err := New(prov, time.Hour)
+	require.NoError(t, err)
+
+	keyID, _, err := prov.KMSValue.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, code, _ := sendRequestToHandler(t, handlerLookup(t, op, PublishKeyPath),
+		bytes.NewBufferString(fmt.Sprintf(`{"key_id":%q,"key_type":"ED25519"}`, keyID)), PublishKeyPath)
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("get JWKS", func(t *testing.T) {
+		buf, code, header := sendRequestToHandler(t, handlerLookup(t, op, GetJWKSPath), nil, GetJWKSPath)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "public, max-age=3600", header.Get("Cache-Control"))
+		require.Contains(t, buf.String(), keyID)
+	})
+
+	t.Run("rotate key", func(t *testing.T) {
+		_, code, _ := sendRequestToHandler(t, handlerLookup(t, op, RotateKeyPath), nil,
+			strings.Replace(RotateKeyPath, "{key_id}", keyID, 1)+"?retire_after=invalid")
+		require.Equal(t, http.StatusBadRequest, code)
+
+		buf, code, _ := sendRequestToHandler(t, handlerLookup(t, op, RotateKeyPath), nil,
+			strings.Replace(RotateKeyPath, "{key_id}", keyID, 1)+"?retire_after=60")
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "key_id")
+	})
+
+	t.Run("unpublish key", func(t *testing.T) {
+		path := strings.Replace(UnpublishKeyPath, "{key_id}", keyID, 1)
+
+		_, code, _ := sendRequestToHandler(t, handlerLookup(t, op, UnpublishKeyPath), nil, path)
+		require.Equal(t, http.StatusOK, code)
+
+		_, code, _ = sendRequestToHandler(t, handlerLookup(t, op, UnpublishKeyPath), nil, path)
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code, _ := sendRequestToHandler(t, handlerLookup(t, op, PublishKeyPath),
+			bytes.NewBufferString(`{"key_type":"ED25519"}`), PublishKeyPath)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue:             km,
+	}
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader,
+	path string) (*bytes.Buffer, int, http.Header) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, rr.Header()
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
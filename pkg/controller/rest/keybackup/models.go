@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+)
+
+// keyBackupRequestKeyRequest model
+//
+// This is used for operation to request the backup key of a custodian.
+//
+// swagger:parameters keyBackupRequestKey
+type keyBackupRequestKeyRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection to the custodian.
+		ConnectionID string `json:"connection_id"`
+	}
+}
+
+// keyBackupBackupRequest model
+//
+// This is used for operation to back up keys.
+//
+// swagger:parameters keyBackupBackup
+type keyBackupBackupRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection to the custodian.
+		ConnectionID string `json:"connection_id"`
+		// BackupID is the ID of the backup, generated if empty.
+		BackupID string `json:"backup_id"`
+		// KeyIDs are the IDs of the KMS keys backed up.
+		KeyIDs []string `json:"key_ids"`
+		// Consent is the consent of the user to the custodian holding the keys.
+		Consent *protocol.Consent `json:"consent"`
+	}
+}
+
+// keyBackupRequestRecoveryRequest model
+//
+// This is used for operation to request the recovery of a backup.
+//
+// swagger:parameters keyBackupRequestRecovery
+type keyBackupRequestRecoveryRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection to the custodian.
+		ConnectionID string `json:"connection_id"`
+		// BackupID is the ID of the backup recovered.
+		BackupID string `json:"backup_id"`
+	}
+}
+
+// keyBackupRecoveryRequestID model
+//
+// This is used for operations on a recovery request received.
+//
+// swagger:parameters keyBackupGetRecoveryRequest keyBackupAcceptRecovery
+type keyBackupRecoveryRequestID struct { // nolint: unused,deadcode
+	// RequestID is the ID of the recovery request.
+	//
+	// in: path
+	// required: true
+	RequestID string `json:"request_id"`
+}
+
+// keyBackupDeclineRecoveryRequest model
+//
+// This is used for operation to decline a recovery request.
+//
+// swagger:parameters keyBackupDeclineRecovery
+type keyBackupDeclineRecoveryRequest struct { // nolint: unused,deadcode
+	// RequestID is the ID of the recovery request.
+	//
+	// in: path
+	// required: true
+	RequestID string `json:"request_id"`
+
+	// Reason the recovery is declined.
+	//
+	// in: query
+	Reason string `json:"reason"`
+}
+
+// keyBackupMessageIDResponse model
+//
+// Represents the response of the operations sending a message.
+//
+// swagger:response keyBackupMessageIDResponse
+type keyBackupMessageIDResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MessageID is the ID of the message sent.
+		MessageID string `json:"message_id"`
+	}
+}
+
+// keyBackupBackupResponse model
+//
+// Represents the Backup response message.
+//
+// swagger:response keyBackupBackupResponse
+type keyBackupBackupResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// BackupID is the ID of the backup sent.
+		BackupID string `json:"backup_id"`
+	}
+}
+
+// keyBackupRecoveryRequestResponse model
+//
+// Represents the GetRecoveryRequest response message.
+//
+// swagger:response keyBackupRecoveryRequestResponse
+type keyBackupRecoveryRequestResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// RecoveryRequest is the recovery request received, not handled yet.
+		RecoveryRequest *protocol.RecoveryRequest `json:"recovery_request"`
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation key backup.
+const (
+	OperationID        = "/key-backup"
+	RequestKey         = OperationID + "/request-key"
+	Backup             = OperationID + "/backup"
+	RequestRecovery    = OperationID + "/request-recovery"
+	GetRecoveryRequest = OperationID + "/recovery/{request_id}"
+	AcceptRecovery     = GetRecoveryRequest + "/accept"
+	DeclineRecovery    = GetRecoveryRequest + "/decline"
+)
+
+// Operation is controller REST service controller for the key backup protocol.
+type Operation struct {
+	command  *keybackup.Command
+	handlers []rest.Handler
+}
+
+// New returns new key backup rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := keybackup.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("key backup command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(RequestKey, http.MethodPost, c.RequestKey),
+		cmdutil.NewHTTPHandler(Backup, http.MethodPost, c.Backup),
+		cmdutil.NewHTTPHandler(RequestRecovery, http.MethodPost, c.RequestRecovery),
+		cmdutil.NewHTTPHandler(GetRecoveryRequest, http.MethodGet, c.GetRecoveryRequest),
+		cmdutil.NewHTTPHandler(AcceptRecovery, http.MethodPost, c.AcceptRecovery),
+		cmdutil.NewHTTPHandler(DeclineRecovery, http.MethodPost, c.DeclineRecovery),
+	}
+}
+
+// RequestKey swagger:route POST /key-backup/request-key key-backup keyBackupRequestKey
+//
+// Asks the custodian of the connection for its backup public key.
+//
+// Responses:
+//    default: genericError
+//        200: keyBackupMessageIDResponse
+func (c *Operation) RequestKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.RequestKey, rw, req.Body)
+}
+
+// Backup swagger:route POST /key-backup/backup key-backup keyBackupBackup
+//
+// Backs up keys to the custodian of the connection, encrypted to its backup public key.
+//
+// Responses:
+//    default: genericError
+//        200: keyBackupBackupResponse
+func (c *Operation) Backup(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Backup, rw, req.Body)
+}
+
+// RequestRecovery swagger:route POST /key-backup/request-recovery key-backup keyBackupRequestRecovery
+//
+// Asks the custodian of the connection to recover the keys of a backup.
+//
+// Responses:
+//    default: genericError
+//        200: keyBackupMessageIDResponse
+func (c *Operation) RequestRecovery(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.RequestRecovery, rw, req.Body)
+}
+
+// GetRecoveryRequest swagger:route GET /key-backup/recovery/{request_id} key-backup keyBackupGetRecoveryRequest
+//
+// Returns the recovery request received, if not handled yet.
+//
+// Responses:
+//    default: genericError
+//        200: keyBackupRecoveryRequestResponse
+func (c *Operation) GetRecoveryRequest(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"request_id":%q}`, mux.Vars(req)["request_id"])
+	rest.Execute(c.command.GetRecoveryRequest, rw, bytes.NewBufferString(payload))
+}
+
+// AcceptRecovery swagger:route POST /key-backup/recovery/{request_id}/accept key-backup keyBackupAcceptRecovery
+//
+// Sends the keys of the backup to the requester of the recovery.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) AcceptRecovery(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"request_id":%q}`, mux.Vars(req)["request_id"])
+	rest.Execute(c.command.AcceptRecovery, rw, bytes.NewBufferString(payload))
+}
+
+// DeclineRecovery swagger:route POST /key-backup/recovery/{request_id}/decline key-backup keyBackupDeclineRecovery
+//
+// Declines the recovery request.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) DeclineRecovery(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"request_id":%q,"reason":%q}`, mux.Vars(req)["request_id"],
+		req.URL.Query().Get("reason"))
+	rest.Execute(c.command.DeclineRecovery, rw, bytes.NewBufferString(payload))
+}
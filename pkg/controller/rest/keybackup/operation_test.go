@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 6)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key backup command")
+	})
+}
+
+func TestOperation(t *testing.T) {
+	op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	t.Run("request key", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, RequestKey),
+			bytes.NewBufferString(`{"connection_id":"conn-1"}`), RequestKey)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+	})
+
+	t.Run("backup without custodian key", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, Backup),
+			bytes.NewBufferString(`{"connection_id":"conn-1","key_ids":["kid"],"consent":{"statement":"I agree"}}`),
+			Backup)
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("recovery request not found", func(t *testing.T) {
+		for _, path := range []string{GetRecoveryRequest, AcceptRecovery, DeclineRecovery} {
+			_, code := sendRequestToHandler(t, handlerLookup(t, op, path), nil,
+				strings.Replace(path, "{request_id}", "request-1", 1)+"?reason=unknown")
+			require.Equal(t, http.StatusInternalServerError, code)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, RequestRecovery),
+			bytes.NewBufferString(`{"connection_id":"conn-1"}`), RequestRecovery)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, &mockdispatcher.MockOutbound{},
+		func(prov *mockprovider.Provider) (interface{}, error) {
+			return keybackup.New(prov)
+		})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
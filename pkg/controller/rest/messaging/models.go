@@ -48,6 +48,18 @@ type sendNewMessageRequest struct { // nolint: unused,deadcode
 	Params messaging.SendNewMessageArgs
 }
 
+// sendPlainMessageRequest model
+//
+// This is used for operation to send new DIDComm V2 message
+//
+// swagger:parameters sendPlainMessage
+type sendPlainMessageRequest struct { // nolint: unused,deadcode
+	// Params for sending new DIDComm V2 message
+	//
+	// in: body
+	Params messaging.SendPlainMessageArgs
+}
+
 // SendReplyMessageRequest model
 //
 // This is used for operation to send reply to message
@@ -33,6 +33,7 @@ const (
 	MsgServiceList        = MsgServiceOperationID + "/services"
 	SendNewMsg            = MsgServiceOperationID + "/send"
 	SendReplyMsg          = MsgServiceOperationID + "/reply"
+	SendPlainMsg          = MsgServiceOperationID + "/send-plain"
 )
 
 // provider contains dependencies for the common controller operations
@@ -78,6 +79,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(MsgServiceList, http.MethodGet, o.Services),
 		cmdutil.NewHTTPHandler(SendNewMsg, http.MethodPost, o.Send),
 		cmdutil.NewHTTPHandler(SendReplyMsg, http.MethodPost, o.Reply),
+		cmdutil.NewHTTPHandler(SendPlainMsg, http.MethodPost, o.SendPlain),
 		cmdutil.NewHTTPHandler(RegisterHTTPOverDIDCommService, http.MethodPost, o.RegisterHTTPService),
 	}
 }
@@ -135,6 +137,17 @@ func (o *Operation) Reply(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Reply, rw, req.Body)
 }
 
+// SendPlain swagger:route POST /message/send-plain message sendPlainMessage
+//
+// sends new DIDComm V2 message of the given type, body and attachments to destination provided
+//
+// Responses:
+//    default: genericError
+//    200: sendMessageResponse
+func (o *Operation) SendPlain(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SendPlain, rw, req.Body)
+}
+
 // RegisterHTTPService swagger:route POST /http-over-didcomm/register http-over-didcomm registerHttpMsgSvc
 //
 // registers new http over didcomm service to message handler registrar
@@ -43,6 +43,7 @@ const (
 	errMsgSvcNameRequired            = "service name is required"
 	errMsgInvalidAcceptanceCrit      = "invalid acceptance criteria"
 	errMsgBodyEmpty                  = "empty message body"
+	errMsgTypeEmpty                  = "empty message type"
 	errMsgDestinationMissing         = "missing message destination"
 	errMsgDestSvcEndpointMissing     = "missing service endpoint in message destination"
 	errMsgDestSvcEndpointKeysMissing = "missing service endpoint recipient/routing keys in message destination"
@@ -631,6 +632,74 @@ func TestOperation_Send(t *testing.T) {
 	})
 }
 
+func TestOperation_SendPlain(t *testing.T) {
+	t.Run("Test request param validation", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			requestJSON string
+			errorMsg    string
+		}{
+			{
+				name:        "missing all params",
+				requestJSON: `{}`,
+				errorMsg:    errMsgTypeEmpty,
+			},
+			{
+				name:        "missing destinations",
+				requestJSON: `{"type": "https://example.com/custom/1.0/ping"}`,
+				errorMsg:    errMsgDestinationMissing,
+			},
+			{
+				name:        "invalid input",
+				requestJSON: `----`,
+				errorMsg:    "invalid character",
+			},
+		}
+
+		t.Parallel()
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				svc, err := New(&protocol.MockProvider{}, msghandler.NewMockMsgServiceProvider(),
+					webhook.NewMockWebhookNotifier())
+				require.NoError(t, err)
+				require.NotNil(t, svc)
+
+				handler := lookupCreatePublicDIDHandler(t, svc, SendPlainMsg)
+				buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(tc.requestJSON), handler.Path())
+				require.NoError(t, err)
+				require.NotEmpty(t, buf)
+				require.Equal(t, http.StatusBadRequest, code)
+				verifyError(t, messaging.InvalidRequestErrorCode, tc.errorMsg, buf.Bytes())
+			})
+		}
+	})
+
+	t.Run("Test send plain message", func(t *testing.T) {
+		mockStore := &storage.MockStore{Store: make(map[string]storage.DBEntry)}
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "sample-conn-ID-001",
+			State:        "completed", MyDID: "mydid", TheirDID: "theirDID-001",
+		})
+		require.NoError(t, err)
+		require.NoError(t, mockStore.Put("conn_sample-conn-ID-001", connBytes, spi.Tag{Name: "conn_"}))
+
+		svc, err := New(&protocol.MockProvider{StoreProvider: storage.NewCustomMockStoreProvider(mockStore)},
+			msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupCreatePublicDIDHandler(t, svc, SendPlainMsg)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{
+			"type": "https://example.com/custom/1.0/ping", "body": {"text":"sample"},
+			"connection_ID": "sample-conn-ID-001"}`), handler.Path())
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "{}")
+	})
+}
+
 func TestOperation_Reply(t *testing.T) {
 	t.Run("Test request param validation", func(t *testing.T) {
 		tests := []struct {
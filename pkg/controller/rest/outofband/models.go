@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package outofband
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 )
@@ -28,6 +30,10 @@ type outofbandCreateInvitationRequest struct { // nolint: unused,deadcode
 		// Attachments is intended to provide the possibility to include files, links or even JSON payload to the message.
 		// required: true
 		Attachments []*decorator.Attachment `json:"attachments"`
+		// ExpiresTime after which the invitation is rejected, it doesn't expire if not set.
+		ExpiresTime time.Time `json:"expires_time,omitempty"`
+		// MaxAccepts is the number of parties which can accept the invitation, unlimited if zero.
+		MaxAccepts int `json:"max_accepts,omitempty"`
 	}
 }
 
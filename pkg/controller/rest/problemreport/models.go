@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/problemreport"
+)
+
+// queryProblemReportsReq model
+//
+// This is used for querying the problem reports sent and received by the agent.
+//
+// swagger:parameters queryProblemReports
+type queryProblemReportsReq struct { // nolint: unused,deadcode
+	// ConnectionID of the problem reports, all the problem reports are returned when empty.
+	//
+	// in: query
+	ConnectionID string `json:"connectionID"`
+
+	// From is the start of the time range of the problem reports (RFC 3339), inclusive.
+	//
+	// in: query
+	From string `json:"from"`
+
+	// To is the end of the time range of the problem reports (RFC 3339), exclusive.
+	//
+	// in: query
+	To string `json:"to"`
+}
+
+// queryProblemReportsRes model
+//
+// This is used for returning the problem reports sent and received by the agent.
+//
+// swagger:response queryProblemReportsRes
+type queryProblemReportsRes struct { // nolint: unused,deadcode
+
+	// in: body
+	problemreport.QueryResponse
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdproblemreport "github.com/hyperledger/aries-framework-go/pkg/controller/command/problemreport"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// constants for the problem report operations.
+const (
+	ProblemReportOperationID = "/problem-reports"
+	QueryPath                = ProblemReportOperationID
+)
+
+// provider contains dependencies for the problem report command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type problemReportCommand interface {
+	Query(rw io.Writer, req io.Reader) command.Error
+}
+
+// Operation contains the problem report history operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  problemReportCommand
+}
+
+// New returns new problem report operations rest client instance.
+func New(p provider) (*Operation, error) {
+	cmd, err := cmdproblemreport.New(p)
+	if err != nil {
+		return nil, fmt.Errorf("create problem report command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(QueryPath, http.MethodGet, o.Query),
+	}
+}
+
+// Query swagger:route GET /problem-reports problem-report queryProblemReports
+//
+// Queries the problem reports sent and received by the agent, optionally filtered by connection and time range.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: queryProblemReportsRes
+func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	args := make(map[string]string)
+
+	for k, v := range req.URL.Query() {
+		if len(v) > 0 && v[0] != "" {
+			args[k] = v[0]
+		}
+	}
+
+	reqBytes, err := json.Marshal(args)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdproblemreport.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.Query, rw, bytes.NewReader(reqBytes))
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdproblemreport "github.com/hyperledger/aries-framework-go/pkg/controller/command/problemreport"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, op)
+		require.Len(t, op.GetRESTHandlers(), 1)
+	})
+
+	t.Run("test new operation - error", func(t *testing.T) {
+		p := newProvider()
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestOperation_Query(t *testing.T) {
+	t.Run("test query - success", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, QueryPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, QueryPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var res cmdproblemreport.QueryResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		require.Empty(t, res.ProblemReports)
+	})
+
+	t.Run("test query - query parameters", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmd := &mockCommand{}
+		op.command = cmd
+
+		handler := lookupHandler(t, op, QueryPath, http.MethodGet)
+		_, code, err := sendRequestToHandler(handler, nil,
+			QueryPath+"?connectionID=conn-1&from=2022-01-01T00:00:00Z&to=")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"connectionID":"conn-1","from":"2022-01-01T00:00:00Z"}`, string(cmd.request))
+	})
+
+	t.Run("test query - invalid time", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, op, QueryPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, QueryPath+"?from=yesterday")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, cmdproblemreport.InvalidRequestErrorCode, "request decode", buf.Bytes())
+	})
+
+	t.Run("test query - error", func(t *testing.T) {
+		op, err := New(newProvider())
+		require.NoError(t, err)
+
+		op.command = &mockCommand{err: command.NewExecuteError(cmdproblemreport.QueryError, fmt.Errorf("query error"))}
+
+		handler := lookupHandler(t, op, QueryPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, QueryPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, cmdproblemreport.QueryError, "query error", buf.Bytes())
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	t.Helper()
+
+	// Parser generic error response
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal(data, &errResponse)
+	require.NoError(t, err)
+
+	// verify response
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.Contains(t, errResponse.Message, expectedMsg)
+}
+
+type mockCommand struct {
+	request []byte
+	err     command.Error
+}
+
+func (m *mockCommand) Query(_ io.Writer, req io.Reader) command.Error {
+	m.request, _ = ioutil.ReadAll(req) //nolint:errcheck
+
+	return m.err
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/profile"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+)
+
+// profileSetProfileRequest model
+//
+// This is used for operation to set the profile of the agent.
+//
+// swagger:parameters profileSetProfile
+type profileSetProfileRequest struct { // nolint: unused,deadcode
+	// in: body
+	Params profile.SetProfileArgs
+}
+
+// profileConnectionID model
+//
+// This is used for operations on the profile of the other party of a connection.
+//
+// swagger:parameters profileTheirProfile profileRequestProfile
+type profileConnectionID struct { // nolint: unused,deadcode
+	// ID is the ID of the connection.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// profileSendProfileRequest model
+//
+// This is used for operation to send the profile of the agent to the other party of a connection.
+//
+// swagger:parameters profileSendProfile
+type profileSendProfileRequest struct { // nolint: unused,deadcode
+	// ID is the ID of the connection.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// SendBackYours asks the other party to send its profile in response.
+	//
+	// in: query
+	SendBackYours bool `json:"send_back_yours"`
+}
+
+// profileProfileResponse model
+//
+// Represents the GetProfile and TheirProfile response messages.
+//
+// swagger:response profileProfileResponse
+type profileProfileResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Profile *protocol.Profile `json:"profile,omitempty"`
+	}
+}
+
+// profileMessageResponse model
+//
+// Represents the SendProfile and RequestProfile response messages.
+//
+// swagger:response profileMessageResponse
+type profileMessageResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MessageID is the ID of the message sent.
+		MessageID string `json:"message_id"`
+	}
+}
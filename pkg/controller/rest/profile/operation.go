@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation profile.
+const (
+	ProfilePath    = "/profile"
+	OperationID    = "/connections/{id}"
+	TheirProfile   = OperationID + "/profile"
+	SendProfile    = TheirProfile + "/send"
+	RequestProfile = TheirProfile + "/request"
+)
+
+// Operation is controller REST service controller for the profile protocol.
+type Operation struct {
+	command  *profile.Command
+	handlers []rest.Handler
+}
+
+// New returns new profile rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := profile.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("profile command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(ProfilePath, http.MethodPost, c.SetProfile),
+		cmdutil.NewHTTPHandler(ProfilePath, http.MethodGet, c.GetProfile),
+		cmdutil.NewHTTPHandler(TheirProfile, http.MethodGet, c.TheirProfile),
+		cmdutil.NewHTTPHandler(SendProfile, http.MethodPost, c.SendProfile),
+		cmdutil.NewHTTPHandler(RequestProfile, http.MethodPost, c.RequestProfile),
+	}
+}
+
+// SetProfile swagger:route POST /profile profile profileSetProfile
+//
+// Saves the profile of the agent, optionally sent to all the completed connections.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) SetProfile(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SetProfile, rw, req.Body)
+}
+
+// GetProfile swagger:route GET /profile profile profileGetProfile
+//
+// Returns the profile of the agent.
+//
+// Responses:
+//    default: genericError
+//        200: profileProfileResponse
+func (c *Operation) GetProfile(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.GetProfile, rw, req.Body)
+}
+
+// TheirProfile swagger:route GET /connections/{id}/profile profile profileTheirProfile
+//
+// Returns the profile received from the other party of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: profileProfileResponse
+func (c *Operation) TheirProfile(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q}`, mux.Vars(req)["id"])
+	rest.Execute(c.command.TheirProfile, rw, bytes.NewBufferString(payload))
+}
+
+// SendProfile swagger:route POST /connections/{id}/profile/send profile profileSendProfile
+//
+// Sends the profile of the agent to the other party of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: profileMessageResponse
+func (c *Operation) SendProfile(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q,"send_back_yours":%t}`, mux.Vars(req)["id"],
+		req.URL.Query().Get("send_back_yours") == "true")
+	rest.Execute(c.command.SendProfile, rw, bytes.NewBufferString(payload))
+}
+
+// RequestProfile swagger:route POST /connections/{id}/profile/request profile profileRequestProfile
+//
+// Requests the profile of the other party of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: profileMessageResponse
+func (c *Operation) RequestProfile(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q}`, mux.Vars(req)["id"])
+	rest.Execute(c.command.RequestProfile, rw, bytes.NewBufferString(payload))
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/connectionutil"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 5)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")},
+			mockwebhook.NewMockWebhookNotifier())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "profile command")
+	})
+}
+
+func TestOperation(t *testing.T) {
+	op, err := New(newProvider(t), mockwebhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	pathOf := func(path, id string) string {
+		return strings.Replace(path, "{id}", id, 1)
+	}
+
+	t.Run("set and get profile", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, ProfilePath, http.MethodPost),
+			bytes.NewBufferString(`{"profile":{"display_name":"Alice"},"share":true}`), ProfilePath)
+		require.Equal(t, http.StatusOK, code)
+
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, ProfilePath, http.MethodGet), nil, ProfilePath)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"display_name":"Alice"`)
+
+		_, code = sendRequestToHandler(t, handlerLookup(t, op, ProfilePath, http.MethodPost),
+			bytes.NewBufferString(`{"profile":{"display_picture":"picture.png"}}`), ProfilePath)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("send and request profile", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, SendProfile, http.MethodPost), nil,
+			pathOf(SendProfile, connectionutil.ConnectionID)+"?send_back_yours=true")
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+
+		buf, code = sendRequestToHandler(t, handlerLookup(t, op, RequestProfile, http.MethodPost), nil,
+			pathOf(RequestProfile, connectionutil.ConnectionID))
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "message_id")
+	})
+
+	t.Run("their profile", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, TheirProfile, http.MethodGet), nil,
+			pathOf(TheirProfile, connectionutil.ConnectionID))
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "{}", strings.TrimSpace(buf.String()))
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		for path, method := range map[string]string{
+			TheirProfile:   http.MethodGet,
+			SendProfile:    http.MethodPost,
+			RequestProfile: http.MethodPost,
+		} {
+			_, code := sendRequestToHandler(t, handlerLookup(t, op, path, method), nil, pathOf(path, "unknown"))
+			require.Equal(t, http.StatusInternalServerError, code)
+		}
+	})
+}
+
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	return connectionutil.NewProvider(t, &mockdispatcher.MockOutbound{},
+		func(prov *mockprovider.Provider) (interface{}, error) {
+			return profile.New(prov)
+		})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup, method string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
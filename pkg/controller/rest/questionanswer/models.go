@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+)
+
+// questionAnswerSendQuestionRequest model
+//
+// This is used for operation to ask a question.
+//
+// swagger:parameters questionAnswerSendQuestion
+type questionAnswerSendQuestionRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection the question is sent to.
+		ConnectionID string `json:"connection_id"`
+		// Question is the question sent.
+		Question *protocol.Question `json:"question"`
+	}
+}
+
+// questionAnswerSendAnswerRequest model
+//
+// This is used for operation to answer a question.
+//
+// swagger:parameters questionAnswerSendAnswer
+type questionAnswerSendAnswerRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ConnectionID is the ID of the connection the question was received from.
+		ConnectionID string `json:"connection_id"`
+		// QuestionID is the ID of the question answered.
+		QuestionID string `json:"question_id"`
+		// Response is one of the valid responses of the question.
+		Response string `json:"response"`
+	}
+}
+
+// questionAnswerMessageIDResponse model
+//
+// Represents the response of the operations sending a message.
+//
+// swagger:response questionAnswerMessageIDResponse
+type questionAnswerMessageIDResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MessageID is the ID of the message sent.
+		MessageID string `json:"message_id"`
+	}
+}
+
+// questionAnswerGetQuestionRequest model
+//
+// This is used for operation to get a question received.
+//
+// swagger:parameters questionAnswerGetQuestion
+type questionAnswerGetQuestionRequest struct { // nolint: unused,deadcode
+	// QuestionID is the ID of the question.
+	//
+	// in: path
+	// required: true
+	QuestionID string `json:"question_id"`
+}
+
+// questionAnswerQuestionResponse model
+//
+// Represents the GetQuestion response message.
+//
+// swagger:response questionAnswerQuestionResponse
+type questionAnswerQuestionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// Question is the question received, not answered yet.
+		Question *protocol.Question `json:"question"`
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation question answer.
+const (
+	OperationID  = "/question-answer"
+	SendQuestion = OperationID + "/send-question"
+	SendAnswer   = OperationID + "/send-answer"
+	GetQuestion  = OperationID + "/questions/{question_id}"
+)
+
+// Operation is controller REST service controller for the question answer protocol.
+type Operation struct {
+	command  *questionanswer.Command
+	handlers []rest.Handler
+}
+
+// New returns new question answer rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := questionanswer.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("question answer command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(SendQuestion, http.MethodPost, c.SendQuestion),
+		cmdutil.NewHTTPHandler(SendAnswer, http.MethodPost, c.SendAnswer),
+		cmdutil.NewHTTPHandler(GetQuestion, http.MethodGet, c.GetQuestion),
+	}
+}
+
+// SendQuestion swagger:route POST /question-answer/send-question question-answer questionAnswerSendQuestion
+//
+// Asks the connection a question.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerMessageIDResponse
+func (c *Operation) SendQuestion(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendQuestion, rw, req.Body)
+}
+
+// SendAnswer swagger:route POST /question-answer/send-answer question-answer questionAnswerSendAnswer
+//
+// Answers the question received from the connection.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerMessageIDResponse
+func (c *Operation) SendAnswer(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendAnswer, rw, req.Body)
+}
+
+// GetQuestion swagger:route GET /question-answer/questions/{question_id} question-answer questionAnswerGetQuestion
+//
+// Returns the question received, if not answered yet.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerQuestionResponse
+func (c *Operation) GetQuestion(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"question_id":%q}`, mux.Vars(req)["question_id"])
+	rest.Execute(c.command.GetQuestion, rw, bytes.NewBufferString(payload))
+}
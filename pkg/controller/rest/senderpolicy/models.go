@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
+)
+
+// addSenderPolicyRuleRequest model
+//
+// This is used for operation to add a sender policy rule.
+//
+// swagger:parameters addSenderPolicyRule
+type addSenderPolicyRuleRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ID of the rule, generated when empty. The rule with the same ID is replaced.
+		ID string `json:"id,omitempty"`
+		// Sender is the pattern of the sender DIDs, where * matches any characters (e.g. did:key:*).
+		Sender string `json:"sender"`
+		// MessageType is the pattern of the message types the rule applies to, all message types when empty.
+		MessageType string `json:"message_type,omitempty"`
+		// Action taken on the matching messages: allow or deny.
+		Action string `json:"action"`
+	}
+}
+
+// removeSenderPolicyRuleRequest model
+//
+// This is used for operation to remove a sender policy rule.
+//
+// swagger:parameters removeSenderPolicyRule
+type removeSenderPolicyRuleRequest struct { // nolint: unused,deadcode
+	// ID of the rule.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// senderPolicyRuleResponse model
+//
+// Represents the sender policy rule added.
+//
+// swagger:response senderPolicyRuleResponse
+type senderPolicyRuleResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Rule senderpolicy.Rule `json:"rule"`
+	}
+}
+
+// senderPolicyRulesResponse model
+//
+// Represents the sender policy rules.
+//
+// swagger:response senderPolicyRulesResponse
+type senderPolicyRulesResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Rules []senderpolicy.Rule `json:"rules"`
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/senderpolicy"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the sender policy operations.
+const (
+	OperationID    = "/sender-policy/rules"
+	AddRulePath    = OperationID
+	RulesPath      = OperationID
+	RemoveRulePath = OperationID + "/{id}"
+)
+
+// Operation contains the sender policy operations provided by controller REST API.
+type Operation struct {
+	command  *senderpolicy.Command
+	handlers []rest.Handler
+}
+
+// New returns new sender policy operations rest client instance.
+func New(policy senderpolicy.Policy) *Operation {
+	o := &Operation{command: senderpolicy.New(policy)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(AddRulePath, http.MethodPost, o.AddRule),
+		cmdutil.NewHTTPHandler(RulesPath, http.MethodGet, o.Rules),
+		cmdutil.NewHTTPHandler(RemoveRulePath, http.MethodDelete, o.RemoveRule),
+	}
+}
+
+// AddRule swagger:route POST /sender-policy/rules senderpolicy addSenderPolicyRule
+//
+// Adds a rule allowing or denying the inbound messages of the senders matching its DID pattern.
+//
+// Responses:
+//    default: genericError
+//        200: senderPolicyRuleResponse
+func (o *Operation) AddRule(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AddRule, rw, req.Body)
+}
+
+// Rules swagger:route GET /sender-policy/rules senderpolicy senderPolicyRules
+//
+// Returns the rules of the sender policy.
+//
+// Responses:
+//    default: genericError
+//        200: senderPolicyRulesResponse
+func (o *Operation) Rules(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Rules, rw, req.Body)
+}
+
+// RemoveRule swagger:route DELETE /sender-policy/rules/{id} senderpolicy removeSenderPolicyRule
+//
+// Removes a rule of the sender policy.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RemoveRule(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"id":%q}`, mux.Vars(req)["id"])
+	rest.Execute(o.command.RemoveRule, rw, bytes.NewBufferString(payload))
+}
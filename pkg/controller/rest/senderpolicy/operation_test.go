@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestOperation(t *testing.T) {
+	policy, err := senderpolicy.New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	op := New(policy)
+	require.Len(t, op.GetRESTHandlers(), 3)
+
+	t.Run("add rule", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, AddRulePath, http.MethodPost),
+			bytes.NewBufferString(`{"id":"no-did-key","sender":"did:key:*","action":"deny"}`), AddRulePath)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"id":"no-did-key"`)
+	})
+
+	t.Run("list rules", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, RulesPath, http.MethodGet), nil, RulesPath)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"sender":"did:key:*"`)
+	})
+
+	t.Run("remove rule", func(t *testing.T) {
+		path := strings.Replace(RemoveRulePath, "{id}", "no-did-key", 1)
+
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, RemoveRulePath, http.MethodDelete), nil, path)
+		require.Equal(t, http.StatusOK, code)
+
+		_, code = sendRequestToHandler(t, handlerLookup(t, op, RemoveRulePath, http.MethodDelete), nil, path)
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, AddRulePath, http.MethodPost),
+			bytes.NewBufferString(`{"sender":"did:key:*"}`), AddRulePath)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func handlerLookup(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// trustPingPingRequest model
+//
+// This is used for operation to ping the other party of a connection.
+//
+// swagger:parameters trustPingPing
+type trustPingPingRequest struct { // nolint: unused,deadcode
+	// ID is the ID of the connection.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Comment of the ping.
+	//
+	// in: query
+	Comment string `json:"comment"`
+}
+
+// trustPingConnectionID model
+//
+// This is used for operations on the health check of a connection.
+//
+// swagger:parameters trustPingHealthCheck trustPingEnableHealthCheck trustPingDisableHealthCheck
+type trustPingConnectionID struct { // nolint: unused,deadcode
+	// ID is the ID of the connection.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// trustPingPingResponse model
+//
+// Represents the Ping response message.
+//
+// swagger:response trustPingPingResponse
+type trustPingPingResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MessageID is the ID of the ping sent.
+		MessageID string `json:"message_id"`
+	}
+}
+
+// trustPingHealthCheckResponse model
+//
+// Represents the HealthCheck response message.
+//
+// swagger:response trustPingHealthCheckResponse
+type trustPingHealthCheckResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		*protocol.HealthStatus
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for operation trust ping.
+const (
+	OperationID        = "/connections/{id}"
+	Ping               = OperationID + "/trust-ping"
+	HealthCheck        = OperationID + "/health-check"
+	EnableHealthCheck  = HealthCheck + "/enable"
+	DisableHealthCheck = HealthCheck + "/disable"
+)
+
+// Operation is controller REST service controller for the trust ping protocol.
+type Operation struct {
+	command  *trustping.Command
+	handlers []rest.Handler
+}
+
+// New returns new trust ping rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := trustping.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("trust ping command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Ping, http.MethodPost, c.Ping),
+		cmdutil.NewHTTPHandler(HealthCheck, http.MethodGet, c.HealthCheck),
+		cmdutil.NewHTTPHandler(EnableHealthCheck, http.MethodPost, c.EnableHealthCheck),
+		cmdutil.NewHTTPHandler(DisableHealthCheck, http.MethodPost, c.DisableHealthCheck),
+	}
+}
+
+// Ping swagger:route POST /connections/{id}/trust-ping trust-ping trustPingPing
+//
+// Sends a ping requesting a response to the other party of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: trustPingPingResponse
+func (c *Operation) Ping(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q,"comment":%q}`, mux.Vars(req)["id"],
+		req.URL.Query().Get("comment"))
+	rest.Execute(c.command.Ping, rw, bytes.NewBufferString(payload))
+}
+
+// HealthCheck swagger:route GET /connections/{id}/health-check trust-ping trustPingHealthCheck
+//
+// Returns the health check status of the connection.
+//
+// Responses:
+//    default: genericError
+//        200: trustPingHealthCheckResponse
+func (c *Operation) HealthCheck(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q}`, mux.Vars(req)["id"])
+	rest.Execute(c.command.HealthCheck, rw, bytes.NewBufferString(payload))
+}
+
+// EnableHealthCheck swagger:route POST /connections/{id}/health-check/enable trust-ping trustPingEnableHealthCheck
+//
+// Enables the health check of the connection.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) EnableHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q,"enabled":true}`, mux.Vars(req)["id"])
+	rest.Execute(c.command.SetHealthCheck, rw, bytes.NewBufferString(payload))
+}
+
+// DisableHealthCheck swagger:route POST /connections/{id}/health-check/disable trust-ping trustPingDisableHealthCheck
+//
+// Disables the health check of the connection.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) DisableHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"connection_id":%q,"enabled":false}`, mux.Vars(req)["id"])
+	rest.Execute(c.command.SetHealthCheck, rw, bytes.NewBufferString(payload))
+}
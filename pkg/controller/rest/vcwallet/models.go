@@ -312,6 +312,56 @@ type presentProofRequest struct { // nolint: unused,deadcode
 	Params *vcwallet.PresentProofRequest
 }
 
+// exportRequest is request model for exporting wallet.
+//
+// swagger:parameters exportReq
+type exportRequest struct { // nolint: unused,deadcode
+	// Params for exporting wallet.
+	//
+	// in: body
+	Params *vcwallet.ExportRequest
+}
+
+// exportResponse is response model from wallet export operation.
+//
+// swagger:response exportRes
+type exportResponse struct {
+	// exported wallet, encrypted by password.
+	//
+	// in: body
+	Response *vcwallet.ExportResponse `json:"response"`
+}
+
+// importRequest is request model for importing wallet.
+//
+// swagger:parameters importReq
+type importRequest struct { // nolint: unused,deadcode
+	// Params for importing wallet.
+	//
+	// in: body
+	Params *vcwallet.ImportRequest
+}
+
+// capabilitiesRequest is request model for getting the capabilities of the wallet.
+//
+// swagger:parameters capabilitiesReq
+type capabilitiesRequest struct { // nolint: unused,deadcode
+	// Params for getting the capabilities of the wallet.
+	//
+	// in: body
+	Params *vcwallet.CapabilitiesRequest
+}
+
+// capabilitiesResponse is response model from wallet capabilities operation.
+//
+// swagger:response capabilitiesRes
+type capabilitiesResponse struct {
+	// whether each capability template is satisfiable, and via which credentials.
+	//
+	// in: body
+	Response *vcwallet.CapabilitiesResponse `json:"response"`
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
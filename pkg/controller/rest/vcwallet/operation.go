@@ -46,6 +46,9 @@ const (
 	ConnectPath             = OperationID + "/connect"
 	ProposePresentationPath = OperationID + "/propose-presentation"
 	PresentProofPath        = OperationID + "/present-proof"
+	ExportPath              = OperationID + "/export"
+	ImportPath              = OperationID + "/import"
+	CapabilitiesPath        = OperationID + "/capabilities"
 )
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -114,6 +117,9 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ConnectPath, http.MethodPost, o.Connect),
 		cmdutil.NewHTTPHandler(ProposePresentationPath, http.MethodPost, o.ProposePresentation),
 		cmdutil.NewHTTPHandler(PresentProofPath, http.MethodPost, o.PresentProof),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportPath, http.MethodPost, o.Import),
+		cmdutil.NewHTTPHandler(CapabilitiesPath, http.MethodPost, o.Capabilities),
 	}
 }
 
@@ -384,6 +390,39 @@ func (o *Operation) PresentProof(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.PresentProof, rw, req.Body)
 }
 
+// Export swagger:route POST /vcwallet/export vcwallet exportReq
+//
+// exports all wallet contents and keys into an archive encrypted by given password.
+//
+// Responses:
+//    default: genericError
+//        200: exportRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importReq
+//
+// imports wallet contents and keys from an archive produced by export operation.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
+// Capabilities swagger:route POST /vcwallet/capabilities vcwallet capabilitiesReq
+//
+// reports which capability templates are satisfiable from the credentials of the wallet and via which credentials.
+//
+// Responses:
+//    default: genericError
+//        200: capabilitiesRes
+func (o *Operation) Capabilities(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Capabilities, rw, req.Body)
+}
+
 // getIDFromRequest returns ID from request.
 func getIDFromRequest(rw http.ResponseWriter, req *http.Request) (string, bool) {
 	id := mux.Vars(req)["id"]
@@ -395,3 +434,4 @@ func getIDFromRequest(rw http.ResponseWriter, req *http.Request) (string, bool)
 
 	return id, true
 }
+
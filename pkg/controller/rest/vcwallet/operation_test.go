@@ -251,7 +251,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &vcwallet.Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetRESTHandlers(), 18)
+		require.Len(t, cmd.GetRESTHandlers(), 21)
 	})
 }
 
@@ -1653,6 +1653,146 @@ func TestOperation_PresentProof(t *testing.T) {
 	})
 }
 
+func TestOperation_ExportImport(t *testing.T) {
+	const (
+		sampleUser1    = "sample-user-export-01"
+		sampleUser2    = "sample-user-import-02"
+		samplePassword = "sample-export-password"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	var exported json.RawMessage
+
+	t.Run("wallet export success", func(t *testing.T) {
+		request := &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+			Password:   samplePassword,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var response vcwallet.ExportResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&response))
+		require.NotEmpty(t, response.Contents)
+
+		exported = response.Contents
+	})
+
+	t.Run("wallet import success", func(t *testing.T) {
+		targetctx := newMockProvider(t)
+
+		createSampleUserProfile(t, targetctx, &vcwallet.CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		token2, lock2 := unlockWallet(t, targetctx, &vcwallet.UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer lock2()
+
+		request := &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser2, Auth: token2},
+			Password:   samplePassword,
+			Contents:   exported,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(targetctx, &vcwallet.Config{})
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("wallet import failure", func(t *testing.T) {
+		request := &vcwallet.ImportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+			Password:   samplePassword + "invalid",
+			Contents:   exported,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid password")
+	})
+}
+
+func TestOperation_Capabilities(t *testing.T) {
+	const sampleUser1 = "sample-user-capabilities-01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	t.Run("wallet capabilities success", func(t *testing.T) {
+		request := &vcwallet.CapabilitiesRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+			Templates:  []*wallet.CapabilityTemplate{wallet.KYCLevelTemplate(1)},
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, CapabilitiesPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Capabilities(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		var response vcwallet.CapabilitiesResponse
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&response))
+		require.Equal(t, []*wallet.Capability{{ID: "kyc-level-1", Name: "KYC level 1"}}, response.Capabilities)
+	})
+
+	t.Run("wallet capabilities failure", func(t *testing.T) {
+		request := &vcwallet.CapabilitiesRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token1},
+			Templates:  []*wallet.CapabilityTemplate{{ID: "empty"}},
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, CapabilitiesPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Capabilities(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "has no input descriptors")
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *vcwallet.CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &vcwallet.Config{})
 	require.NotNil(t, cmd)
@@ -147,6 +147,63 @@ type credentialRecord struct {
 	verifiablestore.Record
 }
 
+// getCredentialsReq model
+//
+// This is used for querying the credential records, all the records being returned without criteria.
+//
+// swagger:parameters getCredentials
+type getCredentialsReq struct { // nolint: unused,deadcode
+	// Issuer is the ID of the issuer of the credentials.
+	//
+	// in: query
+	Issuer string `json:"issuer"`
+
+	// Type is one of the types of the credentials.
+	//
+	// in: query
+	Type string `json:"type"`
+
+	// SubjectID is the ID of the subject of the credentials.
+	//
+	// in: query
+	SubjectID string `json:"subjectId"`
+
+	// Schema is the ID of one of the schemas of the credentials.
+	//
+	// in: query
+	Schema string `json:"schema"`
+
+	// ExpiresAfter limits the results to the credentials expiring after this time (RFC3339) or not expiring.
+	//
+	// in: query
+	ExpiresAfter string `json:"expiresAfter"`
+
+	// ExpiresBefore limits the results to the credentials expiring before this time (RFC3339).
+	//
+	// in: query
+	ExpiresBefore string `json:"expiresBefore"`
+
+	// Expired limits the results to the expired credentials.
+	//
+	// in: query
+	Expired bool `json:"expired"`
+
+	// IncludeArchived includes the archived credentials in the results.
+	//
+	// in: query
+	IncludeArchived bool `json:"includeArchived"`
+
+	// Limit is the maximum number of records returned, all the matching records are returned if not set.
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// PageToken is the token of the page of the results to return, as returned with the previous page.
+	//
+	// in: query
+	PageToken string `json:"pageToken"`
+}
+
 // credentialRecordResult model
 //
 // This is used to return credential records.
@@ -155,6 +212,9 @@ type credentialRecord struct {
 type credentialRecordResult struct {
 	// in: body
 	Result []*verifiablestore.Record `json:"result,omitempty"`
+
+	// NextPageToken is the token to query the next page of the credential records, empty on the last page
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 // presentationRecordResult model
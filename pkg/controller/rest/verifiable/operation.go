@@ -9,8 +9,12 @@ package verifiable
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/piprate/json-gold/ld"
@@ -195,13 +199,77 @@ func (o *Operation) GetCredentialByName(rw http.ResponseWriter, req *http.Reques
 
 // GetCredentials swagger:route GET /verifiable/credentials verifiable getCredentials
 //
-// Retrieves the verifiable credentials.
+// Retrieves the verifiable credentials, filtered by issuer, type, subject, schema and expiration, the archived
+// credentials being excluded unless requested.
 //
 // Responses:
 //    default: genericError
 //        200: credentialRecordResult
 func (o *Operation) GetCredentials(rw http.ResponseWriter, req *http.Request) {
-	rest.Execute(o.command.GetCredentials, rw, req.Body)
+	args, err := queryCredentialsArgs(req.URL.Query())
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, verifiable.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	reqBytes, err := json.Marshal(args)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, verifiable.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.GetCredentials, rw, bytes.NewReader(reqBytes))
+}
+
+// queryCredentialsArgs converts the query strings of GetCredentials to the command arguments.
+func queryCredentialsArgs(vals url.Values) (*verifiable.QueryCredentialsArgs, error) {
+	args := &verifiable.QueryCredentialsArgs{}
+	args.Issuer = vals.Get("issuer")
+	args.Type = vals.Get("type")
+	args.SubjectID = vals.Get("subjectId")
+	args.Schema = vals.Get("schema")
+	args.PageToken = vals.Get("pageToken")
+
+	var err error
+
+	if limit := vals.Get("limit"); limit != "" {
+		args.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit : %w", err)
+		}
+	}
+
+	if expiresAfter := vals.Get("expiresAfter"); expiresAfter != "" {
+		args.ExpiresAfter, err = time.Parse(time.RFC3339, expiresAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresAfter : %w", err)
+		}
+	}
+
+	if expiresBefore := vals.Get("expiresBefore"); expiresBefore != "" {
+		args.ExpiresBefore, err = time.Parse(time.RFC3339, expiresBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresBefore : %w", err)
+		}
+	}
+
+	if expired := vals.Get("expired"); expired != "" {
+		args.Expired, err = strconv.ParseBool(expired)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expired : %w", err)
+		}
+	}
+
+	if includeArchived := vals.Get("includeArchived"); includeArchived != "" {
+		args.IncludeArchived, err = strconv.ParseBool(includeArchived)
+		if err != nil {
+			return nil, fmt.Errorf("invalid includeArchived : %w", err)
+		}
+	}
+
+	return args, nil
 }
 
 // SignCredential swagger:route POST /verifiable/signcredential verifiable signCredentialReq
@@ -537,6 +537,39 @@ func TestGetCredentials(t *testing.T) {
 		require.Equal(t, 1, len(response.Result))
 		require.Len(t, response.Result[0].Context, 2)
 		require.Len(t, response.Result[0].Type, 1)
+
+		buf, err = getSuccessResponseFromHandler(handler, nil,
+			GetCredentialsPath+"?issuer=did:example:unknown&limit=1&expiresAfter=2020-01-01T00:00:00Z")
+		require.NoError(t, err)
+
+		response = credentialRecordResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Empty(t, response.Result)
+
+		// the credential expires in 2029
+		buf, err = getSuccessResponseFromHandler(handler, nil, GetCredentialsPath+"?expired=true&includeArchived=true")
+		require.NoError(t, err)
+
+		response = credentialRecordResult{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Empty(t, response.Result)
+	})
+
+	t.Run("test get credentials with invalid query", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, GetCredentialsPath, http.MethodGet)
+
+		for _, query := range []string{"?limit=one", "?expiresAfter=tomorrow", "?expiresBefore=tomorrow",
+			"?expired=maybe", "?includeArchived=maybe", "?pageToken=invalid!"} {
+			buf, code, err := sendRequestToHandler(handler, nil, GetCredentialsPath+query)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, code, query)
+			verifyError(t, verifiable.InvalidRequestErrorCode, "", buf.Bytes())
+		}
 	})
 }
 
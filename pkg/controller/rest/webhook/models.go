@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+)
+
+// addWebhookTargetRequest model
+//
+// This is used for operation to add a webhook target.
+//
+// swagger:parameters addWebhookTarget
+type addWebhookTargetRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// ID of the target, generated when empty. The target with the same ID is replaced.
+		ID string `json:"id,omitempty"`
+		// URL the notifications are posted to.
+		URL string `json:"url"`
+		// Topics of the notifications sent to the target (e.g. issuecredential_states), all topics when empty.
+		Topics []string `json:"topics,omitempty"`
+		// ConnectionIDs of the notifications sent to the target, all notifications when empty.
+		ConnectionIDs []string `json:"connection_ids,omitempty"`
+	}
+}
+
+// removeWebhookTargetRequest model
+//
+// This is used for operation to remove a webhook target.
+//
+// swagger:parameters removeWebhookTarget
+type removeWebhookTargetRequest struct { // nolint: unused,deadcode
+	// ID of the target.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// webhookTargetResponse model
+//
+// Represents the webhook target added.
+//
+// swagger:response webhookTargetResponse
+type webhookTargetResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Target *webnotifier.WebhookTarget `json:"target"`
+	}
+}
+
+// webhookTargetsResponse model
+//
+// Represents the webhook targets.
+//
+// swagger:response webhookTargetsResponse
+type webhookTargetsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Targets []*webnotifier.WebhookTarget `json:"targets"`
+	}
+}
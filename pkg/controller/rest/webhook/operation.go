@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the webhook operations.
+const (
+	OperationID      = "/webhooks"
+	AddTargetPath    = OperationID
+	TargetsPath      = OperationID
+	RemoveTargetPath = OperationID + "/{id}"
+)
+
+// Operation contains the webhook targets operations provided by controller REST API.
+type Operation struct {
+	command  *webhook.Command
+	handlers []rest.Handler
+}
+
+// New returns new webhook targets operations rest client instance.
+func New(router webhook.Router) *Operation {
+	o := &Operation{command: webhook.New(router)}
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(AddTargetPath, http.MethodPost, o.AddTarget),
+		cmdutil.NewHTTPHandler(TargetsPath, http.MethodGet, o.Targets),
+		cmdutil.NewHTTPHandler(RemoveTargetPath, http.MethodDelete, o.RemoveTarget),
+	}
+}
+
+// AddTarget swagger:route POST /webhooks webhook addWebhookTarget
+//
+// Adds a webhook target receiving the notifications matching its topics and connection IDs.
+//
+// Responses:
+//    default: genericError
+//        200: webhookTargetResponse
+func (o *Operation) AddTarget(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AddTarget, rw, req.Body)
+}
+
+// Targets swagger:route GET /webhooks webhook webhookTargets
+//
+// Returns the webhook targets.
+//
+// Responses:
+//    default: genericError
+//        200: webhookTargetsResponse
+func (o *Operation) Targets(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Targets, rw, req.Body)
+}
+
+// RemoveTarget swagger:route DELETE /webhooks/{id} webhook removeWebhookTarget
+//
+// Removes a webhook target.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RemoveTarget(rw http.ResponseWriter, req *http.Request) {
+	payload := fmt.Sprintf(`{"id":%q}`, mux.Vars(req)["id"])
+	rest.Execute(o.command.RemoveTarget, rw, bytes.NewBufferString(payload))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+)
+
+func TestOperation(t *testing.T) {
+	op := New(webnotifier.NewHTTPNotifier(nil))
+	require.Len(t, op.GetRESTHandlers(), 3)
+
+	t.Run("add target", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, AddTargetPath, http.MethodPost),
+			bytes.NewBufferString(`{"id":"crm","url":"http://crm.example.com","topics":["didexchange_states"]}`),
+			AddTargetPath)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"id":"crm"`)
+	})
+
+	t.Run("list targets", func(t *testing.T) {
+		buf, code := sendRequestToHandler(t, handlerLookup(t, op, TargetsPath, http.MethodGet), nil, TargetsPath)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), `"url":"http://crm.example.com"`)
+	})
+
+	t.Run("remove target", func(t *testing.T) {
+		path := strings.Replace(RemoveTargetPath, "{id}", "crm", 1)
+
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, RemoveTargetPath, http.MethodDelete), nil, path)
+		require.Equal(t, http.StatusOK, code)
+
+		_, code = sendRequestToHandler(t, handlerLookup(t, op, RemoveTargetPath, http.MethodDelete), nil, path)
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, code := sendRequestToHandler(t, handlerLookup(t, op, AddTargetPath, http.MethodPost),
+			bytes.NewBufferString(`{"id":"crm"}`), AddTargetPath)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int) {
+	t.Helper()
+
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
+
+func handlerLookup(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
@@ -9,23 +9,115 @@ package webnotifier
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"sync"
 )
 
-// HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP.
+// ErrWebhookTargetNotFound is returned when the webhook target to remove does not exist.
+var ErrWebhookTargetNotFound = errors.New("webhook target not found")
+
+// WebhookTarget is a webhook subscriber receiving the notifications matching its filters.
+type WebhookTarget struct {
+	// ID of the target, unique among the targets.
+	ID string `json:"id"`
+	// URL the notifications are posted to.
+	URL string `json:"url"`
+	// Topics of the notifications sent to the target (e.g. didexchange_states), all topics when empty.
+	Topics []string `json:"topics,omitempty"`
+	// ConnectionIDs of the notifications sent to the target, all notifications when empty. The notifications
+	// without a connectionID property do not match a target filtering connections.
+	ConnectionIDs []string `json:"connection_ids,omitempty"`
+}
+
+func (t *WebhookTarget) matches(topic, connectionID string) bool {
+	return matchFilter(t.Topics, topic) && matchFilter(t.ConnectionIDs, connectionID)
+}
+
+func matchFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, v := range filter {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP. The subscribers are the
+// webhook URLs receiving all the notifications, and the webhook targets receiving the notifications matching their
+// filters, the targets being added and removed at runtime.
 type HTTPNotifier struct {
-	urls []string
+	urls    []string
+	targets map[string]*WebhookTarget
+	mu      sync.RWMutex
 }
 
 // NewHTTPNotifier returns a new instance of an HTTPNotifier.
 func NewHTTPNotifier(webhookURLs []string) *HTTPNotifier {
-	return &HTTPNotifier{urls: webhookURLs}
+	return &HTTPNotifier{urls: webhookURLs, targets: make(map[string]*WebhookTarget)}
 }
 
-// Notify sends the given message to all of the urls.
-// Topic is appended to the end of the webhook (subscriber) URL. E.g. localhost:8080/topic
+// AddWebhookTarget adds the webhook target, replacing the target with the same ID.
+func (n *HTTPNotifier) AddWebhookTarget(target *WebhookTarget) error {
+	if target == nil || target.ID == "" {
+		return errors.New("webhook target ID is required")
+	}
+
+	u, err := url.Parse(target.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid webhook target URL %q", target.URL)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.targets[target.ID] = target
+
+	return nil
+}
+
+// RemoveWebhookTarget removes the webhook target with the given ID.
+func (n *HTTPNotifier) RemoveWebhookTarget(id string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.targets[id]; !ok {
+		return ErrWebhookTargetNotFound
+	}
+
+	delete(n.targets, id)
+
+	return nil
+}
+
+// WebhookTargets returns the webhook targets, sorted by ID.
+func (n *HTTPNotifier) WebhookTargets() []*WebhookTarget {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	targets := make([]*WebhookTarget, 0, len(n.targets))
+
+	for _, target := range n.targets {
+		targets = append(targets, target)
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].ID < targets[j].ID })
+
+	return targets
+}
+
+// Notify sends the given message to all of the urls, and to the webhook targets matching the topic and the
+// connectionID property of the message.
 // If multiple errors are encountered, then the first one is returned.
 func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 	if topic == "" {
@@ -48,9 +140,50 @@ func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 		allErrs = appendError(allErrs, err)
 	}
 
+	for _, target := range n.matchingTargets(topic, message) {
+		err := notifyWH(target.URL, topicMsg)
+		allErrs = appendError(allErrs, err)
+	}
+
 	return allErrs
 }
 
+func (n *HTTPNotifier) matchingTargets(topic string, message []byte) []*WebhookTarget {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if len(n.targets) == 0 {
+		return nil
+	}
+
+	connectionID := connectionIDProperty(message)
+
+	var targets []*WebhookTarget
+
+	for _, target := range n.targets {
+		if target.matches(topic, connectionID) {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
+
+// connectionIDProperty returns the connectionID property of the state message or action notified, if any.
+func connectionIDProperty(message []byte) string {
+	msg := struct {
+		Properties struct {
+			ConnectionID string `json:"connectionID"`
+		}
+	}{}
+
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return ""
+	}
+
+	return msg.Properties.ConnectionID
+}
+
 func notifyWH(destination string, message []byte) error {
 	ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
 	defer cancel()
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -311,3 +312,73 @@ func listenAndStopAfterReceivingNotification(addr string) error {
 func randomURL() string {
 	return fmt.Sprintf("localhost:%d", transportutil.GetRandomPort(3))
 }
+
+func TestWebhookTargets(t *testing.T) {
+	received := make(chan string, 10)
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			topicMsg := struct {
+				Topic string `json:"topic"`
+			}{}
+			require.NoError(t, json.Unmarshal(body, &topicMsg))
+
+			received <- name + " " + topicMsg.Topic
+		}))
+	}
+
+	billing := newServer("billing")
+	defer billing.Close()
+
+	crm := newServer("crm")
+	defer crm.Close()
+
+	n := NewHTTPNotifier(nil)
+
+	require.NoError(t, n.AddWebhookTarget(&WebhookTarget{
+		ID:     "billing",
+		URL:    billing.URL,
+		Topics: []string{"issuecredential_states"},
+	}))
+	require.NoError(t, n.AddWebhookTarget(&WebhookTarget{
+		ID:            "crm",
+		URL:           crm.URL,
+		Topics:        []string{"didexchange_states", "issuecredential_states"},
+		ConnectionIDs: []string{"conn-1"},
+	}))
+
+	targets := n.WebhookTargets()
+	require.Len(t, targets, 2)
+	require.Equal(t, "billing", targets[0].ID)
+	require.Equal(t, "crm", targets[1].ID)
+
+	require.NoError(t, n.Notify("issuecredential_states", []byte(`{"StateID":"done"}`)))
+	require.Equal(t, "billing issuecredential_states", <-received)
+
+	require.NoError(t, n.Notify("didexchange_states", []byte(`{"Properties":{"connectionID":"conn-1"}}`)))
+	require.Equal(t, "crm didexchange_states", <-received)
+
+	require.NoError(t, n.Notify("didexchange_states", []byte(`{"Properties":{"connectionID":"conn-2"}}`)))
+	require.NoError(t, n.Notify("basicmessages", []byte(`{"Properties":{"connectionID":"conn-1"}}`)))
+
+	require.NoError(t, n.RemoveWebhookTarget("billing"))
+	require.ErrorIs(t, n.RemoveWebhookTarget("billing"), ErrWebhookTargetNotFound)
+
+	require.NoError(t, n.Notify("issuecredential_states", []byte(`{"Properties":{"connectionID":"conn-1"}}`)))
+	require.Equal(t, "crm issuecredential_states", <-received)
+
+	select {
+	case msg := <-received:
+		require.FailNow(t, "unexpected notification", msg)
+	default:
+	}
+
+	t.Run("invalid targets", func(t *testing.T) {
+		require.EqualError(t, n.AddWebhookTarget(&WebhookTarget{URL: crm.URL}), "webhook target ID is required")
+		require.EqualError(t, n.AddWebhookTarget(&WebhookTarget{ID: "id", URL: "localhost"}),
+			`invalid webhook target URL "localhost"`)
+	})
+}
@@ -29,6 +29,7 @@ var logger = log.New("aries-framework/webnotifier")
 
 // WebNotifier is a dispatcher capable of notifying multiple subscribers via HTTP Webhooks and WebSockets.
 type WebNotifier struct {
+	*HTTPNotifier
 	notifiers []command.Notifier
 	handlers  []rest.Handler
 }
@@ -39,8 +40,9 @@ func New(wsPath string, webhookURLs []string) *WebNotifier {
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
-		notifiers: []command.Notifier{webhook, ws},
-		handlers:  ws.GetRESTHandlers(),
+		HTTPNotifier: webhook,
+		notifiers:    []command.Notifier{webhook, ws},
+		handlers:     ws.GetRESTHandlers(),
 	}
 
 	return &n
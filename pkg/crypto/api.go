@@ -86,6 +86,41 @@ type Crypto interface {
 	DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int, kh interface{}) ([]byte, error)
 }
 
+// BlindSigner is implemented by the Crypto services supporting BBS+ blind signatures, used by issuers to sign
+// credentials with secrets committed by the holder (eg. link secrets) without learning them.
+type BlindSigner interface {
+	// CommitToMessages will create the holder's commitment to the messages hidden from the signer, keyed by their
+	// index in the messagesCount messages to sign, using the nonce given by the signer and a matching primitive found
+	// in kh key handle of the signer's public key.
+	// returns:
+	// 		commitment in []byte, to send to the signer
+	// 		blinding factor in []byte, kept by the holder to unblind the signature
+	//		error in case of errors
+	CommitToMessages(messages map[int][]byte, nonce []byte, messagesCount int, kh interface{}) ([]byte, []byte, error)
+	// BlindSignMulti will create a blind signature of the known messages, keyed by their index, and of the messages
+	// committed by the holder using a matching signing primitive found in kh key handle of a private key.
+	// returns:
+	// 		blind signature in []byte
+	//		error in case of errors
+	BlindSignMulti(messages map[int][]byte, commitment, nonce []byte, messagesCount int, kh interface{}) ([]byte, error)
+	// UnblindSignature will unblind a signature created by BlindSignMulti with the blinding factor of the commitment.
+	// returns:
+	// 		signature of all the messages in []byte, verifiable with VerifyMulti
+	//		error in case of errors
+	UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error)
+}
+
+// BatchWrapper is implemented by the Crypto services able to wrap a key for several recipients at once, like remote
+// key servers wrapping them in a single request.
+type BatchWrapper interface {
+	// WrapKeys will execute key wrapping of cek using apu, apv and each recipient public key of 'recPubKeys', with
+	// the same options as WrapKey.
+	// returns:
+	// 		RecipientWrappedKeys containing the wrapped cek values, in the order of the recipients
+	// 		error in case of errors
+	WrapKeys(cek, apu, apv []byte, recPubKeys []*PublicKey, opts ...WrapKeyOpts) ([]*RecipientWrappedKey, error)
+}
+
 // DefKeySize is the default key size for crypto primitives.
 const DefKeySize = 32
 
@@ -0,0 +1,276 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package awscrypto provides a crypto.Crypto executing the crypto operations in AWS KMS, with the key handles of the
+// awskms key manager. AWS KMS supports signing with ECDSA and RSA keys and encryption with symmetric keys, the other
+// crypto operations (MAC, key wrapping and BBS+ signatures) are not supported.
+package awscrypto
+
+import (
+	"crypto"
+	// register the hash functions of the AWS KMS signing algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awskmsapi "github.com/aws/aws-sdk-go/service/kms"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/awskms"
+)
+
+// aadContextKey is the key of the encryption context entry holding the additional authenticated data.
+const aadContextKey = "aad"
+
+var errNotSupported = errors.New("not supported by AWS KMS")
+
+// Crypto implementation of crypto.Crypto api executing the crypto operations in AWS KMS.
+type Crypto struct {
+	client awskms.Client
+}
+
+// New creates a new AWS KMS crypto service using the AWS KMS client.
+func New(client awskms.Client) *Crypto {
+	return &Crypto{client: client}
+}
+
+// Encrypt will encrypt msg with the AWS KMS symmetric key referenced by kh, aad is bound to the ciphertext through
+// the AWS KMS encryption context. The returned nonce is always nil, AWS KMS ciphertexts embed their nonce.
+func (c *Crypto) Encrypt(msg, aad []byte, kh interface{}) ([]byte, []byte, error) {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, err := c.client.Encrypt(&awskmsapi.EncryptInput{
+		KeyId:             aws.String(keyHandle.KeyID),
+		Plaintext:         msg,
+		EncryptionContext: encryptionContext(aad),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("AWS KMS encrypt: %w", err)
+	}
+
+	return output.CiphertextBlob, nil, nil
+}
+
+// Decrypt will decrypt cipher with the AWS KMS symmetric key referenced by kh, aad must be the one used to encrypt.
+func (c *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, error) {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := c.client.Decrypt(&awskmsapi.DecryptInput{
+		KeyId:             aws.String(keyHandle.KeyID),
+		CiphertextBlob:    cipher,
+		EncryptionContext: encryptionContext(aad),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS decrypt: %w", err)
+	}
+
+	return output.Plaintext, nil
+}
+
+// Sign will sign msg with the AWS KMS key referenced by kh. The digest of msg is computed locally and signed by
+// AWS KMS, the size of msg is not limited.
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, digest, err := digestMessage(keyHandle, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := c.client.Sign(&awskmsapi.SignInput{
+		KeyId:            aws.String(keyHandle.KeyID),
+		Message:          digest,
+		MessageType:      aws.String(awskmsapi.MessageTypeDigest),
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS sign: %w", err)
+	}
+
+	if !keyHandle.IEEEP1363() {
+		return output.Signature, nil
+	}
+
+	return derToIEEEP1363(output.Signature, curveSize(algorithm))
+}
+
+// Verify will verify signature of msg with the AWS KMS key referenced by kh.
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return err
+	}
+
+	algorithm, digest, err := digestMessage(keyHandle, msg)
+	if err != nil {
+		return err
+	}
+
+	if keyHandle.IEEEP1363() {
+		signature, err = ieeeP1363ToDER(signature, curveSize(algorithm))
+		if err != nil {
+			return err
+		}
+	}
+
+	output, err := c.client.Verify(&awskmsapi.VerifyInput{
+		KeyId:            aws.String(keyHandle.KeyID),
+		Message:          digest,
+		MessageType:      aws.String(awskmsapi.MessageTypeDigest),
+		Signature:        signature,
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	if err != nil {
+		return fmt.Errorf("AWS KMS verify: %w", err)
+	}
+
+	if !aws.BoolValue(output.SignatureValid) {
+		return errors.New("AWS KMS verify: invalid signature")
+	}
+
+	return nil
+}
+
+// ComputeMAC is not supported by AWS KMS.
+func (c *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("ComputeMAC: %w", errNotSupported)
+}
+
+// VerifyMAC is not supported by AWS KMS.
+func (c *Crypto) VerifyMAC(mac, data []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyMAC: %w", errNotSupported)
+}
+
+// WrapKey is not supported by AWS KMS.
+func (c *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
+	opts ...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	return nil, fmt.Errorf("WrapKey: %w", errNotSupported)
+}
+
+// UnwrapKey is not supported by AWS KMS.
+func (c *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, kh interface{},
+	opts ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	return nil, fmt.Errorf("UnwrapKey: %w", errNotSupported)
+}
+
+// SignMulti is not supported by AWS KMS.
+func (c *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("SignMulti: %w", errNotSupported)
+}
+
+// VerifyMulti is not supported by AWS KMS.
+func (c *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyMulti: %w", errNotSupported)
+}
+
+// VerifyProof is not supported by AWS KMS.
+func (c *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyProof: %w", errNotSupported)
+}
+
+// DeriveProof is not supported by AWS KMS.
+func (c *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
+	kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("DeriveProof: %w", errNotSupported)
+}
+
+func getKeyHandle(kh interface{}) (*awskms.KeyHandle, error) {
+	keyHandle, ok := kh.(*awskms.KeyHandle)
+	if !ok || keyHandle == nil {
+		return nil, errors.New("bad key handle format")
+	}
+
+	return keyHandle, nil
+}
+
+func encryptionContext(aad []byte) map[string]*string {
+	if len(aad) == 0 {
+		return nil
+	}
+
+	return map[string]*string{aadContextKey: aws.String(base64.RawURLEncoding.EncodeToString(aad))}
+}
+
+func digestMessage(kh *awskms.KeyHandle, msg []byte) (string, []byte, error) {
+	algorithm, err := kh.SigningAlgorithm()
+	if err != nil {
+		return "", nil, err
+	}
+
+	hash := crypto.SHA256
+
+	switch algorithm {
+	case awskmsapi.SigningAlgorithmSpecEcdsaSha384:
+		hash = crypto.SHA384
+	case awskmsapi.SigningAlgorithmSpecEcdsaSha512:
+		hash = crypto.SHA512
+	}
+
+	h := hash.New()
+	_, _ = h.Write(msg) // nolint: errcheck
+
+	return algorithm, h.Sum(nil), nil
+}
+
+// curveSize returns the size in bytes of the ECDSA curve of the signing algorithm.
+func curveSize(algorithm string) int {
+	switch algorithm {
+	case awskmsapi.SigningAlgorithmSpecEcdsaSha384:
+		return 48 // nolint: gomnd
+	case awskmsapi.SigningAlgorithmSpecEcdsaSha512:
+		return 66 // nolint: gomnd
+	default:
+		return 32 // nolint: gomnd
+	}
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func derToIEEEP1363(signature []byte, size int) ([]byte, error) {
+	sig := &ecdsaSignature{}
+
+	if _, err := asn1.Unmarshal(signature, sig); err != nil {
+		return nil, fmt.Errorf("unmarshal AWS KMS signature: %w", err)
+	}
+
+	rBytes, sBytes := sig.R.Bytes(), sig.S.Bytes()
+	if len(rBytes) > size || len(sBytes) > size {
+		return nil, errors.New("invalid AWS KMS signature size")
+	}
+
+	p1363 := make([]byte, 2*size)
+	copy(p1363[size-len(rBytes):size], rBytes)
+	copy(p1363[2*size-len(sBytes):], sBytes)
+
+	return p1363, nil
+}
+
+func ieeeP1363ToDER(signature []byte, size int) ([]byte, error) {
+	if len(signature) != 2*size {
+		return nil, errors.New("invalid signature size")
+	}
+
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(signature[:size]),
+		S: new(big.Int).SetBytes(signature[size:]),
+	})
+}
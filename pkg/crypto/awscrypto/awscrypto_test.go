@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package awscrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/awskms"
+	mockawskms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/awskms"
+)
+
+var _ cryptoapi.Crypto = (*Crypto)(nil)
+
+func TestCrypto_SignVerify(t *testing.T) {
+	client := &mockawskms.Client{}
+	km := awskms.New(client)
+	c := New(client)
+
+	msg := []byte("lorem ipsum")
+
+	for _, kt := range []kms.KeyType{
+		kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363,
+		kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363, kms.RSARS256Type, kms.RSAPS256Type,
+	} {
+		kt := kt
+
+		t.Run(string(kt), func(t *testing.T) {
+			_, kh, err := km.Create(kt)
+			require.NoError(t, err)
+
+			signature, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(signature, msg, kh))
+
+			err = c.Verify(signature, []byte("other message"), kh)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "AWS KMS verify")
+		})
+	}
+
+	t.Run("IEEE P1363 signature verifies with the exported public key", func(t *testing.T) {
+		keyID, pubKeyBytes, err := km.CreateAndExportPubKeyBytes(kms.ECDSAP521TypeIEEEP1363)
+		require.NoError(t, err)
+
+		kh, err := km.Get(keyID)
+		require.NoError(t, err)
+
+		signature, err := c.Sign(msg, kh)
+		require.NoError(t, err)
+		require.Len(t, signature, 132)
+
+		x, y := elliptic.Unmarshal(elliptic.P521(), pubKeyBytes)
+		digest := sha512.Sum512(msg)
+
+		require.True(t, ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P521(), X: x, Y: y}, digest[:],
+			new(big.Int).SetBytes(signature[:66]), new(big.Int).SetBytes(signature[66:])))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.Sign(msg, "keyID")
+		require.EqualError(t, err, "bad key handle format")
+
+		err = c.Verify([]byte("signature"), msg, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		_, kh, err := km.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		_, err = c.Sign(msg, kh)
+		require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by AWS KMS")
+
+		err = c.Verify([]byte("signature"), msg, kh)
+		require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by AWS KMS")
+
+		_, kh, err = km.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		err = c.Verify([]byte("signature"), msg, kh)
+		require.EqualError(t, err, "invalid signature size")
+
+		_, err = New(&mockawskms.Client{SignErr: errors.New("throttled")}).Sign(msg, kh)
+		require.EqualError(t, err, "AWS KMS sign: throttled")
+
+		_, err = New(&mockawskms.Client{}).Sign(msg, kh)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "AWS KMS sign: NotFoundException")
+	})
+}
+
+func TestCrypto_EncryptDecrypt(t *testing.T) {
+	client := &mockawskms.Client{}
+	km := awskms.New(client)
+	c := New(client)
+
+	_, kh, err := km.Create(kms.AES256GCMType)
+	require.NoError(t, err)
+
+	msg := []byte("lorem ipsum")
+	aad := []byte("dolor sit amet")
+
+	t.Run("with aad", func(t *testing.T) {
+		cipher, nonce, err := c.Encrypt(msg, aad, kh)
+		require.NoError(t, err)
+		require.Nil(t, nonce)
+
+		plaintext, err := c.Decrypt(cipher, aad, nonce, kh)
+		require.NoError(t, err)
+		require.Equal(t, msg, plaintext)
+
+		_, err = c.Decrypt(cipher, []byte("other aad"), nonce, kh)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "AWS KMS decrypt")
+	})
+
+	t.Run("without aad", func(t *testing.T) {
+		cipher, nonce, err := c.Encrypt(msg, nil, kh)
+		require.NoError(t, err)
+
+		plaintext, err := c.Decrypt(cipher, nil, nonce, kh)
+		require.NoError(t, err)
+		require.Equal(t, msg, plaintext)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := c.Encrypt(msg, aad, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		_, err = c.Decrypt(msg, aad, nil, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		_, _, err = New(&mockawskms.Client{EncryptErr: errors.New("throttled")}).Encrypt(msg, aad, kh)
+		require.EqualError(t, err, "AWS KMS encrypt: throttled")
+	})
+}
+
+func TestCrypto_NotSupported(t *testing.T) {
+	c := New(&mockawskms.Client{})
+	kh := &awskms.KeyHandle{KeyID: "keyID", KeyType: kms.HMACSHA256Tag256Type}
+
+	_, err := c.ComputeMAC([]byte("data"), kh)
+	require.EqualError(t, err, "ComputeMAC: not supported by AWS KMS")
+
+	err = c.VerifyMAC([]byte("mac"), []byte("data"), kh)
+	require.EqualError(t, err, "VerifyMAC: not supported by AWS KMS")
+
+	_, err = c.WrapKey([]byte("cek"), nil, nil, &cryptoapi.PublicKey{})
+	require.EqualError(t, err, "WrapKey: not supported by AWS KMS")
+
+	_, err = c.UnwrapKey(&cryptoapi.RecipientWrappedKey{}, kh)
+	require.EqualError(t, err, "UnwrapKey: not supported by AWS KMS")
+
+	_, err = c.SignMulti([][]byte{[]byte("msg")}, kh)
+	require.EqualError(t, err, "SignMulti: not supported by AWS KMS")
+
+	err = c.VerifyMulti([][]byte{[]byte("msg")}, []byte("signature"), kh)
+	require.EqualError(t, err, "VerifyMulti: not supported by AWS KMS")
+
+	err = c.VerifyProof([][]byte{[]byte("msg")}, []byte("proof"), []byte("nonce"), kh)
+	require.EqualError(t, err, "VerifyProof: not supported by AWS KMS")
+
+	_, err = c.DeriveProof([][]byte{[]byte("msg")}, []byte("signature"), []byte("nonce"), []int{0}, kh)
+	require.EqualError(t, err, "DeriveProof: not supported by AWS KMS")
+}
@@ -0,0 +1,297 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package azurecrypto provides a crypto.Crypto executing the crypto operations in Azure Key Vault, with the key
+// handles of the azurekms key manager. Key Vault supports signing with ECDSA and RSA keys and wrapping keys with RSA
+// keys (RSA-OAEP-256): the content encryption keys are wrapped for a recipient Key Vault RSA key, referenced by the
+// KID of the recipient public key. The other crypto operations (MAC, ECDH key wrapping and BBS+ signatures) are not
+// supported.
+package azurecrypto
+
+import (
+	"crypto"
+	// register the hash functions of the Key Vault signing algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/azurekms"
+)
+
+var errNotSupported = errors.New("not supported by Key Vault")
+
+// Crypto implementation of crypto.Crypto api executing the crypto operations in Azure Key Vault.
+type Crypto struct {
+	client azurekms.Client
+}
+
+// New creates a new Key Vault crypto service using the Key Vault client.
+func New(client azurekms.Client) *Crypto {
+	return &Crypto{client: client}
+}
+
+// Encrypt is not supported by Key Vault.
+func (c *Crypto) Encrypt(msg, aad []byte, kh interface{}) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("Encrypt: %w", errNotSupported)
+}
+
+// Decrypt is not supported by Key Vault.
+func (c *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("Decrypt: %w", errNotSupported)
+}
+
+// Sign will sign msg with the Key Vault key referenced by kh. The digest of msg is computed locally and signed by
+// Key Vault, the size of msg is not limited.
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, digest, err := digestMessage(keyHandle, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.client.Sign(keyHandle.KeyID, &azurekms.KeyOperationParameters{
+		Algorithm: algorithm,
+		Value:     base64.RawURLEncoding.EncodeToString(digest),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign with Key Vault: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode Key Vault signature: %w", err)
+	}
+
+	if !isECDSA(algorithm) || keyHandle.IEEEP1363() {
+		return signature, nil
+	}
+
+	return ieeeP1363ToDER(signature, curveSize(algorithm))
+}
+
+// Verify will verify signature of msg with the Key Vault key referenced by kh.
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return err
+	}
+
+	algorithm, digest, err := digestMessage(keyHandle, msg)
+	if err != nil {
+		return err
+	}
+
+	if isECDSA(algorithm) && !keyHandle.IEEEP1363() {
+		signature, err = derToIEEEP1363(signature, curveSize(algorithm))
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := c.client.Verify(keyHandle.KeyID, &azurekms.KeyVerifyParameters{
+		Algorithm: algorithm,
+		Digest:    base64.RawURLEncoding.EncodeToString(digest),
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	})
+	if err != nil {
+		return fmt.Errorf("verify with Key Vault: %w", err)
+	}
+
+	if !result.Value {
+		return errors.New("verify with Key Vault: invalid signature")
+	}
+
+	return nil
+}
+
+// ComputeMAC is not supported by Key Vault.
+func (c *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("ComputeMAC: %w", errNotSupported)
+}
+
+// VerifyMAC is not supported by Key Vault.
+func (c *Crypto) VerifyMAC(mac, data []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyMAC: %w", errNotSupported)
+}
+
+// WrapKey will wrap cek with the Key Vault RSA key of the recipient, referenced by the KID of recPubKey, using
+// RSA-OAEP-256. The sender key option (ECDH-1PU) is not supported.
+func (c *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
+	opts ...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	if recPubKey == nil || recPubKey.KID == "" {
+		return nil, errors.New("wrapKey: recipient public key KID is required")
+	}
+
+	wrapOpts := cryptoapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(wrapOpts)
+	}
+
+	if wrapOpts.SenderKey() != nil {
+		return nil, fmt.Errorf("WrapKey with sender key: %w", errNotSupported)
+	}
+
+	result, err := c.client.WrapKey(recPubKey.KID, &azurekms.KeyOperationParameters{
+		Algorithm: azurekms.WrapAlgorithm,
+		Value:     base64.RawURLEncoding.EncodeToString(cek),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wrap key with Key Vault: %w", err)
+	}
+
+	wrapped, err := base64.RawURLEncoding.DecodeString(result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode Key Vault wrapped key: %w", err)
+	}
+
+	return &cryptoapi.RecipientWrappedKey{
+		KID:          recPubKey.KID,
+		EncryptedCEK: wrapped,
+		Alg:          azurekms.WrapAlgorithm,
+		APU:          apu,
+		APV:          apv,
+	}, nil
+}
+
+// UnwrapKey will unwrap the key of recWK, wrapped with RSA-OAEP-256, with the Key Vault RSA key referenced by kh.
+func (c *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, kh interface{},
+	opts ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	keyHandle, err := getKeyHandle(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	if recWK == nil || recWK.Alg != azurekms.WrapAlgorithm {
+		return nil, fmt.Errorf("UnwrapKey without %s algorithm: %w", azurekms.WrapAlgorithm, errNotSupported)
+	}
+
+	result, err := c.client.UnwrapKey(keyHandle.KeyID, &azurekms.KeyOperationParameters{
+		Algorithm: azurekms.WrapAlgorithm,
+		Value:     base64.RawURLEncoding.EncodeToString(recWK.EncryptedCEK),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unwrap key with Key Vault: %w", err)
+	}
+
+	cek, err := base64.RawURLEncoding.DecodeString(result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode Key Vault unwrapped key: %w", err)
+	}
+
+	return cek, nil
+}
+
+// SignMulti is not supported by Key Vault.
+func (c *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("SignMulti: %w", errNotSupported)
+}
+
+// VerifyMulti is not supported by Key Vault.
+func (c *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyMulti: %w", errNotSupported)
+}
+
+// VerifyProof is not supported by Key Vault.
+func (c *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error {
+	return fmt.Errorf("VerifyProof: %w", errNotSupported)
+}
+
+// DeriveProof is not supported by Key Vault.
+func (c *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
+	kh interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("DeriveProof: %w", errNotSupported)
+}
+
+func getKeyHandle(kh interface{}) (*azurekms.KeyHandle, error) {
+	keyHandle, ok := kh.(*azurekms.KeyHandle)
+	if !ok || keyHandle == nil {
+		return nil, errors.New("bad key handle format")
+	}
+
+	return keyHandle, nil
+}
+
+func digestMessage(kh *azurekms.KeyHandle, msg []byte) (string, []byte, error) {
+	algorithm, err := kh.SigningAlgorithm()
+	if err != nil {
+		return "", nil, err
+	}
+
+	hash := crypto.SHA256
+
+	switch algorithm {
+	case "ES384":
+		hash = crypto.SHA384
+	case "ES512":
+		hash = crypto.SHA512
+	}
+
+	h := hash.New()
+	_, _ = h.Write(msg) // nolint: errcheck
+
+	return algorithm, h.Sum(nil), nil
+}
+
+func isECDSA(algorithm string) bool {
+	return strings.HasPrefix(algorithm, "ES")
+}
+
+// curveSize returns the size in bytes of the ECDSA curve of the signing algorithm.
+func curveSize(algorithm string) int {
+	switch algorithm {
+	case "ES384":
+		return 48 // nolint: gomnd
+	case "ES512":
+		return 66 // nolint: gomnd
+	default:
+		return 32 // nolint: gomnd
+	}
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func derToIEEEP1363(signature []byte, size int) ([]byte, error) {
+	sig := &ecdsaSignature{}
+
+	if _, err := asn1.Unmarshal(signature, sig); err != nil {
+		return nil, fmt.Errorf("unmarshal DER signature: %w", err)
+	}
+
+	rBytes, sBytes := sig.R.Bytes(), sig.S.Bytes()
+	if len(rBytes) > size || len(sBytes) > size {
+		return nil, errors.New("invalid signature size")
+	}
+
+	p1363 := make([]byte, 2*size)
+	copy(p1363[size-len(rBytes):size], rBytes)
+	copy(p1363[2*size-len(sBytes):], sBytes)
+
+	return p1363, nil
+}
+
+func ieeeP1363ToDER(signature []byte, size int) ([]byte, error) {
+	if len(signature) != 2*size {
+		return nil, errors.New("invalid Key Vault signature size")
+	}
+
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(signature[:size]),
+		S: new(big.Int).SetBytes(signature[size:]),
+	})
+}
@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azurecrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/azurekms"
+	mockazurekms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/azurekms"
+)
+
+var _ cryptoapi.Crypto = (*Crypto)(nil)
+
+func TestCrypto_SignVerify(t *testing.T) {
+	client := &mockazurekms.Client{}
+	km := azurekms.New(client)
+	c := New(client)
+
+	msg := []byte("lorem ipsum")
+
+	for _, kt := range []kms.KeyType{
+		kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363,
+		kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363, kms.RSARS256Type, kms.RSAPS256Type,
+	} {
+		kt := kt
+
+		t.Run(string(kt), func(t *testing.T) {
+			_, kh, err := km.Create(kt)
+			require.NoError(t, err)
+
+			signature, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(signature, msg, kh))
+
+			err = c.Verify(signature, []byte("other message"), kh)
+			require.EqualError(t, err, "verify with Key Vault: invalid signature")
+		})
+	}
+
+	t.Run("IEEE P1363 signature verifies with the exported public key", func(t *testing.T) {
+		keyID, pubKeyBytes, err := km.CreateAndExportPubKeyBytes(kms.ECDSAP521TypeIEEEP1363)
+		require.NoError(t, err)
+
+		kh, err := km.Get(keyID)
+		require.NoError(t, err)
+
+		signature, err := c.Sign(msg, kh)
+		require.NoError(t, err)
+		require.Len(t, signature, 132)
+
+		x, y := elliptic.Unmarshal(elliptic.P521(), pubKeyBytes)
+		digest := sha512.Sum512(msg)
+
+		require.True(t, ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P521(), X: x, Y: y}, digest[:],
+			new(big.Int).SetBytes(signature[:66]), new(big.Int).SetBytes(signature[66:])))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.Sign(msg, "keyID")
+		require.EqualError(t, err, "bad key handle format")
+
+		err = c.Verify([]byte("signature"), msg, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		kh := &azurekms.KeyHandle{KeyID: "keyID", KeyType: kms.AES256GCMType}
+
+		_, err = c.Sign(msg, kh)
+		require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by Key Vault")
+
+		err = c.Verify([]byte("signature"), msg, kh)
+		require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by Key Vault")
+
+		_, kh2, err := km.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		err = c.Verify([]byte("signature"), msg, kh2)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal DER signature")
+
+		_, err = New(&mockazurekms.Client{SignErr: errors.New("throttled")}).Sign(msg, kh2)
+		require.EqualError(t, err, "sign with Key Vault: throttled")
+
+		err = New(&mockazurekms.Client{VerifyErr: errors.New("throttled")}).Verify([]byte("signature"), msg,
+			&azurekms.KeyHandle{KeyID: "keyID", KeyType: kms.RSAPS256Type})
+		require.EqualError(t, err, "verify with Key Vault: throttled")
+
+		_, err = New(&mockazurekms.Client{}).Sign(msg, kh2)
+		require.EqualError(t, err, "sign with Key Vault: key not found")
+	})
+}
+
+func TestCrypto_WrapUnwrapKey(t *testing.T) {
+	client := &mockazurekms.Client{}
+	km := azurekms.New(client)
+	c := New(client)
+
+	keyID, kh, err := km.Create(kms.RSAPS256Type)
+	require.NoError(t, err)
+
+	recPubKey := &cryptoapi.PublicKey{KID: keyID, Type: "RSA"}
+	cek := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("success", func(t *testing.T) {
+		wrapped, err := c.WrapKey(cek, []byte("apu"), []byte("apv"), recPubKey)
+		require.NoError(t, err)
+		require.Equal(t, keyID, wrapped.KID)
+		require.Equal(t, azurekms.WrapAlgorithm, wrapped.Alg)
+		require.Equal(t, []byte("apu"), wrapped.APU)
+		require.NotEqual(t, cek, wrapped.EncryptedCEK)
+
+		unwrapped, err := c.UnwrapKey(wrapped, kh)
+		require.NoError(t, err)
+		require.Equal(t, cek, unwrapped)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{})
+		require.EqualError(t, err, "wrapKey: recipient public key KID is required")
+
+		_, err = c.WrapKey(cek, nil, nil, recPubKey, cryptoapi.WithSender(kh))
+		require.EqualError(t, err, "WrapKey with sender key: not supported by Key Vault")
+
+		_, err = New(&mockazurekms.Client{WrapKeyErr: errors.New("forbidden")}).WrapKey(cek, nil, nil, recPubKey)
+		require.EqualError(t, err, "wrap key with Key Vault: forbidden")
+
+		_, err = c.UnwrapKey(&cryptoapi.RecipientWrappedKey{Alg: "ECDH-ES+A256KW"}, kh)
+		require.EqualError(t, err, "UnwrapKey without RSA-OAEP-256 algorithm: not supported by Key Vault")
+
+		_, err = c.UnwrapKey(&cryptoapi.RecipientWrappedKey{Alg: azurekms.WrapAlgorithm}, "kh")
+		require.EqualError(t, err, "bad key handle format")
+
+		_, err = New(&mockazurekms.Client{UnwrapKeyErr: errors.New("forbidden")}).
+			UnwrapKey(&cryptoapi.RecipientWrappedKey{Alg: azurekms.WrapAlgorithm}, kh)
+		require.EqualError(t, err, "unwrap key with Key Vault: forbidden")
+	})
+}
+
+func TestCrypto_NotSupported(t *testing.T) {
+	c := New(&mockazurekms.Client{})
+	kh := &azurekms.KeyHandle{KeyID: "keyID", KeyType: kms.HMACSHA256Tag256Type}
+
+	_, _, err := c.Encrypt([]byte("msg"), nil, kh)
+	require.EqualError(t, err, "Encrypt: not supported by Key Vault")
+
+	_, err = c.Decrypt([]byte("cipher"), nil, nil, kh)
+	require.EqualError(t, err, "Decrypt: not supported by Key Vault")
+
+	_, err = c.ComputeMAC([]byte("data"), kh)
+	require.EqualError(t, err, "ComputeMAC: not supported by Key Vault")
+
+	err = c.VerifyMAC([]byte("mac"), []byte("data"), kh)
+	require.EqualError(t, err, "VerifyMAC: not supported by Key Vault")
+
+	_, err = c.SignMulti([][]byte{[]byte("msg")}, kh)
+	require.EqualError(t, err, "SignMulti: not supported by Key Vault")
+
+	err = c.VerifyMulti([][]byte{[]byte("msg")}, []byte("signature"), kh)
+	require.EqualError(t, err, "VerifyMulti: not supported by Key Vault")
+
+	err = c.VerifyProof([][]byte{[]byte("msg")}, []byte("proof"), []byte("nonce"), kh)
+	require.EqualError(t, err, "VerifyProof: not supported by Key Vault")
+
+	_, err = c.DeriveProof([][]byte{[]byte("msg")}, []byte("signature"), []byte("nonce"), []int{0}, kh)
+	require.EqualError(t, err, "DeriveProof: not supported by Key Vault")
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// BlindCommitment is the commitment of a holder to the messages it hides from the signer of a blind signature
+// (eg. a link secret).
+type BlindCommitment struct {
+	// Commitment is sent to the signer, it holds the indexes of the committed messages, the commitment and the proof of
+	// knowledge of the committed messages.
+	Commitment []byte
+	// BlindingFactor is kept by the holder to unblind the signature.
+	BlindingFactor []byte
+}
+
+// CommitToMessages creates the commitment of the holder to the messages hidden from the signer, messages being the
+// hidden messages by index and messagesCount the count of both hidden and known messages to sign. The nonce is given by
+// the signer to prevent the replay of the commitment.
+func (bbs *BBSG2Pub) CommitToMessages(messages map[int][]byte, nonce, pubKeyBytes []byte,
+	messagesCount int) (*BlindCommitment, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("no message to commit")
+	}
+
+	indexes, err := messageIndexes(messages, messagesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	publicKeyWithGenerators, err := pubKey.ToPublicKeyWithGenerators(messagesCount)
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	blindingFactor := createRandSignatureFr()
+
+	cb := newCommitmentBuilder(len(indexes) + 1)
+	cb.add(publicKeyWithGenerators.h0, blindingFactor)
+
+	committing := NewProverCommittingG1()
+	committing.Commit(publicKeyWithGenerators.h0)
+
+	secrets := make([]*bls12381.Fr, 0, len(indexes)+1)
+	secrets = append(secrets, blindingFactor)
+
+	for _, i := range indexes {
+		message := ParseSignatureMessage(messages[i])
+
+		cb.add(publicKeyWithGenerators.h[i], message.FR)
+		committing.Commit(publicKeyWithGenerators.h[i])
+
+		secrets = append(secrets, message.FR)
+	}
+
+	commitment := cb.build()
+	committed := committing.Finish()
+
+	challenge := commitmentChallenge(commitment, committed.bases, committed.commitment, nonce)
+	proof := committed.GenerateProof(challenge, secrets)
+
+	commitmentBytes := make([]byte, 0)
+	commitmentBytes = append(commitmentBytes, uint32ToBytes(uint32(len(indexes)))...)
+
+	for _, i := range indexes {
+		commitmentBytes = append(commitmentBytes, uint32ToBytes(uint32(i))...)
+	}
+
+	commitmentBytes = append(commitmentBytes, g1.ToCompressed(commitment)...)
+	commitmentBytes = append(commitmentBytes, proof.ToBytes()...)
+
+	return &BlindCommitment{
+		Commitment:     commitmentBytes,
+		BlindingFactor: blindingFactor.ToBytes(),
+	}, nil
+}
+
+// BlindSign signs the known messages and the messages committed by the holder using private key in compressed form,
+// messages being the known messages by index. The proof of knowledge of the commitment is verified with the nonce
+// given to the holder. The holder unblinds the signature with UnblindSignature.
+func (bbs *BBSG2Pub) BlindSign(messages map[int][]byte, commitmentBytes, nonce, privKeyBytes []byte,
+	messagesCount int) ([]byte, error) {
+	privKey, err := UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	knownIndexes, err := messageIndexes(messages, messagesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	committedIndexes, commitment, proof, err := parseBlindCommitment(commitmentBytes, messagesCount)
+	if err != nil {
+		return nil, fmt.Errorf("parse commitment: %w", err)
+	}
+
+	if len(knownIndexes)+len(committedIndexes) != messagesCount {
+		return nil, errors.New("known and committed messages don't match the messages count")
+	}
+
+	for _, i := range committedIndexes {
+		if _, ok := messages[i]; ok {
+			return nil, fmt.Errorf("message %d is both known and committed", i)
+		}
+	}
+
+	publicKeyWithGenerators, err := privKey.PublicKey().ToPublicKeyWithGenerators(messagesCount)
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	bases := make([]*bls12381.PointG1, 0, len(committedIndexes)+1)
+	bases = append(bases, publicKeyWithGenerators.h0)
+
+	for _, i := range committedIndexes {
+		bases = append(bases, publicKeyWithGenerators.h[i])
+	}
+
+	challenge := commitmentChallenge(commitment, bases, proof.commitment, nonce)
+
+	if err = proof.Verify(bases, commitment, challenge); err != nil {
+		return nil, fmt.Errorf("verify commitment proof: %w", err)
+	}
+
+	e, s := createRandSignatureFr(), createRandSignatureFr()
+	exp := bls12381.NewFr().Set(privKey.FR)
+	exp.Add(exp, e)
+	exp.Inverse(exp)
+
+	cb := newCommitmentBuilder(len(knownIndexes) + 2) //nolint:gomnd
+	cb.add(g1.One(), bls12381.NewFr().One())
+	cb.add(publicKeyWithGenerators.h0, s)
+
+	for _, i := range knownIndexes {
+		cb.add(publicKeyWithGenerators.h[i], ParseSignatureMessage(messages[i]).FR)
+	}
+
+	b := cb.build()
+	g1.Add(b, b, commitment)
+
+	sig := g1.New()
+	g1.MulScalar(sig, b, frToRepr(exp))
+
+	signature := &Signature{
+		A: sig,
+		E: e,
+		S: s,
+	}
+
+	return signature.ToBytes()
+}
+
+// UnblindSignature unblinds the signature created by BlindSign with the blinding factor of the commitment, the
+// unblinded signature being a regular signature of all the messages.
+func (bbs *BBSG2Pub) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	signature, err := ParseSignature(blindSignature)
+	if err != nil {
+		return nil, fmt.Errorf("parse signature: %w", err)
+	}
+
+	if len(blindingFactor) != frCompressedSize {
+		return nil, errors.New("invalid size of blinding factor")
+	}
+
+	signature.S.Add(signature.S, parseFr(blindingFactor))
+
+	return signature.ToBytes()
+}
+
+// messageIndexes returns the sorted indexes of the messages, checking they are in the range of the messages count.
+func messageIndexes(messages map[int][]byte, messagesCount int) ([]int, error) {
+	indexes := make([]int, 0, len(messages))
+
+	for i := range messages {
+		if i < 0 || i >= messagesCount {
+			return nil, fmt.Errorf("message index %d out of range", i)
+		}
+
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes, nil
+}
+
+func commitmentChallenge(commitment *bls12381.PointG1, bases []*bls12381.PointG1, proofCommitment *bls12381.PointG1,
+	nonce []byte) *bls12381.Fr {
+	challengeBytes := make([]byte, 0)
+
+	for _, base := range bases {
+		challengeBytes = append(challengeBytes, g1.ToUncompressed(base)...)
+	}
+
+	challengeBytes = append(challengeBytes, g1.ToUncompressed(proofCommitment)...)
+	challengeBytes = append(challengeBytes, g1.ToUncompressed(commitment)...)
+	challengeBytes = append(challengeBytes, ParseProofNonce(nonce).ToBytes()...)
+
+	return frFromOKM(challengeBytes)
+}
+
+func parseBlindCommitment(bytes []byte, messagesCount int) ([]int, *bls12381.PointG1, *ProofG1, error) {
+	const uint32Size = 4
+
+	if len(bytes) < uint32Size {
+		return nil, nil, nil, errors.New("invalid size of commitment")
+	}
+
+	count := int(binary.BigEndian.Uint32(bytes))
+	offset := uint32Size
+
+	if count == 0 || count > messagesCount || len(bytes) < offset+count*uint32Size+g1CompressedSize {
+		return nil, nil, nil, errors.New("invalid size of commitment")
+	}
+
+	indexes := make([]int, count)
+
+	for i := range indexes {
+		indexes[i] = int(uint32FromBytes(bytes[offset : offset+uint32Size]))
+		offset += uint32Size
+
+		if indexes[i] >= messagesCount || (i > 0 && indexes[i] <= indexes[i-1]) {
+			return nil, nil, nil, errors.New("invalid committed message indexes")
+		}
+	}
+
+	commitment, err := g1.FromCompressed(bytes[offset : offset+g1CompressedSize])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parse G1 point: %w", err)
+	}
+
+	offset += g1CompressedSize
+
+	proof, err := ParseProofG1(bytes[offset:])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parse G1 proof: %w", err)
+	}
+
+	if len(proof.responses) != count+1 {
+		return nil, nil, nil, errors.New("invalid commitment proof")
+	}
+
+	return indexes, commitment, proof, nil
+}
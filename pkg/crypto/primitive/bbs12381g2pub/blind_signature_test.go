@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+func TestBBSG2Pub_BlindSign(t *testing.T) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	bls := bbs12381g2pub.New()
+	nonce := []byte("nonce")
+
+	linkSecret := map[int][]byte{1: []byte("link secret")}
+	known := map[int][]byte{0: []byte("message1"), 2: []byte("message3")}
+	messages := [][]byte{known[0], linkSecret[1], known[2]}
+
+	commitment, err := bls.CommitToMessages(linkSecret, nonce, pubKeyBytes, len(messages))
+	require.NoError(t, err)
+
+	t.Run("blind sign and unblind", func(t *testing.T) {
+		blindSignature, err := bls.BlindSign(known, commitment.Commitment, nonce, privKeyBytes, len(messages))
+		require.NoError(t, err)
+
+		// the blind signature isn't a signature of the messages
+		require.Error(t, bls.Verify(messages, blindSignature, pubKeyBytes))
+
+		signature, err := bls.UnblindSignature(blindSignature, commitment.BlindingFactor)
+		require.NoError(t, err)
+		require.NoError(t, bls.Verify(messages, signature, pubKeyBytes))
+
+		// the holder derives proofs from the unblinded signature
+		proof, err := bls.DeriveProof(messages, signature, nonce, pubKeyBytes, []int{0})
+		require.NoError(t, err)
+		require.NoError(t, bls.VerifyProof(messages[:1], proof, nonce, pubKeyBytes))
+	})
+
+	t.Run("commitment proof with another nonce", func(t *testing.T) {
+		_, err := bls.BlindSign(known, commitment.Commitment, []byte("other"), privKeyBytes, len(messages))
+		require.EqualError(t, err, "verify commitment proof: contribution is not zero")
+	})
+
+	t.Run("committed message is known", func(t *testing.T) {
+		_, err := bls.BlindSign(map[int][]byte{0: known[0]}, commitment.Commitment, nonce, privKeyBytes, 1)
+		require.EqualError(t, err, "parse commitment: invalid committed message indexes")
+
+		_, err = bls.BlindSign(map[int][]byte{0: known[0], 1: known[2], 2: known[2]}, commitment.Commitment, nonce,
+			privKeyBytes, len(messages))
+		require.EqualError(t, err, "known and committed messages don't match the messages count")
+
+		_, err = bls.BlindSign(map[int][]byte{0: known[0], 1: known[2]}, commitment.Commitment, nonce,
+			privKeyBytes, len(messages))
+		require.EqualError(t, err, "message 1 is both known and committed")
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := bls.CommitToMessages(nil, nonce, pubKeyBytes, len(messages))
+		require.EqualError(t, err, "no message to commit")
+
+		_, err = bls.CommitToMessages(map[int][]byte{3: nil}, nonce, pubKeyBytes, len(messages))
+		require.EqualError(t, err, "message index 3 out of range")
+
+		_, err = bls.CommitToMessages(linkSecret, nonce, []byte("invalid"), len(messages))
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+
+		_, err = bls.BlindSign(known, commitment.Commitment, nonce, []byte("invalid"), len(messages))
+		require.EqualError(t, err, "unmarshal private key: invalid size of private key")
+
+		_, err = bls.BlindSign(known, []byte("invalid"), nonce, privKeyBytes, len(messages))
+		require.EqualError(t, err, "parse commitment: invalid size of commitment")
+
+		_, err = bls.UnblindSignature([]byte("invalid"), commitment.BlindingFactor)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+	})
+}
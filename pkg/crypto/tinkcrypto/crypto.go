@@ -23,8 +23,11 @@ import (
 	"golang.org/x/crypto/chacha20poly1305"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+	// registers the secp256k1 key managers of the ES256K keys signing and verifying with Sign and Verify.
+	_ "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 const (
@@ -394,3 +397,67 @@ func (t *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, reve
 
 	return proof, nil
 }
+
+// CommitToMessages will create the holder's commitment to the messages hidden from the signer using the signer's
+// public key in signerPubKH handle.
+// returns:
+// 		commitment in []byte
+// 		blinding factor in []byte
+//		error in case of errors
+func (t *Crypto) CommitToMessages(messages map[int][]byte, nonce []byte, messagesCount int,
+	signerPubKH interface{}) ([]byte, []byte, error) {
+	keyHandle, ok := signerPubKH.(*keyset.Handle)
+	if !ok {
+		return nil, nil, errBadKeyHandleFormat
+	}
+
+	committer, err := bbs.NewCommitter(keyHandle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create new BBS+ committer: %w", err)
+	}
+
+	commitment, blindingFactor, err := committer.CommitToMessages(messages, nonce, messagesCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("BBS+ commit to msg: %w", err)
+	}
+
+	return commitment, blindingFactor, nil
+}
+
+// BlindSignMulti will create a BBS+ blind signature of the known messages and of the messages committed by the holder
+// using the signer's private key in signerKH handle.
+// returns:
+// 		blind signature in []byte
+//		error in case of errors
+func (t *Crypto) BlindSignMulti(messages map[int][]byte, commitment, nonce []byte, messagesCount int,
+	signerKH interface{}) ([]byte, error) {
+	keyHandle, ok := signerKH.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	signer, err := bbs.NewBlindSigner(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new BBS+ blind signer: %w", err)
+	}
+
+	s, err := signer.BlindSign(messages, commitment, nonce, messagesCount)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ blind sign msg: %w", err)
+	}
+
+	return s, nil
+}
+
+// UnblindSignature will unblind a BBS+ signature created by BlindSignMulti with the blinding factor of the commitment.
+// returns:
+// 		signature in []byte
+//		error in case of errors
+func (t *Crypto) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	s, err := bbs12381g2pub.New().UnblindSignature(blindSignature, blindingFactor)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ unblind signature: %w", err)
+	}
+
+	return s, nil
+}
@@ -38,6 +38,9 @@ const testMessage = "test message"
 // Assert that Crypto implements the Crypto interface.
 var _ crypto.Crypto = (*Crypto)(nil)
 
+// Assert that Crypto implements the BlindSigner interface.
+var _ crypto.BlindSigner = (*Crypto)(nil)
+
 func TestNew(t *testing.T) {
 	_, err := New()
 	require.NoError(t, err)
@@ -767,3 +770,59 @@ func TestBBSCrypto_SignVerify_DeriveProofVerifyProof(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestBBSCrypto_BlindSign(t *testing.T) {
+	c := Crypto{}
+	nonce := []byte("nonce")
+	linkSecret := map[int][]byte{0: []byte("link secret")}
+	known := map[int][]byte{1: []byte(testMessage + "1"), 2: []byte(testMessage + "2")}
+	msg := [][]byte{linkSecret[0], known[1], known[2]}
+
+	kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	t.Run("test with BBS+ blind signature", func(t *testing.T) {
+		commitment, blindingFactor, err := c.CommitToMessages(linkSecret, nonce, len(msg), pubKH)
+		require.NoError(t, err)
+
+		blindSignature, err := c.BlindSignMulti(known, commitment, nonce, len(msg), kh)
+		require.NoError(t, err)
+
+		s, err := c.UnblindSignature(blindSignature, blindingFactor)
+		require.NoError(t, err)
+
+		err = c.VerifyMulti(msg, s, pubKH)
+		require.NoError(t, err)
+	})
+
+	t.Run("test with BBS+ blind signature errors", func(t *testing.T) {
+		_, _, err := c.CommitToMessages(linkSecret, nonce, len(msg), "bad key type")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		_, _, err = c.CommitToMessages(nil, nonce, len(msg), pubKH)
+		require.EqualError(t, err, "BBS+ commit to msg: no message to commit")
+
+		_, err = c.BlindSignMulti(known, nil, nonce, len(msg), "bad key type")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		_, err = c.BlindSignMulti(known, []byte("invalid"), nonce, len(msg), kh)
+		require.EqualError(t, err, "BBS+ blind sign msg: parse commitment: invalid size of commitment")
+
+		tinkKH, err := keyset.NewHandle(&tinkpb.KeyTemplate{
+			TypeUrl:          bbs.BLS12381G2KeyTemplate().TypeUrl,
+			Value:            bbs.BLS12381G2KeyTemplate().Value,
+			OutputPrefixType: tinkpb.OutputPrefixType_TINK,
+		})
+		require.NoError(t, err)
+
+		_, err = c.BlindSignMulti(known, nil, nonce, len(msg), tinkKH)
+		require.EqualError(t, err,
+			"create new BBS+ blind signer: bbs_blind_factory: blind signatures require a RAW primary key")
+
+		_, err = c.UnblindSignature([]byte("invalid"), nil)
+		require.EqualError(t, err, "BBS+ unblind signature: parse signature: invalid size of signature")
+	})
+}
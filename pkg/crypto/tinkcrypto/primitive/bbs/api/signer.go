@@ -15,3 +15,14 @@ type Signer interface {
 	//		error in case of errors
 	Sign(messages [][]byte) ([]byte, error)
 }
+
+// BlindSigner is the blind signing interface primitive for BBS+ signatures used by Tink.
+type BlindSigner interface {
+	// BlindSign will create a signature of the known messages and of the messages committed by the holder (see
+	// Committer) using the signer's private key. The signature is unblinded by the holder with the blinding factor
+	// of the commitment.
+	// returns:
+	// 		blind signature in []byte
+	//		error in case of errors
+	BlindSign(messages map[int][]byte, commitment, nonce []byte, messagesCount int) ([]byte, error)
+}
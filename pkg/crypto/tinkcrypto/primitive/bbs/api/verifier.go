@@ -26,3 +26,13 @@ type Verifier interface {
 	//		error in case of errors
 	DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int) ([]byte, error)
 }
+
+// Committer is the interface primitive used by holders to commit to BBS+ messages hidden from the signer.
+type Committer interface {
+	// CommitToMessages will create a commitment to the hidden messages (eg. a link secret) for the signer's public key.
+	// returns:
+	// 		commitment in []byte, to send to the signer
+	// 		blinding factor in []byte, to unblind the signature
+	//		error in case of errors
+	CommitToMessages(messages map[int][]byte, nonce []byte, messagesCount int) ([]byte, []byte, error)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/core/primitiveset"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	bbsapi "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs/api"
+)
+
+// errNonRawPrimaryKey is returned for blind signatures with a prefixed primary key: the holder unblinds the signature
+// and verifies it with all the messages, the key prefix being unknown to the messages and the blinding factor.
+var errNonRawPrimaryKey = errors.New("bbs_blind_factory: blind signatures require a RAW primary key")
+
+// NewBlindSigner returns a BBS BlindSigner primitive from the given keyset handle, the primary key of which must have
+// the RAW output prefix type.
+func NewBlindSigner(h *keyset.Handle) (bbsapi.BlindSigner, error) {
+	primary, err := rawPrimary(h)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := primary.(bbsapi.BlindSigner)
+	if !ok {
+		return nil, fmt.Errorf("bbs_blind_factory: not a BBS BlindSigner primitive")
+	}
+
+	return signer, nil
+}
+
+// NewCommitter returns a BBS Committer primitive from the given public keyset handle, the primary key of which must
+// have the RAW output prefix type.
+func NewCommitter(h *keyset.Handle) (bbsapi.Committer, error) {
+	primary, err := rawPrimary(h)
+	if err != nil {
+		return nil, err
+	}
+
+	committer, ok := primary.(bbsapi.Committer)
+	if !ok {
+		return nil, fmt.Errorf("bbs_blind_factory: not a BBS Committer primitive")
+	}
+
+	return committer, nil
+}
+
+func rawPrimary(h *keyset.Handle) (interface{}, error) {
+	ps, err := h.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("bbs_blind_factory: cannot obtain primitive set: %w", err)
+	}
+
+	return primaryPrimitive(ps)
+}
+
+func primaryPrimitive(ps *primitiveset.PrimitiveSet) (interface{}, error) {
+	if ps.Primary == nil {
+		return nil, errors.New("bbs_blind_factory: no primary primitive")
+	}
+
+	if ps.Primary.PrefixType != tinkpb.OutputPrefixType_RAW {
+		return nil, errNonRawPrimaryKey
+	}
+
+	return ps.Primary.Primitive, nil
+}
@@ -34,3 +34,13 @@ func NewBLS12381G2Signer(privateKey []byte) *BLS12381G2Signer {
 func (s *BLS12381G2Signer) Sign(messages [][]byte) ([]byte, error) {
 	return s.bbsPrimitive.Sign(messages, s.privateKeyBytes)
 }
+
+// BlindSign will create a signature of the known messages and of the messages committed by the holder using the
+// signer's private key.
+// returns:
+// 		blind signature in []byte
+//		error in case of errors
+func (s *BLS12381G2Signer) BlindSign(messages map[int][]byte, commitment, nonce []byte,
+	messagesCount int) ([]byte, error) {
+	return s.bbsPrimitive.BlindSign(messages, commitment, nonce, s.privateKeyBytes, messagesCount)
+}
@@ -49,3 +49,18 @@ func (v *BLS12381G2Verifier) DeriveProof(messages [][]byte, signature, nonce []b
 	revealedIndexes []int) ([]byte, error) {
 	return v.bbsPrimitive.DeriveProof(messages, signature, nonce, v.signerPubKeyBytes, revealedIndexes)
 }
+
+// CommitToMessages will create a commitment to the hidden messages for the signer's public key.
+// returns:
+// 		commitment in []byte
+// 		blinding factor in []byte
+//		error in case of errors
+func (v *BLS12381G2Verifier) CommitToMessages(messages map[int][]byte, nonce []byte,
+	messagesCount int) ([]byte, []byte, error) {
+	commitment, err := v.bbsPrimitive.CommitToMessages(messages, nonce, v.signerPubKeyBytes, messagesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitment.Commitment, commitment.BlindingFactor, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides the key management of ECDSA secp256k1 (ES256K) signing keys, which Tink doesn't support.
+//
+// The keys are serialized as Tink ECDSA keys with the secp256k1 type URLs below, the curve being implied by the type
+// URL. The signatures are IEEE P1363 encoded (r||s) as expected by JWS. The signing and verification primitives are
+// the Tink signature ones:
+//
+//  package main
+//
+//  import (
+//      "github.com/google/tink/go/keyset"
+//      "github.com/google/tink/go/signature"
+//
+//      "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
+//  )
+//
+//  func main() {
+//      kh, err := keyset.NewHandle(secp256k1.IEEEP1363KeyTemplate())
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      s, err := signature.NewSigner(kh)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      sig, err := s.Sign([]byte("message"))
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      pubKH, err := kh.Public()
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      v, err := signature.NewVerifier(pubKH)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      err = v.Verify(sig, []byte("message"))
+//  }
+package secp256k1
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// TODO - find a better way to setup tink than init.
+// nolint: gochecknoinits
+func init() {
+	// TODO - avoid the tink registry singleton.
+	err := registry.RegisterKeyManager(newSignerKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+
+	err = registry.RegisterKeyManager(newVerifierKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+)
+
+// IEEEP1363KeyTemplate creates a Tink key template for ECDSA secp256k1 keys signing SHA-256 digests with IEEE P1363
+// encoded signatures (ES256K).
+func IEEEP1363KeyTemplate() *tinkpb.KeyTemplate {
+	format := &ecdsapb.EcdsaKeyFormat{
+		Params: &ecdsapb.EcdsaParams{
+			HashType: commonpb.HashType_SHA256,
+			Curve:    commonpb.EllipticCurveType_UNKNOWN_CURVE,
+			Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		},
+	}
+
+	serializedFormat, err := proto.Marshal(format)
+	if err != nil {
+		panic("failed to marshal EcdsaKeyFormat proto")
+	}
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          signerKeyTypeURL,
+		Value:            serializedFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
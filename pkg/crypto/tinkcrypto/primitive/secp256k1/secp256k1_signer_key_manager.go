@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	signerKeyVersion = 0
+	signerKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+)
+
+// common errors.
+var (
+	errInvalidSignerKey       = errors.New("secp256k1_signer_key_manager: invalid key")
+	errInvalidSignerKeyFormat = errors.New("secp256k1_signer_key_manager: invalid key format")
+)
+
+// signerKeyManager is an implementation of KeyManager interface for ECDSA secp256k1 signatures.
+// It generates new secp256k1 private keys and produces new instances of the secp256k1 Signer subtle.
+type signerKeyManager struct{}
+
+// newSignerKeyManager creates a new signerKeyManager.
+func newSignerKeyManager() *signerKeyManager {
+	return new(signerKeyManager)
+}
+
+// Primitive creates a secp256k1 Signer subtle for the given serialized EcdsaPrivateKey proto.
+func (km *signerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSignerKey
+	}
+
+	key := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSignerKey.Error()+": invalid proto: %w", err)
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSignerKey.Error()+": %w", err)
+	}
+
+	privKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: btcec.S256(),
+			X:     new(big.Int).SetBytes(key.PublicKey.X),
+			Y:     new(big.Int).SetBytes(key.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(key.KeyValue),
+	}
+
+	return subtle.NewSigner(privKey), nil
+}
+
+// NewKey creates a new key according to the specification of EcdsaKeyFormat.
+func (km *signerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidSignerKeyFormat
+	}
+
+	keyFormat := new(ecdsapb.EcdsaKeyFormat)
+
+	err := proto.Unmarshal(serializedKeyFormat, keyFormat)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSignerKeyFormat.Error()+": invalid proto: %w", err)
+	}
+
+	err = validateKeyParams(keyFormat.Params)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSignerKeyFormat.Error()+": %w", err)
+	}
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: generate key: %w", err)
+	}
+
+	return &ecdsapb.EcdsaPrivateKey{
+		Version:  signerKeyVersion,
+		KeyValue: privKey.D.Bytes(),
+		PublicKey: &ecdsapb.EcdsaPublicKey{
+			Version: signerKeyVersion,
+			Params:  keyFormat.Params,
+			X:       privKey.X.Bytes(),
+			Y:       privKey.Y.Bytes(),
+		},
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to the specification of EcdsaKeyFormat.
+// It should be used solely by the key management API.
+func (km *signerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: Proto.Marshal failed: %w", err)
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         signerKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *signerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidSignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidSignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         verifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *signerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == signerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *signerKeyManager) TypeURL() string {
+	return signerKeyTypeURL
+}
+
+// validateKey validates the given EcdsaPrivateKey.
+func (km *signerKeyManager) validateKey(key *ecdsapb.EcdsaPrivateKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, signerKeyVersion)
+	if err != nil {
+		return err
+	}
+
+	if key.PublicKey == nil {
+		return errors.New("missing public key")
+	}
+
+	return validateKeyParams(key.PublicKey.Params)
+}
+
+// validateKeyParams validates the parameters of the keys, SHA-256 digests signed with IEEE P1363 encoding being the
+// only ones supported by ES256K.
+func validateKeyParams(params *ecdsapb.EcdsaParams) error {
+	if params == nil {
+		return errors.New("missing params")
+	}
+
+	if params.HashType != commonpb.HashType_SHA256 {
+		return fmt.Errorf("unsupported hash type '%s'", params.HashType)
+	}
+
+	if params.Encoding != ecdsapb.EcdsaSignatureEncoding_IEEE_P1363 {
+		return fmt.Errorf("unsupported signature encoding '%s'", params.Encoding)
+	}
+
+	return nil
+}
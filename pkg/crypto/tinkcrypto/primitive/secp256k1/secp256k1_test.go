@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	kh, err := keyset.NewHandle(IEEEP1363KeyTemplate())
+	require.NoError(t, err)
+
+	s, err := signature.NewSigner(kh)
+	require.NoError(t, err)
+
+	msg := []byte("lorem ipsum")
+
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, 64)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	v, err := signature.NewVerifier(pubKH)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(sig, msg))
+	require.Error(t, v.Verify(sig, []byte("other message")))
+}
+
+func TestSignerKeyManager(t *testing.T) {
+	km := newSignerKeyManager()
+
+	require.True(t, km.DoesSupport(signerKeyTypeURL))
+	require.Equal(t, signerKeyTypeURL, km.TypeURL())
+
+	t.Run("new key", func(t *testing.T) {
+		keyData, err := km.NewKeyData(IEEEP1363KeyTemplate().Value)
+		require.NoError(t, err)
+
+		key := new(ecdsapb.EcdsaPrivateKey)
+		require.NoError(t, proto.Unmarshal(keyData.Value, key))
+
+		// the public key of the key created is a secp256k1 point of the private key
+		x, y := btcec.S256().ScalarBaseMult(key.KeyValue)
+		require.Equal(t, x.Bytes(), key.PublicKey.X)
+		require.Equal(t, y.Bytes(), key.PublicKey.Y)
+
+		pubKeyData, err := km.PublicKeyData(keyData.Value)
+		require.NoError(t, err)
+		require.Equal(t, verifierKeyTypeURL, pubKeyData.TypeUrl)
+	})
+
+	t.Run("invalid key format", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, errInvalidSignerKeyFormat.Error())
+
+		_, err = km.NewKey([]byte("invalid"))
+		require.Error(t, err)
+
+		for _, params := range []*ecdsapb.EcdsaParams{
+			{HashType: commonpb.HashType_SHA512, Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363},
+			{HashType: commonpb.HashType_SHA256, Encoding: ecdsapb.EcdsaSignatureEncoding_DER},
+		} {
+			format, err := proto.Marshal(&ecdsapb.EcdsaKeyFormat{Params: params})
+			require.NoError(t, err)
+
+			_, err = km.NewKey(format)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), errInvalidSignerKeyFormat.Error())
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidSignerKey.Error())
+
+		_, err = km.Primitive([]byte("invalid"))
+		require.Error(t, err)
+
+		key, err := proto.Marshal(&ecdsapb.EcdsaPrivateKey{Version: 1})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(key)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errInvalidSignerKey.Error())
+
+		_, err = km.PublicKeyData([]byte("invalid"))
+		require.EqualError(t, err, errInvalidSignerKey.Error())
+	})
+}
+
+func TestVerifierKeyManager(t *testing.T) {
+	km := newVerifierKeyManager()
+
+	require.True(t, km.DoesSupport(verifierKeyTypeURL))
+	require.Equal(t, verifierKeyTypeURL, km.TypeURL())
+
+	_, err := km.NewKey(nil)
+	require.Error(t, err)
+
+	_, err = km.NewKeyData(nil)
+	require.Error(t, err)
+
+	_, err = km.Primitive(nil)
+	require.EqualError(t, err, errInvalidVerifierKey.Error())
+
+	_, err = km.Primitive([]byte("invalid"))
+	require.EqualError(t, err, errInvalidVerifierKey.Error())
+
+	params := &ecdsapb.EcdsaParams{
+		HashType: commonpb.HashType_SHA256,
+		Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+	}
+
+	key, err := proto.Marshal(&ecdsapb.EcdsaPublicKey{Params: params, X: []byte{1}, Y: []byte{2}})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(key)
+	require.EqualError(t, err, errInvalidVerifierKey.Error()+": point not on curve")
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	key, err = proto.Marshal(&ecdsapb.EcdsaPublicKey{Params: params, X: privKey.X.Bytes(), Y: privKey.Y.Bytes()})
+	require.NoError(t, err)
+
+	v, err := km.Primitive(key)
+	require.NoError(t, err)
+	require.NotNil(t, v)
+}
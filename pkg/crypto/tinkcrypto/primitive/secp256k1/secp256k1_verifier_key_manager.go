@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	verifierKeyVersion = 0
+	verifierKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
+)
+
+// common errors.
+var errInvalidVerifierKey = errors.New("secp256k1_verifier_key_manager: invalid key")
+
+// verifierKeyManager is an implementation of KeyManager interface for ECDSA secp256k1 signature verification.
+// It doesn't support key generation.
+type verifierKeyManager struct{}
+
+// newVerifierKeyManager creates a new verifierKeyManager.
+func newVerifierKeyManager() *verifierKeyManager {
+	return new(verifierKeyManager)
+}
+
+// Primitive creates a secp256k1 Verifier subtle for the given serialized EcdsaPublicKey proto.
+func (km *verifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidVerifierKey
+	}
+
+	key := new(ecdsapb.EcdsaPublicKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidVerifierKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidVerifierKey.Error()+": %w", err)
+	}
+
+	pubKey := &ecdsa.PublicKey{
+		Curve: btcec.S256(),
+		X:     new(big.Int).SetBytes(key.X),
+		Y:     new(big.Int).SetBytes(key.Y),
+	}
+
+	if !pubKey.Curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, fmt.Errorf(errInvalidVerifierKey.Error() + ": point not on curve")
+	}
+
+	return subtle.NewVerifier(pubKey), nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *verifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == verifierKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *verifierKeyManager) TypeURL() string {
+	return verifierKeyTypeURL
+}
+
+// NewKey is not implemented for public key manager.
+func (km *verifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *verifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKeyData not implemented")
+}
+
+// validateKey validates the given EcdsaPublicKey.
+func (km *verifierKeyManager) validateKey(key *ecdsapb.EcdsaPublicKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, verifierKeyVersion)
+	if err != nil {
+		return err
+	}
+
+	return validateKeyParams(key.Params)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package subtle provides the ECDSA secp256k1 signing and verification of SHA-256 digests, with IEEE P1363 encoded
+// signatures (ES256K).
+package subtle
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// keySize is the size of the secp256k1 scalars, in bytes.
+const keySize = 32
+
+// Signer signs messages with a secp256k1 private key.
+type Signer struct {
+	privKey *ecdsa.PrivateKey
+}
+
+// NewSigner returns a Signer of privKey, a secp256k1 private key.
+func NewSigner(privKey *ecdsa.PrivateKey) *Signer {
+	return &Signer{privKey: privKey}
+}
+
+// Sign computes the signature of the SHA-256 digest of data, normalized to the low S form accepted by the bitcoin and
+// ethereum verifiers.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1 sign: %w", err)
+	}
+
+	n := s.privKey.Curve.Params().N
+
+	if sig.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sig = new(big.Int).Sub(n, sig)
+	}
+
+	signature := make([]byte, 2*keySize)
+	r.FillBytes(signature[:keySize])
+	sig.FillBytes(signature[keySize:])
+
+	return signature, nil
+}
+
+// Verifier verifies signatures with a secp256k1 public key.
+type Verifier struct {
+	pubKey *ecdsa.PublicKey
+}
+
+// NewVerifier returns a Verifier of pubKey, a secp256k1 public key.
+func NewVerifier(pubKey *ecdsa.PublicKey) *Verifier {
+	return &Verifier{pubKey: pubKey}
+}
+
+// Verify verifies signature is the signature of the SHA-256 digest of data.
+func (v *Verifier) Verify(signature, data []byte) error {
+	if len(signature) != 2*keySize {
+		return errors.New("secp256k1 verify: invalid signature size")
+	}
+
+	digest := sha256.Sum256(data)
+
+	r := new(big.Int).SetBytes(signature[:keySize])
+	s := new(big.Int).SetBytes(signature[keySize:])
+
+	if !ecdsa.Verify(v.pubKey, digest[:], r, s) {
+		return errors.New("secp256k1 verify: invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestSignVerify(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("lorem ipsum")
+	halfOrder := new(big.Int).Rsh(btcec.S256().N, 1)
+
+	for i := 0; i < 10; i++ {
+		sig, err := NewSigner(privKey).Sign(msg)
+		require.NoError(t, err)
+		require.Len(t, sig, 64)
+
+		// low S form
+		require.True(t, new(big.Int).SetBytes(sig[32:]).Cmp(halfOrder) <= 0)
+
+		require.NoError(t, NewVerifier(&privKey.PublicKey).Verify(sig, msg))
+
+		// the signatures are ES256K ones
+		pubKey := &verifier.PublicKey{
+			Type:  "EcdsaSecp256k1VerificationKey2019",
+			Value: (*btcec.PublicKey)(&privKey.PublicKey).SerializeUncompressed(),
+		}
+		require.NoError(t, verifier.NewECDSASecp256k1SignatureVerifier().Verify(pubKey, msg, sig))
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		v := NewVerifier(&privKey.PublicKey)
+
+		require.EqualError(t, v.Verify([]byte("short"), msg), "secp256k1 verify: invalid signature size")
+		require.EqualError(t, v.Verify(make([]byte, 64), msg), "secp256k1 verify: invalid signature")
+	})
+}
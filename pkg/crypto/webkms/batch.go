@@ -0,0 +1,336 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+const (
+	batchURI = "/batch"
+
+	batchSignOp   = "sign"
+	batchWrapOp   = "wrap"
+	batchUnwrapOp = "unwrap"
+)
+
+// errBatchUnsupported is returned when the operations can't be sent in a multi-op request, for them to be sent one
+// request each.
+var errBatchUnsupported = errors.New("batch requests not supported")
+
+// batchReq is the multi-op request, each operation holding the request body of its single operation endpoint.
+type batchReq struct {
+	Operations []*batchOperation `json:"operations"`
+}
+
+type batchOperation struct {
+	Operation string          `json:"operation"`
+	KeyID     string          `json:"keyID,omitempty"`
+	Request   json.RawMessage `json:"request"`
+}
+
+// batchResp is the multi-op response, each result holding the response body of its single operation endpoint or the
+// error of the operation, in the order of the operations.
+type batchResp struct {
+	Results []*batchResult `json:"results"`
+}
+
+type batchResult struct {
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// SignBatch remotely signs each message with the private key at the keyURL of the same index, in multi-op requests
+// if batching is enabled with webkms.WithBatching().
+// returns:
+// 		signatures in [][]byte, in the order of the messages
+// 		error in case of errors
+func (r *RemoteCrypto) SignBatch(msgs [][]byte, keyURLs []interface{}) ([][]byte, error) {
+	if len(msgs) != len(keyURLs) {
+		return nil, errors.New("sign batch: messages and key URLs count mismatch")
+	}
+
+	startSign := time.Now()
+	ops := make([]*batchOperation, len(msgs))
+
+	for i, msg := range msgs {
+		ops[i] = &batchOperation{Operation: batchSignOp, KeyID: r.batchKeyID(keyURLs[i])}
+
+		if err := r.setBatchRequest(ops[i], signReq{Message: base64.URLEncoding.EncodeToString(msg)}); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := r.postBatch(ops)
+	if errors.Is(err, errBatchUnsupported) {
+		sigs := make([][]byte, len(msgs))
+
+		for i, msg := range msgs {
+			if sigs[i], err = r.Sign(msg, keyURLs[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		return sigs, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := make([][]byte, len(results))
+
+	for i, res := range results {
+		httpResp := &signResp{}
+
+		if err = r.unmarshalFunc(res, httpResp); err != nil {
+			return nil, fmt.Errorf("unmarshal signature %d for SignBatch failed: %w", i, err)
+		}
+
+		if sigs[i], err = base64.URLEncoding.DecodeString(httpResp.Signature); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debugf("overall SignBatch duration: %s", time.Since(startSign))
+
+	return sigs, nil
+}
+
+// WrapKeys remotely wraps cek for each recipient public key, in multi-op requests if batching is enabled with
+// webkms.WithBatching(). 'opts' are the WrapKey options, applied to all recipients.
+// returns:
+// 		RecipientWrappedKeys containing the wrapped cek values, in the order of the recipients
+// 		error in case of errors
+func (r *RemoteCrypto) WrapKeys(cek, apu, apv []byte, recPubKeys []*crypto.PublicKey,
+	opts ...crypto.WrapKeyOpts) ([]*crypto.RecipientWrappedKey, error) {
+	startWrapKeys := time.Now()
+	ops := make([]*batchOperation, len(recPubKeys))
+
+	for i, recPubKey := range recPubKeys {
+		ops[i] = &batchOperation{Operation: batchWrapOp}
+
+		if err := r.setBatchRequest(ops[i], newWrapKeyReq(cek, apu, apv, recPubKey, opts...)); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := r.postBatch(ops)
+	if errors.Is(err, errBatchUnsupported) {
+		wrappedKeys := make([]*crypto.RecipientWrappedKey, len(recPubKeys))
+
+		for i, recPubKey := range recPubKeys {
+			if wrappedKeys[i], err = r.WrapKey(cek, apu, apv, recPubKey, opts...); err != nil {
+				return nil, err
+			}
+		}
+
+		return wrappedKeys, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKeys := make([]*crypto.RecipientWrappedKey, len(results))
+
+	for i, res := range results {
+		if wrappedKeys[i], err = r.buildWrappedKeyResponse(res, r.keystoreURL+batchURI); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debugf("overall WrapKeys duration: %s", time.Since(startWrapKeys))
+
+	return wrappedKeys, nil
+}
+
+// UnwrapKeys remotely unwraps each key in recWKs with the recipient private key at the keyURL of the same index, in
+// multi-op requests if batching is enabled with webkms.WithBatching(). 'opts' are the UnwrapKey options, applied to
+// all keys.
+// returns:
+// 		unwrapped keys in raw bytes, in the order of the wrapped keys
+// 		error in case of errors
+func (r *RemoteCrypto) UnwrapKeys(recWKs []*crypto.RecipientWrappedKey, keyURLs []interface{},
+	opts ...crypto.WrapKeyOpts) ([][]byte, error) {
+	if len(recWKs) != len(keyURLs) {
+		return nil, errors.New("unwrap keys: wrapped keys and key URLs count mismatch")
+	}
+
+	startUnwrapKeys := time.Now()
+	ops := make([]*batchOperation, len(recWKs))
+
+	for i, recWK := range recWKs {
+		ops[i] = &batchOperation{Operation: batchUnwrapOp, KeyID: r.batchKeyID(keyURLs[i])}
+
+		if err := r.setBatchRequest(ops[i], newUnwrapKeyReq(recWK, opts...)); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := r.postBatch(ops)
+	if errors.Is(err, errBatchUnsupported) {
+		keys := make([][]byte, len(recWKs))
+
+		for i, recWK := range recWKs {
+			if keys[i], err = r.UnwrapKey(recWK, keyURLs[i], opts...); err != nil {
+				return nil, err
+			}
+		}
+
+		return keys, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, len(results))
+
+	for i, res := range results {
+		httpResp := &unwrapKeyResp{}
+
+		if err = r.unmarshalFunc(res, httpResp); err != nil {
+			return nil, fmt.Errorf("unmarshal unwrapKeyResp %d for UnwrapKeys failed: %w", i, err)
+		}
+
+		if keys[i], err = base64.URLEncoding.DecodeString(httpResp.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debugf("overall UnwrapKeys duration: %s", time.Since(startUnwrapKeys))
+
+	return keys, nil
+}
+
+// batchKeyID returns the ID of the key at keyURL in the keystore, empty if the key isn't in the keystore.
+func (r *RemoteCrypto) batchKeyID(keyURL interface{}) string {
+	prefix := r.keystoreURL + keysURI + "/"
+	keyURLStr := fmt.Sprintf("%s", keyURL)
+
+	if !strings.HasPrefix(keyURLStr, prefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(keyURLStr, prefix)
+}
+
+func (r *RemoteCrypto) setBatchRequest(op *batchOperation, req interface{}) error {
+	raw, err := r.marshalFunc(req)
+	if err != nil {
+		return fmt.Errorf("marshal %s request for batch failed: %w", op.Operation, err)
+	}
+
+	op.Request = raw
+
+	return nil
+}
+
+// postBatch posts the operations in multi-op requests and returns the responses of the operations, or
+// errBatchUnsupported if they must be sent one request each.
+func (r *RemoteCrypto) postBatch(ops []*batchOperation) ([]json.RawMessage, error) {
+	if !r.opts.Batching || atomic.LoadInt32(&r.batchUnsupported) != 0 {
+		return nil, errBatchUnsupported
+	}
+
+	for _, op := range ops {
+		// keys out of the keystore can't be referenced by a multi-op request of the keystore.
+		if op.Operation != batchWrapOp && op.KeyID == "" {
+			return nil, errBatchUnsupported
+		}
+	}
+
+	maxOps := r.opts.BatchMaxOps
+	if maxOps <= 0 {
+		maxOps = len(ops)
+	}
+
+	results := make([]json.RawMessage, 0, len(ops))
+
+	for start := 0; start < len(ops); start += maxOps {
+		end := start + maxOps
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		chunk, err := r.postBatchChunk(ops[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for i, res := range chunk {
+			if res.Error != "" {
+				return nil, fmt.Errorf("batch %s operation %d failed: %s", ops[start+i].Operation, start+i, res.Error)
+			}
+
+			results = append(results, res.Response)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *RemoteCrypto) postBatchChunk(ops []*batchOperation) ([]*batchResult, error) {
+	destination := r.keystoreURL + batchURI
+
+	httpReqBytes, err := r.marshalFunc(&batchReq{Operations: ops})
+	if err != nil {
+		return nil, fmt.Errorf("marshal batch request failed [%s, %w]", destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	if err != nil {
+		return nil, fmt.Errorf("posting batch request failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "batch")
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		// the key server doesn't support multi-op requests, the operations are sent one by one from now on.
+		if atomic.CompareAndSwapInt32(&r.batchUnsupported, 0, 1) {
+			logger.Infof("key server %s doesn't support batch requests, sending one request per operation",
+				r.keystoreURL)
+		}
+
+		return nil, errBatchUnsupported
+	default:
+		return nil, fmt.Errorf("batch request failed [%s, status %d]", destination, resp.StatusCode)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read batch response failed [%s, %w]", destination, err)
+	}
+
+	httpResp := &batchResp{}
+
+	err = r.unmarshalFunc(respBody, httpResp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal batch response failed [%s, %w]", destination, err)
+	}
+
+	if len(httpResp.Results) != len(ops) {
+		return nil, fmt.Errorf("batch response has %d results for %d operations [%s]", len(httpResp.Results),
+			len(ops), destination)
+	}
+
+	return httpResp.Results, nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	webkmsimpl "github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
+)
+
+// fakeKeyServer signs messages as "<keyID>:<message>" and wraps keys as "<recipient kid>:<cek>".
+type fakeKeyServer struct {
+	mu             sync.Mutex
+	batchSupported bool
+	batchRequests  []int
+	singleRequests int
+	failOp         int
+}
+
+func (s *fakeKeyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == batchURI {
+		if !s.batchSupported {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		req := &batchReq{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		s.batchRequests = append(s.batchRequests, len(req.Operations))
+		resp := &batchResp{}
+
+		for _, op := range req.Operations {
+			res := &batchResult{}
+
+			if s.failOp > 0 && len(resp.Results)+1 == s.failOp {
+				res.Error = "operation failed"
+			} else {
+				res.Response = s.handle(op.Operation, op.KeyID, op.Request)
+			}
+
+			resp.Results = append(resp.Results, res)
+		}
+
+		_ = json.NewEncoder(w).Encode(resp) // nolint:errcheck
+
+		return
+	}
+
+	s.singleRequests++
+
+	body := json.RawMessage{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, keysURI+"/")
+	op := path[strings.LastIndex(path, "/")+1:]
+	keyID := strings.TrimSuffix(path, "/"+op)
+
+	_, _ = w.Write(s.handle(op, keyID, body)) // nolint:errcheck
+}
+
+func (s *fakeKeyServer) handle(op, keyID string, body json.RawMessage) json.RawMessage {
+	var resp interface{}
+
+	switch op {
+	case batchSignOp:
+		req := &signReq{}
+		_ = json.Unmarshal(body, req)                          // nolint:errcheck
+		msg, _ := base64.URLEncoding.DecodeString(req.Message) // nolint:errcheck
+		resp = &signResp{Signature: base64.URLEncoding.EncodeToString([]byte(keyID + ":" + string(msg)))}
+	case batchWrapOp:
+		req := &wrapKeyReq{}
+		_ = json.Unmarshal(body, req)                                // nolint:errcheck
+		kid, _ := base64.URLEncoding.DecodeString(req.RecPubKey.KID) // nolint:errcheck
+		cek, _ := base64.URLEncoding.DecodeString(req.CEK)           // nolint:errcheck
+		resp = &wrapKeyResp{WrappedKey: wrappedKeyToSerializableReq(&crypto.RecipientWrappedKey{
+			KID:          string(kid),
+			EncryptedCEK: []byte(string(kid) + ":" + string(cek)),
+			Alg:          "ECDH-ES+A256KW",
+		})}
+	case batchUnwrapOp:
+		req := &unwrapKeyReq{}
+		_ = json.Unmarshal(body, req)                      // nolint:errcheck
+		wk, _ := serializableToWrappedKey(&req.WrappedKey) // nolint:errcheck
+		resp = &unwrapKeyResp{Key: base64.URLEncoding.EncodeToString(
+			[]byte(strings.TrimPrefix(string(wk.EncryptedCEK), wk.KID+":")))}
+	}
+
+	data, _ := json.Marshal(resp) // nolint:errcheck
+
+	return data
+}
+
+func TestSignBatch(t *testing.T) {
+	msgs := [][]byte{[]byte("msg1"), []byte("msg2"), []byte("msg3")}
+
+	for _, tc := range []struct {
+		name           string
+		opts           []webkmsimpl.Opt
+		batchSupported bool
+		batchRequests  []int
+		singleRequests int
+	}{
+		{name: "batch", opts: []webkmsimpl.Opt{webkmsimpl.WithBatching(0)}, batchSupported: true,
+			batchRequests: []int{3}},
+		{name: "batch in chunks", opts: []webkmsimpl.Opt{webkmsimpl.WithBatching(2)}, batchSupported: true,
+			batchRequests: []int{2, 1}},
+		{name: "batching disabled", batchSupported: true, singleRequests: 3},
+		{name: "fallback if batch unsupported", opts: []webkmsimpl.Opt{webkmsimpl.WithBatching(0)},
+			singleRequests: 3},
+	} {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			server := &fakeKeyServer{batchSupported: tc.batchSupported}
+			srv := httptest.NewServer(server)
+			defer srv.Close()
+
+			rCrypto := New(srv.URL, srv.Client(), tc.opts...)
+			keyURLs := []interface{}{srv.URL + "/keys/k1", srv.URL + "/keys/k2", srv.URL + "/keys/k1"}
+
+			sigs, err := rCrypto.SignBatch(msgs, keyURLs)
+			require.NoError(t, err)
+			require.Equal(t, [][]byte{[]byte("k1:msg1"), []byte("k2:msg2"), []byte("k1:msg3")}, sigs)
+			require.Equal(t, tc.batchRequests, server.batchRequests)
+			require.Equal(t, tc.singleRequests, server.singleRequests)
+		})
+	}
+
+	t.Run("unsupported batch remembered", func(t *testing.T) {
+		server := &fakeKeyServer{}
+		srv := httptest.NewServer(server)
+		defer srv.Close()
+
+		rCrypto := New(srv.URL, srv.Client(), webkmsimpl.WithBatching(0))
+		keyURLs := []interface{}{srv.URL + "/keys/k1", srv.URL + "/keys/k1", srv.URL + "/keys/k1"}
+
+		for i := 0; i < 2; i++ {
+			_, err := rCrypto.SignBatch(msgs, keyURLs)
+			require.NoError(t, err)
+		}
+
+		server.batchSupported = true
+
+		_, err := rCrypto.SignBatch(msgs, keyURLs)
+		require.NoError(t, err)
+		require.Empty(t, server.batchRequests)
+		require.Equal(t, 9, server.singleRequests)
+	})
+
+	t.Run("keys out of the keystore sent one by one", func(t *testing.T) {
+		server := &fakeKeyServer{batchSupported: true}
+		srv := httptest.NewServer(server)
+		defer srv.Close()
+
+		rCrypto := New(srv.URL+"/keystores/ks1", srv.Client(), webkmsimpl.WithBatching(0))
+
+		sigs, err := rCrypto.SignBatch(msgs[:1], []interface{}{srv.URL + "/keys/k1"})
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("k1:msg1")}, sigs)
+		require.Empty(t, server.batchRequests)
+		require.Equal(t, 1, server.singleRequests)
+	})
+
+	t.Run("operation error", func(t *testing.T) {
+		server := &fakeKeyServer{batchSupported: true, failOp: 2}
+		srv := httptest.NewServer(server)
+		defer srv.Close()
+
+		rCrypto := New(srv.URL, srv.Client(), webkmsimpl.WithBatching(0))
+		keyURLs := []interface{}{srv.URL + "/keys/k1", srv.URL + "/keys/k2", srv.URL + "/keys/k1"}
+
+		_, err := rCrypto.SignBatch(msgs, keyURLs)
+		require.EqualError(t, err, "batch sign operation 1 failed: operation failed")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		rCrypto := New(srv.URL, srv.Client(), webkmsimpl.WithBatching(0))
+
+		_, err := rCrypto.SignBatch(msgs[:1], []interface{}{srv.URL + "/keys/k1"})
+		require.EqualError(t, err, "batch request failed ["+srv.URL+"/batch, status 500]")
+	})
+
+	t.Run("messages and key URLs mismatch", func(t *testing.T) {
+		_, err := New("http://localhost", nil).SignBatch(msgs, nil)
+		require.EqualError(t, err, "sign batch: messages and key URLs count mismatch")
+	})
+}
+
+func TestWrapUnwrapKeys(t *testing.T) {
+	cek := []byte("0123456789abcdef0123456789abcdef")
+	recPubKeys := []*crypto.PublicKey{{KID: "rec1"}, {KID: "rec2"}, {KID: "rec3"}}
+
+	for _, batchSupported := range []bool{true, false} {
+		server := &fakeKeyServer{batchSupported: batchSupported}
+		srv := httptest.NewServer(server)
+
+		rCrypto := New(srv.URL, srv.Client(), webkmsimpl.WithBatching(2))
+
+		wrappedKeys, err := rCrypto.WrapKeys(cek, []byte("apu"), []byte("apv"), recPubKeys)
+		require.NoError(t, err)
+		require.Len(t, wrappedKeys, len(recPubKeys))
+
+		keyURLs := make([]interface{}, len(wrappedKeys))
+
+		for i, wk := range wrappedKeys {
+			require.Equal(t, recPubKeys[i].KID, wk.KID)
+
+			keyURLs[i] = srv.URL + "/keys/" + wk.KID
+		}
+
+		keys, err := rCrypto.UnwrapKeys(wrappedKeys, keyURLs)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{cek, cek, cek}, keys)
+
+		if batchSupported {
+			require.Equal(t, []int{2, 1, 2, 1}, server.batchRequests)
+			require.Zero(t, server.singleRequests)
+		} else {
+			require.Empty(t, server.batchRequests)
+			require.Equal(t, 6, server.singleRequests)
+		}
+
+		srv.Close()
+	}
+
+	t.Run("wrapped keys and key URLs mismatch", func(t *testing.T) {
+		_, err := New("http://localhost", nil).UnwrapKeys([]*crypto.RecipientWrappedKey{{}}, nil)
+		require.EqualError(t, err, "unwrap keys: wrapped keys and key URLs count mismatch")
+	})
+}
@@ -117,6 +117,8 @@ type RemoteCrypto struct {
 	marshalFunc   marshalFunc
 	unmarshalFunc unmarshalFunc
 	opts          *webkmsimpl.Opts
+	// batchUnsupported is set once the key server rejects a multi-op request.
+	batchUnsupported int32
 }
 
 const (
@@ -483,57 +485,82 @@ func (r *RemoteCrypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey
 	startWrapKey := time.Now()
 	destination := r.keystoreURL + wrapURI
 
-	pOpts := crypto.NewOpt()
+	wReq := newWrapKeyReq(cek, apu, apv, recPubKey, opts...)
 
-	for _, opt := range opts {
-		opt(pOpts)
+	httpReqBytes, err := r.marshalFunc(wReq)
+	if err != nil {
+		return nil, fmt.Errorf("marshal wrapKeyReq for WrapKey failed [%s, %w]", destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	if err != nil {
+		return nil, fmt.Errorf("posting WrapKey failed [%s, %w]", destination, err)
 	}
 
-	senderURL := pOpts.SenderKey()
-	recipientPubKey := pubKeyToSerializableReq(recPubKey)
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "WrapKey")
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read wrap key response for WrapKey failed [%s, %w]", destination, err)
+	}
+
+	rwk, err := r.buildWrappedKeyResponse(respBody, destination)
+
+	logger.Debugf("overall WrapKey duration: %s", time.Since(startWrapKey))
+
+	return rwk, err
+}
+
+func newWrapKeyReq(cek, apu, apv []byte, recPubKey *crypto.PublicKey, opts ...crypto.WrapKeyOpts) *wrapKeyReq {
 	wReq := &wrapKeyReq{
 		CEK:       base64.URLEncoding.EncodeToString(cek),
 		APU:       base64.URLEncoding.EncodeToString(apu),
 		APV:       base64.URLEncoding.EncodeToString(apv),
-		RecPubKey: recipientPubKey,
+		RecPubKey: pubKeyToSerializableReq(recPubKey),
 	}
 
-	senderURLStr := fmt.Sprintf("%s", senderURL)
-
-	var nilVal interface{}
-
 	// if senderURL is set, extract keyID and add it to the request (for ECDH-1PU wrapping)
-	if senderURLStr != "" && senderURLStr != fmt.Sprintf("%s", nilVal) {
-		senderKID := senderURLStr[strings.LastIndex(senderURLStr, keysURI)+len(keysURI):]
+	if senderKID := senderKeyID(opts...); senderKID != "" {
 		// TODO key server must store the sender public key in the recipient's keystore (or by means of a
 		//  third party store). Need to confirm what needs to be done to make Authcrypt key wrapping work on the
 		//  key server side.
 		wReq.SenderKID = senderKID
 	}
 
-	httpReqBytes, err := r.marshalFunc(wReq)
-	if err != nil {
-		return nil, fmt.Errorf("marshal wrapKeyReq for WrapKey failed [%s, %w]", destination, err)
+	return wReq
+}
+
+func newUnwrapKeyReq(recWK *crypto.RecipientWrappedKey, opts ...crypto.WrapKeyOpts) *unwrapKeyReq {
+	uReq := &unwrapKeyReq{
+		WrappedKey: wrappedKeyToSerializableReq(recWK),
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
-	if err != nil {
-		return nil, fmt.Errorf("posting WrapKey failed [%s, %w]", destination, err)
+	// is senderURL is set, extract keyID and add it to the request (for ECDH-1PU unwrapping)
+	if senderKID := senderKeyID(opts...); senderKID != "" {
+		uReq.SenderKID = base64.URLEncoding.EncodeToString([]byte(senderKID))
 	}
 
-	// handle response
-	defer closeResponseBody(resp.Body, logger, "WrapKey")
+	return uReq
+}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read wrap key response for WrapKey failed [%s, %w]", destination, err)
+// senderKeyID returns the key ID of the sender key URL set in the options, empty if not set.
+func senderKeyID(opts ...crypto.WrapKeyOpts) string {
+	pOpts := crypto.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
 	}
 
-	rwk, err := r.buildWrappedKeyResponse(respBody, destination)
+	senderURLStr := fmt.Sprintf("%s", pOpts.SenderKey())
 
-	logger.Debugf("overall WrapKey duration: %s", time.Since(startWrapKey))
+	var nilVal interface{}
 
-	return rwk, err
+	if senderURLStr == "" || senderURLStr == fmt.Sprintf("%s", nilVal) {
+		return ""
+	}
+
+	return senderURLStr[strings.LastIndex(senderURLStr, keysURI)+len(keysURI):]
 }
 
 func (r *RemoteCrypto) buildWrappedKeyResponse(respBody []byte, dest string) (*crypto.RecipientWrappedKey, error) {
@@ -564,27 +591,7 @@ func (r *RemoteCrypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, keyURL inter
 	startUnwrapKey := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + unwrapURI
 
-	pOpts := crypto.NewOpt()
-
-	for _, opt := range opts {
-		opt(pOpts)
-	}
-
-	senderURL := pOpts.SenderKey()
-	httpWK := wrappedKeyToSerializableReq(recWK)
-	uReq := unwrapKeyReq{
-		WrappedKey: httpWK,
-	}
-
-	senderURLStr := fmt.Sprintf("%s", senderURL)
-
-	var nilVal interface{}
-
-	// is senderURL is set, extract keyID and add it to the request (for ECDH-1PU unwrapping)
-	if senderURLStr != "" && senderURLStr != fmt.Sprintf("%s", nilVal) {
-		senderKID := senderURLStr[strings.LastIndex(senderURLStr, keysURI)+len(keysURI):]
-		uReq.SenderKID = base64.URLEncoding.EncodeToString([]byte(senderKID))
-	}
+	uReq := newUnwrapKeyReq(recWK, opts...)
 
 	httpReqBytes, err := r.marshalFunc(uReq)
 	if err != nil {
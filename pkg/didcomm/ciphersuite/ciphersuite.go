@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ciphersuite records the algorithms negotiated with the other party of a connection: the content encryption
+// and key wrapping algorithms of the JWE envelopes received over the connection are saved in the connection record,
+// and the envelopes protected with an algorithm forbidden by the policy are rejected.
+package ciphersuite
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// ErrForbidden is returned when an envelope is protected with an algorithm forbidden by the policy.
+var ErrForbidden = errors.New("cipher suite is forbidden by the policy")
+
+// ErrNilChannel is returned when registering a nil channel.
+var ErrNilChannel = errors.New("channel is nil")
+
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Policy lists the algorithms which aren't accepted in the inbound JWE envelopes, for example to disallow the
+// A256CBC-HS512 fallback of the authcrypt envelopes.
+type Policy struct {
+	// ForbiddenContentEncryption are the forbidden content encryption algorithms ("enc" header).
+	ForbiddenContentEncryption []string
+	// ForbiddenKeyWrapping are the forbidden key wrapping algorithms ("alg" header).
+	ForbiddenKeyWrapping []string
+}
+
+// RenegotiationEvent is raised when the other party of a connection sends an envelope with other algorithms than the
+// ones recorded for the connection, or with algorithms forbidden by the policy. The application can renegotiate the
+// algorithms with the other party, for example by rotating its DID to keys of another type.
+type RenegotiationEvent struct {
+	ConnectionID string
+	MyDID        string
+	TheirDID     string
+	// Previous is the cipher suite recorded for the connection, nil if none was recorded.
+	Previous *connection.CipherSuite
+	// Current is the cipher suite of the envelope.
+	Current connection.CipherSuite
+	// Forbidden reports whether the envelope was rejected by the policy, the cipher suite isn't recorded then.
+	Forbidden bool
+}
+
+// Recorder records the cipher suites of the connections.
+type Recorder struct {
+	policy      Policy
+	connections *connection.Recorder
+	lock        sync.Mutex
+	eventsLock  sync.RWMutex
+	events      []chan<- RenegotiationEvent
+}
+
+// New returns a new cipher suite recorder enforcing the given policy.
+func New(p provider, policy Policy) (*Recorder, error) {
+	connections, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection recorder: %w", err)
+	}
+
+	return &Recorder{policy: policy, connections: connections}, nil
+}
+
+// RegisterRenegotiationEvent registers a channel receiving the renegotiation events. The events are sent
+// synchronously, blocking the message handling until they are received.
+func (r *Recorder) RegisterRenegotiationEvent(ch chan<- RenegotiationEvent) error {
+	if ch == nil {
+		return ErrNilChannel
+	}
+
+	r.eventsLock.Lock()
+	r.events = append(r.events, ch)
+	r.eventsLock.Unlock()
+
+	return nil
+}
+
+// UnregisterRenegotiationEvent unregisters a channel registered by RegisterRenegotiationEvent.
+func (r *Recorder) UnregisterRenegotiationEvent(ch chan<- RenegotiationEvent) error {
+	r.eventsLock.Lock()
+	for i := 0; i < len(r.events); i++ {
+		if r.events[i] == ch {
+			r.events = append(r.events[:i], r.events[i+1:]...)
+			i--
+		}
+	}
+	r.eventsLock.Unlock()
+
+	return nil
+}
+
+// HandleInboundMessage checks the algorithms of an envelope received over the connection between myDID and theirDID
+// against the policy, and records them in the connection record if they changed. The envelopes which aren't JWE
+// envelopes are ignored, and the algorithms of the envelopes not received over a connection are only checked.
+func (r *Recorder) HandleInboundMessage(envelope *transport.Envelope, myDID, theirDID string) error {
+	if envelope.ContentEncryption == "" && envelope.KeyWrapping == "" {
+		return nil
+	}
+
+	suite := connection.CipherSuite{
+		ContentEncryption: envelope.ContentEncryption,
+		KeyWrapping:       envelope.KeyWrapping,
+	}
+
+	forbidden := r.forbids(suite)
+
+	record, err := r.getConnection(myDID, theirDID)
+	if err != nil {
+		return err
+	}
+
+	if record == nil {
+		if forbidden {
+			return ErrForbidden
+		}
+
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !forbidden && record.CipherSuite != nil && *record.CipherSuite == suite {
+		return nil
+	}
+
+	event := RenegotiationEvent{
+		ConnectionID: record.ConnectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		Previous:     record.CipherSuite,
+		Current:      suite,
+		Forbidden:    forbidden,
+	}
+
+	if !forbidden {
+		record.CipherSuite = &suite
+
+		if err = r.connections.SaveConnectionRecord(record); err != nil {
+			return fmt.Errorf("save connection record: %w", err)
+		}
+	}
+
+	// the first cipher suite recorded for a connection isn't a renegotiation
+	if event.Previous != nil || forbidden {
+		r.notify(event)
+	}
+
+	if forbidden {
+		return fmt.Errorf("connection %s: %w", record.ConnectionID, ErrForbidden)
+	}
+
+	return nil
+}
+
+// CipherSuite returns the cipher suite recorded for the connection, nil if none is recorded.
+func (r *Recorder) CipherSuite(connectionID string) (*connection.CipherSuite, error) {
+	record, err := r.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection record: %w", err)
+	}
+
+	return record.CipherSuite, nil
+}
+
+func (r *Recorder) getConnection(myDID, theirDID string) (*connection.Record, error) {
+	if myDID == "" || theirDID == "" {
+		return nil, nil
+	}
+
+	connectionID, err := r.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get connection ID: %w", err)
+	}
+
+	record, err := r.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *Recorder) forbids(suite connection.CipherSuite) bool {
+	return contains(r.policy.ForbiddenContentEncryption, suite.ContentEncryption) ||
+		contains(r.policy.ForbiddenKeyWrapping, suite.KeyWrapping)
+}
+
+func (r *Recorder) notify(event RenegotiationEvent) {
+	r.eventsLock.RLock()
+	events := append(r.events[:0:0], r.events...)
+	r.eventsLock.RUnlock()
+
+	for _, ch := range events {
+		ch <- event
+	}
+}
+
+func contains(algs []string, alg string) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+
+	return false
+}
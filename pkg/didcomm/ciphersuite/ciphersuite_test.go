@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ciphersuite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID   = "conn-1"
+	aliceDID = "did:test:alice"
+	bobDID   = "did:test:bob"
+
+	xc20p       = "XC20P"
+	a256gcm     = "A256GCM"
+	cbcHS512    = "A256CBC-HS512"
+	ecdh1puKW   = "ECDH-1PU+A256KW"
+	ecdhESKW    = "ECDH-ES+A256KW"
+	unknownDID  = "did:test:unknown"
+	testFailure = "test error"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r, err := New(newProvider(), Policy{})
+		require.NoError(t, err)
+		require.NotNil(t, r)
+	})
+
+	t.Run("error if cannot create the connection recorder", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New(testFailure)},
+		}, Policy{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection recorder")
+	})
+}
+
+func TestRecorder_HandleInboundMessage(t *testing.T) {
+	t.Run("records the cipher suite of the connection", func(t *testing.T) {
+		r := newRecorder(t, Policy{})
+
+		events := make(chan RenegotiationEvent, 1)
+		require.NoError(t, r.RegisterRenegotiationEvent(events))
+
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID))
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID))
+
+		suite, err := r.CipherSuite(connID)
+		require.NoError(t, err)
+		require.Equal(t, &connection.CipherSuite{ContentEncryption: a256gcm, KeyWrapping: ecdhESKW}, suite)
+
+		// the first cipher suite isn't a renegotiation
+		require.Empty(t, events)
+	})
+
+	t.Run("ignores the envelopes which aren't JWE envelopes", func(t *testing.T) {
+		r := newRecorder(t, Policy{ForbiddenContentEncryption: []string{""}})
+
+		require.NoError(t, r.HandleInboundMessage(&transport.Envelope{}, aliceDID, bobDID))
+
+		suite, err := r.CipherSuite(connID)
+		require.NoError(t, err)
+		require.Nil(t, suite)
+	})
+
+	t.Run("ignores the envelopes not received over a connection", func(t *testing.T) {
+		r := newRecorder(t, Policy{})
+
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), "", bobDID))
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, unknownDID))
+
+		suite, err := r.CipherSuite(connID)
+		require.NoError(t, err)
+		require.Nil(t, suite)
+	})
+
+	t.Run("raises a renegotiation event when the cipher suite changes", func(t *testing.T) {
+		r := newRecorder(t, Policy{})
+
+		events := make(chan RenegotiationEvent, 1)
+		require.NoError(t, r.RegisterRenegotiationEvent(events))
+
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID))
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(xc20p, ecdh1puKW), aliceDID, bobDID))
+
+		event := <-events
+		require.Equal(t, connID, event.ConnectionID)
+		require.Equal(t, aliceDID, event.MyDID)
+		require.Equal(t, bobDID, event.TheirDID)
+		require.Equal(t, &connection.CipherSuite{ContentEncryption: a256gcm, KeyWrapping: ecdhESKW}, event.Previous)
+		require.Equal(t, connection.CipherSuite{ContentEncryption: xc20p, KeyWrapping: ecdh1puKW}, event.Current)
+		require.False(t, event.Forbidden)
+
+		suite, err := r.CipherSuite(connID)
+		require.NoError(t, err)
+		require.Equal(t, &event.Current, suite)
+
+		require.NoError(t, r.UnregisterRenegotiationEvent(events))
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID))
+		require.Empty(t, events)
+	})
+
+	t.Run("rejects the forbidden cipher suites", func(t *testing.T) {
+		r := newRecorder(t, Policy{
+			ForbiddenContentEncryption: []string{cbcHS512},
+			ForbiddenKeyWrapping:       []string{ecdhESKW},
+		})
+
+		events := make(chan RenegotiationEvent, 2)
+		require.NoError(t, r.RegisterRenegotiationEvent(events))
+
+		require.NoError(t, r.HandleInboundMessage(newEnvelope(a256gcm, ecdh1puKW), aliceDID, bobDID))
+
+		err := r.HandleInboundMessage(newEnvelope(cbcHS512, ecdh1puKW), aliceDID, bobDID)
+		require.True(t, errors.Is(err, ErrForbidden))
+		require.Contains(t, err.Error(), connID)
+
+		err = r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID)
+		require.True(t, errors.Is(err, ErrForbidden))
+
+		event := <-events
+		require.True(t, event.Forbidden)
+		require.Equal(t, connection.CipherSuite{ContentEncryption: cbcHS512, KeyWrapping: ecdh1puKW}, event.Current)
+
+		event = <-events
+		require.True(t, event.Forbidden)
+
+		// the forbidden cipher suites aren't recorded
+		suite, err := r.CipherSuite(connID)
+		require.NoError(t, err)
+		require.Equal(t, &connection.CipherSuite{ContentEncryption: a256gcm, KeyWrapping: ecdh1puKW}, suite)
+
+		// the policy is enforced without a connection
+		err = r.HandleInboundMessage(newEnvelope(cbcHS512, ecdh1puKW), "", "")
+		require.True(t, errors.Is(err, ErrForbidden))
+	})
+
+	t.Run("error if cannot read the connection record", func(t *testing.T) {
+		p := newProvider()
+		r, err := New(p, Policy{})
+		require.NoError(t, err)
+
+		saveConnection(t, p)
+
+		p.StorageProviderValue.(*mockstorage.MockStoreProvider).Store.ErrGet = errors.New(testFailure)
+
+		err = r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), testFailure)
+
+		_, err = r.CipherSuite(connID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("error if cannot save the connection record", func(t *testing.T) {
+		p := newProvider()
+		r, err := New(p, Policy{})
+		require.NoError(t, err)
+
+		saveConnection(t, p)
+
+		p.StorageProviderValue.(*mockstorage.MockStoreProvider).Store.ErrPut = errors.New(testFailure)
+
+		err = r.HandleInboundMessage(newEnvelope(a256gcm, ecdhESKW), aliceDID, bobDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "save connection record")
+	})
+}
+
+func TestRecorder_RegisterRenegotiationEvent(t *testing.T) {
+	r := newRecorder(t, Policy{})
+
+	require.True(t, errors.Is(r.RegisterRenegotiationEvent(nil), ErrNilChannel))
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+func newRecorder(t *testing.T, policy Policy) *Recorder {
+	t.Helper()
+
+	p := newProvider()
+
+	r, err := New(p, policy)
+	require.NoError(t, err)
+
+	saveConnection(t, p)
+
+	return r
+}
+
+func saveConnection(t *testing.T, p *mockprovider.Provider) {
+	t.Helper()
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        aliceDID,
+		TheirDID:     bobDID,
+	}))
+}
+
+func newEnvelope(enc, alg string) *transport.Envelope {
+	return &transport.Envelope{ContentEncryption: enc, KeyWrapping: alg}
+}
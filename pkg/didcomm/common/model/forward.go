@@ -6,6 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package model
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
 // Forward route forward message.
 // nolint:lll // url in the next line is long
 // https://github.com/hyperledger/aries-rfcs/blob/master/concepts/0094-cross-domain-messaging/README.md#corerouting10forward
@@ -14,4 +18,30 @@ type Forward struct {
 	ID   string `json:"@id,omitempty"`
 	To   string `json:"to,omitempty"`
 	Msg  []byte `json:"msg,omitempty"`
+	// Next is set when the forward message is addressed to a relay chosen by the sender (onion routing). The relay
+	// delivers Msg as is to the next hop instead of looking up To in its routing table.
+	Next *ForwardHop `json:"next,omitempty"`
+}
+
+// ForwardHop is the next hop a relay has to deliver a forwarded message to.
+type ForwardHop struct {
+	ServiceEndpoint string   `json:"serviceEndpoint,omitempty"`
+	RecipientKeys   []string `json:"recipientKeys,omitempty"`
+}
+
+// ForwardV2 is the DIDComm v2 route forward message, the message forwarded is the JSON attachment.
+// https://identity.foundation/didcomm-messaging/spec/#messages
+type ForwardV2 struct {
+	Type        string                   `json:"type,omitempty"`
+	ID          string                   `json:"id,omitempty"`
+	To          []string                 `json:"to,omitempty"`
+	ExpiresTime int64                    `json:"expires_time,omitempty"`
+	Body        ForwardV2Body            `json:"body"`
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// ForwardV2Body is the body of the DIDComm v2 route forward message.
+type ForwardV2Body struct {
+	// Next is the key ID (or DID) of the recipient of the message forwarded.
+	Next string `json:"next"`
 }
@@ -8,3 +8,6 @@ package service
 
 // ForwardMsgType defines the route forward message type.
 const ForwardMsgType = "https://didcomm.org/routing/1.0/forward"
+
+// ForwardV2MsgType defines the DIDComm v2 route forward message type.
+const ForwardV2MsgType = "https://didcomm.org/routing/2.0/forward"
@@ -21,6 +21,13 @@ type Destination struct {
 	TransportReturnRoute string
 	MediaTypeProfiles    []string
 	DIDDoc               *did.Doc
+	// ThreadID is the thread of the message sent to the destination. The message is delivered through a return route
+	// scoped to this thread (see the "thread" transport return route option) if the transport has one.
+	ThreadID string
+	// Relays is an optional, sender chosen, list of relays the message is routed through (in order) before it is
+	// delivered to ServiceEndpoint. Each relay only learns the next hop, which hides the network location of the
+	// sender from the recipient.
+	Relays []*Destination
 }
 
 const (
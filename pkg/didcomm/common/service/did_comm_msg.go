@@ -223,6 +223,11 @@ func (m DIDCommMsgMap) Type() string {
 	return m.typeV2()
 }
 
+// IsDIDCommV2 checks whether the message is a DIDComm v2 message.
+func (m DIDCommMsgMap) IsDIDCommV2() bool {
+	return m.typeV1() == "" && m.typeV2() != ""
+}
+
 // ParentThreadID returns the message parent threadID.
 func (m DIDCommMsgMap) ParentThreadID() string {
 	if m == nil {
@@ -212,6 +212,13 @@ func TestDIDCommMsgMap_Type(t *testing.T) {
 	}
 }
 
+func TestDIDCommMsgMap_IsDIDCommV2(t *testing.T) {
+	require.False(t, DIDCommMsgMap(nil).IsDIDCommV2())
+	require.False(t, DIDCommMsgMap{jsonTypeV1: "Type"}.IsDIDCommV2())
+	require.False(t, DIDCommMsgMap{jsonTypeV1: "Type", jsonTypeV2: "Type"}.IsDIDCommV2())
+	require.True(t, DIDCommMsgMap{jsonTypeV2: "Type"}.IsDIDCommV2())
+}
+
 func TestDIDCommMsgMap_Clone(t *testing.T) {
 	tests := []struct {
 		name     string
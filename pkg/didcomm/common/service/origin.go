@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import "crypto/tls"
+
+// OriginKey is the key under which the inbound message Origin is kept in the DIDCommMsg metadata
+// and in the DIDCommContext properties.
+const OriginKey = "_origin"
+
+// Origin holds transport level information about where an inbound message came from.
+// It allows services and event consumers (eg: policy engines) to make network-aware decisions.
+// NOTE: Origin is not a part of the JSON message and it is never sent to another agent.
+type Origin struct {
+	// Transport is the name of the inbound transport that received the message (eg: http, ws).
+	Transport string
+	// RemoteAddr is the network address of the peer that delivered the message, if known.
+	RemoteAddr string
+	// TLS contains the TLS connection state (including peer certificates) if the message was received over TLS.
+	TLS *tls.ConnectionState
+	// ViaMediator is true when the message was not received directly from the sender but relayed by a mediator.
+	ViaMediator bool
+}
+
+// SetOrigin attaches the inbound origin to the message metadata.
+func SetOrigin(msg DIDCommMsg, origin *Origin) {
+	if msg == nil || origin == nil {
+		return
+	}
+
+	if m, ok := msg.(DIDCommMsgMap); ok && m != nil && m.Metadata() == nil {
+		m[jsonMetadata] = map[string]interface{}{}
+	}
+
+	if metadata := msg.Metadata(); metadata != nil {
+		metadata[OriginKey] = origin
+	}
+}
+
+// GetOrigin returns the inbound origin of the message or nil if the origin is unknown
+// (eg: the message was created locally).
+func GetOrigin(msg DIDCommMsg) *Origin {
+	if msg == nil {
+		return nil
+	}
+
+	origin, ok := msg.Metadata()[OriginKey].(*Origin)
+	if !ok {
+		return nil
+	}
+
+	return origin
+}
+
+// OriginFromContext returns the inbound origin of the message being processed in the given context
+// or nil if the origin is unknown.
+func OriginFromContext(ctx DIDCommContext) *Origin {
+	if ctx == nil {
+		return nil
+	}
+
+	origin, ok := ctx.All()[OriginKey].(*Origin)
+	if !ok {
+		return nil
+	}
+
+	return origin
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestOrigin(t *testing.T) {
+	origin := &service.Origin{
+		Transport:   "http",
+		RemoteAddr:  "10.0.0.1:443",
+		TLS:         &tls.ConnectionState{},
+		ViaMediator: true,
+	}
+
+	t.Run("set and get origin", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(struct {
+			ID string `json:"@id"`
+		}{ID: "id"})
+
+		require.Nil(t, service.GetOrigin(msg))
+
+		service.SetOrigin(msg, origin)
+		require.Equal(t, origin, service.GetOrigin(msg))
+
+		// origin is never serialized
+		raw, err := msg.MarshalJSON()
+		require.NoError(t, err)
+		require.NotContains(t, string(raw), service.OriginKey)
+	})
+
+	t.Run("set origin on message without metadata", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{"@id": "id"}
+
+		service.SetOrigin(msg, origin)
+		require.Equal(t, origin, service.GetOrigin(msg))
+	})
+
+	t.Run("nil values", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			service.SetOrigin(nil, origin)
+			service.SetOrigin(service.DIDCommMsgMap(nil), origin)
+			service.SetOrigin(service.DIDCommMsgMap{}, nil)
+		})
+		require.Nil(t, service.GetOrigin(nil))
+		require.Nil(t, service.OriginFromContext(nil))
+	})
+
+	t.Run("origin from context", func(t *testing.T) {
+		require.Nil(t, service.OriginFromContext(service.EmptyDIDCommContext()))
+
+		ctx := service.NewDIDCommContext("myDID", "theirDID", map[string]interface{}{service.OriginKey: origin})
+		require.Equal(t, origin, service.OriginFromContext(ctx))
+	})
+}
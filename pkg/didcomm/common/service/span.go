@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import "go.opentelemetry.io/otel/trace"
+
+// SpanContextKey is the key under which the span context of the inbound message handling is kept in the DIDCommMsg
+// metadata and in the DIDCommContext properties. The outbound dispatcher continues the trace of the span context
+// attached to the messages it sends, so that the replies are part of the trace of the inbound message.
+const SpanContextKey = "_span_context"
+
+// SetSpanContext attaches the span context to the message metadata, the invalid span contexts are ignored.
+func SetSpanContext(msg DIDCommMsg, sc trace.SpanContext) {
+	if msg == nil || !sc.IsValid() {
+		return
+	}
+
+	if m, ok := msg.(DIDCommMsgMap); ok && m != nil && m.Metadata() == nil {
+		m[jsonMetadata] = map[string]interface{}{}
+	}
+
+	if metadata := msg.Metadata(); metadata != nil {
+		metadata[SpanContextKey] = sc
+	}
+}
+
+// GetSpanContext returns the span context attached to the message or an invalid span context if there is none.
+func GetSpanContext(msg DIDCommMsg) trace.SpanContext {
+	if msg == nil {
+		return trace.SpanContext{}
+	}
+
+	sc, ok := msg.Metadata()[SpanContextKey].(trace.SpanContext)
+	if !ok {
+		return trace.SpanContext{}
+	}
+
+	return sc
+}
+
+// SpanContextFromContext returns the span context of the inbound message handling in the given context or an invalid
+// span context if the message isn't traced.
+func SpanContextFromContext(ctx DIDCommContext) trace.SpanContext {
+	if ctx == nil {
+		return trace.SpanContext{}
+	}
+
+	sc, ok := ctx.All()[SpanContextKey].(trace.SpanContext)
+	if !ok {
+		return trace.SpanContext{}
+	}
+
+	return sc
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestSpanContext(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+
+	t.Run("set and get span context", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{"@id": "id"}
+
+		require.False(t, service.GetSpanContext(msg).IsValid())
+
+		service.SetSpanContext(msg, sc)
+		require.Equal(t, sc, service.GetSpanContext(msg))
+
+		// the span context is never serialized
+		raw, err := msg.MarshalJSON()
+		require.NoError(t, err)
+		require.NotContains(t, string(raw), service.SpanContextKey)
+	})
+
+	t.Run("invalid span context is ignored", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{"@id": "id"}
+
+		service.SetSpanContext(msg, trace.SpanContext{})
+		require.Nil(t, msg.Metadata())
+	})
+
+	t.Run("nil values", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			service.SetSpanContext(nil, sc)
+			service.SetSpanContext(service.DIDCommMsgMap(nil), sc)
+		})
+		require.False(t, service.GetSpanContext(nil).IsValid())
+		require.False(t, service.SpanContextFromContext(nil).IsValid())
+	})
+
+	t.Run("span context from context", func(t *testing.T) {
+		require.False(t, service.SpanContextFromContext(service.EmptyDIDCommContext()).IsValid())
+
+		ctx := service.NewDIDCommContext("myDID", "theirDID", map[string]interface{}{service.SpanContextKey: sc})
+		require.Equal(t, sc, service.SpanContextFromContext(ctx))
+	})
+}
@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package deeplink renders DIDComm invitations as links opening a wallet app, and parses them back on the receiving
+// side.
+//
+// An invitation is rendered as:
+//  - a 'didcomm://' deep link, opening the app registered for the scheme.
+//  - an HTTPS URL on the inviter's domain, opened by the app when it is associated with the domain (Android App Links,
+//    iOS Universal Links), or by the browser otherwise. The Landing payload holds what the page served at that URL
+//    shows when the app is not installed: the invitation label, the deep link and the app store links.
+//
+// Both carry the base64url encoded invitation in the 'oob' query parameter (Aries RFC 0434) for out-of-band
+// invitations, or 'c_i' (Aries RFC 0160) for connection invitations.
+package deeplink
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+)
+
+const (
+	// Scheme of the deep links.
+	Scheme = "didcomm"
+	// DefaultHost of the deep links.
+	DefaultHost = "invite"
+
+	// OOBParam is the query parameter of out-of-band invitations.
+	OOBParam = "oob"
+	// ConnectionsParam is the query parameter of connection invitations.
+	ConnectionsParam = "c_i"
+	// MessageParam is the query parameter of connectionless messages, used by some agents for invitations.
+	MessageParam = "d_m"
+
+	sovPrefix     = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/"
+	didcommPrefix = "https://didcomm.org/"
+
+	connectionsInvitationMsgType = "https://didcomm.org/connections/1.0/invitation"
+)
+
+// StoreLink is the link to the wallet app in an app store.
+type StoreLink struct {
+	// Platform of the store, e.g. "android" or "ios".
+	Platform string `json:"platform"`
+	URL      string `json:"url"`
+}
+
+// Landing is the payload of the page served at the HTTPS URL of the invitation, for the users who don't have the app.
+type Landing struct {
+	Label      string          `json:"label,omitempty"`
+	Goal       string          `json:"goal,omitempty"`
+	GoalCode   string          `json:"goalCode,omitempty"`
+	ImageURL   string          `json:"imageUrl,omitempty"`
+	DeepLink   string          `json:"deepLink"`
+	URL        string          `json:"url,omitempty"`
+	Stores     []StoreLink     `json:"stores,omitempty"`
+	Invitation json.RawMessage `json:"invitation"`
+}
+
+// Link is an invitation rendered as links.
+type Link struct {
+	// DeepLink is the 'didcomm://' link of the invitation.
+	DeepLink string `json:"deepLink"`
+	// URL is the HTTPS link of the invitation, set when created WithBaseURL.
+	URL string `json:"url,omitempty"`
+	// Landing is the payload of the page served at URL.
+	Landing *Landing `json:"landing"`
+}
+
+type options struct {
+	host    string
+	baseURL string
+	stores  []StoreLink
+}
+
+// Opt configures the links created.
+type Opt func(opts *options)
+
+// WithHost sets the host of the deep link, DefaultHost by default.
+func WithHost(host string) Opt {
+	return func(opts *options) {
+		opts.host = host
+	}
+}
+
+// WithBaseURL sets the HTTPS URL the invitation is appended to, e.g. "https://example.com/invite". The domain must be
+// associated with the app for the URL to open it.
+func WithBaseURL(baseURL string) Opt {
+	return func(opts *options) {
+		opts.baseURL = baseURL
+	}
+}
+
+// WithStoreLinks sets the app store links of the landing payload.
+func WithStoreLinks(stores ...StoreLink) Opt {
+	return func(opts *options) {
+		opts.stores = stores
+	}
+}
+
+// header of the invitations, the fields of the landing payload.
+type header struct {
+	Type     string `json:"@type"`
+	Label    string `json:"label"`
+	Goal     string `json:"goal"`
+	GoalCode string `json:"goal_code"`
+	ImageURL string `json:"imageUrl"`
+}
+
+// Create renders an out-of-band or connection invitation as links. The invitation is any value marshalled to the
+// invitation message, e.g. *outofband.Invitation or *didexchange.Invitation.
+func Create(invitation interface{}, opts ...Opt) (*Link, error) {
+	o := &options{host: DefaultHost}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	invBytes, err := json.Marshal(invitation)
+	if err != nil {
+		return nil, fmt.Errorf("marshal invitation: %w", err)
+	}
+
+	h := &header{}
+
+	if err = json.Unmarshal(invBytes, h); err != nil {
+		return nil, fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	var param string
+
+	switch normalizeType(h.Type) {
+	case outofband.InvitationMsgType:
+		param = OOBParam
+	case didexchange.InvitationMsgType, connectionsInvitationMsgType:
+		param = ConnectionsParam
+	default:
+		return nil, fmt.Errorf("unsupported invitation type: %s", h.Type)
+	}
+
+	query := url.Values{param: {base64.RawURLEncoding.EncodeToString(invBytes)}}
+
+	link := &Link{
+		DeepLink: (&url.URL{Scheme: Scheme, Host: o.host, RawQuery: query.Encode()}).String(),
+	}
+
+	if o.baseURL != "" {
+		link.URL, err = httpsURL(o.baseURL, param, query.Get(param))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	link.Landing = &Landing{
+		Label:      h.Label,
+		Goal:       h.Goal,
+		GoalCode:   h.GoalCode,
+		ImageURL:   h.ImageURL,
+		DeepLink:   link.DeepLink,
+		URL:        link.URL,
+		Stores:     o.stores,
+		Invitation: invBytes,
+	}
+
+	return link, nil
+}
+
+// httpsURL returns the base URL with the encoded invitation set in its query.
+func httpsURL(baseURL, param, value string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("base URL must be an absolute https URL: %s", baseURL)
+	}
+
+	q := u.Query()
+	q.Set(param, value)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// normalizeType returns the message type with the legacy 'did:sov' prefix replaced by the 'https://didcomm.org/' one.
+func normalizeType(t string) string {
+	if strings.HasPrefix(t, sovPrefix) {
+		return didcommPrefix + strings.TrimPrefix(t, sovPrefix)
+	}
+
+	return t
+}
@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deeplink
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+)
+
+func oobInvitation() *outofband.Invitation {
+	return &outofband.Invitation{
+		ID:        "a0c8e5f6-1b6e-4c8c-9b5c-3b7f1d3c8e01",
+		Type:      outofband.InvitationMsgType,
+		Label:     "Faber College",
+		Goal:      "To issue a Faber College Graduate credential",
+		GoalCode:  "issue-vc",
+		Services:  []interface{}{"did:example:faber"},
+		Protocols: []string{didexchange.PIURI},
+	}
+}
+
+func connectionInvitation() *didexchange.Invitation {
+	return &didexchange.Invitation{
+		ID:              "12345678900987654321",
+		Type:            "https://didcomm.org/connections/1.0/invitation",
+		Label:           "Alice",
+		ImageURL:        "https://example.com/alice.png",
+		RecipientKeys:   []string{"8HH5gYEeNc3z7PYXmd54d4x6qAfCNrqQqEB3nS7Zfu7K"},
+		ServiceEndpoint: "https://example.com/endpoint",
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("out-of-band invitation", func(t *testing.T) {
+		link, err := Create(oobInvitation(), WithBaseURL("https://example.com/invite?lang=en"),
+			WithStoreLinks(StoreLink{Platform: "android", URL: "https://play.google.com/store/apps/details?id=wallet"}))
+		require.NoError(t, err)
+
+		deepLink, err := url.Parse(link.DeepLink)
+		require.NoError(t, err)
+		require.Equal(t, Scheme, deepLink.Scheme)
+		require.Equal(t, DefaultHost, deepLink.Host)
+		require.NotEmpty(t, deepLink.Query().Get(OOBParam))
+
+		httpsURL, err := url.Parse(link.URL)
+		require.NoError(t, err)
+		require.Equal(t, "example.com", httpsURL.Host)
+		require.Equal(t, "/invite", httpsURL.Path)
+		require.Equal(t, "en", httpsURL.Query().Get("lang"))
+		require.Equal(t, deepLink.Query().Get(OOBParam), httpsURL.Query().Get(OOBParam))
+
+		require.Equal(t, "Faber College", link.Landing.Label)
+		require.Equal(t, "issue-vc", link.Landing.GoalCode)
+		require.Equal(t, link.DeepLink, link.Landing.DeepLink)
+		require.Equal(t, link.URL, link.Landing.URL)
+		require.Len(t, link.Landing.Stores, 1)
+
+		landing, err := json.Marshal(link.Landing)
+		require.NoError(t, err)
+		require.Contains(t, string(landing), `"invitation":{"@id":"a0c8e5f6-1b6e-4c8c-9b5c-3b7f1d3c8e01"`)
+	})
+
+	t.Run("connection invitation", func(t *testing.T) {
+		link, err := Create(connectionInvitation(), WithHost("connect"))
+		require.NoError(t, err)
+		require.Empty(t, link.URL)
+		require.Equal(t, "https://example.com/alice.png", link.Landing.ImageURL)
+
+		deepLink, err := url.Parse(link.DeepLink)
+		require.NoError(t, err)
+		require.Equal(t, "connect", deepLink.Host)
+		require.NotEmpty(t, deepLink.Query().Get(ConnectionsParam))
+	})
+
+	t.Run("legacy invitation type", func(t *testing.T) {
+		inv := oobInvitation()
+		inv.Type = outofband.OldInvitationMsgType
+
+		link, err := Create(inv)
+		require.NoError(t, err)
+		require.Contains(t, link.DeepLink, OOBParam+"=")
+	})
+
+	t.Run("error - unsupported invitation type", func(t *testing.T) {
+		_, err := Create(map[string]string{"@type": "https://didcomm.org/basicmessage/1.0/message"})
+		require.EqualError(t, err, "unsupported invitation type: https://didcomm.org/basicmessage/1.0/message")
+	})
+
+	t.Run("error - invalid base URL", func(t *testing.T) {
+		_, err := Create(oobInvitation(), WithBaseURL("http://example.com/invite"))
+		require.EqualError(t, err, "base URL must be an absolute https URL: http://example.com/invite")
+
+		_, err = Create(oobInvitation(), WithBaseURL("/invite"))
+		require.EqualError(t, err, "base URL must be an absolute https URL: /invite")
+	})
+
+	t.Run("error - invitation not marshallable", func(t *testing.T) {
+		_, err := Create(make(chan int))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal invitation")
+
+		_, err = Create([]string{"invitation"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal invitation")
+	})
+}
+
+func TestParse(t *testing.T) {
+	oobBytes, err := json.Marshal(oobInvitation())
+	require.NoError(t, err)
+
+	link, err := Create(oobInvitation(), WithBaseURL("https://example.com/invite"))
+	require.NoError(t, err)
+
+	connLink, err := Create(connectionInvitation())
+	require.NoError(t, err)
+
+	t.Run("success - out-of-band invitation", func(t *testing.T) {
+		for name, l := range map[string]string{
+			"deep link":            link.DeepLink,
+			"https URL":            link.URL,
+			"padded base64url":     "https://example.com?oob=" + base64.URLEncoding.EncodeToString(oobBytes),
+			"base64":               "didcomm://invite?oob=" + url.QueryEscape(base64.StdEncoding.EncodeToString(oobBytes)),
+			"base64 not escaped":   "didcomm://invite?oob=" + base64.StdEncoding.EncodeToString(oobBytes),
+			"URL encoded JSON":     "https://example.com?oob=" + url.QueryEscape(string(oobBytes)),
+			"connectionless param": "https://example.com?d_m=" + base64.RawURLEncoding.EncodeToString(oobBytes),
+			"fragment":             "https://example.com/#oob=" + base64.RawURLEncoding.EncodeToString(oobBytes),
+			"parameter value":      " " + base64.RawURLEncoding.EncodeToString(oobBytes) + "\n",
+			"JSON":                 string(oobBytes),
+		} {
+			inv, err := Parse(l)
+			require.NoError(t, err, name)
+			require.Nil(t, inv.DIDExchange, name)
+			require.Equal(t, oobInvitation(), inv.OOB, name)
+		}
+	})
+
+	t.Run("success - connection invitation", func(t *testing.T) {
+		inv, err := Parse(connLink.DeepLink)
+		require.NoError(t, err)
+		require.Nil(t, inv.OOB)
+		require.Equal(t, connectionInvitation(), inv.DIDExchange)
+
+		legacy := connectionInvitation()
+		legacy.Type = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/connections/1.0/invitation"
+
+		legacyBytes, err := json.Marshal(legacy)
+		require.NoError(t, err)
+
+		inv, err = Parse("https://example.com?c_i=" + base64.URLEncoding.EncodeToString(legacyBytes))
+		require.NoError(t, err)
+		require.Equal(t, legacy, inv.DIDExchange)
+	})
+
+	t.Run("error - empty link", func(t *testing.T) {
+		_, err := Parse(" ")
+		require.EqualError(t, err, "empty link")
+	})
+
+	t.Run("error - no invitation in link", func(t *testing.T) {
+		_, err := Parse("https://example.com/invite?lang=en")
+		require.EqualError(t, err,
+			"no invitation in link: expected one of the 'oob', 'c_i' or 'd_m' parameters")
+	})
+
+	t.Run("error - invalid encoding", func(t *testing.T) {
+		_, err := Parse("didcomm://invite?oob=%21%21")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode invitation")
+	})
+
+	t.Run("error - invalid invitation", func(t *testing.T) {
+		_, err := Parse("{invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal invitation")
+
+		_, err = Parse(`{"@type":"https://didcomm.org/out-of-band/1.0/invitation","services":"invalid"}`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal invitation")
+	})
+
+	t.Run("error - unsupported invitation type", func(t *testing.T) {
+		_, err := Parse(`{"@type":"https://didcomm.org/basicmessage/1.0/message"}`)
+		require.EqualError(t, err, "unsupported invitation type: https://didcomm.org/basicmessage/1.0/message")
+	})
+}
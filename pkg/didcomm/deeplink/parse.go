@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deeplink
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+)
+
+// Invitation parsed from a link, either an out-of-band or a connection invitation.
+type Invitation struct {
+	OOB         *outofband.Invitation
+	DIDExchange *didexchange.Invitation
+}
+
+// Parse parses the invitation of a link scanned or opened by the wallet app. It accepts:
+//  - 'didcomm://' deep links and HTTP(S) URLs, with the invitation in the 'oob', 'c_i' or 'd_m' query parameter, or in
+//    the URL fragment.
+//  - the value of these parameters alone.
+//  - the invitation JSON.
+// The invitation is base64url or base64 encoded, padded or not, or URL encoded JSON.
+func Parse(link string) (*Invitation, error) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return nil, errors.New("empty link")
+	}
+
+	value := link
+
+	if u, err := url.Parse(link); err == nil && u.Scheme != "" {
+		value, err = invitationParam(u)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	invBytes, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInvitation(invBytes)
+}
+
+// invitationParam returns the value of the first invitation parameter of the link's query, then of its fragment.
+func invitationParam(u *url.URL) (string, error) {
+	fragment, err := url.ParseQuery(u.Fragment)
+	if err != nil {
+		fragment = url.Values{}
+	}
+
+	for _, values := range []url.Values{u.Query(), fragment} {
+		for _, param := range []string{OOBParam, ConnectionsParam, MessageParam} {
+			if v := values.Get(param); v != "" {
+				return v, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no invitation in link: expected one of the '%s', '%s' or '%s' parameters",
+		OOBParam, ConnectionsParam, MessageParam)
+}
+
+// decode returns the invitation JSON of an encoded parameter value.
+func decode(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "{") {
+		return []byte(value), nil
+	}
+
+	// a '+' of standard base64 that was not URL encoded in the link is read as a space
+	value = strings.ReplaceAll(value, " ", "+")
+	// decoding as unpadded base64url accepts the four variants of base64
+	value = strings.NewReplacer("+", "-", "/", "_").Replace(strings.TrimRight(value, "="))
+
+	invBytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode invitation: %w", err)
+	}
+
+	return invBytes, nil
+}
+
+func parseInvitation(invBytes []byte) (*Invitation, error) {
+	h := &header{}
+
+	if err := json.Unmarshal(invBytes, h); err != nil {
+		return nil, fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	inv := &Invitation{}
+
+	var target interface{}
+
+	switch normalizeType(h.Type) {
+	case outofband.InvitationMsgType:
+		inv.OOB = &outofband.Invitation{}
+		target = inv.OOB
+	case didexchange.InvitationMsgType, connectionsInvitationMsgType:
+		inv.DIDExchange = &didexchange.Invitation{}
+		target = inv.DIDExchange
+	default:
+		return nil, fmt.Errorf("unsupported invitation type: %s", h.Type)
+	}
+
+	if err := json.Unmarshal(invBytes, target); err != nil {
+		return nil, fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return inv, nil
+}
@@ -0,0 +1,343 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package didrotate implements the DID rotation of DIDComm v2 connections: the party rotating its DID signs a
+// from_prior JWT with a key of its previous DID, the JWT is sent in the from_prior header of its DIDComm v2 messages
+// until the other party sends a message to the new DID.
+package didrotate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// FromPriorJSONKey is the DIDComm v2 message header holding the from_prior JWT.
+	FromPriorJSONKey = "from_prior"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	jsonWebKey2020             = "JsonWebKey2020"
+	ed25519Curve               = "Ed25519"
+	signatureEdDSA             = "EdDSA"
+	jsonFrom                   = "from"
+)
+
+type provider interface {
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	VDRegistry() vdrapi.Registry
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// DIDRotator rotates the DIDs of DIDComm v2 connections and handles the DID rotations of the other parties.
+type DIDRotator struct {
+	kms         kms.KeyManager
+	crypto      crypto.Crypto
+	vdr         vdrapi.Registry
+	connections *connection.Recorder
+}
+
+// New returns a new DID rotator.
+func New(p provider) (*DIDRotator, error) {
+	connections, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection recorder: %w", err)
+	}
+
+	return &DIDRotator{
+		kms:         p.KMS(),
+		crypto:      p.Crypto(),
+		vdr:         p.VDRegistry(),
+		connections: connections,
+	}, nil
+}
+
+// RotateConnectionDID rotates my DID of the connection to newDID. The from_prior JWT is signed with the key signingKID
+// of the current DID, an empty signingKID selects its first Ed25519 authentication key.
+func (r *DIDRotator) RotateConnectionDID(connectionID, signingKID, newDID string) error {
+	record, err := r.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return fmt.Errorf("connection %s is not completed", connectionID)
+	}
+
+	if record.MyDIDRotation != nil {
+		return fmt.Errorf("rotation of DID %s to %s is not acknowledged yet",
+			record.MyDIDRotation.OldDID, record.MyDIDRotation.NewDID)
+	}
+
+	if newDID == record.MyDID {
+		return errors.New("new DID is the connection DID")
+	}
+
+	if _, err = r.vdr.Resolve(newDID); err != nil {
+		return fmt.Errorf("resolve new DID: %w", err)
+	}
+
+	fromPrior, err := r.createFromPrior(record.MyDID, signingKID, newDID)
+	if err != nil {
+		return err
+	}
+
+	record.MyDIDRotation = &connection.DIDRotationRecord{
+		OldDID:    record.MyDID,
+		NewDID:    newDID,
+		FromPrior: fromPrior,
+	}
+	record.MyDID = newDID
+
+	if err = r.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	return nil
+}
+
+// HandleInboundMessage handles the from_prior header of an inbound DIDComm v2 message, updating their DID of the
+// connection, and marks my DID rotation as acknowledged once the other party sends a message to my new DID.
+func (r *DIDRotator) HandleInboundMessage(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if !msg.IsDIDCommV2() || myDID == "" {
+		return nil
+	}
+
+	if fromPrior, ok := msg[FromPriorJSONKey].(string); ok && fromPrior != "" {
+		if err := r.handleFromPrior(msg, fromPrior, myDID, theirDID); err != nil {
+			return fmt.Errorf("handle from_prior: %w", err)
+		}
+	}
+
+	if theirDID == "" {
+		return nil
+	}
+
+	connID, err := r.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get connection ID: %w", err)
+	}
+
+	record, err := r.connections.GetConnectionRecord(connID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if record.MyDIDRotation == nil || record.MyDID != myDID {
+		return nil
+	}
+
+	record.MyDIDRotation = nil
+
+	if err = r.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DIDRotator) handleFromPrior(msg service.DIDCommMsgMap, fromPrior, myDID, theirDID string) error {
+	token, err := jwt.Parse(fromPrior, jwt.WithSignatureVerifier(jwt.NewVerifier(jwt.KeyResolverFunc(r.resolveKey))))
+	if err != nil {
+		return fmt.Errorf("parse from_prior JWT: %w", err)
+	}
+
+	claims := &jwt.Claims{}
+
+	if err = token.DecodeClaims(claims); err != nil {
+		return fmt.Errorf("decode from_prior claims: %w", err)
+	}
+
+	if claims.Subject == "" || claims.Subject == claims.Issuer {
+		return errors.New("invalid from_prior subject")
+	}
+
+	if claims.IssuedAt == nil || claims.IssuedAt.Time().After(time.Now().Add(josejwt.DefaultLeeway)) {
+		return errors.New("invalid from_prior issued at time")
+	}
+
+	if theirDID != "" && theirDID != claims.Subject {
+		return fmt.Errorf("sender %s is not the from_prior subject", theirDID)
+	}
+
+	if from, ok := msg[jsonFrom].(string); ok && from != claims.Subject {
+		return fmt.Errorf("message from %s is not the from_prior subject", from)
+	}
+
+	connID, err := r.connections.GetConnectionIDByDIDs(myDID, claims.Issuer)
+	if err != nil {
+		return fmt.Errorf("get connection ID of the prior DID: %w", err)
+	}
+
+	record, err := r.connections.GetConnectionRecord(connID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	switch record.TheirDID {
+	case claims.Subject:
+		// the rotation has already been applied, the other party sends from_prior until it is acknowledged.
+		return nil
+	case claims.Issuer:
+	default:
+		return fmt.Errorf("from_prior issuer %s is not the connection DID", claims.Issuer)
+	}
+
+	if _, err = r.vdr.Resolve(claims.Subject); err != nil {
+		return fmt.Errorf("resolve from_prior subject: %w", err)
+	}
+
+	record.TheirDID = claims.Subject
+
+	if err = r.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DIDRotator) createFromPrior(oldDID, signingKID, newDID string) (string, error) {
+	doc, err := r.vdr.Resolve(oldDID)
+	if err != nil {
+		return "", fmt.Errorf("resolve DID: %w", err)
+	}
+
+	vm, err := signingKey(doc.DIDDocument, signingKID)
+	if err != nil {
+		return "", err
+	}
+
+	kmsKID, err := localkms.CreateKID(vm.Value, kms.ED25519Type)
+	if err != nil {
+		return "", fmt.Errorf("create KMS key ID: %w", err)
+	}
+
+	kh, err := r.kms.Get(kmsKID)
+	if err != nil {
+		return "", fmt.Errorf("get signing key handle: %w", err)
+	}
+
+	claims := &jwt.Claims{
+		Issuer:   oldDID,
+		Subject:  newDID,
+		IssuedAt: josejwt.NewNumericDate(time.Now()),
+	}
+
+	token, err := jwt.NewSigned(claims, nil, &signer{
+		crypto: r.crypto,
+		kh:     kh,
+		kid:    absoluteID(oldDID, vm.ID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("sign from_prior JWT: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+func (r *DIDRotator) resolveKey(issuer, kid string) (*verifier.PublicKey, error) {
+	if kid != "" && !strings.HasPrefix(kid, "#") && !strings.HasPrefix(kid, issuer+"#") {
+		return nil, fmt.Errorf("key %s is not a key of the issuer %s", kid, issuer)
+	}
+
+	doc, err := r.vdr.Resolve(issuer)
+	if err != nil {
+		return nil, fmt.Errorf("resolve issuer DID: %w", err)
+	}
+
+	vm, err := signingKey(doc.DIDDocument, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifier.PublicKey{Type: vm.Type, Value: vm.Value, JWK: vm.JSONWebKey()}, nil
+}
+
+// signingKey returns the Ed25519 authentication key kid of the DID document, referenced or embedded, or its first
+// Ed25519 authentication key when kid is empty.
+func signingKey(doc *did.Doc, kid string) (*did.VerificationMethod, error) {
+	for i := range doc.Authentication {
+		vm := &doc.Authentication[i].VerificationMethod
+
+		if kid == "" {
+			if isEd25519(vm) {
+				return vm, nil
+			}
+
+			continue
+		}
+
+		if absoluteID(doc.ID, vm.ID) == absoluteID(doc.ID, kid) {
+			if !isEd25519(vm) {
+				return nil, fmt.Errorf("key %s is not an Ed25519 key", kid)
+			}
+
+			return vm, nil
+		}
+	}
+
+	if kid != "" {
+		return nil, fmt.Errorf("authentication key %s not found in DID %s", kid, doc.ID)
+	}
+
+	return nil, fmt.Errorf("no Ed25519 authentication key found in DID %s", doc.ID)
+}
+
+func isEd25519(vm *did.VerificationMethod) bool {
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return true
+	case jsonWebKey2020:
+		return vm.JSONWebKey() != nil && vm.JSONWebKey().Crv == ed25519Curve
+	default:
+		return false
+	}
+}
+
+func absoluteID(didID, id string) string {
+	if strings.HasPrefix(id, "#") {
+		return didID + id
+	}
+
+	return id
+}
+
+// signer signs the from_prior JWT with the crypto service.
+type signer struct {
+	crypto crypto.Crypto
+	kh     interface{}
+	kid    string
+}
+
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.kh)
+}
+
+func (s *signer) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm: signatureEdDSA,
+		jose.HeaderKeyID:     s.kid,
+	}
+}
@@ -0,0 +1,432 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didrotate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID    = "conn-1"
+	aliceDID  = "did:test:alice"
+	alice2DID = "did:test:alice2"
+	bobDID    = "did:test:bob"
+	msgType   = "https://didcomm.org/basicmessage/2.0/message"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r, err := New(newAgent(t, newKMS(t), nil).provider)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+	})
+
+	t.Run("error if cannot open the connection store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection recorder")
+	})
+}
+
+func TestDIDRotator(t *testing.T) {
+	km := newKMS(t)
+	docs := map[string]*did.Doc{}
+
+	for _, id := range []string{aliceDID, alice2DID, bobDID} {
+		docs[id] = newDoc(t, km, id)
+	}
+
+	alice := newAgent(t, km, docs)
+	bob := newAgent(t, km, docs)
+
+	alice.saveConnection(t, aliceDID, bobDID)
+	bob.saveConnection(t, bobDID, aliceDID)
+
+	require.NoError(t, alice.rotator.RotateConnectionDID(connID, "", alice2DID))
+
+	aliceConn := alice.connection(t)
+	require.Equal(t, alice2DID, aliceConn.MyDID)
+	require.NotNil(t, aliceConn.MyDIDRotation)
+	require.Equal(t, aliceDID, aliceConn.MyDIDRotation.OldDID)
+	require.Equal(t, alice2DID, aliceConn.MyDIDRotation.NewDID)
+	require.NotEmpty(t, aliceConn.MyDIDRotation.FromPrior)
+
+	err := alice.rotator.RotateConnectionDID(connID, "", aliceDID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not acknowledged yet")
+
+	// bob receives a message from alice's new DID.
+	msg := newMessage(alice2DID, aliceConn.MyDIDRotation.FromPrior)
+	require.NoError(t, bob.rotator.HandleInboundMessage(msg, bobDID, alice2DID))
+	require.Equal(t, alice2DID, bob.connection(t).TheirDID)
+
+	// alice sends from_prior until bob acknowledges the rotation.
+	require.NoError(t, bob.rotator.HandleInboundMessage(msg, bobDID, alice2DID))
+	require.Equal(t, alice2DID, bob.connection(t).TheirDID)
+
+	// alice receives a message sent by bob to her new DID.
+	require.NoError(t, alice.rotator.HandleInboundMessage(newMessage(bobDID, ""), alice2DID, bobDID))
+	require.Nil(t, alice.connection(t).MyDIDRotation)
+	require.Equal(t, alice2DID, alice.connection(t).MyDID)
+}
+
+func TestDIDRotator_RotateConnectionDID(t *testing.T) {
+	km := newKMS(t)
+	docs := map[string]*did.Doc{aliceDID: newDoc(t, km, aliceDID), alice2DID: newDoc(t, km, alice2DID)}
+
+	t.Run("success with signing key ID", func(t *testing.T) {
+		alice := newAgent(t, km, docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		require.NoError(t, alice.rotator.RotateConnectionDID(connID, aliceDID+"#key-1", alice2DID))
+		require.Contains(t, alice.connection(t).MyDIDRotation.FromPrior, ".")
+	})
+
+	t.Run("success with embedded authentication key", func(t *testing.T) {
+		doc := newDoc(t, km, aliceDID)
+		vm := doc.VerificationMethod[0]
+		doc.Authentication = []did.Verification{*did.NewEmbeddedVerification(&vm, did.Authentication)}
+		doc.VerificationMethod = nil
+
+		alice := newAgent(t, km, map[string]*did.Doc{aliceDID: doc, alice2DID: docs[alice2DID]})
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		require.NoError(t, alice.rotator.RotateConnectionDID(connID, "#key-1", alice2DID))
+	})
+
+	t.Run("error if connection not found", func(t *testing.T) {
+		err := newAgent(t, km, docs).rotator.RotateConnectionDID(connID, "", alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("error if connection is not completed", func(t *testing.T) {
+		alice := newAgent(t, km, docs)
+		require.NoError(t, alice.rotator.connections.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connID,
+			State:        "requested",
+			MyDID:        aliceDID,
+			TheirDID:     bobDID,
+		}))
+
+		err := alice.rotator.RotateConnectionDID(connID, "", alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not completed")
+	})
+
+	t.Run("error if new DID is the connection DID", func(t *testing.T) {
+		alice := newAgent(t, km, docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		err := alice.rotator.RotateConnectionDID(connID, "", aliceDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new DID is the connection DID")
+	})
+
+	t.Run("error if new DID cannot be resolved", func(t *testing.T) {
+		alice := newAgent(t, km, docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		err := alice.rotator.RotateConnectionDID(connID, "", "did:test:unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve new DID")
+	})
+
+	t.Run("error if signing key not found", func(t *testing.T) {
+		alice := newAgent(t, km, docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		err := alice.rotator.RotateConnectionDID(connID, "#key-2", alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key #key-2 not found")
+	})
+
+	t.Run("error if signing key is not an authentication key", func(t *testing.T) {
+		_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		doc := newDoc(t, km, aliceDID)
+		doc.VerificationMethod = append(doc.VerificationMethod,
+			*did.NewVerificationMethodFromBytes("#key-2", ed25519VerificationKey2018, aliceDID, pubKey))
+
+		alice := newAgent(t, km, map[string]*did.Doc{aliceDID: doc, alice2DID: docs[alice2DID]})
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		err = alice.rotator.RotateConnectionDID(connID, "#key-2", alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "authentication key #key-2 not found")
+	})
+
+	t.Run("error if signing key is not in the KMS", func(t *testing.T) {
+		alice := newAgent(t, newKMS(t), docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		err := alice.rotator.RotateConnectionDID(connID, "", alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get signing key handle")
+	})
+}
+
+func TestDIDRotator_HandleInboundMessage(t *testing.T) {
+	km := newKMS(t)
+	docs := map[string]*did.Doc{}
+
+	for _, id := range []string{aliceDID, alice2DID, bobDID} {
+		docs[id] = newDoc(t, km, id)
+	}
+
+	fromPrior := func(t *testing.T) string {
+		t.Helper()
+
+		alice := newAgent(t, km, docs)
+		alice.saveConnection(t, aliceDID, bobDID)
+
+		require.NoError(t, alice.rotator.RotateConnectionDID(connID, "", alice2DID))
+
+		return alice.connection(t).MyDIDRotation.FromPrior
+	}(t)
+
+	t.Run("ignores DIDComm v1 messages", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		msg := service.DIDCommMsgMap{"@type": msgType, "@id": "1", FromPriorJSONKey: fromPrior}
+
+		require.NoError(t, bob.rotator.HandleInboundMessage(msg, bobDID, alice2DID))
+		require.Equal(t, aliceDID, bob.connection(t).TheirDID)
+	})
+
+	t.Run("ignores messages of unknown connections", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+
+		require.NoError(t, bob.rotator.HandleInboundMessage(newMessage(aliceDID, ""), bobDID, aliceDID))
+	})
+
+	t.Run("error if from_prior is not a valid JWT", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, "invalid"), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse from_prior JWT")
+	})
+
+	t.Run("error if from_prior signature is invalid", func(t *testing.T) {
+		bob := newAgent(t, km, map[string]*did.Doc{
+			aliceDID: newDoc(t, newKMS(t), aliceDID), alice2DID: docs[alice2DID], bobDID: docs[bobDID],
+		})
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse from_prior JWT")
+	})
+
+	t.Run("error if sender is not the from_prior subject", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, "did:test:mallory")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not the from_prior subject")
+
+		err = bob.rotator.HandleInboundMessage(newMessage("did:test:mallory", fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not the from_prior subject")
+	})
+
+	t.Run("error if from_prior issued at time is invalid", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		for _, iat := range []*josejwt.NumericDate{nil, josejwt.NewNumericDate(time.Now().Add(time.Hour))} {
+			claims := &jwt.Claims{Issuer: aliceDID, Subject: alice2DID, IssuedAt: iat}
+
+			err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, signFromPrior(t, km, docs[aliceDID], claims)),
+				bobDID, alice2DID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid from_prior issued at time")
+		}
+
+		require.Equal(t, aliceDID, bob.connection(t).TheirDID)
+	})
+
+	t.Run("error if from_prior subject cannot be resolved", func(t *testing.T) {
+		bob := newAgent(t, km, map[string]*did.Doc{aliceDID: docs[aliceDID], bobDID: docs[bobDID]})
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve from_prior subject")
+		require.Equal(t, aliceDID, bob.connection(t).TheirDID)
+	})
+
+	t.Run("error if from_prior is signed with a key which is not an authentication key", func(t *testing.T) {
+		doc := newDoc(t, km, aliceDID)
+		doc.Authentication = nil
+
+		bob := newAgent(t, km, map[string]*did.Doc{aliceDID: doc, alice2DID: docs[alice2DID], bobDID: docs[bobDID]})
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse from_prior JWT")
+	})
+
+	t.Run("error if no connection with the from_prior issuer", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection ID of the prior DID")
+	})
+
+	t.Run("error if from_prior issuer is not the connection DID", func(t *testing.T) {
+		bob := newAgent(t, km, docs)
+		bob.saveConnection(t, bobDID, aliceDID)
+
+		record := bob.connection(t)
+		record.TheirDID = "did:test:other"
+		require.NoError(t, bob.rotator.connections.SaveConnectionRecord(record))
+
+		err := bob.rotator.HandleInboundMessage(newMessage(alice2DID, fromPrior), bobDID, alice2DID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not the connection DID")
+	})
+}
+
+type agent struct {
+	provider *mockprovider.Provider
+	rotator  *DIDRotator
+}
+
+func newAgent(t *testing.T, km kms.KeyManager, docs map[string]*did.Doc) *agent {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	p := &mockprovider.Provider{
+		KMSValue:    km,
+		CryptoValue: c,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc, ok := docs[didID]
+				if !ok {
+					return nil, fmt.Errorf("DID %s not found", didID)
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+
+	r, err := New(p)
+	require.NoError(t, err)
+
+	return &agent{provider: p, rotator: r}
+}
+
+func (a *agent) saveConnection(t *testing.T, myDID, theirDID string) {
+	t.Helper()
+
+	require.NoError(t, a.rotator.connections.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+}
+
+func (a *agent) connection(t *testing.T) *connection.Record {
+	t.Helper()
+
+	record, err := a.rotator.connections.GetConnectionRecord(connID)
+	require.NoError(t, err)
+
+	return record
+}
+
+func newMessage(from, fromPrior string) service.DIDCommMsgMap {
+	msg := service.DIDCommMsgMap{"type": msgType, "id": "1", "from": from}
+
+	if fromPrior != "" {
+		msg[FromPriorJSONKey] = fromPrior
+	}
+
+	return msg
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return km
+}
+
+func newDoc(t *testing.T, km kms.KeyManager, id string) *did.Doc {
+	t.Helper()
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, id, pubKey)
+
+	return &did.Doc{
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+	}
+}
+
+func signFromPrior(t *testing.T, km kms.KeyManager, doc *did.Doc, claims *jwt.Claims) string {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, err := localkms.CreateKID(doc.VerificationMethod[0].Value, kms.ED25519Type)
+	require.NoError(t, err)
+
+	kh, err := km.Get(kid)
+	require.NoError(t, err)
+
+	token, err := jwt.NewSigned(claims, nil, &signer{crypto: c, kh: kh, kid: doc.ID + doc.VerificationMethod[0].ID})
+	require.NoError(t, err)
+
+	fromPrior, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return fromPrior
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -18,6 +20,12 @@ type ProtocolService interface {
 	Name() string
 }
 
+// Discoverable is implemented by the protocol services disclosing the URIs of their protocols (e.g.
+// https://didcomm.org/trust_ping/1.0) to the discover-features queries of the other agents.
+type Discoverable interface {
+	PIURIs() []string
+}
+
 // MessageService is service for handling generic messages
 // matching accept criteria based on message header.
 type MessageService interface {
@@ -37,3 +45,13 @@ type Outbound interface {
 	// Forward forwards the message without packing to the destination.
 	Forward(interface{}, *service.Destination) error
 }
+
+// OutboundScheduler schedules outbound messages for a future delivery, it is implemented by the OutboundDispatcher.
+type OutboundScheduler interface {
+	// Schedule the message to be sent at the given time after packing with the sender key and recipient keys,
+	// it returns the ID of the scheduled message.
+	Schedule(msg interface{}, senderKey string, des *service.Destination, sendAt time.Time) (string, error)
+
+	// CancelScheduled cancels the delivery of the scheduled message with the given ID.
+	CancelScheduled(id string) error
+}
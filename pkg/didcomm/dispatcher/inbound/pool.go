@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package inbound provides a bounded worker pool handling the inbound messages concurrently.
+//
+// The messages of a same protocol thread are handled by the same worker, in the order they were received, so that
+// the protocol state machines see the messages of a thread in order while the other threads are handled
+// concurrently.
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+const defaultQueueSize = 100
+
+var logger = log.New("aries-framework/didcomm/dispatcher/inbound")
+
+// ErrPoolClosed is returned when a message is handed to a closed pool.
+var ErrPoolClosed = errors.New("inbound worker pool is closed")
+
+// ErrDrainTimeout is returned when the queued messages aren't handled before the drain timeout.
+var ErrDrainTimeout = errors.New("inbound messages still being handled after the drain timeout")
+
+// Option configures the worker pool.
+type Option func(p *Pool)
+
+// WithQueueSize sets the number of messages waiting to be handled by each worker, 100 by default. The transports
+// are blocked once the queue of the worker is full, until the message is queued or the pool is closed.
+func WithQueueSize(size int) Option {
+	return func(p *Pool) {
+		p.queueSize = size
+	}
+}
+
+type job struct {
+	handler  transport.InboundMessageHandler
+	envelope *transport.Envelope
+}
+
+// Pool is a bounded pool of workers handling the inbound messages.
+type Pool struct {
+	queues    []chan *job
+	queueSize int
+	closed    bool
+	done      chan struct{}
+	lock      sync.RWMutex
+	senders   sync.WaitGroup
+	wg        sync.WaitGroup
+}
+
+// NewPool starts a pool of the given number of workers.
+func NewPool(workers int, opts ...Option) (*Pool, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("invalid number of inbound workers: %d", workers)
+	}
+
+	p := &Pool{queueSize: defaultQueueSize, done: make(chan struct{})}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.queueSize < 0 {
+		return nil, fmt.Errorf("invalid inbound worker queue size: %d", p.queueSize)
+	}
+
+	p.queues = make([]chan *job, workers)
+
+	for i := range p.queues {
+		p.queues[i] = make(chan *job, p.queueSize)
+
+		p.wg.Add(1)
+
+		go p.work(p.queues[i])
+	}
+
+	return p, nil
+}
+
+// Handler returns an inbound message handler queuing the messages to the workers of the pool, which hand them to
+// the given handler. The messages are handled asynchronously: the returned handler only reports the errors of
+// messages which can't be queued, the handling errors are logged.
+func (p *Pool) Handler(handler transport.InboundMessageHandler) transport.InboundMessageHandler {
+	return func(envelope *transport.Envelope) error {
+		msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+		if err != nil {
+			// invalid messages are handed to the handler right away to report the error to the transport.
+			return handler(envelope)
+		}
+
+		p.lock.RLock()
+
+		if p.closed {
+			p.lock.RUnlock()
+
+			return ErrPoolClosed
+		}
+
+		// the queues are closed once the pending sends are done, the send blocking on a full queue doesn't hold the
+		// lock so that the pool can be closed meanwhile.
+		p.senders.Add(1)
+		defer p.senders.Done()
+
+		p.lock.RUnlock()
+
+		select {
+		case p.queues[p.worker(msg)] <- &job{handler: handler, envelope: envelope}:
+			return nil
+		case <-p.done:
+			return ErrPoolClosed
+		}
+	}
+}
+
+// Close stops queuing messages and waits for the queued messages to be handled.
+func (p *Pool) Close() {
+	p.stop()
+
+	p.wg.Wait()
+}
+
+// Drain stops queuing messages and waits for the queued messages to be handled, for at most the given timeout. The
+// messages still queued after the timeout are handled in the background, ErrDrainTimeout being returned.
+func (p *Pool) Drain(timeout time.Duration) error {
+	p.stop()
+
+	done := make(chan struct{})
+
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return ErrDrainTimeout
+	}
+}
+
+func (p *Pool) stop() {
+	p.lock.Lock()
+
+	if p.closed {
+		p.lock.Unlock()
+
+		return
+	}
+
+	p.closed = true
+	close(p.done)
+
+	p.lock.Unlock()
+
+	// the sends blocked on full queues are aborted before closing the queues.
+	p.senders.Wait()
+
+	for _, queue := range p.queues {
+		close(queue)
+	}
+}
+
+func (p *Pool) work(queue chan *job) {
+	defer p.wg.Done()
+
+	for j := range queue {
+		if err := j.handler(j.envelope); err != nil {
+			logger.Warnf("failed to handle inbound message: %s", err)
+		}
+	}
+}
+
+// worker returns the worker handling the message: the messages of a same protocol and thread share the worker.
+func (p *Pool) worker(msg service.DIDCommMsgMap) int {
+	h := fnv.New32a()
+
+	_, _ = h.Write([]byte(protocol(msg.Type()))) // nolint: errcheck
+
+	if thID, err := msg.ThreadID(); err == nil {
+		_, _ = h.Write([]byte(thID)) // nolint: errcheck
+	}
+
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// protocol returns the protocol of the message type, i.e. the message type without the message name.
+func protocol(msgType string) string {
+	if i := strings.LastIndex(msgType, "/"); i >= 0 {
+		return msgType[:i]
+	}
+
+	return msgType
+}
@@ -0,0 +1,273 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+func TestNewPool(t *testing.T) {
+	t.Run("invalid number of workers", func(t *testing.T) {
+		_, err := NewPool(0)
+		require.EqualError(t, err, "invalid number of inbound workers: 0")
+	})
+
+	t.Run("invalid queue size", func(t *testing.T) {
+		_, err := NewPool(1, WithQueueSize(-1))
+		require.EqualError(t, err, "invalid inbound worker queue size: -1")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p, err := NewPool(2, WithQueueSize(0))
+		require.NoError(t, err)
+		require.Len(t, p.queues, 2)
+
+		p.Close()
+		p.Close()
+	})
+}
+
+func TestPool_Handler(t *testing.T) {
+	t.Run("messages of a thread are handled in order", func(t *testing.T) {
+		p, err := NewPool(4)
+		require.NoError(t, err)
+
+		var (
+			handled = map[string][]int{}
+			mu      sync.Mutex
+		)
+
+		handler := p.Handler(func(envelope *transport.Envelope) error {
+			msg := struct {
+				Thread struct {
+					ID  string `json:"thid"`
+					Seq int    `json:"seq"`
+				} `json:"~thread"`
+			}{}
+
+			if err := json.Unmarshal(envelope.Message, &msg); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			handled[msg.Thread.ID] = append(handled[msg.Thread.ID], msg.Thread.Seq)
+			mu.Unlock()
+
+			return nil
+		})
+
+		for seq := 0; seq < 20; seq++ {
+			for _, thID := range []string{"a", "b", "c"} {
+				require.NoError(t, handler(&transport.Envelope{Message: []byte(fmt.Sprintf(
+					`{"@type":"https://didcomm.org/test/1.0/msg","~thread":{"thid":"%s","seq":%d}}`, thID, seq))}))
+			}
+		}
+
+		p.Close()
+
+		require.Len(t, handled, 3)
+
+		for _, sequence := range handled {
+			require.Len(t, sequence, 20)
+
+			for i, seq := range sequence {
+				require.Equal(t, i, seq)
+			}
+		}
+	})
+
+	t.Run("threads are handled concurrently", func(t *testing.T) {
+		const workers = 2
+
+		p, err := NewPool(workers)
+		require.NoError(t, err)
+
+		var (
+			running, maxRunning int
+			mu                  sync.Mutex
+			release             = make(chan struct{})
+		)
+
+		handler := p.Handler(func(envelope *transport.Envelope) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			<-release
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			return nil
+		})
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, handler(&transport.Envelope{Message: []byte(fmt.Sprintf(
+				`{"@id":"%d","@type":"https://didcomm.org/test/1.0/msg"}`, i))}))
+		}
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return running == workers
+		}, time.Second, 10*time.Millisecond)
+
+		close(release)
+		p.Close()
+
+		require.Equal(t, workers, maxRunning)
+	})
+
+	t.Run("invalid message is handled right away", func(t *testing.T) {
+		p, err := NewPool(1)
+		require.NoError(t, err)
+
+		defer p.Close()
+
+		handlerErr := errors.New("invalid message")
+
+		err = p.Handler(func(envelope *transport.Envelope) error {
+			return handlerErr
+		})(&transport.Envelope{Message: []byte("invalid")})
+		require.True(t, errors.Is(err, handlerErr))
+	})
+
+	t.Run("handling errors are not reported", func(t *testing.T) {
+		p, err := NewPool(1)
+		require.NoError(t, err)
+
+		handled := make(chan struct{})
+
+		err = p.Handler(func(envelope *transport.Envelope) error {
+			close(handled)
+
+			return errors.New("handle error")
+		})(&transport.Envelope{Message: []byte(`{"@type":"https://didcomm.org/test/1.0/msg"}`)})
+		require.NoError(t, err)
+
+		p.Close()
+
+		select {
+		case <-handled:
+		default:
+			require.Fail(t, "message not handled")
+		}
+	})
+
+	t.Run("closed pool", func(t *testing.T) {
+		p, err := NewPool(1)
+		require.NoError(t, err)
+
+		p.Close()
+
+		err = p.Handler(func(envelope *transport.Envelope) error {
+			return nil
+		})(&transport.Envelope{Message: []byte(`{"@type":"https://didcomm.org/test/1.0/msg"}`)})
+		require.True(t, errors.Is(err, ErrPoolClosed))
+	})
+}
+
+func TestPool_Drain(t *testing.T) {
+	msg := &transport.Envelope{Message: []byte(`{"@type":"https://didcomm.org/test/1.0/msg"}`)}
+
+	t.Run("queued messages are handled", func(t *testing.T) {
+		p, err := NewPool(1)
+		require.NoError(t, err)
+
+		handled := 0
+
+		handler := p.Handler(func(envelope *transport.Envelope) error {
+			time.Sleep(10 * time.Millisecond)
+
+			handled++
+
+			return nil
+		})
+
+		require.NoError(t, handler(msg))
+		require.NoError(t, handler(msg))
+
+		require.NoError(t, p.Drain(time.Second))
+		require.Equal(t, 2, handled)
+
+		require.True(t, errors.Is(handler(msg), ErrPoolClosed))
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		p, err := NewPool(1)
+		require.NoError(t, err)
+
+		release := make(chan struct{})
+
+		require.NoError(t, p.Handler(func(envelope *transport.Envelope) error {
+			<-release
+
+			return nil
+		})(msg))
+
+		require.True(t, errors.Is(p.Drain(10*time.Millisecond), ErrDrainTimeout))
+
+		close(release)
+		p.Close()
+	})
+
+	t.Run("messages blocked on a full queue", func(t *testing.T) {
+		p, err := NewPool(1, WithQueueSize(0))
+		require.NoError(t, err)
+
+		handling := make(chan struct{})
+		release := make(chan struct{})
+
+		handler := p.Handler(func(envelope *transport.Envelope) error {
+			handling <- struct{}{}
+			<-release
+
+			return nil
+		})
+
+		require.NoError(t, handler(msg))
+		<-handling
+
+		blocked := make(chan error)
+
+		go func() {
+			blocked <- handler(msg)
+		}()
+
+		select {
+		case <-blocked:
+			require.Fail(t, "message not blocked on the full queue")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		// the pool is drained while a message waits for the busy worker, the message being rejected
+		require.True(t, errors.Is(p.Drain(10*time.Millisecond), ErrDrainTimeout))
+		require.True(t, errors.Is(<-blocked, ErrPoolClosed))
+
+		close(release)
+		p.Close()
+	})
+}
+
+func TestProtocol(t *testing.T) {
+	require.Equal(t, "https://didcomm.org/test/1.0", protocol("https://didcomm.org/test/1.0/msg"))
+	require.Equal(t, "msg", protocol("msg"))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// Direction of the messages handled by the message middleware.
+type Direction string
+
+const (
+	// DirectionInbound is the direction of the messages received, after they are unpacked.
+	DirectionInbound Direction = "inbound"
+	// DirectionOutbound is the direction of the messages sent, before they are packed.
+	DirectionOutbound Direction = "outbound"
+)
+
+// MessageMetadata is the DIDComm message handled by the message middleware, with its metadata.
+type MessageMetadata struct {
+	// Direction of the message.
+	Direction Direction
+	// Message is the decrypted inbound message, or the outbound message before packing. The middleware can change it
+	// in place or replace it, the message handled or sent being the one passed to the next handler of the chain.
+	// The service handling an inbound message is selected before the middleware is called.
+	Message service.DIDCommMsgMap
+	// MyDID and TheirDID are the DIDs of the connection of the message, empty when unknown, like for the DID
+	// exchange messages received or the messages sent with Send.
+	MyDID    string
+	TheirDID string
+	// Origin is the transport origin of the inbound messages, nil for the outbound messages.
+	Origin *service.Origin
+	// Destination is the destination of the outbound messages, nil for the inbound messages.
+	Destination *service.Destination
+	// Annotations are set by the middleware for the next handlers of the chain. The annotations of the inbound
+	// messages are added to the properties of the DIDComm context of the service handling them.
+	Annotations map[string]interface{}
+}
+
+// MessageHandler handles the messages passed through the message middleware.
+type MessageHandler interface {
+	Handle(metadata *MessageMetadata) error
+}
+
+// MessageHandlerFunc is a helper type which implements the MessageHandler interface.
+type MessageHandlerFunc func(metadata *MessageMetadata) error
+
+// Handle implements function to satisfy the MessageHandler interface.
+func (hf MessageHandlerFunc) Handle(metadata *MessageMetadata) error {
+	return hf(metadata)
+}
+
+// MessageMiddleware receives the next handler of the chain and returns the handler to be executed. A middleware
+// blocks a message by returning an error without calling the next handler, the error being returned to the sender
+// of an outbound message or to the inbound transport. A middleware returning nil without calling the next handler
+// drops the message silently.
+type MessageMiddleware func(next MessageHandler) MessageHandler
+
+// NewMessageHandler chains the middleware in front of the handler, the first middleware being called first.
+func NewMessageHandler(handler MessageHandler, middleware ...MessageMiddleware) MessageHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageHandler(t *testing.T) {
+	newMiddleware := func(name string, calls *[]string) MessageMiddleware {
+		return func(next MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(metadata *MessageMetadata) error {
+				*calls = append(*calls, name)
+				metadata.Annotations[name] = true
+
+				return next.Handle(metadata)
+			})
+		}
+	}
+
+	t.Run("middleware called in order", func(t *testing.T) {
+		var calls []string
+
+		handler := NewMessageHandler(MessageHandlerFunc(func(metadata *MessageMetadata) error {
+			calls = append(calls, "handler")
+			require.Equal(t, map[string]interface{}{"first": true, "second": true}, metadata.Annotations)
+
+			return nil
+		}), newMiddleware("first", &calls), newMiddleware("second", &calls))
+
+		require.NoError(t, handler.Handle(&MessageMetadata{Annotations: map[string]interface{}{}}))
+		require.Equal(t, []string{"first", "second", "handler"}, calls)
+	})
+
+	t.Run("message blocked by middleware", func(t *testing.T) {
+		var calls []string
+
+		handler := NewMessageHandler(MessageHandlerFunc(func(metadata *MessageMetadata) error {
+			calls = append(calls, "handler")
+
+			return nil
+		}), func(next MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(metadata *MessageMetadata) error {
+				return errors.New("blocked")
+			})
+		}, newMiddleware("second", &calls))
+
+		require.EqualError(t, handler.Handle(&MessageMetadata{Annotations: map[string]interface{}{}}), "blocked")
+		require.Empty(t, calls)
+	})
+
+	t.Run("no middleware", func(t *testing.T) {
+		called := false
+
+		handler := NewMessageHandler(MessageHandlerFunc(func(metadata *MessageMetadata) error {
+			called = true
+
+			return nil
+		}))
+
+		require.NoError(t, handler.Handle(&MessageMetadata{}))
+		require.True(t, called)
+	})
+}
@@ -7,21 +7,29 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -37,6 +45,11 @@ type provider interface {
 	ProtocolStateStorageProvider() storage.Provider
 	StorageProvider() storage.Provider
 	MediaTypeProfiles() []string
+	TracerProvider() trace.TracerProvider
+	MetricsProvider() metrics.Provider
+	OutboundRelays() []*service.Destination
+	OutboundRetryPolicy() *RetryPolicy
+	OutboundMiddleware() []MessageMiddleware
 }
 
 type connectionLookup interface {
@@ -54,8 +67,19 @@ type OutboundDispatcher struct {
 	keyAgreementType     kms.KeyType
 	connections          connectionLookup
 	mediaTypeProfiles    []string
+	tracer               trace.Tracer
+	metrics              metrics.Provider
+	relays               []*service.Destination
+	retry                *retryQueue
+	scheduler            *scheduler
+	problemReports       *problemreport.Store
+	middleware           []MessageMiddleware
+	mediators            *mediatorResolver
 }
 
+// jsonFromPrior is the DIDComm v2 message header holding the from_prior JWT of a DID rotation.
+const jsonFromPrior = "from_prior"
+
 var logger = log.New("aries-framework/didcomm/dispatcher")
 
 // NewOutbound return new dispatcher outbound instance.
@@ -68,6 +92,11 @@ func NewOutbound(prov provider) (*OutboundDispatcher, error) {
 		kms:                  prov.KMS(),
 		keyAgreementType:     prov.KeyAgreementType(),
 		mediaTypeProfiles:    prov.MediaTypeProfiles(),
+		tracer:               tracing.Tracer(prov.TracerProvider()),
+		metrics:              prov.MetricsProvider(),
+		relays:               prov.OutboundRelays(),
+		middleware:           prov.OutboundMiddleware(),
+		mediators:            newMediatorResolver(prov.VDRegistry()),
 	}
 
 	var err error
@@ -77,9 +106,54 @@ func NewOutbound(prov provider) (*OutboundDispatcher, error) {
 		return nil, fmt.Errorf("failed to init connections lookup: %w", err)
 	}
 
+	o.problemReports, err = problemreport.New(prov)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init problem report store: %w", err)
+	}
+
+	if policy := prov.OutboundRetryPolicy(); policy != nil {
+		o.retry, err = newRetryQueue(prov.StorageProvider(), policy, o.deliver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init outbound retry queue: %w", err)
+		}
+
+		if err = o.retry.resume(); err != nil {
+			return nil, fmt.Errorf("failed to resume outbound retry queue: %w", err)
+		}
+	}
+
+	o.scheduler, err = newScheduler(prov.StorageProvider(), o.sendScheduled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init outbound scheduler: %w", err)
+	}
+
+	if err = o.scheduler.resume(); err != nil {
+		return nil, fmt.Errorf("failed to resume scheduled outbound messages: %w", err)
+	}
+
 	return o, nil
 }
 
+// OnDeliveryFailure registers a handler notified when an outbound message is dropped after all its delivery
+// retries failed. Handlers are only notified when an outbound retry policy is set, otherwise delivery errors are
+// returned by Send and Forward.
+func (o *OutboundDispatcher) OnDeliveryFailure(handler DeliveryFailureHandler) {
+	if o.retry != nil {
+		o.retry.onDeliveryFailure(handler)
+	}
+}
+
+// Flush stops the outbound retries and scheduled deliveries before the agent shuts down: a last delivery attempt of
+// the messages queued for retry is made right away, and the messages which still can't be delivered are kept in the
+// outbound store with the scheduled messages, to be resumed when the agent restarts.
+func (o *OutboundDispatcher) Flush() {
+	if o.retry != nil {
+		o.retry.flush()
+	}
+
+	o.scheduler.stop()
+}
+
 // SendToDID sends a message from myDID to the agent who owns theirDID.
 func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	var mediaTypes []string
@@ -99,10 +173,19 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 	}
 
 	if connID != "" {
-		mediaTypes, err = o.mediaTypeProfilesFromConnection(mediaTypes, connID)
+		var record *connection.Record
+
+		mediaTypes, record, err = o.mediaTypeProfilesFromConnection(mediaTypes, connID)
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.SendToDID: %w", err)
 		}
+
+		if record != nil && record.MyDIDRotation != nil {
+			msg, err = addFromPrior(msg, record.MyDIDRotation.FromPrior)
+			if err != nil {
+				return fmt.Errorf("outboundDispatcher.SendToDID: %w", err)
+			}
+		}
 	}
 
 	dest, err := service.GetDestination(theirDID, o.vdRegistry)
@@ -120,11 +203,18 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 		return fmt.Errorf("outboundDispatcher.SendToDID failed to get didcomm destination for myDID [%s]: %w", myDID, err)
 	}
 
+	msg, err = o.applyMiddleware(msg, myDID, theirDID, dest)
+	if err != nil || msg == nil {
+		return err
+	}
+
 	// We get at least one recipient key, so we can use the first one
 	//  (right now, with only one key type used for sending)
 	key := src.RecipientKeys[0]
 
-	return o.Send(msg, key, dest)
+	o.saveProblemReport(msg, myDID, theirDID)
+
+	return o.send(msg, key, dest)
 }
 
 func (o *OutboundDispatcher) defaultMediaTypeProfiles() []string {
@@ -134,7 +224,8 @@ func (o *OutboundDispatcher) defaultMediaTypeProfiles() []string {
 	return mediaTypes
 }
 
-func (o *OutboundDispatcher) mediaTypeProfilesFromConnection(mediaTypes []string, connID string) ([]string, error) {
+func (o *OutboundDispatcher) mediaTypeProfilesFromConnection(mediaTypes []string,
+	connID string) ([]string, *connection.Record, error) {
 	record, err := o.connections.GetConnectionRecord(connID)
 	if err != nil {
 		if errors.Is(err, storage.ErrDataNotFound) {
@@ -146,7 +237,7 @@ func (o *OutboundDispatcher) mediaTypeProfilesFromConnection(mediaTypes []string
 				mediaTypes = o.defaultMediaTypeProfiles()
 			}
 		} else {
-			return nil, fmt.Errorf("failed to fetch connection record for connID=%s: %w", connID, err)
+			return nil, nil, fmt.Errorf("failed to fetch connection record for connID=%s: %w", connID, err)
 		}
 	}
 
@@ -155,66 +246,226 @@ func (o *OutboundDispatcher) mediaTypeProfilesFromConnection(mediaTypes []string
 		copy(mediaTypes, record.MediaTypeProfiles)
 	}
 
-	return mediaTypes, nil
+	return mediaTypes, record, nil
+}
+
+// addFromPrior adds the from_prior JWT of the connection DID rotation to DIDComm v2 messages, until the other party
+// acknowledges the rotation.
+func addFromPrior(msg interface{}, fromPrior string) (interface{}, error) {
+	msgMap, err := toDIDCommMsgMap(msg)
+	if err != nil {
+		return nil, fmt.Errorf("add from_prior: %w", err)
+	}
+
+	if !msgMap.IsDIDCommV2() {
+		return msg, nil
+	}
+
+	msgMap = msgMap.Clone()
+	msgMap[jsonFromPrior] = fromPrior
+
+	return msgMap, nil
+}
+
+func toDIDCommMsgMap(msg interface{}) (service.DIDCommMsgMap, error) {
+	if msgMap, ok := msg.(service.DIDCommMsgMap); ok {
+		return msgMap, nil
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal message: %w", err)
+	}
+
+	return service.ParseDIDCommMsgMap(raw)
+}
+
+// applyMiddleware passes the outbound message through the outbound middleware, it returns the message to send, nil
+// when the message is dropped by a middleware.
+func (o *OutboundDispatcher) applyMiddleware(msg interface{}, myDID, theirDID string,
+	des *service.Destination) (interface{}, error) {
+	if len(o.middleware) == 0 {
+		return msg, nil
+	}
+
+	msgMap, err := toDIDCommMsgMap(msg)
+	if err != nil {
+		return nil, fmt.Errorf("outbound middleware: %w", err)
+	}
+
+	var out service.DIDCommMsgMap
+
+	handler := NewMessageHandler(MessageHandlerFunc(func(metadata *MessageMetadata) error {
+		out = metadata.Message
+
+		return nil
+	}), o.middleware...)
+
+	err = handler.Handle(&MessageMetadata{
+		Direction:   DirectionOutbound,
+		Message:     msgMap,
+		MyDID:       myDID,
+		TheirDID:    theirDID,
+		Destination: des,
+		Annotations: map[string]interface{}{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbound middleware: %w", err)
+	}
+
+	if out == nil {
+		logger.Debugf("outbound message [%s] dropped by middleware", msgMap.ID())
+
+		return nil, nil
+	}
+
+	return out, nil
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
 func (o *OutboundDispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error {
-	for _, v := range o.outboundTransports {
-		// check if outbound accepts routing keys, else use recipient keys
-		keys := des.RecipientKeys
-		if len(des.RoutingKeys) != 0 {
-			keys = des.RoutingKeys
+	msg, err := o.applyMiddleware(msg, "", "", des)
+	if err != nil || msg == nil {
+		return err
+	}
+
+	o.saveProblemReport(msg, "", "")
+
+	return o.send(msg, senderKey, des)
+}
+
+// saveProblemReport keeps the history of the problem reports sent, the message is sent even if it can't be saved.
+func (o *OutboundDispatcher) saveProblemReport(msg interface{}, myDID, theirDID string) {
+	if err := o.problemReports.Save(msg, problemreport.Outbound, myDID, theirDID); err != nil {
+		logger.Warnf("failed to save outbound problem report: %s", err)
+	}
+}
+
+func (o *OutboundDispatcher) send(msg interface{}, senderKey string, des *service.Destination) (err error) {
+	ctx, span := o.tracer.Start(parentContext(msg), "didcomm.outbound.send",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("didcomm.service_endpoint", des.ServiceEndpoint)))
+	defer func() { tracing.End(span, err) }()
+
+	v, packedMsg, nextHop, err := o.prepare(ctx, msg, senderKey, des)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: %w", err)
+	}
+
+	_, err = v.Send(packedMsg, nextHop)
+	if err != nil {
+		o.transportError(nextHop)
+
+		if o.retry != nil {
+			return o.queue(packedMsg, nextHop, err)
 		}
 
-		if !v.AcceptRecipient(keys) {
-			if !v.Accept(des.ServiceEndpoint) {
-				continue
-			}
+		return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
+	}
+
+	return nil
+}
+
+// parentContext returns the parent context of the span of the message sending, holding the span context attached to
+// the message when it is sent while handling an inbound message.
+func parentContext(msg interface{}) context.Context {
+	if m, ok := msg.(service.DIDCommMsg); ok {
+		if sc := service.GetSpanContext(m); sc.IsValid() {
+			return trace.ContextWithSpanContext(context.Background(), sc)
+		}
+	}
+
+	return context.Background()
+}
+
+// prepare packs the message for the destination, it returns the outbound transport accepting the next hop of the
+// message (the destination or the first relay) with the packed message and the next hop.
+func (o *OutboundDispatcher) prepare(ctx context.Context, msg interface{}, senderKey string,
+	des *service.Destination) (transport.OutboundTransport, []byte, *service.Destination, error) {
+	relays := des.Relays
+	if len(relays) == 0 {
+		relays = o.relays
+	}
+
+	// with relays, the message is sent to the first relay instead of the recipient (or its mediator)
+	nextHop := des
+	if len(relays) != 0 {
+		nextHop = relays[0]
+	} else {
+		// the message can be sent through a return route scoped to its thread.
+		des.ThreadID = threadID(msg)
+	}
+
+	for _, v := range o.outboundTransports {
+		if !accept(v, nextHop) {
+			continue
 		}
 
 		req, err := json.Marshal(msg)
 		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed marshal to bytes: %w", err)
 		}
 
-		// update the outbound message with transport return route option [all or thread]
-		req, err = o.addTransportRouteOptions(req, des)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options: %w", err)
+		// update the outbound message with transport return route option [all or thread], the recipient can't
+		// reply on the same transport connection when the message goes through relays.
+		if len(relays) == 0 {
+			req, err = o.addTransportRouteOptions(req, des)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to add transport route options: %w", err)
+			}
 		}
 
-		packedMsg, err := o.packager.PackMessage(&transport.Envelope{
-			MediaTypeProfile: o.mediaTypeProfile(des),
-			Message:          req,
-			FromKey:          []byte(senderKey),
-			ToKeys:           des.RecipientKeys,
-		})
+		// propagate the trace context to the recipient
+		req, err = tracing.Inject(ctx, req)
 		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to add trace context: %w", err)
 		}
 
-		// set the return route option
-		des.TransportReturnRoute = o.transportReturnRoute
-
-		packedMsg, err = o.createForwardMessage(packedMsg, des)
+		packedMsg, err := o.pack(senderKey, req, des)
 		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg: %w", err)
+			return nil, nil, nil, err
 		}
 
-		_, err = v.Send(packedMsg, des)
+		packedMsg, err = o.createRelayForwardMessages(packedMsg, des, relays)
 		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to create relay forward msg: %w", err)
 		}
 
-		return nil
+		return v, packedMsg, nextHop, nil
+	}
+
+	return nil, nil, nil, fmt.Errorf("no transport found for destination: %+v", des)
+}
+
+func (o *OutboundDispatcher) pack(senderKey string, req []byte, des *service.Destination) ([]byte, error) {
+	packedMsg, err := o.packager.PackMessage(&transport.Envelope{
+		MediaTypeProfile: o.mediaTypeProfile(des),
+		Message:          req,
+		FromKey:          []byte(senderKey),
+		ToKeys:           des.RecipientKeys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack msg: %w", err)
+	}
+
+	// set the return route option
+	des.TransportReturnRoute = o.transportReturnRoute
+
+	packedMsg, err = o.createForwardMessage(packedMsg, des)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forward msg: %w", err)
 	}
 
-	return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
+	return packedMsg, nil
 }
 
 // Forward forwards the message without packing to the destination.
-func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
+func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) (err error) {
+	_, span := o.tracer.Start(parentContext(msg), "didcomm.outbound.forward",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("didcomm.service_endpoint", des.ServiceEndpoint)))
+	defer func() { tracing.End(span, err) }()
+
 	for _, v := range o.outboundTransports {
 		if !v.AcceptRecipient(des.RecipientKeys) {
 			if !v.Accept(des.ServiceEndpoint) {
@@ -229,6 +480,12 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 
 		_, err = v.Send(req, des)
 		if err != nil {
+			o.transportError(des)
+
+			if o.retry != nil {
+				return o.queue(req, des, err)
+			}
+
 			return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
 		}
 
@@ -238,27 +495,199 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
 }
 
+// queue keeps the message which couldn't be sent in the retry queue.
+func (o *OutboundDispatcher) queue(data []byte, des *service.Destination, sendErr error) error {
+	if err := o.retry.enqueue(data, des, sendErr); err != nil {
+		return fmt.Errorf("outboundDispatcher: %w", err)
+	}
+
+	return nil
+}
+
+// deliver sends the already packed message to the destination, it is used to retry failed deliveries.
+func (o *OutboundDispatcher) deliver(data []byte, des *service.Destination) error {
+	for _, v := range o.outboundTransports {
+		if !accept(v, des) {
+			continue
+		}
+
+		if _, err := v.Send(data, des); err != nil {
+			o.transportError(des)
+
+			return fmt.Errorf("failed to send msg using outbound transport: %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
+}
+
+// transportError records the failure of the transport to send a message to the destination.
+func (o *OutboundDispatcher) transportError(des *service.Destination) {
+	scheme := "unknown"
+
+	if u, err := url.Parse(des.ServiceEndpoint); err == nil && u.Scheme != "" {
+		scheme = u.Scheme
+	}
+
+	o.metrics.TransportError(scheme)
+}
+
+// createForwardMessage wraps the packed message in the forward messages routing it to the recipient through the
+// mediators of the destination, one per routing key.
 func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
 	if len(des.RoutingKeys) == 0 {
 		return msg, nil
 	}
 
-	// create forward message
-	forward := &model.Forward{
-		Type: service.ForwardMsgType,
+	forwardMsg, _, err := o.createMediatorForwardMessages(msg, des.RecipientKeys[0], des, 0)
+
+	return forwardMsg, err
+}
+
+// wrapForward wraps the packed message in a forward message to the mediator, packed for the mediator keys. DIDComm v2
+// messages are wrapped in a routing 2.0 forward message, anoncrypted to the mediator keys.
+func (o *OutboundDispatcher) wrapForward(msg []byte, to string, mediatorKeys []string,
+	mtProfile string) ([]byte, error) {
+	if !usesRoutingV2(mtProfile) {
+		forward := &model.Forward{
+			Type: service.ForwardMsgType,
+			ID:   uuid.New().String(),
+			To:   to,
+			Msg:  msg,
+		}
+
+		return o.packForwardMessage(forward, mtProfile, mediatorKeys)
+	}
+
+	data := decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(msg)}
+
+	// JWE envelopes in JSON serialization are embedded as is, anything else is base64 encoded.
+	if json.Valid(msg) {
+		data = decorator.AttachmentData{JSON: json.RawMessage(msg)}
+	}
+
+	forward := &model.ForwardV2{
+		Type: service.ForwardV2MsgType,
 		ID:   uuid.New().String(),
-		To:   des.RecipientKeys[0],
-		Msg:  msg,
+		To:   mediatorKeys,
+		Body: model.ForwardV2Body{Next: to},
+		Attachments: []decorator.AttachmentV2{{
+			MediaType: transport.MediaTypeV2EncryptedEnvelope,
+			Data:      data,
+		}},
+	}
+
+	return o.packForwardMessage(forward, mtProfile, mediatorKeys)
+}
+
+// usesRoutingV2 checks whether the media type profile carries DIDComm v2 messages, which are forwarded with the
+// routing 2.0 protocol.
+func usesRoutingV2(mtProfile string) bool {
+	switch mtProfile {
+	case transport.MediaTypeV2EncryptedEnvelope, transport.MediaTypeV2PlaintextPayload,
+		transport.MediaTypeDIDCommV2Profile:
+		return true
+	}
+
+	return false
+}
+
+// createRelayForwardMessages wraps the packed message in nested forward messages, one per relay starting from the
+// last one, so that each relay can only unpack the forward message telling it where to deliver the next layer.
+func (o *OutboundDispatcher) createRelayForwardMessages(msg []byte, des *service.Destination,
+	relays []*service.Destination) ([]byte, error) {
+	next := des
+
+	for i := len(relays) - 1; i >= 0; i-- {
+		relay := relays[i]
+
+		if len(relay.RecipientKeys) == 0 {
+			return nil, fmt.Errorf("relay %d [%s] has no recipient keys", i, relay.ServiceEndpoint)
+		}
+
+		keys := hopKeys(next)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("next hop of relay %d [%s] has no keys", i, relay.ServiceEndpoint)
+		}
+
+		forward := &model.Forward{
+			Type: service.ForwardMsgType,
+			ID:   uuid.New().String(),
+			To:   keys[0],
+			Msg:  msg,
+			Next: &model.ForwardHop{
+				ServiceEndpoint: next.ServiceEndpoint,
+				RecipientKeys:   keys,
+			},
+		}
+
+		var err error
+
+		msg, err = o.packForwardMessage(forward, o.mediaTypeProfile(relay), relay.RecipientKeys)
+		if err != nil {
+			return nil, fmt.Errorf("relay %d [%s]: %w", i, relay.ServiceEndpoint, err)
+		}
+
+		next = relay
+	}
+
+	return msg, nil
+}
+
+// accept checks if the outbound transport has a return route scoped to the thread of the message, or a connection
+// for the routing keys (else the recipient keys), or else accepts the service endpoint.
+func accept(t transport.OutboundTransport, des *service.Destination) bool {
+	if threadAcceptor, ok := t.(transport.ThreadAcceptor); ok && des.ThreadID != "" &&
+		threadAcceptor.AcceptThread(des.ThreadID) {
+		return true
+	}
+
+	return t.AcceptRecipient(hopKeys(des)) || t.Accept(des.ServiceEndpoint)
+}
+
+// threadID returns the thread ID of the message, empty if it has none.
+func threadID(msg interface{}) string {
+	msgMap, ok := msg.(service.DIDCommMsgMap)
+	if !ok {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return ""
+		}
+
+		msgMap, err = service.ParseDIDCommMsgMap(raw)
+		if err != nil {
+			return ""
+		}
+	}
+
+	thID, err := msgMap.ThreadID()
+	if err != nil {
+		return ""
+	}
+
+	return thID
+}
+
+// hopKeys returns the keys of the agent listening on the destination's service endpoint, which is the first mediator
+// of the routing keys.
+func hopKeys(des *service.Destination) []string {
+	if len(des.RoutingKeys) != 0 {
+		return des.RoutingKeys[:1]
 	}
 
+	return des.RecipientKeys
+}
+
+func (o *OutboundDispatcher) packForwardMessage(forward interface{}, mtProfile string,
+	toKeys []string) ([]byte, error) {
 	// convert forward message to bytes
 	req, err := json.Marshal(forward)
 	if err != nil {
 		return nil, fmt.Errorf("failed marshal to bytes: %w", err)
 	}
 
-	mtProfile := o.mediaTypeProfile(des)
-
 	var senderKey []byte
 
 	switch mtProfile {
@@ -281,7 +710,7 @@ func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Desti
 		MediaTypeProfile: mtProfile,
 		Message:          req,
 		FromKey:          senderKey,
-		ToKeys:           des.RoutingKeys,
+		ToKeys:           toKeys,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack forward msg: %w", err)
@@ -301,6 +730,10 @@ func (o *OutboundDispatcher) addTransportRouteOptions(req []byte, des *service.D
 		// create the decorator with the option set in the framework
 		transportDec := &decorator.Transport{ReturnRoute: &decorator.ReturnRoute{Value: o.transportReturnRoute}}
 
+		if o.transportReturnRoute == decorator.TransportReturnRouteThread {
+			transportDec.ReturnRoute.Thread = des.ThreadID
+		}
+
 		transportDecJSON, jsonErr := json.Marshal(transportDec)
 		if jsonErr != nil {
 			return nil, fmt.Errorf("json marshal : %w", jsonErr)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,7 +15,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -24,7 +29,9 @@ import (
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -44,6 +51,173 @@ func TestNewOutbound(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_SendTracing(t *testing.T) {
+	t.Run("test trace context is propagated in the message", func(t *testing.T) {
+		tp := &mocktracing.TracerProvider{}
+		out := &captureOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			tracerProvider:          tp,
+		})
+		require.NoError(t, err)
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}))
+
+		span := tp.Span("didcomm.outbound.send")
+		require.NotNil(t, span)
+		require.True(t, span.Ended())
+		require.Equal(t, "url", span.Attribute("didcomm.service_endpoint").AsString())
+
+		msg := struct {
+			TraceContext *decorator.TraceContext `json:"~trace_context"`
+		}{}
+		require.NoError(t, json.Unmarshal(out.data, &msg))
+		require.NotNil(t, msg.TraceContext)
+		require.Contains(t, msg.TraceContext.TraceParent, span.SpanContext().TraceID().String())
+		require.Contains(t, msg.TraceContext.TraceParent, span.SpanContext().SpanID().String())
+	})
+
+	t.Run("test span records failure", func(t *testing.T) {
+		tp := &mocktracing.TracerProvider{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeV1PlaintextPayload},
+			tracerProvider:       tp,
+		})
+		require.NoError(t, err)
+		require.Error(t, o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"}))
+
+		span := tp.Span("didcomm.outbound.send")
+		require.NotNil(t, span)
+		require.Len(t, span.Errors(), 1)
+		require.Equal(t, codes.Error, span.Status())
+	})
+
+	t.Run("test message is unchanged when tracing is disabled", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+		})
+		require.NoError(t, err)
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}))
+		require.NotContains(t, string(out.data), decorator.TraceContextKey)
+	})
+}
+
+func TestOutboundDispatcher_SendMiddleware(t *testing.T) {
+	newOutbound := func(t *testing.T, out transport.OutboundTransport, mw ...MessageMiddleware) *OutboundDispatcher {
+		t.Helper()
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			outboundMiddleware:      mw,
+		})
+		require.NoError(t, err)
+
+		return o
+	}
+
+	t.Run("test message changed by middleware", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+		des := &service.Destination{ServiceEndpoint: "url"}
+
+		o := newOutbound(t, out, func(next MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(metadata *MessageMetadata) error {
+				require.Equal(t, DirectionOutbound, metadata.Direction)
+				require.Equal(t, des, metadata.Destination)
+
+				metadata.Message["comment"] = "changed"
+
+				return next.Handle(metadata)
+			})
+		})
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t), des))
+
+		msg := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(out.data, &msg))
+		require.Equal(t, "123", msg["@id"])
+		require.Equal(t, "changed", msg["comment"])
+	})
+
+	t.Run("test message blocked by middleware", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+
+		o := newOutbound(t, out, func(next MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(metadata *MessageMetadata) error {
+				return errors.New("blocked")
+			})
+		})
+		err := o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"})
+		require.EqualError(t, err, "outbound middleware: blocked")
+		require.Nil(t, out.data)
+	})
+
+	t.Run("test message dropped by middleware", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+
+		o := newOutbound(t, out, func(next MessageHandler) MessageHandler {
+			return MessageHandlerFunc(func(metadata *MessageMetadata) error {
+				return nil
+			})
+		})
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}))
+		require.Nil(t, out.data)
+	})
+
+	t.Run("test invalid message", func(t *testing.T) {
+		o := newOutbound(t, &captureOutboundTransport{}, func(next MessageHandler) MessageHandler {
+			return next
+		})
+		err := o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "outbound middleware")
+	})
+}
+
+func TestOutboundDispatcher_TransportErrorMetrics(t *testing.T) {
+	mp := &mockmetrics.Provider{}
+
+	o, err := NewOutbound(&mockProvider{
+		packagerValue:           &mockpackager.Packager{},
+		outboundTransportsValue: []transport.OutboundTransport{&mockOutboundTransport{expectedRequest: "data"}},
+		storageProvider:         mockstore.NewMockStoreProvider(),
+		protoStorageProvider:    mockstore.NewMockStoreProvider(),
+		mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+		metricsProvider:         mp,
+	})
+	require.NoError(t, err)
+
+	require.Error(t, o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "https://url"}))
+	require.Error(t, o.Forward("other", &service.Destination{ServiceEndpoint: "ws://url"}))
+	require.Error(t, o.deliver([]byte("other"), &service.Destination{ServiceEndpoint: "url"}))
+
+	observations := mp.Observations(mockmetrics.TransportError)
+	require.Len(t, observations, 3)
+	require.Equal(t, []string{"https"}, observations[0].Labels)
+	require.Equal(t, []string{"ws"}, observations[1].Labels)
+	require.Equal(t, []string{"unknown"}, observations[2].Labels)
+}
+
 func TestOutboundDispatcher_Send(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
 		o, err := NewOutbound(&mockProvider{
@@ -181,6 +355,66 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 			"and export Encryption Key: create and export key error")
 	})
 
+	t.Run("test create forward message - DIDComm v2 routing", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		envelope := []byte(`{"protected":"abc","ciphertext":"def"}`)
+
+		fwdBytes, err := o.createForwardMessage(envelope, &service.Destination{
+			ServiceEndpoint: "url",
+			RecipientKeys:   []string{"abc"},
+			RoutingKeys:     []string{"xyz"},
+		})
+		require.NoError(t, err)
+
+		forward := &model.ForwardV2{}
+		require.NoError(t, json.Unmarshal(fwdBytes, forward))
+		require.Equal(t, service.ForwardV2MsgType, forward.Type)
+		require.NotEmpty(t, forward.ID)
+		require.Equal(t, []string{"xyz"}, forward.To)
+		require.Equal(t, "abc", forward.Body.Next)
+		require.Len(t, forward.Attachments, 1)
+		require.Equal(t, transport.MediaTypeV2EncryptedEnvelope, forward.Attachments[0].MediaType)
+
+		attached, err := forward.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.JSONEq(t, string(envelope), string(attached))
+	})
+
+	t.Run("test create forward message - DIDComm v2 routing with compact envelope", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		fwdBytes, err := o.createForwardMessage([]byte("abc.def.ghi"), &service.Destination{
+			ServiceEndpoint: "url",
+			RecipientKeys:   []string{"abc"},
+			RoutingKeys:     []string{"xyz"},
+		})
+		require.NoError(t, err)
+
+		forward := &model.ForwardV2{}
+		require.NoError(t, json.Unmarshal(fwdBytes, forward))
+		require.Len(t, forward.Attachments, 1)
+		require.NotEmpty(t, forward.Attachments[0].Data.Base64)
+
+		attached, err := forward.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "abc.def.ghi", string(attached))
+	})
+
 	t.Run("test send with forward message - packer error", func(t *testing.T) {
 		o, err := NewOutbound(&mockProvider{
 			packagerValue:           &mockpackager.Packager{PackErr: errors.New("pack error")},
@@ -227,6 +461,55 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 		require.NoError(t, o.SendToDID("data", "", ""))
 	})
 
+	t.Run("success with DID rotation adds from_prior to DIDComm v2 messages", func(t *testing.T) {
+		packager := &capturingPackager{Packager: mockpackager.Packager{PackValue: createPackedMsgForForward(t)}}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: packager,
+			vdr: &mockvdr.MockVDRegistry{
+				ResolveValue: mockDoc,
+			},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockdidcomm.MockOutboundTransport{AcceptValue: true},
+			},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		o.connections = &mockConnectionLookup{
+			getConnectionByDIDsVal: "mock1",
+			getConnectionRecordVal: &connection.Record{
+				MyDIDRotation: &connection.DIDRotationRecord{FromPrior: "from-prior-jwt"},
+			},
+		}
+
+		msgV2 := service.DIDCommMsgMap{"id": "1", "type": "https://didcomm.org/test/2.0/test"}
+		require.NoError(t, o.SendToDID(msgV2, "", ""))
+		require.Contains(t, packager.packed(), `"from_prior":"from-prior-jwt"`)
+		require.NotContains(t, msgV2, jsonFromPrior)
+
+		packager.messages = nil
+
+		require.NoError(t, o.SendToDID(struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		}{ID: "2", Type: "https://didcomm.org/test/2.0/test"}, "", ""))
+		require.Contains(t, packager.packed(), `"from_prior":"from-prior-jwt"`)
+
+		packager.messages = nil
+
+		require.NoError(t, o.SendToDID(service.DIDCommMsgMap{
+			"@id": "3", "@type": "https://didcomm.org/test/1.0/test",
+		}, "", ""))
+		require.NotContains(t, packager.packed(), jsonFromPrior)
+
+		err = o.SendToDID(make(chan int), "", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal message")
+	})
+
 	t.Run("resolve err", func(t *testing.T) {
 		o, err := NewOutbound(&mockProvider{
 			packagerValue: &mockpackager.Packager{},
@@ -453,6 +736,42 @@ func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 		require.NoError(t, o.Send(req, mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"}))
 	})
 
+	t.Run("transport route option - message sent through the return route of its thread", func(t *testing.T) {
+		thID := uuid.New().String()
+
+		threadTransport := &threadOutboundTransport{threadID: thID}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{threadTransport},
+			transportReturnRoute:    decorator.TransportReturnRouteThread,
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{
+			"@id":     uuid.New().String(),
+			"@type":   "https://didcomm.org/test/1.0/test",
+			"~thread": map[string]interface{}{"thid": thID},
+		}
+
+		require.NoError(t, o.Send(msg, mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"}))
+		require.Equal(t, thID, threadTransport.destination.ThreadID)
+
+		sent := &decorator.Transport{}
+		require.NoError(t, json.Unmarshal(threadTransport.data, sent))
+		require.Equal(t, &decorator.ReturnRoute{Value: decorator.TransportReturnRouteThread, Thread: thID},
+			sent.ReturnRoute)
+
+		msg["~thread"] = map[string]interface{}{"thid": uuid.New().String()}
+
+		err = o.Send(msg, mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no transport found for destination")
+	})
+
 	t.Run("transport route option - no value set", func(t *testing.T) {
 		req := &decorator.Thread{
 			ID: uuid.New().String(),
@@ -542,6 +861,100 @@ func TestOutboundDispatcher_Forward(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_SendThroughRelays(t *testing.T) {
+	relay1 := &service.Destination{ServiceEndpoint: "relay1", RecipientKeys: []string{"relay1Key"}}
+	relay2 := &service.Destination{ServiceEndpoint: "relay2", RecipientKeys: []string{"relay2Key"}}
+
+	unwrap := func(t *testing.T, data []byte) *model.Forward {
+		t.Helper()
+
+		forward := &model.Forward{}
+		require.NoError(t, json.Unmarshal(data, forward))
+		require.Equal(t, service.ForwardMsgType, forward.Type)
+
+		return forward
+	}
+
+	t.Run("test success - relays from provider", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			relays:                  []*service.Destination{relay1, relay2},
+		})
+		require.NoError(t, err)
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url", RecipientKeys: []string{"recKey"}}))
+
+		require.Equal(t, relay1, out.destination)
+
+		forward := unwrap(t, out.data)
+		require.Equal(t, "relay2Key", forward.To)
+		require.Equal(t, &model.ForwardHop{ServiceEndpoint: "relay2", RecipientKeys: []string{"relay2Key"}}, forward.Next)
+
+		forward = unwrap(t, forward.Msg)
+		require.Equal(t, "recKey", forward.To)
+		require.Equal(t, &model.ForwardHop{ServiceEndpoint: "url", RecipientKeys: []string{"recKey"}}, forward.Next)
+
+		msg := map[string]string{}
+		require.NoError(t, json.Unmarshal(forward.Msg, &msg))
+		require.Equal(t, "123", msg["@id"])
+	})
+
+	t.Run("test success - destination relays with recipient mediator", func(t *testing.T) {
+		out := &captureOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			relays:                  []*service.Destination{relay1},
+		})
+		require.NoError(t, err)
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{
+				ServiceEndpoint: "mediator",
+				RecipientKeys:   []string{"recKey"},
+				RoutingKeys:     []string{"routingKey"},
+				Relays:          []*service.Destination{relay2},
+			}))
+
+		require.Equal(t, relay2, out.destination)
+
+		forward := unwrap(t, out.data)
+		require.Equal(t, "routingKey", forward.To)
+		require.Equal(t, &model.ForwardHop{ServiceEndpoint: "mediator", RecipientKeys: []string{"routingKey"}},
+			forward.Next)
+
+		forward = unwrap(t, forward.Msg)
+		require.Equal(t, "recKey", forward.To)
+		require.Nil(t, forward.Next)
+	})
+
+	t.Run("test failure - relay without recipient keys", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{&captureOutboundTransport{}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			relays:                  []*service.Destination{{ServiceEndpoint: "relay"}},
+		})
+		require.NoError(t, err)
+
+		err = o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url", RecipientKeys: []string{"recKey"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "relay 0 [relay] has no recipient keys")
+	})
+}
+
 func createPackedMsgForForward(_ *testing.T) []byte {
 	return []byte("")
 }
@@ -557,6 +970,11 @@ type mockProvider struct {
 	protoStorageProvider    storage.Provider
 	mediaTypeProfiles       []string
 	keyAgreementType        kms.KeyType
+	tracerProvider          trace.TracerProvider
+	metricsProvider         metrics.Provider
+	relays                  []*service.Destination
+	retryPolicy             *RetryPolicy
+	outboundMiddleware      []MessageMiddleware
 }
 
 func (p *mockProvider) Packager() transport.Packager {
@@ -599,6 +1017,30 @@ func (p *mockProvider) KeyAgreementType() kms.KeyType {
 	return p.keyAgreementType
 }
 
+func (p *mockProvider) TracerProvider() trace.TracerProvider {
+	return p.tracerProvider
+}
+
+func (p *mockProvider) MetricsProvider() metrics.Provider {
+	if p.metricsProvider == nil {
+		return metrics.Noop{}
+	}
+
+	return p.metricsProvider
+}
+
+func (p *mockProvider) OutboundRelays() []*service.Destination {
+	return p.relays
+}
+
+func (p *mockProvider) OutboundRetryPolicy() *RetryPolicy {
+	return p.retryPolicy
+}
+
+func (p *mockProvider) OutboundMiddleware() []MessageMiddleware {
+	return p.outboundMiddleware
+}
+
 // mockOutboundTransport mock outbound transport.
 type mockOutboundTransport struct {
 	expectedRequest string
@@ -625,7 +1067,62 @@ func (o *mockOutboundTransport) Accept(url string) bool {
 	return true
 }
 
+// captureOutboundTransport keeps the last sent message and its destination.
+type captureOutboundTransport struct {
+	data        []byte
+	destination *service.Destination
+}
+
+func (o *captureOutboundTransport) Start(transport.Provider) error {
+	return nil
+}
+
+func (o *captureOutboundTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	o.data = data
+	o.destination = destination
+
+	return "", nil
+}
+
+func (o *captureOutboundTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *captureOutboundTransport) Accept(string) bool {
+	return true
+}
+
+// threadOutboundTransport only has a return route scoped to a thread.
+type threadOutboundTransport struct {
+	captureOutboundTransport
+	threadID string
+}
+
+func (o *threadOutboundTransport) AcceptThread(threadID string) bool {
+	return threadID == o.threadID
+}
+
+func (o *threadOutboundTransport) Accept(string) bool {
+	return false
+}
+
 // mockPackager mock packager.
+// capturingPackager captures the packed messages.
+type capturingPackager struct {
+	mockpackager.Packager
+	messages [][]byte
+}
+
+func (p *capturingPackager) PackMessage(e *transport.Envelope) ([]byte, error) {
+	p.messages = append(p.messages, e.Message)
+
+	return p.Packager.PackMessage(e)
+}
+
+func (p *capturingPackager) packed() string {
+	return string(bytes.Join(p.messages, nil))
+}
+
 type mockPackager struct{}
 
 func (m *mockPackager) PackMessage(e *transport.Envelope) ([]byte, error) {
@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	errSvcAlreadyRegistered = "registration failed, protocol service with name `%s` already registered"
+	errSvcNeverRegistered   = "failed to unregister, unable to find registered protocol service with name `%s`"
+)
+
+// ProtocolRegistry maintains the list of protocol services inbound messages are routed to. Services can be
+// registered and unregistered while the agent is running, inbound messages are routed to the first registered
+// service accepting their message type.
+type ProtocolRegistry struct {
+	services []ProtocolService
+	lock     sync.RWMutex
+}
+
+// NewProtocolRegistry returns a new protocol registry holding the given services.
+func NewProtocolRegistry(services ...ProtocolService) *ProtocolRegistry {
+	svcs := make([]ProtocolService, len(services))
+	copy(svcs, services)
+
+	return &ProtocolRegistry{services: svcs}
+}
+
+// Services returns the registered protocol services.
+func (r *ProtocolRegistry) Services() []ProtocolService {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	svcs := make([]ProtocolService, len(r.services))
+	copy(svcs, r.services)
+
+	return svcs
+}
+
+// Service returns the registered protocol service with the given name.
+func (r *ProtocolRegistry) Service(name string) (ProtocolService, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, svc := range r.services {
+		if svc.Name() == name {
+			return svc, true
+		}
+	}
+
+	return nil, false
+}
+
+// PIURIs returns the sorted URIs of the protocols of the registered services implementing Discoverable.
+func (r *ProtocolRegistry) PIURIs() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	uris := map[string]struct{}{}
+
+	for _, svc := range r.services {
+		if d, ok := svc.(Discoverable); ok {
+			for _, uri := range d.PIURIs() {
+				uris[uri] = struct{}{}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(uris))
+
+	for uri := range uris {
+		sorted = append(sorted, uri)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// Register registers the given protocol services, returns error in case of duplicate registration.
+func (r *ProtocolRegistry) Register(services ...ProtocolService) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make(map[string]struct{}, len(r.services)+len(services))
+
+	for _, svc := range r.services {
+		names[svc.Name()] = struct{}{}
+	}
+
+	for _, newSvc := range services {
+		if _, ok := names[newSvc.Name()]; ok {
+			return fmt.Errorf(errSvcAlreadyRegistered, newSvc.Name())
+		}
+
+		names[newSvc.Name()] = struct{}{}
+	}
+
+	r.services = append(r.services, services...)
+
+	return nil
+}
+
+// Unregister unregisters the protocol service with the given name, returns error if the service isn't registered.
+func (r *ProtocolRegistry) Unregister(name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, svc := range r.services {
+		if svc.Name() == name {
+			r.services = append(r.services[:i:i], r.services[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf(errSvcNeverRegistered, name)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestProtocolRegistry(t *testing.T) {
+	t.Run("test register and unregister", func(t *testing.T) {
+		registry := NewProtocolRegistry(&mockProtocolService{name: "svc1"})
+		require.Len(t, registry.Services(), 1)
+
+		require.NoError(t, registry.Register(&mockProtocolService{name: "svc2"}, &mockProtocolService{name: "svc3"}))
+		require.Len(t, registry.Services(), 3)
+
+		svc, ok := registry.Service("svc2")
+		require.True(t, ok)
+		require.Equal(t, "svc2", svc.Name())
+
+		require.NoError(t, registry.Unregister("svc2"))
+		require.Len(t, registry.Services(), 2)
+		require.Equal(t, "svc1", registry.Services()[0].Name())
+		require.Equal(t, "svc3", registry.Services()[1].Name())
+
+		_, ok = registry.Service("svc2")
+		require.False(t, ok)
+	})
+
+	t.Run("test duplicate registration", func(t *testing.T) {
+		registry := NewProtocolRegistry(&mockProtocolService{name: "svc1"})
+
+		err := registry.Register(&mockProtocolService{name: "svc1"})
+		require.EqualError(t, err, "registration failed, protocol service with name `svc1` already registered")
+
+		err = registry.Register(&mockProtocolService{name: "svc2"}, &mockProtocolService{name: "svc2"})
+		require.EqualError(t, err, "registration failed, protocol service with name `svc2` already registered")
+		require.Len(t, registry.Services(), 1)
+	})
+
+	t.Run("test unregister unknown service", func(t *testing.T) {
+		err := NewProtocolRegistry().Unregister("svc1")
+		require.EqualError(t, err, "failed to unregister, unable to find registered protocol service with name `svc1`")
+	})
+
+	t.Run("test services snapshot", func(t *testing.T) {
+		registry := NewProtocolRegistry(&mockProtocolService{name: "svc1"})
+
+		svcs := registry.Services()
+		require.NoError(t, registry.Unregister("svc1"))
+		require.Len(t, svcs, 1)
+		require.Empty(t, registry.Services())
+	})
+
+	t.Run("test protocol URIs", func(t *testing.T) {
+		registry := NewProtocolRegistry(
+			&mockDiscoverableService{mockProtocolService{name: "svc1"}, []string{"https://didcomm.org/b/1.0"}},
+			&mockProtocolService{name: "svc2"},
+			&mockDiscoverableService{mockProtocolService{name: "svc3"}, []string{
+				"https://didcomm.org/a/2.0", "https://didcomm.org/b/1.0",
+			}},
+		)
+
+		require.Equal(t, []string{"https://didcomm.org/a/2.0", "https://didcomm.org/b/1.0"}, registry.PIURIs())
+
+		require.NoError(t, registry.Unregister("svc3"))
+		require.Equal(t, []string{"https://didcomm.org/b/1.0"}, registry.PIURIs())
+		require.Empty(t, NewProtocolRegistry().PIURIs())
+	})
+}
+
+type mockProtocolService struct {
+	name string
+}
+
+func (m *mockProtocolService) HandleInbound(service.DIDCommMsg, service.DIDCommContext) (string, error) {
+	return "", nil
+}
+
+func (m *mockProtocolService) HandleOutbound(service.DIDCommMsg, string, string) (string, error) {
+	return "", nil
+}
+
+func (m *mockProtocolService) Accept(string) bool {
+	return true
+}
+
+func (m *mockProtocolService) Name() string {
+	return m.name
+}
+
+type mockDiscoverableService struct {
+	mockProtocolService
+	piURIs []string
+}
+
+func (m *mockDiscoverableService) PIURIs() []string {
+	return m.piURIs
+}
@@ -0,0 +1,377 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// OutboundRetryStore is the name of the store keeping the outbound messages waiting for a delivery retry, and
+	// the outbound messages scheduled for a future delivery.
+	OutboundRetryStore = "outbound_retry"
+
+	retryTag = "outbound_retry"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultMultiplier     = 2
+)
+
+// outboundStoreConfig is the configuration of the store keeping the messages queued for retry and the scheduled
+// messages.
+var outboundStoreConfig = storage.StoreConfiguration{TagNames: []string{retryTag, scheduledTag}} // nolint: gochecknoglobals
+
+// RetryPolicy configures the delivery retries of outbound messages which couldn't be sent by the outbound transport.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of delivery attempts (including the first one) before the message is dropped
+	// and a DeliveryFailure is reported. Defaults to 5.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to 1s.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two retries. Defaults to 1m.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay is multiplied by after each failed retry. Defaults to 2.
+	Multiplier float64
+}
+
+func (p *RetryPolicy) withDefaults() *RetryPolicy {
+	policy := *p
+
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaultMaxAttempts
+	}
+
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaultInitialBackoff
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaultMaxBackoff
+	}
+
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaultMultiplier
+	}
+
+	return &policy
+}
+
+// backoff returns the delay before the next delivery attempt once the message failed the given number of attempts.
+func (p *RetryPolicy) backoff(attempts int) time.Duration {
+	delay := float64(p.InitialBackoff)
+
+	for i := 1; i < attempts; i++ {
+		delay *= p.Multiplier
+
+		if delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// DeliveryFailure describes an outbound message dropped after all its delivery attempts failed.
+type DeliveryFailure struct {
+	// ID of the queued message.
+	ID string
+	// ServiceEndpoint the message was sent to.
+	ServiceEndpoint string
+	// Attempts is the number of delivery attempts.
+	Attempts int
+	// Err is the error of the last delivery attempt.
+	Err error
+}
+
+// All implements service.EventProperties.
+func (f *DeliveryFailure) All() map[string]interface{} {
+	return map[string]interface{}{
+		"id":               f.ID,
+		"service_endpoint": f.ServiceEndpoint,
+		"attempts":         f.Attempts,
+		"error":            f.Err.Error(),
+	}
+}
+
+// DeliveryFailureHandler is notified when an outbound message is dropped.
+type DeliveryFailureHandler func(failure *DeliveryFailure)
+
+// queuedMessage is an undelivered packed message kept in the retry store.
+type queuedMessage struct {
+	ID              string   `json:"id"`
+	Data            []byte   `json:"data"`
+	ServiceEndpoint string   `json:"service_endpoint"`
+	RecipientKeys   []string `json:"recipient_keys,omitempty"`
+	RoutingKeys     []string `json:"routing_keys,omitempty"`
+	Attempts        int      `json:"attempts"`
+	LastError       string   `json:"last_error,omitempty"`
+}
+
+func (m *queuedMessage) destination() *service.Destination {
+	return &service.Destination{
+		ServiceEndpoint: m.ServiceEndpoint,
+		RecipientKeys:   m.RecipientKeys,
+		RoutingKeys:     m.RoutingKeys,
+	}
+}
+
+// retryQueue persists undelivered outbound messages and retries their delivery with an exponential backoff.
+type retryQueue struct {
+	store    storage.Store
+	policy   *RetryPolicy
+	send     func(data []byte, des *service.Destination) error
+	mu       sync.RWMutex
+	handlers []DeliveryFailureHandler
+	timersMu sync.Mutex
+	timers   map[string]*pendingRetry
+	flushed  bool
+	inflight sync.WaitGroup
+}
+
+// pendingRetry is a message waiting for its next delivery attempt.
+type pendingRetry struct {
+	msg   *queuedMessage
+	timer *time.Timer
+}
+
+func newRetryQueue(provider storage.Provider, policy *RetryPolicy,
+	send func([]byte, *service.Destination) error) (*retryQueue, error) {
+	store, err := provider.OpenStore(OutboundRetryStore)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(OutboundRetryStore, outboundStoreConfig)
+	if err != nil {
+		return nil, fmt.Errorf("set store config: %w", err)
+	}
+
+	return &retryQueue{
+		store:  store,
+		policy: policy.withDefaults(),
+		send:   send,
+		timers: make(map[string]*pendingRetry),
+	}, nil
+}
+
+// resume schedules the delivery of the messages queued before the agent was (re)started.
+func (q *retryQueue) resume() error {
+	iter, err := q.store.Query(retryTag)
+	if err != nil {
+		return fmt.Errorf("query queued messages: %w", err)
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Warnf("failed to close queued messages iterator: %s", errClose)
+		}
+	}()
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("next queued message: %w", err)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		value, err := iter.Value()
+		if err != nil {
+			return fmt.Errorf("queued message value: %w", err)
+		}
+
+		msg := &queuedMessage{}
+
+		if err = json.Unmarshal(value, msg); err != nil {
+			return fmt.Errorf("unmarshal queued message: %w", err)
+		}
+
+		q.schedule(msg)
+	}
+}
+
+// enqueue persists the message which failed its first delivery attempt and schedules a retry. An error is returned
+// when the message is dropped without retries or can't be queued.
+func (q *retryQueue) enqueue(data []byte, des *service.Destination, sendErr error) error {
+	msg := &queuedMessage{
+		ID:              uuid.New().String(),
+		Data:            data,
+		ServiceEndpoint: des.ServiceEndpoint,
+		RecipientKeys:   des.RecipientKeys,
+		RoutingKeys:     des.RoutingKeys,
+		Attempts:        1,
+		LastError:       sendErr.Error(),
+	}
+
+	if msg.Attempts >= q.policy.MaxAttempts {
+		q.notify(msg, sendErr)
+
+		return fmt.Errorf("message dropped after %d attempts: %w", msg.Attempts, sendErr)
+	}
+
+	if err := q.save(msg); err != nil {
+		return fmt.Errorf("failed to queue msg for retry (send error: %s): %w", sendErr, err)
+	}
+
+	logger.Warnf("outbound message [%s] to %s queued for retry: %s", msg.ID, msg.ServiceEndpoint, sendErr)
+
+	q.schedule(msg)
+
+	return nil
+}
+
+// schedule retries the delivery of the message after the backoff. Once the queue is flushed, the message is only kept
+// in the store, to be retried when the agent restarts.
+func (q *retryQueue) schedule(msg *queuedMessage) {
+	q.timersMu.Lock()
+	defer q.timersMu.Unlock()
+
+	if q.flushed {
+		return
+	}
+
+	q.timers[msg.ID] = &pendingRetry{
+		msg: msg,
+		timer: time.AfterFunc(q.policy.backoff(msg.Attempts), func() {
+			if q.take(msg.ID) {
+				defer q.inflight.Done()
+
+				q.retry(msg)
+			}
+		}),
+	}
+}
+
+// take removes the message from the pending retries before its delivery attempt, unless the queue was flushed in
+// the meantime.
+func (q *retryQueue) take(id string) bool {
+	q.timersMu.Lock()
+	defer q.timersMu.Unlock()
+
+	if _, ok := q.timers[id]; !ok {
+		return false
+	}
+
+	delete(q.timers, id)
+	q.inflight.Add(1)
+
+	return true
+}
+
+// flush stops the retries, making a last delivery attempt of the pending messages right away and waiting for the
+// attempts in progress. The messages which still can't be delivered are kept in the store.
+func (q *retryQueue) flush() {
+	q.timersMu.Lock()
+
+	if q.flushed {
+		q.timersMu.Unlock()
+
+		return
+	}
+
+	q.flushed = true
+
+	pending := make([]*queuedMessage, 0, len(q.timers))
+
+	for id, p := range q.timers {
+		p.timer.Stop()
+		pending = append(pending, p.msg)
+
+		delete(q.timers, id)
+	}
+
+	q.timersMu.Unlock()
+
+	for _, msg := range pending {
+		q.retry(msg)
+	}
+
+	q.inflight.Wait()
+}
+
+func (q *retryQueue) retry(msg *queuedMessage) {
+	err := q.send(msg.Data, msg.destination())
+	if err == nil {
+		logger.Debugf("outbound message [%s] delivered after %d attempts", msg.ID, msg.Attempts+1)
+
+		if err = q.store.Delete(msg.ID); err != nil {
+			logger.Errorf("failed to delete delivered outbound message [%s]: %s", msg.ID, err)
+		}
+
+		return
+	}
+
+	msg.Attempts++
+	msg.LastError = err.Error()
+
+	if msg.Attempts >= q.policy.MaxAttempts {
+		if errDelete := q.store.Delete(msg.ID); errDelete != nil {
+			logger.Errorf("failed to delete undelivered outbound message [%s]: %s", msg.ID, errDelete)
+		}
+
+		q.notify(msg, err)
+
+		return
+	}
+
+	if err = q.save(msg); err != nil {
+		logger.Errorf("failed to update outbound message [%s]: %s", msg.ID, err)
+	}
+
+	q.schedule(msg)
+}
+
+func (q *retryQueue) save(msg *queuedMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal queued message: %w", err)
+	}
+
+	if err = q.store.Put(msg.ID, raw, storage.Tag{Name: retryTag}); err != nil {
+		return fmt.Errorf("save queued message: %w", err)
+	}
+
+	return nil
+}
+
+func (q *retryQueue) notify(msg *queuedMessage, err error) {
+	logger.Errorf("outbound message [%s] to %s dropped after %d attempts: %s",
+		msg.ID, msg.ServiceEndpoint, msg.Attempts, err)
+
+	failure := &DeliveryFailure{
+		ID:              msg.ID,
+		ServiceEndpoint: msg.ServiceEndpoint,
+		Attempts:        msg.Attempts,
+		Err:             err,
+	}
+
+	q.mu.RLock()
+	handlers := append(q.handlers[:0:0], q.handlers...)
+	q.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(failure)
+	}
+}
+
+func (q *retryQueue) onDeliveryFailure(handler DeliveryFailureHandler) {
+	q.mu.Lock()
+	q.handlers = append(q.handlers, handler)
+	q.mu.Unlock()
+}
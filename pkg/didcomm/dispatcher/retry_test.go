@@ -0,0 +1,306 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const retryTimeout = 2 * time.Second
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("test defaults", func(t *testing.T) {
+		policy := (&RetryPolicy{}).withDefaults()
+		require.Equal(t, defaultMaxAttempts, policy.MaxAttempts)
+		require.Equal(t, defaultInitialBackoff, policy.InitialBackoff)
+		require.Equal(t, defaultMaxBackoff, policy.MaxBackoff)
+		require.Equal(t, float64(defaultMultiplier), policy.Multiplier)
+	})
+
+	t.Run("test exponential backoff", func(t *testing.T) {
+		policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+		require.Equal(t, time.Second, policy.backoff(1))
+		require.Equal(t, 2*time.Second, policy.backoff(2))
+		require.Equal(t, 4*time.Second, policy.backoff(3))
+		require.Equal(t, 5*time.Second, policy.backoff(4))
+		require.Equal(t, 5*time.Second, policy.backoff(10))
+	})
+}
+
+func TestOutboundDispatcher_Retry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	newOutbound := func(t *testing.T, out transport.OutboundTransport, store storage.Provider) *OutboundDispatcher {
+		t.Helper()
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         store,
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			retryPolicy:             policy,
+		})
+		require.NoError(t, err)
+
+		return o
+	}
+
+	t.Run("test send delivered after retries", func(t *testing.T) {
+		out := newFlakyOutboundTransport(2)
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(t, out, store)
+
+		require.NoError(t, o.Send(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}))
+
+		select {
+		case data := <-out.delivered:
+			require.Contains(t, string(data), "123")
+		case <-time.After(retryTimeout):
+			require.Fail(t, "message not delivered")
+		}
+
+		require.Equal(t, 3, out.attempts())
+		require.Eventually(t, func() bool {
+			iter, err := store.Store.Query(retryTag)
+			require.NoError(t, err)
+
+			ok, err := iter.Next()
+			require.NoError(t, err)
+
+			return !ok
+		}, retryTimeout, time.Millisecond)
+	})
+
+	t.Run("test forward dropped after max attempts", func(t *testing.T) {
+		out := newFlakyOutboundTransport(policy.MaxAttempts)
+		o := newOutbound(t, out, mockstore.NewMockStoreProvider())
+
+		failures := make(chan *DeliveryFailure, 1)
+		o.OnDeliveryFailure(func(failure *DeliveryFailure) {
+			failures <- failure
+		})
+
+		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
+
+		select {
+		case failure := <-failures:
+			require.Equal(t, "url", failure.ServiceEndpoint)
+			require.Equal(t, policy.MaxAttempts, failure.Attempts)
+			require.EqualError(t, failure.Err, "failed to send msg using outbound transport: send error")
+		case <-time.After(retryTimeout):
+			require.Fail(t, "delivery failure not reported")
+		}
+
+		require.Equal(t, policy.MaxAttempts, out.attempts())
+	})
+
+	t.Run("test message dropped without retries", func(t *testing.T) {
+		q, err := newRetryQueue(mockstore.NewMockStoreProvider(), &RetryPolicy{MaxAttempts: 1},
+			func([]byte, *service.Destination) error { return nil })
+		require.NoError(t, err)
+
+		failures := make(chan *DeliveryFailure, 1)
+		q.onDeliveryFailure(func(failure *DeliveryFailure) {
+			failures <- failure
+		})
+
+		err = q.enqueue([]byte("data"), &service.Destination{ServiceEndpoint: "url"}, errors.New("send error"))
+		require.EqualError(t, err, "message dropped after 1 attempts: send error")
+		require.Equal(t, 1, (<-failures).Attempts)
+	})
+
+	t.Run("test queued messages are resumed", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+
+		raw, err := json.Marshal(&queuedMessage{ID: "id", Data: []byte("data"), ServiceEndpoint: "url", Attempts: 1})
+		require.NoError(t, err)
+
+		s, err := store.OpenStore(OutboundRetryStore)
+		require.NoError(t, err)
+		require.NoError(t, s.Put("id", raw, storage.Tag{Name: retryTag}))
+
+		out := newFlakyOutboundTransport(0)
+		newOutbound(t, out, store)
+
+		select {
+		case data := <-out.delivered:
+			require.Equal(t, "data", string(data))
+		case <-time.After(retryTimeout):
+			require.Fail(t, "message not delivered")
+		}
+	})
+
+	t.Run("test queue error", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+		store.Store.ErrPut = errors.New("put error")
+
+		o := newOutbound(t, newFlakyOutboundTransport(1), store)
+
+		err := o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to queue msg for retry (send error: send error)")
+		require.Contains(t, err.Error(), "put error")
+	})
+
+	t.Run("test flush makes a last delivery attempt", func(t *testing.T) {
+		queued := func(t *testing.T, store *mockstore.MockStoreProvider) []*queuedMessage {
+			t.Helper()
+
+			iter, err := store.Store.Query(retryTag)
+			require.NoError(t, err)
+
+			var messages []*queuedMessage
+
+			for {
+				ok, err := iter.Next()
+				require.NoError(t, err)
+
+				if !ok {
+					return messages
+				}
+
+				value, err := iter.Value()
+				require.NoError(t, err)
+
+				msg := &queuedMessage{}
+				require.NoError(t, json.Unmarshal(value, msg))
+
+				messages = append(messages, msg)
+			}
+		}
+
+		newOutbound := func(out transport.OutboundTransport, store storage.Provider) *OutboundDispatcher {
+			o, err := NewOutbound(&mockProvider{
+				packagerValue:           &mockPackager{},
+				outboundTransportsValue: []transport.OutboundTransport{out},
+				storageProvider:         store,
+				protoStorageProvider:    mockstore.NewMockStoreProvider(),
+				mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+				retryPolicy:             &RetryPolicy{InitialBackoff: time.Hour},
+			})
+			require.NoError(t, err)
+
+			return o
+		}
+
+		// the message is delivered by the flush instead of waiting for the backoff
+		out := newFlakyOutboundTransport(1)
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(out, store)
+
+		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
+		require.Len(t, queued(t, store), 1)
+
+		o.Flush()
+		o.Flush()
+
+		require.Equal(t, `"data"`, string(<-out.delivered))
+		require.Empty(t, queued(t, store))
+
+		// the undelivered messages are kept for the restart of the agent
+		out = newFlakyOutboundTransport(3)
+		store = mockstore.NewMockStoreProvider()
+		o = newOutbound(out, store)
+
+		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
+
+		o.Flush()
+
+		messages := queued(t, store)
+		require.Len(t, messages, 1)
+		require.Equal(t, 2, messages[0].Attempts)
+
+		// a message failing its first delivery attempt after the flush is only queued
+		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
+		require.Len(t, queued(t, store), 2)
+		require.Equal(t, 3, out.attempts())
+	})
+
+	t.Run("test init errors", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+		store.FailNamespace = OutboundRetryStore
+
+		_, err := NewOutbound(&mockProvider{
+			storageProvider:      store,
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			retryPolicy:          policy,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init outbound retry queue: open store")
+
+		store = mockstore.NewMockStoreProvider()
+		store.Store.ErrQuery = errors.New("query error")
+
+		_, err = NewOutbound(&mockProvider{
+			storageProvider:      store,
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			retryPolicy:          policy,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resume outbound retry queue: query queued messages: query error")
+	})
+}
+
+// flakyOutboundTransport fails the given number of sends before delivering messages.
+type flakyOutboundTransport struct {
+	mu        sync.Mutex
+	failures  int
+	sent      int
+	delivered chan []byte
+}
+
+func newFlakyOutboundTransport(failures int) *flakyOutboundTransport {
+	return &flakyOutboundTransport{failures: failures, delivered: make(chan []byte, 1)}
+}
+
+func (o *flakyOutboundTransport) Start(transport.Provider) error {
+	return nil
+}
+
+func (o *flakyOutboundTransport) Send(data []byte, _ *service.Destination) (string, error) {
+	o.mu.Lock()
+	o.sent++
+	fail := o.sent <= o.failures
+	o.mu.Unlock()
+
+	if fail {
+		return "", errors.New("send error")
+	}
+
+	o.delivered <- data
+
+	return "", nil
+}
+
+func (o *flakyOutboundTransport) attempts() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.sent
+}
+
+func (o *flakyOutboundTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *flakyOutboundTransport) Accept(string) bool {
+	return true
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	mediatorCacheSize       = 100
+	mediatorCacheExpiration = 5 * time.Minute
+	// maxMediatorTiers limits the nesting of mediators, it stops the resolution of routing loops between mediators.
+	maxMediatorTiers = 5
+)
+
+// mediatorResolver resolves the destinations of the mediators referenced by DID in the routing keys, the
+// destinations being cached as every message sent through the mediators needs them.
+type mediatorResolver struct {
+	vdr   vdr.Registry
+	cache gcache.Cache
+}
+
+func newMediatorResolver(registry vdr.Registry) *mediatorResolver {
+	return &mediatorResolver{
+		vdr:   registry,
+		cache: gcache.New(mediatorCacheSize).LRU().Expiration(mediatorCacheExpiration).Build(),
+	}
+}
+
+// resolve returns the destination of the mediator DID.
+func (r *mediatorResolver) resolve(didID string) (*service.Destination, error) {
+	if cached, err := r.cache.Get(didID); err == nil {
+		return cached.(*service.Destination), nil
+	}
+
+	dest, err := service.GetDestination(didID, r.vdr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dest.RecipientKeys) == 0 {
+		return nil, fmt.Errorf("mediator [%s] has no recipient keys", didID)
+	}
+
+	if err = r.cache.Set(didID, dest); err != nil {
+		logger.Warnf("failed to cache destination of mediator %s: %v", didID, err)
+	}
+
+	return dest, nil
+}
+
+// isMediatorDID checks whether the routing key references the DID of a mediator rather than one of its keys, which
+// are either did:key or DID URLs of the verification methods.
+func isMediatorDID(routingKey string) bool {
+	return strings.HasPrefix(routingKey, "did:") && !strings.HasPrefix(routingKey, "did:key:") &&
+		!strings.Contains(routingKey, "#")
+}
+
+// createMediatorForwardMessages wraps the packed message in the forward messages routing it to the recipient key
+// through the routing keys of the destination, starting from the last one (closest to the recipient) as the first
+// routing key is the mediator the message is sent to. Each routing key is a mediator tier: a routing key referencing
+// a mediator DID is resolved to the mediator's destination, whose own routing keys add the tiers in front of it.
+// It returns the key of the first mediator the message goes through.
+func (o *OutboundDispatcher) createMediatorForwardMessages(msg []byte, to string, des *service.Destination,
+	tier int) ([]byte, string, error) {
+	if tier >= maxMediatorTiers {
+		return nil, "", fmt.Errorf("routing through more than %d mediator tiers", maxMediatorTiers)
+	}
+
+	for i := len(des.RoutingKeys) - 1; i >= 0; i-- {
+		mediator, err := o.mediator(des.RoutingKeys[i], des)
+		if err != nil {
+			return nil, "", err
+		}
+
+		msg, err = o.wrapForward(msg, to, mediator.RecipientKeys, o.mediaTypeProfile(mediator))
+		if err != nil {
+			return nil, "", err
+		}
+
+		to = mediator.RecipientKeys[0]
+
+		if len(mediator.RoutingKeys) != 0 {
+			msg, to, err = o.createMediatorForwardMessages(msg, to, mediator, tier+1)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	return msg, to, nil
+}
+
+// mediator returns the destination of the mediator tier of the routing key, a key other than a mediator DID being
+// the single recipient key of a mediator known only by this key.
+func (o *OutboundDispatcher) mediator(routingKey string, des *service.Destination) (*service.Destination, error) {
+	if !isMediatorDID(routingKey) {
+		return &service.Destination{
+			RecipientKeys:     []string{routingKey},
+			MediaTypeProfiles: des.MediaTypeProfiles,
+		}, nil
+	}
+
+	mediator, err := o.mediators.resolve(routingKey)
+	if err != nil {
+		return nil, fmt.Errorf("resolve mediator [%s]: %w", routingKey, err)
+	}
+
+	return mediator, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+func TestIsMediatorDID(t *testing.T) {
+	require.True(t, isMediatorDID("did:peer:mediator"))
+	require.False(t, isMediatorDID("did:peer:mediator#key-1"))
+	require.False(t, isMediatorDID(mockdiddoc.MockDIDKey(t)))
+	require.False(t, isMediatorDID("H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"))
+}
+
+func TestOutboundDispatcher_MediatorTiers(t *testing.T) {
+	const (
+		recipientKey = "did:peer:bob#key-1"
+		envelope     = `{"protected":"abc","ciphertext":"def"}`
+	)
+
+	// mediatorDocs returns the DID documents of mediators, each one routing through the next one.
+	mediatorDocs := func(t *testing.T, ids ...string) map[string]*did.Doc {
+		t.Helper()
+
+		docs := make(map[string]*did.Doc)
+
+		for i, id := range ids {
+			doc := mockdiddoc.GetMockDIDDocWithDIDCommV2Bloc(t, id)
+			doc.Service[0].RoutingKeys = nil
+
+			if i+1 < len(ids) {
+				doc.Service[0].RoutingKeys = []string{"did:peer:" + ids[i+1]}
+			}
+
+			docs[doc.ID] = doc
+		}
+
+		return docs
+	}
+
+	newOutbound := func(t *testing.T, docs map[string]*did.Doc, resolved map[string]int) *OutboundDispatcher {
+		t.Helper()
+
+		var mu sync.Mutex
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					mu.Lock()
+					resolved[didID]++
+					mu.Unlock()
+
+					doc, ok := docs[didID]
+					if !ok {
+						return nil, fmt.Errorf("DID not found: %s", didID)
+					}
+
+					return &did.DocResolution{DIDDocument: doc}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		return o
+	}
+
+	// unwrap checks the forward message to the mediator keys and returns the message forwarded.
+	unwrap := func(t *testing.T, msg []byte, mediatorKey, next string) []byte {
+		t.Helper()
+
+		forward := &model.ForwardV2{}
+		require.NoError(t, json.Unmarshal(msg, forward))
+		require.Equal(t, service.ForwardV2MsgType, forward.Type)
+		require.Equal(t, []string{mediatorKey}, forward.To)
+		require.Equal(t, next, forward.Body.Next)
+		require.Len(t, forward.Attachments, 1)
+
+		forwarded, err := forward.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+
+		return forwarded
+	}
+
+	t.Run("success - nested mediators referenced by DID", func(t *testing.T) {
+		docs := mediatorDocs(t, "mediator1", "mediator2")
+		resolved := make(map[string]int)
+		o := newOutbound(t, docs, resolved)
+
+		des := &service.Destination{
+			ServiceEndpoint:   "https://localhost:8090",
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:mediator1"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		}
+
+		for i := 0; i < 2; i++ {
+			msg, err := o.createForwardMessage([]byte(envelope), des)
+			require.NoError(t, err)
+
+			// the message goes through mediator2 first, then mediator1 which delivers it to the recipient
+			msg = unwrap(t, msg, "did:peer:mediator2#key-4", "did:peer:mediator1#key-4")
+			msg = unwrap(t, msg, "did:peer:mediator1#key-4", recipientKey)
+			require.JSONEq(t, envelope, string(msg))
+		}
+
+		// the mediator DID documents are cached
+		require.Equal(t, map[string]int{"did:peer:mediator1": 1, "did:peer:mediator2": 1}, resolved)
+	})
+
+	t.Run("success - chained mediator keys", func(t *testing.T) {
+		o := newOutbound(t, nil, make(map[string]int))
+
+		msg, err := o.createForwardMessage([]byte(envelope), &service.Destination{
+			ServiceEndpoint:   "https://localhost:8090",
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:outer#key-1", "did:peer:inner#key-1"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		// the first routing key is the mediator the message is sent to
+		msg = unwrap(t, msg, "did:peer:outer#key-1", "did:peer:inner#key-1")
+		msg = unwrap(t, msg, "did:peer:inner#key-1", recipientKey)
+		require.JSONEq(t, envelope, string(msg))
+	})
+
+	t.Run("success - routing keys mixing mediator DIDs and keys", func(t *testing.T) {
+		docs := mediatorDocs(t, "mediator1")
+		o := newOutbound(t, docs, make(map[string]int))
+
+		msg, err := o.createForwardMessage([]byte(envelope), &service.Destination{
+			ServiceEndpoint:   "https://localhost:8090",
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:mediator0#key-1", "did:peer:mediator1"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		msg = unwrap(t, msg, "did:peer:mediator0#key-1", "did:peer:mediator1#key-4")
+		msg = unwrap(t, msg, "did:peer:mediator1#key-4", recipientKey)
+		require.JSONEq(t, envelope, string(msg))
+	})
+
+	t.Run("error - mediator DID resolution fails", func(t *testing.T) {
+		o := newOutbound(t, mediatorDocs(t, "mediator1"), make(map[string]int))
+
+		_, err := o.createForwardMessage([]byte(envelope), &service.Destination{
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:unknown"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve mediator [did:peer:unknown]")
+	})
+
+	t.Run("error - routing loop between mediators", func(t *testing.T) {
+		docs := mediatorDocs(t, "mediator1", "mediator2")
+		docs["did:peer:mediator2"].Service[0].RoutingKeys = []string{"did:peer:mediator1"}
+
+		o := newOutbound(t, docs, make(map[string]int))
+
+		_, err := o.createForwardMessage([]byte(envelope), &service.Destination{
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:mediator1"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.EqualError(t, err, fmt.Sprintf("routing through more than %d mediator tiers", maxMediatorTiers))
+	})
+
+	t.Run("error - pack forward message fails", func(t *testing.T) {
+		o := newOutbound(t, mediatorDocs(t, "mediator1"), make(map[string]int))
+		o.packager = &mockpackager.Packager{PackErr: errors.New("pack error")}
+
+		_, err := o.createForwardMessage([]byte(envelope), &service.Destination{
+			RecipientKeys:     []string{recipientKey},
+			RoutingKeys:       []string{"did:peer:mediator1"},
+			MediaTypeProfiles: []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.Error(t, err)
+		require.EqualError(t, err, "failed to pack forward msg: pack error")
+	})
+}
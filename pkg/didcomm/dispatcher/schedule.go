@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/tracing"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const scheduledTag = "outbound_scheduled"
+
+// ErrScheduledMessageNotFound is returned when cancelling a scheduled message which is unknown or already sent.
+var ErrScheduledMessageNotFound = errors.New("scheduled message not found")
+
+// scheduledMessage is a packed message kept in the outbound store until its delivery time.
+type scheduledMessage struct {
+	queuedMessage
+	SendAt time.Time `json:"send_at"`
+}
+
+// scheduler persists the outbound messages scheduled for a future delivery and sends them when they are due.
+type scheduler struct {
+	store   storage.Store
+	send    func(msg *queuedMessage)
+	mu      sync.Mutex
+	timers  map[string]*time.Timer
+	stopped bool
+	sending sync.WaitGroup
+}
+
+func newScheduler(provider storage.Provider, send func(msg *queuedMessage)) (*scheduler, error) {
+	store, err := provider.OpenStore(OutboundRetryStore)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(OutboundRetryStore, outboundStoreConfig)
+	if err != nil {
+		return nil, fmt.Errorf("set store config: %w", err)
+	}
+
+	return &scheduler{
+		store:  store,
+		send:   send,
+		timers: make(map[string]*time.Timer),
+	}, nil
+}
+
+// resume schedules the delivery of the messages scheduled before the agent was (re)started, the messages which
+// became due in the meantime being sent right away.
+func (s *scheduler) resume() error {
+	iter, err := s.store.Query(scheduledTag)
+	if err != nil {
+		return fmt.Errorf("query scheduled messages: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var messages []*scheduledMessage
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("next scheduled message: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, err := iter.Value()
+		if err != nil {
+			return fmt.Errorf("scheduled message value: %w", err)
+		}
+
+		msg := &scheduledMessage{}
+
+		if err = json.Unmarshal(value, msg); err != nil {
+			return fmt.Errorf("unmarshal scheduled message: %w", err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	for _, msg := range messages {
+		s.start(msg)
+	}
+
+	return nil
+}
+
+// add persists the packed message and schedules its delivery.
+func (s *scheduler) add(data []byte, des *service.Destination, sendAt time.Time) (string, error) {
+	msg := &scheduledMessage{
+		queuedMessage: queuedMessage{
+			ID:              uuid.New().String(),
+			Data:            data,
+			ServiceEndpoint: des.ServiceEndpoint,
+			RecipientKeys:   des.RecipientKeys,
+			RoutingKeys:     des.RoutingKeys,
+		},
+		SendAt: sendAt,
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal scheduled message: %w", err)
+	}
+
+	if err = s.store.Put(msg.ID, raw, storage.Tag{Name: scheduledTag}); err != nil {
+		return "", fmt.Errorf("save scheduled message: %w", err)
+	}
+
+	s.start(msg)
+
+	return msg.ID, nil
+}
+
+func (s *scheduler) start(msg *scheduledMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the message is sent when the agent restarts
+	if s.stopped {
+		return
+	}
+
+	s.timers[msg.ID] = time.AfterFunc(time.Until(msg.SendAt), func() {
+		s.fire(msg)
+	})
+}
+
+// fire sends the message once it is due, unless it was cancelled in the meantime.
+func (s *scheduler) fire(msg *scheduledMessage) {
+	s.mu.Lock()
+
+	if _, ok := s.timers[msg.ID]; !ok {
+		s.mu.Unlock()
+
+		return
+	}
+
+	delete(s.timers, msg.ID)
+	s.sending.Add(1)
+	s.mu.Unlock()
+
+	defer s.sending.Done()
+
+	if err := s.store.Delete(msg.ID); err != nil {
+		logger.Errorf("failed to delete scheduled outbound message [%s]: %s", msg.ID, err)
+	}
+
+	s.send(&msg.queuedMessage)
+}
+
+// cancel stops the delivery of the scheduled message and removes it from the store.
+func (s *scheduler) cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timer, ok := s.timers[id]
+	if !ok {
+		return ErrScheduledMessageNotFound
+	}
+
+	timer.Stop()
+	delete(s.timers, id)
+
+	if err := s.store.Delete(id); err != nil {
+		return fmt.Errorf("delete scheduled message: %w", err)
+	}
+
+	return nil
+}
+
+// stop stops the timers of the scheduled messages, which are kept in the store, and waits for the messages being
+// sent.
+func (s *scheduler) stop() {
+	s.mu.Lock()
+
+	s.stopped = true
+
+	for id, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+
+	s.mu.Unlock()
+
+	s.sending.Wait()
+}
+
+// Schedule packs the message with the sender key and recipient keys, and keeps it in the outbound store until it is
+// sent at the given time. The scheduled messages survive restarts of the agent, the ones which became due while the
+// agent was stopped being sent when it starts. The returned ID cancels the delivery with CancelScheduled.
+func (o *OutboundDispatcher) Schedule(msg interface{}, senderKey string, des *service.Destination,
+	sendAt time.Time) (id string, err error) {
+	ctx, span := o.tracer.Start(context.Background(), "didcomm.outbound.schedule",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("didcomm.service_endpoint", des.ServiceEndpoint)))
+	defer func() { tracing.End(span, err) }()
+
+	msg, err = o.applyMiddleware(msg, "", "", des)
+	if err != nil {
+		return "", fmt.Errorf("outboundDispatcher.Schedule: %w", err)
+	}
+
+	if msg == nil {
+		return "", nil
+	}
+
+	_, packedMsg, nextHop, err := o.prepare(ctx, msg, senderKey, des)
+	if err != nil {
+		return "", fmt.Errorf("outboundDispatcher.Schedule: %w", err)
+	}
+
+	id, err = o.scheduler.add(packedMsg, nextHop, sendAt)
+	if err != nil {
+		return "", fmt.Errorf("outboundDispatcher.Schedule: %w", err)
+	}
+
+	logger.Debugf("outbound message [%s] to %s scheduled at %s", id, nextHop.ServiceEndpoint, sendAt)
+
+	return id, nil
+}
+
+// CancelScheduled cancels the delivery of a message scheduled with Schedule, ErrScheduledMessageNotFound is returned
+// when the message is unknown or already sent.
+func (o *OutboundDispatcher) CancelScheduled(id string) error {
+	if err := o.scheduler.cancel(id); err != nil {
+		return fmt.Errorf("outboundDispatcher.CancelScheduled: %w", err)
+	}
+
+	return nil
+}
+
+// sendScheduled sends the scheduled message once it is due, the message being queued for retry when the delivery
+// fails and a retry policy is set.
+func (o *OutboundDispatcher) sendScheduled(msg *queuedMessage) {
+	des := msg.destination()
+
+	err := o.deliver(msg.Data, des)
+	if err == nil {
+		logger.Debugf("scheduled outbound message [%s] delivered", msg.ID)
+
+		return
+	}
+
+	if o.retry != nil {
+		if err = o.queue(msg.Data, des, err); err != nil {
+			logger.Errorf("scheduled outbound message [%s]: %s", msg.ID, err)
+		}
+
+		return
+	}
+
+	logger.Errorf("failed to deliver scheduled outbound message [%s] to %s: %s", msg.ID, msg.ServiceEndpoint, err)
+}
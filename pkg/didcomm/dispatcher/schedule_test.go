@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestOutboundDispatcher_Schedule(t *testing.T) {
+	newOutbound := func(t *testing.T, out transport.OutboundTransport, store storage.Provider,
+		policy *RetryPolicy) *OutboundDispatcher {
+		t.Helper()
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{out},
+			storageProvider:         store,
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+			retryPolicy:             policy,
+		})
+		require.NoError(t, err)
+
+		return o
+	}
+
+	scheduled := func(t *testing.T, store *mockstore.MockStoreProvider) int {
+		t.Helper()
+
+		iter, err := store.Store.Query(scheduledTag)
+		require.NoError(t, err)
+
+		count := 0
+
+		for {
+			ok, err := iter.Next()
+			require.NoError(t, err)
+
+			if !ok {
+				return count
+			}
+
+			count++
+		}
+	}
+
+	t.Run("test message sent at the scheduled time", func(t *testing.T) {
+		out := newFlakyOutboundTransport(0)
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(t, out, store, nil)
+
+		var _ OutboundScheduler = o
+
+		sendAt := time.Now().Add(50 * time.Millisecond)
+
+		id, err := o.Schedule(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}, sendAt)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+		require.Equal(t, 1, scheduled(t, store))
+
+		select {
+		case data := <-out.delivered:
+			require.False(t, time.Now().Before(sendAt))
+			require.Contains(t, string(data), "123")
+		case <-time.After(retryTimeout):
+			require.Fail(t, "message not delivered")
+		}
+
+		require.Eventually(t, func() bool {
+			return scheduled(t, store) == 0
+		}, retryTimeout, time.Millisecond)
+
+		err = o.CancelScheduled(id)
+		require.True(t, errors.Is(err, ErrScheduledMessageNotFound))
+	})
+
+	t.Run("test cancel scheduled message", func(t *testing.T) {
+		out := newFlakyOutboundTransport(0)
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(t, out, store, nil)
+
+		id, err := o.Schedule(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}, time.Now().Add(50*time.Millisecond))
+		require.NoError(t, err)
+
+		require.NoError(t, o.CancelScheduled(id))
+		require.Equal(t, 0, scheduled(t, store))
+
+		select {
+		case <-out.delivered:
+			require.Fail(t, "cancelled message delivered")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		err = o.CancelScheduled(id)
+		require.True(t, errors.Is(err, ErrScheduledMessageNotFound))
+	})
+
+	t.Run("test scheduled messages kept on flush", func(t *testing.T) {
+		out := newFlakyOutboundTransport(0)
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(t, out, store, nil)
+
+		_, err := o.Schedule(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}, time.Now().Add(50*time.Millisecond))
+		require.NoError(t, err)
+
+		o.Flush()
+
+		_, err = o.Schedule(map[string]string{"@id": "456"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}, time.Now())
+		require.NoError(t, err)
+
+		select {
+		case <-out.delivered:
+			require.Fail(t, "scheduled message delivered after the flush")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		require.Equal(t, 2, scheduled(t, store))
+	})
+
+	t.Run("test scheduled messages are resumed", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+
+		raw, err := json.Marshal(&scheduledMessage{
+			queuedMessage: queuedMessage{ID: "id", Data: []byte("data"), ServiceEndpoint: "url"},
+			SendAt:        time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		s, err := store.OpenStore(OutboundRetryStore)
+		require.NoError(t, err)
+		require.NoError(t, s.Put("id", raw, storage.Tag{Name: scheduledTag}))
+
+		out := newFlakyOutboundTransport(0)
+		newOutbound(t, out, store, nil)
+
+		select {
+		case data := <-out.delivered:
+			require.Equal(t, "data", string(data))
+		case <-time.After(retryTimeout):
+			require.Fail(t, "message not delivered")
+		}
+	})
+
+	t.Run("test failed scheduled message queued for retry", func(t *testing.T) {
+		out := newFlakyOutboundTransport(1)
+		o := newOutbound(t, out, mockstore.NewMockStoreProvider(),
+			&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+		_, err := o.Schedule(map[string]string{"@id": "123"}, mockdiddoc.MockDIDKey(t),
+			&service.Destination{ServiceEndpoint: "url"}, time.Now())
+		require.NoError(t, err)
+
+		select {
+		case data := <-out.delivered:
+			require.Contains(t, string(data), "123")
+		case <-time.After(retryTimeout):
+			require.Fail(t, "message not delivered")
+		}
+
+		require.Equal(t, 2, out.attempts())
+	})
+
+	t.Run("test schedule errors", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+		o := newOutbound(t, newFlakyOutboundTransport(0), store, nil)
+
+		o.outboundTransports = nil
+
+		_, err := o.Schedule("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"},
+			time.Now())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "outboundDispatcher.Schedule: no transport found for destination")
+
+		o = newOutbound(t, newFlakyOutboundTransport(0), store, nil)
+		store.Store.ErrPut = errors.New("put error")
+
+		_, err = o.Schedule("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"},
+			time.Now())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "save scheduled message: put error")
+	})
+
+	t.Run("test init errors", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+		store.FailNamespace = OutboundRetryStore
+
+		_, err := NewOutbound(&mockProvider{
+			storageProvider:      store,
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init outbound scheduler: open store")
+
+		store = mockstore.NewMockStoreProvider()
+		store.Store.ErrQuery = errors.New("query error")
+
+		_, err = NewOutbound(&mockProvider{
+			storageProvider:      store,
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"failed to resume scheduled outbound messages: query scheduled messages: query error")
+	})
+}
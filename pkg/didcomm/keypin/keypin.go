@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keypin pins the keys of the other party of a connection: the sender key of the first message received over
+// the connection is pinned (trust on first use), and the messages later sent with a different key, without a DID
+// rotation of the connection, raise a key change event and are rejected by the Block policy.
+package keypin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// StoreName is the name of the store of the pinned keys.
+const StoreName = "keypin"
+
+// Policy is the action taken when a message is sent with a key which isn't pinned.
+type Policy int
+
+const (
+	// Alert raises a key change event and handles the message.
+	Alert Policy = iota
+	// Block raises a key change event and rejects the message.
+	Block
+)
+
+// ErrKeyChanged is returned by the Block policy when a message is sent with a key which isn't pinned.
+var ErrKeyChanged = errors.New("sender key is not pinned for the connection")
+
+// ErrNilChannel is returned when registering a nil channel.
+var ErrNilChannel = errors.New("channel is nil")
+
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// KeyChangeEvent is raised when a message is sent over a connection with a key which isn't pinned.
+type KeyChangeEvent struct {
+	ConnectionID string
+	MyDID        string
+	TheirDID     string
+	// PinnedKeys are the pinned keys of the connection.
+	PinnedKeys []string
+	// Key is the sender key of the message.
+	Key string
+	// Blocked reports whether the message was rejected.
+	Blocked bool
+}
+
+// pin holds the pinned keys of a connection.
+type pin struct {
+	TheirDID string   `json:"theirDID"`
+	Keys     []string `json:"keys"`
+}
+
+// KeyPinner pins the keys of the other parties of the connections.
+type KeyPinner struct {
+	policy      Policy
+	store       storage.Store
+	connections *connection.Lookup
+	lock        sync.Mutex
+	eventsLock  sync.RWMutex
+	events      []chan<- KeyChangeEvent
+}
+
+// New returns a new key pinner applying the given policy.
+func New(p provider, policy Policy) (*KeyPinner, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key pin store: %w", err)
+	}
+
+	connections, err := connection.NewLookup(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection lookup: %w", err)
+	}
+
+	return &KeyPinner{policy: policy, store: store, connections: connections}, nil
+}
+
+// RegisterKeyChangeEvent registers a channel receiving the key change events. The events are sent synchronously,
+// blocking the message handling until they are received.
+func (k *KeyPinner) RegisterKeyChangeEvent(ch chan<- KeyChangeEvent) error {
+	if ch == nil {
+		return ErrNilChannel
+	}
+
+	k.eventsLock.Lock()
+	k.events = append(k.events, ch)
+	k.eventsLock.Unlock()
+
+	return nil
+}
+
+// UnregisterKeyChangeEvent unregisters a channel registered by RegisterKeyChangeEvent.
+func (k *KeyPinner) UnregisterKeyChangeEvent(ch chan<- KeyChangeEvent) error {
+	k.eventsLock.Lock()
+	for i := 0; i < len(k.events); i++ {
+		if k.events[i] == ch {
+			k.events = append(k.events[:i], k.events[i+1:]...)
+			i--
+		}
+	}
+	k.eventsLock.Unlock()
+
+	return nil
+}
+
+// HandleInboundMessage checks the sender key of a message received over the connection between myDID and theirDID.
+// The key is pinned if the connection has no pinned key yet, or if their DID of the connection was rotated since the
+// keys were pinned. The messages not sent over a connection are ignored.
+func (k *KeyPinner) HandleInboundMessage(senderKey []byte, myDID, theirDID string) error {
+	if len(senderKey) == 0 || myDID == "" || theirDID == "" {
+		return nil
+	}
+
+	connID, err := k.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get connection ID: %w", err)
+	}
+
+	key := keyID(senderKey)
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	p, err := k.getPin(connID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case p == nil || p.TheirDID != theirDID:
+		return k.savePin(connID, &pin{TheirDID: theirDID, Keys: []string{key}})
+	case contains(p.Keys, key):
+		return nil
+	}
+
+	event := KeyChangeEvent{
+		ConnectionID: connID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		PinnedKeys:   p.Keys,
+		Key:          key,
+		Blocked:      k.policy == Block,
+	}
+
+	k.notify(event)
+
+	if event.Blocked {
+		return fmt.Errorf("connection %s: %w", connID, ErrKeyChanged)
+	}
+
+	return nil
+}
+
+// PinnedKeys returns the pinned keys of the connection, nil if none are pinned.
+func (k *KeyPinner) PinnedKeys(connectionID string) ([]string, error) {
+	p, err := k.getPin(connectionID)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	return p.Keys, nil
+}
+
+// PinKey adds a key to the pinned keys of the connection, once the application verified the other party changed
+// its key.
+func (k *KeyPinner) PinKey(connectionID, key string) error {
+	record, err := k.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	p, err := k.getPin(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if p == nil || p.TheirDID != record.TheirDID {
+		p = &pin{TheirDID: record.TheirDID}
+	}
+
+	if contains(p.Keys, key) {
+		return nil
+	}
+
+	p.Keys = append(p.Keys, key)
+
+	return k.savePin(connectionID, p)
+}
+
+// Unpin removes the pinned keys of the connection: the sender key of the next message is pinned.
+func (k *KeyPinner) Unpin(connectionID string) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if err := k.store.Delete(connectionID); err != nil {
+		return fmt.Errorf("delete pinned keys: %w", err)
+	}
+
+	return nil
+}
+
+func (k *KeyPinner) getPin(connectionID string) (*pin, error) {
+	data, err := k.store.Get(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get pinned keys: %w", err)
+	}
+
+	p := &pin{}
+
+	if err = json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("unmarshal pinned keys: %w", err)
+	}
+
+	return p, nil
+}
+
+func (k *KeyPinner) savePin(connectionID string, p *pin) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal pinned keys: %w", err)
+	}
+
+	if err = k.store.Put(connectionID, data); err != nil {
+		return fmt.Errorf("save pinned keys: %w", err)
+	}
+
+	return nil
+}
+
+func (k *KeyPinner) notify(event KeyChangeEvent) {
+	k.eventsLock.RLock()
+	events := append(k.events[:0:0], k.events...)
+	k.eventsLock.RUnlock()
+
+	for _, ch := range events {
+		ch <- event
+	}
+}
+
+// keyID returns the base58 encoding of the sender key: the raw public key of the legacy packers, or the public key
+// material of the JSON keys of the DIDComm v2 packers.
+func keyID(senderKey []byte) string {
+	pubKey := &crypto.PublicKey{}
+
+	if err := json.Unmarshal(senderKey, pubKey); err == nil && len(pubKey.X) != 0 {
+		return base58.Encode(append(append([]byte{}, pubKey.X...), pubKey.Y...))
+	}
+
+	return base58.Encode(senderKey)
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keypin
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID    = "conn-1"
+	aliceDID  = "did:test:alice"
+	bobDID    = "did:test:bob"
+	bob2DID   = "did:test:bob2"
+	unknownID = "did:test:unknown"
+)
+
+var (
+	bobKey      = []byte("bob-key")
+	bobOtherKey = []byte("bob-other-key")
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		k, err := New(newProvider(), Block)
+		require.NoError(t, err)
+		require.NotNil(t, k)
+	})
+
+	t.Run("error if cannot open the key pin store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")},
+		}, Alert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open key pin store")
+	})
+
+	t.Run("error if cannot create the connection lookup", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")},
+		}, Alert)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection lookup")
+	})
+}
+
+func TestKeyPinner_HandleInboundMessage(t *testing.T) {
+	t.Run("pins the key of the first message", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(bobKey)}, keys)
+	})
+
+	t.Run("ignores the messages not sent over a connection", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.HandleInboundMessage(nil, aliceDID, bobDID))
+		require.NoError(t, k.HandleInboundMessage(bobKey, "", bobDID))
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, ""))
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, unknownID))
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("blocks a key change", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		events := make(chan KeyChangeEvent, 1)
+		require.NoError(t, k.RegisterKeyChangeEvent(events))
+
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+
+		err := k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID)
+		require.True(t, errors.Is(err, ErrKeyChanged))
+
+		require.Equal(t, KeyChangeEvent{
+			ConnectionID: connID,
+			MyDID:        aliceDID,
+			TheirDID:     bobDID,
+			PinnedKeys:   []string{base58.Encode(bobKey)},
+			Key:          base58.Encode(bobOtherKey),
+			Blocked:      true,
+		}, <-events)
+	})
+
+	t.Run("alerts on a key change", func(t *testing.T) {
+		k := newKeyPinner(t, Alert)
+
+		events := make(chan KeyChangeEvent, 1)
+		require.NoError(t, k.RegisterKeyChangeEvent(events))
+
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+		require.NoError(t, k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID))
+
+		event := <-events
+		require.False(t, event.Blocked)
+		require.Equal(t, base58.Encode(bobOtherKey), event.Key)
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(bobKey)}, keys)
+	})
+
+	t.Run("pins the key again after a DID rotation", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+
+		record, err := k.connections.GetConnectionRecord(connID)
+		require.NoError(t, err)
+
+		record.TheirDID = bob2DID
+
+		recorder, err := connection.NewRecorder(k.provider)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		require.NoError(t, k.HandleInboundMessage(bobOtherKey, aliceDID, bob2DID))
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(bobOtherKey)}, keys)
+	})
+
+	t.Run("pins the key material of JSON keys", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		key := func(kid string) []byte {
+			data, err := json.Marshal(&crypto.PublicKey{KID: kid, X: []byte("x"), Y: []byte("y"), Curve: "P-256"})
+			require.NoError(t, err)
+
+			return data
+		}
+
+		require.NoError(t, k.HandleInboundMessage(key(bobDID+"#key-1"), aliceDID, bobDID))
+		require.NoError(t, k.HandleInboundMessage(key(bobDID+"#key-2"), aliceDID, bobDID))
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode([]byte("xy"))}, keys)
+	})
+
+	t.Run("error if cannot get the pinned keys", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.store.Put(connID, []byte("invalid")))
+
+		err := k.HandleInboundMessage(bobKey, aliceDID, bobDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal pinned keys")
+	})
+}
+
+func TestKeyPinner_PinKey(t *testing.T) {
+	t.Run("accepts a new key", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+		require.NoError(t, k.PinKey(connID, base58.Encode(bobOtherKey)))
+		require.NoError(t, k.PinKey(connID, base58.Encode(bobOtherKey)))
+		require.NoError(t, k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID))
+
+		keys, err := k.PinnedKeys(connID)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(bobKey), base58.Encode(bobOtherKey)}, keys)
+	})
+
+	t.Run("pins a key before the first message", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		require.NoError(t, k.PinKey(connID, base58.Encode(bobKey)))
+
+		err := k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID)
+		require.True(t, errors.Is(err, ErrKeyChanged))
+	})
+
+	t.Run("error if the connection doesn't exist", func(t *testing.T) {
+		k := newKeyPinner(t, Block)
+
+		err := k.PinKey("unknown", base58.Encode(bobKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+}
+
+func TestKeyPinner_Unpin(t *testing.T) {
+	k := newKeyPinner(t, Block)
+
+	require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+	require.NoError(t, k.Unpin(connID))
+	require.NoError(t, k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID))
+
+	keys, err := k.PinnedKeys(connID)
+	require.NoError(t, err)
+	require.Equal(t, []string{base58.Encode(bobOtherKey)}, keys)
+}
+
+func TestKeyPinner_KeyChangeEvents(t *testing.T) {
+	k := newKeyPinner(t, Alert)
+
+	require.True(t, errors.Is(k.RegisterKeyChangeEvent(nil), ErrNilChannel))
+
+	events := make(chan KeyChangeEvent)
+	require.NoError(t, k.RegisterKeyChangeEvent(events))
+	require.NoError(t, k.UnregisterKeyChangeEvent(events))
+
+	require.NoError(t, k.HandleInboundMessage(bobKey, aliceDID, bobDID))
+	require.NoError(t, k.HandleInboundMessage(bobOtherKey, aliceDID, bobDID))
+}
+
+type testKeyPinner struct {
+	*KeyPinner
+	provider *mockprovider.Provider
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+func newKeyPinner(t *testing.T, policy Policy) *testKeyPinner {
+	t.Helper()
+
+	p := newProvider()
+
+	k, err := New(p, policy)
+	require.NoError(t, err)
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+		MyDID:        aliceDID,
+		TheirDID:     bobDID,
+	}))
+
+	return &testKeyPinner{KeyPinner: k, provider: p}
+}
@@ -66,3 +66,23 @@ func ExampleRegistrar_Services() {
 
 	// Output: available services 2
 }
+
+func ExampleRegistrar_Replace() {
+	registrar := NewRegistrar()
+
+	const serviceName = "sample-service"
+
+	err := registrar.Register(&generic.MockMessageSvc{NameVal: serviceName})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = registrar.Replace(&generic.MockMessageSvc{NameVal: serviceName}, WithConcurrencyLimit(10))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println("message service replaced")
+
+	// Output: message service replaced
+}
@@ -13,22 +13,80 @@
 // this message handler also provides register/unregister functionality which can be used to add/remove
 // message services from already running agent.
 //
+// Registered message services can be replaced atomically while the agent is running, and can be registered with
+// options limiting the purposes they accept (wildcards supported) and the number of messages they handle concurrently.
+//
 // (RFC Reference : https://github.com/hyperledger/aries-rfcs/blob/master/features/0351-purpose-decorator/README.md)
 //
 package msghandler
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 )
 
 const (
 	errAlreadyRegistered = "registration failed, message service with name `%s` already registered"
 	errNeverRegistered   = "failed to unregister, unable to find registered message service with name `%s`"
+	errNeverReplaced     = "failed to replace, unable to find registered message service with name `%s`"
+	errInvalidLimit      = "invalid concurrency limit %d for message service with name `%s`"
+
+	// wildcard is the purpose criteria suffix matching any purpose with the preceding prefix, `*` alone matches any
+	// purpose.
+	wildcard = "*"
 )
 
+// Option configures the registration of a message service.
+type Option func(opts *options)
+
+type options struct {
+	purposes         []string
+	concurrencyLimit int
+}
+
+// WithPurposes restricts the registered message service to the messages having one of the given purposes, in
+// addition to its own acceptance criteria. A purpose ending with `*` is a wildcard matching any purpose starting with
+// the preceding prefix, for example `payment.*` matches `payment.invoice`.
+func WithPurposes(purposes ...string) Option {
+	return func(opts *options) {
+		opts.purposes = purposes
+	}
+}
+
+// WithConcurrencyLimit limits the number of messages handled concurrently by the registered message service, the
+// messages exceeding the limit wait for a message being handled to complete.
+func WithConcurrencyLimit(limit int) Option {
+	return func(opts *options) {
+		opts.concurrencyLimit = limit
+	}
+}
+
+// MatchPurpose returns true if any of the message purposes matches any of the purpose criteria, criteria ending with
+// `*` are wildcards matching by prefix.
+func MatchPurpose(criteria, purposes []string) bool {
+	for _, criterion := range criteria {
+		for _, purpose := range purposes {
+			if matchPurpose(criterion, purpose) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchPurpose(criterion, purpose string) bool {
+	if strings.HasSuffix(criterion, wildcard) {
+		return strings.HasPrefix(purpose, strings.TrimSuffix(criterion, wildcard))
+	}
+
+	return criterion == purpose
+}
+
 // NewRegistrar returns new message registrar instance.
 func NewRegistrar() *Registrar {
 	return &Registrar{}
@@ -82,6 +140,42 @@ func (m *Registrar) Register(msgServices ...dispatcher.MessageService) error {
 	return nil
 }
 
+// RegisterWithOptions registers given message service to this handler with the given options,
+// returns error in case of duplicate registration.
+func (m *Registrar) RegisterWithOptions(msgService dispatcher.MessageService, opts ...Option) error {
+	svc, err := withOptions(msgService, opts...)
+	if err != nil {
+		return err
+	}
+
+	return m.Register(svc)
+}
+
+// Replace atomically replaces the registered message service having the same name as the given message service,
+// so that a running message service can be upgraded without missing any message. The options of the replaced
+// registration aren't kept, the given options apply to the new message service.
+// Messages being handled by the replaced message service complete with it.
+// Returns error if no message service with that name is registered.
+func (m *Registrar) Replace(msgService dispatcher.MessageService, opts ...Option) error {
+	svc, err := withOptions(msgService, opts...)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for i, existingSvc := range m.services {
+		if existingSvc.Name() == msgService.Name() {
+			m.services[i] = svc
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf(errNeverReplaced, msgService.Name())
+}
+
 // Unregister unregisters message service with given name from this message handler,
 // returns error if given message service doesn't exists.
 func (m *Registrar) Unregister(name string) error {
@@ -106,3 +200,55 @@ func (m *Registrar) Unregister(name string) error {
 
 	return nil
 }
+
+// withOptions wraps the message service to apply the options, the message service is returned as is without options.
+func withOptions(msgService dispatcher.MessageService, opts ...Option) (dispatcher.MessageService, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.concurrencyLimit < 0 {
+		return nil, fmt.Errorf(errInvalidLimit, o.concurrencyLimit, msgService.Name())
+	}
+
+	if len(o.purposes) == 0 && o.concurrencyLimit == 0 {
+		return msgService, nil
+	}
+
+	svc := &registeredService{MessageService: msgService, purposes: o.purposes}
+
+	if o.concurrencyLimit > 0 {
+		svc.slots = make(chan struct{}, o.concurrencyLimit)
+	}
+
+	return svc, nil
+}
+
+// registeredService is a message service registered with options.
+type registeredService struct {
+	dispatcher.MessageService
+	purposes []string
+	slots    chan struct{}
+}
+
+// Accept accepts the messages accepted by the message service and matching the registration purposes.
+func (r *registeredService) Accept(msgType string, purpose []string) bool {
+	if len(r.purposes) != 0 && !MatchPurpose(r.purposes, purpose) {
+		return false
+	}
+
+	return r.MessageService.Accept(msgType, purpose)
+}
+
+// HandleInbound handles the message once the number of messages being handled is below the concurrency limit.
+func (r *registeredService) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if r.slots != nil {
+		r.slots <- struct{}{}
+
+		defer func() { <-r.slots }()
+	}
+
+	return r.MessageService.HandleInbound(msg, ctx)
+}
@@ -11,10 +11,13 @@ package msghandler
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 )
@@ -152,3 +155,192 @@ func TestRegistrar_Unregister(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistrar_RegisterWithOptions(t *testing.T) {
+	t.Run("without options", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		svc := generic.NewCustomMockMessageSvc("test", "sample-name")
+
+		require.NoError(t, handler.RegisterWithOptions(svc))
+		require.Equal(t, []dispatcher.MessageService{svc}, handler.Services())
+	})
+
+	t.Run("duplicate registration", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.Register(generic.NewCustomMockMessageSvc("test", "sample-name")))
+
+		err := handler.RegisterWithOptions(generic.NewCustomMockMessageSvc("test", "sample-name"),
+			WithPurposes("prp"))
+		require.EqualError(t, err, fmt.Sprintf(errAlreadyRegistered, "sample-name"))
+	})
+
+	t.Run("with purposes", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.RegisterWithOptions(generic.NewCustomMockMessageSvc("test", "sample-name"),
+			WithPurposes("team:payment", "invoice.*")))
+
+		svc := handler.Services()[0]
+		require.Equal(t, "sample-name", svc.Name())
+		require.True(t, svc.Accept("test", []string{"team:payment"}))
+		require.True(t, svc.Accept("test", []string{"other", "invoice.paid"}))
+		require.False(t, svc.Accept("test", []string{"invoice"}))
+		require.False(t, svc.Accept("test", nil))
+		require.False(t, svc.Accept("other", []string{"team:payment"}))
+	})
+
+	t.Run("with concurrency limit", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		const limit = 2
+
+		var (
+			running, maxRunning int
+			mu                  sync.Mutex
+			release             = make(chan struct{})
+		)
+
+		svc := &generic.MockMessageSvc{
+			NameVal: "sample-name",
+			HandleFunc: func(*service.DIDCommMsg) (string, error) {
+				mu.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+
+				<-release
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				return "", nil
+			},
+		}
+
+		require.NoError(t, handler.RegisterWithOptions(svc, WithConcurrencyLimit(limit)))
+
+		registered := handler.Services()[0]
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := registered.HandleInbound(service.NewDIDCommMsgMap(struct{}{}), service.EmptyDIDCommContext())
+				require.NoError(t, err)
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return running == limit
+		}, time.Second, 10*time.Millisecond)
+
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, limit, maxRunning)
+	})
+
+	t.Run("invalid concurrency limit", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		err := handler.RegisterWithOptions(generic.NewCustomMockMessageSvc("test", "sample-name"),
+			WithConcurrencyLimit(-1))
+		require.EqualError(t, err, fmt.Sprintf(errInvalidLimit, -1, "sample-name"))
+		require.Empty(t, handler.Services())
+	})
+}
+
+func TestRegistrar_Replace(t *testing.T) {
+	t.Run("replaces registered service", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.Register(
+			generic.NewCustomMockMessageSvc("test1", "sample-name-01"),
+			generic.NewCustomMockMessageSvc("test1", "sample-name-02"),
+			generic.NewCustomMockMessageSvc("test1", "sample-name-03"),
+		))
+
+		upgraded := generic.NewCustomMockMessageSvc("test2", "sample-name-02")
+
+		require.NoError(t, handler.Replace(upgraded))
+
+		svcs := handler.Services()
+		require.Len(t, svcs, 3)
+		require.Equal(t, "sample-name-01", svcs[0].Name())
+		require.Equal(t, upgraded, svcs[1])
+		require.Equal(t, "sample-name-03", svcs[2].Name())
+		require.True(t, svcs[1].Accept("test2", nil))
+		require.False(t, svcs[1].Accept("test1", nil))
+	})
+
+	t.Run("replaces registered service with options", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.RegisterWithOptions(generic.NewCustomMockMessageSvc("test", "sample-name"),
+			WithPurposes("prp-1")))
+
+		require.NoError(t, handler.Replace(generic.NewCustomMockMessageSvc("test", "sample-name"),
+			WithPurposes("prp-2")))
+
+		svcs := handler.Services()
+		require.Len(t, svcs, 1)
+		require.False(t, svcs[0].Accept("test", []string{"prp-1"}))
+		require.True(t, svcs[0].Accept("test", []string{"prp-2"}))
+	})
+
+	t.Run("service never registered", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.Register(generic.NewCustomMockMessageSvc("test", "sample-name-01")))
+
+		err := handler.Replace(generic.NewCustomMockMessageSvc("test", "sample-name-02"))
+		require.EqualError(t, err, fmt.Sprintf(errNeverReplaced, "sample-name-02"))
+		require.Len(t, handler.Services(), 1)
+	})
+
+	t.Run("invalid concurrency limit", func(t *testing.T) {
+		handler := NewRegistrar()
+
+		require.NoError(t, handler.Register(generic.NewCustomMockMessageSvc("test", "sample-name")))
+
+		err := handler.Replace(generic.NewCustomMockMessageSvc("test", "sample-name"), WithConcurrencyLimit(-1))
+		require.EqualError(t, err, fmt.Sprintf(errInvalidLimit, -1, "sample-name"))
+	})
+}
+
+func TestMatchPurpose(t *testing.T) {
+	tests := []struct {
+		testName string
+		criteria []string
+		purposes []string
+		expected bool
+	}{
+		{testName: "exact match", criteria: []string{"prp-1", "prp-2"}, purposes: []string{"prp-2"}, expected: true},
+		{testName: "no match", criteria: []string{"prp-1"}, purposes: []string{"prp-2"}},
+		{testName: "wildcard matches any", criteria: []string{"*"}, purposes: []string{"prp"}, expected: true},
+		{testName: "wildcard matches prefix", criteria: []string{"team.*"}, purposes: []string{"team.a"}, expected: true},
+		{testName: "wildcard doesn't match other prefix", criteria: []string{"team.*"}, purposes: []string{"teams"}},
+		{testName: "no purposes", criteria: []string{"*"}},
+		{testName: "no criteria", purposes: []string{"prp"}},
+	}
+
+	for _, test := range tests {
+		tc := test
+
+		t.Run(tc.testName, func(t *testing.T) {
+			require.Equal(t, tc.expected, MatchPurpose(tc.criteria, tc.purposes))
+		})
+	}
+}
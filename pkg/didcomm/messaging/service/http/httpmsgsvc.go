@@ -27,6 +27,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 )
 
@@ -123,15 +124,7 @@ func (m *OverDIDComm) Accept(msgType string, purpose []string) bool {
 	}
 
 	// match purpose if provided
-	for _, msgPurpose := range purpose {
-		for _, svcPurpose := range m.purpose {
-			if msgPurpose == svcPurpose {
-				return true
-			}
-		}
-	}
-
-	return false
+	return msghandler.MatchPurpose(m.purpose, purpose)
 }
 
 // HandleInbound for HTTP over DIDComm message service.
@@ -19,8 +19,20 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-// MessengerStore is messenger store name.
-const MessengerStore = "messenger_store"
+const (
+	// MessengerStore is messenger store name.
+	MessengerStore = "messenger_store"
+	// Name of the messenger, used as ProtocolName of the messenger events.
+	Name = "messenger"
+	// StateDeliveryFailed is the StateID of the event published when an outbound message is dropped after all its
+	// delivery retries failed. The event Properties hold the *dispatcher.DeliveryFailure.
+	StateDeliveryFailed = "delivery-failed"
+)
+
+// deliveryFailureNotifier is implemented by outbound dispatchers retrying failed deliveries.
+type deliveryFailureNotifier interface {
+	OnDeliveryFailure(handler dispatcher.DeliveryFailureHandler)
+}
 
 // record is an internal structure and keeps payload about inbound message.
 type record struct {
@@ -37,7 +49,9 @@ type Provider interface {
 }
 
 // Messenger describes the messenger structure.
+// Delivery failures of outbound messages are published to the channels registered with RegisterMsgEvent.
 type Messenger struct {
+	events     service.Message
 	store      storage.Store
 	dispatcher dispatcher.Outbound
 }
@@ -51,10 +65,47 @@ func NewMessenger(ctx Provider) (*Messenger, error) {
 		return nil, fmt.Errorf("open store: %w", err)
 	}
 
-	return &Messenger{
+	m := &Messenger{
 		store:      store,
 		dispatcher: ctx.OutboundDispatcher(),
-	}, nil
+	}
+
+	if notifier, ok := m.dispatcher.(deliveryFailureNotifier); ok {
+		notifier.OnDeliveryFailure(m.deliveryFailed)
+	}
+
+	return m, nil
+}
+
+// RegisterMsgEvent registers a channel receiving the delivery failures of outbound messages.
+// The events are dropped for channels which are not ready to receive them, a buffered channel should be used.
+func (m *Messenger) RegisterMsgEvent(ch chan<- service.StateMsg) error {
+	return m.events.RegisterMsgEvent(ch)
+}
+
+// UnregisterMsgEvent unregisters a channel registered with RegisterMsgEvent.
+func (m *Messenger) UnregisterMsgEvent(ch chan<- service.StateMsg) error {
+	return m.events.UnregisterMsgEvent(ch)
+}
+
+// deliveryFailed publishes the delivery failure to the message event channels, without blocking the retry queue
+// on the channels which are not ready.
+func (m *Messenger) deliveryFailed(failure *dispatcher.DeliveryFailure) {
+	msg := service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PostState,
+		StateID:      StateDeliveryFailed,
+		Properties:   failure,
+	}
+
+	for _, handler := range m.events.MsgEvents() {
+		select {
+		case handler <- msg:
+		default:
+			logger.Warnf("delivery failure of outbound message [%s] not published: message event channel is full",
+				failure.ID)
+		}
+	}
 }
 
 // HandleInbound handles all inbound messages.
@@ -141,6 +192,8 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 	out.UnsetThread()
 	// sets thread
 	out.SetThread(thID, in.ParentThreadID(), opts...)
+	// the reply is part of the trace of the inbound message
+	service.SetSpanContext(out, service.GetSpanContext(in))
 
 	return m.dispatcher.SendToDID(out, myDID, theirDID)
 }
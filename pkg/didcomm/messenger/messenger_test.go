@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
 	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
@@ -67,6 +68,65 @@ func TestNewMessenger(t *testing.T) {
 	})
 }
 
+func TestMessenger_DeliveryFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageProvider := storageMocks.NewMockProvider(ctrl)
+	storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+	outbound := &notifyingOutbound{}
+
+	provider := messengerMocks.NewMockProvider(ctrl)
+	provider.EXPECT().StorageProvider().Return(storageProvider)
+	provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+	msgr, err := NewMessenger(provider)
+	require.NoError(t, err)
+	require.NotNil(t, outbound.handler)
+
+	events := make(chan service.StateMsg, 1)
+	require.NoError(t, msgr.RegisterMsgEvent(events))
+
+	failure := &dispatcher.DeliveryFailure{
+		ID:              ID,
+		ServiceEndpoint: "http://example.com",
+		Attempts:        3,
+		Err:             errors.New(errMsg),
+	}
+	outbound.handler(failure)
+
+	event := <-events
+	require.Equal(t, Name, event.ProtocolName)
+	require.Equal(t, service.PostState, event.Type)
+	require.Equal(t, StateDeliveryFailed, event.StateID)
+	require.Equal(t, failure, event.Properties)
+	require.Equal(t, errMsg, event.Properties.All()["error"])
+
+	// the failure is not published to the channels which are not ready
+	unready := make(chan service.StateMsg)
+	require.NoError(t, msgr.RegisterMsgEvent(unready))
+
+	outbound.handler(failure)
+	require.Equal(t, failure, (<-events).Properties)
+
+	require.NoError(t, msgr.UnregisterMsgEvent(unready))
+	require.NoError(t, msgr.UnregisterMsgEvent(events))
+
+	outbound.handler(failure)
+	require.Empty(t, events)
+}
+
+// notifyingOutbound is an outbound dispatcher reporting delivery failures.
+type notifyingOutbound struct {
+	dispatcher.Outbound
+	handler dispatcher.DeliveryFailureHandler
+}
+
+func (o *notifyingOutbound) OnDeliveryFailure(handler dispatcher.DeliveryFailureHandler) {
+	o.handler = handler
+}
+
 func TestMessenger_HandleInbound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -8,13 +8,18 @@ package packager
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
@@ -24,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmsdidkey"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -40,6 +46,8 @@ type Provider interface {
 	Packers() []packer.Packer
 	PrimaryPacker() packer.Packer
 	VDRegistry() vdr.Registry
+	TracerProvider() trace.TracerProvider
+	MetricsProvider() metrics.Provider
 }
 
 // Creator method to create new packager service.
@@ -50,6 +58,8 @@ type Packager struct {
 	primaryPacker packer.Packer
 	packers       map[string]packer.Packer
 	vdrRegistry   vdr.Registry
+	tracer        trace.Tracer
+	metrics       metrics.Provider
 }
 
 // PackerCreator holds a creator function for a Packer and the name of the Packer's encoding method.
@@ -64,6 +74,8 @@ func New(ctx Provider) (*Packager, error) {
 		primaryPacker: nil,
 		packers:       map[string]packer.Packer{},
 		vdrRegistry:   ctx.VDRegistry(),
+		tracer:        tracing.Tracer(ctx.TracerProvider()),
+		metrics:       ctx.MetricsProvider(),
 	}
 
 	for _, packerType := range ctx.Packers() {
@@ -96,16 +108,23 @@ func (bp *Packager) addPacker(pack packer.Packer) {
 }
 
 // PackMessage Pack a message for one or more recipients.
-func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, error) {
+func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) (_ []byte, err error) {
 	if messageEnvelope == nil {
 		return nil, errors.New("packMessage: envelope argument is nil")
 	}
 
+	_, span := bp.tracer.Start(tracing.Extract(context.Background(), messageEnvelope.Message), "didcomm.packager.pack")
+	defer func() { tracing.End(span, err) }()
+
+	defer func() { bp.metrics.EnvelopePacked(messageEnvelope.MediaTypeProfile, err) }()
+
 	cty, p, err := bp.getCTYAndPacker(messageEnvelope)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: %w", err)
 	}
 
+	span.SetAttributes(attribute.String("didcomm.content_type", cty))
+
 	senderKey, recipients, err := bp.prepareSenderAndRecipientKeys(cty, messageEnvelope)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: %w", err)
@@ -359,12 +378,19 @@ func getEncodingType(encMessage []byte) (string, []byte, error) {
 }
 
 // UnpackMessage Unpack a message.
-func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+func (bp *Packager) UnpackMessage(encMessage []byte) (_ *transport.Envelope, err error) {
+	_, span := bp.tracer.Start(context.Background(), "didcomm.packager.unpack")
+	defer func() { tracing.End(span, err) }()
+
 	encType, b64DecodedMessage, err := getEncodingType(encMessage)
 	if err != nil {
 		return nil, fmt.Errorf("getEncodingType: %w", err)
 	}
 
+	span.SetAttributes(attribute.String("didcomm.encoding_type", encType))
+
+	defer func() { bp.metrics.EnvelopeUnpacked(encType, err) }()
+
 	p, ok := bp.packers[encType]
 	if !ok {
 		return nil, fmt.Errorf("message Type not recognized")
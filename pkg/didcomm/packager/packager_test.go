@@ -13,7 +13,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
@@ -30,6 +32,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -536,6 +539,8 @@ func TestPackagerLegacyInterop(t *testing.T) {
 
 	resolveLegacyDIDFunc, legacyFromDID, legacyToDID := newLegacyDIDsAndDIDDocResolverFunc(t, customKMS)
 
+	metricsProvider := &mockmetrics.Provider{}
+
 	mockedProviders := &mockProvider{
 		kms:           customKMS,
 		primaryPacker: nil,
@@ -545,6 +550,7 @@ func TestPackagerLegacyInterop(t *testing.T) {
 		vdr: &mockvdr.MockVDRegistry{
 			ResolveFunc: resolveLegacyDIDFunc,
 		},
+		metrics: metricsProvider,
 	}
 
 	legacyPacker := legacy.New(mockedProviders)
@@ -597,6 +603,24 @@ func TestPackagerLegacyInterop(t *testing.T) {
 			require.Equal(t, unpackedMsg.Message, []byte("msg"))
 		})
 	}
+
+	t.Run("envelopes are counted", func(t *testing.T) {
+		packed := metricsProvider.Observations(mockmetrics.EnvelopePacked)
+		require.Len(t, packed, len(tests))
+		require.Equal(t, []string{transport.MediaTypeRFC0019EncryptedEnvelope}, packed[0].Labels)
+		require.NoError(t, packed[0].Err)
+
+		unpacked := metricsProvider.Observations(mockmetrics.EnvelopeUnpacked)
+		require.Len(t, unpacked, len(tests))
+		require.NoError(t, unpacked[0].Err)
+
+		_, err = packager.PackMessage(&transport.Envelope{MediaTypeProfile: "unknown", Message: []byte("msg")})
+		require.Error(t, err)
+
+		packed = metricsProvider.Observations(mockmetrics.EnvelopePacked)
+		require.Len(t, packed, len(tests)+1)
+		require.Error(t, packed[len(tests)].Err)
+	})
 }
 
 func TestPackager_PackMessage_DIDKey_Failures(t *testing.T) {
@@ -861,18 +885,20 @@ func newLegacyDIDsAndDIDDocResolverFunc(t *testing.T, customKMS kms.KeyManager)
 }
 
 func newMockKMSProvider(storagePvdr *mockstorage.MockStoreProvider) *mockProvider {
-	return &mockProvider{storagePvdr, nil, &noop.NoLock{}, nil, nil, nil, nil}
+	return &mockProvider{storage: storagePvdr, secretLock: &noop.NoLock{}}
 }
 
 // mockProvider mocks provider for KMS.
 type mockProvider struct {
-	storage       *mockstorage.MockStoreProvider
-	kms           kms.KeyManager
-	secretLock    secretlock.Service
-	crypto        cryptoapi.Crypto
-	packers       []packer.Packer
-	primaryPacker packer.Packer
-	vdr           vdrapi.Registry
+	storage        *mockstorage.MockStoreProvider
+	kms            kms.KeyManager
+	secretLock     secretlock.Service
+	crypto         cryptoapi.Crypto
+	packers        []packer.Packer
+	primaryPacker  packer.Packer
+	vdr            vdrapi.Registry
+	tracerProvider trace.TracerProvider
+	metrics        metrics.Provider
 }
 
 func (m *mockProvider) Packers() []packer.Packer {
@@ -900,6 +926,18 @@ func (m *mockProvider) VDRegistry() vdrapi.Registry {
 	return m.vdr
 }
 
+func (m *mockProvider) TracerProvider() trace.TracerProvider {
+	return m.tracerProvider
+}
+
+func (m *mockProvider) MetricsProvider() metrics.Provider {
+	if m.metrics == nil {
+		return metrics.Noop{}
+	}
+
+	return m.metrics
+}
+
 func (m *mockProvider) Crypto() cryptoapi.Crypto {
 	return m.crypto
 }
@@ -179,10 +179,14 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 			return nil, fmt.Errorf("anoncrypt Unpack: failed to marshal public key: %w", err)
 		}
 
-		return &transport.Envelope{
+		env := &transport.Envelope{
 			Message: pt,
 			ToKey:   ecdhesPubKeyByes,
-		}, nil
+		}
+
+		env.ContentEncryption, env.KeyWrapping = packer.JWEAlgorithms(jwe, i)
+
+		return env, nil
 	}
 
 	return nil, fmt.Errorf("anoncrypt Unpack: no matching recipient in envelope")
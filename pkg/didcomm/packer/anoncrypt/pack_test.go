@@ -157,7 +157,12 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 			recKey, err := exportPubKeyBytes(keyHandles[0], recDIDKeys[0])
 			require.NoError(t, err)
 
-			require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+			require.EqualValues(t, &transport.Envelope{
+				Message:           origMsg,
+				ToKey:             recKey,
+				ContentEncryption: string(tc.encAlg),
+				KeyWrapping:       keyWrappingAlg(tc.keyType),
+			}, msg)
 
 			jweJSON, err := afgjose.Deserialize(string(ct))
 			require.NoError(t, err)
@@ -180,7 +185,12 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 			msg, err = anonPacker.Unpack(ct)
 			require.NoError(t, err)
 
-			require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+			require.EqualValues(t, &transport.Envelope{
+				Message:           origMsg,
+				ToKey:             recKey,
+				ContentEncryption: string(tc.encAlg),
+				KeyWrapping:       keyWrappingAlg(tc.keyType),
+			}, msg)
 
 			verifyJWETypes(t, tc.cty, jweJSON.ProtectedHeaders)
 		})
@@ -243,8 +253,10 @@ func TestAnoncryptPackerSuccessWithDifferentCurvesSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	require.EqualValues(t, &transport.Envelope{
-		Message: origMsg,
-		ToKey:   recKey,
+		Message:           origMsg,
+		ToKey:             recKey,
+		ContentEncryption: string(afgjose.A256GCM),
+		KeyWrapping:       tinkcrypto.ECDHESA256KWAlg,
 	}, msg)
 
 	// try with only 1 recipient
@@ -255,8 +267,10 @@ func TestAnoncryptPackerSuccessWithDifferentCurvesSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	require.EqualValues(t, &transport.Envelope{
-		Message: origMsg,
-		ToKey:   recKey,
+		Message:           origMsg,
+		ToKey:             recKey,
+		ContentEncryption: string(afgjose.A256GCM),
+		KeyWrapping:       tinkcrypto.ECDHESA256KWAlg,
 	}, msg)
 }
 
@@ -428,6 +442,15 @@ func exportPubKeyBytes(keyHandle *keyset.Handle, kid string) ([]byte, error) {
 }
 
 // createRecipients and return their public key and keyset.Handle.
+// keyWrappingAlg returns the key wrapping algorithm of the JWE envelopes packed for the recipient keys of the type.
+func keyWrappingAlg(keyType kms.KeyType) string {
+	if keyType == kms.X25519ECDHKWType {
+		return tinkcrypto.ECDHESXC20PKWAlg
+	}
+
+	return tinkcrypto.ECDHESA256KWAlg
+}
+
 func createRecipients(t *testing.T, k *localkms.LocalKMS,
 	recipientsCount int) ([]string, []string, [][]byte, []*keyset.Handle) {
 	return createRecipientsByKeyType(t, k, recipientsCount, kms.NISTP256ECDHKW)
@@ -222,6 +222,8 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 			return nil, fmt.Errorf("authcrypt Unpack: %w", err)
 		}
 
+		env.ContentEncryption, env.KeyWrapping = packer.JWEAlgorithms(jwe, i)
+
 		return env, nil
 	}
 
@@ -170,7 +170,13 @@ func TestAuthcryptPackerSuccess(t *testing.T) {
 			mSenderPubKey, err = json.Marshal(senderPubKey)
 			require.NoError(t, err)
 
-			require.EqualValues(t, &transport.Envelope{Message: origMsg, FromKey: mSenderPubKey, ToKey: recKey}, msg)
+			require.EqualValues(t, &transport.Envelope{
+				Message:           origMsg,
+				FromKey:           mSenderPubKey,
+				ToKey:             recKey,
+				ContentEncryption: string(tc.encAlg),
+				KeyWrapping:       keyWrappingAlg(t, ct),
+			}, msg)
 
 			jweJSON, err := afgjose.Deserialize(string(ct))
 			require.NoError(t, err)
@@ -193,7 +199,13 @@ func TestAuthcryptPackerSuccess(t *testing.T) {
 			msg, err = authPacker.Unpack(ct)
 			require.NoError(t, err)
 
-			require.EqualValues(t, &transport.Envelope{Message: origMsg, FromKey: mSenderPubKey, ToKey: recKey}, msg)
+			require.EqualValues(t, &transport.Envelope{
+				Message:           origMsg,
+				FromKey:           mSenderPubKey,
+				ToKey:             recKey,
+				ContentEncryption: string(tc.encAlg),
+				KeyWrapping:       keyWrappingAlg(t, ct),
+			}, msg)
 
 			verifyJWETypes(t, tc.cty, jweJSON.ProtectedHeaders)
 		})
@@ -265,9 +277,11 @@ func TestAuthcryptPackerUsingKeysWithDifferentCurvesSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	require.EqualValues(t, &transport.Envelope{
-		Message: origMsg,
-		FromKey: mSenderPubKey,
-		ToKey:   recKey,
+		Message:           origMsg,
+		FromKey:           mSenderPubKey,
+		ToKey:             recKey,
+		ContentEncryption: string(afgjose.A256CBCHS512),
+		KeyWrapping:       tinkcrypto.ECDH1PUA256KWAlg,
 	}, msg)
 
 	// try with only 1 recipient
@@ -288,9 +302,11 @@ func TestAuthcryptPackerUsingKeysWithDifferentCurvesSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	require.EqualValues(t, &transport.Envelope{
-		Message: origMsg,
-		FromKey: mSenderPubKey,
-		ToKey:   recKey,
+		Message:           origMsg,
+		FromKey:           mSenderPubKey,
+		ToKey:             recKey,
+		ContentEncryption: string(afgjose.A256CBCHS512),
+		KeyWrapping:       tinkcrypto.ECDH1PUA256KWAlg,
 	}, msg)
 
 	jweJSON, err := afgjose.Deserialize(string(ct))
@@ -499,6 +515,19 @@ func exportPubKeyBytes(keyHandle *keyset.Handle, kid string) ([]byte, error) {
 }
 
 // createRecipients and return their public key, jwk kid, didKey and keyset.Handle.
+// keyWrappingAlg returns the key wrapping algorithm of the JWE, it depends on the key type and the content encryption.
+func keyWrappingAlg(t *testing.T, ct []byte) string {
+	t.Helper()
+
+	jwe, err := afgjose.Deserialize(string(ct))
+	require.NoError(t, err)
+
+	alg, ok := jwe.ProtectedHeaders.Algorithm()
+	require.True(t, ok)
+
+	return alg
+}
+
 func createRecipients(t *testing.T, k *localkms.LocalKMS,
 	recipientsCount int) ([]string, []string, [][]byte, []*keyset.Handle) {
 	return createRecipientsByKeyType(t, k, recipientsCount, kms.NISTP256ECDHKW)
@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packer
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// JWEAlgorithms returns the content encryption ("enc" header) and key wrapping ("alg" header) algorithms of the JWE
+// for the recipient at index i. The key wrapping algorithm is read from the recipient header when it isn't protected.
+func JWEAlgorithms(jwe *jose.JSONWebEncryption, i int) (string, string) {
+	enc, _ := jwe.ProtectedHeaders.Encryption()
+
+	alg, ok := jwe.ProtectedHeaders.Algorithm()
+	if !ok && i < len(jwe.Recipients) && jwe.Recipients[i].Header != nil {
+		alg = jwe.Recipients[i].Header.Alg
+	}
+
+	return enc, alg
+}
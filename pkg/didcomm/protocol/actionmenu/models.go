@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Menu is the menu of actions the responder offers to the requester.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#menu
+type Menu struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ErrorMsg    string            `json:"errormsg,omitempty"`
+	Options     []MenuOption      `json:"options"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// MenuOption is an action of the menu.
+type MenuOption struct {
+	// Name identifies the option in the perform message.
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	// Form describes the parameters the requester submits with the option, if any.
+	Form *Form `json:"form,omitempty"`
+}
+
+// Form is the form of parameters of a menu option.
+type Form struct {
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Params      []FormParam `json:"params,omitempty"`
+	SubmitLabel string      `json:"submit-label,omitempty"`
+}
+
+// FormParam is a parameter of a form.
+type FormParam struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// MenuRequest is sent by the requester to ask for the current menu of the responder.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#menu-request
+type MenuRequest struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// Perform is sent by the requester to perform a menu option.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0509-action-menu#perform
+type Perform struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// ActionMenu defines the protocol name.
+	ActionMenu = "action-menu"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/action-menu/1.0/"
+	// MenuMsgType defines the protocol menu message type.
+	MenuMsgType = Spec + "menu"
+	// MenuRequestMsgType defines the protocol menu-request message type.
+	MenuRequestMsgType = Spec + "menu-request"
+	// PerformMsgType defines the protocol perform message type.
+	PerformMsgType = Spec + "perform"
+
+	// Namespace is namespace of action menu store name.
+	Namespace = "actionmenu"
+)
+
+// State IDs of the message events triggered for the incoming messages.
+const (
+	// StateMenuReceived is the state of the requester receiving a menu, saved as the active menu of the connection.
+	StateMenuReceived = "menu-received"
+	// StateMenuRequested is the state of the responder asked for its menu, to be sent with SendMenu.
+	StateMenuRequested = "menu-requested"
+	// StatePerformRequested is the state of the responder asked to perform a menu option.
+	StatePerformRequested = "perform-requested"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrMenuNotFound is returned when no menu was received on the connection.
+	ErrMenuNotFound = errors.New("menu not found")
+
+	logger = log.New("aries-framework/actionmenu")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
+}
+
+// Service for the action menu protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	menuStore        storage.Store
+}
+
+// New returns the action menu service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open action menu store: %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		outbound:         prov.OutboundDispatcher(),
+		menuStore:        store,
+		connectionLookup: connectionLookup,
+	}, nil
+}
+
+// HandleInbound handles inbound action menu messages, saving the menus received and triggering message events.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	connectionID, err := s.connectionLookup.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("action menu - get connection: %w", err)
+	}
+
+	var stateID string
+
+	switch msg.Type() {
+	case MenuMsgType:
+		stateID = StateMenuReceived
+		err = s.saveMenu(connectionID, msg)
+	case MenuRequestMsgType:
+		stateID = StateMenuRequested
+	case PerformMsgType:
+		stateID = StatePerformRequested
+		err = msg.Decode(&Perform{})
+	default:
+		return "", fmt.Errorf("action menu - unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("action menu - handle %s: %w", msg.Type(), err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("action menu - thread ID: %w", err)
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: ActionMenu,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Msg:          msg,
+		Properties:   &eventProps{connectionID: connectionID, threadID: thID},
+	})
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the action menu message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("action menu - send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case MenuMsgType, MenuRequestMsgType, PerformMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return ActionMenu
+}
+
+// PIURIs of the action menu protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// SendMenu sends the menu to the connection, returning the ID of the menu message.
+func (s *Service) SendMenu(connectionID string, menu *Menu) (string, error) {
+	menu.Type = MenuMsgType
+	if menu.ID == "" {
+		menu.ID = uuid.New().String()
+	}
+
+	return s.send(connectionID, menu)
+}
+
+// RequestMenu asks the connection for its current menu, returning the ID of the menu-request message.
+func (s *Service) RequestMenu(connectionID string) (string, error) {
+	return s.send(connectionID, &MenuRequest{Type: MenuRequestMsgType, ID: uuid.New().String()})
+}
+
+// Perform performs the option of the menu received from the connection, returning the ID of the perform message.
+func (s *Service) Perform(connectionID string, perform *Perform) (string, error) {
+	perform.Type = PerformMsgType
+	if perform.ID == "" {
+		perform.ID = uuid.New().String()
+	}
+
+	if perform.Thread == nil {
+		menu, err := s.ActiveMenu(connectionID)
+		if err != nil && !errors.Is(err, ErrMenuNotFound) {
+			return "", err
+		}
+
+		// threads the perform message to the menu it was chosen from.
+		if menu != nil {
+			perform.Thread = &decorator.Thread{ID: menu.ID}
+		}
+	}
+
+	return s.send(connectionID, perform)
+}
+
+// ActiveMenu returns the last menu received from the connection.
+func (s *Service) ActiveMenu(connectionID string) (*Menu, error) {
+	menuBytes, err := s.menuStore.Get(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrMenuNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get active menu: %w", err)
+	}
+
+	menu := &Menu{}
+
+	err = json.Unmarshal(menuBytes, menu)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal active menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// CloseMenu discards the menu received from the connection.
+func (s *Service) CloseMenu(connectionID string) error {
+	err := s.menuStore.Delete(connectionID)
+	if err != nil {
+		return fmt.Errorf("delete active menu: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) saveMenu(connectionID string, msg service.DIDCommMsg) error {
+	menu := &Menu{}
+
+	err := msg.Decode(menu)
+	if err != nil {
+		return err
+	}
+
+	menuBytes, err := json.Marshal(menu)
+	if err != nil {
+		return err
+	}
+
+	return s.menuStore.Put(connectionID, menuBytes)
+}
+
+func (s *Service) send(connectionID string, msg interface{}) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(msg), conn.MyDID, conn.TheirDID)
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) triggerEvent(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+
+	logger.Debugf("action menu - %s on connection %s", msg.StateID, msg.Properties.All()[connectionIDPropKey])
+}
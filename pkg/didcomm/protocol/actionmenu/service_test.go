@@ -0,0 +1,246 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	myDID        = "did:example:my"
+	theirDID     = "did:example:their"
+	connectionID = "conn-1"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.Equal(t, ActionMenu, svc.Name())
+		require.True(t, svc.Accept(MenuMsgType))
+		require.True(t, svc.Accept(MenuRequestMsgType))
+		require.True(t, svc.Accept(PerformMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open action menu store")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("menu", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(states))
+
+		_, err := svc.ActiveMenu(connectionID)
+		require.True(t, errors.Is(err, ErrMenuNotFound))
+
+		menu := sampleMenu()
+		menu.Type = MenuMsgType
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(menu), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		state := <-states
+		require.Equal(t, ActionMenu, state.ProtocolName)
+		require.Equal(t, StateMenuReceived, state.StateID)
+		require.Equal(t, map[string]interface{}{
+			connectionIDPropKey: connectionID,
+			threadIDPropKey:     menu.ID,
+		}, state.Properties.All())
+
+		active, err := svc.ActiveMenu(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, menu, active)
+
+		require.NoError(t, svc.CloseMenu(connectionID))
+
+		_, err = svc.ActiveMenu(connectionID)
+		require.True(t, errors.Is(err, ErrMenuNotFound))
+	})
+
+	t.Run("menu request and perform", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(states))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType, ID: "req-1"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		state := <-states
+		require.Equal(t, StateMenuRequested, state.StateID)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{
+			Type:   PerformMsgType,
+			ID:     "perform-1",
+			Name:   "obtain-email-cred",
+			Params: map[string]string{"email": "alice@example.com"},
+			Thread: &decorator.Thread{ID: "menu-1"},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		state = <-states
+		require.Equal(t, StatePerformRequested, state.StateID)
+		require.Equal(t, "menu-1", state.Properties.All()[threadIDPropKey])
+
+		perform := &Perform{}
+		require.NoError(t, state.Msg.Decode(perform))
+		require.Equal(t, "alice@example.com", perform.Params["email"])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType, ID: "req-1"}),
+			service.NewDIDCommContext(myDID, "did:example:unknown", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu - get connection")
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: "unknown", ID: "req-1"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "action menu - unsupported message type unknown")
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@type": MenuMsgType, "@id": "menu-1", "options": "invalid"},
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action menu - handle "+MenuMsgType)
+	})
+}
+
+func TestService_Send(t *testing.T) {
+	t.Run("send menu", func(t *testing.T) {
+		var sent service.DIDCommMsgMap
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, theirDID, their)
+
+				sent = msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		})
+
+		id, err := svc.SendMenu(connectionID, &Menu{Title: "Welcome", Options: sampleMenu().Options})
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+		require.Equal(t, MenuMsgType, sent.Type())
+		require.Equal(t, id, sent.ID())
+
+		id, err = svc.RequestMenu(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, MenuRequestMsgType, sent.Type())
+		require.Equal(t, id, sent.ID())
+	})
+
+	t.Run("perform threaded to active menu", func(t *testing.T) {
+		var sent service.DIDCommMsgMap
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent = msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		})
+
+		_, err := svc.Perform(connectionID, &Perform{Name: "obtain-email-cred"})
+		require.NoError(t, err)
+		require.Equal(t, PerformMsgType, sent.Type())
+		require.Empty(t, sent.ParentThreadID())
+
+		menu := sampleMenu()
+		menu.Type = MenuMsgType
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(menu), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		id, err := svc.Perform(connectionID, &Perform{Name: "obtain-email-cred"})
+		require.NoError(t, err)
+		require.Equal(t, id, sent.ID())
+
+		thID, err := sent.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, menu.ID, thID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+
+		_, err := svc.SendMenu("unknown", sampleMenu())
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.RequestMenu(connectionID)
+		require.EqualError(t, err, "action menu - send "+MenuRequestMsgType+": send error")
+	})
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func sampleMenu() *Menu {
+	return &Menu{
+		ID:          "menu-1",
+		Title:       "Welcome to IIWBook",
+		Description: "IIWBook facilitates connections between attendees.",
+		Options: []MenuOption{
+			{
+				Name:  "obtain-email-cred",
+				Title: "Verify e-mail address",
+				Form: &Form{
+					Params:      []FormParam{{Name: "email", Title: "E-mail", Required: true, Type: "text"}},
+					SubmitLabel: "Verify",
+				},
+			},
+		},
+	}
+}
@@ -30,6 +30,12 @@ const (
 
 	// TransportReturnRouteThread return route option thread.
 	TransportReturnRouteThread = "thread"
+
+	// TraceContextKey is the message attribute holding the TraceContext decorator.
+	TraceContextKey = "~trace_context"
+
+	traceParentHeader = "traceparent"
+	traceStateHeader  = "tracestate"
 )
 
 // Thread thread data.
@@ -54,6 +60,42 @@ type Transport struct {
 // ReturnRoute works with Transport decorator. Acceptable values - "none", "all" or "thread".
 type ReturnRoute struct {
 	Value string `json:"~return_route,omitempty"`
+	// Thread is the thread the return route is scoped to with the "thread" option.
+	Thread string `json:"~return_route_thread,omitempty"`
+}
+
+// TraceContext decorator carries the W3C trace context (https://www.w3.org/TR/trace-context) of the sender,
+// so that the distributed trace can be continued by the receiving agent.
+type TraceContext struct {
+	TraceParent string `json:"traceparent,omitempty"`
+	TraceState  string `json:"tracestate,omitempty"`
+}
+
+// Get returns the value of the given trace context header.
+func (t *TraceContext) Get(key string) string {
+	switch key {
+	case traceParentHeader:
+		return t.TraceParent
+	case traceStateHeader:
+		return t.TraceState
+	default:
+		return ""
+	}
+}
+
+// Set sets the value of the given trace context header. Unknown headers are ignored.
+func (t *TraceContext) Set(key, value string) {
+	switch key {
+	case traceParentHeader:
+		t.TraceParent = value
+	case traceStateHeader:
+		t.TraceState = value
+	}
+}
+
+// Keys lists the trace context headers.
+func (t *TraceContext) Keys() []string {
+	return []string{traceParentHeader, traceStateHeader}
 }
 
 // Attachment is intended to provide the possibility to include files, links or even JSON payload to the message.
@@ -143,18 +143,19 @@ func buildJWKFromBytes(pubKeyBytes []byte) (*jwk.JWK, error) {
 
 // nolint:gochecknoglobals
 var vmType = map[kms.KeyType]string{
-	kms.ED25519Type:            ed25519VerificationKey2018,
-	kms.BLS12381G2Type:         bls12381G2Key2020,
-	kms.ECDSAP256TypeDER:       jsonWebKey2020,
-	kms.ECDSAP256TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP384TypeDER:       jsonWebKey2020,
-	kms.ECDSAP384TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP521TypeDER:       jsonWebKey2020,
-	kms.ECDSAP521TypeIEEEP1363: jsonWebKey2020,
-	kms.X25519ECDHKWType:       x25519KeyAgreementKey2019,
-	kms.NISTP256ECDHKWType:     jsonWebKey2020,
-	kms.NISTP384ECDHKWType:     jsonWebKey2020,
-	kms.NISTP521ECDHKWType:     jsonWebKey2020,
+	kms.ED25519Type:                 ed25519VerificationKey2018,
+	kms.BLS12381G2Type:              bls12381G2Key2020,
+	kms.ECDSAP256TypeDER:            jsonWebKey2020,
+	kms.ECDSAP256TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSAP384TypeDER:            jsonWebKey2020,
+	kms.ECDSAP384TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSAP521TypeDER:            jsonWebKey2020,
+	kms.ECDSAP521TypeIEEEP1363:      jsonWebKey2020,
+	kms.ECDSASecp256k1TypeIEEEP1363: jsonWebKey2020,
+	kms.X25519ECDHKWType:            x25519KeyAgreementKey2019,
+	kms.NISTP256ECDHKWType:          jsonWebKey2020,
+	kms.NISTP384ECDHKWType:          jsonWebKey2020,
+	kms.NISTP521ECDHKWType:          jsonWebKey2020,
 }
 
 func getVerMethodType(kt kms.KeyType) string {
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 )
@@ -33,6 +35,12 @@ type OOBInvitation struct {
 	// MediaTypeProfiles are the message format profiles supported by the sender of this invitation
 	// as defined in RFC 0044.
 	MediaTypeProfiles []string
+	// ExpiresTime after which the requests referring to my invitation are rejected, it doesn't expire if zero.
+	ExpiresTime time.Time
+	// MaxAccepts is the number of requests referring to my invitation which are accepted, unlimited if zero.
+	MaxAccepts int
+	// Accepts is the number of requests referring to my invitation which were accepted.
+	Accepts int
 }
 
 // Invitation model
@@ -115,6 +123,16 @@ type Connection struct {
 	DIDDoc *did.Doc `json:"did_doc,omitempty"`
 }
 
+// ProblemReport defines a2a DID exchange problem report message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0023-did-exchange#errors
+type ProblemReport struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+	ProblemCode string            `json:"problem-code,omitempty"`
+	Explain     string            `json:"explain,omitempty"`
+}
+
 // Complete defines a2a DID exchange complete message.
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0023-did-exchange#3-exchange-complete
 type Complete struct {
@@ -11,9 +11,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	commonlock "github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -27,12 +29,22 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 var logger = log.New("aries-framework/did-exchange/service")
 
+var (
+	// ErrInvitationExpired is returned when a request refers to an out-of-band invitation which has expired.
+	ErrInvitationExpired = errors.New("invitation has expired")
+	// ErrInvitationExhausted is returned when a request refers to an out-of-band invitation which was accepted the
+	// maximum number of times.
+	ErrInvitationExhausted = errors.New("invitation was accepted the maximum number of times")
+)
+
 const (
 	// DIDExchange did exchange protocol.
 	DIDExchange = "didexchange"
@@ -48,6 +60,8 @@ const (
 	AckMsgType = PIURI + "/ack"
 	// CompleteMsgType defines the did-exchange complete message type.
 	CompleteMsgType = PIURI + "/complete"
+	// ProblemReportMsgType defines the did-exchange problem report message type.
+	ProblemReportMsgType = PIURI + "/problem_report"
 	// oobMsgType is the internal message type for the oob invitation that the didexchange service receives.
 	oobMsgType             = "oob-invitation"
 	routerConnsMetadataKey = "routerConnections"
@@ -86,6 +100,8 @@ type provider interface {
 	KeyType() kms.KeyType
 	KeyAgreementType() kms.KeyType
 	MediaTypeProfiles() []string
+	LockService() lock.Service
+	NonceStore() *nonce.Store
 }
 
 // stateMachineMsg is an internal struct used to pass data to state machine.
@@ -103,6 +119,8 @@ type Service struct {
 	callbackChannel    chan *message
 	connectionRecorder *connection.Recorder
 	connectionStore    didstore.ConnectionStore
+	lockService        lock.Service
+	nonceStore         *nonce.Store
 }
 
 type context struct {
@@ -131,9 +149,30 @@ type opts interface {
 	RouterConnections() []string
 }
 
+// ServiceOption configures the DID exchange service.
+type ServiceOption func(opts *serviceOptions)
+
+type serviceOptions struct {
+	protocolStateTTL time.Duration
+}
+
+// WithProtocolStateTTL sets the time the records of the connections in the protocol state store expire after, the
+// records of the completed connections being kept in the permanent store. The records don't expire by default.
+func WithProtocolStateTTL(ttl time.Duration) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.protocolStateTTL = ttl
+	}
+}
+
 // New return didexchange service.
-func New(prov provider) (*Service, error) {
-	connRecorder, err := connection.NewRecorder(prov)
+func New(prov provider, opts ...ServiceOption) (*Service, error) {
+	svcOpts := &serviceOptions{}
+
+	for _, opt := range opts {
+		opt(svcOpts)
+	}
+
+	connRecorder, err := connection.NewRecorder(prov, connection.WithProtocolStateTTL(svcOpts.protocolStateTTL))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize connection recorder: %w", err)
 	}
@@ -183,6 +222,8 @@ func New(prov provider) (*Service, error) {
 		callbackChannel:    make(chan *message, callbackChannelSize),
 		connectionRecorder: connRecorder,
 		connectionStore:    prov.DIDConnectionStore(),
+		lockService:        prov.LockService(),
+		nonceStore:         prov.NonceStore(),
 	}
 
 	// start the listener
@@ -238,7 +279,7 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	}
 
 	go func(msg *message, aEvent chan<- service.DIDCommAction) {
-		if err = s.handle(msg, aEvent); err != nil {
+		if err = s.handleInbound(msg, aEvent); err != nil {
 			logutil.LogError(logger, DIDExchange, "processMessage", err.Error(),
 				logutil.CreateKeyValueString("msgType", msg.Msg.Type()),
 				logutil.CreateKeyValueString("msgID", msg.Msg.ID()),
@@ -264,6 +305,11 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// PIURIs returns the DID exchange protocol URI.
+func (s *Service) PIURIs() []string {
+	return []string{PIURI}
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType || msgType == oobMsgType {
@@ -316,7 +362,28 @@ func (s *Service) nextState(msgType, thID string) (state, error) {
 	return next, nil
 }
 
-func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) error { //nolint:funlen,gocyclo
+// handleInbound runs the state transitions of the inbound message holding the lock of the thread. The transition is
+// validated again once the lock is held, as another agent instance sharing the storage may have handled a message of
+// the thread since HandleInbound validated it.
+func (s *Service) handleInbound(msg *message, aEvent chan<- service.DIDCommAction) error {
+	return commonlock.Do(s.lockService, threadLockName(msg.ThreadID), func() error {
+		if _, err := s.nextState(msg.Msg.Type(), msg.ThreadID); err != nil {
+			return fmt.Errorf("handle inbound - next state : %w", err)
+		}
+
+		return s.transition(msg, aEvent)
+	})
+}
+
+// handle runs the state transitions of the message holding the lock of the thread, so that the agent instances
+// sharing the storage don't run concurrent transitions of a connection.
+func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) error {
+	return commonlock.Do(s.lockService, threadLockName(msg.ThreadID), func() error {
+		return s.transition(msg, aEvent)
+	})
+}
+
+func (s *Service) transition(msg *message, aEvent chan<- service.DIDCommAction) error { //nolint:funlen,gocyclo
 	logger.Debugf("handling msg: %+v", msg)
 
 	next, err := stateFromName(msg.NextStateName)
@@ -412,6 +479,14 @@ func (s *Service) handleWithoutAction(msg *message) error {
 	return s.handle(msg, nil)
 }
 
+func threadLockName(thID string) string {
+	return DIDExchange + "_" + thID
+}
+
+func invitationLockName(invitationID string) string {
+	return DIDExchange + "_invitation_" + invitationID
+}
+
 func createEventProperties(connectionID, invitationID string) *didExchangeEvent {
 	return &didExchangeEvent{
 		connectionID: connectionID,
@@ -494,7 +569,10 @@ func (s *Service) startInternalListener() {
 			continue
 		}
 
-		if err := s.abandon(msg.ThreadID, msg.Msg, msg.err); err != nil {
+		err := commonlock.Do(s.lockService, threadLockName(msg.ThreadID), func() error {
+			return s.abandon(msg.ThreadID, msg.Msg, msg.err)
+		})
+		if err != nil {
 			logger.Errorf("process callback : %s", err)
 		}
 	}
@@ -819,6 +897,10 @@ func (s *Service) requestMsgRecord(msg service.DIDCommMsg) (*connection.Record,
 		return nil, fmt.Errorf("missing parent thread ID on didexchange request with @id=%s", request.ID)
 	}
 
+	if err = s.useInvitation(msg, &request, invitationID); err != nil {
+		return nil, err
+	}
+
 	connRecord := &connection.Record{
 		TheirLabel:   request.Label,
 		ConnectionID: generateRandomID(),
@@ -842,6 +924,104 @@ func (s *Service) requestMsgRecord(msg service.DIDCommMsg) (*connection.Record,
 	return connRecord, nil
 }
 
+// useInvitation checks that the invitation the request refers to can still be accepted: all the invitations are
+// single-use when the nonce store is set, my out-of-band invitations can expire or be accepted a limited number of
+// times. The implicit invitations of the public DIDs can be accepted by any number of parties. The rejected requests
+// are answered with a problem report and raise an abandoned state event.
+func (s *Service) useInvitation(msg service.DIDCommMsg, request *Request, invitationID string) error {
+	if isDID(invitationID) {
+		return nil
+	}
+
+	var err error
+
+	if s.nonceStore != nil {
+		err = s.nonceStore.Use(nonce.InvitationScope, invitationID)
+	}
+
+	if err == nil {
+		err = s.acceptOOBInvitation(invitationID)
+	}
+
+	explain, rejected := rejectionReason(err)
+	if !rejected {
+		if err != nil {
+			return fmt.Errorf("use invitation: %w", err)
+		}
+
+		return nil
+	}
+
+	if e := s.ctx.sendProblemReport(request, invitationID, codeRequestNotAccepted, explain); e != nil {
+		logger.Warnf("failed to send problem report for request %s: %s", request.ID, e)
+	}
+
+	go s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: DIDExchange,
+		Type:         service.PostState,
+		Msg:          msg.Clone(),
+		StateID:      StateIDAbandoned,
+		Properties:   createErrorEventProperties("", invitationID, err),
+	})
+
+	return fmt.Errorf("request %s: %w", request.ID, err)
+}
+
+// acceptOOBInvitation counts the requests referring to my out-of-band invitation, holding the lock of the invitation
+// so that the agent instances sharing the storage don't accept it more than its max accepts.
+func (s *Service) acceptOOBInvitation(invitationID string) error {
+	return commonlock.Do(s.lockService, invitationLockName(invitationID), func() error {
+		var invitation OOBInvitation
+
+		err := s.connectionRecorder.GetInvitation(invitationID, &invitation)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("get invitation: %w", err)
+		}
+
+		if invitation.Type != oobMsgType {
+			return nil
+		}
+
+		if !invitation.ExpiresTime.IsZero() && time.Now().After(invitation.ExpiresTime) {
+			return fmt.Errorf("invitation %s: %w", invitationID, ErrInvitationExpired)
+		}
+
+		if invitation.MaxAccepts == 0 {
+			return nil
+		}
+
+		if invitation.Accepts >= invitation.MaxAccepts {
+			return fmt.Errorf("invitation %s: %w", invitationID, ErrInvitationExhausted)
+		}
+
+		invitation.Accepts++
+
+		if err = s.connectionRecorder.SaveInvitation(invitationID, &invitation); err != nil {
+			return fmt.Errorf("save invitation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// rejectionReason returns the explanation of the problem report answering the requests rejected with the error.
+func rejectionReason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, nonce.ErrReplay):
+		return "invitation was already accepted", true
+	case errors.Is(err, ErrInvitationExpired):
+		return "invitation has expired", true
+	case errors.Is(err, ErrInvitationExhausted):
+		return "invitation was accepted the maximum number of times", true
+	default:
+		return "", false
+	}
+}
+
 func (s *Service) responseMsgRecord(payload service.DIDCommMsg) (*connection.Record, error) {
 	return s.fetchConnectionRecord(myNSPrefix, payload)
 }
@@ -936,7 +1116,7 @@ func (s *Service) CreateImplicitInvitation(inviterLabel, inviterDID,
 	internalMsg.Options = &options{publicDID: inviteeDID, label: inviteeLabel, routerConnections: routerConnections}
 
 	go func(msg *message, aEvent chan<- service.DIDCommAction) {
-		if err = s.handle(msg, aEvent); err != nil {
+		if err = s.handleInbound(msg, aEvent); err != nil {
 			logger.Errorf("error from handle for implicit invitation: %s", err)
 		}
 	}(internalMsg, s.ActionEvent())
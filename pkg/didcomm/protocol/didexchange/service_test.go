@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	goctx "context"
 	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
@@ -20,6 +21,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	commonlock "github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -35,13 +38,16 @@ import (
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -624,7 +630,7 @@ func TestService_Update(t *testing.T) {
 	data := make(map[string][]byte)
 	connRecord := &connection.Record{
 		ThreadID: "123", ConnectionID: "123456", State: s.Name(),
-		Namespace: findNamespace(RequestMsgType),
+		Namespace: findNamespace(RequestMsgType), CreatedTime: time.Now().UTC(),
 	}
 	bytes, err := json.Marshal(connRecord)
 	require.NoError(t, err)
@@ -1088,6 +1094,145 @@ func TestEventProcessCallback(t *testing.T) {
 	require.Contains(t, err.Error(), "unable to update the state to abandoned")
 }
 
+func TestServiceLock(t *testing.T) {
+	t.Run("state transitions hold the lock of the thread", func(t *testing.T) {
+		lockService := &mocklock.MockLockService{}
+
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			LockServiceValue: lockService,
+		})
+		require.NoError(t, err)
+
+		msg := &message{
+			ThreadID: threadIDValue,
+			Msg:      service.NewDIDCommMsgMap(model.Ack{Type: AckMsgType}),
+		}
+
+		err = svc.handleWithoutAction(msg)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid state name")
+		require.Equal(t, []string{DIDExchange + "_" + threadIDValue}, lockService.Locked())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			LockServiceValue: &mocklock.MockLockService{ErrLock: errors.New("lock error")},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleWithoutAction(&message{
+			ThreadID:      threadIDValue,
+			Msg:           service.NewDIDCommMsgMap(model.Ack{Type: AckMsgType}),
+			NextStateName: StateIDCompleted,
+		})
+		require.EqualError(t, err, "acquire lock "+DIDExchange+"_"+threadIDValue+": lock error")
+	})
+}
+
+func TestServicesSharingStorage(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	k := newKMS(t, sp)
+	ctx := &context{
+		kms:              k,
+		keyType:          kms.ED25519Type,
+		keyAgreementType: kms.X25519ECDHKWType,
+	}
+
+	lockService := &notifyingLockService{Service: commonlock.NewLocal(), unlocked: make(chan string, 10)}
+	actionCh := make(chan service.DIDCommAction, 10)
+
+	var services []*Service
+
+	// two agent instances running against the same storage and lock service
+	for i := 0; i < 2; i++ {
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			StoreProvider:              sp,
+			ProtocolStateStoreProvider: sp,
+			CustomKMS:                  k,
+			KeyTypeValue:               ctx.keyType,
+			KeyAgreementTypeValue:      ctx.keyAgreementType,
+			LockServiceValue:           lockService,
+		})
+		require.NoError(t, err)
+		require.NoError(t, svc.RegisterActionEvent(actionCh))
+
+		services = append(services, svc)
+	}
+
+	pubKey, _ := newSigningAndEncryptionDIDKeys(t, ctx)
+	id := randomString()
+	invite, err := json.Marshal(
+		&Invitation{
+			Type:          InvitationMsgType,
+			ID:            id,
+			Label:         "test",
+			RecipientKeys: []string{pubKey},
+		},
+	)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(invite)
+	require.NoError(t, err)
+
+	// both instances receive the invitation and validate the transition before any of them runs it
+	threadLock, err := lockService.Lock(goctx.Background(), threadLockName(id))
+	require.NoError(t, err)
+
+	for _, svc := range services {
+		_, err = svc.HandleInbound(didMsg, service.EmptyDIDCommContext())
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, threadLock.Unlock())
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-lockService.unlocked:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "thread lock not released")
+		}
+	}
+
+	// the instance running the transition last finds the connection invited and rejects the invitation
+	require.Len(t, actionCh, 1)
+	validateState(t, services[0], id, myNSPrefix, StateIDInvited)
+}
+
+type notifyingLockService struct {
+	spilock.Service
+	unlocked chan string
+}
+
+func (s *notifyingLockService) Lock(ctx goctx.Context, name string) (spilock.Lock, error) {
+	l, err := s.Service.Lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notifyingLock{Lock: l, unlocked: func() { s.unlocked <- name }}, nil
+}
+
+type notifyingLock struct {
+	spilock.Lock
+	unlocked func()
+}
+
+func (l *notifyingLock) Unlock() error {
+	err := l.Lock.Unlock()
+	l.unlocked()
+
+	return err
+}
+
 func validateState(t *testing.T, svc *Service, id, namespace, expected string) {
 	nsThid, err := connection.CreateNamespaceKey(namespace, id)
 	require.NoError(t, err)
@@ -1426,6 +1571,159 @@ func TestRequestRecord(t *testing.T) {
 	})
 }
 
+func TestSingleUseInvitations(t *testing.T) {
+	nonceStore, err := nonce.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, time.Hour)
+	require.NoError(t, err)
+
+	svc, err := New(&protocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		NonceStoreValue: nonceStore,
+	})
+	require.NoError(t, err)
+
+	invitationID := uuid.New().String()
+
+	_, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(), invitationID))
+	require.NoError(t, err)
+
+	// another party accepting the same invitation is rejected
+	_, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(), invitationID))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, nonce.ErrReplay))
+
+	// the implicit invitations of the public DIDs can be accepted by any number of parties
+	for i := 0; i < 2; i++ {
+		_, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+			"did:example:public"))
+		require.NoError(t, err)
+	}
+
+	used, err := nonceStore.IsUsed(nonce.InvitationScope, "did:example:public")
+	require.NoError(t, err)
+	require.False(t, used)
+}
+
+func TestOOBInvitationPolicy(t *testing.T) {
+	newService := func(t *testing.T) *Service {
+		t.Helper()
+
+		svc, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("rejects the requests referring to an expired invitation", func(t *testing.T) {
+		svc := newService(t)
+
+		statusCh := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(statusCh))
+
+		invitationID := uuid.New().String()
+		require.NoError(t, svc.SaveInvitation(&OOBInvitation{
+			ThreadID:    invitationID,
+			ExpiresTime: time.Now().Add(-time.Minute),
+		}))
+
+		_, err := svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+			invitationID))
+		require.True(t, errors.Is(err, ErrInvitationExpired))
+
+		select {
+		case e := <-statusCh:
+			require.Equal(t, service.PostState, e.Type)
+			require.Equal(t, StateIDAbandoned, e.StateID)
+
+			props, ok := e.Properties.(*didExchangeEventError)
+			require.True(t, ok)
+			require.Equal(t, invitationID, props.InvitationID())
+			require.True(t, errors.Is(props.err, ErrInvitationExpired))
+		case <-time.After(time.Second):
+			t.Fatal("abandoned event was not raised")
+		}
+	})
+
+	t.Run("accepts the requests until the invitation expires", func(t *testing.T) {
+		svc := newService(t)
+
+		invitationID := uuid.New().String()
+		require.NoError(t, svc.SaveInvitation(&OOBInvitation{
+			ThreadID:    invitationID,
+			ExpiresTime: time.Now().Add(time.Hour),
+		}))
+
+		for i := 0; i < 2; i++ {
+			_, err := svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+				invitationID))
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("rejects the requests once the invitation is exhausted", func(t *testing.T) {
+		svc := newService(t)
+
+		invitationID := uuid.New().String()
+		require.NoError(t, svc.SaveInvitation(&OOBInvitation{ThreadID: invitationID, MaxAccepts: 2}))
+
+		for i := 0; i < 2; i++ {
+			_, err := svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+				invitationID))
+			require.NoError(t, err)
+		}
+
+		_, err := svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+			invitationID))
+		require.True(t, errors.Is(err, ErrInvitationExhausted))
+	})
+
+	t.Run("error if cannot read the invitation", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		svc, err := New(&protocol.MockProvider{
+			StoreProvider: &mockstorage.MockStoreProvider{Store: store},
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		store.ErrGet = errors.New("get error")
+
+		_, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+			uuid.New().String()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "use invitation: get invitation")
+	})
+
+	t.Run("error if cannot save the invitation", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		svc, err := New(&protocol.MockProvider{
+			StoreProvider: &mockstorage.MockStoreProvider{Store: store},
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		invitationID := uuid.New().String()
+		require.NoError(t, svc.SaveInvitation(&OOBInvitation{ThreadID: invitationID, MaxAccepts: 1}))
+
+		store.ErrPut = errors.New("put error")
+
+		_, err = svc.requestMsgRecord(generateRequestMsgPayload(t, &protocol.MockProvider{}, randomString(),
+			invitationID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "use invitation: save invitation")
+	})
+}
+
 func TestAcceptExchangeRequest(t *testing.T) {
 	sp := mockstorage.NewMockStoreProvider()
 	k := newKMS(t, sp)
@@ -15,7 +15,6 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/mitchellh/mapstructure"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -52,6 +51,8 @@ const (
 	jsonWebKey2020             = "JsonWebKey2020"
 	didMethod                  = "peer"
 	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	// codeRequestNotAccepted is the problem code of the requests rejected by the inviter.
+	codeRequestNotAccepted = "request_not_accepted"
 )
 
 var errVerKeyNotFound = errors.New("verkey not found")
@@ -881,6 +882,38 @@ func (ctx *context) handleInboundResponse(response *Response) (stateAction, *con
 	}, connRecord, nil
 }
 
+// sendProblemReport answers the request with a problem report, signed with the key of the invitation it refers to.
+func (ctx *context) sendProblemReport(request *Request, invitationID, code, explain string) error {
+	requestDID := request.DID
+	// Interop: aca-py issue https://github.com/hyperledger/aries-cloudagent-python/issues/1048
+	if ctx.doACAPyInterop && !strings.HasPrefix(requestDID, "did") {
+		requestDID = "did:peer:" + requestDID
+	}
+
+	requestDidDoc, err := ctx.resolveDidDocFromMessage(requestDID, request.DocAttach)
+	if err != nil {
+		return fmt.Errorf("resolve did doc from exchange request: %w", err)
+	}
+
+	destination, err := service.CreateDestination(requestDidDoc)
+	if err != nil {
+		return err
+	}
+
+	senderVerKey, err := ctx.getVerKey(invitationID)
+	if err != nil {
+		return fmt.Errorf("get sender verkey: %w", err)
+	}
+
+	return ctx.outboundDispatcher.Send(&ProblemReport{
+		Type:        ProblemReportMsgType,
+		ID:          uuid.New().String(),
+		Thread:      &decorator.Thread{ID: request.ID, PID: invitationID},
+		ProblemCode: code,
+		Explain:     explain,
+	}, senderVerKey, destination)
+}
+
 func (ctx *context) getVerKey(invitationID string) (string, error) {
 	pubKey, err := ctx.getVerKeyFromOOBInvitation(invitationID)
 	if err != nil && !errors.Is(err, errVerKeyNotFound) {
@@ -995,12 +1028,13 @@ func (ctx *context) getServiceBlock(i *OOBInvitation) (*did.Service, error) {
 	case map[string]interface{}:
 		var s did.Service
 
-		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &s})
+		// the service endpoint may be in the legacy string form or in the DIDComm v2 object form
+		svcBytes, err := json.Marshal(svc)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize decoder : %w", err)
+			return nil, fmt.Errorf("failed to marshal service block : %w", err)
 		}
 
-		err = decoder.Decode(svc)
+		err = json.Unmarshal(svcBytes, &s)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode service block : %w", err)
 		}
@@ -2400,3 +2400,49 @@ func connRecorder(t *testing.T, p provider) *connection.Recorder {
 
 	return s
 }
+
+func TestSendProblemReport(t *testing.T) {
+	prov := getProvider(t)
+	ctx := getContext(t, &prov, kms.ED25519Type, kms.X25519ECDHKWType, transport.MediaTypeRFC0019EncryptedEnvelope)
+
+	request, err := createRequest(t, ctx, true, transport.MediaTypeRFC0019EncryptedEnvelope)
+	require.NoError(t, err)
+
+	t.Run("sends problem report", func(t *testing.T) {
+		sent := false
+
+		ctx.outboundDispatcher = &mockdispatcher.MockOutbound{
+			ValidateSend: func(msg interface{}, senderVerKey string, des *service.Destination) error {
+				sent = true
+
+				report, ok := msg.(*ProblemReport)
+				require.True(t, ok)
+				require.Equal(t, ProblemReportMsgType, report.Type)
+				require.Equal(t, codeRequestNotAccepted, report.ProblemCode)
+				require.Equal(t, "test", report.Explain)
+				require.Equal(t, request.ID, report.Thread.ID)
+				require.Equal(t, request.Thread.PID, report.Thread.PID)
+				require.NotEmpty(t, senderVerKey)
+				require.NotEmpty(t, des.RecipientKeys)
+
+				return nil
+			},
+		}
+
+		require.NoError(t, ctx.sendProblemReport(request, request.Thread.PID, codeRequestNotAccepted, "test"))
+		require.True(t, sent)
+	})
+
+	t.Run("error if the invitation is unknown", func(t *testing.T) {
+		err = ctx.sendProblemReport(request, "unknown", codeRequestNotAccepted, "test")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get sender verkey")
+	})
+
+	t.Run("error if the did doc of the request cannot be resolved", func(t *testing.T) {
+		err = ctx.sendProblemReport(&Request{ID: request.ID, DID: request.DID}, request.Thread.PID,
+			codeRequestNotAccepted, "test")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve did doc from exchange request")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -851,6 +852,11 @@ func (s *Service) Name() string {
 	return Introduce
 }
 
+// PIURIs returns the introduce protocol URI.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(IntroduceSpec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -36,6 +36,14 @@ type Metadata interface {
 	IssueCredential() *IssueCredential
 	// RequestCredential is pointer to message provided by the user through the Continue function.
 	RequestCredential() *RequestCredential
+	// OfferCredentialV3 is pointer to the message provided by the user through the Continue function.
+	OfferCredentialV3() *OfferCredentialV3
+	// ProposeCredentialV3 is pointer to the message provided by the user through the Continue function.
+	ProposeCredentialV3() *ProposeCredentialV3
+	// IssueCredentialV3 is pointer to the message provided by the user through the Continue function.
+	IssueCredentialV3() *IssueCredentialV3
+	// RequestCredentialV3 is pointer to message provided by the user through the Continue function.
+	RequestCredentialV3() *RequestCredentialV3
 	// CredentialNames is a slice which contains credential names provided by the user through the Continue function.
 	CredentialNames() []string
 	// StateName provides the state name
@@ -100,3 +100,93 @@ type Attribute struct {
 	MimeType string `json:"mime-type,omitempty"`
 	Value    string `json:"value,omitempty"`
 }
+
+// ProposeCredentialV3 is an optional message sent by the potential Holder to the Issuer
+// to initiate the protocol or in response to a offer-credential message when the Holder
+// wants some adjustments made to the credential data offered by Issuer.
+type ProposeCredentialV3 struct {
+	Type string                  `json:"type,omitempty"`
+	Body ProposeCredentialV3Body `json:"body,omitempty"`
+	// Attachments is an array of attachments that further define the credential being proposed.
+	// This might be used to clarify which formats or format versions are wanted.
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// ProposeCredentialV3Body represents body for ProposeCredentialV3.
+type ProposeCredentialV3Body struct {
+	GoalCode string `json:"goal_code,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Offer,
+	// so the offer can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// CredentialPreview is an optional JSON-LD object that represents
+	// the credential data that the Prover wants to receive.
+	CredentialPreview *PreviewCredentialV3 `json:"credential_preview,omitempty"`
+}
+
+// OfferCredentialV3 is a message sent by the Issuer to the potential Holder,
+// describing the credential they intend to offer.
+type OfferCredentialV3 struct {
+	Type string                `json:"type,omitempty"`
+	Body OfferCredentialV3Body `json:"body,omitempty"`
+	// Attachments is a slice of attachments that further define the credential being offered.
+	// This might be used to clarify which formats or format versions will be issued.
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// OfferCredentialV3Body represents body for OfferCredentialV3.
+type OfferCredentialV3Body struct {
+	GoalCode string `json:"goal_code,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Offer,
+	// so the offer can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// ReplacementID is an optional field identifying the credentials the offered credential replaces.
+	ReplacementID string `json:"replacement_id,omitempty"`
+	// CredentialPreview is a JSON-LD object that represents the credential data that Issuer is willing to issue.
+	CredentialPreview *PreviewCredentialV3 `json:"credential_preview,omitempty"`
+}
+
+// RequestCredentialV3 is a message sent by the potential Holder to the Issuer,
+// to request the issuance of a credential.
+type RequestCredentialV3 struct {
+	Type string                  `json:"type,omitempty"`
+	Body RequestCredentialV3Body `json:"body,omitempty"`
+	// Attachments is a slice of attachments defining the requested formats for the credential.
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// RequestCredentialV3Body represents body for RequestCredentialV3.
+type RequestCredentialV3Body struct {
+	GoalCode string `json:"goal_code,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Request,
+	// so the request can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+}
+
+// IssueCredentialV3 contains as attached payload the credentials being issued and is
+// sent in response to a valid RequestCredentialV3 message.
+type IssueCredentialV3 struct {
+	Type string                `json:"type,omitempty"`
+	Body IssueCredentialV3Body `json:"body,omitempty"`
+	// Attachments is a slice of attachments containing the issued credentials.
+	Attachments []decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// IssueCredentialV3Body represents body for IssueCredentialV3.
+type IssueCredentialV3Body struct {
+	GoalCode string `json:"goal_code,omitempty"`
+	// Comment is an optional field that provides human readable information about the issued credentials.
+	Comment string `json:"comment,omitempty"`
+	// ReplacementID is an optional field identifying the credentials the issued credential replaces.
+	ReplacementID string `json:"replacement_id,omitempty"`
+}
+
+// PreviewCredentialV3 is used to construct a preview of the data for the credential that is to be issued.
+type PreviewCredentialV3 struct {
+	Type string                  `json:"type,omitempty"`
+	Body PreviewCredentialV3Body `json:"body,omitempty"`
+}
+
+// PreviewCredentialV3Body represents body for PreviewCredentialV3.
+type PreviewCredentialV3Body struct {
+	Attributes []Attribute `json:"attributes,omitempty"`
+}
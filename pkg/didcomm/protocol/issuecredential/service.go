@@ -10,11 +10,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -37,6 +40,23 @@ const (
 	ProblemReportMsgType = Spec + "problem-report"
 	// CredentialPreviewMsgType defines the protocol credential-preview inner object type.
 	CredentialPreviewMsgType = Spec + "credential-preview"
+
+	// SpecV3 defines the protocol spec for DIDComm V2.
+	SpecV3 = "https://didcomm.org/issue-credential/3.0/"
+	// ProposeCredentialMsgTypeV3 defines the protocol propose-credential message type.
+	ProposeCredentialMsgTypeV3 = SpecV3 + "propose-credential"
+	// OfferCredentialMsgTypeV3 defines the protocol offer-credential message type.
+	OfferCredentialMsgTypeV3 = SpecV3 + "offer-credential"
+	// RequestCredentialMsgTypeV3 defines the protocol request-credential message type.
+	RequestCredentialMsgTypeV3 = SpecV3 + "request-credential"
+	// IssueCredentialMsgTypeV3 defines the protocol issue-credential message type.
+	IssueCredentialMsgTypeV3 = SpecV3 + "issue-credential"
+	// AckMsgTypeV3 defines the protocol ack message type, which completes the protocol.
+	AckMsgTypeV3 = SpecV3 + "ack"
+	// ProblemReportMsgTypeV3 defines the protocol problem-report message type.
+	ProblemReportMsgTypeV3 = SpecV3 + "problem-report"
+	// CredentialPreviewMsgTypeV3 defines the protocol credential-preview inner object type.
+	CredentialPreviewMsgTypeV3 = SpecV3 + "credential-preview"
 )
 
 const (
@@ -76,6 +96,11 @@ type MetaData struct {
 	proposeCredential *ProposeCredential
 	requestCredential *RequestCredential
 	issueCredential   *IssueCredential
+	// keeps the DIDComm V2 messages provided by the user.
+	offerCredentialV3   *OfferCredentialV3
+	proposeCredentialV3 *ProposeCredentialV3
+	requestCredentialV3 *RequestCredentialV3
+	issueCredentialV3   *IssueCredentialV3
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function.
@@ -107,6 +132,26 @@ func (md *MetaData) IssueCredential() *IssueCredential {
 	return md.issueCredential
 }
 
+// OfferCredentialV3 didcomm message.
+func (md *MetaData) OfferCredentialV3() *OfferCredentialV3 {
+	return md.offerCredentialV3
+}
+
+// ProposeCredentialV3 didcomm message.
+func (md *MetaData) ProposeCredentialV3() *ProposeCredentialV3 {
+	return md.proposeCredentialV3
+}
+
+// RequestCredentialV3 didcomm message.
+func (md *MetaData) RequestCredentialV3() *RequestCredentialV3 {
+	return md.requestCredentialV3
+}
+
+// IssueCredentialV3 didcomm message.
+func (md *MetaData) IssueCredentialV3() *IssueCredentialV3 {
+	return md.issueCredentialV3
+}
+
 // CredentialNames are the names with which to save credentials with.
 func (md *MetaData) CredentialNames() []string {
 	return md.credentialNames
@@ -166,6 +211,38 @@ func WithIssueCredential(msg *IssueCredential) Opt {
 	}
 }
 
+// WithProposeCredentialV3 allows providing ProposeCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithProposeCredentialV3(msg *ProposeCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.proposeCredentialV3 = msg
+	}
+}
+
+// WithRequestCredentialV3 allows providing RequestCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithRequestCredentialV3(msg *RequestCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.requestCredentialV3 = msg
+	}
+}
+
+// WithOfferCredentialV3 allows providing OfferCredentialV3 message
+// USAGE: This message should be provided after receiving a ProposeCredentialV3 message.
+func WithOfferCredentialV3(msg *OfferCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.offerCredentialV3 = msg
+	}
+}
+
+// WithIssueCredentialV3 allows providing IssueCredentialV3 message
+// USAGE: This message should be provided after receiving a RequestCredentialV3 message.
+func WithIssueCredentialV3(msg *IssueCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.issueCredentialV3 = msg
+	}
+}
+
 // WithFriendlyNames allows providing names for the credentials.
 // USAGE: This function should be used when the Holder receives IssueCredential message.
 func WithFriendlyNames(names ...string) Opt {
@@ -188,16 +265,30 @@ type Service struct {
 	callbacks  chan *MetaData
 	messenger  service.Messenger
 	middleware Handler
+	actionTTL  time.Duration
+}
+
+// ServiceOption configures the issuecredential service.
+type ServiceOption func(s *Service)
+
+// WithActionTTL sets the time the actions waiting to be continued or stopped are kept for, the actions being kept
+// until they are continued or stopped by default.
+func WithActionTTL(ttl time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.actionTTL = ttl
+	}
 }
 
 // New returns the issuecredential service.
-func New(p Provider) (*Service, error) {
+func New(p Provider, opts ...ServiceOption) (*Service, error) {
 	store, err := p.StorageProvider().OpenStore(Name)
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{transitionalPayloadKey}})
+	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{
+		transitionalPayloadKey, retention.UpdatedTagName, retention.TerminalTagName,
+	}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config: %w", err)
 	}
@@ -209,6 +300,10 @@ func New(p Provider) (*Service, error) {
 		middleware: initialHandler,
 	}
 
+	for _, opt := range opts {
+		opt(svc)
+	}
+
 	// start the listener
 	go svc.startInternalListener()
 
@@ -294,7 +389,7 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 func (s *Service) getCurrentStateNameAndPIID(msg service.DIDCommMsg) (string, string, error) {
 	piID, err := getPIID(msg)
 	if errors.Is(err, service.ErrThreadIDNotFound) {
-		msg.SetID(uuid.New().String())
+		msg.SetID(uuid.New().String(), service.WithVersion(getDIDVersion(getVersion(msg.Type()))))
 
 		return msg.ID(), stateNameStart, nil
 	}
@@ -415,7 +510,8 @@ func getPIID(msg service.DIDCommMsg) (string, error) {
 }
 
 func (s *Service) saveStateName(piID, stateName string) error {
-	return s.store.Put(stateNameKey+piID, []byte(stateName))
+	// the abandoning state is followed by the done state
+	return s.store.Put(stateNameKey+piID, []byte(stateName), retention.Tags(stateName == stateNameDone)...)
 }
 
 func (s *Service) currentStateName(piID string) (string, error) {
@@ -460,41 +556,64 @@ func stateFromName(name string) state {
 
 func nextState(msg service.DIDCommMsg, outbound bool) (state, error) {
 	switch msg.Type() {
-	case ProposeCredentialMsgType:
+	case ProposeCredentialMsgType, ProposeCredentialMsgTypeV3:
 		if outbound {
 			return &proposalSent{}, nil
 		}
 
 		return &proposalReceived{}, nil
-	case OfferCredentialMsgType:
+	case OfferCredentialMsgType, OfferCredentialMsgTypeV3:
 		if outbound {
 			return &offerSent{}, nil
 		}
 
 		return &offerReceived{}, nil
-	case RequestCredentialMsgType:
+	case RequestCredentialMsgType, RequestCredentialMsgTypeV3:
 		if outbound {
 			return &requestSent{}, nil
 		}
 
 		return &requestReceived{}, nil
-	case IssueCredentialMsgType:
+	case IssueCredentialMsgType, IssueCredentialMsgTypeV3:
 		return &credentialReceived{}, nil
-	case ProblemReportMsgType:
+	case ProblemReportMsgType, ProblemReportMsgTypeV3:
 		return &abandoning{}, nil
-	case AckMsgType:
+	case AckMsgType, AckMsgTypeV3:
 		return &done{}, nil
 	default:
 		return nil, fmt.Errorf("unrecognized msgType: %s", msg.Type())
 	}
 }
 
+// getVersion returns the protocol spec of the message type.
+func getVersion(t string) string {
+	if strings.HasPrefix(t, SpecV3) {
+		return SpecV3
+	}
+
+	return Spec
+}
+
+// getDIDVersion returns the DIDComm version the messages of the protocol spec are exchanged with.
+func getDIDVersion(v string) service.Version {
+	if v == SpecV3 {
+		return service.V2
+	}
+
+	return service.V1
+}
+
 func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) error {
 	src, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("marshal transitional payload: %w", err)
 	}
 
+	if s.actionTTL > 0 {
+		return storage.PutWithTTL(s.store, fmt.Sprintf(transitionalPayloadKey, id), src, s.actionTTL,
+			storage.Tag{Name: transitionalPayloadKey})
+	}
+
 	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src, storage.Tag{Name: transitionalPayloadKey})
 }
 
@@ -504,7 +623,12 @@ func canTriggerActionEvents(msg service.DIDCommMsg) bool {
 		msg.Type() == OfferCredentialMsgType ||
 		msg.Type() == IssueCredentialMsgType ||
 		msg.Type() == RequestCredentialMsgType ||
-		msg.Type() == ProblemReportMsgType
+		msg.Type() == ProblemReportMsgType ||
+		msg.Type() == ProposeCredentialMsgTypeV3 ||
+		msg.Type() == OfferCredentialMsgTypeV3 ||
+		msg.Type() == IssueCredentialMsgTypeV3 ||
+		msg.Type() == RequestCredentialMsgTypeV3 ||
+		msg.Type() == ProblemReportMsgTypeV3
 }
 
 func (s *Service) getTransitionalPayload(id string) (*transitionalPayload, error) {
@@ -670,6 +794,10 @@ func (s *Service) execute(next state, md *MetaData) (state, stateAction, error)
 
 	md.properties = newEventProps(md).All()
 
+	if md.inbound && next.Name() == stateNameOfferReceived {
+		prefillRequestCredential(md)
+	}
+
 	if err := s.middleware.Handle(md); err != nil {
 		return nil, nil, fmt.Errorf("middleware: %w", err)
 	}
@@ -682,6 +810,26 @@ func (s *Service) execute(next state, md *MetaData) (state, stateAction, error)
 	return exec(md)
 }
 
+// prefillRequestCredential sets the request replying to the offer when the user didn't provide one, the middlewares
+// may then complete it (eg. with the attachments of the formats they handle).
+func prefillRequestCredential(md *MetaData) {
+	if isV3(md) || md.proposeCredential != nil || md.requestCredential != nil {
+		return
+	}
+
+	offer := OfferCredential{}
+	if err := md.Msg.Decode(&offer); err != nil {
+		// the error is reported by the state
+		return
+	}
+
+	md.requestCredential = &RequestCredential{
+		Type:           RequestCredentialMsgType,
+		Formats:        offer.Formats,
+		RequestsAttach: offer.OffersAttach,
+	}
+}
+
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *MetaData, stateID string, stateType service.StateMsgType) {
 	// trigger the message events
@@ -701,11 +849,18 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// PIURIs returns the issue credential v2 and v3 URIs.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/"), strings.TrimSuffix(SpecV3, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case ProposeCredentialMsgType, OfferCredentialMsgType, RequestCredentialMsgType,
-		IssueCredentialMsgType, AckMsgType, ProblemReportMsgType:
+		IssueCredentialMsgType, AckMsgType, ProblemReportMsgType,
+		ProposeCredentialMsgTypeV3, OfferCredentialMsgTypeV3, RequestCredentialMsgTypeV3,
+		IssueCredentialMsgTypeV3, AckMsgTypeV3, ProblemReportMsgTypeV3:
 		return true
 	}
 
@@ -25,6 +25,7 @@ import (
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -253,7 +254,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "done", string(name))
 
 			return nil
@@ -311,7 +312,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "offer-sent", string(name))
 
 			return nil
@@ -469,7 +470,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "done", string(name))
 
 			return nil
@@ -525,7 +526,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "proposal-sent", string(name))
 
 			return nil
@@ -581,7 +582,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "request-sent", string(name))
 
 			return nil
@@ -639,7 +640,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "request-sent", string(name))
 
 			return nil
@@ -679,6 +680,66 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Offer Credential (request completed by a middleware)", func(t *testing.T) {
+		done := make(chan struct{})
+		attachment := []decorator.Attachment{{ID: "ID1"}}
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &RequestCredential{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, RequestCredentialMsgType, r.Type)
+				require.Equal(t, "request comment", r.Comment)
+				require.Equal(t, attachment, r.RequestsAttach)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		svc.AddMiddleware(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				if metadata.StateName() == stateNameOfferReceived {
+					require.NotNil(t, metadata.RequestCredential())
+					metadata.RequestCredential().Comment = "request comment"
+				}
+
+				return next.Handle(metadata)
+			})
+		})
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(OfferCredential{
+			Type:         OfferCredentialMsgType,
+			OffersAttach: attachment,
+		})
+
+		msg.SetID(uuid.New().String())
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(nil)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
 	t.Run("Receive Invitation Credential Stop", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -698,7 +759,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "done", string(name))
 
 			return nil
@@ -754,7 +815,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "credential-issued", string(name))
 
 			return nil
@@ -799,7 +860,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			require.Equal(t, "done", string(name))
@@ -846,7 +907,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			require.Equal(t, "done", string(name))
@@ -904,7 +965,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "done", string(name))
 
 			return nil
@@ -986,7 +1047,7 @@ func TestService_HandleInbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "done", string(name))
 
 			return nil
@@ -1029,7 +1090,7 @@ func TestService_HandleInbound(t *testing.T) {
 		done := make(chan struct{})
 
 		store.EXPECT().Get(gomock.Any()).Return([]byte("credential-issued"), nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			require.Equal(t, "done", string(name))
@@ -1122,7 +1183,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	t.Run("Send Propose Credential", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "proposal-sent", string(name))
 
 			return nil
@@ -1155,7 +1216,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	})
 
 	t.Run("Send Propose Credential with error", func(t *testing.T) {
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		svc, err := New(provider)
 		require.NoError(t, err)
@@ -1174,7 +1235,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	t.Run("Send Offer Credential", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "offer-sent", string(name))
 
 			return nil
@@ -1207,7 +1268,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	})
 
 	t.Run("Send Offer with error", func(t *testing.T) {
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		svc, err := New(provider)
 		require.NoError(t, err)
@@ -1226,7 +1287,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	t.Run("Send Invitation Credential", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
 			require.Equal(t, "request-sent", string(name))
 
 			return nil
@@ -1259,7 +1320,7 @@ func TestService_HandleOutbound(t *testing.T) {
 	})
 
 	t.Run("Send Invitation with error", func(t *testing.T) {
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		svc, err := New(provider)
 		require.NoError(t, err)
@@ -1276,6 +1337,348 @@ func TestService_HandleOutbound(t *testing.T) {
 	})
 }
 
+func TestService_HandleInboundV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storageMocks.NewMockStore(ctrl)
+
+	storeProvider := storageMocks.NewMockProvider(ctrl)
+	storeProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil).AnyTimes()
+	storeProvider.EXPECT().SetStoreConfig(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+	t.Run("Receive Propose Credential Continue", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, opts ...service.Opt) error {
+				defer close(done)
+
+				r := &OfferCredentialV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, OfferCredentialMsgTypeV3, r.Type)
+				require.Equal(t, "offer", r.Body.Comment)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+			require.Equal(t, "offer-sent", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredentialV3{
+			Type: ProposeCredentialMsgTypeV3,
+		})
+
+		msg.SetID(uuid.New().String(), service.WithVersion(service.V2))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		action.Continue(WithOfferCredentialV3(&OfferCredentialV3{Body: OfferCredentialV3Body{Comment: "offer"}}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Offer Credential Continue", func(t *testing.T) {
+		done := make(chan struct{})
+
+		attachments := []decorator.AttachmentV2{{
+			ID:        "attach-1",
+			MediaType: "application/json",
+			Data:      decorator.AttachmentData{JSON: map[string]interface{}{"key": "value"}},
+		}}
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, opts ...service.Opt) error {
+				defer close(done)
+
+				r := &RequestCredentialV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, RequestCredentialMsgTypeV3, r.Type)
+				require.Equal(t, attachments[0].ID, r.Attachments[0].ID)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+			require.Equal(t, "request-sent", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(OfferCredentialV3{
+			Type:        OfferCredentialMsgTypeV3,
+			Attachments: attachments,
+		})
+
+		msg.SetID(uuid.New().String(), service.WithVersion(service.V2))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(nil)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Request Credential Continue", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, opts ...service.Opt) error {
+				defer close(done)
+
+				r := &IssueCredentialV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, IssueCredentialMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("offer-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+			require.Equal(t, "credential-issued", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(RequestCredentialV3{
+			Type: RequestCredentialMsgTypeV3,
+		})
+
+		msg.SetID(uuid.New().String(), service.WithVersion(service.V2))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(WithIssueCredentialV3(&IssueCredentialV3{}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Issue Credential Continue", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string, opts ...service.Opt) error {
+				defer close(done)
+
+				r := &model.AckV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, AckMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+			require.Equal(t, "done", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(IssueCredentialV3{
+			Type: IssueCredentialMsgTypeV3,
+		})
+
+		msg.SetID(uuid.New().String(), service.WithVersion(service.V2))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(WithFriendlyNames("UniversityDegree"))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Request Credential Stop", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReportV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeRejectedError, r.Body.Code)
+				require.Equal(t, ProblemReportMsgTypeV3, r.Type)
+				require.Equal(t, service.V2, opts.V)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("offer-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+			require.Equal(t, "done", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(RequestCredentialV3{
+			Type: RequestCredentialMsgTypeV3,
+		})
+
+		msg.SetID(uuid.New().String(), service.WithVersion(service.V2))
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Stop(nil)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+}
+
+func TestService_HandleOutboundV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storageMocks.NewMockStore(ctrl)
+
+	storeProvider := storageMocks.NewMockProvider(ctrl)
+	storeProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil).AnyTimes()
+	storeProvider.EXPECT().SetStoreConfig(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger).AnyTimes()
+	provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+	tests := []struct {
+		name  string
+		msg   interface{}
+		state string
+	}{
+		{
+			name:  "Send Propose Credential",
+			msg:   ProposeCredentialV3{Type: ProposeCredentialMsgTypeV3},
+			state: "proposal-sent",
+		},
+		{
+			name:  "Send Offer Credential",
+			msg:   OfferCredentialV3{Type: OfferCredentialMsgTypeV3},
+			state: "offer-sent",
+		},
+		{
+			name:  "Send Request Credential",
+			msg:   RequestCredentialV3{Type: RequestCredentialMsgTypeV3},
+			state: "request-sent",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte, _ ...storage.Tag) error {
+				require.Equal(t, tc.state, string(name))
+
+				return nil
+			})
+
+			svc, err := New(provider)
+			require.NoError(t, err)
+
+			msg := service.NewDIDCommMsgMap(tc.msg)
+
+			messenger.EXPECT().Send(msg, Alice, Bob, gomock.Any()).
+				Do(func(msg service.DIDCommMsgMap, myDID, theirDID string, opts ...service.Opt) error {
+					require.NotEmpty(t, msg.ID())
+					require.NotEmpty(t, msg["id"])
+
+					return nil
+				})
+
+			piid, err := svc.HandleOutbound(msg, Alice, Bob)
+			require.NotEmpty(t, piid)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestService_ActionContinue(t *testing.T) {
 	t.Run("Error transitional payload (get)", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -1386,6 +1789,46 @@ func TestService_ActionStop(t *testing.T) {
 	})
 }
 
+func TestService_saveStateName(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore(Name)
+	require.NoError(t, err)
+
+	svc := &Service{store: store}
+
+	require.NoError(t, svc.saveStateName("piid-1", stateNameOfferSent))
+	require.NoError(t, svc.saveStateName("piid-2", stateNameAbandoning))
+	require.NoError(t, svc.saveStateName("piid-3", stateNameDone))
+
+	for piID, terminal := range map[string]bool{"piid-1": false, "piid-2": false, "piid-3": true} {
+		tags, err := store.GetTags(stateNameKey + piID)
+		require.NoError(t, err)
+
+		require.Equal(t, retention.UpdatedTagName, tags[0].Name)
+		require.Equal(t, terminal, len(tags) == 2 && tags[1].Name == retention.TerminalTagName)
+	}
+}
+
+func TestService_saveTransitionalPayload(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore(Name)
+	require.NoError(t, err)
+
+	svc := &Service{store: store}
+
+	// the actions are kept until they are continued or stopped by default
+	require.NoError(t, svc.saveTransitionalPayload("piid-1", transitionalPayload{StateName: stateNameOfferSent}))
+
+	WithActionTTL(time.Millisecond)(svc)
+	require.NoError(t, svc.saveTransitionalPayload("piid-2", transitionalPayload{StateName: stateNameOfferSent}))
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = store.Get(fmt.Sprintf(transitionalPayloadKey, "piid-1"))
+	require.NoError(t, err)
+
+	_, err = store.Get(fmt.Sprintf(transitionalPayloadKey, "piid-2"))
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoning), &abandoning{})
@@ -1456,6 +1899,24 @@ func Test_nextState(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, next, &abandoning{})
 
+	next, err = nextState(service.NewDIDCommMsgMap(OfferCredentialV3{
+		Type: OfferCredentialMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &offerReceived{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(IssueCredentialV3{
+		Type: IssueCredentialMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &credentialReceived{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(model.AckV2{
+		Type: AckMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &done{})
+
 	next, err = nextState(service.NewDIDCommMsgMap(struct{}{}), false)
 	require.Error(t, err)
 	require.Nil(t, next)
@@ -1472,6 +1933,12 @@ func TestService_Accept(t *testing.T) {
 	require.True(t, (*Service).Accept(nil, IssueCredentialMsgType))
 	require.True(t, (*Service).Accept(nil, AckMsgType))
 	require.True(t, (*Service).Accept(nil, ProblemReportMsgType))
+	require.True(t, (*Service).Accept(nil, ProposeCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, OfferCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, RequestCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, IssueCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, AckMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, ProblemReportMsgTypeV3))
 	require.False(t, (*Service).Accept(nil, "unknown"))
 }
 
@@ -1492,5 +1959,13 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 		Type: RequestCredentialMsgType,
 	})))
 
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(IssueCredentialV3{
+		Type: IssueCredentialMsgTypeV3,
+	})))
+
+	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(model.AckV2{
+		Type: AckMsgTypeV3,
+	})))
+
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
@@ -57,6 +57,30 @@ type state interface {
 // represents zero state's action.
 func zeroAction(service.Messenger) error { return nil }
 
+// isV3 checks whether the protocol is executed with the DIDComm V2 messages of the issue-credential/3.0 spec.
+func isV3(md *MetaData) bool {
+	return getVersion(md.Msg.Type()) == SpecV3
+}
+
+// forwardInitial creates the state's action sending the initial message of the protocol.
+func forwardInitial(md *MetaData) stateAction {
+	return func(messenger service.Messenger) error {
+		if isV3(md) {
+			return messenger.Send(md.Msg, md.MyDID, md.TheirDID, service.WithVersion(service.V2))
+		}
+
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+}
+
+// replyV3 creates the state's action replying to the message with the given DIDComm V2 message.
+func replyV3(md *MetaData, msg interface{}) stateAction {
+	return func(messenger service.Messenger) error {
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(msg), md.MyDID, md.TheirDID,
+			service.WithVersion(service.V2))
+	}
+}
+
 // noOp state.
 type noOp struct{}
 
@@ -120,7 +144,7 @@ func (s *abandoning) CanTransitionTo(st state) bool {
 func (s *abandoning) ExecuteInbound(md *MetaData) (state, stateAction, error) {
 	// if code is not provided it means we do not need to notify the another agent.
 	// if we received ProblemReport message no need to answer.
-	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType {
+	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType || md.Msg.Type() == ProblemReportMsgTypeV3 {
 		return &done{}, zeroAction, nil
 	}
 
@@ -137,6 +161,13 @@ func (s *abandoning) ExecuteInbound(md *MetaData) (state, stateAction, error) {
 	}
 
 	return &done{}, func(messenger service.Messenger) error {
+		if isV3(md) {
+			return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReportV2{
+				Type: ProblemReportMsgTypeV3,
+				Body: model.ProblemReportV2Body{Code: code.Code},
+			}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID, V: service.V2})
+		}
+
 		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
 			Type:        ProblemReportMsgType,
 			Description: code,
@@ -200,6 +231,17 @@ func (s *offerSent) CanTransitionTo(st state) bool {
 }
 
 func (s *offerSent) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md) {
+		if md.offerCredentialV3 == nil {
+			return nil, nil, errors.New("offer credential was not provided")
+		}
+
+		// sets message type.
+		md.offerCredentialV3.Type = OfferCredentialMsgTypeV3
+
+		return &noOp{}, replyV3(md, md.offerCredentialV3), nil
+	}
+
 	if md.offerCredential == nil {
 		return nil, nil, errors.New("offer credential was not provided")
 	}
@@ -215,12 +257,7 @@ func (s *offerSent) ExecuteInbound(md *MetaData) (state, stateAction, error) {
 }
 
 func (s *offerSent) ExecuteOutbound(md *MetaData) (state, stateAction, error) {
-	// creates the state's action.
-	action := func(messenger service.Messenger) error {
-		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
-	}
-
-	return &noOp{}, action, nil
+	return &noOp{}, forwardInitial(md), nil
 }
 
 // requestReceived the Issuer's state.
@@ -235,6 +272,17 @@ func (s *requestReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *requestReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md) {
+		if md.issueCredentialV3 == nil {
+			return nil, nil, errors.New("issue credential was not provided")
+		}
+
+		// sets message type
+		md.issueCredentialV3.Type = IssueCredentialMsgTypeV3
+
+		return &credentialIssued{}, replyV3(md, md.issueCredentialV3), nil
+	}
+
 	if md.issueCredential == nil {
 		return nil, nil, errors.New("issue credential was not provided")
 	}
@@ -284,6 +332,17 @@ func (s *proposalSent) CanTransitionTo(st state) bool {
 }
 
 func (s *proposalSent) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md) {
+		if md.proposeCredentialV3 == nil {
+			return nil, nil, errors.New("propose credential was not provided")
+		}
+
+		// sets message type
+		md.proposeCredentialV3.Type = ProposeCredentialMsgTypeV3
+
+		return &noOp{}, replyV3(md, md.proposeCredentialV3), nil
+	}
+
 	if md.proposeCredential == nil {
 		return nil, nil, errors.New("propose credential was not provided")
 	}
@@ -299,12 +358,7 @@ func (s *proposalSent) ExecuteInbound(md *MetaData) (state, stateAction, error)
 }
 
 func (s *proposalSent) ExecuteOutbound(md *MetaData) (state, stateAction, error) {
-	// creates the state's action
-	action := func(messenger service.Messenger) error {
-		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
-	}
-
-	return &noOp{}, action, nil
+	return &noOp{}, forwardInitial(md), nil
 }
 
 // offerReceived the Holder's state.
@@ -321,6 +375,10 @@ func (s *offerReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *offerReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md) {
+		return s.executeInboundV3(md)
+	}
+
 	// sends propose credential if it was provided
 	if md.proposeCredential != nil {
 		return &proposalSent{}, zeroAction, nil
@@ -350,6 +408,30 @@ func (s *offerReceived) ExecuteInbound(md *MetaData) (state, stateAction, error)
 	return &requestSent{}, action, nil
 }
 
+func (s *offerReceived) executeInboundV3(md *MetaData) (state, stateAction, error) {
+	// sends propose credential if it was provided
+	if md.proposeCredentialV3 != nil {
+		return &proposalSent{}, zeroAction, nil
+	}
+
+	offer := OfferCredentialV3{}
+	if err := md.Msg.Decode(&offer); err != nil {
+		return nil, nil, fmt.Errorf("decode: %w", err)
+	}
+
+	response := &RequestCredentialV3{
+		Type:        RequestCredentialMsgTypeV3,
+		Attachments: offer.Attachments,
+	}
+
+	if md.RequestCredentialV3() != nil {
+		response = md.RequestCredentialV3()
+		response.Type = RequestCredentialMsgTypeV3
+	}
+
+	return &requestSent{}, replyV3(md, response), nil
+}
+
 func (s *offerReceived) ExecuteOutbound(_ *MetaData) (state, stateAction, error) {
 	return nil, nil, fmt.Errorf("%s: ExecuteOutbound is not implemented yet", s.Name())
 }
@@ -370,12 +452,7 @@ func (s *requestSent) ExecuteInbound(_ *MetaData) (state, stateAction, error) {
 }
 
 func (s *requestSent) ExecuteOutbound(md *MetaData) (state, stateAction, error) {
-	// creates the state's action
-	action := func(messenger service.Messenger) error {
-		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
-	}
-
-	return &noOp{}, action, nil
+	return &noOp{}, forwardInitial(md), nil
 }
 
 // credentialReceived state.
@@ -390,6 +467,13 @@ func (s *credentialReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *credentialReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md) {
+		return &done{}, replyV3(md, model.AckV2{
+			Type: AckMsgTypeV3,
+			Body: model.AckV2Body{Status: "OK"},
+		}), nil
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
 		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(model.Ack{
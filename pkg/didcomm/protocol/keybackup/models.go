@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// KeyRequest is sent by the key owner to get the public key of the custodian the keys are backed up to.
+type KeyRequest struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// Key is sent by the custodian in reply to a key request, with its backup public key.
+type Key struct {
+	Type      string            `json:"@type,omitempty"`
+	ID        string            `json:"@id,omitempty"`
+	PublicKey *crypto.PublicKey `json:"public_key"`
+	Thread    *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Consent is the consent of the user to the custodian holding the keys backed up. It is bound to the encrypted
+// keys as the additional authenticated data of the JWE.
+type Consent struct {
+	// Statement is the text the user consented to.
+	Statement string `json:"statement"`
+	// Subject identifies the user consenting, like an account or an email address known to the custodian.
+	Subject     string    `json:"subject,omitempty"`
+	GrantedTime time.Time `json:"granted_time"`
+	// ExpiresTime is the time after which the custodian must not recover the keys anymore, no expiry if zero.
+	ExpiresTime time.Time `json:"expires_time,omitempty"`
+}
+
+// Backup is sent by the key owner to the custodian with the keys encrypted to the public key of the custodian.
+type Backup struct {
+	Type     string   `json:"@type,omitempty"`
+	ID       string   `json:"@id,omitempty"`
+	BackupID string   `json:"backup_id"`
+	Consent  *Consent `json:"consent"`
+	// EncryptedKeys is the JSON serialized JWE of the keys backed up.
+	EncryptedKeys string `json:"encrypted_keys"`
+}
+
+// BackupAck is sent by the custodian to acknowledge the backup is stored.
+type BackupAck struct {
+	Type     string            `json:"@type,omitempty"`
+	ID       string            `json:"@id,omitempty"`
+	BackupID string            `json:"backup_id"`
+	Thread   *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// RecoveryRequest is sent to the custodian, typically from a new device of the key owner, to recover the keys of a
+// backup. The keys recovered are encrypted to the recipient key.
+type RecoveryRequest struct {
+	Type         string            `json:"@type,omitempty"`
+	ID           string            `json:"@id,omitempty"`
+	BackupID     string            `json:"backup_id"`
+	RecipientKey *crypto.PublicKey `json:"recipient_key"`
+}
+
+// Recovery is sent by the custodian once the recovery request is accepted, with the keys of the backup encrypted to
+// the recipient key of the request.
+type Recovery struct {
+	Type          string            `json:"@type,omitempty"`
+	ID            string            `json:"@id,omitempty"`
+	BackupID      string            `json:"backup_id"`
+	EncryptedKeys string            `json:"encrypted_keys"`
+	Thread        *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// ProblemReport is sent by the custodian when it declines a recovery request.
+type ProblemReport struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Description Code              `json:"description"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Code of the problem report, with an optional explanation.
+type Code struct {
+	Code string `json:"code"`
+	En   string `json:"en,omitempty"`
+}
+
+// keyArchive is the plaintext of the encrypted keys.
+type keyArchive struct {
+	Keys []*archivedKey `json:"keys"`
+}
+
+type archivedKey struct {
+	ID     string `json:"id"`
+	KeySet []byte `json:"keyset"`
+}
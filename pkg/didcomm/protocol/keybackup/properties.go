@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+const (
+	connectionIDPropKey = "connectionID"
+	threadIDPropKey     = "threadID"
+	backupIDPropKey     = "backupID"
+	keyIDsPropKey       = "keyIDs"
+)
+
+type eventProps struct {
+	connectionID string
+	threadID     string
+	backupID     string
+	keyIDs       []string
+}
+
+// ConnectionID returns the ID of the connection the message was received on.
+func (e *eventProps) ConnectionID() string {
+	return e.connectionID
+}
+
+// ThreadID returns the thread ID of the message.
+func (e *eventProps) ThreadID() string {
+	return e.threadID
+}
+
+// BackupID returns the ID of the backup.
+func (e *eventProps) BackupID() string {
+	return e.backupID
+}
+
+// KeyIDs returns the IDs of the keys recovered.
+func (e *eventProps) KeyIDs() []string {
+	return e.keyIDs
+}
+
+// All implements EventProperties interface.
+func (e eventProps) All() map[string]interface{} {
+	all := map[string]interface{}{}
+	if e.connectionID != "" {
+		all[connectionIDPropKey] = e.connectionID
+	}
+
+	if e.threadID != "" {
+		all[threadIDPropKey] = e.threadID
+	}
+
+	if e.backupID != "" {
+		all[backupIDPropKey] = e.backupID
+	}
+
+	if len(e.keyIDs) > 0 {
+		all[keyIDsPropKey] = e.keyIDs
+	}
+
+	return all
+}
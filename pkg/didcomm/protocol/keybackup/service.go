@@ -0,0 +1,886 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// KeyBackup defines the protocol name.
+	KeyBackup = "keybackup"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/keybackup/1.0/"
+	// KeyRequestMsgType defines the protocol key-request message type.
+	KeyRequestMsgType = Spec + "key-request"
+	// KeyMsgType defines the protocol key message type.
+	KeyMsgType = Spec + "key"
+	// BackupMsgType defines the protocol backup message type.
+	BackupMsgType = Spec + "backup"
+	// BackupAckMsgType defines the protocol backup-ack message type.
+	BackupAckMsgType = Spec + "backup-ack"
+	// RecoveryRequestMsgType defines the protocol recovery-request message type.
+	RecoveryRequestMsgType = Spec + "recovery-request"
+	// RecoveryMsgType defines the protocol recovery message type.
+	RecoveryMsgType = Spec + "recovery"
+	// ProblemReportMsgType defines the protocol problem-report message type.
+	ProblemReportMsgType = Spec + "problem-report"
+
+	// Namespace is namespace of key backup store name.
+	Namespace = "keybackup"
+
+	// CodeRecoveryDeclined is the problem report code of the recovery requests declined by the custodian.
+	CodeRecoveryDeclined = "recovery-declined"
+
+	keyRequestKey      = "keyrequest_"
+	custodianKeyKey    = "custodiankey_"
+	backupKeyKey       = "backupkey_"
+	backupKey          = "backup_"
+	recoveryRequestKey = "recoveryrequest_"
+	recoveryKey        = "recovery_"
+
+	backupKeyType = kms.NISTP256ECDHKWType
+)
+
+// State IDs of the message events triggered for the incoming messages.
+const (
+	// StateKeyRequested is the state of the custodian having sent its backup public key to the key owner.
+	StateKeyRequested = "key-requested"
+	// StateKeyReceived is the state of the key owner receiving the backup public key of the custodian.
+	StateKeyReceived = "key-received"
+	// StateBackupReceived is the state of the custodian storing a backup.
+	StateBackupReceived = "backup-received"
+	// StateBackupStored is the state of the key owner receiving the acknowledgement of its backup.
+	StateBackupStored = "backup-stored"
+	// StateRecoveryRequested is the state of the custodian asked to recover a backup, to be accepted with
+	// AcceptRecovery or declined with DeclineRecovery.
+	StateRecoveryRequested = "recovery-requested"
+	// StateKeysRecovered is the state of the key owner having imported the keys recovered.
+	StateKeysRecovered = "keys-recovered"
+	// StateRecoveryDeclined is the state of the key owner whose recovery request is declined by the custodian.
+	StateRecoveryDeclined = "recovery-declined"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrCustodianKeyNotFound is returned when backing up keys to a connection whose key was not received.
+	ErrCustodianKeyNotFound = errors.New("custodian key not found")
+	// ErrBackupNotFound is returned when the backup does not exist.
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrRecoveryRequestNotFound is returned when the recovery request does not exist or was already handled.
+	ErrRecoveryRequestNotFound = errors.New("recovery request not found")
+	// ErrConsentExpired is returned when recovering a backup after the expiry time of its consent.
+	ErrConsentExpired = errors.New("consent expired")
+	// ErrKeyExportNotSupported is returned when the key manager can't export or import private keys.
+	ErrKeyExportNotSupported = errors.New("key manager does not support private key export")
+
+	logger = log.New("aries-framework/keybackup")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
+}
+
+// keySetExporter is implemented by key managers supporting export of their private keys, like localkms.
+type keySetExporter interface {
+	ExportKeySet(keyID string) ([]byte, error)
+	ImportKeySet(marshalledKeySet []byte, opts ...kms.PrivateKeyOpts) (string, interface{}, error)
+}
+
+// backupRecord is a backup stored by the custodian.
+type backupRecord struct {
+	BackupID      string   `json:"backupID"`
+	ConnectionID  string   `json:"connectionID"`
+	KeyID         string   `json:"keyID"`
+	Consent       *Consent `json:"consent"`
+	EncryptedKeys string   `json:"encryptedKeys"`
+}
+
+// recoveryRequestRecord is a recovery request received by the custodian.
+type recoveryRequestRecord struct {
+	ConnectionID string           `json:"connectionID"`
+	Request      *RecoveryRequest `json:"request"`
+}
+
+// recoveryRecord is a recovery requested by the key owner.
+type recoveryRecord struct {
+	ConnectionID string `json:"connectionID"`
+	BackupID     string `json:"backupID"`
+	RecipientKID string `json:"recipientKID"`
+}
+
+// Service for the key backup protocol. The key owner backs up private keys of its KMS, encrypted to the public key
+// of a custodian with the consent of the user, and recovers them later, possibly on another connection.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	store            storage.Store
+	kms              kms.KeyManager
+	crypto           crypto.Crypto
+}
+
+// New returns the key backup service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open key backup store: %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		outbound:         prov.OutboundDispatcher(),
+		store:            store,
+		connectionLookup: connectionLookup,
+		kms:              prov.KMS(),
+		crypto:           prov.Crypto(),
+	}, nil
+}
+
+// HandleInbound handles inbound key backup messages and triggers message events.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	connectionID, err := s.connectionLookup.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("key backup - get connection: %w", err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("key backup - thread ID: %w", err)
+	}
+
+	props := &eventProps{connectionID: connectionID, threadID: thID}
+
+	var stateID string
+
+	switch msg.Type() {
+	case KeyRequestMsgType:
+		stateID = StateKeyRequested
+		err = s.sendKey(connectionID, msg, ctx)
+	case KeyMsgType:
+		stateID = StateKeyReceived
+		err = s.saveCustodianKey(connectionID, thID, msg)
+	case BackupMsgType:
+		stateID = StateBackupReceived
+		err = s.saveBackup(connectionID, msg, ctx, props)
+	case BackupAckMsgType:
+		stateID = StateBackupStored
+		err = decodeBackupID(msg, props)
+	case RecoveryRequestMsgType:
+		stateID = StateRecoveryRequested
+		err = s.saveRecoveryRequest(connectionID, msg, props)
+	case RecoveryMsgType:
+		stateID = StateKeysRecovered
+		err = s.recoverKeys(connectionID, thID, msg, props)
+	case ProblemReportMsgType:
+		stateID = StateRecoveryDeclined
+		err = s.handleProblemReport(connectionID, thID, props)
+	default:
+		return "", fmt.Errorf("key backup - unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("key backup - handle %s: %w", msg.Type(), err)
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: KeyBackup,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Msg:          msg,
+		Properties:   props,
+	})
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the key backup message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("key backup - send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case KeyRequestMsgType, KeyMsgType, BackupMsgType, BackupAckMsgType, RecoveryRequestMsgType, RecoveryMsgType,
+		ProblemReportMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return KeyBackup
+}
+
+// PIURIs of the key backup protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// RequestKey asks the custodian of the connection for its backup public key, returning the ID of the request
+// message. A key-received event is triggered when the key is received, keys can then be backed up with Backup.
+func (s *Service) RequestKey(connectionID string) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	request := &KeyRequest{Type: KeyRequestMsgType, ID: uuid.New().String()}
+
+	if err = s.put(keyRequestKey+request.ID, connectionID); err != nil {
+		return "", err
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(request), conn.MyDID, conn.TheirDID)
+}
+
+// Backup encrypts the private keys of the KMS with the given IDs to the public key of the custodian of the
+// connection, and sends them with the consent of the user. The consent is bound to the encrypted keys. Returns the
+// ID of the backup, a new backup with the same ID replaces the previous one.
+func (s *Service) Backup(connectionID, backupID string, keyIDs []string, consent *Consent) (string, error) {
+	if len(keyIDs) == 0 {
+		return "", errors.New("no keys to back up")
+	}
+
+	if consent == nil || consent.Statement == "" {
+		return "", errors.New("consent statement is required")
+	}
+
+	if backupID == "" {
+		backupID = uuid.New().String()
+	}
+
+	if consent.GrantedTime.IsZero() {
+		consent.GrantedTime = time.Now().UTC()
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	custodianKey := &crypto.PublicKey{}
+
+	err = s.get(custodianKeyKey+connectionID, custodianKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", ErrCustodianKeyNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	archive, err := s.exportKeys(keyIDs)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedKeys, err := s.encrypt(archive, custodianKey, consent)
+	if err != nil {
+		return "", err
+	}
+
+	backup := &Backup{
+		Type:          BackupMsgType,
+		ID:            uuid.New().String(),
+		BackupID:      backupID,
+		Consent:       consent,
+		EncryptedKeys: encryptedKeys,
+	}
+
+	if _, err = s.HandleOutbound(service.NewDIDCommMsgMap(backup), conn.MyDID, conn.TheirDID); err != nil {
+		return "", err
+	}
+
+	return backupID, nil
+}
+
+// RequestRecovery asks the custodian of the connection to recover the keys of the backup, returning the ID of the
+// request message. The keys are encrypted to a new key of the KMS, and imported with their original IDs when
+// received, triggering a keys-recovered event.
+func (s *Service) RequestRecovery(connectionID, backupID string) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	kid, pubKeyBytes, err := s.kms.CreateAndExportPubKeyBytes(backupKeyType)
+	if err != nil {
+		return "", fmt.Errorf("create recipient key: %w", err)
+	}
+
+	recipientKey, err := publicKey(kid, pubKeyBytes)
+	if err != nil {
+		return "", err
+	}
+
+	request := &RecoveryRequest{
+		Type:         RecoveryRequestMsgType,
+		ID:           uuid.New().String(),
+		BackupID:     backupID,
+		RecipientKey: recipientKey,
+	}
+
+	err = s.put(recoveryKey+request.ID, &recoveryRecord{
+		ConnectionID: connectionID,
+		BackupID:     backupID,
+		RecipientKID: kid,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(request), conn.MyDID, conn.TheirDID)
+}
+
+// RecoveryRequest returns the recovery request received with the given ID, if not handled yet.
+func (s *Service) RecoveryRequest(requestID string) (*RecoveryRequest, error) {
+	record, err := s.getRecoveryRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Request, nil
+}
+
+// AcceptRecovery sends the keys of the backup of the recovery request, re-encrypted to the recipient key of the
+// request. The custodian is expected to have verified the identity of the requester out of band before.
+func (s *Service) AcceptRecovery(requestID string) error {
+	record, err := s.getRecoveryRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	backup := &backupRecord{}
+
+	err = s.get(backupKey+record.Request.BackupID, backup)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return ErrBackupNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !backup.Consent.ExpiresTime.IsZero() && time.Now().After(backup.Consent.ExpiresTime) {
+		return ErrConsentExpired
+	}
+
+	archive, err := s.decrypt(backup.EncryptedKeys, backup.KeyID)
+	if err != nil {
+		return err
+	}
+
+	encryptedKeys, err := s.encrypt(archive, record.Request.RecipientKey, backup.Consent)
+	if err != nil {
+		return err
+	}
+
+	recovery := &Recovery{
+		Type:          RecoveryMsgType,
+		ID:            uuid.New().String(),
+		BackupID:      backup.BackupID,
+		EncryptedKeys: encryptedKeys,
+		Thread:        &decorator.Thread{ID: requestID},
+	}
+
+	return s.reply(record.ConnectionID, requestID, service.NewDIDCommMsgMap(recovery))
+}
+
+// DeclineRecovery declines the recovery request, sending a problem report with the reason to the requester.
+func (s *Service) DeclineRecovery(requestID, reason string) error {
+	record, err := s.getRecoveryRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	report := &ProblemReport{
+		Type:        ProblemReportMsgType,
+		ID:          uuid.New().String(),
+		Description: Code{Code: CodeRecoveryDeclined, En: reason},
+		Thread:      &decorator.Thread{ID: requestID},
+	}
+
+	return s.reply(record.ConnectionID, requestID, service.NewDIDCommMsgMap(report))
+}
+
+func (s *Service) reply(connectionID, requestID string, msg service.DIDCommMsgMap) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.HandleOutbound(msg, conn.MyDID, conn.TheirDID); err != nil {
+		return err
+	}
+
+	if err = s.store.Delete(recoveryRequestKey + requestID); err != nil {
+		return fmt.Errorf("delete recovery request: %w", err)
+	}
+
+	return nil
+}
+
+// sendKey replies to the key request with the backup public key of the custodian for the connection, created on
+// the first request.
+func (s *Service) sendKey(connectionID string, msg service.DIDCommMsg, ctx service.DIDCommContext) error {
+	var kid string
+
+	err := s.get(backupKeyKey+connectionID, &kid)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	var pubKeyBytes []byte
+
+	if kid == "" {
+		kid, pubKeyBytes, err = s.kms.CreateAndExportPubKeyBytes(backupKeyType)
+		if err != nil {
+			return fmt.Errorf("create backup key: %w", err)
+		}
+
+		if err = s.put(backupKeyKey+connectionID, kid); err != nil {
+			return err
+		}
+	} else {
+		pubKeyBytes, err = s.kms.ExportPubKeyBytes(kid)
+		if err != nil {
+			return fmt.Errorf("export backup key: %w", err)
+		}
+	}
+
+	pubKey, err := publicKey(kid, pubKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	key := &Key{
+		Type:      KeyMsgType,
+		ID:        uuid.New().String(),
+		PublicKey: pubKey,
+		Thread:    &decorator.Thread{ID: msg.ID()},
+	}
+
+	_, err = s.HandleOutbound(service.NewDIDCommMsgMap(key), ctx.MyDID(), ctx.TheirDID())
+
+	return err
+}
+
+func (s *Service) saveCustodianKey(connectionID, thID string, msg service.DIDCommMsg) error {
+	var requestConnectionID string
+
+	err := s.get(keyRequestKey+thID, &requestConnectionID)
+	if err != nil {
+		return fmt.Errorf("get key request: %w", err)
+	}
+
+	if requestConnectionID != connectionID {
+		return errors.New("key request not sent on the connection")
+	}
+
+	key := &Key{}
+
+	if err = msg.Decode(key); err != nil {
+		return err
+	}
+
+	if key.PublicKey == nil || key.PublicKey.KID == "" {
+		return errors.New("missing custodian public key")
+	}
+
+	if err = s.put(custodianKeyKey+connectionID, key.PublicKey); err != nil {
+		return err
+	}
+
+	if err = s.store.Delete(keyRequestKey + thID); err != nil {
+		return fmt.Errorf("delete key request: %w", err)
+	}
+
+	return nil
+}
+
+// saveBackup checks the backup is encrypted to the backup key of the connection and bound to the consent, saves it
+// and acknowledges it.
+func (s *Service) saveBackup(connectionID string, msg service.DIDCommMsg, ctx service.DIDCommContext,
+	props *eventProps) error {
+	backup := &Backup{}
+
+	err := msg.Decode(backup)
+	if err != nil {
+		return err
+	}
+
+	if backup.BackupID == "" || backup.Consent == nil || backup.Consent.Statement == "" {
+		return errors.New("backup ID and consent statement are required")
+	}
+
+	var kid string
+
+	if err = s.get(backupKeyKey+connectionID, &kid); err != nil {
+		return fmt.Errorf("get backup key: %w", err)
+	}
+
+	existing := &backupRecord{}
+
+	err = s.get(backupKey+backup.BackupID, existing)
+	if err == nil && existing.ConnectionID != connectionID {
+		return fmt.Errorf("backup %s exists on another connection", backup.BackupID)
+	}
+
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	if err = s.checkEncryptedKeys(backup.EncryptedKeys, kid, backup.Consent); err != nil {
+		return err
+	}
+
+	err = s.put(backupKey+backup.BackupID, &backupRecord{
+		BackupID:      backup.BackupID,
+		ConnectionID:  connectionID,
+		KeyID:         kid,
+		Consent:       backup.Consent,
+		EncryptedKeys: backup.EncryptedKeys,
+	})
+	if err != nil {
+		return err
+	}
+
+	props.backupID = backup.BackupID
+
+	ack := &BackupAck{
+		Type:     BackupAckMsgType,
+		ID:       uuid.New().String(),
+		BackupID: backup.BackupID,
+		Thread:   &decorator.Thread{ID: msg.ID()},
+	}
+
+	_, err = s.HandleOutbound(service.NewDIDCommMsgMap(ack), ctx.MyDID(), ctx.TheirDID())
+
+	return err
+}
+
+func (s *Service) checkEncryptedKeys(encryptedKeys, kid string, consent *Consent) error {
+	jwe, err := jose.Deserialize(encryptedKeys)
+	if err != nil {
+		return fmt.Errorf("deserialize encrypted keys: %w", err)
+	}
+
+	if recipientKID, _ := jwe.ProtectedHeaders.KeyID(); len(jwe.Recipients) != 1 || recipientKID != kid {
+		return errors.New("keys are not encrypted to the backup key of the connection")
+	}
+
+	aad, err := json.Marshal(consent)
+	if err != nil {
+		return fmt.Errorf("marshal consent: %w", err)
+	}
+
+	if !bytes.Equal([]byte(jwe.AAD), aad) {
+		return errors.New("consent does not match the consent of the encrypted keys")
+	}
+
+	_, err = s.decrypt(encryptedKeys, kid)
+
+	return err
+}
+
+func decodeBackupID(msg service.DIDCommMsg, props *eventProps) error {
+	ack := &BackupAck{}
+
+	if err := msg.Decode(ack); err != nil {
+		return err
+	}
+
+	props.backupID = ack.BackupID
+
+	return nil
+}
+
+func (s *Service) saveRecoveryRequest(connectionID string, msg service.DIDCommMsg, props *eventProps) error {
+	request := &RecoveryRequest{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return err
+	}
+
+	if request.RecipientKey == nil || request.RecipientKey.KID == "" {
+		return errors.New("missing recipient key")
+	}
+
+	_, err = s.store.Get(backupKey + request.BackupID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return ErrBackupNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("get backup: %w", err)
+	}
+
+	props.backupID = request.BackupID
+
+	return s.put(recoveryRequestKey+request.ID, &recoveryRequestRecord{ConnectionID: connectionID, Request: request})
+}
+
+// recoverKeys decrypts the keys recovered with the recipient key of the recovery request and imports them.
+func (s *Service) recoverKeys(connectionID, thID string, msg service.DIDCommMsg, props *eventProps) error {
+	recovery := &Recovery{}
+
+	err := msg.Decode(recovery)
+	if err != nil {
+		return err
+	}
+
+	record, err := s.getRecovery(connectionID, thID)
+	if err != nil {
+		return err
+	}
+
+	archive, err := s.decrypt(recovery.EncryptedKeys, record.RecipientKID)
+	if err != nil {
+		return err
+	}
+
+	importer, ok := s.kms.(keySetExporter)
+	if !ok {
+		return ErrKeyExportNotSupported
+	}
+
+	for _, key := range archive.Keys {
+		if _, _, err = importer.ImportKeySet(key.KeySet, kms.WithKeyID(key.ID)); err != nil {
+			return fmt.Errorf("import key %s: %w", key.ID, err)
+		}
+
+		props.keyIDs = append(props.keyIDs, key.ID)
+	}
+
+	props.backupID = record.BackupID
+
+	if err = s.store.Delete(recoveryKey + thID); err != nil {
+		return fmt.Errorf("delete recovery: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleProblemReport(connectionID, thID string, props *eventProps) error {
+	record, err := s.getRecovery(connectionID, thID)
+	if err != nil {
+		return err
+	}
+
+	props.backupID = record.BackupID
+
+	if err = s.store.Delete(recoveryKey + thID); err != nil {
+		return fmt.Errorf("delete recovery: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) exportKeys(keyIDs []string) (*keyArchive, error) {
+	exporter, ok := s.kms.(keySetExporter)
+	if !ok {
+		return nil, ErrKeyExportNotSupported
+	}
+
+	archive := &keyArchive{}
+
+	for _, kid := range keyIDs {
+		ks, err := exporter.ExportKeySet(kid)
+		if err != nil {
+			return nil, fmt.Errorf("export key %s: %w", kid, err)
+		}
+
+		archive.Keys = append(archive.Keys, &archivedKey{ID: kid, KeySet: ks})
+	}
+
+	return archive, nil
+}
+
+// encrypt encrypts the key archive to the public key, with the consent as additional authenticated data.
+func (s *Service) encrypt(archive *keyArchive, pubKey *crypto.PublicKey, consent *Consent) (string, error) {
+	plaintext, err := json.Marshal(archive)
+	if err != nil {
+		return "", fmt.Errorf("marshal keys: %w", err)
+	}
+
+	aad, err := json.Marshal(consent)
+	if err != nil {
+		return "", fmt.Errorf("marshal consent: %w", err)
+	}
+
+	encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, packer.EnvelopeEncodingTypeV2, "", "", nil,
+		[]*crypto.PublicKey{pubKey}, s.crypto)
+	if err != nil {
+		return "", fmt.Errorf("create JWE encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.EncryptWithAuthData(plaintext, aad)
+	if err != nil {
+		return "", fmt.Errorf("encrypt keys: %w", err)
+	}
+
+	return jwe.FullSerialize(json.Marshal)
+}
+
+func (s *Service) decrypt(encryptedKeys, kid string) (*keyArchive, error) {
+	jwe, err := jose.Deserialize(encryptedKeys)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize encrypted keys: %w", err)
+	}
+
+	if recipientKID, _ := jwe.ProtectedHeaders.KeyID(); recipientKID != kid {
+		return nil, fmt.Errorf("keys are not encrypted to key %s", kid)
+	}
+
+	plaintext, err := jose.NewJWEDecrypt(nil, s.crypto, s.kms).Decrypt(jwe)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keys: %w", err)
+	}
+
+	archive := &keyArchive{}
+
+	if err = json.Unmarshal(plaintext, archive); err != nil {
+		return nil, fmt.Errorf("unmarshal keys: %w", err)
+	}
+
+	return archive, nil
+}
+
+func publicKey(kid string, pubKeyBytes []byte) (*crypto.PublicKey, error) {
+	pubKey := &crypto.PublicKey{}
+
+	if err := json.Unmarshal(pubKeyBytes, pubKey); err != nil {
+		return nil, fmt.Errorf("unmarshal public key: %w", err)
+	}
+
+	pubKey.KID = kid
+
+	return pubKey, nil
+}
+
+func (s *Service) getRecoveryRequest(requestID string) (*recoveryRequestRecord, error) {
+	record := &recoveryRequestRecord{}
+
+	err := s.get(recoveryRequestKey+requestID, record)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrRecoveryRequestNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *Service) getRecovery(connectionID, requestID string) (*recoveryRecord, error) {
+	record := &recoveryRecord{}
+
+	err := s.get(recoveryKey+requestID, record)
+	if errors.Is(err, storage.ErrDataNotFound) || err == nil && record.ConnectionID != connectionID {
+		return nil, ErrRecoveryRequestNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *Service) put(key string, v interface{}) error {
+	recordBytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", key, err)
+	}
+
+	if err = s.store.Put(key, recordBytes); err != nil {
+		return fmt.Errorf("save %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Service) get(key string, v interface{}) error {
+	recordBytes, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("get %s: %w", key, err)
+	}
+
+	if err = json.Unmarshal(recordBytes, v); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) triggerEvent(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+
+	logger.Debugf("key backup - %s on connection %s", msg.StateID, msg.Properties.All()[connectionIDPropKey])
+}
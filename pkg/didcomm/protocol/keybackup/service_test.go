@@ -0,0 +1,389 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keybackup
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	ownerDID     = "did:example:owner"
+	deviceDID    = "did:example:device"
+	custodianDID = "did:example:custodian"
+	ownerConnID  = "conn-1"
+	deviceConnID = "conn-2"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		a := newAgents(t)
+		require.Equal(t, KeyBackup, a.owner.Name())
+		require.True(t, a.owner.Accept(BackupMsgType))
+		require.True(t, a.owner.Accept(ProblemReportMsgType))
+		require.False(t, a.owner.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open key backup store")
+	})
+}
+
+func TestService_BackupAndRecovery(t *testing.T) {
+	t.Run("keys recovered on a new device", func(t *testing.T) {
+		a := newAgents(t)
+		keyIDs := a.backUp(t, &Consent{Statement: "I allow the custodian to hold my keys", Subject: "alice"})
+
+		requestID, err := a.device.RequestRecovery(deviceConnID, "backup-1")
+		require.NoError(t, err)
+
+		state := a.deliver(t, a.custodian, custodianDID, deviceDID)
+		require.Equal(t, StateRecoveryRequested, state.StateID)
+		require.Equal(t, map[string]interface{}{
+			connectionIDPropKey: deviceConnID,
+			threadIDPropKey:     requestID,
+			backupIDPropKey:     "backup-1",
+		}, state.Properties.All())
+
+		request, err := a.custodian.RecoveryRequest(requestID)
+		require.NoError(t, err)
+		require.Equal(t, "backup-1", request.BackupID)
+
+		require.NoError(t, a.custodian.AcceptRecovery(requestID))
+
+		state = a.deliver(t, a.device, deviceDID, custodianDID)
+		require.Equal(t, StateKeysRecovered, state.StateID)
+		require.Equal(t, keyIDs, state.Properties.All()[keyIDsPropKey])
+
+		for _, kid := range keyIDs {
+			ownerKey, err := a.ownerKMS.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+
+			deviceKey, err := a.deviceKMS.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+			require.Equal(t, ownerKey, deviceKey)
+		}
+
+		err = a.custodian.AcceptRecovery(requestID)
+		require.True(t, errors.Is(err, ErrRecoveryRequestNotFound))
+	})
+
+	t.Run("recovery declined", func(t *testing.T) {
+		a := newAgents(t)
+		a.backUp(t, &Consent{Statement: "I allow the custodian to hold my keys"})
+
+		requestID, err := a.device.RequestRecovery(deviceConnID, "backup-1")
+		require.NoError(t, err)
+
+		a.deliver(t, a.custodian, custodianDID, deviceDID)
+
+		require.NoError(t, a.custodian.DeclineRecovery(requestID, "identity not verified"))
+
+		report := &ProblemReport{}
+		require.NoError(t, a.sent.Decode(report))
+		require.Equal(t, CodeRecoveryDeclined, report.Description.Code)
+
+		state := a.deliver(t, a.device, deviceDID, custodianDID)
+		require.Equal(t, StateRecoveryDeclined, state.StateID)
+		require.Equal(t, "backup-1", state.Properties.All()[backupIDPropKey])
+
+		_, err = a.custodian.RecoveryRequest(requestID)
+		require.True(t, errors.Is(err, ErrRecoveryRequestNotFound))
+	})
+
+	t.Run("consent expired", func(t *testing.T) {
+		a := newAgents(t)
+		a.backUp(t, &Consent{
+			Statement:   "I allow the custodian to hold my keys for a day",
+			GrantedTime: time.Now().Add(-48 * time.Hour),
+			ExpiresTime: time.Now().Add(-24 * time.Hour),
+		})
+
+		requestID, err := a.device.RequestRecovery(deviceConnID, "backup-1")
+		require.NoError(t, err)
+
+		a.deliver(t, a.custodian, custodianDID, deviceDID)
+
+		err = a.custodian.AcceptRecovery(requestID)
+		require.True(t, errors.Is(err, ErrConsentExpired))
+	})
+}
+
+func TestService_Errors(t *testing.T) {
+	consent := &Consent{Statement: "I allow the custodian to hold my keys"}
+
+	t.Run("backup", func(t *testing.T) {
+		a := newAgents(t)
+
+		_, err := a.owner.Backup(ownerConnID, "", []string{"kid"}, consent)
+		require.True(t, errors.Is(err, ErrCustodianKeyNotFound))
+
+		_, err = a.owner.Backup(ownerConnID, "", nil, consent)
+		require.EqualError(t, err, "no keys to back up")
+
+		_, err = a.owner.Backup(ownerConnID, "", []string{"kid"}, &Consent{})
+		require.EqualError(t, err, "consent statement is required")
+
+		_, err = a.owner.Backup("unknown", "", []string{"kid"}, consent)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		a.requestKey(t)
+
+		_, err = a.owner.Backup(ownerConnID, "", []string{"unknown"}, consent)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "export key unknown")
+	})
+
+	t.Run("consent not bound to the keys", func(t *testing.T) {
+		a := newAgents(t)
+		a.requestKey(t)
+
+		kid, _, err := a.ownerKMS.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = a.owner.Backup(ownerConnID, "backup-1", []string{kid}, consent)
+		require.NoError(t, err)
+
+		a.sent["consent"] = map[string]interface{}{
+			"statement":    "I allow the custodian to hold my keys forever",
+			"granted_time": time.Now().Format(time.RFC3339),
+		}
+
+		_, err = a.custodian.HandleInbound(a.sent, service.NewDIDCommContext(custodianDID, ownerDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "consent does not match the consent of the encrypted keys")
+	})
+
+	t.Run("backup ID of another connection", func(t *testing.T) {
+		a := newAgents(t)
+		a.backUp(t, consent)
+
+		_, err := a.device.RequestKey(deviceConnID)
+		require.NoError(t, err)
+
+		a.deliver(t, a.custodian, custodianDID, deviceDID)
+		a.deliver(t, a.device, deviceDID, custodianDID)
+
+		kid, _, err := a.deviceKMS.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = a.device.Backup(deviceConnID, "backup-1", []string{kid}, consent)
+		require.NoError(t, err)
+
+		_, err = a.custodian.HandleInbound(a.sent, service.NewDIDCommContext(custodianDID, deviceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "backup backup-1 exists on another connection")
+	})
+
+	t.Run("unknown backup", func(t *testing.T) {
+		a := newAgents(t)
+
+		_, err := a.device.RequestRecovery(deviceConnID, "unknown")
+		require.NoError(t, err)
+
+		_, err = a.custodian.HandleInbound(a.sent, service.NewDIDCommContext(custodianDID, deviceDID, nil))
+		require.True(t, errors.Is(err, ErrBackupNotFound))
+	})
+
+	t.Run("unsolicited key", func(t *testing.T) {
+		a := newAgents(t)
+
+		_, err := a.owner.RequestKey(ownerConnID)
+		require.NoError(t, err)
+
+		a.deliver(t, a.custodian, custodianDID, ownerDID)
+
+		_, err = a.device.HandleInbound(a.sent, service.NewDIDCommContext(deviceDID, custodianDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get key request")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		a := newAgents(t)
+
+		_, err := a.owner.HandleInbound(service.NewDIDCommMsgMap(&KeyRequest{Type: Spec + "unknown", ID: "1"}),
+			service.NewDIDCommContext(ownerDID, custodianDID, nil))
+		require.EqualError(t, err, "key backup - unsupported message type "+Spec+"unknown")
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		a := newAgents(t)
+
+		_, err := a.owner.HandleInbound(service.NewDIDCommMsgMap(&KeyRequest{Type: KeyRequestMsgType, ID: "1"}),
+			service.NewDIDCommContext(ownerDID, "did:example:unknown", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key backup - get connection")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		a := newAgents(t)
+		a.sendErr = errors.New("send error")
+
+		_, err := a.owner.RequestKey(ownerConnID)
+		require.EqualError(t, err, "key backup - send "+KeyRequestMsgType+": send error")
+	})
+}
+
+type agents struct {
+	owner, device, custodian *Service
+	ownerKMS, deviceKMS      kms.KeyManager
+	sent                     service.DIDCommMsgMap
+	sendErr                  error
+}
+
+// requestKey gets the key of the custodian for the owner.
+func (a *agents) requestKey(t *testing.T) {
+	t.Helper()
+
+	_, err := a.owner.RequestKey(ownerConnID)
+	require.NoError(t, err)
+
+	state := a.deliver(t, a.custodian, custodianDID, ownerDID)
+	require.Equal(t, StateKeyRequested, state.StateID)
+
+	state = a.deliver(t, a.owner, ownerDID, custodianDID)
+	require.Equal(t, StateKeyReceived, state.StateID)
+}
+
+// backUp backs up two keys of the owner to the custodian as backup-1, returning the key IDs.
+func (a *agents) backUp(t *testing.T, consent *Consent) []string {
+	t.Helper()
+
+	a.requestKey(t)
+
+	var keyIDs []string
+
+	for _, kt := range []kms.KeyType{kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363} {
+		kid, _, err := a.ownerKMS.Create(kt)
+		require.NoError(t, err)
+
+		keyIDs = append(keyIDs, kid)
+	}
+
+	backupID, err := a.owner.Backup(ownerConnID, "backup-1", keyIDs, consent)
+	require.NoError(t, err)
+	require.Equal(t, "backup-1", backupID)
+
+	state := a.deliver(t, a.custodian, custodianDID, ownerDID)
+	require.Equal(t, StateBackupReceived, state.StateID)
+	require.Equal(t, "backup-1", state.Properties.All()[backupIDPropKey])
+
+	state = a.deliver(t, a.owner, ownerDID, custodianDID)
+	require.Equal(t, StateBackupStored, state.StateID)
+	require.Equal(t, "backup-1", state.Properties.All()[backupIDPropKey])
+
+	return keyIDs
+}
+
+// deliver hands the last message sent to the service of the receiver, returning the message event triggered.
+func (a *agents) deliver(t *testing.T, receiver *Service, myDID, theirDID string) service.StateMsg {
+	t.Helper()
+
+	states := make(chan service.StateMsg, 1)
+	require.NoError(t, receiver.RegisterMsgEvent(states))
+
+	defer func() {
+		require.NoError(t, receiver.UnregisterMsgEvent(states))
+	}()
+
+	_, err := receiver.HandleInbound(a.sent, service.NewDIDCommContext(myDID, theirDID, nil))
+	require.NoError(t, err)
+
+	return <-states
+}
+
+func newAgents(t *testing.T) *agents {
+	t.Helper()
+
+	a := &agents{ownerKMS: newKMS(t), deviceKMS: newKMS(t)}
+
+	outbound := &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			if a.sendErr != nil {
+				return a.sendErr
+			}
+
+			a.sent = msg.(service.DIDCommMsgMap)
+
+			return nil
+		},
+	}
+
+	a.owner = newService(t, outbound, a.ownerKMS, &connection.Record{
+		ConnectionID: ownerConnID, MyDID: ownerDID, TheirDID: custodianDID,
+	})
+	a.device = newService(t, outbound, a.deviceKMS, &connection.Record{
+		ConnectionID: deviceConnID, MyDID: deviceDID, TheirDID: custodianDID,
+	})
+	a.custodian = newService(t, outbound, newKMS(t),
+		&connection.Record{ConnectionID: ownerConnID, MyDID: custodianDID, TheirDID: ownerDID},
+		&connection.Record{ConnectionID: deviceConnID, MyDID: custodianDID, TheirDID: deviceDID},
+	)
+
+	return a
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound, km kms.KeyManager,
+	conns ...*connection.Record) *Service {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+		KMSValue:                          km,
+		CryptoValue:                       c,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	for _, conn := range conns {
+		conn.State = connection.StateNameCompleted
+		require.NoError(t, recorder.SaveConnectionRecord(conn))
+	}
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return km
+}
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mediator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// data key to store the router connection IDs of a mediation chain.
+	routeChainDataKey = "route_chain_%s"
+
+	// a chain of a single router is the router itself.
+	minChainLength = 2
+)
+
+// ErrChainNotFound mediation chain not found error.
+var ErrChainNotFound = errors.New("mediation chain not found")
+
+// ComposeChain composes a mediation chain of the registered routers, the first router connection being the outermost
+// mediator (the one the messages are sent to) and the last one the mediator closest to the agent. The messages sent to
+// the agent are forwarded through every router of the chain, each inner router being expected to have registered its
+// routing keys with the router in front of it.
+//
+// The chain ID returned is usable wherever a router connection ID is accepted: its config has the endpoint of the
+// outermost router and the routing keys of all the routers, and the keys added to the chain are added to the innermost
+// router.
+func (s *Service) ComposeChain(connIDs []string) (string, error) {
+	if len(connIDs) < minChainLength {
+		return "", errors.New("a mediation chain needs at least two router connections")
+	}
+
+	seen := make(map[string]struct{}, len(connIDs))
+
+	for _, connID := range connIDs {
+		if _, ok := seen[connID]; ok {
+			return "", fmt.Errorf("router connection [%s] is used more than once in the chain", connID)
+		}
+
+		seen[connID] = struct{}{}
+
+		if err := s.ensureConnectionExists(connID); err != nil {
+			return "", fmt.Errorf("ensure connection [%s] exists: %w", connID, err)
+		}
+	}
+
+	bytes, err := json.Marshal(connIDs)
+	if err != nil {
+		return "", fmt.Errorf("marshal mediation chain: %w", err)
+	}
+
+	chainID := uuid.New().String()
+
+	if err = s.routeStore.Put(fmt.Sprintf(routeChainDataKey, chainID), bytes); err != nil {
+		return "", fmt.Errorf("save mediation chain: %w", err)
+	}
+
+	return chainID, nil
+}
+
+// Chain returns the router connection IDs of the mediation chain, outermost router first.
+func (s *Service) Chain(chainID string) ([]string, error) {
+	val, err := s.routeStore.Get(fmt.Sprintf(routeChainDataKey, chainID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrChainNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get mediation chain: %w", err)
+	}
+
+	var connIDs []string
+
+	if err = json.Unmarshal(val, &connIDs); err != nil {
+		return nil, fmt.Errorf("unmarshal mediation chain: %w", err)
+	}
+
+	return connIDs, nil
+}
+
+// RemoveChain removes the mediation chain, the routers of the chain stay registered.
+func (s *Service) RemoveChain(chainID string) error {
+	if _, err := s.Chain(chainID); err != nil {
+		return err
+	}
+
+	return s.routeStore.Delete(fmt.Sprintf(routeChainDataKey, chainID))
+}
+
+// lookupChain returns the router connections of the mediation chain when the ID, not being a registered router
+// connection (routerErr), is a chain ID.
+func (s *Service) lookupChain(chainID string, routerErr error) ([]string, bool) {
+	if !errors.Is(routerErr, ErrRouterNotRegistered) {
+		return nil, false
+	}
+
+	chain, err := s.Chain(chainID)
+	if err != nil {
+		return nil, false
+	}
+
+	return chain, true
+}
+
+// chainConfig returns the config of the mediation chain: the endpoint of the outermost router and the routing keys of
+// the routers, outermost first.
+func (s *Service) chainConfig(connIDs []string) (*Config, error) {
+	var (
+		endpoint string
+		keys     []string
+	)
+
+	for i, connID := range connIDs {
+		if err := s.ensureConnectionExists(connID); err != nil {
+			return nil, fmt.Errorf("ensure connection [%s] exists: %w", connID, err)
+		}
+
+		conf, err := s.getRouterConfig(connID)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			endpoint = conf.Endpoint()
+		}
+
+		keys = append(keys, conf.Keys()...)
+	}
+
+	return NewConfig(endpoint, keys), nil
+}
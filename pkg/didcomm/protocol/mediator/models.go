@@ -55,3 +55,39 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// KeylistQuery route keylist query message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#keylist-query
+type KeylistQuery struct {
+	Type     string    `json:"@type,omitempty"`
+	ID       string    `json:"@id,omitempty"`
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate keylist query pagination, the router returns a page of defaultKeylistLimit keys when the limit is not set.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// Keylist route keylist message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#keylist
+type Keylist struct {
+	Type       string       `json:"@type,omitempty"`
+	ID         string       `json:"@id,omitempty"`
+	Keys       []KeylistKey `json:"keys"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+}
+
+// KeylistKey route keylist key.
+type KeylistKey struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// Pagination keylist pagination, Offset is the offset of the next page and Remaining the number of keys after
+// this page.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
@@ -7,15 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
 
+	commonlock "github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -29,6 +33,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -53,6 +58,12 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// KeylistQueryMsgType defines the route coordination key list query message type.
+	KeylistQueryMsgType = CoordinationSpec + "keylist_query"
+
+	// KeylistMsgType defines the route coordination key list message type.
+	KeylistMsgType = CoordinationSpec + "keylist"
 )
 
 // constants for key list update processing
@@ -79,10 +90,16 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	// tag of the route keys, valued with the hash of the DID the keys are routed to.
+	routeKeyTagName = "route_key"
 )
 
 const (
 	updateTimeout = 10 * time.Second
+
+	// number of keys of a keylist page when the keylist query doesn't set the limit.
+	defaultKeylistLimit = 100
 )
 
 // ErrConnectionNotFound connection not found error.
@@ -102,6 +119,7 @@ type provider interface {
 	Service(id string) (interface{}, error)
 	KeyAgreementType() kms.KeyType
 	MediaTypeProfiles() []string
+	LockService() lock.Service
 }
 
 // ClientOption configures the route client.
@@ -112,6 +130,24 @@ type ClientOptions struct {
 	Timeout time.Duration
 }
 
+// ServiceOption configures the route coordination service.
+type ServiceOption func(s *Service)
+
+// WithForwardRelay enables relaying of forward messages carrying a sender chosen next hop (onion routing) to the
+// given next hop service endpoints only, so the agent can't be used as an open relay. Without this option such
+// forward messages are rejected.
+func WithForwardRelay(nextHops ...string) ServiceOption {
+	return func(s *Service) {
+		if s.relayNextHops == nil {
+			s.relayNextHops = make(map[string]struct{})
+		}
+
+		for _, nextHop := range nextHops {
+			s.relayNextHops[nextHop] = struct{}{}
+		}
+	}
+}
+
 // Options is a container for route protocol options.
 type Options struct {
 	ServiceEndpoint string
@@ -144,21 +180,25 @@ type Service struct {
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock sync.RWMutex
+	keylistMap           map[string]chan *Keylist
+	keylistMapLock       sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
 	keyAgreementType     kms.KeyType
 	mediaTypeProfiles    []string
+	relayNextHops        map[string]struct{}
+	lockService          lock.Service
 }
 
 // New return route coordination service.
-func New(prov provider) (*Service, error) {
+func New(prov provider, opts ...ServiceOption) (*Service, error) {
 	store, err := prov.StorageProvider().OpenStore(Coordination)
 	if err != nil {
 		return nil, fmt.Errorf("open route coordination store : %w", err)
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeKeyTagName}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -186,10 +226,16 @@ func New(prov provider) (*Service, error) {
 		vdRegistry:        prov.VDRegistry(),
 		connectionLookup:  connectionLookup,
 		keylistUpdateMap:  make(map[string]chan *KeylistUpdateResponse),
+		keylistMap:        make(map[string]chan *Keylist),
 		callbacks:         make(chan *callback),
 		messagePickupSvc:  messagePickupSvc,
 		keyAgreementType:  prov.KeyAgreementType(),
 		mediaTypeProfiles: prov.MediaTypeProfiles(),
+		lockService:       prov.LockService(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	logger.Debugf("default endpoint: %s", s.endpoint)
@@ -211,7 +257,10 @@ func (s *Service) listenForCallbacks() {
 
 		switch c.msg.Type() {
 		case RequestMsgType:
-			err := s.handleInboundRequest(c)
+			// the grants of a DID are serialized across the agent instances
+			err := commonlock.Do(s.lockService, routeLockName(c.theirDID), func() error {
+				return s.handleInboundRequest(c)
+			})
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
 			}
@@ -286,17 +335,25 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		case GrantMsgType:
 			err = s.saveGrant(msg)
 		case KeylistUpdateMsgType:
-			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
+			err = commonlock.Do(s.lockService, routeLockName(ctx.TheirDID()), func() error {
+				return s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
+			})
 		case KeylistUpdateResponseMsgType:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistQueryMsgType:
+			err = s.handleKeylistQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistMsgType:
+			err = s.handleKeylist(msg)
 		case service.ForwardMsgType:
 			err = s.handleForward(msg)
+		case service.ForwardV2MsgType:
+			err = s.handleForwardV2(msg)
 		}
 
 		connectionIDLog := ""
 
 		// mediator forward messages don't have connection established with the sender; hence skip the lookup
-		if msg.Type() != service.ForwardMsgType {
+		if msg.Type() != service.ForwardMsgType && msg.Type() != service.ForwardV2MsgType {
 			connectionID, connErr := s.connectionLookup.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
 			if connErr != nil {
 				logutil.LogError(logger, Coordination, "connectionID lookup using DIDs", connErr.Error())
@@ -340,7 +397,8 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType:
+	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, KeylistQueryMsgType,
+		KeylistMsgType, service.ForwardMsgType, service.ForwardV2MsgType:
 		return true
 	}
 
@@ -352,6 +410,11 @@ func (s *Service) Name() string {
 	return Coordination
 }
 
+// PIURIs of the coordinate mediation protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(CoordinationSpec, "/")}
+}
+
 func (s *Service) handleInboundRequest(c *callback) error {
 	logger.Debugf("handling callback: %+v", c)
 	logger.Debugf("options: %+v", c.options)
@@ -447,7 +510,7 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 
 			toKey := dataKey(v.RecipientKey)
 
-			err = s.routeStore.Put(toKey, []byte(val))
+			err = s.routeStore.Put(toKey, []byte(val), storage.Tag{Name: routeKeyTagName, Value: routeKeyTagValue(val)})
 			if err != nil {
 				logger.Errorf("failed to add the route key to store : %s", err)
 
@@ -502,6 +565,117 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleKeylistQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	query := &KeylistQuery{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("route keylist query message unmarshal : %w", err)
+	}
+
+	keys, err := s.routedKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("route keylist query : %w", err)
+	}
+
+	limit, offset := defaultKeylistLimit, 0
+
+	if query.Paginate != nil {
+		if query.Paginate.Limit > 0 {
+			limit = query.Paginate.Limit
+		}
+
+		if query.Paginate.Offset > 0 {
+			offset = query.Paginate.Offset
+		}
+	}
+
+	if offset > len(keys) {
+		offset = len(keys)
+	}
+
+	end := offset + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	keylist := &Keylist{
+		Type: KeylistMsgType,
+		ID:   msg.ID(),
+		Keys: make([]KeylistKey, 0, end-offset),
+		Pagination: &Pagination{
+			Count:     end - offset,
+			Offset:    end,
+			Remaining: len(keys) - end,
+		},
+	}
+
+	for _, key := range keys[offset:end] {
+		keylist.Keys = append(keylist.Keys, KeylistKey{RecipientKey: key})
+	}
+
+	return s.outbound.SendToDID(keylist, myDID, theirDID)
+}
+
+// routedKeys returns the recipient keys routed to the DID, sorted so that the keylist pages are consistent.
+func (s *Service) routedKeys(theirDID string) ([]string, error) {
+	records, err := s.routeStore.Query(fmt.Sprintf("%s:%s", routeKeyTagName, routeKeyTagValue(theirDID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var keys []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *Service) handleKeylist(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	keylist := &Keylist{}
+
+	err := msg.Decode(keylist)
+	if err != nil {
+		return fmt.Errorf("route keylist message unmarshal : %w", err)
+	}
+
+	// check if there are any channels registered for the message ID
+	keylistCh := s.getKeylistCh(keylist.ID)
+
+	if keylistCh != nil {
+		// invoke the channel for the incoming message, unless a keylist was already received for the query
+		select {
+		case keylistCh <- keylist:
+		default:
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	forward := &model.Forward{}
@@ -511,12 +685,50 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("forward message unmarshal : %w", err)
 	}
 
+	if forward.Next != nil {
+		return s.relayForward(forward)
+	}
+
 	// TODO Open question - https://github.com/hyperledger/aries-framework-go/issues/965 Mismatch between Route
 	//  Coordination and Forward RFC. For now assume, the TO field contains the recipient key (DIDComm V2 uses
 	//  keyAgreement.ID, double check if this to do comment is still needed).
-	toKey := dataKey(forward.To)
+	return s.deliverForward(forward.To, forward.Msg, forward.Msg)
+}
+
+// handleForwardV2 handles the DIDComm v2 forward messages, delivering the attached envelope to the recipient
+// identified by the next key.
+func (s *Service) handleForwardV2(msg service.DIDCommMsg) error {
+	forward := &model.ForwardV2{}
 
-	theirDID, err := s.routeStore.Get(toKey)
+	err := msg.Decode(forward)
+	if err != nil {
+		return fmt.Errorf("forward v2 message unmarshal : %w", err)
+	}
+
+	if forward.Body.Next == "" {
+		return errors.New("forward v2 message : next is missing")
+	}
+
+	if len(forward.Attachments) == 0 {
+		return errors.New("forward v2 message : attachment is missing")
+	}
+
+	envelope, err := forward.Attachments[0].Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("forward v2 message attachment : %w", err)
+	}
+
+	if json.Valid(envelope) {
+		return s.deliverForward(forward.Body.Next, json.RawMessage(envelope), envelope)
+	}
+
+	return s.deliverForward(forward.Body.Next, envelope, envelope)
+}
+
+// deliverForward delivers the message forwarded to the agent which registered the recipient key, the message is kept
+// for pickup when it can't be delivered.
+func (s *Service) deliverForward(recipientKey string, msg interface{}, pickupMsg []byte) error {
+	theirDID, err := s.routeStore.Get(dataKey(recipientKey))
 	if err != nil {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
@@ -526,14 +738,39 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("get destination : %w", err)
 	}
 
-	err = s.outbound.Forward(forward.Msg, dest)
+	err = s.outbound.Forward(msg, dest)
 	if err != nil && s.messagePickupSvc != nil {
-		return s.messagePickupSvc.AddMessage(forward.Msg, string(theirDID))
+		return s.messagePickupSvc.AddMessage(pickupMsg, string(theirDID))
 	}
 
 	return err
 }
 
+// relayForward delivers the forwarded message to the next hop chosen by the sender.
+func (s *Service) relayForward(forward *model.Forward) error {
+	if len(s.relayNextHops) == 0 {
+		return errors.New("forward relay is not enabled")
+	}
+
+	if forward.Next.ServiceEndpoint == "" {
+		return errors.New("forward relay : next hop service endpoint is missing")
+	}
+
+	if _, ok := s.relayNextHops[forward.Next.ServiceEndpoint]; !ok {
+		return fmt.Errorf("forward relay : next hop %s is not allowed", forward.Next.ServiceEndpoint)
+	}
+
+	err := s.outbound.Forward(forward.Msg, &service.Destination{
+		ServiceEndpoint: forward.Next.ServiceEndpoint,
+		RecipientKeys:   forward.Next.RecipientKeys,
+	})
+	if err != nil {
+		return fmt.Errorf("forward relay : %w", err)
+	}
+
+	return nil
+}
+
 // Register registers the agent with the router on the other end of the connection identified by
 // connectionID. This method blocks until a response is received from the router or it times out.
 // The agent is registered with the router and retrieves the router endpoint and routing keys.
@@ -557,7 +794,15 @@ func (s *Service) Register(connectionID string, options ...ClientOption) error {
 	)
 }
 
+// doRegistration registers the agent with the router holding the lock of the router connection, so that the agent
+// instances sharing the storage don't register concurrently with the same router.
 func (s *Service) doRegistration(record *connection.Record, req *Request, timeout time.Duration) error {
+	return commonlock.Do(s.lockService, registrationLockName(record.ConnectionID), func() error {
+		return s.register(record, req, timeout)
+	})
+}
+
+func (s *Service) register(record *connection.Record, req *Request, timeout time.Duration) error {
 	// check if router is already registered
 	err := s.ensureConnectionExists(record.ConnectionID)
 	if err == nil {
@@ -682,12 +927,18 @@ func (s *Service) GetConnections() ([]string, error) {
 
 // AddKey adds a recKey of the agent to the registered router. This method blocks until a response is
 // received from the router or it times out.
-// TODO https://github.com/hyperledger/aries-framework-go/issues/1076 Support for multiple routers
 // TODO https://github.com/hyperledger/aries-framework-go/issues/1105 Support to Add multiple
 //  recKeys to the Router
+//
+// The recKey added to a mediation chain (see ComposeChain) is added to the innermost router of the chain.
 func (s *Service) AddKey(connID, recKey string) error {
 	// check if router is already registered
 	err := s.ensureConnectionExists(connID)
+	if chain, ok := s.lookupChain(connID, err); ok {
+		connID = chain[len(chain)-1]
+		err = s.ensureConnectionExists(connID)
+	}
+
 	if err != nil {
 		return fmt.Errorf("ensure connection exists: %w", err)
 	}
@@ -735,10 +986,61 @@ func (s *Service) AddKey(connID, recKey string) error {
 	return nil
 }
 
-// Config fetches the router config - endpoint and routingKeys.
+// KeylistQuery queries a page of the recipient keys the agent added to the registered router. The router returns the
+// first page of keys when paginate is nil. This method blocks until a response is received from the router or it
+// times out.
+func (s *Service) KeylistQuery(connID string, paginate *Paginate, options ...ClientOption) (*Keylist, error) {
+	// check if router is already registered
+	err := s.ensureConnectionExists(connID)
+	if err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	opts := parseClientOpts(options...)
+
+	// generate message ID
+	msgID := uuid.New().String()
+
+	// register chan for callback processing, buffered as the response may arrive after the timeout
+	keylistCh := make(chan *Keylist, 1)
+	s.setKeylistCh(msgID, keylistCh)
+
+	// remove the channel once its been processed
+	defer s.setKeylistCh(msgID, nil)
+
+	query := &KeylistQuery{
+		ID:       msgID,
+		Type:     KeylistQueryMsgType,
+		Paginate: paginate,
+	}
+
+	if err := s.outbound.SendToDID(query, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send keylist query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return keylist, nil
+	case <-time.After(opts.Timeout):
+		return nil, errors.New("timeout waiting for keylist from the router")
+	}
+}
+
+// Config fetches the router config - endpoint and routingKeys. The connID is either a router connection ID or the ID
+// of a mediation chain (see ComposeChain).
 func (s *Service) Config(connID string) (*Config, error) {
 	// check if router is already registered
 	if err := s.ensureConnectionExists(connID); err != nil {
+		if chain, ok := s.lookupChain(connID, err); ok {
+			return s.chainConfig(chain)
+		}
+
 		return nil, fmt.Errorf("ensure connection exists: %w", err)
 	}
 
@@ -773,6 +1075,24 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getKeylistCh(msgID string) chan *Keylist {
+	s.keylistMapLock.RLock()
+	defer s.keylistMapLock.RUnlock()
+
+	return s.keylistMap[msgID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *Keylist) {
+	s.keylistMapLock.Lock()
+	defer s.keylistMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
 func (s *Service) ensureConnectionExists(connID string) error {
 	_, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
 	if errors.Is(err, storage.ErrDataNotFound) {
@@ -858,6 +1178,18 @@ func dataKey(id string) string {
 	return "route-" + id
 }
 
+func routeLockName(theirDID string) string {
+	return Coordination + "_route_" + theirDID
+}
+
+func registrationLockName(connID string) string {
+	return Coordination + "_registration_" + connID
+}
+
+func routeKeyTagValue(did string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(did)))
+}
+
 func parseClientOpts(options ...ClientOption) *ClientOptions {
 	opts := &ClientOptions{
 		Timeout: updateTimeout,
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -26,6 +28,7 @@ import (
 	mockmessagep "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/messagepickup"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -75,7 +78,10 @@ func TestServiceAccept(t *testing.T) {
 	require.Equal(t, true, s.Accept(GrantMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgType))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgType))
+	require.Equal(t, true, s.Accept(KeylistMsgType))
 	require.Equal(t, true, s.Accept(service.ForwardMsgType))
+	require.Equal(t, true, s.Accept(service.ForwardV2MsgType))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -1273,6 +1279,280 @@ func TestKeylistUpdate(t *testing.T) {
 	})
 }
 
+func TestServiceKeylistQueryMsg(t *testing.T) {
+	newService := func(t *testing.T, keylists chan *Keylist) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					if keylist, ok := msg.(*Keylist); ok && theirDID == THEIRDID {
+						keylists <- keylist
+					}
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("test service handle inbound key list query msg - success", func(t *testing.T) {
+		keylists := make(chan *Keylist, 1)
+		svc := newService(t, keylists)
+
+		msgID := randomID()
+
+		id, err := svc.HandleInbound(generateKeylistQueryMsgPayload(t, msgID, nil),
+			service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, msgID, id)
+
+		select {
+		case keylist := <-keylists:
+			require.Equal(t, msgID, keylist.ID)
+			require.Empty(t, keylist.Keys)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for keylist")
+		}
+	})
+
+	t.Run("test service handle key list query msg - pagination", func(t *testing.T) {
+		keylists := make(chan *Keylist, 1)
+		svc := newService(t, keylists)
+
+		var updates []Update
+		for i := 0; i < 5; i++ {
+			updates = append(updates, Update{RecipientKey: fmt.Sprintf("key-%d", i), Action: add})
+		}
+
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), updates),
+			MYDID, THEIRDID))
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), []Update{
+			{RecipientKey: "other-key", Action: add},
+		}), MYDID, "otherDID"))
+
+		tests := []struct {
+			paginate   *Paginate
+			keys       []string
+			pagination Pagination
+		}{
+			{nil, []string{"key-0", "key-1", "key-2", "key-3", "key-4"}, Pagination{Count: 5, Offset: 5}},
+			{&Paginate{Limit: 2}, []string{"key-0", "key-1"}, Pagination{Count: 2, Offset: 2, Remaining: 3}},
+			{&Paginate{Limit: 2, Offset: 4}, []string{"key-4"}, Pagination{Count: 1, Offset: 5}},
+			{&Paginate{Limit: 2, Offset: 10}, []string{}, Pagination{Count: 0, Offset: 5}},
+		}
+
+		for _, tc := range tests {
+			msgID := randomID()
+
+			require.NoError(t, svc.handleKeylistQuery(generateKeylistQueryMsgPayload(t, msgID, tc.paginate),
+				MYDID, THEIRDID))
+
+			keylist := <-keylists
+			require.Equal(t, KeylistMsgType, keylist.Type)
+			require.Equal(t, msgID, keylist.ID)
+			require.Equal(t, tc.pagination, *keylist.Pagination)
+
+			keys := []string{}
+			for _, k := range keylist.Keys {
+				keys = append(keys, k.RecipientKey)
+			}
+
+			require.Equal(t, tc.keys, keys)
+		}
+	})
+
+	t.Run("test service handle key list query msg - error", func(t *testing.T) {
+		svc := newService(t, make(chan *Keylist, 1))
+
+		err := svc.handleKeylistQuery(&service.DIDCommMsgMap{"@id": map[int]int{}}, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route keylist query message unmarshal")
+
+		svc.routeStore = &mockstore.MockStore{ErrQuery: errors.New("query error")}
+
+		err = svc.handleKeylistQuery(generateKeylistQueryMsgPayload(t, randomID(), nil), MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query error")
+	})
+
+	t.Run("test service handle key list msg - error", func(t *testing.T) {
+		svc := newService(t, make(chan *Keylist, 1))
+
+		err := svc.handleKeylist(&service.DIDCommMsgMap{"@id": map[int]int{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route keylist message unmarshal")
+	})
+}
+
+func TestKeylistQuery(t *testing.T) {
+	newService := func(t *testing.T, queries chan *KeylistQuery) (*Service, map[string]mockstore.DBEntry) {
+		t.Helper()
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					require.Equal(t, MYDID, myDID)
+					require.Equal(t, THEIRDID, theirDID)
+
+					query, ok := msg.(*KeylistQuery)
+					require.True(t, ok)
+
+					if queries != nil {
+						queries <- query
+					}
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		})
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		return svc, s
+	}
+
+	t.Run("test keylist query - success", func(t *testing.T) {
+		queries := make(chan *KeylistQuery)
+		svc, _ := newService(t, queries)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+
+		go func() {
+			query := <-queries
+			require.Equal(t, &Paginate{Limit: 2, Offset: 2}, query.Paginate)
+
+			require.NoError(t, svc.handleKeylist(generateKeylistMsgPayload(t, query.ID, &Keylist{
+				Keys:       []KeylistKey{{RecipientKey: "key-2"}, {RecipientKey: "key-3"}},
+				Pagination: &Pagination{Count: 2, Offset: 4, Remaining: 1},
+			})))
+
+			// the duplicate keylist is ignored
+			require.NoError(t, svc.handleKeylist(generateKeylistMsgPayload(t, query.ID, &Keylist{})))
+		}()
+
+		keylist, err := svc.KeylistQuery("conn", &Paginate{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		require.Len(t, keylist.Keys, 2)
+		require.Equal(t, "key-2", keylist.Keys[0].RecipientKey)
+		require.Equal(t, &Pagination{Count: 2, Offset: 4, Remaining: 1}, keylist.Pagination)
+	})
+
+	t.Run("test keylist query - timeout error", func(t *testing.T) {
+		svc, _ := newService(t, nil)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+
+		_, err := svc.KeylistQuery("conn", nil, func(opts *ClientOptions) {
+			opts.Timeout = 10 * time.Millisecond
+		})
+		require.EqualError(t, err, "timeout waiting for keylist from the router")
+	})
+
+	t.Run("test keylist query - failure", func(t *testing.T) {
+		svc, s := newService(t, nil)
+
+		// no router registered
+		_, err := svc.KeylistQuery("conn", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "router not registered")
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+		delete(s, "conn_conn")
+
+		// no connections saved
+		_, err = svc.KeylistQuery("conn", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connection not found")
+	})
+
+	t.Run("test keylist query - send error", func(t *testing.T) {
+		svc, _ := newService(t, nil)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn"))
+
+		svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		_, err := svc.KeylistQuery("conn", nil)
+		require.EqualError(t, err, "send keylist query: send error")
+	})
+}
+
+func TestServiceLock(t *testing.T) {
+	t.Run("keylist updates hold the lock of the DID", func(t *testing.T) {
+		lockService := &mocklock.MockLockService{}
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			LockServiceValue:                  lockService,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(generateKeyUpdateListMsgPayload(t, randomID(), []Update{{
+			RecipientKey: "ABC",
+			Action:       add,
+		}}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			locked := lockService.Locked()
+
+			return len(locked) == 1 && locked[0] == routeLockName(THEIRDID)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("registration lock error", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			LockServiceValue:                  &mocklock.MockLockService{ErrLock: errors.New("lock error")},
+		})
+		require.NoError(t, err)
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		})
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		err = svc.Register("conn")
+		require.EqualError(t, err, "acquire lock "+registrationLockName("conn")+": lock error")
+	})
+}
+
 func TestConfig(t *testing.T) {
 	routingKeys := []string{"abc", "xyz"}
 
@@ -1390,6 +1670,167 @@ func TestConfig(t *testing.T) {
 	})
 }
 
+func TestMediationChain(t *testing.T) {
+	newService := func(t *testing.T, s map[string]mockstore.DBEntry, outbound *mockdispatcher.MockOutbound) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           outbound,
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	// registerRouters registers the routers of the connections, each one with its own endpoint and routing key.
+	registerRouters := func(t *testing.T, svc *Service, connIDs ...string) {
+		t.Helper()
+
+		for _, connID := range connIDs {
+			require.NoError(t, svc.saveRouterConnectionID(connID))
+			require.NoError(t, svc.saveRouterConfig(connID, &config{
+				RouterEndpoint: "http://" + connID,
+				RoutingKeys:    []string{connID + "-key"},
+			}))
+		}
+	}
+
+	t.Run("test mediation chain - config", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), &mockdispatcher.MockOutbound{})
+		registerRouters(t, svc, "outer", "inner")
+
+		chainID, err := svc.ComposeChain([]string{"outer", "inner"})
+		require.NoError(t, err)
+		require.NotEmpty(t, chainID)
+
+		chain, err := svc.Chain(chainID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"outer", "inner"}, chain)
+
+		conf, err := svc.Config(chainID)
+		require.NoError(t, err)
+		require.Equal(t, "http://outer", conf.Endpoint())
+		require.Equal(t, []string{"outer-key", "inner-key"}, conf.Keys())
+
+		// the routers of the chain are still usable on their own
+		conf, err = svc.Config("inner")
+		require.NoError(t, err)
+		require.Equal(t, "http://inner", conf.Endpoint())
+		require.Equal(t, []string{"inner-key"}, conf.Keys())
+	})
+
+	t.Run("test mediation chain - add key to the innermost router", func(t *testing.T) {
+		const recKey = "ojaosdjoajs123jkas"
+
+		keyUpdateMsg := make(chan KeylistUpdate)
+		s := make(map[string]mockstore.DBEntry)
+
+		svc := newService(t, s, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, "did:example:inner", theirDID)
+
+				request, ok := msg.(*KeylistUpdate)
+				require.True(t, ok)
+
+				keyUpdateMsg <- *request
+
+				return nil
+			},
+		})
+		registerRouters(t, svc, "outer", "inner")
+
+		for _, connID := range []string{"outer", "inner"} {
+			connBytes, err := json.Marshal(&connection.Record{
+				ConnectionID: connID, MyDID: MYDID, TheirDID: "did:example:" + connID, State: "complete",
+			})
+			require.NoError(t, err)
+			s["conn_"+connID] = mockstore.DBEntry{Value: connBytes}
+		}
+
+		chainID, err := svc.ComposeChain([]string{"outer", "inner"})
+		require.NoError(t, err)
+
+		go func() {
+			updateMsg := <-keyUpdateMsg
+
+			require.Equal(t, recKey, updateMsg.Updates[0].RecipientKey)
+			require.NoError(t, svc.handleKeylistUpdateResponse(generateKeylistUpdateResponseMsgPayload(
+				t, updateMsg.ID, []UpdateResponse{{RecipientKey: recKey, Action: add, Result: success}})))
+		}()
+
+		require.NoError(t, svc.AddKey(chainID, recKey))
+	})
+
+	t.Run("test mediation chain - remove", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), &mockdispatcher.MockOutbound{})
+		registerRouters(t, svc, "outer", "inner")
+
+		chainID, err := svc.ComposeChain([]string{"outer", "inner"})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.RemoveChain(chainID))
+		require.True(t, errors.Is(svc.RemoveChain(chainID), ErrChainNotFound))
+
+		_, err = svc.Chain(chainID)
+		require.True(t, errors.Is(err, ErrChainNotFound))
+
+		_, err = svc.Config(chainID)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+
+		// the routers stay registered
+		_, err = svc.Config("outer")
+		require.NoError(t, err)
+	})
+
+	t.Run("test mediation chain - compose errors", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), &mockdispatcher.MockOutbound{})
+		registerRouters(t, svc, "outer", "inner")
+
+		_, err := svc.ComposeChain([]string{"outer"})
+		require.EqualError(t, err, "a mediation chain needs at least two router connections")
+
+		_, err = svc.ComposeChain([]string{"outer", "inner", "outer"})
+		require.EqualError(t, err, "router connection [outer] is used more than once in the chain")
+
+		_, err = svc.ComposeChain([]string{"outer", "unknown"})
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test mediation chain - router of the chain unregistered", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), &mockdispatcher.MockOutbound{})
+		registerRouters(t, svc, "outer", "inner")
+
+		chainID, err := svc.ComposeChain([]string{"outer", "inner"})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Unregister("inner"))
+
+		_, err = svc.Config(chainID)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+		require.Contains(t, err.Error(), "ensure connection [inner] exists")
+
+		err = svc.AddKey(chainID, "recKey")
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test mediation chain - invalid chain data in db", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), &mockdispatcher.MockOutbound{})
+
+		require.NoError(t, svc.routeStore.Put(fmt.Sprintf(routeChainDataKey, "chain"), []byte("invalid data")))
+
+		_, err := svc.Chain("chain")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal mediation chain")
+	})
+}
+
 func TestGetConnections(t *testing.T) {
 	routerConnectionID := "conn-abc-xyz"
 
@@ -1485,6 +1926,265 @@ func generateKeylistUpdateResponseMsgPayload(t *testing.T, id string, updates []
 	return didMsg
 }
 
+func generateKeylistQueryMsgPayload(t *testing.T, id string, paginate *Paginate) service.DIDCommMsg {
+	queryBytes, err := json.Marshal(&KeylistQuery{
+		Type:     KeylistQueryMsgType,
+		ID:       id,
+		Paginate: paginate,
+	})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(queryBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
+func generateKeylistMsgPayload(t *testing.T, id string, keylist *Keylist) service.DIDCommMsg {
+	keylist.Type = KeylistMsgType
+	keylist.ID = id
+
+	keylistBytes, err := json.Marshal(keylist)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(keylistBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
+func TestServiceForwardRelay(t *testing.T) {
+	relayMsg := func(t *testing.T, next *model.ForwardHop) service.DIDCommMsg {
+		t.Helper()
+
+		requestBytes, err := json.Marshal(&model.Forward{
+			Type: service.ForwardMsgType,
+			ID:   randomID(),
+			To:   "relayKey",
+			Msg:  []byte("inner"),
+			Next: next,
+		})
+		require.NoError(t, err)
+
+		didMsg, err := service.ParseDIDCommMsgMap(requestBytes)
+		require.NoError(t, err)
+
+		return didMsg
+	}
+
+	newService := func(t *testing.T, outbound *mockdispatcher.MockOutbound, opts ...ServiceOption) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           outbound,
+		}, opts...)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("test relay forward msg - success", func(t *testing.T) {
+		var (
+			forwarded   interface{}
+			destination *service.Destination
+		)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateForward: func(msg interface{}, des *service.Destination) error {
+				forwarded = msg
+				destination = des
+
+				return nil
+			},
+		}, WithForwardRelay("http://next.example.com"))
+
+		err := svc.handleForward(relayMsg(t, &model.ForwardHop{
+			ServiceEndpoint: "http://next.example.com",
+			RecipientKeys:   []string{"nextKey"},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []byte("inner"), forwarded)
+		require.Equal(t, &service.Destination{
+			ServiceEndpoint: "http://next.example.com",
+			RecipientKeys:   []string{"nextKey"},
+		}, destination)
+	})
+
+	t.Run("test relay forward msg - relay disabled", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		err := svc.handleForward(relayMsg(t, &model.ForwardHop{ServiceEndpoint: "http://next.example.com"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "forward relay is not enabled")
+	})
+
+	t.Run("test relay forward msg - missing next hop endpoint", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{}, WithForwardRelay("http://next.example.com"))
+
+		err := svc.handleForward(relayMsg(t, &model.ForwardHop{RecipientKeys: []string{"nextKey"}}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "next hop service endpoint is missing")
+	})
+
+	t.Run("test relay forward msg - next hop not allowed", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{}, WithForwardRelay("http://next.example.com"))
+
+		err := svc.handleForward(relayMsg(t, &model.ForwardHop{ServiceEndpoint: "http://other.example.com"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "next hop http://other.example.com is not allowed")
+	})
+
+	t.Run("test relay forward msg - outbound forward error", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateForward: func(interface{}, *service.Destination) error {
+				return errors.New("forward error")
+			},
+		}, WithForwardRelay("http://next.example.com"))
+
+		err := svc.handleForward(relayMsg(t, &model.ForwardHop{ServiceEndpoint: "http://next.example.com"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "forward relay : forward error")
+	})
+}
+
+func TestServiceForwardV2Msg(t *testing.T) {
+	envelope := []byte(`{"protected":"eyJ0eXAiOiJhcHBsaWNhdGlvbi9kaWRjb21tLWVuY3J5cHRlZCtqc29uIn0",` +
+		`"iv":"JS2FxjEKdndnt-J7QX5pEnVwyBTu0_3d","ciphertext":"qQyzvajdvCDJbwxM","tag":"2FqZMMQuNPYfL0JsSkj8LQ"}`)
+
+	newService := func(t *testing.T, validateForward func(msg interface{}, des *service.Destination) error) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{ValidateForward: validateForward},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveValue: mockdiddoc.GetMockDIDDoc(t),
+			},
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("test service handle inbound forward v2 msg - success", func(t *testing.T) {
+		next := randomID()
+		msgID := randomID()
+
+		forwarded := make(chan interface{}, 1)
+
+		svc := newService(t, func(msg interface{}, des *service.Destination) error {
+			forwarded <- msg
+
+			return nil
+		})
+
+		err := svc.routeStore.Put(dataKey(next), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		id, err := svc.HandleInbound(generateForwardV2MsgPayload(t, msgID, next, &decorator.AttachmentData{
+			JSON: json.RawMessage(envelope),
+		}), service.EmptyDIDCommContext())
+		require.NoError(t, err)
+		require.Equal(t, msgID, id)
+
+		select {
+		case msg := <-forwarded:
+			raw, ok := msg.(json.RawMessage)
+			require.True(t, ok)
+			require.JSONEq(t, string(envelope), string(raw))
+		case <-time.After(time.Second):
+			require.Fail(t, "forward v2 message was not delivered")
+		}
+	})
+
+	t.Run("test service handle forward v2 msg - base64 envelope", func(t *testing.T) {
+		next := randomID()
+
+		svc := newService(t, func(msg interface{}, des *service.Destination) error {
+			require.Equal(t, []byte("abc.def.ghi"), msg)
+
+			return nil
+		})
+
+		err := svc.routeStore.Put(dataKey(next), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		err = svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), next, &decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString([]byte("abc.def.ghi")),
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("test service handle forward v2 msg - outbound error stores message for pickup", func(t *testing.T) {
+		next := randomID()
+
+		svc := newService(t, func(msg interface{}, des *service.Destination) error {
+			return errors.New("forward error")
+		})
+
+		err := svc.routeStore.Put(dataKey(next), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		err = svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), next, &decorator.AttachmentData{
+			JSON: json.RawMessage(envelope),
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("test service handle forward v2 msg - unmarshal error", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handleForwardV2(&service.DIDCommMsgMap{"id": map[int]int{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "forward v2 message unmarshal")
+	})
+
+	t.Run("test service handle forward v2 msg - next is missing", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), "", &decorator.AttachmentData{
+			JSON: json.RawMessage(envelope),
+		}))
+		require.EqualError(t, err, "forward v2 message : next is missing")
+	})
+
+	t.Run("test service handle forward v2 msg - attachment is missing", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), randomID(), nil))
+		require.EqualError(t, err, "forward v2 message : attachment is missing")
+	})
+
+	t.Run("test service handle forward v2 msg - empty attachment", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), randomID(), &decorator.AttachmentData{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "forward v2 message attachment")
+	})
+
+	t.Run("test service handle forward v2 msg - route key fetch fail", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handleForwardV2(generateForwardV2MsgPayload(t, randomID(), randomID(), &decorator.AttachmentData{
+			JSON: json.RawMessage(envelope),
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route key fetch")
+	})
+}
+
 func generateForwardMsgPayload(t *testing.T, id, to string, msg []byte) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&model.Forward{
 		Type: service.ForwardMsgType,
@@ -1500,6 +2200,27 @@ func generateForwardMsgPayload(t *testing.T, id, to string, msg []byte) service.
 	return didMsg
 }
 
+func generateForwardV2MsgPayload(t *testing.T, id, next string, data *decorator.AttachmentData) service.DIDCommMsg {
+	forward := &model.ForwardV2{
+		Type: service.ForwardV2MsgType,
+		ID:   id,
+		To:   []string{randomID()},
+		Body: model.ForwardV2Body{Next: next},
+	}
+
+	if data != nil {
+		forward.Attachments = []decorator.AttachmentV2{{Data: *data}}
+	}
+
+	requestBytes, err := json.Marshal(forward)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(requestBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
 func randomID() string {
 	return uuid.New().String()
 }
@@ -9,6 +9,7 @@ package messagepickup
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -152,6 +153,11 @@ func (s *Service) Name() string {
 	return MessagePickup
 }
 
+// PIURIs of the message pickup protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 func (s *Service) handleStatus(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	statusMsg := &Status{}
@@ -584,6 +590,9 @@ func (s *Service) handle(msg *Message) error {
 		return fmt.Errorf("unmarshal transport decorator : %w", err)
 	}
 
+	// messages delivered through a batch pickup were stored and relayed by the mediator.
+	unpackMsg.Origin = &service.Origin{ViaMediator: true}
+
 	messageHandler := s.msgHandler
 
 	err = messageHandler(unpackMsg)
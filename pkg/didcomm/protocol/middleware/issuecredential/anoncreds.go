@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	stateNameOfferReceived = "offer-received"
+
+	// AnonCredsStoreName is the name of the store of the credential request metadata, kept until the credential is
+	// issued.
+	AnonCredsStoreName = "anoncreds_request_metadata"
+
+	mimeTypeApplicationJSON = "application/json"
+)
+
+var (
+	errFormatNotFound = errors.New("format not found")
+
+	logger = log.New("aries-framework/issuecredential/middleware")
+)
+
+// AnonCredsProvider contains dependencies for the AnonCredsHolder middleware function.
+type AnonCredsProvider interface {
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// AnonCredsHolder is the middleware of the holder of AnonCreds credentials (eg. issued by ACA-Py). At the
+// offer-received state, the hlindy/cred-abstract@v2.0 attachment of the offer is validated and replaced in the request
+// by the hlindy/cred-req@v2.0 attachment created by the prover. At the credential-received state, the hlindy/cred@v2.0
+// attachment is validated and stored by the prover, its ID is added to the names property.
+// Only the issue-credential/2.0 protocol is supported, the AnonCreds formats aren't defined for issue-credential/3.0.
+func AnonCredsHolder(p AnonCredsProvider, prover anoncreds.Prover) (issuecredential.Middleware, error) {
+	store, err := p.ProtocolStateStorageProvider().OpenStore(AnonCredsStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open anoncreds store: %w", err)
+	}
+
+	h := &anonCredsHolder{prover: prover, store: store}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			var err error
+
+			switch {
+			case metadata.StateName() == stateNameOfferReceived &&
+				metadata.Message().Type() == issuecredential.OfferCredentialMsgType:
+				err = h.requestCredential(metadata)
+			case metadata.StateName() == stateNameCredentialReceived &&
+				metadata.Message().Type() == issuecredential.IssueCredentialMsgType:
+				err = h.storeCredential(metadata)
+			}
+
+			if err != nil {
+				return fmt.Errorf("anoncreds: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+type anonCredsHolder struct {
+	prover anoncreds.Prover
+	store  storage.Store
+}
+
+func (h *anonCredsHolder) requestCredential(metadata issuecredential.Metadata) error {
+	request := metadata.RequestCredential()
+
+	// a proposal is sent instead of the request, or the request was created by the user
+	if request == nil || hasFormat(request.Formats, anoncreds.CredentialRequestFormat) {
+		return nil
+	}
+
+	offer := issuecredential.OfferCredential{}
+	if err := metadata.Message().Decode(&offer); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	src, err := getAttachmentByFormat(offer.Formats, offer.OffersAttach, anoncreds.CredentialAbstractFormat)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	credentialOffer, err := anoncreds.ParseCredentialOffer(src)
+	if err != nil {
+		return fmt.Errorf("parse credential offer: %w", err)
+	}
+
+	credentialRequest, requestMetadata, err := h.prover.CreateCredentialRequest(credentialOffer)
+	if err != nil {
+		return fmt.Errorf("create credential request: %w", err)
+	}
+
+	if err = credentialRequest.Validate(); err != nil {
+		return fmt.Errorf("validate credential request: %w", err)
+	}
+
+	requestBytes, err := json.Marshal(credentialRequest)
+	if err != nil {
+		return fmt.Errorf("marshal credential request: %w", err)
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	if err = h.store.Put(thID, requestMetadata); err != nil {
+		return fmt.Errorf("save credential request metadata: %w", err)
+	}
+
+	// the credential request replaces the credential offer copied from the offer message
+	attachID := formatAttachID(request.Formats, anoncreds.CredentialAbstractFormat)
+
+	request.Formats = removeFormat(request.Formats, anoncreds.CredentialAbstractFormat)
+	request.RequestsAttach = removeAttachment(request.RequestsAttach, attachID)
+
+	request.Formats = append(request.Formats, issuecredential.Format{
+		AttachID: attachID,
+		Format:   anoncreds.CredentialRequestFormat,
+	})
+	request.RequestsAttach = append(request.RequestsAttach, decorator.Attachment{
+		ID:       attachID,
+		MimeType: mimeTypeApplicationJSON,
+		Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(requestBytes)},
+	})
+
+	return nil
+}
+
+func (h *anonCredsHolder) storeCredential(metadata issuecredential.Metadata) error {
+	issued := issuecredential.IssueCredential{}
+	if err := metadata.Message().Decode(&issued); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	src, err := getAttachmentByFormat(issued.Formats, issued.CredentialsAttach, anoncreds.CredentialFormat)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	credential, err := anoncreds.ParseCredential(src)
+	if err != nil {
+		return fmt.Errorf("parse credential: %w", err)
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	requestMetadata, err := h.store.Get(thID)
+	if err != nil {
+		return fmt.Errorf("get credential request metadata: %w", err)
+	}
+
+	id, err := h.prover.StoreCredential(credential, requestMetadata)
+	if err != nil {
+		return fmt.Errorf("store credential: %w", err)
+	}
+
+	if err = h.store.Delete(thID); err != nil {
+		logger.Warnf("failed to delete the credential request metadata of thread %s: %s", thID, err)
+	}
+
+	properties := metadata.Properties()
+
+	// nolint: errcheck
+	names, _ := properties[namesKey].([]string)
+	properties[namesKey] = append(names, id)
+
+	return nil
+}
+
+func getAttachmentByFormat(formats []issuecredential.Format, attachments []decorator.Attachment,
+	format string) ([]byte, error) {
+	attachID := formatAttachID(formats, format)
+	if attachID == "" {
+		return nil, errFormatNotFound
+	}
+
+	for i := range attachments {
+		if attachments[i].ID == attachID {
+			data, err := attachments[i].Data.Fetch()
+			if err != nil {
+				return nil, fmt.Errorf("fetch %s attachment: %w", format, err)
+			}
+
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s attachment %s not found", format, attachID)
+}
+
+func formatAttachID(formats []issuecredential.Format, format string) string {
+	for _, f := range formats {
+		if f.Format == format {
+			return f.AttachID
+		}
+	}
+
+	return ""
+}
+
+func hasFormat(formats []issuecredential.Format, format string) bool {
+	return formatAttachID(formats, format) != ""
+}
+
+func removeFormat(formats []issuecredential.Format, format string) []issuecredential.Format {
+	var result []issuecredential.Format
+
+	for _, f := range formats {
+		if f.Format != format {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+func removeAttachment(attachments []decorator.Attachment, id string) []decorator.Attachment {
+	var result []decorator.Attachment
+
+	for i := range attachments {
+		if attachments[i].ID != id {
+			result = append(result, attachments[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const thID = "thread-1"
+
+type mockProver struct {
+	request         *anoncreds.CredentialRequest
+	requestMetadata json.RawMessage
+	requestErr      error
+	stored          *anoncreds.Credential
+	storedMetadata  json.RawMessage
+	storeErr        error
+}
+
+func (p *mockProver) CreateCredentialRequest(*anoncreds.CredentialOffer) (*anoncreds.CredentialRequest,
+	json.RawMessage, error) {
+	return p.request, p.requestMetadata, p.requestErr
+}
+
+func (p *mockProver) StoreCredential(credential *anoncreds.Credential, requestMetadata json.RawMessage) (string,
+	error) {
+	p.stored, p.storedMetadata = credential, requestMetadata
+
+	return "credential-1", p.storeErr
+}
+
+func (p *mockProver) CreateProof(*anoncreds.ProofRequest) (*anoncreds.Proof, error) {
+	return nil, errors.New("not implemented")
+}
+
+func newProver() *mockProver {
+	return &mockProver{
+		request: &anoncreds.CredentialRequest{
+			ProverDID:                 "did:sov:prover",
+			CredDefID:                 "cred-def-1",
+			BlindedMS:                 json.RawMessage(`{"u":"1"}`),
+			BlindedMSCorrectnessProof: json.RawMessage(`{"c":"1"}`),
+			Nonce:                     "1234",
+		},
+		requestMetadata: json.RawMessage(`{"master_secret_blinding_data":{}}`),
+	}
+}
+
+func newOffer(offer interface{}) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(issuecredential.OfferCredential{
+		Type:    issuecredential.OfferCredentialMsgType,
+		Formats: []issuecredential.Format{{AttachID: "offer", Format: anoncreds.CredentialAbstractFormat}},
+		OffersAttach: []decorator.Attachment{
+			{ID: "offer", Data: decorator.AttachmentData{JSON: offer}},
+		},
+	})
+	msg.SetID(thID)
+
+	return msg
+}
+
+func newIssueCredential(credential interface{}) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+		Type:    issuecredential.IssueCredentialMsgType,
+		Formats: []issuecredential.Format{{AttachID: "cred", Format: anoncreds.CredentialFormat}},
+		CredentialsAttach: []decorator.Attachment{
+			{ID: "cred", Data: decorator.AttachmentData{JSON: credential}},
+		},
+	})
+	msg.SetID("msg-1")
+	msg["~thread"] = map[string]interface{}{"thid": thID}
+
+	return msg
+}
+
+func toRequest(msg service.DIDCommMsgMap) *issuecredential.RequestCredential {
+	offer := &issuecredential.OfferCredential{}
+	if err := msg.Decode(offer); err != nil {
+		panic(err)
+	}
+
+	return &issuecredential.RequestCredential{
+		Type:           issuecredential.RequestCredentialMsgType,
+		Formats:        offer.Formats,
+		RequestsAttach: offer.OffersAttach,
+	}
+}
+
+func TestAnonCredsHolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	credentialOffer := &anoncreds.CredentialOffer{
+		SchemaID:            "schema-1",
+		CredDefID:           "cred-def-1",
+		Nonce:               "1234",
+		KeyCorrectnessProof: json.RawMessage(`{"c":"1"}`),
+	}
+
+	credential := &anoncreds.Credential{
+		SchemaID:                  "schema-1",
+		CredDefID:                 "cred-def-1",
+		Values:                    map[string]anoncreds.AttributeValue{"name": {Raw: "Alice", Encoded: "1139481716"}},
+		Signature:                 json.RawMessage(`{"p_credential":{}}`),
+		SignatureCorrectnessProof: json.RawMessage(`{"se":"1"}`),
+	}
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	newMetadata := func(state string, msg service.DIDCommMsgMap,
+		request *issuecredential.RequestCredential) (*mocks.MockMetadata, map[string]interface{}) {
+		properties := map[string]interface{}{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(state).AnyTimes()
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().RequestCredential().Return(request).AnyTimes()
+		metadata.EXPECT().Properties().Return(properties).AnyTimes()
+
+		return metadata, properties
+	}
+
+	t.Run("request and store credential", func(t *testing.T) {
+		prover := newProver()
+
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, prover)
+		require.NoError(t, err)
+
+		offer := newOffer(credentialOffer)
+		request := toRequest(offer)
+
+		metadata, _ := newMetadata(stateNameOfferReceived, offer, request)
+		require.NoError(t, mw(next).Handle(metadata))
+
+		require.Equal(t, []issuecredential.Format{{AttachID: "offer", Format: anoncreds.CredentialRequestFormat}},
+			request.Formats)
+		require.Len(t, request.RequestsAttach, 1)
+
+		src, err := request.RequestsAttach[0].Data.Fetch()
+		require.NoError(t, err)
+
+		credentialRequest, err := anoncreds.ParseCredentialRequest(src)
+		require.NoError(t, err)
+		require.Equal(t, prover.request, credentialRequest)
+
+		metadata, properties := newMetadata(stateNameCredentialReceived, newIssueCredential(credential), nil)
+		require.NoError(t, mw(next).Handle(metadata))
+
+		require.Equal(t, credential, prover.stored)
+		require.JSONEq(t, string(prover.requestMetadata), string(prover.storedMetadata))
+		require.Equal(t, []string{"credential-1"}, properties[namesKey])
+	})
+
+	t.Run("not applicable", func(t *testing.T) {
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, newProver())
+		require.NoError(t, err)
+
+		metadata, _ := newMetadata("state-name", newOffer(credentialOffer), nil)
+		require.NoError(t, mw(next).Handle(metadata))
+
+		// a proposal is sent
+		metadata, _ = newMetadata(stateNameOfferReceived, newOffer(credentialOffer), nil)
+		require.NoError(t, mw(next).Handle(metadata))
+
+		msg := service.NewDIDCommMsgMap(issuecredential.OfferCredential{Type: issuecredential.OfferCredentialMsgType})
+		metadata, _ = newMetadata(stateNameOfferReceived, msg, toRequest(msg))
+		require.NoError(t, mw(next).Handle(metadata))
+
+		msg = service.NewDIDCommMsgMap(issuecredential.IssueCredential{Type: issuecredential.IssueCredentialMsgType})
+		metadata, properties := newMetadata(stateNameCredentialReceived, msg, nil)
+		require.NoError(t, mw(next).Handle(metadata))
+		require.Empty(t, properties)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("error")},
+		}, newProver())
+		require.EqualError(t, err, "open anoncreds store: error")
+	})
+
+	t.Run("invalid credential offer", func(t *testing.T) {
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, newProver())
+		require.NoError(t, err)
+
+		offer := newOffer(&anoncreds.CredentialOffer{SchemaID: "schema-1", CredDefID: "cred-def-1", Nonce: "abc"})
+
+		metadata, _ := newMetadata(stateNameOfferReceived, offer, toRequest(offer))
+		err = mw(next).Handle(metadata)
+		require.ErrorIs(t, err, anoncreds.ErrInvalidAttachment)
+		require.Contains(t, err.Error(), "key_correctness_proof, nonce")
+	})
+
+	t.Run("create credential request error", func(t *testing.T) {
+		prover := newProver()
+		prover.requestErr = errors.New("no link secret")
+
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, prover)
+		require.NoError(t, err)
+
+		offer := newOffer(credentialOffer)
+
+		metadata, _ := newMetadata(stateNameOfferReceived, offer, toRequest(offer))
+		require.EqualError(t, mw(next).Handle(metadata),
+			"anoncreds: create credential request: no link secret")
+	})
+
+	t.Run("no credential request metadata", func(t *testing.T) {
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, newProver())
+		require.NoError(t, err)
+
+		metadata, _ := newMetadata(stateNameCredentialReceived, newIssueCredential(credential), nil)
+		require.Contains(t, mw(next).Handle(metadata).Error(), "get credential request metadata")
+	})
+
+	t.Run("invalid credential", func(t *testing.T) {
+		mw, err := AnonCredsHolder(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, newProver())
+		require.NoError(t, err)
+
+		metadata, _ := newMetadata(stateNameCredentialReceived, newIssueCredential(&anoncreds.Credential{
+			SchemaID:                  "schema-1",
+			CredDefID:                 "cred-def-1",
+			Values:                    map[string]anoncreds.AttributeValue{"name": {Raw: "Alice"}},
+			Signature:                 json.RawMessage(`{}`),
+			SignatureCorrectnessProof: json.RawMessage(`{}`),
+		}), nil)
+		err = mw(next).Handle(metadata)
+		require.ErrorIs(t, err, anoncreds.ErrInvalidAttachment)
+		require.Contains(t, err.Error(), "value name isn't encoded")
+	})
+}
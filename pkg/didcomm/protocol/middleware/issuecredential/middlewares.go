@@ -9,12 +9,15 @@ package issuecredential
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
@@ -49,14 +52,17 @@ func SaveCredentials(p Provider) issuecredential.Middleware {
 				return next.Handle(metadata)
 			}
 
-			credential := issuecredential.IssueCredential{}
-
-			err := metadata.Message().Decode(&credential)
+			attachments, anonCreds, err := getAttachments(metadata.Message())
 			if err != nil {
-				return fmt.Errorf("decode: %w", err)
+				return fmt.Errorf("get attachments: %w", err)
+			}
+
+			// the AnonCreds credentials are stored by the AnonCredsHolder middleware
+			if len(attachments) == 0 && anonCreds {
+				return next.Handle(metadata)
 			}
 
-			credentials, err := toVerifiableCredentials(vdr, credential.CredentialsAttach, documentLoader)
+			credentials, err := toVerifiableCredentials(vdr, attachments, documentLoader)
 			if err != nil {
 				return fmt.Errorf("to verifiable credentials: %w", err)
 			}
@@ -108,12 +114,63 @@ func getName(idx int, id string, metadata issuecredential.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachment,
+// getAttachments returns the attachments of the credentials, except the AnonCreds ones: the second result reports
+// whether AnonCreds attachments were skipped.
+func getAttachments(msg service.DIDCommMsg) ([]decorator.AttachmentData, bool, error) {
+	if strings.HasPrefix(msg.Type(), issuecredential.SpecV3) {
+		credential := issuecredential.IssueCredentialV3{}
+		if err := msg.Decode(&credential); err != nil {
+			return nil, false, fmt.Errorf("decode: %w", err)
+		}
+
+		var attachments []decorator.AttachmentData
+
+		for i := range credential.Attachments {
+			attachments = append(attachments, credential.Attachments[i].Data)
+		}
+
+		return attachments, false, nil
+	}
+
+	credential := issuecredential.IssueCredential{}
+	if err := msg.Decode(&credential); err != nil {
+		return nil, false, fmt.Errorf("decode: %w", err)
+	}
+
+	var (
+		attachments []decorator.AttachmentData
+		anonCreds   bool
+	)
+
+	for i := range credential.CredentialsAttach {
+		if isAnonCredsAttachment(credential.Formats, credential.CredentialsAttach[i].ID) {
+			anonCreds = true
+
+			continue
+		}
+
+		attachments = append(attachments, credential.CredentialsAttach[i].Data)
+	}
+
+	return attachments, anonCreds, nil
+}
+
+func isAnonCredsAttachment(formats []issuecredential.Format, attachID string) bool {
+	for _, f := range formats {
+		if f.AttachID == attachID && anoncreds.IsFormat(f.Format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.AttachmentData,
 	documentLoader ld.DocumentLoader) ([]*verifiable.Credential, error) {
 	var credentials []*verifiable.Credential
 
 	for i := range attachments {
-		rawVC, err := attachments[i].Data.Fetch()
+		rawVC, err := attachments[i].Fetch()
 		if err != nil {
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -84,6 +85,20 @@ func TestSaveCredentials(t *testing.T) {
 		require.EqualError(t, err, "credentials were not provided")
 	})
 
+	t.Run("Ignores AnonCreds credentials", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredential{
+			Type:    issuecredential.IssueCredentialMsgType,
+			Formats: []issuecredential.Format{{AttachID: "cred", Format: anoncreds.CredentialFormat}},
+			CredentialsAttach: []decorator.Attachment{
+				{ID: "cred", Data: decorator.AttachmentData{JSON: &anoncreds.Credential{}}},
+			},
+		}))
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+	})
+
 	t.Run("Marshal credentials error", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
@@ -252,6 +267,53 @@ func TestSaveCredentials(t *testing.T) {
 		require.NotEmpty(t, props["names"].([]string)[0])
 	})
 
+	t.Run("Success (V3)", func(t *testing.T) {
+		const vcName = "vc-name"
+
+		props := map[string]interface{}{
+			myDIDKey:    myDIDKey,
+			theirDIDKey: theirDIDKey,
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().CredentialNames().Return([]string{vcName}).Times(2)
+		metadata.EXPECT().Properties().Return(props)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.IssueCredentialV3{
+			Type: issuecredential.IssueCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{
+				{Data: decorator.AttachmentData{JSON: getCredential()}},
+			},
+		}))
+
+		verifiableStore := mockstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SaveCredential(vcName, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		loader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(verifiableStore)
+		provider.EXPECT().JSONLDDocumentLoader().Return(loader)
+
+		require.NoError(t, SaveCredentials(provider)(next).Handle(metadata))
+		require.Equal(t, props["names"], []string{vcName})
+	})
+
+	t.Run("Decode error (V3)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{
+			"type": issuecredential.IssueCredentialMsgTypeV3,
+			"body": "body",
+		})
+
+		err := SaveCredentials(provider)(next).Handle(metadata)
+		require.Contains(t, fmt.Sprintf("%v", err), "decode")
+	})
+
 	t.Run("Success (credential with a proof)", func(t *testing.T) {
 		const vcName = "vc-name"
 
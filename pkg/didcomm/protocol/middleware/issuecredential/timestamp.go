@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	stateNameCredentialIssued = "credential-issued"
+
+	// CredentialTimestampsStoreName is the name of the store of the time-stamps of the issued credentials.
+	CredentialTimestampsStoreName = "credential_timestamps"
+)
+
+// TimestampProvider contains dependencies for the TimestampCredentials middleware function.
+type TimestampProvider interface {
+	StorageProvider() storage.Provider
+}
+
+// CredentialTimestamp is the RFC 3161 time-stamp of an issued credential, the time-stamped data being the content of
+// the credential attachment.
+type CredentialTimestamp struct {
+	AttachID  string               `json:"attachID"`
+	Format    string               `json:"format,omitempty"`
+	Timestamp *timestamp.Timestamp `json:"timestamp"`
+}
+
+// TimestampCredentials is the middleware of the issuer time-stamping the credentials it issues with the stamper. At
+// the credential-issued state, each credential attachment of the issue-credential message is time-stamped and the
+// time-stamps are stored by thread ID, to be retrieved with GetCredentialTimestamps. The credentials aren't issued if
+// they can't be time-stamped.
+func TimestampCredentials(p TimestampProvider, stamper timestamp.Stamper) (issuecredential.Middleware, error) {
+	store, err := p.StorageProvider().OpenStore(CredentialTimestampsStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open credential timestamps store: %w", err)
+	}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			if metadata.StateName() != stateNameCredentialIssued {
+				return next.Handle(metadata)
+			}
+
+			if err := timestampCredentials(store, stamper, metadata); err != nil {
+				return fmt.Errorf("timestamp credentials: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+// GetCredentialTimestamps returns the time-stamps of the credentials issued in the thread.
+func GetCredentialTimestamps(p TimestampProvider, thID string) ([]CredentialTimestamp, error) {
+	store, err := p.StorageProvider().OpenStore(CredentialTimestampsStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open credential timestamps store: %w", err)
+	}
+
+	src, err := store.Get(thID)
+	if err != nil {
+		return nil, fmt.Errorf("get credential timestamps: %w", err)
+	}
+
+	var timestamps []CredentialTimestamp
+
+	if err = json.Unmarshal(src, &timestamps); err != nil {
+		return nil, fmt.Errorf("unmarshal credential timestamps: %w", err)
+	}
+
+	return timestamps, nil
+}
+
+func timestampCredentials(store storage.Store, stamper timestamp.Stamper, metadata issuecredential.Metadata) error {
+	var attachments []CredentialTimestamp
+
+	var data []decorator.AttachmentData
+
+	switch {
+	case metadata.IssueCredential() != nil:
+		issued := metadata.IssueCredential()
+
+		for i := range issued.CredentialsAttach {
+			attachID := issued.CredentialsAttach[i].ID
+
+			attachments = append(attachments, CredentialTimestamp{
+				AttachID: attachID,
+				Format:   formatByAttachID(issued.Formats, attachID),
+			})
+			data = append(data, issued.CredentialsAttach[i].Data)
+		}
+	case metadata.IssueCredentialV3() != nil:
+		issued := metadata.IssueCredentialV3()
+
+		for i := range issued.Attachments {
+			attachments = append(attachments, CredentialTimestamp{
+				AttachID: issued.Attachments[i].ID,
+				Format:   issued.Attachments[i].Format,
+			})
+			data = append(data, issued.Attachments[i].Data)
+		}
+	}
+
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	for i := range attachments {
+		src, err := data[i].Fetch()
+		if err != nil {
+			return fmt.Errorf("fetch attachment %s: %w", attachments[i].AttachID, err)
+		}
+
+		attachments[i].Timestamp, err = stamper.Timestamp(src)
+		if err != nil {
+			return fmt.Errorf("time-stamp attachment %s: %w", attachments[i].AttachID, err)
+		}
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	src, err := json.Marshal(attachments)
+	if err != nil {
+		return fmt.Errorf("marshal credential timestamps: %w", err)
+	}
+
+	if err = store.Put(thID, src); err != nil {
+		return fmt.Errorf("save credential timestamps: %w", err)
+	}
+
+	return nil
+}
+
+func formatByAttachID(formats []issuecredential.Format, attachID string) string {
+	for _, f := range formats {
+		if f.AttachID == attachID {
+			return f.Format
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// mockStamper returns the digests of the data as tokens.
+type mockStamper struct {
+	err error
+}
+
+func (s *mockStamper) Timestamp(data []byte) (*timestamp.Timestamp, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	token := sha256.Sum256(data)
+
+	return &timestamp.Timestamp{Token: token[:], Time: time.Unix(0, 0).UTC()}, nil
+}
+
+func TestTimestampCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	credential := []byte(`{"id":"http://example.edu/credentials/1872"}`)
+	token := sha256.Sum256(credential)
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	request := service.NewDIDCommMsgMap(issuecredential.RequestCredential{
+		Type: issuecredential.RequestCredentialMsgType,
+	})
+	request.SetID(thID)
+
+	newMetadata := func(state string, issued *issuecredential.IssueCredential,
+		issuedV3 *issuecredential.IssueCredentialV3) *mocks.MockMetadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(state).AnyTimes()
+		metadata.EXPECT().Message().Return(request).AnyTimes()
+		metadata.EXPECT().IssueCredential().Return(issued).AnyTimes()
+		metadata.EXPECT().IssueCredentialV3().Return(issuedV3).AnyTimes()
+
+		return metadata
+	}
+
+	t.Run("time-stamp credentials", func(t *testing.T) {
+		provider := &mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}
+
+		mw, err := TimestampCredentials(provider, &mockStamper{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialIssued, &issuecredential.IssueCredential{
+			Formats: []issuecredential.Format{{AttachID: "cred", Format: "aries/ld-proof-vc@v1.0"}},
+			CredentialsAttach: []decorator.Attachment{{
+				ID:   "cred",
+				Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(credential)},
+			}},
+		}, nil)
+		require.NoError(t, mw(next).Handle(metadata))
+
+		timestamps, err := GetCredentialTimestamps(provider, thID)
+		require.NoError(t, err)
+		require.Equal(t, []CredentialTimestamp{{
+			AttachID:  "cred",
+			Format:    "aries/ld-proof-vc@v1.0",
+			Timestamp: &timestamp.Timestamp{Token: token[:], Time: time.Unix(0, 0).UTC()},
+		}}, timestamps)
+	})
+
+	t.Run("time-stamp credentials (v3)", func(t *testing.T) {
+		provider := &mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}
+
+		mw, err := TimestampCredentials(provider, &mockStamper{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialIssued, nil, &issuecredential.IssueCredentialV3{
+			Attachments: []decorator.AttachmentV2{{
+				ID:     "cred",
+				Format: "aries/ld-proof-vc@v1.0",
+				Data:   decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(credential)},
+			}},
+		})
+		require.NoError(t, mw(next).Handle(metadata))
+
+		timestamps, err := GetCredentialTimestamps(provider, thID)
+		require.NoError(t, err)
+		require.Len(t, timestamps, 1)
+		require.Equal(t, token[:], timestamps[0].Timestamp.Token)
+	})
+
+	t.Run("not applicable", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider()
+		provider := &mockprovider.Provider{StorageProviderValue: store}
+
+		mw, err := TimestampCredentials(provider, &mockStamper{err: errors.New("not called")})
+		require.NoError(t, err)
+
+		require.NoError(t, mw(next).Handle(newMetadata("state-name", nil, nil)))
+		require.NoError(t, mw(next).Handle(newMetadata(stateNameCredentialIssued, nil, nil)))
+
+		_, err = GetCredentialTimestamps(provider, thID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("time-stamp error", func(t *testing.T) {
+		mw, err := TimestampCredentials(&mockprovider.Provider{
+			StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, &mockStamper{err: errors.New("TSA unavailable")})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialIssued, &issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{ID: "cred", Data: decorator.AttachmentData{JSON: "credential"}}},
+		}, nil)
+		require.EqualError(t, mw(next).Handle(metadata),
+			"timestamp credentials: time-stamp attachment cred: TSA unavailable")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		mw, err := TimestampCredentials(&mockprovider.Provider{
+			StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}, &mockStamper{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialIssued, &issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{ID: "cred"}},
+		}, nil)
+		require.Contains(t, mw(next).Handle(metadata).Error(), "fetch attachment cred")
+	})
+
+	t.Run("save error", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider()
+		store.Store.ErrPut = errors.New("put error")
+
+		mw, err := TimestampCredentials(&mockprovider.Provider{StorageProviderValue: store}, &mockStamper{})
+		require.NoError(t, err)
+
+		metadata := newMetadata(stateNameCredentialIssued, &issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{ID: "cred", Data: decorator.AttachmentData{JSON: "credential"}}},
+		}, nil)
+		require.EqualError(t, mw(next).Handle(metadata),
+			"timestamp credentials: save credential timestamps: put error")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		provider := &mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("error")},
+		}
+
+		_, err := TimestampCredentials(provider, &mockStamper{})
+		require.EqualError(t, err, "open credential timestamps store: error")
+
+		_, err = GetCredentialTimestamps(provider, thID)
+		require.EqualError(t, err, "open credential timestamps store: error")
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		store := mockstorage.NewMockStoreProvider()
+		require.NoError(t, store.Store.Put(thID, []byte("invalid")))
+
+		_, err := GetCredentialTimestamps(&mockprovider.Provider{StorageProviderValue: store}, thID)
+		require.Contains(t, err.Error(), "unmarshal credential timestamps")
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
+)
+
+const mimeTypeApplicationJSON = "application/json"
+
+// AnonCredsProof is the middleware of the prover answering the AnonCreds proof requests (eg. of the ACA-Py
+// verifiers): at the request-received state, the hlindy/proof-req@v2.0 attachment of the request is validated and
+// the hlindy/proof@v2.0 attachment created by the prover is added to the presentation.
+// The presentation must be provided when accepting the request, it may be empty: the middleware doesn't apply when
+// the presentation has a proof attachment already.
+// Only the present-proof/2.0 protocol is supported, the AnonCreds formats aren't defined for present-proof/3.0.
+func AnonCredsProof(prover anoncreds.Prover) presentproof.Middleware {
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if metadata.StateName() != stateNameRequestReceived ||
+				metadata.Message().Type() != presentproof.RequestPresentationMsgTypeV2 {
+				return next.Handle(metadata)
+			}
+
+			request := presentproof.RequestPresentation{}
+			if err := metadata.Message().Decode(&request); err != nil {
+				return fmt.Errorf("decode: %w", err)
+			}
+
+			presentation := metadata.Presentation()
+
+			if presentation == nil ||
+				!hasFormat(request.Formats, anoncreds.ProofRequestFormat) ||
+				hasFormat(presentation.Formats, anoncreds.ProofFormat) {
+				return next.Handle(metadata)
+			}
+
+			src, _, err := getAttachmentByFormat(request.Formats, request.RequestPresentationsAttach,
+				anoncreds.ProofRequestFormat)
+			if err != nil {
+				return fmt.Errorf("get attachment by format: %w", err)
+			}
+
+			proofRequest, err := anoncreds.ParseProofRequest(src)
+			if err != nil {
+				return fmt.Errorf("parse proof request: %w", err)
+			}
+
+			proof, err := prover.CreateProof(proofRequest)
+			if err != nil {
+				return fmt.Errorf("create proof: %w", err)
+			}
+
+			if err = proof.Validate(); err != nil {
+				return fmt.Errorf("validate proof: %w", err)
+			}
+
+			proofBytes, err := json.Marshal(proof)
+			if err != nil {
+				return fmt.Errorf("marshal proof: %w", err)
+			}
+
+			attachID := uuid.New().String()
+
+			presentation.Formats = append(presentation.Formats, presentproof.Format{
+				AttachID: attachID,
+				Format:   anoncreds.ProofFormat,
+			})
+			presentation.PresentationsAttach = append(presentation.PresentationsAttach, decorator.Attachment{
+				ID:       attachID,
+				MimeType: mimeTypeApplicationJSON,
+				Data:     decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(proofBytes)},
+			})
+
+			return next.Handle(metadata)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/anoncreds"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+)
+
+type mockProver struct {
+	proof    *anoncreds.Proof
+	proofErr error
+}
+
+func (p *mockProver) CreateCredentialRequest(*anoncreds.CredentialOffer) (*anoncreds.CredentialRequest,
+	json.RawMessage, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (p *mockProver) StoreCredential(*anoncreds.Credential, json.RawMessage) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (p *mockProver) CreateProof(*anoncreds.ProofRequest) (*anoncreds.Proof, error) {
+	return p.proof, p.proofErr
+}
+
+func TestAnonCredsProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	proofRequest := &anoncreds.ProofRequest{
+		Name:    "proof of age",
+		Version: "1.0",
+		Nonce:   "1234",
+		RequestedAttributes: map[string]*anoncreds.AttributeInfo{
+			"name": {Name: "name", Restrictions: json.RawMessage(`[{"cred_def_id":"cred-def-1"}]`)},
+		},
+		RequestedPredicates: map[string]*anoncreds.PredicateInfo{
+			"age": {Name: "age", PType: anoncreds.PredicateGE, PValue: 18},
+		},
+	}
+
+	proof := &anoncreds.Proof{
+		Proof:          json.RawMessage(`{"proofs":[]}`),
+		RequestedProof: json.RawMessage(`{"revealed_attrs":{}}`),
+		Identifiers:    []anoncreds.Identifier{{SchemaID: "schema-1", CredDefID: "cred-def-1"}},
+	}
+
+	newRequest := func(request interface{}) service.DIDCommMsgMap {
+		return service.NewDIDCommMsgMap(presentproof.RequestPresentation{
+			Type:    presentproof.RequestPresentationMsgTypeV2,
+			Formats: []presentproof.Format{{AttachID: "request", Format: anoncreds.ProofRequestFormat}},
+			RequestPresentationsAttach: []decorator.Attachment{
+				{ID: "request", Data: decorator.AttachmentData{JSON: request}},
+			},
+		})
+	}
+
+	newMetadata := func(state string, msg service.DIDCommMsgMap,
+		presentation *presentproof.Presentation) *mocks.MockMetadata {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(state).AnyTimes()
+		metadata.EXPECT().Message().Return(msg).AnyTimes()
+		metadata.EXPECT().Presentation().Return(presentation).AnyTimes()
+
+		return metadata
+	}
+
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		return nil
+	})
+
+	t.Run("success", func(t *testing.T) {
+		presentation := &presentproof.Presentation{}
+
+		metadata := newMetadata(stateNameRequestReceived, newRequest(proofRequest), presentation)
+		require.NoError(t, AnonCredsProof(&mockProver{proof: proof})(next).Handle(metadata))
+
+		require.Len(t, presentation.Formats, 1)
+		require.Equal(t, anoncreds.ProofFormat, presentation.Formats[0].Format)
+		require.Len(t, presentation.PresentationsAttach, 1)
+		require.Equal(t, presentation.Formats[0].AttachID, presentation.PresentationsAttach[0].ID)
+
+		src, err := presentation.PresentationsAttach[0].Data.Fetch()
+		require.NoError(t, err)
+
+		result, err := anoncreds.ParseProof(src)
+		require.NoError(t, err)
+		require.Equal(t, proof, result)
+	})
+
+	t.Run("not applicable", func(t *testing.T) {
+		prover := &mockProver{proofErr: errors.New("unexpected")}
+
+		metadata := newMetadata("state-name", newRequest(proofRequest), &presentproof.Presentation{})
+		require.NoError(t, AnonCredsProof(prover)(next).Handle(metadata))
+
+		metadata = newMetadata(stateNameRequestReceived, newRequest(proofRequest), nil)
+		require.NoError(t, AnonCredsProof(prover)(next).Handle(metadata))
+
+		metadata = newMetadata(stateNameRequestReceived, newRequest(proofRequest), &presentproof.Presentation{
+			Formats: []presentproof.Format{{AttachID: "proof", Format: anoncreds.ProofFormat}},
+		})
+		require.NoError(t, AnonCredsProof(prover)(next).Handle(metadata))
+
+		metadata = newMetadata(stateNameRequestReceived, service.NewDIDCommMsgMap(presentproof.RequestPresentation{
+			Type: presentproof.RequestPresentationMsgTypeV2,
+		}), &presentproof.Presentation{})
+		require.NoError(t, AnonCredsProof(prover)(next).Handle(metadata))
+
+		metadata = newMetadata(stateNameRequestReceived, service.NewDIDCommMsgMap(presentproof.RequestPresentationV3{
+			Type: presentproof.RequestPresentationMsgTypeV3,
+		}), nil)
+		require.NoError(t, AnonCredsProof(prover)(next).Handle(metadata))
+	})
+
+	t.Run("invalid proof request", func(t *testing.T) {
+		metadata := newMetadata(stateNameRequestReceived, newRequest(&anoncreds.ProofRequest{
+			Name:    "proof of age",
+			Version: "1.0",
+			Nonce:   "1234",
+			RequestedPredicates: map[string]*anoncreds.PredicateInfo{
+				"age": {Name: "age", PType: "=", PValue: 18},
+			},
+		}), &presentproof.Presentation{})
+
+		err := AnonCredsProof(&mockProver{proof: proof})(next).Handle(metadata)
+		require.ErrorIs(t, err, anoncreds.ErrInvalidAttachment)
+		require.Contains(t, err.Error(), `predicate age has an unsupported type "="`)
+	})
+
+	t.Run("create proof error", func(t *testing.T) {
+		metadata := newMetadata(stateNameRequestReceived, newRequest(proofRequest), &presentproof.Presentation{})
+
+		err := AnonCredsProof(&mockProver{proofErr: errors.New("no credentials")})(next).Handle(metadata)
+		require.EqualError(t, err, "create proof: no credentials")
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		metadata := newMetadata(stateNameRequestReceived, newRequest(proofRequest), &presentproof.Presentation{})
+
+		err := AnonCredsProof(&mockProver{proof: &anoncreds.Proof{}})(next).Handle(metadata)
+		require.ErrorIs(t, err, anoncreds.ErrInvalidAttachment)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
@@ -36,6 +37,7 @@ const (
 	myDIDKey                      = "myDID"
 	theirDIDKey                   = "theirDID"
 	namesKey                      = "names"
+	challengeKey                  = "challenge"
 
 	mimeTypeApplicationLdJSON = "application/ld+json"
 	mimeTypeAll               = "*"
@@ -115,6 +117,47 @@ func SavePresentation(p Provider) presentproof.Middleware {
 	}
 }
 
+// SingleUseChallenge the helper function for the present proof protocol which rejects the presentations whose proof
+// challenge was already used by a presentation received, the protocol being abandoned with a problem report. It must
+// precede the middlewares saving the presentations.
+func SingleUseChallenge(p Provider, nonceStore *nonce.Store) presentproof.Middleware {
+	vdr := p.VDRegistry()
+	documentLoader := p.JSONLDDocumentLoader()
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if metadata.StateName() != stateNamePresentationReceived {
+				return next.Handle(metadata)
+			}
+
+			attachments, err := getAttachments(metadata.Message())
+			if err != nil {
+				return fmt.Errorf("get attachments: %w", err)
+			}
+
+			presentations, err := toVerifiablePresentation(vdr, attachments, documentLoader)
+			if err != nil {
+				return fmt.Errorf("to verifiable presentation: %w", err)
+			}
+
+			for _, presentation := range presentations {
+				for _, proof := range presentation.Proofs {
+					challenge, ok := proof[challengeKey].(string)
+					if !ok || challenge == "" {
+						continue
+					}
+
+					if err = nonceStore.Use(nonce.ChallengeScope, challenge); err != nil {
+						return fmt.Errorf("use presentation challenge: %w", err)
+					}
+				}
+			}
+
+			return next.Handle(metadata)
+		})
+	}
+}
+
 func getAttachments(msg service.DIDCommMsg) ([]decorator.AttachmentData, error) {
 	if strings.HasPrefix(msg.Type(), presentproof.SpecV3) {
 		presentation := presentproof.PresentationV3{}
@@ -17,22 +17,30 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
 	mocksvdr "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/framework/aries/api/vdr"
 	mocksstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 )
 
 // nolint: gochecknoglobals
@@ -670,3 +678,111 @@ func TestPresentationDefinition(t *testing.T) {
 		require.Nil(t, PresentationDefinition(provider, WithAddProofFn(AddBBSProofFn(provider)))(next).Handle(metadata))
 	})
 }
+
+func TestSingleUseChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		return nil
+	})
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	registry := mocksvdr.NewMockRegistry(ctrl)
+	registry.EXPECT().Resolve("did:example:holder").Return(&did.DocResolution{DIDDocument: &did.Doc{
+		VerificationMethod: []did.VerificationMethod{{
+			ID:    "did:example:holder#key-1",
+			Type:  "Ed25519VerificationKey2018",
+			Value: signer.PublicKeyBytes(),
+		}},
+	}}, nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+	provider.EXPECT().JSONLDDocumentLoader().Return(loader).AnyTimes()
+
+	newMessage := func(challenge string) service.DIDCommMsgMap {
+		vp, e := verifiable.NewPresentation()
+		require.NoError(t, e)
+
+		if challenge != "" {
+			require.NoError(t, vp.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: verifiable.SignatureJWS,
+				Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+				VerificationMethod:      "did:example:holder#key-1",
+				Challenge:               challenge,
+			}, jsonld.WithDocumentLoader(loader)))
+		}
+
+		return service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgTypeV2,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: vp}},
+			},
+		})
+	}
+
+	newNonceStore := func() *nonce.Store {
+		store, e := nonce.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, time.Hour)
+		require.NoError(t, e)
+
+		return store
+	}
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return("state-name")
+		require.NoError(t, SingleUseChallenge(provider, newNonceStore())(next).Handle(metadata))
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{"@type": map[int]int{}})
+
+		err := SingleUseChallenge(provider, newNonceStore())(next).Handle(metadata)
+		require.Contains(t, fmt.Sprintf("%v", err), "get attachments")
+	})
+
+	t.Run("Invalid presentation", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgTypeV2,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: &verifiable.Presentation{
+					Context: []string{"https://www.w3.org/2018/presentation/v1"},
+				}}},
+			},
+		}))
+
+		err := SingleUseChallenge(provider, newNonceStore())(next).Handle(metadata)
+		require.Contains(t, fmt.Sprintf("%v", err), "to verifiable presentation")
+	})
+
+	t.Run("Challenge is used once", func(t *testing.T) {
+		store := newNonceStore()
+
+		for _, challenge := range []string{"", "", "challenge-1", "challenge-2"} {
+			metadata := mocks.NewMockMetadata(ctrl)
+			metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+			metadata.EXPECT().Message().Return(newMessage(challenge))
+
+			require.NoError(t, SingleUseChallenge(provider, store)(next).Handle(metadata))
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(newMessage("challenge-1"))
+
+		err := SingleUseChallenge(provider, store)(next).Handle(metadata)
+		require.True(t, errors.Is(err, nonce.ErrReplay))
+		require.Contains(t, err.Error(), "use presentation challenge")
+	})
+}
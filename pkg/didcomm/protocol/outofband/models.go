@@ -8,6 +8,10 @@ package outofband
 
 import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 
+// CredentialMimeType is the mime-type of the invitation attachments holding a signed JSON-LD verifiable credential,
+// which the invitee claims from the invitation alone, without a connection.
+const CredentialMimeType = "application/vc+ld+json"
+
 // Invitation is this protocol's `invitation` message.
 type Invitation struct {
 	ID        string                  `json:"@id"`
@@ -19,6 +23,7 @@ type Invitation struct {
 	Accept    []string                `json:"accept,omitempty"`
 	Protocols []string                `json:"handshake_protocols,omitempty"`
 	Requests  []*decorator.Attachment `json:"request~attach,omitempty"`
+	Timing    *decorator.Timing       `json:"~timing,omitempty"`
 }
 
 // HandshakeReuse is this protocol's 'handshake-reuse' message.
@@ -32,3 +37,9 @@ type HandshakeReuseAccepted struct {
 	ID   string `json:"@id"`
 	Type string `json:"@type"`
 }
+
+// IsCredentialAttachment checks whether the attachment holds a verifiable credential to claim rather than a request
+// to dispatch once connected.
+func IsCredentialAttachment(a *decorator.Attachment) bool {
+	return a.MimeType == CredentialMimeType
+}
@@ -10,9 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/mitchellh/mapstructure"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -216,6 +216,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// PIURIs are this service's protocol URIs.
+func (s *Service) PIURIs() []string {
+	return []string{PIURI}
+}
+
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -239,6 +244,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, didCommCtx service.DIDCo
 		return "", fmt.Errorf("no clients registered to handle action events for %s protocol", Name)
 	}
 
+	if err := s.rejectExpiredInvitation(msg); err != nil {
+		return "", err
+	}
+
 	myContext, err := s.currentContext(msg, didCommCtx, nil)
 	if err != nil {
 		return "", fmt.Errorf("unable to load current context for msgID=%s: %w", msg.ID(), err)
@@ -255,6 +264,27 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, didCommCtx service.DIDCo
 	return "", s.handleContext(myContext)
 }
 
+// rejectExpiredInvitation rejects the inbound invitations which have expired, raising a post-state event with the
+// error.
+func (s *Service) rejectExpiredInvitation(msg service.DIDCommMsg) error {
+	if msg.Type() != InvitationMsgType && msg.Type() != OldInvitationMsgType {
+		return nil
+	}
+
+	inv := &Invitation{}
+
+	if err := msg.Decode(inv); err != nil {
+		return fmt.Errorf("failed to decode invitation: %w", err)
+	}
+
+	err := checkExpiry(inv)
+	if err != nil {
+		go sendMsgEvent(service.PostState, StateNameInitial, &s.Message, msg.Clone(), &eventProps{Err: err})
+	}
+
+	return err
+}
+
 func (s *Service) handleContext(ctx *context) error { // nolint:funlen
 	logger.Debugf("context: %+v", ctx)
 
@@ -583,8 +613,19 @@ func (s *Service) AcceptInvitation(i *Invitation, options Options) (string, erro
 	return connID, nil
 }
 
-// SaveInvitation created by the outofband client.
-func (s *Service) SaveInvitation(i *Invitation) error {
+// SaveOption configures the acceptance of an invitation saved with SaveInvitation.
+type SaveOption func(*didexchange.OOBInvitation)
+
+// WithMaxAccepts limits the number of parties which can accept the invitation, unlimited if zero.
+func WithMaxAccepts(n int) SaveOption {
+	return func(i *didexchange.OOBInvitation) {
+		i.MaxAccepts = n
+	}
+}
+
+// SaveInvitation created by the outofband client. The requests referring to the invitation are rejected once its
+// `~timing.expires_time` has passed.
+func (s *Service) SaveInvitation(i *Invitation, opts ...SaveOption) error {
 	target, err := chooseTarget(i.Services)
 	if err != nil {
 		return fmt.Errorf("failed to choose a target to connect against : %w", err)
@@ -598,13 +639,23 @@ func (s *Service) SaveInvitation(i *Invitation) error {
 
 	logger.Debugf("saved invitation: %+v", i)
 
-	err = s.didSvc.SaveInvitation(&didexchange.OOBInvitation{
+	didInv := &didexchange.OOBInvitation{
 		ID:                uuid.New().String(),
 		ThreadID:          i.ID,
 		TheirLabel:        i.Label,
 		Target:            target,
 		MediaTypeProfiles: i.Accept,
-	})
+	}
+
+	if i.Timing != nil {
+		didInv.ExpiresTime = i.Timing.ExpiresTime
+	}
+
+	for _, opt := range opts {
+		opt(didInv)
+	}
+
+	err = s.didSvc.SaveInvitation(didInv)
 	if err != nil {
 		return fmt.Errorf("the didexchange service failed to save the oob invitation : %w", err)
 	}
@@ -785,8 +836,13 @@ func (s *Service) fetchAttachmentHandlingState(id string) (*attachmentHandlingSt
 //  - https://github.com/hyperledger/aries-rfcs/issues/451
 //  This logic should be injected into the service.
 func chooseAttachment(state *attachmentHandlingState) (*decorator.Attachment, error) {
-	if !state.Done && len(state.Invitation.Requests) > 0 {
-		return state.Invitation.Requests[0], nil
+	if !state.Done {
+		// the credentials are claimed from the invitation, they aren't dispatched.
+		for _, a := range state.Invitation.Requests {
+			if !IsCredentialAttachment(a) {
+				return a, nil
+			}
+		}
 	}
 
 	return nil, errors.New("not attachments in invitation")
@@ -861,6 +917,14 @@ func validateInvitationAcceptance(msg service.DIDCommMsg, myProfiles []string, o
 		return fmt.Errorf("no acceptable media type profile found in invitation")
 	}
 
+	return checkExpiry(inv)
+}
+
+func checkExpiry(inv *Invitation) error {
+	if inv.Timing != nil && !inv.Timing.ExpiresTime.IsZero() && time.Now().After(inv.Timing.ExpiresTime) {
+		return fmt.Errorf("invitation %s: %w", inv.ID, didexchange.ErrInvitationExpired)
+	}
+
 	return nil
 }
 
@@ -937,12 +1001,13 @@ func chooseTarget(svcs []interface{}) (interface{}, error) {
 		case map[string]interface{}:
 			var s did.Service
 
-			decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &s})
+			// the service endpoint may be in the legacy string form or in the DIDComm v2 object form
+			svcBytes, err := json.Marshal(svc)
 			if err != nil {
-				return nil, fmt.Errorf("failed to initialize decoder : %w", err)
+				return nil, fmt.Errorf("failed to marshal service block : %w", err)
 			}
 
-			err = decoder.Decode(svc)
+			err = json.Unmarshal(svcBytes, &s)
 			if err != nil {
 				return nil, fmt.Errorf("failed to decode service block : %w", err)
 			}
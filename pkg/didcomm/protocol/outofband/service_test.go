@@ -126,6 +126,36 @@ func TestHandleInbound(t *testing.T) {
 		_, err = s.HandleInbound(service.NewDIDCommMsgMap(req), service.NewDIDCommContext(myDID, theirDID, nil))
 		require.Error(t, err)
 	})
+	t.Run("rejects expired invitations", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+		require.NoError(t, s.RegisterActionEvent(make(chan service.DIDCommAction)))
+		states := make(chan service.StateMsg)
+		require.NoError(t, s.RegisterMsgEvent(states))
+		inv := newInvitation()
+		inv.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(-time.Minute)}
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(inv), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.True(t, errors.Is(err, didexchange.ErrInvitationExpired))
+		select {
+		case e := <-states:
+			require.Equal(t, service.PostState, e.Type)
+			require.Equal(t, StateNameInitial, e.StateID)
+			props, ok := e.Properties.(*eventProps)
+			require.True(t, ok)
+			require.True(t, errors.Is(props.Error(), didexchange.ErrInvitationExpired))
+		case <-time.After(1 * time.Second):
+			t.Error("timeout waiting for post-state event")
+		}
+	})
+	t.Run("rejects invalid invitations", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+		require.NoError(t, s.RegisterActionEvent(make(chan service.DIDCommAction)))
+		_, err = s.HandleInbound(service.DIDCommMsgMap{"@type": InvitationMsgType, "~timing": 1},
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decode invitation")
+	})
 	t.Run("fires off an action event", func(t *testing.T) {
 		expected := service.NewDIDCommMsgMap(newInvitation())
 		s, err := New(testProvider())
@@ -856,6 +886,14 @@ func TestAcceptInvitation(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "no acceptable media type profile found in invitation")
 	})
+	t.Run("error if invitation has expired", func(t *testing.T) {
+		s := newAutoService(t, testProvider())
+		inv := newInvitation()
+		inv.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(-time.Minute)}
+		_, err := s.AcceptInvitation(inv, &userOptions{})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, didexchange.ErrInvitationExpired))
+	})
 }
 
 func TestSaveInvitation(t *testing.T) {
@@ -911,6 +949,21 @@ func TestSaveInvitation(t *testing.T) {
 		err := s.SaveInvitation(inv)
 		require.Error(t, err)
 	})
+	t.Run("saves the expiry and max accepts of the invitation", func(t *testing.T) {
+		expected := newInvitation()
+		expected.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)}
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			SaveFunc: func(i *didexchange.OOBInvitation) error {
+				require.Equal(t, expected.Timing.ExpiresTime, i.ExpiresTime)
+				require.Equal(t, 2, i.MaxAccepts)
+				return nil
+			},
+		}
+		s := newAutoService(t, provider)
+		err := s.SaveInvitation(expected, WithMaxAccepts(2))
+		require.NoError(t, err)
+	})
 	t.Run("wraps error from didexchange service", func(t *testing.T) {
 		expected := errors.New("test")
 		provider := testProvider()
@@ -966,12 +1019,60 @@ func TestChooseTarget(t *testing.T) {
 		require.Equal(t, expected["routingKeys"], result.RoutingKeys)
 		require.Equal(t, expected["serviceEndpoint"], result.ServiceEndpoint)
 	})
+	t.Run("chooses a map-type service with a DIDComm v2 service endpoint", func(t *testing.T) {
+		expected := map[string]interface{}{
+			"id":            uuid.New().String(),
+			"type":          "DIDCommMessaging",
+			"recipientKeys": []string{"my ver key"},
+			"serviceEndpoint": map[string]interface{}{
+				"uri":         "my service endpoint",
+				"accept":      []string{"didcomm/v2"},
+				"routingKeys": []string{"my routing key"},
+			},
+		}
+		svc, err := chooseTarget([]interface{}{expected})
+		require.NoError(t, err)
+		result, ok := svc.(*did.Service)
+		require.True(t, ok)
+		require.Equal(t, "my service endpoint", result.ServiceEndpoint)
+		require.Equal(t, []string{"didcomm/v2"}, result.Accept)
+		require.Equal(t, []string{"my routing key"}, result.RoutingKeys)
+		require.True(t, result.EndpointObject)
+	})
 	t.Run("fails if not services are specified", func(t *testing.T) {
 		_, err := chooseTarget([]interface{}{})
 		require.Error(t, err)
 	})
 }
 
+func TestChooseAttachment(t *testing.T) {
+	request := &decorator.Attachment{ID: "request", MimeType: "application/json"}
+	credential := &decorator.Attachment{ID: "credential", MimeType: CredentialMimeType}
+
+	t.Run("skips the credentials", func(t *testing.T) {
+		a, err := chooseAttachment(&attachmentHandlingState{
+			Invitation: &Invitation{Requests: []*decorator.Attachment{credential, request}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, request, a)
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		_, err := chooseAttachment(&attachmentHandlingState{
+			Invitation: &Invitation{Requests: []*decorator.Attachment{credential}},
+		})
+		require.EqualError(t, err, "not attachments in invitation")
+	})
+
+	t.Run("request already dispatched", func(t *testing.T) {
+		_, err := chooseAttachment(&attachmentHandlingState{
+			Invitation: &Invitation{Requests: []*decorator.Attachment{request}},
+			Done:       true,
+		})
+		require.EqualError(t, err, "not attachments in invitation")
+	})
+}
+
 func testProvider() *protocol.MockProvider {
 	return &protocol.MockProvider{
 		StoreProvider:              mockstore.NewMockStoreProvider(),
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -258,7 +259,9 @@ func New(p Provider) (*Service, error) {
 		return nil, err
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{transitionalPayloadKey}})
+	err = p.StorageProvider().SetStoreConfig(Name, storage.StoreConfiguration{TagNames: []string{
+		transitionalPayloadKey, retention.UpdatedTagName, retention.TerminalTagName,
+	}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -286,6 +289,13 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// AddMiddleware appends the given Middleware to the chain of middlewares.
+func (s *Service) AddMiddleware(mw ...Middleware) {
+	for i := len(mw) - 1; i >= 0; i-- {
+		s.middleware = mw[i](s.middleware)
+	}
+}
+
 // HandleInbound handles inbound message (presentproof protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
@@ -479,7 +489,9 @@ func (s *Service) saveInternalData(piID string, data *internalData) error {
 		return err
 	}
 
-	return s.store.Put(internalDataKey+piID, src)
+	terminal := data.StateName == stateNameDone || data.StateName == stateNameAbandoned
+
+	return s.store.Put(internalDataKey+piID, src, retention.Tags(terminal)...)
 }
 
 func (s *Service) currentInternalData(piID string) (*internalData, error) {
@@ -777,6 +789,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// PIURIs returns the present proof v2 and v3 URIs.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(SpecV2, "/"), strings.TrimSuffix(SpecV3, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
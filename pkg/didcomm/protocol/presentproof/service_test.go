@@ -25,6 +25,7 @@ import (
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -153,6 +154,39 @@ func TestService_Use(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Success (added function)", func(t *testing.T) {
+		storeProvider := storageMocks.NewMockProvider(ctrl)
+		storeProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil).Times(1)
+		storeProvider.EXPECT().SetStoreConfig(Name, gomock.Any()).Return(nil)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(storeProvider).Times(2)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		var executed bool
+		svc.Use(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				require.True(t, executed)
+				return next.Handle(metadata)
+			})
+		})
+
+		svc.AddMiddleware(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				executed = true
+				return next.Handle(metadata)
+			})
+		})
+
+		_, _, err = svc.execute(&done{}, &metaData{})
+		require.NoError(t, err)
+		require.True(t, executed)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		storeProvider := storageMocks.NewMockProvider(ctrl)
 		storeProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil).Times(1)
@@ -374,7 +408,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "abandoned"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -429,7 +463,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "request-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -437,7 +471,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "presentation-sent"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -587,7 +621,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "request-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -595,7 +629,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "proposal-sent"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -648,7 +682,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "proposal-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -656,7 +690,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "request-sent"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -702,7 +736,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(src, nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			src, err = json.Marshal(&internalData{StateName: "abandoned"})
@@ -748,7 +782,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(src, nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			src, err = json.Marshal(&internalData{StateName: "abandoned"})
@@ -804,7 +838,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "proposal-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -812,7 +846,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "abandoned"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -867,7 +901,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(src, nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "presentation-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -875,7 +909,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "done"})
@@ -942,7 +976,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		store.EXPECT().Get(gomock.Any()).Return(src, nil)
 		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().Delete(gomock.Any()).Return(nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "presentation-received"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -950,7 +984,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 			return nil
 		})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "done"})
@@ -1005,7 +1039,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 		require.NoError(t, err)
 
 		store.EXPECT().Get(gomock.Any()).Return(src, nil)
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			defer close(done)
 
 			src, err = json.Marshal(&internalData{StateName: "done"})
@@ -1035,7 +1069,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 	t.Run("Send Invitation Presentation", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "request-sent"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -1090,7 +1124,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 	t.Run("Send Proposal", func(t *testing.T) {
 		done := make(chan struct{})
 
-		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte, _ ...storage.Tag) error {
 			src, err := json.Marshal(&internalData{StateName: "proposal-sent"})
 			require.NoError(t, err)
 			require.Equal(t, src, data)
@@ -1127,7 +1161,7 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 	})
 
 	t.Run("Send Proposal with error", func(t *testing.T) {
-		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		svc, err := New(provider)
 		require.NoError(t, err)
@@ -1143,6 +1177,25 @@ func TestService_HandleInboundOutbound(t *testing.T) {
 	})
 }
 
+func TestService_saveInternalData(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore(Name)
+	require.NoError(t, err)
+
+	svc := &Service{store: store}
+
+	require.NoError(t, svc.saveInternalData("piid-1", &internalData{StateName: stateNameRequestSent}))
+	require.NoError(t, svc.saveInternalData("piid-2", &internalData{StateName: stateNameDone}))
+	require.NoError(t, svc.saveInternalData("piid-3", &internalData{StateName: stateNameAbandoned}))
+
+	for piID, terminal := range map[string]bool{"piid-1": false, "piid-2": true, "piid-3": true} {
+		tags, err := store.GetTags(internalDataKey + piID)
+		require.NoError(t, err)
+
+		require.Equal(t, retention.UpdatedTagName, tags[0].Name)
+		require.Equal(t, terminal, len(tags) == 2 && tags[1].Name == retention.TerminalTagName)
+	}
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart, SpecV2), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoned, SpecV2), &abandoned{V: SpecV2})
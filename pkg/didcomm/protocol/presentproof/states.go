@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 )
 
 const (
@@ -36,6 +37,7 @@ const (
 	// error codes.
 	codeInternalError = "internal"
 	codeRejectedError = "rejected"
+	codeReplayError   = "replay"
 )
 
 // state action for network call.
@@ -107,6 +109,11 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 		code = model.Code{Code: codeRejectedError}
 	}
 
+	// the presentation was replayed, its challenge being already used
+	if errors.Is(md.err, nonce.ErrReplay) {
+		code = model.Code{Code: codeReplayError}
+	}
+
 	thID, err := md.Msg.ThreadID()
 	if err != nil {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 )
 
 func TestStart_CanTransitionTo(t *testing.T) {
@@ -156,6 +157,33 @@ func TestAbandoning_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Replay Error", func(t *testing.T) {
+		md := &metaData{err: fmt.Errorf("challenge: %w", nonce.ErrReplay)}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+		md.Msg.SetID(uuid.New().String())
+
+		followup, action, err := (&abandoned{V: SpecV2, Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeReplayError, r.Description.Code)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
 	t.Run("No error code", func(t *testing.T) {
 		md := &metaData{}
 		md.Msg = service.NewDIDCommMsgMap(struct{}{})
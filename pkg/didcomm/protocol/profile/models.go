@@ -0,0 +1,28 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// ProfileMsg shares the profile of an agent with the other party of a connection, threaded to the request it
+// responds to, if any.
+type ProfileMsg struct {
+	Type    string  `json:"@type,omitempty"`
+	ID      string  `json:"@id,omitempty"`
+	Profile Profile `json:"profile"`
+	// SendBackYours asks the other party to send its profile in response.
+	SendBackYours bool              `json:"send_back_yours,omitempty"`
+	Thread        *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// RequestProfile requests the profile of the other party of a connection.
+type RequestProfile struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
@@ -0,0 +1,356 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package profile implements the profile protocol (RFC 0557-like): the agents share their display name, picture
+// hashlink and organization with their connections. The profile received from the other party is saved in the
+// connection record, as a richer identity hint than the label of the invitation, and changes are reported with a
+// message event.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// Name defines the protocol name.
+	Name = "profile"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/profile/1.0/"
+	// ProfileMsgType defines the protocol profile message type.
+	ProfileMsgType = Spec + "profile"
+	// RequestProfileMsgType defines the protocol request profile message type.
+	RequestProfileMsgType = Spec + "request-profile"
+
+	// Namespace is namespace of profile store name.
+	Namespace = "profile"
+
+	myProfileKey   = "my_profile"
+	hashlinkPrefix = "hl:"
+)
+
+// State IDs of the message events triggered by the service.
+const (
+	// StateProfileUpdated is the state of the party receiving a profile that differs from the one saved in the
+	// connection record.
+	StateProfileUpdated = "profile-updated"
+	// StateProfileRequested is the state of the party receiving a profile request, its profile is sent in response.
+	StateProfileRequested = "profile-requested"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrInvalidProfile is returned when a profile is invalid, e.g. its picture isn't a hashlink.
+	ErrInvalidProfile = errors.New("invalid profile")
+
+	logger = log.New("aries-framework/profile")
+)
+
+// Profile is the public profile of an agent.
+type Profile = connection.Profile
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Service for the profile protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connections *connection.Recorder
+	outbound    dispatcher.Outbound
+	store       storage.Store
+	lock        sync.Mutex
+}
+
+// New returns the profile service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open profile store: %w", err)
+	}
+
+	connections, err := connection.NewRecorder(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		outbound:    prov.OutboundDispatcher(),
+		store:       store,
+		connections: connections,
+	}, nil
+}
+
+// HandleInbound handles inbound profile messages: the profiles received are saved in the connection record, and the
+// profile of the agent is sent to the other party when requested.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	connectionID, err := s.connections.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("profile - get connection: %w", err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("profile - thread ID: %w", err)
+	}
+
+	sendBack := false
+
+	switch msg.Type() {
+	case ProfileMsgType:
+		profileMsg := &ProfileMsg{}
+
+		if err = msg.Decode(profileMsg); err != nil {
+			return "", fmt.Errorf("profile - decode %s: %w", msg.Type(), err)
+		}
+
+		if err = s.saveTheirProfile(connectionID, thID, msg, &profileMsg.Profile); err != nil {
+			return "", fmt.Errorf("profile - handle %s: %w", msg.Type(), err)
+		}
+
+		sendBack = profileMsg.SendBackYours
+	case RequestProfileMsgType:
+		s.triggerEvent(service.StateMsg{
+			ProtocolName: Name,
+			Type:         service.PostState,
+			StateID:      StateProfileRequested,
+			Msg:          msg,
+			Properties:   &eventProps{connectionID: connectionID, threadID: thID},
+		})
+
+		sendBack = true
+	default:
+		return "", fmt.Errorf("profile - unsupported message type %s", msg.Type())
+	}
+
+	if sendBack {
+		if _, err = s.sendProfile(ctx.MyDID(), ctx.TheirDID(), &decorator.Thread{ID: thID}, false); err != nil {
+			return "", err
+		}
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the profile message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("profile - send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case ProfileMsgType, RequestProfileMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return Name
+}
+
+// PIURIs of the profile protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// SetProfile saves the profile of the agent. With share, the profile is also sent to all the completed connections,
+// the connections failing to receive it are logged.
+func (s *Service) SetProfile(profile *Profile, share bool) error {
+	if err := validate(profile); err != nil {
+		return err
+	}
+
+	profileBytes, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("marshal profile: %w", err)
+	}
+
+	if err = s.store.Put(myProfileKey, profileBytes); err != nil {
+		return fmt.Errorf("save profile: %w", err)
+	}
+
+	if !share {
+		return nil
+	}
+
+	records, err := s.connections.QueryConnectionRecords()
+	if err != nil {
+		return fmt.Errorf("query connection records: %w", err)
+	}
+
+	for _, conn := range records {
+		if conn.State != connection.StateNameCompleted {
+			continue
+		}
+
+		if _, err = s.sendProfile(conn.MyDID, conn.TheirDID, nil, false); err != nil {
+			logger.Warnf("failed to share profile with connection %s: %s", conn.ConnectionID, err)
+		}
+	}
+
+	return nil
+}
+
+// Profile returns the profile of the agent, empty if it wasn't set.
+func (s *Service) Profile() (*Profile, error) {
+	profileBytes, err := s.store.Get(myProfileKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &Profile{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get profile: %w", err)
+	}
+
+	profile := &Profile{}
+
+	if err = json.Unmarshal(profileBytes, profile); err != nil {
+		return nil, fmt.Errorf("unmarshal profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// SendProfile sends the profile of the agent to the connection, returning the ID of the message. With sendBackYours,
+// the other party is asked to send its profile in response.
+func (s *Service) SendProfile(connectionID string, sendBackYours bool) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.sendProfile(conn.MyDID, conn.TheirDID, nil, sendBackYours)
+}
+
+// RequestProfile requests the profile of the other party of the connection, returning the ID of the request.
+func (s *Service) RequestProfile(connectionID string) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	request := &RequestProfile{
+		Type: RequestProfileMsgType,
+		ID:   uuid.New().String(),
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(request), conn.MyDID, conn.TheirDID)
+}
+
+// TheirProfile returns the profile received from the other party of the connection, nil if none was received.
+func (s *Service) TheirProfile(connectionID string) (*Profile, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.TheirProfile, nil
+}
+
+func (s *Service) sendProfile(myDID, theirDID string, thread *decorator.Thread, sendBackYours bool) (string, error) {
+	profile, err := s.Profile()
+	if err != nil {
+		return "", err
+	}
+
+	msg := &ProfileMsg{
+		Type:          ProfileMsgType,
+		ID:            uuid.New().String(),
+		Profile:       *profile,
+		SendBackYours: sendBackYours,
+		Thread:        thread,
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+func (s *Service) saveTheirProfile(connectionID, thID string, msg service.DIDCommMsg, profile *Profile) error {
+	if err := validate(profile); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if conn.TheirProfile != nil && reflect.DeepEqual(conn.TheirProfile, profile) {
+		return nil
+	}
+
+	conn.TheirProfile = profile
+
+	if err = s.connections.SaveConnectionRecord(conn); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PostState,
+		StateID:      StateProfileUpdated,
+		Msg:          msg,
+		Properties:   &eventProps{connectionID: connectionID, threadID: thID},
+	})
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) triggerEvent(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+
+	logger.Debugf("profile - %s on connection %s", msg.StateID, msg.Properties.All()[connectionIDPropKey])
+}
+
+func validate(profile *Profile) error {
+	if profile.DisplayPicture != "" && !strings.HasPrefix(profile.DisplayPicture, hashlinkPrefix) {
+		return fmt.Errorf("%w: display picture %s isn't a hashlink", ErrInvalidProfile, profile.DisplayPicture)
+	}
+
+	return nil
+}
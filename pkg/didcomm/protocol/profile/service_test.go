@@ -0,0 +1,299 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	aliceDID     = "did:example:alice"
+	bobDID       = "did:example:bob"
+	connectionID = "conn-1"
+	pictureLink  = "hl:zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+		require.Equal(t, Name, svc.Name())
+		require.True(t, svc.Accept(ProfileMsgType))
+		require.True(t, svc.Accept(RequestProfileMsgType))
+		require.Equal(t, []string{"https://didcomm.org/profile/1.0"}, svc.PIURIs())
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open profile store")
+	})
+}
+
+func TestService_SetProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		a := newAgents(t)
+
+		profile, err := a.alice.Profile()
+		require.NoError(t, err)
+		require.Equal(t, &Profile{}, profile)
+
+		aliceProfile := &Profile{DisplayName: "Alice", DisplayPicture: pictureLink, Organization: "Faber College"}
+
+		require.NoError(t, a.alice.SetProfile(aliceProfile, false))
+		require.Nil(t, a.sent)
+
+		profile, err = a.alice.Profile()
+		require.NoError(t, err)
+		require.Equal(t, aliceProfile, profile)
+
+		require.NoError(t, a.alice.SetProfile(aliceProfile, true))
+
+		msg := &ProfileMsg{}
+		require.NoError(t, a.sent.Decode(msg))
+		require.Equal(t, ProfileMsgType, msg.Type)
+		require.Equal(t, *aliceProfile, msg.Profile)
+		require.False(t, msg.SendBackYours)
+	})
+
+	t.Run("invalid picture", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		err := svc.SetProfile(&Profile{DisplayPicture: "https://example.com/alice.png"}, false)
+		require.True(t, errors.Is(err, ErrInvalidProfile))
+	})
+
+	t.Run("send errors are logged", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				return errors.New("send error")
+			},
+		}, aliceDID, bobDID)
+
+		require.NoError(t, svc.SetProfile(&Profile{DisplayName: "Alice"}, true))
+	})
+}
+
+func TestService_SendProfile(t *testing.T) {
+	t.Run("profiles exchanged", func(t *testing.T) {
+		a := newAgents(t)
+
+		require.NoError(t, a.alice.SetProfile(&Profile{DisplayName: "Alice"}, false))
+		require.NoError(t, a.bob.SetProfile(&Profile{DisplayName: "Bob", URL: "https://bob.example.com"}, false))
+
+		msgID, err := a.alice.SendProfile(connectionID, true)
+		require.NoError(t, err)
+
+		state := a.deliver(t, a.bob, bobDID, aliceDID)
+		require.NotNil(t, state)
+		require.Equal(t, Name, state.ProtocolName)
+		require.Equal(t, StateProfileUpdated, state.StateID)
+		require.Equal(t, map[string]interface{}{
+			connectionIDPropKey: connectionID,
+			threadIDPropKey:     msgID,
+		}, state.Properties.All())
+
+		profile, err := a.bob.TheirProfile(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, &Profile{DisplayName: "Alice"}, profile)
+
+		response := &ProfileMsg{}
+		require.NoError(t, a.sent.Decode(response))
+		require.Equal(t, msgID, response.Thread.ID)
+		require.False(t, response.SendBackYours)
+
+		state = a.deliver(t, a.alice, aliceDID, bobDID)
+		require.NotNil(t, state)
+		require.Equal(t, msgID, state.Properties.All()[threadIDPropKey])
+
+		profile, err = a.alice.TheirProfile(connectionID)
+		require.NoError(t, err)
+		require.Equal(t, &Profile{DisplayName: "Bob", URL: "https://bob.example.com"}, profile)
+	})
+
+	t.Run("unchanged profile", func(t *testing.T) {
+		a := newAgents(t)
+
+		require.NoError(t, a.alice.SetProfile(&Profile{DisplayName: "Alice"}, false))
+
+		_, err := a.alice.SendProfile(connectionID, false)
+		require.NoError(t, err)
+		require.NotNil(t, a.deliver(t, a.bob, bobDID, aliceDID))
+
+		a.sent = nil
+
+		_, err = a.alice.SendProfile(connectionID, false)
+		require.NoError(t, err)
+
+		msg := a.sent
+		require.Nil(t, a.deliver(t, a.bob, bobDID, aliceDID))
+		require.Equal(t, msg, a.sent)
+	})
+
+	t.Run("invalid profile received", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, bobDID, aliceDID)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&ProfileMsg{
+			Type:    ProfileMsgType,
+			ID:      "profile-1",
+			Profile: Profile{DisplayPicture: "picture.png"},
+		}), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.True(t, errors.Is(err, ErrInvalidProfile))
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		_, err := svc.SendProfile("unknown", false)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.RequestProfile("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.TheirProfile("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&RequestProfile{Type: RequestProfileMsgType}),
+			service.NewDIDCommContext(aliceDID, "did:example:carol", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "profile - get connection")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				return errors.New("send error")
+			},
+		}, aliceDID, bobDID)
+
+		_, err := svc.SendProfile(connectionID, false)
+		require.EqualError(t, err, "profile - send "+ProfileMsgType+": send error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&ProfileMsg{Type: "unknown", ID: "profile-1"}),
+			service.NewDIDCommContext(aliceDID, bobDID, nil))
+		require.EqualError(t, err, "profile - unsupported message type unknown")
+	})
+}
+
+func TestService_RequestProfile(t *testing.T) {
+	a := newAgents(t)
+
+	require.NoError(t, a.bob.SetProfile(&Profile{DisplayName: "Bob"}, false))
+
+	requestID, err := a.alice.RequestProfile(connectionID)
+	require.NoError(t, err)
+
+	state := a.deliver(t, a.bob, bobDID, aliceDID)
+	require.NotNil(t, state)
+	require.Equal(t, StateProfileRequested, state.StateID)
+
+	response := &ProfileMsg{}
+	require.NoError(t, a.sent.Decode(response))
+	require.Equal(t, requestID, response.Thread.ID)
+	require.Equal(t, Profile{DisplayName: "Bob"}, response.Profile)
+
+	state = a.deliver(t, a.alice, aliceDID, bobDID)
+	require.NotNil(t, state)
+	require.Equal(t, StateProfileUpdated, state.StateID)
+
+	profile, err := a.alice.TheirProfile(connectionID)
+	require.NoError(t, err)
+	require.Equal(t, "Bob", profile.DisplayName)
+}
+
+type agents struct {
+	alice *Service
+	bob   *Service
+	sent  service.DIDCommMsgMap
+}
+
+// deliver hands the last message sent to the service of the receiver, returning the message event triggered, nil if
+// none was.
+func (a *agents) deliver(t *testing.T, receiver *Service, myDID, theirDID string) *service.StateMsg {
+	t.Helper()
+
+	states := make(chan service.StateMsg, 1)
+	require.NoError(t, receiver.RegisterMsgEvent(states))
+
+	defer func() {
+		require.NoError(t, receiver.UnregisterMsgEvent(states))
+	}()
+
+	_, err := receiver.HandleInbound(a.sent, service.NewDIDCommContext(myDID, theirDID, nil))
+	require.NoError(t, err)
+
+	select {
+	case state := <-states:
+		return &state
+	default:
+		return nil
+	}
+}
+
+func newAgents(t *testing.T) *agents {
+	t.Helper()
+
+	a := &agents{}
+
+	outbound := &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			a.sent = msg.(service.DIDCommMsgMap)
+
+			return nil
+		},
+	}
+
+	a.alice, _ = newService(t, outbound, aliceDID, bobDID)
+	a.bob, _ = newService(t, outbound, bobDID, aliceDID)
+
+	return a
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound, myDID, theirDID string) (*Service,
+	*connection.Recorder) {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	return svc, recorder
+}
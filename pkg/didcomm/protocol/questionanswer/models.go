@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Question is sent by the questioner to ask the responder to pick one of the valid responses.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0113-question-answer#question
+type Question struct {
+	Type           string `json:"@type,omitempty"`
+	ID             string `json:"@id,omitempty"`
+	QuestionText   string `json:"question_text"`
+	QuestionDetail string `json:"question_detail,omitempty"`
+	// Nonce is signed with the response, it binds the answer signature to this question.
+	Nonce string `json:"nonce,omitempty"`
+	// SignatureRequired asks the responder to sign the answer with a key of its DID.
+	SignatureRequired bool              `json:"signature_required,omitempty"`
+	ValidResponses    []ValidResponse   `json:"valid_responses"`
+	Timing            *decorator.Timing `json:"~timing,omitempty"`
+}
+
+// ValidResponse is a response the responder can pick.
+type ValidResponse struct {
+	Text string `json:"text"`
+}
+
+// Answer is sent by the responder with the response picked, threaded to the question.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0113-question-answer#answer
+type Answer struct {
+	Type              string             `json:"@type,omitempty"`
+	ID                string             `json:"@id,omitempty"`
+	Response          string             `json:"response"`
+	ResponseSignature *ResponseSignature `json:"response~sig,omitempty"`
+	Thread            *decorator.Thread  `json:"~thread,omitempty"`
+}
+
+// ResponseSignature is the ed25519Sha512_single signature of the answer.
+type ResponseSignature struct {
+	Type string `json:"@type,omitempty"`
+	// Signature is the base64URL encoded signature of the signed data.
+	Signature string `json:"signature"`
+	// SignedData is the base64URL encoded 64 bit unix epoch timestamp followed by the question text, the response
+	// and the nonce of the question.
+	SignedData string `json:"sig_data"`
+	// Signer is the base58 encoded Ed25519 public key of the responder.
+	Signer string `json:"signer"`
+}
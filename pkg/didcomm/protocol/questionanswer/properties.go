@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+const (
+	connectionIDPropKey = "connectionID"
+	threadIDPropKey     = "threadID"
+)
+
+type eventProps struct {
+	connectionID string
+	threadID     string
+}
+
+// ConnectionID returns the ID of the connection the message was received on.
+func (e *eventProps) ConnectionID() string {
+	return e.connectionID
+}
+
+// ThreadID returns the thread ID of the message.
+func (e *eventProps) ThreadID() string {
+	return e.threadID
+}
+
+// All implements EventProperties interface.
+func (e eventProps) All() map[string]interface{} {
+	all := map[string]interface{}{}
+	if e.connectionID != "" {
+		all[connectionIDPropKey] = e.connectionID
+	}
+
+	if e.threadID != "" {
+		all[threadIDPropKey] = e.threadID
+	}
+
+	return all
+}
@@ -0,0 +1,533 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// QuestionAnswer defines the protocol name.
+	QuestionAnswer = "questionanswer"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/questionanswer/1.0/"
+	// QuestionMsgType defines the protocol question message type.
+	QuestionMsgType = Spec + "question"
+	// AnswerMsgType defines the protocol answer message type.
+	AnswerMsgType = Spec + "answer"
+	// SignatureType is the type of the answer signatures.
+	SignatureType = "https://didcomm.org/signature/1.0/ed25519Sha512_single"
+
+	// Namespace is namespace of question answer store name.
+	Namespace = "questionanswer"
+
+	sentQuestionKey     = "sent_"
+	receivedQuestionKey = "received_"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	jsonWebKey2020             = "JsonWebKey2020"
+	ed25519Curve               = "Ed25519"
+	timestampSize              = 8
+)
+
+// State IDs of the message events triggered for the incoming messages.
+const (
+	// StateQuestionReceived is the state of the responder asked a question, to be answered with SendAnswer.
+	StateQuestionReceived = "question-received"
+	// StateAnswerReceived is the state of the questioner receiving a valid answer to its question.
+	StateAnswerReceived = "answer-received"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrQuestionNotFound is returned when the question was not asked on the connection or was already answered.
+	ErrQuestionNotFound = errors.New("question not found")
+	// ErrQuestionExpired is returned when the question is answered after its expiry time.
+	ErrQuestionExpired = errors.New("question expired")
+	// ErrInvalidResponse is returned when the response is not one of the valid responses of the question.
+	ErrInvalidResponse = errors.New("response is not a valid response of the question")
+	// ErrInvalidSignature is returned when the answer to a question requiring a signature is not signed by the
+	// responder.
+	ErrInvalidSignature = errors.New("invalid response signature")
+
+	logger = log.New("aries-framework/questionanswer")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	VDRegistry() vdrapi.Registry
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
+}
+
+type questionRecord struct {
+	ConnectionID string    `json:"connectionID"`
+	Question     *Question `json:"question"`
+}
+
+// Service for the question answer protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	questionStore    storage.Store
+	kms              kms.KeyManager
+	crypto           crypto.Crypto
+	vdr              vdrapi.Registry
+}
+
+// New returns the question answer service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open question answer store: %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		outbound:         prov.OutboundDispatcher(),
+		questionStore:    store,
+		connectionLookup: connectionLookup,
+		kms:              prov.KMS(),
+		crypto:           prov.Crypto(),
+		vdr:              prov.VDRegistry(),
+	}, nil
+}
+
+// HandleInbound handles inbound question answer messages, saving the questions received, validating the answers
+// received and triggering message events.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	connectionID, err := s.connectionLookup.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("question answer - get connection: %w", err)
+	}
+
+	var stateID string
+
+	switch msg.Type() {
+	case QuestionMsgType:
+		stateID = StateQuestionReceived
+		err = s.saveReceivedQuestion(connectionID, msg)
+	case AnswerMsgType:
+		stateID = StateAnswerReceived
+		err = s.checkAnswer(connectionID, ctx.TheirDID(), msg)
+	default:
+		return "", fmt.Errorf("question answer - unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("question answer - handle %s: %w", msg.Type(), err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("question answer - thread ID: %w", err)
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: QuestionAnswer,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Msg:          msg,
+		Properties:   &eventProps{connectionID: connectionID, threadID: thID},
+	})
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the question answer message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("question answer - send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QuestionMsgType, AnswerMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return QuestionAnswer
+}
+
+// PIURIs of the question-answer protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// SendQuestion asks the connection the question, returning the ID of the question message. A nonce is generated
+// for the questions without one.
+func (s *Service) SendQuestion(connectionID string, question *Question) (string, error) {
+	if len(question.ValidResponses) == 0 {
+		return "", errors.New("question has no valid responses")
+	}
+
+	question.Type = QuestionMsgType
+	if question.ID == "" {
+		question.ID = uuid.New().String()
+	}
+
+	if question.Nonce == "" {
+		question.Nonce = uuid.New().String()
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.putQuestion(sentQuestionKey, &questionRecord{ConnectionID: connectionID, Question: question})
+	if err != nil {
+		return "", err
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(question), conn.MyDID, conn.TheirDID)
+}
+
+// SendAnswer answers the question received from the connection with one of its valid responses, returning the ID
+// of the answer message. The answer is signed with the first Ed25519 authentication key of the connection DID when
+// the question requires a signature. A question can be answered once.
+func (s *Service) SendAnswer(connectionID, questionID, response string) (string, error) {
+	record, err := s.getQuestion(receivedQuestionKey, questionID)
+	if err != nil {
+		return "", err
+	}
+
+	if record.ConnectionID != connectionID {
+		return "", ErrQuestionNotFound
+	}
+
+	if err = checkResponse(record.Question, response); err != nil {
+		return "", err
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	answer := &Answer{
+		Type:     AnswerMsgType,
+		ID:       uuid.New().String(),
+		Response: response,
+		Thread:   &decorator.Thread{ID: questionID},
+	}
+
+	if record.Question.SignatureRequired {
+		answer.ResponseSignature, err = s.signResponse(conn.MyDID, signedData(record.Question, response))
+		if err != nil {
+			return "", fmt.Errorf("sign response: %w", err)
+		}
+	}
+
+	msgID, err := s.HandleOutbound(service.NewDIDCommMsgMap(answer), conn.MyDID, conn.TheirDID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.questionStore.Delete(receivedQuestionKey + questionID); err != nil {
+		return "", fmt.Errorf("delete answered question: %w", err)
+	}
+
+	return msgID, nil
+}
+
+// Question returns the question received with the given ID, if not answered yet.
+func (s *Service) Question(questionID string) (*Question, error) {
+	record, err := s.getQuestion(receivedQuestionKey, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Question, nil
+}
+
+func (s *Service) saveReceivedQuestion(connectionID string, msg service.DIDCommMsg) error {
+	question := &Question{}
+
+	err := msg.Decode(question)
+	if err != nil {
+		return err
+	}
+
+	return s.putQuestion(receivedQuestionKey, &questionRecord{ConnectionID: connectionID, Question: question})
+}
+
+func (s *Service) checkAnswer(connectionID, theirDID string, msg service.DIDCommMsg) error {
+	answer := &Answer{}
+
+	err := msg.Decode(answer)
+	if err != nil {
+		return err
+	}
+
+	if answer.Thread == nil {
+		return ErrQuestionNotFound
+	}
+
+	record, err := s.getQuestion(sentQuestionKey, answer.Thread.ID)
+	if err != nil {
+		return err
+	}
+
+	if record.ConnectionID != connectionID {
+		return ErrQuestionNotFound
+	}
+
+	if err = checkResponse(record.Question, answer.Response); err != nil {
+		return err
+	}
+
+	if record.Question.SignatureRequired {
+		err = s.verifyResponse(theirDID, answer.ResponseSignature, signedData(record.Question, answer.Response))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = s.questionStore.Delete(sentQuestionKey + answer.Thread.ID); err != nil {
+		return fmt.Errorf("delete answered question: %w", err)
+	}
+
+	return nil
+}
+
+func checkResponse(question *Question, response string) error {
+	if question.Timing != nil && !question.Timing.ExpiresTime.IsZero() &&
+		time.Now().After(question.Timing.ExpiresTime) {
+		return ErrQuestionExpired
+	}
+
+	for _, valid := range question.ValidResponses {
+		if valid.Text == response {
+			return nil
+		}
+	}
+
+	return ErrInvalidResponse
+}
+
+func signedData(question *Question, response string) []byte {
+	return []byte(question.QuestionText + response + question.Nonce)
+}
+
+func (s *Service) signResponse(myDID string, data []byte) (*ResponseSignature, error) {
+	doc, err := s.vdr.Resolve(myDID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID: %w", err)
+	}
+
+	vm, err := signingKey(doc.DIDDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	kmsKID, err := localkms.CreateKID(vm.Value, kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create KMS key ID: %w", err)
+	}
+
+	kh, err := s.kms.Get(kmsKID)
+	if err != nil {
+		return nil, fmt.Errorf("get signing key handle: %w", err)
+	}
+
+	sigData := make([]byte, timestampSize, timestampSize+len(data))
+	binary.BigEndian.PutUint64(sigData, uint64(time.Now().Unix()))
+	sigData = append(sigData, data...)
+
+	sig, err := s.crypto.Sign(sigData, kh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseSignature{
+		Type:       SignatureType,
+		Signature:  base64.URLEncoding.EncodeToString(sig),
+		SignedData: base64.URLEncoding.EncodeToString(sigData),
+		Signer:     base58.Encode(vm.Value),
+	}, nil
+}
+
+func (s *Service) verifyResponse(theirDID string, responseSig *ResponseSignature, data []byte) error {
+	if responseSig == nil {
+		return fmt.Errorf("%w: missing signature", ErrInvalidSignature)
+	}
+
+	sig, err := base64.URLEncoding.DecodeString(responseSig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: decode signature: %v", ErrInvalidSignature, err)
+	}
+
+	sigData, err := base64.URLEncoding.DecodeString(responseSig.SignedData)
+	if err != nil {
+		return fmt.Errorf("%w: decode signed data: %v", ErrInvalidSignature, err)
+	}
+
+	if len(sigData) < timestampSize || !bytes.Equal(sigData[timestampSize:], data) {
+		return fmt.Errorf("%w: signed data does not match the answer", ErrInvalidSignature)
+	}
+
+	doc, err := s.vdr.Resolve(theirDID)
+	if err != nil {
+		return fmt.Errorf("resolve DID: %w", err)
+	}
+
+	signer := base58.Decode(responseSig.Signer)
+
+	if !hasKey(doc.DIDDocument, signer) {
+		return fmt.Errorf("%w: signer is not a key of DID %s", ErrInvalidSignature, theirDID)
+	}
+
+	if !ed25519.Verify(signer, sigData, sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func signingKey(doc *did.Doc) (*did.VerificationMethod, error) {
+	for i := range doc.Authentication {
+		if vm := &doc.Authentication[i].VerificationMethod; isEd25519(vm) {
+			return vm, nil
+		}
+	}
+
+	for i := range doc.VerificationMethod {
+		if vm := &doc.VerificationMethod[i]; isEd25519(vm) {
+			return vm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no Ed25519 key found in DID %s", doc.ID)
+}
+
+func hasKey(doc *did.Doc, pubKey []byte) bool {
+	for i := range doc.Authentication {
+		if vm := &doc.Authentication[i].VerificationMethod; isEd25519(vm) && bytes.Equal(vm.Value, pubKey) {
+			return true
+		}
+	}
+
+	for i := range doc.VerificationMethod {
+		if vm := &doc.VerificationMethod[i]; isEd25519(vm) && bytes.Equal(vm.Value, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEd25519(vm *did.VerificationMethod) bool {
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return len(vm.Value) == ed25519.PublicKeySize
+	case jsonWebKey2020:
+		return vm.JSONWebKey() != nil && vm.JSONWebKey().Crv == ed25519Curve
+	default:
+		return false
+	}
+}
+
+func (s *Service) putQuestion(prefix string, record *questionRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal question: %w", err)
+	}
+
+	if err = s.questionStore.Put(prefix+record.Question.ID, recordBytes); err != nil {
+		return fmt.Errorf("save question: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getQuestion(prefix, questionID string) (*questionRecord, error) {
+	recordBytes, err := s.questionStore.Get(prefix + questionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrQuestionNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get question: %w", err)
+	}
+
+	record := &questionRecord{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal question: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) triggerEvent(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+
+	logger.Debugf("question answer - %s on connection %s", msg.StateID, msg.Properties.All()[connectionIDPropKey])
+}
@@ -0,0 +1,368 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	aliceDID     = "did:example:alice"
+	bobDID       = "did:example:bob"
+	connectionID = "conn-1"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID, nil)
+		require.Equal(t, QuestionAnswer, svc.Name())
+		require.True(t, svc.Accept(QuestionMsgType))
+		require.True(t, svc.Accept(AnswerMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open question answer store")
+	})
+}
+
+func TestService_QuestionAnswer(t *testing.T) {
+	t.Run("answer without signature", func(t *testing.T) {
+		a := newAgents(t)
+
+		questionID, err := a.alice.SendQuestion(connectionID, sampleQuestion(false))
+		require.NoError(t, err)
+
+		state := a.deliver(t, a.bob, bobDID, aliceDID)
+		require.Equal(t, QuestionAnswer, state.ProtocolName)
+		require.Equal(t, StateQuestionReceived, state.StateID)
+		require.Equal(t, map[string]interface{}{
+			connectionIDPropKey: connectionID,
+			threadIDPropKey:     questionID,
+		}, state.Properties.All())
+
+		question, err := a.bob.Question(questionID)
+		require.NoError(t, err)
+		require.Equal(t, "Alice, are you on the phone with Bob from Faber Bank right now?", question.QuestionText)
+		require.NotEmpty(t, question.Nonce)
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "Yes, it's me")
+		require.NoError(t, err)
+
+		state = a.deliver(t, a.alice, aliceDID, bobDID)
+		require.Equal(t, StateAnswerReceived, state.StateID)
+		require.Equal(t, questionID, state.Properties.All()[threadIDPropKey])
+
+		answer := &Answer{}
+		require.NoError(t, state.Msg.Decode(answer))
+		require.Equal(t, "Yes, it's me", answer.Response)
+		require.Nil(t, answer.ResponseSignature)
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "Yes, it's me")
+		require.True(t, errors.Is(err, ErrQuestionNotFound))
+	})
+
+	t.Run("signed answer", func(t *testing.T) {
+		a := newAgents(t)
+
+		questionID, err := a.alice.SendQuestion(connectionID, sampleQuestion(true))
+		require.NoError(t, err)
+
+		a.deliver(t, a.bob, bobDID, aliceDID)
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "No, that's not me!")
+		require.NoError(t, err)
+
+		answer := a.sentAnswer(t)
+		require.NotNil(t, answer.ResponseSignature)
+		require.Equal(t, SignatureType, answer.ResponseSignature.Type)
+		require.Equal(t, base58.Encode(a.docs[bobDID].VerificationMethod[0].Value), answer.ResponseSignature.Signer)
+
+		state := a.deliver(t, a.alice, aliceDID, bobDID)
+		require.Equal(t, StateAnswerReceived, state.StateID)
+	})
+
+	t.Run("invalid answers", func(t *testing.T) {
+		a := newAgents(t)
+
+		questionID, err := a.alice.SendQuestion(connectionID, sampleQuestion(true))
+		require.NoError(t, err)
+
+		a.deliver(t, a.bob, bobDID, aliceDID)
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "Maybe")
+		require.True(t, errors.Is(err, ErrInvalidResponse))
+
+		_, err = a.bob.SendAnswer("other", questionID, "Yes, it's me")
+		require.True(t, errors.Is(err, ErrQuestionNotFound))
+
+		ctx := service.NewDIDCommContext(aliceDID, bobDID, nil)
+
+		_, err = a.alice.HandleInbound(service.NewDIDCommMsgMap(&Answer{
+			Type:     AnswerMsgType,
+			ID:       "answer-1",
+			Response: "Yes, it's me",
+			Thread:   &decorator.Thread{ID: questionID},
+		}), ctx)
+		require.True(t, errors.Is(err, ErrInvalidSignature))
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "Yes, it's me")
+		require.NoError(t, err)
+
+		answer := a.sentAnswer(t)
+		answer.ResponseSignature.Signer = base58.Encode(a.docs[aliceDID].VerificationMethod[0].Value)
+
+		_, err = a.alice.HandleInbound(service.NewDIDCommMsgMap(answer), ctx)
+		require.True(t, errors.Is(err, ErrInvalidSignature))
+		require.Contains(t, err.Error(), "signer is not a key of DID "+bobDID)
+
+		_, err = a.alice.HandleInbound(service.NewDIDCommMsgMap(&Answer{
+			Type:     AnswerMsgType,
+			ID:       "answer-2",
+			Response: "Yes, it's me",
+			Thread:   &decorator.Thread{ID: "unknown"},
+		}), ctx)
+		require.True(t, errors.Is(err, ErrQuestionNotFound))
+	})
+
+	t.Run("expired question", func(t *testing.T) {
+		a := newAgents(t)
+
+		question := sampleQuestion(false)
+		question.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(-time.Minute)}
+
+		questionID, err := a.alice.SendQuestion(connectionID, question)
+		require.NoError(t, err)
+
+		a.deliver(t, a.bob, bobDID, aliceDID)
+
+		_, err = a.bob.SendAnswer(connectionID, questionID, "Yes, it's me")
+		require.True(t, errors.Is(err, ErrQuestionExpired))
+	})
+}
+
+func TestService_Errors(t *testing.T) {
+	t.Run("handle inbound", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID, nil)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(sampleQuestion(false)),
+			service.NewDIDCommContext(aliceDID, "did:example:unknown", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "question answer - get connection")
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Question{Type: "unknown", ID: "question-1"}),
+			service.NewDIDCommContext(aliceDID, bobDID, nil))
+		require.EqualError(t, err, "question answer - unsupported message type unknown")
+
+		_, err = svc.HandleInbound(service.DIDCommMsgMap{"@type": QuestionMsgType, "@id": "q-1", "valid_responses": 1},
+			service.NewDIDCommContext(aliceDID, bobDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "question answer - handle "+QuestionMsgType)
+	})
+
+	t.Run("send", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}, aliceDID, bobDID, nil)
+
+		_, err := svc.SendQuestion(connectionID, &Question{QuestionText: "no responses"})
+		require.EqualError(t, err, "question has no valid responses")
+
+		_, err = svc.SendQuestion("unknown", sampleQuestion(false))
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.SendQuestion(connectionID, sampleQuestion(false))
+		require.EqualError(t, err, "question answer - send "+QuestionMsgType+": send error")
+
+		_, err = svc.SendAnswer(connectionID, "unknown", "Yes, it's me")
+		require.True(t, errors.Is(err, ErrQuestionNotFound))
+
+		_, err = svc.Question("unknown")
+		require.True(t, errors.Is(err, ErrQuestionNotFound))
+	})
+
+	t.Run("sign without key", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{}, bobDID, aliceDID, map[string]*did.Doc{
+			bobDID: {ID: bobDID},
+		})
+
+		question := sampleQuestion(true)
+		question.Type = QuestionMsgType
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(question), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.NoError(t, err)
+
+		_, err = svc.SendAnswer(connectionID, question.ID, "Yes, it's me")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sign response: no Ed25519 key found in DID "+bobDID)
+	})
+}
+
+type agents struct {
+	alice *Service
+	bob   *Service
+	docs  map[string]*did.Doc
+	sent  service.DIDCommMsgMap
+}
+
+func (a *agents) sentAnswer(t *testing.T) *Answer {
+	t.Helper()
+
+	answer := &Answer{}
+	require.NoError(t, a.sent.Decode(answer))
+
+	return answer
+}
+
+// deliver hands the last message sent to the service of the receiver, returning the message event triggered.
+func (a *agents) deliver(t *testing.T, receiver *Service, myDID, theirDID string) service.StateMsg {
+	t.Helper()
+
+	states := make(chan service.StateMsg, 1)
+	require.NoError(t, receiver.RegisterMsgEvent(states))
+
+	defer func() {
+		require.NoError(t, receiver.UnregisterMsgEvent(states))
+	}()
+
+	_, err := receiver.HandleInbound(a.sent, service.NewDIDCommContext(myDID, theirDID, nil))
+	require.NoError(t, err)
+
+	return <-states
+}
+
+func newAgents(t *testing.T) *agents {
+	t.Helper()
+
+	a := &agents{docs: map[string]*did.Doc{}}
+
+	outbound := &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			a.sent = msg.(service.DIDCommMsgMap)
+
+			return nil
+		},
+	}
+
+	aliceKMS, bobKMS := newKMS(t), newKMS(t)
+	a.docs[aliceDID] = newDoc(t, aliceKMS, aliceDID)
+	a.docs[bobDID] = newDoc(t, bobKMS, bobDID)
+
+	a.alice = newServiceWithKMS(t, outbound, aliceKMS, aliceDID, bobDID, a.docs)
+	a.bob = newServiceWithKMS(t, outbound, bobKMS, bobDID, aliceDID, a.docs)
+
+	return a
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound, myDID, theirDID string,
+	docs map[string]*did.Doc) *Service {
+	t.Helper()
+
+	return newServiceWithKMS(t, outbound, newKMS(t), myDID, theirDID, docs)
+}
+
+func newServiceWithKMS(t *testing.T, outbound *mockdispatcher.MockOutbound, km kms.KeyManager, myDID, theirDID string,
+	docs map[string]*did.Doc) *Service {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+		KMSValue:                          km,
+		CryptoValue:                       c,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc, ok := docs[didID]
+				if !ok {
+					return nil, fmt.Errorf("DID %s not found", didID)
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return km
+}
+
+func newDoc(t *testing.T, km kms.KeyManager, id string) *did.Doc {
+	t.Helper()
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, id, pubKey)
+
+	return &did.Doc{
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+	}
+}
+
+func sampleQuestion(signatureRequired bool) *Question {
+	return &Question{
+		ID:                "question-1",
+		QuestionText:      "Alice, are you on the phone with Bob from Faber Bank right now?",
+		QuestionDetail:    "This is only valid if you are on the phone with Bob.",
+		SignatureRequired: signatureRequired,
+		ValidResponses:    []ValidResponse{{Text: "Yes, it's me"}, {Text: "No, that's not me!"}},
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Ping is sent to test the connection with the other party.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping#messages
+type Ping struct {
+	Type              string `json:"@type,omitempty"`
+	ID                string `json:"@id,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	ResponseRequested bool   `json:"response_requested"`
+}
+
+// PingResponse is sent in response to a ping requesting a response, threaded to the ping.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping#messages
+type PingResponse struct {
+	Type    string            `json:"@type,omitempty"`
+	ID      string            `json:"@id,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Thread  *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// HealthStatus is the health check status of a connection.
+type HealthStatus struct {
+	ConnectionID string `json:"connection_id"`
+	// Enabled reports whether the connection is health-checked.
+	Enabled bool `json:"enabled"`
+	// Healthy is false once the other party didn't respond to the pings for the HealthCheckConfig UnhealthyAfter
+	// duration.
+	Healthy bool `json:"healthy"`
+	// LastSeen is the time the last ping or ping response was received from the other party.
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+type healthRecord struct {
+	ConnectionID string    `json:"connectionID"`
+	Enabled      bool      `json:"enabled"`
+	Since        time.Time `json:"since"`
+	Unhealthy    bool      `json:"unhealthy,omitempty"`
+}
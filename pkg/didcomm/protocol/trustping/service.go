@@ -0,0 +1,531 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package trustping implements the trust ping protocol and the optional health check of the connections: the
+// monitored connections are pinged in the background, the time the other party was last seen is recorded in the
+// connection record, and the connections not seen for too long are reported unhealthy with a message event.
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgType defines the protocol ping message type.
+	PingMsgType = Spec + "ping"
+	// PingResponseMsgType defines the protocol ping response message type.
+	PingResponseMsgType = Spec + "ping_response"
+
+	// Namespace is namespace of trust ping store name.
+	Namespace = "trustping"
+
+	healthRecordKey = "health_"
+
+	defaultHealthCheckInterval = time.Minute
+	defaultUnhealthyIntervals  = 3
+)
+
+// State IDs of the message events triggered by the service.
+const (
+	// StatePingReceived is the state of the party receiving a ping, the ping response is sent when requested.
+	StatePingReceived = "ping-received"
+	// StatePingResponseReceived is the state of the party receiving the response to its ping.
+	StatePingResponseReceived = "ping-response-received"
+	// StateConnectionUnhealthy is triggered, without message, when the other party of a health-checked connection
+	// wasn't seen for the HealthCheckConfig UnhealthyAfter duration.
+	StateConnectionUnhealthy = "connection-unhealthy"
+	// StateConnectionHealthy is triggered, without message, when the other party of an unhealthy connection is seen
+	// again.
+	StateConnectionHealthy = "connection-healthy"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrHealthCheckNotConfigured is returned when enabling the health check of a connection while the service
+	// wasn't created with the WithHealthCheck option.
+	ErrHealthCheckNotConfigured = errors.New("health check is not configured")
+
+	logger = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// HealthCheckConfig configures the health check of the connections.
+type HealthCheckConfig struct {
+	// Interval is the interval between two pings of the monitored connections (1 minute by default).
+	Interval time.Duration
+	// UnhealthyAfter is the time after which a connection not seen is unhealthy (3 intervals by default).
+	UnhealthyAfter time.Duration
+	// AllConnections monitors all the completed connections, except the ones disabled with SetHealthCheck. Otherwise
+	// only the connections enabled with SetHealthCheck are monitored.
+	AllConnections bool
+}
+
+// Opt configures the Service.
+type Opt func(s *Service)
+
+// WithHealthCheck enables the health check of the connections, started with Start.
+func WithHealthCheck(config HealthCheckConfig) Opt {
+	return func(s *Service) {
+		if config.Interval <= 0 {
+			config.Interval = defaultHealthCheckInterval
+		}
+
+		if config.UnhealthyAfter <= 0 {
+			config.UnhealthyAfter = defaultUnhealthyIntervals * config.Interval
+		}
+
+		s.healthCheck = &config
+	}
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connections *connection.Recorder
+	outbound    dispatcher.Outbound
+	store       storage.Store
+	healthCheck *HealthCheckConfig
+	lock        sync.Mutex
+	mu          sync.Mutex
+	started     bool
+	stopped     bool
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// New returns the trust ping service.
+func New(prov provider, opts ...Opt) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open trust ping store: %w", err)
+	}
+
+	connections, err := connection.NewRecorder(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		outbound:    prov.OutboundDispatcher(),
+		store:       store,
+		connections: connections,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// HandleInbound handles inbound trust ping messages, recording the time the other party was seen and sending the
+// ping responses requested.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	connectionID, err := s.connections.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("trust ping - get connection: %w", err)
+	}
+
+	var (
+		stateID string
+		ping    Ping
+	)
+
+	switch msg.Type() {
+	case PingMsgType:
+		stateID = StatePingReceived
+		err = msg.Decode(&ping)
+	case PingResponseMsgType:
+		stateID = StatePingResponseReceived
+	default:
+		return "", fmt.Errorf("trust ping - unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("trust ping - decode %s: %w", msg.Type(), err)
+	}
+
+	if err = s.markSeen(connectionID, time.Now().UTC()); err != nil {
+		return "", fmt.Errorf("trust ping - handle %s: %w", msg.Type(), err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return "", fmt.Errorf("trust ping - thread ID: %w", err)
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: TrustPing,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Msg:          msg,
+		Properties:   &eventProps{connectionID: connectionID, threadID: thID},
+	})
+
+	if ping.ResponseRequested {
+		response := &PingResponse{
+			Type:   PingResponseMsgType,
+			ID:     uuid.New().String(),
+			Thread: &decorator.Thread{ID: msg.ID()},
+		}
+
+		responseMsg := service.NewDIDCommMsgMap(response)
+		// the response is part of the trace of the ping
+		service.SetSpanContext(responseMsg, service.SpanContextFromContext(ctx))
+
+		if _, err = s.HandleOutbound(responseMsg, ctx.MyDID(), ctx.TheirDID()); err != nil {
+			return "", err
+		}
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the trust ping message.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("trust ping - send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case PingMsgType, PingResponseMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// PIURIs of the trust ping protocol.
+func (s *Service) PIURIs() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// Ping sends a ping requesting a response to the connection, returning the ID of the ping message.
+func (s *Service) Ping(connectionID, comment string) (string, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.ping(conn, comment)
+}
+
+// SetHealthCheck enables or disables the health check of the connection. Enabling the health check of a connection
+// resets its health, the connection is unhealthy if not seen for the UnhealthyAfter duration from now.
+func (s *Service) SetHealthCheck(connectionID string, enabled bool) error {
+	if s.healthCheck == nil {
+		return ErrHealthCheckNotConfigured
+	}
+
+	if _, err := s.getConnection(connectionID); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.putHealthRecord(&healthRecord{ConnectionID: connectionID, Enabled: enabled, Since: time.Now().UTC()})
+}
+
+// HealthCheck returns the health check status of the connection.
+func (s *Service) HealthCheck(connectionID string) (*HealthStatus, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.getHealthRecord(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HealthStatus{
+		ConnectionID: connectionID,
+		Enabled:      s.monitored(record),
+		Healthy:      record == nil || !record.Unhealthy,
+		LastSeen:     conn.LastSeen,
+	}, nil
+}
+
+// Start checks the health of the monitored connections now and then in the background at the health check interval.
+// Start does nothing if the service wasn't created with the WithHealthCheck option.
+func (s *Service) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.healthCheck == nil || s.started || s.stopped {
+		return
+	}
+
+	s.started = true
+
+	go s.run()
+}
+
+// Stop stops the background health check.
+func (s *Service) Stop() {
+	s.mu.Lock()
+
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+
+	started := s.started
+
+	s.mu.Unlock()
+
+	if started {
+		<-s.done
+	}
+}
+
+// CheckHealth checks the health of the monitored connections at the given time: the connections not seen for the
+// UnhealthyAfter duration are reported unhealthy, then all the monitored connections are pinged.
+func (s *Service) CheckHealth(now time.Time) error {
+	if s.healthCheck == nil {
+		return ErrHealthCheckNotConfigured
+	}
+
+	records, err := s.connections.QueryConnectionRecords()
+	if err != nil {
+		return fmt.Errorf("query connection records: %w", err)
+	}
+
+	for _, conn := range records {
+		if conn.State != connection.StateNameCompleted {
+			continue
+		}
+
+		monitored, err := s.checkConnection(conn, now)
+		if err != nil {
+			return fmt.Errorf("check connection %s: %w", conn.ConnectionID, err)
+		}
+
+		if !monitored {
+			continue
+		}
+
+		if _, err = s.ping(conn, ""); err != nil {
+			logger.Warnf("failed to ping connection %s: %s", conn.ConnectionID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.healthCheck.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CheckHealth(time.Now().UTC()); err != nil {
+			logger.Errorf("failed to check the health of the connections: %s", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Service) checkConnection(conn *connection.Record, now time.Time) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	record, err := s.getHealthRecord(conn.ConnectionID)
+	if err != nil {
+		return false, err
+	}
+
+	if !s.monitored(record) {
+		return false, nil
+	}
+
+	if record == nil {
+		record = &healthRecord{ConnectionID: conn.ConnectionID, Enabled: true, Since: now}
+
+		return true, s.putHealthRecord(record)
+	}
+
+	lastSeen := record.Since
+	if conn.LastSeen != nil && conn.LastSeen.After(lastSeen) {
+		lastSeen = *conn.LastSeen
+	}
+
+	if record.Unhealthy || now.Sub(lastSeen) <= s.healthCheck.UnhealthyAfter {
+		return true, nil
+	}
+
+	record.Unhealthy = true
+
+	if err = s.putHealthRecord(record); err != nil {
+		return false, err
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: TrustPing,
+		Type:         service.PostState,
+		StateID:      StateConnectionUnhealthy,
+		Properties:   &eventProps{connectionID: conn.ConnectionID},
+	})
+
+	return true, nil
+}
+
+func (s *Service) markSeen(connectionID string, now time.Time) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	conn.LastSeen = &now
+
+	if err = s.connections.SaveConnectionRecord(conn); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	record, err := s.getHealthRecord(connectionID)
+	if err != nil || record == nil || !record.Unhealthy {
+		return err
+	}
+
+	record.Unhealthy = false
+
+	if err = s.putHealthRecord(record); err != nil {
+		return err
+	}
+
+	s.triggerEvent(service.StateMsg{
+		ProtocolName: TrustPing,
+		Type:         service.PostState,
+		StateID:      StateConnectionHealthy,
+		Properties:   &eventProps{connectionID: connectionID},
+	})
+
+	return nil
+}
+
+func (s *Service) monitored(record *healthRecord) bool {
+	if s.healthCheck == nil {
+		return false
+	}
+
+	if record != nil {
+		return record.Enabled
+	}
+
+	return s.healthCheck.AllConnections
+}
+
+func (s *Service) ping(conn *connection.Record, comment string) (string, error) {
+	ping := &Ping{
+		Type:              PingMsgType,
+		ID:                uuid.New().String(),
+		Comment:           comment,
+		ResponseRequested: true,
+	}
+
+	return s.HandleOutbound(service.NewDIDCommMsgMap(ping), conn.MyDID, conn.TheirDID)
+}
+
+func (s *Service) putHealthRecord(record *healthRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal health record: %w", err)
+	}
+
+	if err = s.store.Put(healthRecordKey+record.ConnectionID, recordBytes); err != nil {
+		return fmt.Errorf("save health record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getHealthRecord(connectionID string) (*healthRecord, error) {
+	recordBytes, err := s.store.Get(healthRecordKey + connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get health record: %w", err)
+	}
+
+	record := &healthRecord{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal health record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) triggerEvent(msg service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- msg
+	}
+
+	logger.Debugf("trust ping - %s on connection %s", msg.StateID, msg.Properties.All()[connectionIDPropKey])
+}
@@ -0,0 +1,358 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	aliceDID     = "did:example:alice"
+	bobDID       = "did:example:bob"
+	connectionID = "conn-1"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+		require.Equal(t, TrustPing, svc.Name())
+		require.True(t, svc.Accept(PingMsgType))
+		require.True(t, svc.Accept(PingResponseMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open trust ping store")
+	})
+
+	t.Run("health check defaults", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID, WithHealthCheck(HealthCheckConfig{}))
+		require.Equal(t, time.Minute, svc.healthCheck.Interval)
+		require.Equal(t, 3*time.Minute, svc.healthCheck.UnhealthyAfter)
+	})
+}
+
+func TestService_Ping(t *testing.T) {
+	t.Run("ping and response", func(t *testing.T) {
+		a := newAgents(t)
+
+		pingID, err := a.alice.Ping(connectionID, "hello")
+		require.NoError(t, err)
+
+		state := a.deliver(t, a.bob, bobDID, aliceDID)
+		require.Equal(t, TrustPing, state.ProtocolName)
+		require.Equal(t, StatePingReceived, state.StateID)
+		require.Equal(t, map[string]interface{}{
+			connectionIDPropKey: connectionID,
+			threadIDPropKey:     pingID,
+		}, state.Properties.All())
+
+		response := &PingResponse{}
+		require.NoError(t, a.sent.Decode(response))
+		require.Equal(t, PingResponseMsgType, response.Type)
+		require.Equal(t, pingID, response.Thread.ID)
+
+		state = a.deliver(t, a.alice, aliceDID, bobDID)
+		require.Equal(t, StatePingResponseReceived, state.StateID)
+		require.Equal(t, pingID, state.Properties.All()[threadIDPropKey])
+
+		for _, svc := range []*Service{a.alice, a.bob} {
+			status, err := svc.HealthCheck(connectionID)
+			require.NoError(t, err)
+			require.NotNil(t, status.LastSeen)
+			require.True(t, status.Healthy)
+			require.False(t, status.Enabled)
+		}
+	})
+
+	t.Run("response not requested", func(t *testing.T) {
+		var sent int
+
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				sent++
+
+				return nil
+			},
+		}, bobDID, aliceDID)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "ping-1"}),
+			service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.NoError(t, err)
+		require.Zero(t, sent)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		_, err := svc.Ping("unknown", "")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType}),
+			service.NewDIDCommContext(aliceDID, "did:example:carol", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "trust ping - get connection")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				return errors.New("send error")
+			},
+		}, aliceDID, bobDID)
+
+		_, err := svc.Ping(connectionID, "")
+		require.EqualError(t, err, "trust ping - send "+PingMsgType+": send error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{Type: "unknown"}),
+			service.NewDIDCommContext(aliceDID, bobDID, nil))
+		require.EqualError(t, err, "trust ping - unsupported message type unknown")
+	})
+}
+
+func TestService_CheckHealth(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID)
+
+		require.True(t, errors.Is(svc.CheckHealth(time.Now()), ErrHealthCheckNotConfigured))
+		require.True(t, errors.Is(svc.SetHealthCheck(connectionID, true), ErrHealthCheckNotConfigured))
+
+		svc.Start()
+		svc.Stop()
+	})
+
+	t.Run("enabled connection", func(t *testing.T) {
+		var pings int
+
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				pings++
+
+				return nil
+			},
+		}, aliceDID, bobDID, WithHealthCheck(HealthCheckConfig{Interval: time.Hour, UnhealthyAfter: 2 * time.Hour}))
+
+		// the ping response of an unhealthy connection triggers two events
+		states := make(chan service.StateMsg, 2)
+		require.NoError(t, svc.RegisterMsgEvent(states))
+
+		now := time.Now().UTC()
+
+		require.NoError(t, svc.CheckHealth(now))
+		require.Zero(t, pings)
+
+		require.NoError(t, svc.SetHealthCheck(connectionID, true))
+
+		status, err := svc.HealthCheck(connectionID)
+		require.NoError(t, err)
+		require.True(t, status.Enabled)
+		require.True(t, status.Healthy)
+		require.Nil(t, status.LastSeen)
+
+		require.NoError(t, svc.CheckHealth(now.Add(time.Hour)))
+		require.Equal(t, 1, pings)
+		require.Empty(t, states)
+
+		require.NoError(t, svc.CheckHealth(now.Add(3*time.Hour)))
+		require.Equal(t, 2, pings)
+
+		state := <-states
+		require.Equal(t, StateConnectionUnhealthy, state.StateID)
+		require.Nil(t, state.Msg)
+		require.Equal(t, map[string]interface{}{connectionIDPropKey: connectionID}, state.Properties.All())
+
+		// the unhealthy event is triggered once
+		require.NoError(t, svc.CheckHealth(now.Add(4*time.Hour)))
+		require.Empty(t, states)
+
+		status, err = svc.HealthCheck(connectionID)
+		require.NoError(t, err)
+		require.False(t, status.Healthy)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{Type: PingResponseMsgType, ID: "response"}),
+			service.NewDIDCommContext(aliceDID, bobDID, nil))
+		require.NoError(t, err)
+		// the other party is marked as seen before the event of the received message is triggered, so the connection
+		// is reported healthy before the ping response is reported received
+		require.Equal(t, StateConnectionHealthy, (<-states).StateID)
+		require.Equal(t, StatePingResponseReceived, (<-states).StateID)
+
+		status, err = svc.HealthCheck(connectionID)
+		require.NoError(t, err)
+		require.True(t, status.Healthy)
+		require.NotNil(t, status.LastSeen)
+
+		// the connection isn't pinged once its health check is disabled, the pings are the ones of the checks at 1h,
+		// 3h and 4h
+		require.NoError(t, svc.SetHealthCheck(connectionID, false))
+		require.NoError(t, svc.CheckHealth(now.Add(10*time.Hour)))
+		require.Equal(t, 3, pings)
+	})
+
+	t.Run("all connections", func(t *testing.T) {
+		var pings int
+
+		svc, recorder := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				pings++
+
+				return errors.New("send error")
+			},
+		}, aliceDID, bobDID, WithHealthCheck(HealthCheckConfig{Interval: time.Hour, AllConnections: true}))
+
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-2",
+			MyDID:        aliceDID,
+			TheirDID:     "did:example:carol",
+			State:        connection.StateNameCompleted,
+		}))
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-3",
+			State:        "requested",
+		}))
+
+		require.NoError(t, svc.SetHealthCheck("conn-2", false))
+
+		status, err := svc.HealthCheck(connectionID)
+		require.NoError(t, err)
+		require.True(t, status.Enabled)
+
+		status, err = svc.HealthCheck("conn-2")
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+
+		require.NoError(t, svc.CheckHealth(time.Now().UTC()))
+		require.Equal(t, 1, pings)
+	})
+
+	t.Run("start and stop", func(t *testing.T) {
+		pinged := make(chan struct{}, 1)
+
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				select {
+				case pinged <- struct{}{}:
+				default:
+				}
+
+				return nil
+			},
+		}, aliceDID, bobDID, WithHealthCheck(HealthCheckConfig{Interval: time.Hour, AllConnections: true}))
+
+		svc.Start()
+		svc.Start()
+
+		select {
+		case <-pinged:
+		case <-time.After(time.Second):
+			t.Fatal("connection not pinged")
+		}
+
+		svc.Stop()
+		svc.Stop()
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, _ := newService(t, &mockdispatcher.MockOutbound{}, aliceDID, bobDID,
+			WithHealthCheck(HealthCheckConfig{}))
+
+		require.True(t, errors.Is(svc.SetHealthCheck("unknown", true), ErrConnectionNotFound))
+
+		_, err := svc.HealthCheck("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+}
+
+type agents struct {
+	alice *Service
+	bob   *Service
+	sent  service.DIDCommMsgMap
+}
+
+// deliver hands the last message sent to the service of the receiver, returning the message event triggered.
+func (a *agents) deliver(t *testing.T, receiver *Service, myDID, theirDID string) service.StateMsg {
+	t.Helper()
+
+	states := make(chan service.StateMsg, 1)
+	require.NoError(t, receiver.RegisterMsgEvent(states))
+
+	defer func() {
+		require.NoError(t, receiver.UnregisterMsgEvent(states))
+	}()
+
+	_, err := receiver.HandleInbound(a.sent, service.NewDIDCommContext(myDID, theirDID, nil))
+	require.NoError(t, err)
+
+	return <-states
+}
+
+func newAgents(t *testing.T) *agents {
+	t.Helper()
+
+	a := &agents{}
+
+	outbound := &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, _, _ string) error {
+			a.sent = msg.(service.DIDCommMsgMap)
+
+			return nil
+		},
+	}
+
+	a.alice, _ = newService(t, outbound, aliceDID, bobDID)
+	a.bob, _ = newService(t, outbound, bobDID, aliceDID)
+
+	return a
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound, myDID, theirDID string,
+	opts ...Opt) (*Service, *connection.Recorder) {
+	t.Helper()
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connectionID,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	svc, err := New(prov, opts...)
+	require.NoError(t, err)
+
+	return svc, recorder
+}
@@ -0,0 +1,326 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/tidwall/sjson"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+)
+
+const (
+	defaultTimeout     = 5 * time.Second
+	defaultQuietPeriod = 100 * time.Millisecond
+	sentBufferSize     = 100
+)
+
+var logger = log.New("aries-framework/didcomm/replay")
+
+// Divergence is the error returned when the replayed service doesn't behave as recorded.
+type Divergence struct {
+	// Index of the envelope in the session.
+	Index int
+	// Expected is the recorded envelope, nil if the service sent an unexpected message.
+	Expected *Envelope
+	// Actual is the envelope produced by the replayed service, nil if the expected message was not sent.
+	Actual *Envelope
+	// Reason of the divergence.
+	Reason string
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("replay diverged at envelope %d: %s", d.Index, d.Reason)
+}
+
+// ActionHandler handles the action events of the replayed service.
+type ActionHandler func(action service.DIDCommAction)
+
+// PlayerOption configures the player.
+type PlayerOption func(p *Player)
+
+// WithTimeout sets how long the player waits for each message the service is expected to send. Defaults to 5s.
+func WithTimeout(timeout time.Duration) PlayerOption {
+	return func(p *Player) {
+		p.timeout = timeout
+	}
+}
+
+// WithQuietPeriod sets how long the player waits, once the session is replayed, for unexpected messages the service
+// would still send. Defaults to 100ms.
+func WithQuietPeriod(period time.Duration) PlayerOption {
+	return func(p *Player) {
+		p.quietPeriod = period
+	}
+}
+
+// WithTimings replays the inbound and initiated messages with their recorded timings, divided by the given speed
+// factor (1 for real time). By default the messages are replayed without delay.
+func WithTimings(speed float64) PlayerOption {
+	return func(p *Player) {
+		p.speed = speed
+	}
+}
+
+// WithIgnoredFields adds the paths (in gjson/sjson syntax) of the message fields ignored when comparing the sent
+// messages with the recorded ones, on top of the non-deterministic '@id', '~thread.thid' and '~thread.pthid' fields.
+func WithIgnoredFields(paths ...string) PlayerOption {
+	return func(p *Player) {
+		p.ignoredFields = append(p.ignoredFields, paths...)
+	}
+}
+
+// WithActionHandler sets the handler of the action events of the replayed service.
+// By default all actions are continued without arguments.
+func WithActionHandler(handler ActionHandler) PlayerOption {
+	return func(p *Player) {
+		p.actions = handler
+	}
+}
+
+// Player replays a recorded session against a protocol service.
+type Player struct {
+	session       *Session
+	timeout       time.Duration
+	quietPeriod   time.Duration
+	speed         float64
+	ignoredFields []string
+	actions       ActionHandler
+	sent          chan *Envelope
+}
+
+// NewPlayer returns a new player of the session.
+func NewPlayer(session *Session, opts ...PlayerOption) *Player {
+	p := &Player{
+		session:       session,
+		timeout:       defaultTimeout,
+		quietPeriod:   defaultQuietPeriod,
+		ignoredFields: []string{"@id", "~thread.thid", "~thread.pthid"},
+		actions: func(action service.DIDCommAction) {
+			action.Continue(nil)
+		},
+		sent: make(chan *Envelope, sentBufferSize),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Outbound returns the outbound dispatcher the replayed service must be created with, it captures the messages the
+// service sends instead of sending them.
+func (p *Player) Outbound() dispatcher.Outbound {
+	return &capturingOutbound{sent: p.sent}
+}
+
+// Play feeds the recorded inbound and initiated messages to the service and checks it sends the recorded messages,
+// in the recorded order. A *Divergence error is returned when the service behaves differently.
+func (p *Player) Play(svc dispatcher.ProtocolService) error {
+	if p.session.Protocol != "" && svc.Name() != p.session.Protocol {
+		return fmt.Errorf("session of protocol %s can't be replayed against service %s", p.session.Protocol, svc.Name())
+	}
+
+	if ev, ok := svc.(actionEvent); ok {
+		stop, err := p.handleActions(ev)
+		if err != nil {
+			return err
+		}
+
+		defer stop()
+	}
+
+	var lastOffset time.Duration
+
+	for i, envelope := range p.session.Envelopes {
+		var err error
+
+		switch envelope.Direction {
+		case Inbound, Initiated:
+			p.wait(envelope.Offset - lastOffset)
+			lastOffset = envelope.Offset
+
+			err = p.handle(i, svc, envelope)
+		case Sent:
+			err = p.expect(i, envelope)
+		default:
+			err = fmt.Errorf("envelope %d: unsupported direction %s", i, envelope.Direction)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	select {
+	case actual := <-p.sent:
+		return &Divergence{
+			Index:  len(p.session.Envelopes),
+			Actual: actual,
+			Reason: "unexpected message sent: " + string(actual.Message),
+		}
+	case <-time.After(p.quietPeriod):
+		return nil
+	}
+}
+
+// actionEvent is implemented by the services triggering action events.
+type actionEvent interface {
+	RegisterActionEvent(ch chan<- service.DIDCommAction) error
+	UnregisterActionEvent(ch chan<- service.DIDCommAction) error
+}
+
+func (p *Player) handleActions(ev actionEvent) (func(), error) {
+	actions := make(chan service.DIDCommAction)
+
+	if err := ev.RegisterActionEvent(actions); err != nil {
+		return nil, fmt.Errorf("register action event: %w", err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case action := <-actions:
+				p.actions(action)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		if err := ev.UnregisterActionEvent(actions); err != nil {
+			logger.Warnf("failed to unregister action event: %s", err)
+		}
+
+		close(done)
+	}, nil
+}
+
+func (p *Player) wait(delay time.Duration) {
+	if p.speed <= 0 || delay <= 0 {
+		return
+	}
+
+	time.Sleep(time.Duration(float64(delay) / p.speed))
+}
+
+func (p *Player) handle(i int, svc dispatcher.ProtocolService, envelope *Envelope) error {
+	msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+	if err != nil {
+		return fmt.Errorf("envelope %d: parse message: %w", i, err)
+	}
+
+	if envelope.Direction == Inbound {
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(envelope.MyDID, envelope.TheirDID, nil))
+	} else {
+		_, err = svc.HandleOutbound(msg, envelope.MyDID, envelope.TheirDID)
+	}
+
+	switch {
+	case err == nil && envelope.Error != "":
+		return &Divergence{Index: i, Expected: envelope, Reason: "expected error: " + envelope.Error}
+	case err != nil && envelope.Error == "":
+		return &Divergence{Index: i, Expected: envelope, Reason: "unexpected error: " + err.Error()}
+	default:
+		return nil
+	}
+}
+
+func (p *Player) expect(i int, expected *Envelope) error {
+	select {
+	case actual := <-p.sent:
+		equal, err := p.equal(expected.Message, actual.Message)
+		if err != nil {
+			return fmt.Errorf("envelope %d: %w", i, err)
+		}
+
+		if !equal {
+			return &Divergence{
+				Index:    i,
+				Expected: expected,
+				Actual:   actual,
+				Reason:   fmt.Sprintf("expected message %s, got %s", expected.Message, actual.Message),
+			}
+		}
+
+		return nil
+	case <-time.After(p.timeout):
+		return &Divergence{
+			Index:    i,
+			Expected: expected,
+			Reason:   fmt.Sprintf("expected message %s was not sent within %s", expected.Message, p.timeout),
+		}
+	}
+}
+
+func (p *Player) equal(expected, actual []byte) (bool, error) {
+	var e, a interface{}
+
+	if err := json.Unmarshal(p.strip(expected), &e); err != nil {
+		return false, fmt.Errorf("unmarshal expected message: %w", err)
+	}
+
+	if err := json.Unmarshal(p.strip(actual), &a); err != nil {
+		return false, fmt.Errorf("unmarshal sent message: %w", err)
+	}
+
+	return reflect.DeepEqual(e, a), nil
+}
+
+func (p *Player) strip(msg []byte) []byte {
+	for _, path := range p.ignoredFields {
+		stripped, err := sjson.DeleteBytes(msg, path)
+		if err != nil {
+			continue
+		}
+
+		msg = stripped
+	}
+
+	return msg
+}
+
+// capturingOutbound captures the messages sent by the replayed service.
+type capturingOutbound struct {
+	sent chan *Envelope
+}
+
+func (o *capturingOutbound) Send(msg interface{}, _ string, des *service.Destination) error {
+	return o.capture(&Envelope{Direction: Sent, ServiceEndpoint: des.ServiceEndpoint}, msg)
+}
+
+func (o *capturingOutbound) SendToDID(msg interface{}, myDID, theirDID string) error {
+	return o.capture(&Envelope{Direction: Sent, MyDID: myDID, TheirDID: theirDID}, msg)
+}
+
+func (o *capturingOutbound) Forward(msg interface{}, des *service.Destination) error {
+	return o.capture(&Envelope{Direction: Sent, ServiceEndpoint: des.ServiceEndpoint}, msg)
+}
+
+func (o *capturingOutbound) capture(envelope *Envelope, msg interface{}) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.New("replay: failed to capture the sent message: " + err.Error())
+	}
+
+	envelope.Message = raw
+
+	o.sent <- envelope
+
+	return nil
+}
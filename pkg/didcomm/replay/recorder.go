@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+)
+
+// Recorder records a protocol session.
+type Recorder struct {
+	mu      sync.Mutex
+	start   time.Time
+	session Session
+}
+
+// NewRecorder returns a new recorder of a session of the given protocol.
+func NewRecorder(protocol string) *Recorder {
+	return &Recorder{session: Session{Protocol: protocol}}
+}
+
+// Service wraps the protocol service, recording the inbound and initiated messages it handles.
+// Action events must be registered on the wrapped service.
+func (r *Recorder) Service(svc dispatcher.ProtocolService) dispatcher.ProtocolService {
+	return &recordedService{ProtocolService: svc, recorder: r}
+}
+
+// Outbound wraps the outbound dispatcher of the protocol service, recording the messages it sends.
+func (r *Recorder) Outbound(outbound dispatcher.Outbound) dispatcher.Outbound {
+	return &recordedOutbound{outbound: outbound, recorder: r}
+}
+
+// Session returns the session recorded so far.
+func (r *Recorder) Session() *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Session{
+		Protocol:  r.session.Protocol,
+		Envelopes: append([]*Envelope(nil), r.session.Envelopes...),
+	}
+}
+
+func (r *Recorder) record(envelope *Envelope, msg interface{}, err error) {
+	raw, e := json.Marshal(msg)
+	if e != nil {
+		logger.Warnf("failed to record %s message: %s", envelope.Direction, e)
+
+		return
+	}
+
+	envelope.Message = raw
+
+	if err != nil {
+		envelope.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	if r.start.IsZero() {
+		r.start = now
+	}
+
+	envelope.Offset = now.Sub(r.start)
+
+	r.session.Envelopes = append(r.session.Envelopes, envelope)
+}
+
+type recordedService struct {
+	dispatcher.ProtocolService
+	recorder *Recorder
+}
+
+func (s *recordedService) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	envelope := &Envelope{Direction: Inbound, MyDID: ctx.MyDID(), TheirDID: ctx.TheirDID()}
+
+	// the message is recorded before it is handled to keep the messages sent while handling it after it.
+	s.recorder.record(envelope, msg, nil)
+
+	piID, err := s.ProtocolService.HandleInbound(msg, ctx)
+	if err != nil {
+		s.recorder.setError(envelope, err)
+	}
+
+	return piID, err
+}
+
+func (s *recordedService) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	envelope := &Envelope{Direction: Initiated, MyDID: myDID, TheirDID: theirDID}
+
+	s.recorder.record(envelope, msg, nil)
+
+	piID, err := s.ProtocolService.HandleOutbound(msg, myDID, theirDID)
+	if err != nil {
+		s.recorder.setError(envelope, err)
+	}
+
+	return piID, err
+}
+
+func (r *Recorder) setError(envelope *Envelope, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	envelope.Error = err.Error()
+}
+
+type recordedOutbound struct {
+	outbound dispatcher.Outbound
+	recorder *Recorder
+}
+
+func (o *recordedOutbound) Send(msg interface{}, senderKey string, des *service.Destination) error {
+	err := o.outbound.Send(msg, senderKey, des)
+
+	o.recorder.record(&Envelope{Direction: Sent, ServiceEndpoint: des.ServiceEndpoint}, msg, err)
+
+	return err
+}
+
+func (o *recordedOutbound) SendToDID(msg interface{}, myDID, theirDID string) error {
+	err := o.outbound.SendToDID(msg, myDID, theirDID)
+
+	o.recorder.record(&Envelope{Direction: Sent, MyDID: myDID, TheirDID: theirDID}, msg, err)
+
+	return err
+}
+
+func (o *recordedOutbound) Forward(msg interface{}, des *service.Destination) error {
+	err := o.outbound.Forward(msg, des)
+
+	o.recorder.record(&Envelope{Direction: Sent, ServiceEndpoint: des.ServiceEndpoint}, msg, err)
+
+	return err
+}
@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+)
+
+const (
+	echoProtocol = "echo"
+	pingMsgType  = "https://example.org/echo/1.0/ping"
+	pongMsgType  = "https://example.org/echo/1.0/pong"
+	myDID        = "did:example:alice"
+	theirDID     = "did:example:bob"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	session := recordSession(t)
+
+	require.Equal(t, echoProtocol, session.Protocol)
+	require.Len(t, session.Envelopes, 5)
+
+	directions := make([]Direction, len(session.Envelopes))
+	for i, e := range session.Envelopes {
+		directions[i] = e.Direction
+	}
+
+	require.Equal(t, []Direction{Inbound, Sent, Initiated, Sent, Inbound}, directions)
+	require.Equal(t, myDID, session.Envelopes[0].MyDID)
+	require.Equal(t, theirDID, session.Envelopes[0].TheirDID)
+	require.Contains(t, session.Envelopes[4].Error, "unsupported message type")
+
+	for i := 1; i < len(session.Envelopes); i++ {
+		require.GreaterOrEqual(t, int64(session.Envelopes[i].Offset), int64(session.Envelopes[i-1].Offset))
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, session.Save(path))
+
+	session, err := LoadSession(path)
+	require.NoError(t, err)
+
+	t.Run("replay against the same implementation", func(t *testing.T) {
+		player := NewPlayer(session, WithTimings(100))
+		require.NoError(t, player.Play(newEchoService(player.Outbound(), strings.ToLower)))
+	})
+
+	t.Run("replay against a regressed implementation", func(t *testing.T) {
+		player := NewPlayer(session)
+
+		err := player.Play(newEchoService(player.Outbound(), strings.ToUpper))
+		require.Error(t, err)
+
+		divergence := &Divergence{}
+		require.True(t, errors.As(err, &divergence))
+		require.Equal(t, 1, divergence.Index)
+		require.Contains(t, string(divergence.Expected.Message), `"text": "hello"`)
+		require.Contains(t, string(divergence.Actual.Message), `"text":"HELLO"`)
+	})
+
+	t.Run("replay with ignored fields", func(t *testing.T) {
+		player := NewPlayer(session, WithIgnoredFields("text"))
+		require.NoError(t, player.Play(newEchoService(player.Outbound(), strings.ToUpper)))
+	})
+
+	t.Run("expected message not sent", func(t *testing.T) {
+		stopped := make(chan struct{}, 1)
+
+		player := NewPlayer(session, WithTimeout(50*time.Millisecond),
+			WithActionHandler(func(action service.DIDCommAction) {
+				stopped <- struct{}{}
+				action.Stop(errors.New("rejected"))
+			}))
+
+		err := player.Play(newEchoService(player.Outbound(), strings.ToLower))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "replay diverged at envelope 1: expected message")
+		require.Contains(t, err.Error(), "was not sent within 50ms")
+		require.Len(t, stopped, 1)
+	})
+
+	t.Run("unexpected message sent", func(t *testing.T) {
+		player := NewPlayer(&Session{Protocol: echoProtocol, Envelopes: session.Envelopes[:1]})
+
+		err := player.Play(newEchoService(player.Outbound(), strings.ToLower))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "replay diverged at envelope 1: unexpected message sent")
+	})
+
+	t.Run("expected error not returned", func(t *testing.T) {
+		svc := newEchoService(nil, strings.ToLower)
+		svc.acceptAll = true
+
+		player := NewPlayer(&Session{Protocol: echoProtocol, Envelopes: session.Envelopes[4:]})
+		require.EqualError(t, player.Play(svc),
+			"replay diverged at envelope 0: expected error: "+session.Envelopes[4].Error)
+	})
+
+	t.Run("protocol mismatch", func(t *testing.T) {
+		player := NewPlayer(&Session{Protocol: "other"})
+		require.EqualError(t, player.Play(newEchoService(player.Outbound(), strings.ToLower)),
+			"session of protocol other can't be replayed against service echo")
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		player := NewPlayer(&Session{Envelopes: []*Envelope{{Direction: "unknown"}}})
+		require.EqualError(t, player.Play(newEchoService(player.Outbound(), strings.ToLower)),
+			"envelope 0: unsupported direction unknown")
+
+		player = NewPlayer(&Session{Envelopes: []*Envelope{{Direction: Inbound, Message: []byte("[]")}}})
+		err := player.Play(newEchoService(player.Outbound(), strings.ToLower))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "envelope 0: parse message")
+	})
+}
+
+func TestLoadSession(t *testing.T) {
+	_, err := LoadSession(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "read session")
+
+	err = (&Session{}).Save(filepath.Join(t.TempDir(), "missing", "session.json"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "write session")
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = LoadSession(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal session")
+}
+
+// recordSession records a session where bob pings alice, alice pings bob and bob sends an unsupported message.
+func recordSession(t *testing.T) *Session {
+	t.Helper()
+
+	recorder := NewRecorder(echoProtocol)
+
+	sent := make(chan struct{}, 2)
+
+	svc := newEchoService(recorder.Outbound(&mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			sent <- struct{}{}
+
+			return nil
+		},
+	}), strings.ToLower)
+
+	actions := make(chan service.DIDCommAction)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	go func() {
+		for action := range actions {
+			action.Continue(nil)
+		}
+	}()
+
+	recorded := recorder.Service(svc)
+
+	_, err := recorded.HandleInbound(newPing("Hello"), service.NewDIDCommContext(myDID, theirDID, nil))
+	require.NoError(t, err)
+	waitFor(t, sent)
+
+	_, err = recorded.HandleOutbound(newPing("bonjour"), myDID, theirDID)
+	require.NoError(t, err)
+	waitFor(t, sent)
+
+	_, err = recorded.HandleInbound(service.NewDIDCommMsgMap(&ping{ID: uuid.New().String(), Type: "unknown"}),
+		service.NewDIDCommContext(myDID, theirDID, nil))
+	require.Error(t, err)
+
+	return recorder.Session()
+}
+
+func waitFor(t *testing.T, ch <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout")
+	}
+}
+
+type ping struct {
+	ID     string            `json:"@id"`
+	Type   string            `json:"@type"`
+	Text   string            `json:"text"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+func newPing(text string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(&ping{ID: uuid.New().String(), Type: pingMsgType, Text: text})
+}
+
+// echoService answers the pings with a pong holding the transformed text, once the ping action is continued.
+type echoService struct {
+	service.Action
+	outbound  dispatcher.Outbound
+	transform func(string) string
+	acceptAll bool
+}
+
+func newEchoService(outbound dispatcher.Outbound, transform func(string) string) *echoService {
+	return &echoService{outbound: outbound, transform: transform}
+}
+
+func (s *echoService) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if msg.Type() != pingMsgType && !s.acceptAll {
+		return "", errors.New("unsupported message type " + msg.Type())
+	}
+
+	if msg.Type() != pingMsgType {
+		return "", nil
+	}
+
+	p := &ping{}
+	if err := msg.Decode(p); err != nil {
+		return "", err
+	}
+
+	go func() {
+		s.ActionEvent() <- service.DIDCommAction{
+			ProtocolName: echoProtocol,
+			Message:      msg,
+			Continue: func(interface{}) {
+				pong := &ping{
+					ID:     uuid.New().String(),
+					Type:   pongMsgType,
+					Text:   s.transform(p.Text),
+					Thread: &decorator.Thread{ID: p.ID},
+				}
+
+				_ = s.outbound.SendToDID(service.NewDIDCommMsgMap(pong), ctx.MyDID(), ctx.TheirDID()) // nolint: errcheck
+			},
+			Stop: func(error) {},
+		}
+	}()
+
+	return "", nil
+}
+
+func (s *echoService) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", s.outbound.SendToDID(msg, myDID, theirDID)
+}
+
+func (s *echoService) Accept(msgType string) bool {
+	return msgType == pingMsgType
+}
+
+func (s *echoService) Name() string {
+	return echoProtocol
+}
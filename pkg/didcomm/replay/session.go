@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package replay is a record/replay harness for protocol services regression testing. A Recorder captures a real
+// protocol session (the messages a service handles and sends, along with their timings) and a Player replays it
+// in-process against a service implementation, reporting where its behavior diverges from the recording.
+//
+// Recording a session:
+//
+//	recorder := replay.NewRecorder(svc.Name())
+//	// create the service with a provider returning recorder.Outbound(outbound) as outbound dispatcher
+//	// and register recorder.Service(svc) in the framework instead of svc
+//	...
+//	err := recorder.Session().Save("testdata/session.json")
+//
+// Replaying it:
+//
+//	session, err := replay.LoadSession("testdata/session.json")
+//	player := replay.NewPlayer(session)
+//	// create the service with a provider returning player.Outbound() as outbound dispatcher
+//	err = player.Play(svc)
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Direction of a recorded message.
+type Direction string
+
+const (
+	// Inbound messages are handled by the service (HandleInbound).
+	Inbound Direction = "inbound"
+	// Initiated messages are outbound messages the application asked the service to send (HandleOutbound).
+	Initiated Direction = "initiated"
+	// Sent messages are the messages the service sent through the outbound dispatcher.
+	Sent Direction = "sent"
+)
+
+// Envelope is a recorded message.
+type Envelope struct {
+	Direction Direction `json:"direction"`
+	// Offset is the time elapsed since the start of the session.
+	Offset          time.Duration   `json:"offset"`
+	MyDID           string          `json:"myDID,omitempty"`
+	TheirDID        string          `json:"theirDID,omitempty"`
+	ServiceEndpoint string          `json:"serviceEndpoint,omitempty"`
+	Message         json.RawMessage `json:"message"`
+	// Error returned by the service when handling the inbound or initiated message.
+	Error string `json:"error,omitempty"`
+}
+
+// Session is a recorded protocol session.
+type Session struct {
+	// Protocol is the name of the recorded protocol service.
+	Protocol  string      `json:"protocol"`
+	Envelopes []*Envelope `json:"envelopes"`
+}
+
+// Save writes the session as JSON to the file at the given path.
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal session: %w", err)
+	}
+
+	if err = ioutil.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSession reads the session saved at the given path.
+func LoadSession(path string) (*Session, error) {
+	data, err := ioutil.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+
+	session := &Session{}
+
+	if err = json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("unmarshal session: %w", err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package senderpolicy filters the inbound messages by sender DID: the rules of the policy allow or deny the senders
+// matching a DID pattern (e.g. did:key:* for all the did:key senders), optionally for the message types matching a
+// type pattern only. The rules are saved in a store and can be changed at runtime.
+//
+// A message is rejected when a deny rule matches its sender and type, or when allow rules match its type but none of
+// them matches its sender. The other messages are accepted.
+package senderpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// StoreName is the name of the store of the sender policy rules.
+const StoreName = "senderpolicy"
+
+const ruleTag = "senderpolicy_rule"
+
+var logger = log.New("aries-framework/senderpolicy")
+
+// Action is the action of a rule on the messages it matches.
+type Action string
+
+const (
+	// Allow accepts the messages of the matching senders, and rejects the messages of the other senders of the same
+	// message types.
+	Allow Action = "allow"
+	// Deny rejects the messages of the matching senders.
+	Deny Action = "deny"
+)
+
+// ErrDenied is returned when the sender of a message is denied by the policy.
+var ErrDenied = errors.New("sender is denied by the sender policy")
+
+// ErrInvalidRule is returned when adding an invalid rule.
+var ErrInvalidRule = errors.New("invalid sender policy rule")
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Rule allows or denies the messages of the senders matching its DID pattern. In the patterns, * matches any sequence
+// of characters.
+type Rule struct {
+	// ID of the rule. The rule with the same ID is replaced.
+	ID string `json:"id"`
+	// Sender is the pattern of the sender DIDs, e.g. did:key:* or did:peer:1zQm*.
+	Sender string `json:"sender"`
+	// MessageType is the pattern of the message types the rule applies to, e.g.
+	// https://didcomm.org/issue-credential/*, all the message types when empty.
+	MessageType string `json:"message_type,omitempty"`
+	// Action taken on the matching messages.
+	Action Action `json:"action"`
+}
+
+// Policy filters the inbound messages by sender DID.
+type Policy struct {
+	store storage.Store
+	lock  sync.RWMutex
+	rules map[string]Rule
+}
+
+// New returns the sender policy saved in the store, with the given rules added.
+func New(p provider, rules ...Rule) (*Policy, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sender policy store: %w", err)
+	}
+
+	err = p.StorageProvider().SetStoreConfig(StoreName, storage.StoreConfiguration{TagNames: []string{ruleTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set sender policy store config: %w", err)
+	}
+
+	policy := &Policy{store: store, rules: map[string]Rule{}}
+
+	if err = policy.load(); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err = policy.AddRule(rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return policy, nil
+}
+
+func (p *Policy) load() error {
+	iter, err := p.store.Query(ruleTag)
+	if err != nil {
+		return fmt.Errorf("query sender policy rules: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	more, err := iter.Next()
+	if err != nil {
+		return fmt.Errorf("query sender policy rules: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return fmt.Errorf("get sender policy rule: %w", err)
+		}
+
+		var rule Rule
+
+		if err = json.Unmarshal(value, &rule); err != nil {
+			return fmt.Errorf("unmarshal sender policy rule: %w", err)
+		}
+
+		p.rules[rule.ID] = rule
+
+		more, err = iter.Next()
+		if err != nil {
+			return fmt.Errorf("query sender policy rules: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AddRule adds a rule to the policy, replacing the rule with the same ID.
+func (p *Policy) AddRule(rule Rule) error {
+	if err := validate(rule); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(rule)
+	if err != nil {
+		return fmt.Errorf("marshal sender policy rule: %w", err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err = p.store.Put(rule.ID, value, storage.Tag{Name: ruleTag}); err != nil {
+		return fmt.Errorf("save sender policy rule: %w", err)
+	}
+
+	p.rules[rule.ID] = rule
+
+	return nil
+}
+
+// RemoveRule removes a rule from the policy. storage.ErrDataNotFound is returned if the policy has no such rule.
+func (p *Policy) RemoveRule(id string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.rules[id]; !ok {
+		return fmt.Errorf("sender policy rule %s: %w", id, storage.ErrDataNotFound)
+	}
+
+	if err := p.store.Delete(id); err != nil {
+		return fmt.Errorf("delete sender policy rule: %w", err)
+	}
+
+	delete(p.rules, id)
+
+	return nil
+}
+
+// Rules returns the rules of the policy, sorted by ID.
+func (p *Policy) Rules() []Rule {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	rules := make([]Rule, 0, len(p.rules))
+
+	for _, rule := range p.rules {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
+
+// Check returns ErrDenied if the policy rejects the message of the given type sent by the sender DID. The sender is
+// empty when the message was sent anonymously.
+func (p *Policy) Check(sender, msgType string) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var allowRules, allowed bool
+
+	for _, rule := range p.rules {
+		if rule.MessageType != "" && !match(rule.MessageType, msgType) {
+			continue
+		}
+
+		senderMatch := match(rule.Sender, sender)
+
+		switch rule.Action {
+		case Deny:
+			if senderMatch {
+				return fmt.Errorf("%w: rule %s denies sender [%s] of message type %s", ErrDenied, rule.ID, sender,
+					msgType)
+			}
+		case Allow:
+			allowRules = true
+			allowed = allowed || senderMatch
+		}
+	}
+
+	if allowRules && !allowed {
+		return fmt.Errorf("%w: sender [%s] of message type %s isn't allowed", ErrDenied, sender, msgType)
+	}
+
+	return nil
+}
+
+func validate(rule Rule) error {
+	switch {
+	case rule.ID == "":
+		return fmt.Errorf("%w: empty id", ErrInvalidRule)
+	case rule.Sender == "":
+		return fmt.Errorf("%w: empty sender pattern", ErrInvalidRule)
+	case rule.Action != Allow && rule.Action != Deny:
+		return fmt.Errorf("%w: unsupported action '%s'", ErrInvalidRule, rule.Action)
+	}
+
+	return nil
+}
+
+// match reports whether s matches the pattern, where * matches any sequence of characters.
+func match(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
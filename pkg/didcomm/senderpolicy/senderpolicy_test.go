@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package senderpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	issueCredentialOffer = "https://didcomm.org/issue-credential/2.0/offer-credential"
+	trustPing            = "https://didcomm.org/trust_ping/1.0/ping"
+	didKeySender         = "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"
+	didPeerSender        = "did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa"
+	testFailure          = "test error"
+)
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success - rules are saved", func(t *testing.T) {
+		p := newProvider()
+
+		policy, err := New(p, Rule{ID: "no-did-key", Sender: "did:key:*", Action: Deny})
+		require.NoError(t, err)
+		require.Len(t, policy.Rules(), 1)
+
+		policy, err = New(p, Rule{ID: "peer", Sender: "did:peer:*", Action: Allow})
+		require.NoError(t, err)
+		require.Equal(t, []Rule{
+			{ID: "no-did-key", Sender: "did:key:*", Action: Deny},
+			{ID: "peer", Sender: "did:peer:*", Action: Allow},
+		}, policy.Rules())
+	})
+
+	t.Run("error - invalid rule", func(t *testing.T) {
+		_, err := New(newProvider(), Rule{ID: "rule", Sender: "did:key:*", Action: "block"})
+		require.ErrorIs(t, err, ErrInvalidRule)
+	})
+
+	t.Run("error - open store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New(testFailure)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open sender policy store")
+	})
+
+	t.Run("error - query rules", func(t *testing.T) {
+		p := mockstorage.NewMockStoreProvider()
+		p.Store.ErrQuery = errors.New(testFailure)
+
+		_, err := New(&mockprovider.Provider{StorageProviderValue: p})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query sender policy rules")
+	})
+
+	t.Run("error - invalid saved rule", func(t *testing.T) {
+		p := newProvider()
+
+		store, err := p.StorageProvider().OpenStore(StoreName)
+		require.NoError(t, err)
+		require.NoError(t, store.Put("rule", []byte("{"), storage.Tag{Name: ruleTag}))
+
+		_, err = New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal sender policy rule")
+	})
+}
+
+func TestPolicy_RemoveRule(t *testing.T) {
+	p := newProvider()
+
+	policy, err := New(p, Rule{ID: "no-did-key", Sender: "did:key:*", Action: Deny})
+	require.NoError(t, err)
+
+	require.NoError(t, policy.RemoveRule("no-did-key"))
+	require.Empty(t, policy.Rules())
+	require.ErrorIs(t, policy.RemoveRule("no-did-key"), storage.ErrDataNotFound)
+
+	policy, err = New(p)
+	require.NoError(t, err)
+	require.Empty(t, policy.Rules())
+}
+
+func TestPolicy_Check(t *testing.T) {
+	t.Run("no rules", func(t *testing.T) {
+		policy, err := New(newProvider())
+		require.NoError(t, err)
+		require.NoError(t, policy.Check(didKeySender, trustPing))
+		require.NoError(t, policy.Check("", trustPing))
+	})
+
+	t.Run("deny list by message type", func(t *testing.T) {
+		policy, err := New(newProvider(), Rule{
+			ID:          "no-did-key-issuance",
+			Sender:      "did:key:*",
+			MessageType: "https://didcomm.org/issue-credential/*",
+			Action:      Deny,
+		})
+		require.NoError(t, err)
+
+		err = policy.Check(didKeySender, issueCredentialOffer)
+		require.ErrorIs(t, err, ErrDenied)
+		require.Contains(t, err.Error(), "rule no-did-key-issuance denies sender")
+
+		require.NoError(t, policy.Check(didKeySender, trustPing))
+		require.NoError(t, policy.Check(didPeerSender, issueCredentialOffer))
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		policy, err := New(newProvider(),
+			Rule{ID: "peer", Sender: "did:peer:*", Action: Allow},
+			Rule{ID: "trusted-key", Sender: didKeySender, Action: Allow},
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, policy.Check(didPeerSender, trustPing))
+		require.NoError(t, policy.Check(didKeySender, trustPing))
+		require.ErrorIs(t, policy.Check("did:key:z6MkOther", trustPing), ErrDenied)
+		require.ErrorIs(t, policy.Check("", trustPing), ErrDenied)
+	})
+
+	t.Run("deny rules take precedence over allow rules", func(t *testing.T) {
+		policy, err := New(newProvider(),
+			Rule{ID: "peer", Sender: "did:peer:*", Action: Allow},
+			Rule{ID: "blocked-peer", Sender: "did:peer:1zQmZMyg*", Action: Deny},
+		)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, policy.Check(didPeerSender, trustPing), ErrDenied)
+		require.NoError(t, policy.Check("did:peer:1zQmOther", trustPing))
+	})
+
+	t.Run("anonymous senders", func(t *testing.T) {
+		policy, err := New(newProvider(), Rule{ID: "anonymous", Sender: "*", MessageType: "*/offer-*", Action: Deny})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, policy.Check("", issueCredentialOffer), ErrDenied)
+		require.NoError(t, policy.Check("", trustPing))
+	})
+}
+
+func TestPolicy_AddRule(t *testing.T) {
+	policy, err := New(newProvider())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, policy.AddRule(Rule{Sender: "*", Action: Deny}), ErrInvalidRule)
+	require.ErrorIs(t, policy.AddRule(Rule{ID: "rule", Action: Deny}), ErrInvalidRule)
+
+	require.NoError(t, policy.AddRule(Rule{ID: "rule", Sender: "did:key:*", Action: Deny}))
+	require.NoError(t, policy.AddRule(Rule{ID: "rule", Sender: "did:web:*", Action: Deny}))
+	require.Equal(t, []Rule{{ID: "rule", Sender: "did:web:*", Action: Deny}}, policy.Rules())
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		{"did:key:*", "did:key:z6Mk", true},
+		{"did:key:*", "did:peer:1zQm", false},
+		{"did:key:z6Mk", "did:key:z6Mk", true},
+		{"did:key:z6Mk", "did:key:z6Mkx", false},
+		{"*", "", true},
+		{"did:*:alice", "did:example:alice", true},
+		{"did:*:alice", "did:example:bob", false},
+		{"https://didcomm.org/*/*/request*", "https://didcomm.org/present-proof/2.0/request-presentation", true},
+		{"ab*ba", "aba", false},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.match, match(tc.pattern, tc.s), "%s %s", tc.pattern, tc.s)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal"
+)
+
+// InboundOpt is an inbound gRPC transport option.
+type InboundOpt func(i *Inbound)
+
+// WithInboundTLSConfig sets the TLS configuration of the gRPC server, set ClientAuth to
+// tls.RequireAndVerifyClientCert for mutual TLS.
+func WithInboundTLSConfig(tlsConfig *tls.Config) InboundOpt {
+	return func(i *Inbound) {
+		i.serverOpts = append(i.serverOpts, googlegrpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+}
+
+// WithServerOptions sets options of the gRPC server.
+func WithServerOptions(opts ...googlegrpc.ServerOption) InboundOpt {
+	return func(i *Inbound) {
+		i.serverOpts = append(i.serverOpts, opts...)
+	}
+}
+
+// Inbound gRPC type.
+type Inbound struct {
+	internalAddr string
+	externalAddr string
+	serverOpts   []googlegrpc.ServerOption
+	server       *googlegrpc.Server
+	prov         transport.Provider
+}
+
+// NewInbound creates a new gRPC inbound transport instance listening on internalAddr (eg. ":8090"), externalAddr
+// being the endpoint of the transport (eg. "grpc://agent.example.com:8090").
+func NewInbound(internalAddr, externalAddr string, opts ...InboundOpt) (*Inbound, error) {
+	if internalAddr == "" {
+		return nil, errors.New("grpc address is mandatory")
+	}
+
+	if externalAddr == "" {
+		externalAddr = grpcScheme + internalAddr
+	}
+
+	i := &Inbound{
+		internalAddr: internalAddr,
+		externalAddr: externalAddr,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
+}
+
+// Start the gRPC server.
+func (i *Inbound) Start(prov transport.Provider) error {
+	if prov == nil || prov.InboundMessageHandler() == nil {
+		return errors.New("creation of inbound handler failed")
+	}
+
+	listener, err := net.Listen("tcp", i.internalAddr)
+	if err != nil {
+		return fmt.Errorf("gRPC server listen on [%s] failed: %w", i.internalAddr, err)
+	}
+
+	i.prov = prov
+	i.server = googlegrpc.NewServer(i.serverOpts...)
+	i.server.RegisterService(&serviceDesc, i)
+
+	go func() {
+		if err := i.server.Serve(listener); err != nil {
+			logger.Errorf("gRPC server with address [%s] stopped, cause: %s", i.internalAddr, err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop the gRPC server, the envelopes being received are handled before the server stops.
+func (i *Inbound) Stop() error {
+	if i.server != nil {
+		i.server.GracefulStop()
+	}
+
+	return nil
+}
+
+// Endpoint provides the gRPC connection details.
+func (i *Inbound) Endpoint() string {
+	return i.externalAddr
+}
+
+// Stream receives the envelopes of a sender, each envelope being acknowledged once handled.
+func (i *Inbound) Stream(stream googlegrpc.ServerStream) error {
+	for {
+		env := &Envelope{}
+
+		if err := stream.RecvMsg(env); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		ack := &Ack{}
+
+		if err := i.handle(stream.Context(), env.Message); err != nil {
+			logger.Errorf("incoming msg processing failed: %s", err)
+
+			ack.Error = err.Error()
+		}
+
+		if err := stream.SendMsg(ack); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *Inbound) handle(ctx context.Context, message []byte) error {
+	unpackMsg, err := internal.UnpackMessage(message, i.prov.Packager(), "grpc")
+	if err != nil {
+		return err
+	}
+
+	unpackMsg.Origin = origin(ctx)
+
+	return i.prov.InboundMessageHandler()(unpackMsg)
+}
+
+// origin returns the origin of the messages received on the stream.
+func origin(ctx context.Context) *service.Origin {
+	o := &service.Origin{Transport: "grpc"}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return o
+	}
+
+	if p.Addr != nil {
+		o.RemoteAddr = p.Addr.String()
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		state := tlsInfo.State
+		o.TLS = &state
+	}
+
+	return o
+}
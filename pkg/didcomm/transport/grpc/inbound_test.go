@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+func TestInboundTransport(t *testing.T) {
+	t.Run("test inbound transport - with host/port", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+		externalAddr := "grpc://example.com" + port
+		inbound, err := NewInbound("localhost"+port, externalAddr)
+		require.NoError(t, err)
+		require.Equal(t, externalAddr, inbound.Endpoint())
+	})
+
+	t.Run("test inbound transport - no external address", func(t *testing.T) {
+		internalAddr := "example.com:" + strconv.Itoa(transportutil.GetRandomPort(5))
+		inbound, err := NewInbound(internalAddr, "")
+		require.NoError(t, err)
+		require.Equal(t, "grpc://"+internalAddr, inbound.Endpoint())
+	})
+
+	t.Run("test inbound transport - no address", func(t *testing.T) {
+		_, err := NewInbound("", "")
+		require.EqualError(t, err, "grpc address is mandatory")
+	})
+
+	t.Run("test inbound transport - start/stop", func(t *testing.T) {
+		inbound, err := NewInbound(":"+strconv.Itoa(transportutil.GetRandomPort(5)), "")
+		require.NoError(t, err)
+
+		err = inbound.Start(&mockProvider{
+			packagerValue: &mockpackager.Packager{},
+			handler:       func(*transport.Envelope) error { return nil },
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, inbound.Stop())
+	})
+
+	t.Run("test inbound transport - nil provider", func(t *testing.T) {
+		inbound, err := NewInbound(":"+strconv.Itoa(transportutil.GetRandomPort(5)), "")
+		require.NoError(t, err)
+
+		err = inbound.Start(nil)
+		require.EqualError(t, err, "creation of inbound handler failed")
+	})
+
+	t.Run("test inbound transport - address in use", func(t *testing.T) {
+		addr := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+		prov := &mockProvider{
+			packagerValue: &mockpackager.Packager{},
+			handler:       func(*transport.Envelope) error { return nil },
+		}
+
+		inbound, err := NewInbound(addr, "")
+		require.NoError(t, err)
+		require.NoError(t, inbound.Start(prov))
+
+		defer func() {
+			require.NoError(t, inbound.Stop())
+		}()
+
+		other, err := NewInbound(addr, "")
+		require.NoError(t, err)
+
+		err = other.Start(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "gRPC server listen on")
+	})
+}
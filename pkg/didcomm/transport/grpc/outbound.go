@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// OutboundOpt is an outbound gRPC transport option.
+type OutboundOpt func(o *Outbound)
+
+// WithOutboundTLSConfig sets the TLS configuration of the connections to the other agents, set Certificates for
+// mutual TLS. The connections are not encrypted if neither this option nor a credentials dial option is set.
+func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundOpt {
+	return func(o *Outbound) {
+		o.dialOpts = append(o.dialOpts, googlegrpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		o.secure = true
+	}
+}
+
+// WithDialOptions sets options of the gRPC connections to the other agents.
+func WithDialOptions(opts ...googlegrpc.DialOption) OutboundOpt {
+	return func(o *Outbound) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
+
+// Outbound gRPC type, it keeps a connection and a stream per endpoint, the envelopes sent to an endpoint being
+// multiplexed with the other streams of the connection.
+type Outbound struct {
+	dialOpts []googlegrpc.DialOption
+	secure   bool
+	mu       sync.Mutex
+	conns    map[string]*endpointConn
+}
+
+// endpointConn is the connection and the stream to an endpoint.
+type endpointConn struct {
+	mu     sync.Mutex
+	conn   *googlegrpc.ClientConn
+	stream googlegrpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// NewOutbound creates a new instance of Outbound gRPC transport.
+func NewOutbound(opts ...OutboundOpt) (*Outbound, error) {
+	o := &Outbound{conns: make(map[string]*endpointConn)}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if !o.secure {
+		o.dialOpts = append(o.dialOpts, googlegrpc.WithInsecure())
+	}
+
+	o.dialOpts = append(o.dialOpts, googlegrpc.WithDefaultCallOptions(googlegrpc.CallContentSubtype(CodecName)))
+
+	return o, nil
+}
+
+// Start starts outbound transport.
+func (o *Outbound) Start(prov transport.Provider) error {
+	return nil
+}
+
+// Send sends the envelope on the stream to the endpoint, it returns once the receiver acknowledged the envelope.
+func (o *Outbound) Send(data []byte, destination *service.Destination) (string, error) {
+	ec, err := o.endpoint(destination.ServiceEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if err = ec.send(data); err != nil {
+		return "", fmt.Errorf("send envelope to [%s]: %w", destination.ServiceEndpoint, err)
+	}
+
+	return "", nil
+}
+
+// AcceptRecipient checks if there is a connection for the list of recipient keys.
+func (o *Outbound) AcceptRecipient([]string) bool {
+	return false
+}
+
+// Accept url.
+func (o *Outbound) Accept(url string) bool {
+	return strings.HasPrefix(url, grpcScheme)
+}
+
+// Close closes the connections to the endpoints.
+func (o *Outbound) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var errs []string
+
+	for endpoint, ec := range o.conns {
+		ec.mu.Lock()
+		ec.closeStream()
+		ec.mu.Unlock()
+
+		if err := ec.conn.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("[%s]: %s", endpoint, err))
+		}
+
+		delete(o.conns, endpoint)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("close gRPC connections: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// endpoint returns the connection to the endpoint, dialing it if needed.
+func (o *Outbound) endpoint(endpoint string) (*endpointConn, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if ec, ok := o.conns[endpoint]; ok {
+		return ec, nil
+	}
+
+	// the connection is established on the first send.
+	conn, err := googlegrpc.Dial(strings.TrimPrefix(endpoint, grpcScheme), o.dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial [%s]: %w", endpoint, err)
+	}
+
+	ec := &endpointConn{conn: conn}
+	o.conns[endpoint] = ec
+
+	return ec, nil
+}
+
+// send sends the envelope on the stream and waits for its acknowledgement, the stream being opened again by the next
+// send when it fails.
+func (ec *endpointConn) send(data []byte) error {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if ec.stream == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		stream, err := ec.conn.NewStream(ctx, streamDesc, streamMethod)
+		if err != nil {
+			cancel()
+
+			return fmt.Errorf("open stream: %w", err)
+		}
+
+		ec.stream = stream
+		ec.cancel = cancel
+	}
+
+	if err := ec.stream.SendMsg(&Envelope{Message: data}); err != nil {
+		ec.closeStream()
+
+		return err
+	}
+
+	ack := &Ack{}
+
+	if err := ec.stream.RecvMsg(ack); err != nil {
+		ec.closeStream()
+
+		return err
+	}
+
+	if ack.Error != "" {
+		return errors.New(ack.Error)
+	}
+
+	return nil
+}
+
+func (ec *endpointConn) closeStream() {
+	if ec.cancel != nil {
+		ec.cancel()
+	}
+
+	ec.stream = nil
+	ec.cancel = nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpc
+
+import (
+	"crypto/tls"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+func TestOutboundTransport(t *testing.T) {
+	t.Run("test outbound transport - accept", func(t *testing.T) {
+		outbound, err := NewOutbound()
+		require.NoError(t, err)
+		require.NoError(t, outbound.Start(nil))
+
+		require.True(t, outbound.Accept("grpc://localhost:8090"))
+		require.False(t, outbound.Accept("http://localhost:8090"))
+		require.False(t, outbound.AcceptRecipient([]string{"key"}))
+	})
+
+	t.Run("test outbound transport - send and receive", func(t *testing.T) {
+		received := make(chan *transport.Envelope, 2)
+
+		endpoint := startInbound(t, &mockProvider{
+			packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("unpacked")}},
+			handler: func(envelope *transport.Envelope) error {
+				received <- envelope
+
+				return nil
+			},
+		})
+
+		outbound, err := NewOutbound()
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, outbound.Close())
+		}()
+
+		// the envelopes are sent on the same stream
+		for i := 0; i < 2; i++ {
+			_, err = outbound.Send([]byte("packed"), &service.Destination{ServiceEndpoint: endpoint})
+			require.NoError(t, err)
+
+			select {
+			case envelope := <-received:
+				require.Equal(t, "unpacked", string(envelope.Message))
+				require.Equal(t, "grpc", envelope.Origin.Transport)
+				require.NotEmpty(t, envelope.Origin.RemoteAddr)
+				require.Nil(t, envelope.Origin.TLS)
+			case <-time.After(time.Second):
+				require.Fail(t, "envelope not received")
+			}
+		}
+	})
+
+	t.Run("test outbound transport - receiver error", func(t *testing.T) {
+		endpoint := startInbound(t, &mockProvider{
+			packagerValue: &mockpackager.Packager{UnpackErr: errors.New("unpack error")},
+			handler:       func(*transport.Envelope) error { return nil },
+		})
+
+		outbound, err := NewOutbound()
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("packed"), &service.Destination{ServiceEndpoint: endpoint})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unpack msg from grpc: unpack error")
+
+		require.NoError(t, outbound.Close())
+	})
+
+	t.Run("test outbound transport - no receiver", func(t *testing.T) {
+		outbound, err := NewOutbound(WithDialOptions())
+		require.NoError(t, err)
+
+		endpoint := "grpc://localhost:" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		_, err = outbound.Send([]byte("packed"), &service.Destination{ServiceEndpoint: endpoint})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send envelope to ["+endpoint+"]")
+
+		require.NoError(t, outbound.Close())
+	})
+
+	t.Run("test outbound transport - mutual TLS", func(t *testing.T) {
+		cert, pool := selfSignedCert(t)
+		received := make(chan *transport.Envelope, 1)
+
+		endpoint := startInbound(t, &mockProvider{
+			packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("unpacked")}},
+			handler: func(envelope *transport.Envelope) error {
+				received <- envelope
+
+				return nil
+			},
+		}, WithInboundTLSConfig(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		}))
+
+		outbound, err := NewOutbound(WithOutboundTLSConfig(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+			MinVersion:   tls.VersionTLS12,
+		}))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, outbound.Close())
+		}()
+
+		_, err = outbound.Send([]byte("packed"), &service.Destination{ServiceEndpoint: endpoint})
+		require.NoError(t, err)
+
+		envelope := <-received
+		require.NotNil(t, envelope.Origin.TLS)
+		require.Len(t, envelope.Origin.TLS.PeerCertificates, 1)
+
+		// the client certificate is required
+		noClientCert, err := NewOutbound(WithOutboundTLSConfig(&tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}))
+		require.NoError(t, err)
+
+		_, err = noClientCert.Send([]byte("packed"), &service.Destination{ServiceEndpoint: endpoint})
+		require.Error(t, err)
+
+		require.NoError(t, noClientCert.Close())
+	})
+}
+
+func startInbound(t *testing.T, prov transport.Provider, opts ...InboundOpt) string {
+	t.Helper()
+
+	addr := "localhost:" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+	inbound, err := NewInbound(addr, "", opts...)
+	require.NoError(t, err)
+	require.NoError(t, inbound.Start(prov))
+
+	t.Cleanup(func() {
+		require.NoError(t, inbound.Stop())
+	})
+
+	require.NoError(t, transportutil.VerifyListener(addr, time.Second))
+
+	return inbound.Endpoint()
+}
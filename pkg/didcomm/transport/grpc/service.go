@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpc provides the DIDComm transport over gRPC, for deployments (typically agents in the same data center)
+// preferring HTTP/2 multiplexing and mutual TLS to HTTP posts. The agents exchange the packed envelopes on a
+// bidirectional stream of the Transport service: the sender streams the envelopes, the receiver acknowledging each
+// envelope once handled. The envelopes are sent as raw bytes, so the peers don't need generated stubs.
+//
+// The service endpoints of the transport use the grpc scheme, eg. grpc://agent.example.com:8090. The transports are
+// registered with the framework options:
+//
+//	inbound, err := grpc.NewInbound(":8090", "grpc://agent.example.com:8090", grpc.WithInboundTLSConfig(serverTLS))
+//	outbound, err := grpc.NewOutbound(grpc.WithOutboundTLSConfig(clientTLS))
+//	framework, err := aries.New(aries.WithInboundTransport(inbound), aries.WithOutboundTransports(outbound))
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+const (
+	// ServiceName is the name of the DIDComm gRPC transport service.
+	ServiceName = "aries.didcomm.Transport"
+	// CodecName is the content-subtype of the transport gRPC calls, the envelopes being sent as raw bytes.
+	CodecName = "didcomm-envelope"
+
+	grpcScheme   = "grpc://"
+	streamName   = "Stream"
+	streamMethod = "/" + ServiceName + "/" + streamName
+)
+
+var logger = log.New("aries-framework/grpc")
+
+// nolint: gochecknoinits
+func init() {
+	encoding.RegisterCodec(envelopeCodec{})
+}
+
+// Envelope is a packed DIDComm envelope sent on the transport stream.
+type Envelope struct {
+	Message []byte
+}
+
+// Ack acknowledges an envelope received on the transport stream, Error being set when the envelope couldn't be
+// handled.
+type Ack struct {
+	Error string
+}
+
+type transportServer interface {
+	Stream(stream googlegrpc.ServerStream) error
+}
+
+// nolint: gochecknoglobals
+var serviceDesc = googlegrpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*transportServer)(nil),
+	Streams: []googlegrpc.StreamDesc{{
+		StreamName:    streamName,
+		Handler:       streamHandler,
+		ServerStreams: true,
+		ClientStreams: true,
+	}},
+}
+
+// nolint: gochecknoglobals
+var streamDesc = &serviceDesc.Streams[0]
+
+func streamHandler(srv interface{}, stream googlegrpc.ServerStream) error {
+	return srv.(transportServer).Stream(stream)
+}
+
+// envelopeCodec encodes the envelopes and acks as raw bytes.
+type envelopeCodec struct{}
+
+func (envelopeCodec) Marshal(v interface{}) ([]byte, error) {
+	switch m := v.(type) {
+	case *Envelope:
+		return m.Message, nil
+	case *Ack:
+		return []byte(m.Error), nil
+	default:
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+}
+
+func (envelopeCodec) Unmarshal(data []byte, v interface{}) error {
+	switch m := v.(type) {
+	case *Envelope:
+		m.Message = append([]byte(nil), data...)
+	case *Ack:
+		m.Error = string(data)
+	default:
+		return errors.New("unsupported message type")
+	}
+
+	return nil
+}
+
+func (envelopeCodec) Name() string {
+	return CodecName
+}
@@ -10,40 +10,78 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/rs/cors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal"
 )
 
 var logger = log.New("aries-framework/http")
 
+var errMessageTooLarge = errors.New("message too large")
+
 // TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
 
+// InboundHTTPOpt is an inbound HTTP transport option.
+type InboundHTTPOpt func(opts *inboundCommHTTPOpts)
+
+type inboundCommHTTPOpts struct {
+	limits             transport.InboundLimits
+	certReloadInterval time.Duration
+}
+
+// WithInboundLimits option enforces a maximum envelope size and a rate limit per remote address on the inbound
+// messages. The rejected messages are answered with a problem report. Behind a reverse proxy, the rate limit applies
+// to the address of the proxy unless the limits set the client address header of the proxy.
+func WithInboundLimits(limits transport.InboundLimits) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.limits = limits
+	}
+}
+
+// WithCertificateReload option reloads the TLS certificate of the transport when its files are modified, eg. when it is
+// renewed, without restarting the transport. The files are checked at most once per interval, on the TLS handshakes.
+func WithCertificateReload(interval time.Duration) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.certReloadInterval = interval
+	}
+}
+
 // NewInboundHandler will create a new handler to enforce Did-Comm HTTP transport specs
 // then routes processing to the mandatory 'msgHandler' argument.
 //
 // Arguments:
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
-func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
+func NewInboundHandler(prov transport.Provider, opts ...InboundHTTPOpt) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
+	inOpts := &inboundCommHTTPOpts{}
+
+	for _, opt := range opts {
+		opt(inOpts)
+	}
+
+	limiter := internal.NewInboundLimiter(inOpts.limits)
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
+		processPOSTRequest(w, r, prov, limiter)
 	})
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider, limiter *internal.InboundLimiter) {
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
@@ -52,7 +90,24 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
+	remoteAddr := limiter.RemoteAddr(r)
+
+	if !limiter.Allow(remoteAddr) {
+		logger.Warnf("rate limit exceeded by %s - returning Code: %d", remoteAddr, http.StatusTooManyRequests)
+		writeProblemReport(w, http.StatusTooManyRequests, internal.RateLimitedCode)
+
+		return
+	}
+
+	body, err := readBody(r, limiter.MaxMessageSize)
+	if errors.Is(err, errMessageTooLarge) {
+		logger.Warnf("message from %s larger than %d bytes - returning Code: %d", remoteAddr,
+			limiter.MaxMessageSize, http.StatusRequestEntityTooLarge)
+		writeProblemReport(w, http.StatusRequestEntityTooLarge, internal.MessageTooLargeCode)
+
+		return
+	}
+
 	if err != nil {
 		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "Failed to read payload", http.StatusInternalServerError)
@@ -68,6 +123,12 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	unpackMsg.Origin = &service.Origin{
+		Transport:  "http",
+		RemoteAddr: remoteAddr,
+		TLS:        r.TLS,
+	}
+
 	messageHandler := prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg)
@@ -81,6 +142,37 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 	}
 }
 
+// readBody reads the request body, at most maxMessageSize bytes if set.
+func readBody(r *http.Request, maxMessageSize int64) ([]byte, error) {
+	if maxMessageSize <= 0 {
+		return ioutil.ReadAll(r.Body)
+	}
+
+	if r.ContentLength > maxMessageSize {
+		return nil, errMessageTooLarge
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > maxMessageSize {
+		return nil, errMessageTooLarge
+	}
+
+	return body, nil
+}
+
+func writeProblemReport(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(internal.ProblemReport(code)); err != nil {
+		logger.Errorf("failed to write problem report: %s", err)
+	}
+}
+
 // validatePayload validate and get the payload from the request.
 func validatePayload(r *http.Request, w http.ResponseWriter) bool {
 	if r.ContentLength == 0 { // empty payload should not be accepted
@@ -113,10 +205,11 @@ type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	certFile, keyFile string
+	opts              []InboundHTTPOpt
 }
 
 // NewInbound creates a new HTTP inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundHTTPOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("http address is mandatory")
 	}
@@ -130,18 +223,23 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
+		opts:         opts,
 	}, nil
 }
 
 // Start the http server.
 func (i *Inbound) Start(prov transport.Provider) error {
-	handler, err := NewInboundHandler(prov)
+	handler, err := NewInboundHandler(prov, i.opts...)
 	if err != nil {
 		return fmt.Errorf("HTTP server start failed: %w", err)
 	}
 
 	i.server.Handler = handler
 
+	if err = i.setCertReloader(); err != nil {
+		return fmt.Errorf("HTTP server start failed: %w", err)
+	}
+
 	go func() {
 		if err := i.listenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatalf("HTTP server start with address [%s] failed, cause:  %s", i.server.Addr, err)
@@ -151,7 +249,34 @@ func (i *Inbound) Start(prov transport.Provider) error {
 	return nil
 }
 
+// setCertReloader serves the certificate through a reloader when the certificate reload option is set.
+func (i *Inbound) setCertReloader() error {
+	inOpts := &inboundCommHTTPOpts{}
+
+	for _, opt := range i.opts {
+		opt(inOpts)
+	}
+
+	if i.certFile == "" || i.keyFile == "" || inOpts.certReloadInterval <= 0 {
+		return nil
+	}
+
+	reloader, err := internal.NewCertReloader(i.certFile, i.keyFile, inOpts.certReloadInterval)
+	if err != nil {
+		return err
+	}
+
+	i.server.TLSConfig = reloader.TLSConfig()
+
+	return nil
+}
+
 func (i *Inbound) listenAndServe() error {
+	if i.server.TLSConfig != nil {
+		// the certificate is served by the TLS configuration
+		return i.server.ListenAndServeTLS("", "")
+	}
+
 	if i.certFile != "" && i.keyFile != "" {
 		return i.server.ListenAndServeTLS(i.certFile, i.keyFile)
 	}
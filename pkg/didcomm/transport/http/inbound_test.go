@@ -10,25 +10,35 @@ import (
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 )
 
 type mockProvider struct {
 	packagerValue transport.Packager
+	handlerFunc   transport.InboundMessageHandler
 }
 
 func (p *mockProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	if p.handlerFunc != nil {
+		return p.handlerFunc
+	}
+
 	return func(envelope *transport.Envelope) error {
 		logger.Debugf("message received is %s", envelope.Message)
 		return nil
@@ -130,6 +140,107 @@ func TestInboundHandler(t *testing.T) {
 	require.NoError(t, resp.Body.Close())
 }
 
+func TestInboundHandlerOrigin(t *testing.T) {
+	var origin *service.Origin
+
+	inHandler, err := NewInboundHandler(&mockProvider{
+		packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}},
+		handlerFunc: func(envelope *transport.Envelope) error {
+			origin = envelope.Origin
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("success"))
+	req.Header.Set("Content-Type", commContentType)
+	req.RemoteAddr = "192.168.0.10:5555"
+
+	rec := httptest.NewRecorder()
+	inHandler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.NotNil(t, origin)
+	require.Equal(t, "http", origin.Transport)
+	require.Equal(t, "192.168.0.10:5555", origin.RemoteAddr)
+	require.Nil(t, origin.TLS)
+	require.False(t, origin.ViaMediator)
+}
+
+func TestInboundHandlerLimits(t *testing.T) {
+	newHandler := func(t *testing.T, limits transport.InboundLimits) http.Handler {
+		t.Helper()
+
+		inHandler, err := NewInboundHandler(&mockProvider{
+			packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}},
+		}, WithInboundLimits(limits))
+		require.NoError(t, err)
+
+		return inHandler
+	}
+
+	post := func(inHandler http.Handler, remoteAddr string, body io.Reader) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.Header.Set("Content-Type", commContentType)
+		req.RemoteAddr = remoteAddr
+
+		rec := httptest.NewRecorder()
+		inHandler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("test max message size", func(t *testing.T) {
+		inHandler := newHandler(t, transport.InboundLimits{MaxMessageSize: 7})
+
+		rec := post(inHandler, "192.168.0.10:5555", bytes.NewBufferString("success"))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		rec = post(inHandler, "192.168.0.10:5555", bytes.NewBufferString("too large"))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		requireProblemReport(t, rec.Body.Bytes(), "message-too-large")
+
+		// the size is checked while reading when the content length is unknown
+		rec = post(inHandler, "192.168.0.10:5555", ioutil.NopCloser(bytes.NewBufferString("too large")))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		requireProblemReport(t, rec.Body.Bytes(), "message-too-large")
+	})
+
+	t.Run("test rate limit", func(t *testing.T) {
+		inHandler := newHandler(t, transport.InboundLimits{RateLimit: 0.001, RateBurst: 2})
+
+		for i := 0; i < 2; i++ {
+			rec := post(inHandler, "192.168.0.10:5555", bytes.NewBufferString("success"))
+			require.Equal(t, http.StatusAccepted, rec.Code)
+		}
+
+		// the port of the remote address is ignored
+		rec := post(inHandler, "192.168.0.10:5556", bytes.NewBufferString("success"))
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		requireProblemReport(t, rec.Body.Bytes(), "rate-limited")
+
+		rec = post(inHandler, "192.168.0.11:5555", bytes.NewBufferString("success"))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("test inbound transport with limits", func(t *testing.T) {
+		inbound, err := NewInbound(":0", "", "", "", WithInboundLimits(transport.InboundLimits{MaxMessageSize: 10}))
+		require.NoError(t, err)
+		require.Len(t, inbound.opts, 1)
+	})
+}
+
+func requireProblemReport(t *testing.T, body []byte, code string) {
+	t.Helper()
+
+	report := &model.ProblemReport{}
+	require.NoError(t, json.Unmarshal(body, report))
+	require.Equal(t, "https://didcomm.org/report-problem/1.0/problem-report", report.Type)
+	require.NotEmpty(t, report.ID)
+	require.Equal(t, code, report.Description.Code)
+}
+
 func TestInboundTransport(t *testing.T) {
 	t.Run("test inbound transport - with host/port", func(t *testing.T) {
 		port := "26601"
@@ -182,6 +293,15 @@ func TestInboundTransport(t *testing.T) {
 		require.Contains(t, err.Error(), "open invalid: no such file or directory")
 	})
 
+	t.Run("test inbound transport - invalid TLS with certificate reload", func(t *testing.T) {
+		svc, err := NewInbound(":0", "", "invalid", "invalid", WithCertificateReload(time.Minute))
+		require.NoError(t, err)
+
+		err = svc.Start(&mockProvider{packagerValue: &mockpackager.Packager{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stat TLS file")
+	})
+
 	t.Run("test inbound transport - invoke endpoint", func(t *testing.T) {
 		// initiate inbound with port
 		inbound, err := NewInbound(":26605", "", "", "")
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package internal
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader serves the TLS certificate of a key pair saved in files, reloaded when the files are modified (eg. when
+// the certificate is renewed), without restarting the inbound transport.
+type CertReloader struct {
+	certFile, keyFile string
+	interval          time.Duration
+	lock              sync.RWMutex
+	cert              *tls.Certificate
+	modTime           time.Time
+	checked           time.Time
+	now               func() time.Time
+}
+
+// NewCertReloader loads the key pair of the files, which are checked for modifications at most once per interval.
+func NewCertReloader(certFile, keyFile string, interval time.Duration) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, interval: interval, now: time.Now}
+
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.load(modTime); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a TLS configuration serving the certificate of the reloader.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: r.GetCertificate}
+}
+
+// GetCertificate returns the current certificate, reloaded first if the files were modified since it was loaded. The
+// previous certificate is kept when the files can't be loaded, eg. while they are being written.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.lock.RLock()
+	cert, due := r.cert, r.now().Sub(r.checked) >= r.interval
+	r.lock.RUnlock()
+
+	if !due {
+		return cert, nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.checked = r.now()
+
+	modTime, err := r.filesModTime()
+	if err != nil {
+		logger.Warnf("failed to check TLS certificate files: %s", err)
+
+		return r.cert, nil
+	}
+
+	if modTime.Equal(r.modTime) {
+		return r.cert, nil
+	}
+
+	if err = r.load(modTime); err != nil {
+		logger.Warnf("failed to reload TLS certificate, the previous one is served: %s", err)
+
+		return r.cert, nil
+	}
+
+	logger.Infof("reloaded TLS certificate %s", r.certFile)
+
+	return r.cert, nil
+}
+
+func (r *CertReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair: %w", err)
+	}
+
+	r.cert = &cert
+	r.modTime = modTime
+	r.checked = r.now()
+
+	return nil
+}
+
+// filesModTime returns the latest modification time of the certificate and key files.
+func (r *CertReloader) filesModTime() (time.Time, error) {
+	var modTime time.Time
+
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat TLS file: %w", err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
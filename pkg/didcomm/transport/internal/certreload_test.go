@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package internal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	writeKeyPair(t, certFile, keyFile, "first", time.Now().Add(-time.Hour))
+
+	t.Run("success - reloads the modified certificate", func(t *testing.T) {
+		r, err := NewCertReloader(certFile, keyFile, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, r.TLSConfig().GetCertificate)
+
+		now := time.Now()
+		r.now = func() time.Time { return now }
+
+		requireCertificate(t, r, "first")
+
+		writeKeyPair(t, certFile, keyFile, "second", time.Now())
+
+		// the files aren't checked again within the interval
+		requireCertificate(t, r, "first")
+
+		now = now.Add(time.Minute)
+		requireCertificate(t, r, "second")
+
+		// the previous certificate is served while the files can't be loaded
+		require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0o600))
+		require.NoError(t, os.Chtimes(keyFile, now, now.Add(time.Hour)))
+
+		now = now.Add(time.Minute)
+		requireCertificate(t, r, "second")
+
+		require.NoError(t, os.Remove(keyFile))
+
+		now = now.Add(time.Minute)
+		requireCertificate(t, r, "second")
+	})
+
+	t.Run("error - invalid files", func(t *testing.T) {
+		_, err := NewCertReloader(certFile, filepath.Join(dir, "unknown.pem"), time.Minute)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stat TLS file")
+
+		invalidFile := filepath.Join(dir, "invalid.pem")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("invalid"), 0o600))
+
+		_, err = NewCertReloader(invalidFile, invalidFile, time.Minute)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load TLS key pair")
+	})
+}
+
+func requireCertificate(t *testing.T, r *CertReloader, commonName string) {
+	t.Helper()
+
+	cert, err := r.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, commonName, leaf.Subject.CommonName)
+}
+
+// writeKeyPair writes a self-signed certificate with the given common name, the files being modified at modTime.
+func writeKeyPair(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		0o600))
+
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package internal
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+const (
+	// ProblemReportMsgType is the type of the problem reports returned by the inbound transports.
+	ProblemReportMsgType = "https://didcomm.org/report-problem/1.0/problem-report"
+	// MessageTooLargeCode is the problem report code of the messages larger than the maximum message size.
+	MessageTooLargeCode = "message-too-large"
+	// RateLimitedCode is the problem report code of the messages exceeding the rate limit of the remote address.
+	RateLimitedCode = "rate-limited"
+
+	// the buckets which are full again are removed at most once per sweepInterval.
+	sweepInterval = time.Minute
+)
+
+// ProblemReport returns a plaintext problem report with the given code, returned by the inbound transports for the
+// messages they reject before unpacking them.
+func ProblemReport(code string) []byte {
+	// marshalling the problem report can't fail
+	report, _ := json.Marshal(&model.ProblemReport{ // nolint: errcheck
+		Type:        ProblemReportMsgType,
+		ID:          uuid.New().String(),
+		Description: model.Code{Code: code},
+	})
+
+	return report
+}
+
+// InboundLimiter enforces the inbound limits of a transport.
+type InboundLimiter struct {
+	// MaxMessageSize is the maximum size in bytes of a received envelope, not limited when zero.
+	MaxMessageSize   int64
+	rateLimiter      *RateLimiter
+	clientAddrHeader string
+}
+
+// NewInboundLimiter returns the limiter of the inbound limits.
+func NewInboundLimiter(limits transport.InboundLimits) *InboundLimiter {
+	l := &InboundLimiter{MaxMessageSize: limits.MaxMessageSize, clientAddrHeader: limits.ClientAddrHeader}
+
+	if limits.RateLimit > 0 {
+		l.rateLimiter = NewRateLimiter(limits.RateLimit, limits.RateBurst)
+	}
+
+	return l
+}
+
+// Allow returns true if a message from the remote address is accepted by the rate limit.
+func (l *InboundLimiter) Allow(remoteAddr string) bool {
+	return l.rateLimiter == nil || l.rateLimiter.Allow(remoteAddr)
+}
+
+// RemoteAddr returns the remote address of the request the limits apply to: the last address of the client address
+// header when it is configured and set, the remote address of the connection otherwise.
+func (l *InboundLimiter) RemoteAddr(r *http.Request) string {
+	if l.clientAddrHeader == "" {
+		return r.RemoteAddr
+	}
+
+	values := r.Header.Values(l.clientAddrHeader)
+	if len(values) == 0 {
+		return r.RemoteAddr
+	}
+
+	// the trusted proxy appends the address of its client to the addresses set by the previous hops.
+	addrs := strings.Split(values[len(values)-1], ",")
+
+	if addr := strings.TrimSpace(addrs[len(addrs)-1]); addr != "" {
+		return addr
+	}
+
+	return r.RemoteAddr
+}
+
+// RateLimiter limits the rate of the messages received from each remote address with a token bucket.
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a rate limiter accepting rate messages per second from each remote address, and burst
+// messages at once.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow returns true if a message from the remote address is accepted. The port of the address is ignored.
+func (l *RateLimiter) Allow(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[host] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+func (l *RateLimiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+}
+
+// sweep removes the buckets which are full again, they are created full when the remote address sends a message.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for host, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, host)
+		}
+	}
+
+	l.lastSweep = now
+}
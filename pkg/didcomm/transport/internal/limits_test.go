@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package internal
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+func TestProblemReport(t *testing.T) {
+	report := &model.ProblemReport{}
+	require.NoError(t, json.Unmarshal(ProblemReport(MessageTooLargeCode), report))
+	require.Equal(t, ProblemReportMsgType, report.Type)
+	require.NotEmpty(t, report.ID)
+	require.Equal(t, MessageTooLargeCode, report.Description.Code)
+}
+
+func TestInboundLimiter(t *testing.T) {
+	t.Run("test no rate limit", func(t *testing.T) {
+		l := NewInboundLimiter(transport.InboundLimits{MaxMessageSize: 10})
+		require.EqualValues(t, 10, l.MaxMessageSize)
+
+		for i := 0; i < 100; i++ {
+			require.True(t, l.Allow("192.168.0.10:5555"))
+		}
+	})
+
+	t.Run("test rate limit", func(t *testing.T) {
+		l := NewInboundLimiter(transport.InboundLimits{RateLimit: 0.001})
+
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.False(t, l.Allow("192.168.0.10:5555"))
+	})
+
+	t.Run("test remote address", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "10.0.0.1:5555"
+
+		// the header isn't trusted unless it is configured
+		r.Header.Set("X-Forwarded-For", "192.168.0.10")
+		require.Equal(t, "10.0.0.1:5555", NewInboundLimiter(transport.InboundLimits{}).RemoteAddr(r))
+
+		l := NewInboundLimiter(transport.InboundLimits{ClientAddrHeader: "X-Forwarded-For"})
+		require.Equal(t, "192.168.0.10", l.RemoteAddr(r))
+
+		// the address appended by the trusted proxy is used, the previous ones can be spoofed by the client
+		r.Header.Set("X-Forwarded-For", "1.2.3.4, 192.168.0.11")
+		require.Equal(t, "192.168.0.11", l.RemoteAddr(r))
+
+		r.Header.Add("X-Forwarded-For", "192.168.0.12")
+		require.Equal(t, "192.168.0.12", l.RemoteAddr(r))
+
+		r.Header.Set("X-Forwarded-For", " ")
+		require.Equal(t, "10.0.0.1:5555", l.RemoteAddr(r))
+
+		r.Header.Del("X-Forwarded-For")
+		require.Equal(t, "10.0.0.1:5555", l.RemoteAddr(r))
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("test refill", func(t *testing.T) {
+		now := time.Now()
+
+		l := NewRateLimiter(2, 2)
+		l.now = func() time.Time { return now }
+
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.True(t, l.Allow("192.168.0.10:5556"))
+		require.False(t, l.Allow("192.168.0.10:5555"))
+
+		// other remote addresses have their own bucket
+		require.True(t, l.Allow("192.168.0.11:5555"))
+		require.True(t, l.Allow("remote"))
+
+		now = now.Add(500 * time.Millisecond)
+
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.False(t, l.Allow("192.168.0.10:5555"))
+
+		// the bucket is not refilled above the burst
+		now = now.Add(time.Hour)
+
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.False(t, l.Allow("192.168.0.10:5555"))
+	})
+
+	t.Run("test sweep", func(t *testing.T) {
+		now := time.Now()
+
+		l := NewRateLimiter(1, 0)
+		l.now = func() time.Time { return now }
+
+		require.True(t, l.Allow("192.168.0.10:5555"))
+		require.True(t, l.Allow("192.168.0.11:5555"))
+		require.Len(t, l.buckets, 2)
+
+		now = now.Add(sweepInterval)
+
+		// the full buckets are removed before the bucket of the remote address is created again
+		require.True(t, l.Allow("192.168.0.11:5555"))
+		require.Len(t, l.buckets, 1)
+	})
+}
@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+// InboundLimits configures the limits enforced by the inbound transports on the messages received.
+type InboundLimits struct {
+	// MaxMessageSize is the maximum size in bytes of a received envelope, not limited when zero.
+	MaxMessageSize int64
+	// RateLimit is the number of messages per second accepted from a remote address, not limited when zero.
+	RateLimit float64
+	// RateBurst is the number of messages accepted at once from a remote address, one when not set.
+	RateBurst int
+	// ClientAddrHeader is the header in which a trusted reverse proxy sets the address of the client, such as
+	// X-Forwarded-For or X-Real-IP. Behind a proxy the remote address is the one of the proxy, so the rate limit
+	// applies to the last address of the header instead, which is also the remote address of the message origin.
+	// Only set it when the proxy overwrites or appends to the header, the clients can spoof it otherwise.
+	ClientAddrHeader string
+}
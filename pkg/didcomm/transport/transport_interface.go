@@ -27,6 +27,14 @@ type OutboundTransport interface {
 	Accept(string) bool
 }
 
+// ThreadAcceptor is implemented by the outbound transports supporting the return routes scoped to a thread ("thread"
+// transport return route option).
+type ThreadAcceptor interface {
+	// AcceptThread checks if there is a connection for the thread. The framework executes this function before
+	// AcceptRecipient() in outbound message dispatcher.
+	AcceptThread(threadID string) bool
+}
+
 // Envelope holds message data and metadata for inbound and outbound messaging.
 type Envelope struct {
 	MediaTypeProfile string
@@ -36,6 +44,12 @@ type Envelope struct {
 	ToKeys []string
 	// ToKey holds the key that was used to decrypt an inbound message
 	ToKey []byte
+	// Origin holds transport information about where an inbound message came from
+	Origin *service.Origin
+	// ContentEncryption holds the content encryption algorithm ("enc" header) of an inbound JWE envelope
+	ContentEncryption string
+	// KeyWrapping holds the key wrapping algorithm ("alg" header) of an inbound JWE envelope
+	KeyWrapping string
 }
 
 // InboundMessageHandler handles the inbound requests. The transport will unpack the payload prior to the
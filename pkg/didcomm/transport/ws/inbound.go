@@ -11,25 +11,53 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal"
 )
 
 var logger = log.New("aries-framework/ws")
 
+// InboundOpt is an inbound WS transport option.
+type InboundOpt func(i *Inbound)
+
+// WithInboundLimits option enforces a maximum envelope size and a rate limit per remote address on the inbound
+// messages. The messages exceeding the rate limit are dropped and the connections sending oversized messages are
+// closed, after a problem report. Behind a reverse proxy, the rate limit applies to the address of the proxy unless
+// the limits set the client address header of the proxy.
+func WithInboundLimits(limits transport.InboundLimits) InboundOpt {
+	return func(i *Inbound) {
+		i.limiter = internal.NewInboundLimiter(limits)
+	}
+}
+
+// WithCertificateReload option reloads the TLS certificate of the transport when its files are modified, eg. when it is
+// renewed, without restarting the transport. The files are checked at most once per interval, on the TLS handshakes.
+func WithCertificateReload(interval time.Duration) InboundOpt {
+	return func(i *Inbound) {
+		i.certReloadInterval = interval
+	}
+}
+
 // Inbound http(ws) type.
 type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	pool              *connPool
 	certFile, keyFile string
+	limiter           *internal.InboundLimiter
+	// certReloadInterval is the interval the certificate files are checked at, the certificate isn't reloaded
+	// when zero.
+	certReloadInterval time.Duration
 }
 
 // NewInbound creates a new WebSocket inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("websocket address is mandatory")
 	}
@@ -38,12 +66,19 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		externalAddr = internalAddr
 	}
 
-	return &Inbound{
+	i := &Inbound{
 		certFile:     certFile,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
-	}, nil
+		limiter:      internal.NewInboundLimiter(transport.InboundLimits{}),
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i, nil
 }
 
 // Start the http(ws) server.
@@ -58,6 +93,10 @@ func (i *Inbound) Start(prov transport.Provider) error {
 
 	i.pool = getConnPool(prov)
 
+	if err := i.setCertReloader(); err != nil {
+		return fmt.Errorf("websocket server start failed: %w", err)
+	}
+
 	go func() {
 		if err := i.listenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatalf("websocket server start with address [%s] failed, cause:  %s", i.server.Addr, err)
@@ -67,7 +106,28 @@ func (i *Inbound) Start(prov transport.Provider) error {
 	return nil
 }
 
+// setCertReloader serves the certificate through a reloader when the certificate reload option is set.
+func (i *Inbound) setCertReloader() error {
+	if i.certFile == "" || i.keyFile == "" || i.certReloadInterval <= 0 {
+		return nil
+	}
+
+	reloader, err := internal.NewCertReloader(i.certFile, i.keyFile, i.certReloadInterval)
+	if err != nil {
+		return err
+	}
+
+	i.server.TLSConfig = reloader.TLSConfig()
+
+	return nil
+}
+
 func (i *Inbound) listenAndServe() error {
+	if i.server.TLSConfig != nil {
+		// the certificate is served by the TLS configuration
+		return i.server.ListenAndServeTLS("", "")
+	}
+
 	if i.certFile != "" && i.keyFile != "" {
 		return i.server.ListenAndServeTLS(i.certFile, i.keyFile)
 	}
@@ -96,7 +156,16 @@ func (i *Inbound) processRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	i.pool.listener(c, false)
+	if i.limiter.MaxMessageSize > 0 {
+		// the message size is checked by the listener, the connection fails above the limit
+		c.SetReadLimit(i.limiter.MaxMessageSize + 1)
+	}
+
+	i.pool.listener(c, nil, i.limiter, &service.Origin{
+		Transport:  "ws",
+		RemoteAddr: i.limiter.RemoteAddr(r),
+		TLS:        r.TLS,
+	})
 }
 
 func upgradeConnection(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
@@ -8,13 +8,16 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
@@ -66,6 +69,15 @@ func TestInboundTransport(t *testing.T) {
 		require.Contains(t, err.Error(), "open invalid: no such file or directory")
 	})
 
+	t.Run("test inbound transport - invalid TLS with certificate reload", func(t *testing.T) {
+		svc, err := NewInbound(":0", "", "invalid", "invalid", WithCertificateReload(time.Minute))
+		require.NoError(t, err)
+
+		err = svc.Start(&mockProvider{packagerValue: &mockpackager.Packager{}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stat TLS file")
+	})
+
 	t.Run("test inbound transport - invalid port number", func(t *testing.T) {
 		_, err := NewInbound("", "", "", "")
 		require.Error(t, err)
@@ -165,3 +177,51 @@ func TestInboundDataProcessing(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestInboundLimits(t *testing.T) {
+	port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+	inbound, err := NewInbound(port, "", "", "", WithInboundLimits(transport.InboundLimits{
+		MaxMessageSize: 50000,
+		RateLimit:      0.001,
+		RateBurst:      1,
+	}))
+	require.NoError(t, err)
+
+	mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("valid-data")}}
+	require.NoError(t, inbound.Start(&mockProvider{packagerValue: mockPackager}))
+
+	defer func() {
+		require.NoError(t, inbound.Stop())
+	}()
+
+	client, _ := websocketClient(t, port)
+
+	ctx := context.Background()
+
+	// the message is larger than the default read limit of the connection
+	require.NoError(t, client.Write(ctx, websocket.MessageText, make([]byte, 40000)))
+	require.NoError(t, client.Write(ctx, websocket.MessageText, []byte("random")))
+
+	requireProblemReport(t, client, "rate-limited")
+
+	require.NoError(t, client.Write(ctx, websocket.MessageText, make([]byte, 60000)))
+
+	requireProblemReport(t, client, "message-too-large")
+
+	// the connection is closed after an oversized message
+	_, _, err = client.Read(ctx)
+	require.Error(t, err)
+}
+
+func requireProblemReport(t *testing.T, client *websocket.Conn, code string) {
+	t.Helper()
+
+	_, message, err := client.Read(context.Background())
+	require.NoError(t, err)
+
+	report := &model.ProblemReport{}
+	require.NoError(t, json.Unmarshal(message, report))
+	require.Equal(t, "https://didcomm.org/report-problem/1.0/problem-report", report.Type)
+	require.Equal(t, code, report.Description.Code)
+}
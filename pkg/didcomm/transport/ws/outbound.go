@@ -10,7 +10,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -18,17 +21,82 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
-const webSocketScheme = "ws"
+const (
+	webSocketScheme = "ws"
+
+	defaultKeepAliveInterval     = 30 * time.Second
+	defaultKeepAliveTimeout      = 10 * time.Second
+	defaultReconnectInitialDelay = time.Second
+	defaultReconnectMaxDelay     = time.Minute
+)
+
+// OutboundClientOpt is an outbound WS transport option.
+type OutboundClientOpt func(cs *OutboundClient)
+
+// WithKeepAliveInterval sets the frequency of the pings keeping alive the connections opened with a return route
+// (defaults to 30s).
+func WithKeepAliveInterval(interval time.Duration) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.keepAlive.interval = interval
+	}
+}
+
+// WithKeepAliveTimeout sets how long to wait for the pong answering a keepalive ping before closing the connection
+// (defaults to 10s).
+func WithKeepAliveTimeout(timeout time.Duration) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.keepAlive.timeout = timeout
+	}
+}
+
+// WithAutoReconnect enables the reconnection of the connections opened with a return route once they are closed by
+// the remote agent (eg. a mediator restart) or fail the keepalive. Up to maxAttempts reconnections are attempted
+// (unlimited if 0) with an exponential backoff.
+func WithAutoReconnect(maxAttempts int) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.reconnect = true
+		cs.maxReconnectAttempts = maxAttempts
+	}
+}
+
+// WithReconnectBackoff sets the delay before the first reconnection attempt and the maximum delay between two
+// attempts (defaults to 1s and 1m).
+func WithReconnectBackoff(initialDelay, maxDelay time.Duration) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.reconnectInitialDelay = initialDelay
+		cs.reconnectMaxDelay = maxDelay
+	}
+}
 
 // OutboundClient websocket outbound.
 type OutboundClient struct {
-	pool *connPool
-	prov transport.Provider
+	pool                  *connPool
+	prov                  transport.Provider
+	keepAlive             keepAlive
+	reconnect             bool
+	maxReconnectAttempts  int
+	reconnectInitialDelay time.Duration
+	reconnectMaxDelay     time.Duration
+	stateEvents           []chan<- ConnectionStateEvent
+	mu                    sync.RWMutex
 }
 
 // NewOutbound creates a client for Outbound WS transport.
-func NewOutbound() *OutboundClient {
-	return &OutboundClient{}
+func NewOutbound(opts ...OutboundClientOpt) *OutboundClient {
+	cs := &OutboundClient{
+		keepAlive: keepAlive{
+			interval: defaultKeepAliveInterval,
+			timeout:  defaultKeepAliveTimeout,
+		},
+		reconnectInitialDelay: defaultReconnectInitialDelay,
+		reconnectMaxDelay:     defaultReconnectMaxDelay,
+	}
+
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	return cs
 }
 
 // Start starts the outbound transport.
@@ -64,6 +132,11 @@ func (cs *OutboundClient) Accept(url string) bool {
 	return strings.HasPrefix(url, webSocketScheme)
 }
 
+// AcceptThread checks if there is a return route connection scoped to the thread.
+func (cs *OutboundClient) AcceptThread(threadID string) bool {
+	return cs.pool.fetchThread(threadID) != nil
+}
+
 // AcceptRecipient checks if there is a connection for the list of recipient keys.
 func (cs *OutboundClient) AcceptRecipient(keys []string) bool {
 	return acceptRecipient(cs.pool, keys)
@@ -72,6 +145,11 @@ func (cs *OutboundClient) AcceptRecipient(keys []string) bool {
 func (cs *OutboundClient) getConnection(destination *service.Destination) (*websocket.Conn, func(), error) {
 	var conn *websocket.Conn
 
+	// the connection of a return route scoped to the thread of the message is used first
+	if destination.ThreadID != "" {
+		conn = cs.pool.fetchThread(destination.ThreadID)
+	}
+
 	// get the connection for the routing or recipient keys
 	keys := destination.RecipientKeys
 	if len(destination.RoutingKeys) != 0 {
@@ -79,11 +157,11 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 	}
 
 	for _, v := range keys {
-		if c := cs.pool.fetch(v); c != nil {
-			conn = c
-
+		if conn != nil {
 			break
 		}
+
+		conn = cs.pool.fetch(v)
 	}
 
 	cleanup := func() {}
@@ -105,7 +183,19 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 			cs.pool.add(v, conn)
 		}
 
-		go cs.pool.listener(conn, true)
+		go cs.listen(conn, destination)
+
+		cs.notify(destination, StateConnected, 0, nil)
+
+		return conn, cleanup, nil
+	}
+
+	// with a return route scoped to the thread, the connection is kept open to listen to the responses in the thread
+	// only, it is neither kept alive nor reconnected once closed.
+	if destination.TransportReturnRoute == decorator.TransportReturnRouteThread && destination.ThreadID != "" {
+		cs.pool.addThread(destination.ThreadID, conn)
+
+		go cs.pool.listener(conn, nil, nil, &service.Origin{Transport: "ws", RemoteAddr: destination.ServiceEndpoint})
 
 		return conn, cleanup, nil
 	}
@@ -119,3 +209,77 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	return conn, cleanup, nil
 }
+
+// listen listens to the connection opened with a return route and reconnects it once closed if auto reconnect is
+// enabled.
+func (cs *OutboundClient) listen(conn *websocket.Conn, destination *service.Destination) {
+	origin := &service.Origin{
+		Transport:  "ws",
+		RemoteAddr: destination.ServiceEndpoint,
+		// messages received on a return route established through routing keys are relayed by the mediator.
+		ViaMediator: len(destination.RoutingKeys) != 0,
+	}
+
+	for {
+		cs.pool.listener(conn, &cs.keepAlive, nil, origin)
+
+		cs.notify(destination, StateDisconnected, 0, nil)
+
+		if !cs.reconnect {
+			return
+		}
+
+		var err error
+
+		conn, err = cs.redial(destination)
+		if err != nil {
+			logger.Errorf("failed to reconnect to %s : %v", destination.ServiceEndpoint, err)
+
+			cs.notify(destination, StateReconnectFailed, 0, err)
+
+			return
+		}
+
+		for _, v := range destination.RecipientKeys {
+			cs.pool.add(v, conn)
+		}
+
+		cs.notify(destination, StateConnected, 0, nil)
+	}
+}
+
+func (cs *OutboundClient) redial(destination *service.Destination) (*websocket.Conn, error) {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = cs.reconnectInitialDelay
+	expBackoff.MaxInterval = cs.reconnectMaxDelay
+	expBackoff.MaxElapsedTime = 0
+
+	var policy backoff.BackOff = expBackoff
+
+	if cs.maxReconnectAttempts > 0 {
+		// the first attempt is not a retry.
+		policy = backoff.WithMaxRetries(expBackoff, uint64(cs.maxReconnectAttempts-1))
+	}
+
+	var (
+		conn    *websocket.Conn
+		attempt int
+	)
+
+	err := backoff.Retry(func() error {
+		attempt++
+
+		cs.notify(destination, StateReconnecting, attempt, nil)
+
+		var err error
+
+		conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, nil) // nolint: bodyclose
+
+		return err
+	}, policy)
+	if err != nil {
+		return nil, fmt.Errorf("websocket client : %w", err)
+	}
+
+	return conn, nil
+}
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
+	"context"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
@@ -163,3 +166,153 @@ func TestClient(t *testing.T) {
 		require.Equal(t, "", resp)
 	})
 }
+
+func TestClient_ConnectionState(t *testing.T) {
+	verKey := "XYZ"
+	recKey := []string{verKey}
+
+	startOutbound := func(t *testing.T, opts ...OutboundClientOpt) (*OutboundClient, chan ConnectionStateEvent) {
+		t.Helper()
+
+		outbound := NewOutbound(opts...)
+		require.NoError(t, outbound.Start(&mockProvider{
+			&mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}},
+		}))
+
+		events := make(chan ConnectionStateEvent, 10)
+		require.NoError(t, outbound.RegisterConnectionStateEvent(events))
+
+		return outbound, events
+	}
+
+	expectState := func(t *testing.T, events chan ConnectionStateEvent, state ConnectionState) ConnectionStateEvent {
+		t.Helper()
+
+		select {
+		case event := <-events:
+			require.Equal(t, state, event.State)
+			require.Equal(t, recKey, event.RecipientKeys)
+
+			return event
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout waiting for connection state "+string(state))
+		}
+
+		return ConnectionStateEvent{}
+	}
+
+	send := func(t *testing.T, outbound *OutboundClient, addr string) {
+		t.Helper()
+
+		_, err := outbound.Send(createTransportDecRequest(t, decorator.TransportReturnRouteAll),
+			prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteAll, recKey))
+		require.NoError(t, err)
+	}
+
+	t.Run("register nil channel", func(t *testing.T) {
+		require.Equal(t, ErrNilChannel, NewOutbound().RegisterConnectionStateEvent(nil))
+	})
+
+	t.Run("unregister channel", func(t *testing.T) {
+		outbound, events := startOutbound(t)
+		outbound.UnregisterConnectionStateEvent(events)
+
+		send(t, outbound, startWebSocketServer(t, echo))
+		require.Empty(t, events)
+	})
+
+	t.Run("keepalive timeout closes the connection", func(t *testing.T) {
+		outbound, events := startOutbound(t,
+			WithKeepAliveInterval(50*time.Millisecond), WithKeepAliveTimeout(50*time.Millisecond))
+
+		release := make(chan struct{})
+		defer close(release)
+
+		// the server doesn't read the connection, the pings are never answered.
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			c, err := Accept(w, r)
+			require.NoError(t, err)
+
+			<-release
+
+			_ = c.Close(websocket.StatusNormalClosure, "closing the connection") // nolint: errcheck
+		})
+
+		send(t, outbound, addr)
+
+		expectState(t, events, StateConnected)
+		expectState(t, events, StateDisconnected)
+		require.False(t, outbound.AcceptRecipient(recKey))
+	})
+
+	t.Run("reconnects once the connection is closed by the server", func(t *testing.T) {
+		outbound, events := startOutbound(t, WithAutoReconnect(0),
+			WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond))
+
+		var connections int32
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			// the first connection is closed as if the server was restarting.
+			if atomic.AddInt32(&connections, 1) == 1 {
+				c, err := Accept(w, r)
+				require.NoError(t, err)
+
+				_, _, err = c.Read(context.Background())
+				require.NoError(t, err)
+
+				require.NoError(t, c.Close(websocket.StatusGoingAway, "restarting"))
+
+				return
+			}
+
+			echo(t, w, r)
+		})
+
+		send(t, outbound, addr)
+
+		expectState(t, events, StateConnected)
+		expectState(t, events, StateDisconnected)
+		require.Equal(t, 1, expectState(t, events, StateReconnecting).Attempt)
+		expectState(t, events, StateConnected)
+
+		require.True(t, outbound.AcceptRecipient(recKey))
+		require.Equal(t, int32(2), atomic.LoadInt32(&connections))
+	})
+
+	t.Run("reconnection fails", func(t *testing.T) {
+		outbound, events := startOutbound(t, WithAutoReconnect(2),
+			WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond))
+
+		var connections int32
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			// the server doesn't accept websocket connections after the first one.
+			if atomic.AddInt32(&connections, 1) > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			c, err := Accept(w, r)
+			require.NoError(t, err)
+
+			_, _, err = c.Read(context.Background())
+			require.NoError(t, err)
+
+			require.NoError(t, c.Close(websocket.StatusGoingAway, "shutting down"))
+		})
+
+		send(t, outbound, addr)
+
+		expectState(t, events, StateConnected)
+		expectState(t, events, StateDisconnected)
+		require.Equal(t, 1, expectState(t, events, StateReconnecting).Attempt)
+		require.Equal(t, 2, expectState(t, events, StateReconnecting).Attempt)
+
+		event := expectState(t, events, StateReconnectFailed)
+		require.Error(t, event.Err)
+		require.Contains(t, event.Err.Error(), "websocket client")
+
+		require.False(t, outbound.AcceptRecipient(recKey))
+	})
+}
@@ -8,6 +8,9 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +18,7 @@ import (
 	"nhooyr.io/websocket"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -24,15 +28,20 @@ import (
 )
 
 const (
-	// TODO configure ping request frequency.
-	pingFrequency = 30 * time.Second
-
 	// legacyKeyLen key length.
 	legacyKeyLen = 32
 )
 
+// keepAlive configures the pings sent to keep a connection alive.
+type keepAlive struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
 type connPool struct {
 	connMap map[string]*websocket.Conn
+	// threadMap holds the connections of the return routes scoped to a thread.
+	threadMap map[string]*websocket.Conn
 	sync.RWMutex
 	packager   transport.Packager
 	msgHandler transport.InboundMessageHandler
@@ -41,12 +50,15 @@ type connPool struct {
 // nolint: gochecknoglobals
 var pool = make(map[string]*connPool)
 
+var errMessageTooLarge = errors.New("message too large")
+
 func getConnPool(prov transport.Provider) *connPool {
 	id := prov.AriesFrameworkID()
 
 	if _, ok := pool[id]; !ok {
 		pool[id] = &connPool{
 			connMap:    make(map[string]*websocket.Conn),
+			threadMap:  make(map[string]*websocket.Conn),
 			packager:   prov.Packager(),
 			msgHandler: prov.InboundMessageHandler(),
 		}
@@ -69,6 +81,20 @@ func (d *connPool) fetch(verKey string) *websocket.Conn {
 	return d.connMap[verKey]
 }
 
+func (d *connPool) addThread(threadID string, wsConn *websocket.Conn) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.threadMap[threadID] = wsConn
+}
+
+func (d *connPool) fetchThread(threadID string) *websocket.Conn {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.threadMap[threadID]
+}
+
 func (d *connPool) remove(verKey string) {
 	d.Lock()
 	defer d.Unlock()
@@ -76,15 +102,52 @@ func (d *connPool) remove(verKey string) {
 	delete(d.connMap, verKey)
 }
 
-func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
-	verKeys := []string{}
+// removeConn removes the connection from the pool, whatever the keys or threads it was added for.
+func (d *connPool) removeConn(conn *websocket.Conn) {
+	d.Lock()
+	defer d.Unlock()
+
+	for k, c := range d.connMap {
+		if c == conn {
+			delete(d.connMap, k)
+		}
+	}
+
+	for thID, c := range d.threadMap {
+		if c == conn {
+			delete(d.threadMap, thID)
+		}
+	}
+}
+
+// listener reads the messages received on the connection until it is closed. The connection is kept alive with
+// pings if ka is set, and the inbound limits are enforced if limiter is set.
+func (d *connPool) listener(conn *websocket.Conn, ka *keepAlive, limiter *internal.InboundLimiter,
+	origin *service.Origin) {
+	done := make(chan struct{})
+
+	defer d.close(conn)
+	defer close(done)
 
-	defer d.close(conn, verKeys)
+	if ka != nil {
+		go keepConnAlive(conn, ka.interval, ka.timeout, done)
+	}
 
-	go keepConnAlive(conn, outbound, pingFrequency)
+	if limiter == nil {
+		limiter = internal.NewInboundLimiter(transport.InboundLimits{})
+	}
 
 	for {
-		_, message, err := conn.Read(context.Background())
+		message, err := readMessage(conn, limiter.MaxMessageSize)
+		if errors.Is(err, errMessageTooLarge) {
+			// the rest of the message isn't read, the connection is closed after the problem report
+			logger.Warnf("message from %s larger than %d bytes, closing the connection", origin.RemoteAddr,
+				limiter.MaxMessageSize)
+			writeProblemReport(conn, internal.MessageTooLargeCode)
+
+			break
+		}
+
 		if err != nil {
 			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 				logger.Errorf("Error reading request message: %v", err)
@@ -93,6 +156,13 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			break
 		}
 
+		if !limiter.Allow(origin.RemoteAddr) {
+			logger.Warnf("rate limit exceeded by %s, message dropped", origin.RemoteAddr)
+			writeProblemReport(conn, internal.RateLimitedCode)
+
+			continue
+		}
+
 		unpackMsg, err := internal.UnpackMessage(message, d.packager, "ws")
 		if err != nil {
 			logger.Errorf("%w", err)
@@ -109,6 +179,8 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 
 		d.addKey(unpackMsg, trans, conn)
 
+		unpackMsg.Origin = origin
+
 		messageHandler := d.msgHandler
 
 		err = messageHandler(unpackMsg)
@@ -118,6 +190,37 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 	}
 }
 
+// readMessage reads a message from the connection, at most maxMessageSize bytes if set.
+func readMessage(conn *websocket.Conn, maxMessageSize int64) ([]byte, error) {
+	if maxMessageSize <= 0 {
+		_, message, err := conn.Read(context.Background())
+
+		return message, err
+	}
+
+	_, r, err := conn.Reader(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := ioutil.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(message)) > maxMessageSize {
+		return nil, errMessageTooLarge
+	}
+
+	return message, nil
+}
+
+func writeProblemReport(conn *websocket.Conn, code string) {
+	if err := conn.Write(context.Background(), websocket.MessageText, internal.ProblemReport(code)); err != nil {
+		logger.Errorf("failed to write problem report: %v", err)
+	}
+}
+
 func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transport, conn *websocket.Conn) {
 	var fromKey string
 
@@ -135,6 +238,15 @@ func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transp
 		}
 	}
 
+	if trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteThread {
+		// the connection is only used to reply in the thread, it is not linked to the keys of the sender.
+		if trans.ReturnRoute.Thread != "" {
+			d.addThread(trans.ReturnRoute.Thread, conn)
+		}
+
+		return
+	}
+
 	if trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
 		if fromKey != "" {
 			d.add(fromKey, conn)
@@ -152,15 +264,13 @@ func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transp
 	}
 }
 
-func (d *connPool) close(conn *websocket.Conn, verKeys []string) {
+func (d *connPool) close(conn *websocket.Conn) {
 	if err := conn.Close(websocket.StatusNormalClosure,
 		"closing the connection"); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 		logger.Errorf("connection close error")
 	}
 
-	for _, v := range verKeys {
-		d.remove(v)
-	}
+	d.removeConn(conn)
 }
 
 func (d *connPool) checkKeyAgreementIDs(message []byte) []string {
@@ -7,6 +7,7 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 	"testing"
 	"time"
@@ -132,4 +133,105 @@ func TestConnectionStore(t *testing.T) {
 			require.Fail(t, "tests are not validated due to timeout")
 		}
 	})
+
+	t.Run("test transport pool - agent with inbound (consumer) - thread return route", func(t *testing.T) {
+		thID := uuid.New().String()
+
+		request, err := json.Marshal(&decorator.Transport{ReturnRoute: &decorator.ReturnRoute{
+			Value:  decorator.TransportReturnRouteThread,
+			Thread: thID,
+		}})
+		require.NoError(t, err)
+
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+		inbound, err := NewInbound(port, "", "", "")
+		require.NoError(t, err)
+
+		outbound := NewOutbound()
+
+		verKey := mockdiddoc.MockDIDKey(t)
+
+		verKeyBytes, err := fingerprint.PubKeyFromDIDKey(verKey)
+		require.NoError(t, err)
+
+		response := "Hello"
+		transportProvider := &mockTransportProvider{
+			packagerValue: &mockpackager.Packager{
+				UnpackValue: &transport.Envelope{Message: request, FromKey: verKeyBytes},
+			},
+			frameworkID: uuid.New().String(),
+			executeInbound: func(envelope *transport.Envelope) error {
+				// the connection is only used for the replies in the thread
+				require.True(t, outbound.AcceptThread(thID))
+				require.False(t, outbound.AcceptRecipient([]string{verKey}))
+
+				des := prepareDestinationWithTransport("ws://doesnt-matter", "", []string{verKey})
+				des.ThreadID = thID
+
+				resp, outboundErr := outbound.Send([]byte(response), des)
+				require.NoError(t, outboundErr)
+				require.Equal(t, "", resp)
+				return nil
+			},
+		}
+
+		require.NoError(t, inbound.Start(transportProvider))
+		require.NoError(t, outbound.Start(transportProvider))
+
+		client, cleanup := websocketClient(t, port)
+		defer cleanup()
+
+		ctx := context.Background()
+
+		err = client.Write(ctx, websocket.MessageText, request)
+		require.NoError(t, err)
+
+		mt, message, err := client.Read(ctx)
+		require.NoError(t, err)
+		require.Equal(t, websocket.MessageText, mt)
+		require.Equal(t, response, string(message))
+	})
+
+	t.Run("test transport pool - agent without inbound (client) - thread return route", func(t *testing.T) {
+		thID := uuid.New().String()
+		request := createTransportDecRequest(t, decorator.TransportReturnRouteThread)
+
+		addr := startWebSocketServer(t, echo)
+
+		outbound := NewOutbound()
+
+		verKey := "ABCD"
+
+		done := make(chan struct{})
+
+		transportProvider := &mockTransportProvider{
+			packagerValue: &mockPackager{verKey: verKey},
+			frameworkID:   uuid.New().String(),
+			executeInbound: func(envelope *transport.Envelope) error {
+				require.Equal(t, request, envelope.Message)
+				done <- struct{}{}
+				return nil
+			},
+		}
+
+		require.NoError(t, outbound.Start(transportProvider))
+
+		des := prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteThread, []string{verKey})
+		des.ThreadID = thID
+
+		resp, err := outbound.Send(request, des)
+		require.NoError(t, err)
+		require.Equal(t, "", resp)
+
+		// the connection is kept for the thread only
+		require.True(t, outbound.AcceptThread(thID))
+		require.False(t, outbound.AcceptThread(uuid.New().String()))
+		require.False(t, outbound.AcceptRecipient([]string{verKey}))
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "tests are not validated due to timeout")
+		}
+	})
 }
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// ConnectionState is the state of an outbound connection opened with a return route.
+type ConnectionState string
+
+const (
+	// StateConnected is emitted once the connection is opened or reconnected.
+	StateConnected ConnectionState = "connected"
+	// StateDisconnected is emitted once the connection is closed by the remote agent or failed the keepalive.
+	StateDisconnected ConnectionState = "disconnected"
+	// StateReconnecting is emitted before each reconnection attempt.
+	StateReconnecting ConnectionState = "reconnecting"
+	// StateReconnectFailed is emitted once all the reconnection attempts failed.
+	StateReconnectFailed ConnectionState = "reconnect-failed"
+)
+
+// ErrNilChannel is returned when registering a nil connection state channel.
+var ErrNilChannel = errors.New("channel is nil")
+
+// ConnectionStateEvent is the event emitted when the state of an outbound connection changes. As the remote agent
+// only knows the return route of the connection once it receives a message on it, subscribers should send a message
+// with a return route (eg. a mediator status request) once the connection is reconnected.
+type ConnectionStateEvent struct {
+	ServiceEndpoint string
+	RecipientKeys   []string
+	State           ConnectionState
+	// Attempt is the number of the reconnection attempt, set for StateReconnecting.
+	Attempt int
+	// Err is the last reconnection error, set for StateReconnectFailed.
+	Err error
+}
+
+// RegisterConnectionStateEvent registers the channel receiving the connection state events. The events are dropped
+// if the channel is not ready to receive them, a buffered channel is recommended.
+func (cs *OutboundClient) RegisterConnectionStateEvent(ch chan<- ConnectionStateEvent) error {
+	if ch == nil {
+		return ErrNilChannel
+	}
+
+	cs.mu.Lock()
+	cs.stateEvents = append(cs.stateEvents, ch)
+	cs.mu.Unlock()
+
+	return nil
+}
+
+// UnregisterConnectionStateEvent unregisters the connection state events channel.
+func (cs *OutboundClient) UnregisterConnectionStateEvent(ch chan<- ConnectionStateEvent) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	for i := 0; i < len(cs.stateEvents); i++ {
+		if cs.stateEvents[i] == ch {
+			cs.stateEvents = append(cs.stateEvents[:i], cs.stateEvents[i+1:]...)
+			i--
+		}
+	}
+}
+
+func (cs *OutboundClient) notify(destination *service.Destination, state ConnectionState, attempt int, err error) {
+	event := ConnectionStateEvent{
+		ServiceEndpoint: destination.ServiceEndpoint,
+		RecipientKeys:   destination.RecipientKeys,
+		State:           state,
+		Attempt:         attempt,
+		Err:             err,
+	}
+
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	for _, ch := range cs.stateEvents {
+		select {
+		case ch <- event:
+		default:
+			logger.Warnf("connection state event %s of %s dropped", state, destination.ServiceEndpoint)
+		}
+	}
+}
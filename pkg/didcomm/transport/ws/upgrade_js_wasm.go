@@ -31,6 +31,6 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 	return false
 }
 
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
+func keepConnAlive(conn *websocket.Conn, interval, timeout time.Duration, done <-chan struct{}) {
 	// TODO make sure connection is alive (conn.Ping() doesn't work with JS/WASM build)
 }
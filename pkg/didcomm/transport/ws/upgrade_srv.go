@@ -1,3 +1,4 @@
+//go:build !js && !wasm
 // +build !js,!wasm
 
 /*
@@ -48,23 +49,31 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 
 // keepConnAlive sends the pings the server based on time frequency. The web server, load balancer, network routers
 // between the client and server closes the TCP keepalives connection. This function calls websocket ping request
-// directly to the server and keeps the connection active.
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
-	if outbound {
-		ticker := time.NewTicker(frequency)
-		done := make(chan struct{})
-
-		for {
-			select {
-			case <-done:
-				return
-			case <-ticker.C:
-				if err := conn.Ping(context.Background()); err != nil {
-					logger.Errorf("websocket ping error : %v", err)
+// directly to the server and keeps the connection active. The connection is closed when the server doesn't answer
+// the ping (pong) within the timeout, until then it runs until done is closed.
+func keepConnAlive(conn *websocket.Conn, interval, timeout time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			err := conn.Ping(ctx)
+
+			cancel()
 
-					ticker.Stop()
-					done <- struct{}{}
+			if err != nil {
+				logger.Errorf("websocket ping error : %v", err)
+
+				// closing the connection stops its listener.
+				if err = conn.Close(websocket.StatusGoingAway, "keepalive timeout"); err != nil {
+					logger.Debugf("close connection after ping failure: %v", err)
 				}
+
+				return
 			}
 		}
 	}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Query is a discover-features query of the features of the other party.
+type Query struct {
+	FeatureType string `json:"feature-type"`
+	// Match is the feature ID, or a pattern ending with * matching the feature IDs with the given prefix.
+	Match string `json:"match"`
+}
+
+// Disclosure is a feature disclosed by the other party.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+// Queries is the discover-features queries message of DIDComm v1.
+type Queries struct {
+	ID      string  `json:"@id,omitempty"`
+	Type    string  `json:"@type,omitempty"`
+	Queries []Query `json:"queries"`
+}
+
+// Disclose is the discover-features disclose message of DIDComm v1.
+type Disclose struct {
+	ID          string            `json:"@id,omitempty"`
+	Type        string            `json:"@type,omitempty"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+	Disclosures []Disclosure      `json:"disclosures"`
+}
+
+// QueriesV2 is the discover-features queries message of DIDComm v2.
+type QueriesV2 struct {
+	ID   string      `json:"id,omitempty"`
+	Type string      `json:"type,omitempty"`
+	Body QueriesBody `json:"body"`
+}
+
+// QueriesBody is the body of the discover-features queries message of DIDComm v2.
+type QueriesBody struct {
+	Queries []Query `json:"queries"`
+}
+
+// DiscloseV2 is the discover-features disclose message of DIDComm v2.
+type DiscloseV2 struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     DiscloseBody `json:"body"`
+}
+
+// DiscloseBody is the body of the discover-features disclose message of DIDComm v2.
+type DiscloseBody struct {
+	Disclosures []Disclosure `json:"disclosures"`
+}
@@ -0,0 +1,460 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package upgrade implements the upgrade of existing DIDComm v1 connections to DIDComm v2. The party upgrading the
+// connection discovers the DIDComm v2 media type profiles accepted by the other party with a discover-features 2.0
+// query of the "accept" feature type, switches the connection to a common DIDComm v2 profile and discloses it in a
+// DIDComm v2 message, so that the other party switches the connection too. The connection DIDs are kept: both DIDs
+// need a DIDComm v2 service with key agreement keys.
+//
+// The discover-features queries of the "protocol" feature type are answered too, with the protocols of the services
+// registered in the protocol registry given with WithProtocolRegistry.
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// QueriesMsgType is the discover-features queries message type.
+	QueriesMsgType = "https://didcomm.org/discover-features/2.0/queries"
+	// DiscloseMsgType is the discover-features disclose message type.
+	DiscloseMsgType = "https://didcomm.org/discover-features/2.0/disclose"
+
+	// AcceptFeatureType is the feature type of the media type profiles accepted by an agent.
+	AcceptFeatureType = "accept"
+	// ProtocolFeatureType is the feature type of the protocols supported by an agent.
+	ProtocolFeatureType = "protocol"
+
+	didCommV2ServiceType = "DIDCommMessaging"
+	defaultTimeout       = 10 * time.Second
+)
+
+// ErrNotSupported is returned when the upgrade of a connection isn't supported by one of the parties.
+var ErrNotSupported = errors.New("DIDComm v2 is not supported")
+
+var logger = log.New("aries-framework/didcomm/upgrade")
+
+type provider interface {
+	VDRegistry() vdrapi.Registry
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Upgrader upgrades DIDComm v1 connections to DIDComm v2 and handles the upgrades of the other parties.
+type Upgrader struct {
+	vdr         vdrapi.Registry
+	outbound    dispatcher.Outbound
+	connections *connection.Recorder
+	timeout     time.Duration
+	pending     map[string]chan []Disclosure
+	mu          sync.RWMutex
+	protocols   *dispatcher.ProtocolRegistry
+}
+
+// Opt configures the Upgrader.
+type Opt func(u *Upgrader)
+
+// WithProtocolRegistry discloses the protocols of the services registered in the registry to the discover-features
+// queries of the "protocol" feature type, no protocol is disclosed otherwise.
+func WithProtocolRegistry(registry *dispatcher.ProtocolRegistry) Opt {
+	return func(u *Upgrader) {
+		u.protocols = registry
+	}
+}
+
+// New returns a new connection upgrader.
+func New(p provider, opts ...Opt) (*Upgrader, error) {
+	connections, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection recorder: %w", err)
+	}
+
+	u := &Upgrader{
+		vdr:         p.VDRegistry(),
+		outbound:    p.OutboundDispatcher(),
+		connections: connections,
+		timeout:     defaultTimeout,
+		pending:     make(map[string]chan []Disclosure),
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u, nil
+}
+
+// Upgrade upgrades the DIDComm v1 connection to DIDComm v2, keeping its DIDs. It returns ErrNotSupported when one of
+// the DIDs has no DIDComm v2 service or the other party doesn't accept a DIDComm v2 profile of my DID.
+func (u *Upgrader) Upgrade(connectionID string) error {
+	record, err := u.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return fmt.Errorf("connection %s is not completed", connectionID)
+	}
+
+	if IsDIDCommV2(record.MediaTypeProfiles) {
+		return nil
+	}
+
+	myProfiles, err := u.acceptedProfiles(record.MyDID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = u.acceptedProfiles(record.TheirDID); err != nil {
+		return err
+	}
+
+	disclosures, err := u.query(record)
+	if err != nil {
+		return err
+	}
+
+	profile := selectProfile(myProfiles, disclosures)
+	if profile == "" {
+		return fmt.Errorf("no DIDComm v2 profile accepted by the other party: %w", ErrNotSupported)
+	}
+
+	previous := record.MediaTypeProfiles
+	record.MediaTypeProfiles = []string{profile}
+
+	if err = u.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	// the other party upgrades the connection when it receives the disclosure of the profile in a DIDComm v2 message
+	err = u.outbound.SendToDID(&DiscloseV2{
+		ID:   uuid.New().String(),
+		Type: DiscloseMsgType,
+		Body: DiscloseBody{Disclosures: []Disclosure{{FeatureType: AcceptFeatureType, ID: profile}}},
+	}, record.MyDID, record.TheirDID)
+	if err != nil {
+		record.MediaTypeProfiles = previous
+
+		if e := u.connections.SaveConnectionRecord(record); e != nil {
+			logger.Warnf("failed to restore the media type profiles of connection %s: %s", connectionID, e)
+		}
+
+		return fmt.Errorf("send DIDComm v2 disclose: %w", err)
+	}
+
+	logger.Infof("upgraded connection %s to %s", connectionID, profile)
+
+	return nil
+}
+
+// query queries the DIDComm v2 profiles accepted by the other party over the DIDComm v1 connection.
+func (u *Upgrader) query(record *connection.Record) ([]Disclosure, error) {
+	msgID := uuid.New().String()
+
+	disclosuresCh := make(chan []Disclosure, 1)
+	u.setPending(msgID, disclosuresCh)
+
+	defer u.setPending(msgID, nil)
+
+	err := u.outbound.SendToDID(&Queries{
+		ID:      msgID,
+		Type:    QueriesMsgType,
+		Queries: []Query{{FeatureType: AcceptFeatureType, Match: "*"}},
+	}, record.MyDID, record.TheirDID)
+	if err != nil {
+		return nil, fmt.Errorf("send discover-features queries: %w", err)
+	}
+
+	select {
+	case disclosures := <-disclosuresCh:
+		return disclosures, nil
+	case <-time.After(u.timeout):
+		return nil, errors.New("timeout waiting for the discover-features disclose of the other party")
+	}
+}
+
+// Accept checks whether the message type is handled by the upgrader.
+func Accept(msgType string) bool {
+	return msgType == QueriesMsgType || msgType == DiscloseMsgType
+}
+
+// HandleInbound handles the discover-features messages exchanged to upgrade connections: the queries of the
+// accepted profiles, the disclosures answering my queries and the DIDComm v2 disclosures of the upgraded connections.
+func (u *Upgrader) HandleInbound(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	switch msg.Type() {
+	case QueriesMsgType:
+		return u.handleQueries(msg, myDID, theirDID)
+	case DiscloseMsgType:
+		return u.handleDisclose(msg, myDID, theirDID)
+	default:
+		return fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+}
+
+func (u *Upgrader) handleQueries(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if myDID == "" || theirDID == "" {
+		return errors.New("discover-features queries received out of a connection")
+	}
+
+	var queries []Query
+
+	if msg.IsDIDCommV2() {
+		m := QueriesV2{}
+
+		if err := msg.Decode(&m); err != nil {
+			return fmt.Errorf("decode queries: %w", err)
+		}
+
+		queries = m.Body.Queries
+	} else {
+		m := Queries{}
+
+		if err := msg.Decode(&m); err != nil {
+			return fmt.Errorf("decode queries: %w", err)
+		}
+
+		queries = m.Queries
+	}
+
+	// the other features types aren't disclosed
+	disclosures := []Disclosure{}
+
+	profiles, err := u.acceptedProfiles(myDID)
+	if err != nil && !errors.Is(err, ErrNotSupported) {
+		return err
+	}
+
+	var protocols []string
+
+	if u.protocols != nil {
+		protocols = u.protocols.PIURIs()
+	}
+
+	for _, query := range queries {
+		var features []string
+
+		switch query.FeatureType {
+		case AcceptFeatureType:
+			features = profiles
+		case ProtocolFeatureType:
+			features = protocols
+		}
+
+		for _, feature := range features {
+			if matches(query.Match, feature) {
+				disclosures = append(disclosures, Disclosure{FeatureType: query.FeatureType, ID: feature})
+			}
+		}
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("get thread ID: %w", err)
+	}
+
+	var disclose interface{} = &Disclose{
+		ID:          uuid.New().String(),
+		Type:        DiscloseMsgType,
+		Thread:      &decorator.Thread{ID: thID},
+		Disclosures: disclosures,
+	}
+
+	if msg.IsDIDCommV2() {
+		disclose = &DiscloseV2{
+			ID:       uuid.New().String(),
+			Type:     DiscloseMsgType,
+			ThreadID: thID,
+			Body:     DiscloseBody{Disclosures: disclosures},
+		}
+	}
+
+	if err = u.outbound.SendToDID(disclose, myDID, theirDID); err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	return nil
+}
+
+func (u *Upgrader) handleDisclose(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	var disclosures []Disclosure
+
+	if msg.IsDIDCommV2() {
+		m := DiscloseV2{}
+
+		if err := msg.Decode(&m); err != nil {
+			return fmt.Errorf("decode disclose: %w", err)
+		}
+
+		disclosures = m.Body.Disclosures
+	} else {
+		m := Disclose{}
+
+		if err := msg.Decode(&m); err != nil {
+			return fmt.Errorf("decode disclose: %w", err)
+		}
+
+		disclosures = m.Disclosures
+	}
+
+	if thID, err := msg.ThreadID(); err == nil {
+		if ch := u.getPending(thID); ch != nil {
+			select {
+			case ch <- disclosures:
+			default:
+				logger.Debugf("ignoring duplicate disclose of thread %s", thID)
+			}
+
+			return nil
+		}
+	}
+
+	// only the disclosures received over DIDComm v2 upgrade the connection
+	if !msg.IsDIDCommV2() || myDID == "" || theirDID == "" {
+		return nil
+	}
+
+	return u.handleUpgrade(disclosures, myDID, theirDID)
+}
+
+// handleUpgrade switches the connection to the DIDComm v2 profile disclosed by the party who upgraded it.
+func (u *Upgrader) handleUpgrade(disclosures []Disclosure, myDID, theirDID string) error {
+	connID, err := u.connections.GetConnectionIDByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get connection ID: %w", err)
+	}
+
+	record, err := u.connections.GetConnectionRecord(connID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if IsDIDCommV2(record.MediaTypeProfiles) {
+		return nil
+	}
+
+	profiles, err := u.acceptedProfiles(myDID)
+	if err != nil {
+		return err
+	}
+
+	profile := selectProfile(profiles, disclosures)
+	if profile == "" {
+		return nil
+	}
+
+	record.MediaTypeProfiles = []string{profile}
+
+	if err = u.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	logger.Infof("connection %s upgraded to %s by the other party", connID, profile)
+
+	return nil
+}
+
+// acceptedProfiles returns the DIDComm v2 profiles accepted by the DIDComm v2 service of the DID.
+func (u *Upgrader) acceptedProfiles(didID string) ([]string, error) {
+	docResolution, err := u.vdr.Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID %s: %w", didID, err)
+	}
+
+	svc, ok := did.LookupService(docResolution.DIDDocument, didCommV2ServiceType)
+	if !ok || len(docResolution.DIDDocument.KeyAgreement) == 0 {
+		return nil, fmt.Errorf("DID %s has no DIDComm v2 service: %w", didID, ErrNotSupported)
+	}
+
+	if len(svc.Accept) == 0 {
+		return []string{transport.MediaTypeDIDCommV2Profile}, nil
+	}
+
+	var profiles []string
+
+	for _, profile := range svc.Accept {
+		if IsDIDCommV2([]string{profile}) {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("DID %s accepts no DIDComm v2 profile: %w", didID, ErrNotSupported)
+	}
+
+	return profiles, nil
+}
+
+func (u *Upgrader) getPending(thID string) chan []Disclosure {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	return u.pending[thID]
+}
+
+func (u *Upgrader) setPending(thID string, ch chan []Disclosure) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if ch == nil {
+		delete(u.pending, thID)
+	} else {
+		u.pending[thID] = ch
+	}
+}
+
+// IsDIDCommV2 checks whether the media type profiles select DIDComm v2 messaging.
+func IsDIDCommV2(mediaTypeProfiles []string) bool {
+	for _, profile := range mediaTypeProfiles {
+		switch profile {
+		case transport.MediaTypeDIDCommV2Profile, transport.MediaTypeV2EncryptedEnvelope,
+			transport.MediaTypeV2PlaintextPayload:
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectProfile returns my first profile disclosed by the other party.
+func selectProfile(profiles []string, disclosures []Disclosure) string {
+	for _, profile := range profiles {
+		for _, disclosure := range disclosures {
+			if disclosure.FeatureType == AcceptFeatureType && disclosure.ID == profile {
+				return profile
+			}
+		}
+	}
+
+	return ""
+}
+
+func matches(pattern, id string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(id, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == id
+}
@@ -0,0 +1,459 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	connID   = "conn-1"
+	aliceDID = "did:test:alice"
+	bobDID   = "did:test:bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		u, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, u)
+	})
+
+	t.Run("error if connection recorder fails", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue:              &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")},
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestUpgrader_Upgrade(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		alice, bob := newAgents(t, newDIDs(nil, nil))
+
+		require.NoError(t, alice.upgrader.Upgrade(connID))
+
+		require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile}, alice.mediaTypeProfiles(t))
+		require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile}, bob.mediaTypeProfiles(t))
+		require.Len(t, alice.sent, 2)
+		require.Equal(t, QueriesMsgType, alice.sent[0].Type())
+		require.False(t, alice.sent[0].IsDIDCommV2())
+		require.Equal(t, DiscloseMsgType, alice.sent[1].Type())
+		require.True(t, alice.sent[1].IsDIDCommV2())
+		require.Len(t, bob.sent, 1)
+		require.Equal(t, DiscloseMsgType, bob.sent[0].Type())
+
+		// upgrading a DIDComm v2 connection is a no-op
+		require.NoError(t, alice.upgrader.Upgrade(connID))
+		require.Len(t, alice.sent, 2)
+	})
+
+	t.Run("select the first profile of my DID accepted by the other party", func(t *testing.T) {
+		alice, bob := newAgents(t, newDIDs(
+			[]string{transport.MediaTypeProfileDIDCommAIP1, transport.MediaTypeV2EncryptedEnvelope,
+				transport.MediaTypeDIDCommV2Profile},
+			[]string{transport.MediaTypeDIDCommV2Profile},
+		))
+
+		require.NoError(t, alice.upgrader.Upgrade(connID))
+
+		require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile}, alice.mediaTypeProfiles(t))
+		require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile}, bob.mediaTypeProfiles(t))
+	})
+
+	t.Run("error if the other party accepts no DIDComm v2 profile of my DID", func(t *testing.T) {
+		alice, bob := newAgents(t, newDIDs(
+			[]string{transport.MediaTypeV2EncryptedEnvelope},
+			[]string{transport.MediaTypeDIDCommV2Profile},
+		))
+
+		err := alice.upgrader.Upgrade(connID)
+		require.ErrorIs(t, err, ErrNotSupported)
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, alice.mediaTypeProfiles(t))
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, bob.mediaTypeProfiles(t))
+	})
+
+	t.Run("error if a DID has no DIDComm v2 service", func(t *testing.T) {
+		docs := newDIDs(nil, nil)
+		docs[bobDID].Service = nil
+
+		alice, _ := newAgents(t, docs)
+
+		err := alice.upgrader.Upgrade(connID)
+		require.ErrorIs(t, err, ErrNotSupported)
+		require.Contains(t, err.Error(), "DID did:test:bob has no DIDComm v2 service")
+		require.Empty(t, alice.sent)
+	})
+
+	t.Run("error if a DID accepts no DIDComm v2 profile", func(t *testing.T) {
+		alice, _ := newAgents(t, newDIDs([]string{transport.MediaTypeAIP2RFC0019Profile}, nil))
+
+		err := alice.upgrader.Upgrade(connID)
+		require.ErrorIs(t, err, ErrNotSupported)
+		require.Contains(t, err.Error(), "DID did:test:alice accepts no DIDComm v2 profile")
+	})
+
+	t.Run("error if the connection is unknown", func(t *testing.T) {
+		alice, _ := newAgents(t, newDIDs(nil, nil))
+
+		err := alice.upgrader.Upgrade("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("error if the connection is not completed", func(t *testing.T) {
+		alice, _ := newAgents(t, newDIDs(nil, nil))
+
+		require.NoError(t, alice.connections.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-2",
+			State:        "requested",
+			MyDID:        aliceDID,
+			TheirDID:     bobDID,
+		}))
+
+		err := alice.upgrader.Upgrade("conn-2")
+		require.EqualError(t, err, "connection conn-2 is not completed")
+	})
+
+	t.Run("error if the other party doesn't answer", func(t *testing.T) {
+		alice, _ := newAgents(t, newDIDs(nil, nil))
+		alice.upgrader.outbound = &mockdispatcher.MockOutbound{}
+		alice.upgrader.timeout = time.Millisecond
+
+		err := alice.upgrader.Upgrade(connID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout waiting for the discover-features disclose")
+		require.Empty(t, alice.upgrader.pending)
+	})
+
+	t.Run("error if the queries can't be sent", func(t *testing.T) {
+		alice, _ := newAgents(t, newDIDs(nil, nil))
+		alice.upgrader.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		err := alice.upgrader.Upgrade(connID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send discover-features queries")
+	})
+
+	t.Run("restore the connection if the DIDComm v2 disclose can't be sent", func(t *testing.T) {
+		alice, bob := newAgents(t, newDIDs(nil, nil))
+
+		outbound := alice.upgrader.outbound
+		alice.upgrader.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				if _, ok := msg.(*DiscloseV2); ok {
+					return errors.New("send error")
+				}
+
+				return outbound.SendToDID(msg, myDID, theirDID)
+			},
+		}
+
+		err := alice.upgrader.Upgrade(connID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send DIDComm v2 disclose")
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, alice.mediaTypeProfiles(t))
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, bob.mediaTypeProfiles(t))
+	})
+}
+
+func TestUpgrader_HandleInbound(t *testing.T) {
+	t.Run("disclose the accepted profiles matching the queries", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil,
+			[]string{transport.MediaTypeDIDCommV2Profile, transport.MediaTypeV2EncryptedEnvelope}))
+
+		var disclose *DiscloseV2
+
+		bob.upgrader.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, bobDID, myDID)
+				require.Equal(t, aliceDID, theirDID)
+
+				disclose = msg.(*DiscloseV2)
+
+				return nil
+			},
+		}
+
+		require.NoError(t, bob.upgrader.HandleInbound(toMsgMap(t, &QueriesV2{
+			ID:   "queries-1",
+			Type: QueriesMsgType,
+			Body: QueriesBody{Queries: []Query{
+				{FeatureType: AcceptFeatureType, Match: "didcomm/*"},
+				{FeatureType: "protocol", Match: "*"},
+			}},
+		}), bobDID, aliceDID))
+
+		require.Equal(t, "queries-1", disclose.ThreadID)
+		require.Equal(t, []Disclosure{{FeatureType: AcceptFeatureType, ID: transport.MediaTypeDIDCommV2Profile}},
+			disclose.Body.Disclosures)
+	})
+
+	t.Run("disclose no profile if my DID has no DIDComm v2 service", func(t *testing.T) {
+		docs := newDIDs(nil, nil)
+		docs[bobDID].Service = nil
+
+		_, bob := newAgents(t, docs)
+
+		var disclose *Disclose
+
+		bob.upgrader.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				disclose = msg.(*Disclose)
+
+				return nil
+			},
+		}
+
+		require.NoError(t, bob.upgrader.HandleInbound(toMsgMap(t, &Queries{
+			ID:      "queries-1",
+			Type:    QueriesMsgType,
+			Queries: []Query{{FeatureType: AcceptFeatureType, Match: "*"}},
+		}), bobDID, aliceDID))
+
+		require.Equal(t, "queries-1", disclose.Thread.ID)
+		require.Empty(t, disclose.Disclosures)
+	})
+
+	t.Run("disclose the registered protocols", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil, nil))
+
+		WithProtocolRegistry(dispatcher.NewProtocolRegistry(&protocolService{piURIs: []string{
+			"https://didcomm.org/issue-credential/2.0", "https://didcomm.org/present-proof/2.0",
+		}}))(bob.upgrader)
+
+		var disclose *Disclose
+
+		bob.upgrader.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				disclose = msg.(*Disclose)
+
+				return nil
+			},
+		}
+
+		require.NoError(t, bob.upgrader.HandleInbound(toMsgMap(t, &Queries{
+			ID:      "queries-1",
+			Type:    QueriesMsgType,
+			Queries: []Query{{FeatureType: ProtocolFeatureType, Match: "https://didcomm.org/present-proof/*"}},
+		}), bobDID, aliceDID))
+
+		require.Equal(t, []Disclosure{{FeatureType: ProtocolFeatureType, ID: "https://didcomm.org/present-proof/2.0"}},
+			disclose.Disclosures)
+	})
+
+	t.Run("ignore the DIDComm v1 disclosures out of a query", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil, nil))
+
+		require.NoError(t, bob.upgrader.HandleInbound(toMsgMap(t, &Disclose{
+			ID:          "disclose-1",
+			Type:        DiscloseMsgType,
+			Disclosures: []Disclosure{{FeatureType: AcceptFeatureType, ID: transport.MediaTypeDIDCommV2Profile}},
+		}), bobDID, aliceDID))
+
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, bob.mediaTypeProfiles(t))
+	})
+
+	t.Run("ignore the DIDComm v2 disclosures out of a connection", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil, nil))
+
+		require.NoError(t, bob.upgrader.HandleInbound(toMsgMap(t, &DiscloseV2{
+			ID:   "disclose-1",
+			Type: DiscloseMsgType,
+			Body: DiscloseBody{Disclosures: []Disclosure{
+				{FeatureType: AcceptFeatureType, ID: transport.MediaTypeDIDCommV2Profile},
+			}},
+		}), bobDID, "did:test:carol"))
+
+		require.Equal(t, []string{transport.MediaTypeAIP2RFC0019Profile}, bob.mediaTypeProfiles(t))
+	})
+
+	t.Run("error if the queries are received out of a connection", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil, nil))
+
+		err := bob.upgrader.HandleInbound(toMsgMap(t, &Queries{ID: "queries-1", Type: QueriesMsgType}), bobDID, "")
+		require.EqualError(t, err, "discover-features queries received out of a connection")
+	})
+
+	t.Run("error if the message type is not supported", func(t *testing.T) {
+		_, bob := newAgents(t, newDIDs(nil, nil))
+
+		err := bob.upgrader.HandleInbound(service.DIDCommMsgMap{"@type": "unknown"}, bobDID, aliceDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestAccept(t *testing.T) {
+	require.True(t, Accept(QueriesMsgType))
+	require.True(t, Accept(DiscloseMsgType))
+	require.False(t, Accept("https://didcomm.org/discover-features/1.0/query"))
+}
+
+func TestIsDIDCommV2(t *testing.T) {
+	require.True(t, IsDIDCommV2([]string{transport.MediaTypeDIDCommV2Profile}))
+	require.True(t, IsDIDCommV2([]string{transport.MediaTypeProfileDIDCommAIP1, transport.MediaTypeV2EncryptedEnvelope}))
+	require.False(t, IsDIDCommV2([]string{transport.MediaTypeAIP2RFC0587Profile}))
+	require.False(t, IsDIDCommV2(nil))
+}
+
+type agent struct {
+	upgrader    *Upgrader
+	connections *connection.Recorder
+	sent        []service.DIDCommMsgMap
+}
+
+func (a *agent) mediaTypeProfiles(t *testing.T) []string {
+	t.Helper()
+
+	record, err := a.connections.GetConnectionRecord(connID)
+	require.NoError(t, err)
+
+	return record.MediaTypeProfiles
+}
+
+// newAgents returns the agents of Alice and Bob, with a DIDComm v1 connection and delivering their messages to each
+// other.
+func newAgents(t *testing.T, docs map[string]*did.Doc) (*agent, *agent) {
+	t.Helper()
+
+	vdr := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			doc, ok := docs[didID]
+			if !ok {
+				return nil, fmt.Errorf("DID %s not found", didID)
+			}
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	}
+
+	agents := map[string]*agent{}
+
+	for _, myDID := range []string{aliceDID, bobDID} {
+		a := &agent{}
+		agents[myDID] = a
+
+		p := &mockprovider.Provider{
+			VDRegistryValue:                   vdr,
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					msgMap := toMsgMap(t, msg)
+					a.sent = append(a.sent, msgMap)
+
+					return agents[theirDID].upgrader.HandleInbound(msgMap, theirDID, myDID)
+				},
+			},
+		}
+
+		var err error
+
+		a.connections, err = connection.NewRecorder(p)
+		require.NoError(t, err)
+
+		theirDID := bobDID
+		if myDID == bobDID {
+			theirDID = aliceDID
+		}
+
+		require.NoError(t, a.connections.SaveConnectionRecord(&connection.Record{
+			ConnectionID:      connID,
+			State:             connection.StateNameCompleted,
+			MyDID:             myDID,
+			TheirDID:          theirDID,
+			MediaTypeProfiles: []string{transport.MediaTypeAIP2RFC0019Profile},
+		}))
+
+		a.upgrader, err = New(p)
+		require.NoError(t, err)
+	}
+
+	return agents[aliceDID], agents[bobDID]
+}
+
+// newDIDs returns the DID documents of Alice and Bob with DIDComm v2 services accepting the given profiles.
+func newDIDs(aliceAccept, bobAccept []string) map[string]*did.Doc {
+	return map[string]*did.Doc{
+		aliceDID: newDoc(aliceDID, aliceAccept),
+		bobDID:   newDoc(bobDID, bobAccept),
+	}
+}
+
+func newDoc(id string, accept []string) *did.Doc {
+	vm := did.NewVerificationMethodFromBytes(id+"#key-1", "X25519KeyAgreementKey2019", id, []byte("key"))
+
+	return &did.Doc{
+		ID:                 id,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		KeyAgreement:       []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)},
+		Service: []did.Service{{
+			ID:              id + "#didcomm",
+			Type:            didCommV2ServiceType,
+			ServiceEndpoint: "https://example.com/" + id,
+			Accept:          accept,
+		}},
+	}
+}
+
+func toMsgMap(t *testing.T, msg interface{}) service.DIDCommMsgMap {
+	t.Helper()
+
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	msgMap, err := service.ParseDIDCommMsgMap(raw)
+	require.NoError(t, err)
+
+	return msgMap
+}
+
+type protocolService struct {
+	piURIs []string
+}
+
+func (s *protocolService) HandleInbound(service.DIDCommMsg, service.DIDCommContext) (string, error) {
+	return "", nil
+}
+
+func (s *protocolService) HandleOutbound(service.DIDCommMsg, string, string) (string, error) {
+	return "", nil
+}
+
+func (s *protocolService) Accept(string) bool {
+	return false
+}
+
+func (s *protocolService) Name() string {
+	return "protocol"
+}
+
+func (s *protocolService) PIURIs() []string {
+	return s.piURIs
+}
@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package anoncreds implements the AnonCreds (Hyperledger Indy) attachment formats of the issue-credential and
+// present-proof protocols (RFC 0592): the credential offers, requests and credentials, and the proof requests and
+// proofs exchanged with the Indy agents (eg. ACA-Py). The AnonCreds math is left to a Prover, implemented with an
+// AnonCreds library holding the link secret and the credentials of the holder.
+package anoncreds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Attachment formats of the issue-credential and present-proof protocols (RFC 0592).
+const (
+	// CredentialFilterFormat is the format of the credential filters of the credential proposals.
+	CredentialFilterFormat = "hlindy/cred-filter@v2.0"
+	// CredentialAbstractFormat is the format of the credential offers.
+	CredentialAbstractFormat = "hlindy/cred-abstract@v2.0"
+	// CredentialRequestFormat is the format of the credential requests.
+	CredentialRequestFormat = "hlindy/cred-req@v2.0"
+	// CredentialFormat is the format of the credentials issued.
+	CredentialFormat = "hlindy/cred@v2.0"
+	// ProofRequestFormat is the format of the proof requests.
+	ProofRequestFormat = "hlindy/proof-req@v2.0"
+	// ProofFormat is the format of the proofs presented.
+	ProofFormat = "hlindy/proof@v2.0"
+
+	formatPrefix = "hlindy/"
+)
+
+// ErrInvalidAttachment is returned when an AnonCreds attachment is missing required fields.
+var ErrInvalidAttachment = errors.New("invalid anoncreds attachment")
+
+// Predicate types of the proof requests.
+const (
+	PredicateGE = ">="
+	PredicateGT = ">"
+	PredicateLE = "<="
+	PredicateLT = "<"
+)
+
+// Prover is the AnonCreds math of the holder, implemented with an AnonCreds library (eg. anoncreds-rs or libindy)
+// holding the link secret and the credentials, and resolving the schemas and credential definitions from the ledger.
+type Prover interface {
+	// CreateCredentialRequest creates the request of the credential offered. The request metadata is kept by the
+	// caller and passed to StoreCredential along with the credential issued.
+	CreateCredentialRequest(offer *CredentialOffer) (*CredentialRequest, json.RawMessage, error)
+	// StoreCredential processes and stores the credential issued, returning its ID.
+	StoreCredential(credential *Credential, requestMetadata json.RawMessage) (string, error)
+	// CreateProof creates the proof of the proof request from the stored credentials.
+	CreateProof(request *ProofRequest) (*Proof, error)
+}
+
+// IsFormat checks whether the attachment format is an AnonCreds format.
+func IsFormat(format string) bool {
+	return strings.HasPrefix(format, formatPrefix)
+}
+
+// CredentialOffer is the hlindy/cred-abstract@v2.0 attachment of the credential offers.
+type CredentialOffer struct {
+	SchemaID            string          `json:"schema_id"`
+	CredDefID           string          `json:"cred_def_id"`
+	Nonce               string          `json:"nonce"`
+	KeyCorrectnessProof json.RawMessage `json:"key_correctness_proof"`
+}
+
+// Validate checks the required fields of the credential offer.
+func (o *CredentialOffer) Validate() error {
+	return validate(CredentialAbstractFormat, map[string]bool{
+		"schema_id":             o.SchemaID != "",
+		"cred_def_id":           o.CredDefID != "",
+		"nonce":                 isNonce(o.Nonce),
+		"key_correctness_proof": isSet(o.KeyCorrectnessProof),
+	})
+}
+
+// CredentialRequest is the hlindy/cred-req@v2.0 attachment of the credential requests.
+type CredentialRequest struct {
+	ProverDID                 string          `json:"prover_did,omitempty"`
+	CredDefID                 string          `json:"cred_def_id"`
+	BlindedMS                 json.RawMessage `json:"blinded_ms"`
+	BlindedMSCorrectnessProof json.RawMessage `json:"blinded_ms_correctness_proof"`
+	Nonce                     string          `json:"nonce"`
+}
+
+// Validate checks the required fields of the credential request.
+func (r *CredentialRequest) Validate() error {
+	return validate(CredentialRequestFormat, map[string]bool{
+		"cred_def_id":                  r.CredDefID != "",
+		"blinded_ms":                   isSet(r.BlindedMS),
+		"blinded_ms_correctness_proof": isSet(r.BlindedMSCorrectnessProof),
+		"nonce":                        isNonce(r.Nonce),
+	})
+}
+
+// Credential is the hlindy/cred@v2.0 attachment of the credentials issued.
+type Credential struct {
+	SchemaID                  string                    `json:"schema_id"`
+	CredDefID                 string                    `json:"cred_def_id"`
+	RevRegID                  string                    `json:"rev_reg_id,omitempty"`
+	Values                    map[string]AttributeValue `json:"values"`
+	Signature                 json.RawMessage           `json:"signature"`
+	SignatureCorrectnessProof json.RawMessage           `json:"signature_correctness_proof"`
+	RevReg                    json.RawMessage           `json:"rev_reg,omitempty"`
+	Witness                   json.RawMessage           `json:"witness,omitempty"`
+}
+
+// AttributeValue is the value of a credential attribute, raw and encoded as an integer.
+type AttributeValue struct {
+	Raw     string `json:"raw"`
+	Encoded string `json:"encoded"`
+}
+
+// Validate checks the required fields of the credential.
+func (c *Credential) Validate() error {
+	if err := validate(CredentialFormat, map[string]bool{
+		"schema_id":                   c.SchemaID != "",
+		"cred_def_id":                 c.CredDefID != "",
+		"values":                      len(c.Values) != 0,
+		"signature":                   isSet(c.Signature),
+		"signature_correctness_proof": isSet(c.SignatureCorrectnessProof),
+	}); err != nil {
+		return err
+	}
+
+	for name, value := range c.Values {
+		if value.Encoded == "" {
+			return fmt.Errorf("%w: %s: value %s isn't encoded", ErrInvalidAttachment, CredentialFormat, name)
+		}
+	}
+
+	return nil
+}
+
+// ProofRequest is the hlindy/proof-req@v2.0 attachment of the proof requests.
+type ProofRequest struct {
+	Name                string                    `json:"name"`
+	Version             string                    `json:"version"`
+	Nonce               string                    `json:"nonce"`
+	RequestedAttributes map[string]*AttributeInfo `json:"requested_attributes"`
+	RequestedPredicates map[string]*PredicateInfo `json:"requested_predicates"`
+	NonRevoked          *NonRevokedInterval       `json:"non_revoked,omitempty"`
+}
+
+// AttributeInfo is an attribute requested, by name or by names of attributes of the same credential.
+type AttributeInfo struct {
+	Name         string              `json:"name,omitempty"`
+	Names        []string            `json:"names,omitempty"`
+	Restrictions json.RawMessage     `json:"restrictions,omitempty"`
+	NonRevoked   *NonRevokedInterval `json:"non_revoked,omitempty"`
+}
+
+// PredicateInfo is a predicate requested on a credential attribute, eg. birthdate_dateint <= 20051015.
+type PredicateInfo struct {
+	Name         string              `json:"name"`
+	PType        string              `json:"p_type"`
+	PValue       int64               `json:"p_value"`
+	Restrictions json.RawMessage     `json:"restrictions,omitempty"`
+	NonRevoked   *NonRevokedInterval `json:"non_revoked,omitempty"`
+}
+
+// NonRevokedInterval is the interval, in seconds since the epoch, the credentials must not be revoked in.
+type NonRevokedInterval struct {
+	From int64 `json:"from,omitempty"`
+	To   int64 `json:"to,omitempty"`
+}
+
+// Validate checks the required fields of the proof request and its attributes and predicates.
+func (r *ProofRequest) Validate() error {
+	if err := validate(ProofRequestFormat, map[string]bool{
+		"name":                               r.Name != "",
+		"version":                            r.Version != "",
+		"nonce":                              isNonce(r.Nonce),
+		"requested_attributes or predicates": len(r.RequestedAttributes)+len(r.RequestedPredicates) != 0,
+	}); err != nil {
+		return err
+	}
+
+	for referent, attr := range r.RequestedAttributes {
+		if attr == nil || (attr.Name == "") == (len(attr.Names) == 0) {
+			return fmt.Errorf("%w: %s: attribute %s requires either a name or names", ErrInvalidAttachment,
+				ProofRequestFormat, referent)
+		}
+	}
+
+	for referent, predicate := range r.RequestedPredicates {
+		if predicate == nil || predicate.Name == "" {
+			return fmt.Errorf("%w: %s: predicate %s requires a name", ErrInvalidAttachment, ProofRequestFormat,
+				referent)
+		}
+
+		switch predicate.PType {
+		case PredicateGE, PredicateGT, PredicateLE, PredicateLT:
+		default:
+			return fmt.Errorf("%w: %s: predicate %s has an unsupported type %q", ErrInvalidAttachment,
+				ProofRequestFormat, referent, predicate.PType)
+		}
+	}
+
+	return nil
+}
+
+// Proof is the hlindy/proof@v2.0 attachment of the presentations.
+type Proof struct {
+	Proof          json.RawMessage `json:"proof"`
+	RequestedProof json.RawMessage `json:"requested_proof"`
+	Identifiers    []Identifier    `json:"identifiers"`
+}
+
+// Identifier identifies a credential the proof is derived from.
+type Identifier struct {
+	SchemaID  string `json:"schema_id"`
+	CredDefID string `json:"cred_def_id"`
+	RevRegID  string `json:"rev_reg_id,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+}
+
+// Validate checks the required fields of the proof.
+func (p *Proof) Validate() error {
+	if err := validate(ProofFormat, map[string]bool{
+		"proof":           isSet(p.Proof),
+		"requested_proof": isSet(p.RequestedProof),
+		"identifiers":     len(p.Identifiers) != 0,
+	}); err != nil {
+		return err
+	}
+
+	for i, identifier := range p.Identifiers {
+		if identifier.SchemaID == "" || identifier.CredDefID == "" {
+			return fmt.Errorf("%w: %s: identifier %d requires a schema_id and a cred_def_id", ErrInvalidAttachment,
+				ProofFormat, i)
+		}
+	}
+
+	return nil
+}
+
+// ParseCredentialOffer parses and validates the hlindy/cred-abstract@v2.0 attachment.
+func ParseCredentialOffer(data []byte) (*CredentialOffer, error) {
+	offer := &CredentialOffer{}
+
+	return offer, parse(data, offer)
+}
+
+// ParseCredentialRequest parses and validates the hlindy/cred-req@v2.0 attachment.
+func ParseCredentialRequest(data []byte) (*CredentialRequest, error) {
+	request := &CredentialRequest{}
+
+	return request, parse(data, request)
+}
+
+// ParseCredential parses and validates the hlindy/cred@v2.0 attachment.
+func ParseCredential(data []byte) (*Credential, error) {
+	credential := &Credential{}
+
+	return credential, parse(data, credential)
+}
+
+// ParseProofRequest parses and validates the hlindy/proof-req@v2.0 attachment.
+func ParseProofRequest(data []byte) (*ProofRequest, error) {
+	request := &ProofRequest{}
+
+	return request, parse(data, request)
+}
+
+// ParseProof parses and validates the hlindy/proof@v2.0 attachment.
+func ParseProof(data []byte) (*Proof, error) {
+	proof := &Proof{}
+
+	return proof, parse(data, proof)
+}
+
+type validator interface {
+	Validate() error
+}
+
+func parse(data []byte, v validator) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAttachment, err)
+	}
+
+	return v.Validate()
+}
+
+// validate reports the missing fields, sorted by name.
+func validate(format string, fields map[string]bool) error {
+	var missing []string
+
+	for name, present := range fields {
+		if !present {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+
+	return fmt.Errorf("%w: %s: missing or invalid %s", ErrInvalidAttachment, format, strings.Join(missing, ", "))
+}
+
+// isSet checks the raw JSON value is set and isn't null.
+func isSet(raw json.RawMessage) bool {
+	return len(raw) != 0 && string(raw) != "null"
+}
+
+// isNonce checks the nonce is a decimal number, as generated by the AnonCreds libraries.
+func isNonce(nonce string) bool {
+	if nonce == "" {
+		return false
+	}
+
+	for _, c := range nonce {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
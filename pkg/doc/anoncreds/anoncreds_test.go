@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nolint: lll
+const (
+	credentialOffer   = `{"schema_id":"WgWxqztrNooG92RXvxSTWv:2:schema_name:1.0","cred_def_id":"WgWxqztrNooG92RXvxSTWv:3:CL:20:tag","nonce":"57a62300-fbe2-4f08-ace0-6c329c5210e1","key_correctness_proof":{"c":"1"}}`
+	credentialRequest = `{"prover_did":"did:sov:abcxyz123","cred_def_id":"WgWxqztrNooG92RXvxSTWv:3:CL:20:tag","blinded_ms":{"u":"1"},"blinded_ms_correctness_proof":{"c":"1"},"nonce":"1234567890"}`
+	credential        = `{"schema_id":"WgWxqztrNooG92RXvxSTWv:2:schema_name:1.0","cred_def_id":"WgWxqztrNooG92RXvxSTWv:3:CL:20:tag","values":{"name":{"raw":"Alice","encoded":"1139481716457488690172217916278103335"}},"signature":{"p_credential":{}},"signature_correctness_proof":{"se":"1"}}`
+	proofRequest      = `{"name":"proof of age","version":"1.0","nonce":"1234567890","requested_attributes":{"name":{"name":"name","restrictions":[{"cred_def_id":"WgWxqztrNooG92RXvxSTWv:3:CL:20:tag"}]},"address":{"names":["street","city"]}},"requested_predicates":{"age":{"name":"age","p_type":">=","p_value":18}},"non_revoked":{"to":1600000000}}`
+	proof             = `{"proof":{"proofs":[]},"requested_proof":{"revealed_attrs":{}},"identifiers":[{"schema_id":"WgWxqztrNooG92RXvxSTWv:2:schema_name:1.0","cred_def_id":"WgWxqztrNooG92RXvxSTWv:3:CL:20:tag","timestamp":1600000000}]}`
+)
+
+func TestIsFormat(t *testing.T) {
+	require.True(t, IsFormat(CredentialAbstractFormat))
+	require.True(t, IsFormat(ProofFormat))
+	require.False(t, IsFormat("aries/ld-proof-vc-detail@v1.0"))
+}
+
+func TestParseCredentialOffer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		offer, err := ParseCredentialOffer([]byte(`{"schema_id":"schema-1","cred_def_id":"cred-def-1",` +
+			`"nonce":"1234","key_correctness_proof":{"c":"1"}}`))
+		require.NoError(t, err)
+		require.Equal(t, "cred-def-1", offer.CredDefID)
+	})
+
+	t.Run("invalid nonce", func(t *testing.T) {
+		_, err := ParseCredentialOffer([]byte(credentialOffer))
+		require.ErrorIs(t, err, ErrInvalidAttachment)
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/cred-abstract@v2.0: missing or invalid nonce")
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		_, err := ParseCredentialOffer([]byte(`{"nonce":"1234","key_correctness_proof":null}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/cred-abstract@v2.0: "+
+			"missing or invalid cred_def_id, key_correctness_proof, schema_id")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseCredentialOffer([]byte(`[]`))
+		require.ErrorIs(t, err, ErrInvalidAttachment)
+	})
+}
+
+func TestParseCredentialRequest(t *testing.T) {
+	request, err := ParseCredentialRequest([]byte(credentialRequest))
+	require.NoError(t, err)
+	require.Equal(t, "did:sov:abcxyz123", request.ProverDID)
+
+	_, err = ParseCredentialRequest([]byte(`{"cred_def_id":"cred-def-1","nonce":"1234"}`))
+	require.EqualError(t, err, "invalid anoncreds attachment: hlindy/cred-req@v2.0: "+
+		"missing or invalid blinded_ms, blinded_ms_correctness_proof")
+}
+
+func TestParseCredential(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cred, err := ParseCredential([]byte(credential))
+		require.NoError(t, err)
+		require.Equal(t, "Alice", cred.Values["name"].Raw)
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		_, err := ParseCredential([]byte(`{"schema_id":"schema-1","cred_def_id":"cred-def-1",` +
+			`"signature":{},"signature_correctness_proof":{}}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/cred@v2.0: missing or invalid values")
+	})
+
+	t.Run("value not encoded", func(t *testing.T) {
+		_, err := ParseCredential([]byte(`{"schema_id":"schema-1","cred_def_id":"cred-def-1",` +
+			`"values":{"name":{"raw":"Alice"}},"signature":{},"signature_correctness_proof":{}}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/cred@v2.0: value name isn't encoded")
+	})
+}
+
+func TestParseProofRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		request, err := ParseProofRequest([]byte(proofRequest))
+		require.NoError(t, err)
+		require.Equal(t, []string{"street", "city"}, request.RequestedAttributes["address"].Names)
+		require.Equal(t, int64(18), request.RequestedPredicates["age"].PValue)
+		require.Equal(t, int64(1600000000), request.NonRevoked.To)
+	})
+
+	t.Run("nothing requested", func(t *testing.T) {
+		_, err := ParseProofRequest([]byte(`{"name":"proof","version":"1.0","nonce":"1234"}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof-req@v2.0: "+
+			"missing or invalid requested_attributes or predicates")
+	})
+
+	t.Run("attribute with a name and names", func(t *testing.T) {
+		_, err := ParseProofRequest([]byte(`{"name":"proof","version":"1.0","nonce":"1234",` +
+			`"requested_attributes":{"name":{"name":"name","names":["name"]}}}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof-req@v2.0: "+
+			"attribute name requires either a name or names")
+	})
+
+	t.Run("predicate without name", func(t *testing.T) {
+		_, err := ParseProofRequest([]byte(`{"name":"proof","version":"1.0","nonce":"1234",` +
+			`"requested_predicates":{"age":{"p_type":">=","p_value":18}}}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof-req@v2.0: "+
+			"predicate age requires a name")
+	})
+
+	t.Run("unsupported predicate type", func(t *testing.T) {
+		_, err := ParseProofRequest([]byte(`{"name":"proof","version":"1.0","nonce":"1234",` +
+			`"requested_predicates":{"age":{"name":"age","p_type":"!=","p_value":18}}}`))
+		require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof-req@v2.0: "+
+			`predicate age has an unsupported type "!="`)
+	})
+}
+
+func TestParseProof(t *testing.T) {
+	p, err := ParseProof([]byte(proof))
+	require.NoError(t, err)
+	require.Len(t, p.Identifiers, 1)
+
+	_, err = ParseProof([]byte(`{"proof":{},"requested_proof":{},"identifiers":[{"schema_id":"schema-1"}]}`))
+	require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof@v2.0: "+
+		"identifier 0 requires a schema_id and a cred_def_id")
+
+	_, err = ParseProof([]byte(`{}`))
+	require.EqualError(t, err, "invalid anoncreds attachment: hlindy/proof@v2.0: "+
+		"missing or invalid identifiers, proof, requested_proof")
+}
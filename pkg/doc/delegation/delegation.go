@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package delegation supports organization DID hierarchies, where the root DID of an organization delegates the
+// issuance of credentials to departmental DIDs with delegation credentials. The credentials issued by a departmental
+// DID include the chain of delegation credentials from the root DID, which is verified against the trusted roots.
+package delegation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// CredentialType is the type of the delegation credentials.
+	CredentialType = "DelegatedIssuanceCredential"
+	// ChainField is the field of the issued credentials holding the chain of delegation credentials.
+	ChainField = "delegationChain"
+
+	vcType          = "VerifiableCredential"
+	vcContext       = "https://www.w3.org/2018/credentials/v1"
+	scopeField      = "delegation"
+	vocab           = "urn:aries:delegation#"
+	jsonLiteralType = "@json"
+)
+
+// Scope of the issuance authority delegated to the subject of a delegation credential.
+type Scope struct {
+	// CredentialTypes are the types of the credentials the delegate may issue, any type if empty.
+	CredentialTypes []string `json:"credentialTypes,omitempty"`
+	// MayDelegate allows the delegate to delegate its authority, within the scope, to other DIDs.
+	MayDelegate bool `json:"mayDelegate,omitempty"`
+}
+
+// NewCredential creates the credential delegating the issuance authority of the delegator DID, within the scope,
+// to the delegate DID. The credential expires at the given time, unless it is zero, and must be signed by the
+// delegator before being given to the delegate.
+func NewCredential(delegator, delegate string, scope *Scope, expires time.Time) (*verifiable.Credential, error) {
+	if delegator == "" || delegate == "" {
+		return nil, errors.New("delegator and delegate DIDs are required")
+	}
+
+	if scope == nil {
+		scope = &Scope{}
+	}
+
+	// the scope is a JSON literal, for the proofs of the credential to cover it.
+	vc := &verifiable.Credential{
+		Context: []string{vcContext},
+		CustomContext: []interface{}{map[string]interface{}{
+			"@version":     1.1,
+			CredentialType: vocab + CredentialType,
+			scopeField:     map[string]interface{}{"@id": vocab + scopeField, "@type": jsonLiteralType},
+		}},
+		ID:     "urn:uuid:" + uuid.New().String(),
+		Types:  []string{vcType, CredentialType},
+		Issuer: verifiable.Issuer{ID: delegator},
+		Issued: util.NewTime(time.Now().UTC()),
+		Subject: map[string]interface{}{
+			"id":       delegate,
+			scopeField: scope,
+		},
+	}
+
+	if !expires.IsZero() {
+		vc.Expired = util.NewTime(expires.UTC())
+	}
+
+	return vc, nil
+}
+
+// AttachChain includes the chain of delegation credentials, from the one issued by the root DID to the one issued
+// to the issuer of the credential, into the credential. The chain is attached before the credential is signed.
+func AttachChain(vc *verifiable.Credential, chain ...*verifiable.Credential) error {
+	if len(chain) == 0 {
+		return errors.New("empty delegation chain")
+	}
+
+	raw := make([]json.RawMessage, len(chain))
+
+	for i, c := range chain {
+		data, err := c.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("marshal delegation credential: %w", err)
+		}
+
+		raw[i] = data
+	}
+
+	if vc.CustomFields == nil {
+		vc.CustomFields = verifiable.CustomFields{}
+	}
+
+	vc.CustomFields[ChainField] = raw
+	vc.CustomContext = append(vc.CustomContext, map[string]interface{}{
+		"@version": 1.1,
+		ChainField: map[string]interface{}{"@id": vocab + ChainField, "@type": jsonLiteralType},
+	})
+
+	return nil
+}
+
+// ParseChain parses the chain of delegation credentials included into the credential, nil if it has none. The proofs
+// of the delegation credentials are checked unless disabled by the options.
+func ParseChain(vc *verifiable.Credential, opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	field, ok := vc.CustomFields[ChainField]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(field)
+	if err != nil {
+		return nil, fmt.Errorf("marshal delegation chain: %w", err)
+	}
+
+	var raw []json.RawMessage
+
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("delegation chain is not an array: %w", err)
+	}
+
+	chain := make([]*verifiable.Credential, len(raw))
+
+	for i, r := range raw {
+		// a delegation credential is either a JSON object or a JWT.
+		var jwt string
+		if json.Unmarshal(r, &jwt) == nil {
+			r = []byte(jwt)
+		}
+
+		chain[i], err = verifiable.ParseCredential(r, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("parse delegation credential %d: %w", i, err)
+		}
+	}
+
+	return chain, nil
+}
+
+// delegationOf returns the delegate and the scope of a delegation credential.
+func delegationOf(vc *verifiable.Credential) (string, *Scope, error) {
+	if !hasType(vc.Types, CredentialType) {
+		return "", nil, fmt.Errorf("credential %s is not a delegation credential", vc.ID)
+	}
+
+	data, err := vc.MarshalJSON()
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal delegation credential: %w", err)
+	}
+
+	type subject struct {
+		ID    string `json:"id"`
+		Scope *Scope `json:"delegation"`
+	}
+
+	raw := struct {
+		Subject json.RawMessage `json:"credentialSubject"`
+	}{}
+
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return "", nil, fmt.Errorf("unmarshal delegation credential: %w", err)
+	}
+
+	var subjects []subject
+
+	if json.Unmarshal(raw.Subject, &subjects) != nil {
+		subjects = make([]subject, 1)
+
+		// a subject without delegation may also be a string, rejected below.
+		_ = json.Unmarshal(raw.Subject, &subjects[0]) // nolint:errcheck
+	}
+
+	if len(subjects) != 1 || subjects[0].ID == "" || subjects[0].Scope == nil {
+		return "", nil, fmt.Errorf("delegation credential %s must have one subject with a delegation", vc.ID)
+	}
+
+	return subjects[0].ID, subjects[0].Scope, nil
+}
+
+func hasType(types []string, typ string) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delegation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	rootDID = "did:example:org"
+	deptDID = "did:example:hr"
+	teamDID = "did:example:payroll"
+)
+
+func TestNewCredential(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		expires := time.Now().Add(time.Hour)
+
+		vc, err := NewCredential(rootDID, deptDID, &Scope{CredentialTypes: []string{"EmployeeCredential"}}, expires)
+		require.NoError(t, err)
+		require.Equal(t, rootDID, vc.Issuer.ID)
+		require.Equal(t, []string{vcType, CredentialType}, vc.Types)
+		require.Equal(t, expires.Unix(), vc.Expired.Time.Unix())
+
+		delegate, scope, err := delegationOf(vc)
+		require.NoError(t, err)
+		require.Equal(t, deptDID, delegate)
+		require.Equal(t, []string{"EmployeeCredential"}, scope.CredentialTypes)
+	})
+
+	t.Run("missing DID", func(t *testing.T) {
+		_, err := NewCredential(rootDID, "", nil, time.Time{})
+		require.EqualError(t, err, "delegator and delegate DIDs are required")
+	})
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	h := newHierarchy(t)
+
+	rootToDept := h.delegate(t, rootDID, deptDID, &Scope{MayDelegate: true}, time.Time{})
+	deptToTeam := h.delegate(t, deptDID, teamDID, &Scope{CredentialTypes: []string{"PayslipCredential"}},
+		time.Now().Add(time.Hour))
+
+	verifier := NewVerifier([]string{rootDID}, WithCredentialOptions(h.opts()...))
+
+	t.Run("credential issued through a delegation chain", func(t *testing.T) {
+		vc := h.issue(t, teamDID, "PayslipCredential", rootToDept, deptToTeam)
+
+		chain, err := verifier.Verify(vc)
+		require.NoError(t, err)
+		require.Len(t, chain, 2)
+		require.Equal(t, deptDID, chain[1].Issuer.ID)
+	})
+
+	t.Run("credential issued by the root", func(t *testing.T) {
+		chain, err := verifier.Verify(h.issue(t, rootDID, "PayslipCredential"))
+		require.NoError(t, err)
+		require.Empty(t, chain)
+	})
+
+	t.Run("issuer without delegation chain", func(t *testing.T) {
+		_, err := verifier.Verify(h.issue(t, deptDID, "PayslipCredential"))
+		require.EqualError(t, err, "issuer did:example:hr is not a trusted root and has no delegation chain")
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		_, err := NewVerifier([]string{"did:example:other"}, WithCredentialOptions(h.opts()...)).
+			Verify(h.issue(t, deptDID, "EmployeeCredential", rootToDept))
+		require.EqualError(t, err, "delegation chain root did:example:org is not trusted")
+	})
+
+	t.Run("credential type not delegated", func(t *testing.T) {
+		_, err := verifier.Verify(h.issue(t, teamDID, "EmployeeCredential", rootToDept, deptToTeam))
+		require.EqualError(t, err, "delegation credential 1: credential type EmployeeCredential not delegated")
+	})
+
+	t.Run("delegate not allowed to delegate", func(t *testing.T) {
+		teamToOther := h.delegate(t, teamDID, "did:example:other", &Scope{}, time.Time{})
+
+		vc := h.issue(t, "did:example:other", "PayslipCredential", rootToDept, deptToTeam, teamToOther)
+
+		_, err := verifier.Verify(vc)
+		require.EqualError(t, err, "delegation credential 1 does not allow did:example:payroll to delegate")
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		_, err := verifier.Verify(h.issue(t, teamDID, "PayslipCredential", deptToTeam, rootToDept))
+		require.EqualError(t, err, "delegation chain root did:example:hr is not trusted")
+
+		_, err = verifier.Verify(h.issue(t, deptDID, "PayslipCredential", rootToDept, deptToTeam))
+		require.EqualError(t, err,
+			"delegation chain ends with did:example:payroll instead of the issuer did:example:hr")
+
+		rootToTeam := h.delegate(t, rootDID, teamDID, &Scope{}, time.Time{})
+
+		_, err = verifier.Verify(h.issue(t, teamDID, "PayslipCredential", rootToDept, rootToTeam))
+		require.EqualError(t, err,
+			"delegation credential 1 is issued by did:example:org instead of did:example:hr")
+	})
+
+	t.Run("expired delegation", func(t *testing.T) {
+		expired := h.delegate(t, rootDID, deptDID, &Scope{}, time.Now().Add(-time.Minute))
+
+		_, err := verifier.Verify(h.issue(t, deptDID, "PayslipCredential", expired))
+		require.EqualError(t, err, "delegation credential 0: expired")
+	})
+
+	t.Run("tampered delegation scope", func(t *testing.T) {
+		vc := h.issue(t, teamDID, "EmployeeCredential", rootToDept, deptToTeam)
+
+		raw := vc.CustomFields[ChainField].([]interface{})
+		scope := raw[1].(map[string]interface{})["credentialSubject"].(map[string]interface{})["delegation"]
+		scope.(map[string]interface{})["credentialTypes"] = []interface{}{"EmployeeCredential"}
+
+		_, err := verifier.Verify(vc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse delegation credential 1")
+	})
+
+	t.Run("not a delegation credential", func(t *testing.T) {
+		other := h.sign(t, &verifiable.Credential{
+			Context: []string{vcContext},
+			ID:      "urn:uuid:other",
+			Types:   []string{vcType},
+			Issuer:  verifiable.Issuer{ID: rootDID},
+			Issued:  util.NewTime(time.Now()),
+			Subject: map[string]interface{}{"id": deptDID},
+		})
+
+		_, err := verifier.Verify(h.issue(t, deptDID, "PayslipCredential", other))
+		require.EqualError(t, err, "credential urn:uuid:other is not a delegation credential")
+	})
+
+	t.Run("invalid chain", func(t *testing.T) {
+		vc := h.issue(t, deptDID, "PayslipCredential")
+		vc.CustomFields = verifiable.CustomFields{ChainField: []interface{}{"chain"}}
+
+		_, err := verifier.Verify(vc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse delegation credential 0")
+
+		vc.CustomFields = verifiable.CustomFields{ChainField: map[string]interface{}{}}
+
+		_, err = verifier.Verify(vc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "delegation chain is not an array")
+	})
+}
+
+func TestAttachChain(t *testing.T) {
+	err := AttachChain(&verifiable.Credential{})
+	require.EqualError(t, err, "empty delegation chain")
+}
+
+type hierarchy struct {
+	signers map[string]signature.Signer
+	loader  *ld.DocumentLoader
+}
+
+func newHierarchy(t *testing.T) *hierarchy {
+	t.Helper()
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	h := &hierarchy{signers: map[string]signature.Signer{}, loader: loader}
+
+	for _, d := range []string{rootDID, deptDID, teamDID, "did:example:other"} {
+		h.signers[d], err = signature.NewSigner(kms.ED25519Type)
+		require.NoError(t, err)
+	}
+
+	return h
+}
+
+func (h *hierarchy) opts() []verifiable.CredentialOpt {
+	return []verifiable.CredentialOpt{
+		verifiable.WithJSONLDDocumentLoader(h.loader),
+		verifiable.WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+			s, ok := h.signers[issuerID]
+			if !ok {
+				return nil, fmt.Errorf("unknown issuer %s", issuerID)
+			}
+
+			return &sigverifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: s.PublicKeyBytes()}, nil
+		}),
+	}
+}
+
+func (h *hierarchy) sign(t *testing.T, vc *verifiable.Credential) *verifiable.Credential {
+	t.Helper()
+
+	require.NoError(t, vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           ed25519signature2018.SignatureType,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(h.signers[vc.Issuer.ID])),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      vc.Issuer.ID + "#key-1",
+		Purpose:                 "assertionMethod",
+	}, jsonld.WithDocumentLoader(h.loader)))
+
+	// round trip the credential, as received by the verifier.
+	data, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	parsed, err := verifiable.ParseCredential(data, h.opts()...)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func (h *hierarchy) delegate(t *testing.T, delegator, delegate string, scope *Scope,
+	expires time.Time) *verifiable.Credential {
+	t.Helper()
+
+	vc, err := NewCredential(delegator, delegate, scope, expires)
+	require.NoError(t, err)
+
+	if !expires.IsZero() && expires.Before(time.Now()) {
+		vc.Issued = util.NewTime(expires.Add(-time.Hour))
+	}
+
+	return h.sign(t, vc)
+}
+
+func (h *hierarchy) issue(t *testing.T, issuer, typ string, chain ...*verifiable.Credential) *verifiable.Credential {
+	t.Helper()
+
+	vc := &verifiable.Credential{
+		Context:       []string{vcContext},
+		CustomContext: []interface{}{map[string]interface{}{typ: "urn:example:" + typ}},
+		ID:            "urn:uuid:" + strings.ToLower(typ),
+		Types:         []string{vcType, typ},
+		Issuer:        verifiable.Issuer{ID: issuer},
+		Issued:        util.NewTime(time.Now()),
+		Subject:       map[string]interface{}{"id": "did:example:employee"},
+	}
+
+	if len(chain) > 0 {
+		require.NoError(t, AttachChain(vc, chain...))
+	}
+
+	return h.sign(t, vc)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delegation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// Verifier verifies the issuance authority of the issuers of credentials, from the trusted root DIDs.
+type Verifier struct {
+	roots   map[string]struct{}
+	vcOpts  []verifiable.CredentialOpt
+	nowFunc func() time.Time
+}
+
+// Opt configures the verifier.
+type Opt func(v *Verifier)
+
+// WithCredentialOptions sets the options the delegation credentials are parsed with, like the public key fetcher
+// checking their proofs and the JSON-LD document loader.
+func WithCredentialOptions(opts ...verifiable.CredentialOpt) Opt {
+	return func(v *Verifier) {
+		v.vcOpts = append(v.vcOpts, opts...)
+	}
+}
+
+// NewVerifier returns a verifier trusting the given root DIDs.
+func NewVerifier(roots []string, opts ...Opt) *Verifier {
+	v := &Verifier{
+		roots:   make(map[string]struct{}, len(roots)),
+		nowFunc: time.Now,
+	}
+
+	for _, root := range roots {
+		v.roots[root] = struct{}{}
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify verifies the issuer of the credential is a trusted root DID, or the delegate of a trusted root DID through
+// the delegation chain included into the credential, and returns the chain. The delegation credentials must be valid
+// when the credential was issued, and each of them must allow the types of the credential. The proof of the
+// credential itself is not checked.
+func (v *Verifier) Verify(vc *verifiable.Credential) ([]*verifiable.Credential, error) {
+	chain, err := ParseChain(vc, v.vcOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) == 0 {
+		if _, ok := v.roots[vc.Issuer.ID]; ok {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("issuer %s is not a trusted root and has no delegation chain", vc.Issuer.ID)
+	}
+
+	if _, ok := v.roots[chain[0].Issuer.ID]; !ok {
+		return nil, fmt.Errorf("delegation chain root %s is not trusted", chain[0].Issuer.ID)
+	}
+
+	at := v.nowFunc()
+	if vc.Issued != nil {
+		at = vc.Issued.Time
+	}
+
+	delegator := chain[0].Issuer.ID
+
+	for i, d := range chain {
+		if d.Issuer.ID != delegator {
+			return nil, fmt.Errorf("delegation credential %d is issued by %s instead of %s", i, d.Issuer.ID, delegator)
+		}
+
+		delegate, scope, err := delegationOf(d)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkValidity(d, at); err != nil {
+			return nil, fmt.Errorf("delegation credential %d: %w", i, err)
+		}
+
+		if i < len(chain)-1 && !scope.MayDelegate {
+			return nil, fmt.Errorf("delegation credential %d does not allow %s to delegate", i, delegate)
+		}
+
+		if err = checkTypes(scope, vc.Types); err != nil {
+			return nil, fmt.Errorf("delegation credential %d: %w", i, err)
+		}
+
+		delegator = delegate
+	}
+
+	if vc.Issuer.ID != delegator {
+		return nil, fmt.Errorf("delegation chain ends with %s instead of the issuer %s", delegator, vc.Issuer.ID)
+	}
+
+	return chain, nil
+}
+
+func checkValidity(vc *verifiable.Credential, at time.Time) error {
+	if vc.Issued != nil && at.Before(vc.Issued.Time) {
+		return errors.New("not valid yet")
+	}
+
+	if vc.Expired != nil && !at.Before(vc.Expired.Time) {
+		return errors.New("expired")
+	}
+
+	return nil
+}
+
+func checkTypes(scope *Scope, types []string) error {
+	if len(scope.CredentialTypes) == 0 {
+		return nil
+	}
+
+	for _, t := range types {
+		if t != vcType && !hasType(scope.CredentialTypes, t) {
+			return fmt.Errorf("credential type %s not delegated", t)
+		}
+	}
+
+	return nil
+}
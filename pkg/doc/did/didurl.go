@@ -0,0 +1,289 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+)
+
+// DID parameters of a DID URL, see https://w3c.github.io/did-core/#did-parameters.
+const (
+	// ServiceParam identifies a service of the DID document by ID.
+	ServiceParam = "service"
+	// RelativeRefParam is a relative URI reference resolved against the service endpoint of the service parameter.
+	RelativeRefParam = "relativeRef"
+	// VersionIDParam identifies a specific version of the DID document.
+	VersionIDParam = "versionId"
+	// VersionTimeParam identifies the version of the DID document valid at a given time.
+	VersionTimeParam = "versionTime"
+	// HashLinkParam is a resource hash of the DID document adding integrity protection.
+	HashLinkParam = "hl"
+)
+
+// ErrResourceNotFound is returned when the resource a DID URL references isn't found in the DID document.
+var ErrResourceNotFound = errors.New("DID URL resource not found")
+
+// DIDURL is a DID URL parsed according to the DID URL syntax: https://w3c.github.io/did-core/#did-url-syntax
+type DIDURL struct {
+	DID
+	Path     string     // Path is the path of the DID URL, including the leading "/"
+	Queries  url.Values // Queries are the DID parameters and the other query parameters of the DID URL
+	Fragment string     // Fragment is the fragment of the DID URL, without the leading "#"
+}
+
+// ParseDIDURL parses the string according to the DID URL syntax.
+func ParseDIDURL(didURL string) (*DIDURL, error) {
+	rest, fragment := cut(didURL, "#")
+	rest, query := cut(rest, "?")
+
+	didPart, path := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		didPart, path = rest[:i], rest[i:]
+	}
+
+	d, err := Parse(didPart)
+	if err != nil {
+		return nil, err
+	}
+
+	queries, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID URL query %q: %w", query, err)
+	}
+
+	fragment, err = url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID URL fragment: %w", err)
+	}
+
+	return &DIDURL{DID: *d, Path: path, Queries: queries, Fragment: fragment}, nil
+}
+
+// DIDParams returns the DID parameters of the DID URL passed to the DID method when resolving the DID: the versionId
+// and versionTime parameters.
+func (u *DIDURL) DIDParams() map[string]string {
+	params := make(map[string]string)
+
+	for _, name := range []string{VersionIDParam, VersionTimeParam} {
+		if value := u.Queries.Get(name); value != "" {
+			params[name] = value
+		}
+	}
+
+	return params
+}
+
+// ResolveFunc resolves the DID, passing the DID parameters of the dereferenced DID URL to the DID method.
+type ResolveFunc func(did string, params map[string]string) (*DocResolution, error)
+
+// DereferenceResult is the resource a DID URL references: the DID document (or the version of the document) when the
+// DID URL has no fragment nor service parameter, the verification method or service identified by the fragment, or
+// the service selected by the service parameter and its endpoint.
+type DereferenceResult struct {
+	DocResolution      *DocResolution
+	VerificationMethod *VerificationMethod
+	Service            *Service
+	// ServiceEndpoint is the service endpoint of the service parameter, the relativeRef parameter being resolved
+	// against it and the fragment of the DID URL added to it.
+	ServiceEndpoint string
+}
+
+// Dereferencer dereferences DID URLs, see https://w3c.github.io/did-core/#did-url-dereferencing.
+type Dereferencer struct {
+	resolve ResolveFunc
+}
+
+// NewDereferencer returns a dereferencer resolving the DIDs with the resolve function.
+func NewDereferencer(resolve ResolveFunc) *Dereferencer {
+	return &Dereferencer{resolve: resolve}
+}
+
+// Dereference resolves the DID document of the DID URL and returns the resource it references. The hl parameter, if
+// any, is verified against the multihash of the JSON bytes of the DID document.
+func (d *Dereferencer) Dereference(didURL string) (*DereferenceResult, error) {
+	u, err := ParseDIDURL(didURL)
+	if err != nil {
+		return nil, fmt.Errorf("dereference %s: %w", didURL, err)
+	}
+
+	if u.Path != "" {
+		return nil, fmt.Errorf("dereference %s: DID URL paths are not supported", didURL)
+	}
+
+	docResolution, err := d.resolve(u.DID.String(), u.DIDParams())
+	if err != nil {
+		return nil, fmt.Errorf("dereference %s: resolve DID: %w", didURL, err)
+	}
+
+	if docResolution == nil || docResolution.DIDDocument == nil {
+		return nil, fmt.Errorf("dereference %s: %w", didURL, ErrDIDDocumentNotExist)
+	}
+
+	if hl := u.Queries.Get(HashLinkParam); hl != "" {
+		if err = verifyHashLink(docResolution.DIDDocument, hl); err != nil {
+			return nil, fmt.Errorf("dereference %s: %w", didURL, err)
+		}
+	}
+
+	result, err := dereferenceResource(docResolution, u)
+	if err != nil {
+		return nil, fmt.Errorf("dereference %s: %w", didURL, err)
+	}
+
+	return result, nil
+}
+
+func dereferenceResource(docResolution *DocResolution, u *DIDURL) (*DereferenceResult, error) {
+	doc := docResolution.DIDDocument
+	result := &DereferenceResult{DocResolution: docResolution}
+
+	if serviceID := u.Queries.Get(ServiceParam); serviceID != "" {
+		svc, ok := lookupServiceByID(doc, serviceID)
+		if !ok {
+			return nil, fmt.Errorf("service %s: %w", serviceID, ErrResourceNotFound)
+		}
+
+		endpoint, err := serviceEndpointURL(svc.ServiceEndpoint, u.Queries.Get(RelativeRefParam), u.Fragment)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Service, result.ServiceEndpoint = svc, endpoint
+
+		return result, nil
+	}
+
+	if u.Fragment == "" {
+		return result, nil
+	}
+
+	if vm, ok := lookupVerificationMethodByID(doc, u.Fragment); ok {
+		result.VerificationMethod = vm
+
+		return result, nil
+	}
+
+	if svc, ok := lookupServiceByID(doc, u.Fragment); ok {
+		result.Service, result.ServiceEndpoint = svc, svc.ServiceEndpoint
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("fragment %s: %w", u.Fragment, ErrResourceNotFound)
+}
+
+// serviceEndpointURL resolves the relative reference against the service endpoint, adding the fragment if the
+// resolved URL has none.
+func serviceEndpointURL(endpoint, relativeRef, fragment string) (string, error) {
+	if relativeRef == "" && fragment == "" {
+		return endpoint, nil
+	}
+
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid service endpoint %q: %w", endpoint, err)
+	}
+
+	ref, err := url.Parse(relativeRef)
+	if err != nil {
+		return "", fmt.Errorf("invalid relativeRef %q: %w", relativeRef, err)
+	}
+
+	resolved := base.ResolveReference(ref)
+	if resolved.Fragment == "" {
+		resolved.Fragment = fragment
+	}
+
+	return resolved.String(), nil
+}
+
+// lookupVerificationMethodByID returns the verification method with the fragment ID from the verification methods
+// of the document and the ones embedded in its verification relationships.
+func lookupVerificationMethodByID(doc *Doc, fragment string) (*VerificationMethod, bool) {
+	for i := range doc.VerificationMethod {
+		if matchesFragment(doc.ID, doc.VerificationMethod[i].ID, fragment) {
+			return &doc.VerificationMethod[i], true
+		}
+	}
+
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			vm := verifications[i].VerificationMethod
+
+			if verifications[i].Embedded && matchesFragment(doc.ID, vm.ID, fragment) {
+				return &vm, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// lookupServiceByID returns the service with the ID, the ID being a fragment or an absolute DID URL.
+func lookupServiceByID(doc *Doc, id string) (*Service, bool) {
+	fragment := strings.TrimPrefix(id, "#")
+	if strings.HasPrefix(id, doc.ID+"#") {
+		fragment = strings.TrimPrefix(id, doc.ID+"#")
+	}
+
+	for i := range doc.Service {
+		if doc.Service[i].ID == id || matchesFragment(doc.ID, doc.Service[i].ID, fragment) {
+			return &doc.Service[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// matchesFragment reports whether the ID, absolute or relative to the DID, has the fragment.
+func matchesFragment(didID, id, fragment string) bool {
+	return id == "#"+fragment || id == didID+"#"+fragment
+}
+
+// verifyHashLink verifies the hl parameter, a multibase-encoded multihash of the JSON bytes of the DID document.
+func verifyHashLink(doc *Doc, hl string) error {
+	_, encoded, err := multibase.Decode(hl)
+	if err != nil {
+		return fmt.Errorf("invalid hl parameter: %w", err)
+	}
+
+	decoded, err := multihash.Decode(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid hl parameter: %w", err)
+	}
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("marshal DID document: %w", err)
+	}
+
+	digest, err := multihash.Sum(docBytes, decoded.Code, decoded.Length)
+	if err != nil {
+		return fmt.Errorf("hash DID document: %w", err)
+	}
+
+	if !bytes.Equal(digest, encoded) {
+		return errors.New("DID document doesn't match the hl parameter")
+	}
+
+	return nil
+}
+
+func cut(s, sep string) (string, string) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):]
+	}
+
+	return s, ""
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+)
+
+const dereferenceDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "verificationMethod": [{
+    "id": "did:example:123#key-1",
+    "type": "Ed25519VerificationKey2018",
+    "controller": "did:example:123",
+    "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+  }],
+  "authentication": [{
+    "id": "#key-2",
+    "type": "Ed25519VerificationKey2018",
+    "controller": "did:example:123",
+    "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+  }],
+  "service": [{
+    "id": "#messaging",
+    "type": "DIDCommMessaging",
+    "serviceEndpoint": "https://example.com/messages/8377464"
+  }]
+}`
+
+func TestParseDIDURL(t *testing.T) {
+	t.Run("parse DID URL", func(t *testing.T) {
+		u, err := ParseDIDURL("did:example:123/path?service=agent&relativeRef=%2Fcredentials%3Fa%3D1#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", u.DID.String())
+		require.Equal(t, "/path", u.Path)
+		require.Equal(t, "agent", u.Queries.Get(ServiceParam))
+		require.Equal(t, "/credentials?a=1", u.Queries.Get(RelativeRefParam))
+		require.Equal(t, "key-1", u.Fragment)
+		require.Empty(t, u.DIDParams())
+
+		u, err = ParseDIDURL("did:example:123?versionId=1&versionTime=2021-05-10T17:00:00Z&hl=zQm")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			VersionIDParam:   "1",
+			VersionTimeParam: "2021-05-10T17:00:00Z",
+		}, u.DIDParams())
+	})
+
+	t.Run("invalid DID URL", func(t *testing.T) {
+		_, err := ParseDIDURL("example:123#key-1")
+		require.Contains(t, err.Error(), "invalid did")
+
+		_, err = ParseDIDURL("did:example:123?service=%zz")
+		require.Contains(t, err.Error(), "invalid DID URL query")
+
+		_, err = ParseDIDURL("did:example:123#%zz")
+		require.Contains(t, err.Error(), "invalid DID URL fragment")
+	})
+}
+
+func TestDereferencer_Dereference(t *testing.T) {
+	doc, err := ParseDocument([]byte(dereferenceDoc))
+	require.NoError(t, err)
+
+	var resolvedParams map[string]string
+
+	d := NewDereferencer(func(did string, params map[string]string) (*DocResolution, error) {
+		require.Equal(t, "did:example:123", did)
+
+		resolvedParams = params
+
+		return &DocResolution{DIDDocument: doc}, nil
+	})
+
+	t.Run("dereference DID document", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123?versionId=2")
+		require.NoError(t, err)
+		require.Equal(t, doc, result.DocResolution.DIDDocument)
+		require.Nil(t, result.VerificationMethod)
+		require.Nil(t, result.Service)
+		require.Equal(t, map[string]string{VersionIDParam: "2"}, resolvedParams)
+	})
+
+	t.Run("dereference verification method", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-1", result.VerificationMethod.ID)
+
+		// embedded in a verification relationship
+		result, err = d.Dereference("did:example:123#key-2")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-2", result.VerificationMethod.ID)
+	})
+
+	t.Run("dereference service", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123#messaging")
+		require.NoError(t, err)
+		require.Equal(t, "DIDCommMessaging", result.Service.Type)
+		require.Equal(t, "https://example.com/messages/8377464", result.ServiceEndpoint)
+
+		result, err = d.Dereference("did:example:123?service=messaging")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/messages/8377464", result.ServiceEndpoint)
+
+		result, err = d.Dereference("did:example:123?service=messaging&relativeRef=" +
+			url.QueryEscape("/some/path?query") + "#frag")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/some/path?query#frag", result.ServiceEndpoint)
+
+		result, err = d.Dereference("did:example:123?service=" + url.QueryEscape("did:example:123#messaging"))
+		require.NoError(t, err)
+		require.Equal(t, "DIDCommMessaging", result.Service.Type)
+	})
+
+	t.Run("resource not found", func(t *testing.T) {
+		_, err := d.Dereference("did:example:123#key-3")
+		require.True(t, errors.Is(err, ErrResourceNotFound))
+
+		_, err = d.Dereference("did:example:123?service=agent")
+		require.True(t, errors.Is(err, ErrResourceNotFound))
+	})
+
+	t.Run("hash link", func(t *testing.T) {
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		hash, err := multihash.Sum(docBytes, multihash.SHA2_256, -1)
+		require.NoError(t, err)
+
+		hl, err := multibase.Encode(multibase.Base58BTC, hash)
+		require.NoError(t, err)
+
+		result, err := d.Dereference("did:example:123?hl=" + hl + "#key-1")
+		require.NoError(t, err)
+		require.NotNil(t, result.VerificationMethod)
+
+		hash, err = multihash.Sum([]byte("other document"), multihash.SHA2_256, -1)
+		require.NoError(t, err)
+
+		hl, err = multibase.Encode(multibase.Base58BTC, hash)
+		require.NoError(t, err)
+
+		_, err = d.Dereference("did:example:123?hl=" + hl)
+		require.Contains(t, err.Error(), "DID document doesn't match the hl parameter")
+
+		_, err = d.Dereference("did:example:123?hl=invalid")
+		require.Contains(t, err.Error(), "invalid hl parameter")
+	})
+
+	t.Run("dereference errors", func(t *testing.T) {
+		_, err := d.Dereference("did:example:123/path")
+		require.Contains(t, err.Error(), "DID URL paths are not supported")
+
+		_, err = d.Dereference("did:example")
+		require.Contains(t, err.Error(), "invalid did")
+
+		_, err = d.Dereference("did:example:123?service=messaging&relativeRef=%25zz")
+		require.Contains(t, err.Error(), "invalid relativeRef")
+
+		_, err = NewDereferencer(func(string, map[string]string) (*DocResolution, error) {
+			return nil, errors.New("resolve error")
+		}).Dereference("did:example:123")
+		require.EqualError(t, err, "dereference did:example:123: resolve DID: resolve error")
+
+		_, err = NewDereferencer(func(string, map[string]string) (*DocResolution, error) {
+			return &DocResolution{}, nil
+		}).Dereference("did:example:123")
+		require.True(t, errors.Is(err, ErrDIDDocumentNotExist))
+	})
+}
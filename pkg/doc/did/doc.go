@@ -7,6 +7,7 @@ package did
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/multiformats/go-multibase"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
@@ -37,6 +39,8 @@ const (
 	jsonldServicePoint  = "serviceEndpoint"
 	jsonldRecipientKeys = "recipientKeys"
 	jsonldRoutingKeys   = "routingKeys"
+	jsonldAccept        = "accept"
+	jsonldURI           = "uri"
 	jsonldPriority      = "priority"
 	jsonldController    = "controller"
 	jsonldOwner         = "owner"
@@ -54,6 +58,13 @@ const (
 	jsonldPublicKeyHex    = "publicKeyHex"
 	jsonldPublicKeyPem    = "publicKeyPem"
 	jsonldPublicKeyjwk    = "publicKeyJwk"
+
+	jsonldPublicKeyMultibase = "publicKeyMultibase"
+
+	// ed25519VerificationKey2020 keys are encoded as "publicKeyMultibase".
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	// ed25519PubKeyMultiCodec is the multicodec code of Ed25519 public keys.
+	ed25519PubKeyMultiCodec = 0xed
 )
 
 var (
@@ -250,6 +261,10 @@ func (pk *VerificationMethod) JSONWebKey() *jwk.JWK {
 }
 
 // Service DID doc service.
+//
+// The service endpoint is serialized in the DIDComm v2 object form
+// {"uri": ServiceEndpoint, "accept": Accept, "routingKeys": RoutingKeys} if EndpointObject is set, which it is when
+// the service is parsed from that form, and as a string with the accept and routing keys set on the service otherwise.
 type Service struct {
 	ID                       string                 `json:"id"`
 	Type                     string                 `json:"type"`
@@ -259,11 +274,67 @@ type Service struct {
 	ServiceEndpoint          string                 `json:"serviceEndpoint"`
 	Accept                   []string               `json:"accept,omitempty"`
 	Properties               map[string]interface{} `json:"properties,omitempty"`
+	EndpointObject           bool                   `json:"-"`
 	recipientKeysRelativeURL map[string]bool
 	routingKeysRelativeURL   map[string]bool
 	relativeURL              bool
 }
 
+// MarshalJSON marshals the service as a standalone service block, e.g. in an out-of-band invitation, with the
+// service endpoint in the DIDComm v2 object form if EndpointObject is set.
+func (s Service) MarshalJSON() ([]byte, error) { //nolint:gocritic
+	type service Service
+
+	if !s.EndpointObject {
+		return json.Marshal(service(s))
+	}
+
+	return json.Marshal(struct {
+		service
+		ServiceEndpoint serviceEndpoint `json:"serviceEndpoint"`
+		RoutingKeys     []string        `json:"routingKeys,omitempty"`
+		Accept          []string        `json:"accept,omitempty"`
+	}{
+		service: service(s),
+		ServiceEndpoint: serviceEndpoint{
+			URI:         s.ServiceEndpoint,
+			Accept:      s.Accept,
+			RoutingKeys: s.RoutingKeys,
+		},
+	})
+}
+
+// UnmarshalJSON unmarshals a standalone service block, accepting the service endpoint in the legacy string form,
+// in the DIDComm v2 object form or as an array of those.
+func (s *Service) UnmarshalJSON(data []byte) error {
+	type service Service
+
+	raw := struct {
+		*service
+		ServiceEndpoint interface{} `json:"serviceEndpoint"`
+	}{service: (*service)(s)}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	endpoint, isObject := parseServiceEndpoint(raw.ServiceEndpoint)
+
+	s.ServiceEndpoint = endpoint.URI
+	s.EndpointObject = isObject
+
+	if len(endpoint.RoutingKeys) > 0 {
+		s.RoutingKeys = endpoint.RoutingKeys
+	}
+
+	if len(endpoint.Accept) > 0 {
+		s.Accept = endpoint.Accept
+	}
+
+	return nil
+}
+
 // VerificationRelationship defines a verification relationship between DID subject and a verification method.
 type VerificationRelationship int
 
@@ -531,7 +602,17 @@ func populateServices(didID, baseURI string, rawServices []map[string]interface{
 	for _, rawService := range rawServices {
 		id := stringEntry(rawService[jsonldID])
 		recipientKeys := stringArray(rawService[jsonldRecipientKeys])
-		routingKeys := stringArray(rawService[jsonldRoutingKeys])
+		endpoint, isObject := parseServiceEndpoint(rawService[jsonldServicePoint])
+
+		routingKeys := endpoint.RoutingKeys
+		if len(routingKeys) == 0 {
+			routingKeys = stringArray(rawService[jsonldRoutingKeys])
+		}
+
+		accept := endpoint.Accept
+		if len(accept) == 0 {
+			accept = stringArray(rawService[jsonldAccept])
+		}
 
 		var recipientKeysRelativeURL map[string]bool
 
@@ -554,7 +635,7 @@ func populateServices(didID, baseURI string, rawServices []map[string]interface{
 
 		service := Service{
 			ID: id, Type: stringEntry(rawService[jsonldType]), relativeURL: isRelative,
-			ServiceEndpoint: stringEntry(rawService[jsonldServicePoint]), RecipientKeys: recipientKeys,
+			ServiceEndpoint: endpoint.URI, RecipientKeys: recipientKeys, Accept: accept, EndpointObject: isObject,
 			RoutingKeys: routingKeys, Priority: uintEntry(rawService[jsonldPriority]),
 			recipientKeysRelativeURL: recipientKeysRelativeURL, routingKeysRelativeURL: routingKeysRelativeURL,
 		}
@@ -564,6 +645,7 @@ func populateServices(didID, baseURI string, rawServices []map[string]interface{
 		delete(rawService, jsonldServicePoint)
 		delete(rawService, jsonldRecipientKeys)
 		delete(rawService, jsonldRoutingKeys)
+		delete(rawService, jsonldAccept)
 		delete(rawService, jsonldPriority)
 
 		service.Properties = rawService
@@ -573,6 +655,36 @@ func populateServices(didID, baseURI string, rawServices []map[string]interface{
 	return services
 }
 
+// serviceEndpoint is the DIDComm v2 object form of a service endpoint.
+type serviceEndpoint struct {
+	URI         string   `json:"uri"`
+	Accept      []string `json:"accept,omitempty"`
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+}
+
+// parseServiceEndpoint parses a service endpoint given as a string (legacy form), as an object (DIDComm v2 form) or
+// as an array of those, in which case the first one is used. It returns whether the endpoint is in the object form.
+func parseServiceEndpoint(entry interface{}) (serviceEndpoint, bool) {
+	switch e := entry.(type) {
+	case string:
+		return serviceEndpoint{URI: e}, false
+	case map[string]interface{}:
+		uri, _ := e[jsonldURI].(string) //nolint:errcheck
+
+		return serviceEndpoint{
+			URI:         uri,
+			Accept:      stringArray(e[jsonldAccept]),
+			RoutingKeys: stringArray(e[jsonldRoutingKeys]),
+		}, true
+	case []interface{}:
+		if len(e) > 0 {
+			return parseServiceEndpoint(e[0])
+		}
+	}
+
+	return serviceEndpoint{}, false
+}
+
 func populateKeys(keys []string, didID, baseURI string) ([]string, map[string]bool) {
 	values := make([]string, 0)
 	keysRelativeURL := make(map[string]bool)
@@ -770,9 +882,41 @@ func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
 		return decodeVMJwk(jwkMap, vm)
 	}
 
+	if stringEntry(rawPK[jsonldPublicKeyMultibase]) != "" {
+		return decodeVMMultibase(stringEntry(rawPK[jsonldPublicKeyMultibase]), vm)
+	}
+
 	return errors.New("public key encoding not supported")
 }
 
+// decodeVMMultibase decodes the multibase encoded public key, prefixed by the multicodec code of the key type.
+func decodeVMMultibase(publicKeyMultibase string, vm *VerificationMethod) error {
+	_, value, err := multibase.Decode(publicKeyMultibase)
+	if err != nil {
+		return fmt.Errorf("decode public key multibase failed: %w", err)
+	}
+
+	_, n := binary.Uvarint(value)
+	if n <= 0 {
+		return errors.New("decode public key multibase failed: invalid multicodec prefix")
+	}
+
+	vm.Value = value[n:]
+
+	return nil
+}
+
+// encodeVMMultibase encodes the Ed25519 public key as multibase base58btc, prefixed by the Ed25519 multicodec code.
+func encodeVMMultibase(value []byte) string {
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, ed25519PubKeyMultiCodec)
+
+	// the base58btc encoding doesn't fail
+	s, _ := multibase.Encode(multibase.Base58BTC, append(prefix[:n], value...)) //nolint:errcheck
+
+	return s
+}
+
 func decodeVMJwk(jwkMap map[string]interface{}, vm *VerificationMethod) error {
 	jwkBytes, err := json.Marshal(jwkMap)
 	if err != nil {
@@ -1174,11 +1318,24 @@ func populateRawServices(services []Service, didID, baseURI string) []map[string
 		}
 
 		rawService[jsonldType] = services[i].Type
-		rawService[jsonldServicePoint] = services[i].ServiceEndpoint
 		rawService[jsonldRecipientKeys] = recipientKeys
-		rawService[jsonldRoutingKeys] = routingKeys
 		rawService[jsonldPriority] = services[i].Priority
 
+		if services[i].EndpointObject {
+			rawService[jsonldServicePoint] = serviceEndpoint{
+				URI:         services[i].ServiceEndpoint,
+				Accept:      services[i].Accept,
+				RoutingKeys: routingKeys,
+			}
+		} else {
+			rawService[jsonldServicePoint] = services[i].ServiceEndpoint
+			rawService[jsonldRoutingKeys] = routingKeys
+
+			if len(services[i].Accept) > 0 {
+				rawService[jsonldAccept] = services[i].Accept
+			}
+		}
+
 		rawServices = append(rawServices, rawService)
 	}
 
@@ -1227,6 +1384,8 @@ func populateRawVerificationMethod(context, didID, baseURI string,
 		}
 
 		rawVM[jsonldPublicKeyjwk] = json.RawMessage(jwkBytes)
+	} else if vm.Value != nil && vm.Type == ed25519VerificationKey2020 {
+		rawVM[jsonldPublicKeyMultibase] = encodeVMMultibase(vm.Value)
 	} else if vm.Value != nil {
 		rawVM[jsonldPublicKeyBase58] = base58.Encode(vm.Value)
 	}
@@ -479,10 +479,10 @@ func TestPublicKeys(t *testing.T) {
 
 			if len(raw.PublicKey) != 0 {
 				delete(raw.PublicKey[1], jsonldPublicKeyPem)
-				raw.PublicKey[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.PublicKey[1]["publicKeyGpg"] = wrongDataMsg
 			} else {
 				delete(raw.VerificationMethod[1], jsonldPublicKeyPem)
-				raw.VerificationMethod[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.VerificationMethod[1]["publicKeyGpg"] = wrongDataMsg
 			}
 
 			bytes, err := json.Marshal(raw)
@@ -769,6 +769,133 @@ func TestValidateDidDocService(t *testing.T) {
 	})
 }
 
+func TestServiceEndpoint(t *testing.T) {
+	const docWithServices = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "service": [
+    {
+      "id": "#didcomm-v2",
+      "type": "DIDCommMessaging",
+      "serviceEndpoint": {
+        "uri": "https://agent.example.com/v2",
+        "accept": ["didcomm/v2"],
+        "routingKeys": ["#key-1"]
+      }
+    },
+    {
+      "id": "#didcomm",
+      "type": "did-communication",
+      "serviceEndpoint": "https://agent.example.com/",
+      "recipientKeys": ["did:key:z6MkrX"],
+      "routingKeys": ["did:key:z6MkrY"],
+      "accept": ["didcomm/aip2;env=rfc19"]
+    },
+    {
+      "id": "#array",
+      "type": "DIDCommMessaging",
+      "serviceEndpoint": [{"uri": "https://mediator.example.com/"}, "https://other.example.com/"]
+    }
+  ]
+}`
+
+	t.Run("parse the object, string and array forms", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(docWithServices))
+		require.NoError(t, err)
+		require.Len(t, doc.Service, 3)
+
+		require.Equal(t, "https://agent.example.com/v2", doc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{"didcomm/v2"}, doc.Service[0].Accept)
+		require.Equal(t, []string{"did:example:123#key-1"}, doc.Service[0].RoutingKeys)
+		require.True(t, doc.Service[0].EndpointObject)
+
+		require.Equal(t, "https://agent.example.com/", doc.Service[1].ServiceEndpoint)
+		require.Equal(t, []string{"didcomm/aip2;env=rfc19"}, doc.Service[1].Accept)
+		require.Equal(t, []string{"did:key:z6MkrY"}, doc.Service[1].RoutingKeys)
+		require.Empty(t, doc.Service[1].Properties)
+		require.False(t, doc.Service[1].EndpointObject)
+
+		require.Equal(t, "https://mediator.example.com/", doc.Service[2].ServiceEndpoint)
+		require.True(t, doc.Service[2].EndpointObject)
+	})
+
+	t.Run("serialize each service in the form it was parsed from", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(docWithServices))
+		require.NoError(t, err)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal(docBytes, raw))
+
+		require.Equal(t, map[string]interface{}{
+			"uri":         "https://agent.example.com/v2",
+			"accept":      []interface{}{"didcomm/v2"},
+			"routingKeys": []interface{}{"#key-1"},
+		}, raw.Service[0][jsonldServicePoint])
+		require.NotContains(t, raw.Service[0], jsonldRoutingKeys)
+		require.NotContains(t, raw.Service[0], jsonldAccept)
+
+		require.Equal(t, "https://agent.example.com/", raw.Service[1][jsonldServicePoint])
+		require.Equal(t, []interface{}{"didcomm/aip2;env=rfc19"}, raw.Service[1][jsonldAccept])
+
+		doc2, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, doc.Service[:2], doc2.Service[:2])
+	})
+
+	t.Run("invalid service endpoint", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(docWithServices), raw))
+
+		raw.Service[0][jsonldServicePoint] = map[string]interface{}{"accept": []string{"didcomm/v2"}}
+
+		docBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = ParseDocument(docBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "uri is required")
+	})
+
+	t.Run("standalone service block", func(t *testing.T) {
+		svc := Service{
+			ID:              "service-1",
+			Type:            "DIDCommMessaging",
+			RecipientKeys:   []string{"did:key:z6MkrX"},
+			RoutingKeys:     []string{"did:key:z6MkrY"},
+			Accept:          []string{"didcomm/v2"},
+			ServiceEndpoint: "https://agent.example.com/",
+			EndpointObject:  true,
+		}
+
+		svcBytes, err := json.Marshal(svc)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"service-1","type":"DIDCommMessaging","recipientKeys":["did:key:z6MkrX"],`+
+			`"serviceEndpoint":{"uri":"https://agent.example.com/","accept":["didcomm/v2"],`+
+			`"routingKeys":["did:key:z6MkrY"]}}`, string(svcBytes))
+
+		var parsed Service
+		require.NoError(t, json.Unmarshal(svcBytes, &parsed))
+		require.Equal(t, svc, parsed)
+
+		svc.EndpointObject = false
+
+		svcBytes, err = json.Marshal(&svc)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"service-1","type":"DIDCommMessaging","recipientKeys":["did:key:z6MkrX"],`+
+			`"routingKeys":["did:key:z6MkrY"],"accept":["didcomm/v2"],`+
+			`"serviceEndpoint":"https://agent.example.com/"}`, string(svcBytes))
+
+		parsed = Service{}
+		require.NoError(t, json.Unmarshal(svcBytes, &parsed))
+		require.Equal(t, svc, parsed)
+
+		require.Error(t, json.Unmarshal([]byte(`{"id":1}`), &parsed))
+	})
+}
+
 func TestValidateDidDocCreated(t *testing.T) {
 	t.Run("test did doc with empty created", func(t *testing.T) {
 		docs := []string{validDoc, validDocV011}
@@ -1098,6 +1225,48 @@ func TestJSONWebKey(t *testing.T) {
 	require.Equal(t, didDocBytes, parsedDidDocBytes)
 }
 
+func TestPublicKeyMultibase(t *testing.T) {
+	const didContext = "https://www.w3.org/ns/did/v1"
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	vm := NewVerificationMethodFromBytes(creator, "Ed25519VerificationKey2020", did, pubKey)
+
+	didDoc := &Doc{
+		Context:            []string{didContext},
+		ID:                 did,
+		VerificationMethod: []VerificationMethod{*vm},
+	}
+
+	didDocBytes, err := didDoc.JSONBytes()
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(didDocBytes, &raw))
+
+	rawVM, ok := raw["verificationMethod"].([]interface{})[0].(map[string]interface{})
+	require.True(t, ok)
+	// Ed25519 multicodec code and public key encoded as base58btc
+	require.Equal(t, "z"+base58.Encode(append([]byte{0xed, 0x01}, pubKey...)), rawVM["publicKeyMultibase"])
+	require.NotContains(t, rawVM, "publicKeyBase58")
+
+	parsedDidDoc, err := ParseDocument(didDocBytes)
+	require.NoError(t, err)
+	require.Equal(t, []byte(pubKey), parsedDidDoc.VerificationMethod[0].Value)
+
+	t.Run("invalid multibase", func(t *testing.T) {
+		rawVM["publicKeyMultibase"] = "invalid"
+
+		invalidDocBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = ParseDocument(invalidDocBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode public key multibase failed")
+	})
+}
+
 func TestVerifyProof(t *testing.T) {
 	docs := []string{validDoc, validDocV011}
 	for _, d := range docs {
@@ -150,8 +150,40 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "type": "object",
+              "required": [
+                "uri"
+              ],
+              "properties": {
+                "uri": {
+                  "type": "string",
+                  "format": "uri"
+                },
+                "accept": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "routingKeys": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                }
+              }
+            },
+            {
+              "type": "array",
+              "minItems": 1
+            }
+          ]
         }
       }
     }
@@ -299,8 +331,40 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "type": "object",
+              "required": [
+                "uri"
+              ],
+              "properties": {
+                "uri": {
+                  "type": "string",
+                  "format": "uri"
+                },
+                "accept": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "routingKeys": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                }
+              }
+            },
+            {
+              "type": "array",
+              "minItems": 1
+            }
+          ]
         }
       }
     }
@@ -418,8 +482,40 @@ const (
           "type": "string"
         },
         "serviceEndpoint": {
-          "type": "string",
-          "format": "uri"
+          "oneOf": [
+            {
+              "type": "string",
+              "format": "uri"
+            },
+            {
+              "type": "object",
+              "required": [
+                "uri"
+              ],
+              "properties": {
+                "uri": {
+                  "type": "string",
+                  "format": "uri"
+                },
+                "accept": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "routingKeys": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                }
+              }
+            },
+            {
+              "type": "array",
+              "minItems": 1
+            }
+          ]
         }
       }
     }
@@ -372,3 +372,75 @@ func TestJWKMarshalEPKFailure(t *testing.T) {
 		require.EqualError(t, err, "unable to read JWK: invalid character 'b' looking for beginning of value")
 	})
 }
+
+// batchWrapCrypto wraps keys for all the recipients with a single WrapKeys call.
+type batchWrapCrypto struct {
+	cryptoapi.Crypto
+	wrapKeysCalls int
+	wrapKeysErr   error
+}
+
+func (c *batchWrapCrypto) WrapKeys(cek, apu, apv []byte, recPubKeys []*cryptoapi.PublicKey,
+	opts ...cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, error) {
+	c.wrapKeysCalls++
+
+	if c.wrapKeysErr != nil {
+		return nil, c.wrapKeysErr
+	}
+
+	keks := make([]*cryptoapi.RecipientWrappedKey, len(recPubKeys))
+
+	for i, recPubKey := range recPubKeys {
+		kek, err := c.WrapKey(cek, apu, apv, recPubKey, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		keks[i] = kek
+	}
+
+	return keks, nil
+}
+
+func TestJWEEncryptWithBatchWrapper(t *testing.T) {
+	tc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	c := &batchWrapCrypto{Crypto: tc}
+
+	t.Run("recipients keys wrapped at once", func(t *testing.T) {
+		recipients, _ := createRecipients(t, 3)
+
+		enc, err := NewJWEEncrypt(A256GCM, testEncType, testPayloadType, "", nil, recipients, c)
+		require.NoError(t, err)
+
+		jwe, err := enc.Encrypt([]byte("test"))
+		require.NoError(t, err)
+		require.Len(t, jwe.Recipients, 3)
+		require.Equal(t, 1, c.wrapKeysCalls)
+	})
+
+	t.Run("single recipient key wrapped with WrapKey", func(t *testing.T) {
+		c.wrapKeysCalls = 0
+		recipients, _ := createRecipients(t, 1)
+
+		enc, err := NewJWEEncrypt(A256GCM, testEncType, testPayloadType, "", nil, recipients, c)
+		require.NoError(t, err)
+
+		_, err = enc.Encrypt([]byte("test"))
+		require.NoError(t, err)
+		require.Zero(t, c.wrapKeysCalls)
+	})
+
+	t.Run("batch wrap error", func(t *testing.T) {
+		c.wrapKeysErr = fmt.Errorf("wrap error")
+		recipients, _ := createRecipients(t, 2)
+
+		enc, err := NewJWEEncrypt(A256GCM, testEncType, testPayloadType, "", nil, recipients, c)
+		require.NoError(t, err)
+
+		_, err = enc.Encrypt([]byte("test"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrapKey: batch failed: wrap error")
+	})
+}
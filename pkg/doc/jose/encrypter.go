@@ -306,22 +306,12 @@ func (je *JWEEncrypt) wrapKey(cek, apu, apv, aad []byte, wrapOpts []cryptoapi.Wr
 		singleRecipientAAD []byte
 	)
 
-	for i, recPubKey := range je.recipientsKeys {
-		var (
-			kek *cryptoapi.RecipientWrappedKey
-			err error
-		)
-
-		if len(wrapOpts) > 0 {
-			kek, err = je.crypto.WrapKey(cek, apu, apv, recPubKey, wrapOpts...)
-		} else {
-			kek, err = je.crypto.WrapKey(cek, apu, apv, recPubKey)
-		}
-
-		if err != nil {
-			return nil, nil, fmt.Errorf("wrapKey: %d failed: %w", i+1, err)
-		}
+	keks, err := je.wrapKeys(cek, apu, apv, wrapOpts)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	for i, kek := range keks {
 		je.encodeAPUAPV(kek)
 
 		recipientsWK = append(recipientsWK, kek)
@@ -337,6 +327,37 @@ func (je *JWEEncrypt) wrapKey(cek, apu, apv, aad []byte, wrapOpts []cryptoapi.Wr
 	return recipientsWK, singleRecipientAAD, nil
 }
 
+// wrapKeys wraps cek for all the recipients, at once if the crypto service supports it.
+func (je *JWEEncrypt) wrapKeys(cek, apu, apv []byte,
+	wrapOpts []cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, error) {
+	if batchWrapper, ok := je.crypto.(cryptoapi.BatchWrapper); ok && len(je.recipientsKeys) > 1 {
+		keks, err := batchWrapper.WrapKeys(cek, apu, apv, je.recipientsKeys, wrapOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("wrapKey: batch failed: %w", err)
+		}
+
+		return keks, nil
+	}
+
+	keks := make([]*cryptoapi.RecipientWrappedKey, len(je.recipientsKeys))
+
+	for i, recPubKey := range je.recipientsKeys {
+		var err error
+
+		if len(wrapOpts) > 0 {
+			keks[i], err = je.crypto.WrapKey(cek, apu, apv, recPubKey, wrapOpts...)
+		} else {
+			keks[i], err = je.crypto.WrapKey(cek, apu, apv, recPubKey)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("wrapKey: %d failed: %w", i+1, err)
+		}
+	}
+
+	return keks, nil
+}
+
 func (je *JWEEncrypt) encodeAPUAPV(kek *cryptoapi.RecipientWrappedKey) {
 	// APU and APV must be base64URL encoded.
 	if len(kek.APU) > 0 {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rsa"
@@ -190,6 +191,76 @@ func (j *JWK) KeyType() (kms.KeyType, error) {
 	}
 }
 
+// Thumbprint computes the RFC 7638 thumbprint of the JWK using hash. It overrides the go-jose thumbprint to support
+// the OKP (Ed25519, X25519), secp256k1 and BLS12381G2 keys, the thumbprint being computed from the required members
+// of the JSON representation of the public key.
+func (j *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, errors.New("thumbprint: hash function not available")
+	}
+
+	jwkBytes, err := j.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("thumbprint: failed to marshal jwk: %w", err)
+	}
+
+	var members map[string]interface{}
+
+	err = json.Unmarshal(jwkBytes, &members)
+	if err != nil {
+		return nil, fmt.Errorf("thumbprint: failed to unmarshal jwk: %w", err)
+	}
+
+	kty, _ := members["kty"].(string) // nolint:errcheck
+	crv, _ := members["crv"].(string) // nolint:errcheck
+
+	required, err := thumbprintMembers(kty, crv)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbprintJWK := make(map[string]interface{}, len(required))
+
+	for _, name := range required {
+		value, ok := members[name]
+		if !ok {
+			return nil, fmt.Errorf("thumbprint: missing '%s' member in jwk", name)
+		}
+
+		thumbprintJWK[name] = value
+	}
+
+	// json.Marshal sorts the members lexicographically and adds no whitespace, as required by RFC 7638.
+	thumbprintBytes, err := json.Marshal(thumbprintJWK)
+	if err != nil {
+		return nil, fmt.Errorf("thumbprint: failed to marshal required members: %w", err)
+	}
+
+	h := hash.New()
+	_, _ = h.Write(thumbprintBytes) // hash.Hash Write() never returns an error
+
+	return h.Sum(nil), nil
+}
+
+// thumbprintMembers returns the required members of a JWK of type kty (RFC 7638 section 3.2), BLS12381G2 keys
+// having no 'y' coordinate.
+func thumbprintMembers(kty, crv string) ([]string, error) {
+	switch {
+	case isBLS12381G2(kty, crv):
+		return []string{"crv", "kty", "x"}, nil
+	case kty == ecKty:
+		return []string{"crv", "kty", "x", "y"}, nil
+	case kty == okpKty:
+		return []string{"crv", "kty", "x"}, nil
+	case kty == "RSA":
+		return []string{"e", "kty", "n"}, nil
+	case kty == "oct":
+		return []string{"k", "kty"}, nil
+	default:
+		return nil, fmt.Errorf("thumbprint: unsupported key type '%s'", kty)
+	}
+}
+
 func ecdsaPubKeyType(pub *ecdsa.PublicKey) (kms.KeyType, error) {
 	switch pub.Curve {
 	case btcec.S256():
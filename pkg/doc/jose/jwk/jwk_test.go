@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
@@ -557,3 +559,76 @@ func TestJWK_KeyType(t *testing.T) {
 		require.Equal(t, kms.KeyType(""), kt)
 	})
 }
+
+func TestJWK_Thumbprint(t *testing.T) {
+	t.Run("RFC 7638 example", func(t *testing.T) {
+		//nolint:lll
+		rsaJWK := `{
+			"kty": "RSA",
+			"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			"e": "AQAB",
+			"alg": "RS256",
+			"kid": "2011-04-29"
+		}`
+
+		j := &JWK{}
+		require.NoError(t, json.Unmarshal([]byte(rsaJWK), j))
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", base64.RawURLEncoding.EncodeToString(tp))
+	})
+
+	t.Run("OKP keys", func(t *testing.T) {
+		const x = "sEHL6KXs8bUz9Ss2qSWWjhhRMHVjrog0lzFENM132R8"
+
+		for _, crv := range []string{"Ed25519", "X25519"} {
+			j := &JWK{}
+			require.NoError(t, json.Unmarshal([]byte(`{"kty":"OKP","crv":"`+crv+`","x":"`+x+`","kid":"k1"}`), j))
+
+			tp, err := j.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+
+			expected := sha256.Sum256([]byte(`{"crv":"` + crv + `","kty":"OKP","x":"` + x + `"}`))
+			require.Equal(t, expected[:], tp)
+		}
+	})
+
+	t.Run("private and public keys have the same thumbprint", func(t *testing.T) {
+		secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, bbsKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		for _, keys := range [][2]interface{}{
+			{secp256k1Key, &secp256k1Key.PublicKey},
+			{p256Key, &p256Key.PublicKey},
+			{bbsKey, bbsKey.PublicKey()},
+		} {
+			privTP, err := (&JWK{JSONWebKey: jose.JSONWebKey{Key: keys[0]}}).Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+
+			pubTP, err := (&JWK{JSONWebKey: jose.JSONWebKey{Key: keys[1]}}).Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+			require.Equal(t, privTP, pubTP)
+		}
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		j := &JWK{JSONWebKey: jose.JSONWebKey{Key: []byte("secret")}}
+
+		_, err := j.Thumbprint(crypto.Hash(0))
+		require.EqualError(t, err, "thumbprint: hash function not available")
+
+		_, err = (&JWK{}).Thumbprint(crypto.SHA256)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "thumbprint: failed to marshal jwk")
+
+		_, err = thumbprintMembers("unknown", "")
+		require.EqualError(t, err, "thumbprint: unsupported key type 'unknown'")
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -99,6 +100,13 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*jwk.JWK, error) { //
 		x, y := elliptic.Unmarshal(crv, bytes)
 
 		return JWKFromKey(&ecdsa.PublicKey{Curve: crv, X: x, Y: y})
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(bytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(pubKey.ToECDSA())
 	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER:
 		pubKey, err := x509.ParsePKIXPublicKey(bytes)
 		if err != nil {
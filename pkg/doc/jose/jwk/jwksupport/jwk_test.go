@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
@@ -349,6 +350,10 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 			name:    "P-521 IEEE1363 test",
 			keyType: kms.ECDSAP521TypeIEEEP1363,
 		},
+		{
+			name:    "secp256k1 IEEE1363 test",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+		},
 		{
 			name:    "P-256 DER test",
 			keyType: kms.ECDSAP256TypeDER,
@@ -433,6 +438,22 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 				require.NotEmpty(t, jwkKey)
 				require.Equal(t, "EC", jwkKey.Kty)
 				require.Equal(t, crv.Params().Name, jwkKey.Crv)
+			case kms.ECDSASecp256k1TypeIEEEP1363:
+				privKey, err := btcec.NewPrivateKey(btcec.S256())
+				require.NoError(t, err)
+
+				for _, keyBytes := range [][]byte{
+					privKey.PubKey().SerializeUncompressed(), privKey.PubKey().SerializeCompressed(),
+				} {
+					jwkKey, err := PubKeyBytesToJWK(keyBytes, tc.keyType)
+					require.NoError(t, err)
+					require.Equal(t, "EC", jwkKey.Kty)
+					require.Equal(t, "secp256k1", jwkKey.Crv)
+					require.Equal(t, privKey.PubKey().ToECDSA(), jwkKey.Key)
+				}
+
+				_, err = PubKeyBytesToJWK([]byte("invalid EC Key"), tc.keyType)
+				require.Error(t, err)
 			case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER:
 				crv := getECDSACurve(tc.keyType)
 				privKey, err := ecdsa.GenerateKey(crv, rand.Reader)
@@ -26,6 +26,9 @@ const (
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signatureES256K defines ES256K alg.
+	signatureES256K = "ES256K"
 )
 
 const issuerClaim = "iss"
@@ -64,6 +67,10 @@ func NewVerifier(resolver KeyResolver) *BasicVerifier {
 			Alg:      signatureRS256,
 			Verifier: getVerifier(resolver, VerifyRS256),
 		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES256K,
+			Verifier: getVerifier(resolver, VerifyES256K),
+		},
 	)
 	// TODO ECDSA to support NIST P256 curve
 	//  https://github.com/hyperledger/aries-framework-go/issues/1266
@@ -144,6 +151,11 @@ func VerifyRS256(pubKey *verifier.PublicKey, message, signature []byte) error {
 	return rsa.VerifyPKCS1v15(pubKeyRsa, crypto.SHA256, hashed, signature)
 }
 
+// VerifyES256K verifies ES256K (ECDSA secp256k1) signature.
+func VerifyES256K(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifier.NewECDSASecp256k1SignatureVerifier().Verify(pubKey, message, signature)
+}
+
 func getIssuerClaim(claims map[string]interface{}) (string, error) {
 	v, ok := claims[issuerClaim]
 	if !ok {
@@ -8,13 +8,17 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"errors"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 
@@ -182,3 +186,33 @@ func TestVerifyRS256(t *testing.T) {
 	}, []byte("test message"), signature)
 	r.Error(err)
 }
+
+func TestVerifyES256K(t *testing.T) {
+	r := require.New(t)
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	r.NoError(err)
+
+	hashed := sha256.Sum256([]byte("test message"))
+
+	sigR, sigS, err := ecdsa.Sign(rand.Reader, privKey, hashed[:])
+	r.NoError(err)
+
+	signature := make([]byte, 64)
+	sigR.FillBytes(signature[:32])
+	sigS.FillBytes(signature[32:])
+
+	pubKey := &verifier.PublicKey{
+		Type:  kms.ECDSASecp256k1IEEEP1363,
+		Value: elliptic.Marshal(btcec.S256(), privKey.X, privKey.Y), // nolint: staticcheck
+	}
+
+	err = VerifyES256K(pubKey, []byte("test message"), signature)
+	r.NoError(err)
+
+	err = VerifyES256K(pubKey, []byte("another message"), signature)
+	r.EqualError(err, "ecdsa: invalid signature")
+
+	err = VerifyES256K(pubKey, []byte("test message"), signature[1:])
+	r.EqualError(err, "ecdsa: invalid signature size")
+}
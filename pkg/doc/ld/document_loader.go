@@ -9,6 +9,7 @@ package ld
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
 
@@ -29,8 +30,9 @@ type provider interface {
 
 // DocumentLoader is an implementation of ld.DocumentLoader backed by storage.
 type DocumentLoader struct {
-	store                ld.ContextStore
-	remoteDocumentLoader jsonld.DocumentLoader
+	store       ld.ContextStore
+	storeLoader *chainedLoader
+	loaders     []*chainedLoader
 }
 
 // NewDocumentLoader returns a new DocumentLoader instance.
@@ -41,6 +43,7 @@ type DocumentLoader struct {
 //
 // By default, missing contexts are not fetched from the remote URL. Use WithRemoteDocumentLoader() option
 // to specify a custom loader that can resolve context documents from the network.
+// Use WithLoader() options for chaining more loaders resolving the contexts missing from the storage.
 func NewDocumentLoader(ctx provider, opts ...DocumentLoaderOpts) (*DocumentLoader, error) {
 	loaderOpts := &documentLoaderOpts{}
 
@@ -59,9 +62,20 @@ func NewDocumentLoader(ctx provider, opts ...DocumentLoaderOpts) (*DocumentLoade
 		return nil, fmt.Errorf("import contexts: %w", err)
 	}
 
+	loaders := loaderOpts.loaders
+
+	if loaderOpts.remoteDocumentLoader != nil {
+		loaders = append(loaders, &chainedLoader{
+			name:   remoteLoaderName,
+			loader: loaderOpts.remoteDocumentLoader,
+			policy: Stop,
+		})
+	}
+
 	return &DocumentLoader{
-		store:                store,
-		remoteDocumentLoader: loaderOpts.remoteDocumentLoader,
+		store:       store,
+		storeLoader: &chainedLoader{name: storeLoaderName},
+		loaders:     loaders,
 	}, nil
 }
 
@@ -96,42 +110,67 @@ func prepareContexts(providerStore ld.RemoteProviderStore, opts *documentLoaderO
 	return contexts, nil
 }
 
-// LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from remote URL.
-// If document is not found in the storage and remote DocumentLoader is not specified, ErrContextNotFound is returned.
+// LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from the chained loaders
+// (including the remote URL). If document is not found in the storage and by the chained loaders,
+// ErrContextNotFound is returned.
 func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	start := time.Now()
+
 	rd, err := l.store.Get(u)
+
+	l.storeLoader.record(start, err)
+
 	if err != nil {
 		if !errors.Is(err, storage.ErrDataNotFound) {
 			return nil, fmt.Errorf("load document: %w", err)
 		}
 
-		if l.remoteDocumentLoader == nil { // fetching from the remote URL is disabled
-			return nil, ErrContextNotFound
-		}
-
-		return l.loadDocumentFromURL(u)
+		return l.loadDocumentFromChain(u)
 	}
 
 	return rd, nil
 }
 
-func (l *DocumentLoader) loadDocumentFromURL(u string) (*jsonld.RemoteDocument, error) {
-	rd, err := l.remoteDocumentLoader.LoadDocument(u)
-	if err != nil {
-		return nil, fmt.Errorf("load remote context document: %w", err)
+func (l *DocumentLoader) loadDocumentFromChain(u string) (*jsonld.RemoteDocument, error) {
+	var lastErr error
+
+	for _, loader := range l.loaders {
+		rd, err := loader.load(u)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+
+			if loader.policy == Stop {
+				return nil, fmt.Errorf("load %s context document: %w", loader.name, err)
+			}
+
+			lastErr = fmt.Errorf("load %s context document: %w", loader.name, err)
+
+			continue
+		}
+
+		if !loader.noCache {
+			if err = l.store.Put(u, rd); err != nil {
+				return nil, fmt.Errorf("save loaded document: %w", err)
+			}
+		}
+
+		return rd, nil
 	}
 
-	if err = l.store.Put(u, rd); err != nil {
-		return nil, fmt.Errorf("save loaded document: %w", err)
+	if lastErr != nil {
+		return nil, fmt.Errorf("%w: %s", ErrContextNotFound, lastErr.Error())
 	}
 
-	return rd, nil
+	return nil, ErrContextNotFound
 }
 
 type documentLoaderOpts struct {
 	remoteDocumentLoader jsonld.DocumentLoader
 	extraContexts        []ldcontext.Document
 	remoteProviders      []RemoteProvider
+	loaders              []*chainedLoader
 }
 
 // DocumentLoaderOpts configures DocumentLoader during creation.
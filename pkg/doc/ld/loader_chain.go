@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	jsonld "github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	storeLoaderName  = "store"
+	remoteLoaderName = "remote"
+)
+
+// FailurePolicy tells how the DocumentLoader handles the errors of a loader of its chain.
+type FailurePolicy int
+
+const (
+	// Continue tries the next loaders of the chain when the loader fails.
+	Continue FailurePolicy = iota
+	// Stop returns the error of the loader without trying the next loaders of the chain.
+	Stop
+)
+
+// LoaderStats are the metrics of a loader of the DocumentLoader chain.
+type LoaderStats struct {
+	// Name of the loader.
+	Name string
+	// Hits is the number of documents resolved by the loader.
+	Hits uint64
+	// Misses is the number of documents the loader doesn't have.
+	Misses uint64
+	// Failures is the number of documents the loader failed to load.
+	Failures uint64
+	// Duration is the total time spent loading documents with the loader.
+	Duration time.Duration
+}
+
+// chainedLoader is a loader of the DocumentLoader chain.
+type chainedLoader struct {
+	// the counters are first for the 64-bit alignment required by the atomic operations.
+	hits     uint64
+	misses   uint64
+	failures uint64
+	duration int64
+	name     string
+	loader   jsonld.DocumentLoader
+	policy   FailurePolicy
+	noCache  bool
+}
+
+// LoaderOpts configures a loader of the DocumentLoader chain.
+type LoaderOpts func(l *chainedLoader)
+
+// WithFailurePolicy sets how the errors of the loader are handled (Continue by default).
+func WithFailurePolicy(policy FailurePolicy) LoaderOpts {
+	return func(l *chainedLoader) {
+		l.policy = policy
+	}
+}
+
+// WithoutCaching disables saving the documents resolved by the loader into the underlying storage.
+func WithoutCaching() LoaderOpts {
+	return func(l *chainedLoader) {
+		l.noCache = true
+	}
+}
+
+// WithLoader appends a loader to the chain resolving the documents missing from the underlying storage. The loaders
+// are tried in the order of the options, before the remote document loader set by WithRemoteDocumentLoader. A loader
+// returns ErrContextNotFound (or storage.ErrDataNotFound) for the documents it doesn't have, the next loader being
+// tried, and the documents it resolves are saved into the underlying storage unless WithoutCaching is set.
+func WithLoader(name string, loader jsonld.DocumentLoader, loaderOpts ...LoaderOpts) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		l := &chainedLoader{name: name, loader: loader}
+
+		for _, opt := range loaderOpts {
+			opt(l)
+		}
+
+		opts.loaders = append(opts.loaders, l)
+	}
+}
+
+// load resolves the document with the loader, recording the metrics of the loader.
+func (l *chainedLoader) load(u string) (*jsonld.RemoteDocument, error) {
+	start := time.Now()
+
+	rd, err := l.loader.LoadDocument(u)
+
+	l.record(start, err)
+
+	return rd, err
+}
+
+func (l *chainedLoader) record(start time.Time, err error) {
+	atomic.AddInt64(&l.duration, int64(time.Since(start)))
+
+	switch {
+	case err == nil:
+		atomic.AddUint64(&l.hits, 1)
+	case isNotFound(err):
+		atomic.AddUint64(&l.misses, 1)
+	default:
+		atomic.AddUint64(&l.failures, 1)
+	}
+}
+
+func (l *chainedLoader) stats() LoaderStats {
+	return LoaderStats{
+		Name:     l.name,
+		Hits:     atomic.LoadUint64(&l.hits),
+		Misses:   atomic.LoadUint64(&l.misses),
+		Failures: atomic.LoadUint64(&l.failures),
+		Duration: time.Duration(atomic.LoadInt64(&l.duration)),
+	}
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrContextNotFound) || errors.Is(err, storage.ErrDataNotFound)
+}
+
+// Stats returns the metrics of the loaders of the chain, in the order they are tried: the underlying storage
+// ("store"), the loaders set by WithLoader and the remote document loader ("remote").
+func (l *DocumentLoader) Stats() []LoaderStats {
+	stats := []LoaderStats{l.storeLoader.stats()}
+
+	for _, loader := range l.loaders {
+		stats = append(stats, loader.stats())
+	}
+
+	return stats
+}
+
+// allowlistLoader resolves the documents with allowed URLs.
+type allowlistLoader struct {
+	loader  jsonld.DocumentLoader
+	allowed []string
+}
+
+// NewAllowlistLoader returns a loader resolving with the given loader the documents whose URL starts with one of the
+// allowed prefixes, ErrContextNotFound being returned for the other documents.
+func NewAllowlistLoader(loader jsonld.DocumentLoader, allowed ...string) jsonld.DocumentLoader {
+	return &allowlistLoader{loader: loader, allowed: allowed}
+}
+
+// LoadDocument resolves the document if its URL is allowed.
+func (l *allowlistLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	for _, prefix := range l.allowed {
+		if strings.HasPrefix(u, prefix) {
+			return l.loader.LoadDocument(u)
+		}
+	}
+
+	return nil, ErrContextNotFound
+}
+
+// contextsLoader resolves documents from memory.
+type contextsLoader map[string]*jsonld.RemoteDocument
+
+// NewContextsLoader returns a loader resolving the given context documents from memory, ErrContextNotFound being
+// returned for the other documents.
+func NewContextsLoader(contexts ...ldcontext.Document) (jsonld.DocumentLoader, error) {
+	l := make(contextsLoader)
+
+	for _, c := range contexts {
+		document, err := jsonld.DocumentFromReader(strings.NewReader(string(c.Content)))
+		if err != nil {
+			return nil, fmt.Errorf("document from reader: %w", err)
+		}
+
+		l[c.URL] = &jsonld.RemoteDocument{DocumentURL: c.DocumentURL, Document: document}
+	}
+
+	return l, nil
+}
+
+// LoadDocument resolves the document from memory.
+func (l contextsLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	rd, ok := l[u]
+	if !ok {
+		return nil, ErrContextNotFound
+	}
+
+	return rd, nil
+}
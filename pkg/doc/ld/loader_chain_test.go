@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	mockldstore "github.com/hyperledger/aries-framework-go/pkg/mock/ld"
+)
+
+const (
+	bundledContextURL = "https://example.com/bundled.jsonld"
+	remoteContextURL  = "https://example.com/context.jsonld"
+)
+
+func TestLoaderChain(t *testing.T) {
+	bundled, err := ld.NewContextsLoader(ldcontext.Document{
+		URL:     bundledContextURL,
+		Content: []byte(sampleJSONLDContext),
+	})
+	require.NoError(t, err)
+
+	t.Run("Load documents from the chained loaders", func(t *testing.T) {
+		store := mockldstore.NewMockContextStore()
+
+		loader, err := ld.NewDocumentLoader(createMockProvider(withContextStore(store)),
+			ld.WithLoader("bundled", bundled, ld.WithoutCaching()),
+			ld.WithLoader("allowlist", ld.NewAllowlistLoader(&mockRemoteDocumentLoader{}, "https://example.com/")))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(bundledContextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+		require.Nil(t, store.Store.Store[bundledContextURL].Value)
+
+		rd, err = loader.LoadDocument(remoteContextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+		require.NotNil(t, store.Store.Store[remoteContextURL].Value)
+
+		// the remote document is loaded from the store once cached
+		_, err = loader.LoadDocument(remoteContextURL)
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://other.example.com/context.jsonld")
+		require.EqualError(t, err, ld.ErrContextNotFound.Error())
+
+		stats := loader.Stats()
+		require.Len(t, stats, 3)
+		require.Equal(t, ld.LoaderStats{Name: "store", Hits: 1, Misses: 3}, withoutDuration(stats[0]))
+		require.Equal(t, ld.LoaderStats{Name: "bundled", Hits: 1, Misses: 2}, withoutDuration(stats[1]))
+		require.Equal(t, ld.LoaderStats{Name: "allowlist", Hits: 1, Misses: 1}, withoutDuration(stats[2]))
+	})
+
+	t.Run("Continue with the next loader on failure", func(t *testing.T) {
+		loader, err := ld.NewDocumentLoader(createMockProvider(),
+			ld.WithLoader("failing", &mockRemoteDocumentLoader{ErrLoadDocument: errors.New("load error")}),
+			ld.WithLoader("bundled", bundled))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(bundledContextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		_, err = loader.LoadDocument(remoteContextURL)
+		require.True(t, errors.Is(err, ld.ErrContextNotFound))
+		require.Contains(t, err.Error(), "load failing context document: load error")
+
+		stats := loader.Stats()
+		require.Equal(t, ld.LoaderStats{Name: "failing", Failures: 2}, withoutDuration(stats[1]))
+	})
+
+	t.Run("Stop on failure", func(t *testing.T) {
+		loader, err := ld.NewDocumentLoader(createMockProvider(),
+			ld.WithLoader("failing", &mockRemoteDocumentLoader{ErrLoadDocument: errors.New("load error")},
+				ld.WithFailurePolicy(ld.Stop)),
+			ld.WithLoader("bundled", bundled))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(bundledContextURL)
+		require.EqualError(t, err, "load failing context document: load error")
+	})
+
+	t.Run("Remote document loader is the last loader of the chain", func(t *testing.T) {
+		loader, err := ld.NewDocumentLoader(createMockProvider(),
+			ld.WithLoader("bundled", bundled),
+			ld.WithRemoteDocumentLoader(&mockRemoteDocumentLoader{}))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(remoteContextURL)
+		require.NoError(t, err)
+
+		stats := loader.Stats()
+		require.Len(t, stats, 3)
+		require.Equal(t, ld.LoaderStats{Name: "bundled", Misses: 1}, withoutDuration(stats[1]))
+		require.Equal(t, ld.LoaderStats{Name: "remote", Hits: 1}, withoutDuration(stats[2]))
+	})
+
+	t.Run("Fail to create contexts loader", func(t *testing.T) {
+		_, err := ld.NewContextsLoader(ldcontext.Document{URL: bundledContextURL, Content: []byte("invalid")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document from reader")
+	})
+}
+
+func withoutDuration(stats ld.LoaderStats) ld.LoaderStats {
+	stats.Duration = 0
+
+	return stats
+}
@@ -36,6 +36,10 @@ var (
 	revocationList2020 []byte
 	//go:embed third_party/digitalbazaar.github.io/ed25519-signature-2018-v1.jsonld
 	ed255192018 []byte
+	//go:embed third_party/w3c-ccg.github.io/lds-ed25519-2020_v1.jsonld
+	ed255192020 []byte
+	//go:embed third_party/w3c.github.io/data-integrity_v1.jsonld
+	dataIntegrityV1 []byte
 	//go:embed third_party/identity.foundation/presentation-submission_v1.jsonld
 	presentationSubmission []byte
 	//go:embed third_party/ns.did.ai/x25519-2019_v1.jsonld
@@ -116,6 +120,16 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		DocumentURL: "https://digitalbazaar.github.io/ed25519-signature-2018-context/contexts/ed25519-signature-2018-v1.jsonld", //nolint:lll
 		Content:     ed255192018,
 	},
+	{
+		URL:         "https://w3id.org/security/suites/ed25519-2020/v1",
+		DocumentURL: "https://w3c-ccg.github.io/lds-ed25519-2020/contexts/lds-ed25519-2020-v1.jsonld",
+		Content:     ed255192020,
+	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v1",
+		DocumentURL: "https://w3c.github.io/vc-data-integrity/contexts/data-integrity/v1.jsonld",
+		Content:     dataIntegrityV1,
+	},
 	{
 		URL:         "https://w3id.org/security/suites/x25519-2019/v1",
 		DocumentURL: "https://ns.did.ai/suites/x25519-2019/v1/",
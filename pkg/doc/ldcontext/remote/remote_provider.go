@@ -9,6 +9,7 @@ package remote
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -21,6 +22,10 @@ const defaultTimeout = time.Minute
 
 var logger = log.New("aries-framework/ldcontext/remote")
 
+// ErrNotModified is returned by ContextsIfNoneMatch and ContextsIfChanged when the contexts of the remote source
+// didn't change.
+var ErrNotModified = errors.New("contexts not modified")
+
 // Provider is a remote JSON-LD context provider.
 type Provider struct {
 	endpoint   string
@@ -53,14 +58,50 @@ func (p *Provider) Endpoint() string {
 
 // Contexts returns JSON-LD contexts from the remote source.
 func (p *Provider) Contexts() ([]ldcontext.Document, error) {
+	documents, _, err := p.ContextsIfNoneMatch("")
+	if err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// ContextsIfNoneMatch returns JSON-LD contexts from the remote source along with their ETag. The contexts are
+// requested with the If-None-Match header when etag is set, ErrNotModified being returned if they match the ETag.
+func (p *Provider) ContextsIfNoneMatch(etag string) ([]ldcontext.Document, string, error) {
+	documents, validators, err := p.ContextsIfChanged(Validators{ETag: etag})
+
+	return documents, validators.ETag, err
+}
+
+// Validators are the HTTP cache validators of the contexts of a remote source.
+type Validators struct {
+	// ETag is the entity tag of the contexts, sent back in the If-None-Match header.
+	ETag string
+	// LastModified is the last modification date of the contexts, sent back in the If-Modified-Since header.
+	LastModified string
+}
+
+// ContextsIfChanged returns JSON-LD contexts from the remote source along with their validators. The contexts are
+// requested with the If-None-Match and If-Modified-Since headers of the validators set, ErrNotModified being returned
+// if they didn't change since. The sources supporting neither header always return their contexts.
+func (p *Provider) ContextsIfChanged(validators Validators) ([]ldcontext.Document, Validators, error) {
 	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, p.endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("new request: %w", err)
+		return nil, Validators{}, fmt.Errorf("new request: %w", err)
+	}
+
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
 	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("httpClient do: %w", err)
+		return nil, Validators{}, fmt.Errorf("httpClient do: %w", err)
 	}
 
 	defer func() {
@@ -70,17 +111,24 @@ func (p *Provider) Contexts() ([]ldcontext.Document, error) {
 		}
 	}()
 
+	if validators != (Validators{}) && resp.StatusCode == http.StatusNotModified {
+		return nil, validators, ErrNotModified
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response status code: %d", resp.StatusCode)
+		return nil, Validators{}, fmt.Errorf("response status code: %d", resp.StatusCode)
 	}
 
 	var response Response
 
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+		return nil, Validators{}, fmt.Errorf("decode response: %w", err)
 	}
 
-	return response.Documents, nil
+	return response.Documents, Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
 }
 
 // ProviderOpt configures the remote context provider.
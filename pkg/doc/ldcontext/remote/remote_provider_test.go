@@ -90,6 +90,80 @@ func TestProvider_Contexts(t *testing.T) {
 	})
 }
 
+func TestProvider_ContextsIfNoneMatch(t *testing.T) {
+	respBytes, err := json.Marshal(remote.Response{Documents: ldtestutil.Contexts()})
+	require.NoError(t, err)
+
+	p := remote.NewProvider("endpoint", remote.WithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": []string{`"v1"`}},
+				Body:       ioutil.NopCloser(bytes.NewReader(respBytes)),
+			}, nil
+		},
+	}))
+
+	t.Run("Modified contexts", func(t *testing.T) {
+		contexts, etag, err := p.ContextsIfNoneMatch(`"v0"`)
+		require.NoError(t, err)
+		require.Equal(t, len(ldtestutil.Contexts()), len(contexts))
+		require.Equal(t, `"v1"`, etag)
+	})
+
+	t.Run("Contexts not modified", func(t *testing.T) {
+		contexts, etag, err := p.ContextsIfNoneMatch(`"v1"`)
+		require.True(t, errors.Is(err, remote.ErrNotModified))
+		require.Empty(t, contexts)
+		require.Equal(t, `"v1"`, etag)
+	})
+}
+
+func TestProvider_ContextsIfChanged(t *testing.T) {
+	respBytes, err := json.Marshal(remote.Response{Documents: ldtestutil.Contexts()})
+	require.NoError(t, err)
+
+	const lastModified = "Wed, 21 Oct 2026 07:28:00 GMT"
+
+	p := remote.NewProvider("endpoint", remote.WithHTTPClient(&mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-Modified-Since") == lastModified {
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Last-Modified": []string{lastModified}},
+				Body:       ioutil.NopCloser(bytes.NewReader(respBytes)),
+			}, nil
+		},
+	}))
+
+	t.Run("Modified contexts", func(t *testing.T) {
+		contexts, validators, err := p.ContextsIfChanged(remote.Validators{})
+		require.NoError(t, err)
+		require.Equal(t, len(ldtestutil.Contexts()), len(contexts))
+		require.Equal(t, remote.Validators{LastModified: lastModified}, validators)
+	})
+
+	t.Run("Contexts not modified since", func(t *testing.T) {
+		contexts, validators, err := p.ContextsIfChanged(remote.Validators{LastModified: lastModified})
+		require.True(t, errors.Is(err, remote.ErrNotModified))
+		require.Empty(t, contexts)
+		require.Equal(t, remote.Validators{LastModified: lastModified}, validators)
+	})
+}
+
 type mockHTTPClient struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
@@ -9,6 +9,7 @@ package presexch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/PaesslerAG/gval"
@@ -50,6 +51,10 @@ type InputDescriptorMapping struct {
 // MatchOptions is a holder of options that can set when matching a submission against definitions.
 type MatchOptions struct {
 	CredentialOptions []verifiable.CredentialOpt
+	// Version is the Presentation Exchange version the definition is processed with, v1 by default.
+	Version Version
+	// CombineCredentials evaluates the submission requirements of a v1 definition the way v2 ones are.
+	CombineCredentials bool
 }
 
 // MatchOption is an option that sets an option for when matching.
@@ -62,7 +67,25 @@ func WithCredentialOptions(options ...verifiable.CredentialOpt) MatchOption {
 	}
 }
 
+// WithVersion sets the Presentation Exchange version the definition is processed with.
+func WithVersion(version Version) MatchOption {
+	return func(m *MatchOptions) {
+		m.Version = version
+	}
+}
+
+// WithCombinedCredentials evaluates the submission requirements of a v1 definition the way v2 ones are: the holder
+// picks the input descriptors satisfying the requirements, and the presentation combines the credentials of all the
+// descriptors picked, instead of the credentials having to satisfy every requirement on their own.
+func WithCombinedCredentials() MatchOption {
+	return func(m *MatchOptions) {
+		m.CombineCredentials = true
+	}
+}
+
 // Match returns the credentials matched against the InputDescriptors ids.
+// In v2, the schemas of the input descriptors are optional, the credentials must be in the format of their input
+// descriptor and the submission requirements are evaluated.
 func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:gocyclo,funlen
 	contextLoader ld.DocumentLoader, options ...MatchOption) (map[string]*verifiable.Credential, error) {
 	opts := &MatchOptions{}
@@ -115,6 +138,17 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 
 		inputDescriptor := pd.inputDescriptor(mapping.ID)
 
+		if opts.Version == V2 && !pd.formatAccepted(inputDescriptor, vc) {
+			return nil, fmt.Errorf("input descriptor id [%s] requires a format which does not match vc selected by path [%s]",
+				inputDescriptor.ID, mapping.Path)
+		}
+
+		if opts.Version == V2 && len(inputDescriptor.Schema) == 0 {
+			result[mapping.ID] = vc
+
+			continue
+		}
+
 		passed := filterSchema(inputDescriptor.Schema, []*verifiable.Credential{vc}, contextLoader)
 		if len(passed) == 0 {
 			return nil, fmt.Errorf(
@@ -127,7 +161,12 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 		result[mapping.ID] = vc
 	}
 
-	err = pd.evalSubmissionRequirements(result)
+	if opts.Version == V2 {
+		err = pd.evalSubmissionRequirementsV2(result)
+	} else {
+		err = pd.evalSubmissionRequirements(result)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed submission requirements: %w", err)
 	}
@@ -150,6 +189,26 @@ func (pd *PresentationDefinition) evalSubmissionRequirements(matched map[string]
 	return nil
 }
 
+// Ensures the matched credentials meet the submission requirements rules.
+func (pd *PresentationDefinition) evalSubmissionRequirementsV2(matched map[string]*verifiable.Credential) error {
+	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	if err != nil {
+		return err
+	}
+
+	if !req.isSatisfiedBy(matched) {
+		return errors.New("the submitted input descriptors do not satisfy the submission requirements")
+	}
+
+	return nil
+}
+
+func (pd *PresentationDefinition) formatAccepted(descriptor *InputDescriptor, vc *verifiable.Credential) bool {
+	format := pd.descriptorFormat(descriptor)
+
+	return format == nil || format.accepts(vc)
+}
+
 func (pd *PresentationDefinition) inputDescriptor(id string) *InputDescriptor {
 	for i := range pd.InputDescriptors {
 		if pd.InputDescriptors[i].ID == id {
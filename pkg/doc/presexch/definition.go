@@ -97,6 +97,9 @@ type PresentationDefinition struct {
 	// If not present, all inputs listed in the InputDescriptors array are required for submission.
 	SubmissionRequirements []*SubmissionRequirement `json:"submission_requirements,omitempty"`
 	InputDescriptors       []*InputDescriptor       `json:"input_descriptors,omitempty"`
+	// Frame is a JSON-LD frame selecting the claims the BBS+ credentials disclose when their disclosure is limited
+	// (v2 only).
+	Frame map[string]interface{} `json:"frame,omitempty"`
 }
 
 // SubmissionRequirement describes input that must be submitted via a Presentation Submission
@@ -121,6 +124,8 @@ type InputDescriptor struct {
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	Schema      []*Schema              `json:"schema,omitempty"`
 	Constraints *Constraints           `json:"constraints,omitempty"`
+	// Format overrides the format of the presentation definition for this input descriptor (v2 only).
+	Format *Format `json:"format,omitempty"`
 }
 
 // Schema input descriptor schema.
@@ -170,8 +175,12 @@ type Filter struct {
 
 // ValidateSchema validates presentation definition.
 func (pd *PresentationDefinition) ValidateSchema() error {
+	return pd.validateSchema(DefinitionJSONSchema)
+}
+
+func (pd *PresentationDefinition) validateSchema(jsonSchema string) error {
 	result, err := gojsonschema.Validate(
-		gojsonschema.NewStringLoader(DefinitionJSONSchema),
+		gojsonschema.NewStringLoader(jsonSchema),
 		gojsonschema.NewGoLoader(struct {
 			PD *PresentationDefinition `json:"presentation_definition"`
 		}{PD: pd}),
@@ -298,7 +307,8 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 	return req, nil
 }
 
-// CreateVP creates verifiable presentation.
+// CreateVP creates verifiable presentation. The definition is processed as a Presentation Exchange v1 definition,
+// see CreateVPWithOptions for v2 definitions.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
 	if err := pd.ValidateSchema(); err != nil {
@@ -315,7 +325,12 @@ func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 		return nil, err
 	}
 
-	applicableCredentials, descriptors := merge(result)
+	return pd.presentation(result, V1)
+}
+
+func (pd *PresentationDefinition) presentation(result map[string][]*verifiable.Credential,
+	version Version) (*verifiable.Presentation, error) {
+	applicableCredentials, descriptors := merge(result, version)
 
 	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(applicableCredentials...))
 	if err != nil {
@@ -347,7 +362,7 @@ func applyRequirement(req *requirement, creds []*verifiable.Credential,
 	for _, descriptor := range req.InputDescriptors {
 		filtered := filterSchema(descriptor.Schema, creds, documentLoader)
 
-		filtered, err := filterConstraints(descriptor.Constraints, filtered, opts...)
+		filtered, err := filterConstraints(descriptor.Constraints, filtered, V1, nil, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -414,22 +429,22 @@ func mergeNestedResult(nr []map[string][]*verifiable.Credential,
 
 	for _, res := range nr {
 		for key, credentials := range res {
-			set := map[string]struct{}{}
+			set := map[*verifiable.Credential]struct{}{}
 
 			var mergedCredentials []*verifiable.Credential
 
 			for _, credential := range result[key] {
-				if _, ok := set[credential.ID]; !ok {
+				if _, ok := set[credential]; !ok {
 					mergedCredentials = append(mergedCredentials, credential)
-					set[credential.ID] = struct{}{}
+					set[credential] = struct{}{}
 				}
 			}
 
 			for _, credential := range credentials {
-				if _, ok := set[credential.ID]; !ok {
+				if _, ok := set[credential]; !ok {
 					if _, exist := exclude[key+credential.ID]; !exist {
 						mergedCredentials = append(mergedCredentials, credential)
-						set[credential.ID] = struct{}{}
+						set[credential] = struct{}{}
 					}
 				}
 			}
@@ -500,9 +515,12 @@ func subjectIsIssuer(credential *verifiable.Credential) bool {
 	return false
 }
 
+// filterConstraints filters the credentials satisfying the constraints. In v2, the disclosure of the credentials is
+// limited with their BBS+ proofs (using the frame when set): the credentials without BBS+ proofs are filtered out when
+// the limited disclosure is required and disclosed entirely when it is preferred.
 // nolint: gocyclo,funlen,gocognit
-func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
-	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+func filterConstraints(constraints *Constraints, creds []*verifiable.Credential, version Version,
+	frame map[string]interface{}, opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
 	if constraints == nil {
 		return creds, nil
 	}
@@ -553,7 +571,17 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 			continue
 		}
 
-		if constraints.LimitDisclosure.isRequired() || predicate {
+		limitDisclosure := constraints.LimitDisclosure.isRequired()
+
+		if version == V2 {
+			if limitDisclosure && !hasBBS(credential) {
+				continue
+			}
+
+			limitDisclosure = constraints.LimitDisclosure != nil && hasBBS(credential)
+		}
+
+		if limitDisclosure || predicate {
 			template := credentialSrc
 
 			var contexts []interface{}
@@ -564,7 +592,7 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 
 			contexts = append(contexts, credential.CustomContext...)
 
-			if constraints.LimitDisclosure.isRequired() {
+			if limitDisclosure {
 				template, err = json.Marshal(map[string]interface{}{
 					"id":                credential.ID,
 					"type":              credential.Types,
@@ -580,7 +608,8 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 
 			var err error
 
-			credential, err = createNewCredential(constraints, credentialSrc, template, credential, opts...)
+			credential, err = createNewCredential(constraints, limitDisclosure, frame, credentialSrc, template, credential,
+				opts...)
 			if err != nil {
 				return nil, fmt.Errorf("create new credential: %w", err)
 			}
@@ -617,8 +646,9 @@ func trimTmpID(id string) string {
 }
 
 // nolint: funlen,gocognit,gocyclo
-func createNewCredential(constraints *Constraints, src, limitedCred []byte,
-	credential *verifiable.Credential, opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+func createNewCredential(constraints *Constraints, limitDisclosure bool, frame map[string]interface{},
+	src, limitedCred []byte, credential *verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
 	var (
 		BBSSupport          = hasBBS(credential)
 		modifiedByPredicate bool
@@ -660,7 +690,7 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 				val = gjson.GetBytes(src, path[1]).Value()
 			}
 
-			if constraints.LimitDisclosure.isRequired() && BBSSupport {
+			if limitDisclosure && BBSSupport {
 				chunks := strings.Split(path[0], ".")
 				explicitPath := strings.Join(chunks[:len(chunks)-1], ".")
 				explicitPaths[explicitPath] = true
@@ -673,11 +703,15 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 		}
 	}
 
-	if !constraints.LimitDisclosure.isRequired() || !BBSSupport || modifiedByPredicate {
+	if !limitDisclosure || !BBSSupport || modifiedByPredicate {
 		opts = append(opts, verifiable.WithDisabledProofCheck())
 		return verifiable.ParseCredential(limitedCred, opts...)
 	}
 
+	if frame != nil {
+		return credential.GenerateBBSSelectiveDisclosure(frame, []byte(uuid.New().String()), opts...)
+	}
+
 	limitedCred, err := enhanceRevealDoc(explicitPaths, limitedCred, src)
 	if err != nil {
 		return nil, err
@@ -840,9 +874,12 @@ func getPath(keys []interface{}, set map[string]int) [2]string {
 	return [...]string{strings.Join(newPath, "."), strings.Join(originalPath, ".")}
 }
 
-func merge(setOfCredentials map[string][]*verifiable.Credential) ([]*verifiable.Credential, []*InputDescriptorMapping) {
-	setOfCreds := make(map[string]int)
-	setOfDescriptors := make(map[string]struct{})
+// merge combines the credentials of the input descriptors in the presentation, each credential being presented
+// once, and maps every descriptor to the position in the presentation of each of its credentials.
+func merge(setOfCredentials map[string][]*verifiable.Credential,
+	version Version) ([]*verifiable.Credential, []*InputDescriptorMapping) {
+	// the credentials are identified by reference: the credentials without ID are distinct.
+	setOfCreds := make(map[*verifiable.Credential]int)
 
 	var (
 		result      []*verifiable.Credential
@@ -856,28 +893,39 @@ func merge(setOfCredentials map[string][]*verifiable.Credential) ([]*verifiable.
 
 	sort.Strings(keys)
 
+	// v1 submissions keep the format they have always had, v2 ones declare the format of the credential.
+	format := "ldp_vp"
+	if version == V2 {
+		format = credentialFormat
+	}
+
 	for _, descriptorID := range keys {
-		credentials := setOfCredentials[descriptorID]
+		mapped := make(map[int]struct{})
 
-		for _, credential := range credentials {
-			if _, ok := setOfCreds[credential.ID]; !ok {
+		for _, credential := range setOfCredentials[descriptorID] {
+			idx, ok := setOfCreds[credential]
+			if !ok {
 				credential.ID = trimTmpID(credential.ID)
+				idx = len(result)
 				result = append(result, credential)
-				setOfCreds[credential.ID] = len(descriptors)
+				setOfCreds[credential] = idx
 			}
 
-			if _, ok := setOfDescriptors[fmt.Sprintf("%s-%s", credential.ID, credential.ID)]; !ok {
-				descriptors = append(descriptors, &InputDescriptorMapping{
-					ID: descriptorID,
-					// TODO: what format should be here?
-					Format: "ldp_vp",
-					Path:   fmt.Sprintf("$.verifiableCredential[%d]", setOfCreds[credential.ID]),
-				})
+			if _, ok := mapped[idx]; ok {
+				continue
 			}
+
+			mapped[idx] = struct{}{}
+
+			descriptors = append(descriptors, &InputDescriptorMapping{
+				ID:     descriptorID,
+				Format: format,
+				Path:   fmt.Sprintf("$.verifiableCredential[%d]", idx),
+			})
 		}
 	}
 
-	sort.Sort(byID(descriptors))
+	sort.Stable(byID(descriptors))
 
 	return result, descriptors
 }
@@ -0,0 +1,263 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// Version is the version of the Presentation Exchange specification a definition is processed with.
+type Version string
+
+const (
+	// V1 is Presentation Exchange v1, the default version.
+	V1 Version = "v1"
+	// V2 is Presentation Exchange v2.
+	V2 Version = "v2"
+
+	// credentialFormat is the claim format designation of the credentials: they are JSON-LD credentials, those parsed
+	// from a JWT don't keep their JWT serialization.
+	credentialFormat = "ldp_vc"
+)
+
+// ValidateSchemaV2 validates the presentation definition against the Presentation Exchange v2 schema and
+// submission requirement rules.
+func (pd *PresentationDefinition) ValidateSchemaV2() error {
+	if err := pd.validateSchema(DefinitionJSONSchemaV2); err != nil {
+		return err
+	}
+
+	return validateSubmissionRequirements(pd.SubmissionRequirements)
+}
+
+// CreateVPWithOptions creates verifiable presentation, processing the definition with the version set by
+// WithVersion (v1 by default). In v2, the credentials are filtered by the format of their input descriptor, the
+// disclosure of the BBS+ credentials is limited with the frame of the definition and the submission requirements
+// follow the v2 nesting rules: the holder picks the first descriptors (or nested requirements) satisfying a rule.
+// WithCombinedCredentials applies these nesting rules to v1 definitions.
+func (pd *PresentationDefinition) CreateVPWithOptions(credentials []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, options ...MatchOption) (*verifiable.Presentation, error) {
+	opts := &MatchOptions{}
+
+	for i := range options {
+		options[i](opts)
+	}
+
+	version := V1
+
+	switch {
+	case opts.Version == V2:
+		if err := pd.ValidateSchemaV2(); err != nil {
+			return nil, err
+		}
+
+		version = V2
+	case opts.CombineCredentials:
+		if err := pd.ValidateSchema(); err != nil {
+			return nil, err
+		}
+	default:
+		return pd.CreateVP(credentials, documentLoader, opts.CredentialOptions...)
+	}
+
+	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := pd.applyRequirementV2(req, credentials, documentLoader, version, opts.CredentialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.presentation(result, version)
+}
+
+// validateSubmissionRequirements checks the pick rules can be evaluated.
+func validateSubmissionRequirements(requirements []*SubmissionRequirement) error {
+	for _, sr := range requirements {
+		if sr.Rule == Pick && sr.Count == 0 && sr.Min == 0 && sr.Max == 0 {
+			return fmt.Errorf("submission requirement %q: pick rule requires count, min or max", sr.Name)
+		}
+
+		if sr.Max > 0 && sr.Min > sr.Max {
+			return fmt.Errorf("submission requirement %q: min is greater than max", sr.Name)
+		}
+
+		if err := validateSubmissionRequirements(sr.FromNested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pick returns the number of satisfied items (descriptors or nested requirements) the holder picks to satisfy the
+// requirement and whether the requirement can be satisfied.
+func (r *requirement) pick(satisfied int) (int, bool) {
+	switch {
+	case r.Count > 0:
+		return r.Count, satisfied >= r.Count
+	case satisfied < r.Min:
+		return 0, false
+	case r.Max > 0 && satisfied > r.Max:
+		return r.Max, true
+	default:
+		return satisfied, true
+	}
+}
+
+// applyRequirementV2 picks the input descriptors satisfying the requirement, the descriptors being filtered with the
+// rules of the given version.
+func (pd *PresentationDefinition) applyRequirementV2(req *requirement, creds []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, version Version,
+	opts ...verifiable.CredentialOpt) (map[string][]*verifiable.Credential, error) {
+	if len(req.InputDescriptors) != 0 {
+		result := make(map[string][]*verifiable.Credential)
+
+		var satisfied []string
+
+		for _, descriptor := range req.InputDescriptors {
+			filtered, err := pd.filterDescriptor(descriptor, creds, documentLoader, version, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(filtered) != 0 {
+				result[descriptor.ID] = filtered
+				satisfied = append(satisfied, descriptor.ID)
+			}
+		}
+
+		n, ok := req.pick(len(satisfied))
+		if !ok {
+			return nil, ErrNoCredentials
+		}
+
+		for _, id := range satisfied[n:] {
+			delete(result, id)
+		}
+
+		return result, nil
+	}
+
+	var satisfied []map[string][]*verifiable.Credential
+
+	for _, r := range req.Nested {
+		res, err := pd.applyRequirementV2(r, creds, documentLoader, version, opts...)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		satisfied = append(satisfied, res)
+	}
+
+	n, ok := req.pick(len(satisfied))
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	return mergeNestedResult(satisfied[:n], map[string]struct{}{}), nil
+}
+
+func (pd *PresentationDefinition) filterDescriptor(descriptor *InputDescriptor, creds []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, version Version,
+	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	if version == V2 {
+		return pd.filterDescriptorV2(descriptor, creds, documentLoader, opts...)
+	}
+
+	filtered := filterSchema(descriptor.Schema, creds, documentLoader)
+
+	return filterConstraints(descriptor.Constraints, filtered, V1, nil, opts...)
+}
+
+func (pd *PresentationDefinition) filterDescriptorV2(descriptor *InputDescriptor, creds []*verifiable.Credential,
+	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	filtered := creds
+
+	// the schemas are optional in v2.
+	if len(descriptor.Schema) != 0 {
+		filtered = filterSchema(descriptor.Schema, filtered, documentLoader)
+	}
+
+	filtered = filterFormat(pd.descriptorFormat(descriptor), filtered)
+
+	return filterConstraints(descriptor.Constraints, filtered, V2, pd.Frame, opts...)
+}
+
+// descriptorFormat returns the format negotiated for the input descriptor: its own format, or else the format of the
+// definition.
+func (pd *PresentationDefinition) descriptorFormat(descriptor *InputDescriptor) *Format {
+	if descriptor.Format != nil {
+		return descriptor.Format
+	}
+
+	return pd.Format
+}
+
+func filterFormat(format *Format, creds []*verifiable.Credential) []*verifiable.Credential {
+	if format == nil {
+		return creds
+	}
+
+	var result []*verifiable.Credential
+
+	for _, credential := range creds {
+		if format.accepts(credential) {
+			result = append(result, credential)
+		}
+	}
+
+	return result
+}
+
+// accepts checks the credential has a proof of a type accepted by the Linked Data Proof format designations.
+func (f *Format) accepts(credential *verifiable.Credential) bool {
+	for _, ldp := range []*LdpType{f.Ldp, f.LdpVC} {
+		if ldp == nil {
+			continue
+		}
+
+		for _, proof := range credential.Proofs {
+			proofType, ok := proof["type"].(string)
+			if ok && contains(ldp.ProofType, proofType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isSatisfiedBy checks the matched input descriptors satisfy the requirement.
+func (r *requirement) isSatisfiedBy(matched map[string]*verifiable.Credential) bool {
+	satisfied := 0
+
+	for _, descriptor := range r.InputDescriptors {
+		if _, ok := matched[descriptor.ID]; ok {
+			satisfied++
+		}
+	}
+
+	for _, nested := range r.Nested {
+		if nested.isSatisfiedBy(matched) {
+			satisfied++
+		}
+	}
+
+	return r.isLenApplicable(satisfied)
+}
@@ -0,0 +1,493 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestPresentationDefinition_ValidateSchemaV2(t *testing.T) {
+	t.Run("schemas are optional, descriptors may declare a format and the definition a frame", func(t *testing.T) {
+		pd := &presexch.PresentationDefinition{
+			ID:    uuid.New().String(),
+			Frame: map[string]interface{}{"@explicit": true},
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID:     uuid.New().String(),
+				Format: &presexch.Format{LdpVC: &presexch.LdpType{ProofType: []string{"BbsBlsSignature2020"}}},
+			}},
+		}
+
+		require.NoError(t, pd.ValidateSchemaV2())
+
+		err := pd.ValidateSchema()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema is required")
+	})
+
+	t.Run("pick rule requires count, min or max", func(t *testing.T) {
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			SubmissionRequirements: []*presexch.SubmissionRequirement{{
+				Name: "outer",
+				Rule: presexch.All,
+				FromNested: []*presexch.SubmissionRequirement{{
+					Name: "inner",
+					Rule: presexch.Pick,
+					From: "A",
+				}},
+			}},
+			InputDescriptors: []*presexch.InputDescriptor{{ID: uuid.New().String(), Group: []string{"A"}}},
+		}
+
+		require.EqualError(t, pd.ValidateSchemaV2(),
+			`submission requirement "inner": pick rule requires count, min or max`)
+	})
+
+	t.Run("min must not be greater than max", func(t *testing.T) {
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			SubmissionRequirements: []*presexch.SubmissionRequirement{{
+				Name: "requirement",
+				Rule: presexch.Pick,
+				Min:  2,
+				Max:  1,
+				From: "A",
+			}},
+			InputDescriptors: []*presexch.InputDescriptor{{ID: uuid.New().String(), Group: []string{"A"}}},
+		}
+
+		require.EqualError(t, pd.ValidateSchemaV2(),
+			`submission requirement "requirement": min is greater than max`)
+	})
+}
+
+func TestPresentationDefinition_CreateVPWithOptions(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	t.Run("v1 by default", func(t *testing.T) {
+		pd := &presexch.PresentationDefinition{
+			ID:               uuid.New().String(),
+			InputDescriptors: []*presexch.InputDescriptor{{ID: uuid.New().String()}},
+		}
+
+		_, err := pd.CreateVPWithOptions([]*verifiable.Credential{newCredential("did:example:1")}, lddl)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema is required")
+	})
+
+	t.Run("format is negotiated per input descriptor", func(t *testing.T) {
+		edVC := newCredential("did:example:ed")
+		edVC.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+		bbsVC := newCredential("did:example:bbs")
+		bbsVC.Proofs = []verifiable.Proof{{"type": "BbsBlsSignature2020"}}
+
+		pd := &presexch.PresentationDefinition{
+			ID:     uuid.New().String(),
+			Format: &presexch.Format{LdpVC: &presexch.LdpType{ProofType: []string{"Ed25519Signature2018"}}},
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "definition-format",
+			}, {
+				ID:     "descriptor-format",
+				Format: &presexch.Format{Ldp: &presexch.LdpType{ProofType: []string{"BbsBlsSignature2020"}}},
+			}},
+		}
+
+		vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{edVC, bbsVC}, lddl,
+			presexch.WithVersion(presexch.V2))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+
+		checkSubmission(t, vp, pd)
+
+		ps, ok := vp.CustomFields["presentation_submission"].(*presexch.PresentationSubmission)
+		require.True(t, ok)
+		require.Len(t, ps.DescriptorMap, 2)
+
+		for _, mapping := range ps.DescriptorMap {
+			require.Equal(t, "ldp_vc", mapping.Format)
+
+			vc := selectCredential(t, vp, mapping)
+
+			switch mapping.ID {
+			case "definition-format":
+				require.Equal(t, edVC.ID, vc.ID)
+			case "descriptor-format":
+				require.Equal(t, bbsVC.ID, vc.ID)
+			}
+		}
+
+		_, err = pd.CreateVPWithOptions([]*verifiable.Credential{edVC}, lddl, presexch.WithVersion(presexch.V2))
+		require.ErrorIs(t, err, presexch.ErrNoCredentials)
+	})
+
+	t.Run("holder picks the first descriptors satisfying a rule", func(t *testing.T) {
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			SubmissionRequirements: []*presexch.SubmissionRequirement{{
+				Rule:  presexch.Pick,
+				Count: 1,
+				FromNested: []*presexch.SubmissionRequirement{{
+					Rule: presexch.All,
+					From: "missing",
+				}, {
+					Rule: presexch.Pick,
+					Max:  1,
+					From: "A",
+				}},
+			}},
+			InputDescriptors: []*presexch.InputDescriptor{
+				{ID: "a1", Group: []string{"A"}},
+				{ID: "a2", Group: []string{"A"}},
+				{ID: "m1", Group: []string{"missing"}, Constraints: &presexch.Constraints{
+					Fields: []*presexch.Field{{Path: []string{"$.missing"}}},
+				}},
+			},
+		}
+
+		vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{newCredential("did:example:1")}, lddl,
+			presexch.WithVersion(presexch.V2))
+		require.NoError(t, err)
+
+		ps, ok := vp.CustomFields["presentation_submission"].(*presexch.PresentationSubmission)
+		require.True(t, ok)
+		require.Len(t, ps.DescriptorMap, 1)
+		require.Equal(t, "a1", ps.DescriptorMap[0].ID)
+
+		pd.SubmissionRequirements[0].Rule = presexch.All
+		pd.SubmissionRequirements[0].Count = 0
+
+		_, err = pd.CreateVPWithOptions([]*verifiable.Credential{newCredential("did:example:1")}, lddl,
+			presexch.WithVersion(presexch.V2))
+		require.ErrorIs(t, err, presexch.ErrNoCredentials)
+	})
+
+	t.Run("v1 definition with combined credentials", func(t *testing.T) {
+		schema := []*presexch.Schema{{URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType)}}
+		field := func(path string) *presexch.Constraints {
+			return &presexch.Constraints{Fields: []*presexch.Field{{Path: []string{path}}}}
+		}
+
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			SubmissionRequirements: []*presexch.SubmissionRequirement{{
+				Rule: presexch.All,
+				From: "A",
+			}, {
+				Rule:  presexch.Pick,
+				Count: 1,
+				From:  "B",
+			}},
+			InputDescriptors: []*presexch.InputDescriptor{
+				{ID: "name", Group: []string{"A"}, Schema: schema, Constraints: field("$.credentialSubject.name")},
+				{ID: "issuer", Group: []string{"A"}, Schema: schema, Constraints: field("$.issuer")},
+				{ID: "degree", Group: []string{"B"}, Schema: schema, Constraints: field("$.credentialSubject.degree")},
+				{ID: "age", Group: []string{"B"}, Schema: schema, Constraints: field("$.credentialSubject.age")},
+			},
+		}
+
+		nameVC := newCredential("did:example:1")
+
+		// the credentials without ID are distinct.
+		ageVCs := make([]*verifiable.Credential, 2)
+
+		for i := range ageVCs {
+			ageVCs[i] = newCredential("did:example:2")
+			ageVCs[i].ID = ""
+			ageVCs[i].Subject = verifiable.Subject{
+				ID:           "did:example:2",
+				CustomFields: map[string]interface{}{"age": 20 + i},
+			}
+		}
+
+		vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{ageVCs[0], ageVCs[1], nameVC}, lddl,
+			presexch.WithCombinedCredentials())
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 3)
+
+		checkSubmission(t, vp, pd)
+
+		ps, ok := vp.CustomFields["presentation_submission"].(*presexch.PresentationSubmission)
+		require.True(t, ok)
+
+		mapped := map[string]int{}
+
+		for _, mapping := range ps.DescriptorMap {
+			require.Equal(t, "ldp_vp", mapping.Format)
+
+			mapped[mapping.ID]++
+
+			vc := selectCredential(t, vp, mapping)
+
+			switch mapping.ID {
+			case "name":
+				require.Equal(t, nameVC.ID, vc.ID)
+			case "age":
+				require.Empty(t, vc.ID)
+			}
+		}
+
+		require.Equal(t, map[string]int{"name": 1, "issuer": 3, "age": 2}, mapped)
+
+		pd.InputDescriptors[3].Constraints = field("$.credentialSubject.missing")
+
+		_, err = pd.CreateVPWithOptions([]*verifiable.Credential{nameVC}, lddl, presexch.WithCombinedCredentials())
+		require.ErrorIs(t, err, presexch.ErrNoCredentials)
+
+		pd.InputDescriptors[0].Schema = nil
+
+		_, err = pd.CreateVPWithOptions([]*verifiable.Credential{nameVC}, lddl, presexch.WithCombinedCredentials())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema is required")
+	})
+
+	t.Run("limit disclosure is enforced", func(t *testing.T) {
+		required := presexch.Required
+		preferred := presexch.Preferred
+
+		vc := newCredential("did:example:1")
+		vc.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &presexch.Constraints{
+					LimitDisclosure: &required,
+					Fields: []*presexch.Field{{
+						Path:   []string{"$.credentialSubject.name"},
+						Filter: &presexch.Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		// credentials without BBS+ proofs can't be disclosed selectively.
+		_, err := pd.CreateVPWithOptions([]*verifiable.Credential{vc}, lddl, presexch.WithVersion(presexch.V2))
+		require.ErrorIs(t, err, presexch.ErrNoCredentials)
+
+		// they are disclosed entirely when the limited disclosure is preferred.
+		pd.InputDescriptors[0].Constraints.LimitDisclosure = &preferred
+
+		vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{vc}, lddl, presexch.WithVersion(presexch.V2))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		disclosed, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Equal(t, vc.ID, disclosed.ID)
+		require.NotEmpty(t, disclosed.Proofs)
+	})
+
+	t.Run("frame limits the disclosure of BBS+ credentials", func(t *testing.T) {
+		required := presexch.Required
+
+		vc := &verifiable.Credential{
+			ID: "https://issuer.oidp.uscis.gov/credentials/83627465",
+			Context: []string{
+				verifiable.ContextURI,
+				"https://w3id.org/citizenship/v1",
+				"https://w3id.org/security/bbs/v1",
+			},
+			Types: []string{"VerifiableCredential", "PermanentResidentCard"},
+			Subject: verifiable.Subject{
+				ID: "did:example:b34ca6cd37bbf23",
+				CustomFields: map[string]interface{}{
+					"type":       []string{"PermanentResident", "Person"},
+					"givenName":  "JOHN",
+					"familyName": "SMITH",
+				},
+			},
+			Issued: &util.TimeWrapper{Time: time.Now()},
+			Issuer: verifiable.Issuer{ID: "did:example:489398593"},
+		}
+
+		publicKey, privateKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		srcPublicKey, err := publicKey.Marshal()
+		require.NoError(t, err)
+
+		signer, err := newBBSSigner(privateKey)
+		require.NoError(t, err)
+
+		require.NoError(t, vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "BbsBlsSignature2020",
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonld.WithDocumentLoader(lddl)))
+
+		pd := &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			Frame: map[string]interface{}{
+				"@context": []interface{}{
+					verifiable.ContextURI,
+					"https://w3id.org/citizenship/v1",
+					"https://w3id.org/security/bbs/v1",
+				},
+				"type":         []interface{}{"VerifiableCredential", "PermanentResidentCard"},
+				"@explicit":    true,
+				"identifier":   map[string]interface{}{},
+				"issuer":       map[string]interface{}{},
+				"issuanceDate": map[string]interface{}{},
+				"credentialSubject": map[string]interface{}{
+					"@explicit": true,
+					"type":      []interface{}{"PermanentResident", "Person"},
+					"givenName": map[string]interface{}{},
+				},
+			},
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &presexch.Constraints{
+					LimitDisclosure: &required,
+					Fields: []*presexch.Field{{
+						Path:   []string{"$.credentialSubject.givenName"},
+						Filter: &presexch.Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{vc}, lddl,
+			presexch.WithVersion(presexch.V2),
+			presexch.WithCredentialOptions(
+				verifiable.WithJSONLDDocumentLoader(lddl),
+				verifiable.WithPublicKeyFetcher(verifiable.SingleKey(srcPublicKey, "Bls12381G2Key2020")),
+			))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		disclosed, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.NotEmpty(t, disclosed.Proofs)
+		require.Equal(t, "BbsBlsSignatureProof2020", disclosed.Proofs[0]["type"])
+
+		subject := disclosed.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, "JOHN", subject.CustomFields["givenName"])
+		require.Empty(t, subject.CustomFields["familyName"])
+	})
+}
+
+func TestPresentationDefinition_MatchV2(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	vc := newCredential("did:example:1")
+	vc.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+	pd := &presexch.PresentationDefinition{
+		ID: uuid.New().String(),
+		SubmissionRequirements: []*presexch.SubmissionRequirement{{
+			Rule:  presexch.Pick,
+			Count: 1,
+			From:  "A",
+		}},
+		InputDescriptors: []*presexch.InputDescriptor{
+			{ID: "a1", Group: []string{"A"}},
+			{ID: "a2", Group: []string{"A"}},
+		},
+	}
+
+	vp, err := pd.CreateVPWithOptions([]*verifiable.Credential{vc}, lddl, presexch.WithVersion(presexch.V2))
+	require.NoError(t, err)
+
+	vp = parsePresentation(t, vp)
+
+	t.Run("success", func(t *testing.T) {
+		matched, err := pd.Match(vp, lddl, presexch.WithVersion(presexch.V2),
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(lddl)))
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		require.Equal(t, vc.ID, matched["a1"].ID)
+	})
+
+	t.Run("error if the submission requirements are not satisfied", func(t *testing.T) {
+		pd.SubmissionRequirements[0].Count = 2
+		defer func() { pd.SubmissionRequirements[0].Count = 1 }()
+
+		_, err := pd.Match(vp, lddl, presexch.WithVersion(presexch.V2),
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(lddl)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "do not satisfy the submission requirements")
+	})
+
+	t.Run("error if the format is not accepted", func(t *testing.T) {
+		pd.InputDescriptors[0].Format = &presexch.Format{
+			LdpVC: &presexch.LdpType{ProofType: []string{"BbsBlsSignature2020"}},
+		}
+		defer func() { pd.InputDescriptors[0].Format = nil }()
+
+		_, err := pd.Match(vp, lddl, presexch.WithVersion(presexch.V2),
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(lddl)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "requires a format which does not match vc")
+	})
+}
+
+func newCredential(subjectID string) *verifiable.Credential {
+	return &verifiable.Credential{
+		ID:      "http://example.edu/credentials/" + uuid.New().String(),
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		Subject: verifiable.Subject{
+			ID:           subjectID,
+			CustomFields: map[string]interface{}{"name": "Jayden Doe"},
+		},
+		Issued: &util.TimeWrapper{Time: time.Now()},
+		Issuer: verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+	}
+}
+
+func selectCredential(t *testing.T, vp *verifiable.Presentation,
+	mapping *presexch.InputDescriptorMapping) *verifiable.Credential {
+	t.Helper()
+
+	for i, cred := range vp.Credentials() {
+		if mapping.Path == fmt.Sprintf("$.verifiableCredential[%d]", i) {
+			vc, ok := cred.(*verifiable.Credential)
+			require.True(t, ok)
+
+			return vc
+		}
+	}
+
+	require.Fail(t, "credential not found", mapping.Path)
+
+	return nil
+}
+
+func parsePresentation(t *testing.T, vp *verifiable.Presentation) *verifiable.Presentation {
+	t.Helper()
+
+	src, err := json.Marshal(vp)
+	require.NoError(t, err)
+
+	parsed, err := verifiable.ParsePresentation(src,
+		verifiable.WithPresDisabledProofCheck(),
+		verifiable.WithPresJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)))
+	require.NoError(t, err)
+
+	return parsed
+}
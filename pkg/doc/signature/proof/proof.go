@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/multiformats/go-multibase"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 )
 
@@ -36,8 +38,17 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for the cryptosuite of a Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
 )
 
+// multibaseProofTypes are the proof types with a "proofValue" encoded as a multibase base58btc string,
+// instead of base64.
+var multibaseProofTypes = map[string]bool{ //nolint:gochecknoglobals
+	"Ed25519Signature2020": true,
+	"DataIntegrityProof":   true,
+}
+
 // Proof is cryptographic proof of the integrity of the DID Document.
 type Proof struct {
 	Type                    string
@@ -50,6 +61,7 @@ type Proof struct {
 	Domain                  string
 	Nonce                   []byte
 	Challenge               string
+	Cryptosuite             string
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
@@ -71,7 +83,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	)
 
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), stringEntry(emap[jsonldType]))
 		if err != nil {
 			return nil, err
 		}
@@ -108,6 +120,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Domain:                  stringEntry(emap[jsonldDomain]),
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 		CapabilityChain:         capabilityChain,
 	}, nil
 }
@@ -128,6 +141,34 @@ func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error)
 	return capabilityChain, nil
 }
 
+func decodeProofValue(s, proofType string) ([]byte, error) {
+	if !multibaseProofTypes[proofType] {
+		return decodeBase64(s)
+	}
+
+	encoding, value, err := multibase.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase proof value: %w", err)
+	}
+
+	if encoding != multibase.Base58BTC {
+		return nil, errors.New("proof value is not multibase base58btc encoded")
+	}
+
+	return value, nil
+}
+
+func encodeProofValue(value []byte, proofType string) string {
+	if !multibaseProofTypes[proofType] {
+		return base64.RawURLEncoding.EncodeToString(value)
+	}
+
+	// the base58btc encoding doesn't fail
+	s, _ := multibase.Encode(multibase.Base58BTC, value) //nolint:errcheck
+
+	return s
+}
+
 func decodeBase64(s string) ([]byte, error) {
 	allEncodings := []*base64.Encoding{
 		base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding,
@@ -170,7 +211,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	}
 
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = encodeProofValue(p.ProofValue, p.Type)
 	}
 
 	if len(p.JWS) > 0 {
@@ -197,6 +238,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	return emap
 }
 
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
@@ -147,6 +148,48 @@ func TestInvalidProofValue(t *testing.T) {
 	require.Contains(t, err.Error(), "signature is not defined")
 }
 
+func TestMultibaseProofValue(t *testing.T) {
+	proofValueBytes, err := base64.RawURLEncoding.DecodeString(proofValueBase64)
+	require.NoError(t, err)
+
+	for _, proofType := range []string{"Ed25519Signature2020", "DataIntegrityProof"} {
+		p := &Proof{
+			Type:        proofType,
+			Created:     util.NewTime(time.Now()),
+			ProofValue:  proofValueBytes,
+			Cryptosuite: "eddsa-rdfc-2022",
+		}
+
+		emap := p.JSONLdObject()
+		require.Equal(t, "z"+base58.Encode(proofValueBytes), emap["proofValue"])
+		require.Equal(t, "eddsa-rdfc-2022", emap["cryptosuite"])
+
+		decoded, err := NewProof(emap)
+		require.NoError(t, err)
+		require.Equal(t, proofValueBytes, decoded.ProofValue)
+		require.Equal(t, "eddsa-rdfc-2022", decoded.Cryptosuite)
+	}
+
+	// base64 proof value
+	p, err := NewProof(map[string]interface{}{
+		"type":       "Ed25519Signature2020",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "m" + base64.RawStdEncoding.EncodeToString(proofValueBytes),
+	})
+	require.EqualError(t, err, "proof value is not multibase base58btc encoded")
+	require.Nil(t, p)
+
+	// not multibase
+	p, err = NewProof(map[string]interface{}{
+		"type":       "Ed25519Signature2020",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "hello",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode multibase proof value")
+	require.Nil(t, p)
+}
+
 func TestInvalidNonce(t *testing.T) {
 	p, err := NewProof(map[string]interface{}{
 		"type":       "Ed25519Signature2018",
@@ -36,6 +36,13 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuite is implemented by the Data Integrity signature suites, the proofs of which hold the name of the
+// cryptosuite.
+type cryptosuite interface {
+	// Cryptosuite returns the name of the cryptosuite
+	Cryptosuite() string
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -113,6 +120,10 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		CapabilityChain:         context.CapabilityChain,
 	}
 
+	if cs, ok := suite.(cryptosuite); ok {
+		p.Cryptosuite = cs.Cryptosuite()
+	}
+
 	// TODO support custom proof purpose
 	//  (https://github.com/hyperledger/aries-framework-go/issues/1586)
 	if p.ProofPurpose == "" {
@@ -0,0 +1,34 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataintegrity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+	msgSig := ed25519.Sign(privKey, msg)
+
+	v := NewPublicKeyVerifier()
+
+	err = v.Verify(&verifier.PublicKey{Type: kmsapi.ED25519, Value: pubKey}, msg, msgSig)
+	require.NoError(t, err)
+
+	err = v.Verify(&verifier.PublicKey{Type: kmsapi.ED25519, Value: pubKey}, []byte("other message"), msgSig)
+	require.Error(t, err)
+}
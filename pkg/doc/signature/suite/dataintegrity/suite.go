@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package dataintegrity implements the DataIntegrityProof signature suite of the W3C Verifiable Credential Data
+// Integrity specification, with the eddsa-rdfc-2022 cryptosuite.
+// The cryptosuite uses the RDF Dataset Canonicalization Algorithm [RDF-CANON] to transform the input document into
+// its canonical form, SHA-256 [RFC6234] as the message digest algorithm and Ed25519 [ED25519] as the signature
+// algorithm. The name of the cryptosuite is held by the "cryptosuite" of the proof and the signature by its
+// "proofValue", as a multibase base58btc string.
+package dataintegrity
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements the Data Integrity signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the signature type of Data Integrity proofs.
+	SignatureType = "DataIntegrityProof"
+	// EdDSARDFC2022 is the EdDSA cryptosuite using the RDF Dataset Canonicalization Algorithm.
+	EdDSARDFC2022 = "eddsa-rdfc-2022"
+	// ContextURL is the JSON-LD context of Data Integrity proofs.
+	ContextURL    = "https://w3id.org/security/data-integrity/v1"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of Data Integrity signature suite with the eddsa-rdfc-2022 cryptosuite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// eddsa-rdfc-2022 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only Data Integrity signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns the name of the cryptosuite of the proofs.
+func (s *Suite) Cryptosuite() string {
+	return EdDSARDFC2022
+}
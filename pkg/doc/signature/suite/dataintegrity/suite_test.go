@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataintegrity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//go:embed testdata/valid_credential.jsonld
+var validCredential string //nolint:gochecknoglobals
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{"dc": "http://purl.org/dc/terms/"},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("Ed25519Signature2020"))
+	require.Equal(t, "eddsa-rdfc-2022", ss.Cryptosuite())
+}
+
+func TestSignatureSuite_SignAndVerify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	s := signer.New(New(suite.WithSigner(signature.GetEd25519Signer(privKey, pubKey))))
+
+	signedDoc, err := s.Sign(&signer.Context{
+		SignatureType:      SignatureType,
+		VerificationMethod: "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+	}, []byte(validCredential), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &doc))
+
+	proofMap, ok := doc["proof"].([]interface{})[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, SignatureType, proofMap["type"])
+	require.Equal(t, EdDSARDFC2022, proofMap["cryptosuite"])
+	// the proof value is multibase base58btc encoded
+	require.True(t, strings.HasPrefix(proofMap["proofValue"].(string), "z"))
+
+	v, err := verifier.New(&keyResolver{&verifier.PublicKey{Type: kms.ED25519, Value: pubKey}},
+		New(suite.WithVerifier(NewPublicKeyVerifier())))
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+
+	t.Run("tampered document", func(t *testing.T) {
+		tampered := copyDoc(t, doc)
+		tampered["issuer"] = "did:example:other"
+
+		tamperedDoc, err := json.Marshal(tampered)
+		require.NoError(t, err)
+
+		require.EqualError(t, v.Verify(tamperedDoc, ldtestutil.WithDocumentLoader(t)), "ed25519: invalid signature")
+	})
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		tampered := copyDoc(t, doc)
+		tampered["proof"].([]interface{})[0].(map[string]interface{})["cryptosuite"] = "ecdsa-rdfc-2019"
+
+		tamperedDoc, err := json.Marshal(tampered)
+		require.NoError(t, err)
+
+		require.EqualError(t, v.Verify(tamperedDoc, ldtestutil.WithDocumentLoader(t)),
+			"signature type DataIntegrityProof with cryptosuite ecdsa-rdfc-2019 not supported")
+	})
+}
+
+func copyDoc(t *testing.T, doc map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	docBytes, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var docCopy map[string]interface{}
+	require.NoError(t, json.Unmarshal(docBytes, &docCopy))
+
+	return docCopy
+}
+
+type keyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *keyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
@@ -281,26 +281,44 @@ func (sv *ECDSASignatureVerifier) Verify(pubKey *PublicKey, msg, signature []byt
 }
 
 func (sv *ECDSASignatureVerifier) createJWK(pubKeyBytes []byte) (*jwk.JWK, error) {
+	ecdsaPubKey, err := sv.parsePublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwk.JWK{
+		JSONWebKey: gojose.JSONWebKey{
+			Key:       ecdsaPubKey,
+			Algorithm: sv.algorithm,
+		},
+		Kty: sv.keyType,
+		Crv: sv.curve,
+	}, nil
+}
+
+// parsePublicKey parses the marshalled point pubKeyBytes, secp256k1 points being either compressed (e.g. in did:ethr
+// and did:ion documents) or uncompressed.
+func (sv *ECDSASignatureVerifier) parsePublicKey(pubKeyBytes []byte) (*ecdsa.PublicKey, error) {
 	curve := sv.ec.curve
 
+	if curve == btcec.S256() {
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, errors.New("invalid public key")
+		}
+
+		return pubKey.ToECDSA(), nil
+	}
+
 	x, y := elliptic.Unmarshal(curve, pubKeyBytes)
 	if x == nil {
 		return nil, errors.New("invalid public key")
 	}
 
-	ecdsaPubKey := &ecdsa.PublicKey{
+	return &ecdsa.PublicKey{
 		Curve: curve,
 		X:     x,
 		Y:     y,
-	}
-
-	return &jwk.JWK{
-		JSONWebKey: gojose.JSONWebKey{
-			Key:       ecdsaPubKey,
-			Algorithm: sv.algorithm,
-		},
-		Kty: sv.keyType,
-		Crv: sv.curve,
 	}, nil
 }
 
@@ -8,6 +8,7 @@ package verifier
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"encoding/base64"
@@ -315,6 +316,24 @@ func TestNewECDSAES256SignatureVerifier(t *testing.T) {
 		require.NoError(t, verifyError)
 	})
 
+	t.Run("verify with compressed secp256k1 public key bytes", func(t *testing.T) {
+		secp256k1Signer, err := newCryptoSigner(kmsapi.ECDSASecp256k1TypeIEEEP1363)
+		require.NoError(t, err)
+
+		secp256k1Sig, err := secp256k1Signer.Sign(msg)
+		require.NoError(t, err)
+
+		pubKey, ok := secp256k1Signer.PublicKey().(*ecdsa.PublicKey)
+		require.True(t, ok)
+
+		verifyError := NewECDSASecp256k1SignatureVerifier().Verify(&PublicKey{
+			Type:  "EcdsaSecp256k1VerificationKey2019",
+			Value: (*btcec.PublicKey)(pubKey).SerializeCompressed(),
+		}, msg, secp256k1Sig)
+
+		require.NoError(t, verifyError)
+	})
+
 	t.Run("invalid public key", func(t *testing.T) {
 		verifyError := v.Verify(&PublicKey{
 			Type:  "JwsVerificationKey2020",
@@ -34,6 +34,13 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuite is implemented by the Data Integrity signature suites, the proofs of which hold the name of the
+// cryptosuite.
+type cryptosuite interface {
+	// Cryptosuite returns the name of the cryptosuite
+	Cryptosuite() string
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,7 +103,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p)
 		if err != nil {
 			return err
 		}
@@ -120,15 +127,25 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and, for Data Integrity proofs, cryptosuite.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof) (SignatureSuite, error) {
 	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(p.Type) {
+			continue
+		}
+
+		if cs, ok := s.(cryptosuite); ok && cs.Cryptosuite() != p.Cryptosuite {
+			continue
 		}
+
+		return s, nil
+	}
+
+	if p.Cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package timestamp obtains RFC 3161 time-stamp tokens from a time-stamping authority (TSA) and verifies them, as
+// long-term evidence that some data (eg. an issued credential) existed at the time of the token.
+package timestamp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"time"
+
+	// register the hash functions of the message imprints.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+const (
+	requestContentType  = "application/timestamp-query"
+	responseContentType = "application/timestamp-reply"
+
+	// statusGranted and statusGrantedWithMods are the PKIStatus values of the responses holding a token.
+	statusGranted         = 0
+	statusGrantedWithMods = 1
+
+	nonceBits = 64
+)
+
+// ErrInvalidToken is returned when a time-stamp token can't be parsed or doesn't match the time-stamped data.
+var ErrInvalidToken = errors.New("invalid time-stamp token")
+
+// Timestamp is an RFC 3161 time-stamp token of some data, stored alongside the data.
+type Timestamp struct {
+	// Token is the DER encoded TimeStampToken (a CMS SignedData signed by the TSA).
+	Token []byte `json:"token"`
+	// Time is the time at which the TSA time-stamped the data.
+	Time time.Time `json:"time"`
+	// Authority is the URL of the TSA.
+	Authority string `json:"authority,omitempty"`
+}
+
+// Stamper obtains time-stamp tokens of data.
+type Stamper interface {
+	Timestamp(data []byte) (*Timestamp, error)
+}
+
+// ClientOpt configures the Client.
+type ClientOpt func(c *Client)
+
+// WithHTTPClient sets the HTTP client sending the requests to the TSA.
+func WithHTTPClient(httpClient *http.Client) ClientOpt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHash sets the hash function of the message imprints sent to the TSA, crypto.SHA256 by default. Only the SHA-2
+// functions are supported.
+func WithHash(hash crypto.Hash) ClientOpt {
+	return func(c *Client) {
+		c.hash = hash
+	}
+}
+
+// WithPolicy requests the TSA to time-stamp with the given policy.
+func WithPolicy(policy asn1.ObjectIdentifier) ClientOpt {
+	return func(c *Client) {
+		c.policy = policy
+	}
+}
+
+// Client is a Stamper requesting the tokens to a TSA over HTTP (RFC 3161 section 3.4).
+type Client struct {
+	url        string
+	httpClient *http.Client
+	hash       crypto.Hash
+	policy     asn1.ObjectIdentifier
+}
+
+// NewClient returns a new client of the TSA at the given URL.
+func NewClient(url string, opts ...ClientOpt) *Client {
+	c := &Client{
+		url:        url,
+		httpClient: http.DefaultClient,
+		hash:       crypto.SHA256,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Timestamp requests a time-stamp token of the data, checking that the token returned by the TSA time-stamps the data.
+func (c *Client) Timestamp(data []byte) (*Timestamp, error) {
+	hashOID, ok := hashOIDs[c.hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function %s", c.hash)
+	}
+
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), nonceBits))
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	req, err := asn1.Marshal(timeStampReq{
+		Version: 1,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: hashOID},
+			HashedMessage: digest(c.hash, data),
+		},
+		ReqPolicy: c.policy,
+		Nonce:     nonce,
+		CertReq:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal time-stamp request: %w", err)
+	}
+
+	token, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = info.checkImprint(data); err != nil {
+		return nil, err
+	}
+
+	if info.Nonce == nil || info.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	return &Timestamp{Token: token, Time: info.GenTime.UTC(), Authority: c.url}, nil
+}
+
+func (c *Client) send(req []byte) ([]byte, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(req))
+	if err != nil {
+		return nil, fmt.Errorf("create TSA request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", requestContentType)
+	httpReq.Header.Set("Accept", responseContentType)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("send TSA request: %w", err)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read TSA response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TSA request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tsResp timeStampResp
+
+	if _, err = asn1.Unmarshal(body, &tsResp); err != nil {
+		return nil, fmt.Errorf("unmarshal TSA response: %w", err)
+	}
+
+	if tsResp.Status.Status != statusGranted && tsResp.Status.Status != statusGrantedWithMods {
+		return nil, fmt.Errorf("TSA rejected the request with status %d: %v", tsResp.Status.Status,
+			tsResp.Status.StatusString)
+	}
+
+	if len(tsResp.TimeStampToken.Raw) == 0 {
+		return nil, fmt.Errorf("%w: missing from the TSA response", ErrInvalidToken)
+	}
+
+	return tsResp.TimeStampToken.Raw, nil
+}
+
+// Verify checks that the token time-stamps the data at the time of the Timestamp and that it is signed by a TSA
+// certificate chaining up to the given roots, nil roots using the system roots.
+func (t *Timestamp) Verify(data []byte, roots *x509.CertPool) error {
+	info, signed, err := parseToken(t.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = info.checkImprint(data); err != nil {
+		return err
+	}
+
+	if !info.GenTime.Equal(t.Time) {
+		return fmt.Errorf("%w: time %s doesn't match the token time %s", ErrInvalidToken, t.Time, info.GenTime)
+	}
+
+	return signed.verify(info.GenTime, roots)
+}
+
+func digest(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data) // nolint: errcheck, gosec
+
+	return h.Sum(nil)
+}
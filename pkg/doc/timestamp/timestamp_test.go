@@ -0,0 +1,364 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timestamp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Timestamp(t *testing.T) {
+	data := []byte(`{"id":"urn:uuid:credential"}`)
+
+	t.Run("success", func(t *testing.T) {
+		tsa := newMockTSA(t)
+
+		ts, err := NewClient(tsa.server.URL, WithHTTPClient(tsa.server.Client())).Timestamp(data)
+		require.NoError(t, err)
+		require.Equal(t, tsa.server.URL, ts.Authority)
+		require.Equal(t, tsa.genTime, ts.Time)
+		require.Equal(t, crypto.SHA256, tsa.hash)
+
+		require.NoError(t, ts.Verify(data, tsa.roots))
+	})
+
+	t.Run("success with SHA-384 and a policy", func(t *testing.T) {
+		tsa := newMockTSA(t)
+
+		ts, err := NewClient(tsa.server.URL, WithHash(crypto.SHA384),
+			WithPolicy(asn1.ObjectIdentifier{1, 2, 3, 4})).Timestamp(data)
+		require.NoError(t, err)
+		require.Equal(t, crypto.SHA384, tsa.hash)
+		require.Equal(t, asn1.ObjectIdentifier{1, 2, 3, 4}, tsa.policy)
+
+		require.NoError(t, ts.Verify(data, tsa.roots))
+	})
+
+	t.Run("unsupported hash", func(t *testing.T) {
+		_, err := NewClient("https://tsa.example.com", WithHash(crypto.MD5)).Timestamp(data)
+		require.EqualError(t, err, "unsupported hash function MD5")
+	})
+
+	t.Run("TSA errors", func(t *testing.T) {
+		tsa := newMockTSA(t)
+
+		tsa.httpStatus = http.StatusInternalServerError
+		_, err := NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "TSA request failed with status 500: ")
+
+		tsa.httpStatus = http.StatusOK
+		tsa.status = 2
+		_, err = NewClient(tsa.server.URL).Timestamp(data)
+		require.EqualError(t, err, "TSA rejected the request with status 2: [bad request]")
+
+		_, err = NewClient("http://localhost:1").Timestamp(data)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send TSA request")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("invalid")) // nolint: errcheck, gosec
+		}))
+		defer server.Close()
+
+		_, err := NewClient(server.URL).Timestamp(data)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal TSA response")
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		tsa := newMockTSA(t)
+		tsa.nonce = big.NewInt(1)
+
+		_, err := NewClient(tsa.server.URL).Timestamp(data)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "nonce mismatch")
+	})
+
+	t.Run("message imprint mismatch", func(t *testing.T) {
+		tsa := newMockTSA(t)
+		tsa.imprint = make([]byte, 32)
+
+		_, err := NewClient(tsa.server.URL).Timestamp(data)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "message imprint doesn't match the data")
+	})
+}
+
+func TestTimestamp_Verify(t *testing.T) {
+	data := []byte(`{"id":"urn:uuid:credential"}`)
+
+	tsa := newMockTSA(t)
+
+	ts, err := NewClient(tsa.server.URL).Timestamp(data)
+	require.NoError(t, err)
+
+	t.Run("other data", func(t *testing.T) {
+		err := ts.Verify([]byte("other"), tsa.roots)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "message imprint doesn't match the data")
+	})
+
+	t.Run("other time", func(t *testing.T) {
+		other := *ts
+		other.Time = ts.Time.Add(-time.Hour)
+
+		err := other.Verify(data, tsa.roots)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "doesn't match the token time")
+	})
+
+	t.Run("untrusted TSA", func(t *testing.T) {
+		err := ts.Verify(data, newMockTSA(t).roots)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "TSA certificate")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		other := newMockTSA(t)
+		other.wrongKey = true
+
+		forged, err := NewClient(other.server.URL).Timestamp(data)
+		require.NoError(t, err)
+
+		err = forged.Verify(data, other.roots)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+		require.Contains(t, err.Error(), "signature")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		err := (&Timestamp{Token: []byte("invalid")}).Verify(data, tsa.roots)
+		require.True(t, errors.Is(err, ErrInvalidToken))
+
+		err = (&Timestamp{Token: append(append([]byte{}, ts.Token...), 0)}).Verify(data, tsa.roots)
+		require.EqualError(t, err, "invalid time-stamp token: trailing data")
+	})
+}
+
+// mockTSA is a time-stamping authority signing the tokens with an ECDSA key certified by its own root.
+type mockTSA struct {
+	server  *httptest.Server
+	roots   *x509.CertPool
+	key     *ecdsa.PrivateKey
+	cert    *x509.Certificate
+	genTime time.Time
+	// received
+	hash   crypto.Hash
+	policy asn1.ObjectIdentifier
+	// misbehaviours
+	httpStatus int
+	status     int
+	nonce      *big.Int
+	imprint    []byte
+	wrongKey   bool
+}
+
+func newMockTSA(t *testing.T) *mockTSA {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "TSA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	tsa := &mockTSA{
+		roots:      x509.NewCertPool(),
+		key:        key,
+		cert:       cert,
+		genTime:    time.Now().UTC().Truncate(time.Second),
+		httpStatus: http.StatusOK,
+	}
+
+	tsa.roots.AddCert(ca)
+
+	tsa.server = httptest.NewServer(tsa)
+	t.Cleanup(tsa.server.Close)
+
+	return tsa
+}
+
+func (m *mockTSA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.httpStatus != http.StatusOK {
+		w.WriteHeader(m.httpStatus)
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || r.Header.Get("Content-Type") != requestContentType {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	var req timeStampReq
+
+	if _, err = asn1.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	m.hash, _ = hashFromOID(req.MessageImprint.HashAlgorithm.Algorithm) // nolint: errcheck
+	m.policy = req.ReqPolicy
+
+	resp := timeStampResp{Status: pkiStatusInfo{Status: m.status}}
+
+	if m.status != statusGranted {
+		resp.Status.StatusString = []string{"bad request"}
+	} else {
+		resp.TimeStampToken.Raw, err = m.sign(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	respDER, err := asn1.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", responseContentType)
+	w.Write(respDER) // nolint: errcheck, gosec
+}
+
+func (m *mockTSA) sign(req *timeStampReq) ([]byte, error) {
+	info := tstInfo{
+		Version:        1,
+		Policy:         asn1.ObjectIdentifier{1, 2, 3},
+		MessageImprint: req.MessageImprint,
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		GenTime:        m.genTime,
+		Nonce:          req.Nonce,
+	}
+
+	if m.nonce != nil {
+		info.Nonce = m.nonce
+	}
+
+	if m.imprint != nil {
+		info.MessageImprint.HashedMessage = m.imprint
+	}
+
+	eContent, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, err := asn1.Marshal(oidTSTInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDigest, err := asn1.Marshal(digest(crypto.SHA256, eContent))
+	if err != nil {
+		return nil, err
+	}
+
+	signedAttrs, err := asn1.MarshalWithParams([]attribute{
+		{Type: oidContentType, Values: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: contentType}},
+		{Type: oidMessageDigest, Values: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: messageDigest}},
+	}, "set")
+	if err != nil {
+		return nil, err
+	}
+
+	key := m.key
+
+	if m.wrongKey {
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+	}
+
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest(crypto.SHA256, signedAttrs))
+	if err != nil {
+		return nil, err
+	}
+
+	sid, err := asn1.Marshal(issuerAndSerialNumber{
+		Issuer:       asn1.RawValue{FullBytes: m.cert.RawIssuer},
+		SerialNumber: m.cert.SerialNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: m.cert.Raw})
+	if err != nil {
+		return nil, err
+	}
+
+	sha256 := pkix.AlgorithmIdentifier{Algorithm: hashOIDs[crypto.SHA256]}
+
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content: signedData{
+			Version:          3,
+			DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256},
+			EncapContentInfo: encapContentInfo{EContentType: oidTSTInfo, EContent: eContent},
+			Certificates:     rawContent{Raw: certs},
+			SignerInfos: []signerInfo{{
+				Version:            1,
+				SID:                asn1.RawValue{FullBytes: sid},
+				DigestAlgorithm:    sha256,
+				SignedAttrs:        rawContent{Raw: signedAttrs},
+				SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+				Signature:          signature,
+			}},
+		},
+	})
+}
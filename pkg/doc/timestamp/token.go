@@ -0,0 +1,363 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timestamp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// nolint: gochecknoglobals
+var (
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidRSAPSS        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+	hashOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+		crypto.SHA256: {2, 16, 840, 1, 101, 3, 4, 2, 1},
+		crypto.SHA384: {2, 16, 840, 1, 101, 3, 4, 2, 2},
+		crypto.SHA512: {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	}
+)
+
+// rawContent keeps the DER encoding of an element, including its tag.
+type rawContent struct {
+	Raw asn1.RawContent
+}
+
+// RFC 3161 section 2.4.1.
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+	Nonce          *big.Int              `asn1:"optional"`
+	CertReq        bool                  `asn1:"optional"`
+	Extensions     []pkix.Extension      `asn1:"optional,tag:0"`
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+// RFC 3161 section 2.4.2.
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken rawContent `asn1:"optional"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []string       `asn1:"optional"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+// RFC 3161 section 2.4.2.
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time        `asn1:"generalized"`
+	Accuracy       accuracy         `asn1:"optional"`
+	Ordering       bool             `asn1:"optional"`
+	Nonce          *big.Int         `asn1:"optional"`
+	TSA            rawContent       `asn1:"optional,tag:0"`
+	Extensions     []pkix.Extension `asn1:"optional,tag:1"`
+}
+
+type accuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+// RFC 5652 sections 3 and 5.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     signedData `asn1:"explicit,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapContentInfo
+	Certificates     rawContent   `asn1:"optional,tag:0"`
+	CRLs             rawContent   `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo `asn1:"set"`
+}
+
+type encapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"optional,explicit,tag:0"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        rawContent `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      rawContent `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// parseToken parses the TimeStampToken, returning its TSTInfo and its SignedData.
+func parseToken(token []byte) (*tstInfo, *signedData, error) {
+	var ci contentInfo
+
+	rest, err := asn1.Unmarshal(token, &ci)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	if len(rest) > 0 {
+		return nil, nil, fmt.Errorf("%w: trailing data", ErrInvalidToken)
+	}
+
+	if !ci.ContentType.Equal(oidSignedData) || !ci.Content.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, nil, fmt.Errorf("%w: not a signed TSTInfo", ErrInvalidToken)
+	}
+
+	info := &tstInfo{}
+
+	rest, err = asn1.Unmarshal(ci.Content.EncapContentInfo.EContent, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: TSTInfo: %s", ErrInvalidToken, err)
+	}
+
+	if len(rest) > 0 {
+		return nil, nil, fmt.Errorf("%w: TSTInfo: trailing data", ErrInvalidToken)
+	}
+
+	return info, &ci.Content, nil
+}
+
+// checkImprint checks that the message imprint of the TSTInfo is the digest of the data.
+func (info *tstInfo) checkImprint(data []byte) error {
+	hash, err := hashFromOID(info.MessageImprint.HashAlgorithm.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest(hash, data), info.MessageImprint.HashedMessage) {
+		return fmt.Errorf("%w: message imprint doesn't match the data", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// verify checks the signature of the TSTInfo and that the certificate of the signer is a time-stamping certificate
+// chaining up to the roots at the time of the token.
+func (sd *signedData) verify(genTime time.Time, roots *x509.CertPool) error {
+	if len(sd.SignerInfos) != 1 {
+		return fmt.Errorf("%w: expected one signer, got %d", ErrInvalidToken, len(sd.SignerInfos))
+	}
+
+	si := sd.SignerInfos[0]
+
+	certs, err := sd.certificates()
+	if err != nil {
+		return err
+	}
+
+	signer, err := si.findCertificate(certs)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashFromOID(si.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	signedAttrs, err := si.checkSignedAttrs(hash, sd.EncapContentInfo.EContent)
+	if err != nil {
+		return err
+	}
+
+	algorithm, err := signatureAlgorithm(signer.PublicKey, hash, si.SignatureAlgorithm.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	if err = signer.CheckSignature(algorithm, signedAttrs, si.Signature); err != nil {
+		return fmt.Errorf("%w: signature: %s", ErrInvalidToken, err)
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = signer.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   genTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: TSA certificate: %s", ErrInvalidToken, err)
+	}
+
+	return nil
+}
+
+func (sd *signedData) certificates() ([]*x509.Certificate, error) {
+	if len(sd.Certificates.Raw) == 0 {
+		return nil, fmt.Errorf("%w: missing TSA certificate", ErrInvalidToken)
+	}
+
+	var raw asn1.RawValue
+
+	if _, err := asn1.Unmarshal(sd.Certificates.Raw, &raw); err != nil {
+		return nil, fmt.Errorf("%w: certificates: %s", ErrInvalidToken, err)
+	}
+
+	certs, err := x509.ParseCertificates(raw.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: certificates: %s", ErrInvalidToken, err)
+	}
+
+	return certs, nil
+}
+
+// findCertificate returns the certificate identified by the signer identifier, either an issuer and serial number or
+// a subject key identifier.
+func (si *signerInfo) findCertificate(certs []*x509.Certificate) (*x509.Certificate, error) {
+	var match func(cert *x509.Certificate) bool
+
+	switch {
+	case si.SID.Class == asn1.ClassUniversal && si.SID.Tag == asn1.TagSequence:
+		var ias issuerAndSerialNumber
+
+		if _, err := asn1.Unmarshal(si.SID.FullBytes, &ias); err != nil {
+			return nil, fmt.Errorf("%w: signer identifier: %s", ErrInvalidToken, err)
+		}
+
+		match = func(cert *x509.Certificate) bool {
+			return bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) && cert.SerialNumber.Cmp(ias.SerialNumber) == 0
+		}
+	case si.SID.Class == asn1.ClassContextSpecific && si.SID.Tag == 0:
+		match = func(cert *x509.Certificate) bool {
+			return bytes.Equal(cert.SubjectKeyId, si.SID.Bytes)
+		}
+	default:
+		return nil, fmt.Errorf("%w: invalid signer identifier", ErrInvalidToken)
+	}
+
+	for _, cert := range certs {
+		if match(cert) {
+			return cert, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: missing signer certificate", ErrInvalidToken)
+}
+
+// checkSignedAttrs checks the content type and message digest attributes, returning the DER encoding of the signed
+// attributes covered by the signature.
+func (si *signerInfo) checkSignedAttrs(hash crypto.Hash, content []byte) ([]byte, error) {
+	if len(si.SignedAttrs.Raw) == 0 {
+		return nil, fmt.Errorf("%w: missing signed attributes", ErrInvalidToken)
+	}
+
+	// the signature covers the SET OF encoding of the attributes, not their implicitly tagged encoding
+	signedAttrs := append([]byte{}, si.SignedAttrs.Raw...)
+	signedAttrs[0] = asn1.TagSet | 0x20 // nolint: gomnd
+
+	var attrs []attribute
+
+	if _, err := asn1.UnmarshalWithParams(signedAttrs, &attrs, "set"); err != nil {
+		return nil, fmt.Errorf("%w: signed attributes: %s", ErrInvalidToken, err)
+	}
+
+	var contentType asn1.ObjectIdentifier
+
+	var messageDigest []byte
+
+	for _, attr := range attrs {
+		var err error
+
+		switch {
+		case attr.Type.Equal(oidContentType):
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &contentType)
+		case attr.Type.Equal(oidMessageDigest):
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &messageDigest)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: signed attribute %s: %s", ErrInvalidToken, attr.Type, err)
+		}
+	}
+
+	if !contentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("%w: invalid content type attribute", ErrInvalidToken)
+	}
+
+	if !bytes.Equal(messageDigest, digest(hash, content)) {
+		return nil, fmt.Errorf("%w: message digest attribute doesn't match the TSTInfo", ErrInvalidToken)
+	}
+
+	return signedAttrs, nil
+}
+
+func hashFromOID(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	for hash, hashOID := range hashOIDs {
+		if oid.Equal(hashOID) {
+			return hash, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: unsupported hash algorithm %s", ErrInvalidToken, oid)
+}
+
+func signatureAlgorithm(pub crypto.PublicKey, hash crypto.Hash,
+	oid asn1.ObjectIdentifier) (x509.SignatureAlgorithm, error) {
+	algorithms := map[crypto.Hash][3]x509.SignatureAlgorithm{
+		crypto.SHA256: {x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256},
+		crypto.SHA384: {x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384},
+		crypto.SHA512: {x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512},
+	}[hash]
+
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if oid.Equal(oidRSAPSS) {
+			return algorithms[1], nil
+		}
+
+		return algorithms[0], nil
+	case *ecdsa.PublicKey:
+		return algorithms[2], nil
+	case ed25519.PublicKey:
+		return x509.PureEd25519, nil
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("%w: unsupported TSA key type %T", ErrInvalidToken, pub)
+	}
+}
@@ -89,13 +89,16 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jwk.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa DER key: %w", err)
 		}
-		// TODO remove `case kms.ED25519Type` in CreateKID() and uncomment below case when go-jose fixes Ed25519
-		//      JWK thumbprint. Also remove `createED25519KID(keyBytes []byte)` function further below.
-	// case kms.ED25519Type:
-	//	j, err = jwksupport.JWKFromKey(ed25519.PublicKey(keyBytes))
-	//	if err != nil {
-	//		return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
-	//	}
+	case kms.ED25519Type:
+		j, err = jwksupport.JWKFromKey(ed25519.PublicKey(keyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
+		}
+	case kms.BLS12381G2Type:
+		j, err = jwksupport.PubKeyBytesToJWK(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from BBS+ key: %w", err)
+		}
 	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
 		c := getCurveByKMSKeyType(kt)
 		x, y := elliptic.Unmarshal(c, keyBytes)
@@ -110,6 +113,11 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jwk.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa key in IEEE1363 format: %w", err)
 		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		j, err = jwksupport.PubKeyBytesToJWK(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from secp256k1 key: %w", err)
+		}
 	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
 		j, err = generateJWKFromECDH(keyBytes)
 		if err != nil {
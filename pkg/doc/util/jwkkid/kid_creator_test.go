@@ -39,12 +39,17 @@ func TestCreateKID(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, kid)
 
-	// now try building go-jose thumbprint and compare its base64URL with kid above
-	// they should not match since go-jose's thumbprint is built from a wrong Ed25519 JWK.
+	// the JWK thumbprint must match the kid above.
 	j, err := jwksupport.JWKFromKey(pubKey)
 	require.NoError(t, err)
 
-	goJoseTP, err := j.Thumbprint(crypto.SHA256)
+	tp, err := j.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp))
+
+	// now try building go-jose thumbprint and compare its base64URL with kid above
+	// they should not match since go-jose's thumbprint is built from a wrong Ed25519 JWK.
+	goJoseTP, err := j.JSONWebKey.Thumbprint(crypto.SHA256)
 	require.NoError(t, err)
 
 	goJoseKID := base64.RawURLEncoding.EncodeToString(goJoseTP)
@@ -272,3 +277,30 @@ func TestCreateBLS12381G2KID(t *testing.T) {
 	_, err = CreateKID(append(pubKeyBytes, []byte("larger key")...), kms.BLS12381G2Type)
 	require.EqualError(t, err, "createKID: invalid BBS+ key")
 }
+
+func TestBuildJWKThumbprintMatchesKID(t *testing.T) {
+	ed25519PubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecDERKey, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	for kt, keyBytes := range map[kms.KeyType][]byte{
+		kms.ED25519Type:            ed25519PubKey,
+		kms.ECDSAP256TypeIEEEP1363: elliptic.Marshal(ecKey.Curve, ecKey.X, ecKey.Y),
+		kms.ECDSAP256TypeDER:       ecDERKey,
+	} {
+		kid, err := CreateKID(keyBytes, kt)
+		require.NoError(t, err)
+
+		j, err := BuildJWK(keyBytes, kt)
+		require.NoError(t, err)
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp), kt)
+	}
+}
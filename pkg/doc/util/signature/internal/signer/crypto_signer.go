@@ -14,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -116,6 +118,14 @@ func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, erro
 			Y:     y,
 		}, nil
 
+	case kmsapi.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("parse secp256k1 public key: %w", err)
+		}
+
+		return pubKey.ToECDSA(), nil
+
 	case kmsapi.ED25519Type:
 		return ed25519.PublicKey(pubKeyBytes), nil
 
@@ -26,8 +26,6 @@ import (
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
-// TODO https://github.com/square/go-jose/issues/263 support ES256K
-
 // JWSAlgorithm defines JWT signature algorithms of Verifiable Credential.
 type JWSAlgorithm int
 
@@ -37,6 +35,9 @@ const (
 
 	// EdDSA JWT Algorithm.
 	EdDSA
+
+	// ES256K JWT Algorithm (ECDSA secp256k1 with SHA-256).
+	ES256K
 )
 
 // name return the name of the signature algorithm.
@@ -46,6 +47,8 @@ func (ja JWSAlgorithm) name() (string, error) {
 		return "RS256", nil
 	case EdDSA:
 		return "EdDSA", nil
+	case ES256K:
+		return "ES256K", nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
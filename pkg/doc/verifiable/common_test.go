@@ -27,6 +27,10 @@ func TestJwtAlgorithm_Name(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "EdDSA", alg)
 
+	alg, err = ES256K.name()
+	require.NoError(t, err)
+	require.Equal(t, "ES256K", alg)
+
 	// not supported alg
 	sa, err := JWSAlgorithm(-1).name()
 	require.Error(t, err)
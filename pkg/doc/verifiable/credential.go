@@ -574,10 +574,16 @@ type credentialOpts struct {
 	disabledProofCheck    bool
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
+	sdJWTKeyBinding       *sdJWTKeyBindingOpts
 
 	jsonldCredentialOpts
 }
 
+type sdJWTKeyBindingOpts struct {
+	audience string
+	nonce    string
+}
+
 // CredentialOpt is the Verifiable Credential decoding option.
 type CredentialOpt func(opts *credentialOpts)
 
@@ -588,6 +594,14 @@ func WithDisabledProofCheck() CredentialOpt {
 	}
 }
 
+// WithExpectedSDJWTKeyBinding option requires the SD-JWT credential to be presented with a key binding JWT issued
+// for the given audience and nonce.
+func WithExpectedSDJWTKeyBinding(audience, nonce string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.sdJWTKeyBinding = &sdJWTKeyBindingOpts{audience: audience, nonce: nonce}
+	}
+}
+
 // WithNoCustomSchemaCheck option is for disabling of Credential Schemas download if defined
 // in Verifiable Credential. Instead, the Verifiable Credential is checked against default Schema.
 func WithNoCustomSchemaCheck() CredentialOpt {
@@ -1007,6 +1021,19 @@ func parseTypedID(bytes json.RawMessage) ([]TypedID, error) {
 func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 	vcStr := string(vcData)
 
+	if IsSDJWT(vcStr) { // External proof, is checked by the issuer signed JWT.
+		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
+			return nil, errors.New("public key fetcher is not defined")
+		}
+
+		vcDecodedBytes, err := decodeCredSDJWT(vcStr, vcOpts)
+		if err != nil {
+			return nil, fmt.Errorf("SD-JWT decoding: %w", err)
+		}
+
+		return vcDecodedBytes, nil
+	}
+
 	if jwt.IsJWS(vcStr) { // External proof, is checked by JWS.
 		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
 			return nil, errors.New("public key fetcher is not defined")
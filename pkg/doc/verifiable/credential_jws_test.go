@@ -15,8 +15,11 @@ import (
 	"github.com/square/go-jose/v3/jwt"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 )
 
 func TestJWTCredClaimsMarshalJWS(t *testing.T) {
@@ -50,6 +53,46 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 	})
 }
 
+func TestJWTCredClaimsMarshalJWS_ES256K(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	jws, err := jwtClaims.MarshalJWS(ES256K, signer, "#key-1")
+	require.NoError(t, err)
+
+	// the issuer key is a JsonWebKey2020 verification method of its DID, as in the did:ethr and did:ion documents
+	j, err := jwksupport.JWKFromKey(signer.PublicKey())
+	require.NoError(t, err)
+
+	vm, err := did.NewVerificationMethodFromJWK(vc.Issuer.ID+"#key-1", "JsonWebKey2020", vc.Issuer.ID, j)
+	require.NoError(t, err)
+
+	resolver := NewVDRKeyResolver(&mockvdr.MockVDRegistry{
+		ResolveValue: &did.Doc{ID: vc.Issuer.ID, VerificationMethod: []did.VerificationMethod{*vm}},
+	})
+
+	parsedVC, err := parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(resolver.PublicKeyFetcher()))
+	require.NoError(t, err)
+	require.Equal(t, vc.ID, parsedVC.ID)
+
+	// signed by another key
+	otherSigner, err := newCryptoSigner(kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	jws, err = jwtClaims.MarshalJWS(ES256K, otherSigner, "#key-1")
+	require.NoError(t, err)
+
+	_, err = parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(resolver.PublicKeyFetcher()))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ecdsa: invalid signature")
+}
+
 type invalidCredClaims struct {
 	*jwt.Claims
 
@@ -0,0 +1,569 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	// SDJWTHashAlgSHA256 is the hash algorithm used to compute the digests of the SD-JWT disclosures.
+	SDJWTHashAlgSHA256 = "sha-256"
+
+	// SDJWTKeyBindingType is the "typ" header of the key binding JWT appended by the holder to an SD-JWT.
+	SDJWTKeyBindingType = "kb+jwt"
+
+	sdJWTSeparator   = "~"
+	sdClaim          = "_sd"
+	sdSaltSize       = 16
+	disclosureLength = 3
+
+	vcSubjectField = "credentialSubject"
+)
+
+// SDJWTDisclosure is a claim disclosure of an SD-JWT (Selective Disclosure JWT).
+type SDJWTDisclosure struct {
+	// Salt of the disclosure.
+	Salt string
+	// Name of the disclosed claim.
+	Name string
+	// Value of the disclosed claim.
+	Value interface{}
+	// Encoded disclosure as it appears in the SD-JWT.
+	Encoded string
+}
+
+// sdJWTCredClaims are the claims of the issuer signed JWT of a VC SD-JWT.
+type sdJWTCredClaims struct {
+	*jwt.Claims
+
+	VC    map[string]interface{} `json:"vc,omitempty"`
+	SDAlg string                 `json:"_sd_alg,omitempty"`
+	CNF   *sdJWTConfirmation     `json:"cnf,omitempty"`
+}
+
+// sdJWTConfirmation holds the public key of the holder the SD-JWT is bound to.
+type sdJWTConfirmation struct {
+	JWK *jwk.JWK `json:"jwk,omitempty"`
+}
+
+// sdJWTKeyBindingClaims are the claims of the key binding JWT.
+type sdJWTKeyBindingClaims struct {
+	IssuedAt *josejwt.NumericDate `json:"iat,omitempty"`
+	Audience string               `json:"aud,omitempty"`
+	Nonce    string               `json:"nonce,omitempty"`
+	SDHash   string               `json:"sd_hash,omitempty"`
+}
+
+type makeSDJWTOpts struct {
+	holderPublicKey *jwk.JWK
+	alwaysDisclosed map[string]bool
+}
+
+// MakeSDJWTOpt is the SD-JWT issuance option.
+type MakeSDJWTOpt func(opts *makeSDJWTOpts)
+
+// WithSDJWTHolderPublicKey binds the SD-JWT to the holder public key ("cnf" claim). The holder then has to prove the
+// possession of the key with a key binding JWT when presenting the credential.
+func WithSDJWTHolderPublicKey(key *jwk.JWK) MakeSDJWTOpt {
+	return func(opts *makeSDJWTOpts) {
+		opts.holderPublicKey = key
+	}
+}
+
+// WithSDJWTAlwaysDisclosed keeps the given credential subject claims in clear (not selectively disclosable).
+func WithSDJWTAlwaysDisclosed(claims ...string) MakeSDJWTOpt {
+	return func(opts *makeSDJWTOpts) {
+		for _, claim := range claims {
+			opts.alwaysDisclosed[claim] = true
+		}
+	}
+}
+
+// MakeSDJWT serializes the credential into the SD-JWT combined format
+// (<issuer-signed JWT>~<disclosure 1>~...~<disclosure N>~). Every top level claim of the credential subject
+// (but its "id") is replaced by the digest of its disclosure so that the holder can choose which claims to disclose.
+// See https://datatracker.ietf.org/doc/draft-ietf-oauth-selective-disclosure-jwt.
+func (vc *Credential) MakeSDJWT(signer Signer, signatureAlg JWSAlgorithm, keyID string,
+	opts ...MakeSDJWTOpt) (string, error) {
+	sdOpts := &makeSDJWTOpts{alwaysDisclosed: map[string]bool{}}
+
+	for _, opt := range opts {
+		opt(sdOpts)
+	}
+
+	credClaims, err := vc.JWTClaims(false)
+	if err != nil {
+		return "", fmt.Errorf("create JWT claims: %w", err)
+	}
+
+	var disclosures []string
+
+	switch subject := credClaims.VC[vcSubjectField].(type) {
+	case map[string]interface{}:
+		disclosures, err = makeSDClaims(subject, sdOpts.alwaysDisclosed)
+	case []interface{}:
+		for _, s := range subject {
+			if subjectMap, ok := s.(map[string]interface{}); ok {
+				var subjectDisclosures []string
+
+				subjectDisclosures, err = makeSDClaims(subjectMap, sdOpts.alwaysDisclosed)
+				if err != nil {
+					break
+				}
+
+				disclosures = append(disclosures, subjectDisclosures...)
+			}
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("create disclosures: %w", err)
+	}
+
+	sdClaims := &sdJWTCredClaims{
+		Claims: credClaims.Claims,
+		VC:     credClaims.VC,
+		SDAlg:  SDJWTHashAlgSHA256,
+	}
+
+	if sdOpts.holderPublicKey != nil {
+		sdClaims.CNF = &sdJWTConfirmation{JWK: sdOpts.holderPublicKey}
+	}
+
+	issuerJWT, err := marshalJWS(sdClaims, signatureAlg, signer, keyID)
+	if err != nil {
+		return "", fmt.Errorf("sign SD-JWT: %w", err)
+	}
+
+	return combineSDJWT(issuerJWT, disclosures), nil
+}
+
+// makeSDClaims replaces the claims of the object by the digests of their disclosures ("_sd" claim).
+func makeSDClaims(object map[string]interface{}, alwaysDisclosed map[string]bool) ([]string, error) {
+	var (
+		disclosures []string
+		digests     []string
+	)
+
+	for name, value := range object {
+		if name == vcIDField || name == sdClaim || alwaysDisclosed[name] {
+			continue
+		}
+
+		disclosure, err := encodeSDJWTDisclosure(name, value)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosures = append(disclosures, disclosure)
+		digests = append(digests, sdJWTDigest(disclosure))
+
+		delete(object, name)
+	}
+
+	if len(digests) == 0 {
+		return nil, nil
+	}
+
+	// sort the digests so that their order doesn't reveal the original order of the claims
+	sort.Strings(digests)
+	sort.Strings(disclosures)
+
+	object[sdClaim] = digests
+
+	return disclosures, nil
+}
+
+func encodeSDJWTDisclosure(name string, value interface{}) (string, error) {
+	salt := make([]byte, sdSaltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	raw, err := json.Marshal([]interface{}{base64.RawURLEncoding.EncodeToString(salt), name, value})
+	if err != nil {
+		return "", fmt.Errorf("marshal disclosure: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func sdJWTDigest(s string) string {
+	digest := sha256.Sum256([]byte(s))
+
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func combineSDJWT(issuerJWT string, disclosures []string) string {
+	parts := append([]string{issuerJWT}, disclosures...)
+
+	return strings.Join(parts, sdJWTSeparator) + sdJWTSeparator
+}
+
+// splitSDJWT splits the SD-JWT combined format into the issuer signed JWT, the disclosures and the key binding JWT.
+func splitSDJWT(sdJWT string) (issuerJWT string, disclosures []string, keyBindingJWT string) {
+	parts := strings.Split(sdJWT, sdJWTSeparator)
+
+	return parts[0], parts[1 : len(parts)-1], parts[len(parts)-1]
+}
+
+// IsSDJWT checks if the given string is an SD-JWT in combined format.
+func IsSDJWT(s string) bool {
+	if !strings.Contains(s, sdJWTSeparator) {
+		return false
+	}
+
+	issuerJWT, _, _ := splitSDJWT(s)
+
+	return jwt.IsJWS(issuerJWT)
+}
+
+// ParseSDJWTDisclosures returns the disclosures of the SD-JWT, eg: to let the holder choose which claims to disclose.
+func ParseSDJWTDisclosures(sdJWT string) ([]*SDJWTDisclosure, error) {
+	if !IsSDJWT(sdJWT) {
+		return nil, errors.New("not an SD-JWT")
+	}
+
+	_, encoded, _ := splitSDJWT(sdJWT)
+
+	disclosures := make([]*SDJWTDisclosure, 0, len(encoded))
+
+	for _, e := range encoded {
+		d, err := decodeSDJWTDisclosure(e)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosures = append(disclosures, d)
+	}
+
+	return disclosures, nil
+}
+
+func decodeSDJWTDisclosure(encoded string) (*SDJWTDisclosure, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode disclosure: %w", err)
+	}
+
+	var values []interface{}
+
+	if err = json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("unmarshal disclosure: %w", err)
+	}
+
+	if len(values) != disclosureLength {
+		return nil, fmt.Errorf("disclosure must be an array of %d elements", disclosureLength)
+	}
+
+	salt, ok := values[0].(string)
+	if !ok {
+		return nil, errors.New("disclosure salt must be a string")
+	}
+
+	name, ok := values[1].(string)
+	if !ok {
+		return nil, errors.New("disclosure claim name must be a string")
+	}
+
+	return &SDJWTDisclosure{Salt: salt, Name: name, Value: values[2], Encoded: encoded}, nil
+}
+
+// SDJWTHolderBinding contains the parameters of the key binding JWT proving the holder owns the key the SD-JWT
+// is bound to.
+type SDJWTHolderBinding struct {
+	// Signer signing with the private key matching the SD-JWT holder public key.
+	Signer Signer
+	// Algorithm of the signature.
+	Algorithm JWSAlgorithm
+	// Audience is the intended receiver (verifier) of the presentation.
+	Audience string
+	// Nonce provided by the verifier to ensure the freshness of the presentation.
+	Nonce string
+	// IssuedAt is the creation time of the key binding JWT. Optional, current time is used by default.
+	IssuedAt *time.Time
+}
+
+type sdJWTPresentationOpts struct {
+	holderBinding *SDJWTHolderBinding
+}
+
+// SDJWTPresentationOpt is the SD-JWT presentation option.
+type SDJWTPresentationOpt func(opts *sdJWTPresentationOpts)
+
+// WithSDJWTHolderBinding appends a key binding JWT to the presented SD-JWT.
+func WithSDJWTHolderBinding(binding *SDJWTHolderBinding) SDJWTPresentationOpt {
+	return func(opts *sdJWTPresentationOpts) {
+		opts.holderBinding = binding
+	}
+}
+
+// CreateSDJWTPresentation creates the SD-JWT sent by the holder to a verifier, it only keeps the disclosures of the
+// given claims.
+func CreateSDJWTPresentation(sdJWT string, claims []string, opts ...SDJWTPresentationOpt) (string, error) {
+	presOpts := &sdJWTPresentationOpts{}
+
+	for _, opt := range opts {
+		opt(presOpts)
+	}
+
+	disclosures, err := ParseSDJWTDisclosures(sdJWT)
+	if err != nil {
+		return "", fmt.Errorf("parse SD-JWT disclosures: %w", err)
+	}
+
+	disclosed := make(map[string]bool, len(claims))
+	for _, claim := range claims {
+		disclosed[claim] = true
+	}
+
+	var selected []string
+
+	for _, d := range disclosures {
+		if disclosed[d.Name] {
+			selected = append(selected, d.Encoded)
+		}
+	}
+
+	issuerJWT, _, _ := splitSDJWT(sdJWT)
+
+	presentation := combineSDJWT(issuerJWT, selected)
+
+	if presOpts.holderBinding == nil {
+		return presentation, nil
+	}
+
+	keyBindingJWT, err := makeSDJWTKeyBinding(presentation, presOpts.holderBinding)
+	if err != nil {
+		return "", fmt.Errorf("create key binding JWT: %w", err)
+	}
+
+	return presentation + keyBindingJWT, nil
+}
+
+func makeSDJWTKeyBinding(presentation string, binding *SDJWTHolderBinding) (string, error) {
+	algName, err := binding.Algorithm.name()
+	if err != nil {
+		return "", err
+	}
+
+	issuedAt := time.Now()
+	if binding.IssuedAt != nil {
+		issuedAt = *binding.IssuedAt
+	}
+
+	payload, err := json.Marshal(&sdJWTKeyBindingClaims{
+		IssuedAt: josejwt.NewNumericDate(issuedAt),
+		Audience: binding.Audience,
+		Nonce:    binding.Nonce,
+		SDHash:   sdJWTDigest(presentation),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+
+	signer := &jwtSigner{signer: binding.Signer, headers: map[string]interface{}{
+		jose.HeaderAlgorithm: algName,
+		jose.HeaderType:      SDJWTKeyBindingType,
+	}}
+
+	jws, err := jose.NewJWS(nil, nil, payload, signer)
+	if err != nil {
+		return "", err
+	}
+
+	return jws.SerializeCompact(false)
+}
+
+// decodeCredSDJWT verifies the SD-JWT and returns the credential with the disclosed claims.
+func decodeCredSDJWT(sdJWT string, vcOpts *credentialOpts) ([]byte, error) {
+	issuerJWT, encoded, keyBindingJWT := splitSDJWT(sdJWT)
+
+	claims := &sdJWTCredClaims{}
+
+	if err := unmarshalJWS(issuerJWT, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.SDAlg != "" && claims.SDAlg != SDJWTHashAlgSHA256 {
+		return nil, fmt.Errorf("unsupported _sd_alg: %s", claims.SDAlg)
+	}
+
+	if err := discloseSDJWTClaims(claims.VC, encoded); err != nil {
+		return nil, err
+	}
+
+	if err := verifySDJWTKeyBinding(sdJWT[:len(sdJWT)-len(keyBindingJWT)], keyBindingJWT, claims.CNF,
+		vcOpts); err != nil {
+		return nil, fmt.Errorf("key binding: %w", err)
+	}
+
+	return decodeCredJWT(issuerJWT, func(string) (*JWTCredClaims, error) {
+		return &JWTCredClaims{Claims: claims.Claims, VC: claims.VC}, nil
+	})
+}
+
+// discloseSDJWTClaims replaces the digests of the disclosed claims by their values.
+func discloseSDJWTClaims(vc map[string]interface{}, encoded []string) error {
+	disclosures := make(map[string]*SDJWTDisclosure, len(encoded))
+
+	for _, e := range encoded {
+		digest := sdJWTDigest(e)
+
+		if _, ok := disclosures[digest]; ok {
+			return errors.New("duplicate disclosure")
+		}
+
+		d, err := decodeSDJWTDisclosure(e)
+		if err != nil {
+			return err
+		}
+
+		disclosures[digest] = d
+	}
+
+	if err := discloseSDJWTObject(vc, disclosures); err != nil {
+		return err
+	}
+
+	if len(disclosures) != 0 {
+		return errors.New("disclosure not referenced by the SD-JWT")
+	}
+
+	return nil
+}
+
+func discloseSDJWTObject(object interface{}, disclosures map[string]*SDJWTDisclosure) error {
+	switch o := object.(type) {
+	case map[string]interface{}:
+		for _, v := range o {
+			if err := discloseSDJWTObject(v, disclosures); err != nil {
+				return err
+			}
+		}
+
+		digests, ok := o[sdClaim].([]interface{})
+		if !ok {
+			return nil
+		}
+
+		delete(o, sdClaim)
+
+		for _, digest := range digests {
+			d, ok := disclosures[fmt.Sprint(digest)]
+			if !ok {
+				continue
+			}
+
+			if _, exists := o[d.Name]; exists {
+				return fmt.Errorf("disclosed claim '%s' already exists", d.Name)
+			}
+
+			o[d.Name] = d.Value
+
+			delete(disclosures, fmt.Sprint(digest))
+		}
+	case []interface{}:
+		for _, v := range o {
+			if err := discloseSDJWTObject(v, disclosures); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func verifySDJWTKeyBinding(presentation, keyBindingJWT string, cnf *sdJWTConfirmation, vcOpts *credentialOpts) error {
+	expected := vcOpts.sdJWTKeyBinding
+
+	if keyBindingJWT == "" {
+		if expected != nil {
+			return errors.New("key binding JWT is missing")
+		}
+
+		return nil
+	}
+
+	if cnf == nil || cnf.JWK == nil {
+		return errors.New("SD-JWT is not bound to a holder key")
+	}
+
+	var keyVerifier jose.SignatureVerifier = &noVerifier{}
+
+	if !vcOpts.disabledProofCheck {
+		pubKeyBytes, err := cnf.JWK.PublicKeyBytes()
+		if err != nil {
+			return fmt.Errorf("holder public key: %w", err)
+		}
+
+		pubKey := &verifier.PublicKey{Value: pubKeyBytes, JWK: cnf.JWK}
+
+		keyVerifier = jose.NewCompositeAlgSigVerifier(
+			jose.AlgSignatureVerifier{Alg: "EdDSA", Verifier: sdJWTKeyVerifier(pubKey, jwt.VerifyEdDSA)},
+			jose.AlgSignatureVerifier{Alg: "RS256", Verifier: sdJWTKeyVerifier(pubKey, jwt.VerifyRS256)},
+			jose.AlgSignatureVerifier{Alg: "ES256K", Verifier: sdJWTKeyVerifier(pubKey, jwt.VerifyES256K)},
+		)
+	}
+
+	jws, err := jose.ParseJWS(keyBindingJWT, keyVerifier)
+	if err != nil {
+		return fmt.Errorf("parse key binding JWT: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != SDJWTKeyBindingType {
+		return fmt.Errorf("unexpected key binding JWT type: %s", typ)
+	}
+
+	claims := &sdJWTKeyBindingClaims{}
+
+	if err = json.Unmarshal(jws.Payload, claims); err != nil {
+		return fmt.Errorf("unmarshal key binding JWT claims: %w", err)
+	}
+
+	if claims.SDHash != sdJWTDigest(presentation) {
+		return errors.New("sd_hash doesn't match the presentation")
+	}
+
+	if expected == nil {
+		return nil
+	}
+
+	if claims.Audience != expected.audience {
+		return fmt.Errorf("unexpected audience: %s", claims.Audience)
+	}
+
+	if claims.Nonce != expected.nonce {
+		return errors.New("unexpected nonce")
+	}
+
+	return nil
+}
+
+func sdJWTKeyVerifier(pubKey *verifier.PublicKey,
+	verify func(*verifier.PublicKey, []byte, []byte) error) jose.SignatureVerifier {
+	return jose.SignatureVerifierFunc(func(_ jose.Headers, _, signingInput, signature []byte) error {
+		return verify(pubKey, signingInput, signature)
+	})
+}
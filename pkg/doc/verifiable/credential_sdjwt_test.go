@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const sdJWTTestCredential = `
+{
+	"@context": [
+	  "https://www.w3.org/2018/credentials/v1",
+	  "https://www.w3.org/2018/credentials/examples/v1"
+	],
+	"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+	"credentialSubject": {
+	  "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+	  "name": "Jayden Doe",
+	  "degree": {
+		"type": "BachelorDegree",
+		"university": "MIT"
+	  }
+	},
+  "issuer": {
+    "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+    "name": "Example University"
+  },
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "expirationDate": "2020-01-01T19:23:24Z"
+}
+`
+
+func TestCredential_SDJWT(t *testing.T) {
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderKey, err := jwksupport.JWKFromKey(ed25519.PublicKey(holderSigner.PublicKeyBytes()))
+	require.NoError(t, err)
+
+	keyFetcher := WithPublicKeyFetcher(
+		createDIDKeyFetcher(t, issuerSigner.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f"))
+
+	vc, err := parseTestCredential(t, []byte(sdJWTTestCredential))
+	require.NoError(t, err)
+
+	sdJWT, err := vc.MakeSDJWT(issuerSigner, EdDSA, keyID, WithSDJWTHolderPublicKey(holderKey))
+	require.NoError(t, err)
+	require.True(t, IsSDJWT(sdJWT))
+	require.True(t, strings.HasSuffix(sdJWT, sdJWTSeparator))
+
+	binding := &SDJWTHolderBinding{
+		Signer:    holderSigner,
+		Algorithm: EdDSA,
+		Audience:  "https://verifier.example.com",
+		Nonce:     "nonce",
+	}
+
+	t.Run("issued SD-JWT hides the credential subject claims", func(t *testing.T) {
+		disclosures, err := ParseSDJWTDisclosures(sdJWT)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 2)
+
+		names := []string{disclosures[0].Name, disclosures[1].Name}
+		require.ElementsMatch(t, []string{"name", "degree"}, names)
+
+		issuerJWT, _, _ := splitSDJWT(sdJWT)
+		require.NotContains(t, issuerJWT, "Jayden")
+
+		claims := &sdJWTCredClaims{}
+		require.NoError(t, unmarshalJWS(issuerJWT, false, nil, claims))
+		require.Equal(t, SDJWTHashAlgSHA256, claims.SDAlg)
+		require.NotNil(t, claims.CNF)
+
+		subject, ok := claims.VC[vcSubjectField].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject["id"])
+		require.Len(t, subject[sdClaim], 2)
+	})
+
+	t.Run("verify SD-JWT with all disclosures", func(t *testing.T) {
+		parsed, err := parseTestCredential(t, []byte(sdJWT), keyFetcher)
+		require.NoError(t, err)
+		require.Equal(t, vc, parsed)
+	})
+
+	t.Run("verify SD-JWT presentation with selected disclosures", func(t *testing.T) {
+		presentation, err := CreateSDJWTPresentation(sdJWT, []string{"degree"})
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(presentation), keyFetcher)
+		require.NoError(t, err)
+
+		subject, ok := parsed.Subject.([]Subject)
+		require.True(t, ok)
+		require.Len(t, subject, 1)
+		require.NotContains(t, subject[0].CustomFields, "name")
+		require.Contains(t, subject[0].CustomFields, "degree")
+	})
+
+	t.Run("verify SD-JWT presentation with holder binding", func(t *testing.T) {
+		presentation, err := CreateSDJWTPresentation(sdJWT, []string{"name"}, WithSDJWTHolderBinding(binding))
+		require.NoError(t, err)
+		require.False(t, strings.HasSuffix(presentation, sdJWTSeparator))
+
+		_, err = parseTestCredential(t, []byte(presentation), keyFetcher,
+			WithExpectedSDJWTKeyBinding(binding.Audience, binding.Nonce))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(presentation), keyFetcher,
+			WithExpectedSDJWTKeyBinding(binding.Audience, "other nonce"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding: unexpected nonce")
+
+		_, err = parseTestCredential(t, []byte(presentation), keyFetcher,
+			WithExpectedSDJWTKeyBinding("https://other.example.com", binding.Nonce))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding: unexpected audience")
+	})
+
+	t.Run("verify SD-JWT presentation - missing key binding", func(t *testing.T) {
+		presentation, err := CreateSDJWTPresentation(sdJWT, []string{"name"})
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(presentation), keyFetcher,
+			WithExpectedSDJWTKeyBinding(binding.Audience, binding.Nonce))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT is missing")
+	})
+
+	t.Run("verify SD-JWT presentation - disclosures changed after key binding", func(t *testing.T) {
+		presentation, err := CreateSDJWTPresentation(sdJWT, []string{"name"}, WithSDJWTHolderBinding(binding))
+		require.NoError(t, err)
+
+		_, disclosures, _ := splitSDJWT(sdJWT)
+		issuerJWT, _, keyBindingJWT := splitSDJWT(presentation)
+
+		tampered := combineSDJWT(issuerJWT, disclosures) + keyBindingJWT
+
+		_, err = parseTestCredential(t, []byte(tampered), keyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sd_hash doesn't match the presentation")
+	})
+
+	t.Run("verify SD-JWT presentation - key binding signed by another key", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		presentation, err := CreateSDJWTPresentation(sdJWT, []string{"name"}, WithSDJWTHolderBinding(
+			&SDJWTHolderBinding{Signer: otherSigner, Algorithm: EdDSA}))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(presentation), keyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse key binding JWT")
+	})
+
+	t.Run("verify SD-JWT - unreferenced disclosure", func(t *testing.T) {
+		disclosure, err := encodeSDJWTDisclosure("name", "John Doe")
+		require.NoError(t, err)
+
+		issuerJWT, disclosures, _ := splitSDJWT(sdJWT)
+
+		_, err = parseTestCredential(t, []byte(combineSDJWT(issuerJWT, append(disclosures, disclosure))),
+			keyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "disclosure not referenced by the SD-JWT")
+	})
+
+	t.Run("verify SD-JWT - invalid issuer signature", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(sdJWT), WithPublicKeyFetcher(
+			createDIDKeyFetcher(t, holderSigner.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "SD-JWT decoding")
+
+		_, err = parseTestCredential(t, []byte(sdJWT))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key fetcher is not defined")
+	})
+
+	t.Run("SD-JWT with always disclosed claims", func(t *testing.T) {
+		sdJWT, err := vc.MakeSDJWT(issuerSigner, EdDSA, keyID, WithSDJWTAlwaysDisclosed("degree"))
+		require.NoError(t, err)
+
+		disclosures, err := ParseSDJWTDisclosures(sdJWT)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+		require.Equal(t, "name", disclosures[0].Name)
+		require.Equal(t, "Jayden Doe", disclosures[0].Value)
+
+		presentation, err := CreateSDJWTPresentation(sdJWT, nil)
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(presentation), keyFetcher)
+		require.NoError(t, err)
+
+		subject, ok := parsed.Subject.([]Subject)
+		require.True(t, ok)
+		require.Contains(t, subject[0].CustomFields, "degree")
+		require.NotContains(t, subject[0].CustomFields, "name")
+	})
+
+	t.Run("invalid disclosures", func(t *testing.T) {
+		_, err := ParseSDJWTDisclosures("not an SD-JWT")
+		require.EqualError(t, err, "not an SD-JWT")
+
+		issuerJWT, _, _ := splitSDJWT(sdJWT)
+
+		_, err = ParseSDJWTDisclosures(combineSDJWT(issuerJWT, []string{"!"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode disclosure")
+
+		_, err = decodeSDJWTDisclosure("WyJzYWx0IiwgIm5hbWUiXQ") // ["salt", "name"]
+		require.EqualError(t, err, "disclosure must be an array of 3 elements")
+	})
+}
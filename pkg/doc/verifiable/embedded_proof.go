@@ -15,14 +15,18 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 const (
 	ed25519Signature2018        = "Ed25519Signature2018"
+	ed25519Signature2020        = "Ed25519Signature2020"
+	dataIntegrityProof          = "DataIntegrityProof"
 	jsonWebSignature2020        = "JsonWebSignature2020"
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
@@ -37,8 +41,8 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
-	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020:
+	case ed25519Signature2018, ed25519Signature2020, dataIntegrityProof, jsonWebSignature2020,
+		ecdsaSecp256k1Signature2019, bbsBlsSignature2020, bbsBlsSignatureProof2020:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -115,6 +119,12 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 			case ed25519Signature2018:
 				ldpSuites = append(ldpSuites, ed25519signature2018.New(
 					suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
+			case ed25519Signature2020:
+				ldpSuites = append(ldpSuites, ed25519signature2020.New(
+					suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier())))
+			case dataIntegrityProof:
+				ldpSuites = append(ldpSuites, dataintegrity.New(
+					suite.WithVerifier(dataintegrity.NewPublicKeyVerifier())))
 			case jsonWebSignature2020:
 				ldpSuites = append(ldpSuites, jsonwebsignature2020.New(
 					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())))
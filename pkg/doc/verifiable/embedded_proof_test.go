@@ -35,6 +35,18 @@ func Test_parseEmbeddedProof(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, ecdsaSecp256k1Signature2019, s)
+
+		s, err = getProofType(map[string]interface{}{
+			"type": ed25519Signature2020,
+		})
+		require.NoError(t, err)
+		require.Equal(t, ed25519Signature2020, s)
+
+		s, err = getProofType(map[string]interface{}{
+			"type": dataIntegrityProof,
+		})
+		require.NoError(t, err)
+		require.Equal(t, dataIntegrityProof, s)
 	})
 
 	t.Run("parse embedded proof without \"type\" element", func(t *testing.T) {
@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kafka provides an event sink publishing the events to Kafka through a Kafka REST Proxy (API v2), see
+// https://docs.confluent.io/platform/current/kafka-rest/api.html. The events are published as JSON records to the
+// topic of the event, prefixed with the topic prefix, keyed by the key of the event, so that the events of a thread
+// are kept in order in the same partition.
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+)
+
+const (
+	contentType = "application/vnd.kafka.json.v2+json"
+	acceptType  = "application/vnd.kafka.v2+json"
+)
+
+var logger = log.New("aries-framework/eventsink/kafka")
+
+// HTTPClient interface for the http client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Opt is a Kafka sink option.
+type Opt func(s *Sink)
+
+// WithHTTPClient sets the http client sending the records to the REST proxy (http.DefaultClient by default).
+func WithHTTPClient(client HTTPClient) Opt {
+	return func(s *Sink) {
+		s.httpClient = client
+	}
+}
+
+// WithTopicPrefix sets the prefix of the Kafka topics, eg. "aries." to publish the didexchange_states events to the
+// aries.didexchange_states topic.
+func WithTopicPrefix(prefix string) Opt {
+	return func(s *Sink) {
+		s.topicPrefix = prefix
+	}
+}
+
+// WithBasicAuth sets the credentials authenticating the requests to the REST proxy.
+func WithBasicAuth(username, password string) Opt {
+	return func(s *Sink) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// Sink publishes the events to Kafka through a Kafka REST proxy.
+type Sink struct {
+	url         string
+	httpClient  HTTPClient
+	topicPrefix string
+	username    string
+	password    string
+}
+
+// record is the value of the Kafka records.
+type record struct {
+	ID      string          `json:"id"`
+	Topic   string          `json:"topic"`
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type produceRequest struct {
+	Records []produceRecord `json:"records"`
+}
+
+type produceRecord struct {
+	Key   string  `json:"key,omitempty"`
+	Value *record `json:"value"`
+}
+
+type produceResponse struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		ErrorCode int    `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// New returns a new Kafka sink publishing the events through the REST proxy at the given URL.
+func New(proxyURL string, opts ...Opt) *Sink {
+	s := &Sink{
+		url:        strings.TrimSuffix(proxyURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Publish publishes the event as a record of the topic of the event, and returns once the record is written to Kafka.
+func (s *Sink) Publish(event *eventsink.Event) error {
+	body, err := json.Marshal(&produceRequest{Records: []produceRecord{{
+		Key: event.Key,
+		Value: &record{
+			ID:      event.ID,
+			Topic:   event.Topic,
+			Time:    event.Time,
+			Payload: event.Payload,
+		},
+	}}})
+	if err != nil {
+		return fmt.Errorf("marshal kafka record: %w", err)
+	}
+
+	topic := s.topicPrefix + event.Topic
+
+	req, err := http.NewRequest(http.MethodPost, s.url+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create kafka produce request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", acceptType)
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send kafka produce request: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read kafka produce response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka produce to %s failed with status %d: %s", topic, resp.StatusCode, respBody)
+	}
+
+	var produceResp produceResponse
+
+	if err := json.Unmarshal(respBody, &produceResp); err != nil {
+		return fmt.Errorf("unmarshal kafka produce response: %w", err)
+	}
+
+	for _, offset := range produceResp.Offsets {
+		if offset.ErrorCode != 0 || offset.Error != "" {
+			return fmt.Errorf("kafka produce to %s failed with error %d: %s", topic, offset.ErrorCode, offset.Error)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the sink.
+func (s *Sink) Close() error {
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	if err := respBody.Close(); err != nil {
+		logger.Errorf("failed to close response body: %s", err)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+)
+
+func TestSink_Publish(t *testing.T) {
+	event := &eventsink.Event{
+		ID:      "event-1",
+		Topic:   "didexchange_states",
+		Key:     "thread-1",
+		Payload: []byte(`{"stateID":"completed"}`),
+		Time:    time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/topics/aries.didexchange_states", r.URL.Path)
+			require.Equal(t, contentType, r.Header.Get("Content-Type"))
+
+			user, pass, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "user", user)
+			require.Equal(t, "pass", pass)
+
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"records":[{"key":"thread-1","value":{"id":"event-1","topic":"didexchange_states",`+
+				`"time":"2021-09-01T00:00:00Z","payload":{"stateID":"completed"}}}]}`, string(body))
+
+			_, err = fmt.Fprint(w, `{"offsets":[{"partition":0,"offset":42}]}`)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		sink := New(srv.URL+"/", WithTopicPrefix("aries."), WithBasicAuth("user", "pass"),
+			WithHTTPClient(srv.Client()))
+
+		require.NoError(t, sink.Publish(event))
+		require.NoError(t, sink.Close())
+	})
+
+	t.Run("record error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, `{"offsets":[{"error_code":50002,"error":"Kafka error"}]}`)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		err := New(srv.URL).Publish(event)
+		require.EqualError(t, err, "kafka produce to didexchange_states failed with error 50002: Kafka error")
+	})
+
+	t.Run("status error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+
+			_, err := fmt.Fprint(w, `{"error_code":40401,"message":"Topic not found"}`)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		err := New(srv.URL).Publish(event)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kafka produce to didexchange_states failed with status 404")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, `{`)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		err := New(srv.URL).Publish(event)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal kafka produce response")
+	})
+
+	t.Run("http client error", func(t *testing.T) {
+		err := New("http://localhost", WithHTTPClient(&mockHTTPClient{err: errors.New("connection refused")})).
+			Publish(event)
+		require.EqualError(t, err, "send kafka produce request: connection refused")
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		err := New("http://localhost").Publish(&eventsink.Event{Topic: "topic", Payload: []byte("{")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal kafka record")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		err := New(":invalid").Publish(event)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create kafka produce request")
+	})
+}
+
+type mockHTTPClient struct {
+	err error
+}
+
+func (c *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, c.err
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -48,6 +50,8 @@ type Provider interface {
 	KeyType() kms.KeyType
 	KeyAgreementType() kms.KeyType
 	MediaTypeProfiles() []string
+	LockService() lock.Service
+	NonceStore() *nonce.Store
 }
 
 // ProtocolSvcCreator method to create new protocol service.
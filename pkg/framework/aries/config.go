@@ -0,0 +1,522 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	webcrypto "github.com/hyperledger/aries-framework-go/pkg/crypto/webkms"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
+	"github.com/hyperledger/aries-framework-go/pkg/eventsink/kafka"
+	"github.com/hyperledger/aries-framework-go/pkg/eventsink/nats"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/vault"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Transport, storage, KMS and secret lock types of the framework configuration.
+const (
+	TransportHTTP = "http"
+	TransportWS   = "ws"
+
+	StorageMem = "mem"
+
+	KMSLocal = "local"
+	KMSWeb   = "web"
+
+	SecretLockNoop  = "noop"
+	SecretLockLocal = "local"
+	SecretLockVault = "vault"
+
+	EventSinkKafka = "kafka"
+	EventSinkNATS  = "nats"
+)
+
+// Config is the framework configuration, loaded from a YAML or JSON document by ParseConfig. The zero value of each
+// field keeps the framework default.
+//
+// Example:
+//
+//	transports:
+//	  inbound:
+//	    - type: http
+//	      internal_addr: 0.0.0.0:8081
+//	      external_addr: https://agent.example.com
+//	  outbound: [http, ws]
+//	storage:
+//	  type: mem
+//	kms:
+//	  type: local
+//	  key_type: ED25519
+//	  key_agreement_type: X25519ECDHKW
+//	  secret_lock:
+//	    type: local
+//	    master_key_file: /etc/aries/master.key
+//	media_type_profiles: [didcomm/aip2;env=rfc19]
+//	vdrs:
+//	  - method: orb
+//	    url: https://resolver.example.com/1.0/identifiers
+//	protocols:
+//	  disabled: [introduce]
+//	  replay_protection: true
+//	  replay_protection_ttl: 24h
+//	event_sink:
+//	  type: nats
+//	  url: nats://localhost:4222
+//	  topic_prefix: aries.
+//	  jetstream: true
+type Config struct {
+	Transports        TransportsConfig `yaml:"transports" json:"transports"`
+	Storage           StorageConfig    `yaml:"storage" json:"storage"`
+	KMS               KMSConfig        `yaml:"kms" json:"kms"`
+	MediaTypeProfiles []string         `yaml:"media_type_profiles" json:"media_type_profiles"`
+	VDRs              []VDRConfig      `yaml:"vdrs" json:"vdrs"`
+	JSONLD            JSONLDConfig     `yaml:"jsonld" json:"jsonld"`
+	Protocols         ProtocolsConfig  `yaml:"protocols" json:"protocols"`
+	EventSink         EventSinkConfig  `yaml:"event_sink" json:"event_sink"`
+}
+
+// TransportsConfig configures the DIDComm transports.
+type TransportsConfig struct {
+	Inbound []InboundTransportConfig `yaml:"inbound" json:"inbound"`
+	// Outbound transport types, http and/or ws. Defaults to http.
+	Outbound []string `yaml:"outbound" json:"outbound"`
+	// ReturnRoute is the transport return route option, none, all or thread.
+	ReturnRoute string `yaml:"return_route" json:"return_route"`
+}
+
+// InboundTransportConfig configures an inbound transport.
+type InboundTransportConfig struct {
+	// Type of the transport, http or ws.
+	Type string `yaml:"type" json:"type"`
+	// InternalAddr is the address the transport listens on.
+	InternalAddr string `yaml:"internal_addr" json:"internal_addr"`
+	// ExternalAddr is the endpoint of the transport as seen by the other agents, InternalAddr when empty.
+	ExternalAddr string `yaml:"external_addr" json:"external_addr"`
+	TLSCertFile  string `yaml:"tls_cert_file" json:"tls_cert_file"`
+	TLSKeyFile   string `yaml:"tls_key_file" json:"tls_key_file"`
+	// TLSCertReloadInterval is the interval the TLS files are checked at to reload the renewed certificates, the
+	// certificate isn't reloaded when zero.
+	TLSCertReloadInterval time.Duration `yaml:"tls_cert_reload_interval" json:"tls_cert_reload_interval"`
+}
+
+// StorageConfig configures the storage of the framework.
+type StorageConfig struct {
+	// Type of the storage, mem by default. The other types are created by the storage creators passed to
+	// Config.Options.
+	Type string `yaml:"type" json:"type"`
+	// Prefix is the storage specific location of the databases, eg. the path of the leveldb databases.
+	Prefix string `yaml:"prefix" json:"prefix"`
+}
+
+// KMSConfig configures the key management.
+type KMSConfig struct {
+	// Type of the KMS, local by default, or web for a remote KMS (and crypto) server.
+	Type string `yaml:"type" json:"type"`
+	// URL of the key store of the web KMS.
+	URL string `yaml:"url" json:"url"`
+	// Replicas are the URLs of the key store on the replicas of the web KMS server. The requests using the keys fail
+	// over to the replicas when the server of URL is unavailable, see webkms.FailoverClient.
+	Replicas []string `yaml:"replicas" json:"replicas"`
+	// KeyType is the default signing key type, eg. ED25519 or ECDSAP256IEEEP1363.
+	KeyType string `yaml:"key_type" json:"key_type"`
+	// KeyAgreementType is the default key agreement type, eg. X25519ECDHKW or NISTP256ECDHKW.
+	KeyAgreementType string           `yaml:"key_agreement_type" json:"key_agreement_type"`
+	SecretLock       SecretLockConfig `yaml:"secret_lock" json:"secret_lock"`
+}
+
+// SecretLockConfig configures the secret lock protecting the keys of the local KMS.
+type SecretLockConfig struct {
+	// Type of the secret lock: noop (default, keys are not protected), local or vault.
+	Type string `yaml:"type" json:"type"`
+	// MasterKeyFile is the file of the master key of the local secret lock.
+	MasterKeyFile string `yaml:"master_key_file" json:"master_key_file"`
+	// Address of the HashiCorp Vault server of the vault secret lock.
+	Address string `yaml:"address" json:"address"`
+	// Token authenticating the requests of the vault secret lock.
+	Token     string `yaml:"token" json:"token"`
+	KeyName   string `yaml:"key_name" json:"key_name"`
+	MountPath string `yaml:"mount_path" json:"mount_path"`
+}
+
+// VDRConfig configures a DID resolver served over HTTP (DID resolution HTTP(S) binding).
+type VDRConfig struct {
+	// Method is the DID method resolved by the resolver.
+	Method string `yaml:"method" json:"method"`
+	// URL is the endpoint of the resolver.
+	URL string `yaml:"url" json:"url"`
+}
+
+// JSONLDConfig configures the JSON-LD document loader.
+type JSONLDConfig struct {
+	// ContextProviderURLs are the URLs of the remote JSON-LD context providers.
+	ContextProviderURLs []string `yaml:"context_provider_urls" json:"context_provider_urls"`
+}
+
+// ProtocolsConfig configures the protocol services.
+type ProtocolsConfig struct {
+	// Enabled are the names of the only protocol services registered when set, see WithOnlyProtocols.
+	Enabled []string `yaml:"enabled" json:"enabled"`
+	// Disabled are the names of the protocol services not registered, see WithoutProtocols. The controllers of the
+	// REST and gRPC APIs require the default protocol services.
+	Disabled []string `yaml:"disabled" json:"disabled"`
+	// EventJournal enables the journal of the state events, see WithEventJournal.
+	EventJournal bool `yaml:"event_journal" json:"event_journal"`
+	// ReplayProtection makes the invitations and presentation challenges single-use, see WithReplayProtection.
+	ReplayProtection    bool          `yaml:"replay_protection" json:"replay_protection"`
+	ReplayProtectionTTL time.Duration `yaml:"replay_protection_ttl" json:"replay_protection_ttl"`
+	// ForwardRelay are the next hop service endpoints the agent relays forward messages to, see WithForwardRelay.
+	ForwardRelay []string `yaml:"forward_relay" json:"forward_relay"`
+	// InboundWorkers is the number of workers handling the inbound messages, see WithInboundWorkers.
+	InboundWorkers int `yaml:"inbound_workers" json:"inbound_workers"`
+}
+
+// EventSinkConfig configures the event sink the state events are published to, see WithEventSink.
+type EventSinkConfig struct {
+	// Type of the event sink, kafka (through a Kafka REST proxy) or nats.
+	Type string `yaml:"type" json:"type"`
+	// URL of the Kafka REST proxy, or of the NATS server.
+	URL string `yaml:"url" json:"url"`
+	// TopicPrefix is the prefix of the Kafka topics or NATS subjects.
+	TopicPrefix string `yaml:"topic_prefix" json:"topic_prefix"`
+	// JetStream publishes the events to NATS JetStream streams.
+	JetStream bool `yaml:"jetstream" json:"jetstream"`
+}
+
+// StorageCreator creates the storage provider of a storage configuration.
+type StorageCreator func(cfg *StorageConfig) (storage.Provider, error)
+
+// ParseConfig parses the YAML or JSON framework configuration read from r, unknown fields are rejected.
+func ParseConfig(r io.Reader) (*Config, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	cfg := &Config{}
+
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse framework configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// NewFromConfig initializes the Aries framework from the YAML or JSON configuration read from r, and the options,
+// applied after the ones of the configuration. The storage of the configuration must be of type mem: use ParseConfig
+// and Config.Options with storage creators for the other storage types, or inject the store provider with
+// WithStoreProvider.
+func NewFromConfig(r io.Reader, opts ...Option) (*Aries, error) {
+	cfg, err := ParseConfig(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgOpts, err := cfg.Options(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append(cfgOpts, opts...)...)
+}
+
+// NewFromConfigFile initializes the Aries framework from the configuration file at path, see NewFromConfig.
+func NewFromConfigFile(path string, opts ...Option) (*Aries, error) {
+	f, err := os.Open(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("open framework configuration: %w", err)
+	}
+
+	defer func() {
+		if errClose := f.Close(); errClose != nil {
+			logger.Warnf("failed to close framework configuration %s: %s", path, errClose)
+		}
+	}()
+
+	return NewFromConfig(f, opts...)
+}
+
+// Options returns the framework options of the configuration. The storage types other than mem are created by the
+// storage creators, by type.
+func (c *Config) Options(storageCreators map[string]StorageCreator) ([]Option, error) {
+	opts, err := c.Transports.options()
+	if err != nil {
+		return nil, err
+	}
+
+	storageOpts, err := c.Storage.options(storageCreators)
+	if err != nil {
+		return nil, err
+	}
+
+	kmsOpts, err := c.KMS.options()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, storageOpts...)
+	opts = append(opts, kmsOpts...)
+
+	if len(c.MediaTypeProfiles) > 0 {
+		opts = append(opts, WithMediaTypeProfiles(c.MediaTypeProfiles))
+	}
+
+	for _, v := range c.VDRs {
+		method := v.Method
+
+		httpVDR, err := httpbinding.New(v.URL, httpbinding.WithAccept(func(m string) bool { return m == method }))
+		if err != nil {
+			return nil, fmt.Errorf("vdr %s: %w", v.Method, err)
+		}
+
+		opts = append(opts, WithVDR(httpVDR))
+	}
+
+	if len(c.JSONLD.ContextProviderURLs) > 0 {
+		opts = append(opts, WithJSONLDContextProviderURL(c.JSONLD.ContextProviderURLs...))
+	}
+
+	sinkOpts, err := c.EventSink.options()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, sinkOpts...)
+
+	return append(opts, c.Protocols.options()...), nil
+}
+
+func (c *TransportsConfig) options() ([]Option, error) {
+	var opts []Option
+
+	for _, in := range c.Inbound {
+		var (
+			inbound transport.InboundTransport
+			err     error
+		)
+
+		switch in.Type {
+		case TransportHTTP:
+			inbound, err = arieshttp.NewInbound(in.InternalAddr, in.ExternalAddr, in.TLSCertFile, in.TLSKeyFile,
+				arieshttp.WithCertificateReload(in.TLSCertReloadInterval))
+		case TransportWS:
+			inbound, err = ws.NewInbound(in.InternalAddr, in.ExternalAddr, in.TLSCertFile, in.TLSKeyFile,
+				ws.WithCertificateReload(in.TLSCertReloadInterval))
+		default:
+			return nil, fmt.Errorf("inbound transport type [%s] not supported", in.Type)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s inbound transport initialization failed: %w", in.Type, err)
+		}
+
+		opts = append(opts, WithInboundTransport(inbound))
+	}
+
+	for _, out := range c.Outbound {
+		switch out {
+		case TransportHTTP:
+			outbound, err := arieshttp.NewOutbound(arieshttp.WithOutboundHTTPClient(&http.Client{}))
+			if err != nil {
+				return nil, fmt.Errorf("http outbound transport initialization failed: %w", err)
+			}
+
+			opts = append(opts, WithOutboundTransports(outbound))
+		case TransportWS:
+			opts = append(opts, WithOutboundTransports(ws.NewOutbound()))
+		default:
+			return nil, fmt.Errorf("outbound transport type [%s] not supported", out)
+		}
+	}
+
+	if c.ReturnRoute != "" {
+		opts = append(opts, WithTransportReturnRoute(c.ReturnRoute))
+	}
+
+	return opts, nil
+}
+
+func (c *StorageConfig) options(storageCreators map[string]StorageCreator) ([]Option, error) {
+	if c.Type == "" {
+		return nil, nil
+	}
+
+	if c.Type == StorageMem {
+		return []Option{WithStoreProvider(mem.NewProvider())}, nil
+	}
+
+	create, ok := storageCreators[c.Type]
+	if !ok {
+		return nil, fmt.Errorf("storage type [%s] not supported", c.Type)
+	}
+
+	p, err := create(c)
+	if err != nil {
+		return nil, fmt.Errorf("create %s storage: %w", c.Type, err)
+	}
+
+	return []Option{WithStoreProvider(p)}, nil
+}
+
+func (c *KMSConfig) options() ([]Option, error) {
+	var opts []Option
+
+	switch c.Type {
+	case "", KMSLocal:
+		secretLockOpts, err := c.SecretLock.options()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, secretLockOpts...)
+	case KMSWeb:
+		if c.URL == "" {
+			return nil, errors.New("web kms url is required")
+		}
+
+		var httpClient webkms.HTTPClient = http.DefaultClient
+
+		if len(c.Replicas) > 0 {
+			client, err := webkms.NewFailoverClient(http.DefaultClient, c.URL, c.Replicas)
+			if err != nil {
+				return nil, err
+			}
+
+			httpClient = client
+		}
+
+		opts = append(opts,
+			WithKMS(func(kms.Provider) (kms.KeyManager, error) {
+				return webkms.New(c.URL, httpClient), nil
+			}),
+			WithCrypto(webcrypto.New(c.URL, httpClient)),
+		)
+	default:
+		return nil, fmt.Errorf("kms type [%s] not supported", c.Type)
+	}
+
+	if c.KeyType != "" {
+		opts = append(opts, WithKeyType(kms.KeyType(c.KeyType)))
+	}
+
+	if c.KeyAgreementType != "" {
+		opts = append(opts, WithKeyAgreementType(kms.KeyType(c.KeyAgreementType)))
+	}
+
+	return opts, nil
+}
+
+func (c *SecretLockConfig) options() ([]Option, error) {
+	switch c.Type {
+	case "":
+		return nil, nil
+	case SecretLockNoop:
+		return []Option{WithSecretLock(&noop.NoLock{})}, nil
+	case SecretLockLocal:
+		masterKey, err := os.Open(c.MasterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("open master key file: %w", err)
+		}
+
+		defer func() {
+			if errClose := masterKey.Close(); errClose != nil {
+				logger.Warnf("failed to close master key file: %s", errClose)
+			}
+		}()
+
+		s, err := local.NewService(masterKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create local secret lock: %w", err)
+		}
+
+		return []Option{WithSecretLock(s)}, nil
+	case SecretLockVault:
+		var vaultOpts []vault.Opt
+
+		if c.Token != "" {
+			vaultOpts = append(vaultOpts, vault.WithToken(c.Token))
+		}
+
+		if c.KeyName != "" {
+			vaultOpts = append(vaultOpts, vault.WithKeyName(c.KeyName))
+		}
+
+		if c.MountPath != "" {
+			vaultOpts = append(vaultOpts, vault.WithMountPath(c.MountPath))
+		}
+
+		return []Option{WithVaultSecretLock(c.Address, vaultOpts...)}, nil
+	default:
+		return nil, fmt.Errorf("secret lock type [%s] not supported", c.Type)
+	}
+}
+
+func (c *EventSinkConfig) options() ([]Option, error) {
+	switch c.Type {
+	case "":
+		return nil, nil
+	case EventSinkKafka:
+		return []Option{WithEventSink(kafka.New(c.URL, kafka.WithTopicPrefix(c.TopicPrefix)))}, nil
+	case EventSinkNATS:
+		natsOpts := []nats.Opt{nats.WithSubjectPrefix(c.TopicPrefix)}
+
+		if c.JetStream {
+			natsOpts = append(natsOpts, nats.WithJetStream())
+		}
+
+		sink, err := nats.New(c.URL, natsOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create nats event sink: %w", err)
+		}
+
+		return []Option{WithEventSink(sink)}, nil
+	default:
+		return nil, fmt.Errorf("event sink type [%s] not supported", c.Type)
+	}
+}
+
+func (c *ProtocolsConfig) options() []Option {
+	var opts []Option
+
+	if len(c.Enabled) > 0 {
+		opts = append(opts, WithOnlyProtocols(c.Enabled...))
+	}
+
+	if len(c.Disabled) > 0 {
+		opts = append(opts, WithoutProtocols(c.Disabled...))
+	}
+
+	if c.EventJournal {
+		opts = append(opts, WithEventJournal())
+	}
+
+	if c.ReplayProtection {
+		opts = append(opts, WithReplayProtection(c.ReplayProtectionTTL))
+	}
+
+	if len(c.ForwardRelay) > 0 {
+		opts = append(opts, WithForwardRelay(c.ForwardRelay...))
+	}
+
+	if c.InboundWorkers > 0 {
+		opts = append(opts, WithInboundWorkers(c.InboundWorkers))
+	}
+
+	return opts
+}
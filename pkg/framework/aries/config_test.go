@@ -0,0 +1,289 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const yamlConfig = `
+transports:
+  inbound:
+    - type: http
+      internal_addr: localhost:0
+      external_addr: http://agent.example.com
+      tls_cert_reload_interval: 12h
+  outbound: [http, ws]
+  return_route: all
+storage:
+  type: mem
+kms:
+  key_type: ECDSAP256IEEEP1363
+  key_agreement_type: NISTP256ECDHKW
+  secret_lock:
+    type: noop
+media_type_profiles: [didcomm/aip2;env=rfc19]
+vdrs:
+  - method: example
+    url: https://resolver.example.com/1.0/identifiers
+protocols:
+  disabled: [introduce]
+  event_journal: true
+  replay_protection: true
+  replay_protection_ttl: 1h
+  forward_relay: [https://next.example.com]
+  inbound_workers: 2
+`
+
+const jsonConfig = `{
+  "storage": {"type": "mem"},
+  "kms": {"key_type": "ED25519"},
+  "protocols": {"enabled": ["trustping"], "disabled": ["introduce"], "replay_protection_ttl": "30m"}
+}`
+
+func TestParseConfig(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		cfg, err := ParseConfig(strings.NewReader(yamlConfig))
+		require.NoError(t, err)
+
+		require.Len(t, cfg.Transports.Inbound, 1)
+		require.Equal(t, "localhost:0", cfg.Transports.Inbound[0].InternalAddr)
+		require.Equal(t, 12*time.Hour, cfg.Transports.Inbound[0].TLSCertReloadInterval)
+		require.Equal(t, []string{TransportHTTP, TransportWS}, cfg.Transports.Outbound)
+		require.Equal(t, StorageMem, cfg.Storage.Type)
+		require.Equal(t, SecretLockNoop, cfg.KMS.SecretLock.Type)
+		require.Equal(t, "example", cfg.VDRs[0].Method)
+		require.Equal(t, []string{"introduce"}, cfg.Protocols.Disabled)
+		require.Equal(t, time.Hour, cfg.Protocols.ReplayProtectionTTL)
+		require.Equal(t, 2, cfg.Protocols.InboundWorkers)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cfg, err := ParseConfig(strings.NewReader(jsonConfig))
+		require.NoError(t, err)
+
+		require.Equal(t, StorageMem, cfg.Storage.Type)
+		require.Equal(t, string(kms.ED25519Type), cfg.KMS.KeyType)
+		require.Equal(t, []string{"trustping"}, cfg.Protocols.Enabled)
+		require.Equal(t, 30*time.Minute, cfg.Protocols.ReplayProtectionTTL)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		cfg, err := ParseConfig(strings.NewReader(""))
+		require.NoError(t, err)
+		require.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParseConfig(strings.NewReader("storage:\n  typ: mem\n"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse framework configuration")
+	})
+}
+
+func TestConfig_Options(t *testing.T) {
+	t.Run("storage creator", func(t *testing.T) {
+		var prefix string
+
+		cfg := &Config{Storage: StorageConfig{Type: "custom", Prefix: "db"}}
+
+		opts, err := cfg.Options(map[string]StorageCreator{
+			"custom": func(c *StorageConfig) (storage.Provider, error) {
+				prefix = c.Prefix
+
+				return mem.NewProvider(), nil
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+		require.Equal(t, "db", prefix)
+	})
+
+	t.Run("storage creator error", func(t *testing.T) {
+		cfg := &Config{Storage: StorageConfig{Type: "custom"}}
+
+		_, err := cfg.Options(map[string]StorageCreator{
+			"custom": func(*StorageConfig) (storage.Provider, error) {
+				return nil, errors.New("creator error")
+			},
+		})
+		require.EqualError(t, err, "create custom storage: creator error")
+	})
+
+	t.Run("web kms", func(t *testing.T) {
+		cfg := &Config{KMS: KMSConfig{Type: KMSWeb, URL: "https://kms.example.com/kms/keystores/1"}}
+
+		opts, err := cfg.Options(nil)
+		require.NoError(t, err)
+		require.Len(t, opts, 2)
+
+		_, err = (&Config{KMS: KMSConfig{Type: KMSWeb}}).Options(nil)
+		require.EqualError(t, err, "web kms url is required")
+
+		cfg.KMS.Replicas = []string{"https://kms-replica.example.com/kms/keystores/1"}
+
+		opts, err = cfg.Options(nil)
+		require.NoError(t, err)
+		require.Len(t, opts, 2)
+
+		cfg.KMS.Replicas = []string{"kms-replica.example.com"}
+
+		_, err = cfg.Options(nil)
+		require.EqualError(t, err, `invalid webkms endpoint "kms-replica.example.com"`)
+	})
+
+	t.Run("local secret lock", func(t *testing.T) {
+		cfg := &Config{KMS: KMSConfig{SecretLock: SecretLockConfig{
+			Type:          SecretLockLocal,
+			MasterKeyFile: filepath.Join(t.TempDir(), "missing.key"),
+		}}}
+
+		_, err := cfg.Options(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open master key file")
+
+		keyFile := filepath.Join(t.TempDir(), "master.key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("invalid master key"), 0o600))
+
+		cfg.KMS.SecretLock.MasterKeyFile = keyFile
+
+		_, err = cfg.Options(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create local secret lock")
+	})
+
+	t.Run("vault secret lock", func(t *testing.T) {
+		cfg := &Config{KMS: KMSConfig{SecretLock: SecretLockConfig{
+			Type: SecretLockVault, Address: "https://vault.example.com", Token: "token",
+			KeyName: "key", MountPath: "transit",
+		}}}
+
+		opts, err := cfg.Options(nil)
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+	})
+
+	t.Run("event sinks", func(t *testing.T) {
+		opts, err := (&Config{EventSink: EventSinkConfig{Type: EventSinkKafka, URL: "http://localhost:8082"}}).
+			Options(nil)
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+
+		opts, err = (&Config{EventSink: EventSinkConfig{
+			Type: EventSinkNATS, URL: "nats://localhost:4222", TopicPrefix: "aries.", JetStream: true,
+		}}).Options(nil)
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+	})
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, c := range []struct {
+			cfg *Config
+			err string
+		}{
+			{
+				cfg: &Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{{Type: "tcp"}}}},
+				err: "inbound transport type [tcp] not supported",
+			},
+			{
+				cfg: &Config{Transports: TransportsConfig{Inbound: []InboundTransportConfig{{Type: TransportWS}}}},
+				err: "ws inbound transport initialization failed",
+			},
+			{
+				cfg: &Config{Transports: TransportsConfig{Outbound: []string{"tcp"}}},
+				err: "outbound transport type [tcp] not supported",
+			},
+			{
+				cfg: &Config{Storage: StorageConfig{Type: "leveldb"}},
+				err: "storage type [leveldb] not supported",
+			},
+			{
+				cfg: &Config{KMS: KMSConfig{Type: "hsm"}},
+				err: "kms type [hsm] not supported",
+			},
+			{
+				cfg: &Config{KMS: KMSConfig{SecretLock: SecretLockConfig{Type: "hsm"}}},
+				err: "secret lock type [hsm] not supported",
+			},
+			{
+				cfg: &Config{VDRs: []VDRConfig{{Method: "example", URL: ""}}},
+				err: "vdr example",
+			},
+			{
+				cfg: &Config{EventSink: EventSinkConfig{Type: "amqp"}},
+				err: "event sink type [amqp] not supported",
+			},
+			{
+				cfg: &Config{EventSink: EventSinkConfig{Type: EventSinkNATS, URL: "http://localhost"}},
+				err: "create nats event sink",
+			},
+		} {
+			_, err := c.cfg.Options(nil)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		}
+	})
+}
+
+func TestNewFromConfig(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		aries, err := NewFromConfig(strings.NewReader(yamlConfig))
+		require.NoError(t, err)
+
+		require.Equal(t, kms.ECDSAP256TypeIEEEP1363, aries.keyType)
+		require.Equal(t, kms.NISTP256ECDHKWType, aries.keyAgreementType)
+		require.Equal(t, []string{"didcomm/aip2;env=rfc19"}, aries.mediaTypeProfiles)
+		require.Equal(t, "http://agent.example.com", aries.inboundTransports[0].Endpoint())
+		require.NotNil(t, aries.nonceStore)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		_, err = ctx.Service("introduce")
+		require.Error(t, err)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("options override the configuration", func(t *testing.T) {
+		aries, err := NewFromConfig(strings.NewReader(jsonConfig), WithKeyType(kms.ED25519Type))
+		require.NoError(t, err)
+		require.Equal(t, kms.ED25519Type, aries.keyType)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewFromConfig(strings.NewReader("storage: [mem]"))
+		require.Error(t, err)
+
+		_, err = NewFromConfig(strings.NewReader("storage:\n  type: leveldb\n"))
+		require.EqualError(t, err, "storage type [leveldb] not supported")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "aries.json")
+		require.NoError(t, os.WriteFile(path, []byte(jsonConfig), 0o600))
+
+		aries, err := NewFromConfigFile(path)
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+
+		_, err = NewFromConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open framework configuration")
+	})
+}
@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
@@ -18,15 +20,20 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
 	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -36,6 +43,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/instrumented"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -55,6 +63,21 @@ func defFrameworkOpts(frameworkOpts *Aries) error { //nolint:gocyclo
 		frameworkOpts.storeProvider = storeProvider()
 	}
 
+	if frameworkOpts.protocolStateStoreProvider == nil {
+		frameworkOpts.protocolStateStoreProvider = storeProvider()
+	}
+
+	if frameworkOpts.lockService == nil {
+		frameworkOpts.lockService = lock.NewLocal()
+	}
+
+	if frameworkOpts.metricsProvider != nil {
+		frameworkOpts.storeProvider = instrumented.NewProvider(frameworkOpts.storeProvider,
+			frameworkOpts.metricsProvider)
+		frameworkOpts.protocolStateStoreProvider = instrumented.NewProvider(frameworkOpts.protocolStateStoreProvider,
+			frameworkOpts.metricsProvider)
+	}
+
 	err := createJSONLDContextStore(frameworkOpts)
 	if err != nil {
 		return err
@@ -80,9 +103,33 @@ func defFrameworkOpts(frameworkOpts *Aries) error { //nolint:gocyclo
 	// - DIDExchange depends on Route
 	// - OutOfBand depends on DIDExchange
 	// - Introduce depends on OutOfBand
-	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
-		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+	defaultSvcCreators := []struct {
+		name      string
+		creator   api.ProtocolSvcCreator
+		dependsOn string
+	}{
+		{messagepickup.MessagePickup, newMessagePickupSvc(), ""},
+		{mediator.Coordination, newRouteSvc(frameworkOpts.relayNextHops), messagepickup.MessagePickup},
+		{didexchange.DIDExchange, newExchangeSvc(frameworkOpts.protocolStateTTL), mediator.Coordination},
+		{outofband.Name, newOutOfBandSvc(), didexchange.DIDExchange},
+		{introduce.Introduce, newIntroduceSvc(), outofband.Name},
+		{issuecredential.Name, newIssueCredentialSvc(frameworkOpts.protocolStateTTL), ""},
+		{presentproof.Name, newPresentProofSvc(), ""},
+	}
+
+	// the disabled services aren't created, the services they depend on may be disabled too
+	for _, svc := range defaultSvcCreators {
+		if frameworkOpts.protocolDisabled(svc.name) {
+			continue
+		}
+
+		if svc.dependsOn != "" && frameworkOpts.protocolDisabled(svc.dependsOn) {
+			return fmt.Errorf("protocol service %s depends on the disabled protocol service %s", svc.name, svc.dependsOn)
+		}
+
+		frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
+			namedProtocolSvcCreator{name: svc.name, create: svc.creator})
+	}
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -94,9 +141,9 @@ func defFrameworkOpts(frameworkOpts *Aries) error { //nolint:gocyclo
 	return setAdditionalDefaultOpts(frameworkOpts)
 }
 
-func newExchangeSvc() api.ProtocolSvcCreator {
+func newExchangeSvc(protocolStateTTL time.Duration) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
-		return didexchange.New(prv)
+		return didexchange.New(prv, didexchange.WithProtocolStateTTL(protocolStateTTL))
 	}
 }
 
@@ -106,9 +153,9 @@ func newIntroduceSvc() api.ProtocolSvcCreator {
 	}
 }
 
-func newIssueCredentialSvc() api.ProtocolSvcCreator {
+func newIssueCredentialSvc(actionTTL time.Duration) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
-		service, err := issuecredential.New(prv)
+		service, err := issuecredential.New(prv, issuecredential.WithActionTTL(actionTTL))
 		if err != nil {
 			return nil, err
 		}
@@ -127,20 +174,67 @@ func newPresentProofSvc() api.ProtocolSvcCreator {
 			return nil, err
 		}
 
-		// sets default middleware to the service
-		service.Use(
+		middlewares := []presentproof.Middleware{
 			mdpresentproof.SavePresentation(prv),
 			mdpresentproof.PresentationDefinition(prv,
 				mdpresentproof.WithAddProofFn(mdpresentproof.AddBBSProofFn(prv)),
 			),
-		)
+		}
+
+		// rejects the replayed presentations before saving them
+		if prv.NonceStore() != nil {
+			middlewares = append([]presentproof.Middleware{
+				mdpresentproof.SingleUseChallenge(prv, prv.NonceStore()),
+			}, middlewares...)
+		}
+
+		// sets default middleware to the service
+		service.Use(middlewares...)
 
 		return service, nil
 	}
 }
 
-func newRouteSvc() api.ProtocolSvcCreator {
+func newActionMenuSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return actionmenu.New(prv)
+	}
+}
+
+func newQuestionAnswerSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return questionanswer.New(prv)
+	}
+}
+
+func newKeyBackupSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return keybackup.New(prv)
+	}
+}
+
+func newTrustPingSvc(frameworkOpts *Aries) api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		if frameworkOpts.trustPingHealthCheck != nil {
+			return trustping.New(prv, trustping.WithHealthCheck(*frameworkOpts.trustPingHealthCheck))
+		}
+
+		return trustping.New(prv)
+	}
+}
+
+func newProfileSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return profile.New(prv)
+	}
+}
+
+func newRouteSvc(relayNextHops []string) api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		if len(relayNextHops) > 0 {
+			return mediator.New(prv, mediator.WithForwardRelay(relayNextHops...))
+		}
+
 		return mediator.New(prv)
 	}
 }
@@ -201,7 +295,7 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 			return legacy.New(provider), nil
 		}
 
-		frameworkOpts.packerCreators = []packer.Creator{
+		frameworkOpts.packerCreators = append([]packer.Creator{
 			func(provider packer.Provider) (packer.Packer, error) {
 				return legacy.New(provider), nil
 			},
@@ -211,7 +305,7 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 			func(provider packer.Provider) (packer.Packer, error) {
 				return anoncrypt.New(provider, jose.A256GCM)
 			},
-		}
+		}, frameworkOpts.packerCreators...)
 	}
 
 	if frameworkOpts.packagerCreator == nil {
@@ -220,10 +314,6 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 		}
 	}
 
-	if frameworkOpts.protocolStateStoreProvider == nil {
-		frameworkOpts.protocolStateStoreProvider = storeProvider()
-	}
-
 	if frameworkOpts.msgSvcProvider == nil {
 		frameworkOpts.msgSvcProvider = &noOpMessageServiceProvider{}
 	}
@@ -14,10 +14,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 )
 
-// WithInboundHTTPAddr return new default http inbound transport.
-func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string) aries.Option {
+// WithInboundHTTPAddr return new default http inbound transport, configured with the given options (eg.
+// http.WithCertificateReload to reload the renewed TLS certificates).
+func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string,
+	inboundOpts ...http.InboundHTTPOpt) aries.Option {
 	return func(opts *aries.Aries) error {
-		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile)
+		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile, inboundOpts...)
 		if err != nil {
 			return fmt.Errorf("http inbound transport initialization failed : %w", err)
 		}
@@ -26,10 +28,11 @@ func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string) a
 	}
 }
 
-// WithInboundWSAddr return new default ws inbound transport.
-func WithInboundWSAddr(internalAddr, externalAddr, certFile, keyFile string) aries.Option {
+// WithInboundWSAddr return new default ws inbound transport, configured with the given options (eg.
+// ws.WithCertificateReload to reload the renewed TLS certificates).
+func WithInboundWSAddr(internalAddr, externalAddr, certFile, keyFile string, inboundOpts ...ws.InboundOpt) aries.Option {
 	return func(opts *aries.Aries) error {
-		inbound, err := ws.NewInbound(internalAddr, externalAddr, certFile, keyFile)
+		inbound, err := ws.NewInbound(internalAddr, externalAddr, certFile, keyFile, inboundOpts...)
 		if err != nil {
 			return fmt.Errorf("ws inbound transport initialization failed : %w", err)
 		}
@@ -8,9 +8,12 @@ package defaults
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 )
 
@@ -26,6 +29,13 @@ func TestWithInboundHTTPPort(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "http inbound transport initialization failed")
 	})
+
+	t.Run("test inbound with http port - certificate reload of invalid TLS files", func(t *testing.T) {
+		_, err := aries.New(WithInboundHTTPAddr(":26504", "", "invalid", "invalid",
+			http.WithCertificateReload(time.Minute)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stat TLS file")
+	})
 }
 
 func TestWithInboundWSPort(t *testing.T) {
@@ -40,4 +50,11 @@ func TestWithInboundWSPort(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "ws inbound transport initialization failed")
 	})
+
+	t.Run("test inbound with ws port - certificate reload of invalid TLS files", func(t *testing.T) {
+		_, err := aries.New(WithInboundWSAddr(":26504", "", "invalid", "invalid",
+			ws.WithCertificateReload(time.Minute)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stat TLS file")
+	})
 }
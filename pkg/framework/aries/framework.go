@@ -7,47 +7,85 @@ SPDX-License-Identifier: Apache-2.0
 package aries
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	jsonld "github.com/piprate/json-gold/ld"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/cachedstore"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ciphersuite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/didrotate"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	inboundpool "github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/inbound"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/keypin"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/upgrade"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	ldsvc "github.com/hyperledger/aries-framework-go/pkg/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/vault"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/eventjournal"
 	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	"github.com/hyperledger/aries-framework-go/pkg/store/outbox"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/namespace"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
+	spilog "github.com/hyperledger/aries-framework-go/spi/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
-	defaultEndpoint     = "didcomm:transport/queue"
-	defaultMasterKeyURI = "local-lock://default/master/key/"
+	defaultEndpoint        = "didcomm:transport/queue"
+	defaultMasterKeyURI    = "local-lock://default/master/key/"
+	defaultShutdownTimeout = 30 * time.Second
 )
 
+var logger = log.New("aries-framework/framework/aries")
+
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
 type Aries struct {
 	storeProvider              storage.Provider
 	protocolStateStoreProvider storage.Provider
-	protocolSvcCreators        []api.ProtocolSvcCreator
-	services                   []dispatcher.ProtocolService
+	protocolSvcCreators        []namedProtocolSvcCreator
+	disabledProtocols          map[string]bool
+	enabledProtocols           map[string]bool
+	protocolRegistry           *dispatcher.ProtocolRegistry
 	msgSvcProvider             api.MessageServiceProvider
 	outboundDispatcher         dispatcher.Outbound
 	messenger                  service.MessengerHandler
@@ -67,24 +105,80 @@ type Aries struct {
 	vdr                        []vdrapi.VDR
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
+	didRotator                 *didrotate.DIDRotator
+	keyPinningPolicy           *keypin.Policy
+	keyPinner                  *keypin.KeyPinner
+	cipherSuitePolicy          *ciphersuite.Policy
+	cipherSuiteRecorder        *ciphersuite.Recorder
+	senderPolicyEnabled        bool
+	senderPolicyRules          []senderpolicy.Rule
+	senderPolicy               *senderpolicy.Policy
+	problemReports             *problemreport.Store
+	eventJournalEnabled        bool
+	eventJournal               *eventjournal.Journal
+	timestampStamper           timestamp.Stamper
+	checkpointInterval         time.Duration
+	journalCheckpointer        *eventjournal.Checkpointer
+	eventSink                  eventsink.Sink
+	eventOutboxOpts            []outbox.Opt
+	eventOutbox                *outbox.Outbox
+	upgrader                   *upgrade.Upgrader
+	cacheProvider              storage.Provider
 	contextStore               ldstore.ContextStore
 	remoteProviderStore        ldstore.RemoteProviderStore
 	documentLoader             jsonld.DocumentLoader
 	contextProviderURLs        []string
+	documentLoaderOpts         []ld.DocumentLoaderOpts
+	contextRefreshOpts         []ldsvc.RefreshOpt
+	contextRefresh             bool
+	contextRefresher           *ldsvc.RefreshScheduler
+	retentionPolicies          map[string]retention.Policy
+	retentionReaperOpts        []retention.ReaperOpt
+	retentionReaper            *retention.Reaper
+	protocolStateTTL           time.Duration
+	credentialExpiryOpts       []verifiable.ExpiryOpt
+	credentialExpiry           bool
+	credentialExpiryMonitor    *verifiable.ExpiryMonitor
 	transportReturnRoute       string
 	id                         string
 	keyType                    kms.KeyType
 	keyAgreementType           kms.KeyType
 	mediaTypeProfiles          []string
+	tracerProvider             trace.TracerProvider
+	metricsProvider            metrics.Provider
+	loggerProvider             spilog.LoggerProvider
+	lockService                spilock.Service
+	replayProtection           bool
+	nonceTTL                   time.Duration
+	nonceStore                 *nonce.Store
+	stateObservers             map[string]*stateObserver
+	stateObserversMutex        sync.Mutex
+	outboundRelays             []*service.Destination
+	relayNextHops              []string
+	outboundRetryPolicy        *dispatcher.RetryPolicy
+	inboundWorkers             int
+	inboundPool                *inboundpool.Pool
+	shutdownTimeout            time.Duration
+	inboundMiddleware          []dispatcher.MessageMiddleware
+	outboundMiddleware         []dispatcher.MessageMiddleware
+	trustPingHealthCheck       *trustping.HealthCheckConfig
+	trustPing                  *trustping.Service
 }
 
 // Option configures the framework.
 type Option func(opts *Aries) error
 
+// namedProtocolSvcCreator creates a protocol service. The name of the service is known before its creation for the default
+// services and the ones injected with WithNamedProtocol, which aren't created when the protocol is disabled.
+type namedProtocolSvcCreator struct {
+	name   string
+	create api.ProtocolSvcCreator
+}
+
 // New initializes the Aries framework based on the set of options provided. This function returns a framework
 // which can be used to manage Aries clients by getting the framework context.
 func New(opts ...Option) (*Aries, error) {
-	frameworkOpts := &Aries{}
+	frameworkOpts := &Aries{shutdownTimeout: defaultShutdownTimeout}
 
 	// generate framework configs from options
 	for _, option := range opts {
@@ -95,6 +189,10 @@ func New(opts ...Option) (*Aries, error) {
 		}
 	}
 
+	if frameworkOpts.loggerProvider != nil {
+		log.Initialize(frameworkOpts.loggerProvider)
+	}
+
 	// generate a random framework ID
 	frameworkOpts.id = uuid.New().String()
 
@@ -145,16 +243,94 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, err
 	}
 
+	// Create DID rotator
+	if err := createDIDRotator(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create connection upgrader
+	if err := createConnectionUpgrader(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create key pinner
+	if err := createKeyPinner(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create cipher suite recorder
+	if err := createCipherSuiteRecorder(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create sender policy
+	if err := createSenderPolicy(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create used nonce store
+	if err := createNonceStore(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create problem report store
+	if err := createProblemReportStore(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create event journal
+	if err := createEventJournal(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create event outbox
+	if err := createEventOutbox(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Create inbound worker pool
+	if err := createInboundPool(frameworkOpts); err != nil {
+		return nil, err
+	}
+
 	// Load services
 	if err := loadServices(frameworkOpts); err != nil {
 		return nil, err
 	}
 
+	// Time-stamp the issued credentials and the event journal
+	if err := startTimestamping(frameworkOpts); err != nil {
+		return nil, err
+	}
+
 	// Start inbound/outbound transports
 	if err := startTransports(frameworkOpts); err != nil {
 		return nil, err
 	}
 
+	// Start the refresh of the JSON-LD contexts
+	if err := startJSONLDContextRefresh(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Start the deletion of the protocol records no longer retained
+	if err := startRetentionReaper(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Start the expiry checks of the stored credentials
+	if err := startCredentialExpiryMonitor(frameworkOpts); err != nil {
+		return nil, err
+	}
+
+	// Start the health check of the connections
+	startTrustPingHealthCheck(frameworkOpts)
+
+	// Start the publication of the events to the event sink
+	if frameworkOpts.eventOutbox != nil {
+		frameworkOpts.eventOutbox.Start()
+	}
+
 	return frameworkOpts, nil
 }
 
@@ -184,12 +360,13 @@ func WithInboundTransport(inboundTransport ...transport.InboundTransport) Option
 
 // WithTransportReturnRoute injects transport return route option to the Aries framework. Acceptable values - "none",
 // "all" or "thread". RFC - https://github.com/hyperledger/aries-rfcs/tree/master/features/0092-transport-return-route.
-// Currently, framework supports "all" and "none" option with WebSocket transport ("thread" is not supported).
+// Currently, framework supports these options with WebSocket transport. With "thread", the connection is only used
+// for the replies in the thread of the message sent, it isn't kept open for the other messages.
 func WithTransportReturnRoute(transportReturnRoute string) Option {
 	return func(opts *Aries) error {
-		//  "thread" option is not supported at the moment.
 		if transportReturnRoute != decorator.TransportReturnRouteNone &&
-			transportReturnRoute != decorator.TransportReturnRouteAll {
+			transportReturnRoute != decorator.TransportReturnRouteAll &&
+			transportReturnRoute != decorator.TransportReturnRouteThread {
 			return fmt.Errorf("invalid transport return route option : %s", transportReturnRoute)
 		}
 
@@ -199,30 +376,141 @@ func WithTransportReturnRoute(transportReturnRoute string) Option {
 	}
 }
 
-// WithStoreProvider injects a storage provider to the Aries framework.
-func WithStoreProvider(prov storage.Provider) Option {
+// WithStoreProvider injects a storage provider to the Aries framework. The namespace options (namespace.WithNamespace,
+// namespace.WithEncryptedStoreNames) prefix and/or encrypt the names of the framework stores, so that several agents
+// can share the same database. A namespaced provider isn't closed with the framework, only the stores of the namespace.
+func WithStoreProvider(prov storage.Provider, nsOpts ...namespace.Option) Option {
+	return func(opts *Aries) error {
+		p, err := namespacedProvider(prov, nsOpts)
+		if err != nil {
+			return fmt.Errorf("store provider: %w", err)
+		}
+
+		opts.storeProvider = p
+
+		return nil
+	}
+}
+
+// WithProtocolStateStoreProvider injects a protocol state storage provider to the Aries framework. The namespace
+// options are the WithStoreProvider ones.
+func WithProtocolStateStoreProvider(prov storage.Provider, nsOpts ...namespace.Option) Option {
 	return func(opts *Aries) error {
-		opts.storeProvider = prov
+		p, err := namespacedProvider(prov, nsOpts)
+		if err != nil {
+			return fmt.Errorf("protocol state store provider: %w", err)
+		}
+
+		opts.protocolStateStoreProvider = p
+
 		return nil
 	}
 }
 
-// WithProtocolStateStoreProvider injects a protocol state storage provider to the Aries framework.
-func WithProtocolStateStoreProvider(prov storage.Provider) Option {
+// WithCacheProvider injects a cache provider, such as the Redis provider of component/storage/redis shared by the
+// instances of an agent, caching the JSON-LD contexts, the JSON-LD remote providers and the resolved DID documents. The
+// time to live of the cached records is set by the cache provider, the DID resolutions being cached in the
+// vdr.ResolutionCacheStoreName store.
+func WithCacheProvider(prov storage.Provider) Option {
 	return func(opts *Aries) error {
-		opts.protocolStateStoreProvider = prov
+		opts.cacheProvider = prov
 		return nil
 	}
 }
 
-// WithProtocols injects a protocol service to the Aries framework.
+func namespacedProvider(prov storage.Provider, opts []namespace.Option) (storage.Provider, error) {
+	if len(opts) == 0 {
+		return prov, nil
+	}
+
+	return namespace.NewProvider(prov, opts...)
+}
+
+// WithProtocols injects a protocol service to the Aries framework. The service is created before being discarded if
+// its protocol is disabled, see WithNamedProtocol.
 func WithProtocols(protocolSvcCreator ...api.ProtocolSvcCreator) Option {
 	return func(opts *Aries) error {
-		opts.protocolSvcCreators = append(opts.protocolSvcCreators, protocolSvcCreator...)
+		for _, creator := range protocolSvcCreator {
+			opts.protocolSvcCreators = append(opts.protocolSvcCreators, namedProtocolSvcCreator{create: creator})
+		}
+
+		return nil
+	}
+}
+
+// WithNamedProtocol injects the protocol service with the given name to the Aries framework. Unlike with
+// WithProtocols, the service isn't created when its protocol is disabled with WithoutProtocols or WithOnlyProtocols.
+func WithNamedProtocol(name string, creator api.ProtocolSvcCreator) Option {
+	return func(opts *Aries) error {
+		opts.protocolSvcCreators = append(opts.protocolSvcCreators, namedProtocolSvcCreator{name: name, create: creator})
+
+		return nil
+	}
+}
+
+// WithoutProtocols disables the protocol services with the given names (eg. introduce.Introduce), which are not
+// registered: the messages of the protocols are not handled and the clients of the protocols can't be created. The
+// services the other protocols depend on (eg. didexchange.DIDExchange for outofband.Name) must not be disabled, New
+// fails otherwise.
+func WithoutProtocols(names ...string) Option {
+	return func(opts *Aries) error {
+		if opts.disabledProtocols == nil {
+			opts.disabledProtocols = make(map[string]bool)
+		}
+
+		for _, name := range names {
+			opts.disabledProtocols[name] = true
+		}
+
+		return nil
+	}
+}
+
+// WithOnlyProtocols registers only the protocol services with the given names (eg. didexchange.DIDExchange and
+// presentproof.Name for a verifier), to run a lean agent: the other services are disabled like with WithoutProtocols.
+// The services the enabled protocols depend on (eg. mediator.Coordination for didexchange.DIDExchange) must be
+// enabled too. The protocols disclosed to the discover-features queries are the ones of the registered services.
+func WithOnlyProtocols(names ...string) Option {
+	return func(opts *Aries) error {
+		if opts.enabledProtocols == nil {
+			opts.enabledProtocols = make(map[string]bool)
+		}
+
+		for _, name := range names {
+			opts.enabledProtocols[name] = true
+		}
+
 		return nil
 	}
 }
 
+// WithActionMenu registers the action menu protocol service, which isn't registered by default.
+func WithActionMenu() Option {
+	return WithNamedProtocol(actionmenu.ActionMenu, newActionMenuSvc())
+}
+
+// WithQuestionAnswer registers the question answer protocol service, which isn't registered by default.
+func WithQuestionAnswer() Option {
+	return WithNamedProtocol(questionanswer.QuestionAnswer, newQuestionAnswerSvc())
+}
+
+// WithKeyBackup registers the key backup protocol service, which isn't registered by default.
+func WithKeyBackup() Option {
+	return WithNamedProtocol(keybackup.KeyBackup, newKeyBackupSvc())
+}
+
+// WithTrustPing registers the trust ping protocol service, which isn't registered by default.
+func WithTrustPing() Option {
+	return func(opts *Aries) error {
+		return WithNamedProtocol(trustping.TrustPing, newTrustPingSvc(opts))(opts)
+	}
+}
+
+// WithProfile registers the profile protocol service, which isn't registered by default.
+func WithProfile() Option {
+	return WithNamedProtocol(profile.Name, newProfileSvc())
+}
+
 // WithSecretLock injects a SecretLock service to the Aries framework.
 func WithSecretLock(s secretlock.Service) Option {
 	return func(opts *Aries) error {
@@ -231,6 +519,22 @@ func WithSecretLock(s secretlock.Service) Option {
 	}
 }
 
+// WithVaultSecretLock injects a secret lock service backed by the transit secrets engine of the HashiCorp Vault server
+// at address to the Aries framework, so that the keys of the KMS are encrypted by Vault and no master key is stored by
+// the agent. See pkg/secretlock/vault for the options.
+func WithVaultSecretLock(address string, vaultOpts ...vault.Opt) Option {
+	return func(opts *Aries) error {
+		s, err := vault.New(address, vaultOpts...)
+		if err != nil {
+			return fmt.Errorf("create vault secret lock: %w", err)
+		}
+
+		opts.secretLock = s
+
+		return nil
+	}
+}
+
 // WithKMS injects a KMS service to the Aries framework.
 func WithKMS(k kms.Creator) Option {
 	return func(opts *Aries) error {
@@ -277,6 +581,16 @@ func WithPacker(primary packer.Creator, additionalPackers ...packer.Creator) Opt
 	}
 }
 
+// WithAdditionalPackers injects Packer services available for unpacking inbound messages into the Aries framework,
+// without changing the primary Packer.
+func WithAdditionalPackers(packers ...packer.Creator) Option {
+	return func(opts *Aries) error {
+		opts.packerCreators = append(opts.packerCreators, packers...)
+
+		return nil
+	}
+}
+
 // WithVerifiableStore injects a verifiable credential store.
 func WithVerifiableStore(store verifiable.Store) Option {
 	return func(opts *Aries) error {
@@ -325,6 +639,114 @@ func WithJSONLDContextProviderURL(url ...string) Option {
 	}
 }
 
+// WithJSONLDDocumentLoaderOpts injects options of the JSON-LD document loader created by the framework, eg. the
+// loaders chained with ld.WithLoader. The options are ignored when a loader is injected with WithJSONLDDocumentLoader.
+func WithJSONLDDocumentLoaderOpts(loaderOpts ...ld.DocumentLoaderOpts) Option {
+	return func(opts *Aries) error {
+		opts.documentLoaderOpts = append(opts.documentLoaderOpts, loaderOpts...)
+		return nil
+	}
+}
+
+// WithJSONLDContextRefresh enables the background refresh of the JSON-LD contexts of the remote providers, configured
+// with the ld.RefreshScheduler options (eg. the refresh intervals and the handler of the changed contexts).
+func WithJSONLDContextRefresh(refreshOpts ...ldsvc.RefreshOpt) Option {
+	return func(opts *Aries) error {
+		opts.contextRefresh = true
+		opts.contextRefreshOpts = append(opts.contextRefreshOpts, refreshOpts...)
+
+		return nil
+	}
+}
+
+// WithProtocolStateTTL sets the time the transient protocol records expire after: the records of the DID exchange
+// connections in the protocol state store and the issue credential actions waiting to be continued or stopped. The
+// records don't expire by default. The expired records are deleted by the stores supporting expiry, see
+// storage.ExpiringStore.
+func WithProtocolStateTTL(ttl time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.protocolStateTTL = ttl
+		return nil
+	}
+}
+
+// WithRetentionPolicy sets the retention policy of the records of the protocol store with the given name, eg.
+// presentproof.Name or issuecredential.Name. The records the policy no longer retains are deleted in the background
+// by a retention.Reaper configured with the given options.
+func WithRetentionPolicy(storeName string, policy retention.Policy, reaperOpts ...retention.ReaperOpt) Option {
+	return func(opts *Aries) error {
+		if opts.retentionPolicies == nil {
+			opts.retentionPolicies = make(map[string]retention.Policy)
+		}
+
+		opts.retentionPolicies[storeName] = policy
+		opts.retentionReaperOpts = append(opts.retentionReaperOpts, reaperOpts...)
+
+		return nil
+	}
+}
+
+// WithCredentialExpiryMonitor enables the expiry checks of the credentials of the verifiable store in the background,
+// configured with the verifiable.ExpiryMonitor options (eg. the expiring window, the auto-archive policy and the
+// handler of the expiry events). The default verifiable store is required.
+func WithCredentialExpiryMonitor(expiryOpts ...verifiable.ExpiryOpt) Option {
+	return func(opts *Aries) error {
+		opts.credentialExpiry = true
+		opts.credentialExpiryOpts = append(opts.credentialExpiryOpts, expiryOpts...)
+
+		return nil
+	}
+}
+
+// WithTrustPingHealthCheck periodically pings the connections in the background to check their health: the time the
+// other party was last seen is recorded in the connection record, and the connections not seen for the
+// HealthCheckConfig UnhealthyAfter duration are reported with trustping.StateConnectionUnhealthy message events.
+// The health check of each connection is enabled or disabled with the trust ping client. The trust ping protocol
+// service must be registered with WithTrustPing.
+func WithTrustPingHealthCheck(config trustping.HealthCheckConfig) Option {
+	return func(opts *Aries) error {
+		opts.trustPingHealthCheck = &config
+		return nil
+	}
+}
+
+// WithEventJournal enables the journal of the state events emitted by the protocol services, numbered with sequence
+// numbers so that the consumers which were offline can replay the events they missed, see the eventjournal package.
+// Set a retention policy of the eventjournal.StoreName store with WithRetentionPolicy to delete the old events.
+func WithEventJournal() Option {
+	return func(opts *Aries) error {
+		opts.eventJournalEnabled = true
+		return nil
+	}
+}
+
+// WithTimestampAuthority obtains RFC 3161 time-stamps of the issued credentials and of the event journal from a
+// time-stamping authority (eg. a timestamp.Client), as long-term evidence of the issuance and of the journaled events.
+// The time-stamps of the credentials are stored by the issue-credential middleware TimestampCredentials, the issuance
+// failing if the TSA can't be reached. When the event journal is enabled, it is checkpointed every checkpointInterval
+// (eventjournal.DefaultCheckpointInterval if zero).
+func WithTimestampAuthority(stamper timestamp.Stamper, checkpointInterval time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.timestampStamper = stamper
+		opts.checkpointInterval = checkpointInterval
+
+		return nil
+	}
+}
+
+// WithEventSink publishes the state events emitted by the protocol services to the event sink, eg. a Kafka or NATS
+// sink of the eventsink packages, through a transactional outbox: the events are saved to the outbox.StoreName store
+// before being published, so that they are delivered at least once even if the sink or the agent restarts. The
+// outbox, available from the context, can also be the notifier of the controller to publish its notifications.
+func WithEventSink(sink eventsink.Sink, outboxOpts ...outbox.Opt) Option {
+	return func(opts *Aries) error {
+		opts.eventSink = sink
+		opts.eventOutboxOpts = outboxOpts
+
+		return nil
+	}
+}
+
 // WithKeyType injects a default signing key type.
 func WithKeyType(keyType kms.KeyType) Option {
 	return func(opts *Aries) error {
@@ -351,90 +773,364 @@ func WithMediaTypeProfiles(mediaTypeProfiles []string) Option {
 	}
 }
 
-// Context provides a handle to the framework context.
-func (a *Aries) Context() (*context.Provider, error) {
-	return context.New(
-		context.WithOutboundDispatcher(a.outboundDispatcher),
-		context.WithMessengerHandler(a.messenger),
-		context.WithOutboundTransports(a.outboundTransports...),
-		context.WithProtocolServices(a.services...),
-		context.WithKMS(a.kms),
-		context.WithSecretLock(a.secretLock),
-		context.WithCrypto(a.crypto),
-		context.WithServiceEndpoint(serviceEndpoint(a)),
-		context.WithRouterEndpoint(routingEndpoint(a)),
-		context.WithStorageProvider(a.storeProvider),
-		context.WithProtocolStateStorageProvider(a.protocolStateStoreProvider),
-		context.WithPacker(a.primaryPacker, a.packers...),
-		context.WithPackager(a.packager),
-		context.WithVDRegistry(a.vdrRegistry),
-		context.WithTransportReturnRoute(a.transportReturnRoute),
-		context.WithAriesFrameworkID(a.id),
-		context.WithMessageServiceProvider(a.msgSvcProvider),
-		context.WithVerifiableStore(a.verifiableStore),
-		context.WithDIDConnectionStore(a.didConnectionStore),
-		context.WithJSONLDContextStore(a.contextStore),
-		context.WithJSONLDRemoteProviderStore(a.remoteProviderStore),
-		context.WithJSONLDDocumentLoader(a.documentLoader),
-		context.WithKeyType(a.keyType),
-		context.WithKeyAgreementType(a.keyAgreementType),
-		context.WithMediaTypeProfiles(a.mediaTypeProfiles),
-	)
-}
+// WithOutboundRelays routes all outbound messages through the given relays (in order) before they reach the
+// recipient or its mediator. Each relay gets a nested forward message only revealing the next hop, so the
+// recipient doesn't learn the network location of the sender. The relays must have the forward relay option
+// enabled (see WithForwardRelay). Relays set on a service.Destination take precedence over this option.
+func WithOutboundRelays(relays ...*service.Destination) Option {
+	return func(opts *Aries) error {
+		for i, r := range relays {
+			if r == nil || r.ServiceEndpoint == "" || len(r.RecipientKeys) == 0 {
+				return fmt.Errorf("relay %d: service endpoint and recipient keys are mandatory", i)
+			}
+		}
 
-// Messenger returns messenger for sending messages through this agent framework
-// TODO should use dedicated messenger interface instead of Outbound dispatcher [Issue #1058].
-func (a *Aries) Messenger() service.Messenger {
-	return a.messenger
-}
+		opts.outboundRelays = relays
 
-// Close frees resources being maintained by the framework.
-func (a *Aries) Close() error {
-	if a.storeProvider != nil {
-		err := a.storeProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
-		}
+		return nil
 	}
+}
 
-	if a.protocolStateStoreProvider != nil {
-		err := a.protocolStateStoreProvider.Close()
-		if err != nil {
-			return fmt.Errorf("failed to close the store: %w", err)
+// WithForwardRelay allows the agent to act as a relay for forward messages carrying a sender chosen next hop
+// (see WithOutboundRelays). The messages are only relayed to the given next hop service endpoints, so the agent
+// can't be used as an open relay. It is disabled by default.
+func WithForwardRelay(nextHops ...string) Option {
+	return func(opts *Aries) error {
+		if len(nextHops) == 0 {
+			return errors.New("forward relay: the allowed next hop service endpoints are mandatory")
 		}
-	}
 
-	for _, inbound := range a.inboundTransports {
-		if err := inbound.Stop(); err != nil {
-			return fmt.Errorf("inbound transport close failed: %w", err)
-		}
-	}
+		opts.relayNextHops = nextHops
 
-	return a.closeVDR()
+		return nil
+	}
 }
 
-func (a *Aries) closeVDR() error {
-	if a.vdrRegistry != nil {
-		if err := a.vdrRegistry.Close(); err != nil {
-			return fmt.Errorf("vdr registry close failed: %w", err)
-		}
+// WithOutboundRetryPolicy keeps the outbound messages which couldn't be delivered in the framework store and retries
+// their delivery with an exponential backoff. The sends of queued messages succeed, messages dropped after the last
+// attempt are reported as messenger.StateDeliveryFailed events on the messenger message events.
+func WithOutboundRetryPolicy(policy *dispatcher.RetryPolicy) Option {
+	return func(opts *Aries) error {
+		opts.outboundRetryPolicy = policy
+		return nil
 	}
-
-	return nil
 }
 
-func createKMS(frameworkOpts *Aries) error {
-	ctx, err := context.New(
-		context.WithStorageProvider(frameworkOpts.storeProvider),
-		context.WithSecretLock(frameworkOpts.secretLock),
-	)
-	if err != nil {
-		return fmt.Errorf("create context failed: %w", err)
+// WithInboundMiddleware passes the inbound messages through the middleware after they are unpacked, before they are
+// handled by the service accepting them. The middleware can log, change, annotate or block the messages.
+func WithInboundMiddleware(middleware ...dispatcher.MessageMiddleware) Option {
+	return func(opts *Aries) error {
+		opts.inboundMiddleware = append(opts.inboundMiddleware, middleware...)
+		return nil
 	}
+}
 
-	frameworkOpts.kms, err = frameworkOpts.kmsCreator(ctx)
-	if err != nil {
-		return fmt.Errorf("create KMS failed: %w", err)
+// WithOutboundMiddleware passes the outbound messages through the middleware before they are packed. The middleware
+// can log, change, annotate or block the messages.
+func WithOutboundMiddleware(middleware ...dispatcher.MessageMiddleware) Option {
+	return func(opts *Aries) error {
+		opts.outboundMiddleware = append(opts.outboundMiddleware, middleware...)
+		return nil
+	}
+}
+
+// WithKeyPinning pins the sender key of the first message received over each connection, and applies the policy to
+// the messages later sent with a different key without a DID rotation of the connection: key change events are
+// raised by the key pinner of the context with both policies, and the messages are rejected by keypin.Block.
+func WithKeyPinning(policy keypin.Policy) Option {
+	return func(opts *Aries) error {
+		opts.keyPinningPolicy = &policy
+		return nil
+	}
+}
+
+// WithCipherSuitePolicy records the content encryption and key wrapping algorithms of the messages received over each
+// connection in the connection record, and rejects the messages protected with the algorithms forbidden by the policy.
+// Renegotiation events are raised by the cipher suite recorder of the context when the algorithms of a connection
+// change or are forbidden.
+func WithCipherSuitePolicy(policy ciphersuite.Policy) Option {
+	return func(opts *Aries) error {
+		opts.cipherSuitePolicy = &policy
+		return nil
+	}
+}
+
+// WithSenderPolicy filters the inbound messages by sender DID before they are handled by the protocol services, the
+// given rules being added to the rules saved by the sender policy. The rules can be changed at runtime through the
+// sender policy of the context.
+func WithSenderPolicy(rules ...senderpolicy.Rule) Option {
+	return func(opts *Aries) error {
+		opts.senderPolicyEnabled = true
+		opts.senderPolicyRules = append(opts.senderPolicyRules, rules...)
+
+		return nil
+	}
+}
+
+// WithInboundWorkers handles the inbound messages with a bounded pool of the given number of workers, instead of
+// handling them serially on the goroutine of the inbound transport. The messages of a same protocol thread are handled
+// in the order they were received by the same worker. The inbound messages are handled asynchronously: the inbound
+// transports don't report the handling errors, which are logged.
+func WithInboundWorkers(workers int) Option {
+	return func(opts *Aries) error {
+		if workers <= 0 {
+			return fmt.Errorf("invalid number of inbound workers: %d", workers)
+		}
+
+		opts.inboundWorkers = workers
+
+		return nil
+	}
+}
+
+// WithShutdownTimeout sets the time Close waits for the inbound workers (see WithInboundWorkers) to handle the queued
+// messages, 30s by default. Close doesn't wait for the messages which aren't handled within the timeout.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(opts *Aries) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid shutdown timeout: %s", timeout)
+		}
+
+		opts.shutdownTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithTracerProvider injects an OpenTelemetry tracer provider used to create spans across the DIDComm
+// dispatch pipeline (inbound and outbound dispatchers, packager). Plug an exporter to the provider to
+// collect the traces. Tracing is disabled by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(opts *Aries) error {
+		opts.tracerProvider = tp
+		return nil
+	}
+}
+
+// WithMetricsProvider injects a provider recording the metrics of the framework internals: packed and unpacked
+// envelopes, protocol state transitions, storage operation latency and transport errors. See the
+// pkg/common/metrics/prometheus package for a Prometheus implementation. Metrics are disabled by default.
+func WithMetricsProvider(mp metrics.Provider) Option {
+	return func(opts *Aries) error {
+		opts.metricsProvider = mp
+		return nil
+	}
+}
+
+// WithLogger routes the framework logs to the loggers of the given provider, see the component/log/zap and
+// component/log/zerolog modules for adapters. Loggers implementing log.StructuredLogger receive the fields of the
+// structured log entries. Note that the logger provider is process-wide: it applies to every framework instance.
+func WithLogger(provider spilog.LoggerProvider) Option {
+	return func(opts *Aries) error {
+		opts.loggerProvider = provider
+		return nil
+	}
+}
+
+// WithLockService injects the service providing the locks guarding the critical sections of the protocol services,
+// like the connection state transitions and the routing grants. Agents running several instances of the same agent
+// identity against a shared storage must share the locks between the instances, see the component/lock/redis module
+// for a Redis implementation. By default the locks are local to the process.
+func WithLockService(ls spilock.Service) Option {
+	return func(opts *Aries) error {
+		opts.lockService = ls
+		return nil
+	}
+}
+
+// WithReplayProtection makes the invitations and the presentation challenges single-use: the DID exchange requests
+// referring to an invitation already accepted by another party, and the presentations whose proof challenge was
+// already used, are rejected with a problem report. The used invitation IDs and challenges are kept for the given
+// TTL (nonce.DefaultTTL if zero), and are checked under the locks of the lock service.
+func WithReplayProtection(ttl time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.replayProtection = true
+		opts.nonceTTL = ttl
+
+		return nil
+	}
+}
+
+// Context provides a handle to the framework context.
+func (a *Aries) Context() (*context.Provider, error) {
+	return context.New(
+		context.WithOutboundDispatcher(a.outboundDispatcher),
+		context.WithMessengerHandler(a.messenger),
+		context.WithOutboundTransports(a.outboundTransports...),
+		context.WithProtocolRegistry(a.protocolRegistry),
+		context.WithKMS(a.kms),
+		context.WithSecretLock(a.secretLock),
+		context.WithCrypto(a.crypto),
+		context.WithServiceEndpoint(serviceEndpoint(a)),
+		context.WithRouterEndpoint(routingEndpoint(a)),
+		context.WithStorageProvider(a.storeProvider),
+		context.WithProtocolStateStorageProvider(a.protocolStateStoreProvider),
+		context.WithPacker(a.primaryPacker, a.packers...),
+		context.WithPackager(a.packager),
+		context.WithVDRegistry(a.vdrRegistry),
+		context.WithTransportReturnRoute(a.transportReturnRoute),
+		context.WithAriesFrameworkID(a.id),
+		context.WithMessageServiceProvider(a.msgSvcProvider),
+		context.WithVerifiableStore(a.verifiableStore),
+		context.WithDIDConnectionStore(a.didConnectionStore),
+		context.WithDIDRotator(a.didRotator),
+		context.WithConnectionUpgrader(a.upgrader),
+		context.WithKeyPinner(a.keyPinner),
+		context.WithCipherSuiteRecorder(a.cipherSuiteRecorder),
+		context.WithSenderPolicy(a.senderPolicy),
+		context.WithProblemReportStore(a.problemReports),
+		context.WithJSONLDContextStore(a.contextStore),
+		context.WithJSONLDRemoteProviderStore(a.remoteProviderStore),
+		context.WithJSONLDDocumentLoader(a.documentLoader),
+		context.WithKeyType(a.keyType),
+		context.WithKeyAgreementType(a.keyAgreementType),
+		context.WithMediaTypeProfiles(a.mediaTypeProfiles),
+		context.WithTracerProvider(a.tracerProvider),
+		context.WithMetricsProvider(a.metricsProvider),
+		context.WithLockService(a.lockService),
+		context.WithNonceStore(a.nonceStore),
+		context.WithOutboundRelays(a.outboundRelays...),
+		context.WithOutboundRetryPolicy(a.outboundRetryPolicy),
+		context.WithInboundPool(a.inboundPool),
+		context.WithInboundMiddleware(a.inboundMiddleware...),
+		context.WithOutboundMiddleware(a.outboundMiddleware...),
+		context.WithEventOutbox(a.eventOutbox),
+	)
+}
+
+// Messenger returns messenger for sending messages through this agent framework
+// TODO should use dedicated messenger interface instead of Outbound dispatcher [Issue #1058].
+func (a *Aries) Messenger() service.Messenger {
+	return a.messenger
+}
+
+// RegisterService registers the protocol service in the running framework, inbound messages accepted by the service
+// are routed to it right away. Use Context to get the provider needed to create the service.
+// Returns an error if a service with the same name is already registered.
+func (a *Aries) RegisterService(svc dispatcher.ProtocolService) error {
+	if err := a.protocolRegistry.Register(svc); err != nil {
+		return fmt.Errorf("register service: %w", err)
+	}
+
+	if err := a.observeStates(svc); err != nil {
+		return fmt.Errorf("register service: %w", err)
+	}
+
+	return nil
+}
+
+// UnregisterService unregisters the protocol service with the given name from the running framework, inbound
+// messages are not routed to it anymore.
+func (a *Aries) UnregisterService(name string) error {
+	if err := a.protocolRegistry.Unregister(name); err != nil {
+		return fmt.Errorf("unregister service: %w", err)
+	}
+
+	if err := a.stopObservingStates(name); err != nil {
+		return fmt.Errorf("unregister service: %w", err)
+	}
+
+	return nil
+}
+
+// Close frees resources being maintained by the framework. The agent is shut down gracefully: the inbound transports
+// stop accepting messages, the messages queued to the inbound workers are handled within the shutdown timeout (see
+// WithShutdownTimeout), the messages queued for an outbound delivery retry get a last delivery attempt, and the stores
+// are closed last.
+func (a *Aries) Close() error {
+	for _, inbound := range a.inboundTransports {
+		if err := inbound.Stop(); err != nil {
+			return fmt.Errorf("inbound transport close failed: %w", err)
+		}
+	}
+
+	if a.inboundPool != nil {
+		if err := a.inboundPool.Drain(a.shutdownTimeout); err != nil {
+			logger.Warnf("inbound messages dropped on shutdown: %s", err)
+		}
+	}
+
+	if a.trustPing != nil {
+		a.trustPing.Stop()
+	}
+
+	if a.contextRefresher != nil {
+		a.contextRefresher.Stop()
+	}
+
+	if a.retentionReaper != nil {
+		a.retentionReaper.Stop()
+	}
+
+	if a.credentialExpiryMonitor != nil {
+		a.credentialExpiryMonitor.Stop()
+	}
+
+	if a.journalCheckpointer != nil {
+		a.journalCheckpointer.Stop()
+	}
+
+	if err := a.stopObservingAllStates(); err != nil {
+		return fmt.Errorf("failed to stop observing protocol states: %w", err)
+	}
+
+	if a.eventOutbox != nil {
+		a.eventOutbox.Stop()
+
+		if err := a.eventSink.Close(); err != nil {
+			return fmt.Errorf("failed to close the event sink: %w", err)
+		}
+	}
+
+	if outbound, ok := a.outboundDispatcher.(*dispatcher.OutboundDispatcher); ok {
+		outbound.Flush()
+	}
+
+	if a.storeProvider != nil {
+		err := a.storeProvider.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close the store: %w", err)
+		}
+	}
+
+	if a.protocolStateStoreProvider != nil {
+		err := a.protocolStateStoreProvider.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close the store: %w", err)
+		}
+	}
+
+	if a.cacheProvider != nil {
+		if err := a.cacheProvider.Close(); err != nil {
+			return fmt.Errorf("failed to close the cache provider: %w", err)
+		}
+	}
+
+	return a.closeVDR()
+}
+
+func (a *Aries) closeVDR() error {
+	if a.vdrRegistry != nil {
+		if err := a.vdrRegistry.Close(); err != nil {
+			return fmt.Errorf("vdr registry close failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func createKMS(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithSecretLock(frameworkOpts.secretLock),
+	)
+	if err != nil {
+		return fmt.Errorf("create context failed: %w", err)
+	}
+
+	frameworkOpts.kms, err = frameworkOpts.kmsCreator(ctx)
+	if err != nil {
+		return fmt.Errorf("create KMS failed: %w", err)
 	}
 
 	return nil
@@ -477,7 +1173,16 @@ func createVDR(frameworkOpts *Aries) error {
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(jwk.New()))
+
+	if frameworkOpts.cacheProvider != nil {
+		cache, err := frameworkOpts.cacheProvider.OpenStore(vdr.ResolutionCacheStoreName)
+		if err != nil {
+			return fmt.Errorf("open DID resolution cache: %w", err)
+		}
+
+		opts = append(opts, vdr.WithResolutionCache(cache))
+	}
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -514,6 +1219,11 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
 		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
 		context.WithKeyAgreementType(frameworkOpts.keyAgreementType),
+		context.WithTracerProvider(frameworkOpts.tracerProvider),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider),
+		context.WithOutboundRelays(frameworkOpts.outboundRelays...),
+		context.WithOutboundRetryPolicy(frameworkOpts.outboundRetryPolicy),
+		context.WithOutboundMiddleware(frameworkOpts.outboundMiddleware...),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
@@ -545,12 +1255,203 @@ func createDIDConnectionStore(frameworkOpts *Aries) error {
 	return err
 }
 
+func createDIDRotator(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithKMS(frameworkOpts.kms),
+		context.WithCrypto(frameworkOpts.crypto),
+		context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.didRotator, err = didrotate.New(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init DID rotator: %w", err)
+	}
+
+	return nil
+}
+
+func createConnectionUpgrader(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithOutboundDispatcher(frameworkOpts.outboundDispatcher),
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	// the services are registered later on, the protocols disclosed are the ones registered at the time of the query
+	frameworkOpts.protocolRegistry = dispatcher.NewProtocolRegistry()
+
+	frameworkOpts.upgrader, err = upgrade.New(ctx, upgrade.WithProtocolRegistry(frameworkOpts.protocolRegistry))
+	if err != nil {
+		return fmt.Errorf("failed to init connection upgrader: %w", err)
+	}
+
+	return nil
+}
+
+func createKeyPinner(frameworkOpts *Aries) error {
+	if frameworkOpts.keyPinningPolicy == nil {
+		return nil
+	}
+
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.keyPinner, err = keypin.New(ctx, *frameworkOpts.keyPinningPolicy)
+	if err != nil {
+		return fmt.Errorf("failed to init key pinner: %w", err)
+	}
+
+	return nil
+}
+
+func createNonceStore(frameworkOpts *Aries) error {
+	if !frameworkOpts.replayProtection {
+		return nil
+	}
+
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithLockService(frameworkOpts.lockService),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.nonceStore, err = nonce.New(ctx, frameworkOpts.nonceTTL)
+	if err != nil {
+		return fmt.Errorf("failed to init nonce store: %w", err)
+	}
+
+	return nil
+}
+
+func createCipherSuiteRecorder(frameworkOpts *Aries) error {
+	if frameworkOpts.cipherSuitePolicy == nil {
+		return nil
+	}
+
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.cipherSuiteRecorder, err = ciphersuite.New(ctx, *frameworkOpts.cipherSuitePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to init cipher suite recorder: %w", err)
+	}
+
+	return nil
+}
+
+func createSenderPolicy(frameworkOpts *Aries) error {
+	if !frameworkOpts.senderPolicyEnabled {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.senderPolicy, err = senderpolicy.New(ctx, frameworkOpts.senderPolicyRules...)
+	if err != nil {
+		return fmt.Errorf("failed to init sender policy: %w", err)
+	}
+
+	return nil
+}
+
+func createEventJournal(frameworkOpts *Aries) error {
+	if !frameworkOpts.eventJournalEnabled {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.eventJournal, err = eventjournal.New(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init event journal: %w", err)
+	}
+
+	return nil
+}
+
+func createEventOutbox(frameworkOpts *Aries) error {
+	if frameworkOpts.eventSink == nil {
+		return nil
+	}
+
+	ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.eventOutbox, err = outbox.New(ctx, frameworkOpts.eventSink, frameworkOpts.eventOutboxOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to init event outbox: %w", err)
+	}
+
+	return nil
+}
+
+func createProblemReportStore(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.problemReports, err = problemreport.New(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init problem report store: %w", err)
+	}
+
+	return nil
+}
+
+func createInboundPool(frameworkOpts *Aries) error {
+	if frameworkOpts.inboundWorkers == 0 {
+		return nil
+	}
+
+	pool, err := inboundpool.NewPool(frameworkOpts.inboundWorkers)
+	if err != nil {
+		return fmt.Errorf("failed to init inbound worker pool: %w", err)
+	}
+
+	frameworkOpts.inboundPool = pool
+
+	return nil
+}
+
 func createJSONLDContextStore(frameworkOpts *Aries) error {
 	if frameworkOpts.contextStore != nil {
 		return nil
 	}
 
-	s, err := ldstore.NewContextStore(frameworkOpts.storeProvider)
+	s, err := ldstore.NewContextStore(ldStoreProvider(frameworkOpts))
 	if err != nil {
 		return fmt.Errorf("failed to init JSON-LD context store: %w", err)
 	}
@@ -565,7 +1466,7 @@ func createJSONLDRemoteProviderStore(frameworkOpts *Aries) error {
 		return nil
 	}
 
-	s, err := ldstore.NewRemoteProviderStore(frameworkOpts.storeProvider)
+	s, err := ldstore.NewRemoteProviderStore(ldStoreProvider(frameworkOpts))
 	if err != nil {
 		return fmt.Errorf("failed to init JSON-LD remote provider store: %w", err)
 	}
@@ -575,6 +1476,15 @@ func createJSONLDRemoteProviderStore(frameworkOpts *Aries) error {
 	return nil
 }
 
+// ldStoreProvider returns the provider of the JSON-LD stores, reading through the cache provider if any.
+func ldStoreProvider(frameworkOpts *Aries) storage.Provider {
+	if frameworkOpts.cacheProvider == nil {
+		return frameworkOpts.storeProvider
+	}
+
+	return cachedstore.NewProvider(frameworkOpts.storeProvider, frameworkOpts.cacheProvider)
+}
+
 func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 	if frameworkOpts.documentLoader != nil {
 		return nil
@@ -596,6 +1506,8 @@ func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 		}
 	}
 
+	loaderOpts = append(loaderOpts, frameworkOpts.documentLoaderOpts...)
+
 	documentLoader, err := ld.NewDocumentLoader(ctx, loaderOpts...)
 	if err != nil {
 		return fmt.Errorf("document loader creation failed: %w", err)
@@ -606,18 +1518,131 @@ func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 	return nil
 }
 
+func startJSONLDContextRefresh(frameworkOpts *Aries) error {
+	if !frameworkOpts.contextRefresh {
+		return nil
+	}
+
+	ctx, err := context.New(
+		context.WithJSONLDContextStore(frameworkOpts.contextStore),
+		context.WithJSONLDRemoteProviderStore(frameworkOpts.remoteProviderStore),
+	)
+	if err != nil {
+		return fmt.Errorf("context creation failed: %w", err)
+	}
+
+	frameworkOpts.contextRefresher = ldsvc.NewRefreshScheduler(ctx, frameworkOpts.contextRefreshOpts...)
+	frameworkOpts.contextRefresher.Start()
+
+	return nil
+}
+
+func startRetentionReaper(frameworkOpts *Aries) error {
+	if len(frameworkOpts.retentionPolicies) == 0 {
+		return nil
+	}
+
+	reaper, err := retention.NewReaper(frameworkOpts.storeProvider, frameworkOpts.retentionPolicies,
+		frameworkOpts.retentionReaperOpts...)
+	if err != nil {
+		return fmt.Errorf("create retention reaper: %w", err)
+	}
+
+	frameworkOpts.retentionReaper = reaper
+	frameworkOpts.retentionReaper.Start()
+
+	return nil
+}
+
+func startCredentialExpiryMonitor(frameworkOpts *Aries) error {
+	if !frameworkOpts.credentialExpiry {
+		return nil
+	}
+
+	store, ok := frameworkOpts.verifiableStore.(*verifiable.StoreImplementation)
+	if !ok {
+		return fmt.Errorf("credential expiry monitor: unsupported verifiable store %T", frameworkOpts.verifiableStore)
+	}
+
+	frameworkOpts.credentialExpiryMonitor = verifiable.NewExpiryMonitor(store, frameworkOpts.credentialExpiryOpts...)
+	frameworkOpts.credentialExpiryMonitor.Start()
+
+	return nil
+}
+
+func startTimestamping(frameworkOpts *Aries) error {
+	if frameworkOpts.timestampStamper == nil {
+		return nil
+	}
+
+	// the service isn't registered when the issue credential protocol is disabled
+	if svc, ok := frameworkOpts.protocolRegistry.Service(issuecredential.Name); ok {
+		issueCredential, ok := svc.(*issuecredential.Service)
+		if !ok {
+			return fmt.Errorf("credential timestamps: unsupported issue credential service %T", svc)
+		}
+
+		ctx, err := context.New(context.WithStorageProvider(frameworkOpts.storeProvider))
+		if err != nil {
+			return fmt.Errorf("context creation failed: %w", err)
+		}
+
+		mw, err := mdissuecredential.TimestampCredentials(ctx, frameworkOpts.timestampStamper)
+		if err != nil {
+			return fmt.Errorf("credential timestamps: %w", err)
+		}
+
+		issueCredential.AddMiddleware(mw)
+	}
+
+	if frameworkOpts.eventJournal != nil {
+		frameworkOpts.journalCheckpointer = eventjournal.NewCheckpointer(frameworkOpts.eventJournal,
+			frameworkOpts.timestampStamper, frameworkOpts.checkpointInterval)
+		frameworkOpts.journalCheckpointer.Start()
+	}
+
+	return nil
+}
+
+func startTrustPingHealthCheck(frameworkOpts *Aries) {
+	if frameworkOpts.trustPingHealthCheck == nil {
+		return
+	}
+
+	// the service isn't registered when the trust ping protocol is disabled
+	svc, ok := frameworkOpts.protocolRegistry.Service(trustping.TrustPing)
+	if !ok {
+		return
+	}
+
+	if trustPing, ok := svc.(*trustping.Service); ok {
+		frameworkOpts.trustPing = trustPing
+		frameworkOpts.trustPing.Start()
+	}
+}
+
 func startTransports(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithCrypto(frameworkOpts.crypto),
 		context.WithPackager(frameworkOpts.packager),
-		context.WithProtocolServices(frameworkOpts.services...),
+		context.WithProtocolRegistry(frameworkOpts.protocolRegistry),
 		context.WithAriesFrameworkID(frameworkOpts.id),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithMessengerHandler(frameworkOpts.messenger),
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+		context.WithDIDRotator(frameworkOpts.didRotator),
+		context.WithConnectionUpgrader(frameworkOpts.upgrader),
+		context.WithKeyPinner(frameworkOpts.keyPinner),
+		context.WithCipherSuiteRecorder(frameworkOpts.cipherSuiteRecorder),
+		context.WithSenderPolicy(frameworkOpts.senderPolicy),
+		context.WithProblemReportStore(frameworkOpts.problemReports),
 		context.WithKeyType(frameworkOpts.keyType),
 		context.WithKeyAgreementType(frameworkOpts.keyAgreementType),
 		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
+		context.WithTracerProvider(frameworkOpts.tracerProvider),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider),
+		context.WithInboundPool(frameworkOpts.inboundPool),
+		context.WithInboundMiddleware(frameworkOpts.inboundMiddleware...),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
@@ -642,6 +1667,7 @@ func startTransports(frameworkOpts *Aries) error {
 
 func loadServices(frameworkOpts *Aries) error {
 	ctx, err := context.New(
+		context.WithProtocolRegistry(frameworkOpts.protocolRegistry),
 		context.WithOutboundDispatcher(frameworkOpts.outboundDispatcher),
 		context.WithMessengerHandler(frameworkOpts.messenger),
 		context.WithStorageProvider(frameworkOpts.storeProvider),
@@ -654,33 +1680,63 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithVDRegistry(frameworkOpts.vdrRegistry),
 		context.WithVerifiableStore(frameworkOpts.verifiableStore),
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+		context.WithDIDRotator(frameworkOpts.didRotator),
+		context.WithKeyPinner(frameworkOpts.keyPinner),
+		context.WithCipherSuiteRecorder(frameworkOpts.cipherSuiteRecorder),
+		context.WithSenderPolicy(frameworkOpts.senderPolicy),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithJSONLDDocumentLoader(frameworkOpts.documentLoader),
 		context.WithKeyType(frameworkOpts.keyType),
 		context.WithKeyAgreementType(frameworkOpts.keyAgreementType),
 		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
+		context.WithTracerProvider(frameworkOpts.tracerProvider),
+		context.WithInboundPool(frameworkOpts.inboundPool),
+		context.WithInboundMiddleware(frameworkOpts.inboundMiddleware...),
+		context.WithLockService(frameworkOpts.lockService),
+		context.WithNonceStore(frameworkOpts.nonceStore),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
 	}
 
 	for _, v := range frameworkOpts.protocolSvcCreators {
-		svc, svcErr := v(ctx)
+		if v.name != "" && frameworkOpts.protocolDisabled(v.name) {
+			logger.Infof("protocol service %s is disabled", v.name)
+
+			continue
+		}
+
+		svc, svcErr := v.create(ctx)
 		if svcErr != nil {
 			return fmt.Errorf("new protocol service failed: %w", svcErr)
 		}
 
-		frameworkOpts.services = append(frameworkOpts.services, svc)
-		// after service was successfully created we need to add it to the context
-		// since the introduce protocol depends on did-exchange
-		if err := context.WithProtocolServices(frameworkOpts.services...)(ctx); err != nil {
-			return err
+		// the name of the services injected with WithProtocols is only known once created
+		if frameworkOpts.protocolDisabled(svc.Name()) {
+			logger.Infof("protocol service %s is disabled", svc.Name())
+
+			continue
+		}
+
+		// the registry is shared with the context since the introduce protocol depends on did-exchange
+		if err := frameworkOpts.protocolRegistry.Register(svc); err != nil {
+			return fmt.Errorf("register protocol service failed: %w", err)
+		}
+
+		if err := frameworkOpts.observeStates(svc); err != nil {
+			return fmt.Errorf("observe protocol service failed: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// protocolDisabled reports whether the protocol service with the given name is disabled with WithoutProtocols, or
+// not enabled with WithOnlyProtocols.
+func (a *Aries) protocolDisabled(name string) bool {
+	return a.disabledProtocols[name] || a.enabledProtocols != nil && !a.enabledProtocols[name]
+}
+
 func createPackersAndPackager(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithCrypto(frameworkOpts.crypto),
@@ -711,7 +1767,9 @@ func createPackersAndPackager(frameworkOpts *Aries) error {
 	}
 
 	ctx, err = context.New(context.WithPacker(frameworkOpts.primaryPacker, frameworkOpts.packers...),
-		context.WithStorageProvider(frameworkOpts.storeProvider), context.WithVDRegistry(frameworkOpts.vdrRegistry))
+		context.WithStorageProvider(frameworkOpts.storeProvider), context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithTracerProvider(frameworkOpts.tracerProvider),
+		context.WithMetricsProvider(frameworkOpts.metricsProvider))
 	if err != nil {
 		return fmt.Errorf("create packager context failed: %w", err)
 	}
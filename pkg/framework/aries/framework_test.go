@@ -17,19 +17,42 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/lock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log/mocklogger"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/awscrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ciphersuite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	inboundpool "github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/inbound"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/keypin"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/keybackup"
+	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/profile"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
@@ -37,6 +60,8 @@ import (
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	ldsvc "github.com/hyperledger/aries-framework-go/pkg/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/awskms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
@@ -44,15 +69,33 @@ import (
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockeventsink "github.com/hyperledger/aries-framework-go/pkg/mock/eventsink"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockawskms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/awskms"
 	mockldstore "github.com/hyperledger/aries-framework-go/pkg/mock/ld"
+	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/vault"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/eventjournal"
+	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	"github.com/hyperledger/aries-framework-go/pkg/store/outbox"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/instrumented"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/namespace"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	jwkvdr "github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
 )
 
 //nolint:lll
@@ -205,6 +248,26 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - resolves did:key and did:jwk by default", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		didKey := "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		resolvedDoc, err := aries.vdrRegistry.Resolve(didKey)
+		require.NoError(t, err)
+		require.Equal(t, didKey, resolvedDoc.DIDDocument.ID)
+
+		jwkDoc, err := aries.vdrRegistry.Create(jwkvdr.DIDMethod, resolvedDoc.DIDDocument)
+		require.NoError(t, err)
+
+		resolvedDoc, err = aries.vdrRegistry.Resolve(jwkDoc.DIDDocument.ID)
+		require.NoError(t, err)
+		require.Equal(t, jwkDoc.DIDDocument.VerificationMethod[0].Value,
+			resolvedDoc.DIDDocument.VerificationMethod[0].Value)
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with default protocol", func(t *testing.T) {
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
 		require.NoError(t, err)
@@ -262,6 +325,42 @@ func TestFramework(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("test register and unregister protocol service at runtime", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		prov, err := aries.Context()
+		require.NoError(t, err)
+
+		_, err = prov.Service("mockProtocolSvc")
+		require.ErrorIs(t, err, api.ErrSvcNotFound)
+
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{ProtocolName: "mockProtocolSvc"}))
+
+		// contexts created before the registration route messages to the new service
+		_, err = prov.Service("mockProtocolSvc")
+		require.NoError(t, err)
+
+		err = aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{ProtocolName: "mockProtocolSvc"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "register service: registration failed, protocol service with name "+
+			"`mockProtocolSvc` already registered")
+
+		require.NoError(t, aries.UnregisterService("mockProtocolSvc"))
+
+		_, err = prov.Service("mockProtocolSvc")
+		require.ErrorIs(t, err, api.ErrSvcNotFound)
+
+		_, err = prov.Service(didexchange.DIDExchange)
+		require.NoError(t, err)
+
+		err = aries.UnregisterService("mockProtocolSvc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unregister service: failed to unregister")
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test error from protocol service", func(t *testing.T) {
 		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
 			return nil, errors.New("error creating the protocol")
@@ -293,6 +392,29 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "inbound transport close failed")
 	})
 
+	t.Run("test KMS and crypto svc - with AWS KMS", func(t *testing.T) {
+		client := &mockawskms.Client{}
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}),
+			WithKMS(func(ctx kms.Provider) (kms.KeyManager, error) {
+				return awskms.New(client), nil
+			}),
+			WithCrypto(awscrypto.New(client)))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		_, kh, err := ctx.KMS().Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		signature, err := ctx.Crypto().Sign([]byte("msg"), kh)
+		require.NoError(t, err)
+		require.NoError(t, ctx.Crypto().Verify(signature, []byte("msg"), kh))
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test KMS svc - with user provided instance", func(t *testing.T) {
 		// with custom KMS
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}),
@@ -407,6 +529,19 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test new with vault secret lock svc", func(t *testing.T) {
+		a, err := New(WithVaultSecretLock("http://localhost:8200", vault.WithToken("token")),
+			WithStoreProvider(storage.NewMockStoreProvider()))
+		require.NoError(t, err)
+		require.IsType(t, &vault.Lock{}, a.secretLock)
+
+		require.NoError(t, a.Close())
+
+		_, err = New(WithVaultSecretLock(""))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create vault secret lock: vault address is required")
+	})
+
 	t.Run("test new with custom (unprotected master key) secret lock svc and with custom KMS", func(t *testing.T) {
 		masterKeyFilePath := "masterKey_aries.txt"
 		tmpfile, err := ioutil.TempFile("", masterKeyFilePath)
@@ -545,6 +680,53 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, s, aries.protocolStateStoreProvider)
 	})
 
+	t.Run("test store providers - with namespace", func(t *testing.T) {
+		shared := mem.NewProvider()
+
+		alice, err := New(WithStoreProvider(shared, namespace.WithNamespace("alice")),
+			WithProtocolStateStoreProvider(shared, namespace.WithNamespace("alice-state")))
+		require.NoError(t, err)
+
+		bob, err := New(WithStoreProvider(shared, namespace.WithNamespace("bob")))
+		require.NoError(t, err)
+
+		for _, a := range []struct {
+			framework *Aries
+			prefix    string
+		}{{alice, "alice"}, {bob, "bob"}} {
+			ctx, e := a.framework.Context()
+			require.NoError(t, e)
+
+			store, e := ctx.StorageProvider().OpenStore(connection.Namespace)
+			require.NoError(t, e)
+
+			sharedStore, e := shared.OpenStore(a.prefix + namespace.Separator + connection.Namespace)
+			require.NoError(t, e)
+			require.Equal(t, sharedStore, store)
+		}
+
+		ctx, err := alice.Context()
+		require.NoError(t, err)
+
+		store, err := ctx.ProtocolStateStorageProvider().OpenStore(connection.Namespace)
+		require.NoError(t, err)
+
+		sharedStore, err := shared.OpenStore("alice-state" + namespace.Separator + connection.Namespace)
+		require.NoError(t, err)
+		require.Equal(t, sharedStore, store)
+
+		require.NoError(t, alice.Close())
+		require.NoError(t, bob.Close())
+
+		_, err = New(WithStoreProvider(shared, namespace.WithEncryptedStoreNames([]byte{})))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store provider: store names encryption key is empty")
+
+		_, err = New(WithProtocolStateStoreProvider(shared, namespace.WithEncryptedStoreNames([]byte{})))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "protocol state store provider: store names encryption key is empty")
+	})
+
 	t.Run("test new with outbound transport service", func(t *testing.T) {
 		aries, err := New(WithOutboundTransports(&didcomm.MockOutboundTransport{ExpectedResponse: "data"},
 			&didcomm.MockOutboundTransport{ExpectedResponse: "data1"}))
@@ -577,9 +759,10 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, aries.Close())
 
 		transportReturnRoute = decorator.TransportReturnRouteThread
-		_, err = New(WithTransportReturnRoute(transportReturnRoute))
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "invalid transport return route option : "+transportReturnRoute)
+		aries, err = New(WithTransportReturnRoute(transportReturnRoute))
+		require.NoError(t, err)
+		require.Equal(t, transportReturnRoute, aries.transportReturnRoute)
+		require.NoError(t, aries.Close())
 
 		transportReturnRoute = decorator.TransportReturnRouteNone
 		aries, err = New(WithTransportReturnRoute(transportReturnRoute))
@@ -654,6 +837,104 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, loader, aries.documentLoader)
 	})
 
+	t.Run("test JSON-LD document loader opts option", func(t *testing.T) {
+		contexts, err := ld.NewContextsLoader(ldcontext.Document{
+			URL:     "https://example.com/context.jsonld",
+			Content: []byte(`{"@context":{"name":"http://xmlns.com/foaf/0.1/name"}}`),
+		})
+		require.NoError(t, err)
+
+		aries, err := New(WithJSONLDDocumentLoaderOpts(ld.WithLoader("contexts", contexts, ld.WithoutCaching())))
+		require.NoError(t, err)
+
+		rd, err := aries.documentLoader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with JSON-LD context refresh", func(t *testing.T) {
+		aries, err := New(WithJSONLDContextRefresh(ldsvc.WithRefreshInterval(time.Hour)))
+		require.NoError(t, err)
+		require.NotNil(t, aries.contextRefresher)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with retention policy", func(t *testing.T) {
+		aries, err := New(WithRetentionPolicy(presentproof.Name, retention.KeepNothing(),
+			retention.WithReapInterval(time.Hour)))
+		require.NoError(t, err)
+		require.NotNil(t, aries.retentionReaper)
+
+		store, err := aries.storeProvider.OpenStore(presentproof.Name)
+		require.NoError(t, err)
+		require.NoError(t, store.Put("internal_data_piid", []byte("{}"), retention.Tags(true)...))
+
+		deleted, err := aries.retentionReaper.Reap(time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 1, deleted)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with protocol state TTL", func(t *testing.T) {
+		aries, err := New(WithProtocolStateTTL(24 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 24*time.Hour, aries.protocolStateTTL)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error create retention reaper", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = "custom-store"
+
+		_, err := New(WithStoreProvider(sp), WithRetentionPolicy("custom-store", retention.KeepFor(time.Hour)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create retention reaper")
+	})
+
+	t.Run("test new with credential expiry monitor", func(t *testing.T) {
+		aries, err := New(WithCredentialExpiryMonitor(verifiable.WithExpiryCheckInterval(time.Hour)))
+		require.NoError(t, err)
+		require.NotNil(t, aries.credentialExpiryMonitor)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error credential expiry monitor with a custom verifiable store", func(t *testing.T) {
+		_, err := New(WithVerifiableStore(&verifiableStoreMocks.MockStore{}), WithCredentialExpiryMonitor())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential expiry monitor: unsupported verifiable store")
+	})
+
+	t.Run("test new with cache provider", func(t *testing.T) {
+		cache := mem.NewProvider()
+
+		aries, err := New(WithCacheProvider(cache))
+		require.NoError(t, err)
+
+		// the embedded JSON-LD contexts are imported through the cache
+		s, err := cache.OpenStore(ldstore.ContextStoreName)
+		require.NoError(t, err)
+
+		_, err = s.Get("https://www.w3.org/2018/credentials/v1")
+		require.NoError(t, err)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error open DID resolution cache", func(t *testing.T) {
+		cache := storage.NewMockStoreProvider()
+		cache.FailNamespace = vdr.ResolutionCacheStoreName
+
+		_, err := New(WithCacheProvider(cache))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open DID resolution cache")
+	})
+
 	t.Run("test KeyType and KeyAgreement option", func(t *testing.T) {
 		aries, err := New(WithKeyType(kms.BLS12381G2Type), WithKeyAgreementType(kms.NISTP384ECDHKWType))
 		require.NoError(t, err)
@@ -671,6 +952,556 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, transport.MediaTypeV2EncryptedEnvelope, aries.mediaTypeProfiles[0])
 		require.Equal(t, transport.MediaTypeV1EncryptedEnvelope, aries.mediaTypeProfiles[1])
 	})
+
+	t.Run("test new with tracer provider", func(t *testing.T) {
+		tp := &mocktracing.TracerProvider{}
+
+		aries, err := New(WithTracerProvider(tp))
+		require.NoError(t, err)
+		require.Equal(t, tp, aries.tracerProvider)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, tp, ctx.TracerProvider())
+	})
+
+	t.Run("test new with lock service", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.IsType(t, &lock.Local{}, aries.lockService)
+		require.NoError(t, aries.Close())
+
+		ls := &mocklock.MockLockService{}
+
+		var svcLockService spilock.Service
+
+		aries, err = New(WithLockService(ls), WithProtocols(func(prv api.Provider) (dispatcher.ProtocolService, error) {
+			svcLockService = prv.LockService()
+
+			return &mockdidexchange.MockDIDExchangeSvc{ProtocolName: "mockProtocolSvc"}, nil
+		}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, ls, ctx.LockService())
+		// the protocol services share the lock service
+		require.Equal(t, ls, svcLockService)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with metrics provider", func(t *testing.T) {
+		mp := &mockmetrics.Provider{}
+
+		aries, err := New(WithMetricsProvider(mp))
+		require.NoError(t, err)
+		require.Equal(t, mp, aries.metricsProvider)
+		require.IsType(t, &instrumented.Provider{}, aries.storeProvider)
+		require.IsType(t, &instrumented.Provider{}, aries.protocolStateStoreProvider)
+		require.NotEmpty(t, mp.Observations(mockmetrics.StorageOperation))
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, mp, ctx.MetricsProvider())
+
+		var states chan<- service.StateMsg
+
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			RegisterMsgEventHandle: func(ch chan<- service.StateMsg) error {
+				states = ch
+				return nil
+			},
+		}))
+		require.NotNil(t, states)
+
+		states <- service.StateMsg{ProtocolName: "mockProtocolSvc", Type: service.PreState, StateID: "requested"}
+		states <- service.StateMsg{ProtocolName: "mockProtocolSvc", Type: service.PostState, StateID: "requested"}
+
+		require.Eventually(t, func() bool {
+			return len(mp.Observations(mockmetrics.StateTransition)) == 1
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, []string{"mockProtocolSvc", "requested"},
+			mp.Observations(mockmetrics.StateTransition)[0].Labels)
+
+		require.NoError(t, aries.UnregisterService("mockProtocolSvc"))
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test metrics provider state events errors", func(t *testing.T) {
+		aries, err := New(WithMetricsProvider(&mockmetrics.Provider{}))
+		require.NoError(t, err)
+
+		err = aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName:        "mockProtocolSvc",
+			RegisterMsgEventErr: errors.New("register error"),
+		})
+		require.EqualError(t, err, "register service: register state events of mockProtocolSvc: register error")
+
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName:          "otherProtocolSvc",
+			UnregisterMsgEventErr: errors.New("unregister error"),
+		}))
+
+		err = aries.UnregisterService("otherProtocolSvc")
+		require.EqualError(t, err, "unregister service: unregister state events of otherProtocolSvc: unregister error")
+
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithMetricsProvider(&mockmetrics.Provider{}),
+			WithProtocols(func(api.Provider) (dispatcher.ProtocolService, error) {
+				return &mockdidexchange.MockDIDExchangeSvc{
+					ProtocolName:        "mockProtocolSvc",
+					RegisterMsgEventErr: errors.New("register error"),
+				}, nil
+			}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "observe protocol service failed")
+	})
+
+	t.Run("test new with logger", func(t *testing.T) {
+		defer log.Initialize(nil)
+
+		mockLogger := &mocklogger.MockLogger{}
+		provider := &mocklogger.Provider{MockLogger: mockLogger}
+
+		aries, err := New(WithLogger(provider))
+		require.NoError(t, err)
+		require.Equal(t, provider, aries.loggerProvider)
+
+		log.New("aries-framework/test").Infof("routed to %s", "custom logger")
+		require.Contains(t, mockLogger.InfoLogContents, "routed to custom logger")
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with event journal", func(t *testing.T) {
+		aries, err := New(WithEventJournal())
+		require.NoError(t, err)
+		require.NotNil(t, aries.eventJournal)
+
+		var states chan<- service.StateMsg
+
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			RegisterMsgEventHandle: func(ch chan<- service.StateMsg) error {
+				states = ch
+				return nil
+			},
+		}))
+		require.NotNil(t, states)
+
+		states <- service.StateMsg{ProtocolName: "mockProtocolSvc", Type: service.PreState, StateID: "requested"}
+		states <- service.StateMsg{ProtocolName: "mockProtocolSvc", Type: service.PostState, StateID: "requested"}
+
+		var records []*eventjournal.Record
+
+		require.Eventually(t, func() bool {
+			records, err = aries.eventJournal.Replay(0, 0)
+
+			return err == nil && len(records) == 2
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, eventjournal.PreState, records[0].Type)
+		require.Equal(t, eventjournal.PostState, records[1].Type)
+		require.Equal(t, "requested", records[1].StateID)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error create event journal", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = eventjournal.StoreName
+
+		_, err := New(WithStoreProvider(sp), WithEventJournal())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init event journal")
+	})
+
+	t.Run("test new with timestamp authority", func(t *testing.T) {
+		aries, err := New(WithEventJournal(), WithTimestampAuthority(&mockStamper{}, time.Millisecond))
+		require.NoError(t, err)
+		require.NotNil(t, aries.journalCheckpointer)
+
+		_, err = aries.eventJournal.Append(service.StateMsg{ProtocolName: "mockProtocolSvc", StateID: "requested"})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			checkpoints, err := aries.eventJournal.Checkpoints()
+
+			return err == nil && len(checkpoints) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error open credential timestamps store", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = mdissuecredential.CredentialTimestampsStoreName
+
+		_, err := New(WithStoreProvider(sp), WithTimestampAuthority(&mockStamper{}, 0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential timestamps: open credential timestamps store")
+	})
+
+	t.Run("test new with event sink", func(t *testing.T) {
+		sink := &mockeventsink.MockSink{}
+
+		aries, err := New(WithEventSink(sink, outbox.WithRetryInterval(time.Millisecond)))
+		require.NoError(t, err)
+		require.NotNil(t, aries.eventOutbox)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.eventOutbox, ctx.EventOutbox())
+
+		var states chan<- service.StateMsg
+
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			RegisterMsgEventHandle: func(ch chan<- service.StateMsg) error {
+				states = ch
+				return nil
+			},
+		}))
+		require.NotNil(t, states)
+
+		states <- service.StateMsg{ProtocolName: "mockProtocolSvc", Type: service.PostState, StateID: "requested"}
+
+		require.Eventually(t, func() bool {
+			return len(sink.Events()) == 1
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, "mockProtocolSvc_states", sink.Events()[0].Topic)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error create event outbox", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = outbox.StoreName
+
+		_, err := New(WithStoreProvider(sp), WithEventSink(&mockeventsink.MockSink{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init event outbox")
+	})
+
+	t.Run("test error close event sink", func(t *testing.T) {
+		aries, err := New(WithEventSink(&mockeventsink.MockSink{CloseErr: errors.New("close error")}))
+		require.NoError(t, err)
+
+		err = aries.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to close the event sink: close error")
+	})
+
+	t.Run("test new with outbound relays", func(t *testing.T) {
+		relay := &service.Destination{ServiceEndpoint: "http://relay.example.com", RecipientKeys: []string{"key"}}
+
+		aries, err := New(WithOutboundRelays(relay), WithForwardRelay("http://next.example.com"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://next.example.com"}, aries.relayNextHops)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, []*service.Destination{relay}, ctx.OutboundRelays())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with outbound retry policy", func(t *testing.T) {
+		policy := &dispatcher.RetryPolicy{MaxAttempts: 3}
+
+		aries, err := New(WithOutboundRetryPolicy(policy))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, policy, ctx.OutboundRetryPolicy())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with message middleware", func(t *testing.T) {
+		mw := func(next dispatcher.MessageHandler) dispatcher.MessageHandler { return next }
+
+		aries, err := New(WithInboundMiddleware(mw), WithInboundMiddleware(mw), WithOutboundMiddleware(mw))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Len(t, ctx.InboundMiddleware(), 2)
+		require.Len(t, ctx.OutboundMiddleware(), 1)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with trust ping health check", func(t *testing.T) {
+		aries, err := New(WithTrustPing(), WithTrustPingHealthCheck(trustping.HealthCheckConfig{Interval: time.Hour}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.trustPing)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(trustping.TrustPing)
+		require.NoError(t, err)
+		require.Equal(t, aries.trustPing, svc)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithTrustPing(), WithTrustPingHealthCheck(trustping.HealthCheckConfig{}),
+			WithoutProtocols(trustping.TrustPing))
+		require.NoError(t, err)
+		require.Nil(t, aries.trustPing)
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithTrustPingHealthCheck(trustping.HealthCheckConfig{}))
+		require.NoError(t, err)
+		require.Nil(t, aries.trustPing)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with key pinning", func(t *testing.T) {
+		aries, err := New(WithKeyPinning(keypin.Block))
+		require.NoError(t, err)
+		require.NotNil(t, aries.keyPinner)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.keyPinner, ctx.KeyPinner())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with key pinning - error", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = keypin.StoreName
+
+		_, err := New(WithKeyPinning(keypin.Alert), WithStoreProvider(sp))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init key pinner")
+	})
+
+	t.Run("test new with cipher suite policy", func(t *testing.T) {
+		aries, err := New(WithCipherSuitePolicy(ciphersuite.Policy{ForbiddenContentEncryption: []string{"A256CBC-HS512"}}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.cipherSuiteRecorder)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.cipherSuiteRecorder, ctx.CipherSuiteRecorder())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with sender policy", func(t *testing.T) {
+		aries, err := New(WithSenderPolicy(senderpolicy.Rule{ID: "no-did-key", Sender: "did:key:*",
+			Action: senderpolicy.Deny}))
+		require.NoError(t, err)
+		require.NotNil(t, aries.senderPolicy)
+		require.Len(t, aries.senderPolicy.Rules(), 1)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.senderPolicy, ctx.SenderPolicy())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with sender policy - error", func(t *testing.T) {
+		_, err := New(WithSenderPolicy(senderpolicy.Rule{ID: "invalid"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init sender policy")
+	})
+
+	t.Run("test new with replay protection", func(t *testing.T) {
+		aries, err := New(WithReplayProtection(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, aries.nonceStore)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, aries.nonceStore, ctx.NonceStore())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with replay protection - error", func(t *testing.T) {
+		sp := storage.NewMockStoreProvider()
+		sp.FailNamespace = nonce.StoreName
+
+		_, err := New(WithReplayProtection(0), WithStoreProvider(sp))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init nonce store")
+	})
+
+	t.Run("test new with inbound workers", func(t *testing.T) {
+		aries, err := New(WithInboundWorkers(2))
+		require.NoError(t, err)
+		require.NotNil(t, aries.inboundPool)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{"@id":"1",` +
+			`"@type":"https://didcomm.org/unknown/1.0/msg"}`)})
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{"@id":"1",` +
+			`"@type":"https://didcomm.org/unknown/1.0/msg"}`)})
+		require.ErrorIs(t, err, inboundpool.ErrPoolClosed)
+	})
+
+	t.Run("test close drains the inbound workers", func(t *testing.T) {
+		aries, err := New(WithInboundWorkers(1), WithShutdownTimeout(time.Second))
+		require.NoError(t, err)
+
+		var handled int32
+
+		// the DID exchange messages are handled without looking up the DIDs of the connection
+		require.NoError(t, aries.UnregisterService(didexchange.DIDExchange))
+		require.NoError(t, aries.RegisterService(&mockdidexchange.MockDIDExchangeSvc{
+			HandleFunc: func(service.DIDCommMsg) (string, error) {
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&handled, 1)
+
+				return "", nil
+			},
+		}))
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(fmt.Sprintf(
+				`{"@id":"%d","@type":"https://didcomm.org/didexchange/1.0/request"}`, i))}))
+		}
+
+		require.NoError(t, aries.Close())
+		require.Equal(t, int32(3), atomic.LoadInt32(&handled))
+	})
+
+	t.Run("test new with invalid shutdown timeout", func(t *testing.T) {
+		_, err := New(WithShutdownTimeout(0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid shutdown timeout: 0s")
+	})
+
+	t.Run("test new with invalid inbound workers", func(t *testing.T) {
+		_, err := New(WithInboundWorkers(0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid number of inbound workers: 0")
+	})
+
+	t.Run("test new with forward relay without next hops", func(t *testing.T) {
+		_, err := New(WithForwardRelay())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the allowed next hop service endpoints are mandatory")
+	})
+
+	t.Run("test new with invalid outbound relay", func(t *testing.T) {
+		_, err := New(WithOutboundRelays(&service.Destination{ServiceEndpoint: "http://relay.example.com"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "relay 0: service endpoint and recipient keys are mandatory")
+	})
+
+	t.Run("test new with additional packers", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}),
+			WithAdditionalPackers(func(ctx packer.Provider) (packer.Packer, error) {
+				return &didcomm.MockAuthCrypt{}, nil
+			}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		// default packers are kept
+		require.Len(t, ctx.Packers(), 4)
+		require.IsType(t, &didcomm.MockAuthCrypt{}, ctx.Packers()[3])
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new without protocols", func(t *testing.T) {
+		aries, err := New(WithoutProtocols("introduce"))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		_, err = ctx.Service("introduce")
+		require.Error(t, err)
+
+		_, err = ctx.Service(didexchange.DIDExchange)
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with a disabled named protocol", func(t *testing.T) {
+		aries, err := New(
+			WithNamedProtocol("mockProtocolSvc", func(prv api.Provider) (dispatcher.ProtocolService, error) {
+				return nil, errors.New("disabled protocol service created")
+			}),
+			WithoutProtocols("mockProtocolSvc"),
+		)
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new without a protocol other protocols depend on", func(t *testing.T) {
+		_, err := New(WithoutProtocols(didexchange.DIDExchange))
+		require.EqualError(t, err, "default option initialization failed: protocol service out-of-band depends on "+
+			"the disabled protocol service didexchange")
+
+		aries, err := New(WithoutProtocols(didexchange.DIDExchange, outofband.Name, introduce.Introduce))
+		require.NoError(t, err)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with optional protocols", func(t *testing.T) {
+		optional := []string{
+			actionmenu.ActionMenu, questionanswer.QuestionAnswer, keybackup.KeyBackup, trustping.TrustPing, profile.Name,
+		}
+
+		aries, err := New()
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		for _, name := range optional {
+			_, err = ctx.Service(name)
+			require.Error(t, err, name)
+		}
+
+		require.NoError(t, aries.Close())
+
+		aries, err = New(WithActionMenu(), WithQuestionAnswer(), WithKeyBackup(), WithTrustPing(), WithProfile())
+		require.NoError(t, err)
+
+		ctx, err = aries.Context()
+		require.NoError(t, err)
+
+		for _, name := range optional {
+			_, err = ctx.Service(name)
+			require.NoError(t, err, name)
+		}
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with only protocols", func(t *testing.T) {
+		aries, err := New(WithTrustPing(), WithOnlyProtocols(presentproof.Name, trustping.TrustPing))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Len(t, ctx.ProtocolServices(), 2)
+
+		_, err = ctx.Service(issuecredential.Name)
+		require.Error(t, err)
+
+		_, err = ctx.Service(presentproof.Name)
+		require.NoError(t, err)
+
+		// the protocols disclosed to the discover-features queries
+		require.Equal(t, []string{
+			"https://didcomm.org/present-proof/2.0",
+			"https://didcomm.org/present-proof/3.0",
+			"https://didcomm.org/trust_ping/1.0",
+		}, aries.protocolRegistry.PIURIs())
+		require.NoError(t, aries.Close())
+	})
 }
 
 func Test_Packager(t *testing.T) {
@@ -715,6 +1546,12 @@ func startMockServer(t *testing.T, handler http.Handler) net.Listener {
 	return listener
 }
 
+type mockStamper struct{}
+
+func (s *mockStamper) Timestamp(data []byte) (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Token: data, Time: time.Now().UTC()}, nil
+}
+
 type mockHTTPHandler struct{}
 
 func (m mockHTTPHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
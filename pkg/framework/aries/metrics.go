@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+)
+
+// stateMsgBufferSize is the size of the channel receiving the state messages of an observed protocol service.
+const stateMsgBufferSize = 10
+
+// stateObserver counts the state transitions of a protocol service with the metrics provider and appends its state
+// events to the event journal and to the event outbox.
+type stateObserver struct {
+	event service.Event
+	msgs  chan service.StateMsg
+	done  chan struct{}
+}
+
+// observeStates counts the state transitions of the protocol service if metrics are enabled, journals its state
+// events if the event journal is enabled, and publishes them if an event sink is set, when the service emits state
+// events.
+func (a *Aries) observeStates(svc dispatcher.ProtocolService) error {
+	event, ok := svc.(service.Event)
+	if (a.metricsProvider == nil && a.eventJournal == nil && a.eventOutbox == nil) || !ok {
+		return nil
+	}
+
+	o := &stateObserver{
+		event: event,
+		msgs:  make(chan service.StateMsg, stateMsgBufferSize),
+		done:  make(chan struct{}),
+	}
+
+	if err := event.RegisterMsgEvent(o.msgs); err != nil {
+		return fmt.Errorf("register state events of %s: %w", svc.Name(), err)
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-o.msgs:
+				a.stateMsg(msg)
+			case <-o.done:
+				return
+			}
+		}
+	}()
+
+	a.stateObserversMutex.Lock()
+	defer a.stateObserversMutex.Unlock()
+
+	if a.stateObservers == nil {
+		a.stateObservers = make(map[string]*stateObserver)
+	}
+
+	a.stateObservers[svc.Name()] = o
+
+	return nil
+}
+
+func (a *Aries) stateMsg(msg service.StateMsg) {
+	if a.metricsProvider != nil && msg.Type == service.PostState {
+		a.metricsProvider.StateTransition(msg.ProtocolName, msg.StateID)
+	}
+
+	if a.eventJournal != nil {
+		if _, err := a.eventJournal.Append(msg); err != nil {
+			logger.Errorf("failed to journal the %s state event: %s", msg.ProtocolName, err)
+		}
+	}
+
+	if a.eventOutbox != nil {
+		if err := a.eventOutbox.AddStateEvent(msg); err != nil {
+			logger.Errorf("failed to add the %s state event to the outbox: %s", msg.ProtocolName, err)
+		}
+	}
+}
+
+// stopObservingStates stops observing the state events of the protocol service with the given name.
+func (a *Aries) stopObservingStates(name string) error {
+	a.stateObserversMutex.Lock()
+	defer a.stateObserversMutex.Unlock()
+
+	o, ok := a.stateObservers[name]
+	if !ok {
+		return nil
+	}
+
+	delete(a.stateObservers, name)
+
+	err := o.event.UnregisterMsgEvent(o.msgs)
+
+	close(o.done)
+
+	if err != nil {
+		return fmt.Errorf("unregister state events of %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (a *Aries) stopObservingAllStates() error {
+	a.stateObserversMutex.Lock()
+	names := make([]string, 0, len(a.stateObservers))
+
+	for name := range a.stateObservers {
+		names = append(names, name)
+	}
+
+	a.stateObserversMutex.Unlock()
+
+	for _, name := range names {
+		if err := a.stopObservingStates(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testagent
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+)
+
+const seededKMSKeyURI = "local-lock://testagent/seeded/key/"
+
+// SeededKMS is a local KMS whose signing keys (ED25519, ECDSA P-256/P-384/P-521 and BLS12381G2) are derived from a
+// seed, in their order of creation: two KMS with the same seed create the same keys under the same key IDs, the IDs
+// being the ones the local KMS computes from the public keys.
+//
+// The keys of the other types (e.g. the ECDH-KW keys of DIDComm V2 or the AEAD keys) can't be imported into the local
+// KMS and are created at random. Note that only the ED25519 and BBS+ signatures are deterministic, the ECDSA and the
+// encryption primitives being randomized.
+type SeededKMS struct {
+	*localkms.LocalKMS
+	seed []byte
+
+	mu      sync.Mutex
+	counter uint64
+}
+
+// NewSeededKMS returns a KMS deriving its signing keys from the seed. It fails outside of a go test binary, the keys
+// being predictable.
+func NewSeededKMS(seed []byte, p kms.Provider) (*SeededKMS, error) {
+	if err := ensureTesting(); err != nil {
+		return nil, err
+	}
+
+	if len(seed) == 0 {
+		return nil, errors.New("seeded kms: empty seed")
+	}
+
+	l, err := localkms.New(seededKMSKeyURI, p)
+	if err != nil {
+		return nil, fmt.Errorf("seeded kms: %w", err)
+	}
+
+	return &SeededKMS{LocalKMS: l, seed: seed}, nil
+}
+
+// Create creates a key of type kt, derived from the seed for the signing key types.
+func (k *SeededKMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	if !seeded(kt) {
+		return k.LocalKMS.Create(kt)
+	}
+
+	privKey, pubKeyBytes, err := k.deriveKey(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("seeded kms: derive %s key: %w", kt, err)
+	}
+
+	kid, err := localkms.CreateKID(pubKeyBytes, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("seeded kms: %w", err)
+	}
+
+	return k.ImportPrivateKey(privKey, kt, kms.WithKeyID(kid))
+}
+
+// CreateAndExportPubKeyBytes creates a key of type kt and returns its ID and public key bytes.
+func (k *SeededKMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	kid, _, err := k.Create(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: failed to create new key: %w", err)
+	}
+
+	pubKeyBytes, err := k.ExportPubKeyBytes(kid)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: failed to export new public key bytes: %w", err)
+	}
+
+	return kid, pubKeyBytes, nil
+}
+
+func seeded(kt kms.KeyType) bool {
+	switch kt {
+	case kms.ED25519Type, kms.BLS12381G2Type,
+		kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER,
+		kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
+		return true
+	default:
+		return false
+	}
+}
+
+// keyMaterial returns the reader of the key material of the next key, derived from the seed, the key type and the
+// number of keys created before.
+func (k *SeededKMS) keyMaterial(kt kms.KeyType) io.Reader {
+	k.mu.Lock()
+	k.counter++
+	counter := k.counter
+	k.mu.Unlock()
+
+	return hkdf.New(sha256.New, k.seed, nil, []byte(fmt.Sprintf("%s/%d", kt, counter)))
+}
+
+// deriveKey returns the next private key of type kt and its public key bytes, marshalled as exported by the KMS.
+func (k *SeededKMS) deriveKey(kt kms.KeyType) (interface{}, []byte, error) {
+	material := k.keyMaterial(kt)
+
+	switch kt {
+	case kms.ED25519Type:
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err := io.ReadFull(material, seed); err != nil {
+			return nil, nil, err
+		}
+
+		privKey := ed25519.NewKeyFromSeed(seed)
+
+		return privKey, privKey.Public().(ed25519.PublicKey), nil
+	case kms.BLS12381G2Type:
+		seed := make([]byte, 32) //nolint:gomnd
+		if _, err := io.ReadFull(material, seed); err != nil {
+			return nil, nil, err
+		}
+
+		pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, seed)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pubKeyBytes, err := pubKey.Marshal()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return privKey, pubKeyBytes, nil
+	default:
+		return deriveECDSAKey(kt, material)
+	}
+}
+
+func deriveECDSAKey(kt kms.KeyType, material io.Reader) (interface{}, []byte, error) {
+	var curve elliptic.Curve
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363:
+		curve = elliptic.P256()
+	case kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363:
+		curve = elliptic.P384()
+	default:
+		curve = elliptic.P521()
+	}
+
+	// the scalar is drawn from 64 more bits than the order, to make the modulo bias negligible (FIPS 186-4 B.4.1)
+	n := curve.Params().N
+	b := make([]byte, (n.BitLen()+7)/8+8) //nolint:gomnd
+
+	if _, err := io.ReadFull(material, b); err != nil {
+		return nil, nil, err
+	}
+
+	d := new(big.Int).SetBytes(b)
+	d.Mod(d, new(big.Int).Sub(n, big.NewInt(1)))
+	d.Add(d, big.NewInt(1))
+
+	privKey := &ecdsa.PrivateKey{D: d, PublicKey: ecdsa.PublicKey{Curve: curve}}
+	privKey.PublicKey.X, privKey.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER:
+		pubKeyBytes, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return privKey, pubKeyBytes, nil
+	default:
+		return privKey, elliptic.Marshal(curve, privKey.PublicKey.X, privKey.PublicKey.Y), nil
+	}
+}
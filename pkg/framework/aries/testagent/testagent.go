@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testagent creates aries frameworks whose keys are derived from a seed, so that tests can compare the DIDs,
+// key IDs and signatures produced by an agent with golden files:
+//
+//	func TestIssueCredential(t *testing.T) {
+//		framework := testagent.New(t, []byte("alice"))
+//		...
+//	}
+//
+// The keys of a test agent are predictable. As guardrails against its use in production, the test agent can only be
+// created within a go test binary, and it always stores its data (keys included) in memory.
+package testagent
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+// New returns an aries framework whose KMS derives its signing keys from the seed (see SeededKMS), closed at the end
+// of the test. Two test agents with the same seed creating their keys in the same order have the same keys, and so
+// the same DIDs when these are derived from the keys (e.g. did:key).
+//
+// The options are applied before the ones of the test agent, which always uses in-memory stores and the seeded KMS.
+func New(t testing.TB, seed []byte, opts ...aries.Option) *aries.Aries {
+	t.Helper()
+
+	opts = append(opts,
+		aries.WithStoreProvider(mem.NewProvider()),
+		aries.WithProtocolStateStoreProvider(mem.NewProvider()),
+		aries.WithSecretLock(&noop.NoLock{}),
+		aries.WithKMS(func(p kms.Provider) (kms.KeyManager, error) {
+			return NewSeededKMS(seed, p)
+		}),
+	)
+
+	framework, err := aries.New(opts...)
+	if err != nil {
+		t.Fatalf("create test agent: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := framework.Close(); err != nil {
+			t.Errorf("close test agent: %s", err)
+		}
+	})
+
+	return framework
+}
+
+// ensureTesting fails outside of a go test binary, which registers the test flags.
+func ensureTesting() error {
+	if flag.Lookup("test.v") == nil {
+		return errors.New("the seeded keys are predictable, they can only be used by tests")
+	}
+
+	return nil
+}
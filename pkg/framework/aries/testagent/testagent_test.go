@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestNew(t *testing.T) {
+	seededTypes := []kms.KeyType{
+		kms.ED25519Type, kms.BLS12381G2Type,
+		kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER,
+		kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+	}
+
+	newContext := func(t *testing.T, seed string) *context.Provider {
+		t.Helper()
+
+		ctx, err := New(t, []byte(seed)).Context()
+		require.NoError(t, err)
+
+		return ctx
+	}
+
+	t.Run("same seed creates the same keys", func(t *testing.T) {
+		alice1, alice2 := newContext(t, "alice"), newContext(t, "alice")
+
+		for _, kt := range seededTypes {
+			kid1, pubKey1, err := alice1.KMS().CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err, kt)
+
+			kid2, pubKey2, err := alice2.KMS().CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err, kt)
+
+			require.Equal(t, kid1, kid2, kt)
+			require.Equal(t, pubKey1, pubKey2, kt)
+
+			// the key IDs are the ones of the local KMS
+			kid, err := localkms.CreateKID(pubKey1, kt)
+			require.NoError(t, err, kt)
+			require.Equal(t, kid, kid1, kt)
+		}
+	})
+
+	t.Run("keys differ by seed and order of creation", func(t *testing.T) {
+		alice, bob := newContext(t, "alice"), newContext(t, "bob")
+
+		kid1, _, err := alice.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		kid2, _, err := alice.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+		require.NotEqual(t, kid1, kid2)
+
+		kid, _, err := bob.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+		require.NotEqual(t, kid1, kid)
+	})
+
+	t.Run("fixed DIDs and signatures", func(t *testing.T) {
+		var (
+			dids       []string
+			signatures [][]byte
+		)
+
+		for i := 0; i < 2; i++ {
+			ctx := newContext(t, "alice")
+
+			kid, pubKey, err := ctx.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+			require.NoError(t, err)
+
+			didKey, _ := fingerprint.CreateDIDKey(pubKey)
+			dids = append(dids, didKey)
+
+			kh, err := ctx.KMS().Get(kid)
+			require.NoError(t, err)
+
+			signature, err := ctx.Crypto().Sign([]byte("golden"), kh)
+			require.NoError(t, err)
+
+			signatures = append(signatures, signature)
+		}
+
+		require.Equal(t, dids[0], dids[1])
+		require.Equal(t, signatures[0], signatures[1])
+	})
+
+	t.Run("other key types are created at random", func(t *testing.T) {
+		kid, pubKey, err := newContext(t, "alice").KMS().CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+		require.NotEmpty(t, kid)
+		require.NotEmpty(t, pubKey)
+	})
+}
+
+func TestNewSeededKMS(t *testing.T) {
+	_, err := NewSeededKMS(nil, mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.EqualError(t, err, "seeded kms: empty seed")
+}
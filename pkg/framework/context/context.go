@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	gocontext "context"
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,22 +18,37 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/cenkalti/backoff/v4"
 	jsonld "github.com/piprate/json-gold/ld"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
+	commonlock "github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ciphersuite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/didrotate"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/inbound"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/keypin"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/upgrade"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	"github.com/hyperledger/aries-framework-go/pkg/store/outbox"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -47,7 +64,7 @@ var logger = log.New("aries-framework/pkg/framework/context")
 
 // Provider supplies the framework configuration to client objects.
 type Provider struct {
-	services                   []dispatcher.ProtocolService
+	protocolRegistry           *dispatcher.ProtocolRegistry
 	msgSvcProvider             api.MessageServiceProvider
 	storeProvider              storage.Provider
 	protocolStateStoreProvider storage.Provider
@@ -65,6 +82,13 @@ type Provider struct {
 	vdr                        vdrapi.Registry
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
+	didRotator                 *didrotate.DIDRotator
+	upgrader                   *upgrade.Upgrader
+	keyPinner                  *keypin.KeyPinner
+	cipherSuiteRecorder        *ciphersuite.Recorder
+	senderPolicy               *senderpolicy.Policy
+	problemReports             *problemreport.Store
+	eventOutbox                *outbox.Outbox
 	contextStore               ld.ContextStore
 	remoteProviderStore        ld.RemoteProviderStore
 	documentLoader             jsonld.DocumentLoader
@@ -75,6 +99,15 @@ type Provider struct {
 	mediaTypeProfiles          []string
 	getDIDsMaxRetries          uint64
 	getDIDsBackOffDuration     time.Duration
+	tracerProvider             trace.TracerProvider
+	metricsProvider            metrics.Provider
+	lockService                lock.Service
+	nonceStore                 *nonce.Store
+	outboundRelays             []*service.Destination
+	outboundRetryPolicy        *dispatcher.RetryPolicy
+	inboundPool                *inbound.Pool
+	inboundMiddleware          []dispatcher.MessageMiddleware
+	outboundMiddleware         []dispatcher.MessageMiddleware
 }
 
 type inboundHandler struct {
@@ -105,6 +138,10 @@ func New(opts ...ProviderOption) (*Provider, error) {
 		}
 	}
 
+	if ctxProvider.lockService == nil {
+		ctxProvider.lockService = commonlock.NewLocal()
+	}
+
 	return &ctxProvider, nil
 }
 
@@ -120,7 +157,7 @@ func (p *Provider) OutboundTransports() []transport.OutboundTransport {
 
 // Service return protocol service.
 func (p *Provider) Service(id string) (interface{}, error) {
-	for _, v := range p.services {
+	for _, v := range p.ProtocolServices() {
 		if v.Name() == id {
 			return v, nil
 		}
@@ -129,6 +166,15 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return nil, api.ErrSvcNotFound
 }
 
+// ProtocolServices returns the protocol services inbound messages are currently routed to.
+func (p *Provider) ProtocolServices() []dispatcher.ProtocolService {
+	if p.protocolRegistry == nil {
+		return nil
+	}
+
+	return p.protocolRegistry.Services()
+}
+
 // KMS returns a Key Management Service.
 func (p *Provider) KMS() kms.KeyManager {
 	return p.kms
@@ -149,6 +195,58 @@ func (p *Provider) Packager() transport.Packager {
 	return p.packager
 }
 
+// OutboundRelays returns the relays outbound messages are routed through before reaching their destination.
+func (p *Provider) OutboundRelays() []*service.Destination {
+	return p.outboundRelays
+}
+
+// OutboundRetryPolicy returns the delivery retry policy of outbound messages, nil if retries are disabled.
+func (p *Provider) OutboundRetryPolicy() *dispatcher.RetryPolicy {
+	return p.outboundRetryPolicy
+}
+
+// InboundMiddleware returns the middleware the inbound messages are passed through before being handled.
+func (p *Provider) InboundMiddleware() []dispatcher.MessageMiddleware {
+	return p.inboundMiddleware
+}
+
+// OutboundMiddleware returns the middleware the outbound messages are passed through before being packed.
+func (p *Provider) OutboundMiddleware() []dispatcher.MessageMiddleware {
+	return p.outboundMiddleware
+}
+
+// TracerProvider returns the OpenTelemetry tracer provider used to instrument the DIDComm pipeline.
+// A no-op provider is returned if none was configured.
+func (p *Provider) TracerProvider() trace.TracerProvider {
+	if p.tracerProvider == nil {
+		return trace.NewNoopTracerProvider()
+	}
+
+	return p.tracerProvider
+}
+
+// MetricsProvider returns the provider recording the metrics of the framework internals.
+// A no-op provider is returned if none was configured.
+func (p *Provider) MetricsProvider() metrics.Provider {
+	if p.metricsProvider == nil {
+		return metrics.Noop{}
+	}
+
+	return p.metricsProvider
+}
+
+// LockService returns the service providing the locks guarding the critical sections of the protocol services.
+// A lock service local to the process is used if none was configured.
+func (p *Provider) LockService() lock.Service {
+	return p.lockService
+}
+
+// NonceStore returns the store of the nonces used by the agent, nil if the invitations and the presentation
+// challenges aren't single-use.
+func (p *Provider) NonceStore() *nonce.Store {
+	return p.nonceStore
+}
+
 // Messenger returns a messenger.
 func (p *Provider) Messenger() service.Messenger {
 	return p.messenger
@@ -189,17 +287,45 @@ func (p *Provider) tryToHandle(
 	return err
 }
 
-// InboundMessageHandler return an inbound message handler.
+// InboundMessageHandler return an inbound message handler. The messages are handled by the inbound worker pool
+// when the context has one.
 func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
-	return func(envelope *transport.Envelope) error {
+	if p.inboundPool != nil {
+		return p.inboundPool.Handler(p.inboundMessageHandler())
+	}
+
+	return p.inboundMessageHandler()
+}
+
+func (p *Provider) inboundMessageHandler() transport.InboundMessageHandler {
+	tracer := tracing.Tracer(p.TracerProvider())
+
+	return func(envelope *transport.Envelope) (err error) {
+		_, span := tracer.Start(tracing.Extract(gocontext.Background(), envelope.Message), "didcomm.inbound.handle",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer func() { tracing.End(span, err) }()
+
+		if envelope.Origin != nil {
+			span.SetAttributes(attribute.String("didcomm.transport", envelope.Origin.Transport))
+		}
+
 		msg, err := service.ParseDIDCommMsgMap(envelope.Message)
 		if err != nil {
 			return err
 		}
 
+		span.SetAttributes(attribute.String("didcomm.message.type", msg.Type()),
+			attribute.String("didcomm.message.id", msg.ID()))
+
+		service.SetOrigin(msg, envelope.Origin)
+		// the services pass the span context to the replies they send, so that they're part of the trace
+		service.SetSpanContext(msg, span.SpanContext())
+
 		// find the service which accepts the message type
-		for _, svc := range p.services {
+		for _, svc := range p.ProtocolServices() {
 			if svc.Accept(msg.Type()) {
+				span.SetAttributes(attribute.String("didcomm.service", svc.Name()))
+
 				var myDID, theirDID string
 
 				switch svc.Name() {
@@ -212,12 +338,26 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 					}
 				}
 
-				_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+				if err = p.preHandle(envelope, msg, myDID, theirDID); err != nil {
+					return fmt.Errorf("inbound message handler: %w", err)
+				}
 
-				return err
+				return p.handleWithMiddleware(envelope, msg, myDID, theirDID,
+					func(msg service.DIDCommMsgMap, ctx service.DIDCommContext) error {
+						_, err := svc.HandleInbound(msg, ctx)
+
+						return err
+					})
 			}
 		}
 
+		// the discover-features messages upgrading the connections are handled by the connection upgrader
+		if p.upgrader != nil && upgrade.Accept(msg.Type()) {
+			span.SetAttributes(attribute.String("didcomm.service", "upgrade"))
+
+			return p.handleUpgrade(envelope, msg)
+		}
+
 		// in case of no services are registered for given message type,
 		// find generic inbound services registered for given message header
 		for _, svc := range p.msgSvcProvider.Services() {
@@ -231,12 +371,21 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 			}
 
 			if svc.Accept(msg.Type(), h.Purpose) {
+				span.SetAttributes(attribute.String("didcomm.service", svc.Name()))
+
 				myDID, theirDID, err := p.getDIDs(envelope)
 				if err != nil {
 					return fmt.Errorf("inbound message handler: %w", err)
 				}
 
-				return p.tryToHandle(svc, msg, service.NewDIDCommContext(myDID, theirDID, nil))
+				if err = p.preHandle(envelope, msg, myDID, theirDID); err != nil {
+					return fmt.Errorf("inbound message handler: %w", err)
+				}
+
+				return p.handleWithMiddleware(envelope, msg, myDID, theirDID,
+					func(msg service.DIDCommMsgMap, ctx service.DIDCommContext) error {
+						return p.tryToHandle(svc, msg, ctx)
+					})
 			}
 		}
 
@@ -244,6 +393,165 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 	}
 }
 
+// handleDIDRotation handles the DID rotation headers of the inbound DIDComm v2 messages.
+func (p *Provider) handleDIDRotation(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if p.didRotator == nil {
+		return nil
+	}
+
+	return p.didRotator.HandleInboundMessage(msg, myDID, theirDID)
+}
+
+// handleUpgrade handles the discover-features messages exchanged to upgrade the connections to DIDComm v2.
+func (p *Provider) handleUpgrade(envelope *transport.Envelope, msg service.DIDCommMsgMap) error {
+	myDID, theirDID, err := p.getDIDs(envelope)
+	if err != nil {
+		return fmt.Errorf("inbound message handler: %w", err)
+	}
+
+	if err = p.preHandle(envelope, msg, myDID, theirDID); err != nil {
+		return fmt.Errorf("inbound message handler: %w", err)
+	}
+
+	return p.upgrader.HandleInbound(msg, myDID, theirDID)
+}
+
+// preHandle runs the checks of the inbound messages before they are dispatched: the sender policy, the DID rotation,
+// the key pinning and the cipher suite policy, in this order. Without a connection (empty DIDs), the DID rotation and
+// the key pinning are skipped.
+func (p *Provider) preHandle(envelope *transport.Envelope, msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if err := p.handleSenderPolicy(envelope, msg, theirDID); err != nil {
+		return err
+	}
+
+	if err := p.handleDIDRotation(msg, myDID, theirDID); err != nil {
+		return err
+	}
+
+	if err := p.handleKeyPinning(envelope, myDID, theirDID); err != nil {
+		return err
+	}
+
+	return p.handleCipherSuite(envelope, myDID, theirDID)
+}
+
+// handleSenderPolicy rejects the inbound messages whose sender is denied by the sender policy. Without a connection,
+// the sender is the DID of the authcrypt sender key, or the did:key of the legacy sender key.
+func (p *Provider) handleSenderPolicy(envelope *transport.Envelope, msg service.DIDCommMsgMap, theirDID string) error {
+	if p.senderPolicy == nil {
+		return nil
+	}
+
+	return p.senderPolicy.Check(senderDID(envelope, theirDID), msg.Type())
+}
+
+// senderDID returns the DID the sender policy is checked against: the DID of the connection when there is one,
+// otherwise the DID of the authcrypt sender key, or the did:key of the legacy sender key. An empty DID is returned
+// for the anonymous messages.
+func senderDID(envelope *transport.Envelope, theirDID string) string {
+	if theirDID != "" {
+		return theirDID
+	}
+
+	if strings.Index(string(envelope.FromKey), kaIdentifier) > 0 &&
+		strings.Index(string(envelope.FromKey), "\"kid\":\"did:") > 0 {
+		if fromDID, err := pubKeyToDID(envelope.FromKey); err == nil {
+			return fromDID
+		}
+	}
+
+	if len(envelope.FromKey) == ed25519.PublicKeySize {
+		didKey, _ := fingerprint.CreateDIDKey(envelope.FromKey)
+
+		return didKey
+	}
+
+	return ""
+}
+
+// handleKeyPinning checks the sender key of the inbound messages against the pinned keys of the connection.
+func (p *Provider) handleKeyPinning(envelope *transport.Envelope, myDID, theirDID string) error {
+	if p.keyPinner == nil {
+		return nil
+	}
+
+	return p.keyPinner.HandleInboundMessage(envelope.FromKey, myDID, theirDID)
+}
+
+// handleCipherSuite records the algorithms of the inbound messages in the connection record and rejects the ones
+// forbidden by the cipher suite policy.
+func (p *Provider) handleCipherSuite(envelope *transport.Envelope, myDID, theirDID string) error {
+	if p.cipherSuiteRecorder == nil {
+		return nil
+	}
+
+	return p.cipherSuiteRecorder.HandleInboundMessage(envelope, myDID, theirDID)
+}
+
+// handleWithMiddleware passes the inbound message through the inbound middleware before handling it, the
+// annotations of the middleware being added to the properties of the DIDComm context.
+func (p *Provider) handleWithMiddleware(envelope *transport.Envelope, msg service.DIDCommMsgMap, myDID, theirDID string,
+	handle func(msg service.DIDCommMsgMap, ctx service.DIDCommContext) error) error {
+	handler := dispatcher.NewMessageHandler(dispatcher.MessageHandlerFunc(func(md *dispatcher.MessageMetadata) error {
+		props := contextProps(envelope, md.Message)
+
+		if len(md.Annotations) > 0 {
+			if props == nil {
+				props = map[string]interface{}{}
+			}
+
+			for k, v := range md.Annotations {
+				props[k] = v
+			}
+		}
+
+		p.saveProblemReport(md.Message, myDID, theirDID)
+
+		return handle(md.Message, service.NewDIDCommContext(myDID, theirDID, props))
+	}), p.inboundMiddleware...)
+
+	return handler.Handle(&dispatcher.MessageMetadata{
+		Direction:   dispatcher.DirectionInbound,
+		Message:     msg,
+		MyDID:       myDID,
+		TheirDID:    theirDID,
+		Origin:      envelope.Origin,
+		Annotations: map[string]interface{}{},
+	})
+}
+
+// saveProblemReport keeps the history of the problem reports received, the message is handled even if it can't be
+// saved.
+func (p *Provider) saveProblemReport(msg service.DIDCommMsgMap, myDID, theirDID string) {
+	if p.problemReports == nil {
+		return
+	}
+
+	if err := p.problemReports.Save(msg, problemreport.Inbound, myDID, theirDID); err != nil {
+		logger.Warnf("failed to save inbound problem report: %s", err)
+	}
+}
+
+// contextProps returns the properties of the DIDComm context of the inbound message: its origin and the span context
+// of its handling.
+func contextProps(envelope *transport.Envelope, msg service.DIDCommMsgMap) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	if envelope.Origin != nil {
+		props[service.OriginKey] = envelope.Origin
+	}
+
+	if sc := service.GetSpanContext(msg); sc.IsValid() {
+		props[service.SpanContextKey] = sc
+	}
+
+	if len(props) == 0 {
+		return nil
+	}
+
+	return props
+}
+
 //nolint:nestif,gocognit,funlen,gocyclo
 func (p *Provider) getDIDs(envelope *transport.Envelope) (string, string, error) {
 	var (
@@ -326,10 +634,7 @@ func pubKeyToDID(key []byte) (string, error) {
 // InboundDIDCommMessageHandler provides a supplier of inbound handlers with all loaded protocol services.
 func (p *Provider) InboundDIDCommMessageHandler() func() service.InboundHandler {
 	return func() service.InboundHandler {
-		tmp := make([]dispatcher.ProtocolService, len(p.services))
-		copy(tmp, p.services)
-
-		return &inboundHandler{handlers: tmp}
+		return &inboundHandler{handlers: p.ProtocolServices()}
 	}
 }
 
@@ -368,6 +673,41 @@ func (p *Provider) DIDConnectionStore() did.ConnectionStore {
 	return p.didConnectionStore
 }
 
+// DIDRotator returns the DID rotator of the DIDComm v2 connections.
+func (p *Provider) DIDRotator() *didrotate.DIDRotator {
+	return p.didRotator
+}
+
+// ConnectionUpgrader returns the upgrader of the DIDComm v1 connections to DIDComm v2.
+func (p *Provider) ConnectionUpgrader() *upgrade.Upgrader {
+	return p.upgrader
+}
+
+// KeyPinner returns the key pinner of the connections, nil if key pinning is disabled.
+func (p *Provider) KeyPinner() *keypin.KeyPinner {
+	return p.keyPinner
+}
+
+// CipherSuiteRecorder returns the recorder of the cipher suites of the connections, nil if they aren't recorded.
+func (p *Provider) CipherSuiteRecorder() *ciphersuite.Recorder {
+	return p.cipherSuiteRecorder
+}
+
+// SenderPolicy returns the policy filtering the inbound messages by sender DID, nil if the messages aren't filtered.
+func (p *Provider) SenderPolicy() *senderpolicy.Policy {
+	return p.senderPolicy
+}
+
+// ProblemReportStore returns the history of the problem reports sent and received by the agent.
+func (p *Provider) ProblemReportStore() *problemreport.Store {
+	return p.problemReports
+}
+
+// EventOutbox returns the outbox publishing the events of the agent to the event sink, nil if no event sink is set.
+func (p *Provider) EventOutbox() *outbox.Outbox {
+	return p.eventOutbox
+}
+
 // JSONLDContextStore returns a JSON-LD context store.
 func (p *Provider) JSONLDContextStore() ld.ContextStore {
 	return p.contextStore
@@ -452,7 +792,16 @@ func WithTransportReturnRoute(transportReturnRoute string) ProviderOption {
 // WithProtocolServices injects a protocol services into the context.
 func WithProtocolServices(services ...dispatcher.ProtocolService) ProviderOption {
 	return func(opts *Provider) error {
-		opts.services = services
+		opts.protocolRegistry = dispatcher.NewProtocolRegistry(services...)
+		return nil
+	}
+}
+
+// WithProtocolRegistry injects a protocol services registry into the context. Unlike WithProtocolServices, the
+// services registered or unregistered after the context creation are taken into account by the context.
+func WithProtocolRegistry(registry *dispatcher.ProtocolRegistry) ProviderOption {
+	return func(opts *Provider) error {
+		opts.protocolRegistry = registry
 		return nil
 	}
 }
@@ -575,6 +924,62 @@ func WithDIDConnectionStore(store did.ConnectionStore) ProviderOption {
 	}
 }
 
+// WithDIDRotator injects the DID rotator of the DIDComm v2 connections into the context.
+func WithDIDRotator(didRotator *didrotate.DIDRotator) ProviderOption {
+	return func(opts *Provider) error {
+		opts.didRotator = didRotator
+		return nil
+	}
+}
+
+// WithConnectionUpgrader injects the upgrader of the DIDComm v1 connections to DIDComm v2 into the context.
+func WithConnectionUpgrader(upgrader *upgrade.Upgrader) ProviderOption {
+	return func(opts *Provider) error {
+		opts.upgrader = upgrader
+		return nil
+	}
+}
+
+// WithKeyPinner injects the key pinner of the connections into the context.
+func WithKeyPinner(keyPinner *keypin.KeyPinner) ProviderOption {
+	return func(opts *Provider) error {
+		opts.keyPinner = keyPinner
+		return nil
+	}
+}
+
+// WithCipherSuiteRecorder injects the recorder of the cipher suites of the connections into the context.
+func WithCipherSuiteRecorder(recorder *ciphersuite.Recorder) ProviderOption {
+	return func(opts *Provider) error {
+		opts.cipherSuiteRecorder = recorder
+		return nil
+	}
+}
+
+// WithSenderPolicy injects the policy filtering the inbound messages by sender DID into the context.
+func WithSenderPolicy(policy *senderpolicy.Policy) ProviderOption {
+	return func(opts *Provider) error {
+		opts.senderPolicy = policy
+		return nil
+	}
+}
+
+// WithEventOutbox injects the outbox publishing the events of the agent to the event sink into the context.
+func WithEventOutbox(o *outbox.Outbox) ProviderOption {
+	return func(opts *Provider) error {
+		opts.eventOutbox = o
+		return nil
+	}
+}
+
+// WithProblemReportStore injects the store keeping the history of the problem reports into the context.
+func WithProblemReportStore(store *problemreport.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.problemReports = store
+		return nil
+	}
+}
+
 // WithJSONLDContextStore injects a JSON-LD context store into the context.
 func WithJSONLDContextStore(store ld.ContextStore) ProviderOption {
 	return func(opts *Provider) error {
@@ -604,7 +1009,8 @@ func WithKeyType(keyType kms.KeyType) ProviderOption {
 	return func(opts *Provider) error {
 		switch keyType {
 		case kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
-			kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER, kms.BLS12381G2Type:
+			kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER, kms.ECDSASecp256k1TypeIEEEP1363,
+			kms.BLS12381G2Type:
 			opts.keyType = keyType
 			return nil
 		default:
@@ -626,6 +1032,78 @@ func WithKeyAgreementType(keyAgreementType kms.KeyType) ProviderOption {
 	}
 }
 
+// WithOutboundRelays injects the relays outbound messages are routed through into the context.
+func WithOutboundRelays(relays ...*service.Destination) ProviderOption {
+	return func(opts *Provider) error {
+		opts.outboundRelays = relays
+		return nil
+	}
+}
+
+// WithOutboundRetryPolicy injects the delivery retry policy of outbound messages into the context.
+func WithOutboundRetryPolicy(policy *dispatcher.RetryPolicy) ProviderOption {
+	return func(opts *Provider) error {
+		opts.outboundRetryPolicy = policy
+		return nil
+	}
+}
+
+// WithInboundMiddleware injects the middleware the inbound messages are passed through into the context.
+func WithInboundMiddleware(middleware ...dispatcher.MessageMiddleware) ProviderOption {
+	return func(opts *Provider) error {
+		opts.inboundMiddleware = middleware
+		return nil
+	}
+}
+
+// WithOutboundMiddleware injects the middleware the outbound messages are passed through into the context.
+func WithOutboundMiddleware(middleware ...dispatcher.MessageMiddleware) ProviderOption {
+	return func(opts *Provider) error {
+		opts.outboundMiddleware = middleware
+		return nil
+	}
+}
+
+// WithInboundPool injects the worker pool handling the inbound messages into the context.
+func WithInboundPool(pool *inbound.Pool) ProviderOption {
+	return func(opts *Provider) error {
+		opts.inboundPool = pool
+		return nil
+	}
+}
+
+// WithTracerProvider injects an OpenTelemetry tracer provider into the context.
+func WithTracerProvider(tp trace.TracerProvider) ProviderOption {
+	return func(opts *Provider) error {
+		opts.tracerProvider = tp
+		return nil
+	}
+}
+
+// WithMetricsProvider injects the provider recording the metrics of the framework internals into the context.
+func WithMetricsProvider(mp metrics.Provider) ProviderOption {
+	return func(opts *Provider) error {
+		opts.metricsProvider = mp
+		return nil
+	}
+}
+
+// WithLockService injects the service providing the locks guarding the critical sections into the context.
+func WithLockService(ls lock.Service) ProviderOption {
+	return func(opts *Provider) error {
+		opts.lockService = ls
+		return nil
+	}
+}
+
+// WithNonceStore injects the store of the nonces used by the agent into the context.
+func WithNonceStore(store *nonce.Store) ProviderOption {
+	return func(opts *Provider) error {
+		opts.nonceStore = store
+		return nil
+	}
+}
+
 // WithMediaTypeProfiles injects a media type profile into the context.
 func WithMediaTypeProfiles(mediaTypeProfiles []string) ProviderOption {
 	return func(opts *Provider) error {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"testing"
@@ -17,9 +18,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/lock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/ciphersuite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/didrotate"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/inbound"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/keypin"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/senderpolicy"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/upgrade"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
@@ -32,11 +43,19 @@ import (
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocklockservice "github.com/hyperledger/aries-framework-go/pkg/mock/lock"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	"github.com/hyperledger/aries-framework-go/pkg/store/problemreport"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
@@ -162,6 +181,428 @@ func TestNewProvider(t *testing.T) {
 		require.Contains(t, err.Error(), "error handling the message")
 	})
 
+	t.Run("test inbound message handlers save the problem reports", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		problemReports, err := problemreport.New(&mockprovider.Provider{
+			StorageProviderValue:              storageProvider,
+			ProtocolStateStorageProviderValue: storageProvider,
+		})
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == "https://didcomm.org/didexchange/1.0/problem-report"
+			},
+		}), WithDIDConnectionStore(connectionStore), WithProblemReportStore(problemReports))
+		require.NoError(t, err)
+		require.Equal(t, problemReports, ctx.ProblemReportStore())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "report-1",
+			"@type": "https://didcomm.org/didexchange/1.0/problem-report",
+			"description": {"code": "request_not_accepted"}
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+
+		records, err := problemReports.Query("", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, problemreport.Inbound, records[0].Direction)
+		require.Equal(t, "request_not_accepted", records[0].Code)
+	})
+
+	t.Run("test inbound message handlers pass the discover-features messages to the connection upgrader",
+		func(t *testing.T) {
+			connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+			connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+			upgrader, err := upgrade.New(&mockprovider.Provider{
+				StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+				ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+			})
+			require.NoError(t, err)
+
+			ctx, err := New(WithDIDConnectionStore(connectionStore), WithConnectionUpgrader(upgrader))
+			require.NoError(t, err)
+			require.Equal(t, upgrader, ctx.ConnectionUpgrader())
+
+			err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "queries-1",
+			"@type": "https://didcomm.org/discover-features/2.0/queries",
+			"queries": [{"feature-type": "accept", "match": "*"}]
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+			require.EqualError(t, err, "discover-features queries received out of a connection")
+		})
+
+	t.Run("test inbound message handlers/dispatchers validate the DID rotation", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("did:test:alice", nil).AnyTimes()
+
+		didRotator, err := didrotate.New(&mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+		}), WithDIDConnectionStore(connectionStore), WithDIDRotator(didRotator))
+		require.NoError(t, err)
+		require.Equal(t, didRotator, ctx.DIDRotator())
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"id": "1",
+			"type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"id": "1",
+			"type": "valid-message-type",
+			"from_prior": "invalid"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "handle from_prior")
+	})
+
+	t.Run("test inbound message handlers/dispatchers validate the pinned keys", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:test:alice", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("did:test:bob", nil).AnyTimes()
+
+		prov := &mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-1",
+			State:        connection.StateNameCompleted,
+			MyDID:        "did:test:alice",
+			TheirDID:     "did:test:bob",
+		}))
+
+		keyPinner, err := keypin.New(prov, keypin.Block)
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+		}), WithDIDConnectionStore(connectionStore), WithKeyPinner(keyPinner))
+		require.NoError(t, err)
+		require.Equal(t, keyPinner, ctx.KeyPinner())
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "1",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "2",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("otherKey")})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, keypin.ErrKeyChanged))
+	})
+
+	t.Run("test inbound message handlers/dispatchers record the cipher suites", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:test:alice", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("did:test:bob", nil).AnyTimes()
+
+		prov := &mockprovider.Provider{
+			StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		}
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-1",
+			State:        connection.StateNameCompleted,
+			MyDID:        "did:test:alice",
+			TheirDID:     "did:test:bob",
+		}))
+
+		suiteRecorder, err := ciphersuite.New(prov, ciphersuite.Policy{ForbiddenContentEncryption: []string{"A256CBC-HS512"}})
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+		}), WithDIDConnectionStore(connectionStore), WithCipherSuiteRecorder(suiteRecorder))
+		require.NoError(t, err)
+		require.Equal(t, suiteRecorder, ctx.CipherSuiteRecorder())
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "1",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey"), ContentEncryption: "XC20P",
+			KeyWrapping: "ECDH-1PU+A256KW"})
+		require.NoError(t, err)
+
+		suite, err := suiteRecorder.CipherSuite("conn-1")
+		require.NoError(t, err)
+		require.Equal(t, &connection.CipherSuite{ContentEncryption: "XC20P", KeyWrapping: "ECDH-1PU+A256KW"}, suite)
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "2",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey"), ContentEncryption: "A256CBC-HS512",
+			KeyWrapping: "ECDH-1PU+A256KW"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ciphersuite.ErrForbidden))
+	})
+
+	t.Run("test inbound message handlers/dispatchers apply the sender policy", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:test:alice", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("did:test:bob", nil).AnyTimes()
+
+		policy, err := senderpolicy.New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()},
+			senderpolicy.Rule{ID: "no-bob", Sender: "did:test:bob", MessageType: "valid-*", Action: senderpolicy.Deny})
+		require.NoError(t, err)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+		}), WithDIDConnectionStore(connectionStore), WithSenderPolicy(policy))
+		require.NoError(t, err)
+		require.Equal(t, policy, ctx.SenderPolicy())
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "1",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, senderpolicy.ErrDenied))
+
+		require.NoError(t, policy.RemoveRule("no-bob"))
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "2",
+			"@type": "valid-message-type"
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+	})
+
+	t.Run("test inbound message handlers/dispatchers surface message origin", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+		origin := &service.Origin{Transport: "http", RemoteAddr: "127.0.0.1:8080", ViaMediator: true}
+
+		var received *service.Origin
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				received = service.GetOrigin(msg)
+
+				return uuid.New().String(), nil
+			},
+		}), WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(`{"@id": "12345", "@type": "valid-message-type"}`),
+			ToKey:   []byte("toKey"),
+			FromKey: []byte("fromKey"),
+			Origin:  origin,
+		})
+		require.NoError(t, err)
+		require.Equal(t, origin, received)
+	})
+
+	t.Run("test inbound message handlers/dispatchers - services registered at runtime", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+		registry := dispatcher.NewProtocolRegistry()
+
+		ctx, err := New(WithProtocolRegistry(registry), WithDIDConnectionStore(connectionStore),
+			WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()))
+		require.NoError(t, err)
+
+		inbound := ctx.InboundMessageHandler()
+		envelope := &transport.Envelope{
+			Message: []byte(`{"@id": "12345", "@type": "valid-message-type"}`),
+			ToKey:   []byte("toKey"),
+			FromKey: []byte("fromKey"),
+		}
+
+		err = inbound(envelope)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no message handlers found for the message type: valid-message-type")
+
+		handled := false
+
+		require.NoError(t, registry.Register(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+			HandleFunc: func(service.DIDCommMsg) (string, error) {
+				handled = true
+
+				return "", nil
+			},
+		}))
+		require.Len(t, ctx.ProtocolServices(), 1)
+
+		require.NoError(t, inbound(envelope))
+		require.True(t, handled)
+
+		require.NoError(t, registry.Unregister("mockProtocolSvc"))
+		require.Empty(t, ctx.ProtocolServices())
+
+		err = inbound(envelope)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no message handlers found for the message type: valid-message-type")
+	})
+
+	t.Run("test inbound message handlers/dispatchers continue the sender trace", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+		tp := &mocktracing.TracerProvider{}
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return msgType == validMessageType
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore), WithTracerProvider(tp))
+		require.NoError(t, err)
+		require.Equal(t, tp, ctx.TracerProvider())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(`{
+				"@id": "12345",
+				"@type": "valid-message-type",
+				"~trace_context": {"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+			}`),
+			ToKey:   []byte("toKey"),
+			FromKey: []byte("fromKey"),
+		})
+		require.NoError(t, err)
+
+		span := tp.Span("didcomm.inbound.handle")
+		require.NotNil(t, span)
+		require.True(t, span.Ended())
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Parent().TraceID().String())
+		require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		require.Equal(t, validMessageType, span.Attribute("didcomm.message.type").AsString())
+		require.Equal(t, "mockProtocolSvc", span.Attribute("didcomm.service").AsString())
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{"@type": "unknown"}`)})
+		require.Error(t, err)
+		require.Len(t, tp.Spans()[1].Errors(), 1)
+	})
+
+	t.Run("test inbound message handling, service and outbound reply share the trace", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:me", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:them", nil).AnyTimes()
+
+		tp := &mocktracing.TracerProvider{}
+		opts := []ProviderOption{
+			WithTracerProvider(tp), WithDIDConnectionStore(connectionStore),
+			WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithStorageProvider(mockstorage.NewMockStoreProvider()),
+			WithProtocolStateStorageProvider(mockstorage.NewMockStoreProvider()),
+			WithPackager(&mockpackager.Packager{PackValue: []byte("packed")}),
+			WithVDRegistry(&mockvdr.MockVDRegistry{ResolveValue: mockdiddoc.GetMockDIDDoc(t)}),
+			WithOutboundTransports(&mockdidcomm.MockOutboundTransport{AcceptValue: true}),
+			WithMediaTypeProfiles([]string{transport.MediaTypeRFC0019EncryptedEnvelope}), WithKMS(&mockkms.KeyManager{}),
+		}
+
+		prov, err := New(opts...)
+		require.NoError(t, err)
+
+		outbound, err := dispatcher.NewOutbound(prov)
+		require.NoError(t, err)
+
+		prov, err = New(append(opts, WithOutboundDispatcher(outbound))...)
+		require.NoError(t, err)
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "connection-id",
+			State:        connection.StateNameCompleted,
+			MyDID:        "did:example:me",
+			TheirDID:     "did:example:them",
+		}))
+
+		svc, err := trustping.New(prov)
+		require.NoError(t, err)
+
+		ctx, err := New(append(opts, WithOutboundDispatcher(outbound), WithProtocolServices(svc))...)
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{
+			Message: []byte(`{
+				"@id": "12345",
+				"@type": "` + trustping.PingMsgType + `",
+				"response_requested": true,
+				"~trace_context": {"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+			}`),
+			ToKey:   []byte("toKey"),
+			FromKey: []byte("fromKey"),
+		})
+		require.NoError(t, err)
+
+		inboundSpan := tp.Span("didcomm.inbound.handle")
+		require.NotNil(t, inboundSpan)
+		require.Equal(t, trustping.TrustPing, inboundSpan.Attribute("didcomm.service").AsString())
+
+		// the ping response sent by the service continues the trace of the ping
+		outboundSpan := tp.Span("didcomm.outbound.send")
+		require.NotNil(t, outboundSpan)
+		require.Equal(t, inboundSpan.SpanContext(), outboundSpan.Parent())
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", outboundSpan.SpanContext().TraceID().String())
+	})
+
 	t.Run("test inbound message handlers/dispatchers with ToKey/FromKey as KeyAgreement ID", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -262,6 +703,105 @@ func TestNewProvider(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("inbound message handler with inbound worker pool", func(t *testing.T) {
+		pool, err := inbound.NewPool(1)
+		require.NoError(t, err)
+
+		handled := make(chan service.DIDCommMsg, 1)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled <- msg
+
+				return uuid.New().String(), nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithInboundPool(pool))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "5678876542345",
+			"@type": "valid-message-type"
+		}`)})
+		require.NoError(t, err)
+
+		pool.Close()
+
+		select {
+		case msg := <-handled:
+			require.Equal(t, "5678876542345", msg.ID())
+		default:
+			require.Fail(t, "message not handled by the inbound worker pool")
+		}
+	})
+
+	t.Run("inbound message handler with inbound middleware", func(t *testing.T) {
+		var handled service.DIDCommMsg
+
+		var props map[string]interface{}
+
+		svc := &ctxCaptureSvc{
+			MockDIDExchangeSvc: mockdidexchange.MockDIDExchangeSvc{
+				ProtocolName: didexchange.DIDExchange,
+				AcceptFunc:   func(msgType string) bool { return true },
+			},
+			handle: func(msg service.DIDCommMsg, ctx service.DIDCommContext) {
+				handled = msg
+				props = ctx.All()
+			},
+		}
+
+		annotate := func(next dispatcher.MessageHandler) dispatcher.MessageHandler {
+			return dispatcher.MessageHandlerFunc(func(md *dispatcher.MessageMetadata) error {
+				require.Equal(t, dispatcher.DirectionInbound, md.Direction)
+
+				md.Annotations["checked"] = true
+				md.Message["comment"] = "changed"
+
+				return next.Handle(md)
+			})
+		}
+
+		block := func(next dispatcher.MessageHandler) dispatcher.MessageHandler {
+			return dispatcher.MessageHandlerFunc(func(md *dispatcher.MessageMetadata) error {
+				if md.Message.ID() == "blocked" {
+					return errors.New("message blocked")
+				}
+
+				return next.Handle(md)
+			})
+		}
+
+		ctx, err := New(WithProtocolServices(svc), WithInboundMiddleware(annotate, block),
+			WithOutboundMiddleware(block))
+		require.NoError(t, err)
+		require.Len(t, ctx.InboundMiddleware(), 2)
+		require.Len(t, ctx.OutboundMiddleware(), 1)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "5678876542345",
+			"@type": "valid-message-type"
+		}`), Origin: &service.Origin{Transport: "http"}})
+		require.NoError(t, err)
+		require.Equal(t, "changed", handled.(service.DIDCommMsgMap)["comment"])
+		require.Equal(t, true, props["checked"])
+		require.NotNil(t, props[service.OriginKey])
+
+		handled = nil
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "blocked",
+			"@type": "valid-message-type"
+		}`)})
+		require.EqualError(t, err, "message blocked")
+		require.Nil(t, handled)
+	})
+
 	t.Run("inbound message handler: DID not found is ok", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().
@@ -685,6 +1225,28 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, mCrypto, prov.Crypto())
 	})
 
+	t.Run("test new with metrics provider", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Equal(t, metrics.Noop{}, prov.MetricsProvider())
+
+		mp := &mockmetrics.Provider{}
+		prov, err = New(WithMetricsProvider(mp))
+		require.NoError(t, err)
+		require.Equal(t, mp, prov.MetricsProvider())
+	})
+
+	t.Run("test new with lock service", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.IsType(t, &lock.Local{}, prov.LockService())
+
+		ls := &mocklockservice.MockLockService{}
+		prov, err = New(WithLockService(ls))
+		require.NoError(t, err)
+		require.Equal(t, ls, prov.LockService())
+	})
+
 	t.Run("test new with secret lock service", func(t *testing.T) {
 		mSecLck := &mocklock.MockSecretLock{}
 		prov, err := New(WithSecretLock(mSecLck))
@@ -813,4 +1375,41 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, transport.MediaTypeV1EncryptedEnvelope, prov.MediaTypeProfiles()[1])
 		require.Equal(t, transport.MediaTypeRFC0019EncryptedEnvelope, prov.MediaTypeProfiles()[2])
 	})
+
+	t.Run("test new with nonce store", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Nil(t, prov.NonceStore())
+
+		store, err := nonce.New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewMockStoreProvider()}, 0)
+		require.NoError(t, err)
+
+		prov, err = New(WithNonceStore(store))
+		require.NoError(t, err)
+		require.Equal(t, store, prov.NonceStore())
+	})
+}
+
+type ctxCaptureSvc struct {
+	mockdidexchange.MockDIDExchangeSvc
+	handle func(msg service.DIDCommMsg, ctx service.DIDCommContext)
+}
+
+func (s *ctxCaptureSvc) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	s.handle(msg, ctx)
+
+	return uuid.New().String(), nil
+}
+
+func TestSenderDID(t *testing.T) {
+	edKey := make([]byte, ed25519.PublicKeySize)
+
+	didKey, _ := fingerprint.CreateDIDKey(edKey)
+
+	require.Equal(t, "did:test:bob", senderDID(&transport.Envelope{FromKey: edKey}, "did:test:bob"))
+	require.Equal(t, didKey, senderDID(&transport.Envelope{FromKey: edKey}, ""))
+	require.Equal(t, "did:test:bob", senderDID(&transport.Envelope{
+		FromKey: []byte(`{"kid":"did:test:bob#key-1"}`),
+	}, ""))
+	require.Empty(t, senderDID(&transport.Envelope{}, ""))
 }
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package extension defines the SPI third-party extensions implement to provide components (protocol services,
+// VDRs, packers, storage, crypto) to the Aries framework, and a loader assembling the framework options from a
+// manifest listing the extensions. Extensions register their factory with Register, from the init function of their
+// package or of a Go plugin listed in the manifest, so they can be shipped independently of the core module.
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Extension provides components (protocol services, VDRs, packers, storage, crypto) to the Aries framework.
+type Extension interface {
+	// Name of the extension.
+	Name() string
+	// Register registers the components provided by the extension.
+	Register(r Registrar) error
+}
+
+// Registrar collects the components provided by the extensions.
+type Registrar interface {
+	// RegisterProtocolServices registers protocol services handling inbound DIDComm messages.
+	RegisterProtocolServices(creators ...api.ProtocolSvcCreator)
+	// RegisterVDRs registers VDRs resolving and creating DIDs of additional methods.
+	RegisterVDRs(vdrs ...vdrapi.VDR)
+	// RegisterPackers registers packers available for unpacking inbound messages.
+	RegisterPackers(creators ...packer.Creator)
+	// RegisterStorageProvider registers the framework storage provider, only one extension can provide it.
+	RegisterStorageProvider(provider storage.Provider) error
+	// RegisterCrypto registers the framework crypto service, only one extension can provide it.
+	RegisterCrypto(c crypto.Crypto) error
+}
+
+// Factory creates an extension with the configuration provided in the manifest.
+type Factory func(config json.RawMessage) (Extension, error)
+
+var logger = log.New("aries-framework/extension")
+
+// nolint: gochecknoglobals
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+// Register makes an extension factory available to the loader under the given name. It is meant to be called from
+// the init function of the package (or Go plugin) implementing the extension:
+//
+//	func init() {
+//		extension.Register("my-extension", New)
+//	}
+//
+// Register panics if the factory is nil or if the name is already registered.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("extension: factory of extension `%s` is nil", name))
+	}
+
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("extension: extension `%s` already registered", name))
+	}
+
+	factories[name] = factory
+}
+
+// Extensions returns the sorted names of the registered extensions.
+func Extensions() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+
+	for name := range factories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func factory(name string) (Factory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	f, ok := factories[name]
+
+	return f, ok
+}
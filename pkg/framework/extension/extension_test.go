@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extension
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("register extension factory", func(t *testing.T) {
+		Register("test-register", func(json.RawMessage) (Extension, error) {
+			return &mockExtension{name: "test-register"}, nil
+		})
+
+		require.Contains(t, Extensions(), "test-register")
+
+		f, ok := factory("test-register")
+		require.True(t, ok)
+
+		ext, err := f(nil)
+		require.NoError(t, err)
+		require.Equal(t, "test-register", ext.Name())
+	})
+
+	t.Run("register extension factory twice", func(t *testing.T) {
+		Register("test-register-twice", func(json.RawMessage) (Extension, error) {
+			return &mockExtension{}, nil
+		})
+
+		require.PanicsWithValue(t, "extension: extension `test-register-twice` already registered", func() {
+			Register("test-register-twice", func(json.RawMessage) (Extension, error) {
+				return &mockExtension{}, nil
+			})
+		})
+	})
+
+	t.Run("register nil factory", func(t *testing.T) {
+		require.PanicsWithValue(t, "extension: factory of extension `test-register-nil` is nil", func() {
+			Register("test-register-nil", nil)
+		})
+
+		require.NotContains(t, Extensions(), "test-register-nil")
+	})
+}
+
+type mockExtension struct {
+	name        string
+	registerErr error
+	register    func(r Registrar) error
+}
+
+func (m *mockExtension) Name() string {
+	return m.name
+}
+
+func (m *mockExtension) Register(r Registrar) error {
+	if m.registerErr != nil {
+		return m.registerErr
+	}
+
+	if m.register != nil {
+		return m.register(r)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extension
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"plugin"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Manifest lists the extensions assembled into the Aries framework.
+type Manifest struct {
+	// Plugins are the paths of the Go plugins to open before creating the extensions, the plugins register their
+	// extension factories from their init function.
+	Plugins []string `json:"plugins,omitempty"`
+	// Extensions to create, in registration order.
+	Extensions []ManifestEntry `json:"extensions"`
+}
+
+// ManifestEntry is an extension to create.
+type ManifestEntry struct {
+	// Name the extension factory is registered with.
+	Name string `json:"name"`
+	// Config is the extension specific configuration passed to the factory.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// ParseManifest parses a JSON manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest: %w", err)
+	}
+
+	for i, entry := range manifest.Extensions {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("extension %d: name is mandatory", i)
+		}
+	}
+
+	return manifest, nil
+}
+
+// LoadManifest reads the JSON manifest at the given path and returns the Aries framework options injecting the
+// components of its extensions.
+func LoadManifest(path string) ([]aries.Option, error) {
+	data, err := ioutil.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	manifest, err := ParseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(manifest)
+}
+
+// Load opens the plugins of the manifest, creates its extensions and returns the Aries framework options injecting
+// their components.
+//
+//	opts, err := extension.Load(manifest)
+//	if err != nil {
+//		return err
+//	}
+//
+//	framework, err := aries.New(opts...)
+func Load(manifest *Manifest) ([]aries.Option, error) {
+	for _, path := range manifest.Plugins {
+		if _, err := plugin.Open(path); err != nil {
+			return nil, fmt.Errorf("open plugin %s: %w", path, err)
+		}
+	}
+
+	r := &registrar{}
+
+	for _, entry := range manifest.Extensions {
+		f, ok := factory(entry.Name)
+		if !ok {
+			return nil, fmt.Errorf("extension `%s` is not registered", entry.Name)
+		}
+
+		ext, err := f(entry.Config)
+		if err != nil {
+			return nil, fmt.Errorf("create extension `%s`: %w", entry.Name, err)
+		}
+
+		if err = ext.Register(r); err != nil {
+			return nil, fmt.Errorf("register extension `%s`: %w", ext.Name(), err)
+		}
+
+		logger.Infof("extension `%s` loaded", ext.Name())
+	}
+
+	return r.options(), nil
+}
+
+// registrar collects the extension components.
+type registrar struct {
+	protocolSvcCreators []api.ProtocolSvcCreator
+	vdrs                []vdrapi.VDR
+	packerCreators      []packer.Creator
+	storeProvider       storage.Provider
+	crypto              crypto.Crypto
+}
+
+func (r *registrar) RegisterProtocolServices(creators ...api.ProtocolSvcCreator) {
+	r.protocolSvcCreators = append(r.protocolSvcCreators, creators...)
+}
+
+func (r *registrar) RegisterVDRs(vdrs ...vdrapi.VDR) {
+	r.vdrs = append(r.vdrs, vdrs...)
+}
+
+func (r *registrar) RegisterPackers(creators ...packer.Creator) {
+	r.packerCreators = append(r.packerCreators, creators...)
+}
+
+func (r *registrar) RegisterStorageProvider(provider storage.Provider) error {
+	if r.storeProvider != nil {
+		return errors.New("storage provider already registered")
+	}
+
+	r.storeProvider = provider
+
+	return nil
+}
+
+func (r *registrar) RegisterCrypto(c crypto.Crypto) error {
+	if r.crypto != nil {
+		return errors.New("crypto already registered")
+	}
+
+	r.crypto = c
+
+	return nil
+}
+
+func (r *registrar) options() []aries.Option {
+	var opts []aries.Option
+
+	if len(r.protocolSvcCreators) > 0 {
+		opts = append(opts, aries.WithProtocols(r.protocolSvcCreators...))
+	}
+
+	for _, v := range r.vdrs {
+		opts = append(opts, aries.WithVDR(v))
+	}
+
+	if len(r.packerCreators) > 0 {
+		opts = append(opts, aries.WithAdditionalPackers(r.packerCreators...))
+	}
+
+	if r.storeProvider != nil {
+		opts = append(opts, aries.WithStoreProvider(r.storeProvider))
+	}
+
+	if r.crypto != nil {
+		opts = append(opts, aries.WithCrypto(r.crypto))
+	}
+
+	return opts
+}
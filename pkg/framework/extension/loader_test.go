@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extension
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const sampleManifest = `{
+	"extensions": [
+		{"name": "test-loader-protocol", "config": {"protocol": "sample-protocol"}},
+		{"name": "test-loader-components"}
+	]
+}`
+
+func TestParseManifest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		manifest, err := ParseManifest([]byte(sampleManifest))
+		require.NoError(t, err)
+		require.Empty(t, manifest.Plugins)
+		require.Len(t, manifest.Extensions, 2)
+		require.Equal(t, "test-loader-protocol", manifest.Extensions[0].Name)
+		require.JSONEq(t, `{"protocol": "sample-protocol"}`, string(manifest.Extensions[0].Config))
+		require.Equal(t, "test-loader-components", manifest.Extensions[1].Name)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		manifest, err := ParseManifest([]byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal manifest")
+		require.Nil(t, manifest)
+	})
+
+	t.Run("missing extension name", func(t *testing.T) {
+		manifest, err := ParseManifest([]byte(`{"extensions": [{"config": {}}]}`))
+		require.EqualError(t, err, "extension 0: name is mandatory")
+		require.Nil(t, manifest)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	storeProvider := mockstorage.NewMockStoreProvider()
+	vdr := &mockvdr.MockVDR{AcceptValue: true}
+
+	Register("test-loader-protocol", func(config json.RawMessage) (Extension, error) {
+		cfg := struct {
+			Protocol string `json:"protocol"`
+		}{}
+
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+
+		return &mockExtension{
+			name: "test-loader-protocol",
+			register: func(r Registrar) error {
+				r.RegisterProtocolServices(func(api.Provider) (dispatcher.ProtocolService, error) {
+					return &mockdidexchange.MockDIDExchangeSvc{ProtocolName: cfg.Protocol}, nil
+				})
+
+				return nil
+			},
+		}, nil
+	})
+
+	Register("test-loader-components", func(json.RawMessage) (Extension, error) {
+		return &mockExtension{
+			name: "test-loader-components",
+			register: func(r Registrar) error {
+				r.RegisterVDRs(vdr)
+				r.RegisterPackers(func(packer.Provider) (packer.Packer, error) {
+					return &didcomm.MockAuthCrypt{}, nil
+				})
+
+				if err := r.RegisterCrypto(&mockcrypto.Crypto{}); err != nil {
+					return err
+				}
+
+				return r.RegisterStorageProvider(storeProvider)
+			},
+		}, nil
+	})
+
+	Register("test-loader-failure", func(config json.RawMessage) (Extension, error) {
+		if len(config) > 0 {
+			return nil, errors.New("invalid config")
+		}
+
+		return &mockExtension{name: "test-loader-failure", registerErr: errors.New("register error")}, nil
+	})
+
+	t.Run("assemble framework from manifest", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(sampleManifest), 0o600))
+
+		opts, err := LoadManifest(path)
+		require.NoError(t, err)
+		require.Len(t, opts, 5)
+
+		framework, err := aries.New(opts...)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, framework.Close())
+		}()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+
+		_, err = ctx.Service("sample-protocol")
+		require.NoError(t, err)
+
+		require.Equal(t, storeProvider, ctx.StorageProvider())
+		require.IsType(t, &mockcrypto.Crypto{}, ctx.Crypto())
+		require.Len(t, ctx.Packers(), 4)
+
+		_, err = ctx.VDRegistry().Resolve("did:example:123")
+		require.NoError(t, err)
+	})
+
+	t.Run("read manifest failure", func(t *testing.T) {
+		opts, err := LoadManifest(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read manifest")
+		require.True(t, errors.Is(err, os.ErrNotExist))
+		require.Nil(t, opts)
+
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0o600))
+
+		opts, err = LoadManifest(path)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal manifest")
+		require.Nil(t, opts)
+	})
+
+	t.Run("open plugin failure", func(t *testing.T) {
+		opts, err := Load(&Manifest{Plugins: []string{filepath.Join(t.TempDir(), "missing.so")}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open plugin")
+		require.Nil(t, opts)
+	})
+
+	t.Run("extension not registered", func(t *testing.T) {
+		opts, err := Load(&Manifest{Extensions: []ManifestEntry{{Name: "test-loader-unknown"}}})
+		require.EqualError(t, err, "extension `test-loader-unknown` is not registered")
+		require.Nil(t, opts)
+	})
+
+	t.Run("create extension failure", func(t *testing.T) {
+		opts, err := Load(&Manifest{Extensions: []ManifestEntry{
+			{Name: "test-loader-failure", Config: json.RawMessage(`{}`)},
+		}})
+		require.EqualError(t, err, "create extension `test-loader-failure`: invalid config")
+		require.Nil(t, opts)
+	})
+
+	t.Run("register extension failure", func(t *testing.T) {
+		opts, err := Load(&Manifest{Extensions: []ManifestEntry{{Name: "test-loader-failure"}}})
+		require.EqualError(t, err, "register extension `test-loader-failure`: register error")
+		require.Nil(t, opts)
+	})
+
+	t.Run("storage and crypto provided by several extensions", func(t *testing.T) {
+		opts, err := Load(&Manifest{Extensions: []ManifestEntry{
+			{Name: "test-loader-components"}, {Name: "test-loader-components"},
+		}})
+		require.EqualError(t, err, "register extension `test-loader-components`: crypto already registered")
+		require.Nil(t, opts)
+
+		r := &registrar{}
+		require.NoError(t, r.RegisterStorageProvider(storeProvider))
+		require.EqualError(t, r.RegisterStorageProvider(storeProvider), "storage provider already registered")
+	})
+}
@@ -78,6 +78,12 @@ func (l *DefLog) Errorf(format string, args ...interface{}) {
 	l.logf(log.ERROR, format, args...)
 }
 
+// Log writes the message followed by its fields formatted as ' key=value' pairs. The CRITICAL level neither exits
+// nor panics.
+func (l *DefLog) Log(level log.Level, msg string, fields ...log.Field) {
+	l.logf(level, "%s", msg+FormatFields(fields...))
+}
+
 // SetOutput sets the output destination for the logger.
 func (l *DefLog) SetOutput(output io.Writer) {
 	l.logger.SetOutput(output)
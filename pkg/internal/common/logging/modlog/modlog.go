@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package modlog
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
 	"github.com/hyperledger/aries-framework-go/spi/log"
 )
@@ -68,3 +72,46 @@ func (m *ModLog) Errorf(format string, args ...interface{}) {
 
 	m.logger.Errorf(format, args...)
 }
+
+// Log writes the structured log entry to the underlying logger if the given level is enabled. If the underlying
+// logger doesn't implement 'log.StructuredLogger' the fields are formatted in the message.
+func (m *ModLog) Log(level log.Level, msg string, fields ...log.Field) {
+	if !metadata.IsEnabledFor(m.module, level) {
+		return
+	}
+
+	if logger, ok := m.logger.(log.StructuredLogger); ok {
+		logger.Log(level, msg, fields...)
+
+		return
+	}
+
+	msg += FormatFields(fields...)
+
+	switch level {
+	case log.CRITICAL, log.ERROR:
+		m.logger.Errorf("%s", msg)
+	case log.WARNING:
+		m.logger.Warnf("%s", msg)
+	case log.INFO:
+		m.logger.Infof("%s", msg)
+	case log.DEBUG:
+		m.logger.Debugf("%s", msg)
+	}
+}
+
+// FormatFields formats the fields of a structured log entry as ' key=value' pairs.
+func FormatFields(fields ...log.Field) string {
+	var sb strings.Builder
+
+	for _, field := range fields {
+		value := fmt.Sprint(field.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		fmt.Fprintf(&sb, " %s=%s", field.Key, value)
+	}
+
+	return sb.String()
+}
@@ -7,7 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package modlog
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
+	"github.com/hyperledger/aries-framework-go/spi/log"
 )
 
 func TestModLog(t *testing.T) {
@@ -15,3 +21,49 @@ func TestModLog(t *testing.T) {
 	modLogger := NewModLog(GetSampleCustomLogger(module), module)
 	VerifyCustomLogger(t, modLogger, module)
 }
+
+func TestModLog_Log(t *testing.T) {
+	t.Run("structured logger", func(t *testing.T) {
+		const module = "sample-module-structured"
+
+		logger := NewModLog(NewDefLog(module), module)
+		SwitchLogOutputToBuffer(logger)
+		metadata.SetLevel(module, log.INFO)
+
+		buf.Reset()
+		logger.Log(log.INFO, "message sent", log.Field{Key: "connectionID", Value: "conn-1"},
+			log.Field{Key: "attempt", Value: 2})
+		require.Regexp(t, `\[sample-module-structured\] .* UTC .*-> INFO message sent connectionID=conn-1 attempt=2`,
+			buf.String())
+
+		buf.Reset()
+		logger.Log(log.DEBUG, "not logged", log.Field{Key: "key", Value: "value"})
+		require.Empty(t, buf.String())
+	})
+
+	t.Run("fields formatted for printf logger", func(t *testing.T) {
+		const module = "sample-module-printf"
+
+		logger := NewModLog(GetSampleCustomLogger(module), module)
+		metadata.SetLevel(module, log.WARNING)
+
+		for _, level := range []log.Level{log.CRITICAL, log.ERROR, log.WARNING} {
+			buf.Reset()
+			logger.Log(level, "message", log.Field{Key: "key", Value: "value"})
+			require.Contains(t, buf.String(), customOutput)
+		}
+
+		for _, level := range []log.Level{log.INFO, log.DEBUG} {
+			buf.Reset()
+			logger.Log(level, "message", log.Field{Key: "key", Value: "value"})
+			require.Empty(t, buf.String())
+		}
+	})
+}
+
+func TestFormatFields(t *testing.T) {
+	require.Empty(t, FormatFields())
+	require.Equal(t, " a=1 b=true", FormatFields(log.Field{Key: "a", Value: 1}, log.Field{Key: "b", Value: true}))
+	require.Equal(t, ` err="some error" empty=""`,
+		FormatFields(log.Field{Key: "err", Value: errors.New("some error")}, log.Field{Key: "empty", Value: ""}))
+}
@@ -231,17 +231,22 @@ func (mr *MockOobServiceMockRecorder) RegisterMsgEvent(arg0 interface{}) *gomock
 }
 
 // SaveInvitation mocks base method.
-func (m *MockOobService) SaveInvitation(arg0 *outofband.Invitation) error {
+func (m *MockOobService) SaveInvitation(arg0 *outofband.Invitation, arg1 ...outofband.SaveOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveInvitation", arg0)
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveInvitation", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveInvitation indicates an expected call of SaveInvitation.
-func (mr *MockOobServiceMockRecorder) SaveInvitation(arg0 interface{}) *gomock.Call {
+func (mr *MockOobServiceMockRecorder) SaveInvitation(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockOobService)(nil).SaveInvitation), arg0)
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockOobService)(nil).SaveInvitation), varargs...)
 }
 
 // UnregisterActionEvent mocks base method.
@@ -125,6 +125,21 @@ func (mr *MockStoreMockRecorder) GetPresentations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresentations", reflect.TypeOf((*MockStore)(nil).GetPresentations))
 }
 
+// QueryCredentials mocks base method.
+func (m *MockStore) QueryCredentials(arg0 *verifiable0.QueryParams) (*verifiable0.QueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryCredentials", arg0)
+	ret0, _ := ret[0].(*verifiable0.QueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryCredentials indicates an expected call of QueryCredentials.
+func (mr *MockStoreMockRecorder) QueryCredentials(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryCredentials", reflect.TypeOf((*MockStore)(nil).QueryCredentials), arg0)
+}
+
 // RemoveCredentialByName mocks base method.
 func (m *MockStore) RemoveCredentialByName(arg0 string) error {
 	m.ctrl.T.Helper()
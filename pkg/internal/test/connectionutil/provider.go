@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connectionutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	// ConnectionID is the ID of the completed connection of the provider.
+	ConnectionID = "conn-1"
+	// MyDID is my DID of the connection.
+	MyDID = "did:example:my"
+	// TheirDID is their DID of the connection.
+	TheirDID = "did:example:their"
+)
+
+// NewProvider returns a provider with in-memory stores holding the completed connection ConnectionID, a local KMS
+// and the given outbound dispatcher. The service of the provider is the protocol service created by newService.
+func NewProvider(t *testing.T, outbound dispatcher.Outbound,
+	newService func(prov *mockprovider.Provider) (interface{}, error)) *mockprovider.Provider {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	prov := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+		KMSValue:                          km,
+		CryptoValue:                       c,
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: ConnectionID,
+		MyDID:        MyDID,
+		TheirDID:     TheirDID,
+		State:        connection.StateNameCompleted,
+	}))
+
+	svc, err := newService(prov)
+	require.NoError(t, err)
+
+	prov.ServiceValue = svc
+
+	return prov
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// TracerName is the instrumentation name of the spans created by the framework.
+const TracerName = "github.com/hyperledger/aries-framework-go"
+
+// nolint: gochecknoglobals
+var propagator = propagation.TraceContext{}
+
+// Tracer returns the framework tracer of the given provider. A no-op tracer is returned if the provider is nil.
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = trace.NewNoopTracerProvider()
+	}
+
+	return tp.Tracer(TracerName)
+}
+
+// Inject adds the span context found in ctx to the raw DIDComm message as a ~trace_context decorator.
+// The message is returned as is when ctx doesn't hold a valid span context.
+func Inject(ctx context.Context, msg []byte) ([]byte, error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return msg, nil
+	}
+
+	tc := &decorator.TraceContext{}
+
+	propagator.Inject(ctx, tc)
+
+	raw, err := json.Marshal(tc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal trace context: %w", err)
+	}
+
+	msg, err = sjson.SetRawBytes(msg, decorator.TraceContextKey, raw)
+	if err != nil {
+		return nil, fmt.Errorf("set trace context decorator: %w", err)
+	}
+
+	return msg, nil
+}
+
+// Extract returns a copy of ctx holding the remote span context found in the ~trace_context decorator of the raw
+// DIDComm message. ctx is returned as is if the message doesn't carry a valid trace context.
+func Extract(ctx context.Context, msg []byte) context.Context {
+	res := gjson.GetBytes(msg, decorator.TraceContextKey)
+	if !res.IsObject() {
+		return ctx
+	}
+
+	tc := &decorator.TraceContext{}
+
+	if err := json.Unmarshal([]byte(res.Raw), tc); err != nil {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, tc)
+}
+
+// End records err (if any) on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mocktracing "github.com/hyperledger/aries-framework-go/pkg/mock/tracing"
+)
+
+func TestTracer(t *testing.T) {
+	require.NotNil(t, Tracer(nil))
+	require.NotNil(t, Tracer(&mocktracing.TracerProvider{}))
+}
+
+func TestInjectExtract(t *testing.T) {
+	tp := &mocktracing.TracerProvider{}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, span := Tracer(tp).Start(context.Background(), "test")
+		defer span.End()
+
+		msg, err := Inject(ctx, []byte(`{"@id":"123","@type":"type"}`))
+		require.NoError(t, err)
+		require.Contains(t, string(msg), decorator.TraceContextKey)
+
+		sc := trace.SpanContextFromContext(Extract(context.Background(), msg))
+		require.True(t, sc.IsValid())
+		require.True(t, sc.IsRemote())
+		require.Equal(t, span.SpanContext().TraceID(), sc.TraceID())
+		require.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+	})
+
+	t.Run("no span context", func(t *testing.T) {
+		msg := []byte(`{"@id":"123"}`)
+
+		res, err := Inject(context.Background(), msg)
+		require.NoError(t, err)
+		require.Equal(t, msg, res)
+	})
+
+	t.Run("extract without trace context", func(t *testing.T) {
+		ctx := Extract(context.Background(), []byte(`{"@id":"123"}`))
+		require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+
+		ctx = Extract(context.Background(), []byte(`{"~trace_context":{"traceparent":"invalid"}}`))
+		require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+
+		ctx = Extract(context.Background(), []byte(`invalid json`))
+		require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	})
+}
+
+func TestEnd(t *testing.T) {
+	tp := &mocktracing.TracerProvider{}
+
+	_, span := Tracer(tp).Start(context.Background(), "success")
+	End(span, nil)
+
+	_, span = Tracer(tp).Start(context.Background(), "failure")
+	End(span, errors.New("test"))
+
+	require.True(t, tp.Span("success").Ended())
+	require.Empty(t, tp.Span("success").Errors())
+	require.Equal(t, codes.Unset, tp.Span("success").Status())
+
+	require.True(t, tp.Span("failure").Ended())
+	require.Len(t, tp.Span("failure").Errors(), 1)
+	require.Equal(t, codes.Error, tp.Span("failure").Status())
+}
@@ -0,0 +1,367 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package awskms provides a kms.KeyManager keeping the keys in AWS KMS, the private keys never leave AWS. It is used
+// along with the crypto service of the awscrypto package, which executes the crypto operations in AWS KMS:
+//
+//	client := kms.New(session.Must(session.NewSession()))
+//	framework, err := aries.New(
+//		aries.WithKMS(func(kms.Provider) (kms.KeyManager, error) { return awskms.New(client), nil }),
+//		aries.WithCrypto(awscrypto.New(client)),
+//	)
+//
+// AWS KMS supports ECDSA (NIST P-256, P-384, P-521 and secp256k1) and RSA signing keys and symmetric encryption keys.
+package awskms
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// KeyTypeTag is the tag of the AWS KMS keys holding their aries key type.
+const KeyTypeTag = "aries-key-type"
+
+var logger = log.New("aries-framework/kms/awskms")
+
+// Client is the subset of the AWS KMS API used by the key manager and the awscrypto service. It is implemented by the
+// AWS SDK client (*kms.KMS).
+type Client interface {
+	CreateKey(input *awskms.CreateKeyInput) (*awskms.CreateKeyOutput, error)
+	DescribeKey(input *awskms.DescribeKeyInput) (*awskms.DescribeKeyOutput, error)
+	ListResourceTags(input *awskms.ListResourceTagsInput) (*awskms.ListResourceTagsOutput, error)
+	GetPublicKey(input *awskms.GetPublicKeyInput) (*awskms.GetPublicKeyOutput, error)
+	Sign(input *awskms.SignInput) (*awskms.SignOutput, error)
+	Verify(input *awskms.VerifyInput) (*awskms.VerifyOutput, error)
+	Encrypt(input *awskms.EncryptInput) (*awskms.EncryptOutput, error)
+	Decrypt(input *awskms.DecryptInput) (*awskms.DecryptOutput, error)
+}
+
+// KeyHandle is the handle of an AWS KMS key, returned by the key manager and used by the awscrypto service.
+type KeyHandle struct {
+	// KeyID is the AWS KMS key ID.
+	KeyID   string
+	KeyType kms.KeyType
+}
+
+// keySpec is the AWS KMS spec of an aries key type.
+type keySpec struct {
+	keySpec          string
+	usage            string
+	signingAlgorithm string
+}
+
+// nolint: gochecknoglobals
+var keySpecs = map[kms.KeyType]keySpec{
+	kms.ECDSAP256TypeDER: {
+		awskms.CustomerMasterKeySpecEccNistP256, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha256,
+	},
+	kms.ECDSAP256TypeIEEEP1363: {
+		awskms.CustomerMasterKeySpecEccNistP256, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha256,
+	},
+	kms.ECDSAP384TypeDER: {
+		awskms.CustomerMasterKeySpecEccNistP384, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha384,
+	},
+	kms.ECDSAP384TypeIEEEP1363: {
+		awskms.CustomerMasterKeySpecEccNistP384, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha384,
+	},
+	kms.ECDSAP521TypeDER: {
+		awskms.CustomerMasterKeySpecEccNistP521, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha512,
+	},
+	kms.ECDSAP521TypeIEEEP1363: {
+		awskms.CustomerMasterKeySpecEccNistP521, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha512,
+	},
+	kms.ECDSASecp256k1TypeIEEEP1363: {
+		awskms.CustomerMasterKeySpecEccSecgP256k1, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecEcdsaSha256,
+	},
+	kms.RSARS256Type: {
+		awskms.CustomerMasterKeySpecRsa2048, awskms.KeyUsageTypeSignVerify,
+		awskms.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+	},
+	kms.RSAPS256Type: {
+		awskms.CustomerMasterKeySpecRsa2048, awskms.KeyUsageTypeSignVerify, awskms.SigningAlgorithmSpecRsassaPssSha256,
+	},
+	kms.AES256GCMType: {
+		awskms.CustomerMasterKeySpecSymmetricDefault, awskms.KeyUsageTypeEncryptDecrypt, "",
+	},
+}
+
+// defaultKeyTypes are the key types of the AWS KMS keys not created by the key manager, by key spec.
+// nolint: gochecknoglobals
+var defaultKeyTypes = map[string]kms.KeyType{
+	awskms.CustomerMasterKeySpecEccNistP256:      kms.ECDSAP256TypeDER,
+	awskms.CustomerMasterKeySpecEccNistP384:      kms.ECDSAP384TypeDER,
+	awskms.CustomerMasterKeySpecEccNistP521:      kms.ECDSAP521TypeDER,
+	awskms.CustomerMasterKeySpecEccSecgP256k1:    kms.ECDSASecp256k1TypeIEEEP1363,
+	awskms.CustomerMasterKeySpecRsa2048:          kms.RSAPS256Type,
+	awskms.CustomerMasterKeySpecRsa3072:          kms.RSAPS256Type,
+	awskms.CustomerMasterKeySpecRsa4096:          kms.RSAPS256Type,
+	awskms.CustomerMasterKeySpecSymmetricDefault: kms.AES256GCMType,
+}
+
+// SigningAlgorithm returns the AWS KMS signing algorithm of the key.
+func (h *KeyHandle) SigningAlgorithm() (string, error) {
+	spec, ok := keySpecs[h.KeyType]
+	if !ok || spec.signingAlgorithm == "" {
+		return "", fmt.Errorf("key type %s is not a signing key type supported by AWS KMS", h.KeyType)
+	}
+
+	return spec.signingAlgorithm, nil
+}
+
+// IEEEP1363 tells if the signatures of the key are IEEE P1363 encoded, AWS KMS producing DER encoded ECDSA signatures.
+func (h *KeyHandle) IEEEP1363() bool {
+	switch h.KeyType { // nolint: exhaustive
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+		kms.ECDSASecp256k1TypeIEEEP1363:
+		return true
+	default:
+		return false
+	}
+}
+
+// Opts are the AWS KMS key manager options.
+type Opts struct {
+	policy      string
+	description string
+	tags        map[string]string
+}
+
+// Opt is an AWS KMS key manager option.
+type Opt func(opts *Opts)
+
+// WithKeyPolicy sets the key policy of the created keys, AWS KMS applies its default key policy if not set.
+func WithKeyPolicy(policy string) Opt {
+	return func(opts *Opts) {
+		opts.policy = policy
+	}
+}
+
+// WithKeyDescription sets the description of the created keys.
+func WithKeyDescription(description string) Opt {
+	return func(opts *Opts) {
+		opts.description = description
+	}
+}
+
+// WithKeyTags adds tags to the created keys, on top of the key type tag.
+func WithKeyTags(tags map[string]string) Opt {
+	return func(opts *Opts) {
+		opts.tags = tags
+	}
+}
+
+// KMS implementation of kms.KeyManager api keeping the keys in AWS KMS.
+type KMS struct {
+	client  Client
+	opts    *Opts
+	handles map[string]*KeyHandle
+	mu      sync.RWMutex
+}
+
+// New creates a new AWS KMS key manager using the AWS KMS client.
+func New(client Client, opts ...Opt) *KMS {
+	kmsOpts := &Opts{}
+
+	for _, opt := range opts {
+		opt(kmsOpts)
+	}
+
+	return &KMS{
+		client:  client,
+		opts:    kmsOpts,
+		handles: make(map[string]*KeyHandle),
+	}
+}
+
+// Create a new key of type kt in AWS KMS.
+// Returns:
+//   - AWS KMS key ID
+//   - *KeyHandle of the key
+//   - error if failure
+func (k *KMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	spec, ok := keySpecs[kt]
+	if !ok {
+		return "", nil, fmt.Errorf("key type %s is not supported by AWS KMS", kt)
+	}
+
+	input := &awskms.CreateKeyInput{
+		CustomerMasterKeySpec: aws.String(spec.keySpec),
+		KeyUsage:              aws.String(spec.usage),
+		Tags:                  []*awskms.Tag{{TagKey: aws.String(KeyTypeTag), TagValue: aws.String(string(kt))}},
+	}
+
+	if k.opts.policy != "" {
+		input.Policy = aws.String(k.opts.policy)
+	}
+
+	if k.opts.description != "" {
+		input.Description = aws.String(k.opts.description)
+	}
+
+	for key, value := range k.opts.tags {
+		input.Tags = append(input.Tags, &awskms.Tag{TagKey: aws.String(key), TagValue: aws.String(value)})
+	}
+
+	output, err := k.client.CreateKey(input)
+	if err != nil {
+		return "", nil, fmt.Errorf("create AWS KMS key: %w", err)
+	}
+
+	kh := &KeyHandle{KeyID: aws.StringValue(output.KeyMetadata.KeyId), KeyType: kt}
+
+	k.mu.Lock()
+	k.handles[kh.KeyID] = kh
+	k.mu.Unlock()
+
+	return kh.KeyID, kh, nil
+}
+
+// Get the handle of the AWS KMS key. The key type of the keys not created by the key manager is the default key type
+// of their key spec (DER encoded ECDSA, secp256k1 IEEE P1363, RSA-PSS or AES256-GCM).
+// Returns:
+//   - *KeyHandle of the key
+//   - error if failure
+func (k *KMS) Get(keyID string) (interface{}, error) {
+	k.mu.RLock()
+	kh, ok := k.handles[keyID]
+	k.mu.RUnlock()
+
+	if ok {
+		return kh, nil
+	}
+
+	kt, err := k.keyType(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	kh = &KeyHandle{KeyID: keyID, KeyType: kt}
+
+	k.mu.Lock()
+	k.handles[keyID] = kh
+	k.mu.Unlock()
+
+	return kh, nil
+}
+
+func (k *KMS) keyType(keyID string) (kms.KeyType, error) {
+	tags, err := k.client.ListResourceTags(&awskms.ListResourceTagsInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return "", fmt.Errorf("list AWS KMS key tags: %w", err)
+	}
+
+	for _, tag := range tags.Tags {
+		if aws.StringValue(tag.TagKey) == KeyTypeTag {
+			return kms.KeyType(aws.StringValue(tag.TagValue)), nil
+		}
+	}
+
+	key, err := k.client.DescribeKey(&awskms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return "", fmt.Errorf("describe AWS KMS key: %w", err)
+	}
+
+	spec := aws.StringValue(key.KeyMetadata.CustomerMasterKeySpec)
+
+	kt, ok := defaultKeyTypes[spec]
+	if !ok {
+		return "", fmt.Errorf("AWS KMS key spec %s is not supported", spec)
+	}
+
+	logger.Debugf("key %s has no %s tag, using key type %s", keyID, KeyTypeTag, kt)
+
+	return kt, nil
+}
+
+// Rotate is not supported, AWS KMS doesn't rotate asymmetric keys.
+func (k *KMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	return "", nil, errors.New("function Rotate is not supported by AWS KMS")
+}
+
+// ExportPubKeyBytes will fetch the public key of the AWS KMS key and returns it in raw bytes: DER encoded for DER ECDSA
+// and RSA keys, marshalled elliptic point for IEEE P1363 ECDSA keys.
+// Returns:
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
+func (k *KMS) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	kh, err := k.Get(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.exportPubKeyBytes(kh.(*KeyHandle))
+}
+
+func (k *KMS) exportPubKeyBytes(kh *KeyHandle) ([]byte, error) {
+	if kh.KeyType == kms.AES256GCMType {
+		return nil, fmt.Errorf("key %s is not an asymmetric key", kh.KeyID)
+	}
+
+	output, err := k.client.GetPublicKey(&awskms.GetPublicKeyInput{KeyId: aws.String(kh.KeyID)})
+	if err != nil {
+		return nil, fmt.Errorf("get AWS KMS public key: %w", err)
+	}
+
+	if !kh.IEEEP1363() {
+		return output.PublicKey, nil
+	}
+
+	// the public key is a DER encoded SubjectPublicKeyInfo holding the marshalled elliptic point.
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	if _, err = asn1.Unmarshal(output.PublicKey, &spki); err != nil {
+		return nil, fmt.Errorf("unmarshal AWS KMS public key: %w", err)
+	}
+
+	return spki.PublicKey.RightAlign(), nil
+}
+
+// CreateAndExportPubKeyBytes will create a key of type kt in AWS KMS and export its public key in raw bytes and
+// returns it. The key must be an asymmetric key.
+// Returns:
+//   - AWS KMS key ID of the new key.
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
+func (k *KMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	if kt == kms.AES256GCMType {
+		return "", nil, fmt.Errorf("key type %s is not an asymmetric key type", kt)
+	}
+
+	keyID, kh, err := k.Create(kt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pubKey, err := k.exportPubKeyBytes(kh.(*KeyHandle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, pubKey, nil
+}
+
+// PubKeyBytesToHandle is not supported, public keys are verified by AWS KMS with their key handle.
+func (k *KMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	return nil, errors.New("function PubKeyBytesToHandle is not supported by AWS KMS")
+}
+
+// ImportPrivateKey is not supported, AWS KMS doesn't import asymmetric keys.
+func (k *KMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	return "", nil, errors.New("function ImportPrivateKey is not supported by AWS KMS")
+}
@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package awskms
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awskmsapi "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockawskms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/awskms"
+)
+
+var (
+	_ kms.KeyManager = (*KMS)(nil)
+	_ Client         = (*awskmsapi.KMS)(nil)
+)
+
+func TestKMS_Create(t *testing.T) {
+	t.Run("create keys", func(t *testing.T) {
+		client := &mockawskms.Client{}
+		k := New(client, WithKeyPolicy("policy"), WithKeyDescription("signing key"),
+			WithKeyTags(map[string]string{"owner": "alice"}))
+
+		keyID, kh, err := k.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+		require.NotEmpty(t, keyID)
+		require.Equal(t, &KeyHandle{KeyID: keyID, KeyType: kms.ECDSAP256TypeIEEEP1363}, kh)
+
+		require.Len(t, client.CreateKeyInputs, 1)
+		input := client.CreateKeyInputs[0]
+		require.Equal(t, awskmsapi.CustomerMasterKeySpecEccNistP256, aws.StringValue(input.CustomerMasterKeySpec))
+		require.Equal(t, awskmsapi.KeyUsageTypeSignVerify, aws.StringValue(input.KeyUsage))
+		require.Equal(t, "policy", aws.StringValue(input.Policy))
+		require.Equal(t, "signing key", aws.StringValue(input.Description))
+		require.Equal(t, []*awskmsapi.Tag{
+			{TagKey: aws.String(KeyTypeTag), TagValue: aws.String(kms.ECDSAP256IEEEP1363)},
+			{TagKey: aws.String("owner"), TagValue: aws.String("alice")},
+		}, input.Tags)
+
+		_, kh, err = k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+		require.Equal(t, kms.AES256GCMType, kh.(*KeyHandle).KeyType)
+		require.Equal(t, awskmsapi.KeyUsageTypeEncryptDecrypt, aws.StringValue(client.CreateKeyInputs[1].KeyUsage))
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, _, err := New(&mockawskms.Client{}).Create(kms.ED25519Type)
+		require.EqualError(t, err, "key type ED25519 is not supported by AWS KMS")
+	})
+
+	t.Run("AWS KMS error", func(t *testing.T) {
+		_, _, err := New(&mockawskms.Client{CreateKeyErr: errors.New("access denied")}).Create(kms.RSAPS256Type)
+		require.EqualError(t, err, "create AWS KMS key: access denied")
+	})
+}
+
+func TestKMS_Get(t *testing.T) {
+	client := &mockawskms.Client{}
+
+	keyID, kh, err := New(client).Create(kms.ECDSAP384TypeIEEEP1363)
+	require.NoError(t, err)
+
+	t.Run("key created by the key manager", func(t *testing.T) {
+		handle, err := New(client).Get(keyID)
+		require.NoError(t, err)
+		require.Equal(t, kh, handle)
+	})
+
+	t.Run("key created outside of the key manager", func(t *testing.T) {
+		output, err := client.CreateKey(&awskmsapi.CreateKeyInput{
+			CustomerMasterKeySpec: aws.String(awskmsapi.CustomerMasterKeySpecRsa2048),
+			KeyUsage:              aws.String(awskmsapi.KeyUsageTypeSignVerify),
+		})
+		require.NoError(t, err)
+
+		handle, err := New(client).Get(aws.StringValue(output.KeyMetadata.KeyId))
+		require.NoError(t, err)
+		require.Equal(t, kms.RSAPS256Type, handle.(*KeyHandle).KeyType)
+	})
+
+	t.Run("handles are cached", func(t *testing.T) {
+		k := New(client)
+
+		_, err := k.Get(keyID)
+		require.NoError(t, err)
+
+		client.ListResourceTagsErr = errors.New("throttled")
+		defer func() { client.ListResourceTagsErr = nil }()
+
+		handle, err := k.Get(keyID)
+		require.NoError(t, err)
+		require.Equal(t, kh, handle)
+
+		_, err = New(client).Get(keyID)
+		require.EqualError(t, err, "list AWS KMS key tags: throttled")
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := New(client).Get("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "list AWS KMS key tags")
+	})
+
+	t.Run("describe key error", func(t *testing.T) {
+		output, err := client.CreateKey(&awskmsapi.CreateKeyInput{
+			CustomerMasterKeySpec: aws.String(awskmsapi.CustomerMasterKeySpecEccNistP256),
+		})
+		require.NoError(t, err)
+
+		client.DescribeKeyErr = errors.New("throttled")
+		defer func() { client.DescribeKeyErr = nil }()
+
+		_, err = New(client).Get(aws.StringValue(output.KeyMetadata.KeyId))
+		require.EqualError(t, err, "describe AWS KMS key: throttled")
+	})
+}
+
+func TestKMS_ExportPubKeyBytes(t *testing.T) {
+	client := &mockawskms.Client{}
+	k := New(client)
+
+	t.Run("DER key", func(t *testing.T) {
+		keyID, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+		require.NoError(t, err)
+		require.IsType(t, &ecdsa.PublicKey{}, pubKey)
+
+		exported, err := k.ExportPubKeyBytes(keyID)
+		require.NoError(t, err)
+		require.Equal(t, pubKeyBytes, exported)
+	})
+
+	t.Run("IEEE P1363 key", func(t *testing.T) {
+		_, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.ECDSAP521TypeIEEEP1363)
+		require.NoError(t, err)
+
+		x, y := elliptic.Unmarshal(elliptic.P521(), pubKeyBytes)
+		require.NotNil(t, x)
+		require.NotNil(t, y)
+	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		_, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.RSARS256Type)
+		require.NoError(t, err)
+
+		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+		require.NoError(t, err)
+		require.IsType(t, &rsa.PublicKey{}, pubKey)
+	})
+
+	t.Run("symmetric key", func(t *testing.T) {
+		_, _, err := k.CreateAndExportPubKeyBytes(kms.AES256GCMType)
+		require.EqualError(t, err, "key type AES256GCM is not an asymmetric key type")
+
+		keyID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		_, err = k.ExportPubKeyBytes(keyID)
+		require.EqualError(t, err, "key "+keyID+" is not an asymmetric key")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := k.ExportPubKeyBytes("unknown")
+		require.Error(t, err)
+
+		_, _, err = New(&mockawskms.Client{CreateKeyErr: errors.New("access denied")}).
+			CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "create AWS KMS key: access denied")
+
+		_, _, err = New(&mockawskms.Client{GetPublicKeyErr: errors.New("access denied")}).
+			CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "get AWS KMS public key: access denied")
+	})
+}
+
+func TestKMS_NotSupported(t *testing.T) {
+	k := New(&mockawskms.Client{})
+
+	_, _, err := k.Rotate(kms.ECDSAP256TypeDER, "keyID")
+	require.EqualError(t, err, "function Rotate is not supported by AWS KMS")
+
+	_, err = k.PubKeyBytesToHandle([]byte("key"), kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, "function PubKeyBytesToHandle is not supported by AWS KMS")
+
+	_, _, err = k.ImportPrivateKey(&ecdsa.PrivateKey{}, kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, "function ImportPrivateKey is not supported by AWS KMS")
+}
+
+func TestKeyHandle_SigningAlgorithm(t *testing.T) {
+	algorithm, err := (&KeyHandle{KeyType: kms.RSARS256Type}).SigningAlgorithm()
+	require.NoError(t, err)
+	require.Equal(t, awskmsapi.SigningAlgorithmSpecRsassaPkcs1V15Sha256, algorithm)
+
+	_, err = (&KeyHandle{KeyType: kms.AES256GCMType}).SigningAlgorithm()
+	require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by AWS KMS")
+}
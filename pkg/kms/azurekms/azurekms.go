@@ -0,0 +1,406 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package azurekms provides a kms.KeyManager keeping the keys in Azure Key Vault, the private keys never leave
+// Key Vault. It is used along with the crypto service of the azurecrypto package, which executes the crypto operations
+// in Key Vault:
+//
+//	client := azurekms.NewClient("https://my-vault.vault.azure.net", http.DefaultClient, tokenProvider)
+//	framework, err := aries.New(
+//		aries.WithKMS(func(kms.Provider) (kms.KeyManager, error) { return azurekms.New(client), nil }),
+//		aries.WithCrypto(azurecrypto.New(client)),
+//	)
+//
+// Key Vault supports ECDSA (NIST P-256, P-384, P-521 and secp256k1) and RSA keys. The RSA keys are created for both
+// signing and key wrapping (RSA-OAEP-256), Key Vault has no ECDH key agreement.
+package azurekms
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// KeyTypeTag is the tag of the Key Vault keys holding their aries key type.
+	KeyTypeTag = "aries-key-type"
+
+	// KeyTypeEC is the Key Vault elliptic curve key type.
+	KeyTypeEC = "EC"
+	// KeyTypeRSA is the Key Vault RSA key type.
+	KeyTypeRSA = "RSA"
+
+	// CurveP256 is the Key Vault NIST P-256 curve.
+	CurveP256 = "P-256"
+	// CurveP384 is the Key Vault NIST P-384 curve.
+	CurveP384 = "P-384"
+	// CurveP521 is the Key Vault NIST P-521 curve.
+	CurveP521 = "P-521"
+	// CurveP256K is the Key Vault secp256k1 curve.
+	CurveP256K = "P-256K"
+
+	// WrapAlgorithm is the Key Vault algorithm wrapping keys with the RSA keys.
+	WrapAlgorithm = "RSA-OAEP-256"
+
+	rsaKeySize = 2048
+	keysPath   = "/keys/"
+)
+
+var logger = log.New("aries-framework/kms/azurekms")
+
+// KeyHandle is the handle of a Key Vault key, returned by the key manager and used by the azurecrypto service.
+type KeyHandle struct {
+	// KeyID is the Key Vault key name followed by a slash and the key version.
+	KeyID   string
+	KeyType kms.KeyType
+}
+
+// keySpec is the Key Vault spec of an aries key type.
+type keySpec struct {
+	keyType          string
+	curve            string
+	signingAlgorithm string
+}
+
+// nolint: gochecknoglobals
+var keySpecs = map[kms.KeyType]keySpec{
+	kms.ECDSAP256TypeDER:            {KeyTypeEC, CurveP256, "ES256"},
+	kms.ECDSAP256TypeIEEEP1363:      {KeyTypeEC, CurveP256, "ES256"},
+	kms.ECDSAP384TypeDER:            {KeyTypeEC, CurveP384, "ES384"},
+	kms.ECDSAP384TypeIEEEP1363:      {KeyTypeEC, CurveP384, "ES384"},
+	kms.ECDSAP521TypeDER:            {KeyTypeEC, CurveP521, "ES512"},
+	kms.ECDSAP521TypeIEEEP1363:      {KeyTypeEC, CurveP521, "ES512"},
+	kms.ECDSASecp256k1TypeIEEEP1363: {KeyTypeEC, CurveP256K, "ES256K"},
+	kms.RSARS256Type:                {KeyTypeRSA, "", "RS256"},
+	kms.RSAPS256Type:                {KeyTypeRSA, "", "PS256"},
+}
+
+// defaultKeyTypes are the key types of the Key Vault keys not created by the key manager, by key type and curve.
+// nolint: gochecknoglobals
+var defaultKeyTypes = map[string]kms.KeyType{
+	KeyTypeEC + CurveP256:  kms.ECDSAP256TypeIEEEP1363,
+	KeyTypeEC + CurveP384:  kms.ECDSAP384TypeIEEEP1363,
+	KeyTypeEC + CurveP521:  kms.ECDSAP521TypeIEEEP1363,
+	KeyTypeEC + CurveP256K: kms.ECDSASecp256k1TypeIEEEP1363,
+	KeyTypeRSA:             kms.RSAPS256Type,
+}
+
+// SigningAlgorithm returns the Key Vault signing algorithm of the key.
+func (h *KeyHandle) SigningAlgorithm() (string, error) {
+	spec, ok := keySpecs[h.KeyType]
+	if !ok {
+		return "", fmt.Errorf("key type %s is not a signing key type supported by Key Vault", h.KeyType)
+	}
+
+	return spec.signingAlgorithm, nil
+}
+
+// IEEEP1363 tells if the signatures of the key are IEEE P1363 encoded, like the ECDSA signatures of Key Vault.
+func (h *KeyHandle) IEEEP1363() bool {
+	switch h.KeyType { // nolint: exhaustive
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+		kms.ECDSASecp256k1TypeIEEEP1363:
+		return true
+	default:
+		return false
+	}
+}
+
+// Opts are the Key Vault key manager options.
+type Opts struct {
+	tags map[string]string
+}
+
+// Opt is a Key Vault key manager option.
+type Opt func(opts *Opts)
+
+// WithKeyTags adds tags to the created keys, on top of the key type tag.
+func WithKeyTags(tags map[string]string) Opt {
+	return func(opts *Opts) {
+		opts.tags = tags
+	}
+}
+
+// KMS implementation of kms.KeyManager api keeping the keys in Azure Key Vault.
+type KMS struct {
+	client  Client
+	opts    *Opts
+	handles map[string]*KeyHandle
+	mu      sync.RWMutex
+}
+
+// New creates a new Key Vault key manager using the Key Vault client.
+func New(client Client, opts ...Opt) *KMS {
+	kmsOpts := &Opts{}
+
+	for _, opt := range opts {
+		opt(kmsOpts)
+	}
+
+	return &KMS{
+		client:  client,
+		opts:    kmsOpts,
+		handles: make(map[string]*KeyHandle),
+	}
+}
+
+// Create a new key of type kt in Key Vault, named with a random UUID.
+// Returns:
+//   - Key Vault key ID, the key name followed by a slash and the key version
+//   - *KeyHandle of the key
+//   - error if failure
+func (k *KMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	kh, _, err := k.createKey(uuid.New().String(), kt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kh.KeyID, kh, nil
+}
+
+func (k *KMS) createKey(keyName string, kt kms.KeyType) (*KeyHandle, *JSONWebKey, error) {
+	spec, ok := keySpecs[kt]
+	if !ok {
+		return nil, nil, fmt.Errorf("key type %s is not supported by Key Vault", kt)
+	}
+
+	params := &KeyCreateParameters{
+		KeyType: spec.keyType,
+		Curve:   spec.curve,
+		KeyOps:  []string{"sign", "verify"},
+		Tags:    map[string]string{KeyTypeTag: string(kt)},
+	}
+
+	if spec.keyType == KeyTypeRSA {
+		params.KeySize = rsaKeySize
+		params.KeyOps = append(params.KeyOps, "wrapKey", "unwrapKey")
+	}
+
+	for key, value := range k.opts.tags {
+		params.Tags[key] = value
+	}
+
+	bundle, err := k.client.CreateKey(keyName, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create Key Vault key: %w", err)
+	}
+
+	keyID, err := KeyIDFromKID(bundle.Key.KID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kh := &KeyHandle{KeyID: keyID, KeyType: kt}
+
+	k.mu.Lock()
+	k.handles[kh.KeyID] = kh
+	k.mu.Unlock()
+
+	return kh, &bundle.Key, nil
+}
+
+// Get the handle of the Key Vault key, keyID being the key name optionally followed by a slash and the key version.
+// The key type of the keys not created by the key manager is the default key type of their Key Vault key type (IEEE
+// P1363 ECDSA or RSA-PSS).
+// Returns:
+//   - *KeyHandle of the key
+//   - error if failure
+func (k *KMS) Get(keyID string) (interface{}, error) {
+	k.mu.RLock()
+	kh, ok := k.handles[keyID]
+	k.mu.RUnlock()
+
+	if ok {
+		return kh, nil
+	}
+
+	bundle, err := k.client.GetKey(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get Key Vault key: %w", err)
+	}
+
+	kt, err := keyType(keyID, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	kh = &KeyHandle{KeyID: keyID, KeyType: kt}
+
+	k.mu.Lock()
+	k.handles[keyID] = kh
+	k.mu.Unlock()
+
+	return kh, nil
+}
+
+func keyType(keyID string, bundle *KeyBundle) (kms.KeyType, error) {
+	if kt, ok := bundle.Tags[KeyTypeTag]; ok {
+		return kms.KeyType(kt), nil
+	}
+
+	spec := bundle.Key.KeyType + bundle.Key.Curve
+
+	kt, ok := defaultKeyTypes[spec]
+	if !ok {
+		return "", fmt.Errorf("key type %s %s of Key Vault is not supported", bundle.Key.KeyType, bundle.Key.Curve)
+	}
+
+	logger.Debugf("key %s has no %s tag, using key type %s", keyID, KeyTypeTag, kt)
+
+	return kt, nil
+}
+
+// Rotate creates a new version of the Key Vault key keyID with the key type kt, the previous versions remain usable.
+// Returns:
+//   - Key Vault key ID of the new version
+//   - *KeyHandle of the new version
+//   - error if failure
+func (k *KMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	kh, _, err := k.createKey(strings.SplitN(keyID, "/", 2)[0], kt) // nolint: gomnd
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kh.KeyID, kh, nil
+}
+
+// ExportPubKeyBytes will fetch the public key of the Key Vault key and returns it in raw bytes: DER encoded for DER
+// ECDSA and RSA keys, marshalled elliptic point for IEEE P1363 ECDSA keys.
+// Returns:
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
+func (k *KMS) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	kh, err := k.Get(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := k.client.GetKey(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get Key Vault key: %w", err)
+	}
+
+	return pubKeyBytes(kh.(*KeyHandle), &bundle.Key)
+}
+
+// CreateAndExportPubKeyBytes will create a key of type kt in Key Vault and export its public key in raw bytes and
+// returns it.
+// Returns:
+//   - Key Vault key ID of the new key.
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
+func (k *KMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	kh, jwk, err := k.createKey(uuid.New().String(), kt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pubKey, err := pubKeyBytes(kh, jwk)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kh.KeyID, pubKey, nil
+}
+
+// PubKeyBytesToHandle is not supported, public keys are verified by Key Vault with their key handle.
+func (k *KMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	return nil, errors.New("function PubKeyBytesToHandle is not supported by Key Vault")
+}
+
+// ImportPrivateKey is not supported, the private keys are generated by Key Vault.
+func (k *KMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	return "", nil, errors.New("function ImportPrivateKey is not supported by Key Vault")
+}
+
+// KeyIDFromKID returns the key ID, the key name followed by a slash and the key version, of the Key Vault key
+// identifier URL kid.
+func KeyIDFromKID(kid string) (string, error) {
+	u, err := url.Parse(kid)
+	if err != nil {
+		return "", fmt.Errorf("parse Key Vault key identifier: %w", err)
+	}
+
+	if !strings.HasPrefix(u.Path, keysPath) || len(strings.Split(u.Path[len(keysPath):], "/")) != 2 { // nolint: gomnd
+		return "", fmt.Errorf("invalid Key Vault key identifier %s", kid)
+	}
+
+	return u.Path[len(keysPath):], nil
+}
+
+func pubKeyBytes(kh *KeyHandle, jwk *JSONWebKey) ([]byte, error) {
+	switch jwk.KeyType {
+	case KeyTypeRSA:
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode RSA modulus: %w", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode RSA exponent: %w", err)
+		}
+
+		return x509.MarshalPKIXPublicKey(&rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		})
+	case KeyTypeEC:
+		return ecPubKeyBytes(kh, jwk)
+	default:
+		return nil, fmt.Errorf("key type %s of Key Vault is not supported", jwk.KeyType)
+	}
+}
+
+func ecPubKeyBytes(kh *KeyHandle, jwk *JSONWebKey) ([]byte, error) {
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("decode EC x coordinate: %w", err)
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("decode EC y coordinate: %w", err)
+	}
+
+	if kh.IEEEP1363() {
+		// uncompressed elliptic point, the coordinates being padded to the curve size by Key Vault.
+		return append(append([]byte{4}, x...), y...), nil // nolint: gomnd
+	}
+
+	var curve elliptic.Curve
+
+	switch jwk.Curve {
+	case CurveP256:
+		curve = elliptic.P256()
+	case CurveP384:
+		curve = elliptic.P384()
+	case CurveP521:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("curve %s has no DER encoding", jwk.Curve)
+	}
+
+	return x509.MarshalPKIXPublicKey(&ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	})
+}
@@ -0,0 +1,235 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azurekms_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/azurekms"
+	mockazurekms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/azurekms"
+)
+
+var _ kms.KeyManager = (*azurekms.KMS)(nil)
+
+func TestKMS_Create(t *testing.T) {
+	t.Run("create keys", func(t *testing.T) {
+		client := &mockazurekms.Client{}
+		k := azurekms.New(client, azurekms.WithKeyTags(map[string]string{"owner": "alice"}))
+
+		keyID, kh, err := k.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+		require.Equal(t, &azurekms.KeyHandle{KeyID: keyID, KeyType: kms.ECDSAP256TypeIEEEP1363}, kh)
+		require.Len(t, strings.Split(keyID, "/"), 2)
+
+		require.Len(t, client.CreateKeyParams, 1)
+		require.Equal(t, &azurekms.KeyCreateParameters{
+			KeyType: azurekms.KeyTypeEC,
+			Curve:   azurekms.CurveP256,
+			KeyOps:  []string{"sign", "verify"},
+			Tags:    map[string]string{azurekms.KeyTypeTag: kms.ECDSAP256IEEEP1363, "owner": "alice"},
+		}, client.CreateKeyParams[0])
+
+		_, kh, err = k.Create(kms.RSARS256Type)
+		require.NoError(t, err)
+		require.Equal(t, kms.RSARS256Type, kh.(*azurekms.KeyHandle).KeyType)
+		require.Equal(t, azurekms.KeyTypeRSA, client.CreateKeyParams[1].KeyType)
+		require.Equal(t, 2048, client.CreateKeyParams[1].KeySize)
+		require.Equal(t, []string{"sign", "verify", "wrapKey", "unwrapKey"}, client.CreateKeyParams[1].KeyOps)
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, _, err := azurekms.New(&mockazurekms.Client{}).Create(kms.ED25519Type)
+		require.EqualError(t, err, "key type ED25519 is not supported by Key Vault")
+	})
+
+	t.Run("Key Vault error", func(t *testing.T) {
+		k := azurekms.New(&mockazurekms.Client{CreateKeyErr: errors.New("forbidden")})
+
+		_, _, err := k.Create(kms.RSAPS256Type)
+		require.EqualError(t, err, "create Key Vault key: forbidden")
+	})
+}
+
+func TestKMS_Get(t *testing.T) {
+	client := &mockazurekms.Client{}
+
+	keyID, kh, err := azurekms.New(client).Create(kms.ECDSAP384TypeDER)
+	require.NoError(t, err)
+
+	t.Run("key created by the key manager", func(t *testing.T) {
+		handle, err := azurekms.New(client).Get(keyID)
+		require.NoError(t, err)
+		require.Equal(t, kh, handle)
+	})
+
+	t.Run("key created outside of the key manager", func(t *testing.T) {
+		_, err := client.CreateKey("external-ec", &azurekms.KeyCreateParameters{
+			KeyType: azurekms.KeyTypeEC,
+			Curve:   azurekms.CurveP521,
+		})
+		require.NoError(t, err)
+
+		handle, err := azurekms.New(client).Get("external-ec")
+		require.NoError(t, err)
+		require.Equal(t, &azurekms.KeyHandle{KeyID: "external-ec", KeyType: kms.ECDSAP521TypeIEEEP1363}, handle)
+
+		_, err = client.CreateKey("external-rsa", &azurekms.KeyCreateParameters{
+			KeyType: azurekms.KeyTypeRSA,
+			KeySize: 2048,
+		})
+		require.NoError(t, err)
+
+		handle, err = azurekms.New(client).Get("external-rsa")
+		require.NoError(t, err)
+		require.Equal(t, kms.RSAPS256Type, handle.(*azurekms.KeyHandle).KeyType)
+	})
+
+	t.Run("handles are cached", func(t *testing.T) {
+		k := azurekms.New(client)
+
+		_, err := k.Get(keyID)
+		require.NoError(t, err)
+
+		client.GetKeyErr = errors.New("throttled")
+		defer func() { client.GetKeyErr = nil }()
+
+		handle, err := k.Get(keyID)
+		require.NoError(t, err)
+		require.Equal(t, kh, handle)
+
+		_, err = azurekms.New(client).Get(keyID)
+		require.EqualError(t, err, "get Key Vault key: throttled")
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := azurekms.New(client).Get("unknown")
+		require.EqualError(t, err, "get Key Vault key: key not found")
+	})
+}
+
+func TestKMS_Rotate(t *testing.T) {
+	client := &mockazurekms.Client{}
+	k := azurekms.New(client)
+
+	keyID, _, err := k.Create(kms.ECDSAP256TypeDER)
+	require.NoError(t, err)
+
+	rotatedID, kh, err := k.Rotate(kms.ECDSAP256TypeIEEEP1363, keyID)
+	require.NoError(t, err)
+	require.NotEqual(t, keyID, rotatedID)
+	require.Equal(t, strings.Split(keyID, "/")[0], strings.Split(rotatedID, "/")[0])
+	require.Equal(t, kms.ECDSAP256TypeIEEEP1363, kh.(*azurekms.KeyHandle).KeyType)
+
+	handle, err := azurekms.New(client).Get(keyID)
+	require.NoError(t, err)
+	require.Equal(t, kms.ECDSAP256TypeDER, handle.(*azurekms.KeyHandle).KeyType)
+
+	_, _, err = k.Rotate(kms.ED25519Type, keyID)
+	require.EqualError(t, err, "key type ED25519 is not supported by Key Vault")
+}
+
+func TestKMS_ExportPubKeyBytes(t *testing.T) {
+	client := &mockazurekms.Client{}
+	k := azurekms.New(client)
+
+	t.Run("DER key", func(t *testing.T) {
+		keyID, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+		require.NoError(t, err)
+		require.IsType(t, &ecdsa.PublicKey{}, pubKey)
+
+		exported, err := k.ExportPubKeyBytes(keyID)
+		require.NoError(t, err)
+		require.Equal(t, pubKeyBytes, exported)
+	})
+
+	t.Run("IEEE P1363 key", func(t *testing.T) {
+		_, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.ECDSAP521TypeIEEEP1363)
+		require.NoError(t, err)
+
+		x, y := elliptic.Unmarshal(elliptic.P521(), pubKeyBytes)
+		require.NotNil(t, x)
+		require.NotNil(t, y)
+	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		_, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.RSAPS256Type)
+		require.NoError(t, err)
+
+		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+		require.NoError(t, err)
+		require.IsType(t, &rsa.PublicKey{}, pubKey)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := k.ExportPubKeyBytes("unknown")
+		require.EqualError(t, err, "get Key Vault key: key not found")
+
+		_, _, err = k.CreateAndExportPubKeyBytes(kms.AES256GCMType)
+		require.EqualError(t, err, "key type AES256GCM is not supported by Key Vault")
+
+		_, _, err = azurekms.New(&mockazurekms.Client{CreateKeyErr: errors.New("forbidden")}).
+			CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "create Key Vault key: forbidden")
+	})
+}
+
+func TestKMS_NotSupported(t *testing.T) {
+	k := azurekms.New(&mockazurekms.Client{})
+
+	_, err := k.PubKeyBytesToHandle([]byte("key"), kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, "function PubKeyBytesToHandle is not supported by Key Vault")
+
+	_, _, err = k.ImportPrivateKey(&ecdsa.PrivateKey{}, kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, "function ImportPrivateKey is not supported by Key Vault")
+}
+
+func TestKeyHandle(t *testing.T) {
+	for kt, algorithm := range map[kms.KeyType]string{
+		kms.ECDSAP256TypeDER:            "ES256",
+		kms.ECDSAP384TypeIEEEP1363:      "ES384",
+		kms.ECDSAP521TypeDER:            "ES512",
+		kms.ECDSASecp256k1TypeIEEEP1363: "ES256K",
+		kms.RSARS256Type:                "RS256",
+		kms.RSAPS256Type:                "PS256",
+	} {
+		a, err := (&azurekms.KeyHandle{KeyType: kt}).SigningAlgorithm()
+		require.NoError(t, err)
+		require.Equal(t, algorithm, a)
+	}
+
+	_, err := (&azurekms.KeyHandle{KeyType: kms.AES256GCMType}).SigningAlgorithm()
+	require.EqualError(t, err, "key type AES256GCM is not a signing key type supported by Key Vault")
+
+	require.True(t, (&azurekms.KeyHandle{KeyType: kms.ECDSAP256TypeIEEEP1363}).IEEEP1363())
+	require.False(t, (&azurekms.KeyHandle{KeyType: kms.ECDSAP256TypeDER}).IEEEP1363())
+	require.False(t, (&azurekms.KeyHandle{KeyType: kms.RSAPS256Type}).IEEEP1363())
+}
+
+func TestKeyIDFromKID(t *testing.T) {
+	keyID, err := azurekms.KeyIDFromKID("https://vault.vault.azure.net/keys/name/version")
+	require.NoError(t, err)
+	require.Equal(t, "name/version", keyID)
+
+	_, err = azurekms.KeyIDFromKID("https://vault.vault.azure.net/secrets/name")
+	require.EqualError(t, err, "invalid Key Vault key identifier https://vault.vault.azure.net/secrets/name")
+
+	_, err = azurekms.KeyIDFromKID(":invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse Key Vault key identifier")
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azurekms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIVersion is the version of the Key Vault REST API called by the REST client.
+const APIVersion = "7.3"
+
+// Client is the subset of the Key Vault keys API used by the key manager and the azurecrypto service. Keys are
+// referenced by their name, optionally followed by a slash and their version. It is implemented by the Key Vault REST
+// client returned by NewClient.
+type Client interface {
+	CreateKey(keyName string, params *KeyCreateParameters) (*KeyBundle, error)
+	GetKey(keyID string) (*KeyBundle, error)
+	Sign(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error)
+	Verify(keyID string, params *KeyVerifyParameters) (*KeyVerifyResult, error)
+	WrapKey(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error)
+	UnwrapKey(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error)
+}
+
+// KeyCreateParameters are the parameters of a key creation.
+type KeyCreateParameters struct {
+	KeyType string            `json:"kty"`
+	KeySize int               `json:"key_size,omitempty"`
+	Curve   string            `json:"crv,omitempty"`
+	KeyOps  []string          `json:"key_ops,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+}
+
+// JSONWebKey is the public part of a Key Vault key, its byte values are base64url encoded.
+type JSONWebKey struct {
+	// KID is the key identifier URL, https://{vault}/keys/{key-name}/{key-version}.
+	KID     string   `json:"kid,omitempty"`
+	KeyType string   `json:"kty,omitempty"`
+	KeyOps  []string `json:"key_ops,omitempty"`
+	N       string   `json:"n,omitempty"`
+	E       string   `json:"e,omitempty"`
+	Curve   string   `json:"crv,omitempty"`
+	X       string   `json:"x,omitempty"`
+	Y       string   `json:"y,omitempty"`
+}
+
+// KeyBundle is a Key Vault key with its tags.
+type KeyBundle struct {
+	Key  JSONWebKey        `json:"key"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// KeyOperationParameters are the parameters of the sign, wrap and unwrap operations, Value is base64url encoded.
+type KeyOperationParameters struct {
+	Algorithm string `json:"alg"`
+	Value     string `json:"value"`
+}
+
+// KeyOperationResult is the result of the sign, wrap and unwrap operations, Value is base64url encoded.
+type KeyOperationResult struct {
+	KID   string `json:"kid,omitempty"`
+	Value string `json:"value"`
+}
+
+// KeyVerifyParameters are the parameters of a signature verification, Digest and Signature are base64url encoded.
+type KeyVerifyParameters struct {
+	Algorithm string `json:"alg"`
+	Digest    string `json:"digest"`
+	Signature string `json:"value"`
+}
+
+// KeyVerifyResult is the result of a signature verification.
+type KeyVerifyResult struct {
+	Value bool `json:"value"`
+}
+
+// HTTPClient interface for the http client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TokenProvider returns the Azure AD access token of the Key Vault requests, typically by running the OAuth2 client
+// credentials flow for the https://vault.azure.net resource.
+type TokenProvider func() (string, error)
+
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// RESTClient is a Client calling the Key Vault REST API.
+type RESTClient struct {
+	vaultURL   string
+	httpClient HTTPClient
+	token      TokenProvider
+}
+
+// NewClient creates a Key Vault REST client for the vault vaultURL (https://{vault-name}.vault.azure.net).
+func NewClient(vaultURL string, httpClient HTTPClient, token TokenProvider) *RESTClient {
+	return &RESTClient{
+		vaultURL:   strings.TrimSuffix(vaultURL, "/"),
+		httpClient: httpClient,
+		token:      token,
+	}
+}
+
+// CreateKey creates a new key, or a new version of the key if it already exists.
+func (c *RESTClient) CreateKey(keyName string, params *KeyCreateParameters) (*KeyBundle, error) {
+	bundle := &KeyBundle{}
+
+	return bundle, c.do(http.MethodPost, keyName+"/create", params, bundle)
+}
+
+// GetKey returns the public part of the key, its latest version if keyID has no version.
+func (c *RESTClient) GetKey(keyID string) (*KeyBundle, error) {
+	bundle := &KeyBundle{}
+
+	return bundle, c.do(http.MethodGet, keyID, nil, bundle)
+}
+
+// Sign signs a digest with the key.
+func (c *RESTClient) Sign(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error) {
+	result := &KeyOperationResult{}
+
+	return result, c.do(http.MethodPost, keyID+"/sign", params, result)
+}
+
+// Verify verifies the signature of a digest with the key.
+func (c *RESTClient) Verify(keyID string, params *KeyVerifyParameters) (*KeyVerifyResult, error) {
+	result := &KeyVerifyResult{}
+
+	return result, c.do(http.MethodPost, keyID+"/verify", params, result)
+}
+
+// WrapKey encrypts a symmetric key with the key.
+func (c *RESTClient) WrapKey(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error) {
+	result := &KeyOperationResult{}
+
+	return result, c.do(http.MethodPost, keyID+"/wrapkey", params, result)
+}
+
+// UnwrapKey decrypts a symmetric key wrapped with the key.
+func (c *RESTClient) UnwrapKey(keyID string, params *KeyOperationParameters) (*KeyOperationResult, error) {
+	result := &KeyOperationResult{}
+
+	return result, c.do(http.MethodPost, keyID+"/unwrapkey", params, result)
+}
+
+func (c *RESTClient) do(method, path string, reqBody, respBody interface{}) error {
+	var body io.Reader
+
+	if reqBody != nil {
+		reqBytes, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("marshal Key Vault request: %w", err)
+		}
+
+		body = bytes.NewReader(reqBytes)
+	}
+
+	destination := fmt.Sprintf("%s/keys/%s?api-version=%s", c.vaultURL, path, url.QueryEscape(APIVersion))
+
+	req, err := http.NewRequest(method, destination, body)
+	if err != nil {
+		return fmt.Errorf("build Key Vault request: %w", err)
+	}
+
+	token, err := c.token()
+	if err != nil {
+		return fmt.Errorf("get Key Vault access token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send Key Vault request %s: %w", destination, err)
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Errorf("failed to close Key Vault response body: %s", errClose)
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errResp := &errorResponse{}
+
+		if err = json.NewDecoder(resp.Body).Decode(errResp); err != nil {
+			return fmt.Errorf("request to Key Vault %s failed with status %d", destination, resp.StatusCode)
+		}
+
+		return fmt.Errorf("request to Key Vault %s failed with status %d: %s %s",
+			destination, resp.StatusCode, errResp.Error.Code, errResp.Error.Message)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(respBody); err != nil {
+		return fmt.Errorf("unmarshal Key Vault response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azurekms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTClient(t *testing.T) {
+	var (
+		requests []*http.Request
+		bodies   []map[string]interface{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		body := map[string]interface{}{}
+		if r.Body != nil && r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+
+		bodies = append(bodies, body)
+
+		switch r.URL.Path {
+		case "/keys/name/create", "/keys/name/version":
+			fmt.Fprint(w, `{"key":{"kid":"https://vault/keys/name/version","kty":"EC","crv":"P-256"},`+
+				`"tags":{"aries-key-type":"ECDSAP256IEEEP1363"}}`)
+		case "/keys/name/version/verify":
+			fmt.Fprint(w, `{"value":true}`)
+		case "/keys/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"KeyNotFound","message":"key not found"}}`)
+		case "/keys/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprint(w, `{"kid":"https://vault/keys/name/version","value":"dmFsdWU"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.Client(), func() (string, error) { return "token", nil })
+
+	t.Run("success", func(t *testing.T) {
+		bundle, err := client.CreateKey("name", &KeyCreateParameters{KeyType: KeyTypeEC, Curve: CurveP256})
+		require.NoError(t, err)
+		require.Equal(t, "https://vault/keys/name/version", bundle.Key.KID)
+		require.Equal(t, map[string]string{KeyTypeTag: "ECDSAP256IEEEP1363"}, bundle.Tags)
+		require.Equal(t, map[string]interface{}{"kty": "EC", "crv": "P-256"}, bodies[0])
+
+		bundle, err = client.GetKey("name/version")
+		require.NoError(t, err)
+		require.Equal(t, CurveP256, bundle.Key.Curve)
+
+		params := &KeyOperationParameters{Algorithm: "ES256", Value: "ZGlnZXN0"}
+
+		result, err := client.Sign("name/version", params)
+		require.NoError(t, err)
+		require.Equal(t, "dmFsdWU", result.Value)
+		require.Equal(t, map[string]interface{}{"alg": "ES256", "value": "ZGlnZXN0"}, bodies[2])
+
+		verifyResult, err := client.Verify("name/version", &KeyVerifyParameters{Algorithm: "ES256"})
+		require.NoError(t, err)
+		require.True(t, verifyResult.Value)
+
+		_, err = client.WrapKey("name/version", params)
+		require.NoError(t, err)
+
+		_, err = client.UnwrapKey("name/version", params)
+		require.NoError(t, err)
+
+		var paths []string
+
+		for _, r := range requests {
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.Equal(t, APIVersion, r.URL.Query().Get("api-version"))
+
+			paths = append(paths, r.Method+" "+r.URL.Path)
+		}
+
+		require.Equal(t, []string{
+			"POST /keys/name/create",
+			"GET /keys/name/version",
+			"POST /keys/name/version/sign",
+			"POST /keys/name/version/verify",
+			"POST /keys/name/version/wrapkey",
+			"POST /keys/name/version/unwrapkey",
+		}, paths)
+	})
+
+	t.Run("Key Vault error", func(t *testing.T) {
+		_, err := client.GetKey("missing")
+		require.EqualError(t, err, fmt.Sprintf("request to Key Vault %s/keys/missing?api-version=%s failed with "+
+			"status 404: KeyNotFound key not found", server.URL, APIVersion))
+
+		_, err = client.GetKey("broken")
+		require.EqualError(t, err, fmt.Sprintf("request to Key Vault %s/keys/broken?api-version=%s failed with "+
+			"status 500", server.URL, APIVersion))
+	})
+
+	t.Run("access token error", func(t *testing.T) {
+		c := NewClient(server.URL, server.Client(), func() (string, error) { return "", errors.New("expired") })
+
+		_, err := c.GetKey("name/version")
+		require.EqualError(t, err, "get Key Vault access token: expired")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		c := NewClient("http://localhost:0", server.Client(), func() (string, error) { return "token", nil })
+
+		_, err := c.GetKey("name/version")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send Key Vault request")
+	})
+}
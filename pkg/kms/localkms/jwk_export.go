@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/subtle"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ErrPrivateKeyExportNotAllowed is returned by ExportPrivateKeyJWK when the LocalKMS was not created with the
+// WithPrivateKeyExport option.
+var ErrPrivateKeyExportNotAllowed = errors.New("private key export is not allowed")
+
+// ExportPubKeyJWK will fetch a key referenced by keyID then gets its public key as a JWK of key type kt, with keyID
+// as the JWK kid. The key must be an asymmetric key.
+// Returns:
+//  - public key JWK
+//  - error if it fails to export the public key
+func (l *LocalKMS) ExportPubKeyJWK(keyID string, kt kms.KeyType) (*jwk.JWK, error) {
+	pubKeyBytes, err := l.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("exportPubKeyJWK: %w", err)
+	}
+
+	j, err := jwkkid.BuildJWK(pubKeyBytes, kt)
+	if err != nil {
+		return nil, fmt.Errorf("exportPubKeyJWK: %w", err)
+	}
+
+	j.KeyID = keyID
+
+	return j, nil
+}
+
+// ExportPrivateKeyJWK will fetch a key referenced by keyID then gets its private key as a JWK, with keyID as the JWK
+// kid. Only the signing keys (ECDSA, Ed25519 and BLS12381G2) can be exported, and only if the LocalKMS was created
+// with the WithPrivateKeyExport option. The caller is responsible for protecting the returned JWK.
+// Returns:
+//  - private key JWK
+//  - error if it fails to export the private key or private key export is not allowed
+func (l *LocalKMS) ExportPrivateKeyJWK(keyID string) (*jwk.JWK, error) {
+	if !l.privateKeyExport {
+		return nil, fmt.Errorf("exportPrivateKeyJWK: %w", ErrPrivateKeyExportNotAllowed)
+	}
+
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("exportPrivateKeyJWK: failed to get keyset handle: %w", err)
+	}
+
+	privKey, err := primaryPrivateKey(insecurecleartextkeyset.KeysetMaterial(kh))
+	if err != nil {
+		return nil, fmt.Errorf("exportPrivateKeyJWK: %w", err)
+	}
+
+	j, err := jwksupport.JWKFromKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("exportPrivateKeyJWK: failed to build JWK: %w", err)
+	}
+
+	j.KeyID = keyID
+
+	return j, nil
+}
+
+// ImportJWK will import the private key of j into the KMS storage then returns the new key id and the newly persisted
+// Handle, the key type being the one of the JWK (ECDSA keys are imported as IEEE-P1363 keys). j must be a private
+// signing key JWK (ECDSA, Ed25519 or BLS12381G2).
+// 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
+// then an error is returned.
+// Returns:
+//  - keyID of the handle
+//  - handle instance (to private key)
+//  - error if import failure (key is public, unsupported key type or storing key failed)
+func (l *LocalKMS) ImportJWK(j *jwk.JWK, opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	kt, err := j.KeyType()
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	if j.IsPublic() {
+		return "", nil, errors.New("importJWK: JWK is not a private key")
+	}
+
+	kid, kh, err := l.ImportPrivateKey(j.Key, kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	return kid, kh, nil
+}
+
+// primaryPrivateKey returns the private key of the primary key of ks.
+func primaryPrivateKey(ks *tinkpb.Keyset) (interface{}, error) {
+	for _, key := range ks.Key {
+		if key.KeyId != ks.PrimaryKeyId || key.Status != tinkpb.KeyStatusType_ENABLED {
+			continue
+		}
+
+		switch key.KeyData.TypeUrl {
+		case ecdsaSignerTypeURL:
+			return ecdsaPrivateKey(key.KeyData.Value)
+		case ed25519SignerTypeURL:
+			privKeyProto := &ed25519pb.Ed25519PrivateKey{}
+
+			err := proto.Unmarshal(key.KeyData.Value, privKeyProto)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal ed25519 private key: %w", err)
+			}
+
+			return ed25519.NewKeyFromSeed(privKeyProto.KeyValue), nil
+		case bbsSignerKeyTypeURL:
+			privKeyProto := &bbspb.BBSPrivateKey{}
+
+			err := proto.Unmarshal(key.KeyData.Value, privKeyProto)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal BBS+ private key: %w", err)
+			}
+
+			return bbs12381g2pub.UnmarshalPrivateKey(privKeyProto.KeyValue)
+		default:
+			return nil, fmt.Errorf("private key of type '%s' can't be exported as JWK", key.KeyData.TypeUrl)
+		}
+	}
+
+	return nil, errors.New("primary key not found")
+}
+
+func ecdsaPrivateKey(marshalledKey []byte) (*ecdsa.PrivateKey, error) {
+	privKeyProto := &ecdsapb.EcdsaPrivateKey{}
+
+	err := proto.Unmarshal(marshalledKey, privKeyProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ecdsa private key: %w", err)
+	}
+
+	curve := subtle.GetCurve(commonpb.EllipticCurveType_name[int32(privKeyProto.PublicKey.Params.Curve)])
+	if curve == nil {
+		return nil, fmt.Errorf("undefined curve")
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(privKeyProto.PublicKey.X),
+			Y:     new(big.Int).SetBytes(privKeyProto.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(privKeyProto.KeyValue),
+	}, nil
+}
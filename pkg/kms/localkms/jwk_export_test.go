@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestLocalKMS_ExportPubKeyJWK(t *testing.T) {
+	k := createKMS(t)
+
+	for _, kt := range []kms.KeyType{
+		kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeDER, kms.BLS12381G2Type,
+		kms.NISTP256ECDHKWType, kms.X25519ECDHKWType,
+	} {
+		t.Run(string(kt), func(t *testing.T) {
+			kid, _, err := k.Create(kt)
+			require.NoError(t, err)
+
+			j, err := k.ExportPubKeyJWK(kid, kt)
+			require.NoError(t, err)
+			require.Equal(t, kid, j.KeyID)
+
+			jwkBytes, err := json.Marshal(j)
+			require.NoError(t, err)
+
+			parsed := &jwk.JWK{}
+			require.NoError(t, json.Unmarshal(jwkBytes, parsed))
+
+			tp, err := parsed.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+
+			if kt != kms.BLS12381G2Type { // BBS+ key IDs aren't built from the BBS+ JWK members.
+				require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp))
+			}
+		})
+	}
+
+	t.Run("export unknown key", func(t *testing.T) {
+		_, err := k.ExportPubKeyJWK("unknown", kms.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportPubKeyJWK: exportPubKeyBytes: failed to get keyset handle")
+	})
+
+	t.Run("key type mismatch", func(t *testing.T) {
+		kid, _, err := k.Create(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = k.ExportPubKeyJWK(kid, kms.ECDSAP256TypeDER)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportPubKeyJWK: buildJWK")
+	})
+}
+
+func TestLocalKMS_ExportImportPrivateKeyJWK(t *testing.T) {
+	source, err := New(testMasterKeyURI, mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(),
+		&noop.NoLock{}), WithPrivateKeyExport())
+	require.NoError(t, err)
+
+	for _, kt := range []kms.KeyType{
+		kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363, kms.BLS12381G2Type,
+	} {
+		t.Run(string(kt), func(t *testing.T) {
+			kid, pubKey, err := source.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			j, err := source.ExportPrivateKeyJWK(kid)
+			require.NoError(t, err)
+			require.Equal(t, kid, j.KeyID)
+			require.False(t, j.IsPublic())
+
+			jwkBytes, err := json.Marshal(j)
+			require.NoError(t, err)
+
+			parsed := &jwk.JWK{}
+			require.NoError(t, json.Unmarshal(jwkBytes, parsed))
+
+			target := createKMS(t)
+
+			importedKID, kh, err := target.ImportJWK(parsed)
+			require.NoError(t, err)
+			require.NotNil(t, kh)
+
+			importedPubKey, err := target.ExportPubKeyBytes(importedKID)
+			require.NoError(t, err)
+			require.Equal(t, pubKey, importedPubKey)
+		})
+	}
+
+	t.Run("export not allowed", func(t *testing.T) {
+		k := createKMS(t)
+
+		kid, _, err := k.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = k.ExportPrivateKeyJWK(kid)
+		require.True(t, errors.Is(err, ErrPrivateKeyExportNotAllowed))
+	})
+
+	t.Run("export unknown key", func(t *testing.T) {
+		_, err := source.ExportPrivateKeyJWK("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportPrivateKeyJWK: failed to get keyset handle")
+	})
+
+	t.Run("export unsupported key type", func(t *testing.T) {
+		kid, _, err := source.Create(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = source.ExportPrivateKeyJWK(kid)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't be exported as JWK")
+	})
+
+	t.Run("import public key", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(pubKey)
+		require.NoError(t, err)
+
+		_, _, err = source.ImportJWK(j)
+		require.EqualError(t, err, "importJWK: JWK is not a private key")
+	})
+
+	t.Run("import unknown key type", func(t *testing.T) {
+		_, _, err := source.ImportJWK(&jwk.JWK{})
+		require.EqualError(t, err, "importJWK: no keytype recognized for jwk")
+	})
+
+	t.Run("import with key ID", func(t *testing.T) {
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(privKey)
+		require.NoError(t, err)
+
+		kid, _, err := source.ImportJWK(j, kms.WithKeyID("imported"))
+		require.NoError(t, err)
+		require.Equal(t, "imported", kid)
+	})
+}
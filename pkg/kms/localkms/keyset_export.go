@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ExportKeySet fetches the keyset referenced by keyID and returns it serialized in cleartext, private keys included,
+// to be imported in another LocalKMS with ImportKeySet. The caller is responsible for protecting the returned bytes.
+// Returns:
+//  - marshalled keyset []byte
+//  - error if it fails to export the keyset
+func (l *LocalKMS) ExportKeySet(keyID string) ([]byte, error) {
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("exportKeySet: failed to get keyset handle: %w", err)
+	}
+
+	ks, err := proto.Marshal(insecurecleartextkeyset.KeysetMaterial(kh))
+	if err != nil {
+		return nil, fmt.Errorf("exportKeySet: failed to marshal keyset: %w", err)
+	}
+
+	return ks, nil
+}
+
+// ImportKeySet imports a keyset exported with ExportKeySet, kms.WithKeyID option can be used to keep the key ID
+// it had in the exporting LocalKMS.
+// Returns:
+//  - keyID of the imported keyset
+//  - handle instance (to private key)
+//  - error if failure
+func (l *LocalKMS) ImportKeySet(marshalledKeySet []byte, opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	ks := &tinkpb.Keyset{}
+
+	err := proto.Unmarshal(marshalledKeySet, ks)
+	if err != nil {
+		return "", nil, fmt.Errorf("importKeySet: failed to unmarshal keyset: %w", err)
+	}
+
+	if len(ks.Key) == 0 {
+		return "", nil, fmt.Errorf("importKeySet: empty keyset")
+	}
+
+	return l.importKeySet(ks, opts...)
+}
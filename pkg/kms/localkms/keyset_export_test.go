@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestLocalKMS_ExportImportKeySet(t *testing.T) {
+	source := createKMS(t)
+
+	for _, kt := range []kms.KeyType{kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.BLS12381G2Type} {
+		t.Run(string(kt), func(t *testing.T) {
+			kid, pubKey, err := source.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			ks, err := source.ExportKeySet(kid)
+			require.NoError(t, err)
+			require.NotEmpty(t, ks)
+
+			target := createKMS(t)
+
+			importedKID, kh, err := target.ImportKeySet(ks, kms.WithKeyID(kid))
+			require.NoError(t, err)
+			require.NotNil(t, kh)
+			require.Equal(t, kid, importedKID)
+
+			importedPubKey, err := target.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+			require.Equal(t, pubKey, importedPubKey)
+		})
+	}
+
+	t.Run("export unknown key", func(t *testing.T) {
+		_, err := source.ExportKeySet("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportKeySet: failed to get keyset handle")
+	})
+
+	t.Run("import invalid keyset", func(t *testing.T) {
+		_, _, err := source.ImportKeySet([]byte("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "importKeySet: failed to unmarshal keyset")
+
+		_, _, err = source.ImportKeySet(nil)
+		require.EqualError(t, err, "importKeySet: empty keyset")
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -55,6 +56,18 @@ type LocalKMS struct {
 	primaryKeyURI     string
 	store             storage.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+	privateKeyExport  bool
+}
+
+// Opt is a LocalKMS option.
+type Opt func(l *LocalKMS)
+
+// WithPrivateKeyExport option allows the private keys to be exported as JWKs with ExportPrivateKeyJWK. Private keys
+// can't be exported by default.
+func WithPrivateKeyExport() Opt {
+	return func(l *LocalKMS) {
+		l.privateKeyExport = true
+	}
 }
 
 func newKeyIDWrapperStore(provider storage.Provider, storePrefix string) (storage.Store, error) {
@@ -67,12 +80,12 @@ func newKeyIDWrapperStore(provider storage.Provider, storePrefix string) (storag
 }
 
 // New will create a new (local) KMS service.
-func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
-	return NewWithPrefix(primaryKeyURI, p, "")
+func New(primaryKeyURI string, p kms.Provider, opts ...Opt) (*LocalKMS, error) {
+	return NewWithPrefix(primaryKeyURI, p, "", opts...)
 }
 
 // NewWithPrefix will create a new (local) KMS service using a store name prefixed with storePrefix.
-func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string) (*LocalKMS, error) {
+func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string, opts ...Opt) (*LocalKMS, error) {
 	store, err := newKeyIDWrapperStore(p.StorageProvider(), storePrefix)
 	if err != nil {
 		return nil, fmt.Errorf("new: failed to ceate local kms: %w", err)
@@ -88,13 +101,18 @@ func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string) (*L
 	// create a KMSEnvelopeAEAD instance to wrap/unwrap keys managed by LocalKMS
 	keyEnvelopeAEAD := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw)
 
-	return &LocalKMS{
-			store:             store,
-			secretLock:        secretLock,
-			primaryKeyURI:     primaryKeyURI,
-			primaryKeyEnvAEAD: keyEnvelopeAEAD,
-		},
-		nil
+	l := &LocalKMS{
+		store:             store,
+		secretLock:        secretLock,
+		primaryKeyURI:     primaryKeyURI,
+		primaryKeyEnvAEAD: keyEnvelopeAEAD,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l, nil
 }
 
 // Create a new key/keyset/key handle for the type kt
@@ -202,6 +220,8 @@ func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA384, commonpb.EllipticCurveType_NIST_P384), nil
 	case kms.ECDSAP521TypeIEEEP1363:
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA512, commonpb.EllipticCurveType_NIST_P521), nil
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		return secp256k1.IEEEP1363KeyTemplate(), nil
 	case kms.ED25519Type:
 		return signature.ED25519KeyWithoutPrefixTemplate(), nil
 	case kms.HMACSHA256Tag256Type:
@@ -21,7 +21,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/signature"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
 
@@ -260,6 +262,7 @@ func TestLocalKMS_Success(t *testing.T) {
 		kms.ECDSAP256TypeIEEEP1363,
 		kms.ECDSAP384TypeIEEEP1363,
 		kms.ECDSAP521TypeIEEEP1363,
+		kms.ECDSASecp256k1TypeIEEEP1363,
 		kms.ED25519Type,
 		kms.NISTP256ECDHKWType,
 		kms.NISTP384ECDHKWType,
@@ -391,6 +394,11 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 			keyType: kms.ECDSAP521TypeIEEEP1363,
 			curve:   elliptic.P521(),
 		},
+		{
+			tcName:  "import private key using ECDSASecp256k1TypeIEEEP1363 type",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+			curve:   btcec.S256(),
+		},
 		{
 			tcName:  "import private key using ED25519Type type",
 			keyType: kms.ED25519Type,
@@ -490,7 +498,8 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 				pubKey, err := x509.MarshalPKIXPublicKey(privKey.Public())
 				require.NoError(t, err)
 				require.EqualValues(t, pubKey, pubKeyBytes)
-			case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
+			case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+				kms.ECDSASecp256k1TypeIEEEP1363:
 				pubKey := elliptic.Marshal(tt.curve, privKey.X, privKey.Y)
 				require.EqualValues(t, pubKey, pubKeyBytes)
 			}
@@ -498,6 +507,49 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 	}
 }
 
+func TestLocalKMS_Secp256k1(t *testing.T) {
+	kmsService, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	kid, pubKeyBytes, err := kmsService.CreateAndExportPubKeyBytes(kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	// the KID is the thumbprint of the secp256k1 JWK
+	expectedKID, err := CreateKID(pubKeyBytes, kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.Equal(t, expectedKID, kid)
+
+	kh, err := kmsService.Get(kid)
+	require.NoError(t, err)
+
+	s, err := signature.NewSigner(kh.(*keyset.Handle))
+	require.NoError(t, err)
+
+	msg := []byte("lorem ipsum")
+
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	require.NoError(t, err)
+
+	// the public key handles are created from both the uncompressed and the compressed points
+	for _, b := range [][]byte{pubKeyBytes, pubKey.SerializeCompressed()} {
+		pubKH, err := kmsService.PubKeyBytesToHandle(b, kms.ECDSASecp256k1TypeIEEEP1363)
+		require.NoError(t, err)
+
+		v, err := signature.NewVerifier(pubKH.(*keyset.Handle))
+		require.NoError(t, err)
+		require.NoError(t, v.Verify(sig, msg))
+	}
+
+	_, err = kmsService.PubKeyBytesToHandle([]byte("invalid"), kms.ECDSASecp256k1TypeIEEEP1363)
+	require.Error(t, err)
+}
+
 func TestLocalKMS_getKeyTemplate(t *testing.T) {
 	keyTemplate, err := getKeyTemplate(kms.HMACSHA256Tag256Type)
 	require.NoError(t, err)
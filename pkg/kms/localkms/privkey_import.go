@@ -25,9 +25,10 @@ import (
 )
 
 const (
-	ecdsaSignerTypeURL   = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
-	ed25519SignerTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
-	bbsSignerKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+	ecdsaSignerTypeURL     = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
+	ed25519SignerTypeURL   = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
+	bbsSignerKeyTypeURL    = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+	secp256k1SignerTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
 )
 
 func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
@@ -39,6 +40,8 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
 	}
 
+	typeURL := ecdsaSignerTypeURL
+
 	switch kt {
 	case kms.ECDSAP256TypeDER:
 		params = &ecdsapb.EcdsaParams{
@@ -76,6 +79,9 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 			Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
 			HashType: commonpb.HashType_SHA512,
 		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		typeURL = secp256k1SignerTypeURL
+		params = secp256k1Params()
 	default:
 		return "", nil, fmt.Errorf("import private EC key failed: invalid ECDSA key type")
 	}
@@ -85,7 +91,7 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
 	}
 
-	ks := newKeySet(ecdsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+	ks := newKeySet(typeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
 
 	return l.importKeySet(ks, opts...)
 }
@@ -159,6 +165,16 @@ func (l *LocalKMS) importBBSKey(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyTyp
 	return l.importKeySet(ks, opts...)
 }
 
+// secp256k1Params returns the params of the secp256k1 keys, the curve being implied by their type URL as Tink doesn't
+// support secp256k1.
+func secp256k1Params() *ecdsapb.EcdsaParams {
+	return &ecdsapb.EcdsaParams{
+		Curve:    commonpb.EllipticCurveType_UNKNOWN_CURVE,
+		Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		HashType: commonpb.HashType_SHA256,
+	}
+}
+
 func validECPrivateKey(privateKey *ecdsa.PrivateKey) error {
 	if privateKey == nil {
 		return fmt.Errorf("private key is nil")
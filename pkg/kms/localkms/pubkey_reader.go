@@ -12,6 +12,7 @@ import (
 	"crypto/x509"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
@@ -142,6 +143,13 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType) ([]byte, stri
 		if err != nil {
 			return nil, "", err
 		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		tURL = secp256k1VerifierTypeURL
+
+		keyValue, err = getMarshalledSecp256k1Key(pubKey)
+		if err != nil {
+			return nil, "", err
+		}
 	case kms.ED25519Type:
 		tURL = ed25519VerifierTypeURL
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
@@ -220,6 +228,16 @@ func getMarshalledECDSAIEEEP1363Key(marshaledPubKey []byte, curveName string, c
 	return getMarshalledECDSAKey(&ecdsa.PublicKey{X: x, Y: y, Curve: curve}, params)
 }
 
+// getMarshalledSecp256k1Key parses the compressed or uncompressed secp256k1 point marshaledPubKey.
+func getMarshalledSecp256k1Key(marshaledPubKey []byte) ([]byte, error) {
+	pubKey, err := btcec.ParsePubKey(marshaledPubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("public key reader: %w", err)
+	}
+
+	return getMarshalledECDSAKey(pubKey.ToECDSA(), secp256k1Params())
+}
+
 func getMarshalledECDSAKey(ecPubKey *ecdsa.PublicKey, params *ecdsapb.EcdsaParams) ([]byte, error) {
 	return proto.Marshal(newProtoECDSAPublicKey(ecPubKey, params))
 }
@@ -14,6 +14,7 @@ import (
 	"io"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
@@ -31,6 +32,7 @@ const (
 	nistPECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPublicKey"
 	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
 	bbsVerifierKeyTypeURL        = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
+	secp256k1VerifierTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
 )
 
 // PubKeyWriter will write the raw bytes of a Tink KeySet's primary public key
@@ -68,7 +70,7 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 	for _, key := range ks {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
-			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL:
+			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, secp256k1VerifierTypeURL:
 				created, err = writePubKey(w, key)
 				if err != nil {
 					return err
@@ -101,7 +103,7 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 	var marshaledRawPubKey []byte
 
 	// TODO add other key types than the ones below and other than nistPECDHKWPublicKeyTypeURL and
-	// TODO x25519ECDHKWPublicKeyTypeURL(eg: secp256k1 when introduced in KMS).
+	// TODO x25519ECDHKWPublicKeyTypeURL.
 	switch key.KeyData.TypeUrl {
 	case ecdsaVerifierTypeURL:
 		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
@@ -115,6 +117,20 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 		if err != nil {
 			return false, err
 		}
+	case secp256k1VerifierTypeURL:
+		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, err
+		}
+
+		// uncompressed secp256k1 point, the encoding of the IEEE P1363 ECDSA keys.
+		marshaledRawPubKey = (&btcec.PublicKey{
+			Curve: btcec.S256(),
+			X:     new(big.Int).SetBytes(pubKeyProto.X),
+			Y:     new(big.Int).SetBytes(pubKeyProto.Y),
+		}).SerializeUncompressed()
 	case ed25519VerifierTypeURL:
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
 
@@ -0,0 +1,397 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHealthCheckPath is the path of the health check endpoint of the key servers.
+	DefaultHealthCheckPath = "/healthcheck"
+
+	defaultFailureThreshold    = 3
+	defaultOpenTimeout         = 30 * time.Second
+	defaultHealthCheckInterval = 10 * time.Second
+)
+
+// ErrNoEndpointAvailable is returned when the circuits of all the key server endpoints a request can be sent to are
+// open.
+var ErrNoEndpointAvailable = errors.New("no webkms endpoint available")
+
+// keyUseOperations are the operations using an existing key, which can be served by any replica of the key store. The
+// other requests (eg. creating or importing keys) are only sent to the primary endpoint.
+var keyUseOperations = map[string]struct{}{ // nolint: gochecknoglobals
+	"sign": {}, "verify": {}, "computemac": {}, "verifymac": {}, "signmulti": {}, "verifymulti": {},
+	"deriveproof": {}, "verifyproof": {}, "encrypt": {}, "decrypt": {}, "wrap": {}, "unwrap": {}, "batch": {},
+	"easy": {}, "easyopen": {}, "sealopen": {},
+}
+
+// FailoverOpt configures the FailoverClient.
+type FailoverOpt func(c *FailoverClient)
+
+// WithFailureThreshold sets the number of consecutive failures opening the circuit of an endpoint, 3 by default.
+func WithFailureThreshold(failures int) FailoverOpt {
+	return func(c *FailoverClient) {
+		c.failureThreshold = failures
+	}
+}
+
+// WithOpenTimeout sets the time the circuit of a failing endpoint stays open before a trial request is sent to the
+// endpoint, 30s by default.
+func WithOpenTimeout(timeout time.Duration) FailoverOpt {
+	return func(c *FailoverClient) {
+		c.openTimeout = timeout
+	}
+}
+
+// WithHealthCheck sets the path of the health check endpoint of the key servers (DefaultHealthCheckPath by default)
+// and the interval of the health checks started with Start (10s by default).
+func WithHealthCheck(healthPath string, interval time.Duration) FailoverOpt {
+	return func(c *FailoverClient) {
+		c.healthPath = healthPath
+		c.healthInterval = interval
+	}
+}
+
+// FailoverClient is an HTTPClient sending the requests of the remote KMS and crypto to a primary key server endpoint,
+// failing over to its replicas when the primary is unavailable, so that an outage of the key server doesn't block the
+// agent. The endpoints are URL prefixes (eg. the URLs of the key servers or of a replicated keystore on each server):
+// a request to a URL starting with the primary endpoint is sent to a replica by replacing the prefix.
+//
+// Only the requests using an existing key (eg. sign, verify, wrap and unwrap) and the GET requests fail over to the
+// replicas, the requests managing the keys are sent to the primary only. A circuit breaker stops sending requests to
+// an endpoint after consecutive failures (transport errors or 502, 503 and 504 responses), until the open timeout
+// elapsed and a trial request succeeds, or a health check started with Start succeeds.
+type FailoverClient struct {
+	httpClient       HTTPClient
+	endpoints        []*endpoint
+	failureThreshold int
+	openTimeout      time.Duration
+	healthPath       string
+	healthInterval   time.Duration
+	mu               sync.Mutex
+	started          bool
+	stopped          bool
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+// endpoint is a key server endpoint guarded by a circuit breaker.
+type endpoint struct {
+	url       string
+	healthURL string
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// NewFailoverClient returns a client sending the requests with the HTTP client to the primary endpoint, failing over
+// to the replicas in the given order.
+func NewFailoverClient(httpClient HTTPClient, primary string, replicas []string,
+	opts ...FailoverOpt) (*FailoverClient, error) {
+	c := &FailoverClient{
+		httpClient:       httpClient,
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		healthPath:       DefaultHealthCheckPath,
+		healthInterval:   defaultHealthCheckInterval,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for _, endpointURL := range append([]string{primary}, replicas...) {
+		u, err := url.Parse(endpointURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid webkms endpoint %q", endpointURL)
+		}
+
+		c.endpoints = append(c.endpoints, &endpoint{
+			url:       strings.TrimSuffix(endpointURL, "/"),
+			healthURL: u.Scheme + "://" + u.Host + c.healthPath,
+		})
+	}
+
+	return c, nil
+}
+
+// Do sends the request to the first available endpoint.
+func (c *FailoverClient) Do(req *http.Request) (*http.Response, error) {
+	primary := c.endpoints[0]
+
+	if !strings.HasPrefix(req.URL.String(), primary.url) {
+		return c.httpClient.Do(req)
+	}
+
+	endpoints := c.endpoints[:1]
+	if isKeyUse(req) {
+		endpoints = c.endpoints
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lastResp *http.Response
+		lastErr  error
+	)
+
+	for _, e := range endpoints {
+		if !e.allow(time.Now()) {
+			continue
+		}
+
+		r, err := rewrite(req, primary.url, e.url, body)
+		if err != nil {
+			closeBody(lastResp)
+
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(r)
+		if err == nil && !isUnavailable(resp.StatusCode) {
+			e.success()
+
+			closeBody(lastResp)
+
+			return resp, nil
+		}
+
+		e.failure(time.Now(), c.failureThreshold, c.openTimeout)
+
+		closeBody(lastResp)
+		lastResp, lastErr = resp, err
+
+		if err != nil {
+			logger.Warnf("webkms endpoint %s failed: %s", e.url, err)
+		} else {
+			logger.Warnf("webkms endpoint %s unavailable: status %d", e.url, resp.StatusCode)
+		}
+	}
+
+	switch {
+	case lastResp != nil:
+		return lastResp, nil
+	case lastErr != nil:
+		return nil, lastErr
+	default:
+		return nil, ErrNoEndpointAvailable
+	}
+}
+
+// Start starts checking the health of the endpoints in the background, opening the circuits of the unhealthy
+// endpoints and closing the circuits of the healthy ones.
+func (c *FailoverClient) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started || c.stopped {
+		return
+	}
+
+	c.started = true
+
+	go c.run()
+}
+
+// Stop stops the health checks.
+func (c *FailoverClient) Stop() {
+	c.mu.Lock()
+
+	if !c.stopped {
+		c.stopped = true
+		close(c.stop)
+	}
+
+	started := c.started
+
+	c.mu.Unlock()
+
+	if started {
+		<-c.done
+	}
+}
+
+func (c *FailoverClient) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkHealth()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *FailoverClient) checkHealth() {
+	for _, e := range c.endpoints {
+		if err := c.probe(e); err != nil {
+			logger.Warnf("webkms endpoint %s unhealthy: %s", e.url, err)
+
+			e.trip(time.Now(), c.openTimeout)
+
+			continue
+		}
+
+		e.success()
+	}
+}
+
+func (c *FailoverClient) probe(e *endpoint) error {
+	req, err := http.NewRequest(http.MethodGet, e.healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// allow reports whether a request can be sent to the endpoint: the circuit is closed, or the open timeout elapsed and
+// no trial request is in progress.
+func (e *endpoint) allow(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.openUntil.IsZero() {
+		return true
+	}
+
+	if now.Before(e.openUntil) || e.trial {
+		return false
+	}
+
+	e.trial = true
+
+	return true
+}
+
+func (e *endpoint) success() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures = 0
+	e.openUntil = time.Time{}
+	e.trial = false
+}
+
+func (e *endpoint) failure(now time.Time, threshold int, openTimeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures++
+
+	if e.trial || e.failures >= threshold {
+		e.openUntil = now.Add(openTimeout)
+		e.trial = false
+	}
+}
+
+func (e *endpoint) trip(now time.Time, openTimeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.openUntil = now.Add(openTimeout)
+	e.trial = false
+}
+
+func isKeyUse(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+
+	_, ok := keyUseOperations[path.Base(req.URL.Path)]
+
+	return ok
+}
+
+func isUnavailable(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer req.Body.Close() // nolint: errcheck
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read webkms request body: %w", err)
+	}
+
+	return body, nil
+}
+
+// rewrite returns a copy of the request sent to the endpoint instead of the primary endpoint.
+func rewrite(req *http.Request, primary, endpointURL string, body []byte) (*http.Request, error) {
+	r := req.Clone(req.Context())
+
+	if endpointURL != primary {
+		u, err := url.Parse(endpointURL + strings.TrimPrefix(req.URL.String(), primary))
+		if err != nil {
+			return nil, fmt.Errorf("rewrite webkms request URL: %w", err)
+		}
+
+		r.URL = u
+		r.Host = u.Host
+	}
+
+	if body != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		r.ContentLength = int64(len(body))
+	}
+
+	return r, nil
+}
+
+func closeBody(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		logger.Warnf("failed to close webkms response body: %s", err)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// keyServer is a test key server replying with the configured status and recording the requests.
+type keyServer struct {
+	*httptest.Server
+	status       int32
+	healthStatus int32
+	mu           sync.Mutex
+	requests     []string
+	bodies       []string
+}
+
+func newKeyServer(t *testing.T) *keyServer {
+	t.Helper()
+
+	s := &keyServer{status: http.StatusOK, healthStatus: http.StatusOK}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == DefaultHealthCheckPath {
+			w.WriteHeader(int(atomic.LoadInt32(&s.healthStatus)))
+
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.bodies = append(s.bodies, string(body))
+		s.mu.Unlock()
+
+		w.WriteHeader(int(atomic.LoadInt32(&s.status)))
+	}))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *keyServer) setStatus(status int) {
+	atomic.StoreInt32(&s.status, int32(status))
+}
+
+func (s *keyServer) setHealthStatus(status int) {
+	atomic.StoreInt32(&s.healthStatus, int32(status))
+}
+
+func (s *keyServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func post(t *testing.T, c *FailoverClient, url, body string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	if err == nil {
+		require.NoError(t, resp.Body.Close())
+	}
+
+	return resp, err
+}
+
+func TestFailoverClient(t *testing.T) {
+	const signPath = "/kms/keystores/ks1/keys/k1/sign"
+
+	t.Run("key use requests fail over to the replicas", func(t *testing.T) {
+		primary, replica := newKeyServer(t), newKeyServer(t)
+		primary.setStatus(http.StatusServiceUnavailable)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL, []string{replica.URL},
+			WithFailureThreshold(2), WithOpenTimeout(time.Hour))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			resp, err := post(t, c, primary.URL+signPath, `{"message":"msg"}`)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+
+		// the circuit of the primary is open after two failures
+		require.Equal(t, []string{"POST " + signPath, "POST " + signPath}, primary.received())
+		require.Len(t, replica.received(), 3)
+		require.Equal(t, `{"message":"msg"}`, replica.bodies[2])
+	})
+
+	t.Run("key management requests are sent to the primary only", func(t *testing.T) {
+		primary, replica := newKeyServer(t), newKeyServer(t)
+		primary.setStatus(http.StatusBadGateway)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL+"/kms/keystores/ks1", []string{
+			replica.URL + "/kms/keystores/ks1",
+		})
+		require.NoError(t, err)
+
+		resp, err := post(t, c, primary.URL+"/kms/keystores/ks1/keys", `{"keyType":"ED25519"}`)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		require.Empty(t, replica.received())
+
+		// the GET requests fail over
+		req, err := http.NewRequest(http.MethodGet, primary.URL+"/kms/keystores/ks1/keys/k1/export", nil)
+		require.NoError(t, err)
+
+		resp, err = c.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []string{"GET /kms/keystores/ks1/keys/k1/export"}, replica.received())
+	})
+
+	t.Run("transport errors fail over", func(t *testing.T) {
+		down, replica := newKeyServer(t), newKeyServer(t)
+		down.Close()
+
+		c, err := NewFailoverClient(http.DefaultClient, down.URL, []string{replica.URL})
+		require.NoError(t, err)
+
+		resp, err := post(t, c, down.URL+signPath, "")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// the last error is returned when no endpoint succeeds
+		c, err = NewFailoverClient(http.DefaultClient, down.URL, nil)
+		require.NoError(t, err)
+
+		_, err = post(t, c, down.URL+signPath, "")
+		require.Error(t, err)
+	})
+
+	t.Run("no endpoint available", func(t *testing.T) {
+		primary := newKeyServer(t)
+		primary.setStatus(http.StatusServiceUnavailable)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL, nil,
+			WithFailureThreshold(1), WithOpenTimeout(time.Hour))
+		require.NoError(t, err)
+
+		resp, err := post(t, c, primary.URL+signPath, "")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		_, err = post(t, c, primary.URL+signPath, "")
+		require.True(t, errors.Is(err, ErrNoEndpointAvailable))
+	})
+
+	t.Run("trial request after the open timeout", func(t *testing.T) {
+		primary, replica := newKeyServer(t), newKeyServer(t)
+		primary.setStatus(http.StatusServiceUnavailable)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL, []string{replica.URL},
+			WithFailureThreshold(1), WithOpenTimeout(10*time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = post(t, c, primary.URL+signPath, "")
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		// the trial request fails, the circuit opens again
+		_, err = post(t, c, primary.URL+signPath, "")
+		require.NoError(t, err)
+		require.Len(t, primary.received(), 2)
+
+		_, err = post(t, c, primary.URL+signPath, "")
+		require.NoError(t, err)
+		require.Len(t, primary.received(), 2)
+
+		primary.setStatus(http.StatusOK)
+		time.Sleep(20 * time.Millisecond)
+
+		// the trial request succeeds, the circuit is closed
+		for i := 0; i < 2; i++ {
+			_, err = post(t, c, primary.URL+signPath, "")
+			require.NoError(t, err)
+		}
+
+		require.Len(t, primary.received(), 4)
+		require.Len(t, replica.received(), 3)
+	})
+
+	t.Run("health checks", func(t *testing.T) {
+		primary, replica := newKeyServer(t), newKeyServer(t)
+		primary.setHealthStatus(http.StatusServiceUnavailable)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL, []string{replica.URL},
+			WithOpenTimeout(time.Hour), WithHealthCheck(DefaultHealthCheckPath, time.Millisecond))
+		require.NoError(t, err)
+
+		c.Start()
+		c.Start()
+
+		require.Eventually(t, func() bool {
+			_, err = post(t, c, primary.URL+signPath, "")
+
+			return err == nil && len(replica.received()) > 0
+		}, time.Second, time.Millisecond)
+
+		primary.setHealthStatus(http.StatusOK)
+
+		require.Eventually(t, func() bool {
+			_, err = post(t, c, primary.URL+signPath, "")
+
+			return err == nil && len(primary.received()) > 0
+		}, time.Second, time.Millisecond)
+
+		c.Stop()
+		c.Stop()
+	})
+
+	t.Run("other requests are sent as is", func(t *testing.T) {
+		primary, other := newKeyServer(t), newKeyServer(t)
+
+		c, err := NewFailoverClient(http.DefaultClient, primary.URL, nil)
+		require.NoError(t, err)
+
+		_, err = post(t, c, other.URL+"/other", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"POST /other"}, other.received())
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		_, err := NewFailoverClient(http.DefaultClient, "https://kms.example.com", []string{"kms.example.com"})
+		require.EqualError(t, err, `invalid webkms endpoint "kms.example.com"`)
+	})
+}
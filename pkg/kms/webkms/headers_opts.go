@@ -20,6 +20,8 @@ type addHeaders func(req *http.Request) (*http.Header, error)
 type Opts struct {
 	HeadersFunc     addHeaders
 	ComputeMACCache gcache.Cache
+	Batching        bool
+	BatchMaxOps     int
 	marshal         marshalFunc
 }
 
@@ -54,3 +56,13 @@ func WithMarshalFn(fn marshalFunc) Opt {
 		opts.marshal = fn
 	}
 }
+
+// WithBatching option coalesces the operations of the remote crypto batch methods (SignBatch, WrapKeys and UnwrapKeys)
+// into multi-op requests of up to maxOps operations, unlimited if zero. The operations are sent one request each to
+// the key servers not supporting multi-op requests.
+func WithBatching(maxOps int) Opt {
+	return func(opts *Opts) {
+		opts.Batching = true
+		opts.BatchMaxOps = maxOps
+	}
+}
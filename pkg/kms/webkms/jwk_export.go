@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ExportPubKeyJWK will remotely fetch a key referenced by keyID then gets its public key as a JWK of key type kt, with
+// keyID as the JWK kid. The key must be an asymmetric key.
+// Returns:
+//  - public key JWK
+//  - error if it fails to export the public key
+func (r *RemoteKMS) ExportPubKeyJWK(keyID string, kt kms.KeyType) (*jwk.JWK, error) {
+	pubKeyBytes, err := r.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("exportPubKeyJWK: %w", err)
+	}
+
+	j, err := jwkkid.BuildJWK(pubKeyBytes, kt)
+	if err != nil {
+		return nil, fmt.Errorf("exportPubKeyJWK: %w", err)
+	}
+
+	j.KeyID = keyID
+
+	return j, nil
+}
+
+// ExportPrivateKeyJWK is not implemented in remoteKMS, private keys never leave the key server.
+func (r *RemoteKMS) ExportPrivateKeyJWK(keyID string) (*jwk.JWK, error) {
+	return nil, errors.New("function ExportPrivateKeyJWK is not implemented in remoteKMS")
+}
+
+// ImportJWK will remotely import the private key of j into the key server then returns the new key id and the key URL,
+// the key type being the one of the JWK (ECDSA keys are imported as IEEE-P1363 keys). j must be a private ECDSA or
+// Ed25519 key JWK.
+// 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
+// then an error is returned.
+// Returns:
+//  - KeyID of the imported key
+//  - key URL
+//  - error if import failure (key is public, unsupported key type or import request failed)
+func (r *RemoteKMS) ImportJWK(j *jwk.JWK, opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	kt, err := j.KeyType()
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	if j.IsPublic() {
+		return "", nil, errors.New("importJWK: JWK is not a private key")
+	}
+
+	kid, keyURL, err := r.ImportPrivateKey(j.Key, kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	return kid, keyURL, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestRemoteKMS_ExportPubKeyJWK(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyBytes := elliptic.Marshal(privKey.Curve, privKey.X, privKey.Y)
+
+	kid, err := jwkkid.CreateKID(pubKeyBytes, kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/keys/"+kid+"/export" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errMessage": "key not found"}`)) // nolint:errcheck
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(&exportKeyResp{ // nolint:errcheck
+			KeyBytes: base64.URLEncoding.EncodeToString(pubKeyBytes),
+		})
+	}))
+	defer srv.Close()
+
+	remoteKMS := New(srv.URL, srv.Client())
+
+	j, err := remoteKMS.ExportPubKeyJWK(kid, kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+	require.Equal(t, kid, j.KeyID)
+	require.Equal(t, &privKey.PublicKey, j.Key)
+
+	tp, err := j.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp))
+
+	_, err = remoteKMS.ExportPubKeyJWK("unknown", kms.ECDSAP256TypeIEEEP1363)
+	require.EqualError(t, err, "exportPubKeyJWK: key not found")
+
+	_, err = remoteKMS.ExportPubKeyJWK(kid, kms.HMACSHA256Tag256Type)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exportPubKeyJWK: buildJWK")
+
+	_, err = remoteKMS.ExportPrivateKeyJWK(kid)
+	require.EqualError(t, err, "function ExportPrivateKeyJWK is not implemented in remoteKMS")
+}
+
+func TestRemoteKMS_ImportJWK(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var req *importKeyReq
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = &importKeyReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+
+		w.Header().Set(LocationHeader, "http://"+r.Host+"/keys/imported")
+	}))
+	defer srv.Close()
+
+	remoteKMS := New(srv.URL, srv.Client())
+
+	j, err := jwksupport.JWKFromKey(privKey)
+	require.NoError(t, err)
+
+	keyID, keyURL, err := remoteKMS.ImportJWK(j, kms.WithKeyID("imported"))
+	require.NoError(t, err)
+	require.Equal(t, "imported", keyID)
+	require.Equal(t, srv.URL+"/keys/imported", keyURL)
+	require.Equal(t, string(kms.ECDSAP256TypeIEEEP1363), req.KeyType)
+	require.Equal(t, "imported", req.KeyID)
+
+	keyBytes, err := base64.URLEncoding.DecodeString(req.KeyBytes)
+	require.NoError(t, err)
+
+	importedKey, err := x509.ParsePKCS8PrivateKey(keyBytes)
+	require.NoError(t, err)
+	require.Equal(t, privKey.D, importedKey.(*ecdsa.PrivateKey).D)
+
+	j, err = jwksupport.JWKFromKey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	_, _, err = remoteKMS.ImportJWK(j)
+	require.EqualError(t, err, "importJWK: JWK is not a private key")
+}
@@ -0,0 +1,266 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
+	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
+)
+
+const defaultRefreshInterval = 24 * time.Hour
+
+var logger = log.New("aries-framework/ld")
+
+// ContextsChangedEvent is sent when the refresh of a remote provider updated JSON-LD contexts.
+type ContextsChangedEvent struct {
+	ProviderID string
+	Endpoint   string
+	// URLs of the added or updated contexts.
+	URLs []string
+}
+
+// RefreshOpt configures the RefreshScheduler.
+type RefreshOpt func(s *RefreshScheduler)
+
+// WithRefreshInterval sets the interval between refreshes of the remote providers (24 hours by default).
+func WithRefreshInterval(interval time.Duration) RefreshOpt {
+	return func(s *RefreshScheduler) {
+		s.interval = interval
+	}
+}
+
+// WithProviderRefreshInterval sets the interval between refreshes of the remote provider with the given endpoint.
+func WithProviderRefreshInterval(endpoint string, interval time.Duration) RefreshOpt {
+	return func(s *RefreshScheduler) {
+		s.intervals[endpoint] = interval
+	}
+}
+
+// WithRemoteProviderOpts sets the options of the remote providers, eg. the HTTP client.
+func WithRemoteProviderOpts(opts ...remote.ProviderOpt) RefreshOpt {
+	return func(s *RefreshScheduler) {
+		s.providerOpts = append(s.providerOpts, opts...)
+	}
+}
+
+// WithContextsChangedHandler sets the handler called when a refresh updated contexts.
+func WithContextsChangedHandler(handler func(ContextsChangedEvent)) RefreshOpt {
+	return func(s *RefreshScheduler) {
+		s.handler = handler
+	}
+}
+
+// RefreshScheduler refreshes the JSON-LD contexts of the remote providers in the background. The contexts are requested
+// with the ETag and Last-Modified validators of the previous refresh, so unchanged contexts aren't downloaded again
+// when the provider supports conditional requests.
+// Changes are detected from the contexts of the previous refresh: the first refresh of a provider since the scheduler
+// started updates the contexts without sending a ContextsChangedEvent.
+type RefreshScheduler struct {
+	contextStore        ld.ContextStore
+	remoteProviderStore ld.RemoteProviderStore
+	interval            time.Duration
+	intervals           map[string]time.Duration
+	providerOpts        []remote.ProviderOpt
+	handler             func(ContextsChangedEvent)
+	states              map[string]*refreshState
+	mu                  sync.Mutex
+	started             bool
+	stopped             bool
+	stop                chan struct{}
+	done                chan struct{}
+}
+
+// refreshState is the state of the refreshes of a remote provider.
+type refreshState struct {
+	endpoint   string
+	next       time.Time
+	validators remote.Validators
+	hashes     map[string]string
+}
+
+// NewRefreshScheduler returns a new scheduler refreshing the remote providers, started with Start.
+func NewRefreshScheduler(ctx provider, opts ...RefreshOpt) *RefreshScheduler {
+	s := &RefreshScheduler{
+		contextStore:        ctx.JSONLDContextStore(),
+		remoteProviderStore: ctx.JSONLDRemoteProviderStore(),
+		interval:            defaultRefreshInterval,
+		intervals:           make(map[string]time.Duration),
+		states:              make(map[string]*refreshState),
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start refreshes the remote providers now and then in the background at their refresh interval. The providers added
+// to the store while the scheduler runs are refreshed from the next check.
+func (s *RefreshScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+
+	s.started = true
+
+	go s.run()
+}
+
+// Stop stops the background refreshes.
+func (s *RefreshScheduler) Stop() {
+	s.mu.Lock()
+
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+
+	started := s.started
+
+	s.mu.Unlock()
+
+	if started {
+		<-s.done
+	}
+}
+
+func (s *RefreshScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		s.refreshDue(time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// checkInterval is the shortest refresh interval.
+func (s *RefreshScheduler) checkInterval() time.Duration {
+	interval := s.interval
+
+	for _, i := range s.intervals {
+		if i < interval {
+			interval = i
+		}
+	}
+
+	return interval
+}
+
+func (s *RefreshScheduler) refreshDue(now time.Time) {
+	records, err := s.remoteProviderStore.GetAll()
+	if err != nil {
+		logger.Errorf("get remote provider records: %s", err)
+
+		return
+	}
+
+	states := make(map[string]*refreshState, len(records))
+
+	for _, record := range records {
+		state, ok := s.states[record.ID]
+		if !ok || state.endpoint != record.Endpoint {
+			state = &refreshState{endpoint: record.Endpoint}
+		}
+
+		states[record.ID] = state
+
+		if now.Before(state.next) {
+			continue
+		}
+
+		state.next = now.Add(s.intervalFor(record.Endpoint))
+
+		if err := s.refresh(record.ID, state); err != nil {
+			logger.Warnf("refresh remote provider [%s]: %s", record.Endpoint, err)
+		}
+	}
+
+	// forget the deleted providers
+	s.states = states
+}
+
+func (s *RefreshScheduler) intervalFor(endpoint string) time.Duration {
+	if interval, ok := s.intervals[endpoint]; ok {
+		return interval
+	}
+
+	return s.interval
+}
+
+func (s *RefreshScheduler) refresh(providerID string, state *refreshState) error {
+	p := remote.NewProvider(state.endpoint, s.providerOpts...)
+
+	contexts, validators, err := p.ContextsIfChanged(state.validators)
+	if errors.Is(err, remote.ErrNotModified) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get contexts from remote provider: %w", err)
+	}
+
+	if err := s.contextStore.Import(contexts); err != nil {
+		return fmt.Errorf("import contexts: %w", err)
+	}
+
+	hashes := contextHashes(contexts)
+
+	var changed []string
+
+	if state.hashes != nil {
+		for _, c := range contexts {
+			if state.hashes[c.URL] != hashes[c.URL] {
+				changed = append(changed, c.URL)
+			}
+		}
+	}
+
+	state.validators = validators
+	state.hashes = hashes
+
+	if len(changed) > 0 && s.handler != nil {
+		s.handler(ContextsChangedEvent{
+			ProviderID: providerID,
+			Endpoint:   state.endpoint,
+			URLs:       changed,
+		})
+	}
+
+	return nil
+}
+
+func contextHashes(contexts []ldcontext.Document) map[string]string {
+	hashes := make(map[string]string, len(contexts))
+
+	for _, c := range contexts {
+		hashes[c.URL] = fmt.Sprintf("%x", sha256.Sum256(c.Content))
+	}
+
+	return hashes
+}
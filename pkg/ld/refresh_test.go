@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ld_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/remote"
+	"github.com/hyperledger/aries-framework-go/pkg/ld"
+	mockldstore "github.com/hyperledger/aries-framework-go/pkg/mock/ld"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestRefreshScheduler(t *testing.T) {
+	t.Run("Refresh remote providers in the background", func(t *testing.T) {
+		var version, notModified int32 = 1, 0
+
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				etag := fmt.Sprintf(`"v%d"`, atomic.LoadInt32(&version))
+
+				if req.Header.Get("If-None-Match") == etag {
+					atomic.AddInt32(&notModified, 1)
+
+					return &http.Response{
+						StatusCode: http.StatusNotModified,
+						Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					}, nil
+				}
+
+				response := fmt.Sprintf(`{"documents": [{"url": "https://example.com/context.jsonld",`+
+					`"content": {"@context": {"version": %s}}}]}`, etag)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Etag": []string{etag}},
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(response))),
+				}, nil
+			},
+		}
+
+		providerStore := mockldstore.NewMockRemoteProviderStore()
+		providerStore.Store.Store["id"] = mockstorage.DBEntry{
+			Value: []byte("endpoint"),
+			Tags:  []storage.Tag{{Name: ldstore.RemoteProviderRecordTag}},
+		}
+
+		events := make(chan ld.ContextsChangedEvent, 1)
+
+		scheduler := ld.NewRefreshScheduler(createMockProvider(withRemoteProviderStore(providerStore)),
+			ld.WithRefreshInterval(time.Hour),
+			ld.WithProviderRefreshInterval("endpoint", 10*time.Millisecond),
+			ld.WithRemoteProviderOpts(remote.WithHTTPClient(httpClient)),
+			ld.WithContextsChangedHandler(func(event ld.ContextsChangedEvent) {
+				events <- event
+			}))
+
+		scheduler.Start()
+		defer scheduler.Stop()
+
+		// unchanged contexts are requested with the ETag of the previous refresh
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&notModified) > 1
+		}, time.Second, 10*time.Millisecond)
+		require.Empty(t, events)
+
+		atomic.StoreInt32(&version, 2)
+
+		select {
+		case event := <-events:
+			require.Equal(t, ld.ContextsChangedEvent{
+				ProviderID: "id",
+				Endpoint:   "endpoint",
+				URLs:       []string{"https://example.com/context.jsonld"},
+			}, event)
+		case <-time.After(time.Second):
+			require.Fail(t, "contexts changed event not received")
+		}
+	})
+
+	t.Run("Refresh remote providers with Last-Modified validator", func(t *testing.T) {
+		const lastModified = "Wed, 21 Oct 2026 07:28:00 GMT"
+
+		var notModified int32
+
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("If-Modified-Since") == lastModified {
+					atomic.AddInt32(&notModified, 1)
+
+					return &http.Response{
+						StatusCode: http.StatusNotModified,
+						Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					}, nil
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Last-Modified": []string{lastModified}},
+					Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"documents": [{"url": ` +
+						`"https://example.com/context.jsonld", "content": {"@context": {}}}]}`))),
+				}, nil
+			},
+		}
+
+		providerStore := mockldstore.NewMockRemoteProviderStore()
+		providerStore.Store.Store["id"] = mockstorage.DBEntry{
+			Value: []byte("endpoint"),
+			Tags:  []storage.Tag{{Name: ldstore.RemoteProviderRecordTag}},
+		}
+
+		scheduler := ld.NewRefreshScheduler(createMockProvider(withRemoteProviderStore(providerStore)),
+			ld.WithRefreshInterval(10*time.Millisecond),
+			ld.WithRemoteProviderOpts(remote.WithHTTPClient(httpClient)))
+
+		scheduler.Start()
+		defer scheduler.Stop()
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&notModified) > 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Failed refreshes are retried at the next interval", func(t *testing.T) {
+		var requests int32
+
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+
+				return nil, errors.New("response error")
+			},
+		}
+
+		providerStore := mockldstore.NewMockRemoteProviderStore()
+		providerStore.Store.Store["id"] = mockstorage.DBEntry{
+			Value: []byte("endpoint"),
+			Tags:  []storage.Tag{{Name: ldstore.RemoteProviderRecordTag}},
+		}
+
+		scheduler := ld.NewRefreshScheduler(createMockProvider(withRemoteProviderStore(providerStore)),
+			ld.WithRefreshInterval(10*time.Millisecond),
+			ld.WithRemoteProviderOpts(remote.WithHTTPClient(httpClient)))
+
+		scheduler.Start()
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&requests) > 1
+		}, time.Second, 10*time.Millisecond)
+
+		scheduler.Stop()
+	})
+
+	t.Run("Stop scheduler not started", func(t *testing.T) {
+		scheduler := ld.NewRefreshScheduler(createMockProvider())
+
+		scheduler.Stop()
+		scheduler.Stop()
+	})
+}
@@ -30,6 +30,9 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	KeylistQueryFunc   func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error)
+	ComposeChainFunc   func(connIDs []string) (string, error)
+	RemoveChainErr     error
 }
 
 // HandleInbound msg.
@@ -117,3 +120,27 @@ func (m *MockMediatorSvc) GetConnections() ([]string, error) {
 
 	return m.Connections, nil
 }
+
+// KeylistQuery queries a page of the agent recKeys added to the router.
+func (m *MockMediatorSvc) KeylistQuery(connID string, paginate *mediator.Paginate,
+	options ...mediator.ClientOption) (*mediator.Keylist, error) {
+	if m.KeylistQueryFunc != nil {
+		return m.KeylistQueryFunc(connID, paginate)
+	}
+
+	return &mediator.Keylist{}, nil
+}
+
+// ComposeChain composes a mediation chain of the routers.
+func (m *MockMediatorSvc) ComposeChain(connIDs []string) (string, error) {
+	if m.ComposeChainFunc != nil {
+		return m.ComposeChainFunc(connIDs)
+	}
+
+	return uuid.New().String(), nil
+}
+
+// RemoveChain removes the mediation chain.
+func (m *MockMediatorSvc) RemoveChain(chainID string) error {
+	return m.RemoveChainErr
+}
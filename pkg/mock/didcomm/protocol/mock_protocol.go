@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package protocol
 
 import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/lock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -23,6 +24,8 @@ import (
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -43,6 +46,8 @@ type MockProvider struct {
 	KeyTypeValue                 kms.KeyType
 	KeyAgreementTypeValue        kms.KeyType
 	mediaTypeProfilesValue       []string
+	LockServiceValue             spilock.Service
+	NonceStoreValue              *nonce.Store
 }
 
 // OutboundDispatcher is mock outbound dispatcher for DID exchange service.
@@ -152,6 +157,20 @@ func (p *MockProvider) MediaTypeProfiles() []string {
 	return p.mediaTypeProfilesValue
 }
 
+// LockService returns the lock service, a new lock service local to the process is returned if not set.
+func (p *MockProvider) LockService() spilock.Service {
+	if p.LockServiceValue != nil {
+		return p.LockServiceValue
+	}
+
+	return lock.NewLocal()
+}
+
+// NonceStore returns the used nonce store.
+func (p *MockProvider) NonceStore() *nonce.Store {
+	return p.NonceStoreValue
+}
+
 type mockConnectionStore struct{}
 
 // GetDID returns DID associated with key.
@@ -18,7 +18,7 @@ type MockOobService struct {
 	ActionsHandle               func() ([]outofband.Action, error)
 	RegisterActionEventHandle   func(chan<- service.DIDCommAction) error
 	RegisterMsgEventHandle      func(chan<- service.StateMsg) error
-	SaveInvitationHandle        func(*outofband.Invitation) error
+	SaveInvitationHandle        func(*outofband.Invitation, ...outofband.SaveOption) error
 	UnregisterActionEventHandle func(chan<- service.DIDCommAction) error
 	UnregisterMsgEventHandle    func(chan<- service.StateMsg) error
 }
@@ -78,9 +78,9 @@ func (m *MockOobService) RegisterMsgEvent(arg0 chan<- service.StateMsg) error {
 }
 
 // SaveInvitation mock implementation.
-func (m *MockOobService) SaveInvitation(arg0 *outofband.Invitation) error {
+func (m *MockOobService) SaveInvitation(arg0 *outofband.Invitation, arg1 ...outofband.SaveOption) error {
 	if m.SaveInvitationHandle != nil {
-		return m.SaveInvitationHandle(arg0)
+		return m.SaveInvitationHandle(arg0, arg1...)
 	}
 
 	return nil
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventsink
+
+import (
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+)
+
+// MockSink mocks an event sink, keeping the events published.
+type MockSink struct {
+	PublishErr error
+	CloseErr   error
+
+	mu     sync.Mutex
+	events []*eventsink.Event
+}
+
+// Publish keeps the event, or returns PublishErr if set.
+func (s *MockSink) Publish(event *eventsink.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.PublishErr != nil {
+		return s.PublishErr
+	}
+
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+// SetPublishErr sets the error returned by Publish.
+func (s *MockSink) SetPublishErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.PublishErr = err
+}
+
+// Events returns the events published.
+func (s *MockSink) Events() []*eventsink.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*eventsink.Event(nil), s.events...)
+}
+
+// Close returns CloseErr.
+func (s *MockSink) Close() error {
+	return s.CloseErr
+}
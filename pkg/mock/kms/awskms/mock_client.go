@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package awskms
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/google/uuid"
+)
+
+const (
+	rsaKeySize = 2048
+	aesKeySize = 32
+)
+
+type key struct {
+	metadata *awskms.KeyMetadata
+	tags     []*awskms.Tag
+	signer   crypto.Signer
+	aead     cipher.AEAD
+}
+
+// Client is an in-memory AWS KMS client supporting NIST ECDSA, RSA 2048 and symmetric keys.
+type Client struct {
+	CreateKeyErr        error
+	DescribeKeyErr      error
+	ListResourceTagsErr error
+	GetPublicKeyErr     error
+	SignErr             error
+	VerifyErr           error
+	EncryptErr          error
+	DecryptErr          error
+	// CreateKeyInputs are the inputs of the CreateKey calls.
+	CreateKeyInputs []*awskms.CreateKeyInput
+	keys            map[string]*key
+	mu              sync.Mutex
+}
+
+// CreateKey creates a key.
+func (c *Client) CreateKey(input *awskms.CreateKeyInput) (*awskms.CreateKeyOutput, error) {
+	if c.CreateKeyErr != nil {
+		return nil, c.CreateKeyErr
+	}
+
+	k := &key{
+		metadata: &awskms.KeyMetadata{
+			KeyId:                 aws.String(uuid.New().String()),
+			CustomerMasterKeySpec: input.CustomerMasterKeySpec,
+			KeyUsage:              input.KeyUsage,
+		},
+		tags: input.Tags,
+	}
+
+	var err error
+
+	switch aws.StringValue(input.CustomerMasterKeySpec) {
+	case awskms.CustomerMasterKeySpecEccNistP256:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case awskms.CustomerMasterKeySpecEccNistP384:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case awskms.CustomerMasterKeySpecEccNistP521:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case awskms.CustomerMasterKeySpecRsa2048:
+		k.signer, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case awskms.CustomerMasterKeySpecSymmetricDefault:
+		k.aead, err = newAEAD()
+	default:
+		return nil, fmt.Errorf("unsupported key spec %s", aws.StringValue(input.CustomerMasterKeySpec))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keys == nil {
+		c.keys = make(map[string]*key)
+	}
+
+	c.keys[aws.StringValue(k.metadata.KeyId)] = k
+	c.CreateKeyInputs = append(c.CreateKeyInputs, input)
+
+	return &awskms.CreateKeyOutput{KeyMetadata: k.metadata}, nil
+}
+
+// DescribeKey describes a key.
+func (c *Client) DescribeKey(input *awskms.DescribeKeyInput) (*awskms.DescribeKeyOutput, error) {
+	if c.DescribeKeyErr != nil {
+		return nil, c.DescribeKeyErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.DescribeKeyOutput{KeyMetadata: k.metadata}, nil
+}
+
+// ListResourceTags lists the tags of a key.
+func (c *Client) ListResourceTags(input *awskms.ListResourceTagsInput) (*awskms.ListResourceTagsOutput, error) {
+	if c.ListResourceTagsErr != nil {
+		return nil, c.ListResourceTagsErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.ListResourceTagsOutput{Tags: k.tags}, nil
+}
+
+// GetPublicKey returns the DER encoded public key of an asymmetric key.
+func (c *Client) GetPublicKey(input *awskms.GetPublicKeyInput) (*awskms.GetPublicKeyOutput, error) {
+	if c.GetPublicKeyErr != nil {
+		return nil, c.GetPublicKeyErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.signer == nil {
+		return nil, &awskms.UnsupportedOperationException{}
+	}
+
+	pubKey, err := x509.MarshalPKIXPublicKey(k.signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.GetPublicKeyOutput{KeyId: input.KeyId, PublicKey: pubKey}, nil
+}
+
+// Sign signs a digest.
+func (c *Client) Sign(input *awskms.SignInput) (*awskms.SignOutput, error) {
+	if c.SignErr != nil {
+		return nil, c.SignErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := signerOpts(k, input.MessageType, input.SigningAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := k.signer.Sign(rand.Reader, input.Message, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.SignOutput{KeyId: input.KeyId, Signature: signature, SigningAlgorithm: input.SigningAlgorithm}, nil
+}
+
+// Verify verifies the signature of a digest.
+func (c *Client) Verify(input *awskms.VerifyInput) (*awskms.VerifyOutput, error) {
+	if c.VerifyErr != nil {
+		return nil, c.VerifyErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := signerOpts(k, input.MessageType, input.SigningAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := false
+
+	switch pubKey := k.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(pubKey, input.Message, input.Signature)
+	case *rsa.PublicKey:
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			valid = rsa.VerifyPSS(pubKey, crypto.SHA256, input.Message, input.Signature, pssOpts) == nil
+		} else {
+			valid = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, input.Message, input.Signature) == nil
+		}
+	}
+
+	if !valid {
+		return nil, &awskms.KMSInvalidSignatureException{Message_: aws.String("invalid signature")}
+	}
+
+	return &awskms.VerifyOutput{KeyId: input.KeyId, SignatureValid: aws.Bool(true)}, nil
+}
+
+// Encrypt encrypts the plaintext with a symmetric key, the encryption context is authenticated.
+func (c *Client) Encrypt(input *awskms.EncryptInput) (*awskms.EncryptOutput, error) {
+	if c.EncryptErr != nil {
+		return nil, c.EncryptErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.aead == nil {
+		return nil, &awskms.InvalidKeyUsageException{}
+	}
+
+	aad, err := json.Marshal(input.EncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, k.aead.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	// the ciphertext blob holds the key ID, as the AWS KMS ones.
+	blob := append([]byte(aws.StringValue(input.KeyId)), nonce...)
+
+	return &awskms.EncryptOutput{KeyId: input.KeyId, CiphertextBlob: k.aead.Seal(blob, nonce, input.Plaintext, aad)}, nil
+}
+
+// Decrypt decrypts the ciphertext with a symmetric key.
+func (c *Client) Decrypt(input *awskms.DecryptInput) (*awskms.DecryptOutput, error) {
+	if c.DecryptErr != nil {
+		return nil, c.DecryptErr
+	}
+
+	k, err := c.key(input.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.aead == nil {
+		return nil, &awskms.InvalidKeyUsageException{}
+	}
+
+	aad, err := json.Marshal(input.EncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := input.CiphertextBlob[len(aws.StringValue(input.KeyId)):]
+	nonceSize := k.aead.NonceSize()
+
+	if len(blob) < nonceSize {
+		return nil, &awskms.InvalidCiphertextException{}
+	}
+
+	plaintext, err := k.aead.Open(nil, blob[:nonceSize], blob[nonceSize:], aad)
+	if err != nil {
+		return nil, &awskms.InvalidCiphertextException{Message_: aws.String(err.Error())}
+	}
+
+	return &awskms.DecryptOutput{KeyId: input.KeyId, Plaintext: plaintext}, nil
+}
+
+func (c *Client) key(keyID *string) (*key, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k, ok := c.keys[aws.StringValue(keyID)]
+	if !ok {
+		return nil, &awskms.NotFoundException{Message_: aws.String("key not found")}
+	}
+
+	return k, nil
+}
+
+func signerOpts(k *key, messageType, algorithm *string) (crypto.SignerOpts, error) {
+	if k.signer == nil {
+		return nil, &awskms.InvalidKeyUsageException{}
+	}
+
+	if aws.StringValue(messageType) != awskms.MessageTypeDigest {
+		return nil, fmt.Errorf("unsupported message type %s", aws.StringValue(messageType))
+	}
+
+	switch aws.StringValue(algorithm) {
+	case awskms.SigningAlgorithmSpecEcdsaSha256, awskms.SigningAlgorithmSpecRsassaPkcs1V15Sha256:
+		return crypto.SHA256, nil
+	case awskms.SigningAlgorithmSpecEcdsaSha384:
+		return crypto.SHA384, nil
+	case awskms.SigningAlgorithmSpecEcdsaSha512:
+		return crypto.SHA512, nil
+	case awskms.SigningAlgorithmSpecRsassaPssSha256:
+		return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %s", aws.StringValue(algorithm))
+	}
+}
+
+func newAEAD() (cipher.AEAD, error) {
+	aesKey := make([]byte, aesKeySize)
+
+	if _, err := rand.Read(aesKey); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
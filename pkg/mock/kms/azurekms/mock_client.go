@@ -0,0 +1,305 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azurekms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms/azurekms"
+)
+
+const (
+	// VaultURL is the URL of the in-memory vault, prefixing the key identifiers.
+	VaultURL = "https://mock.vault.azure.net"
+
+	wrapAlgorithm = "RSA-OAEP-256"
+)
+
+// ErrKeyNotFound is returned for the unknown keys.
+var ErrKeyNotFound = errors.New("key not found")
+
+type key struct {
+	bundle *azurekms.KeyBundle
+	signer crypto.Signer
+}
+
+// Client is an in-memory Key Vault client supporting NIST ECDSA and RSA keys.
+type Client struct {
+	CreateKeyErr error
+	GetKeyErr    error
+	SignErr      error
+	VerifyErr    error
+	WrapKeyErr   error
+	UnwrapKeyErr error
+	// CreateKeyParams are the parameters of the CreateKey calls.
+	CreateKeyParams []*azurekms.KeyCreateParameters
+	// keys by name and version, the empty version being the latest one.
+	keys map[string]*key
+	mu   sync.Mutex
+}
+
+// CreateKey creates a key, or a new version of the key.
+func (c *Client) CreateKey(keyName string, params *azurekms.KeyCreateParameters) (*azurekms.KeyBundle, error) {
+	if c.CreateKeyErr != nil {
+		return nil, c.CreateKeyErr
+	}
+
+	k := &key{}
+
+	var err error
+
+	switch params.KeyType + params.Curve {
+	case azurekms.KeyTypeEC + azurekms.CurveP256:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case azurekms.KeyTypeEC + azurekms.CurveP384:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case azurekms.KeyTypeEC + azurekms.CurveP521:
+		k.signer, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case azurekms.KeyTypeRSA:
+		k.signer, err = rsa.GenerateKey(rand.Reader, params.KeySize)
+	default:
+		return nil, fmt.Errorf("unsupported key type %s %s", params.KeyType, params.Curve)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := keyName + "/" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	k.bundle = &azurekms.KeyBundle{Key: jsonWebKey(k.signer.Public()), Tags: params.Tags}
+	k.bundle.Key.KID = VaultURL + "/keys/" + keyID
+	k.bundle.Key.KeyOps = params.KeyOps
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keys == nil {
+		c.keys = make(map[string]*key)
+	}
+
+	c.keys[keyID] = k
+	c.keys[keyName] = k
+	c.CreateKeyParams = append(c.CreateKeyParams, params)
+
+	return k.bundle, nil
+}
+
+// GetKey returns the public part of a key.
+func (c *Client) GetKey(keyID string) (*azurekms.KeyBundle, error) {
+	if c.GetKeyErr != nil {
+		return nil, c.GetKeyErr
+	}
+
+	k, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.bundle, nil
+}
+
+// Sign signs a digest, the ECDSA signatures being IEEE P1363 encoded.
+func (c *Client) Sign(keyID string, params *azurekms.KeyOperationParameters) (*azurekms.KeyOperationResult, error) {
+	if c.SignErr != nil {
+		return nil, c.SignErr
+	}
+
+	k, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := base64.RawURLEncoding.DecodeString(params.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var signature []byte
+
+	switch signer := k.signer.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, e := ecdsa.Sign(rand.Reader, signer, digest)
+		if e != nil {
+			return nil, e
+		}
+
+		size := (signer.Curve.Params().BitSize + 7) / 8 // nolint: gomnd
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	case *rsa.PrivateKey:
+		signature, err = signRSA(signer, params.Algorithm, digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &azurekms.KeyOperationResult{
+		KID:   k.bundle.Key.KID,
+		Value: base64.RawURLEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// Verify verifies the signature of a digest.
+func (c *Client) Verify(keyID string, params *azurekms.KeyVerifyParameters) (*azurekms.KeyVerifyResult, error) {
+	if c.VerifyErr != nil {
+		return nil, c.VerifyErr
+	}
+
+	k, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := base64.RawURLEncoding.DecodeString(params.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(params.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := false
+
+	switch pubKey := k.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		size := len(signature) / 2 // nolint: gomnd
+		valid = ecdsa.Verify(pubKey, digest,
+			new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:]))
+	case *rsa.PublicKey:
+		if params.Algorithm == "PS256" {
+			valid = rsa.VerifyPSS(pubKey, crypto.SHA256, digest, signature, nil) == nil
+		} else {
+			valid = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, digest, signature) == nil
+		}
+	}
+
+	return &azurekms.KeyVerifyResult{Value: valid}, nil
+}
+
+// WrapKey encrypts a key with RSA-OAEP-256.
+func (c *Client) WrapKey(keyID string, params *azurekms.KeyOperationParameters) (*azurekms.KeyOperationResult, error) {
+	if c.WrapKeyErr != nil {
+		return nil, c.WrapKeyErr
+	}
+
+	k, err := c.rsaKey(keyID, params.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := base64.RawURLEncoding.DecodeString(params.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &k.PublicKey, cek, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azurekms.KeyOperationResult{Value: base64.RawURLEncoding.EncodeToString(wrapped)}, nil
+}
+
+// UnwrapKey decrypts a key wrapped with RSA-OAEP-256.
+func (c *Client) UnwrapKey(keyID string,
+	params *azurekms.KeyOperationParameters) (*azurekms.KeyOperationResult, error) {
+	if c.UnwrapKeyErr != nil {
+		return nil, c.UnwrapKeyErr
+	}
+
+	k, err := c.rsaKey(keyID, params.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := base64.RawURLEncoding.DecodeString(params.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, k, wrapped, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azurekms.KeyOperationResult{Value: base64.RawURLEncoding.EncodeToString(cek)}, nil
+}
+
+func (c *Client) key(keyID string) (*key, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k, ok := c.keys[keyID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return k, nil
+}
+
+func (c *Client) rsaKey(keyID, algorithm string) (*rsa.PrivateKey, error) {
+	k, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := k.signer.(*rsa.PrivateKey)
+	if !ok || algorithm != wrapAlgorithm {
+		return nil, fmt.Errorf("unsupported wrap algorithm %s", algorithm)
+	}
+
+	return rsaKey, nil
+}
+
+func signRSA(k *rsa.PrivateKey, algorithm string, digest []byte) ([]byte, error) {
+	switch algorithm {
+	case "RS256":
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest)
+	case "PS256":
+		return rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest, nil)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %s", algorithm)
+	}
+}
+
+func jsonWebKey(pubKey crypto.PublicKey) azurekms.JSONWebKey {
+	switch k := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8 // nolint: gomnd
+
+		return azurekms.JSONWebKey{
+			KeyType: azurekms.KeyTypeEC,
+			Curve:   k.Curve.Params().Name,
+			X:       base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size))),
+			Y:       base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size))),
+		}
+	case *rsa.PublicKey:
+		return azurekms.JSONWebKey{
+			KeyType: azurekms.KeyTypeRSA,
+			N:       base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	default:
+		return azurekms.JSONWebKey{}
+	}
+}
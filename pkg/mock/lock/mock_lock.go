@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+)
+
+// MockLockService mocking a lock service, recording the names of the locks acquired.
+type MockLockService struct {
+	ErrLock   error
+	ErrUnlock error
+	mu        sync.Mutex
+	locked    []string
+}
+
+// Lock acquires the lock with the given name.
+func (m *MockLockService) Lock(_ context.Context, name string) (lock.Lock, error) {
+	if m.ErrLock != nil {
+		return nil, m.ErrLock
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.locked = append(m.locked, name)
+
+	return &mockLock{err: m.ErrUnlock}, nil
+}
+
+// Locked returns the names of the locks acquired.
+func (m *MockLockService) Locked() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.locked...)
+}
+
+type mockLock struct {
+	err error
+}
+
+func (m *mockLock) Unlock() error {
+	return m.err
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Observation is a metric recorded by the mock provider.
+type Observation struct {
+	Metric string
+	Labels []string
+	Err    error
+}
+
+// names of the metrics recorded by the mock provider.
+const (
+	EnvelopePacked   = "envelope_packed"
+	EnvelopeUnpacked = "envelope_unpacked"
+	StateTransition  = "state_transition"
+	StorageOperation = "storage_operation"
+	TransportError   = "transport_error"
+)
+
+// Provider mocks a metrics provider recording all observations.
+type Provider struct {
+	mu           sync.Mutex
+	observations []Observation
+}
+
+// EnvelopePacked records a packed envelope.
+func (p *Provider) EnvelopePacked(mediaType string, err error) {
+	p.record(EnvelopePacked, err, mediaType)
+}
+
+// EnvelopeUnpacked records an unpacked envelope.
+func (p *Provider) EnvelopeUnpacked(encodingType string, err error) {
+	p.record(EnvelopeUnpacked, err, encodingType)
+}
+
+// StateTransition records a protocol state transition.
+func (p *Provider) StateTransition(protocol, state string) {
+	p.record(StateTransition, nil, protocol, state)
+}
+
+// StorageOperation records a storage operation, the duration is ignored.
+func (p *Provider) StorageOperation(store, operation string, _ time.Duration, err error) {
+	p.record(StorageOperation, err, store, operation)
+}
+
+// TransportError records a transport error.
+func (p *Provider) TransportError(scheme string) {
+	p.record(TransportError, nil, scheme)
+}
+
+// Observations returns the observations of the given metric recorded so far.
+func (p *Provider) Observations(metric string) []Observation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var observations []Observation
+
+	for _, o := range p.observations {
+		if o.Metric == metric {
+			observations = append(observations, o)
+		}
+	}
+
+	return observations
+}
+
+func (p *Provider) record(metric string, err error, labels ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.observations = append(p.observations, Observation{Metric: metric, Labels: labels, Err: err})
+}
@@ -7,7 +7,10 @@ package provider
 
 import (
 	jsonld "github.com/piprate/json-gold/ld"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/lock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
@@ -15,6 +18,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/store/nonce"
+	spilock "github.com/hyperledger/aries-framework-go/spi/lock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -39,6 +44,10 @@ type Provider struct {
 	KeyTypeValue                      kms.KeyType
 	KeyAgreementTypeValue             kms.KeyType
 	MediaTypeProfilesValue            []string
+	TracerProviderValue               trace.TracerProvider
+	MetricsProviderValue              metrics.Provider
+	LockServiceValue                  spilock.Service
+	NonceStoreValue                   *nonce.Store
 }
 
 // Service return service.
@@ -109,6 +118,38 @@ func (p *Provider) VDRegistry() vdrapi.Registry {
 	return p.VDRegistryValue
 }
 
+// TracerProvider returns the tracer provider, a no-op provider is returned if not set.
+func (p *Provider) TracerProvider() trace.TracerProvider {
+	if p.TracerProviderValue == nil {
+		return trace.NewNoopTracerProvider()
+	}
+
+	return p.TracerProviderValue
+}
+
+// MetricsProvider returns the metrics provider, a no-op provider is returned if not set.
+func (p *Provider) MetricsProvider() metrics.Provider {
+	if p.MetricsProviderValue == nil {
+		return metrics.Noop{}
+	}
+
+	return p.MetricsProviderValue
+}
+
+// LockService returns the lock service, a new lock service local to the process is returned if not set.
+func (p *Provider) LockService() spilock.Service {
+	if p.LockServiceValue == nil {
+		return lock.NewLocal()
+	}
+
+	return p.LockServiceValue
+}
+
+// NonceStore returns the used nonce store.
+func (p *Provider) NonceStore() *nonce.Store {
+	return p.NonceStoreValue
+}
+
 // JSONLDContextStore returns JSON-LD context store.
 func (p *Provider) JSONLDContextStore() ld.ContextStore {
 	return p.ContextStoreValue
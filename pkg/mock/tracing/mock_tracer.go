@@ -0,0 +1,204 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerProvider mocks an OpenTelemetry tracer provider recording all started spans.
+type TracerProvider struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// Tracer returns a tracer recording its spans in the provider.
+func (p *TracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &tracer{provider: p}
+}
+
+// Spans returns the spans started so far.
+func (p *TracerProvider) Spans() []*Span {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*Span(nil), p.spans...)
+}
+
+// Span returns the first span with the given name or nil if not found.
+func (p *TracerProvider) Span(name string) *Span {
+	for _, s := range p.Spans() {
+		if s.Name() == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+type tracer struct {
+	provider *TracerProvider
+}
+
+func (t *tracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent := trace.SpanContextFromContext(ctx)
+
+	cfg := trace.NewSpanStartConfig(opts...)
+
+	traceID := parent.TraceID()
+	if !parent.IsValid() {
+		_, _ = rand.Read(traceID[:]) //nolint:errcheck
+	}
+
+	var spanID trace.SpanID
+
+	_, _ = rand.Read(spanID[:]) //nolint:errcheck
+
+	s := &Span{
+		name:   name,
+		parent: parent,
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		provider:   t.provider,
+		attributes: map[attribute.Key]attribute.Value{},
+	}
+
+	s.SetAttributes(cfg.Attributes()...)
+
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, s)
+	t.provider.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+// Span mocks a recording span.
+type Span struct {
+	mu         sync.Mutex
+	name       string
+	parent     trace.SpanContext
+	sc         trace.SpanContext
+	provider   *TracerProvider
+	attributes map[attribute.Key]attribute.Value
+	errs       []error
+	status     codes.Code
+	ended      bool
+}
+
+// Name returns the span name.
+func (s *Span) Name() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.name
+}
+
+// Parent returns the span context of the parent span.
+func (s *Span) Parent() trace.SpanContext {
+	return s.parent
+}
+
+// Attribute returns the value of the given span attribute.
+func (s *Span) Attribute(key string) attribute.Value {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attributes[attribute.Key(key)]
+}
+
+// Errors returns the errors recorded on the span.
+func (s *Span) Errors() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]error(nil), s.errs...)
+}
+
+// Status returns the span status code.
+func (s *Span) Status() codes.Code {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// Ended returns true if the span was ended.
+func (s *Span) Ended() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ended
+}
+
+// End ends the span.
+func (s *Span) End(...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ended = true
+}
+
+// AddEvent is not recorded.
+func (s *Span) AddEvent(string, ...trace.EventOption) {}
+
+// IsRecording always returns true.
+func (s *Span) IsRecording() bool {
+	return true
+}
+
+// RecordError records the error.
+func (s *Span) RecordError(err error, _ ...trace.EventOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errs = append(s.errs, err)
+}
+
+// SpanContext returns the span context.
+func (s *Span) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+// SetStatus sets the span status.
+func (s *Span) SetStatus(code codes.Code, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = code
+}
+
+// SetName sets the span name.
+func (s *Span) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = name
+}
+
+// SetAttributes records the attributes.
+func (s *Span) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range kv {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+// TracerProvider returns the provider which created the span.
+func (s *Span) TracerProvider() trace.TracerProvider {
+	return s.provider
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+)
+
+// package vault provides a secret lock service backed by the transit secrets engine of HashiCorp Vault
+// (https://www.vaultproject.io/docs/secrets/transit). The keys are encrypted by Vault with a transit key that never
+// leaves Vault, so that no master key is stored by the agent.
+//
+// The transit key must be created in Vault prior to using this service, eg:
+//		vault secrets enable transit
+//		vault write -f transit/keys/aries-master-key
+// and the token used by the service must be allowed to encrypt and decrypt with it.
+
+const (
+	defaultMountPath = "transit"
+	defaultKeyName   = "aries-master-key"
+
+	tokenHeader     = "X-Vault-Token"
+	namespaceHeader = "X-Vault-Namespace"
+)
+
+// HTTPClient interface for the http client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Opt is a Vault secret lock option.
+type Opt func(s *Lock)
+
+// WithToken sets the token authenticating the requests to Vault.
+func WithToken(token string) Opt {
+	return func(s *Lock) {
+		s.token = token
+	}
+}
+
+// WithMountPath sets the path the transit secrets engine is mounted at ("transit" by default).
+func WithMountPath(path string) Opt {
+	return func(s *Lock) {
+		s.mountPath = strings.Trim(path, "/")
+	}
+}
+
+// WithKeyName sets the name of the transit key encrypting the keys ("aries-master-key" by default).
+func WithKeyName(name string) Opt {
+	return func(s *Lock) {
+		s.keyName = name
+	}
+}
+
+// WithNamespace sets the Vault Enterprise namespace of the transit secrets engine.
+func WithNamespace(namespace string) Opt {
+	return func(s *Lock) {
+		s.namespace = namespace
+	}
+}
+
+// WithHTTPClient sets the http client sending the requests to Vault (http.DefaultClient by default).
+func WithHTTPClient(client HTTPClient) Opt {
+	return func(s *Lock) {
+		s.httpClient = client
+	}
+}
+
+// Lock is a secret lock service encrypting keys with a transit key of HashiCorp Vault.
+type Lock struct {
+	address    string
+	token      string
+	mountPath  string
+	keyName    string
+	namespace  string
+	httpClient HTTPClient
+}
+
+// New creates a new instance of the Vault secret lock service for the Vault server at address
+// (eg. https://vault.example.com:8200).
+func New(address string, opts ...Opt) (*Lock, error) {
+	if address == "" {
+		return nil, errors.New("vault address is required")
+	}
+
+	s := &Lock{
+		address:    strings.TrimSuffix(address, "/"),
+		mountPath:  defaultMountPath,
+		keyName:    defaultKeyName,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.keyName == "" {
+		return nil, errors.New("vault transit key name is required")
+	}
+
+	return s, nil
+}
+
+type transitRequest struct {
+	Plaintext      string `json:"plaintext,omitempty"`
+	Ciphertext     string `json:"ciphertext,omitempty"`
+	AssociatedData string `json:"associated_data,omitempty"`
+}
+
+type transitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Encrypt a key in req using the transit key of the Vault secret lock service
+// (keyURI is ignored by this implementation, the transit key is set by WithKeyName).
+func (s *Lock) Encrypt(keyURI string, req *secretlock.EncryptRequest) (*secretlock.EncryptResponse, error) {
+	resp, err := s.transit("encrypt", &transitRequest{
+		Plaintext:      base64.StdEncoding.EncodeToString([]byte(req.Plaintext)),
+		AssociatedData: associatedData(req.AdditionalAuthenticatedData),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vault encrypt: %w", err)
+	}
+
+	return &secretlock.EncryptResponse{
+		Ciphertext: base64.URLEncoding.EncodeToString([]byte(resp.Data.Ciphertext)),
+	}, nil
+}
+
+// Decrypt a key in req using the transit key of the Vault secret lock service
+// (keyURI is ignored by this implementation, the transit key is set by WithKeyName).
+func (s *Lock) Decrypt(keyURI string, req *secretlock.DecryptRequest) (*secretlock.DecryptResponse, error) {
+	ct, err := base64.URLEncoding.DecodeString(req.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("vault decrypt: %w", err)
+	}
+
+	resp, err := s.transit("decrypt", &transitRequest{
+		Ciphertext:     string(ct),
+		AssociatedData: associatedData(req.AdditionalAuthenticatedData),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vault decrypt: %w", err)
+	}
+
+	pt, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault decrypt: invalid plaintext: %w", err)
+	}
+
+	return &secretlock.DecryptResponse{Plaintext: string(pt)}, nil
+}
+
+// transit sends the request to the encrypt or decrypt endpoint of the transit key.
+func (s *Lock) transit(operation string, reqBody *transitRequest) (*transitResponse, error) {
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", s.address, s.mountPath, operation, s.keyName)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.token != "" {
+		req.Header.Set(tokenHeader, s.token)
+	}
+
+	if s.namespace != "" {
+		req.Header.Set(namespaceHeader, s.namespace)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close() // nolint: errcheck
+	}()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	transitResp := &transitResponse{}
+
+	if err = json.Unmarshal(respBody, transitResp); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(transitResp.Errors, ", "))
+	}
+
+	return transitResp, nil
+}
+
+func associatedData(aad string) string {
+	if aad == "" {
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(aad))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+)
+
+const (
+	testToken     = "s.testtoken"
+	testNamespace = "aries"
+)
+
+func TestLock(t *testing.T) {
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		server := httptest.NewServer(mockTransit(t, "transit", defaultKeyName))
+		defer server.Close()
+
+		lock, err := New(server.URL, WithToken(testToken), WithNamespace(testNamespace))
+		require.NoError(t, err)
+
+		ct, err := lock.Encrypt("", &secretlock.EncryptRequest{
+			Plaintext:                   "testKey",
+			AdditionalAuthenticatedData: "aad",
+		})
+		require.NoError(t, err)
+		require.NotContains(t, ct.Ciphertext, "testKey")
+
+		pt, err := lock.Decrypt("", &secretlock.DecryptRequest{
+			Ciphertext:                  ct.Ciphertext,
+			AdditionalAuthenticatedData: "aad",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "testKey", pt.Plaintext)
+
+		_, err = lock.Decrypt("", &secretlock.DecryptRequest{
+			Ciphertext:                  ct.Ciphertext,
+			AdditionalAuthenticatedData: "other",
+		})
+		require.EqualError(t, err, "vault decrypt: vault returned status 400: cipher: message authentication failed")
+	})
+
+	t.Run("custom mount path and key name", func(t *testing.T) {
+		server := httptest.NewServer(mockTransit(t, "custom/transit", "custom-key"))
+		defer server.Close()
+
+		lock, err := New(server.URL+"/", WithToken(testToken), WithNamespace(testNamespace),
+			WithMountPath("/custom/transit/"), WithKeyName("custom-key"), WithHTTPClient(server.Client()))
+		require.NoError(t, err)
+
+		ct, err := lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "testKey"})
+		require.NoError(t, err)
+
+		pt, err := lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: ct.Ciphertext})
+		require.NoError(t, err)
+		require.Equal(t, "testKey", pt.Plaintext)
+	})
+
+	t.Run("wraps the keys of the local KMS", func(t *testing.T) {
+		server := httptest.NewServer(mockTransit(t, "transit", defaultKeyName))
+		defer server.Close()
+
+		lock, err := New(server.URL, WithToken(testToken), WithNamespace(testNamespace))
+		require.NoError(t, err)
+
+		storeProvider := mockstorage.NewMockStoreProvider()
+
+		keyManager, err := localkms.New("local-lock://test/master/key/",
+			mockkms.NewProviderForKMS(storeProvider, lock))
+		require.NoError(t, err)
+
+		kid, _, err := keyManager.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = keyManager.Get(kid)
+		require.NoError(t, err)
+
+		// the keys can't be read without Vault
+		lock, err = New(server.URL, WithToken("invalid"))
+		require.NoError(t, err)
+
+		keyManager, err = localkms.New("local-lock://test/master/key/",
+			mockkms.NewProviderForKMS(storeProvider, lock))
+		require.NoError(t, err)
+
+		_, err = keyManager.Get(kid)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "permission denied")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := New("")
+		require.EqualError(t, err, "vault address is required")
+
+		_, err = New("http://localhost:8200", WithKeyName(""))
+		require.EqualError(t, err, "vault transit key name is required")
+
+		server := httptest.NewServer(mockTransit(t, "transit", defaultKeyName))
+		defer server.Close()
+
+		lock, err := New(server.URL, WithToken("invalid"))
+		require.NoError(t, err)
+
+		_, err = lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "testKey"})
+		require.EqualError(t, err, "vault encrypt: vault returned status 403: permission denied")
+
+		_, err = lock.Decrypt("", &secretlock.DecryptRequest{Ciphertext: "!invalid"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "vault decrypt: illegal base64 data")
+
+		lock, err = New("http://[invalid")
+		require.NoError(t, err)
+
+		_, err = lock.Encrypt("", &secretlock.EncryptRequest{Plaintext: "testKey"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "vault encrypt: create request")
+	})
+}
+
+// mockTransit mocks the encrypt and decrypt endpoints of a transit key, the ciphertext binding the plaintext to the
+// associated data.
+func mockTransit(t *testing.T, mountPath, keyName string) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reply := func(status int, resp interface{}) {
+			w.WriteHeader(status)
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		}
+
+		if r.Header.Get(tokenHeader) != testToken || r.Header.Get(namespaceHeader) != testNamespace {
+			reply(http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+
+			return
+		}
+
+		req := &transitRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/%s/encrypt/%s", mountPath, keyName):
+			ct := base64.StdEncoding.EncodeToString([]byte(req.Plaintext + "." + req.AssociatedData))
+
+			reply(http.StatusOK, map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + ct}})
+		case fmt.Sprintf("/v1/%s/decrypt/%s", mountPath, keyName):
+			data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(req.Ciphertext, "vault:v1:"))
+			require.NoError(t, err)
+
+			parts := strings.SplitN(string(data), ".", 2)
+			if parts[1] != req.AssociatedData {
+				reply(http.StatusBadRequest, map[string]interface{}{
+					"errors": []string{"cipher: message authentication failed"},
+				})
+
+				return
+			}
+
+			reply(http.StatusOK, map[string]interface{}{"data": map[string]string{"plaintext": parts[0]}})
+		default:
+			reply(http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -59,6 +61,44 @@ type Record struct {
 	Implicit          bool
 	Namespace         string
 	MediaTypeProfiles []string
+	// CreatedTime is the time the connection record was first saved.
+	CreatedTime time.Time
+	// MyDIDRotation holds the rotation of MyDID until the other party acknowledges it.
+	MyDIDRotation *DIDRotationRecord `json:",omitempty"`
+	// CipherSuite holds the algorithms of the last JWE envelope received over the connection.
+	CipherSuite *CipherSuite `json:",omitempty"`
+	// LastSeen is the time the last trust ping or trust ping response was received from the other party.
+	LastSeen *time.Time `json:",omitempty"`
+	// TheirProfile is the last profile shared by the other party with the profile protocol.
+	TheirProfile *Profile `json:",omitempty"`
+}
+
+// CipherSuite holds the algorithms protecting the JWE envelopes of a connection.
+type CipherSuite struct {
+	// ContentEncryption is the content encryption algorithm, for example A256GCM.
+	ContentEncryption string
+	// KeyWrapping is the key wrapping algorithm, for example ECDH-1PU+A256KW.
+	KeyWrapping string
+}
+
+// Profile is the public profile of an agent shared with its connections.
+type Profile struct {
+	// DisplayName is the name of the agent, displayed instead of the invitation label.
+	DisplayName string `json:"display_name,omitempty"`
+	// DisplayPicture is the hashlink (hl:...) of the picture of the agent.
+	DisplayPicture string `json:"display_picture,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Organization   string `json:"organization,omitempty"`
+	// URL is the website of the agent or its organization.
+	URL string `json:"url,omitempty"`
+}
+
+// DIDRotationRecord holds the rotation of a DIDComm v2 connection DID.
+type DIDRotationRecord struct {
+	OldDID string
+	NewDID string
+	// FromPrior is the from_prior JWT sent with the DIDComm v2 messages until the rotation is acknowledged.
+	FromPrior string
 }
 
 // NewLookup returns new connection lookup instance.
@@ -69,7 +109,9 @@ func NewLookup(p provider) (*Lookup, error) {
 		return nil, fmt.Errorf("failed to open permanent store to create new connection recorder: %w", err)
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix}})
+	err = p.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{
+		TagNames: append([]string{connIDKeyPrefix}, indexTagNames...),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in permanent store: %w", err)
 	}
@@ -80,7 +122,7 @@ func NewLookup(p provider) (*Lookup, error) {
 	}
 
 	err = p.ProtocolStateStorageProvider().SetStoreConfig(Namespace,
-		storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix, connStateKeyPrefix}})
+		storage.StoreConfiguration{TagNames: append([]string{connIDKeyPrefix, connStateKeyPrefix}, indexTagNames...)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in protocol state store: %w", err)
 	}
@@ -92,6 +134,7 @@ func NewLookup(p provider) (*Lookup, error) {
 type Lookup struct {
 	protocolStateStore storage.Store
 	store              storage.Store
+	indexOnce          sync.Once
 }
 
 // GetConnectionRecord return connection record based on the connection ID.
@@ -133,6 +176,46 @@ func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 	return allRecords, nil
 }
 
+// QueryConnectionStateRecords returns the connection records saved for each state of the connections in the
+// protocol state store.
+func (c *Lookup) QueryConnectionStateRecords() ([]*Record, error) {
+	itr, err := c.protocolStateStore.Query(connStateKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query protocol state store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	var records []*Record
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next set of data from iterator: %w", err)
+	}
+
+	for more {
+		value, err := itr.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value from iterator: %w", err)
+		}
+
+		var record Record
+
+		if err = json.Unmarshal(value, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal connection state record: %w", err)
+		}
+
+		records = append(records, &record)
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next set of data from iterator: %w", err)
+		}
+	}
+
+	return records, nil
+}
+
 func (c *Lookup) addDataFromProtocolStateStoreToRecords(searchKey string, keys map[string]struct{},
 	records []*Record) ([]*Record, error) {
 	protocolStateStoreItr, err := c.protocolStateStore.Query(searchKey)
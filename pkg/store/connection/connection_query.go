@@ -0,0 +1,302 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	theirDIDTagName     = "theirdid"
+	myDIDTagName        = "mydid"
+	stateTagName        = "state"
+	invitationIDTagName = "invitationid"
+
+	// indexedKey marks the permanent store as having the records saved before the indexes were introduced indexed.
+	indexedKey = "connindexed"
+)
+
+// indexTagNames are the tags indexing the connection records for QueryConnections.
+var indexTagNames = []string{theirDIDTagName, myDIDTagName, stateTagName, invitationIDTagName} // nolint: gochecknoglobals
+
+// ErrInvalidPageToken is returned by QueryConnections when the page token of the query can't be parsed.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// QueryParams are the criteria of a connection records query, the records matching all the criteria set.
+type QueryParams struct {
+	TheirDID       string
+	MyDID          string
+	State          string
+	InvitationID   string
+	ParentThreadID string
+	// CreatedAfter and CreatedBefore limit the records to the ones created within the (exclusive) time range.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Limit is the maximum number of records returned, all the matching records are returned if not set.
+	Limit int
+	// PageToken is the NextPageToken of the previous page of the query.
+	PageToken string
+}
+
+// QueryResult is a page of connection records matching the criteria of a query.
+type QueryResult struct {
+	Records []*Record
+	// NextPageToken is the token to get the next page of the query, empty on the last page.
+	NextPageToken string
+}
+
+// pageToken is the position of the last record of a page, the records being sorted by creation time then ID.
+type pageToken struct {
+	CreatedTime  time.Time `json:"t"`
+	ConnectionID string    `json:"id"`
+}
+
+// QueryConnections returns the connection records matching the criteria of the query, sorted by creation time.
+// The criteria on the DIDs, state and invitation ID are resolved with the indexes of the underlying stores, the
+// records saved before the indexes were introduced being indexed by the first query using the indexes.
+func (c *Lookup) QueryConnections(params *QueryParams) (*QueryResult, error) {
+	var after *pageToken
+
+	if params.PageToken != "" {
+		token, err := parsePageToken(params.PageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		after = token
+	}
+
+	searchKey := indexQuery(params)
+
+	if searchKey != getConnectionKeyPrefix()("") {
+		c.indexOnce.Do(func() {
+			if err := c.indexRecords(); err != nil {
+				logger.Warnf("failed to index the connection records: %s", err.Error())
+			}
+		})
+	}
+
+	persistentStoreRecords, persistentStoreKeys, err := c.getDataFromPersistentStore(searchKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from persistent store: %w", err)
+	}
+
+	allRecords, err := c.addDataFromProtocolStateStoreToRecords(searchKey, persistentStoreKeys, persistentStoreRecords)
+	if err != nil {
+		return nil, fmt.Errorf("failed to augment records from persistent store with records "+
+			"from the protocol state store: %w", err)
+	}
+
+	var records []*Record
+
+	for _, record := range allRecords {
+		if params.matches(record) && (after == nil || after.before(record)) {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return (&pageToken{CreatedTime: records[i].CreatedTime, ConnectionID: records[i].ConnectionID}).
+			before(records[j])
+	})
+
+	result := &QueryResult{Records: records}
+
+	if params.Limit > 0 && len(records) > params.Limit {
+		result.Records = records[:params.Limit]
+
+		last := result.Records[params.Limit-1]
+
+		result.NextPageToken, err = newPageToken(last)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (p *QueryParams) matches(record *Record) bool {
+	switch {
+	case p.TheirDID != "" && p.TheirDID != record.TheirDID,
+		p.MyDID != "" && p.MyDID != record.MyDID,
+		p.State != "" && p.State != record.State,
+		p.InvitationID != "" && p.InvitationID != record.InvitationID,
+		p.ParentThreadID != "" && p.ParentThreadID != record.ParentThreadID,
+		!p.CreatedAfter.IsZero() && !record.CreatedTime.After(p.CreatedAfter),
+		!p.CreatedBefore.IsZero() && !record.CreatedTime.Before(p.CreatedBefore):
+		return false
+	default:
+		return true
+	}
+}
+
+// indexRecords adds the index tags to the connection records saved before the indexes were introduced.
+func (c *Lookup) indexRecords() error {
+	_, err := c.store.Get(indexedKey)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get indexed marker: %w", err)
+	}
+
+	err = indexStore(c.store, func(key string, record *Record) error {
+		return marshalAndSave(key, record, c.store, recordTags(record)...)
+	})
+	if err != nil {
+		return fmt.Errorf("index permanent store: %w", err)
+	}
+
+	err = indexStore(c.protocolStateStore, func(key string, record *Record) error {
+		return marshalAndSave(key, record, c.protocolStateStore, recordTags(record)...)
+	})
+	if err != nil {
+		return fmt.Errorf("index protocol state store: %w", err)
+	}
+
+	return c.store.Put(indexedKey, []byte("true"))
+}
+
+// indexStore saves the connection records of the store with the save function.
+func indexStore(store storage.Store, save func(key string, record *Record) error) error {
+	itr, err := store.Query(getConnectionKeyPrefix()(""))
+	if err != nil {
+		return fmt.Errorf("query store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	records := make(map[string]*Record)
+
+	more, err := itr.Next()
+
+	for ; err == nil && more; more, err = itr.Next() {
+		key, errKey := itr.Key()
+		if errKey != nil {
+			return fmt.Errorf("get key from iterator: %w", errKey)
+		}
+
+		value, errValue := itr.Value()
+		if errValue != nil {
+			return fmt.Errorf("get value from iterator: %w", errValue)
+		}
+
+		record := &Record{}
+
+		if errUnmarshal := json.Unmarshal(value, record); errUnmarshal != nil {
+			return fmt.Errorf("unmarshal connection record: %w", errUnmarshal)
+		}
+
+		records[key] = record
+	}
+
+	if err != nil {
+		return fmt.Errorf("get next set of data from iterator: %w", err)
+	}
+
+	for key, record := range records {
+		if err = save(key, record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexQuery returns the store query of the most selective index matching the criteria of the query, the other
+// criteria being checked on the records returned by the store.
+func indexQuery(params *QueryParams) string {
+	switch {
+	case params.TheirDID != "":
+		return indexExpression(theirDIDTagName, params.TheirDID)
+	case params.MyDID != "":
+		return indexExpression(myDIDTagName, params.MyDID)
+	case params.InvitationID != "":
+		return indexExpression(invitationIDTagName, params.InvitationID)
+	case params.State != "":
+		return indexExpression(stateTagName, params.State)
+	default:
+		return getConnectionKeyPrefix()("")
+	}
+}
+
+func indexExpression(tagName, value string) string {
+	return fmt.Sprintf("%s:%s", tagName, indexValue(value))
+}
+
+// indexValue encodes the indexed value, the tag values of the stores not allowing the ':' of the DIDs.
+func indexValue(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// recordTags returns the tags of the connection record saved against the connection ID.
+func recordTags(record *Record) []storage.Tag {
+	return append([]storage.Tag{{
+		Name:  getConnectionKeyPrefix()(""),
+		Value: getConnectionKeyPrefix()(record.ConnectionID),
+	}}, indexTags(record)...)
+}
+
+// indexTags returns the tags indexing the record.
+func indexTags(record *Record) []storage.Tag {
+	var tags []storage.Tag
+
+	for name, value := range map[string]string{
+		theirDIDTagName:     record.TheirDID,
+		myDIDTagName:        record.MyDID,
+		stateTagName:        record.State,
+		invitationIDTagName: record.InvitationID,
+	} {
+		if value != "" {
+			tags = append(tags, storage.Tag{Name: name, Value: indexValue(value)})
+		}
+	}
+
+	return tags
+}
+
+// before tells whether the position of the token is before the record.
+func (t *pageToken) before(record *Record) bool {
+	if !t.CreatedTime.Equal(record.CreatedTime) {
+		return t.CreatedTime.Before(record.CreatedTime)
+	}
+
+	return t.ConnectionID < record.ConnectionID
+}
+
+func newPageToken(record *Record) (string, error) {
+	bytes, err := json.Marshal(&pageToken{CreatedTime: record.CreatedTime, ConnectionID: record.ConnectionID})
+	if err != nil {
+		return "", fmt.Errorf("marshal page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func parsePageToken(token string) (*pageToken, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, err.Error())
+	}
+
+	t := &pageToken{}
+
+	if err = json.Unmarshal(bytes, t); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, err.Error())
+	}
+
+	return t, nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package connection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestLookup_QueryConnections(t *testing.T) {
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newRecorder := func(t *testing.T) *Recorder {
+		t.Helper()
+
+		store, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		protocolStateStore, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		recorder, err := NewRecorder(&mockProvider{store: store, protocolStateStore: protocolStateStore})
+		require.NoError(t, err)
+
+		for i := 0; i < 6; i++ {
+			state := StateNameCompleted
+			if i%2 == 1 {
+				state = "requested"
+			}
+
+			require.NoError(t, recorder.SaveConnectionRecord(&Record{
+				ConnectionID: fmt.Sprintf("conn-%d", i),
+				State:        state,
+				TheirDID:     fmt.Sprintf("did:example:their%d", i%3),
+				MyDID:        "did:example:me",
+				InvitationID: fmt.Sprintf("inv-%d", i),
+				CreatedTime:  created.Add(time.Duration(i) * time.Hour),
+			}))
+		}
+
+		return recorder
+	}
+
+	connectionIDs := func(records []*Record) []string {
+		var ids []string
+
+		for _, record := range records {
+			ids = append(ids, record.ConnectionID)
+		}
+
+		return ids
+	}
+
+	t.Run("query by criteria", func(t *testing.T) {
+		recorder := newRecorder(t)
+
+		result, err := recorder.QueryConnections(&QueryParams{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-0", "conn-1", "conn-2", "conn-3", "conn-4", "conn-5"},
+			connectionIDs(result.Records))
+		require.Empty(t, result.NextPageToken)
+
+		result, err = recorder.QueryConnections(&QueryParams{TheirDID: "did:example:their1"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-1", "conn-4"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{MyDID: "did:example:me", State: "requested"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-1", "conn-3", "conn-5"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{InvitationID: "inv-2"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-2"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{State: StateNameCompleted, TheirDID: "did:example:their2"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-2"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{
+			CreatedAfter:  created.Add(time.Hour),
+			CreatedBefore: created.Add(4 * time.Hour),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-2", "conn-3"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{TheirDID: "did:example:unknown"})
+		require.NoError(t, err)
+		require.Empty(t, result.Records)
+	})
+
+	t.Run("index follows the state of the connection", func(t *testing.T) {
+		recorder := newRecorder(t)
+
+		record, err := recorder.GetConnectionRecord("conn-1")
+		require.NoError(t, err)
+
+		record.State = StateNameCompleted
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		result, err := recorder.QueryConnections(&QueryParams{State: "requested"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-3", "conn-5"}, connectionIDs(result.Records))
+
+		result, err = recorder.QueryConnections(&QueryParams{State: StateNameCompleted})
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn-0", "conn-1", "conn-2", "conn-4"}, connectionIDs(result.Records))
+		require.Equal(t, created.Add(time.Hour), result.Records[1].CreatedTime)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		recorder := newRecorder(t)
+
+		var (
+			ids   []string
+			token string
+			pages int
+		)
+
+		for {
+			result, err := recorder.QueryConnections(&QueryParams{MyDID: "did:example:me", Limit: 4, PageToken: token})
+			require.NoError(t, err)
+
+			ids = append(ids, connectionIDs(result.Records)...)
+			pages++
+
+			if result.NextPageToken == "" {
+				break
+			}
+
+			token = result.NextPageToken
+		}
+
+		require.Equal(t, 2, pages)
+		require.Equal(t, []string{"conn-0", "conn-1", "conn-2", "conn-3", "conn-4", "conn-5"}, ids)
+
+		result, err := recorder.QueryConnections(&QueryParams{Limit: 6})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 6)
+		require.Empty(t, result.NextPageToken)
+	})
+
+	t.Run("records saved without the indexes", func(t *testing.T) {
+		store, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		protocolStateStore, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		for i, s := range []storage.Store{store, protocolStateStore} {
+			val, jsonErr := json.Marshal(&Record{
+				ConnectionID: fmt.Sprint(i),
+				State:        StateNameCompleted,
+				TheirDID:     "did:example:their",
+			})
+			require.NoError(t, jsonErr)
+
+			require.NoError(t, s.Put(fmt.Sprintf("%s_abc%d", connIDKeyPrefix, i), val, storage.Tag{Name: "conn_"}))
+		}
+
+		lookup, err := NewLookup(&mockProvider{store: store, protocolStateStore: protocolStateStore})
+		require.NoError(t, err)
+
+		result, err := lookup.QueryConnections(&QueryParams{TheirDID: "did:example:their"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"0", "1"}, connectionIDs(result.Records))
+
+		result, err = lookup.QueryConnections(&QueryParams{})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 2)
+	})
+
+	t.Run("created time is set on first save", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		record := &Record{ConnectionID: "conn", State: "requested"}
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+		require.False(t, record.CreatedTime.IsZero())
+
+		createdTime := record.CreatedTime
+
+		record.State = StateNameCompleted
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+		require.Equal(t, createdTime, record.CreatedTime)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		recorder := newRecorder(t)
+
+		_, err := recorder.QueryConnections(&QueryParams{PageToken: "!invalid"})
+		require.True(t, errors.Is(err, ErrInvalidPageToken))
+
+		_, err = recorder.QueryConnections(&QueryParams{PageToken: "aW52YWxpZA"})
+		require.True(t, errors.Is(err, ErrInvalidPageToken))
+	})
+
+	t.Run("query errors", func(t *testing.T) {
+		lookup, err := NewLookup(&mockProvider{store: &mockstorage.MockStore{ErrQuery: errors.New(sampleErrMsg)}})
+		require.NoError(t, err)
+
+		_, err = lookup.QueryConnections(&QueryParams{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+
+		lookup, err = NewLookup(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{ErrQuery: errors.New(sampleErrMsg)},
+		})
+		require.NoError(t, err)
+
+		_, err = lookup.QueryConnections(&QueryParams{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -30,21 +31,39 @@ const (
 	errMsgInvalidKey = "invalid key"
 )
 
+// RecorderOpt configures the connection recorder.
+type RecorderOpt func(r *Recorder)
+
+// WithProtocolStateTTL sets the time the records of the protocol state store expire after, the records of the
+// completed connections being kept in the permanent store. The records don't expire by default.
+func WithProtocolStateTTL(ttl time.Duration) RecorderOpt {
+	return func(r *Recorder) {
+		r.protocolStateTTL = ttl
+	}
+}
+
 // NewRecorder returns new connection recorder.
 // Recorder is read-write connection store which provides
 // write features on top query features from Lookup.
-func NewRecorder(p provider) (*Recorder, error) {
+func NewRecorder(p provider, opts ...RecorderOpt) (*Recorder, error) {
 	lookup, err := NewLookup(p)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new connection recorder : %w", err)
 	}
 
-	return &Recorder{lookup}, nil
+	r := &Recorder{Lookup: lookup}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r, nil
 }
 
 // Recorder is read-write connection store.
 type Recorder struct {
 	*Lookup
+	protocolStateTTL time.Duration
 }
 
 // SaveInvitation saves invitation in permanent store for given key.
@@ -58,18 +77,22 @@ func (c *Recorder) SaveInvitation(id string, invitation interface{}) error {
 }
 
 // SaveConnectionRecord saves given connection records in underlying store.
+// The CreatedTime of the record is set when the record is saved for the first time.
 func (c *Recorder) SaveConnectionRecord(record *Record) error {
-	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-		record, c.protocolStateStore, storage.Tag{
-			Name:  getConnectionKeyPrefix()(""),
-			Value: getConnectionKeyPrefix()(record.ConnectionID),
-		}); err != nil {
+	if record.CreatedTime.IsZero() {
+		record.CreatedTime = time.Now().UTC()
+	}
+
+	tags := recordTags(record)
+
+	if err := c.marshalAndSaveProtocolState(getConnectionKeyPrefix()(record.ConnectionID),
+		record, tags...); err != nil {
 		return fmt.Errorf("save connection record in protocol state store: %w", err)
 	}
 
 	if record.State != "" {
-		err := marshalAndSave(getConnectionStateKeyPrefix()(record.ConnectionID, record.State),
-			record, c.protocolStateStore, storage.Tag{
+		err := c.marshalAndSaveProtocolState(getConnectionStateKeyPrefix()(record.ConnectionID, record.State),
+			record, storage.Tag{
 				Name:  connStateKeyPrefix,
 				Value: getConnectionStateKeyPrefix()(record.ConnectionID),
 			})
@@ -80,10 +103,7 @@ func (c *Recorder) SaveConnectionRecord(record *Record) error {
 
 	if record.State == StateNameCompleted {
 		if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-			record, c.store, storage.Tag{
-				Name:  getConnectionKeyPrefix()(""),
-				Value: getConnectionKeyPrefix()(record.ConnectionID),
-			}); err != nil {
+			record, c.store, tags...); err != nil {
 			return fmt.Errorf("save connection record in permanent store: %w", err)
 		}
 
@@ -121,7 +141,7 @@ func (c *Recorder) SaveConnectionRecordWithMappings(record *Record) error {
 // SaveEvent saves event related data for given connection ID
 // TODO connection event data shouldn't be transient [Issues #1029].
 func (c *Recorder) SaveEvent(connectionID string, data []byte) error {
-	return c.protocolStateStore.Put(getEventDataKeyPrefix()(connectionID), data)
+	return c.saveProtocolState(getEventDataKeyPrefix()(connectionID), data)
 }
 
 // SaveNamespaceThreadID saves given namespace, threadID and connection ID mapping in protocol state store.
@@ -140,7 +160,7 @@ func (c *Recorder) SaveNamespaceThreadID(threadID, namespace, connectionID strin
 		return err
 	}
 
-	return c.protocolStateStore.Put(getNamespaceKeyPrefix(prefix)(key), []byte(connectionID))
+	return c.saveProtocolState(getNamespaceKeyPrefix(prefix)(key), []byte(connectionID))
 }
 
 // RemoveConnection removes connection record from the store for given id.
@@ -181,6 +201,12 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 	return nil
 }
 
+// RemoveConnectionStates removes the connection records saved for each state of the connection from the protocol
+// state store, the connection record itself is kept.
+func (c *Recorder) RemoveConnectionStates(connectionID string) error {
+	return removeConnectionsForStates(c, connectionID)
+}
+
 func marshalAndSave(k string, v interface{}, store storage.Store, tags ...storage.Tag) error {
 	bytes, err := json.Marshal(v)
 	if err != nil {
@@ -190,6 +216,25 @@ func marshalAndSave(k string, v interface{}, store storage.Store, tags ...storag
 	return store.Put(k, bytes, tags...)
 }
 
+func (c *Recorder) marshalAndSaveProtocolState(k string, v interface{}, tags ...storage.Tag) error {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	return c.saveProtocolState(k, bytes, tags...)
+}
+
+// saveProtocolState saves the value in the protocol state store, the value expiring after the protocol state TTL if
+// set.
+func (c *Recorder) saveProtocolState(k string, v []byte, tags ...storage.Tag) error {
+	if c.protocolStateTTL > 0 {
+		return storage.PutWithTTL(c.protocolStateStore, k, v, c.protocolStateTTL, tags...)
+	}
+
+	return c.protocolStateStore.Put(k, v, tags...)
+}
+
 // isValidConnection validates connection record.
 func isValidConnection(r *Record) error {
 	if r.ThreadID == "" || r.ConnectionID == "" || r.Namespace == "" {
@@ -12,6 +12,7 @@ package connection
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -295,6 +296,33 @@ func TestConnectionStore_SaveAndGetEventData(t *testing.T) {
 		require.Equal(t, valueStored, valueFound)
 	})
 
+	t.Run("test save event data - expires in protocol state store", func(t *testing.T) {
+		const ttl = 24 * time.Hour
+
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{protocolStateStore: store})
+		require.NoError(t, err)
+
+		err = recorder.SaveEvent(sampleConnID, []byte("sample-event-data"))
+		require.NoError(t, err)
+
+		// the records don't expire by default
+		require.Empty(t, store.Store[getEventDataKeyPrefix()(sampleConnID)].Tags)
+
+		recorder, err = NewRecorder(&mockProvider{protocolStateStore: store}, WithProtocolStateTTL(ttl))
+		require.NoError(t, err)
+
+		err = recorder.SaveEvent(sampleConnID, []byte("sample-event-data"))
+		require.NoError(t, err)
+
+		tags := store.Store[getEventDataKeyPrefix()(sampleConnID)].Tags
+		require.Len(t, tags, 1)
+		require.Equal(t, storage.ExpiryTagName, tags[0].Name)
+		require.False(t, storage.IsExpired(tags, time.Now()))
+		require.True(t, storage.IsExpired(tags, time.Now().Add(ttl+time.Minute)))
+	})
+
 	t.Run("test get invitation - not found scenario", func(t *testing.T) {
 		recorder, err := NewRecorder(&mockProvider{})
 		require.NoError(t, err)
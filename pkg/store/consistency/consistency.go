@@ -0,0 +1,292 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package consistency cross-checks the referential integrity of the agent stores: connections referencing missing
+// DIDs or keys, connection state records of deleted connections and mediator routes of deleted connections.
+// The checker can repair the issues it finds, to recover agents after partial failures.
+package consistency
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+
+var logger = log.New("aries-framework/store/consistency")
+
+// IssueType is the type of a consistency issue.
+type IssueType string
+
+// Consistency issue types.
+const (
+	// MissingDID is a connection referencing a DID that can't be resolved.
+	MissingDID IssueType = "missing-did"
+	// MissingKey is a connection whose DID references a key missing in the KMS.
+	MissingKey IssueType = "missing-key"
+	// OrphanedConnectionState is a connection state record of a deleted connection.
+	OrphanedConnectionState IssueType = "orphaned-connection-state"
+	// OrphanedRoute is a mediator route of a deleted or unusable connection.
+	OrphanedRoute IssueType = "orphaned-route"
+)
+
+// Issue is a consistency issue found in the stores.
+type Issue struct {
+	Type         IssueType `json:"type"`
+	ConnectionID string    `json:"connectionID"`
+	Description  string    `json:"description"`
+	// Repaired is set when the issue has been repaired.
+	Repaired bool `json:"repaired"`
+	// RepairError is the error of the failed repair.
+	RepairError string `json:"repairError,omitempty"`
+}
+
+// Report lists the consistency issues found in the stores.
+type Report struct {
+	Issues []*Issue `json:"issues"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+}
+
+// routes is implemented by the mediator service.
+type routes interface {
+	GetConnections() ([]string, error)
+	Unregister(connID string) error
+}
+
+// Checker checks the consistency of the stores.
+type Checker struct {
+	connections *connection.Recorder
+	vdr         vdrapi.Registry
+	kms         kms.KeyManager
+	routes      routes
+}
+
+// New returns a new consistency checker.
+func New(p provider) (*Checker, error) {
+	connections, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection recorder: %w", err)
+	}
+
+	c := &Checker{
+		connections: connections,
+		vdr:         p.VDRegistry(),
+		kms:         p.KMS(),
+	}
+
+	svc, err := p.Service(mediator.Coordination)
+
+	switch {
+	case errors.Is(err, api.ErrSvcNotFound):
+		// the routes are not checked when the mediator service is not registered.
+	case err != nil:
+		return nil, fmt.Errorf("failed to look up mediator service: %w", err)
+	default:
+		r, ok := svc.(routes)
+		if !ok {
+			return nil, errors.New("cast service to mediator service failed")
+		}
+
+		c.routes = r
+	}
+
+	return c, nil
+}
+
+// Check checks the stores and reports the issues found.
+func (c *Checker) Check() (*Report, error) {
+	report, _, err := c.check()
+
+	return report, err
+}
+
+// Repair checks the stores and repairs the issues found: the connections referencing missing DIDs or keys are
+// removed, as well as the orphaned connection state records and mediator routes. The issues that can't be repaired
+// are reported with their repair error.
+func (c *Checker) Repair() (*Report, error) {
+	report, fixes, err := c.check()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, issue := range report.Issues {
+		if err = fixes[i](); err != nil {
+			logger.Warnf("failed to repair %s issue of connection %s: %s", issue.Type, issue.ConnectionID, err)
+
+			issue.RepairError = err.Error()
+
+			continue
+		}
+
+		issue.Repaired = true
+	}
+
+	return report, nil
+}
+
+// check returns the report and the repair function of each issue.
+func (c *Checker) check() (*Report, []func() error, error) {
+	report := &Report{}
+
+	var fixes []func() error
+
+	addIssue := func(issue *Issue, fix func() error) {
+		report.Issues = append(report.Issues, issue)
+		fixes = append(fixes, fix)
+	}
+
+	records, err := c.connections.QueryConnectionRecords()
+	if err != nil {
+		return nil, nil, fmt.Errorf("query connection records: %w", err)
+	}
+
+	existing := make(map[string]bool, len(records))
+	usable := make(map[string]bool, len(records))
+
+	for _, record := range records {
+		existing[record.ConnectionID] = true
+
+		issue, err := c.checkConnection(record)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if issue == nil {
+			usable[record.ConnectionID] = true
+
+			continue
+		}
+
+		connID := record.ConnectionID
+
+		addIssue(issue, func() error {
+			return c.connections.RemoveConnection(connID)
+		})
+	}
+
+	states, err := c.connections.QueryConnectionStateRecords()
+	if err != nil {
+		return nil, nil, fmt.Errorf("query connection state records: %w", err)
+	}
+
+	orphaned := make(map[string]bool)
+
+	for _, state := range states {
+		connID := state.ConnectionID
+
+		if existing[connID] || orphaned[connID] {
+			continue
+		}
+
+		orphaned[connID] = true
+
+		addIssue(&Issue{
+			Type:         OrphanedConnectionState,
+			ConnectionID: connID,
+			Description:  fmt.Sprintf("connection state records of deleted connection %s", connID),
+		}, func() error {
+			return c.connections.RemoveConnectionStates(connID)
+		})
+	}
+
+	if c.routes == nil {
+		return report, fixes, nil
+	}
+
+	routeConnIDs, err := c.routes.GetConnections()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get mediator connections: %w", err)
+	}
+
+	for _, id := range routeConnIDs {
+		connID := id
+
+		if usable[connID] {
+			continue
+		}
+
+		addIssue(&Issue{
+			Type:         OrphanedRoute,
+			ConnectionID: connID,
+			Description:  fmt.Sprintf("mediator route of deleted or unusable connection %s", connID),
+		}, func() error {
+			return c.routes.Unregister(connID)
+		})
+	}
+
+	return report, fixes, nil
+}
+
+// checkConnection checks the DIDs of the connection can be resolved and the keys of my DID are in the KMS.
+func (c *Checker) checkConnection(record *connection.Record) (*Issue, error) {
+	var myDoc *did.Doc
+
+	for _, id := range []string{record.MyDID, record.TheirDID} {
+		if id == "" {
+			continue
+		}
+
+		docRes, err := c.vdr.Resolve(id)
+		if errors.Is(err, vdrapi.ErrNotFound) {
+			return &Issue{
+				Type:         MissingDID,
+				ConnectionID: record.ConnectionID,
+				Description:  fmt.Sprintf("connection %s references missing DID %s", record.ConnectionID, id),
+			}, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("resolve DID %s of connection %s: %w", id, record.ConnectionID, err)
+		}
+
+		if id == record.MyDID {
+			myDoc = docRes.DIDDocument
+		}
+	}
+
+	if myDoc == nil {
+		return nil, nil
+	}
+
+	for i := range myDoc.VerificationMethod {
+		vm := &myDoc.VerificationMethod[i]
+
+		if vm.Type != ed25519VerificationKey2018 {
+			continue
+		}
+
+		kid, err := localkms.CreateKID(vm.Value, kms.ED25519Type)
+		if err != nil {
+			return nil, fmt.Errorf("create KMS key ID of key %s: %w", vm.ID, err)
+		}
+
+		if _, err = c.kms.Get(kid); err != nil {
+			return &Issue{
+				Type:         MissingKey,
+				ConnectionID: record.ConnectionID,
+				Description: fmt.Sprintf("connection %s references DID %s whose key %s is missing in the KMS: %s",
+					record.ConnectionID, myDoc.ID, vm.ID, err),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
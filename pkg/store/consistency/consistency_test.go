@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consistency
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success without mediator service", func(t *testing.T) {
+		c, err := New(newProvider(t, nil))
+		require.NoError(t, err)
+		require.Nil(t, c.routes)
+	})
+
+	t.Run("success with mediator service", func(t *testing.T) {
+		c, err := New(newProvider(t, &mockRoutes{}))
+		require.NoError(t, err)
+		require.NotNil(t, c.routes)
+	})
+
+	t.Run("error if cannot open the connection store", func(t *testing.T) {
+		p := newProvider(t, nil)
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create connection recorder")
+	})
+
+	t.Run("error if cannot look up the mediator service", func(t *testing.T) {
+		p := newProvider(t, nil)
+		p.ServiceErr = errors.New("test error")
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to look up mediator service")
+	})
+
+	t.Run("error if mediator service has an unexpected type", func(t *testing.T) {
+		p := newProvider(t, nil)
+		p.ServiceErr = nil
+		p.ServiceMap = map[string]interface{}{mediator.Coordination: struct{}{}}
+
+		_, err := New(p)
+		require.EqualError(t, err, "cast service to mediator service failed")
+	})
+}
+
+func TestChecker(t *testing.T) {
+	routes := &mockRoutes{connections: []string{"healthy", "missing-their-did", "deleted"}}
+	p := newProvider(t, routes)
+
+	c, err := New(p)
+	require.NoError(t, err)
+
+	connections, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	saveConnection(t, connections, "healthy", "did:test:alice", "did:test:bob")
+	saveConnection(t, connections, "missing-their-did", "did:test:alice", "did:test:unknown")
+	saveConnection(t, connections, "missing-key", "did:test:carol", "did:test:bob")
+	saveConnection(t, connections, "deleted", "did:test:alice", "did:test:bob")
+
+	// simulates a partial failure while deleting the connection, its state records are left.
+	for _, sp := range []storage.Provider{p.StorageProviderValue, p.ProtocolStateStorageProviderValue} {
+		store, e := sp.OpenStore(connection.Namespace)
+		require.NoError(t, e)
+		require.NoError(t, store.Delete("conn_deleted"))
+	}
+
+	report, err := c.Check()
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 5)
+
+	byType := map[IssueType][]string{}
+	for _, issue := range report.Issues {
+		require.False(t, issue.Repaired)
+		byType[issue.Type] = append(byType[issue.Type], issue.ConnectionID)
+	}
+
+	require.Equal(t, []string{"missing-their-did"}, byType[MissingDID])
+	require.Equal(t, []string{"missing-key"}, byType[MissingKey])
+	require.Equal(t, []string{"deleted"}, byType[OrphanedConnectionState])
+	require.ElementsMatch(t, []string{"missing-their-did", "deleted"}, byType[OrphanedRoute])
+
+	report, err = c.Repair()
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 5)
+
+	for _, issue := range report.Issues {
+		require.True(t, issue.Repaired, issue.Description)
+	}
+
+	require.Equal(t, []string{"healthy"}, routes.connections)
+
+	report, err = c.Check()
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+
+	records, err := connections.QueryConnectionRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "healthy", records[0].ConnectionID)
+}
+
+func TestChecker_Errors(t *testing.T) {
+	t.Run("error if DID resolution fails", func(t *testing.T) {
+		p := newProvider(t, nil)
+		p.VDRegistryValue = &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		connections, err := connection.NewRecorder(p)
+		require.NoError(t, err)
+
+		saveConnection(t, connections, "conn", "did:test:alice", "did:test:bob")
+
+		_, err = c.Check()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve error")
+
+		_, err = c.Repair()
+		require.Error(t, err)
+	})
+
+	t.Run("error if cannot get the mediator connections", func(t *testing.T) {
+		c, err := New(newProvider(t, &mockRoutes{getErr: errors.New("routes error")}))
+		require.NoError(t, err)
+
+		_, err = c.Check()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "routes error")
+	})
+
+	t.Run("repair error is reported", func(t *testing.T) {
+		c, err := New(newProvider(t, &mockRoutes{
+			connections:   []string{"deleted"},
+			unregisterErr: errors.New("unregister error"),
+		}))
+		require.NoError(t, err)
+
+		report, err := c.Repair()
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 1)
+		require.False(t, report.Issues[0].Repaired)
+		require.Equal(t, "unregister error", report.Issues[0].RepairError)
+	})
+}
+
+func newProvider(t *testing.T, routes *mockRoutes) *mockprovider.Provider {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	docs := map[string]*did.Doc{
+		"did:test:alice": newDoc(t, km, "did:test:alice", true),
+		"did:test:bob":   newDoc(t, km, "did:test:bob", true),
+		"did:test:carol": newDoc(t, km, "did:test:carol", false),
+	}
+
+	p := &mockprovider.Provider{
+		KMSValue: km,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc, ok := docs[didID]
+				if !ok {
+					return nil, fmt.Errorf("resolve %s: %w", didID, vdrapi.ErrNotFound)
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		ServiceErr:                        api.ErrSvcNotFound,
+	}
+
+	if routes != nil {
+		p.ServiceErr = nil
+		p.ServiceMap = map[string]interface{}{mediator.Coordination: routes}
+	}
+
+	return p
+}
+
+func newDoc(t *testing.T, km kms.KeyManager, id string, keyInKMS bool) *did.Doc {
+	t.Helper()
+
+	var pubKey []byte
+
+	if keyInKMS {
+		var err error
+
+		_, pubKey, err = km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+	} else {
+		pubKey = make([]byte, 32)
+	}
+
+	vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, id, pubKey)
+
+	return &did.Doc{ID: id, VerificationMethod: []did.VerificationMethod{*vm}}
+}
+
+func saveConnection(t *testing.T, connections *connection.Recorder, connID, myDID, theirDID string) {
+	t.Helper()
+
+	require.NoError(t, connections.SaveConnectionRecordWithMappings(&connection.Record{
+		ConnectionID: connID,
+		ThreadID:     "thid-" + connID,
+		Namespace:    connection.MyNSPrefix,
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+}
+
+type mockRoutes struct {
+	connections   []string
+	getErr        error
+	unregisterErr error
+}
+
+func (m *mockRoutes) GetConnections() ([]string, error) {
+	return m.connections, m.getErr
+}
+
+func (m *mockRoutes) Unregister(connID string) error {
+	if m.unregisterErr != nil {
+		return m.unregisterErr
+	}
+
+	for i, id := range m.connections {
+		if id == connID {
+			m.connections = append(m.connections[:i], m.connections[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return mediator.ErrRouterNotRegistered
+}
@@ -0,0 +1,301 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DefaultCheckpointInterval is the default interval between the checkpoints of the Checkpointer.
+	DefaultCheckpointInterval = time.Hour
+
+	lastCheckpointKey     = "last_checkpoint"
+	checkpointKeyTemplate = "checkpoint_%020d"
+)
+
+var logger = log.New("aries-framework/store/eventjournal")
+
+// ErrInvalidCheckpoint is returned when the events of the journal don't match the digest of a checkpoint.
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// Checkpoint is an RFC 3161 time-stamp of the events of the journal up to a sequence number, as long-term evidence
+// that the events were journaled at the time of the checkpoint and haven't been altered since. The digest chains the
+// events since the previous checkpoint to the digest of the previous checkpoint, so that a checkpoint covers all the
+// events which precede it.
+type Checkpoint struct {
+	Sequence         uint64               `json:"sequence"`
+	PreviousSequence uint64               `json:"previousSequence,omitempty"`
+	Digest           []byte               `json:"digest"`
+	Timestamp        *timestamp.Timestamp `json:"timestamp"`
+}
+
+// Checkpoint time-stamps the events appended since the last checkpoint with the stamper, returning the new checkpoint
+// or nil if no event was appended. The checkpoints aren't deleted by the retention policies, but the events covered by
+// a checkpoint must be kept to verify it.
+func (j *Journal) Checkpoint(stamper timestamp.Stamper) (*Checkpoint, error) {
+	j.cpMu.Lock()
+	defer j.cpMu.Unlock()
+
+	previous, err := j.lastCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := j.lastSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{Sequence: last}
+
+	var previousDigest []byte
+
+	if previous != nil {
+		checkpoint.PreviousSequence = previous.Sequence
+		previousDigest = previous.Digest
+	}
+
+	if last == checkpoint.PreviousSequence {
+		return nil, nil
+	}
+
+	checkpoint.Digest, err = j.digest(previousDigest, checkpoint.PreviousSequence, last)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint.Timestamp, err = stamper.Timestamp(checkpoint.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to time-stamp checkpoint: %w", err)
+	}
+
+	src, err := json.Marshal(checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	err = j.store.Batch([]storage.Operation{
+		{Key: checkpointKey(last), Value: src},
+		{Key: lastCheckpointKey, Value: []byte(strconv.FormatUint(last, 10))},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// Checkpoints returns the checkpoints of the journal, in the order they were made.
+func (j *Journal) Checkpoints() ([]*Checkpoint, error) {
+	checkpoint, err := j.lastCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoints []*Checkpoint
+
+	for checkpoint != nil {
+		checkpoints = append([]*Checkpoint{checkpoint}, checkpoints...)
+
+		if checkpoint.PreviousSequence == 0 {
+			break
+		}
+
+		checkpoint, err = j.getCheckpoint(checkpoint.PreviousSequence)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return checkpoints, nil
+}
+
+// VerifyCheckpoint checks that the events of the journal match the digest of the checkpoint and that the digest is
+// time-stamped by a TSA certificate chaining up to the roots, nil roots using the system roots.
+func (j *Journal) VerifyCheckpoint(checkpoint *Checkpoint, roots *x509.CertPool) error {
+	var previousDigest []byte
+
+	if checkpoint.PreviousSequence != 0 {
+		previous, err := j.getCheckpoint(checkpoint.PreviousSequence)
+		if err != nil {
+			return err
+		}
+
+		previousDigest = previous.Digest
+	}
+
+	digest, err := j.digest(previousDigest, checkpoint.PreviousSequence, checkpoint.Sequence)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest, checkpoint.Digest) {
+		return fmt.Errorf("%w: the events %d to %d don't match the digest", ErrInvalidCheckpoint,
+			checkpoint.PreviousSequence+1, checkpoint.Sequence)
+	}
+
+	if checkpoint.Timestamp == nil {
+		return fmt.Errorf("%w: missing timestamp", ErrInvalidCheckpoint)
+	}
+
+	if err = checkpoint.Timestamp.Verify(digest, roots); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCheckpoint, err)
+	}
+
+	return nil
+}
+
+// digest chains the events following the from sequence number up to the to sequence number to the previous digest.
+// The events deleted by a retention policy are skipped.
+func (j *Journal) digest(previous []byte, from, to uint64) ([]byte, error) {
+	h := sha256.New()
+	h.Write(previous) // nolint: errcheck, gosec
+
+	for seq := from + 1; seq <= to; seq++ {
+		src, err := j.store.Get(eventKey(seq))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event %d: %w", seq, err)
+		}
+
+		h.Write([]byte(eventKey(seq))) // nolint: errcheck, gosec
+		h.Write(src)                   // nolint: errcheck, gosec
+	}
+
+	return h.Sum(nil), nil
+}
+
+func (j *Journal) lastCheckpoint() (*Checkpoint, error) {
+	src, err := j.store.Get(lastCheckpointKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last checkpoint: %w", err)
+	}
+
+	seq, err := strconv.ParseUint(string(src), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse last checkpoint: %w", err)
+	}
+
+	return j.getCheckpoint(seq)
+}
+
+func (j *Journal) getCheckpoint(seq uint64) (*Checkpoint, error) {
+	src, err := j.store.Get(checkpointKey(seq))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint %d: %w", seq, err)
+	}
+
+	checkpoint := &Checkpoint{}
+
+	if err = json.Unmarshal(src, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint %d: %w", seq, err)
+	}
+
+	return checkpoint, nil
+}
+
+func checkpointKey(seq uint64) string {
+	return fmt.Sprintf(checkpointKeyTemplate, seq)
+}
+
+// Checkpointer checkpoints the journal in the background at an interval.
+type Checkpointer struct {
+	journal  *Journal
+	stamper  timestamp.Stamper
+	interval time.Duration
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCheckpointer returns a new Checkpointer of the journal, started with Start. The checkpoints are made every
+// interval, DefaultCheckpointInterval if the interval isn't positive.
+func NewCheckpointer(journal *Journal, stamper timestamp.Stamper, interval time.Duration) *Checkpointer {
+	if interval <= 0 {
+		interval = DefaultCheckpointInterval
+	}
+
+	return &Checkpointer{
+		journal:  journal,
+		stamper:  stamper,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts checkpointing the journal in the background.
+func (c *Checkpointer) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started || c.stopped {
+		return
+	}
+
+	c.started = true
+
+	go c.run()
+}
+
+// Stop stops the background checkpoints.
+func (c *Checkpointer) Stop() {
+	c.mu.Lock()
+
+	if !c.stopped {
+		c.stopped = true
+		close(c.stop)
+	}
+
+	started := c.started
+
+	c.mu.Unlock()
+
+	if started {
+		<-c.done
+	}
+}
+
+func (c *Checkpointer) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.journal.Checkpoint(c.stamper); err != nil {
+				logger.Errorf("event journal checkpoint: %s", err)
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/timestamp"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+// mockStamper records the time-stamped data, its tokens being the digests of the data.
+type mockStamper struct {
+	stamped [][]byte
+	err     error
+}
+
+func (s *mockStamper) Timestamp(data []byte) (*timestamp.Timestamp, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.stamped = append(s.stamped, data)
+	token := sha256.Sum256(data)
+
+	return &timestamp.Timestamp{Token: token[:], Time: time.Now().UTC()}, nil
+}
+
+func appendEvents(t *testing.T, j *Journal, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		_, err := j.Append(service.StateMsg{ProtocolName: "issue-credential", StateID: fmt.Sprintf("state-%d", i)})
+		require.NoError(t, err)
+	}
+}
+
+func TestJournal_Checkpoint(t *testing.T) {
+	t.Run("checkpoints chain the events", func(t *testing.T) {
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		stamper := &mockStamper{}
+
+		checkpoint, err := j.Checkpoint(stamper)
+		require.NoError(t, err)
+		require.Nil(t, checkpoint)
+
+		appendEvents(t, j, 3)
+
+		first, err := j.Checkpoint(stamper)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), first.Sequence)
+		require.Zero(t, first.PreviousSequence)
+		require.Equal(t, [][]byte{first.Digest}, stamper.stamped)
+
+		checkpoint, err = j.Checkpoint(stamper)
+		require.NoError(t, err)
+		require.Nil(t, checkpoint)
+
+		appendEvents(t, j, 2)
+
+		second, err := j.Checkpoint(stamper)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), second.Sequence)
+		require.Equal(t, uint64(3), second.PreviousSequence)
+		require.NotEqual(t, first.Digest, second.Digest)
+
+		checkpoints, err := j.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []*Checkpoint{first, second}, checkpoints)
+	})
+
+	t.Run("no checkpoints", func(t *testing.T) {
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		checkpoints, err := j.Checkpoints()
+		require.NoError(t, err)
+		require.Empty(t, checkpoints)
+	})
+
+	t.Run("time-stamp error", func(t *testing.T) {
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		appendEvents(t, j, 1)
+
+		_, err = j.Checkpoint(&mockStamper{err: errors.New("TSA unavailable")})
+		require.EqualError(t, err, "failed to time-stamp checkpoint: TSA unavailable")
+
+		checkpoints, err := j.Checkpoints()
+		require.NoError(t, err)
+		require.Empty(t, checkpoints)
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+
+		j, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.NoError(t, err)
+
+		appendEvents(t, j, 1)
+
+		provider.Store.ErrBatch = errors.New("batch error")
+
+		_, err = j.Checkpoint(&mockStamper{})
+		require.EqualError(t, err, "failed to save checkpoint: batch error")
+
+		require.NoError(t, provider.Store.Put(lastCheckpointKey, []byte("invalid")))
+
+		_, err = j.Checkpoint(&mockStamper{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse last checkpoint")
+
+		require.NoError(t, provider.Store.Put(lastCheckpointKey, []byte("1")))
+
+		_, err = j.Checkpoints()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get checkpoint 1")
+
+		require.NoError(t, provider.Store.Put(checkpointKey(1), []byte("invalid")))
+
+		_, err = j.Checkpoints()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal checkpoint 1")
+
+		provider.Store.ErrGet = errors.New("get error")
+
+		_, err = j.Checkpoint(&mockStamper{})
+		require.EqualError(t, err, "failed to get last checkpoint: get error")
+	})
+}
+
+func TestJournal_VerifyCheckpoint(t *testing.T) {
+	provider := mockstorage.NewMockStoreProvider()
+
+	j, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+	require.NoError(t, err)
+
+	appendEvents(t, j, 3)
+
+	_, err = j.Checkpoint(&mockStamper{})
+	require.NoError(t, err)
+
+	appendEvents(t, j, 2)
+
+	checkpoint, err := j.Checkpoint(&mockStamper{})
+	require.NoError(t, err)
+
+	t.Run("the events match the digest", func(t *testing.T) {
+		// the mock tokens aren't RFC 3161 tokens
+		err := j.VerifyCheckpoint(checkpoint, nil)
+		require.True(t, errors.Is(err, ErrInvalidCheckpoint))
+		require.Contains(t, err.Error(), "invalid time-stamp token")
+	})
+
+	t.Run("missing timestamp", func(t *testing.T) {
+		err := j.VerifyCheckpoint(&Checkpoint{
+			Sequence:         checkpoint.Sequence,
+			PreviousSequence: checkpoint.PreviousSequence,
+			Digest:           checkpoint.Digest,
+		}, nil)
+		require.EqualError(t, err, "invalid checkpoint: missing timestamp")
+	})
+
+	t.Run("altered event", func(t *testing.T) {
+		src, err := provider.Store.Get(eventKey(4))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, provider.Store.Put(eventKey(4), src))
+		}()
+
+		require.NoError(t, provider.Store.Put(eventKey(4), []byte(`{"sequence":4}`)))
+
+		err = j.VerifyCheckpoint(checkpoint, nil)
+		require.EqualError(t, err, "invalid checkpoint: the events 4 to 5 don't match the digest")
+	})
+
+	t.Run("deleted event", func(t *testing.T) {
+		src, err := provider.Store.Get(eventKey(1))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, provider.Store.Put(eventKey(1), src))
+		}()
+
+		require.NoError(t, provider.Store.Delete(eventKey(1)))
+
+		first, err := j.Checkpoints()
+		require.NoError(t, err)
+
+		err = j.VerifyCheckpoint(first[0], nil)
+		require.EqualError(t, err, "invalid checkpoint: the events 1 to 3 don't match the digest")
+	})
+
+	t.Run("missing previous checkpoint", func(t *testing.T) {
+		err := j.VerifyCheckpoint(&Checkpoint{Sequence: 5, PreviousSequence: 4}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get checkpoint 4")
+	})
+}
+
+func TestCheckpointer(t *testing.T) {
+	j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	appendEvents(t, j, 1)
+
+	c := NewCheckpointer(j, &mockStamper{}, time.Millisecond)
+	c.Start()
+	c.Start()
+
+	require.Eventually(t, func() bool {
+		checkpoints, err := j.Checkpoints()
+
+		return err == nil && len(checkpoints) == 1
+	}, time.Second, time.Millisecond)
+
+	c.Stop()
+	c.Stop()
+
+	NewCheckpointer(j, &mockStamper{err: errors.New("TSA unavailable")}, 0).Stop()
+}
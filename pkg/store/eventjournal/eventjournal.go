@@ -0,0 +1,199 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventjournal keeps a journal of the state events emitted by the protocol services, numbered with increasing
+// sequence numbers, so that the consumers which were offline can replay the events they missed from their last
+// sequence number (the cursor) instead of reconciling the full state of the agent.
+package eventjournal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the event journal store.
+	StoreName = "eventjournal"
+
+	// PreState is the type of the events emitted before a state transition.
+	PreState = "pre_state"
+	// PostState is the type of the events emitted after a state transition.
+	PostState = "post_state"
+
+	// DefaultReplayLimit is the number of events replayed when no limit is given.
+	DefaultReplayLimit = 100
+	// MaxReplayLimit is the maximum number of events replayed at once.
+	MaxReplayLimit = 1000
+
+	lastSequenceKey  = "last_sequence"
+	eventKeyTemplate = "event_%020d"
+)
+
+// Record is a state event of the journal.
+type Record struct {
+	Sequence     uint64                 `json:"sequence"`
+	Time         time.Time              `json:"time"`
+	ProtocolName string                 `json:"protocolName"`
+	Type         string                 `json:"type"`
+	StateID      string                 `json:"stateID"`
+	Message      service.DIDCommMsgMap  `json:"message,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Journal keeps the state events emitted by the protocol services. The events are appended by a single journal per
+// store, the other journals of the store only replay them. The records are tagged for the retention policies, see
+// the retention package, so that the old events can be deleted with a policy of the StoreName store.
+type Journal struct {
+	store storage.Store
+	last  uint64
+	mu    sync.Mutex
+	cpMu  sync.Mutex
+	now   func() time.Time
+}
+
+// New returns a new event journal.
+func New(p provider) (*Journal, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event journal store: %w", err)
+	}
+
+	err = p.StorageProvider().SetStoreConfig(StoreName,
+		storage.StoreConfiguration{TagNames: []string{retention.UpdatedTagName, retention.TerminalTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set event journal store config: %w", err)
+	}
+
+	j := &Journal{store: store, now: time.Now}
+
+	j.last, err = j.lastSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Append appends the state event to the journal, returning its record.
+func (j *Journal) Append(msg service.StateMsg) (*Record, error) {
+	record := &Record{
+		ProtocolName: msg.ProtocolName,
+		Type:         PreState,
+		StateID:      msg.StateID,
+	}
+
+	if msg.Type == service.PostState {
+		record.Type = PostState
+	}
+
+	if msg.Msg != nil {
+		record.Message = msg.Msg.Clone()
+	}
+
+	if msg.Properties != nil {
+		record.Properties = msg.Properties.All()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	record.Sequence = j.last + 1
+	record.Time = j.now().UTC()
+
+	src, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	err = j.store.Batch([]storage.Operation{
+		{Key: eventKey(record.Sequence), Value: src, Tags: retention.Tags(true)},
+		{Key: lastSequenceKey, Value: []byte(strconv.FormatUint(record.Sequence, 10))},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save event: %w", err)
+	}
+
+	j.last = record.Sequence
+
+	return record, nil
+}
+
+// Replay returns the events following the cursor, the sequence number of the last event the consumer received (zero
+// to replay from the first event), in the order they were appended. At most limit events are returned,
+// DefaultReplayLimit if limit isn't positive, and never more than MaxReplayLimit. The events deleted by a retention
+// policy are skipped.
+func (j *Journal) Replay(cursor uint64, limit int) ([]*Record, error) {
+	if limit <= 0 {
+		limit = DefaultReplayLimit
+	}
+
+	if limit > MaxReplayLimit {
+		limit = MaxReplayLimit
+	}
+
+	last, err := j.lastSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*Record{}
+
+	for seq := cursor + 1; seq <= last && len(records) < limit; seq++ {
+		src, err := j.store.Get(eventKey(seq))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event %d: %w", seq, err)
+		}
+
+		var record Record
+
+		if err := json.Unmarshal(src, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event %d: %w", seq, err)
+		}
+
+		records = append(records, &record)
+	}
+
+	return records, nil
+}
+
+// lastSequence returns the sequence number of the last event appended to the store.
+func (j *Journal) lastSequence() (uint64, error) {
+	src, err := j.store.Get(lastSequenceKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last event sequence: %w", err)
+	}
+
+	last, err := strconv.ParseUint(string(src), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse last event sequence: %w", err)
+	}
+
+	return last, nil
+}
+
+func eventKey(seq uint64) string {
+	return fmt.Sprintf(eventKeyTemplate, seq)
+}
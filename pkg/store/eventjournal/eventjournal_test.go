@@ -0,0 +1,244 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventjournal
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/retention"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+		require.NotNil(t, j)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}})
+		require.EqualError(t, err, "failed to open event journal store: open error")
+	})
+
+	t.Run("set store config error", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.ErrSetStoreConfig = errors.New("config error")
+
+		_, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.EqualError(t, err, "failed to set event journal store config: config error")
+	})
+
+	t.Run("last sequence errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrGet = errors.New("get error")
+
+		_, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.EqualError(t, err, "failed to get last event sequence: get error")
+
+		provider = mockstorage.NewMockStoreProvider()
+		require.NoError(t, provider.Store.Put(lastSequenceKey, []byte("invalid")))
+
+		_, err = New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse last event sequence")
+	})
+}
+
+func TestJournal_AppendReplay(t *testing.T) {
+	t.Run("append and replay from a cursor", func(t *testing.T) {
+		provider := &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+
+		j, err := New(provider)
+		require.NoError(t, err)
+
+		now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+		j.now = func() time.Time { return now }
+
+		record, err := j.Append(service.StateMsg{
+			ProtocolName: "present-proof",
+			Type:         service.PreState,
+			StateID:      "request-sent",
+			Msg:          service.DIDCommMsgMap{"@id": "msg-1"},
+			Properties:   properties{"piid": "piid-1"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), record.Sequence)
+		require.Equal(t, now, record.Time)
+		require.Equal(t, "present-proof", record.ProtocolName)
+		require.Equal(t, PreState, record.Type)
+		require.Equal(t, "request-sent", record.StateID)
+		require.Equal(t, map[string]interface{}{"piid": "piid-1"}, record.Properties)
+		require.Equal(t, "msg-1", record.Message.ID())
+
+		for i := 2; i <= 5; i++ {
+			record, err = j.Append(service.StateMsg{
+				ProtocolName: "present-proof",
+				Type:         service.PostState,
+				StateID:      fmt.Sprintf("state-%d", i),
+			})
+			require.NoError(t, err)
+			require.Equal(t, uint64(i), record.Sequence)
+			require.Equal(t, PostState, record.Type)
+		}
+
+		records, err := j.Replay(0, 0)
+		require.NoError(t, err)
+		require.Len(t, records, 5)
+		require.Equal(t, "piid-1", records[0].Properties["piid"])
+
+		records, err = j.Replay(2, 2)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, uint64(3), records[0].Sequence)
+		require.Equal(t, uint64(4), records[1].Sequence)
+
+		records, err = j.Replay(5, 10)
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		// a new journal of the store continues the sequence and replays the events of the other journal
+		other, err := New(provider)
+		require.NoError(t, err)
+
+		record, err = other.Append(service.StateMsg{ProtocolName: "issue-credential", StateID: "done"})
+		require.NoError(t, err)
+		require.Equal(t, uint64(6), record.Sequence)
+
+		records, err = j.Replay(4, 0)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, "issue-credential", records[1].ProtocolName)
+	})
+
+	t.Run("events deleted by a retention policy are skipped", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		j, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+			require.NoError(t, err)
+		}
+
+		reaper, err := retention.NewReaper(provider, map[string]retention.Policy{StoreName: retention.KeepFor(time.Hour)})
+		require.NoError(t, err)
+
+		deleted, err := reaper.Reap(time.Now().Add(2 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 3, deleted)
+
+		_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+		require.NoError(t, err)
+
+		records, err := j.Replay(0, 0)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, uint64(4), records[0].Sequence)
+	})
+
+	t.Run("replay limit is capped", func(t *testing.T) {
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		for i := 0; i < MaxReplayLimit+1; i++ {
+			_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+			require.NoError(t, err)
+		}
+
+		records, err := j.Replay(0, MaxReplayLimit+1)
+		require.NoError(t, err)
+		require.Len(t, records, MaxReplayLimit)
+	})
+
+	t.Run("append errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+
+		j, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.NoError(t, err)
+
+		provider.Store.ErrBatch = errors.New("batch error")
+
+		_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+		require.EqualError(t, err, "failed to save event: batch error")
+
+		_, err = j.Append(service.StateMsg{
+			ProtocolName: "present-proof",
+			Properties:   properties{"invalid": make(chan int)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to marshal event")
+	})
+
+	t.Run("replay errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+
+		j, err := New(&mockprovider.Provider{StorageProviderValue: provider})
+		require.NoError(t, err)
+
+		_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+		require.NoError(t, err)
+
+		require.NoError(t, provider.Store.Put(eventKey(1), []byte("invalid")))
+
+		_, err = j.Replay(0, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal event 1")
+
+		provider.Store.ErrGet = errors.New("get error")
+
+		_, err = j.Replay(0, 0)
+		require.EqualError(t, err, "failed to get last event sequence: get error")
+	})
+
+	t.Run("replay get event error", func(t *testing.T) {
+		store := &getErrorStore{Store: &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}}
+
+		j, err := New(&mockprovider.Provider{StorageProviderValue: mockstorage.NewCustomMockStoreProvider(store)})
+		require.NoError(t, err)
+
+		_, err = j.Append(service.StateMsg{ProtocolName: "present-proof", StateID: "done"})
+		require.NoError(t, err)
+
+		store.errGet = errors.New("get error")
+
+		_, err = j.Replay(0, 0)
+		require.EqualError(t, err, "failed to get event 1: get error")
+	})
+}
+
+type properties map[string]interface{}
+
+func (p properties) All() map[string]interface{} {
+	return p
+}
+
+// getErrorStore fails to get the events.
+type getErrorStore struct {
+	storage.Store
+	errGet error
+}
+
+func (s *getErrorStore) Get(key string) ([]byte, error) {
+	if key != lastSequenceKey && s.errGet != nil {
+		return nil, s.errGet
+	}
+
+	return s.Store.Get(key)
+}
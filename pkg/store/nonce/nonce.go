@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package nonce keeps the nonces used by the agent, such as the IDs of the invitations accepted by other parties or
+// the challenges of the presentations received, so that they are only accepted once.
+package nonce
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	commonlock "github.com/hyperledger/aries-framework-go/pkg/common/lock"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the used nonce store.
+	StoreName = "nonce"
+
+	// DefaultTTL is the time the used nonces are kept by default.
+	DefaultTTL = 30 * 24 * time.Hour
+
+	// InvitationScope is the scope of the IDs of the invitations accepted by other parties.
+	InvitationScope = "invitation"
+	// ChallengeScope is the scope of the challenges of the presentations received.
+	ChallengeScope = "challenge"
+)
+
+// ErrReplay is returned when a nonce was already used.
+var ErrReplay = errors.New("nonce was already used")
+
+type provider interface {
+	StorageProvider() storage.Provider
+	LockService() lock.Service
+}
+
+// Store keeps the used nonces until their TTL has elapsed. The nonces are scoped, the same value can be used once in
+// each scope.
+type Store struct {
+	store       storage.Store
+	lockService lock.Service
+	ttl         time.Duration
+}
+
+// New returns a new used nonce store keeping the nonces for the given TTL, DefaultTTL if zero. The nonces are checked
+// and used under a lock of the lock service, so that a nonce is only accepted once by the instances sharing the
+// storage and the lock service.
+func New(p provider, ttl time.Duration) (*Store, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open nonce store: %w", err)
+	}
+
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	lockService := p.LockService()
+	if lockService == nil {
+		lockService = commonlock.NewLocal()
+	}
+
+	return &Store{store: store, lockService: lockService, ttl: ttl}, nil
+}
+
+// Use marks the nonce of the scope as used, it returns an error wrapping ErrReplay if the nonce was already used.
+func (s *Store) Use(scope, nonce string) error {
+	if nonce == "" {
+		return errors.New("nonce is empty")
+	}
+
+	key := scope + "_" + nonce
+
+	return commonlock.Do(s.lockService, StoreName+"_"+key, func() error {
+		_, err := s.store.Get(key)
+		if err == nil {
+			return fmt.Errorf("%s %s: %w", scope, nonce, ErrReplay)
+		}
+
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("get nonce: %w", err)
+		}
+
+		if err = storage.PutWithTTL(s.store, key, []byte(nonce), s.ttl); err != nil {
+			return fmt.Errorf("save nonce: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// IsUsed returns true if the nonce of the scope was used.
+func (s *Store) IsUsed(scope, nonce string) (bool, error) {
+	_, err := s.store.Get(scope + "_" + nonce)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("get nonce: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nonce
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/lock"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := New(&mockProvider{storageProvider: mem.NewProvider()}, 0)
+		require.NoError(t, err)
+		require.Equal(t, DefaultTTL, s.ttl)
+	})
+
+	t.Run("error if cannot open the store", func(t *testing.T) {
+		_, err := New(&mockProvider{
+			storageProvider: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("test error")},
+		}, time.Hour)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open nonce store")
+	})
+}
+
+func TestStore_Use(t *testing.T) {
+	t.Run("nonce is used once", func(t *testing.T) {
+		s := newStore(t, time.Hour)
+
+		used, err := s.IsUsed(InvitationScope, "invitation-1")
+		require.NoError(t, err)
+		require.False(t, used)
+
+		require.NoError(t, s.Use(InvitationScope, "invitation-1"))
+
+		used, err = s.IsUsed(InvitationScope, "invitation-1")
+		require.NoError(t, err)
+		require.True(t, used)
+
+		err = s.Use(InvitationScope, "invitation-1")
+		require.True(t, errors.Is(err, ErrReplay))
+		require.Contains(t, err.Error(), "invitation invitation-1")
+
+		// the nonces are scoped
+		require.NoError(t, s.Use(ChallengeScope, "invitation-1"))
+		require.NoError(t, s.Use(InvitationScope, "invitation-2"))
+	})
+
+	t.Run("nonce is used once by concurrent callers", func(t *testing.T) {
+		s := newStore(t, time.Hour)
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			success int
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if s.Use(ChallengeScope, "challenge") == nil {
+					mu.Lock()
+					success++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, 1, success)
+	})
+
+	t.Run("nonce can be used again once expired", func(t *testing.T) {
+		s := newStore(t, time.Nanosecond)
+
+		require.NoError(t, s.Use(InvitationScope, "invitation-1"))
+		require.NoError(t, s.Use(InvitationScope, "invitation-1"))
+	})
+
+	t.Run("error if the nonce is empty", func(t *testing.T) {
+		s := newStore(t, time.Hour)
+
+		require.EqualError(t, s.Use(InvitationScope, ""), "nonce is empty")
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		s, err := New(&mockProvider{storageProvider: &mockstorage.MockStoreProvider{Store: store}}, 0)
+		require.NoError(t, err)
+
+		store.ErrPut = errors.New("put error")
+
+		err = s.Use(InvitationScope, "invitation-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "save nonce: put error")
+
+		store.ErrGet = errors.New("get error")
+
+		err = s.Use(InvitationScope, "invitation-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get nonce: get error")
+
+		_, err = s.IsUsed(InvitationScope, "invitation-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get nonce: get error")
+	})
+}
+
+func newStore(t *testing.T, ttl time.Duration) *Store {
+	t.Helper()
+
+	s, err := New(&mockProvider{storageProvider: mem.NewProvider()}, ttl)
+	require.NoError(t, err)
+
+	return s
+}
+
+type mockProvider struct {
+	storageProvider storage.Provider
+}
+
+func (p *mockProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *mockProvider) LockService() lock.Service {
+	return nil
+}
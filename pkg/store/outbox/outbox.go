@@ -0,0 +1,329 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package outbox implements a transactional outbox publishing the events of the agent to an event sink, like Kafka
+// or NATS (see the eventsink packages). The events are first saved to the outbox store, then published by a relay in
+// the order they were added, and deleted once the sink acknowledged them. The events which could not be published,
+// because the sink is unavailable, are retried until they are, so that no event is lost when the agent or the sink
+// restarts. The events are delivered at least once: the consumers deduplicate them by ID.
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the outbox store.
+	StoreName = "outbox"
+
+	// DefaultRetryInterval is the interval between the attempts to publish the pending events.
+	DefaultRetryInterval = 5 * time.Second
+
+	// PreState is the type of the events emitted before a state transition.
+	PreState = "pre_state"
+	// PostState is the type of the events emitted after a state transition.
+	PostState = "post_state"
+
+	statesTopicSuffix = "_states"
+
+	nextSequenceKey  = "next_sequence"
+	lastSequenceKey  = "last_sequence"
+	eventKeyTemplate = "event_%020d"
+)
+
+var logger = log.New("aries-framework/store/outbox")
+
+// StateEvent is the payload of the events of the state transitions of the protocol services, published on the
+// <protocol name>_states topic like the webhook notifications.
+type StateEvent struct {
+	ProtocolName string                 `json:"protocolName"`
+	Type         string                 `json:"type"`
+	StateID      string                 `json:"stateID"`
+	Message      service.DIDCommMsgMap  `json:"message,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Opt is an outbox option.
+type Opt func(o *Outbox)
+
+// WithRetryInterval sets the interval between the attempts to publish the pending events (DefaultRetryInterval by
+// default).
+func WithRetryInterval(interval time.Duration) Opt {
+	return func(o *Outbox) {
+		o.retryInterval = interval
+	}
+}
+
+// Outbox saves the events of the agent and publishes them to an event sink. The events are added by a single outbox
+// per store.
+type Outbox struct {
+	store         storage.Store
+	sink          eventsink.Sink
+	retryInterval time.Duration
+	now           func() time.Time
+
+	next, last uint64
+	mu         sync.Mutex
+	publishMu  sync.Mutex
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns a new outbox publishing its events to the sink.
+func New(p provider, sink eventsink.Sink, opts ...Opt) (*Outbox, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox store: %w", err)
+	}
+
+	o := &Outbox{
+		store:         store,
+		sink:          sink,
+		retryInterval: DefaultRetryInterval,
+		now:           time.Now,
+		wake:          make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.last, err = o.sequence(lastSequenceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	o.next, err = o.sequence(nextSequenceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.next == 0 {
+		o.next = 1
+	}
+
+	return o, nil
+}
+
+// Add saves the event to the outbox, to be published by the relay. The ID and time of the event are set when empty.
+func (o *Outbox) Add(event *eventsink.Event) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	if event.Time.IsZero() {
+		event.Time = o.now().UTC()
+	}
+
+	src, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	seq := o.last + 1
+
+	err = o.store.Batch([]storage.Operation{
+		{Key: eventKey(seq), Value: src},
+		{Key: lastSequenceKey, Value: []byte(strconv.FormatUint(seq, 10))},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+
+	o.last = seq
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// AddStateEvent saves the state event of a protocol service to the outbox, see StateEvent.
+func (o *Outbox) AddStateEvent(msg service.StateMsg) error {
+	stateEvent := &StateEvent{
+		ProtocolName: msg.ProtocolName,
+		Type:         PreState,
+		StateID:      msg.StateID,
+	}
+
+	if msg.Type == service.PostState {
+		stateEvent.Type = PostState
+	}
+
+	event := &eventsink.Event{Topic: msg.ProtocolName + statesTopicSuffix}
+
+	if msg.Msg != nil {
+		stateEvent.Message = msg.Msg.Clone()
+		event.Key, _ = msg.Msg.ThreadID() // nolint: errcheck
+	}
+
+	if msg.Properties != nil {
+		stateEvent.Properties = msg.Properties.All()
+	}
+
+	payload, err := json.Marshal(stateEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state event: %w", err)
+	}
+
+	event.Payload = payload
+
+	return o.Add(event)
+}
+
+// Notify saves the notification to the outbox, as an event of the topic. The outbox can so be the notifier of the
+// controller, so that the webhook notifications are published to the event sink as well.
+func (o *Outbox) Notify(topic string, message []byte) error {
+	return o.Add(&eventsink.Event{Topic: topic, Payload: message})
+}
+
+// Pending returns the number of events not published yet.
+func (o *Outbox) Pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return int(o.last + 1 - o.next)
+}
+
+// Flush publishes the pending events, in the order they were added, until the outbox is empty or the sink fails to
+// publish an event. The event which failed is published first by the next flush.
+func (o *Outbox) Flush() error {
+	o.publishMu.Lock()
+	defer o.publishMu.Unlock()
+
+	for {
+		o.mu.Lock()
+		seq, last := o.next, o.last
+		o.mu.Unlock()
+
+		if seq > last {
+			return nil
+		}
+
+		if err := o.publish(seq); err != nil {
+			return err
+		}
+
+		err := o.store.Batch([]storage.Operation{
+			{Key: eventKey(seq)},
+			{Key: nextSequenceKey, Value: []byte(strconv.FormatUint(seq+1, 10))},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete published event %d: %w", seq, err)
+		}
+
+		o.mu.Lock()
+		o.next = seq + 1
+		o.mu.Unlock()
+	}
+}
+
+// Start starts the relay publishing the events as they are added, and retrying the pending events every retry
+// interval.
+func (o *Outbox) Start() {
+	o.stop = make(chan struct{})
+	o.done = make(chan struct{})
+
+	go func() {
+		defer close(o.done)
+
+		ticker := time.NewTicker(o.retryInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := o.Flush(); err != nil {
+				logger.Warnf("failed to publish the outbox events, will retry in %s: %s", o.retryInterval, err)
+			}
+
+			select {
+			case <-o.wake:
+			case <-ticker.C:
+			case <-o.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the relay started by Start. The pending events are published by the next relay.
+func (o *Outbox) Stop() {
+	if o.stop == nil {
+		return
+	}
+
+	close(o.stop)
+	<-o.done
+
+	o.stop = nil
+}
+
+func (o *Outbox) publish(seq uint64) error {
+	src, err := o.store.Get(eventKey(seq))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get event %d: %w", seq, err)
+	}
+
+	var event eventsink.Event
+
+	if err := json.Unmarshal(src, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal event %d: %w", seq, err)
+	}
+
+	if err := o.sink.Publish(&event); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+
+	return nil
+}
+
+func (o *Outbox) sequence(key string) (uint64, error) {
+	src, err := o.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get outbox %s: %w", key, err)
+	}
+
+	seq, err := strconv.ParseUint(string(src), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse outbox %s: %w", key, err)
+	}
+
+	return seq, nil
+}
+
+func eventKey(seq uint64) string {
+	return fmt.Sprintf(eventKeyTemplate, seq)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockeventsink "github.com/hyperledger/aries-framework-go/pkg/mock/eventsink"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/eventsink"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		o, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, &mockeventsink.MockSink{})
+		require.NoError(t, err)
+		require.Equal(t, 0, o.Pending())
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}}, &mockeventsink.MockSink{})
+		require.EqualError(t, err, "failed to open outbox store: open error")
+	})
+
+	t.Run("sequence errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.Store.ErrGet = errors.New("get error")
+
+		_, err := New(&mockprovider.Provider{StorageProviderValue: provider}, &mockeventsink.MockSink{})
+		require.EqualError(t, err, "failed to get outbox last_sequence: get error")
+
+		provider = mockstorage.NewMockStoreProvider()
+		require.NoError(t, provider.Store.Put(nextSequenceKey, []byte("invalid")))
+
+		_, err = New(&mockprovider.Provider{StorageProviderValue: provider}, &mockeventsink.MockSink{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse outbox next_sequence")
+	})
+}
+
+func TestOutbox_Flush(t *testing.T) {
+	t.Run("events published in order", func(t *testing.T) {
+		sink := &mockeventsink.MockSink{}
+
+		o, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, sink)
+		require.NoError(t, err)
+
+		require.NoError(t, o.Notify("didexchange_actions", []byte(`{"id":"1"}`)))
+		require.NoError(t, o.Add(&eventsink.Event{ID: "2", Topic: "custom", Payload: []byte(`{}`)}))
+		require.Equal(t, 2, o.Pending())
+
+		require.NoError(t, o.Flush())
+		require.Equal(t, 0, o.Pending())
+
+		events := sink.Events()
+		require.Len(t, events, 2)
+		require.Equal(t, "didexchange_actions", events[0].Topic)
+		require.NotEmpty(t, events[0].ID)
+		require.False(t, events[0].Time.IsZero())
+		require.JSONEq(t, `{"id":"1"}`, string(events[0].Payload))
+		require.Equal(t, "2", events[1].ID)
+
+		// published events are not published again
+		require.NoError(t, o.Flush())
+		require.Len(t, sink.Events(), 2)
+	})
+
+	t.Run("events retried after a publish error", func(t *testing.T) {
+		sink := &mockeventsink.MockSink{PublishErr: errors.New("sink unavailable")}
+
+		o, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, sink)
+		require.NoError(t, err)
+
+		require.NoError(t, o.Notify("topic", []byte(`{"n":1}`)))
+		require.NoError(t, o.Notify("topic", []byte(`{"n":2}`)))
+
+		err = o.Flush()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sink unavailable")
+		require.Equal(t, 2, o.Pending())
+
+		sink.SetPublishErr(nil)
+
+		require.NoError(t, o.Flush())
+		require.Len(t, sink.Events(), 2)
+		require.JSONEq(t, `{"n":1}`, string(sink.Events()[0].Payload))
+	})
+
+	t.Run("pending events survive a restart", func(t *testing.T) {
+		provider := &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+		sink := &mockeventsink.MockSink{}
+
+		o, err := New(provider, sink)
+		require.NoError(t, err)
+
+		require.NoError(t, o.Notify("topic", []byte(`{"n":1}`)))
+		require.NoError(t, o.Flush())
+		require.NoError(t, o.Notify("topic", []byte(`{"n":2}`)))
+
+		o, err = New(provider, sink)
+		require.NoError(t, err)
+		require.Equal(t, 1, o.Pending())
+
+		require.NoError(t, o.Flush())
+		require.Len(t, sink.Events(), 2)
+		require.JSONEq(t, `{"n":2}`, string(sink.Events()[1].Payload))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+
+		o, err := New(&mockprovider.Provider{StorageProviderValue: provider}, &mockeventsink.MockSink{})
+		require.NoError(t, err)
+
+		provider.Store.ErrBatch = errors.New("batch error")
+		require.EqualError(t, o.Notify("topic", []byte(`{}`)), "failed to save event: batch error")
+
+		provider.Store.ErrBatch = nil
+		require.NoError(t, o.Notify("topic", []byte(`{}`)))
+
+		provider.Store.ErrBatch = errors.New("batch error")
+		require.EqualError(t, o.Flush(), "failed to delete published event 1: batch error")
+
+		provider.Store.ErrGet = errors.New("get error")
+		require.EqualError(t, o.Flush(), "failed to get event 1: get error")
+
+		provider.Store.ErrGet = nil
+		require.NoError(t, provider.Store.Put(eventKey(1), []byte("invalid")))
+		require.Error(t, o.Flush())
+	})
+}
+
+func TestOutbox_AddStateEvent(t *testing.T) {
+	sink := &mockeventsink.MockSink{}
+
+	o, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, sink)
+	require.NoError(t, err)
+
+	msg := service.NewDIDCommMsgMap(struct {
+		ID     string            `json:"@id"`
+		Type   string            `json:"@type"`
+		Thread map[string]string `json:"~thread"`
+	}{ID: "msg-1", Type: "https://didcomm.org/didexchange/1.0/request", Thread: map[string]string{"thid": "thread-1"}})
+
+	require.NoError(t, o.AddStateEvent(service.StateMsg{
+		ProtocolName: "didexchange",
+		Type:         service.PostState,
+		StateID:      "requested",
+		Msg:          msg,
+	}))
+	require.NoError(t, o.AddStateEvent(service.StateMsg{ProtocolName: "didexchange", StateID: "completed"}))
+	require.NoError(t, o.Flush())
+
+	events := sink.Events()
+	require.Len(t, events, 2)
+	require.Equal(t, "didexchange_states", events[0].Topic)
+	require.Equal(t, "thread-1", events[0].Key)
+
+	var stateEvent StateEvent
+
+	require.NoError(t, json.Unmarshal(events[0].Payload, &stateEvent))
+	require.Equal(t, "didexchange", stateEvent.ProtocolName)
+	require.Equal(t, PostState, stateEvent.Type)
+	require.Equal(t, "requested", stateEvent.StateID)
+	require.Equal(t, "msg-1", stateEvent.Message.ID())
+
+	require.NoError(t, json.Unmarshal(events[1].Payload, &stateEvent))
+	require.Equal(t, PreState, stateEvent.Type)
+	require.Empty(t, events[1].Key)
+}
+
+func TestOutbox_Start(t *testing.T) {
+	sink := &mockeventsink.MockSink{PublishErr: errors.New("sink unavailable")}
+
+	o, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()}, sink,
+		WithRetryInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	o.Start()
+	defer o.Stop()
+
+	require.NoError(t, o.Notify("topic", []byte(`{}`)))
+
+	time.Sleep(30 * time.Millisecond)
+	require.Equal(t, 1, o.Pending())
+
+	sink.SetPublishErr(nil)
+
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 1 && o.Pending() == 0
+	}, time.Second, 5*time.Millisecond)
+
+	o.Stop()
+	o.Stop()
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package problemreport keeps the history of the problem reports sent and received by the agent, to diagnose why the
+// protocols with a connection keep failing.
+package problemreport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the problem report store.
+	StoreName = "problemreport"
+
+	connectionIDTag = "connectionID"
+
+	problemReportMsgTypeSuffix = "/problem-report"
+)
+
+var logger = log.New("aries-framework/store/problemreport")
+
+// Direction is the direction of a problem report.
+type Direction string
+
+// Problem report directions.
+const (
+	// Inbound is a problem report received from the other party.
+	Inbound Direction = "inbound"
+	// Outbound is a problem report sent to the other party.
+	Outbound Direction = "outbound"
+)
+
+// Record is a problem report sent or received by the agent. The descriptor fields are read from both the RFC 0035
+// problem reports of DIDComm v1 and the DIDComm v2 problem reports, the raw message being kept in Message.
+type Record struct {
+	ID           string    `json:"id"`
+	ConnectionID string    `json:"connectionID,omitempty"`
+	Direction    Direction `json:"direction"`
+	Time         time.Time `json:"time"`
+	MyDID        string    `json:"myDID,omitempty"`
+	TheirDID     string    `json:"theirDID,omitempty"`
+
+	MsgID          string `json:"msgID,omitempty"`
+	MsgType        string `json:"msgType"`
+	ThreadID       string `json:"threadID,omitempty"`
+	ParentThreadID string `json:"parentThreadID,omitempty"`
+
+	Code          string   `json:"code,omitempty"`
+	Comment       string   `json:"comment,omitempty"`
+	Args          []string `json:"args,omitempty"`
+	Impact        string   `json:"impact,omitempty"`
+	Where         string   `json:"where,omitempty"`
+	WhoRetries    string   `json:"whoRetries,omitempty"`
+	NoticedTime   string   `json:"noticedTime,omitempty"`
+	EscalationURI string   `json:"escalationURI,omitempty"`
+
+	Message json.RawMessage `json:"message"`
+}
+
+// problemReportV1 holds the descriptor fields of an RFC 0035 problem report.
+type problemReportV1 struct {
+	Description struct {
+		Code string `json:"code"`
+		EN   string `json:"en"`
+	} `json:"description"`
+	Impact        string `json:"impact"`
+	Where         string `json:"where"`
+	WhoRetries    string `json:"who_retries"`
+	NoticedTime   string `json:"noticed_time"`
+	EscalationURI string `json:"escalation_uri"`
+}
+
+// problemReportV2 holds the descriptor fields of a DIDComm v2 problem report.
+type problemReportV2 struct {
+	Body struct {
+		Code       string   `json:"code"`
+		Comment    string   `json:"comment"`
+		Args       []string `json:"args"`
+		EscalateTo string   `json:"escalate_to"`
+	} `json:"body"`
+}
+
+type provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Store keeps the problem reports sent and received by the agent.
+type Store struct {
+	store       storage.Store
+	connections *connection.Lookup
+	now         func() time.Time
+}
+
+// New returns a new problem report store.
+func New(p provider) (*Store, error) {
+	store, err := p.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open problem report store: %w", err)
+	}
+
+	err = p.StorageProvider().SetStoreConfig(StoreName,
+		storage.StoreConfiguration{TagNames: []string{connectionIDTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set problem report store config: %w", err)
+	}
+
+	connections, err := connection.NewLookup(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection lookup: %w", err)
+	}
+
+	return &Store{store: store, connections: connections, now: time.Now}, nil
+}
+
+// IsProblemReport checks whether the message type is a problem report type.
+func IsProblemReport(msgType string) bool {
+	return strings.HasSuffix(msgType, problemReportMsgTypeSuffix)
+}
+
+// Save saves the message exchanged between myDID and theirDID when it is a problem report, the other messages are
+// ignored. The problem report is attributed to the connection of the DIDs, if any.
+func (s *Store) Save(msg interface{}, direction Direction, myDID, theirDID string) error {
+	msgMap, ok := msg.(service.DIDCommMsgMap)
+	if !ok {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+
+		msgMap, err = service.ParseDIDCommMsgMap(raw)
+		if err != nil {
+			// not a DIDComm message
+			return nil //nolint:nilerr
+		}
+	}
+
+	if !IsProblemReport(msgMap.Type()) {
+		return nil
+	}
+
+	record, err := newRecord(msgMap)
+	if err != nil {
+		return err
+	}
+
+	record.ID = uuid.New().String()
+	record.Direction = direction
+	record.Time = s.now().UTC()
+	record.MyDID = myDID
+	record.TheirDID = theirDID
+
+	if myDID != "" && theirDID != "" {
+		record.ConnectionID, err = s.connections.GetConnectionIDByDIDs(myDID, theirDID)
+		if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("get connection ID: %w", err)
+		}
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal problem report record: %w", err)
+	}
+
+	err = s.store.Put(record.ID, value, storage.Tag{Name: connectionIDTag, Value: record.ConnectionID})
+	if err != nil {
+		return fmt.Errorf("save problem report record: %w", err)
+	}
+
+	logger.Debugf("saved %s problem report %s of connection [%s]: %s", direction, record.MsgType,
+		record.ConnectionID, record.Code)
+
+	return nil
+}
+
+// Query returns the problem reports of the connection (all the problem reports when the connection ID is empty)
+// saved in the [from, to) time range, sorted by time. Zero times leave the range open.
+func (s *Store) Query(connectionID string, from, to time.Time) ([]*Record, error) {
+	expression := connectionIDTag
+	if connectionID != "" {
+		expression += ":" + connectionID
+	}
+
+	iter, err := s.store.Query(expression)
+	if err != nil {
+		return nil, fmt.Errorf("query problem reports: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	records := []*Record{}
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("query problem reports: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get problem report record: %w", err)
+		}
+
+		record := &Record{}
+
+		if err = json.Unmarshal(value, record); err != nil {
+			return nil, fmt.Errorf("unmarshal problem report record: %w", err)
+		}
+
+		if (from.IsZero() || !record.Time.Before(from)) && (to.IsZero() || record.Time.Before(to)) {
+			records = append(records, record)
+		}
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("query problem reports: %w", err)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	return records, nil
+}
+
+func newRecord(msg service.DIDCommMsgMap) (*Record, error) {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal problem report: %w", err)
+	}
+
+	record := &Record{
+		MsgID:          msg.ID(),
+		MsgType:        msg.Type(),
+		ParentThreadID: msg.ParentThreadID(),
+		Message:        raw,
+	}
+
+	// the message ID is the thread ID of messages without thread
+	record.ThreadID, _ = msg.ThreadID() //nolint:errcheck
+
+	if msg.IsDIDCommV2() {
+		report := problemReportV2{}
+
+		// the raw message is kept when the descriptor fields are malformed
+		if err = msg.Decode(&report); err != nil {
+			logger.Debugf("decode problem report %s: %s", record.MsgID, err)
+
+			return record, nil
+		}
+
+		record.Code = report.Body.Code
+		record.Comment = report.Body.Comment
+		record.Args = report.Body.Args
+		record.EscalationURI = report.Body.EscalateTo
+
+		return record, nil
+	}
+
+	report := problemReportV1{}
+
+	if err = msg.Decode(&report); err != nil {
+		logger.Debugf("decode problem report %s: %s", record.MsgID, err)
+
+		return record, nil
+	}
+
+	record.Code = report.Description.Code
+	record.Comment = report.Description.EN
+	record.Impact = report.Impact
+	record.Where = report.Where
+	record.WhoRetries = report.WhoRetries
+	record.NoticedTime = report.NoticedTime
+	record.EscalationURI = report.EscalationURI
+
+	return record, nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package problemreport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		store, err := New(newProvider())
+		require.NoError(t, err)
+		require.NotNil(t, store)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		p := newProvider()
+		p.storageProvider = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("store error")}
+
+		_, err := New(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open problem report store")
+	})
+}
+
+func TestStore_Save(t *testing.T) {
+	t.Run("save DIDComm v1 problem report", func(t *testing.T) {
+		p := newProvider()
+		connectionID := saveConnection(t, p)
+
+		store, err := New(p)
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{
+			"@id":   "report-1",
+			"@type": "https://didcomm.org/issue-credential/2.0/problem-report",
+			"~thread": map[string]interface{}{
+				"thid":  "thread-1",
+				"pthid": "parent-1",
+			},
+			"description": map[string]interface{}{
+				"code": "issuance-abandoned",
+				"en":   "issuance abandoned",
+			},
+			"impact":         "thread",
+			"where":          "you - issue",
+			"who_retries":    "none",
+			"noticed_time":   "2022-01-01T00:00:00Z",
+			"escalation_uri": "mailto:support@example.com",
+		}
+
+		require.NoError(t, store.Save(msg, Inbound, myDID, theirDID))
+
+		records, err := store.Query(connectionID, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		record := records[0]
+		require.NotEmpty(t, record.ID)
+		require.Equal(t, connectionID, record.ConnectionID)
+		require.Equal(t, Inbound, record.Direction)
+		require.Equal(t, myDID, record.MyDID)
+		require.Equal(t, theirDID, record.TheirDID)
+		require.Equal(t, "report-1", record.MsgID)
+		require.Equal(t, "thread-1", record.ThreadID)
+		require.Equal(t, "parent-1", record.ParentThreadID)
+		require.Equal(t, "issuance-abandoned", record.Code)
+		require.Equal(t, "issuance abandoned", record.Comment)
+		require.Equal(t, "thread", record.Impact)
+		require.Equal(t, "you - issue", record.Where)
+		require.Equal(t, "none", record.WhoRetries)
+		require.Equal(t, "2022-01-01T00:00:00Z", record.NoticedTime)
+		require.Equal(t, "mailto:support@example.com", record.EscalationURI)
+		require.NotEmpty(t, record.Message)
+	})
+
+	t.Run("save DIDComm v2 problem report", func(t *testing.T) {
+		store, err := New(newProvider())
+		require.NoError(t, err)
+
+		msg := struct {
+			ID   string                 `json:"id"`
+			Type string                 `json:"type"`
+			Thid string                 `json:"thid"`
+			Body map[string]interface{} `json:"body"`
+		}{
+			ID:   "report-2",
+			Type: "https://didcomm.org/report-problem/2.0/problem-report",
+			Thid: "thread-2",
+			Body: map[string]interface{}{
+				"code":        "e.p.xfer.cant-use-endpoint",
+				"comment":     "Unable to use the {1} endpoint for {2}.",
+				"args":        []string{"https://agents.r.us/inbox", "did:sov:C805sNYhMrjHiqZDTUASHg"},
+				"escalate_to": "mailto:admin@foo.org",
+			},
+		}
+
+		require.NoError(t, store.Save(msg, Outbound, myDID, theirDID))
+
+		records, err := store.Query("", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		record := records[0]
+		require.Empty(t, record.ConnectionID)
+		require.Equal(t, Outbound, record.Direction)
+		require.Equal(t, "thread-2", record.ThreadID)
+		require.Equal(t, "e.p.xfer.cant-use-endpoint", record.Code)
+		require.Equal(t, "Unable to use the {1} endpoint for {2}.", record.Comment)
+		require.Equal(t, []string{"https://agents.r.us/inbox", "did:sov:C805sNYhMrjHiqZDTUASHg"}, record.Args)
+		require.Equal(t, "mailto:admin@foo.org", record.EscalationURI)
+	})
+
+	t.Run("ignore other messages", func(t *testing.T) {
+		store, err := New(newProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Save(service.DIDCommMsgMap{
+			"@id":   "msg-1",
+			"@type": "https://didcomm.org/basicmessage/1.0/message",
+		}, Inbound, myDID, theirDID))
+		require.NoError(t, store.Save([]byte("not a DIDComm message"), Inbound, myDID, theirDID))
+
+		records, err := store.Query("", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("keep malformed problem report", func(t *testing.T) {
+		store, err := New(newProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Save(service.DIDCommMsgMap{
+			"@id":         "report-3",
+			"@type":       "https://didcomm.org/present-proof/2.0/problem-report",
+			"description": "not an object",
+		}, Inbound, "", ""))
+
+		records, err := store.Query("", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Empty(t, records[0].Code)
+		require.NotEmpty(t, records[0].Message)
+	})
+
+	t.Run("put error", func(t *testing.T) {
+		p := newProvider()
+		p.storageProvider = &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:  make(map[string]mockstorage.DBEntry),
+			ErrPut: errors.New("put error"),
+		}}
+
+		store, err := New(p)
+		require.NoError(t, err)
+
+		err = store.Save(service.DIDCommMsgMap{
+			"@id":   "report-4",
+			"@type": "https://didcomm.org/notification/1.0/problem-report",
+		}, Inbound, "", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+	})
+}
+
+func TestStore_Query(t *testing.T) {
+	p := newProvider()
+	connectionID := saveConnection(t, p)
+
+	store, err := New(p)
+	require.NoError(t, err)
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		now := start.Add(time.Duration(i) * time.Hour)
+		store.now = func() time.Time { return now }
+
+		require.NoError(t, store.Save(service.DIDCommMsgMap{
+			"@id":   "report",
+			"@type": "https://didcomm.org/notification/1.0/problem-report",
+		}, Inbound, myDID, theirDID))
+	}
+
+	store.now = func() time.Time { return start }
+
+	require.NoError(t, store.Save(service.DIDCommMsgMap{
+		"@id":   "report",
+		"@type": "https://didcomm.org/notification/1.0/problem-report",
+	}, Outbound, "did:example:carol", theirDID))
+
+	records, err := store.Query(connectionID, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.True(t, records[0].Time.Before(records[1].Time))
+	require.True(t, records[1].Time.Before(records[2].Time))
+
+	records, err = store.Query(connectionID, start.Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	records, err = store.Query(connectionID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, start, records[0].Time)
+
+	records, err = store.Query("", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	records, err = store.Query("unknown", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
+
+func TestIsProblemReport(t *testing.T) {
+	require.True(t, IsProblemReport("https://didcomm.org/issue-credential/2.0/problem-report"))
+	require.True(t, IsProblemReport("https://didcomm.org/report-problem/2.0/problem-report"))
+	require.False(t, IsProblemReport("https://didcomm.org/issue-credential/2.0/offer-credential"))
+}
+
+func newProvider() *mockProvider {
+	return &mockProvider{
+		storageProvider:              mockstorage.NewMockStoreProvider(),
+		protocolStateStorageProvider: mockstorage.NewMockStoreProvider(),
+	}
+}
+
+// mockProvider avoids the import cycle of the mock provider, which depends on the outbound dispatcher.
+type mockProvider struct {
+	storageProvider              storage.Provider
+	protocolStateStorageProvider storage.Provider
+}
+
+func (p *mockProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *mockProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStorageProvider
+}
+
+func saveConnection(t *testing.T, p *mockProvider) string {
+	t.Helper()
+
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	record := &connection.Record{
+		ConnectionID: "conn-1",
+		State:        connection.StateNameCompleted,
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}
+
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+
+	return record.ConnectionID
+}
@@ -0,0 +1,313 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package retention deletes the records of the protocol stores according to per store retention policies, so that
+// agents running high volumes of protocol instances don't accumulate the records of the completed ones.
+//
+// The protocol services opt in by tagging the records of their protocol instances with the Tags of the instance
+// state, a background Reaper then deletes the records that the policy of their store no longer retains.
+package retention
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// UpdatedTagName is the name of the tag holding the time a record was last updated, as a number of seconds since
+	// the Unix epoch.
+	UpdatedTagName = "retention_updated"
+	// TerminalTagName is the name of the tag marking the records of the protocol instances in a terminal state.
+	TerminalTagName = "retention_terminal"
+
+	defaultReapInterval = time.Hour
+)
+
+var logger = log.New("aries-framework/store/retention")
+
+// Mode is the retention mode of a policy.
+type Mode int
+
+const (
+	// KeepAllMode keeps the records forever.
+	KeepAllMode Mode = iota
+	// KeepForMode keeps the records until they haven't been updated for the policy max age.
+	KeepForMode
+	// KeepTerminalMode keeps the records of the terminated protocol instances, the records of the unfinished ones are
+	// kept until they haven't been updated for the policy max age.
+	KeepTerminalMode
+	// KeepNothingMode deletes the records of the protocol instances once they are terminated.
+	KeepNothingMode
+)
+
+// Policy is the retention policy of the records of a protocol store.
+type Policy struct {
+	Mode   Mode
+	MaxAge time.Duration
+}
+
+// KeepAll returns a policy keeping the records forever, the default when a store has no policy.
+func KeepAll() Policy {
+	return Policy{Mode: KeepAllMode}
+}
+
+// KeepFor returns a policy deleting the records not updated for the given time, whatever the state of their
+// protocol instance, eg. KeepFor(30 * 24 * time.Hour) keeps the records for 30 days.
+func KeepFor(maxAge time.Duration) Policy {
+	return Policy{Mode: KeepForMode, MaxAge: maxAge}
+}
+
+// KeepTerminal returns a policy keeping only the records of the terminated protocol instances: the records of the
+// unfinished (abandoned) instances are deleted once not updated for the given time.
+func KeepTerminal(maxAge time.Duration) Policy {
+	return Policy{Mode: KeepTerminalMode, MaxAge: maxAge}
+}
+
+// KeepNothing returns a policy deleting the records of the protocol instances once they are terminated, the records
+// of the unfinished instances are kept.
+func KeepNothing() Policy {
+	return Policy{Mode: KeepNothingMode}
+}
+
+// Expired returns true if the policy no longer retains the record with the given tags at the given time. Records
+// without the UpdatedTagName tag are always retained.
+func (p Policy) Expired(tags []storage.Tag, now time.Time) bool {
+	updated, terminal, ok := parseTags(tags)
+	if !ok {
+		return false
+	}
+
+	stale := p.MaxAge > 0 && !updated.Add(p.MaxAge).After(now)
+
+	switch p.Mode {
+	case KeepForMode:
+		return stale
+	case KeepTerminalMode:
+		return !terminal && stale
+	case KeepNothingMode:
+		return terminal
+	default:
+		return false
+	}
+}
+
+// Tags returns the tags of a record of a protocol instance updated now, terminal telling whether the instance is in
+// a terminal state.
+func Tags(terminal bool) []storage.Tag {
+	tags := []storage.Tag{{Name: UpdatedTagName, Value: strconv.FormatInt(time.Now().Unix(), 10)}}
+
+	if terminal {
+		tags = append(tags, storage.Tag{Name: TerminalTagName, Value: "true"})
+	}
+
+	return tags
+}
+
+func parseTags(tags []storage.Tag) (time.Time, bool, bool) {
+	var (
+		updated  time.Time
+		found    bool
+		terminal bool
+	)
+
+	for _, tag := range tags {
+		switch tag.Name {
+		case UpdatedTagName:
+			seconds, err := strconv.ParseInt(tag.Value, 10, 64)
+			if err != nil {
+				return time.Time{}, false, false
+			}
+
+			updated, found = time.Unix(seconds, 0), true
+		case TerminalTagName:
+			terminal = true
+		}
+	}
+
+	return updated, terminal, found
+}
+
+// ReaperOpt configures the Reaper.
+type ReaperOpt func(r *Reaper)
+
+// WithReapInterval sets the interval between two deletions of the expired records (1 hour by default).
+func WithReapInterval(interval time.Duration) ReaperOpt {
+	return func(r *Reaper) {
+		r.interval = interval
+	}
+}
+
+// Reaper deletes in the background the records of the protocol stores that their retention policy no longer retains.
+type Reaper struct {
+	stores   map[string]storage.Store
+	policies map[string]Policy
+	interval time.Duration
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewReaper returns a new reaper of the stores of the given provider with a policy, keyed by store name. The reaper is
+// started with Start.
+func NewReaper(provider storage.Provider, policies map[string]Policy, opts ...ReaperOpt) (*Reaper, error) {
+	r := &Reaper{
+		stores:   make(map[string]storage.Store),
+		policies: make(map[string]Policy),
+		interval: defaultReapInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	for name, policy := range policies {
+		if policy.Mode == KeepAllMode {
+			continue
+		}
+
+		store, err := provider.OpenStore(name)
+		if err != nil {
+			return nil, fmt.Errorf("open store %s: %w", name, err)
+		}
+
+		r.stores[name] = store
+		r.policies[name] = policy
+	}
+
+	return r, nil
+}
+
+// Start deletes the expired records now and then in the background at the reap interval.
+func (r *Reaper) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started || r.stopped {
+		return
+	}
+
+	r.started = true
+
+	go r.run()
+}
+
+// Stop stops the background deletions.
+func (r *Reaper) Stop() {
+	r.mu.Lock()
+
+	if !r.stopped {
+		r.stopped = true
+		close(r.stop)
+	}
+
+	started := r.started
+
+	r.mu.Unlock()
+
+	if started {
+		<-r.done
+	}
+}
+
+// Reap deletes the expired records of the stores at the given time, returning the number of deleted records.
+func (r *Reaper) Reap(now time.Time) (int, error) {
+	var deleted int
+
+	for name, store := range r.stores {
+		n, err := reap(store, r.policies[name], now)
+
+		deleted += n
+
+		if err != nil {
+			return deleted, fmt.Errorf("reap store %s: %w", name, err)
+		}
+	}
+
+	return deleted, nil
+}
+
+func (r *Reaper) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := r.Reap(time.Now()); err != nil {
+			logger.Errorf("failed to delete the expired protocol records: %s", err)
+		} else if deleted > 0 {
+			logger.Debugf("deleted %d expired protocol records", deleted)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func reap(store storage.Store, policy Policy, now time.Time) (int, error) {
+	keys, err := expiredKeys(store, policy, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if err := store.Delete(key); err != nil {
+			return i, fmt.Errorf("delete %s: %w", key, err)
+		}
+	}
+
+	return len(keys), nil
+}
+
+func expiredKeys(store storage.Store, policy Policy, now time.Time) ([]string, error) {
+	iter, err := store.Query(UpdatedTagName)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var keys []string
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("next: %w", err)
+		}
+
+		if !ok {
+			return keys, nil
+		}
+
+		tags, err := iter.Tags()
+		if err != nil {
+			return nil, fmt.Errorf("tags: %w", err)
+		}
+
+		if !policy.Expired(tags, now) {
+			continue
+		}
+
+		key, err := iter.Key()
+		if err != nil {
+			return nil, fmt.Errorf("key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retention
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const day = 24 * time.Hour
+
+func TestTags(t *testing.T) {
+	tags := Tags(false)
+	require.Len(t, tags, 1)
+	require.Equal(t, UpdatedTagName, tags[0].Name)
+
+	updated, err := strconv.ParseInt(tags[0].Value, 10, 64)
+	require.NoError(t, err)
+	require.InDelta(t, time.Now().Unix(), updated, 1)
+
+	tags = Tags(true)
+	require.Len(t, tags, 2)
+	require.Equal(t, TerminalTagName, tags[1].Name)
+}
+
+func TestPolicy_Expired(t *testing.T) {
+	now := time.Now()
+
+	recent := updatedTags(now.Add(-day), false)
+	old := updatedTags(now.Add(-10*day), false)
+	recentTerminal := updatedTags(now.Add(-day), true)
+	oldTerminal := updatedTags(now.Add(-10*day), true)
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		expected []bool // recent, old, recentTerminal, oldTerminal
+	}{
+		{"keep all", KeepAll(), []bool{false, false, false, false}},
+		{"keep for", KeepFor(7 * day), []bool{false, true, false, true}},
+		{"keep terminal", KeepTerminal(7 * day), []bool{false, true, false, false}},
+		{"keep nothing", KeepNothing(), []bool{false, false, true, true}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			for i, tags := range [][]storage.Tag{recent, old, recentTerminal, oldTerminal} {
+				require.Equal(t, tc.expected[i], tc.policy.Expired(tags, now), "record %d", i)
+			}
+		})
+	}
+
+	t.Run("records without retention tags are retained", func(t *testing.T) {
+		require.False(t, KeepNothing().Expired([]storage.Tag{{Name: TerminalTagName}}, now))
+		require.False(t, KeepFor(day).Expired(nil, now))
+		require.False(t, KeepFor(day).Expired([]storage.Tag{{Name: UpdatedTagName, Value: "invalid"}}, now))
+	})
+}
+
+func TestReaper(t *testing.T) {
+	now := time.Now()
+
+	t.Run("reap the stores with a policy", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		proofs := openStore(t, provider, "present-proof", map[string][]storage.Tag{
+			"in-progress": updatedTags(now.Add(-day), false),
+			"stale":       updatedTags(now.Add(-10*day), false),
+			"done":        updatedTags(now.Add(-day), true),
+			"untagged":    nil,
+		})
+
+		credentials := openStore(t, provider, "issue-credential", map[string][]storage.Tag{
+			"stale": updatedTags(now.Add(-10*day), false),
+			"done":  updatedTags(now.Add(-10*day), true),
+		})
+
+		others := openStore(t, provider, "other", map[string][]storage.Tag{
+			"done": updatedTags(now.Add(-10*day), true),
+		})
+
+		reaper, err := NewReaper(provider, map[string]Policy{
+			"present-proof":    KeepNothing(),
+			"issue-credential": KeepTerminal(7 * day),
+			"other":            KeepAll(),
+		})
+		require.NoError(t, err)
+
+		deleted, err := reaper.Reap(now)
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+
+		requireKeys(t, proofs, []string{"in-progress", "stale", "untagged"}, []string{"done"})
+		requireKeys(t, credentials, []string{"done"}, []string{"stale"})
+		requireKeys(t, others, []string{"done"}, nil)
+
+		deleted, err = reaper.Reap(now)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+
+	t.Run("start and stop", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		store := openStore(t, provider, "present-proof", map[string][]storage.Tag{
+			"done": updatedTags(now, true),
+		})
+
+		reaper, err := NewReaper(provider, map[string]Policy{"present-proof": KeepNothing()},
+			WithReapInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		reaper.Start()
+		reaper.Start()
+
+		require.Eventually(t, func() bool {
+			_, err := store.Get("done")
+
+			return errors.Is(err, storage.ErrDataNotFound)
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, store.Put("done-later", []byte("{}"), Tags(true)...))
+
+		require.Eventually(t, func() bool {
+			_, err := store.Get("done-later")
+
+			return errors.Is(err, storage.ErrDataNotFound)
+		}, time.Second, 10*time.Millisecond)
+
+		reaper.Stop()
+		reaper.Stop()
+	})
+
+	t.Run("stop without start", func(t *testing.T) {
+		reaper, err := NewReaper(mem.NewProvider(), nil)
+		require.NoError(t, err)
+
+		reaper.Stop()
+		reaper.Start()
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		provider := mockstorage.NewMockStoreProvider()
+		provider.ErrOpenStoreHandle = errors.New("open error")
+
+		_, err := NewReaper(provider, map[string]Policy{"present-proof": KeepNothing()})
+		require.EqualError(t, err, "open store present-proof: open error")
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(s *mockstorage.MockStore)
+			err   string
+		}{
+			{"query", func(s *mockstorage.MockStore) { s.ErrQuery = errors.New("query error") }, "query: query error"},
+			{"next", func(s *mockstorage.MockStore) { s.ErrNext = errors.New("next error") }, "next: next error"},
+			{"key", func(s *mockstorage.MockStore) { s.ErrKey = errors.New("key error") }, "key: key error"},
+			{"delete", func(s *mockstorage.MockStore) { s.ErrDelete = errors.New("delete error") },
+				"delete done: delete error"},
+		}
+
+		for _, tc := range tests {
+			provider := mockstorage.NewMockStoreProvider()
+			require.NoError(t, provider.Store.Put("done", []byte("{}"), updatedTags(now, true)...))
+
+			tc.setup(provider.Store)
+
+			reaper, err := NewReaper(provider, map[string]Policy{"present-proof": KeepNothing()})
+			require.NoError(t, err)
+
+			_, err = reaper.Reap(now)
+			require.EqualError(t, err, "reap store present-proof: "+tc.err, tc.name)
+		}
+	})
+}
+
+func updatedTags(updated time.Time, terminal bool) []storage.Tag {
+	tags := []storage.Tag{{Name: UpdatedTagName, Value: strconv.FormatInt(updated.Unix(), 10)}}
+
+	if terminal {
+		tags = append(tags, storage.Tag{Name: TerminalTagName, Value: "true"})
+	}
+
+	return tags
+}
+
+func openStore(t *testing.T, provider storage.Provider, name string, records map[string][]storage.Tag) storage.Store {
+	t.Helper()
+
+	store, err := provider.OpenStore(name)
+	require.NoError(t, err)
+
+	for key, tags := range records {
+		require.NoError(t, store.Put(key, []byte("{}"), tags...))
+	}
+
+	return store
+}
+
+func requireKeys(t *testing.T, store storage.Store, kept, deleted []string) {
+	t.Helper()
+
+	for _, key := range kept {
+		_, err := store.Get(key)
+		require.NoError(t, err, key)
+	}
+
+	for _, key := range deleted {
+		_, err := store.Get(key)
+		require.ErrorIs(t, err, storage.ErrDataNotFound, key)
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultExpiryCheckInterval = time.Hour
+	defaultExpiringWindow      = 30 * 24 * time.Hour
+)
+
+// ExpiryStatus is the expiry status of a credential, as last reported by the ExpiryMonitor.
+type ExpiryStatus string
+
+const (
+	// ExpiryStatusExpiring is the status of the credentials expiring within the expiring window.
+	ExpiryStatusExpiring ExpiryStatus = "expiring"
+	// ExpiryStatusExpired is the status of the expired credentials.
+	ExpiryStatusExpired ExpiryStatus = "expired"
+)
+
+// ExpiryEventType is the type of the events sent by the ExpiryMonitor.
+type ExpiryEventType string
+
+const (
+	// CredentialExpiring is sent once when a credential enters the expiring window.
+	CredentialExpiring ExpiryEventType = "credential-expiring"
+	// CredentialExpired is sent once when a credential expired.
+	CredentialExpired ExpiryEventType = "credential-expired"
+	// CredentialArchived is sent when an expired credential is archived by the auto-archive policy.
+	CredentialArchived ExpiryEventType = "credential-archived"
+)
+
+// ExpiryEvent is sent by the ExpiryMonitor when a credential nears or passes its expiration date, so the holder can be
+// prompted to get the credential issued again.
+type ExpiryEvent struct {
+	Type   ExpiryEventType
+	Record *Record
+}
+
+// ExpiryOpt configures the ExpiryMonitor.
+type ExpiryOpt func(m *ExpiryMonitor)
+
+// WithExpiryCheckInterval sets the interval between checks of the expiration dates of the credentials (1 hour by
+// default).
+func WithExpiryCheckInterval(interval time.Duration) ExpiryOpt {
+	return func(m *ExpiryMonitor) {
+		m.checkInterval = interval
+	}
+}
+
+// WithExpiringWindow sets how long before their expiration date the credentials are reported as expiring (30 days by
+// default).
+func WithExpiringWindow(window time.Duration) ExpiryOpt {
+	return func(m *ExpiryMonitor) {
+		m.expiringWindow = window
+	}
+}
+
+// WithAutoArchive enables the archiving of the credentials expired for longer than the given grace period, zero
+// archiving the credentials as soon as they expire.
+func WithAutoArchive(gracePeriod time.Duration) ExpiryOpt {
+	return func(m *ExpiryMonitor) {
+		m.autoArchive = true
+		m.gracePeriod = gracePeriod
+	}
+}
+
+// WithExpiryHandler sets the handler called with the expiry events.
+func WithExpiryHandler(handler func(ExpiryEvent)) ExpiryOpt {
+	return func(m *ExpiryMonitor) {
+		m.handler = handler
+	}
+}
+
+// ExpiryMonitor checks the expiration dates of the stored credentials in the background. An event is sent once when a
+// credential nears its expiration date and once when it expired, the expiry status being saved in the credential
+// record. With the auto-archive policy, the expired credentials are archived after a grace period.
+type ExpiryMonitor struct {
+	store          *StoreImplementation
+	checkInterval  time.Duration
+	expiringWindow time.Duration
+	autoArchive    bool
+	gracePeriod    time.Duration
+	handler        func(ExpiryEvent)
+	mu             sync.Mutex
+	started        bool
+	stopped        bool
+	stop           chan struct{}
+	done           chan struct{}
+}
+
+// NewExpiryMonitor returns a new monitor of the expiration dates of the credentials of the store, started with Start.
+func NewExpiryMonitor(store *StoreImplementation, opts ...ExpiryOpt) *ExpiryMonitor {
+	m := &ExpiryMonitor{
+		store:          store,
+		checkInterval:  defaultExpiryCheckInterval,
+		expiringWindow: defaultExpiringWindow,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Start checks the expiration dates of the credentials now and then in the background at the check interval.
+func (m *ExpiryMonitor) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started || m.stopped {
+		return
+	}
+
+	m.started = true
+
+	go m.run()
+}
+
+// Stop stops the background checks.
+func (m *ExpiryMonitor) Stop() {
+	m.mu.Lock()
+
+	if !m.stopped {
+		m.stopped = true
+		close(m.stop)
+	}
+
+	started := m.started
+
+	m.mu.Unlock()
+
+	if started {
+		<-m.done
+	}
+}
+
+func (m *ExpiryMonitor) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		m.check(time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *ExpiryMonitor) check(now time.Time) {
+	m.store.ensureIndexed()
+
+	// only the records of the credentials with an expiration date are tagged with it
+	records, err := m.store.getAllRecords(expiresTagName)
+	if err != nil {
+		logger.Errorf("get credential records with an expiration date: %s", err)
+
+		return
+	}
+
+	for _, record := range records {
+		if record.Archived || record.Expires == nil {
+			continue
+		}
+
+		if err := m.checkRecord(record, now); err != nil {
+			logger.Warnf("check expiry of credential [%s]: %s", record.Name, err)
+		}
+	}
+}
+
+func (m *ExpiryMonitor) checkRecord(record *Record, now time.Time) error {
+	var events []ExpiryEventType
+
+	switch {
+	case !record.Expires.After(now):
+		if record.ExpiryStatus != ExpiryStatusExpired {
+			record.ExpiryStatus = ExpiryStatusExpired
+			events = append(events, CredentialExpired)
+		}
+
+		if m.autoArchive && !now.Before(record.Expires.Add(m.gracePeriod)) {
+			record.Archived = true
+			events = append(events, CredentialArchived)
+		}
+	case record.Expires.Sub(now) <= m.expiringWindow && record.ExpiryStatus == "":
+		record.ExpiryStatus = ExpiryStatusExpiring
+		events = append(events, CredentialExpiring)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := m.store.putCredentialRecord(record); err != nil {
+		return err
+	}
+
+	if m.handler == nil {
+		return nil
+	}
+
+	for _, eventType := range events {
+		m.handler(ExpiryEvent{Type: eventType, Record: record})
+	}
+
+	return nil
+}
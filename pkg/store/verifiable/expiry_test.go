@@ -0,0 +1,186 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestExpiryMonitor(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(10 * 24 * time.Hour)
+
+	newStore := func(t *testing.T) *StoreImplementation {
+		t.Helper()
+
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			DocumentLoaderValue:  loader,
+		})
+		require.NoError(t, err)
+
+		for name, vc := range map[string]*verifiable.Credential{
+			"degree":  newQueryTestCredential("urn:vc:1", universityDID, aliceDID, "UniversityDegreeCredential", &expires),
+			"license": newQueryTestCredential("urn:vc:2", dmvDID, aliceDID, "DriversLicense", nil),
+		} {
+			require.NoError(t, s.SaveCredential(name, vc))
+		}
+
+		return s
+	}
+
+	t.Run("expiring, expired and archived", func(t *testing.T) {
+		s := newStore(t)
+
+		var events []ExpiryEventType
+
+		m := NewExpiryMonitor(s, WithExpiringWindow(2*24*time.Hour), WithAutoArchive(7*24*time.Hour),
+			WithExpiryHandler(func(e ExpiryEvent) {
+				require.Equal(t, "degree", e.Record.Name)
+				events = append(events, e.Type)
+			}))
+
+		m.check(now)
+		require.Empty(t, events)
+
+		m.check(expires.Add(-24 * time.Hour))
+		m.check(expires.Add(-time.Hour))
+		require.Equal(t, []ExpiryEventType{CredentialExpiring}, events)
+
+		m.check(expires)
+		m.check(expires.Add(time.Hour))
+		require.Equal(t, []ExpiryEventType{CredentialExpiring, CredentialExpired}, events)
+
+		result, err := s.QueryCredentials(&QueryParams{Type: "UniversityDegreeCredential"})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 1)
+		require.Equal(t, ExpiryStatusExpired, result.Records[0].ExpiryStatus)
+
+		m.check(expires.Add(7 * 24 * time.Hour))
+		m.check(expires.Add(8 * 24 * time.Hour))
+		require.Equal(t, []ExpiryEventType{CredentialExpiring, CredentialExpired, CredentialArchived}, events)
+
+		result, err = s.QueryCredentials(&QueryParams{})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 1)
+		require.Equal(t, "license", result.Records[0].Name)
+
+		result, err = s.QueryCredentials(&QueryParams{Type: "UniversityDegreeCredential", IncludeArchived: true})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 1)
+		require.True(t, result.Records[0].Archived)
+	})
+
+	t.Run("expired without auto-archive", func(t *testing.T) {
+		s := newStore(t)
+
+		var events []ExpiryEventType
+
+		m := NewExpiryMonitor(s, WithExpiringWindow(time.Hour), WithExpiryHandler(func(e ExpiryEvent) {
+			events = append(events, e.Type)
+		}))
+
+		m.check(expires.Add(-24 * time.Hour))
+		m.check(expires.Add(365 * 24 * time.Hour))
+		require.Equal(t, []ExpiryEventType{CredentialExpired}, events)
+
+		result, err := s.QueryCredentials(&QueryParams{})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 2)
+	})
+
+	t.Run("start and stop", func(t *testing.T) {
+		expired := make(chan ExpiryEvent, 1)
+
+		s := newStore(t)
+		past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, s.SaveCredential("expired", newQueryTestCredential("urn:vc:3", dmvDID, bobDID,
+			"DriversLicense", &past)))
+
+		m := NewExpiryMonitor(s, WithExpiryCheckInterval(time.Millisecond), WithExpiryHandler(func(e ExpiryEvent) {
+			expired <- e
+		}))
+		m.Start()
+		m.Start()
+
+		select {
+		case e := <-expired:
+			require.Equal(t, CredentialExpired, e.Type)
+			require.Equal(t, "expired", e.Record.Name)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		m.Stop()
+		m.Stop()
+
+		result, err := s.QueryCredentials(&QueryParams{Expired: true})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 1)
+		require.Equal(t, "expired", result.Records[0].Name)
+
+		NewExpiryMonitor(s).Stop()
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrQuery: errors.New("query error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		NewExpiryMonitor(s, WithExpiryHandler(func(ExpiryEvent) {
+			require.Fail(t, "unexpected event")
+		})).check(now)
+	})
+}
+
+func TestStore_ArchiveCredentialByName(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	s, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SaveCredential("license",
+		newQueryTestCredential("urn:vc:1", dmvDID, aliceDID, "DriversLicense", nil)))
+
+	require.NoError(t, s.ArchiveCredentialByName("license"))
+
+	result, err := s.QueryCredentials(&QueryParams{Issuer: dmvDID})
+	require.NoError(t, err)
+	require.Empty(t, result.Records)
+
+	result, err = s.QueryCredentials(&QueryParams{Issuer: dmvDID, IncludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, result.Records, 1)
+
+	// the archived credential is kept
+	_, err = s.GetCredential("urn:vc:1")
+	require.NoError(t, err)
+
+	require.EqualError(t, s.ArchiveCredentialByName(""), "credential name is mandatory")
+	require.Contains(t, s.ArchiveCredentialByName("unknown").Error(), "get credential record using name")
+}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
+import "time"
+
 // Record model containing name, ID and other fields of interest.
 type Record struct {
 	Name      string   `json:"name,omitempty"`
@@ -13,8 +15,16 @@ type Record struct {
 	Context   []string `json:"context,omitempty"`
 	Type      []string `json:"type,omitempty"`
 	SubjectID string   `json:"subjectId,omitempty"`
+	// Issuer, Schemas and Expires are the issuer ID, the schema IDs and the expiration date of a credential.
+	Issuer  string     `json:"issuer,omitempty"`
+	Schemas []string   `json:"schemas,omitempty"`
+	Expires *time.Time `json:"expires,omitempty"`
 	// MyDID and TheirDID contains information about participants who were involved in the process
 	// of issuing a credential or presentation.
 	MyDID    string `json:"my_did,omitempty"`
 	TheirDID string `json:"their_did,omitempty"`
+	// ExpiryStatus is the last expiry status of the credential reported by the ExpiryMonitor.
+	ExpiryStatus ExpiryStatus `json:"expiryStatus,omitempty"`
+	// Archived is set on the archived credentials, excluded from the queries unless requested.
+	Archived bool `json:"archived,omitempty"`
 }
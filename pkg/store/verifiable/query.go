@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	issuerTagName  = "vcissuer"
+	typeTagName    = "vctype"
+	subjectTagName = "vcsubject"
+	schemaTagName  = "vcschema"
+	expiresTagName = "vcexpires"
+
+	// indexedKey marks the store as having the credential records saved before the indexes were introduced indexed.
+	indexedKey = "vcindexed"
+)
+
+// indexTagNames are the tags indexing the credential records for QueryCredentials.
+// nolint: gochecknoglobals
+var indexTagNames = []string{issuerTagName, typeTagName, subjectTagName, schemaTagName, expiresTagName}
+
+// ErrInvalidPageToken is returned by QueryCredentials when the page token of the query can't be parsed.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// QueryParams are the criteria of a credential records query, the records matching all the criteria set.
+type QueryParams struct {
+	// Issuer is the ID of the issuer of the credentials.
+	Issuer string `json:"issuer,omitempty"`
+	// Type is one of the types of the credentials.
+	Type string `json:"type,omitempty"`
+	// SubjectID is the ID of the subject of the credentials.
+	SubjectID string `json:"subjectId,omitempty"`
+	// Schema is the ID of one of the schemas of the credentials.
+	Schema string `json:"schema,omitempty"`
+	// ExpiresAfter and ExpiresBefore limit the records to the credentials expiring within the (exclusive) time range,
+	// the credentials without expiration date expiring after any time.
+	ExpiresAfter  time.Time `json:"expiresAfter,omitempty"`
+	ExpiresBefore time.Time `json:"expiresBefore,omitempty"`
+	// Expired limits the records to the credentials expired at the time of the query.
+	Expired bool `json:"expired,omitempty"`
+	// IncludeArchived includes the archived credentials in the records.
+	IncludeArchived bool `json:"includeArchived,omitempty"`
+	// Limit is the maximum number of records returned, all the matching records are returned if not set.
+	Limit int `json:"limit,omitempty"`
+	// PageToken is the NextPageToken of the previous page of the query.
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// QueryResult is a page of credential records matching the criteria of a query.
+type QueryResult struct {
+	Records []*Record
+	// NextPageToken is the token to get the next page of the query, empty on the last page.
+	NextPageToken string
+}
+
+// QueryCredentials returns the credential records matching the criteria of the query, sorted by name.
+// The criteria on the issuer, type, subject and schema are resolved with the indexes of the underlying store, the
+// records saved before the indexes were introduced being indexed by the first query using the indexes.
+func (s *StoreImplementation) QueryCredentials(params *QueryParams) (*QueryResult, error) {
+	var after string
+
+	if params.PageToken != "" {
+		name, err := base64.RawURLEncoding.DecodeString(params.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, err.Error())
+		}
+
+		after = string(name)
+	}
+
+	searchKey := indexQuery(params)
+
+	if searchKey != credentialNameKey {
+		s.ensureIndexed()
+	}
+
+	allRecords, err := s.getAllRecords(searchKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+
+	now := time.Now()
+
+	for _, record := range allRecords {
+		if params.matches(record, now) && record.Name > after {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+
+	result := &QueryResult{Records: records}
+
+	if params.Limit > 0 && len(records) > params.Limit {
+		result.Records = records[:params.Limit]
+		result.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(records[params.Limit-1].Name))
+	}
+
+	return result, nil
+}
+
+// MatchesCredential tells whether the credential matches the criteria of the query, the limit and page token aside.
+func (p *QueryParams) MatchesCredential(vc *verifiable.Credential) bool {
+	return p.matches(newCredentialRecord(vc), time.Now())
+}
+
+func (p *QueryParams) matches(record *Record, now time.Time) bool {
+	switch {
+	case p.Issuer != "" && p.Issuer != record.Issuer,
+		p.Type != "" && !contains(record.Type, p.Type),
+		p.SubjectID != "" && p.SubjectID != record.SubjectID,
+		p.Schema != "" && !contains(record.Schemas, p.Schema),
+		!p.ExpiresAfter.IsZero() && record.Expires != nil && !record.Expires.After(p.ExpiresAfter),
+		!p.ExpiresBefore.IsZero() && (record.Expires == nil || !record.Expires.Before(p.ExpiresBefore)),
+		p.Expired && (record.Expires == nil || record.Expires.After(now)),
+		record.Archived && !p.IncludeArchived:
+		return false
+	default:
+		return true
+	}
+}
+
+// ensureIndexed indexes the credential records saved before the indexes were introduced, once.
+func (s *StoreImplementation) ensureIndexed() {
+	s.indexOnce.Do(func() {
+		if err := s.indexRecords(); err != nil {
+			logger.Warnf("failed to index the credential records: %s", err.Error())
+		}
+	})
+}
+
+// indexRecords adds the index tags to the credential records saved before the indexes were introduced.
+func (s *StoreImplementation) indexRecords() error {
+	_, err := s.store.Get(indexedKey)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get indexed marker: %w", err)
+	}
+
+	records, err := s.getAllRecords(credentialNameKey)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		vcBytes, err := s.store.Get(record.ID)
+		if err != nil {
+			return fmt.Errorf("get credential %s: %w", record.ID, err)
+		}
+
+		// only the fields of the credential are indexed, its schemas aren't loaded.
+		vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithDisabledProofCheck(),
+			verifiable.WithNoCustomSchemaCheck(), verifiable.WithJSONLDDocumentLoader(s.documentLoader))
+		if err != nil {
+			return fmt.Errorf("parse credential %s: %w", record.ID, err)
+		}
+
+		indexed := newCredentialRecord(vc)
+		indexed.ID, indexed.Name, indexed.MyDID, indexed.TheirDID = record.ID, record.Name, record.MyDID, record.TheirDID
+		indexed.ExpiryStatus, indexed.Archived = record.ExpiryStatus, record.Archived
+
+		if err = s.putCredentialRecord(indexed); err != nil {
+			return err
+		}
+	}
+
+	return s.store.Put(indexedKey, []byte("true"))
+}
+
+// indexQuery returns the store query of the most selective index matching the criteria of the query, the other
+// criteria being checked on the records returned by the store.
+func indexQuery(params *QueryParams) string {
+	switch {
+	case params.SubjectID != "":
+		return indexExpression(subjectTagName, params.SubjectID)
+	case params.Issuer != "":
+		return indexExpression(issuerTagName, params.Issuer)
+	case params.Schema != "":
+		return indexExpression(schemaTagName, params.Schema)
+	case params.Type != "":
+		return indexExpression(typeTagName, params.Type)
+	case params.Expired:
+		return expiresTagName
+	default:
+		return credentialNameKey
+	}
+}
+
+func indexExpression(tagName, value string) string {
+	return fmt.Sprintf("%s:%s", tagName, indexValue(value))
+}
+
+// indexValue encodes the indexed value, the tag values of the stores not allowing the ':' of the DIDs.
+func indexValue(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// indexTags returns the tags indexing the credential record.
+func indexTags(record *Record) []storage.Tag {
+	var tags []storage.Tag
+
+	if record.Issuer != "" {
+		tags = append(tags, storage.Tag{Name: issuerTagName, Value: indexValue(record.Issuer)})
+	}
+
+	if record.SubjectID != "" {
+		tags = append(tags, storage.Tag{Name: subjectTagName, Value: indexValue(record.SubjectID)})
+	}
+
+	for _, t := range record.Type {
+		tags = append(tags, storage.Tag{Name: typeTagName, Value: indexValue(t)})
+	}
+
+	for _, schema := range record.Schemas {
+		tags = append(tags, storage.Tag{Name: schemaTagName, Value: indexValue(schema)})
+	}
+
+	if record.Expires != nil {
+		tags = append(tags, storage.Tag{Name: expiresTagName, Value: strconv.FormatInt(record.Expires.Unix(), 10)})
+	}
+
+	return tags
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
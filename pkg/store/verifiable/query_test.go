@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	universityDID = "did:example:university"
+	dmvDID        = "did:example:dmv"
+	aliceDID      = "did:example:alice"
+	bobDID        = "did:example:bob"
+	degreeSchema  = "https://example.edu/schemas/degree"
+)
+
+func newQueryTestCredential(id, issuer, subject, vcType string, expires *time.Time) *verifiable.Credential {
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType, vcType},
+		ID:      id,
+		Issuer:  verifiable.Issuer{ID: issuer},
+		Issued:  util.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Subject: subject,
+	}
+
+	if vcType == "UniversityDegreeCredential" {
+		vc.Schemas = []verifiable.TypedID{{ID: degreeSchema, Type: "JsonSchemaValidator2018"}}
+	}
+
+	if expires != nil {
+		vc.Expired = util.NewTime(*expires)
+	}
+
+	return vc
+}
+
+func TestQueryCredentials(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	s, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+	})
+	require.NoError(t, err)
+
+	soon := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, vc := range map[string]*verifiable.Credential{
+		"alice-degree":  newQueryTestCredential("urn:vc:1", universityDID, aliceDID, "UniversityDegreeCredential", &soon),
+		"alice-license": newQueryTestCredential("urn:vc:2", dmvDID, aliceDID, "DriversLicense", &later),
+		"bob-degree":    newQueryTestCredential("urn:vc:3", universityDID, bobDID, "UniversityDegreeCredential", nil),
+	} {
+		require.NoError(t, s.SaveCredential(name, vc))
+	}
+
+	names := func(records []*Record) []string {
+		var result []string
+
+		for _, r := range records {
+			result = append(result, r.Name)
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		params   *QueryParams
+		expected []string
+	}{
+		{name: "all", params: &QueryParams{}, expected: []string{"alice-degree", "alice-license", "bob-degree"}},
+		{name: "by issuer", params: &QueryParams{Issuer: universityDID},
+			expected: []string{"alice-degree", "bob-degree"}},
+		{name: "by type", params: &QueryParams{Type: "DriversLicense"}, expected: []string{"alice-license"}},
+		{name: "by subject", params: &QueryParams{SubjectID: aliceDID},
+			expected: []string{"alice-degree", "alice-license"}},
+		{name: "by schema", params: &QueryParams{Schema: degreeSchema},
+			expected: []string{"alice-degree", "bob-degree"}},
+		{name: "by subject and issuer", params: &QueryParams{SubjectID: aliceDID, Issuer: universityDID},
+			expected: []string{"alice-degree"}},
+		{name: "expiring before", params: &QueryParams{ExpiresBefore: soon.Add(time.Hour)},
+			expected: []string{"alice-degree"}},
+		{name: "expiring after", params: &QueryParams{ExpiresAfter: soon},
+			expected: []string{"alice-license", "bob-degree"}},
+		{name: "no match", params: &QueryParams{Issuer: bobDID}},
+	}
+
+	for _, tc := range tests {
+		result, err := s.QueryCredentials(tc.params)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, names(result.Records), tc.name)
+		require.Empty(t, result.NextPageToken, tc.name)
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		result, err := s.QueryCredentials(&QueryParams{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice-degree", "alice-license"}, names(result.Records))
+		require.NotEmpty(t, result.NextPageToken)
+
+		result, err = s.QueryCredentials(&QueryParams{Limit: 2, PageToken: result.NextPageToken})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bob-degree"}, names(result.Records))
+		require.Empty(t, result.NextPageToken)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := s.QueryCredentials(&QueryParams{PageToken: "invalid!"})
+		require.True(t, errors.Is(err, ErrInvalidPageToken))
+	})
+
+	t.Run("record fields", func(t *testing.T) {
+		result, err := s.QueryCredentials(&QueryParams{Type: "DriversLicense"})
+		require.NoError(t, err)
+		require.Len(t, result.Records, 1)
+		require.Equal(t, dmvDID, result.Records[0].Issuer)
+		require.Equal(t, later, *result.Records[0].Expires)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		store, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrQuery: errors.New("query error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		_, err = store.QueryCredentials(&QueryParams{})
+		require.EqualError(t, err, "failed to query store: query error")
+	})
+}
+
+func TestQueryCredentialsIndexesRecords(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	store := &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
+
+	s, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewCustomMockStoreProvider(store),
+		DocumentLoaderValue:  loader,
+	})
+	require.NoError(t, err)
+
+	// a credential saved before the indexes were introduced.
+	vc := newQueryTestCredential("urn:vc:1", universityDID, aliceDID, "UniversityDegreeCredential", nil)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+	require.NoError(t, store.Put(vc.ID, vcBytes))
+
+	recordBytes, err := json.Marshal(&Record{ID: vc.ID, Name: "legacy", MyDID: aliceDID})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(credentialNameDataKey("legacy"), recordBytes, storage.Tag{Name: credentialNameKey}))
+
+	result, err := s.QueryCredentials(&QueryParams{Issuer: universityDID})
+	require.NoError(t, err)
+	require.Len(t, result.Records, 1)
+	require.Equal(t, "legacy", result.Records[0].Name)
+	require.Equal(t, aliceDID, result.Records[0].MyDID)
+	require.Equal(t, aliceDID, result.Records[0].SubjectID)
+
+	_, err = store.Get(indexedKey)
+	require.NoError(t, err)
+}
+
+func TestQueryParams_MatchesCredential(t *testing.T) {
+	vc := newQueryTestCredential("urn:vc:1", universityDID, aliceDID, "UniversityDegreeCredential", nil)
+
+	require.True(t, (&QueryParams{Issuer: universityDID, Schema: degreeSchema}).MatchesCredential(vc))
+	require.False(t, (&QueryParams{Type: "DriversLicense"}).MatchesCredential(vc))
+	require.False(t, (&QueryParams{ExpiresBefore: time.Now()}).MatchesCredential(vc))
+}
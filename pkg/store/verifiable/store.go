@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
@@ -63,6 +64,7 @@ type Store interface {
 	GetPresentationIDByName(name string) (string, error)
 	GetCredentials() ([]*Record, error)
 	GetPresentations() ([]*Record, error)
+	QueryCredentials(params *QueryParams) (*QueryResult, error)
 	RemoveCredentialByName(name string) error
 	RemovePresentationByName(name string) error
 }
@@ -71,6 +73,7 @@ type Store interface {
 type StoreImplementation struct {
 	store          storage.Store
 	documentLoader ld.DocumentLoader
+	indexOnce      sync.Once
 }
 
 type provider interface {
@@ -86,7 +89,7 @@ func New(ctx provider) (*StoreImplementation, error) {
 	}
 
 	err = ctx.StorageProvider().SetStoreConfig(NameSpace,
-		storage.StoreConfiguration{TagNames: []string{credentialNameKey, presentationNameKey}})
+		storage.StoreConfiguration{TagNames: append([]string{credentialNameKey, presentationNameKey}, indexTagNames...)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -133,20 +136,22 @@ func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credent
 		opt(o)
 	}
 
-	recordBytes, err := json.Marshal(&Record{
-		ID:        id,
-		Name:      name,
-		Context:   vc.Context,
-		Type:      vc.Types,
-		MyDID:     o.MyDID,
-		TheirDID:  o.TheirDID,
-		SubjectID: getVCSubjectID(vc),
-	})
+	record := newCredentialRecord(vc)
+	record.ID, record.Name, record.MyDID, record.TheirDID = id, name, o.MyDID, o.TheirDID
+
+	return s.putCredentialRecord(record)
+}
+
+// putCredentialRecord saves the credential record, tagged with the indexes of QueryCredentials.
+func (s *StoreImplementation) putCredentialRecord(record *Record) error {
+	recordBytes, err := json.Marshal(record)
 	if err != nil {
 		return fmt.Errorf("failed to marshal record: %w", err)
 	}
 
-	return s.store.Put(credentialNameDataKey(name), recordBytes, storage.Tag{Name: credentialNameKey})
+	tags := append([]storage.Tag{{Name: credentialNameKey}}, indexTags(record)...)
+
+	return s.store.Put(credentialNameDataKey(record.Name), recordBytes, tags...)
 }
 
 // SavePresentation saves a verifiable presentation.
@@ -298,6 +303,38 @@ func (s *StoreImplementation) RemoveCredentialByName(name string) error {
 	return nil
 }
 
+// ArchiveCredentialByName archives the verifiable credential with the given name: the credential is kept, but its
+// record is excluded from the queries unless the archived credentials are requested.
+func (s *StoreImplementation) ArchiveCredentialByName(name string) error {
+	record, err := s.getCredentialRecord(name)
+	if err != nil {
+		return err
+	}
+
+	record.Archived = true
+
+	return s.putCredentialRecord(record)
+}
+
+func (s *StoreImplementation) getCredentialRecord(name string) (*Record, error) {
+	if name == "" {
+		return nil, errors.New("credential name is mandatory")
+	}
+
+	recordBytes, err := s.store.Get(credentialNameDataKey(name))
+	if err != nil {
+		return nil, fmt.Errorf("get credential record using name : %w", err)
+	}
+
+	record := &Record{}
+
+	if err = json.Unmarshal(recordBytes, record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record : %w", err)
+	}
+
+	return record, nil
+}
+
 // RemovePresentationByName removes the verifiable presentation and its records containing given name.
 func (s *StoreImplementation) RemovePresentationByName(name string) error {
 	if name == "" {
@@ -375,6 +412,27 @@ func (s *StoreImplementation) getAllRecords(searchKey string) ([]*Record, error)
 	return records, nil
 }
 
+// newCredentialRecord returns the record of the fields of interest of the credential.
+func newCredentialRecord(vc *verifiable.Credential) *Record {
+	record := &Record{
+		Context:   vc.Context,
+		Type:      vc.Types,
+		SubjectID: getVCSubjectID(vc),
+		Issuer:    vc.Issuer.ID,
+	}
+
+	for _, schema := range vc.Schemas {
+		record.Schemas = append(record.Schemas, schema.ID)
+	}
+
+	if vc.Expired != nil {
+		expires := vc.Expired.Time
+		record.Expires = &expires
+	}
+
+	return record
+}
+
 func getVCSubjectID(vc *verifiable.Credential) string {
 	if subjectID, err := verifiable.SubjectID(vc.Subject); err == nil {
 		return subjectID
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instrumented offers a storage.Provider wrapper recording the duration of the operations on the stores of
+// the underlying provider with a metrics.Provider.
+package instrumented
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// names of the store operations.
+const (
+	OperationPut     = "put"
+	OperationGet     = "get"
+	OperationGetTags = "get_tags"
+	OperationGetBulk = "get_bulk"
+	OperationQuery   = "query"
+	OperationDelete  = "delete"
+	OperationBatch   = "batch"
+	OperationFlush   = "flush"
+)
+
+// Provider is a storage.Provider recording the duration of the operations on the stores it opens.
+// Closing the provider closes the underlying provider.
+type Provider struct {
+	storage.Provider
+	metrics metrics.Provider
+}
+
+// NewProvider returns a new provider instrumenting the stores of the underlying provider.
+func NewProvider(provider storage.Provider, metricsProvider metrics.Provider) *Provider {
+	return &Provider{Provider: provider, metrics: metricsProvider}
+}
+
+// OpenStore opens the store with the given name, instrumented.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.Provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{Store: store, name: name, metrics: p.metrics}, nil
+}
+
+// Store is a storage.Store recording the duration of its operations.
+type Store struct {
+	storage.Store
+	name    string
+	metrics metrics.Provider
+}
+
+// Put stores the key + value pair along with the (optional) tags.
+func (s *Store) Put(key string, value []byte, tags ...storage.Tag) error {
+	start := time.Now()
+
+	err := s.Store.Put(key, value, tags...)
+	s.observe(OperationPut, start, err)
+
+	return err
+}
+
+// Get fetches the value associated with the given key.
+func (s *Store) Get(key string) ([]byte, error) {
+	start := time.Now()
+
+	value, err := s.Store.Get(key)
+	s.observe(OperationGet, start, err)
+
+	return value, err
+}
+
+// GetTags fetches all tags associated with the given key.
+func (s *Store) GetTags(key string) ([]storage.Tag, error) {
+	start := time.Now()
+
+	tags, err := s.Store.GetTags(key)
+	s.observe(OperationGetTags, start, err)
+
+	return tags, err
+}
+
+// GetBulk fetches the values associated with the given keys.
+func (s *Store) GetBulk(keys ...string) ([][]byte, error) {
+	start := time.Now()
+
+	values, err := s.Store.GetBulk(keys...)
+	s.observe(OperationGetBulk, start, err)
+
+	return values, err
+}
+
+// Query returns all data that satisfies the expression, the duration recorded being the one of the query call.
+func (s *Store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	start := time.Now()
+
+	iterator, err := s.Store.Query(expression, options...)
+	s.observe(OperationQuery, start, err)
+
+	return iterator, err
+}
+
+// Delete deletes the key + value pair (and all tags) associated with key.
+func (s *Store) Delete(key string) error {
+	start := time.Now()
+
+	err := s.Store.Delete(key)
+	s.observe(OperationDelete, start, err)
+
+	return err
+}
+
+// Batch performs multiple Put and/or Delete operations in order.
+func (s *Store) Batch(operations []storage.Operation) error {
+	start := time.Now()
+
+	err := s.Store.Batch(operations)
+	s.observe(OperationBatch, start, err)
+
+	return err
+}
+
+// Flush forces any queued up Put and/or Delete operations to execute.
+func (s *Store) Flush() error {
+	start := time.Now()
+
+	err := s.Store.Flush()
+	s.observe(OperationFlush, start, err)
+
+	return err
+}
+
+func (s *Store) observe(operation string, start time.Time, err error) {
+	s.metrics.StorageOperation(s.name, operation, time.Since(start), err)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instrumented
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestProvider(t *testing.T) {
+	t.Run("operations", func(t *testing.T) {
+		mp := &mockmetrics.Provider{}
+		p := NewProvider(mem.NewProvider(), mp)
+
+		store, err := p.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value"), storage.Tag{Name: "tag"}))
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		_, err = store.GetTags("key")
+		require.NoError(t, err)
+
+		_, err = store.GetBulk("key")
+		require.NoError(t, err)
+
+		_, err = store.Query("tag")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "key2", Value: []byte("value")}}))
+		require.NoError(t, store.Flush())
+		require.NoError(t, store.Delete("key"))
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		expected := []string{
+			OperationPut, OperationGet, OperationGetTags, OperationGetBulk, OperationQuery,
+			OperationBatch, OperationFlush, OperationDelete, OperationGet,
+		}
+
+		observations := mp.Observations(mockmetrics.StorageOperation)
+		require.Len(t, observations, len(expected))
+
+		for i, operation := range expected {
+			require.Equal(t, []string{"test", operation}, observations[i].Labels)
+		}
+
+		require.Equal(t, err, observations[len(expected)-1].Err)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		p := NewProvider(&mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}, &mockmetrics.Provider{})
+
+		_, err := p.OpenStore("test")
+		require.EqualError(t, err, "open error")
+	})
+}
@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package namespace offers a storage.Provider wrapper isolating the stores of an agent in a shared database (or
+// cluster): the names of the stores are prefixed with the namespace of the agent and can be encrypted so that the
+// database doesn't reveal them.
+package namespace
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Separator separates the namespace from the store name.
+const Separator = "_"
+
+// Option configures the namespaced provider.
+type Option func(p *Provider)
+
+// WithNamespace sets the namespace prefixing the store names.
+func WithNamespace(namespace string) Option {
+	return func(p *Provider) {
+		p.namespace = namespace
+	}
+}
+
+// WithEncryptedStoreNames encrypts the store names with the key. The names are never decrypted, they are replaced by
+// their HMAC-SHA256, a deterministic keyed one-way encryption, so the same key must be used to reopen the stores.
+func WithEncryptedStoreNames(key []byte) Option {
+	return func(p *Provider) {
+		p.nameKey = key
+	}
+}
+
+// Provider is a storage.Provider opening the stores of the underlying provider under namespaced store names.
+// Closing the provider closes the stores opened through it, the underlying provider shared with the other
+// namespaces is left open and is closed by its owner.
+type Provider struct {
+	provider   storage.Provider
+	namespace  string
+	nameKey    []byte
+	openStores map[string]storage.Store
+	mu         sync.RWMutex
+}
+
+// NewProvider returns a new provider namespacing the store names of the underlying provider.
+func NewProvider(provider storage.Provider, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		provider:   provider,
+		openStores: make(map[string]storage.Store),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.namespace == "" && p.nameKey == nil {
+		return nil, errors.New("namespace or store names encryption key is required")
+	}
+
+	if p.nameKey != nil && len(p.nameKey) == 0 {
+		return nil, errors.New("store names encryption key is empty")
+	}
+
+	return p, nil
+}
+
+// StoreName returns the name of the store in the underlying provider.
+func (p *Provider) StoreName(name string) string {
+	if p.nameKey != nil {
+		// store names are not case-sensitive.
+		mac := hmac.New(sha256.New, p.nameKey)
+		_, _ = mac.Write([]byte(strings.ToLower(name))) // nolint: errcheck
+
+		name = hex.EncodeToString(mac.Sum(nil))
+	}
+
+	if p.namespace == "" {
+		return name
+	}
+
+	return p.namespace + Separator + name
+}
+
+// OpenStore opens the store with the given name under its namespaced name.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errors.New("store name cannot be empty")
+	}
+
+	store, err := p.provider.OpenStore(p.StoreName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.openStores[strings.ToLower(name)] = store
+	p.mu.Unlock()
+
+	return store, nil
+}
+
+// SetStoreConfig sets the configuration of the store with the given name.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	if name == "" {
+		return errors.New("store name cannot be empty")
+	}
+
+	return p.provider.SetStoreConfig(p.StoreName(name), config)
+}
+
+// GetStoreConfig gets the configuration of the store with the given name.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	if name == "" {
+		return storage.StoreConfiguration{}, errors.New("store name cannot be empty")
+	}
+
+	return p.provider.GetStoreConfig(p.StoreName(name))
+}
+
+// GetOpenStores returns the stores opened through this provider.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stores := make([]storage.Store, 0, len(p.openStores))
+
+	for _, store := range p.openStores {
+		stores = append(stores, store)
+	}
+
+	return stores
+}
+
+// Close closes the stores opened through this provider, without closing the underlying provider.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	stores := p.openStores
+	p.openStores = make(map[string]storage.Store)
+	p.mu.Unlock()
+
+	for name, store := range stores {
+		if err := store.Close(); err != nil {
+			return fmt.Errorf("close store %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
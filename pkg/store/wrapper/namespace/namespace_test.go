@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider(mem.NewProvider())
+	require.EqualError(t, err, "namespace or store names encryption key is required")
+
+	_, err = NewProvider(mem.NewProvider(), WithEncryptedStoreNames([]byte{}))
+	require.EqualError(t, err, "store names encryption key is empty")
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("agents sharing a provider have distinct stores", func(t *testing.T) {
+		shared := mem.NewProvider()
+
+		alice, err := NewProvider(shared, WithNamespace("alice"))
+		require.NoError(t, err)
+
+		bob, err := NewProvider(shared, WithNamespace("bob"))
+		require.NoError(t, err)
+
+		aliceStore, err := alice.OpenStore("connections")
+		require.NoError(t, err)
+		require.NoError(t, aliceStore.Put("key", []byte("alice")))
+
+		bobStore, err := bob.OpenStore("connections")
+		require.NoError(t, err)
+
+		_, err = bobStore.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		store, err := shared.OpenStore("alice_connections")
+		require.NoError(t, err)
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("alice"), value)
+
+		require.Len(t, alice.GetOpenStores(), 1)
+		require.Len(t, shared.GetOpenStores(), 2)
+	})
+
+	t.Run("encrypted store names", func(t *testing.T) {
+		shared := mem.NewProvider()
+
+		p, err := NewProvider(shared, WithNamespace("alice"), WithEncryptedStoreNames([]byte("secret")))
+		require.NoError(t, err)
+
+		name := p.StoreName("connections")
+		require.Regexp(t, "^alice_[0-9a-f]{64}$", name)
+		require.NotContains(t, name, "connections")
+		require.Equal(t, name, p.StoreName("Connections"))
+
+		other, err := NewProvider(shared, WithEncryptedStoreNames([]byte("other secret")))
+		require.NoError(t, err)
+		require.Regexp(t, "^[0-9a-f]{64}$", other.StoreName("connections"))
+		require.NotEqual(t, name[len("alice_"):], other.StoreName("connections"))
+
+		store, err := p.OpenStore("connections")
+		require.NoError(t, err)
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		store, err = shared.OpenStore(name)
+		require.NoError(t, err)
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	t.Run("store config", func(t *testing.T) {
+		shared := mem.NewProvider()
+
+		p, err := NewProvider(shared, WithNamespace("alice"))
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("credentials")
+		require.NoError(t, err)
+
+		config := storage.StoreConfiguration{TagNames: []string{"schema"}}
+		require.NoError(t, p.SetStoreConfig("credentials", config))
+
+		actual, err := p.GetStoreConfig("credentials")
+		require.NoError(t, err)
+		require.Equal(t, config, actual)
+
+		actual, err = shared.GetStoreConfig("alice_credentials")
+		require.NoError(t, err)
+		require.Equal(t, config, actual)
+
+		_, err = p.GetStoreConfig("unknown")
+		require.True(t, errors.Is(err, storage.ErrStoreNotFound))
+	})
+
+	t.Run("empty store name", func(t *testing.T) {
+		p, err := NewProvider(mem.NewProvider(), WithNamespace("alice"))
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("")
+		require.EqualError(t, err, "store name cannot be empty")
+
+		require.EqualError(t, p.SetStoreConfig("", storage.StoreConfiguration{}), "store name cannot be empty")
+
+		_, err = p.GetStoreConfig("")
+		require.EqualError(t, err, "store name cannot be empty")
+	})
+
+	t.Run("close", func(t *testing.T) {
+		shared := mem.NewProvider()
+
+		p, err := NewProvider(shared, WithNamespace("alice"))
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("connections")
+		require.NoError(t, err)
+
+		other, err := NewProvider(shared, WithNamespace("bob"))
+		require.NoError(t, err)
+
+		store, err := other.OpenStore("connections")
+		require.NoError(t, err)
+
+		// only the stores of the namespace are closed, the shared provider is left open
+		require.NoError(t, p.Close())
+		require.Empty(t, p.GetOpenStores())
+		require.Len(t, shared.GetOpenStores(), 1)
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		_, err = p.OpenStore("connections")
+		require.NoError(t, err)
+	})
+}
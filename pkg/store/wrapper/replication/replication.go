@@ -0,0 +1,461 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package replication offers a storage.Provider wrapper mirroring the writes of a fast local provider (leveldb, mem)
+// to a durable remote provider. The reads are served by the local provider and the writes are replicated
+// asynchronously: they are recorded in a journal stored by the local provider, then replayed in order to the remote
+// provider. When the remote provider is unreachable, the journal grows until the remote provider is reachable again
+// and the replication catches up.
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// JournalStoreName is the name of the local store of the writes pending replication.
+	JournalStoreName = "replicationjournal"
+
+	journalTag = "replication"
+
+	defaultRetryInterval = 5 * time.Second
+)
+
+var logger = log.New("aries-framework/store/wrapper/replication")
+
+type operationType string
+
+const (
+	putOperation         operationType = "put"
+	deleteOperation      operationType = "delete"
+	batchOperation       operationType = "batch"
+	storeConfigOperation operationType = "config"
+)
+
+// entry is a write pending replication.
+type entry struct {
+	Store      string                      `json:"store"`
+	Type       operationType               `json:"type"`
+	Key        string                      `json:"key,omitempty"`
+	Value      []byte                      `json:"value,omitempty"`
+	Tags       []storage.Tag               `json:"tags,omitempty"`
+	Operations []storage.Operation         `json:"operations,omitempty"`
+	Config     *storage.StoreConfiguration `json:"config,omitempty"`
+}
+
+// Option configures the replicated provider.
+type Option func(p *Provider)
+
+// WithRetryInterval sets the interval between the replication attempts while the remote provider is unreachable.
+// Defaults to 5 seconds.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(p *Provider) {
+		p.retryInterval = interval
+	}
+}
+
+// Provider is a storage.Provider reading from the local provider and replicating its writes to the remote provider.
+// Closing the provider closes both providers.
+type Provider struct {
+	local         storage.Provider
+	remote        storage.Provider
+	retryInterval time.Duration
+	journal       storage.Store
+	seq           uint64
+	seqMu         sync.Mutex
+	openStores    map[string]*store
+	remoteStores  map[string]storage.Store
+	mu            sync.RWMutex
+	writeMu       sync.RWMutex
+	replicateMu   sync.Mutex
+	notify        chan struct{}
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+// NewProvider returns a new provider replicating the writes of the local provider to the remote provider. The writes
+// left in the journal by a previous instance are replicated first.
+func NewProvider(local, remote storage.Provider, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		local:         local,
+		remote:        remote,
+		retryInterval: defaultRetryInterval,
+		openStores:    make(map[string]*store),
+		remoteStores:  make(map[string]storage.Store),
+		notify:        make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var err error
+
+	p.journal, err = local.OpenStore(JournalStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal store: %w", err)
+	}
+
+	err = local.SetStoreConfig(JournalStoreName, storage.StoreConfiguration{TagNames: []string{journalTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set journal store config: %w", err)
+	}
+
+	keys, err := p.pendingKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	if len(keys) > 0 {
+		// keys are sorted, the last one holds the highest sequence number.
+		if _, err = fmt.Sscanf(keys[len(keys)-1], "%d", &p.seq); err != nil {
+			return nil, fmt.Errorf("invalid journal key %s: %w", keys[len(keys)-1], err)
+		}
+	}
+
+	go p.run()
+
+	return p, nil
+}
+
+// OpenStore opens the store with the given name in the local provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if strings.EqualFold(name, JournalStoreName) {
+		return nil, fmt.Errorf("store name %s is reserved", JournalStoreName)
+	}
+
+	localStore, err := p.local.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &store{name: strings.ToLower(name), local: localStore, p: p}
+
+	p.mu.Lock()
+	p.openStores[s.name] = s
+	p.mu.Unlock()
+
+	return s, nil
+}
+
+// SetStoreConfig sets the configuration of the store with the given name in the local provider and replicates it.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	return p.write(&entry{Store: strings.ToLower(name), Type: storeConfigOperation, Config: &config}, func() error {
+		return p.local.SetStoreConfig(name, config)
+	})
+}
+
+// GetStoreConfig gets the configuration of the store with the given name from the local provider.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	return p.local.GetStoreConfig(name)
+}
+
+// GetOpenStores returns the stores opened through this provider.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stores := make([]storage.Store, 0, len(p.openStores))
+
+	for _, s := range p.openStores {
+		stores = append(stores, s)
+	}
+
+	return stores
+}
+
+// Pending returns the number of writes pending replication.
+func (p *Provider) Pending() (int, error) {
+	keys, err := p.pendingKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(keys), nil
+}
+
+// Sync replicates the pending writes to the remote provider and returns once they are all replicated, or the
+// replication fails.
+func (p *Provider) Sync() error {
+	return p.replicate()
+}
+
+// Close stops the replication, makes a last attempt to replicate the pending writes and closes both providers.
+// The writes still pending are replicated by the next instance of the provider.
+func (p *Provider) Close() error {
+	close(p.stop)
+	<-p.done
+
+	if err := p.replicate(); err != nil {
+		logger.Warnf("pending writes are left in the replication journal: %s", err)
+	}
+
+	p.mu.Lock()
+	p.openStores = make(map[string]*store)
+	p.remoteStores = make(map[string]storage.Store)
+	p.mu.Unlock()
+
+	localErr := p.local.Close()
+	remoteErr := p.remote.Close()
+
+	if localErr != nil {
+		return fmt.Errorf("failed to close local provider: %w", localErr)
+	}
+
+	if remoteErr != nil {
+		return fmt.Errorf("failed to close remote provider: %w", remoteErr)
+	}
+
+	return nil
+}
+
+// write records the entry in the journal and applies the write to the local provider. The entry is removed from
+// the journal if the local write fails.
+func (p *Provider) write(e *entry, localWrite func() error) error {
+	// the replication doesn't pick up the entry until the local write is done.
+	p.writeMu.RLock()
+
+	key, err := p.record(e)
+	if err != nil {
+		p.writeMu.RUnlock()
+
+		return fmt.Errorf("failed to record write in replication journal: %w", err)
+	}
+
+	if err = localWrite(); err != nil {
+		if delErr := p.journal.Delete(key); delErr != nil {
+			logger.Errorf("failed to remove journal entry of failed write: %s", delErr)
+		}
+
+		p.writeMu.RUnlock()
+
+		return err
+	}
+
+	p.writeMu.RUnlock()
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (p *Provider) record(e *entry) (string, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	p.seqMu.Lock()
+	defer p.seqMu.Unlock()
+
+	p.seq++
+
+	// zero-padded so that the lexical order of the keys is the replication order.
+	key := fmt.Sprintf("%020d", p.seq)
+
+	if err = p.journal.Put(key, data, storage.Tag{Name: journalTag}); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (p *Provider) run() {
+	defer close(p.done)
+
+	for {
+		if err := p.replicate(); err != nil {
+			logger.Warnf("replication to remote provider failed, retrying in %s: %s", p.retryInterval, err)
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-p.notify:
+		case <-time.After(p.retryInterval):
+		}
+	}
+}
+
+// replicate replays the journal to the remote provider, in order. It stops at the first failure so that the writes
+// are never replicated out of order.
+func (p *Provider) replicate() error {
+	p.replicateMu.Lock()
+	defer p.replicateMu.Unlock()
+
+	p.writeMu.Lock()
+	keys, err := p.pendingKeys()
+	p.writeMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("load journal: %w", err)
+	}
+
+	for _, key := range keys {
+		data, err := p.journal.Get(key)
+		if err != nil {
+			return fmt.Errorf("get journal entry %s: %w", key, err)
+		}
+
+		e := &entry{}
+
+		if err = json.Unmarshal(data, e); err != nil {
+			return fmt.Errorf("unmarshal journal entry %s: %w", key, err)
+		}
+
+		if err = p.apply(e); err != nil {
+			return fmt.Errorf("replicate %s to store %s: %w", e.Type, e.Store, err)
+		}
+
+		if err = p.journal.Delete(key); err != nil {
+			return fmt.Errorf("delete journal entry %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) apply(e *entry) error {
+	// the store is opened first, some providers don't accept the configuration of a store that isn't open.
+	s, err := p.remoteStore(e.Store)
+	if err != nil {
+		return err
+	}
+
+	switch e.Type {
+	case storeConfigOperation:
+		return p.remote.SetStoreConfig(e.Store, *e.Config)
+	case putOperation:
+		return s.Put(e.Key, e.Value, e.Tags...)
+	case deleteOperation:
+		return s.Delete(e.Key)
+	case batchOperation:
+		return s.Batch(e.Operations)
+	default:
+		return fmt.Errorf("unsupported operation type %s", e.Type)
+	}
+}
+
+func (p *Provider) remoteStore(name string) (storage.Store, error) {
+	p.mu.RLock()
+	s, ok := p.remoteStores[name]
+	p.mu.RUnlock()
+
+	if ok {
+		return s, nil
+	}
+
+	s, err := p.remote.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.remoteStores[name] = s
+	p.mu.Unlock()
+
+	return s, nil
+}
+
+// pendingKeys returns the keys of the journal entries, in replication order.
+func (p *Provider) pendingKeys() ([]string, error) {
+	iter, err := p.journal.Query(journalTag)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Errorf("failed to close journal iterator: %s", errClose)
+		}
+	}()
+
+	var keys []string
+
+	more, err := iter.Next()
+
+	for ; more && err == nil; more, err = iter.Next() {
+		key, errKey := iter.Key()
+		if errKey != nil {
+			return nil, errKey
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// store reads from the local store and replicates its writes.
+type store struct {
+	name  string
+	local storage.Store
+	p     *Provider
+}
+
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	return s.p.write(&entry{Store: s.name, Type: putOperation, Key: key, Value: value, Tags: tags}, func() error {
+		return s.local.Put(key, value, tags...)
+	})
+}
+
+func (s *store) Get(key string) ([]byte, error) {
+	return s.local.Get(key)
+}
+
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	return s.local.GetTags(key)
+}
+
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	return s.local.GetBulk(keys...)
+}
+
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	return s.local.Query(expression, options...)
+}
+
+func (s *store) Delete(key string) error {
+	return s.p.write(&entry{Store: s.name, Type: deleteOperation, Key: key}, func() error {
+		return s.local.Delete(key)
+	})
+}
+
+func (s *store) Batch(operations []storage.Operation) error {
+	return s.p.write(&entry{Store: s.name, Type: batchOperation, Operations: operations}, func() error {
+		return s.local.Batch(operations)
+	})
+}
+
+func (s *store) Flush() error {
+	return s.local.Flush()
+}
+
+func (s *store) Close() error {
+	s.p.mu.Lock()
+	delete(s.p.openStores, s.name)
+	s.p.mu.Unlock()
+
+	return s.local.Close()
+}
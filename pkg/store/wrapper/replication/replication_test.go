@@ -0,0 +1,235 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replication
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNewProvider(t *testing.T) {
+	t.Run("error if cannot open the journal store", func(t *testing.T) {
+		_, err := NewProvider(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+			mem.NewProvider())
+		require.EqualError(t, err, "failed to open journal store: open error")
+	})
+
+	t.Run("error if cannot load the journal", func(t *testing.T) {
+		local := mockstorage.NewMockStoreProvider()
+		local.Store.ErrQuery = errors.New("query error")
+
+		_, err := NewProvider(local, mem.NewProvider())
+		require.EqualError(t, err, "failed to load journal: query error")
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("writes are replicated to the remote provider", func(t *testing.T) {
+		remote := mem.NewProvider()
+
+		p, err := NewProvider(mem.NewProvider(), remote)
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("Connections")
+		require.NoError(t, err)
+
+		require.NoError(t, p.SetStoreConfig("Connections", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+		require.Len(t, p.GetOpenStores(), 1)
+
+		require.NoError(t, store.Put("k1", []byte("v1"), storage.Tag{Name: "tag"}))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+		require.NoError(t, store.Delete("k2"))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "k3", Value: []byte("v3")}}))
+
+		value, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), value)
+
+		require.NoError(t, p.Sync())
+
+		pending, err := p.Pending()
+		require.NoError(t, err)
+		require.Zero(t, pending)
+
+		config, err := remote.GetStoreConfig("connections")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tag"}, config.TagNames)
+
+		remoteStore, err := remote.OpenStore("connections")
+		require.NoError(t, err)
+
+		values, err := remoteStore.GetBulk("k1", "k2", "k3")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("v1"), nil, []byte("v3")}, values)
+
+		tags, err := remoteStore.GetTags("k1")
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "tag"}}, tags)
+
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("writes are replicated in the background", func(t *testing.T) {
+		remote := mem.NewProvider()
+
+		p, err := NewProvider(mem.NewProvider(), remote)
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		remoteStore, err := remote.OpenStore("store")
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			_, err := remoteStore.Get("key")
+
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("replication catches up when the remote provider is reachable again", func(t *testing.T) {
+		remote := &flakyProvider{Provider: mem.NewProvider()}
+		remote.setDown(true)
+
+		p, err := NewProvider(mem.NewProvider(), remote, WithRetryInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+		require.NoError(t, store.Delete("k1"))
+
+		require.Error(t, p.Sync())
+
+		pending, err := p.Pending()
+		require.NoError(t, err)
+		require.Equal(t, 3, pending)
+
+		remote.setDown(false)
+
+		require.Eventually(t, func() bool {
+			pending, err = p.Pending()
+
+			return err == nil && pending == 0
+		}, time.Second, 10*time.Millisecond)
+
+		remoteStore, err := remote.Provider.OpenStore("store")
+		require.NoError(t, err)
+
+		_, err = remoteStore.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		value, err := remoteStore.Get("k2")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v2"), value)
+	})
+
+	t.Run("pending writes are replicated after a restart", func(t *testing.T) {
+		local := &noCloseProvider{Provider: mem.NewProvider()}
+		remote := &flakyProvider{Provider: mem.NewProvider()}
+		remote.setDown(true)
+
+		p, err := NewProvider(local, remote)
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+		require.NoError(t, store.Put("k2", []byte("v2")))
+		require.NoError(t, p.Close())
+
+		remote.setDown(false)
+
+		p, err = NewProvider(local, remote)
+		require.NoError(t, err)
+
+		store, err = p.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k3", []byte("v3")))
+		require.NoError(t, p.Sync())
+
+		remoteStore, err := remote.Provider.OpenStore("store")
+		require.NoError(t, err)
+
+		values, err := remoteStore.GetBulk("k1", "k2", "k3")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("v1"), []byte("v2"), []byte("v3")}, values)
+	})
+
+	t.Run("failed local writes are not replicated", func(t *testing.T) {
+		p, err := NewProvider(mem.NewProvider(), mem.NewProvider())
+		require.NoError(t, err)
+
+		store, err := p.OpenStore("store")
+		require.NoError(t, err)
+
+		require.Error(t, store.Put("", []byte("value")))
+		require.Error(t, p.SetStoreConfig("", storage.StoreConfiguration{}))
+
+		pending, err := p.Pending()
+		require.NoError(t, err)
+		require.Zero(t, pending)
+	})
+
+	t.Run("journal store name is reserved", func(t *testing.T) {
+		p, err := NewProvider(mem.NewProvider(), mem.NewProvider())
+		require.NoError(t, err)
+
+		_, err = p.OpenStore("ReplicationJournal")
+		require.EqualError(t, err, "store name replicationjournal is reserved")
+	})
+}
+
+type flakyProvider struct {
+	storage.Provider
+	down bool
+	mu   sync.Mutex
+}
+
+func (f *flakyProvider) setDown(down bool) {
+	f.mu.Lock()
+	f.down = down
+	f.mu.Unlock()
+}
+
+func (f *flakyProvider) OpenStore(name string) (storage.Store, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.down {
+		return nil, errors.New("remote provider is unreachable")
+	}
+
+	return f.Provider.OpenStore(name)
+}
+
+func (f *flakyProvider) Close() error {
+	return nil
+}
+
+type noCloseProvider struct {
+	storage.Provider
+}
+
+func (n *noCloseProvider) Close() error {
+	return nil
+}
@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// NewDereferencer returns a DID URL dereferencer resolving the DIDs with the registry, the DID parameters of the DID
+// URLs (eg. versionId) being passed to the DID methods as options along with the given options.
+func NewDereferencer(registry vdrapi.Registry, opts ...vdrapi.DIDMethodOption) *diddoc.Dereferencer {
+	return diddoc.NewDereferencer(func(did string, params map[string]string) (*diddoc.DocResolution, error) {
+		methodOpts := append([]vdrapi.DIDMethodOption{}, opts...)
+
+		for name, value := range params {
+			methodOpts = append(methodOpts, vdrapi.WithOption(name, value))
+		}
+
+		return registry.Resolve(did, methodOpts...)
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+func TestNewDereferencer(t *testing.T) {
+	doc := &did.Doc{
+		ID:                 "did:example:123",
+		VerificationMethod: []did.VerificationMethod{{ID: "did:example:123#key-1", Type: "Ed25519VerificationKey2018"}},
+	}
+
+	var values map[string]interface{}
+
+	registry := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			require.Equal(t, "did:example:123", didID)
+
+			didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+			for _, opt := range opts {
+				opt(didMethodOpts)
+			}
+
+			values = didMethodOpts.Values
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	}
+
+	result, err := NewDereferencer(registry, vdrapi.WithOption("key", "value")).
+		Dereference("did:example:123?versionId=4#key-1")
+	require.NoError(t, err)
+	require.Equal(t, "did:example:123#key-1", result.VerificationMethod.ID)
+	require.Equal(t, map[string]interface{}{"key": "value", did.VersionIDParam: "4"}, values)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+
+// Create new DID document for didDoc, the DID encoding the key of the first verification method of didDoc, which
+// must either be a JsonWebKey2020 or an Ed25519VerificationKey2018.
+func (v *VDR) Create(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	vm := didDoc.VerificationMethod[0]
+
+	j := vm.JSONWebKey()
+
+	if j == nil {
+		if vm.Type != ed25519VerificationKey2018 {
+			return nil, fmt.Errorf("not supported public key type: %s", vm.Type)
+		}
+
+		if len(vm.Value) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(vm.Value))
+		}
+
+		var err error
+
+		j, err = jwksupport.JWKFromKey(ed25519.PublicKey(vm.Value))
+		if err != nil {
+			return nil, fmt.Errorf("create JWK: %w", err)
+		}
+	}
+
+	didJWK, err := DIDFromJWK(j)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Read(didJWK)
+}
+
+// DIDFromJWK returns the did:jwk DID encoding the given public key.
+func DIDFromJWK(j *jwk.JWK) (string, error) {
+	jwkBytes, err := j.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("marshal JWK: %w", err)
+	}
+
+	if isPrivate(jwkBytes) {
+		return "", fmt.Errorf("JWK must be a public key")
+	}
+
+	return fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes)), nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+)
+
+func TestCreate(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	v := New()
+
+	t.Run("create from Ed25519VerificationKey2018", func(t *testing.T) {
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key1", ed25519VerificationKey2018, "", pubKey),
+		}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:jwk:"))
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+		require.Len(t, doc.Authentication, 1)
+		require.Empty(t, doc.KeyAgreement)
+	})
+
+	t.Run("create from JsonWebKey2020", func(t *testing.T) {
+		j, err := jwksupport.JWKFromKey(pubKey)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key1", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		didJWK, err := DIDFromJWK(j)
+		require.NoError(t, err)
+		require.Equal(t, didJWK, docResolution.DIDDocument.ID)
+	})
+
+	t.Run("create errors", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key1", "Bls12381G2Key2020", "", pubKey),
+		}})
+		require.EqualError(t, err, "not supported public key type: Bls12381G2Key2020")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key1", ed25519VerificationKey2018, "", []byte("invalid")),
+		}})
+		require.EqualError(t, err, "invalid Ed25519 public key size: 7")
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		j, err := jwksupport.JWKFromKey(privKey)
+		require.NoError(t, err)
+
+		_, err = DIDFromJWK(j)
+		require.EqualError(t, err, "JWK must be a public key")
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	useSignature  = "sig"
+	useEncryption = "enc"
+)
+
+// Read expands did:jwk value to a DID document.
+func (v *VDR) Read(didJWK string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID: %w", err)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s", parsed.Method)
+	}
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %w", err)
+	}
+
+	if isPrivate(jwkBytes) {
+		return nil, fmt.Errorf("jwk vdr Read: did:jwk must not encode a private key")
+	}
+
+	var j jwk.JWK
+
+	err = j.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid JWK: %w", err)
+	}
+
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+keyFragment, jsonWebKey2020, didJWK, &j)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: createDoc(vm, &j, didJWK)}, nil
+}
+
+// createDoc creates the DID document of the key, its verification relationships following the intended use of the
+// key: X25519 keys and keys for encryption are only used for key agreement, Ed25519 and BBS+ keys and keys for
+// signature are only used for verification.
+func createDoc(vm *did.VerificationMethod, j *jwk.JWK, didJWK string) *did.Doc {
+	t := time.Now()
+
+	// keys of unknown types are used for both.
+	keyType, _ := j.KeyType() // nolint:errcheck
+
+	signing := j.Use != useEncryption && keyType != kms.X25519ECDHKWType
+	keyAgreement := j.Use != useSignature && keyType != kms.ED25519Type && keyType != kms.BLS12381G2Type
+
+	doc := &did.Doc{
+		Context:            []string{schemaDIDV1},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Created:            &t,
+		Updated:            &t,
+	}
+
+	if signing {
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+	}
+
+	if keyAgreement {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc
+}
+
+// isPrivate tells if the JSON of the JWK has a private key member.
+func isPrivate(jwkBytes []byte) bool {
+	var members map[string]json.RawMessage
+
+	if err := json.Unmarshal(jwkBytes, &members); err != nil {
+		return false
+	}
+
+	_, ok := members["d"]
+
+	return ok
+}
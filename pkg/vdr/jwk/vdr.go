@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jwk implements the did:jwk method (https://github.com/quartzjer/did-jwk/blob/main/spec.md), a DID
+// encoding a single public JSON Web Key.
+package jwk
+
+import (
+	"fmt"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// DIDMethod did method.
+	DIDMethod = "jwk"
+
+	// keyFragment is the fragment of the ID of the only verification method of a did:jwk DID document.
+	keyFragment = "#0"
+
+	schemaResV1    = "https://w3id.org/did-resolution/v1"
+	schemaDIDV1    = "https://www.w3.org/ns/did/v1"
+	jsonWebKey2020 = "JsonWebKey2020"
+)
+
+// VDR implements did:jwk method support.
+type VDR struct{}
+
+// New returns new instance of VDR that works with did:jwk method.
+func New() *VDR {
+	return &VDR{}
+}
+
+// Accept accepts did:jwk method.
+func (v *VDR) Accept(method string) bool {
+	return method == DIDMethod
+}
+
+// Close frees resources being maintained by VDR.
+func (v *VDR) Close() error {
+	return nil
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestVDR(t *testing.T) {
+	v := New()
+
+	require.True(t, v.Accept(DIDMethod))
+	require.False(t, v.Accept("key"))
+	require.NoError(t, v.Close())
+	require.EqualError(t, v.Update(&did.Doc{}), "not supported")
+	require.EqualError(t, v.Deactivate(didP256), "not supported")
+}
@@ -45,22 +45,61 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 		}
 	}
 
+	numAlgo, err := getNumAlgo(docOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	if !store {
-		docResolution, err := build(didDoc, docOpts)
+		var docResolution *did.DocResolution
+
+		docResolution, err = build(didDoc, docOpts)
 		if err != nil {
 			return nil, fmt.Errorf("create peer DID : %w", err)
 		}
 
 		didDoc = docResolution.DIDDocument
+
+		if numAlgo == NumAlgo4 {
+			// the numalgo 4 DID is computed from the document without id
+			didDoc.ID = ""
+
+			_, didDoc.ID, err = computeDidMethod4(didDoc)
+			if err != nil {
+				return nil, fmt.Errorf("create peer DID : %w", err)
+			}
+		}
 	}
 
-	if err := v.storeDID(didDoc, nil); err != nil {
+	if IsNumAlgo4(didDoc.ID) {
+		return v.storeNumAlgo4(didDoc)
+	}
+
+	if err = v.storeDID(didDoc, nil); err != nil {
 		return nil, err
 	}
 
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
 }
 
+func getNumAlgo(docOpts *vdrapi.DIDMethodOpts) (int, error) {
+	numAlgoOpt := docOpts.Values[NumAlgoOption]
+	if numAlgoOpt == nil {
+		return 1, nil
+	}
+
+	numAlgo, ok := numAlgoOpt.(int)
+	if !ok {
+		return 0, fmt.Errorf("numAlgo opt not int")
+	}
+
+	if numAlgo != 1 && numAlgo != NumAlgo4 {
+		return 0, fmt.Errorf("numAlgo %d not supported", numAlgo)
+	}
+
+	return numAlgo, nil
+}
+
 //nolint: funlen,gocyclo
 func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution, error) {
 	if len(didDoc.VerificationMethod) == 0 && len(didDoc.KeyAgreement) == 0 {
@@ -160,6 +199,7 @@ func applyDIDCommV2Keys(i int, didDoc *did.Doc) {
 	if didDoc.Service[i].Type == vdrapi.DIDCommV2ServiceType {
 		didDoc.Service[i].RecipientKeys = []string{}
 		didDoc.Service[i].Priority = 0
+		didDoc.Service[i].EndpointObject = true
 
 		for _, ka := range didDoc.KeyAgreement {
 			kaID := ka.VerificationMethod.ID
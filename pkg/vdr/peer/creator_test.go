@@ -236,7 +236,8 @@ func TestBuild(t *testing.T) {
 					&did.Doc{
 						VerificationMethod: []did.VerificationMethod{expected},
 						Service: []did.Service{{
-							Type: svcType,
+							Type:            svcType,
+							ServiceEndpoint: "https://agent.example.com/",
 						}},
 						KeyAgreement: []did.Verification{keyAgreement},
 					})
@@ -252,6 +253,7 @@ func TestBuild(t *testing.T) {
 				require.NotEmpty(t, result.DIDDocument.Service[0].RecipientKeys)
 				require.Equal(t, expectedKey,
 					result.DIDDocument.Service[0].RecipientKeys[0])
+				require.Equal(t, svcType == vdr.DIDCommV2ServiceType, result.DIDDocument.Service[0].EndpointObject)
 			})
 	}
 }
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+const (
+	// NumAlgoOption is the option selecting the numeric algorithm of the created peer DID, the value is an int.
+	// Numalgo 1 is used when not set.
+	NumAlgoOption = "numAlgo"
+	// NumAlgo4 is the numeric algorithm of the peer DIDs having a short form and a long form embedding the document.
+	// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+	NumAlgo4 = 4
+
+	numAlgo4 = "4"
+
+	jsonIDKey = "id"
+)
+
+// multicodec code of JSON (0x0200) encoded as varint.
+var jsonMulticodec = []byte{0x80, 0x04}
+
+var numAlgo4Regex = regexp.MustCompile(`^did:peer:4(z[1-9a-km-zA-HJ-NP-Z]{46})(:z[1-9a-km-zA-HJ-NP-Z]+)?$`)
+
+// IsNumAlgo4 returns true if the DID is a numalgo 4 peer DID, in short form or in long form.
+func IsNumAlgo4(didID string) bool {
+	return numAlgo4Regex.MatchString(didID)
+}
+
+// ShortForm returns the short form of a numalgo 4 peer DID, the short form is returned as is.
+func ShortForm(didID string) (string, error) {
+	match := numAlgo4Regex.FindStringSubmatch(didID)
+	if match == nil {
+		return "", fmt.Errorf("not a numalgo 4 peer DID: %s", didID)
+	}
+
+	return peerPrefix + numAlgo4 + match[1], nil
+}
+
+// computeDidMethod4 creates the short form and the long form of the numalgo 4 peer DID of the document.
+// For example: did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd and
+// did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd:z2M1k7h4psgp4CmJcnQn2Ljp7Pz7ktsd7oBhMU3dWY5s4fhFNj17qc.
+// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+func computeDidMethod4(doc *did.Doc) (string, string, error) {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return "", "", fmt.Errorf("marshal peer DID document: %w", err)
+	}
+
+	// the input document doesn't have an id, it is added when the document is resolved.
+	rawDoc := map[string]interface{}{}
+
+	if err = json.Unmarshal(docBytes, &rawDoc); err != nil {
+		return "", "", fmt.Errorf("unmarshal peer DID document: %w", err)
+	}
+
+	delete(rawDoc, jsonIDKey)
+
+	inputDoc, err := json.Marshal(rawDoc)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal input peer DID document: %w", err)
+	}
+
+	encDoc, err := multibase.Encode(transform, append(append([]byte{}, jsonMulticodec...), inputDoc...))
+	if err != nil {
+		return "", "", fmt.Errorf("encode peer DID document: %w", err)
+	}
+
+	hash, err := hashDocument(encDoc)
+	if err != nil {
+		return "", "", err
+	}
+
+	shortForm := peerPrefix + numAlgo4 + hash
+
+	return shortForm, shortForm + ":" + encDoc, nil
+}
+
+// resolveLongForm returns the document embedded in a long form numalgo 4 peer DID, with the long form DID as id.
+func resolveLongForm(longForm string) (*did.Doc, error) {
+	match := numAlgo4Regex.FindStringSubmatch(longForm)
+	if match == nil || match[2] == "" {
+		return nil, fmt.Errorf("not a long form numalgo 4 peer DID: %s", longForm)
+	}
+
+	encDoc := strings.TrimPrefix(match[2], ":")
+
+	hash, err := hashDocument(encDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash != match[1] {
+		return nil, errors.New("hash of the peer DID document doesn't match the DID")
+	}
+
+	_, data, err := multibase.Decode(encDoc)
+	if err != nil {
+		return nil, fmt.Errorf("decode peer DID document: %w", err)
+	}
+
+	if !bytes.HasPrefix(data, jsonMulticodec) {
+		return nil, errors.New("peer DID document is not encoded as JSON")
+	}
+
+	rawDoc := map[string]interface{}{}
+
+	if err = json.Unmarshal(data[len(jsonMulticodec):], &rawDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal input peer DID document: %w", err)
+	}
+
+	rawDoc[jsonIDKey] = longForm
+
+	docBytes, err := json.Marshal(rawDoc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal peer DID document: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse peer DID document: %w", err)
+	}
+
+	return doc, nil
+}
+
+func hashDocument(encDoc string) (string, error) {
+	hash, err := multihash.Sum([]byte(encDoc), multihash.SHA2_256, -1)
+	if err != nil {
+		return "", fmt.Errorf("hash peer DID document: %w", err)
+	}
+
+	return multibase.Encode(transform, hash)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNumAlgo4(t *testing.T) {
+	t.Run("test create and resolve", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		shortForm := docResolution.DIDDocument.ID
+		require.True(t, IsNumAlgo4(shortForm))
+		require.Len(t, docResolution.DocumentMetadata.EquivalentID, 1)
+
+		longForm := docResolution.DocumentMetadata.EquivalentID[0]
+		require.True(t, strings.HasPrefix(longForm, shortForm+":z"))
+		require.True(t, IsNumAlgo4(longForm))
+
+		s, err := ShortForm(longForm)
+		require.NoError(t, err)
+		require.Equal(t, shortForm, s)
+
+		l, err := v.LongForm(shortForm)
+		require.NoError(t, err)
+		require.Equal(t, longForm, l)
+
+		// short form is resolved from the store
+		docResolution, err = v.Read(shortForm)
+		require.NoError(t, err)
+		require.Equal(t, shortForm, docResolution.DIDDocument.ID)
+		require.Equal(t, []string{longForm}, docResolution.DocumentMetadata.EquivalentID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+
+		// long form is resolved from the document it embeds
+		docResolution, err = v.Read(longForm)
+		require.NoError(t, err)
+		require.Equal(t, longForm, docResolution.DIDDocument.ID)
+		require.Equal(t, []string{shortForm}, docResolution.DocumentMetadata.EquivalentID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+		require.Len(t, docResolution.DIDDocument.Authentication, 1)
+	})
+
+	t.Run("test resolve long form stores the document", func(t *testing.T) {
+		creator, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := creator.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		shortForm := docResolution.DIDDocument.ID
+		longForm := docResolution.DocumentMetadata.EquivalentID[0]
+
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = v.Read(shortForm)
+		require.True(t, errors.Is(err, vdr.ErrNotFound))
+
+		_, err = v.Read(longForm)
+		require.NoError(t, err)
+
+		docResolution, err = v.Read(shortForm)
+		require.NoError(t, err)
+		require.Equal(t, shortForm, docResolution.DIDDocument.ID)
+		require.Equal(t, []string{longForm}, docResolution.DocumentMetadata.EquivalentID)
+	})
+
+	t.Run("test store received document", func(t *testing.T) {
+		creator, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := creator.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		shortForm := docResolution.DIDDocument.ID
+
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = v.Create(docResolution.DIDDocument, vdr.WithOption("store", true))
+		require.NoError(t, err)
+
+		docResolution, err = v.Read(shortForm)
+		require.NoError(t, err)
+		require.Equal(t, shortForm, docResolution.DIDDocument.ID)
+		require.Nil(t, docResolution.DocumentMetadata)
+
+		_, err = v.LongForm(shortForm)
+		require.True(t, errors.Is(err, vdr.ErrNotFound))
+	})
+
+	t.Run("test create with invalid numalgo", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = v.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, "4"))
+		require.EqualError(t, err, "numAlgo opt not int")
+
+		_, err = v.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, 2))
+		require.EqualError(t, err, "numAlgo 2 not supported")
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		v, err := New(&storage.MockStoreProvider{Store: &storage.MockStore{
+			Store:  make(map[string]storage.DBEntry),
+			ErrPut: errors.New("put error"),
+			ErrGet: errors.New("get error"),
+		}})
+		require.NoError(t, err)
+
+		_, err = v.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "put error")
+
+		_, err = v.LongForm("did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get error")
+	})
+
+	t.Run("test resolve invalid long form", func(t *testing.T) {
+		creator, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := creator.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		otherResolution, err := creator.Create(newNumAlgo4Doc(), vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		longForm := docResolution.DocumentMetadata.EquivalentID[0]
+		otherLongForm := otherResolution.DocumentMetadata.EquivalentID[0]
+
+		// the hash of the short form doesn't match the document
+		_, err = creator.Read(docResolution.DIDDocument.ID + otherLongForm[strings.LastIndex(otherLongForm, ":"):])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hash of the peer DID document doesn't match the DID")
+
+		_, err = resolveLongForm(docResolution.DIDDocument.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a long form numalgo 4 peer DID")
+
+		_, err = resolveLongForm(longForm[:len(longForm)-1] + "0")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a long form numalgo 4 peer DID")
+	})
+
+	t.Run("test short form of other peer DID", func(t *testing.T) {
+		_, err := ShortForm("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a numalgo 4 peer DID")
+		require.False(t, IsNumAlgo4("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa"))
+	})
+}
+
+func newNumAlgo4Doc() *did.Doc {
+	vm := getSigningKey()
+	vm.ID = "#key-1"
+
+	return &did.Doc{VerificationMethod: []did.VerificationMethod{vm}}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -15,6 +16,10 @@ import (
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
 func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if IsNumAlgo4(didID) {
+		return v.readNumAlgo4(didID)
+	}
+
 	// get the document from the store
 	doc, err := v.Get(didID)
 	if err != nil {
@@ -27,3 +32,49 @@ func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolutio
 
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
 }
+
+// readNumAlgo4 resolves a numalgo 4 peer DID. The long form is resolved from the document it embeds, which is stored
+// by short form if it isn't yet so that the short form can be resolved afterwards. The short form is resolved from
+// the store.
+func (v *VDR) readNumAlgo4(didID string) (*did.DocResolution, error) {
+	shortForm, err := ShortForm(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	if didID == shortForm {
+		doc, e := v.Get(shortForm)
+		if e != nil {
+			return nil, fmt.Errorf("fetching data from store failed: %w", e)
+		}
+
+		docResolution := &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}
+
+		longForm, e := v.LongForm(shortForm)
+		if e == nil {
+			docResolution.DocumentMetadata = &did.DocumentMetadata{EquivalentID: []string{longForm}}
+		}
+
+		return docResolution, nil
+	}
+
+	doc, err := resolveLongForm(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve long form peer DID: %w", err)
+	}
+
+	_, err = v.Get(shortForm)
+	if errors.Is(err, vdrapi.ErrNotFound) {
+		_, err = v.storeNumAlgo4(doc)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("store peer DID: %w", err)
+	}
+
+	return &did.DocResolution{
+		Context:          []string{schemaResV1},
+		DIDDocument:      doc,
+		DocumentMetadata: &did.DocumentMetadata{EquivalentID: []string{shortForm}},
+	}, nil
+}
@@ -18,6 +18,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// longFormKeyPrefix is the prefix of the keys of the long forms of the numalgo 4 peer DIDs, stored by short form.
+const longFormKeyPrefix = "longform_"
+
 // modifiedBy key/signature used to update the DID Document.
 type modifiedBy struct {
 	Key string `json:"key,omitempty"`
@@ -60,6 +63,50 @@ func (v *VDR) storeDID(doc *did.Doc, by *[]modifiedBy) error { //nolint: unparam
 	return v.store.Put(doc.ID, val)
 }
 
+// storeNumAlgo4 saves the numalgo 4 Peer DID Document by short form, along with the long form if the document has
+// the long form as DID.
+func (v *VDR) storeNumAlgo4(doc *did.Doc) (*did.DocResolution, error) {
+	shortForm, err := ShortForm(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution := &did.DocResolution{Context: []string{schemaResV1}, DocumentMetadata: &did.DocumentMetadata{}}
+
+	if doc.ID != shortForm {
+		if err = v.store.Put(longFormKeyPrefix+shortForm, []byte(doc.ID)); err != nil {
+			return nil, fmt.Errorf("store long form of peer DID: %w", err)
+		}
+
+		docResolution.DocumentMetadata.EquivalentID = []string{doc.ID}
+	}
+
+	shortFormDoc := *doc
+	shortFormDoc.ID = shortForm
+
+	if err = v.storeDID(&shortFormDoc, nil); err != nil {
+		return nil, err
+	}
+
+	docResolution.DIDDocument = &shortFormDoc
+
+	return docResolution, nil
+}
+
+// LongForm returns the long form of a numalgo 4 Peer DID created or resolved from its long form.
+func (v *VDR) LongForm(shortForm string) (string, error) {
+	longForm, err := v.store.Get(longFormKeyPrefix + shortForm)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", vdrapi.ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("fetching long form from store failed: %w", err)
+	}
+
+	return string(longForm), nil
+}
+
 // Get returns Peer DID Document.
 func (v *VDR) Get(id string) (*did.Doc, error) {
 	if id == "" {
@@ -11,11 +11,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// ResolutionCacheStoreName is the name of the store caching the DID resolutions, opened from a cache provider.
+const ResolutionCacheStoreName = "didresolutions"
+
+var logger = log.New("aries-framework/vdr")
+
 // Option is a vdr instance option.
 type Option func(opts *Registry)
 
@@ -24,6 +31,7 @@ type Registry struct {
 	vdr                []vdrapi.VDR
 	defServiceEndpoint string
 	defServiceType     string
+	resolutionCache    storage.Store
 }
 
 // New return new instance of vdr.
@@ -45,6 +53,15 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, err
 	}
 
+	// the resolutions with options (eg. a version) aren't cached
+	cached := r.resolutionCache != nil && len(opts) == 0
+
+	if cached {
+		if docResolution, ok := r.getCachedResolution(did); ok {
+			return docResolution, nil
+		}
+	}
+
 	// resolve did method
 	method, err := r.resolveVDR(didMethod)
 	if err != nil {
@@ -61,9 +78,56 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
+	if cached {
+		r.cacheResolution(did, didDocResolution)
+	}
+
 	return didDocResolution, nil
 }
 
+func (r *Registry) getCachedResolution(did string) (*diddoc.DocResolution, bool) {
+	data, err := r.resolutionCache.Get(did)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("get cached resolution of %s: %s", did, err)
+		}
+
+		return nil, false
+	}
+
+	docResolution, err := diddoc.ParseDocumentResolution(data)
+	if err != nil {
+		logger.Warnf("parse cached resolution of %s: %s", did, err)
+
+		return nil, false
+	}
+
+	return docResolution, true
+}
+
+func (r *Registry) cacheResolution(did string, docResolution *diddoc.DocResolution) {
+	data, err := docResolution.JSONBytes()
+	if err == nil {
+		err = r.resolutionCache.Put(did, data)
+	}
+
+	if err != nil {
+		logger.Warnf("cache resolution of %s: %s", did, err)
+	}
+}
+
+func (r *Registry) removeCachedResolution(did string) error {
+	if r.resolutionCache == nil {
+		return nil
+	}
+
+	if err := r.resolutionCache.Delete(did); err != nil {
+		return fmt.Errorf("remove cached resolution: %w", err)
+	}
+
+	return nil
+}
+
 // Update did document.
 func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(didDoc.ID)
@@ -77,7 +141,11 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
-	return method.Update(didDoc, opts...)
+	if err = method.Update(didDoc, opts...); err != nil {
+		return err
+	}
+
+	return r.removeCachedResolution(didDoc.ID)
 }
 
 // Deactivate did document.
@@ -93,7 +161,11 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
-	return method.Deactivate(did, opts...)
+	if err = method.Deactivate(did, opts...); err != nil {
+		return err
+	}
+
+	return r.removeCachedResolution(did)
 }
 
 // Create a new DID Document and store it in this registry.
@@ -174,6 +246,15 @@ func WithDefaultServiceEndpoint(serviceEndpoint string) Option {
 	}
 }
 
+// WithResolutionCache caches the DID resolutions in the given store, opened from a cache provider expiring the
+// resolutions such as the Redis provider of component/storage/redis. The cached resolution of a DID is removed when it
+// is updated or deactivated through the registry.
+func WithResolutionCache(store storage.Store) Option {
+	return func(opts *Registry) {
+		opts.resolutionCache = store
+	}
+}
+
 // GetDidMethod get did method.
 func GetDidMethod(didID string) (string, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/20 Validate that the input DID conforms to
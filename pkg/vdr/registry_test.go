@@ -12,9 +12,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestRegistry_New(t *testing.T) {
@@ -269,3 +272,87 @@ func TestRegistry_Create(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRegistry_ResolutionCache(t *testing.T) {
+	newStore := func(t *testing.T) storage.Store {
+		t.Helper()
+
+		s, err := mem.NewProvider().OpenStore(ResolutionCacheStoreName)
+		require.NoError(t, err)
+
+		return s
+	}
+
+	t.Run("test resolution cached", func(t *testing.T) {
+		reads := 0
+
+		v := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			},
+		}
+
+		registry := New(WithVDR(v), WithResolutionCache(newStore(t)))
+
+		for i := 0; i < 2; i++ {
+			result, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+			require.Equal(t, "did:example:123", result.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, reads)
+
+		// resolutions with options aren't cached
+		_, err := registry.Resolve("did:example:123", vdrapi.WithOption("versionId", "1"))
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+
+		require.NoError(t, registry.Update(&did.Doc{ID: "did:example:123"}))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 3, reads)
+
+		require.NoError(t, registry.Deactivate("did:example:123"))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 4, reads)
+	})
+
+	t.Run("test cache errors", func(t *testing.T) {
+		reads := 0
+
+		v := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			},
+		}
+
+		registry := New(WithVDR(v), WithResolutionCache(&mockstorage.Store{
+			ErrGet:    fmt.Errorf("get error"),
+			ErrPut:    fmt.Errorf("put error"),
+			ErrDelete: fmt.Errorf("delete error"),
+		}))
+
+		_, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 1, reads)
+
+		require.EqualError(t, registry.Update(&did.Doc{ID: "did:example:123"}),
+			"remove cached resolution: delete error")
+
+		s := newStore(t)
+		require.NoError(t, s.Put("did:example:123", []byte("invalid")))
+
+		_, err = New(WithVDR(v), WithResolutionCache(s)).Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+	})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universalresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	identifiersPath = "/1.0/identifiers/"
+
+	// didResolutionResult is the media type of the DID resolution result, holding the DID document and its metadata.
+	didResolutionResult = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+	// notFound is the DID resolution metadata error of a DID that doesn't exist.
+	notFound = "notFound"
+)
+
+// ResolutionError is the error of the DID resolution metadata returned by the Universal Resolver
+// (https://www.w3.org/TR/did-core/#did-resolution-metadata), e.g. invalidDid or methodNotSupported.
+type ResolutionError struct {
+	// Code is the error of the DID resolution metadata.
+	Code string
+	// Message is the error message of the Universal Resolver, if any.
+	Message string
+}
+
+// Error returns the error message.
+func (e *ResolutionError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("DID resolution error: %s", e.Code)
+	}
+
+	return fmt.Sprintf("DID resolution error: %s: %s", e.Code, e.Message)
+}
+
+// Is tells if the error is vdrapi.ErrNotFound, for notFound resolution errors.
+func (e *ResolutionError) Is(target error) bool {
+	return target == vdrapi.ErrNotFound && e.Code == notFound //nolint:errorlint
+}
+
+type resolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+type rawResolution struct {
+	ResolutionMetadata *resolutionMetadata `json:"didResolutionMetadata,omitempty"`
+}
+
+// Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
+func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if v.cache != nil {
+		cached, err := v.cache.Get(didID)
+		if err != nil && !errors.Is(err, gcache.KeyNotFoundError) {
+			return nil, err
+		}
+
+		if docResolution, ok := cached.(*did.DocResolution); ok {
+			return docResolution, nil
+		}
+	}
+
+	data, err := v.resolveDID(v.endpointURL + identifiersPath + url.PathEscape(didID))
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := parseResolution(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		if err = v.cache.Set(didID, docResolution); err != nil {
+			logger.Warnf("failed to cache DID resolution of %s: %v", didID, err)
+		}
+	}
+
+	return docResolution, nil
+}
+
+// resolveDID makes DID resolution via HTTP.
+func (v *VDR) resolveDID(uri string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP create get request failed: %w", err)
+	}
+
+	req.Header.Add("Accept", didResolutionResult)
+
+	if v.authToken != "" {
+		req.Header.Add("Authorization", v.authToken)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	gotBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return gotBody, nil
+	}
+
+	// the Universal Resolver returns the DID resolution result, with the resolution error, on failures.
+	if err = resolutionError(gotBody); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return nil, fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
+		resp.StatusCode, resp.Header.Get("Content-type"), gotBody)
+}
+
+// parseResolution parses the DID resolution result returned by the Universal Resolver, drivers returning the bare
+// DID document for some methods.
+func parseResolution(data []byte) (*did.DocResolution, error) {
+	if len(data) == 0 {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	if err := resolutionError(data); err != nil {
+		return nil, err
+	}
+
+	docResolution, err := did.ParseDocumentResolution(data)
+	if err == nil {
+		return docResolution, nil
+	}
+
+	if !errors.Is(err, did.ErrDIDDocumentNotExist) {
+		return nil, fmt.Errorf("parse DID resolution result: %w", err)
+	}
+
+	didDoc, err := did.ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID document: %w", err)
+	}
+
+	return &did.DocResolution{DIDDocument: didDoc}, nil
+}
+
+// resolutionError returns the error of the DID resolution metadata of the DID resolution result, if any.
+func resolutionError(data []byte) error {
+	raw := &rawResolution{}
+
+	if err := json.Unmarshal(data, raw); err != nil || raw.ResolutionMetadata == nil {
+		return nil //nolint:nilerr
+	}
+
+	if raw.ResolutionMetadata.Error == "" {
+		return nil
+	}
+
+	return &ResolutionError{Code: raw.ResolutionMetadata.Error, Message: raw.ResolutionMetadata.Message}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universalresolver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	sampleDID = "did:ion:EiClkZMDxPKqC9c-umQfTkR8vvZ9JPhl_xLDI9Nfk38w5w"
+
+	doc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:ion:EiClkZMDxPKqC9c-umQfTkR8vvZ9JPhl_xLDI9Nfk38w5w",
+  "verificationMethod": [
+    {
+      "id": "#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:ion:EiClkZMDxPKqC9c-umQfTkR8vvZ9JPhl_xLDI9Nfk38w5w",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ]
+}`
+
+	didResolutionData = `{
+  "@context": "https://w3id.org/did-resolution/v1",
+  "didDocument": ` + doc + `,
+  "didResolutionMetadata": {"contentType": "application/did+ld+json"},
+  "didDocumentMetadata": {
+    "canonicalId": "did:ion:EiClkZMDxPKqC9c-umQfTkR8vvZ9JPhl_xLDI9Nfk38w5w",
+    "method": {"published": true}
+  }
+}`
+)
+
+func TestVDR_Read(t *testing.T) {
+	t.Run("success - DID resolution result", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/1.0/identifiers/"+sampleDID, r.URL.Path)
+			require.Equal(t, didResolutionResult, r.Header.Get("Accept"))
+			require.Equal(t, "Bearer tk1", r.Header.Get("Authorization"))
+
+			_, err := fmt.Fprint(w, didResolutionData)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL, WithAuthToken("tk1"))
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(sampleDID)
+		require.NoError(t, err)
+		require.Equal(t, sampleDID, docResolution.DIDDocument.ID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+		require.Equal(t, sampleDID, docResolution.DocumentMetadata.CanonicalID)
+		require.True(t, docResolution.DocumentMetadata.Method.Published)
+	})
+
+	t.Run("success - DID document", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, doc)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL + "/")
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(sampleDID)
+		require.NoError(t, err)
+		require.Equal(t, sampleDID, docResolution.DIDDocument.ID)
+	})
+
+	t.Run("success - cached", func(t *testing.T) {
+		requests := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			_, err := fmt.Fprint(w, didResolutionData)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL, WithCache(10, time.Minute))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			docResolution, err := v.Read(sampleDID)
+			require.NoError(t, err)
+			require.Equal(t, sampleDID, docResolution.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, requests)
+
+		require.NoError(t, v.Close())
+
+		_, err = v.Read(sampleDID)
+		require.NoError(t, err)
+		require.Equal(t, 2, requests)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+
+			_, err := fmt.Fprint(w, `{"didResolutionMetadata":{"error":"notFound"}}`)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read(sampleDID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		var resolutionErr *ResolutionError
+		require.True(t, errors.As(err, &resolutionErr))
+		require.Equal(t, "notFound", resolutionErr.Code)
+	})
+
+	t.Run("not found - empty response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read(sampleDID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("resolution error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotImplemented)
+
+			_, err := fmt.Fprint(w,
+				`{"didResolutionMetadata":{"error":"methodNotSupported","message":"no driver for did:xyz"}}`)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read("did:xyz:123")
+		require.EqualError(t, err, "DID resolution error: methodNotSupported: no driver for did:xyz")
+		require.False(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("resolution error in successful response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, `{"didResolutionMetadata":{"error":"invalidDid"}}`)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read("did:ion:invalid")
+		require.EqualError(t, err, "DID resolution error: invalidDid")
+	})
+
+	t.Run("unsupported response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			_, err := fmt.Fprint(w, "server error")
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read(sampleDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported response from DID resolver [500]")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, `{"didDocument":{"id":1}}`)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read(sampleDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse DID resolution result")
+
+		v, err = New("http://127.0.0.1:0")
+		require.NoError(t, err)
+
+		_, err = v.Read(sampleDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP Get request failed")
+	})
+}
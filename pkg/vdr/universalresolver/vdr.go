@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universalresolver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+var logger = log.New("aries-framework/vdr/universalresolver")
+
+// VDR resolves DIDs through a Universal Resolver instance
+// (https://github.com/decentralized-identity/universal-resolver).
+type VDR struct {
+	endpointURL string
+	client      *http.Client
+	accept      Accept
+	authToken   string
+	cache       gcache.Cache
+}
+
+// Accept is method to accept did method.
+type Accept func(method string) bool
+
+// New creates new Universal Resolver VDR, endpointURL being the base URL of the Universal Resolver instance
+// (e.g. https://dev.uniresolver.io).
+func New(endpointURL string, opts ...Option) (*VDR, error) {
+	v := &VDR{client: &http.Client{}, accept: func(method string) bool { return true }}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	// Validate host
+	_, err := url.ParseRequestURI(endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("base URL invalid: %w", err)
+	}
+
+	v.endpointURL = strings.TrimSuffix(endpointURL, "/")
+
+	return v, nil
+}
+
+// Accept did method - attempt to resolve the methods accepted by the accept option, any method by default.
+func (v *VDR) Accept(method string) bool {
+	return v.accept(method)
+}
+
+// Create did doc.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return nil, fmt.Errorf("build not supported in universal resolver vdr")
+}
+
+// Close frees resources being maintained by vdr.
+func (v *VDR) Close() error {
+	if v.cache != nil {
+		v.cache.Purge()
+	}
+
+	return nil
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Option configures the universal resolver vdr.
+type Option func(opts *VDR)
+
+// WithTimeout option is for definition of HTTP(s) timeout value of DID Resolver.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *VDR) {
+		opts.client.Timeout = timeout
+	}
+}
+
+// WithTLSConfig option is for definition of secured HTTP transport using a tls.Config instance.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(opts *VDR) {
+		opts.client.Transport = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	}
+}
+
+// WithAccept option is for accept did method.
+func WithAccept(accept Accept) Option {
+	return func(opts *VDR) {
+		opts.accept = accept
+	}
+}
+
+// WithMethods option accepts only the given did methods (e.g. "ion", "ebsi"), the ones the Universal Resolver
+// instance has drivers for.
+func WithMethods(methods ...string) Option {
+	accepted := make(map[string]struct{}, len(methods))
+
+	for _, method := range methods {
+		accepted[method] = struct{}{}
+	}
+
+	return WithAccept(func(method string) bool {
+		_, ok := accepted[method]
+
+		return ok
+	})
+}
+
+// WithAuthToken add auth token for resolve.
+func WithAuthToken(authToken string) Option {
+	return func(opts *VDR) {
+		opts.authToken = "Bearer " + authToken
+	}
+}
+
+// WithCache option caches up to size DID resolutions for the ttl duration (no expiry if zero).
+func WithCache(size int, ttl time.Duration) Option {
+	return func(opts *VDR) {
+		builder := gcache.New(size).LRU()
+
+		if ttl > 0 {
+			builder = builder.Expiration(ttl)
+		}
+
+		opts.cache = builder.Build()
+	}
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universalresolver
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		v, err := New("https://dev.uniresolver.io/", WithTimeout(time.Second), WithTLSConfig(&tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "https://dev.uniresolver.io", v.endpointURL)
+		require.Equal(t, time.Second, v.client.Timeout)
+		require.NotNil(t, v.client.Transport)
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		_, err := New("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "base URL invalid")
+	})
+}
+
+func TestVDR_Accept(t *testing.T) {
+	t.Run("any method", func(t *testing.T) {
+		v, err := New("https://dev.uniresolver.io")
+		require.NoError(t, err)
+		require.True(t, v.Accept("ion"))
+		require.True(t, v.Accept("ebsi"))
+	})
+
+	t.Run("with methods", func(t *testing.T) {
+		v, err := New("https://dev.uniresolver.io", WithMethods("ion", "ebsi"))
+		require.NoError(t, err)
+		require.True(t, v.Accept("ion"))
+		require.True(t, v.Accept("ebsi"))
+		require.False(t, v.Accept("key"))
+	})
+
+	t.Run("with accept", func(t *testing.T) {
+		v, err := New("https://dev.uniresolver.io", WithAccept(func(method string) bool { return method == "web" }))
+		require.NoError(t, err)
+		require.True(t, v.Accept("web"))
+		require.False(t, v.Accept("ion"))
+	})
+}
+
+func TestVDR_Unsupported(t *testing.T) {
+	v, err := New("https://dev.uniresolver.io")
+	require.NoError(t, err)
+
+	_, err = v.Create(&did.Doc{})
+	require.EqualError(t, err, "build not supported in universal resolver vdr")
+
+	require.EqualError(t, v.Update(&did.Doc{}), "not supported")
+	require.EqualError(t, v.Deactivate(sampleDID), "not supported")
+	require.NoError(t, v.Close())
+}
@@ -0,0 +1,176 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package vmresolver selects the verification method of a DID matching a proof purpose, like assertionMethod for
+// signing credentials, authentication for DID authentication or keyAgreement for packing messages, for the callers
+// not to sign with a key meant for another purpose.
+package vmresolver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	defaultCacheSize       = 100
+	defaultCacheExpiration = 5 * time.Minute
+)
+
+// ErrNotFound is returned when the DID has no verification method for the proof purpose.
+var ErrNotFound = errors.New("verification method not found")
+
+// nolint:gochecknoglobals
+var proofPurposes = map[did.VerificationRelationship]string{
+	did.Authentication:       "authentication",
+	did.AssertionMethod:      "assertionMethod",
+	did.KeyAgreement:         "keyAgreement",
+	did.CapabilityDelegation: "capabilityDelegation",
+	did.CapabilityInvocation: "capabilityInvocation",
+}
+
+// ProofPurpose returns the proof purpose of the verification relationship, e.g. assertionMethod for
+// did.AssertionMethod, empty for did.VerificationRelationshipGeneral.
+func ProofPurpose(relationship did.VerificationRelationship) string {
+	return proofPurposes[relationship]
+}
+
+// Resolver resolves the verification methods of DIDs by proof purpose, the DID documents being cached.
+type Resolver struct {
+	vdr   vdrapi.Registry
+	cache gcache.Cache
+}
+
+// Option configures the resolver.
+type Option func(opts *Resolver)
+
+// WithCache sets the number of DID documents cached and their expiration, 100 documents for 5 minutes by default.
+// The cache is disabled with a zero size.
+func WithCache(size int, expiration time.Duration) Option {
+	return func(opts *Resolver) {
+		opts.cache = nil
+
+		if size > 0 {
+			opts.cache = gcache.New(size).LRU().Expiration(expiration).Build()
+		}
+	}
+}
+
+// New returns a resolver of the verification methods of the DIDs resolved by the registry.
+func New(registry vdrapi.Registry, opts ...Option) *Resolver {
+	r := &Resolver{
+		vdr:   registry,
+		cache: gcache.New(defaultCacheSize).LRU().Expiration(defaultCacheExpiration).Build(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Resolve returns the verification method of the DID for the proof purpose, the first one with one of the key types
+// if any is given.
+func (r *Resolver) Resolve(didID string, relationship did.VerificationRelationship,
+	keyTypes ...string) (*did.VerificationMethod, error) {
+	doc, err := r.resolveDID(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Select(doc, relationship, keyTypes...)
+}
+
+// Lookup returns the verification method with the ID, failing if it isn't meant for the proof purpose.
+func (r *Resolver) Lookup(vmID string, relationship did.VerificationRelationship) (*did.VerificationMethod, error) {
+	didID := strings.Split(vmID, "#")[0]
+
+	doc, err := r.resolveDID(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Find(doc, vmID, relationship)
+}
+
+// Invalidate removes the DID document from the cache, after an update of the DID.
+func (r *Resolver) Invalidate(didID string) {
+	if r.cache != nil {
+		r.cache.Remove(didID)
+	}
+}
+
+func (r *Resolver) resolveDID(didID string) (*did.Doc, error) {
+	if r.cache != nil {
+		if cached, err := r.cache.Get(didID); err == nil {
+			return cached.(*did.Doc), nil
+		}
+	}
+
+	docResolution, err := r.vdr.Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", didID, err)
+	}
+
+	if r.cache != nil {
+		if err = r.cache.Set(didID, docResolution.DIDDocument); err != nil {
+			return nil, fmt.Errorf("cache %s: %w", didID, err)
+		}
+	}
+
+	return docResolution.DIDDocument, nil
+}
+
+// Select returns the first verification method of the DID document for the proof purpose, with one of the key types
+// if any is given.
+func Select(doc *did.Doc, relationship did.VerificationRelationship,
+	keyTypes ...string) (*did.VerificationMethod, error) {
+	for _, v := range doc.VerificationMethods(relationship)[relationship] {
+		if len(keyTypes) == 0 || hasKeyType(keyTypes, v.VerificationMethod.Type) {
+			vm := v.VerificationMethod
+
+			return &vm, nil
+		}
+	}
+
+	if len(keyTypes) > 0 {
+		return nil, fmt.Errorf("%w: no %s %s key in %s", ErrNotFound, ProofPurpose(relationship),
+			strings.Join(keyTypes, " or "), doc.ID)
+	}
+
+	return nil, fmt.Errorf("%w: no %s key in %s", ErrNotFound, ProofPurpose(relationship), doc.ID)
+}
+
+// Find returns the verification method of the DID document with the ID, failing if it isn't meant for the proof
+// purpose.
+func Find(doc *did.Doc, vmID string, relationship did.VerificationRelationship) (*did.VerificationMethod, error) {
+	for _, v := range doc.VerificationMethods(relationship)[relationship] {
+		if v.VerificationMethod.ID == vmID {
+			vm := v.VerificationMethod
+
+			return &vm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a %s key of %s", ErrNotFound, vmID, ProofPurpose(relationship), doc.ID)
+}
+
+func hasKeyType(keyTypes []string, keyType string) bool {
+	for _, t := range keyTypes {
+		if t == keyType {
+			return true
+		}
+	}
+
+	return false
+}
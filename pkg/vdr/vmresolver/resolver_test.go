@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vmresolver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const didID = "did:example:alice"
+
+func TestSelect(t *testing.T) {
+	doc := newDoc()
+
+	t.Run("by proof purpose", func(t *testing.T) {
+		vm, err := Select(doc, did.AssertionMethod)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#assert-ed25519", vm.ID)
+
+		vm, err = Select(doc, did.Authentication)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#auth", vm.ID)
+
+		vm, err = Select(doc, did.KeyAgreement)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#ka", vm.ID)
+	})
+
+	t.Run("by proof purpose and key type", func(t *testing.T) {
+		vm, err := Select(doc, did.AssertionMethod, "Bls12381G2Key2020")
+		require.NoError(t, err)
+		require.Equal(t, didID+"#assert-bbs", vm.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Select(doc, did.CapabilityDelegation)
+		require.True(t, errors.Is(err, ErrNotFound))
+		require.EqualError(t, err, "verification method not found: no capabilityDelegation key in did:example:alice")
+
+		_, err = Select(doc, did.Authentication, "Bls12381G2Key2020")
+		require.True(t, errors.Is(err, ErrNotFound))
+		require.EqualError(t, err,
+			"verification method not found: no authentication Bls12381G2Key2020 key in did:example:alice")
+	})
+}
+
+func TestFind(t *testing.T) {
+	doc := newDoc()
+
+	vm, err := Find(doc, didID+"#auth", did.Authentication)
+	require.NoError(t, err)
+	require.Equal(t, "Ed25519VerificationKey2018", vm.Type)
+
+	_, err = Find(doc, didID+"#ka", did.AssertionMethod)
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.EqualError(t, err,
+		"verification method not found: did:example:alice#ka is not a assertionMethod key of did:example:alice")
+}
+
+func TestResolver(t *testing.T) {
+	t.Run("resolve and lookup with cache", func(t *testing.T) {
+		resolved := 0
+
+		r := New(&mockvdr.MockVDRegistry{
+			ResolveFunc: func(id string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				resolved++
+
+				require.Equal(t, didID, id)
+
+				return &did.DocResolution{DIDDocument: newDoc()}, nil
+			},
+		})
+
+		vm, err := r.Resolve(didID, did.AssertionMethod)
+		require.NoError(t, err)
+		require.Equal(t, didID+"#assert-ed25519", vm.ID)
+
+		vm, err = r.Lookup(didID+"#assert-bbs", did.AssertionMethod)
+		require.NoError(t, err)
+		require.Equal(t, "Bls12381G2Key2020", vm.Type)
+
+		_, err = r.Lookup(didID+"#auth", did.KeyAgreement)
+		require.True(t, errors.Is(err, ErrNotFound))
+		require.Equal(t, 1, resolved)
+
+		r.Invalidate(didID)
+
+		_, err = r.Resolve(didID, did.KeyAgreement)
+		require.NoError(t, err)
+		require.Equal(t, 2, resolved)
+	})
+
+	t.Run("resolve without cache", func(t *testing.T) {
+		resolved := 0
+
+		r := New(&mockvdr.MockVDRegistry{
+			ResolveFunc: func(id string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				resolved++
+
+				return &did.DocResolution{DIDDocument: newDoc()}, nil
+			},
+		}, WithCache(0, time.Minute))
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Resolve(didID, did.Authentication)
+			require.NoError(t, err)
+		}
+
+		r.Invalidate(didID)
+		require.Equal(t, 2, resolved)
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		r := New(&mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}, WithCache(10, time.Minute))
+
+		_, err := r.Resolve(didID, did.Authentication)
+		require.EqualError(t, err, "resolve did:example:alice: resolve error")
+
+		_, err = r.Lookup(didID+"#auth", did.Authentication)
+		require.EqualError(t, err, "resolve did:example:alice: resolve error")
+	})
+}
+
+func TestProofPurpose(t *testing.T) {
+	require.Equal(t, "assertionMethod", ProofPurpose(did.AssertionMethod))
+	require.Equal(t, "authentication", ProofPurpose(did.Authentication))
+	require.Equal(t, "keyAgreement", ProofPurpose(did.KeyAgreement))
+	require.Empty(t, ProofPurpose(did.VerificationRelationshipGeneral))
+}
+
+func newDoc() *did.Doc {
+	auth := did.NewVerificationMethodFromBytes(didID+"#auth", "Ed25519VerificationKey2018", didID, []byte("auth"))
+	assertBBS := did.NewVerificationMethodFromBytes(didID+"#assert-bbs", "Bls12381G2Key2020", didID,
+		[]byte("bbs"))
+	assertEd25519 := did.NewVerificationMethodFromBytes(didID+"#assert-ed25519", "Ed25519VerificationKey2018",
+		didID, []byte("assert"))
+	ka := did.NewVerificationMethodFromBytes(didID+"#ka", "X25519KeyAgreementKey2019", didID, []byte("ka"))
+
+	return &did.Doc{
+		ID:                 didID,
+		VerificationMethod: []did.VerificationMethod{*auth, *assertBBS, *assertEd25519},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(auth, did.Authentication)},
+		AssertionMethod: []did.Verification{
+			*did.NewReferencedVerification(assertEd25519, did.AssertionMethod),
+			*did.NewReferencedVerification(assertBBS, did.AssertionMethod),
+		},
+		KeyAgreement: []did.Verification{*did.NewEmbeddedVerification(ka, did.KeyAgreement)},
+	}
+}
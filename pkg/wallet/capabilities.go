@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// paths of the credential subject claims checked by the predefined capability templates.
+const (
+	birthDatePath      = "$.credentialSubject.birthDate"
+	addressCountryPath = "$.credentialSubject.address.addressCountry"
+	kycLevelPath       = "$.credentialSubject.kycLevel"
+
+	dateLayout = "2006-01-02"
+)
+
+// CapabilityTemplate is a template of a claim the user may be asked to prove (eg. being over 18), described by the
+// input descriptors a presentation request would hold. The template is satisfiable when each input descriptor is
+// satisfied by a credential of the wallet.
+type CapabilityTemplate struct {
+	// ID of the template.
+	ID string `json:"id"`
+	// Name of the template, displayed to the user.
+	Name string `json:"name,omitempty"`
+	// InputDescriptors are the Presentation Exchange input descriptors to satisfy, their schemas are optional.
+	InputDescriptors []*presexch.InputDescriptor `json:"inputDescriptors"`
+}
+
+// Capability reports whether a capability template is satisfiable from the credentials of the wallet.
+type Capability struct {
+	// ID of the template.
+	ID string `json:"id"`
+	// Name of the template.
+	Name string `json:"name,omitempty"`
+	// Satisfiable is true when the credentials of the wallet satisfy all the input descriptors of the template.
+	Satisfiable bool `json:"satisfiable"`
+	// Credentials are the IDs of the credentials satisfying the template.
+	Credentials []string `json:"credentials,omitempty"`
+}
+
+// AgeOverTemplate returns the template of the credentials proving the subject is at least the given age, from the
+// birthDate of the subject.
+func AgeOverTemplate(age int) *CapabilityTemplate {
+	cutoff := time.Now().UTC().AddDate(-age, 0, 0).Format(dateLayout)
+	str := "string"
+
+	return &CapabilityTemplate{
+		ID:   fmt.Sprintf("age-over-%d", age),
+		Name: fmt.Sprintf("Age over %d", age),
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID: "birth-date",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{
+				Path:   []string{birthDatePath},
+				Filter: &presexch.Filter{Type: &str, Pattern: dateNotAfterPattern(cutoff)},
+			}}},
+		}},
+	}
+}
+
+// ResidencyTemplate returns the template of the credentials proving the subject resides in the given country, from
+// the country of the address of the subject.
+func ResidencyTemplate(country string) *CapabilityTemplate {
+	str := "string"
+
+	return &CapabilityTemplate{
+		ID:   "residency-" + strings.ToLower(country),
+		Name: "Residency in " + country,
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID: "address-country",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{
+				Path:   []string{addressCountryPath},
+				Filter: &presexch.Filter{Type: &str, Const: country},
+			}}},
+		}},
+	}
+}
+
+// KYCLevelTemplate returns the template of the credentials proving the subject passed a know your customer check of
+// at least the given level, from the kycLevel of the subject.
+func KYCLevelTemplate(level int) *CapabilityTemplate {
+	number := "number"
+
+	return &CapabilityTemplate{
+		ID:   fmt.Sprintf("kyc-level-%d", level),
+		Name: fmt.Sprintf("KYC level %d", level),
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID: "kyc-level",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{
+				Path:   []string{kycLevelPath},
+				Filter: &presexch.Filter{Type: &number, Minimum: level},
+			}}},
+		}},
+	}
+}
+
+// capabilities checks the capability templates against the credentials, without creating presentations.
+func capabilities(vcs []*verifiable.Credential, loader ld.DocumentLoader,
+	templates ...*CapabilityTemplate) ([]*Capability, error) {
+	results := make([]*Capability, len(templates))
+
+	for i, template := range templates {
+		if template == nil || len(template.InputDescriptors) == 0 {
+			return nil, fmt.Errorf("capability template %d has no input descriptors", i)
+		}
+
+		definition := &presexch.PresentationDefinition{ID: template.ID, InputDescriptors: template.InputDescriptors}
+
+		capability := &Capability{ID: template.ID, Name: template.Name}
+		results[i] = capability
+
+		vp, err := definition.CreateVPWithOptions(vcs, loader, presexch.WithVersion(presexch.V2),
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(loader)))
+		if errors.Is(err, presexch.ErrNoCredentials) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("capability template %s: %w", template.ID, err)
+		}
+
+		capability.Satisfiable = true
+
+		for _, credential := range vp.Credentials() {
+			if vc, ok := credential.(*verifiable.Credential); ok && vc.ID != "" {
+				capability.Credentials = append(capability.Credentials, vc.ID)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// dateNotAfterPattern returns the pattern of the dates (with an optional time) not after the given date, the dates
+// of the fixed width YYYY-MM-DD layout being compared in lexical order.
+func dateNotAfterPattern(date string) string {
+	alternatives := []string{date}
+
+	for i := range date {
+		if date[i] == '-' || date[i] == '0' {
+			continue
+		}
+
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "%s[0-%c]", date[:i], date[i]-1)
+
+		for _, c := range date[i+1:] {
+			if c == '-' {
+				b.WriteRune(c)
+			} else {
+				b.WriteString(`\d`)
+			}
+		}
+
+		alternatives = append(alternatives, b.String())
+	}
+
+	return "^(" + strings.Join(alternatives, "|") + ")([T ].*)?$"
+}
@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ConformanceProfile is an interoperability profile constraining the SIOP requests the wallet accepts and the
+// responses it creates.
+type ConformanceProfile string
+
+// JWTVCPresentationProfile is the JWT VC Presentation Profile
+// (https://identity.foundation/jwt-vc-presentation-profile/): signed request objects and 'post' responses, ES256K or
+// EdDSA signatures, did:web or did:jwk relying parties and holders, and VP tokens as JWT presentations.
+const JWTVCPresentationProfile ConformanceProfile = "jwt_vc_presentation_profile"
+
+const (
+	// algorithm of the ID tokens and VP tokens signed by the wallet.
+	siopSigningAlgorithm = "EdDSA"
+	// parts of a DID: scheme, method and method specific ID.
+	didParts = 3
+)
+
+// conformanceRules are the constraints of a conformance profile.
+type conformanceRules struct {
+	name         ConformanceProfile
+	algorithms   []string
+	didMethods   []string
+	responseMode string
+}
+
+// nolint: gochecknoglobals
+var conformanceProfiles = map[ConformanceProfile]*conformanceRules{
+	JWTVCPresentationProfile: {
+		name:         JWTVCPresentationProfile,
+		algorithms:   []string{"ES256K", "EdDSA"},
+		didMethods:   []string{"web", "jwk"},
+		responseMode: SIOPResponseModePost,
+	},
+}
+
+// WithSIOPConformanceProfile enforces the constraints of the given conformance profile on the SIOP requests parsed
+// and the responses created, unknown profiles are ignored.
+func WithSIOPConformanceProfile(profile ConformanceProfile) SIOPOptions {
+	return func(opts *siopOpts) {
+		opts.profile = conformanceProfiles[profile]
+	}
+}
+
+// siopClientMetadata are the client metadata constrained by the conformance profiles.
+type siopClientMetadata struct {
+	SubjectSyntaxTypesSupported []string `json:"subject_syntax_types_supported,omitempty"`
+	IDTokenSigningAlgValues     []string `json:"id_token_signing_alg_values_supported,omitempty"`
+	VPFormats                   *struct {
+		JWTVP *struct {
+			Alg []string `json:"alg,omitempty"`
+		} `json:"jwt_vp,omitempty"`
+	} `json:"vp_formats,omitempty"`
+}
+
+// validateRequest validates the request against the profile, the algorithm of its request object being empty if
+// the request has none.
+func (r *conformanceRules) validateRequest(req *SIOPRequest, requestObjectAlg string) error {
+	if requestObjectAlg == "" {
+		return fmt.Errorf("signed request object is required by the %s", r.name)
+	}
+
+	if !containsString(r.algorithms, requestObjectAlg) {
+		return fmt.Errorf("request object algorithm '%s' is not allowed by the %s", requestObjectAlg, r.name)
+	}
+
+	if req.ResponseMode != r.responseMode {
+		return fmt.Errorf("response mode '%s' is not allowed by the %s", req.ResponseMode, r.name)
+	}
+
+	if err := r.validateDID(req.ClientID); err != nil {
+		return fmt.Errorf("invalid client_id: %w", err)
+	}
+
+	if len(req.ClientMetadata) > 0 {
+		var metadata siopClientMetadata
+
+		err := json.Unmarshal(req.ClientMetadata, &metadata)
+		if err != nil {
+			return fmt.Errorf("invalid client metadata: %w", err)
+		}
+
+		err = r.validateClientMetadata(&metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pd := req.PresentationDefinition; pd != nil && pd.Format != nil {
+		if pd.Format.JwtVP == nil {
+			return fmt.Errorf("presentation definition must accept the 'jwt_vp' format of the %s", r.name)
+		}
+
+		if len(pd.Format.JwtVP.Alg) > 0 && !containsString(pd.Format.JwtVP.Alg, siopSigningAlgorithm) {
+			return fmt.Errorf("presentation definition does not accept %s VP tokens", siopSigningAlgorithm)
+		}
+	}
+
+	return nil
+}
+
+func (r *conformanceRules) validateClientMetadata(metadata *siopClientMetadata) error {
+	if len(metadata.SubjectSyntaxTypesSupported) > 0 {
+		supported := false
+
+		for _, method := range r.didMethods {
+			supported = supported || containsString(metadata.SubjectSyntaxTypesSupported, "did:"+method)
+		}
+
+		if !supported {
+			return fmt.Errorf("client supports none of the subject syntax types of the %s", r.name)
+		}
+	}
+
+	if len(metadata.IDTokenSigningAlgValues) > 0 &&
+		!containsString(metadata.IDTokenSigningAlgValues, siopSigningAlgorithm) {
+		return fmt.Errorf("client does not accept %s ID tokens", siopSigningAlgorithm)
+	}
+
+	if metadata.VPFormats != nil && metadata.VPFormats.JWTVP == nil {
+		return fmt.Errorf("client must accept the 'jwt_vp' format of the %s", r.name)
+	}
+
+	if metadata.VPFormats != nil && len(metadata.VPFormats.JWTVP.Alg) > 0 &&
+		!containsString(metadata.VPFormats.JWTVP.Alg, siopSigningAlgorithm) {
+		return fmt.Errorf("client does not accept %s VP tokens", siopSigningAlgorithm)
+	}
+
+	return nil
+}
+
+// validateResponse validates the response to be created to the request with the given proof options.
+func (r *conformanceRules) validateResponse(req *SIOPRequest, options *ProofOptions) error {
+	if req.ResponseMode != r.responseMode {
+		return fmt.Errorf("response mode '%s' is not allowed by the %s", req.ResponseMode, r.name)
+	}
+
+	if err := r.validateDID(options.Controller); err != nil {
+		return fmt.Errorf("invalid controller: %w", err)
+	}
+
+	return nil
+}
+
+func (r *conformanceRules) validateDID(id string) error {
+	parts := strings.SplitN(id, ":", didParts)
+	if len(parts) != didParts || parts[0] != "did" {
+		return errors.New("DID is required")
+	}
+
+	if !containsString(r.didMethods, parts[1]) {
+		return fmt.Errorf("DID method '%s' is not allowed by the %s", parts[1], r.name)
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
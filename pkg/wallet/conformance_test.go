@@ -0,0 +1,383 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	jwkvdr "github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+// nolint: gocyclo
+func TestWallet_SIOPConformanceProfile(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			switch {
+			case strings.HasPrefix(didID, "did:key:"):
+				return key.New().Read(didID)
+			case strings.HasPrefix(didID, "did:jwk:"):
+				return jwkvdr.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	// holder did:jwk.
+	keyPair, err := walletInstance.CreateKeyPair(tkn, kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, err := base64.RawURLEncoding.DecodeString(keyPair.PublicKey)
+	require.NoError(t, err)
+
+	holderJWK, err := jwksupport.JWKFromKey(ed25519.PublicKey(pubKey))
+	require.NoError(t, err)
+
+	holderDID, err := jwkvdr.DIDFromJWK(holderJWK)
+	require.NoError(t, err)
+
+	vc, err := (&verifiable.Credential{
+		Context:      []string{verifiable.ContextURI},
+		Types:        []string{verifiable.VCType},
+		ID:           "http://example.edu/credentials/9999",
+		CustomFields: map[string]interface{}{"first_name": "Jesse"},
+		Issued:       &util.TimeWrapper{Time: time.Now()},
+		Issuer:       verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Subject:      holderDID,
+	}).MarshalJSON()
+	require.NoError(t, err)
+
+	require.NoError(t, walletInstance.Add(tkn, Credential, vc))
+
+	// relying party did:jwk, signing with ES256K.
+	rpPriv, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	rpJWK, err := jwksupport.JWKFromKey(&rpPriv.PublicKey)
+	require.NoError(t, err)
+
+	rpDID, err := jwkvdr.DIDFromJWK(rpJWK)
+	require.NoError(t, err)
+
+	pd := &presexch.PresentationDefinition{
+		ID:     uuid.New().String(),
+		Format: &presexch.Format{JwtVP: &presexch.JwtType{Alg: []string{"ES256K", "EdDSA"}}},
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID: uuid.New().String(),
+			Schema: []*presexch.Schema{{
+				URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+			}},
+			Constraints: &presexch.Constraints{
+				Fields: []*presexch.Field{{Path: []string{"$.first_name"}}},
+			},
+		}},
+	}
+
+	var (
+		requestObject string
+		received      url.Values
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, e := rw.Write([]byte(requestObject))
+			require.NoError(t, e)
+
+			return
+		}
+
+		require.NoError(t, r.ParseForm())
+		received = r.PostForm
+	}))
+	defer server.Close()
+
+	requestClaims := func(mutate func(claims map[string]interface{})) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":           rpDID,
+			"client_id":     rpDID,
+			"redirect_uri":  server.URL,
+			"response_type": "id_token",
+			"response_mode": SIOPResponseModePost,
+			"scope":         "openid",
+			"nonce":         sampleSIOPNonce,
+			"registration": map[string]interface{}{
+				"subject_syntax_types_supported":        []string{"did:web", "did:jwk"},
+				"id_token_signing_alg_values_supported": []string{"ES256K", "EdDSA"},
+				"vp_formats": map[string]interface{}{
+					"jwt_vp": map[string]interface{}{"alg": []string{"ES256K", "EdDSA"}},
+					"jwt_vc": map[string]interface{}{"alg": []string{"ES256K", "EdDSA"}},
+				},
+			},
+			"claims": map[string]interface{}{
+				"vp_token": map[string]interface{}{"presentation_definition": pd},
+			},
+		}
+
+		if mutate != nil {
+			mutate(claims)
+		}
+
+		return claims
+	}
+
+	profile := WithSIOPConformanceProfile(JWTVCPresentationProfile)
+
+	t.Run("parse request and respond with VP token - success", func(t *testing.T) {
+		requestObject = signES256KRequestObject(t, rpPriv, rpDID+"#0", requestClaims(nil))
+
+		request, err := walletInstance.ParseSIOPRequest("openid-vc://?request_uri="+url.QueryEscape(server.URL),
+			profile)
+		require.NoError(t, err)
+		require.Equal(t, rpDID, request.ClientID)
+		require.Equal(t, pd.ID, request.PresentationDefinition.ID)
+
+		response, err := walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: holderDID},
+			profile)
+		require.NoError(t, err)
+		require.NotEmpty(t, response.VPToken)
+		require.Equal(t, response.IDToken, received.Get("id_token"))
+		require.Equal(t, response.VPToken, received.Get("vp_token"))
+
+		keyFetcher := verifiable.NewVDRKeyResolver(customVDR).PublicKeyFetcher()
+
+		// the ID token refers to the credentials nested in the VP token.
+		idToken, err := jwt.Parse(response.IDToken, jwt.WithSignatureVerifier(jwt.NewVerifier(
+			jwt.KeyResolverFunc(keyFetcher))))
+		require.NoError(t, err)
+
+		var idTokenClaims siopIDTokenClaims
+
+		require.NoError(t, idToken.DecodeClaims(&idTokenClaims))
+		require.Equal(t, holderDID, idTokenClaims.Subject)
+
+		submission := idTokenClaims.VPToken.PresentationSubmission
+		require.Equal(t, pd.ID, submission.DefinitionID)
+		require.Len(t, submission.DescriptorMap, 1)
+		require.Equal(t, "$", submission.DescriptorMap[0].Path)
+		require.Equal(t, "jwt_vp", submission.DescriptorMap[0].Format)
+		require.Equal(t, "$.verifiableCredential[0]", submission.DescriptorMap[0].PathNested.Path)
+
+		// the VP token is bound to the nonce and the client.
+		vpToken, err := jwt.Parse(response.VPToken, jwt.WithSignatureVerifier(jwt.NewVerifier(
+			jwt.KeyResolverFunc(keyFetcher))))
+		require.NoError(t, err)
+
+		var vpTokenClaims map[string]interface{}
+
+		require.NoError(t, vpToken.DecodeClaims(&vpTokenClaims))
+		require.Equal(t, holderDID, vpTokenClaims["iss"])
+		require.Equal(t, rpDID, vpTokenClaims["aud"])
+		require.Equal(t, sampleSIOPNonce, vpTokenClaims["nonce"])
+
+		vp, err := verifiable.ParsePresentation([]byte(response.VPToken),
+			verifiable.WithPresPublicKeyFetcher(keyFetcher),
+			verifiable.WithPresJSONLDDocumentLoader(mockctx.JSONLDDocumentLoader()))
+		require.NoError(t, err)
+		require.Equal(t, holderDID, vp.Holder)
+		require.Len(t, vp.Credentials(), 1)
+	})
+
+	t.Run("parse request - profile violations", func(t *testing.T) {
+		for expected, mutate := range map[string]func(claims map[string]interface{}){
+			"response mode 'fragment' is not allowed": func(claims map[string]interface{}) {
+				claims["response_mode"] = SIOPResponseModeFragment
+			},
+			"client supports none of the subject syntax types": func(claims map[string]interface{}) {
+				claims["registration"] = map[string]interface{}{"subject_syntax_types_supported": []string{"did:ion"}}
+			},
+			"client does not accept EdDSA ID tokens": func(claims map[string]interface{}) {
+				claims["registration"] = map[string]interface{}{"id_token_signing_alg_values_supported": []string{"ES256K"}}
+			},
+			"client must accept the 'jwt_vp' format": func(claims map[string]interface{}) {
+				claims["registration"] = map[string]interface{}{"vp_formats": map[string]interface{}{}}
+			},
+			"client does not accept EdDSA VP tokens": func(claims map[string]interface{}) {
+				claims["registration"] = map[string]interface{}{"vp_formats": map[string]interface{}{
+					"jwt_vp": map[string]interface{}{"alg": []string{"ES256K"}},
+				}}
+			},
+			"invalid client metadata": func(claims map[string]interface{}) {
+				claims["registration"] = "metadata"
+			},
+			"presentation definition must accept the 'jwt_vp' format": func(claims map[string]interface{}) {
+				claims["claims"] = map[string]interface{}{"vp_token": map[string]interface{}{
+					"presentation_definition": map[string]interface{}{"id": "pd", "format": map[string]interface{}{
+						"ldp_vp": map[string]interface{}{"proof_type": []string{"Ed25519Signature2018"}},
+					}},
+				}}
+			},
+			"presentation definition does not accept EdDSA VP tokens": func(claims map[string]interface{}) {
+				claims["claims"] = map[string]interface{}{"vp_token": map[string]interface{}{
+					"presentation_definition": map[string]interface{}{"id": "pd", "format": map[string]interface{}{
+						"jwt_vp": map[string]interface{}{"alg": []string{"ES256K"}},
+					}},
+				}}
+			},
+		} {
+			object := signES256KRequestObject(t, rpPriv, rpDID+"#0", requestClaims(mutate))
+
+			_, err := walletInstance.ParseSIOPRequest("openid-vc://?request="+object, profile)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expected)
+		}
+
+		// no request object.
+		_, err := walletInstance.ParseSIOPRequest("openid-vc://?"+url.Values{
+			"response_type": {"id_token"},
+			"client_id":     {rpDID},
+			"redirect_uri":  {server.URL},
+			"response_mode": {SIOPResponseModePost},
+			"scope":         {"openid"},
+			"nonce":         {sampleSIOPNonce},
+		}.Encode(), profile)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed request object is required")
+
+		// relying party DID method not allowed.
+		rpPub, rpEdPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		rpKeyDID, rpKeyID := fingerprint.CreateDIDKey(rpPub)
+		object := signSIOPRequestObject(t, rpEdPriv, rpKeyID,
+			requestClaims(func(claims map[string]interface{}) {
+				claims["iss"] = rpKeyDID
+				claims["client_id"] = rpKeyDID
+			}))
+
+		_, err = walletInstance.ParseSIOPRequest("openid-vc://?request="+object, profile)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DID method 'key' is not allowed")
+
+		// the same request is accepted out of the profile.
+		_, err = walletInstance.ParseSIOPRequest("openid-vc://?request=" + object)
+		require.NoError(t, err)
+
+		// unknown profiles are ignored.
+		_, err = walletInstance.ParseSIOPRequest("openid-vc://?request="+object, WithSIOPConformanceProfile("unknown"))
+		require.NoError(t, err)
+	})
+
+	t.Run("respond to request - profile violations", func(t *testing.T) {
+		request := &SIOPRequest{
+			ClientID:     rpDID,
+			RedirectURI:  server.URL,
+			ResponseMode: SIOPResponseModePost,
+			Nonce:        sampleSIOPNonce,
+		}
+
+		_, err := walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: didKey}, profile)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DID method 'key' is not allowed")
+
+		request.ResponseMode = SIOPResponseModeFragment
+
+		_, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: holderDID}, profile)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "response mode 'fragment' is not allowed")
+
+		// no credentials matching the presentation definition.
+		request.ResponseMode = SIOPResponseModePost
+		request.PresentationDefinition = &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*presexch.Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				Constraints: &presexch.Constraints{
+					Fields: []*presexch.Field{{Path: []string{"$.last_name"}}},
+				},
+			}},
+		}
+
+		_, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: holderDID}, profile)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no credentials satisfy the presentation definition")
+	})
+}
+
+func signES256KRequestObject(t *testing.T, privKey *ecdsa.PrivateKey, kid string,
+	claims map[string]interface{}) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderKeyID: kid}, &es256kTestSigner{privKey: privKey})
+	require.NoError(t, err)
+
+	requestObject, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return requestObject
+}
+
+type es256kTestSigner struct {
+	privKey *ecdsa.PrivateKey
+}
+
+func (s *es256kTestSigner) Sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, hashed[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 64) // nolint: gomnd
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	return signature, nil
+}
+
+func (s *es256kTestSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "ES256K"}
+}
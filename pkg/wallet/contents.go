@@ -72,6 +72,10 @@ func (ct ContentType) Name() string {
 const (
 	// collectionMappingKeyPrefix is db name space for saving collection ID to wallet content mappings.
 	collectionMappingKeyPrefix = "collectionmapping"
+
+	// keyIDs is internal content type for saving IDs of the keys imported into or created by wallet KMS,
+	// so that they can be exported along with wallet contents.
+	keyIDs ContentType = "keyID"
 )
 
 // keyContent is wallet content for key type
@@ -125,6 +129,7 @@ func newContentStore(p storage.Provider, pr *profile) *contentStore {
 func (cs *contentStore) Open(auth string, opts *unlockOpts) error {
 	store, err := cs.provider.OpenStore(auth, opts, storage.StoreConfiguration{TagNames: []string{
 		Collection.Name(), Credential.Name(), Connection.Name(), DIDResolutionResponse.Name(), Connection.Name(), Key.Name(),
+		keyIDs.Name(),
 	}})
 	if err != nil {
 		return err
@@ -219,7 +224,12 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 			return fmt.Errorf("failed to read key contents: %w", err)
 		}
 
-		return saveKey(auth, &key)
+		kids, err := saveKey(auth, &key)
+		if err != nil {
+			return err
+		}
+
+		return cs.saveKeyIDs(auth, kids...)
 	default:
 		return fmt.Errorf("invalid content type '%s', supported types are %s", ct,
 			[]ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection, Key})
@@ -270,18 +280,44 @@ func (cs *contentStore) mapCollection(auth, key, collectionID string, ct Content
 		storage.Tag{Name: base64.StdEncoding.EncodeToString([]byte(collectionID))})
 }
 
-func saveKey(auth string, key *keyContent) error {
+func saveKey(auth string, key *keyContent) ([]string, error) {
+	var kids []string
+
 	if len(key.PrivateKeyJwk) > 0 {
-		err := importKeyJWK(auth, key)
+		kid, err := importKeyJWK(auth, key)
 		if err != nil {
-			return fmt.Errorf("failed to import private key jwk: %w", err)
+			return nil, fmt.Errorf("failed to import private key jwk: %w", err)
 		}
+
+		kids = append(kids, kid)
 	}
 
 	if key.PrivateKeyBase58 != "" {
-		err := importKeyBase58(auth, key)
+		kid, err := importKeyBase58(auth, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import private key base58: %w", err)
+		}
+
+		kids = append(kids, kid)
+	}
+
+	return kids, nil
+}
+
+// saveKeyIDs saves IDs of the keys imported into or created by wallet KMS.
+func (cs *contentStore) saveKeyIDs(auth string, kids ...string) error {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	store, err := cs.open(auth)
+	if err != nil {
+		return err
+	}
+
+	for _, kid := range kids {
+		err = store.Put(getContentKeyPrefix(keyIDs, kid), []byte(kid), storage.Tag{Name: keyIDs.Name()})
 		if err != nil {
-			return fmt.Errorf("failed to import private key base58: %w", err)
+			return fmt.Errorf("failed to save key ID: %w", err)
 		}
 	}
 
@@ -199,7 +199,7 @@ func TestContentStores(t *testing.T) {
 		// open store
 		require.NoError(t, contentStore.Open(token, &unlockOpts{}))
 		require.EqualValues(t, sp.config.TagNames,
-			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key"})
+			[]string{"collection", "credential", "connection", "didResolutionResponse", "connection", "key", "keyID"})
 
 		// close store
 		require.True(t, contentStore.Close())
@@ -380,6 +380,8 @@ func TestContentStores(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, tkn)
 
+		require.NoError(t, contentStore.Open(tkn, &unlockOpts{}))
+
 		// import base58 private key
 		err = contentStore.Save(tkn, Key, []byte(sampleKeyContentBase58Valid))
 		require.NoError(t, err)
@@ -388,6 +390,13 @@ func TestContentStores(t *testing.T) {
 		err = contentStore.Save(tkn, Key, []byte(sampleKeyContentJwkValid))
 		require.NoError(t, err)
 
+		// IDs of the imported keys are saved.
+		kids, err := contentStore.GetAll(tkn, keyIDs)
+		require.NoError(t, err)
+		require.Len(t, kids, 2)
+		require.Contains(t, kids, "key-1")
+		require.Contains(t, kids, "z6MkiEh8RQL83nkPo8ehDeX7")
+
 		// import using invalid auth token
 		err = contentStore.Save(tkn+"invalid", Key, []byte(sampleKeyContentBase58Valid))
 		require.True(t, errors.Is(err, ErrWalletLocked))
@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/aead/subtle"
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
+)
+
+const (
+	// exportVersion is the version of the exported wallet archive format.
+	exportVersion = "1"
+
+	// parameters of the key derived from the export password.
+	exportSaltLength     = 16
+	exportPBKDF2Iter     = 100000
+	exportKeyLength      = 32
+	exportKeyURI         = "local-lock://wallet-export"
+	exportAdditionalData = "vcwallet-export-v" + exportVersion
+)
+
+// exportedContentTypes are the wallet content types exported, in the order they're imported (collections first,
+// for the other contents to be mapped to them).
+// nolint:gochecknoglobals
+var exportedContentTypes = []ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection}
+
+// exportedWallet is the encrypted wallet archive produced by Export.
+type exportedWallet struct {
+	// Version of the archive format.
+	Version string `json:"version"`
+	// Salt of the key derived from the export password.
+	Salt []byte `json:"salt"`
+	// EncryptedKey is the random key encrypting the archive, itself encrypted by the key derived from the password.
+	EncryptedKey string `json:"encryptedKey"`
+	// Ciphertext is the encrypted walletArchive.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// walletArchive holds the wallet contents and keys exported.
+type walletArchive struct {
+	Contents []*archivedContent `json:"contents,omitempty"`
+	Keys     []*archivedKey     `json:"keys,omitempty"`
+}
+
+type archivedContent struct {
+	ContentType  ContentType     `json:"type"`
+	Content      json.RawMessage `json:"content"`
+	CollectionID string          `json:"collectionID,omitempty"`
+}
+
+type archivedKey struct {
+	ID     string `json:"id"`
+	KeySet []byte `json:"keyset"`
+}
+
+// keySetExporter is implemented by key managers supporting export of their private keys, like localkms.
+type keySetExporter interface {
+	ExportKeySet(keyID string) ([]byte, error)
+	ImportKeySet(marshalledKeySet []byte, opts ...kms.PrivateKeyOpts) (string, interface{}, error)
+}
+
+// archive reads all wallet contents and keys into an archive.
+func (c *Wallet) archive(auth string) (*walletArchive, error) {
+	collectionIDs, err := c.contentCollections(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &walletArchive{}
+
+	for _, ct := range exportedContentTypes {
+		contents, err := c.contents.GetAll(auth, ct)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read wallet %s contents: %w", ct, err)
+		}
+
+		for key, content := range contents {
+			archive.Contents = append(archive.Contents, &archivedContent{
+				ContentType:  ct,
+				Content:      content,
+				CollectionID: collectionIDs[getContentKeyPrefix(ct, key)],
+			})
+		}
+	}
+
+	kids, err := c.contents.GetAll(auth, keyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet key IDs: %w", err)
+	}
+
+	if len(kids) == 0 {
+		return archive, nil
+	}
+
+	exporter, err := getKeySetExporter(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	for kid := range kids {
+		ks, err := exporter.ExportKeySet(kid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export key '%s': %w", kid, err)
+		}
+
+		archive.Keys = append(archive.Keys, &archivedKey{ID: kid, KeySet: ks})
+	}
+
+	return archive, nil
+}
+
+// contentCollections returns IDs of the collections the wallet contents are mapped to, by content storage key.
+func (c *Wallet) contentCollections(auth string) (map[string]string, error) {
+	collections, err := c.contents.GetAll(auth, Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet collections: %w", err)
+	}
+
+	collectionIDs := make(map[string]string)
+
+	for collectionID := range collections {
+		for _, ct := range exportedContentTypes {
+			contents, err := c.contents.GetAllByCollection(auth, collectionID, ct)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read wallet collection '%s': %w", collectionID, err)
+			}
+
+			for key := range contents {
+				collectionIDs[getContentKeyPrefix(ct, key)] = collectionID
+			}
+		}
+	}
+
+	return collectionIDs, nil
+}
+
+// restore imports the archived keys and contents into the wallet.
+func (c *Wallet) restore(auth string, archive *walletArchive) error {
+	if len(archive.Keys) > 0 {
+		exporter, err := getKeySetExporter(auth)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range archive.Keys {
+			kid, _, err := exporter.ImportKeySet(key.KeySet, kms.WithKeyID(key.ID))
+			if err != nil {
+				return fmt.Errorf("failed to import key '%s': %w", key.ID, err)
+			}
+
+			err = c.contents.saveKeyIDs(auth, kid)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ct := range exportedContentTypes {
+		for _, content := range archive.Contents {
+			if content.ContentType != ct {
+				continue
+			}
+
+			err := c.contents.Save(auth, ct, content.Content, AddByCollection(content.CollectionID))
+			if err != nil {
+				return fmt.Errorf("failed to import wallet %s content: %w", ct, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func getKeySetExporter(auth string) (keySetExporter, error) {
+	kmgr, err := keyManager().getKeyManger(auth)
+	if err != nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	exporter, ok := kmgr.(keySetExporter)
+	if !ok {
+		return nil, errors.New("wallet key manager doesn't support key export")
+	}
+
+	return exporter, nil
+}
+
+// encryptArchive encrypts the archive with a random key, itself encrypted with the key derived from the password.
+func encryptArchive(password string, archive *walletArchive) (*exportedWallet, error) {
+	archiveBytes, err := json.Marshal(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet archive: %w", err)
+	}
+
+	salt := random.GetRandomBytes(exportSaltLength)
+
+	passwordLock, err := pbkdf2.NewMasterLock(password, sha256.New, exportPBKDF2Iter, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key := random.GetRandomBytes(exportKeyLength)
+
+	encryptedKey, err := passwordLock.Encrypt(exportKeyURI, &secretlock.EncryptRequest{Plaintext: string(key)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet archive key: %w", err)
+	}
+
+	cipher, err := subtle.NewAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := cipher.Encrypt(archiveBytes, []byte(exportAdditionalData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet archive: %w", err)
+	}
+
+	return &exportedWallet{
+		Version:      exportVersion,
+		Salt:         salt,
+		EncryptedKey: encryptedKey.Ciphertext,
+		Ciphertext:   ciphertext,
+	}, nil
+}
+
+// decryptArchive decrypts the archive of the exported wallet with the password used while exporting.
+func decryptArchive(password string, exported *exportedWallet) (*walletArchive, error) {
+	if exported.Version != exportVersion {
+		return nil, fmt.Errorf("unsupported wallet archive version '%s'", exported.Version)
+	}
+
+	passwordLock, err := pbkdf2.NewMasterLock(password, sha256.New, exportPBKDF2Iter, exported.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := passwordLock.Decrypt(exportKeyURI, &secretlock.DecryptRequest{Ciphertext: exported.EncryptedKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet archive key, invalid password: %w", err)
+	}
+
+	cipher, err := subtle.NewAESGCM([]byte(key.Plaintext))
+	if err != nil {
+		return nil, err
+	}
+
+	archiveBytes, err := cipher.Decrypt(exported.Ciphertext, []byte(exportAdditionalData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet archive: %w", err)
+	}
+
+	var archive walletArchive
+
+	err = json.Unmarshal(archiveBytes, &archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet archive: %w", err)
+	}
+
+	return &archive, nil
+}
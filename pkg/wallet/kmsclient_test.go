@@ -333,7 +333,7 @@ func TestImportKeyJWK(t *testing.T) {
 				error: " unknown json web key type",
 			},
 			{
-				name: "import secp256k1 failure - unsupported curve",
+				name: "import secp256k1",
 				sampleJWK: []byte(`{
 					"kty": "EC",
       				"crv": "secp256k1",
@@ -341,8 +341,7 @@ func TestImportKeyJWK(t *testing.T) {
       				"y": "SChlfVBhTXG_sRGc9ZdFeCYzI3Kbph3ivE12OFVk4jo",
       				"d": "m5N7gTItgWz6udWjuqzJsqX-vksUnxJrNjD5OilScBc"
     				}`),
-				ID:    "did:example:123#z6MkiEh8RQL83nkPo8ehDeE7",
-				error: "unsupported Key type secp256k1",
+				ID: "did:example:123#z6MkiEh8RQL83nkPo8ehDeE11",
 			},
 			{
 				name: "import Ed25519 failure - incorrect key type",
@@ -362,14 +361,14 @@ func TestImportKeyJWK(t *testing.T) {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
 				if tc.error != "" {
-					err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
+					_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
 					require.Error(t, err)
 					require.Contains(t, err.Error(), tc.error)
 
 					return
 				}
 
-				err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
+				_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: tc.sampleJWK, ID: tc.ID})
 				require.NoError(t, err)
 
 				kmgr, err := keyManager().getKeyManger(tkn)
@@ -383,7 +382,7 @@ func TestImportKeyJWK(t *testing.T) {
 	})
 
 	t.Run("test key ID already exists", func(t *testing.T) {
-		err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err := importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -393,7 +392,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.NoError(t, err)
 
 		// import different key with same key ID
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
       						"kty": "EC",
       						"crv": "P-384",
       						"x": "eQbMauiHc9HuiqXT894gW5XTCrOpeY8cjLXAckfRtdVBLzVHKaiXAAxBFeVrSB75",
@@ -405,7 +404,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.Contains(t, err.Error(), "requested ID 'z6MkiEh8RQL83nkPo8ehDeX7' already exists")
 
 		// import different key with same content ID (missing kid)
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
       						"kty": "EC",
       						"crv": "P-384",
       						"x": "eQbMauiHc9HuiqXT894gW5XTCrOpeY8cjLXAckfRtdVBLzVHKaiXAAxBFeVrSB75",
@@ -416,7 +415,7 @@ func TestImportKeyJWK(t *testing.T) {
 		require.Contains(t, err.Error(), "requested ID 'z6MkiEh8RQL83nkPo8ehDeX7' already exists")
 
 		// no KID
-		err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
+		_, err = importKeyJWK(tkn, &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -426,7 +425,7 @@ func TestImportKeyJWK(t *testing.T) {
 	})
 
 	t.Run("test key manager errors", func(t *testing.T) {
-		err := importKeyJWK(tkn+"invalid", &keyContent{PrivateKeyJwk: []byte(`{
+		_, err := importKeyJWK(tkn+"invalid", &keyContent{PrivateKeyJwk: []byte(`{
 							"kty": "OKP",
 							"d":"Dq5t2WS3OMzcpkh8AyVxJs5r9v4L39ocIz9CpUOqM40",
 							"crv": "Ed25519",
@@ -498,7 +497,7 @@ func TestImportKeyBase58(t *testing.T) {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
 				if tc.error != "" {
-					err := importKeyBase58(tkn, &keyContent{
+					_, err := importKeyBase58(tkn, &keyContent{
 						ID:               tc.ID,
 						PrivateKeyBase58: tc.keyBase58,
 						KeyType:          tc.keyType,
@@ -509,7 +508,7 @@ func TestImportKeyBase58(t *testing.T) {
 					return
 				}
 
-				err := importKeyBase58(tkn, &keyContent{
+				_, err := importKeyBase58(tkn, &keyContent{
 					ID:               tc.ID,
 					PrivateKeyBase58: tc.keyBase58,
 					KeyType:          tc.keyType,
@@ -527,14 +526,14 @@ func TestImportKeyBase58(t *testing.T) {
 	})
 
 	t.Run("test key ID already exists", func(t *testing.T) {
-		err := importKeyBase58(tkn, &keyContent{
+		_, err := importKeyBase58(tkn, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE4",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
 		})
 		require.NoError(t, err)
 
-		err = importKeyBase58(tkn, &keyContent{
+		_, err = importKeyBase58(tkn, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE4",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -544,7 +543,7 @@ func TestImportKeyBase58(t *testing.T) {
 	})
 
 	t.Run("test key manager errors", func(t *testing.T) {
-		err := importKeyBase58(tkn+"invalid", &keyContent{
+		_, err := importKeyBase58(tkn+"invalid", &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -561,7 +560,7 @@ func TestImportKeyBase58(t *testing.T) {
 			&mockkms.KeyManager{ImportPrivateKeyErr: sampleErr}, 0)
 		require.NoError(t, err)
 
-		err = importKeyBase58(mockToken, &keyContent{
+		_, err = importKeyBase58(mockToken, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "zJRjGFZydU5DBdS2p5qbiUzDFAxbXTkjiDuGPksMBbY5TNyEsGfK4a4WGKjBCh1zeNryeuKtPotp8W1ESnwP71y",
 			KeyType:          "Ed25519VerificationKey2018",
@@ -569,7 +568,7 @@ func TestImportKeyBase58(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, sampleErr))
 
-		err = importKeyBase58(mockToken, &keyContent{
+		_, err = importKeyBase58(mockToken, &keyContent{
 			ID:               "did:example:123#z6MkiEh8RQL83nkPo8ehDeE5",
 			PrivateKeyBase58: "6gsgGpdx7p1nYoKJ4b5fKt1xEomWdnemg9nJFX6mqNCh",
 			KeyType:          "Bls12381G1Key2020",
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -17,7 +18,7 @@ import (
 // Refer https://w3c-ccg.github.io/vp-request-spec/#format for more details.
 type QueryParams struct {
 	// Type of the query.
-	// Allowed values  'QueryByExample', 'QueryByFrame', 'PresentationExchange', 'DIDAuth'
+	// Allowed values  'QueryByExample', 'QueryByFrame', 'PresentationExchange', 'DIDAuth', 'QueryByFilter'
 	Type string `json:"type"`
 
 	// Query can contain one or more credential queries.
@@ -52,6 +53,17 @@ type ProofOptions struct {
 	ProofRepresentation *verifiable.SignatureRepresentation `json:"proofRepresentation,omitempty"`
 }
 
+// SDJWTOptions model containing options for issuing a selective disclosure JWT (SD-JWT) credential.
+type SDJWTOptions struct {
+	// HolderPublicKey is the public key of the holder the credential is bound to, the holder proves the possession of
+	// its private key by adding a key binding JWT when presenting the credential.
+	// Optional, by default credential will not be bound to a holder key.
+	HolderPublicKey *jwk.JWK `json:"holderPublicKey,omitempty"`
+	// AlwaysDisclosed is the list of credential subject claims which are always disclosed to the verifiers.
+	// Optional, by default all credential subject claims are selectively disclosable.
+	AlwaysDisclosed []string `json:"alwaysDisclosed,omitempty"`
+}
+
 // DeriveOptions model containing options for deriving a credential.
 //
 type DeriveOptions struct {
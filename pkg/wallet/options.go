@@ -416,3 +416,19 @@ func FromRawPresentation(raw json.RawMessage) PresentProofFrom {
 		opts.rawPresentation = raw
 	}
 }
+
+// claimCredentialOpts contains options for claiming the credentials attached to an out-of-band invitation.
+type claimCredentialOpts struct {
+	// ID of the collection the claimed credentials are added to.
+	collectionID string
+}
+
+// ClaimCredentialOptions options for claiming the credentials attached to an out-of-band invitation.
+type ClaimCredentialOptions func(opts *claimCredentialOpts)
+
+// ClaimToCollection option for adding the claimed credentials to the collection.
+func ClaimToCollection(collectionID string) ClaimCredentialOptions {
+	return func(opts *claimCredentialOpts) {
+		opts.collectionID = collectionID
+	}
+}
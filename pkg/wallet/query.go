@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vcstore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 // Query errors.
@@ -37,11 +38,14 @@ const (
 	PresentationExchange
 	// DIDAuth https://w3c-ccg.github.io/vp-request-spec/#did-authentication-request
 	DIDAuth
+	// QueryByFilter filters the credentials on their issuer, types, subject, schemas and expiration, each credential
+	// query being a verifiable store query (see pkg/store/verifiable QueryParams) without limit nor page token.
+	QueryByFilter
 )
 
 // Name returns name of the query.
 func (q QueryType) Name() string {
-	return []string{"", "QueryByExample", "QueryByFrame", "PresentationExchange"}[q]
+	return []string{"", "QueryByExample", "QueryByFrame", "PresentationExchange", "DIDAuth", "QueryByFilter"}[q]
 }
 
 // GetQueryType returns QueryType instance for given string query type.
@@ -55,9 +59,11 @@ func GetQueryType(name string) (QueryType, error) {
 		return PresentationExchange, nil
 	case "didauth":
 		return DIDAuth, nil
+	case "querybyfilter":
+		return QueryByFilter, nil
 	default:
-		return 0, fmt.Errorf("unsupported query type, supported types - (%s, %s, %s)",
-			QueryByExample.Name(), QueryByFrame.Name(), PresentationExchange.Name())
+		return 0, fmt.Errorf("unsupported query type, supported types - (%s, %s, %s, %s)",
+			QueryByExample.Name(), QueryByFrame.Name(), PresentationExchange.Name(), QueryByFilter.Name())
 	}
 }
 
@@ -136,6 +142,8 @@ func (q *Query) getCredentials(qType QueryType, vcs []*verifiable.Credential, qu
 		return queryByExample(vcs, query...)
 	case QueryByFrame:
 		return queryByFrame(vcs, q.publicKeyFetcher, q.documentLoader, query...)
+	case QueryByFilter:
+		return queryByFilter(vcs, query...)
 	default:
 		return []*verifiable.Credential{}, nil
 	}
@@ -359,6 +367,32 @@ func queryByExample(vcs []*verifiable.Credential, defs ...json.RawMessage) ([]*v
 	return result, nil
 }
 
+func queryByFilter(vcs []*verifiable.Credential, defs ...json.RawMessage) ([]*verifiable.Credential, error) {
+	filters := make([]*vcstore.QueryParams, len(defs))
+
+	for i, def := range defs {
+		filters[i] = &vcstore.QueryParams{}
+
+		if err := json.Unmarshal(def, filters[i]); err != nil {
+			return nil, fmt.Errorf("failed to parse QueryByFilter query: %w", err)
+		}
+	}
+
+	var result []*verifiable.Credential
+
+	for _, vc := range vcs {
+		for _, filter := range filters {
+			if filter.MatchesCredential(vc) {
+				result = append(result, vc)
+
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func queryByFrame(vcs []*verifiable.Credential, publicKeyFetcher verifiable.PublicKeyFetcher, loader ld.DocumentLoader,
 	defs ...json.RawMessage) ([]*verifiable.Credential, error) {
 	definitions, err := parseQueryByFrame(defs...)
@@ -392,20 +426,39 @@ func queryByFrame(vcs []*verifiable.Credential, publicKeyFetcher verifiable.Publ
 	return result, nil
 }
 
-// queryByPresentationExchange generates presentation submission result based on given query.
+// presentationRequest is the request presentation attachment of the WACI share flow, holding the presentation
+// definition along with the proof options of the relying party.
+type presentationRequest struct {
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition"`
+}
+
+// queryByPresentationExchange generates presentation submission result based on given query. Each query is either
+// a presentation definition or a WACI request presentation attachment holding one. The presentation combines the
+// credentials of all the input descriptors picked to satisfy the submission requirements.
 func (q *Query) queryByPresentationExchange(vcs []*verifiable.Credential, defs ...json.RawMessage) ([]*verifiable.Presentation, error) { // nolint:lll
 	var results []*verifiable.Presentation
 
 	for _, def := range defs {
-		var presDefinition presexch.PresentationDefinition
+		var request presentationRequest
 
-		err := json.Unmarshal(def, &presDefinition)
+		err := json.Unmarshal(def, &request)
 		if err != nil {
 			return nil, err
 		}
 
-		result, err := presDefinition.CreateVP(vcs, q.documentLoader, verifiable.WithDisabledProofCheck(),
-			verifiable.WithJSONLDDocumentLoader(q.documentLoader))
+		presDefinition := request.PresentationDefinition
+		if presDefinition == nil {
+			presDefinition = &presexch.PresentationDefinition{}
+
+			err = json.Unmarshal(def, presDefinition)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result, err := presDefinition.CreateVPWithOptions(vcs, q.documentLoader, presexch.WithCombinedCredentials(),
+			presexch.WithCredentialOptions(verifiable.WithDisabledProofCheck(),
+				verifiable.WithJSONLDDocumentLoader(q.documentLoader)))
 
 		if errors.Is(err, presexch.ErrNoCredentials) {
 			continue
@@ -205,6 +205,12 @@ func TestGetQueryType(t *testing.T) {
 				expected:     DIDAuth,
 				expectedName: "DIDAuth",
 			},
+			{
+				name:         "test for QueryByFilter",
+				typeStr:      []string{"QueryByFilter", "querybyfilter"},
+				expected:     QueryByFilter,
+				expectedName: "QueryByFilter",
+			},
 			{
 				name:         "test for invalid types",
 				typeStr:      []string{"", "QueryByFram", "QueryByExamples", "DIDAuthorization", "invalid"},
@@ -276,6 +282,47 @@ func TestQuery_PerformQuery(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, pdJSON)
 
+	vc2, err := (&verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/1111",
+		CustomFields: map[string]interface{}{
+			"degree": "BachelorDegree",
+		},
+		Issued: &util.TimeWrapper{
+			Time: time.Now(),
+		},
+		Issuer: verifiable.Issuer{
+			ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		},
+		Subject: uuid.New().String(),
+	}).MarshalJSON()
+	require.NoError(t, err)
+
+	// WACI request presentation attachment, each credential satisfying one of the submission requirements
+	schema := []*presexch.Schema{{URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType)}}
+
+	waciRequestJSON, err := json.Marshal(map[string]interface{}{
+		"options": map[string]interface{}{"challenge": uuid.New().String()},
+		"presentation_definition": &presexch.PresentationDefinition{
+			ID: uuid.New().String(),
+			SubmissionRequirements: []*presexch.SubmissionRequirement{
+				{Rule: presexch.All, From: "A"},
+				{Rule: presexch.Pick, Count: 1, From: "B"},
+			},
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "name", Group: []string{"A"}, Schema: schema,
+				Constraints: &presexch.Constraints{Fields: []*presexch.Field{{Path: []string{"$.first_name"}}}},
+			}, {
+				ID: "degree", Group: []string{"B"}, Schema: schema,
+				Constraints: &presexch.Constraints{
+					Fields: []*presexch.Field{{Path: []string{"$.degree"}}},
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
 	udcVC := []byte(sampleUDCVC)
 	vcForQuery := []byte(fmt.Sprintf(sampleVCFmt, verifiable.ContextURI))
 	vcForDerive := []byte(sampleBBSVC)
@@ -339,6 +386,15 @@ func TestQuery_PerformQuery(t *testing.T) {
 				resultCount: 3,
 				vcCount:     map[int]int{0: 1, 1: 1, 2: 1},
 			},
+			{
+				name: "query by presentation exchange - WACI request combining credentials - success",
+				query: []*QueryParams{
+					{Type: "PresentationExchange", Query: []json.RawMessage{waciRequestJSON}},
+				},
+				credentials: []json.RawMessage{vc1, vc2},
+				resultCount: 1,
+				vcCount:     map[int]int{0: 2},
+			},
 			{
 				name: "query by presentation exchange - no results",
 				query: []*QueryParams{
@@ -1111,6 +1167,72 @@ func TestQueryByFrame(t *testing.T) {
 	})
 }
 
+func TestQueryByFilter(t *testing.T) {
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	degree := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType, "UniversityDegreeCredential"},
+		ID:      "http://example.edu/credentials/1872",
+		Issuer:  verifiable.Issuer{ID: "did:example:university"},
+		Subject: "did:example:alice",
+		Schemas: []verifiable.TypedID{{ID: "https://example.edu/schemas/degree", Type: "JsonSchemaValidator2018"}},
+		Expired: util.NewTime(expires),
+	}
+
+	license := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType, "DriversLicense"},
+		ID:      "http://example.gov/credentials/3732",
+		Issuer:  verifiable.Issuer{ID: "did:example:dmv"},
+		Subject: "did:example:alice",
+	}
+
+	vcs := []*verifiable.Credential{degree, license}
+
+	tests := []struct {
+		name     string
+		filters  []string
+		expected []*verifiable.Credential
+	}{
+		{name: "by issuer", filters: []string{`{"issuer":"did:example:dmv"}`},
+			expected: []*verifiable.Credential{license}},
+		{name: "by type and subject", filters: []string{
+			`{"type":"UniversityDegreeCredential","subjectId":"did:example:alice"}`,
+		}, expected: []*verifiable.Credential{degree}},
+		{name: "by schema", filters: []string{`{"schema":"https://example.edu/schemas/degree"}`},
+			expected: []*verifiable.Credential{degree}},
+		{name: "by expiration", filters: []string{`{"expiresBefore":"2031-01-01T00:00:00Z"}`},
+			expected: []*verifiable.Credential{degree}},
+		{name: "not expiring", filters: []string{`{"expiresAfter":"2031-01-01T00:00:00Z"}`},
+			expected: []*verifiable.Credential{license}},
+		{name: "any of the filters", filters: []string{`{"issuer":"did:example:dmv"}`, `{"type":"DriversLicense"}`},
+			expected: []*verifiable.Credential{license}},
+		{name: "no match", filters: []string{`{"issuer":"did:example:unknown"}`}},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			var defs []json.RawMessage
+
+			for _, f := range tc.filters {
+				defs = append(defs, json.RawMessage(f))
+			}
+
+			result, err := queryByFilter(vcs, defs...)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := queryByFilter(vcs, json.RawMessage(`{"issuer":1}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse QueryByFilter query")
+	})
+}
+
 func TestUtilFunctions(t *testing.T) {
 	require.True(t, isEmpty(""))
 	require.True(t, isEmpty([]string{}))
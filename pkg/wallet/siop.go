@@ -0,0 +1,707 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+)
+
+// SIOP response modes, the way the self-issued OpenID provider response is returned to the relying party.
+const (
+	// SIOPResponseModeFragment returns the response in the fragment of the redirect URI (default).
+	SIOPResponseModeFragment = "fragment"
+	// SIOPResponseModeQuery returns the response in the query of the redirect URI.
+	SIOPResponseModeQuery = "query"
+	// SIOPResponseModePost posts the response as a form to the redirect URI.
+	SIOPResponseModePost = "post"
+	// SIOPResponseModeDirectPost posts the response as a form to the redirect URI, without user agent redirect.
+	SIOPResponseModeDirectPost = "direct_post"
+)
+
+const (
+	siopResponseTypeIDToken = "id_token"
+	siopScopeOpenID         = "openid"
+	siopJWTAlgorithm        = "EdDSA"
+	siopFormContentType     = "application/x-www-form-urlencoded"
+	siopSubmissionProperty  = "presentation_submission"
+	siopFormatJWTVP         = "jwt_vp"
+	siopFormatLDPVC         = "ldp_vc"
+
+	defaultSIOPTokenExpiry = 10 * time.Minute
+)
+
+// HTTPClient is the HTTP client used to fetch request objects and to post responses to relying parties.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// SIOPRequest is a self-issued OpenID provider (SIOPv2) authentication request of a relying party.
+type SIOPRequest struct {
+	ClientID       string          `json:"client_id"`
+	RedirectURI    string          `json:"redirect_uri"`
+	ResponseType   string          `json:"response_type"`
+	ResponseMode   string          `json:"response_mode,omitempty"`
+	Scope          string          `json:"scope"`
+	Nonce          string          `json:"nonce"`
+	State          string          `json:"state,omitempty"`
+	ClientMetadata json.RawMessage `json:"client_metadata,omitempty"`
+	// PresentationDefinition is the definition of the credentials requested in a VP token, if any.
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition,omitempty"`
+}
+
+// siopRequestObject is the request object of a SIOP request, the presentation definition of which may be given
+// as a 'vp_token' claim ('claims' parameter) and the client metadata as 'registration' (older drafts).
+type siopRequestObject struct {
+	SIOPRequest
+	Registration json.RawMessage `json:"registration,omitempty"`
+	Claims       *siopClaims     `json:"claims,omitempty"`
+}
+
+type siopClaims struct {
+	VPToken *struct {
+		PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition,omitempty"`
+	} `json:"vp_token,omitempty"`
+}
+
+// SIOPResponse is a self-issued OpenID provider response to a relying party.
+type SIOPResponse struct {
+	// IDToken is the self-issued ID token, signed by the DID key of the wallet.
+	IDToken string `json:"id_token"`
+	// State is the state of the request, if any.
+	State string `json:"state,omitempty"`
+	// VPToken is the verifiable presentation requested by the presentation definition of the request, as a JWT
+	// signed by the DID key of the wallet, if any.
+	VPToken string `json:"vp_token,omitempty"`
+	// RedirectURL is the URL the user agent is to be redirected to: for the 'fragment' and 'query' response modes
+	// the redirect URI carrying the response, for the post response modes the URL returned by the relying party
+	// after the response was posted to it, if any.
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
+
+// siopIDTokenClaims are the claims of a self-issued ID token, subject and issuer being the DID of the wallet user.
+type siopIDTokenClaims struct {
+	Issuer   string `json:"iss"`
+	Subject  string `json:"sub"`
+	Audience string `json:"aud"`
+	Nonce    string `json:"nonce"`
+	IssuedAt int64  `json:"iat"`
+	Expiry   int64  `json:"exp"`
+	// VPToken is the presentation submission of the VP token, if any.
+	VPToken *siopVPTokenClaim `json:"_vp_token,omitempty"`
+}
+
+type siopVPTokenClaim struct {
+	PresentationSubmission *presexch.PresentationSubmission `json:"presentation_submission"`
+}
+
+// SIOPOptions configures the parsing of SIOP requests and the responses to them.
+type SIOPOptions func(opts *siopOpts)
+
+type siopOpts struct {
+	httpClient  HTTPClient
+	tokenExpiry time.Duration
+	profile     *conformanceRules
+}
+
+// WithSIOPHTTPClient sets the HTTP client used to fetch request objects by reference and to post responses.
+func WithSIOPHTTPClient(client HTTPClient) SIOPOptions {
+	return func(opts *siopOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithSIOPTokenExpiry sets the lifetime of the ID tokens, 10 minutes by default.
+func WithSIOPTokenExpiry(expiry time.Duration) SIOPOptions {
+	return func(opts *siopOpts) {
+		opts.tokenExpiry = expiry
+	}
+}
+
+func newSIOPOpts(options []SIOPOptions) *siopOpts {
+	opts := &siopOpts{httpClient: http.DefaultClient, tokenExpiry: defaultSIOPTokenExpiry}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// ParseSIOPRequest parses a self-issued OpenID provider (SIOPv2) request, as an 'openid://' URI or any URL with the
+// request parameters in its query. Request objects, passed by value ('request') or by reference ('request_uri'),
+// must be signed by a DID key of the relying party, their parameters taking precedence over the query ones.
+//
+//	Args:
+//		- the SIOP request URI.
+//		- options for fetching the request object by reference.
+//
+// Returns: the validated SIOP request.
+func (c *Wallet) ParseSIOPRequest(request string, options ...SIOPOptions) (*SIOPRequest, error) {
+	opts := newSIOPOpts(options)
+
+	u, err := url.Parse(request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIOP request: %w", err)
+	}
+
+	params := u.Query()
+
+	req := &SIOPRequest{
+		ClientID:     params.Get("client_id"),
+		RedirectURI:  params.Get("redirect_uri"),
+		ResponseType: params.Get("response_type"),
+		ResponseMode: params.Get("response_mode"),
+		Scope:        params.Get("scope"),
+		Nonce:        params.Get("nonce"),
+		State:        params.Get("state"),
+	}
+
+	err = parseSIOPJSONParams(req, params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIOP request: %w", err)
+	}
+
+	requestObject, requestObjectAlg := params.Get("request"), ""
+
+	if requestURI := params.Get("request_uri"); requestURI != "" && requestObject == "" {
+		requestObject, err = fetchSIOPRequestObject(opts.httpClient, requestURI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIOP request: %w", err)
+		}
+	}
+
+	if requestObject != "" {
+		requestObjectAlg, err = c.mergeSIOPRequestObject(req, requestObject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIOP request: %w", err)
+		}
+	}
+
+	err = validateSIOPRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIOP request: %w", err)
+	}
+
+	if opts.profile != nil {
+		err = opts.profile.validateRequest(req, requestObjectAlg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIOP request: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+// parseSIOPJSONParams reads the request parameters with JSON values.
+func parseSIOPJSONParams(req *SIOPRequest, params url.Values) error {
+	for _, name := range []string{"registration", "client_metadata"} {
+		if metadata := params.Get(name); metadata != "" {
+			req.ClientMetadata = json.RawMessage(metadata)
+		}
+	}
+
+	if definition := params.Get("presentation_definition"); definition != "" {
+		req.PresentationDefinition = &presexch.PresentationDefinition{}
+
+		err := json.Unmarshal([]byte(definition), req.PresentationDefinition)
+		if err != nil {
+			return fmt.Errorf("invalid presentation definition: %w", err)
+		}
+	}
+
+	if claimsParam := params.Get("claims"); claimsParam != "" {
+		var claims siopClaims
+
+		err := json.Unmarshal([]byte(claimsParam), &claims)
+		if err != nil {
+			return fmt.Errorf("invalid claims: %w", err)
+		}
+
+		if claims.VPToken != nil && claims.VPToken.PresentationDefinition != nil {
+			req.PresentationDefinition = claims.VPToken.PresentationDefinition
+		}
+	}
+
+	return nil
+}
+
+func fetchSIOPRequestObject(client HTTPClient, requestURI string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, requestURI, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch request object: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch request object: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("failed to close request object response body: %v", e)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read request object: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch request object: status %d", resp.StatusCode)
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// mergeSIOPRequestObject verifies the signed request object against the DID key of the relying party and overrides
+// the request parameters with its claims, returning the algorithm it is signed with.
+func (c *Wallet) mergeSIOPRequestObject(req *SIOPRequest, requestObject string) (string, error) {
+	if !jwt.IsJWS(requestObject) {
+		return "", errors.New("request object must be signed")
+	}
+
+	keyFetcher := verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher()
+
+	token, err := jwt.Parse(requestObject, jwt.WithSignatureVerifier(jwt.NewVerifier(jwt.KeyResolverFunc(
+		func(issuer, kid string) (*verifier.PublicKey, error) {
+			signerDID := issuer
+			if i := strings.Index(kid, "#"); strings.HasPrefix(kid, "did:") && i > 0 {
+				signerDID = kid[:i]
+			}
+
+			if strings.HasPrefix(req.ClientID, "did:") && signerDID != req.ClientID {
+				return nil, fmt.Errorf("request object signed by '%s' instead of the client", signerDID)
+			}
+
+			return keyFetcher(signerDID, kid)
+		}))))
+	if err != nil {
+		return "", fmt.Errorf("failed to verify request object: %w", err)
+	}
+
+	var object siopRequestObject
+
+	err = token.DecodeClaims(&object)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode request object: %w", err)
+	}
+
+	if req.ClientID != "" && object.ClientID != "" && object.ClientID != req.ClientID {
+		return "", errors.New("request object client_id does not match the request one")
+	}
+
+	mergeSIOPParam(&req.ClientID, object.ClientID)
+	mergeSIOPParam(&req.RedirectURI, object.RedirectURI)
+	mergeSIOPParam(&req.ResponseType, object.ResponseType)
+	mergeSIOPParam(&req.ResponseMode, object.ResponseMode)
+	mergeSIOPParam(&req.Scope, object.Scope)
+	mergeSIOPParam(&req.Nonce, object.Nonce)
+	mergeSIOPParam(&req.State, object.State)
+
+	if len(object.Registration) > 0 {
+		req.ClientMetadata = object.Registration
+	}
+
+	if len(object.ClientMetadata) > 0 {
+		req.ClientMetadata = object.ClientMetadata
+	}
+
+	if object.PresentationDefinition != nil {
+		req.PresentationDefinition = object.PresentationDefinition
+	}
+
+	if object.Claims != nil && object.Claims.VPToken != nil && object.Claims.VPToken.PresentationDefinition != nil {
+		req.PresentationDefinition = object.Claims.VPToken.PresentationDefinition
+	}
+
+	alg, _ := token.Headers.Algorithm()
+
+	return alg, nil
+}
+
+func mergeSIOPParam(param *string, value string) {
+	if value != "" {
+		*param = value
+	}
+}
+
+func validateSIOPRequest(req *SIOPRequest) error {
+	if req.ResponseType != siopResponseTypeIDToken {
+		return fmt.Errorf("unsupported response type '%s'", req.ResponseType)
+	}
+
+	if !containsScope(req.Scope, siopScopeOpenID) {
+		return errors.New("'openid' scope is required")
+	}
+
+	if req.ClientID == "" || req.RedirectURI == "" || req.Nonce == "" {
+		return errors.New("'client_id', 'redirect_uri' and 'nonce' are required")
+	}
+
+	if _, err := url.ParseRequestURI(req.RedirectURI); err != nil {
+		return fmt.Errorf("invalid redirect URI: %w", err)
+	}
+
+	switch req.ResponseMode {
+	case "":
+		req.ResponseMode = SIOPResponseModeFragment
+	case SIOPResponseModeFragment, SIOPResponseModeQuery, SIOPResponseModePost, SIOPResponseModeDirectPost:
+	default:
+		return fmt.Errorf("unsupported response mode '%s'", req.ResponseMode)
+	}
+
+	return nil
+}
+
+func containsScope(scope, value string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RespondSIOPRequest responds to a self-issued OpenID provider (SIOPv2) request with an ID token signed by the
+// controller DID key, only Ed25519 keys are supported. Responses in the 'post' and 'direct_post' modes are posted to
+// the relying party, the ones in the 'fragment' and 'query' modes are returned as a redirect URL for the user agent.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- the SIOP request, as parsed by ParseSIOPRequest.
+//		- proof options for signing the ID token (only 'controller' and 'verificationMethod' are used).
+//		- options for the ID token lifetime and posting the response.
+//
+// Returns: the SIOP response.
+func (c *Wallet) RespondSIOPRequest(authToken string, request *SIOPRequest, proofOptions *ProofOptions,
+	options ...SIOPOptions) (*SIOPResponse, error) {
+	if request == nil {
+		return nil, errors.New("invalid SIOP request: request is required")
+	}
+
+	opts := newSIOPOpts(options)
+
+	err := c.validateProofOption(authToken, proofOptions, did.Authentication)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare proof: %w", err)
+	}
+
+	if opts.profile != nil {
+		err = opts.profile.validateResponse(request, proofOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SIOP response: %w", err)
+		}
+	}
+
+	signer, err := c.siopSigner(authToken, proofOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SIOP response: %w", err)
+	}
+
+	now := time.Now()
+	claims := &siopIDTokenClaims{
+		Issuer:   proofOptions.Controller,
+		Subject:  proofOptions.Controller,
+		Audience: request.ClientID,
+		Nonce:    request.Nonce,
+		IssuedAt: now.Unix(),
+		Expiry:   now.Add(opts.tokenExpiry).Unix(),
+	}
+
+	response := &SIOPResponse{State: request.State}
+
+	if request.PresentationDefinition != nil {
+		response.VPToken, claims.VPToken, err = c.createSIOPVPToken(authToken, request, claims, signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SIOP response: %w", err)
+		}
+	}
+
+	response.IDToken, err = signSIOPToken(claims, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SIOP response: %w", err)
+	}
+
+	switch request.ResponseMode {
+	case SIOPResponseModePost, SIOPResponseModeDirectPost:
+		response.RedirectURL, err = postSIOPResponse(opts.httpClient, request.RedirectURI, response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send SIOP response: %w", err)
+		}
+	default:
+		response.RedirectURL, err = redirectSIOPResponse(request, response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SIOP response: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+// createSIOPVPToken creates the verifiable presentation of the wallet credentials matching the presentation
+// definition of the request, as a JWT bound to the request nonce and client, along with its presentation submission
+// referring to the credentials nested in the JWT.
+func (c *Wallet) createSIOPVPToken(authToken string, request *SIOPRequest, idToken *siopIDTokenClaims,
+	signer *siopTokenSigner) (string, *siopVPTokenClaim, error) {
+	definition, err := json.Marshal(request.PresentationDefinition)
+	if err != nil {
+		return "", nil, err
+	}
+
+	presentations, err := c.Query(authToken, &QueryParams{
+		Type:  PresentationExchange.Name(),
+		Query: []json.RawMessage{definition},
+	})
+	if errors.Is(err, ErrQueryNoResultFound) || err == nil && len(presentations) == 0 {
+		return "", nil, errors.New("no credentials satisfy the presentation definition")
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to query credentials: %w", err)
+	}
+
+	vp := presentations[0]
+	vp.Holder = idToken.Subject
+
+	submission, ok := vp.CustomFields[siopSubmissionProperty].(*presexch.PresentationSubmission)
+	if !ok {
+		return "", nil, errors.New("missing presentation submission")
+	}
+
+	delete(vp.CustomFields, siopSubmissionProperty)
+
+	for i, mapping := range submission.DescriptorMap {
+		submission.DescriptorMap[i] = &presexch.InputDescriptorMapping{
+			ID:     mapping.ID,
+			Format: siopFormatJWTVP,
+			Path:   "$",
+			PathNested: &presexch.InputDescriptorMapping{
+				ID:     mapping.ID,
+				Format: siopFormatLDPVC,
+				Path:   mapping.Path,
+			},
+		}
+	}
+
+	vpClaims, err := vp.JWTClaims([]string{request.ClientID}, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims, err := toClaimsMap(vpClaims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims["nonce"] = idToken.Nonce
+	claims["iat"] = idToken.IssuedAt
+	claims["exp"] = idToken.Expiry
+
+	vpToken, err := signSIOPToken(claims, signer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return vpToken, &siopVPTokenClaim{PresentationSubmission: submission}, nil
+}
+
+func toClaimsMap(claims interface{}) (map[string]interface{}, error) {
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+
+	err = json.Unmarshal(claimsBytes, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func signSIOPToken(claims interface{}, signer *siopTokenSigner) (string, error) {
+	token, err := jwt.NewSigned(claims, nil, signer)
+	if err != nil {
+		return "", err
+	}
+
+	return token.Serialize(false)
+}
+
+// siopSigner returns the signer of the ID token with the wallet key of the verification method, looked up by the
+// fragment of the verification method (key ID of the imported keys), falling back to the thumbprint of its public
+// key (key ID of the keys created by the wallet).
+func (c *Wallet) siopSigner(authToken string, opts *ProofOptions) (*siopTokenSigner, error) {
+	resolved, err := newContentBasedVDR(authToken, c.vdr, c.contents).Resolve(opts.Controller)
+	if err != nil {
+		return nil, err
+	}
+
+	var pubKey []byte
+
+	for _, vm := range resolved.DIDDocument.VerificationMethods(did.Authentication)[did.Authentication] {
+		if vm.VerificationMethod.ID == opts.VerificationMethod {
+			pubKey = vm.VerificationMethod.Value
+
+			break
+		}
+	}
+
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, errors.New("only Ed25519 keys are supported")
+	}
+
+	s, err := newKMSSigner(authToken, c.walletCrypto, opts)
+	if err != nil && !errors.Is(err, ErrWalletLocked) {
+		s, err = newThumbprintKMSSigner(authToken, c.walletCrypto, pubKey)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &siopTokenSigner{kmsSigner: s, headers: jose.Headers{
+		jose.HeaderAlgorithm: siopJWTAlgorithm,
+		jose.HeaderKeyID:     opts.VerificationMethod,
+		jose.HeaderType:      jwt.TypeJWT,
+	}}, nil
+}
+
+func newThumbprintKMSSigner(authToken string, c crypto.Crypto, pubKey []byte) (*kmsSigner, error) {
+	keyManager, err := keyManager().getKeyManger(authToken)
+	if err != nil {
+		return nil, ErrWalletLocked
+	}
+
+	kid, err := localkms.CreateKID(pubKey, kms.ED25519Type)
+	if err != nil {
+		return nil, err
+	}
+
+	keyHandle, err := keyManager.Get(kid)
+	if err != nil {
+		return nil, fmt.Errorf("no wallet key found for the verification method: %w", err)
+	}
+
+	return &kmsSigner{keyHandle: keyHandle, crypto: c}, nil
+}
+
+// siopTokenSigner signs the ID tokens with the JOSE headers identifying the verification method.
+type siopTokenSigner struct {
+	*kmsSigner
+	headers jose.Headers
+}
+
+func (s *siopTokenSigner) Headers() jose.Headers {
+	return s.headers
+}
+
+func redirectSIOPResponse(request *SIOPRequest, response *SIOPResponse) (string, error) {
+	u, err := url.Parse(request.RedirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	params := siopResponseParams(response)
+
+	if request.ResponseMode == SIOPResponseModeQuery {
+		query := u.Query()
+
+		for k, v := range params {
+			query[k] = v
+		}
+
+		u.RawQuery = query.Encode()
+	} else {
+		u.Fragment = ""
+		u.RawFragment = ""
+
+		return u.String() + "#" + params.Encode(), nil
+	}
+
+	return u.String(), nil
+}
+
+// postSIOPResponse posts the response as a form to the relying party, returning the URL it redirects the user agent
+// to, if any.
+func postSIOPResponse(client HTTPClient, redirectURI string, response *SIOPResponse) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, redirectURI, strings.NewReader(siopResponseParams(response).Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", siopFormContentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("failed to close SIOP response body: %v", e)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf("relying party responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		RedirectURI string `json:"redirect_uri"`
+	}
+
+	// the relying party may return the URL to redirect the user agent to.
+	if json.Unmarshal(body, &result) == nil {
+		return result.RedirectURI, nil
+	}
+
+	return "", nil
+}
+
+func siopResponseParams(response *SIOPResponse) url.Values {
+	params := url.Values{"id_token": {response.IDToken}}
+
+	if response.VPToken != "" {
+		params.Set("vp_token", response.VPToken)
+	}
+
+	if response.State != "" {
+		params.Set("state", response.State)
+	}
+
+	return params
+}
@@ -0,0 +1,377 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	jwkvdr "github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const (
+	sampleRPRedirectURI = "https://rp.example.com/callback"
+	sampleSIOPNonce     = "n-0S6_WzA2Mj"
+	sampleSIOPState     = "af0ifjsldkj"
+)
+
+func TestWallet_SIOP(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			switch {
+			case strings.HasPrefix(didID, "did:key:"):
+				return key.New().Read(didID)
+			case strings.HasPrefix(didID, "did:jwk:"):
+				return jwkvdr.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	// did:key with an imported key, the key ID being the fragment of the verification method.
+	kmgr, err := keyManager().getKeyManger(tkn)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+	// did:jwk with a key created by the wallet, the key ID being the thumbprint of the public key.
+	keyPair, err := walletInstance.CreateKeyPair(tkn, kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, err := base64.RawURLEncoding.DecodeString(keyPair.PublicKey)
+	require.NoError(t, err)
+
+	pubJWK, err := jwksupport.JWKFromKey(ed25519.PublicKey(pubKey))
+	require.NoError(t, err)
+
+	didJWK, err := jwkvdr.DIDFromJWK(pubJWK)
+	require.NoError(t, err)
+
+	// relying party DID key signing the request objects.
+	rpPub, rpPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rpDID, rpKeyID := fingerprint.CreateDIDKey(rpPub)
+
+	query := url.Values{
+		"response_type": {"id_token"},
+		"client_id":     {sampleRPRedirectURI},
+		"redirect_uri":  {sampleRPRedirectURI},
+		"scope":         {"openid profile"},
+		"nonce":         {sampleSIOPNonce},
+		"state":         {sampleSIOPState},
+	}
+
+	t.Run("parse request and respond in fragment - success", func(t *testing.T) {
+		for _, controller := range []string{didKey, didJWK} {
+			request, err := walletInstance.ParseSIOPRequest("openid://?" + query.Encode())
+			require.NoError(t, err)
+			require.Equal(t, SIOPResponseModeFragment, request.ResponseMode)
+			require.Equal(t, sampleSIOPState, request.State)
+
+			response, err := walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: controller})
+			require.NoError(t, err)
+			require.Equal(t, sampleSIOPState, response.State)
+
+			u, err := url.Parse(response.RedirectURL)
+			require.NoError(t, err)
+			require.Equal(t, "rp.example.com", u.Host)
+
+			params, err := url.ParseQuery(u.Fragment)
+			require.NoError(t, err)
+			require.Equal(t, sampleSIOPState, params.Get("state"))
+			require.Equal(t, response.IDToken, params.Get("id_token"))
+
+			verifySIOPIDToken(t, customVDR, response.IDToken, controller)
+		}
+	})
+
+	t.Run("respond in query - success", func(t *testing.T) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+
+		q.Set("response_mode", SIOPResponseModeQuery)
+		q.Set("redirect_uri", sampleRPRedirectURI+"?session=1")
+
+		request, err := walletInstance.ParseSIOPRequest("openid://?" + q.Encode())
+		require.NoError(t, err)
+
+		response, err := walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: didJWK})
+		require.NoError(t, err)
+
+		u, err := url.Parse(response.RedirectURL)
+		require.NoError(t, err)
+		require.Equal(t, "1", u.Query().Get("session"))
+		require.Equal(t, response.IDToken, u.Query().Get("id_token"))
+		require.Empty(t, u.Fragment)
+	})
+
+	t.Run("respond with direct post - success", func(t *testing.T) {
+		var received url.Values
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.NoError(t, r.ParseForm())
+
+			received = r.PostForm
+
+			_, err := rw.Write([]byte(`{"redirect_uri":"https://rp.example.com/welcome"}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		request := &SIOPRequest{
+			ClientID:     server.URL,
+			RedirectURI:  server.URL,
+			ResponseMode: SIOPResponseModeDirectPost,
+			Nonce:        sampleSIOPNonce,
+			State:        sampleSIOPState,
+		}
+
+		response, err := walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: didKey},
+			WithSIOPHTTPClient(server.Client()))
+		require.NoError(t, err)
+		require.Equal(t, "https://rp.example.com/welcome", response.RedirectURL)
+		require.Equal(t, response.IDToken, received.Get("id_token"))
+		require.Equal(t, sampleSIOPState, received.Get("state"))
+
+		request.ResponseMode = SIOPResponseModePost
+		request.RedirectURI = server.URL + "/notfound"
+
+		server.Config.Handler = http.NotFoundHandler()
+
+		response, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: didKey})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "relying party responded with status 404")
+		require.Nil(t, response)
+	})
+
+	t.Run("parse signed request object - success", func(t *testing.T) {
+		requestObject := signSIOPRequestObject(t, rpPriv, rpKeyID, map[string]interface{}{
+			"iss":           rpDID,
+			"client_id":     rpDID,
+			"redirect_uri":  sampleRPRedirectURI,
+			"response_type": "id_token",
+			"response_mode": SIOPResponseModePost,
+			"scope":         "openid",
+			"nonce":         sampleSIOPNonce,
+		})
+
+		request, err := walletInstance.ParseSIOPRequest("openid://?" + url.Values{
+			"client_id": {rpDID},
+			"request":   {requestObject},
+			"nonce":     {"overridden"},
+		}.Encode())
+		require.NoError(t, err)
+		require.Equal(t, rpDID, request.ClientID)
+		require.Equal(t, sampleSIOPNonce, request.Nonce)
+		require.Equal(t, SIOPResponseModePost, request.ResponseMode)
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_, err := rw.Write([]byte(requestObject))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		request, err = walletInstance.ParseSIOPRequest("openid://?"+url.Values{
+			"client_id":   {rpDID},
+			"request_uri": {server.URL},
+		}.Encode(), WithSIOPHTTPClient(server.Client()))
+		require.NoError(t, err)
+		require.Equal(t, sampleRPRedirectURI, request.RedirectURI)
+	})
+
+	t.Run("parse request - failure", func(t *testing.T) {
+		rpRequest := func(mutate func(q url.Values)) string {
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+
+			mutate(q)
+
+			return "openid://?" + q.Encode()
+		}
+
+		for request, expected := range map[string]string{
+			rpRequest(func(q url.Values) { q.Set("response_type", "code") }):                 "unsupported response type 'code'",
+			rpRequest(func(q url.Values) { q.Set("scope", "profile") }):                      "'openid' scope is required",
+			rpRequest(func(q url.Values) { q.Del("nonce") }):                                 "'nonce' are required",
+			rpRequest(func(q url.Values) { q.Set("redirect_uri", "callback") }):              "invalid redirect URI",
+			rpRequest(func(q url.Values) { q.Set("response_mode", "form") }):                 "unsupported response mode 'form'",
+			rpRequest(func(q url.Values) { q.Set("request", "eyJhbGciOiJub25lIn0.e30.") }):   "must be signed",
+			rpRequest(func(q url.Values) { q.Set("request_uri", "http://[::1]:namedport") }): "failed to fetch request object",
+			"%zz": "invalid SIOP request",
+		} {
+			_, err := walletInstance.ParseSIOPRequest(request)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expected)
+		}
+
+		// request object signed by another DID than the client.
+		requestObject := signSIOPRequestObject(t, rpPriv, rpKeyID, map[string]interface{}{"iss": rpDID})
+
+		_, err := walletInstance.ParseSIOPRequest(rpRequest(func(q url.Values) {
+			q.Set("client_id", didKey)
+			q.Set("request", requestObject)
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "instead of the client")
+
+		// request object client_id mismatch.
+		requestObject = signSIOPRequestObject(t, rpPriv, rpKeyID, map[string]interface{}{
+			"iss": rpDID, "client_id": rpDID,
+		})
+
+		_, err = walletInstance.ParseSIOPRequest(rpRequest(func(q url.Values) {
+			q.Set("request", requestObject)
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "client_id does not match")
+
+		// request object fetch failure.
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err = walletInstance.ParseSIOPRequest(rpRequest(func(q url.Values) {
+			q.Set("request_uri", server.URL)
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status 404")
+	})
+
+	t.Run("respond to request - failure", func(t *testing.T) {
+		request := &SIOPRequest{ClientID: sampleRPRedirectURI, RedirectURI: sampleRPRedirectURI}
+
+		_, err := walletInstance.RespondSIOPRequest(tkn, nil, &ProofOptions{Controller: didKey})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request is required")
+
+		_, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to prepare proof")
+
+		_, err = walletInstance.RespondSIOPRequest(sampleFakeTkn, request, &ProofOptions{Controller: didKey})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+
+		// no wallet key for the DID.
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		otherDID, _ := fingerprint.CreateDIDKey(otherPub)
+
+		_, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: otherDID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no wallet key found")
+
+		// non Ed25519 key.
+		p256JWK := `{"kty":"EC","crv":"P-256","x":"acbIQiuMs3i8_uszEjJ2tpTtRM4EU3yz91PH6CdH2V0",` +
+			`"y":"_KcyLj9vWMptnmKtm46GqDz8wf74I5LKgrl2GzH3nSE"}`
+		p256DID := "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(p256JWK))
+
+		_, err = walletInstance.RespondSIOPRequest(tkn, request, &ProofOptions{Controller: p256DID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "only Ed25519 keys are supported")
+	})
+}
+
+func verifySIOPIDToken(t *testing.T, vdr vdrapi.Registry, idToken, controller string) {
+	t.Helper()
+
+	keyFetcher := verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()
+
+	token, err := jwt.Parse(idToken, jwt.WithSignatureVerifier(jwt.NewVerifier(jwt.KeyResolverFunc(
+		func(issuer, kid string) (*verifier.PublicKey, error) {
+			require.True(t, strings.HasPrefix(kid, controller+"#"))
+
+			return keyFetcher(issuer, kid)
+		}))))
+	require.NoError(t, err)
+
+	var claims siopIDTokenClaims
+
+	require.NoError(t, token.DecodeClaims(&claims))
+	require.Equal(t, controller, claims.Issuer)
+	require.Equal(t, controller, claims.Subject)
+	require.Equal(t, sampleRPRedirectURI, claims.Audience)
+	require.Equal(t, sampleSIOPNonce, claims.Nonce)
+	require.Equal(t, int64(defaultSIOPTokenExpiry.Seconds()), claims.Expiry-claims.IssuedAt)
+}
+
+func signSIOPRequestObject(t *testing.T, privKey ed25519.PrivateKey, kid string,
+	claims map[string]interface{}) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderKeyID: kid},
+		&ed25519TestSigner{privKey: privKey})
+	require.NoError(t, err)
+
+	requestObject, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return requestObject
+}
+
+type ed25519TestSigner struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519TestSigner) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519TestSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
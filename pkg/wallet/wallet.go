@@ -31,12 +31,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/vmresolver"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -44,6 +47,10 @@ import (
 const (
 	// Ed25519Signature2018 ed25519 signature suite.
 	Ed25519Signature2018 = "Ed25519Signature2018"
+	// Ed25519Signature2020 ed25519 signature suite.
+	Ed25519Signature2020 = "Ed25519Signature2020"
+	// DataIntegrityProof data integrity proof with the eddsa-rdfc-2022 cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
 	// JSONWebSignature2020 json web signature suite.
 	JSONWebSignature2020 = "JsonWebSignature2020"
 	// BbsBlsSignature2020 BBS signature suite.
@@ -347,13 +354,15 @@ func (c *Wallet) Close() bool {
 	return keyManager().removeKeyManager(c.userID) && c.contents.Close()
 }
 
-// Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// Export produces a serialized exported wallet representation, encrypted by given password.
+// Exported wallet contains all wallet contents, keys of the wallet KMS and mappings of contents to collections.
+// Only keys of local KMS can be exported.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- authToken: authorization for performing export operation.
+//		- password: password to be used to encrypt exported wallet, required for importing it.
 //
-//	Returns exported locked wallet.
+//	Returns exported encrypted wallet.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -361,18 +370,29 @@ func (c *Wallet) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Wallet) Export(authToken, password string) (json.RawMessage, error) {
+	archive, err := c.archive(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet: %w", err)
+	}
+
+	exported, err := encryptArchive(password, archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet: %w", err)
+	}
+
+	return json.Marshal(exported)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- authToken: authorization for performing import operation.
+//		- password: password used while exporting the wallet.
+//		- contents: exported wallet to be imported.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -382,9 +402,25 @@ func (c *Wallet) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Wallet) Import(authToken, password string, contents json.RawMessage) error {
+	var exported exportedWallet
+
+	err := json.Unmarshal(contents, &exported)
+	if err != nil {
+		return fmt.Errorf("failed to read exported wallet: %w", err)
+	}
+
+	archive, err := decryptArchive(password, &exported)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet: %w", err)
+	}
+
+	err = c.restore(authToken, archive)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet: %w", err)
+	}
+
+	return nil
 }
 
 // Add adds given data model to wallet contents store.
@@ -475,6 +511,26 @@ func (c *Wallet) Query(authToken string, params ...*QueryParams) ([]*verifiable.
 	return query.PerformQuery(vcContents)
 }
 
+// Capabilities reports which of the capability templates (eg. AgeOverTemplate, ResidencyTemplate, KYCLevelTemplate)
+// are satisfiable from the credentials of the wallet and via which credentials, without creating presentations.
+//
+//	Args:
+//		- auth token for unlocking wallet.
+//		- capability templates to check.
+func (c *Wallet) Capabilities(authToken string, templates ...*CapabilityTemplate) ([]*Capability, error) {
+	vcContents, err := c.contents.GetAll(authToken, Credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	vcs, err := NewQuery(nil, c.jsonldDocumentLoader).parseCredentialContents(vcContents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+
+	return capabilities(vcs, c.jsonldDocumentLoader, templates...)
+}
+
 // Issue adds proof to a Verifiable Credential.
 //
 //	Args:
@@ -505,6 +561,94 @@ func (c *Wallet) Issue(authToken string, credential json.RawMessage,
 	return vc, nil
 }
 
+// IssueSDJWT issues a selective disclosure JWT (SD-JWT) credential signed by the controller key,
+// only Ed25519 keys are supported.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- a verifiable credential to be issued as SD-JWT.
+//		- proof options for signing the issuer JWT (only 'controller' and 'verificationMethod' are used).
+//		- SD-JWT options (optional).
+//
+// Returns: the SD-JWT along with all its disclosures.
+func (c *Wallet) IssueSDJWT(authToken string, credential json.RawMessage, options *ProofOptions,
+	sdOptions *SDJWTOptions) (string, error) {
+	vc, err := verifiable.ParseCredential(credential, verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse credential: %w", err)
+	}
+
+	err = c.validateProofOption(authToken, options, did.AssertionMethod)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare proof: %w", err)
+	}
+
+	s, err := newKMSSigner(authToken, c.walletCrypto, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue SD-JWT credential: %w", err)
+	}
+
+	var opts []verifiable.MakeSDJWTOpt
+
+	if sdOptions != nil {
+		if sdOptions.HolderPublicKey != nil {
+			opts = append(opts, verifiable.WithSDJWTHolderPublicKey(sdOptions.HolderPublicKey))
+		}
+
+		opts = append(opts, verifiable.WithSDJWTAlwaysDisclosed(sdOptions.AlwaysDisclosed...))
+	}
+
+	sdJWT, err := vc.MakeSDJWT(s, verifiable.EdDSA, options.VerificationMethod, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue SD-JWT credential: %w", err)
+	}
+
+	return sdJWT, nil
+}
+
+// PresentSDJWT produces a presentation of a selective disclosure JWT (SD-JWT) credential disclosing only the
+// given credential subject claims.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- the SD-JWT credential as issued.
+//		- names of the credential subject claims to be disclosed.
+//		- proof options for signing the key binding JWT (optional, 'domain' and 'challenge' are used as the
+//		audience and the nonce of the key binding), only Ed25519 keys are supported.
+//
+// Returns: the SD-JWT presentation.
+func (c *Wallet) PresentSDJWT(authToken, sdJWT string, claims []string, options *ProofOptions) (string, error) {
+	var opts []verifiable.SDJWTPresentationOpt
+
+	if options != nil {
+		err := c.validateProofOption(authToken, options, did.Authentication)
+		if err != nil {
+			return "", fmt.Errorf("failed to prepare proof: %w", err)
+		}
+
+		s, err := newKMSSigner(authToken, c.walletCrypto, options)
+		if err != nil {
+			return "", fmt.Errorf("failed to present SD-JWT credential: %w", err)
+		}
+
+		opts = append(opts, verifiable.WithSDJWTHolderBinding(&verifiable.SDJWTHolderBinding{
+			Signer:    s,
+			Algorithm: verifiable.EdDSA,
+			Audience:  options.Domain,
+			Nonce:     options.Challenge,
+			IssuedAt:  options.Created,
+		}))
+	}
+
+	presentation, err := verifiable.CreateSDJWTPresentation(sdJWT, claims, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to present SD-JWT credential: %w", err)
+	}
+
+	return presentation, nil
+}
+
 // Prove produces a Verifiable Presentation.
 //
 //	Args:
@@ -604,6 +748,11 @@ func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType) (*KeyPair,
 		return nil, err
 	}
 
+	err = c.contents.saveKeyIDs(authToken, kid)
+	if err != nil {
+		return nil, err
+	}
+
 	return &KeyPair{
 		KeyID:     kid,
 		PublicKey: base64.RawURLEncoding.EncodeToString(pubBytes),
@@ -661,6 +810,59 @@ func (c *Wallet) Connect(authToken string, invitation *outofband.Invitation, opt
 	return connID, nil
 }
 
+// ClaimCredentials claims the verifiable credentials attached to an out-of-band invitation, without a connection:
+// the credentials are verified then added to the wallet.
+//
+// Args:
+// 		- authToken: authorization for performing operation.
+// 		- invitation: out-of-band invitation with credentials attached by the issuer.
+// 		- options: options for claiming the credentials.
+//
+// Returns:
+// 		- claimed credentials if operation is successful.
+// 		- error if the invitation has no credential, a credential is invalid or can't be added.
+//
+func (c *Wallet) ClaimCredentials(authToken string, invitation *outofband.Invitation, options ...ClaimCredentialOptions) ([]*verifiable.Credential, error) { //nolint: lll
+	opts := &claimCredentialOpts{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	raws, err := outofband.Credentials(invitation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read invitation credentials: %w", err)
+	}
+
+	if len(raws) == 0 {
+		return nil, errors.New("no credential attached to the invitation")
+	}
+
+	vcs := make([]*verifiable.Credential, len(raws))
+
+	// all the credentials are verified before any of them is added.
+	for i, raw := range raws {
+		vcs[i], err = verifiable.ParseCredential(raw, verifiable.WithPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
+		), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+		if err != nil {
+			return nil, fmt.Errorf("credential verification failed: %w", err)
+		}
+
+		if len(vcs[i].Proofs) == 0 {
+			return nil, errors.New("credential verification failed: credential is not signed")
+		}
+	}
+
+	for _, raw := range raws {
+		err = c.contents.Save(authToken, Credential, raw, AddByCollection(opts.collectionID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add claimed credential: %w", err)
+		}
+	}
+
+	return vcs, nil
+}
+
 // ProposePresentation accepts out-of-band invitation and sends message proposing presentation
 // from wallet to relying party.
 // https://w3c-ccg.github.io/universal-wallet-interop-spec/#proposepresentation
@@ -893,6 +1095,14 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 	switch opts.ProofType {
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
+	case Ed25519Signature2020:
+		addContext(p, ed25519signature2020.ContextURL)
+
+		signatureSuite = ed25519signature2020.New(suite.WithSigner(s))
+	case DataIntegrityProof:
+		addContext(p, dataintegrity.ContextURL)
+
+		signatureSuite = dataintegrity.New(suite.WithSigner(s))
 	case JSONWebSignature2020:
 		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
 	case BbsBlsSignature2020:
@@ -937,12 +1147,18 @@ func (c *Wallet) validateProofOption(authToken string, opts *ProofOptions, metho
 		return err
 	}
 
+	if opts.ProofType == "" {
+		opts.ProofType = Ed25519Signature2018
+	}
+
 	if opts.ProofRepresentation == nil {
 		opts.ProofRepresentation = &defaultSignatureRepresentation
-	}
 
-	if opts.ProofType == "" {
-		opts.ProofType = Ed25519Signature2018
+		// the 2020 suites and data integrity proofs are only defined with a multibase proof value
+		if opts.ProofType == Ed25519Signature2020 || opts.ProofType == DataIntegrityProof {
+			proofValue := verifiable.SignatureProofValue
+			opts.ProofRepresentation = &proofValue
+		}
 	}
 
 	return nil
@@ -950,20 +1166,24 @@ func (c *Wallet) validateProofOption(authToken string, opts *ProofOptions, metho
 
 func (c *Wallet) validateVerificationMethod(didDoc *did.Doc, opts *ProofOptions,
 	relationship did.VerificationRelationship) error {
-	vms := didDoc.VerificationMethods(relationship)[relationship]
+	var err error
 
-	for _, vm := range vms {
-		if opts.VerificationMethod == "" {
-			opts.VerificationMethod = vm.VerificationMethod.ID
-			return nil
-		}
+	if opts.VerificationMethod == "" {
+		var vm *did.VerificationMethod
 
-		if opts.VerificationMethod == vm.VerificationMethod.ID {
-			return nil
+		vm, err = vmresolver.Select(didDoc, relationship)
+		if err == nil {
+			opts.VerificationMethod = vm.ID
 		}
+	} else {
+		_, err = vmresolver.Find(didDoc, opts.VerificationMethod, relationship)
 	}
 
-	return fmt.Errorf("unable to find '%s' for given verification method", supportedRelationships[relationship])
+	if err != nil {
+		return fmt.Errorf("unable to find '%s' for given verification method", supportedRelationships[relationship])
+	}
+
+	return nil
 }
 
 // currently correlating response action by connection due to limitation in current present proof V1 implementation.
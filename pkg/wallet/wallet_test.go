@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -27,11 +28,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	outofbandSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	presentproofSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -57,14 +60,14 @@ import (
 
 // nolint: lll
 const (
-	sampleUserID       = "sample-user01"
-	sampleFakeTkn      = "fake-auth-tkn"
-	toBeImplementedErr = "to be implemented"
-	sampleWalletErr    = "sample wallet err"
-	sampleCreatedDate  = "2020-12-25"
-	sampleChallenge    = "sample-challenge"
-	sampleDomain       = "sample-domain"
-	sampleUDCVC        = `{
+	sampleUserID         = "sample-user01"
+	sampleFakeTkn        = "fake-auth-tkn"
+	sampleExportPassword = "sample-export-password"
+	sampleWalletErr      = "sample wallet err"
+	sampleCreatedDate    = "2020-12-25"
+	sampleChallenge      = "sample-challenge"
+	sampleDomain         = "sample-domain"
+	sampleUDCVC          = `{
       "@context": [
         "https://www.w3.org/2018/credentials/v1",
         "https://www.w3.org/2018/credentials/examples/v1",
@@ -837,33 +840,153 @@ func TestWallet_OpenClose(t *testing.T) {
 	})
 }
 
-func TestWallet_Export(t *testing.T) {
+func TestWallet_ExportImport(t *testing.T) {
+	const collectionID = "did:example:acme123456789abcdefghi"
+
+	sampleCollection := `{
+		"@context": ["https://w3id.org/wallet/v1"],
+		"id": "` + collectionID + `",
+		"type": "Collection",
+		"name": "My Acme Vault"
+	}`
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+
+	sourceUser := uuid.New().String()
+	err := CreateProfile(sourceUser, mockctx, WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	source, err := New(sourceUser, mockctx)
 	require.NoError(t, err)
 
-	result, err := walletInstance.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	sourceTkn, err := source.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
 
-func TestWallet_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+	defer source.Close()
+
+	require.NoError(t, source.Add(sourceTkn, Collection, []byte(sampleCollection)))
+	require.NoError(t, source.Add(sourceTkn, Credential, []byte(sampleUDCVC), AddByCollection(collectionID)))
+	require.NoError(t, source.Add(sourceTkn, Metadata, []byte(sampleContentValid)))
+	require.NoError(t, source.Add(sourceTkn, DIDResolutionResponse, []byte(didResolutionResult)))
+	require.NoError(t, source.Add(sourceTkn, Key, []byte(sampleKeyContentBase58Valid)))
+
+	keyPair, err := source.CreateKeyPair(sourceTkn, kms.ED25519)
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	exported, err := source.Export(sourceTkn, sampleExportPassword)
 	require.NoError(t, err)
+	require.NotEmpty(t, exported)
+	require.NotContains(t, string(exported), "UniversityDegreeCredential")
 
-	err = walletInstance.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	t.Run("test import exported wallet", func(t *testing.T) {
+		// wallet imported on another device.
+		mockctx := newMockProvider(t)
+		targetUser := uuid.New().String()
+		err := CreateProfile(targetUser, mockctx, WithPassphrase(samplePassPhrase+"target"))
+		require.NoError(t, err)
+
+		target, err := New(targetUser, mockctx)
+		require.NoError(t, err)
+
+		targetTkn, err := target.Open(WithUnlockByPassphrase(samplePassPhrase + "target"))
+		require.NoError(t, err)
+
+		defer target.Close()
+
+		err = target.Import(targetTkn, sampleExportPassword, exported)
+		require.NoError(t, err)
+
+		for _, ct := range []ContentType{Collection, Credential, Metadata, DIDResolutionResponse} {
+			expected, e := source.GetAll(sourceTkn, ct)
+			require.NoError(t, e)
+
+			imported, e := target.GetAll(targetTkn, ct)
+			require.NoError(t, e)
+			require.Len(t, imported, len(expected))
+
+			for id, content := range expected {
+				require.JSONEq(t, string(content), string(imported[id]))
+			}
+		}
+
+		credentials, err := target.GetAll(targetTkn, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		kmgr, err := keyManager().getKeyManger(targetTkn)
+		require.NoError(t, err)
+
+		pubKey, err := kmgr.ExportPubKeyBytes(keyPair.KeyID)
+		require.NoError(t, err)
+		require.Equal(t, keyPair.PublicKey, base64.RawURLEncoding.EncodeToString(pubKey))
+
+		_, err = kmgr.Get("key-1")
+		require.NoError(t, err)
+
+		// imported wallet can be exported again.
+		_, err = target.Export(targetTkn, sampleExportPassword)
+		require.NoError(t, err)
+
+		// contents can't be imported twice.
+		err = target.Import(targetTkn, sampleExportPassword, exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to import wallet")
+	})
+
+	t.Run("test import with invalid password", func(t *testing.T) {
+		err := source.Import(sourceTkn, sampleExportPassword+"invalid", exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid password")
+	})
+
+	t.Run("test import invalid exported wallet", func(t *testing.T) {
+		err := source.Import(sourceTkn, sampleExportPassword, []byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read exported wallet")
+
+		err = source.Import(sourceTkn, sampleExportPassword, []byte(`{"version":"0"}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported wallet archive version '0'")
+	})
+
+	t.Run("test export and import with invalid auth", func(t *testing.T) {
+		_, err := source.Export(sampleFakeTkn, sampleExportPassword)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+
+		err = source.Import(sampleFakeTkn, sampleExportPassword, exported)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("test export with empty password", func(t *testing.T) {
+		_, err := source.Export(sourceTkn, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "passphrase is empty")
+	})
+
+	t.Run("test export keys from remote kms", func(t *testing.T) {
+		mockctx := newMockProvider(t)
+		remoteUser := uuid.New().String()
+		err := CreateProfile(remoteUser, mockctx, WithKeyServerURL(sampleKeyServerURL))
+		require.NoError(t, err)
+
+		remote, err := New(remoteUser, mockctx)
+		require.NoError(t, err)
+
+		remoteTkn, err := remote.Open(WithUnlockByAuthorizationToken(sampleRemoteKMSAuth))
+		require.NoError(t, err)
+
+		defer remote.Close()
+
+		exported, err := remote.Export(remoteTkn, sampleExportPassword)
+		require.NoError(t, err)
+		require.NotEmpty(t, exported)
+
+		require.NoError(t, remote.contents.saveKeyIDs(remoteTkn, "sample-kid"))
+
+		_, err = remote.Export(remoteTkn, sampleExportPassword)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wallet key manager doesn't support key export")
+	})
 }
 
 func TestWallet_Add(t *testing.T) {
@@ -1010,6 +1133,104 @@ func TestWallet_Remove(t *testing.T) {
 	require.True(t, errors.Is(err, storage.ErrDataNotFound))
 }
 
+func TestWallet_Capabilities(t *testing.T) {
+	mockctx := newMockProvider(t)
+	user := uuid.New().String()
+
+	err := CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	addCredential := func(id string, subject map[string]interface{}) {
+		vc, e := (&verifiable.Credential{
+			Context: []string{verifiable.ContextURI},
+			Types:   []string{verifiable.VCType},
+			ID:      id,
+			Issued:  &util.TimeWrapper{Time: time.Now()},
+			Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Subject: subject,
+		}).MarshalJSON()
+		require.NoError(t, e)
+
+		require.NoError(t, walletInstance.Add(tkn, Credential, vc))
+	}
+
+	addCredential("http://example.edu/credentials/id-card", map[string]interface{}{
+		"id":        "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"birthDate": "1990-07-15",
+		"address":   map[string]interface{}{"addressCountry": "CA"},
+	})
+	addCredential("http://example.edu/credentials/kyc", map[string]interface{}{
+		"id":       "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"kycLevel": 2,
+	})
+
+	t.Run("test capabilities summary", func(t *testing.T) {
+		result, err := walletInstance.Capabilities(tkn, AgeOverTemplate(18), AgeOverTemplate(65),
+			ResidencyTemplate("CA"), ResidencyTemplate("FR"), KYCLevelTemplate(2), KYCLevelTemplate(3))
+		require.NoError(t, err)
+		require.Equal(t, []*Capability{
+			{ID: "age-over-18", Name: "Age over 18", Satisfiable: true,
+				Credentials: []string{"http://example.edu/credentials/id-card"}},
+			{ID: "age-over-65", Name: "Age over 65"},
+			{ID: "residency-ca", Name: "Residency in CA", Satisfiable: true,
+				Credentials: []string{"http://example.edu/credentials/id-card"}},
+			{ID: "residency-fr", Name: "Residency in FR"},
+			{ID: "kyc-level-2", Name: "KYC level 2", Satisfiable: true,
+				Credentials: []string{"http://example.edu/credentials/kyc"}},
+			{ID: "kyc-level-3", Name: "KYC level 3"},
+		}, result)
+	})
+
+	t.Run("test capabilities of template combining credentials", func(t *testing.T) {
+		template := &CapabilityTemplate{
+			ID: "adult-resident",
+			InputDescriptors: append(AgeOverTemplate(18).InputDescriptors,
+				KYCLevelTemplate(1).InputDescriptors...),
+		}
+
+		result, err := walletInstance.Capabilities(tkn, template)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.True(t, result[0].Satisfiable)
+		require.ElementsMatch(t, []string{"http://example.edu/credentials/id-card",
+			"http://example.edu/credentials/kyc"}, result[0].Credentials)
+	})
+
+	t.Run("test capabilities with invalid template", func(t *testing.T) {
+		result, err := walletInstance.Capabilities(tkn, &CapabilityTemplate{ID: "empty"})
+		require.EqualError(t, err, "capability template 0 has no input descriptors")
+		require.Empty(t, result)
+	})
+
+	t.Run("test capabilities with invalid auth", func(t *testing.T) {
+		result, err := walletInstance.Capabilities(sampleFakeTkn, AgeOverTemplate(18))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get credentials")
+		require.Empty(t, result)
+	})
+}
+
+func TestDateNotAfterPattern(t *testing.T) {
+	pattern := regexp.MustCompile(dateNotAfterPattern("2008-10-15"))
+
+	for _, date := range []string{"2008-10-15", "2008-10-14", "2008-09-30", "2007-12-31", "1990-07-15",
+		"2008-10-15T10:00:00Z"} {
+		require.True(t, pattern.MatchString(date), date)
+	}
+
+	for _, date := range []string{"2008-10-16", "2008-11-01", "2009-01-01", "2008-10-1", "15/10/2008"} {
+		require.False(t, pattern.MatchString(date), date)
+	}
+}
+
 func TestWallet_Query(t *testing.T) {
 	mockctx := newMockProvider(t)
 	user := uuid.New().String()
@@ -2280,6 +2501,139 @@ func TestWallet_Verify(t *testing.T) {
 	})
 }
 
+func TestWallet_SDJWT(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if strings.HasPrefix(didID, "did:key:") {
+				return key.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+	require.NotEmpty(t, tkn)
+
+	defer walletInstance.Close()
+
+	// import keys manually
+	kmgr, err := keyManager().getKeyManger(tkn)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+	holderKey, err := jwksupport.JWKFromKey(edPriv.Public())
+	require.NoError(t, err)
+
+	// the issuer JWT is verified against the key of the credential issuer.
+	vc := strings.Replace(sampleUDCVC, "did:example:76e12ec712ebc6f1c221ebfeb1f", didKey, 1)
+
+	t.Run("issue, present and verify SD-JWT credential - success", func(t *testing.T) {
+		sdJWT, err := walletInstance.IssueSDJWT(tkn, []byte(vc), &ProofOptions{Controller: didKey},
+			&SDJWTOptions{HolderPublicKey: holderKey, AlwaysDisclosed: []string{"spouse"}})
+		require.NoError(t, err)
+		require.True(t, verifiable.IsSDJWT(sdJWT))
+
+		disclosures, err := verifiable.ParseSDJWTDisclosures(sdJWT)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 2)
+
+		ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify([]byte(sdJWT)))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		presentation, err := walletInstance.PresentSDJWT(tkn, sdJWT, []string{"degree"}, &ProofOptions{
+			Controller: didKey,
+			Domain:     sampleDomain,
+			Challenge:  sampleChallenge,
+		})
+		require.NoError(t, err)
+
+		disclosures, err = verifiable.ParseSDJWTDisclosures(presentation)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+		require.Equal(t, "degree", disclosures[0].Name)
+
+		ok, err = walletInstance.Verify(tkn, WithRawCredentialToVerify([]byte(presentation)))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		loader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		_, err = verifiable.ParseCredential([]byte(presentation), verifiable.WithPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(customVDR).PublicKeyFetcher()),
+			verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithExpectedSDJWTKeyBinding(sampleDomain, sampleChallenge))
+		require.NoError(t, err)
+	})
+
+	t.Run("present SD-JWT credential without key binding - success", func(t *testing.T) {
+		sdJWT, err := walletInstance.IssueSDJWT(tkn, []byte(vc), &ProofOptions{Controller: didKey}, nil)
+		require.NoError(t, err)
+
+		presentation, err := walletInstance.PresentSDJWT(tkn, sdJWT, []string{"name"}, nil)
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify([]byte(presentation)))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("issue SD-JWT credential - failure", func(t *testing.T) {
+		sdJWT, err := walletInstance.IssueSDJWT(tkn, []byte("{}"), &ProofOptions{Controller: didKey}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse credential")
+		require.Empty(t, sdJWT)
+
+		sdJWT, err = walletInstance.IssueSDJWT(tkn, []byte(vc), &ProofOptions{}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to prepare proof")
+		require.Empty(t, sdJWT)
+
+		sdJWT, err = walletInstance.IssueSDJWT(sampleFakeTkn, []byte(vc), &ProofOptions{Controller: didKey}, nil)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, sdJWT)
+	})
+
+	t.Run("present SD-JWT credential - failure", func(t *testing.T) {
+		sdJWT, err := walletInstance.IssueSDJWT(tkn, []byte(vc), &ProofOptions{Controller: didKey}, nil)
+		require.NoError(t, err)
+
+		presentation, err := walletInstance.PresentSDJWT(tkn, sdJWT, nil, &ProofOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to prepare proof")
+		require.Empty(t, presentation)
+
+		presentation, err = walletInstance.PresentSDJWT(sampleFakeTkn, sdJWT, nil, &ProofOptions{Controller: didKey})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, presentation)
+
+		presentation, err = walletInstance.PresentSDJWT(tkn, "invalid", nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to present SD-JWT credential")
+		require.Empty(t, presentation)
+	})
+}
+
 func TestWallet_Derive(t *testing.T) {
 	user := uuid.New().String()
 	customVDR := &mockvdr.MockVDRegistry{
@@ -2511,6 +2865,115 @@ func TestWallet_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestWallet_ClaimCredentials(t *testing.T) {
+	user := uuid.New().String()
+	customVDR := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if strings.HasPrefix(didID, "did:key:") {
+				return key.New().Read(didID)
+			}
+
+			return nil, fmt.Errorf("did not found")
+		},
+	}
+
+	sampleCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = customVDR
+	mockctx.CryptoValue = sampleCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer walletInstance.Close()
+
+	// import keys manually
+	kmgr, err := keyManager().getKeyManger(tkn)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+	// nolint: errcheck, gosec
+	kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+	// issue a credential
+	sampleVC, err := walletInstance.Issue(tkn, []byte(sampleUDCVC), &ProofOptions{
+		Controller: didKey,
+	})
+	require.NoError(t, err)
+
+	vcBytes, err := sampleVC.MarshalJSON()
+	require.NoError(t, err)
+
+	invitation := &outofband.Invitation{Requests: []*decorator.Attachment{{
+		ID:       uuid.New().String(),
+		MimeType: outofbandSvc.CredentialMimeType,
+		Data:     decorator.AttachmentData{JSON: json.RawMessage(vcBytes)},
+	}}}
+
+	t.Run("Test claiming credentials from an invitation - success", func(t *testing.T) {
+		const collectionID = "did:example:tickets"
+
+		err := walletInstance.Add(tkn, Collection, []byte(`{
+			"@context": ["https://w3id.org/wallet/v1"],
+			"id": "`+collectionID+`",
+			"type": "Collection",
+			"name": "Tickets"
+		}`))
+		require.NoError(t, err)
+
+		vcs, err := walletInstance.ClaimCredentials(tkn, invitation, ClaimToCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, vcs, 1)
+		require.Equal(t, sampleVC.ID, vcs[0].ID)
+
+		stored, err := walletInstance.GetAll(tkn, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Contains(t, stored, sampleVC.ID)
+
+		// the credential can't be claimed twice.
+		_, err = walletInstance.ClaimCredentials(tkn, invitation)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to add claimed credential")
+	})
+
+	t.Run("Test claiming credentials from an invitation - no credential", func(t *testing.T) {
+		_, err := walletInstance.ClaimCredentials(tkn, &outofband.Invitation{})
+		require.EqualError(t, err, "no credential attached to the invitation")
+	})
+
+	t.Run("Test claiming credentials from an invitation - invalid credentials", func(t *testing.T) {
+		tamperedVC := *sampleVC
+		tamperedVC.Issuer.ID += "."
+
+		tamperedBytes, err := tamperedVC.MarshalJSON()
+		require.NoError(t, err)
+
+		for _, raw := range []json.RawMessage{[]byte(sampleUDCVC), tamperedBytes} {
+			_, err = walletInstance.ClaimCredentials(tkn, &outofband.Invitation{Requests: []*decorator.Attachment{{
+				MimeType: outofbandSvc.CredentialMimeType,
+				Data:     decorator.AttachmentData{JSON: raw},
+			}}})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "credential verification failed")
+		}
+
+		_, err = walletInstance.ClaimCredentials(tkn, &outofband.Invitation{Requests: []*decorator.Attachment{{
+			MimeType: outofbandSvc.CredentialMimeType,
+		}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read invitation credentials")
+	})
+}
+
 func TestWallet_Connect(t *testing.T) {
 	sampleDIDCommUser := uuid.New().String()
 	mockctx := newMockProvider(t)
@@ -46,6 +46,18 @@ echo "done linting component/storage/leveldb"
 echo "linting component/storage/indexeddb.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -e GOOS=js -e GOARCH=wasm -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/indexeddb ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage/indexeddb"
+echo "linting component/log/zap.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/log/zap ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/log/zap"
+echo "linting component/log/zerolog.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/log/zerolog ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/log/zerolog"
+echo "linting component/lock/redis.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/lock/redis ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/lock/redis"
+echo "linting component/storage/redis.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/redis ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/storage/redis"
 echo "linting component/storage.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/test/component/storage/ ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage"
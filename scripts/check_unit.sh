@@ -103,5 +103,28 @@ docker kill AriesEDVStorageTest >/dev/null
 remove_docker_containers
 fi
 
+# Running log/zap unit tests
+cd "$ROOT"/component/log/zap
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/log/zap/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+
+# Running log/zerolog unit tests
+cd ../zerolog
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/log/zerolog/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+
+# Running lock/redis unit tests
+cd "$ROOT"/component/lock/redis
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/lock/redis/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+
+# Running storage/redis unit tests
+cd "$ROOT"/component/storage/redis
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/storage/redis/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
 
 cd "$ROOT" || exit
@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventsink
+
+import (
+	"time"
+)
+
+// Event is an event of the agent published to an event sink, like the state events of the protocol services.
+type Event struct {
+	// ID identifies the event. The events are delivered at least once, the consumers deduplicate them by ID.
+	ID string `json:"id"`
+	// Topic of the event, eg. didexchange_states. The sinks typically map the topics to their own topics (Kafka) or
+	// subjects (NATS).
+	Topic string `json:"topic"`
+	// Key groups the related events, eg. the events of a protocol thread, for the sinks preserving their order.
+	Key string `json:"key,omitempty"`
+	// Payload of the event, JSON encoded.
+	Payload []byte `json:"payload"`
+	// Time the event was emitted.
+	Time time.Time `json:"time"`
+}
+
+// Sink publishes the events of the agent to a streaming platform, like Kafka or NATS.
+type Sink interface {
+	// Publish publishes the event. The event must be persisted by the platform when Publish returns without error,
+	// since the event is then discarded by the agent.
+	Publish(event *Event) error
+	// Close closes the sink.
+	Close() error
+}
@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lock
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotHeld is returned when releasing a lock which isn't held anymore.
+var ErrNotHeld = errors.New("lock not held")
+
+// Service provides named locks guarding the critical sections of the agent, like the protocol state transitions.
+// When several instances of the same agent run against a shared storage, the locks must be shared by the instances
+// so that only one of them at a time runs a critical section.
+type Service interface {
+	// Lock acquires the lock with the given name, blocking until the lock is acquired or the context is done.
+	Lock(ctx context.Context, name string) (Lock, error)
+}
+
+// Lock is a lock acquired from a Service.
+type Lock interface {
+	// Unlock releases the lock. ErrNotHeld is returned if the lock was already released or, for the locks
+	// expiring after a while, if the lock expired and may be held by another instance.
+	Unlock() error
+}
@@ -32,3 +32,17 @@ type Logger interface {
 type LoggerProvider interface {
 	GetLogger(module string) Logger
 }
+
+// Field is a key/value pair of a structured log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// StructuredLogger is a logger writing log entries with key/value fields. The loggers of a LoggerProvider
+// implementing it receive the fields of the structured log entries, the fields are formatted in the message otherwise.
+type StructuredLogger interface {
+	Logger
+	// Log writes the message and its fields at the given level. The CRITICAL level neither exits nor panics.
+	Log(level Level, msg string, fields ...Field)
+}
@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	standardlog "log"
+	"strconv"
+	"time"
 
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
@@ -26,6 +28,11 @@ var ErrStoreNotFound = errors.New("store not found")
 // ErrDataNotFound is returned when data is not found.
 var ErrDataNotFound = errors.New("data not found")
 
+// ExpiryTagName is the name of the reserved tag holding the expiry time of a key + value pair, as a number of
+// seconds since the Unix epoch. Stores supporting expiry (see ExpiringStore) no longer return the pairs once they have
+// expired and eventually delete them. Other stores keep the tag as a regular tag.
+const ExpiryTagName = "_expiry"
+
 // StoreConfiguration represents the configuration of a store.
 // Currently, it's only used for creating indexes in underlying storage databases.
 type StoreConfiguration struct {
@@ -220,6 +227,41 @@ type Store interface {
 	Close() error
 }
 
+// ExpiringStore is a Store supporting the expiry of its data. It is an optional interface: the PutWithTTL function
+// can be used with any Store.
+type ExpiringStore interface {
+	Store
+
+	// PutWithTTL stores the key + value pair along with the (optional) tags, the pair expiring once the given TTL has
+	// elapsed. Expired pairs are treated as if they did not exist: Get and GetTags return an error wrapping
+	// ErrDataNotFound, GetBulk returns a nil []byte and Query skips them.
+	// If key is empty, value is nil or ttl isn't positive, then an error will be returned.
+	PutWithTTL(key string, value []byte, ttl time.Duration, tags ...Tag) error
+
+	// DeleteExpired deletes the expired key + value pairs from the underlying database.
+	DeleteExpired() error
+}
+
+// StoreLister is a Provider able to list its stores. It is an optional interface, required from the source Provider
+// of a storage migration.
+type StoreLister interface {
+	Provider
+
+	// StoreNames returns the names of all the stores of the underlying storage, including the ones that are not
+	// currently open in this Provider.
+	StoreNames() ([]string, error)
+}
+
+// IterableStore is a Store able to iterate over all its data, tagged or not. It is an optional interface, required
+// from the stores of the source Provider of a storage migration.
+type IterableStore interface {
+	Store
+
+	// Iterate returns an Iterator over all the key + value pairs of the store, sorted by key. Expired pairs are
+	// skipped.
+	Iterate() (Iterator, error)
+}
+
 // Iterator allows for iteration over a collection of entries in a store.
 type Iterator interface {
 	// Next moves the pointer to the next entry in the iterator.
@@ -260,3 +302,42 @@ func Close(iterator Iterator, logger spi.Logger) {
 		}
 	}
 }
+
+// PutWithTTL stores the key + value pair along with the (optional) tags in the store, the pair expiring once the
+// given TTL has elapsed. The ExpiringStore implementation is used if the store has one, otherwise the pair is stored
+// with the ExpiryTagName tag, and only expires if the store honors that tag.
+func PutWithTTL(store Store, key string, value []byte, ttl time.Duration, tags ...Tag) error {
+	if expiringStore, ok := store.(ExpiringStore); ok {
+		return expiringStore.PutWithTTL(key, value, ttl, tags...)
+	}
+
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	return store.Put(key, value, append(tags, ExpiryTag(time.Now().Add(ttl)))...)
+}
+
+// ExpiryTag returns the ExpiryTagName tag of a key + value pair expiring at the given time.
+func ExpiryTag(expiry time.Time) Tag {
+	return Tag{Name: ExpiryTagName, Value: strconv.FormatInt(expiry.Unix(), 10)}
+}
+
+// IsExpired returns true if the tags of a key + value pair hold an ExpiryTagName tag that is not after the given
+// time. Pairs without a valid expiry tag never expire.
+func IsExpired(tags []Tag, now time.Time) bool {
+	for _, tag := range tags {
+		if tag.Name != ExpiryTagName {
+			continue
+		}
+
+		expiry, err := strconv.ParseInt(tag.Value, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		return expiry <= now.Unix()
+	}
+
+	return false
+}
@@ -18,21 +18,8 @@ import (
 	"github.com/cucumber/godog"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
-	"github.com/hyperledger/aries-framework-go/test/bdd/agent"
 	"github.com/hyperledger/aries-framework-go/test/bdd/dockerutil"
-	bddctx "github.com/hyperledger/aries-framework-go/test/bdd/pkg/context"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/didexchange"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/didresolver"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/introduce"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/issuecredential"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/ld"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/mediator"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/messaging"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/outofband"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/presentproof"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/rfc0593"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/vdr"
-	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/verifiable"
+	"github.com/hyperledger/aries-framework-go/test/bdd/testkit"
 )
 
 const (
@@ -45,14 +32,6 @@ var (
 	composeFiles = []string{"./fixtures/agent-rest", "./fixtures/sidetree-mock"}
 )
 
-// Feature of the aries framework under test.
-type feature interface {
-	// SetContext is called before every scenario is run with a fresh new context
-	SetContext(*bddctx.BDDContext)
-	// invoked once to register the steps on the suite
-	RegisterSteps(*godog.Suite)
-}
-
 func TestMain(m *testing.M) {
 	// default is to run all tests with tag @all
 	tags := "all"
@@ -160,52 +139,8 @@ func generateUUID() string {
 }
 
 func FeatureContext(s *godog.Suite) {
-	features := features()
-
-	for _, f := range features {
-		f.RegisterSteps(s)
-	}
-
-	var bddContext *bddctx.BDDContext
-
-	s.BeforeScenario(func(interface{}) {
-		bddContext = bddctx.NewBDDContext()
+	testkit.New(
 		// set dynamic args
-		bddContext.Args[SideTreeURL] = "http://localhost:48326/sidetree/v1/"
-
-		for _, f := range features {
-			f.SetContext(bddContext)
-		}
-	})
-	s.AfterScenario(func(_ interface{}, _ error) {
-		bddContext.Destroy()
-	})
-}
-
-func features() []feature {
-	return []feature{
-		agent.NewSDKSteps(),
-		agent.NewControllerSteps(),
-		didexchange.NewDIDExchangeSDKSteps(),
-		didexchange.NewDIDExchangeControllerSteps(),
-		introduce.NewIntroduceSDKSteps(),
-		introduce.NewIntroduceControllerSteps(),
-		issuecredential.NewIssueCredentialSDKSteps(),
-		issuecredential.NewIssueCredentialControllerSteps(),
-		didresolver.NewDIDResolverSteps(),
-		messaging.NewMessagingSDKSteps(),
-		messaging.NewMessagingControllerSteps(),
-		mediator.NewRouteSDKSteps(),
-		mediator.NewRouteRESTSteps(),
-		verifiable.NewVerifiableCredentialSDKSteps(),
-		outofband.NewOutOfBandSDKSteps(),
-		outofband.NewOutofbandControllerSteps(),
-		presentproof.NewPresentProofSDKSteps(),
-		presentproof.NewPresentProofControllerSteps(),
-		vdr.NewVDRControllerSteps(),
-		rfc0593.NewGoSDKSteps(),
-		rfc0593.NewRestSDKSteps(),
-		ld.NewLDControllerSteps(),
-		ld.NewSDKSteps(),
-	}
+		testkit.WithArg(SideTreeURL, "http://localhost:48326/sidetree/v1/"),
+	).Register(s)
 }
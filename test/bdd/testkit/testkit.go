@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testkit publishes the BDD steps of the aries framework, so that projects embedding the framework can run
+// their own godog suites against real agents:
+//
+//	func FeatureContext(s *godog.Suite) {
+//		testkit.New(
+//			testkit.WithFeatures(testkit.AgentSDK(), testkit.DIDExchangeSDK(), testkit.IssueCredentialSDK()),
+//			testkit.WithArg("${SIDETREE_URL}", "http://localhost:48326/sidetree/v1/"),
+//		).Register(s)
+//	}
+//
+// The steps of the features are the ones used by the feature files of the aries framework (see test/bdd/features).
+package testkit
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/hyperledger/aries-framework-go/test/bdd/agent"
+	bddctx "github.com/hyperledger/aries-framework-go/test/bdd/pkg/context"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/didexchange"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/didresolver"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/introduce"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/issuecredential"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/ld"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/mediator"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/messaging"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/outofband"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/presentproof"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/rfc0593"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/test/bdd/pkg/verifiable"
+)
+
+// Context is the state of a scenario shared between the steps of the features, such as the agents created and their
+// clients. The context is created before every scenario and destroyed after it.
+type Context = bddctx.BDDContext
+
+// Feature is a set of steps of the aries framework.
+type Feature interface {
+	// SetContext is called before every scenario is run with a fresh new context.
+	SetContext(ctx *Context)
+	// RegisterSteps is invoked once to register the steps on the suite.
+	RegisterSteps(s *godog.Suite)
+}
+
+// AgentSDK returns the steps creating agents with the Go SDK.
+func AgentSDK() Feature {
+	return agent.NewSDKSteps()
+}
+
+// AgentController returns the steps connecting to agents run with the REST controller (see cmd/aries-agent-rest).
+func AgentController() Feature {
+	return agent.NewControllerSteps()
+}
+
+// DIDExchangeSDK returns the steps of the DID exchange protocol run with the Go SDK.
+func DIDExchangeSDK() Feature {
+	return didexchange.NewDIDExchangeSDKSteps()
+}
+
+// DIDExchangeController returns the steps of the DID exchange protocol run with the REST controller.
+func DIDExchangeController() Feature {
+	return didexchange.NewDIDExchangeControllerSteps()
+}
+
+// IssueCredentialSDK returns the steps of the issue credential protocol run with the Go SDK.
+func IssueCredentialSDK() Feature {
+	return issuecredential.NewIssueCredentialSDKSteps()
+}
+
+// IssueCredentialController returns the steps of the issue credential protocol run with the REST controller.
+func IssueCredentialController() Feature {
+	return issuecredential.NewIssueCredentialControllerSteps()
+}
+
+// PresentProofSDK returns the steps of the present proof protocol run with the Go SDK.
+func PresentProofSDK() Feature {
+	return presentproof.NewPresentProofSDKSteps()
+}
+
+// PresentProofController returns the steps of the present proof protocol run with the REST controller.
+func PresentProofController() Feature {
+	return presentproof.NewPresentProofControllerSteps()
+}
+
+// AllFeatures returns the steps of all the features tested by the aries framework.
+func AllFeatures() []Feature {
+	return []Feature{
+		AgentSDK(),
+		AgentController(),
+		DIDExchangeSDK(),
+		DIDExchangeController(),
+		introduce.NewIntroduceSDKSteps(),
+		introduce.NewIntroduceControllerSteps(),
+		IssueCredentialSDK(),
+		IssueCredentialController(),
+		didresolver.NewDIDResolverSteps(),
+		messaging.NewMessagingSDKSteps(),
+		messaging.NewMessagingControllerSteps(),
+		mediator.NewRouteSDKSteps(),
+		mediator.NewRouteRESTSteps(),
+		verifiable.NewVerifiableCredentialSDKSteps(),
+		outofband.NewOutOfBandSDKSteps(),
+		outofband.NewOutofbandControllerSteps(),
+		PresentProofSDK(),
+		PresentProofController(),
+		vdr.NewVDRControllerSteps(),
+		rfc0593.NewGoSDKSteps(),
+		rfc0593.NewRestSDKSteps(),
+		ld.NewLDControllerSteps(),
+		ld.NewSDKSteps(),
+	}
+}
+
+// Kit registers the steps of the features on godog suites, with a new context for every scenario.
+type Kit struct {
+	features []Feature
+	args     map[string]string
+}
+
+// Option configures the kit.
+type Option func(k *Kit)
+
+// WithFeatures sets the features whose steps are registered, all the features by default.
+func WithFeatures(features ...Feature) Option {
+	return func(k *Kit) {
+		k.features = features
+	}
+}
+
+// WithArg sets an argument of the scenarios, the steps replace the argument name (e.g. "${SIDETREE_URL}") in their
+// parameters by its value.
+func WithArg(name, value string) Option {
+	return func(k *Kit) {
+		k.args[name] = value
+	}
+}
+
+// New returns a new kit.
+func New(opts ...Option) *Kit {
+	k := &Kit{args: make(map[string]string)}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	if k.features == nil {
+		k.features = AllFeatures()
+	}
+
+	return k
+}
+
+// Register registers the steps of the features on the suite, a new context is set on the features before every
+// scenario and destroyed after it.
+func (k *Kit) Register(s *godog.Suite) {
+	for _, f := range k.features {
+		f.RegisterSteps(s)
+	}
+
+	var ctx *Context
+
+	s.BeforeScenario(func(interface{}) {
+		ctx = bddctx.NewBDDContext()
+
+		for name, value := range k.args {
+			ctx.Args[name] = value
+		}
+
+		for _, f := range k.features {
+			f.SetContext(ctx)
+		}
+	})
+
+	s.AfterScenario(func(_ interface{}, _ error) {
+		ctx.Destroy()
+	})
+}